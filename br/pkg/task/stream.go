@@ -56,6 +56,7 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/tikv/client-go/v2/config"
 	"github.com/tikv/client-go/v2/oracle"
+	pd "github.com/tikv/pd/client"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.uber.org/zap"
 )
@@ -387,13 +388,17 @@ func (s *streamMgr) checkImportTaskRunning(ctx context.Context) error {
 
 // setGCSafePoint sets the server safe point to PD.
 func (s *streamMgr) setGCSafePoint(ctx context.Context, sp utils.BRServiceSafePoint) error {
-	err := utils.CheckGCSafePoint(ctx, s.mgr.GetPDClient(), sp.BackupTS)
+	return setStreamGCSafePoint(ctx, s.mgr.GetPDClient(), sp)
+}
+
+func setStreamGCSafePoint(ctx context.Context, pdCli pd.Client, sp utils.BRServiceSafePoint) error {
+	err := utils.CheckGCSafePoint(ctx, pdCli, sp.BackupTS)
 	if err != nil {
 		return errors.Annotatef(err,
 			"failed to check gc safePoint, ts %v", sp.BackupTS)
 	}
 
-	err = utils.UpdateServiceSafePoint(ctx, s.mgr.GetPDClient(), sp)
+	err = utils.UpdateServiceSafePoint(ctx, pdCli, sp)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -762,36 +767,53 @@ func RunStreamPause(
 	defer streamMgr.close()
 
 	cli := streamhelper.NewMetaDataClient(streamMgr.mgr.GetDomain().GetEtcdClient())
-	// to add backoff
-	ti, isPaused, err := cli.GetTaskWithPauseStatus(ctx, cfg.TaskName)
+	ti, err := PauseStreamTask(ctx, cli, streamMgr.mgr.GetPDClient(), cfg.TaskName, cfg.SafePointTTL)
 	if err != nil {
 		return errors.Trace(err)
+	}
+
+	summary.Log(cmdName, logutil.StreamBackupTaskInfo(&ti.Info))
+	return nil
+}
+
+// PauseStreamTask pauses the log backup task, the global checkpoint of the task
+// is protected from GC by a service safe point with the TTL in seconds.
+func PauseStreamTask(
+	ctx context.Context,
+	cli *streamhelper.MetaDataClient,
+	pdCli pd.Client,
+	taskName string,
+	safePointTTL int64,
+) (*streamhelper.Task, error) {
+	// to add backoff
+	ti, isPaused, err := cli.GetTaskWithPauseStatus(ctx, taskName)
+	if err != nil {
+		return nil, errors.Trace(err)
 	} else if isPaused {
-		return errors.Annotatef(berrors.ErrKVUnknown, "The task %s is paused already.", cfg.TaskName)
+		return nil, errors.Annotatef(berrors.ErrKVUnknown, "The task %s is paused already.", taskName)
 	}
 
 	globalCheckPointTS, err := ti.GetGlobalCheckPointTS(ctx)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
-	if err = streamMgr.setGCSafePoint(
+	if err = setStreamGCSafePoint(
 		ctx,
+		pdCli,
 		utils.BRServiceSafePoint{
 			ID:       buildPauseSafePointName(ti.Info.Name),
-			TTL:      cfg.SafePointTTL,
+			TTL:      safePointTTL,
 			BackupTS: globalCheckPointTS,
 		},
 	); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
-	err = cli.PauseTask(ctx, cfg.TaskName)
+	err = cli.PauseTask(ctx, taskName)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
-
-	summary.Log(cmdName, logutil.StreamBackupTaskInfo(&ti.Info))
-	return nil
+	return ti, nil
 }
 
 // RunStreamResume specifies resuming a stream task.
@@ -820,36 +842,52 @@ func RunStreamResume(
 	defer streamMgr.close()
 
 	cli := streamhelper.NewMetaDataClient(streamMgr.mgr.GetDomain().GetEtcdClient())
+	ti, err := ResumeStreamTask(ctx, cli, streamMgr.mgr.GetPDClient(), cfg.TaskName)
+	if err != nil {
+		return err
+	}
+
+	summary.Log(cmdName, logutil.StreamBackupTaskInfo(&ti.Info))
+	return nil
+}
+
+// ResumeStreamTask resumes the paused log backup task and cleans its last errors.
+func ResumeStreamTask(
+	ctx context.Context,
+	cli *streamhelper.MetaDataClient,
+	pdCli pd.Client,
+	taskName string,
+) (*streamhelper.Task, error) {
 	// to add backoff
-	ti, isPaused, err := cli.GetTaskWithPauseStatus(ctx, cfg.TaskName)
+	ti, isPaused, err := cli.GetTaskWithPauseStatus(ctx, taskName)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	} else if !isPaused {
-		return errors.Annotatef(berrors.ErrKVUnknown,
-			"The task %s is active already.", cfg.TaskName)
+		return nil, errors.Annotatef(berrors.ErrKVUnknown,
+			"The task %s is active already.", taskName)
 	}
 
 	globalCheckPointTS, err := ti.GetGlobalCheckPointTS(ctx)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
-	err = utils.CheckGCSafePoint(ctx, streamMgr.mgr.GetPDClient(), globalCheckPointTS)
+	err = utils.CheckGCSafePoint(ctx, pdCli, globalCheckPointTS)
 	if err != nil {
-		return errors.Annotatef(err, "the global checkpoint ts: %v(%s) has been gc. ",
+		return nil, errors.Annotatef(err, "the global checkpoint ts: %v(%s) has been gc. ",
 			globalCheckPointTS, oracle.GetTimeFromTS(globalCheckPointTS))
 	}
 
-	err = cli.ResumeTask(ctx, cfg.TaskName)
+	err = cli.ResumeTask(ctx, taskName)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
-	err = cli.CleanLastErrorOfTask(ctx, cfg.TaskName)
+	err = cli.CleanLastErrorOfTask(ctx, taskName)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := streamMgr.setGCSafePoint(ctx,
+	if err := setStreamGCSafePoint(ctx, pdCli,
 		utils.BRServiceSafePoint{
 			ID:       buildPauseSafePointName(ti.Info.Name),
 			TTL:      utils.DefaultStreamStartSafePointTTL,
@@ -859,9 +897,7 @@ func RunStreamResume(
 		log.Warn("failed to remove safe point",
 			zap.Uint64("safe-point", globalCheckPointTS), zap.String("error", err.Error()))
 	}
-
-	summary.Log(cmdName, logutil.StreamBackupTaskInfo(&ti.Info))
-	return nil
+	return ti, nil
 }
 
 func RunStreamAdvancer(c context.Context, g glue.Glue, cmdName string, cfg *StreamConfig) error {
@@ -60,6 +60,7 @@ import (
 	"github.com/pingcap/tidb/pkg/store/mockstore"
 	pumpcli "github.com/pingcap/tidb/pkg/tidb-binlog/pump_client"
 	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/audit"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/cpuprofile"
 	"github.com/pingcap/tidb/pkg/util/deadlockhistory"
@@ -269,6 +270,7 @@ func main() {
 	memory.InitMemoryHook()
 	setupExtensions()
 	setupStmtSummary()
+	setupAuditLog()
 
 	err = cpuprofile.StartCPUProfiler()
 	terror.MustNil(err)
@@ -833,6 +835,13 @@ func setGlobalVars() {
 	tikv.SetStoreLivenessTimeout(t)
 	parsertypes.TiDBStrictIntegerDisplayWidth = cfg.DeprecateIntegerDisplayWidth
 	deadlockhistory.GlobalDeadlockHistory.Resize(cfg.PessimisticTxn.DeadlockHistoryCapacity)
+	if len(cfg.PessimisticTxn.DeadlockHistoryPersistFile) > 0 {
+		err = deadlockhistory.GlobalDeadlockHistory.EnablePersist(cfg.PessimisticTxn.DeadlockHistoryPersistFile,
+			cfg.PessimisticTxn.DeadlockHistoryPersistMaxDays)
+		if err != nil {
+			logutil.BgLogger().Warn("failed to load the persisted deadlock history", zap.Error(err))
+		}
+	}
 	txninfo.Recorder.ResizeSummaries(cfg.TrxSummary.TransactionSummaryCapacity)
 	txninfo.Recorder.SetMinDuration(time.Duration(cfg.TrxSummary.TransactionIDDigestMinDuration) * time.Millisecond)
 	chunk.InitChunkAllocSize(cfg.TiDBMaxReuseChunk, cfg.TiDBMaxReuseColumn)
@@ -938,6 +947,7 @@ func cleanup(svr *server.Server, storage kv.Storage, dom *domain.Domain) {
 	closeDomainAndStorage(storage, dom)
 	disk.CleanUp()
 	closeStmtSummary()
+	audit.Close()
 	topsql.Close()
 }
 
@@ -968,6 +978,20 @@ func setupStmtSummary() {
 	}
 }
 
+func setupAuditLog() {
+	instanceCfg := config.GetGlobalConfig().Instance
+	err := audit.Setup(&audit.Config{
+		Filename:       instanceCfg.AuditLogFilename,
+		FileMaxSize:    instanceCfg.AuditLogFileMaxSize,
+		FileMaxDays:    instanceCfg.AuditLogFileMaxDays,
+		FileMaxBackups: instanceCfg.AuditLogFileMaxBackups,
+		QueueSize:      instanceCfg.AuditLogQueueSize,
+	})
+	if err != nil {
+		logutil.BgLogger().Error("failed to setup audit log", zap.Error(err))
+	}
+}
+
 func closeStmtSummary() {
 	instanceCfg := config.GetGlobalConfig().Instance
 	if instanceCfg.StmtSummaryEnablePersistent {
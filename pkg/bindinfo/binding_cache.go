@@ -35,6 +35,9 @@ type FuzzyBindingCache interface {
 	// FuzzyMatchingBinding supports fuzzy matching on bindings.
 	FuzzyMatchingBinding(sctx sessionctx.Context, fuzzyDigest string, tableNames []*ast.TableName) (bindings Binding, isMatched bool)
 
+	// HasWildcardBinding returns whether there are bindings with table name wildcards in this cache.
+	HasWildcardBinding() bool
+
 	// Copy copies this cache.
 	Copy() (c FuzzyBindingCache, err error)
 
@@ -49,16 +52,21 @@ type fuzzyBindingCache struct {
 	// fuzzy2SQLDigests is used to support fuzzy matching.
 	// fuzzyDigest is the digest calculated after eliminating all DB names, e.g. `select * from test.t` -> `select * from t` -> fuzzyDigest.
 	// sqlDigest is the digest where all DB names are kept, e.g. `select * from test.t` -> exactDigest.
+	// For bindings with table name wildcards like `select * from *.t_*`, the fuzzyDigest is calculated after
+	// eliminating all DB names and table names instead, e.g. `select * from test.t_0001` -> `select * from *`.
 	fuzzy2SQLDigests map[string][]string // fuzzyDigest --> sqlDigests
 
 	sql2FuzzyDigest map[string]string // sqlDigest --> fuzzyDigest
+
+	wildcardSQLDigests map[string]struct{} // sqlDigests of the bindings with table name wildcards
 }
 
 func newFuzzyBindingCache() FuzzyBindingCache {
 	return &fuzzyBindingCache{
-		BindingCache:     newBindCache(),
-		fuzzy2SQLDigests: make(map[string][]string),
-		sql2FuzzyDigest:  make(map[string]string),
+		BindingCache:       newBindCache(),
+		fuzzy2SQLDigests:   make(map[string][]string),
+		sql2FuzzyDigest:    make(map[string]string),
+		wildcardSQLDigests: make(map[string]struct{}),
 	}
 }
 
@@ -70,19 +78,19 @@ func (fbc *fuzzyBindingCache) FuzzyMatchingBinding(sctx sessionctx.Context, fuzz
 		return
 	}
 
-	leastWildcards := len(tableNames) + 1
+	var highestPriority matchPriority
 	enableFuzzyBinding := sctx.GetSessionVars().EnableFuzzyBinding
 	for _, sqlDigest := range fbc.fuzzy2SQLDigests[fuzzyDigest] {
 		if bindings := bindingCache.GetBinding(sqlDigest); bindings != nil {
 			for _, binding := range bindings {
-				numWildcards, matched := fuzzyMatchBindingTableName(sctx.GetSessionVars().CurrentDB, tableNames, binding.TableNames)
-				if matched && numWildcards > 0 && sctx != nil && !enableFuzzyBinding {
+				priority, matched := fuzzyMatchBindingTableName(sctx.GetSessionVars().CurrentDB, tableNames, binding.TableNames)
+				if matched && priority.numWildcards() > 0 && sctx != nil && !enableFuzzyBinding {
 					continue // fuzzy binding is disabled, skip this binding
 				}
-				if matched && numWildcards < leastWildcards {
+				if matched && (!isMatched || priority.higherThan(highestPriority)) {
 					matchedBinding = binding
 					isMatched = true
-					leastWildcards = numWildcards
+					highestPriority = priority
 					break
 				}
 			}
@@ -97,19 +105,25 @@ func (fbc *fuzzyBindingCache) SetBinding(sqlDigest string, bindings Bindings) (e
 
 	// prepare fuzzy digests for all bindings
 	fuzzyDigests := make([]string, 0, len(bindings))
+	wildcards := make([]bool, 0, len(bindings))
 	p := parser.New()
 	for _, binding := range bindings {
 		stmt, err := p.ParseOneStmt(binding.BindSQL, binding.Charset, binding.Collation)
 		if err != nil {
 			return err
 		}
-		_, fuzzyDigest := norm.NormalizeStmtForBinding(stmt, norm.WithFuzz(true))
+		wildcard := hasTableNameWildcard(CollectTableNames(stmt))
+		_, fuzzyDigest := norm.NormalizeStmtForBinding(stmt, norm.WithFuzz(true), norm.WithTableWildcard(wildcard))
 		fuzzyDigests = append(fuzzyDigests, fuzzyDigest)
+		wildcards = append(wildcards, wildcard)
 	}
 
 	for i, binding := range bindings {
 		fbc.fuzzy2SQLDigests[fuzzyDigests[i]] = append(fbc.fuzzy2SQLDigests[fuzzyDigests[i]], binding.SQLDigest)
 		fbc.sql2FuzzyDigest[binding.SQLDigest] = fuzzyDigests[i]
+		if wildcards[i] {
+			fbc.wildcardSQLDigests[binding.SQLDigest] = struct{}{}
+		}
 	}
 	// NOTE: due to LRU eviction, the underlying BindingCache state might be inconsistent with fuzzy2SQLDigests and
 	// sql2FuzzyDigest, but it's acceptable, just return cache-miss in that case.
@@ -132,9 +146,16 @@ func (fbc *fuzzyBindingCache) RemoveBinding(sqlDigest string) {
 	}
 	fbc.fuzzy2SQLDigests[fuzzyDigest] = digestList
 	delete(fbc.sql2FuzzyDigest, sqlDigest)
+	delete(fbc.wildcardSQLDigests, sqlDigest)
 	fbc.BindingCache.RemoveBinding(sqlDigest)
 }
 
+func (fbc *fuzzyBindingCache) HasWildcardBinding() bool {
+	fbc.mu.RLock()
+	defer fbc.mu.RUnlock()
+	return len(fbc.wildcardSQLDigests) > 0
+}
+
 func (fbc *fuzzyBindingCache) Copy() (c FuzzyBindingCache, err error) {
 	fbc.mu.RLock()
 	defer fbc.mu.RUnlock()
@@ -152,10 +173,15 @@ func (fbc *fuzzyBindingCache) Copy() (c FuzzyBindingCache, err error) {
 		copy(newList, list)
 		fuzzy2SQLDigests[k] = newList
 	}
+	wildcardSQLDigests := make(map[string]struct{}, len(fbc.wildcardSQLDigests))
+	for k := range fbc.wildcardSQLDigests {
+		wildcardSQLDigests[k] = struct{}{}
+	}
 	return &fuzzyBindingCache{
-		BindingCache:     bc,
-		fuzzy2SQLDigests: fuzzy2SQLDigests,
-		sql2FuzzyDigest:  sql2FuzzyDigest,
+		BindingCache:       bc,
+		fuzzy2SQLDigests:   fuzzy2SQLDigests,
+		sql2FuzzyDigest:    sql2FuzzyDigest,
+		wildcardSQLDigests: wildcardSQLDigests,
 	}, nil
 }
 
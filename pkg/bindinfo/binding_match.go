@@ -36,6 +36,8 @@ var (
 type BindingMatchInfo struct {
 	FuzzyDigest string
 	TableNames  []*ast.TableName
+	// WildcardDigest is calculated lazily, only when there are bindings with table name wildcards.
+	WildcardDigest string
 }
 
 // MatchSQLBindingForPlanCache matches binding for plan cache.
@@ -77,11 +79,30 @@ func matchSQLBinding(sctx sessionctx.Context, stmtNode ast.StmtNode, info *Bindi
 		fuzzyDigest = info.FuzzyDigest
 		tableNames = info.TableNames
 	}
+	// bindings with table name wildcards are indexed by the digest eliminating all table names, which is only
+	// calculated when such bindings exist.
+	wildcardDigest := func() string {
+		if info != nil && info.WildcardDigest != "" {
+			return info.WildcardDigest
+		}
+		_, digest := norm.NormalizeStmtForBinding(stmtNode, norm.WithTableWildcard(true))
+		if info != nil {
+			info.WildcardDigest = digest
+		}
+		return digest
+	}
 
+	// Session bindings are matched before global bindings, and in each scope, bindings with exact table names
+	// are matched before the ones with table name wildcards.
 	sessionHandle := sctx.Value(SessionBindInfoKeyType).(SessionBindingHandle)
 	if binding, matched := sessionHandle.MatchSessionBinding(sctx, fuzzyDigest, tableNames); matched {
 		return binding, matched, metrics.ScopeSession
 	}
+	if sessionHandle.HasSessionWildcardBinding() {
+		if binding, matched := sessionHandle.MatchSessionBinding(sctx, wildcardDigest(), tableNames); matched {
+			return binding, matched, metrics.ScopeSession
+		}
+	}
 	globalHandle := GetGlobalBindingHandle(sctx)
 	if globalHandle == nil {
 		return
@@ -89,34 +110,100 @@ func matchSQLBinding(sctx sessionctx.Context, stmtNode ast.StmtNode, info *Bindi
 	if binding, matched := globalHandle.MatchGlobalBinding(sctx, fuzzyDigest, tableNames); matched {
 		return binding, matched, metrics.ScopeGlobal
 	}
+	if globalHandle.HasGlobalWildcardBinding() {
+		if binding, matched := globalHandle.MatchGlobalBinding(sctx, wildcardDigest(), tableNames); matched {
+			return binding, matched, metrics.ScopeGlobal
+		}
+	}
 	return
 }
 
-func fuzzyMatchBindingTableName(currentDB string, stmtTableNames, bindingTableNames []*ast.TableName) (numWildcards int, matched bool) {
+// matchPriority is the priority of a matched binding, the binding with fewer table name wildcards, then fewer
+// schema name wildcards, then longer literal table name patterns is preferred.
+type matchPriority struct {
+	tableWildcards int
+	dbWildcards    int
+	literalLen     int
+}
+
+func (p matchPriority) numWildcards() int {
+	return p.tableWildcards + p.dbWildcards
+}
+
+func (p matchPriority) higherThan(other matchPriority) bool {
+	if p.tableWildcards != other.tableWildcards {
+		return p.tableWildcards < other.tableWildcards
+	}
+	if p.dbWildcards != other.dbWildcards {
+		return p.dbWildcards < other.dbWildcards
+	}
+	return p.literalLen > other.literalLen
+}
+
+func fuzzyMatchBindingTableName(currentDB string, stmtTableNames, bindingTableNames []*ast.TableName) (priority matchPriority, matched bool) {
 	if len(stmtTableNames) != len(bindingTableNames) {
-		return 0, false
+		return priority, false
 	}
 	for i := range stmtTableNames {
-		if stmtTableNames[i].Name.L != bindingTableNames[i].Name.L {
-			return 0, false
+		if !matchTableNamePattern(bindingTableNames[i].Name.L, stmtTableNames[i].Name.L) {
+			return matchPriority{}, false
 		}
+		if isTableNameWildcard(bindingTableNames[i].Name.L) {
+			priority.tableWildcards++
+		}
+		priority.literalLen += len(bindingTableNames[i].Name.L) - strings.Count(bindingTableNames[i].Name.L, "*")
 		if bindingTableNames[i].Schema.L == "*" {
-			numWildcards++
+			priority.dbWildcards++
 		}
 		if bindingTableNames[i].Schema.L == stmtTableNames[i].Schema.L || // exactly same, or
 			(stmtTableNames[i].Schema.L == "" && bindingTableNames[i].Schema.L == strings.ToLower(currentDB)) || // equal to the current DB, or
 			bindingTableNames[i].Schema.L == "*" { // fuzzy match successfully
 			continue
 		}
-		return 0, false
+		return matchPriority{}, false
+	}
+	return priority, true
+}
+
+// matchTableNamePattern checks whether the table name matches the pattern, where `*` matches any sequence of
+// characters, e.g. `t_*` matches `t_0001` and `t_4096`.
+func matchTableNamePattern(pattern, name string) bool {
+	if !isTableNameWildcard(pattern) {
+		return pattern == name
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(name, part)
+		if idx < 0 {
+			return false
+		}
+		name = name[idx+len(part):]
 	}
-	return numWildcards, true
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
+
+func isTableNameWildcard(tableName string) bool {
+	return strings.Contains(tableName, "*")
+}
+
+// hasTableNameWildcard checks whether any of the table names is a wildcard pattern.
+func hasTableNameWildcard(tableNames []*ast.TableName) bool {
+	for _, t := range tableNames {
+		if isTableNameWildcard(t.Name.L) {
+			return true
+		}
+	}
+	return false
 }
 
 // isFuzzyBinding checks whether the stmtNode is a fuzzy binding.
 func isFuzzyBinding(stmt ast.Node) bool {
 	for _, t := range CollectTableNames(stmt) {
-		if t.Schema.L == "*" {
+		if t.Schema.L == "*" || isTableNameWildcard(t.Name.L) {
 			return true
 		}
 	}
@@ -74,3 +74,59 @@ func TestExtractTableName(t *testing.T) {
 		require.Equal(t, tt.tables, result)
 	}
 }
+
+func TestMatchTableNamePattern(t *testing.T) {
+	tc := []struct {
+		pattern string
+		name    string
+		matched bool
+	}{
+		{"t", "t", true},
+		{"t", "t1", false},
+		{"t_*", "t_0001", true},
+		{"t_*", "t_", true},
+		{"t_*", "t", false},
+		{"*", "t", true},
+		{"*_log", "t_0001_log", true},
+		{"*_log", "t_0001_logs", false},
+		{"t_*_log", "t_0001_log", true},
+		{"t_*_log", "t_log", false},
+		{"t_*_*", "t_1_2", true},
+		{"t_*_*", "t_1", false},
+	}
+	for _, c := range tc {
+		require.Equal(t, c.matched, matchTableNamePattern(c.pattern, c.name), "%v %v", c.pattern, c.name)
+	}
+}
+
+func TestFuzzyMatchBindingTableNamePriority(t *testing.T) {
+	p := parser.New()
+	tableNames := func(sql string) []*ast.TableName {
+		stmt, err := p.ParseOneStmt(sql, "", "")
+		require.NoError(t, err)
+		return CollectTableNames(stmt)
+	}
+	stmtTableNames := tableNames("select * from t_0001")
+	bindings := []string{
+		"select * from test.t_0001",
+		"select * from *.t_0001",
+		"select * from test.`t_00*`",
+		"select * from test.`t_*`",
+		"select * from *.`t_*`",
+		"select * from *.`*`",
+	}
+	var priorities []matchPriority
+	for _, b := range bindings {
+		priority, matched := fuzzyMatchBindingTableName("test", stmtTableNames, tableNames(b))
+		require.True(t, matched, b)
+		priorities = append(priorities, priority)
+	}
+	for i := 1; i < len(priorities); i++ {
+		require.True(t, priorities[i-1].higherThan(priorities[i]), bindings[i])
+	}
+
+	_, matched := fuzzyMatchBindingTableName("test", stmtTableNames, tableNames("select * from test.`t_1*`"))
+	require.False(t, matched)
+	_, matched = fuzzyMatchBindingTableName("test", stmtTableNames, tableNames("select * from test1.`t_*`"))
+	require.False(t, matched)
+}
@@ -508,3 +508,51 @@ func TestFuzzyBindingHints(t *testing.T) {
 		}
 	}
 }
+
+func TestFuzzyBindingTableNameWildcard(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+
+	tk.MustExec(`set @@tidb_opt_enable_fuzzy_binding=1`)
+	for _, db := range []string{"db1", "db2"} {
+		tk.MustExec(`create database ` + db)
+		tk.MustExec(`use ` + db)
+		for _, tbl := range []string{"t_0001", "t_0002", "t_4096", "u_0001"} {
+			tk.MustExec(fmt.Sprintf(`create table %v (a int, b int, c int, key(a), key(b), key(c))`, tbl))
+		}
+	}
+	tk.MustExec(`use db1`)
+
+	for _, scope := range []string{"session", "global"} {
+		tk.MustExec(fmt.Sprintf("create %v binding using select /*+ use_index(t, a) */ * from *.`t_*` t where a > 1 and b > 1", scope))
+		for _, q := range []string{
+			`select * from t_0001 t where a > 10 and b > 10`,
+			`select * from db1.t_0002 t where a > 10 and b > 10`,
+			`select * from db2.t_4096 t where a > 10 and b > 10`,
+		} {
+			require.True(t, tk.MustUseIndex(q, "a"), q)
+			tk.MustQuery(`select @@last_plan_from_binding`).Check(testkit.Rows("1"))
+		}
+		// the table name doesn't match the pattern
+		tk.MustExec(`select * from u_0001 t where a > 10 and b > 10`)
+		tk.MustQuery(`select @@last_plan_from_binding`).Check(testkit.Rows("0"))
+
+		// a more specific binding has a higher priority
+		tk.MustExec(fmt.Sprintf("create %v binding using select /*+ use_index(t, b) */ * from *.`t_4*` t where a > 1 and b > 1", scope))
+		require.True(t, tk.MustUseIndex(`select * from t_4096 t where a > 10 and b > 10`, "b"))
+		require.True(t, tk.MustUseIndex(`select * from t_0001 t where a > 10 and b > 10`, "a"))
+		tk.MustExec(fmt.Sprintf("create %v binding using select /*+ use_index(t, c) */ * from t_4096 t where a > 1 and b > 1", scope))
+		require.True(t, tk.MustUseIndex(`select * from t_4096 t where a > 10 and b > 10`, "c"))
+		require.True(t, tk.MustUseIndex(`select * from db2.t_4096 t where a > 10 and b > 10`, "b"))
+
+		// table name wildcards are controlled by tidb_opt_enable_fuzzy_binding too
+		tk.MustExec(`set @@tidb_opt_enable_fuzzy_binding=0`)
+		tk.MustExec(`select * from t_0001 t where a > 10 and b > 10`)
+		tk.MustQuery(`select @@last_plan_from_binding`).Check(testkit.Rows("0"))
+		tk.MustExec(`set @@tidb_opt_enable_fuzzy_binding=1`)
+
+		removeAllBindings(tk, scope == "global")
+		tk.MustExec(`select * from t_0001 t where a > 10 and b > 10`)
+		tk.MustQuery(`select @@last_plan_from_binding`).Check(testkit.Rows("0"))
+	}
+}
@@ -47,6 +47,9 @@ type GlobalBindingHandle interface {
 	// MatchGlobalBinding returns the matched binding for this statement.
 	MatchGlobalBinding(sctx sessionctx.Context, fuzzyDigest string, tableNames []*ast.TableName) (matchedBinding Binding, isMatched bool)
 
+	// HasGlobalWildcardBinding returns whether there are global bindings with table name wildcards.
+	HasGlobalWildcardBinding() bool
+
 	// GetAllGlobalBindings returns all bind records in cache.
 	GetAllGlobalBindings() (bindings Bindings)
 
@@ -465,6 +468,11 @@ func (h *globalBindingHandle) MatchGlobalBinding(sctx sessionctx.Context, fuzzyD
 	return h.getCache().FuzzyMatchingBinding(sctx, fuzzyDigest, tableNames)
 }
 
+// HasGlobalWildcardBinding returns whether there are global bindings with table name wildcards.
+func (h *globalBindingHandle) HasGlobalWildcardBinding() bool {
+	return h.getCache().HasWildcardBinding()
+}
+
 // GetAllGlobalBindings returns all bind records in cache.
 func (h *globalBindingHandle) GetAllGlobalBindings() (bindings Bindings) {
 	return h.getCache().GetAllBindings()
@@ -23,8 +23,9 @@ import (
 )
 
 type option struct {
-	specifiedDB string
-	fuzz        bool
+	specifiedDB   string
+	fuzz          bool
+	tableWildcard bool
 }
 
 type optionFunc func(*option)
@@ -36,6 +37,13 @@ func WithFuzz(fuzz bool) optionFunc {
 	}
 }
 
+// WithTableWildcard specifies whether to eliminate schema names and table names.
+func WithTableWildcard(tableWildcard bool) optionFunc {
+	return func(user *option) {
+		user.tableWildcard = tableWildcard
+	}
+}
+
 // WithSpecifiedDB specifies the specified DB name.
 func WithSpecifiedDB(specifiedDB string) optionFunc {
 	return func(user *option) {
@@ -46,12 +54,13 @@ func WithSpecifiedDB(specifiedDB string) optionFunc {
 // NormalizeStmtForBinding normalizes a statement for binding.
 // when fuzz is false, schema names will be completed automatically: `select * from t` --> `select * from db . t`.
 // when fuzz is true, schema names will be eliminated automatically: `select * from db . t` --> `select * from t`.
+// when tableWildcard is true, table names will be eliminated too: `select * from db . t_0001` --> `select * from *`.
 func NormalizeStmtForBinding(stmtNode ast.StmtNode, options ...optionFunc) (normalizedStmt, exactSQLDigest string) {
 	opt := &option{}
 	for _, option := range options {
 		option(opt)
 	}
-	return normalizeStmt(stmtNode, opt.specifiedDB, opt.fuzz)
+	return normalizeStmt(stmtNode, opt)
 }
 
 // NormalizeStmtForBinding normalizes a statement for binding.
@@ -59,15 +68,18 @@ func NormalizeStmtForBinding(stmtNode ast.StmtNode, options ...optionFunc) (norm
 // For normal bindings, DB name will be completed automatically:
 //
 //	e.g. `select * from t where a in (1, 2, 3)` --> `select * from test.t where a in (...)`
-func normalizeStmt(stmtNode ast.StmtNode, specifiedDB string, fuzzy bool) (normalizedStmt, sqlDigest string) {
+func normalizeStmt(stmtNode ast.StmtNode, opt *option) (normalizedStmt, sqlDigest string) {
 	normalize := func(n ast.StmtNode) (normalizedStmt, sqlDigest string) {
 		eraseLastSemicolon(n)
 		var digest *parser.Digest
 		var normalizedSQL string
-		if !fuzzy {
-			normalizedSQL = utilparser.RestoreWithDefaultDB(n, specifiedDB, n.Text())
-		} else {
+		switch {
+		case opt.tableWildcard:
+			normalizedSQL = utilparser.RestoreWithTableWildcard(n)
+		case opt.fuzz:
 			normalizedSQL = utilparser.RestoreWithoutDB(n)
+		default:
+			normalizedSQL = utilparser.RestoreWithDefaultDB(n, opt.specifiedDB, n.Text())
 		}
 		normalizedStmt, digest = parser.NormalizeDigestForBinding(normalizedSQL)
 		return normalizedStmt, digest.String()
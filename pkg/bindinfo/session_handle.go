@@ -44,6 +44,9 @@ type SessionBindingHandle interface {
 	// MatchSessionBinding returns the matched binding for this statement.
 	MatchSessionBinding(sctx sessionctx.Context, fuzzyDigest string, tableNames []*ast.TableName) (matchedBinding Binding, isMatched bool)
 
+	// HasSessionWildcardBinding returns whether there are session bindings with table name wildcards.
+	HasSessionWildcardBinding() bool
+
 	// GetAllSessionBindings return all bindings.
 	GetAllSessionBindings() (bindings Bindings)
 
@@ -106,6 +109,11 @@ func (h *sessionBindingHandle) MatchSessionBinding(sctx sessionctx.Context, fuzz
 	return h.ch.FuzzyMatchingBinding(sctx, fuzzyDigest, tableNames)
 }
 
+// HasSessionWildcardBinding returns whether there are session bindings with table name wildcards.
+func (h *sessionBindingHandle) HasSessionWildcardBinding() bool {
+	return h.ch.HasWildcardBinding()
+}
+
 // GetAllSessionBindings return all session bind info.
 func (h *sessionBindingHandle) GetAllSessionBindings() (bindings Bindings) {
 	return h.ch.GetAllBindings()
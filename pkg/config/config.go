@@ -67,6 +67,8 @@ const (
 	DefPort = 4000
 	// DefStatusPort is the default status port of TiDB
 	DefStatusPort = 10080
+	// DefMySQLXPort is the default X Protocol port of TiDB
+	DefMySQLXPort = 33060
 	// DefHost is the default host of TiDB
 	DefHost = "0.0.0.0"
 	// DefStatusHost is the default status host of TiDB
@@ -200,6 +202,7 @@ type Config struct {
 	PreparedPlanCache          PreparedPlanCache       `toml:"prepared-plan-cache" json:"prepared-plan-cache"`
 	OpenTracing                OpenTracing             `toml:"opentracing" json:"opentracing"`
 	ProxyProtocol              ProxyProtocol           `toml:"proxy-protocol" json:"proxy-protocol"`
+	MySQLX                     MySQLX                  `toml:"mysqlx" json:"mysqlx"`
 	PDClient                   tikvcfg.PDClient        `toml:"pd-client" json:"pd-client"`
 	TiKVClient                 tikvcfg.TiKVClient      `toml:"tikv-client" json:"tikv-client"`
 	Binlog                     Binlog                  `toml:"binlog" json:"binlog"`
@@ -487,6 +490,15 @@ type Log struct {
 	File logutil.FileLogConfig `toml:"file" json:"file"`
 
 	SlowQueryFile string `toml:"slow-query-file" json:"slow-query-file"`
+	// SlowQueryFormat is the format of slow query log, one of text or json.
+	// Empty means text.
+	SlowQueryFormat string `toml:"slow-query-format" json:"slow-query-format"`
+	// SlowQueryRotateInterval rotates the slow query log file by time, such as "1h".
+	// Empty means the file is only rotated by size.
+	SlowQueryRotateInterval string `toml:"slow-query-rotate-interval" json:"slow-query-rotate-interval"`
+	// SlowQueryCompress compresses the rotated slow query log files using gzip.
+	// The compressed files are not read by INFORMATION_SCHEMA.SLOW_QUERY and CLUSTER_SLOW_QUERY.
+	SlowQueryCompress bool `toml:"slow-query-compress" json:"slow-query-compress"`
 	// ExpensiveThreshold is deprecated.
 	ExpensiveThreshold uint `toml:"expensive-threshold" json:"expensive-threshold"`
 
@@ -532,6 +544,17 @@ type Instance struct {
 	// StmtSummaryFileMaxBackups indicates the maximum number of files written
 	// by stmtsummary when StmtSummaryEnablePersistent is true.
 	StmtSummaryFileMaxBackups int `toml:"tidb_stmt_summary_file_max_backups" json:"tidb_stmt_summary_file_max_backups"`
+	// AuditLogFilename indicates the file name of the built-in audit log.
+	AuditLogFilename string `toml:"tidb_audit_log_filename" json:"tidb_audit_log_filename"`
+	// AuditLogFileMaxDays indicates how many days the audit log files will be kept.
+	AuditLogFileMaxDays int `toml:"tidb_audit_log_file_max_days" json:"tidb_audit_log_file_max_days"`
+	// AuditLogFileMaxSize indicates the maximum size (in mb) of a single audit log file.
+	AuditLogFileMaxSize int `toml:"tidb_audit_log_file_max_size" json:"tidb_audit_log_file_max_size"`
+	// AuditLogFileMaxBackups indicates the maximum number of audit log files.
+	AuditLogFileMaxBackups int `toml:"tidb_audit_log_file_max_backups" json:"tidb_audit_log_file_max_backups"`
+	// AuditLogQueueSize is the max number of audit log entries waiting to be written.
+	// Entries are dropped when the queue is full, so the statements are never blocked.
+	AuditLogQueueSize int `toml:"tidb_audit_log_queue_size" json:"tidb_audit_log_queue_size"`
 
 	// These variables exist in both 'instance' section and another place.
 	// The configuration in 'instance' section takes precedence.
@@ -612,6 +635,8 @@ type Security struct {
 	AuthTokenRefreshInterval string `toml:"auth-token-refresh-interval" json:"auth-token-refresh-interval"`
 	// Disconnect directly when the password is expired
 	DisconnectOnExpiredPassword bool `toml:"disconnect-on-expired-password" json:"disconnect-on-expired-password"`
+	// The path of the keyring file which holds the salt of the MASK_HASH function.
+	DataMaskingKeyring string `toml:"data-masking-keyring" json:"data-masking-keyring"`
 }
 
 // The ErrConfigValidationFailed error is used so that external callers can do a type assertion
@@ -803,6 +828,15 @@ type ProxyProtocol struct {
 	Fallbackable bool `toml:"fallbackable" json:"fallbackable"`
 }
 
+// MySQLX is the X Protocol section of the config.
+type MySQLX struct {
+	// Enable the X Protocol listener. Only session establishment,
+	// SQL execution and result streaming are supported.
+	Enable bool `toml:"enable" json:"enable"`
+	// Port is the X Protocol listening port. It shares the host with the MySQL protocol.
+	Port uint `toml:"port" json:"port"`
+}
+
 // Binlog is the config for binlog.
 type Binlog struct {
 	Enable bool `toml:"enable" json:"enable"`
@@ -824,6 +858,10 @@ type PessimisticTxn struct {
 	DeadlockHistoryCapacity uint `toml:"deadlock-history-capacity" json:"deadlock-history-capacity"`
 	// Whether retryable deadlocks (in-statement deadlocks) are collected to the information_schema.deadlocks table.
 	DeadlockHistoryCollectRetryable bool `toml:"deadlock-history-collect-retryable" json:"deadlock-history-collect-retryable"`
+	// The file that the deadlock events are persisted to, so they are kept across restarts. Empty means not to persist.
+	DeadlockHistoryPersistFile string `toml:"deadlock-history-persist-file" json:"deadlock-history-persist-file"`
+	// The max number of days to retain the persisted deadlock events.
+	DeadlockHistoryPersistMaxDays uint `toml:"deadlock-history-persist-max-days" json:"deadlock-history-persist-max-days"`
 	// PessimisticAutoCommit represents if true it means the auto-commit transactions will be in pessimistic mode.
 	PessimisticAutoCommit AtomicBool `toml:"pessimistic-auto-commit" json:"pessimistic-auto-commit"`
 	// ConstraintCheckInPlacePessimistic is the default value for the session variable `tidb_constraint_check_in_place_pessimistic`
@@ -852,6 +890,8 @@ func DefaultPessimisticTxn() PessimisticTxn {
 		MaxRetryCount:                     256,
 		DeadlockHistoryCapacity:           10,
 		DeadlockHistoryCollectRetryable:   false,
+		DeadlockHistoryPersistFile:        "",
+		DeadlockHistoryPersistMaxDays:     7,
 		PessimisticAutoCommit:             *NewAtomicBool(false),
 		ConstraintCheckInPlacePessimistic: true,
 	}
@@ -957,6 +997,11 @@ var defaultConf = Config{
 		StmtSummaryFileMaxDays:      3,
 		StmtSummaryFileMaxSize:      64,
 		StmtSummaryFileMaxBackups:   0,
+		AuditLogFilename:            "tidb-audit.log",
+		AuditLogFileMaxDays:         7,
+		AuditLogFileMaxSize:         300,
+		AuditLogFileMaxBackups:      0,
+		AuditLogQueueSize:           10240,
 		EnableSlowLog:               *NewAtomicBool(logutil.DefaultTiDBEnableSlowLog),
 		SlowThreshold:               logutil.DefaultSlowThreshold,
 		RecordPlanInSlowLog:         logutil.DefaultRecordPlanInSlowLog,
@@ -1020,6 +1065,10 @@ var defaultConf = Config{
 		HeaderTimeout: 5,
 		Fallbackable:  false,
 	},
+	MySQLX: MySQLX{
+		Enable: false,
+		Port:   DefMySQLXPort,
+	},
 	PreparedPlanCache: PreparedPlanCache{
 		Enabled:          true,
 		Capacity:         100,
@@ -1342,6 +1391,14 @@ func (c *Config) Valid() error {
 	if c.Log.File.MaxSize > MaxLogFileSize {
 		return fmt.Errorf("invalid max log file size=%v which is larger than max=%v", c.Log.File.MaxSize, MaxLogFileSize)
 	}
+	if f := strings.ToLower(c.Log.SlowQueryFormat); f != "" && f != logutil.SlowLogFormatText && f != logutil.SlowLogFormatJSON {
+		return fmt.Errorf("invalid slow-query-format=%s, valid formats are [%s, %s]", c.Log.SlowQueryFormat, logutil.SlowLogFormatText, logutil.SlowLogFormatJSON)
+	}
+	if len(c.Log.SlowQueryRotateInterval) > 0 {
+		if d, err := time.ParseDuration(c.Log.SlowQueryRotateInterval); err != nil || d < time.Minute {
+			return fmt.Errorf("invalid slow-query-rotate-interval=%s, it should be a duration not less than 1m", c.Log.SlowQueryRotateInterval)
+		}
+	}
 	if c.TableColumnCountLimit < DefTableColumnCountLimit || c.TableColumnCountLimit > DefMaxOfTableColumnCountLimit {
 		return fmt.Errorf("table-column-limit should be [%d, %d]", DefIndexLimit, DefMaxOfTableColumnCountLimit)
 	}
@@ -1441,10 +1498,17 @@ var TableLockDelayClean = func() uint64 {
 
 // ToLogConfig converts *Log to *logutil.LogConfig.
 func (l *Log) ToLogConfig() *logutil.LogConfig {
-	return logutil.NewLogConfig(l.Level, l.Format, l.SlowQueryFile, l.File, l.getDisableTimestamp(),
+	cfg := logutil.NewLogConfig(l.Level, l.Format, l.SlowQueryFile, l.File, l.getDisableTimestamp(),
 		func(config *zaplog.Config) { config.DisableErrorVerbose = l.getDisableErrorStack() },
 		func(config *zaplog.Config) { config.Timeout = l.Timeout },
 	)
+	cfg.SlowQueryFormat = l.SlowQueryFormat
+	if len(l.SlowQueryRotateInterval) > 0 {
+		// The interval has been checked in Valid.
+		cfg.SlowQueryRotateInterval, _ = time.ParseDuration(l.SlowQueryRotateInterval)
+	}
+	cfg.SlowQueryCompress = l.SlowQueryCompress
+	return cfg
 }
 
 // ToTracingConfig converts *OpenTracing to *tracing.Configuration.
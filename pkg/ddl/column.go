@@ -1713,7 +1713,11 @@ func (r *asAutoIDRequirement) AutoIDClient() *autoid.ClientDiscover {
 // migrate auto_increment ID to auto_random ID if possible.
 func applyNewAutoRandomBits(d *ddlCtx, m *meta.Meta, dbInfo *model.DBInfo,
 	tblInfo *model.TableInfo, oldCol *model.ColumnInfo, newAutoRandBits uint64) error {
+	oldAutoRandBits := tblInfo.AutoRandomBits
 	tblInfo.AutoRandomBits = newAutoRandBits
+	if newAutoRandBits < oldAutoRandBits {
+		return rebaseAutoRandomForDecreasedBits(m, dbInfo, tblInfo, oldCol, oldAutoRandBits, newAutoRandBits)
+	}
 	needMigrateFromAutoIncToAutoRand := mysql.HasAutoIncrementFlag(oldCol.GetFlag())
 	if !needMigrateFromAutoIncToAutoRand {
 		return nil
@@ -1738,6 +1742,26 @@ func applyNewAutoRandomBits(d *ddlCtx, m *meta.Meta, dbInfo *model.DBInfo,
 	return nil
 }
 
+// rebaseAutoRandomForDecreasedBits rebases the auto_random ID allocator after the shard bits are decreased.
+// The low bits of the old shard become the high bits of the new incremental part, so the allocator
+// must skip all the incremental values which may be taken by the IDs generated with the old bit layout.
+func rebaseAutoRandomForDecreasedBits(m *meta.Meta, dbInfo *model.DBInfo, tblInfo *model.TableInfo,
+	col *model.ColumnInfo, oldShardBits, newShardBits uint64) error {
+	oldShardFmt := autoid.NewShardIDFormat(&col.FieldType, oldShardBits, tblInfo.AutoRandomRangeBits)
+	idAcc := m.GetAutoIDAccessors(dbInfo.ID, tblInfo.ID).RandomID()
+	currentBase, err := idAcc.Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	releasedBits := oldShardBits - newShardBits
+	newBase := int64(((uint64(1)<<releasedBits)-1)<<oldShardFmt.IncrementalBits) | currentBase
+	if newBase == currentBase {
+		return nil
+	}
+	_, err = idAcc.Inc(newBase - currentBase)
+	return errors.Trace(err)
+}
+
 // checkForNullValue ensure there are no null values of the column of this table.
 // `isDataTruncated` indicates whether the new field and the old field type are the same, in order to be compatible with mysql.
 func checkForNullValue(ctx context.Context, sctx sessionctx.Context, isDataTruncated bool, schema, table model.CIStr, newCol *model.ColumnInfo, oldCols ...*model.ColumnInfo) error {
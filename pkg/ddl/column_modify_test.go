@@ -420,6 +420,47 @@ func TestVirtualColumnDDL(t *testing.T) {
 	tk.MustQuery("select * from test_local_gv_ddl").Check(testkit.Rows("1 9 11"))
 }
 
+func TestInvisibleColumn(t *testing.T) {
+	store := testkit.CreateMockStoreWithSchemaLease(t, columnModifyLease)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int invisible)")
+	tk.MustExec("insert into t values (1)")
+	tk.MustExec("insert into t (a, b) values (2, 2)")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select a, b from t order by a").Check(testkit.Rows("1 <nil>", "2 2"))
+	tk.MustQuery("select * from (select a, b from t) t1 order by a").Check(testkit.Rows("1 <nil>", "2 2"))
+	tk.MustQuery("desc t").Check(testkit.Rows("a int(11) YES  <nil> ", "b int(11) YES  <nil> INVISIBLE"))
+	tk.MustQuery("select column_name, extra from information_schema.columns where table_schema = 'test' and table_name = 't'").
+		Check(testkit.Rows("a ", "b INVISIBLE"))
+
+	// Add a column before the application knows it.
+	tk.MustExec("alter table t add column c int not null default 3 invisible")
+	tk.MustExec("insert into t values (4)")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1", "2", "4"))
+	tk.MustQuery("select a, c from t where c = 3 order by a").Check(testkit.Rows("1 3", "2 3", "4 3"))
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL /*!80023 INVISIBLE */,\n" +
+		"  `c` int(11) NOT NULL DEFAULT '3' /*!80023 INVISIBLE */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	// Make the column visible to the application.
+	tk.MustExec("alter table t modify column c int not null default 3 visible")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 3", "2 3", "4 3"))
+	tk.MustExec("alter table t change column b b int")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 <nil> 3", "2 2 3", "4 <nil> 3"))
+
+	// A table must have at least 1 visible column.
+	tk.MustGetErrCode("create table t1 (a int invisible)", errno.ErrTableMustHaveAVisibleColumn)
+	tk.MustExec("create table t1 (a int, b int invisible)")
+	tk.MustGetErrCode("alter table t1 modify column a int invisible", errno.ErrTableMustHaveAVisibleColumn)
+	tk.MustGetErrCode("alter table t1 drop column a", errno.ErrTableMustHaveAVisibleColumn)
+	tk.MustExec("alter table t1 add column c int")
+	tk.MustExec("alter table t1 drop column a")
+	tk.MustQuery("select * from t1").Check(testkit.Rows())
+}
+
 func TestTransactionWithWriteOnlyColumn(t *testing.T) {
 	store, dom := testkit.CreateMockStoreAndDomainWithSchemaLease(t, columnModifyLease)
 	tk := testkit.NewTestKit(t, store)
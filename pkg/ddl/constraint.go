@@ -374,9 +374,10 @@ func (w *worker) verifyRemainRecordsForCheckConstraint(dbInfo *model.DBInfo, tab
 	// It's no need to construct expression node out and pull the chunk rows through it. Here we
 	// can let the check expression restored string as the filter in where clause directly.
 	// Prepare internal SQL to fetch data from physical table under this filter.
-	sql := fmt.Sprintf("select 1 from `%s`.`%s` where not %s limit 1", dbInfo.Name.L, tableInfo.Name.L, constr.ExprString)
+	// The expression is parenthesized to keep its precedence under NOT, and '%' is escaped for sqlescape.
+	sql := "select 1 from %n.%n where not (" + strings.ReplaceAll(constr.ExprString, "%", "%%") + ") limit 1"
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
-	rows, _, err := sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(ctx, nil, sql)
+	rows, _, err := sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(ctx, nil, sql, dbInfo.Name.O, tableInfo.Name.O)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -69,6 +69,48 @@ func TestAlterConstraintAddDrop(t *testing.T) {
 	tk.MustExec("drop table if exists t")
 }
 
+func TestAlterConstraintVerifyRemainRecords(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@global.tidb_enable_check_constraint = 1")
+	tk.MustExec("create table t (a int, b int, c varchar(10))")
+	tk.MustExec("insert into t values (1, -1, 'xa'), (-1, 1, 'xb')")
+
+	// The precedence of the check expression should be kept when verifying the existing records.
+	tk.MustExec("alter table t add constraint c1 check (a > 0 or b > 0)")
+	tk.MustGetErrMsg("alter table t add constraint c2 check (a > 0 and b > 0)", "[ddl:3819]Check constraint 'c2' is violated.")
+	tk.MustExec("alter table t add constraint c3 check (c like 'x%')")
+	tk.MustGetErrMsg("alter table t add constraint c4 check (c like '%a')", "[ddl:3819]Check constraint 'c4' is violated.")
+	tk.MustQuery("select constraint_name, constraint_type, enforced from information_schema.table_constraints " +
+		"where table_schema = 'test' and table_name = 't' order by constraint_name").Check(testkit.Rows(
+		"c1 CHECK YES",
+		"c3 CHECK YES",
+	))
+
+	// The records are verified again when the constraint becomes enforced.
+	tk.MustExec("alter table t alter constraint c1 not enforced")
+	tk.MustExec("insert into t values (-1, -1, 'xc')")
+	tk.MustQuery("select constraint_name, enforced from information_schema.table_constraints " +
+		"where table_schema = 'test' and table_name = 't' order by constraint_name").Check(testkit.Rows(
+		"c1 NO",
+		"c3 YES",
+	))
+	tk.MustGetErrMsg("alter table t alter constraint c1 enforced", "[ddl:3819]Check constraint 'c1' is violated.")
+	tk.MustExec("delete from t where a = -1 and b = -1")
+	tk.MustExec("alter table t alter constraint c1 enforced")
+
+	// The UPDATE statements are checked under all sql modes, only IGNORE turns the violation into a warning.
+	for _, sqlMode := range []string{"", "STRICT_TRANS_TABLES"} {
+		tk.MustExec(fmt.Sprintf("set @@sql_mode = '%s'", sqlMode))
+		tk.MustGetErrMsg("update t set a = -2 where a = 1", "[table:3819]Check constraint 'c1' is violated.")
+		tk.MustGetErrMsg("update t set c = 'y' where a = 1", "[table:3819]Check constraint 'c3' is violated.")
+		tk.MustExec("update ignore t set a = -2 where a = 1")
+		tk.MustQuery("show warnings").Check(testkit.Rows("Warning 3819 Check constraint 'c1' is violated."))
+		tk.MustQuery("select * from t order by a").Check(testkit.Rows("-1 1 xb", "1 -1 xa"))
+	}
+}
+
 func TestAlterAddConstraintStateChange(t *testing.T) {
 	store, dom := testkit.CreateMockStoreAndDomain(t)
 	tk := testkit.NewTestKit(t, store)
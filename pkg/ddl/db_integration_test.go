@@ -1644,6 +1644,66 @@ func TestDefaultValueAsExpressions(t *testing.T) {
 	}
 }
 
+func TestDefaultValueAsGeneralExpressions(t *testing.T) {
+	store := testkit.CreateMockStoreWithSchemaLease(t, testLease)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@time_zone = '+08:00'")
+
+	tk.MustExec("create table t (id int, d date default (current_date + interval 1 day), " +
+		"j json default (json_array()), u binary(16) default (uuid_to_bin(uuid())), " +
+		"s varchar(32) default (concat('a', lpad(1, 3, '0'))), i int default (1 + 1))")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) DEFAULT NULL,\n" +
+		"  `d` date DEFAULT (date_add(current_date(), interval 1 day)),\n" +
+		"  `j` json DEFAULT json_array(),\n" +
+		"  `u` binary(16) DEFAULT uuid_to_bin(uuid()),\n" +
+		"  `s` varchar(32) DEFAULT concat(_utf8mb4'a', lpad(1, 3, _utf8mb4'0')),\n" +
+		"  `i` int(11) DEFAULT (1 + 1)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select column_name, column_default, extra from information_schema.columns " +
+		"where table_schema = 'test' and table_name = 't' and column_name in ('d', 'i') order by column_name").Check(testkit.Rows(
+		"d (date_add(current_date(), interval 1 day)) DEFAULT_GENERATED",
+		"i (1 + 1) DEFAULT_GENERATED",
+	))
+	tk.MustExec("insert into t(id) values (1), (2)")
+	tk.MustExec("insert into t values (3, default, default, default, default, default)")
+	tk.MustQuery("select count(*) from t where d = current_date + interval 1 day and j = json_array() " +
+		"and length(u) = 16 and s = 'a001' and i = 2").Check(testkit.Rows("3"))
+	tk.MustQuery("select count(distinct u) from t").Check(testkit.Rows("3"))
+
+	// The known rows are filled with the value evaluated by the DDL statement when adding a column.
+	tk.MustExec("alter table t add column d2 datetime default (str_to_date('2024-01-02', '%Y-%m-%d') + interval 1 hour)")
+	tk.MustExec("alter table t add column j2 json default (json_object('k', upper('v')))")
+	tk.MustExec("alter table t add column ts timestamp default (timestamp('2024-01-01 08:00:00'))")
+	tk.MustQuery("select distinct d2, j2, ts from t").Check(testkit.Rows("2024-01-02 01:00:00 {\"k\": \"V\"} 2024-01-01 08:00:00"))
+	tk.MustExec("set @@time_zone = '+00:00'")
+	tk.MustQuery("select distinct ts from t").Check(testkit.Rows("2024-01-01 00:00:00"))
+	tk.MustGetErrCode("alter table t add column u2 binary(16) default (uuid_to_bin(uuid()))", errno.ErrBinlogUnsafeSystemFunction)
+	tk.MustGetErrCode("alter table t add column r double default (rand() + 1)", errno.ErrBinlogUnsafeSystemFunction)
+
+	// The default value expression can be set by ALTER COLUMN.
+	tk.MustExec("alter table t alter column i set default (abs(-3) * 2)")
+	tk.MustExec("insert into t(id) values (4)")
+	tk.MustQuery("select i from t where id = 4").Check(testkit.Rows("6"))
+	tk.MustExec("alter table t alter column i set default 5")
+	tk.MustExec("insert into t(id) values (5)")
+	tk.MustQuery("select i from t where id = 5").Check(testkit.Rows("5"))
+	tk.MustExec("alter table t alter column d2 set default (now())")
+	tk.MustQuery("select column_default, extra from information_schema.columns " +
+		"where table_schema = 'test' and table_name = 't' and column_name = 'd2'").Check(testkit.Rows("(now()) DEFAULT_GENERATED"))
+	tk.MustExec("insert into t(id) values (6)")
+	tk.MustQuery("select d2 is not null from t where id = 6").Check(testkit.Rows("1"))
+
+	// The disallowed default value expressions.
+	tk.MustGetErrCode("create table t1 (a int default (sleep(1)))", errno.ErrDefValGeneratedNamedFunctionIsNotAllowed)
+	tk.MustGetErrCode("create table t1 (a int default (last_insert_id() + 1))", errno.ErrDefValGeneratedNamedFunctionIsNotAllowed)
+	tk.MustGetErrCode("create table t1 (a int default (a_function_not_supported_yet() + 1))", errno.ErrDefValGeneratedNamedFunctionIsNotAllowed)
+	tk.MustGetErrCode("create table t1 (a int default ((select 1)))", errno.ErrDefValGeneratedFunctionIsNotAllowed)
+	tk.MustGetErrCode("create table t1 (a int default (@a + 1))", errno.ErrDefValGeneratedVariables)
+	tk.MustGetErrCode("create table t1 (a int, b int default (a + 1))", errno.ErrUnsupportedDDLOperation)
+}
+
 func TestChangingDBCharset(t *testing.T) {
 	store := testkit.CreateMockStore(t, mockstore.WithDDLChecker())
 
@@ -1758,6 +1818,53 @@ func TestChangingDBCharset(t *testing.T) {
 	tk.MustExec("alter database TEST_UPPERCASE_DB_CHARSET default character set utf8mb4;")
 }
 
+func TestSchemaReadOnly(t *testing.T) {
+	store := testkit.CreateMockStore(t, mockstore.WithDDLChecker())
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database ro_db")
+	tk.MustExec("use ro_db")
+	tk.MustExec("create table t (a int primary key, b int)")
+	tk.MustExec("insert into t values (1, 1)")
+	tk.MustExec("prepare stmt from 'insert into t values (?, ?)'")
+
+	tk.MustExec("alter database ro_db set read only = 1")
+	tk.MustQuery("select tidb_read_only from information_schema.schemata where schema_name = 'ro_db'").Check(testkit.Rows("YES"))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1"))
+	for _, sql := range []string{
+		"insert into t values (2, 2)",
+		"update t set b = 2",
+		"delete from t",
+		"truncate table t",
+		"create table t1 (a int)",
+		"create view v as select * from t",
+		"alter table t add column c int",
+		"create index idx on t (b)",
+		"drop table t",
+		"drop database ro_db",
+		"alter database ro_db charset utf8mb4",
+	} {
+		tk.MustGetErrCode(sql, errno.ErrSchemaReadOnly)
+	}
+	tk.MustExec("set @a = 2, @b = 2")
+	tk.MustGetErrCode("execute stmt using @a, @b", errno.ErrSchemaReadOnly)
+	// Other databases are not affected.
+	tk.MustExec("create table test.t_ro (a int)")
+	tk.MustExec("insert into test.t_ro select a from t")
+	tk.MustQuery("select * from test.t_ro").Check(testkit.Rows("1"))
+
+	tk.MustExec("alter database ro_db set read only = 0")
+	tk.MustQuery("select tidb_read_only from information_schema.schemata where schema_name = 'ro_db'").Check(testkit.Rows("NO"))
+	tk.MustExec("execute stmt using @a, @b")
+	tk.MustExec("update t set b = 3 where a = 2")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1", "2 3"))
+
+	tk.MustGetErrCode("alter database mysql set read only = 1", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("alter database ro_db set read only = 1")
+	tk.MustExec("alter database ro_db set read only = 0")
+	tk.MustExec("drop database ro_db")
+}
+
 func TestSqlFunctionsInGeneratedColumns(t *testing.T) {
 	store := testkit.CreateMockStore(t, mockstore.WithDDLChecker())
 
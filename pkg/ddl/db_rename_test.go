@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/store/mockstore"
 	"github.com/pingcap/tidb/pkg/testkit"
@@ -487,3 +488,47 @@ func TestRenameConcurrentAutoID(t *testing.T) {
 		"6 5 t1 second null",
 		"8 7 t1 third null"))
 }
+
+func TestRenameTableAcrossDatabases(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database db1")
+	tk.MustExec("create database db2")
+	tk.MustExec("create placement policy p1 followers=2")
+	tk.MustExec("create table db1.t (a int, b datetime) placement policy p1 ttl = b + interval 1 day")
+	tk.MustExec("insert into db1.t values (1, now())")
+	tk.MustExec("analyze table db1.t")
+	h := dom.StatsHandle()
+	require.NoError(t, h.Update(dom.InfoSchema()))
+
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("db1"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	oldTblInfo := tbl.Meta()
+	require.False(t, h.GetTableStats(oldTblInfo).Pseudo)
+
+	tk.MustExec("rename table db1.t to db2.t")
+	is := dom.InfoSchema()
+	tbl, err = is.TableByName(model.NewCIStr("db2"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tblInfo := tbl.Meta()
+	require.Equal(t, oldTblInfo.ID, tblInfo.ID)
+	require.Greater(t, tblInfo.UpdateTS, oldTblInfo.UpdateTS)
+
+	// The placement bundle follows the table.
+	bundle, ok := is.PlacementBundleByPhysicalTableID(tblInfo.ID)
+	require.True(t, ok)
+	require.NotEmpty(t, bundle.Rules)
+
+	// The TTL table is listed in the new schema.
+	results := is.ListTablesWithSpecialAttribute(infoschema.TTLAttribute)
+	require.Len(t, results, 1)
+	require.Equal(t, "db2", results[0].DBName.L)
+
+	// The stats are kept by the table ID.
+	require.NoError(t, h.Update(is))
+	require.False(t, h.GetTableStats(tblInfo).Pseudo)
+	tk.MustQuery("select count(*) from db2.t").Check(testkit.Rows("1"))
+	tk.MustExec("drop table db2.t")
+	tk.MustExec("drop placement policy p1")
+}
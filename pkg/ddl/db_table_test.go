@@ -309,6 +309,53 @@ func TestCreateTableWithIntegerColWithDefault(t *testing.T) {
 	require.True(t, strings.Contains(ret.(string), "`a` double DEFAULT '12.43'"))
 }
 
+func TestCreateTableWithGIPK(t *testing.T) {
+	store := testkit.CreateMockStore(t, mockstore.WithDDLChecker())
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@sql_generated_invisible_primary_key = on")
+	tk.MustExec("create table t1 (a int, b int)")
+	tk.MustQuery("show create table t1").Check(testkit.Rows("t1 CREATE TABLE `t1` (\n" +
+		"  `my_row_id` bigint(20) unsigned NOT NULL AUTO_INCREMENT /*!80023 INVISIBLE */,\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`my_row_id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustExec("insert into t1 values (1, 1), (2, 2)")
+	tk.MustExec("insert into t1 (a, b) values (3, 3)")
+	tk.MustQuery("select * from t1 order by a").Check(testkit.Rows("1 1", "2 2", "3 3"))
+	tk.MustQuery("select my_row_id, a from t1 order by my_row_id").Check(testkit.Rows("1 1", "2 2", "3 3"))
+
+	// The table with a primary key is untouched.
+	tk.MustExec("create table t2 (a int primary key, b int)")
+	tk.MustExec("create table t3 (a int, b int, primary key(a, b))")
+	for _, tbl := range []string{"t2", "t3"} {
+		tk.MustQuery(fmt.Sprintf("select count(*) from information_schema.columns where table_schema = 'test' and table_name = '%s'", tbl)).
+			Check(testkit.Rows("2"))
+	}
+	tk.MustGetErrCode("create table t4 (my_row_id int, a int)", errno.ErrGIPKColumnExists)
+	tk.MustGetErrCode("create table t4 (a int auto_increment, key(a))", errno.ErrGIPKFailedAutoIncColumnExists)
+
+	// The generated invisible primary key can be hidden from SHOW and information_schema.
+	tk.MustExec("set @@show_gipk_in_create_table_and_information_schema = off")
+	tk.MustQuery("show create table t1").Check(testkit.Rows("t1 CREATE TABLE `t1` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("show columns from t1").Check(testkit.Rows("a int(11) YES  <nil> ", "b int(11) YES  <nil> "))
+	tk.MustQuery("select column_name from information_schema.columns where table_schema = 'test' and table_name = 't1'").
+		Check(testkit.Rows("a", "b"))
+	tk.MustExec("set @@show_gipk_in_create_table_and_information_schema = on")
+	tk.MustQuery("select column_name, column_key, extra from information_schema.columns where table_schema = 'test' and table_name = 't1'").
+		Check(testkit.Rows("my_row_id PRI auto_increment INVISIBLE", "a  ", "b  "))
+
+	tk.MustExec("set @@sql_generated_invisible_primary_key = off")
+	tk.MustExec("create table t4 (a int, b int)")
+	tk.MustQuery("select count(*) from information_schema.columns where table_schema = 'test' and table_name = 't4'").
+		Check(testkit.Rows("2"))
+}
+
 func TestCreateTableWithInfo(t *testing.T) {
 	store, dom := testkit.CreateMockStoreAndDomain(t)
 	tk := testkit.NewTestKit(t, store)
@@ -807,6 +807,21 @@ func TestReportingMinStartTimestamp(t *testing.T) {
 	infoSyncer.SetSessionManager(sm)
 	infoSyncer.ReportMinStartTS(dom.Store())
 	require.Equal(t, validTS, infoSyncer.GetMinStartTS())
+
+	// the sessions enabling tidb_enable_query_gc_protection delay GC until tidb_query_gc_protection_max_time.
+	protectedTS := oracle.GoTimeToLowerLimitStartTS(now.Add(time.Minute), 2*tikv.MaxTxnTimeUse)
+	expiredTS := oracle.GoTimeToLowerLimitStartTS(now, 8*tikv.MaxTxnTimeUse)
+	sm.PS = []*util.ProcessInfo{
+		{ID: 1, CurTxnStartTS: lowerLimit},
+		{ID: 2, CurTxnStartTS: validTS},
+		{ID: 3, CurTxnStartTS: protectedTS},
+		{ID: 4, CurTxnStartTS: expiredTS, GCProtected: true},
+	}
+	infoSyncer.ReportMinStartTS(dom.Store())
+	require.Equal(t, validTS, infoSyncer.GetMinStartTS())
+	sm.PS[2].GCProtected = true
+	infoSyncer.ReportMinStartTS(dom.Store())
+	require.Equal(t, protectedTS, infoSyncer.GetMinStartTS())
 }
 
 // for issue #34931
@@ -190,6 +190,9 @@ type DDL interface {
 	CreateSequence(ctx sessionctx.Context, stmt *ast.CreateSequenceStmt) error
 	DropSequence(ctx sessionctx.Context, stmt *ast.DropSequenceStmt) (err error)
 	AlterSequence(ctx sessionctx.Context, stmt *ast.AlterSequenceStmt) error
+	CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error
+	DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error
+	RecordMaterializedViewRefresh(ctx sessionctx.Context, ident ast.Ident, tableID int64, lastRefreshTS uint64) error
 	CreatePlacementPolicy(ctx sessionctx.Context, stmt *ast.CreatePlacementPolicyStmt) error
 	DropPlacementPolicy(ctx sessionctx.Context, stmt *ast.DropPlacementPolicyStmt) error
 	AlterPlacementPolicy(ctx sessionctx.Context, stmt *ast.AlterPlacementPolicyStmt) error
@@ -1347,7 +1350,7 @@ func (d *ddl) startCleanDeadTableLock() {
 			if !d.ownerManager.IsOwner() {
 				continue
 			}
-			deadLockTables, err := d.tableLockCkr.GetDeadLockedTables(d.ctx, d.infoCache.GetLatest().AllSchemas())
+			deadLockTables, err := d.tableLockCkr.GetDeadLockedTables(d.ctx, lockedTableSchemas(d.infoCache.GetLatest()))
 			if err != nil {
 				logutil.BgLogger().Info("get dead table lock failed.", zap.String("category", "ddl"), zap.Error(err))
 				continue
@@ -1364,6 +1367,20 @@ func (d *ddl) startCleanDeadTableLock() {
 	}
 }
 
+// lockedTableSchemas returns the schemas with only the tables locked by LOCK TABLES.
+func lockedTableSchemas(is infoschema.InfoSchema) []*model.DBInfo {
+	results := is.ListTablesWithSpecialAttribute(infoschema.TableLockAttribute)
+	schemas := make([]*model.DBInfo, 0, len(results))
+	for _, r := range results {
+		tblInfos := make([]*model.TableInfo, 0, len(r.Tables))
+		for _, tbl := range r.Tables {
+			tblInfos = append(tblInfos, tbl.Meta())
+		}
+		schemas = append(schemas, &model.DBInfo{ID: r.DBID, Name: r.DBName, Tables: tblInfos})
+	}
+	return schemas
+}
+
 // SwitchMDL enables MDL or disable MDL.
 func (d *ddl) SwitchMDL(enable bool) error {
 	isEnableBefore := variable.EnableMDL.Load()
@@ -1525,6 +1542,9 @@ type RecoverSchemaInfo struct {
 	DropJobID       int64
 	SnapshotTS      uint64
 	OldSchemaName   model.CIStr
+	// SchemaExists indicates the tables are recovered into the existing schema, it's used by
+	// FLASHBACK DATABASE TO TIMESTAMP to recover the tables dropped after the timestamp.
+	SchemaExists bool
 }
 
 // delayForAsyncCommit sleeps `SafeWindow + AllowedClockDrift` before a DDL job finishes.
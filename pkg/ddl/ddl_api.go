@@ -32,6 +32,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl/label"
 	"github.com/pingcap/tidb/pkg/ddl/resourcegroup"
@@ -44,6 +45,7 @@ import (
 	"github.com/pingcap/tidb/pkg/meta/autoid"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/charset"
 	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/model"
@@ -298,6 +300,36 @@ func (d *ddl) ModifySchemaDefaultPlacement(ctx sessionctx.Context, stmt *ast.Alt
 	return errors.Trace(err)
 }
 
+// ModifySchemaReadOnly sets or clears the read only flag of the database.
+func (d *ddl) ModifySchemaReadOnly(ctx sessionctx.Context, stmt *ast.AlterDatabaseStmt, readOnly bool) (err error) {
+	dbName := stmt.Name
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	dbInfo, ok := is.SchemaByName(dbName)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(dbName.O)
+	}
+	if util.IsMemOrSysDB(dbInfo.Name.L) {
+		return errors.Trace(dbterror.ErrUnsupportedReadOnlyForSysDB)
+	}
+	if dbInfo.ReadOnly == readOnly {
+		return nil
+	}
+
+	// Do the DDL job.
+	job := &model.Job{
+		SchemaID:       dbInfo.ID,
+		SchemaName:     dbInfo.Name.L,
+		Type:           model.ActionModifySchemaReadOnly,
+		BinlogInfo:     &model.HistoryInfo{},
+		Args:           []any{readOnly},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
 // getPendingTiFlashTableCount counts unavailable TiFlash replica by iterating all tables in infoCache.
 func (d *ddl) getPendingTiFlashTableCount(sctx sessionctx.Context, originVersion int64, pendingCount uint32) (int64, uint32) {
 	is := d.GetInfoSchemaWithInterceptor(sctx)
@@ -556,6 +588,7 @@ func (d *ddl) AlterSchema(sctx sessionctx.Context, stmt *ast.AlterDatabaseStmt)
 	var (
 		toCharset, toCollate                                         string
 		isAlterCharsetAndCollate, isAlterPlacement, isTiFlashReplica bool
+		isAlterReadOnly, readOnly                                    bool
 		placementPolicyRef                                           *model.PolicyRefInfo
 		tiflashReplica                                               *ast.TiFlashReplicaSpec
 	)
@@ -592,6 +625,9 @@ func (d *ddl) AlterSchema(sctx sessionctx.Context, stmt *ast.AlterDatabaseStmt)
 		case ast.DatabaseSetTiFlashReplica:
 			tiflashReplica = val.TiFlashReplica
 			isTiFlashReplica = true
+		case ast.DatabaseSetReadOnly:
+			readOnly = val.UintValue == 1
+			isAlterReadOnly = true
 		}
 	}
 
@@ -610,6 +646,11 @@ func (d *ddl) AlterSchema(sctx sessionctx.Context, stmt *ast.AlterDatabaseStmt)
 			return err
 		}
 	}
+	if isAlterReadOnly {
+		if err = d.ModifySchemaReadOnly(sctx, stmt, readOnly); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -666,7 +707,12 @@ func (d *ddl) DropSchema(ctx sessionctx.Context, stmt *ast.DropDatabaseStmt) (er
 
 func (d *ddl) RecoverSchema(ctx sessionctx.Context, recoverSchemaInfo *RecoverSchemaInfo) error {
 	recoverSchemaInfo.State = model.StateNone
+	var schemaID int64
+	if recoverSchemaInfo.SchemaExists {
+		schemaID = recoverSchemaInfo.ID
+	}
 	job := &model.Job{
+		SchemaID:       schemaID,
 		Type:           model.ActionRecoverSchema,
 		BinlogInfo:     &model.HistoryInfo{},
 		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
@@ -1016,7 +1062,7 @@ func buildColumnAndConstraint(
 // In NO_ZERO_DATE SQL mode, TIMESTAMP/DATE/DATETIME type can't have zero date like '0000-00-00' or '0000-00-00 00:00:00'.
 func checkColumnDefaultValue(ctx sessionctx.Context, col *table.Column, value any) (bool, any, error) {
 	hasDefaultValue := true
-	if value != nil && (col.GetType() == mysql.TypeJSON ||
+	if value != nil && (col.GetType() == mysql.TypeJSON || col.GetType() == mysql.TypeTiDBVectorFloat32 ||
 		col.GetType() == mysql.TypeTinyBlob || col.GetType() == mysql.TypeMediumBlob ||
 		col.GetType() == mysql.TypeLongBlob || col.GetType() == mysql.TypeBlob) {
 		// In non-strict SQL mode.
@@ -1245,6 +1291,10 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 				}
 			case ast.ColumnOptionFulltext:
 				ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.FastGenByArgs())
+			case ast.ColumnOptionVisible:
+				col.Invisible = false
+			case ast.ColumnOptionInvisible:
+				col.Invisible = true
 			case ast.ColumnOptionCheck:
 				if !variable.EnableCheckConstraint.Load() {
 					ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackError("the switch of check constraint is off"))
@@ -1271,7 +1321,7 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 	return col, constraints, nil
 }
 
-func restoreFuncCall(expr *ast.FuncCallExpr) (string, error) {
+func restoreDefaultValueExpr(expr ast.ExprNode) (string, error) {
 	var sb strings.Builder
 	restoreFlags := format.RestoreStringSingleQuotes | format.RestoreKeyWordLowercase | format.RestoreNameBackQuotes |
 		format.RestoreSpacesAroundBinaryOperation
@@ -1282,6 +1332,103 @@ func restoreFuncCall(expr *ast.FuncCallExpr) (string, error) {
 	return sb.String(), nil
 }
 
+// illegalFunctions4DefaultValue stores the functions which can't be used in the default value expression.
+var illegalFunctions4DefaultValue = map[string]struct{}{
+	ast.Benchmark:       {},
+	ast.FoundRows:       {},
+	ast.GetLock:         {},
+	ast.GetVar:          {},
+	ast.IsFreeLock:      {},
+	ast.IsUsedLock:      {},
+	ast.LastInsertId:    {},
+	ast.LastVal:         {},
+	ast.LoadFile:        {},
+	ast.MasterPosWait:   {},
+	ast.NameConst:       {},
+	ast.NextVal:         {},
+	ast.ReleaseAllLocks: {},
+	ast.ReleaseLock:     {},
+	ast.RowCount:        {},
+	ast.SetVal:          {},
+	ast.SetVar:          {},
+	ast.Sleep:           {},
+	ast.Values:          {},
+}
+
+// nonDeterministicFunctions4DefaultValue stores the functions which return different values for the rows
+// in a statement, the existing rows can't be filled with them when adding a column.
+var nonDeterministicFunctions4DefaultValue = map[string]struct{}{
+	ast.Rand:        {},
+	ast.RandomBytes: {},
+	ast.Sysdate:     {},
+	ast.UUID:        {},
+	ast.UUIDShort:   {},
+}
+
+// defaultValueExprChecker checks whether the expression can be used as the default value of a column.
+type defaultValueExprChecker struct {
+	colName          string
+	nonDeterministic bool
+	err              error
+}
+
+// Enter implements ast.Visitor interface.
+func (c *defaultValueExprChecker) Enter(inNode ast.Node) (outNode ast.Node, skipChildren bool) {
+	switch node := inNode.(type) {
+	case *ast.FuncCallExpr:
+		_, isIllegal := illegalFunctions4DefaultValue[node.FnName.L]
+		if isIllegal || node.Schema.L != "" || !expression.IsFunctionSupported(node.FnName.L) {
+			c.err = dbterror.ErrDefValGeneratedNamedFunctionIsNotAllowed.GenWithStackByArgs(c.colName, node.FnName.String())
+			return inNode, true
+		}
+		if err := expression.VerifyArgsWrapper(node.FnName.L, len(node.Args)); err != nil {
+			c.err = err
+			return inNode, true
+		}
+		if _, ok := nonDeterministicFunctions4DefaultValue[node.FnName.L]; ok {
+			c.nonDeterministic = true
+		}
+	case *ast.VariableExpr:
+		c.err = dbterror.ErrDefValGeneratedVariables.GenWithStackByArgs(c.colName)
+		return inNode, true
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr, *ast.AggregateFuncExpr, *ast.WindowFuncExpr,
+		*ast.RowExpr, *ast.ValuesExpr, *ast.DefaultExpr, ast.ParamMarkerExpr:
+		c.err = dbterror.ErrDefValGeneratedFunctionIsNotAllowed.GenWithStackByArgs(c.colName)
+		return inNode, true
+	case *ast.ColumnNameExpr:
+		c.err = dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("referring to other columns in the default value expression")
+		return inNode, true
+	}
+	return inNode, false
+}
+
+// Leave implements ast.Visitor interface.
+func (c *defaultValueExprChecker) Leave(inNode ast.Node) (node ast.Node, ok bool) {
+	return inNode, c.err == nil
+}
+
+// checkDefaultValueExpr checks the default value expression of the column, and returns whether
+// the expression is non-deterministic.
+func checkDefaultValueExpr(colName string, expr ast.ExprNode) (bool, error) {
+	c := &defaultValueExprChecker{colName: colName}
+	expr.Accept(c)
+	return c.nonDeterministic, c.err
+}
+
+// getExprDefaultValue gets the default column value of the expression, which is kept as the
+// restored string and evaluated when the default value is used.
+func getExprDefaultValue(col *table.Column, expr ast.ExprNode) (any, bool, error) {
+	if _, err := checkDefaultValueExpr(col.Name.O, expr); err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	str, err := restoreDefaultValueExpr(expr)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	col.DefaultIsExpr = true
+	return str, false, nil
+}
+
 // getFuncCallDefaultValue gets the default column value of function-call expression.
 func getFuncCallDefaultValue(col *table.Column, option *ast.ColumnOption, expr *ast.FuncCallExpr) (any, bool, error) {
 	switch expr.FnName.L {
@@ -1306,114 +1453,8 @@ func getFuncCallDefaultValue(col *table.Column, option *ast.ColumnOption, expr *
 			return nil, false, errors.Trace(err)
 		}
 		return str, true, nil
-	case ast.Rand, ast.UUID, ast.UUIDToBin:
-		if err := expression.VerifyArgsWrapper(expr.FnName.L, len(expr.Args)); err != nil {
-			return nil, false, errors.Trace(err)
-		}
-		str, err := restoreFuncCall(expr)
-		if err != nil {
-			return nil, false, errors.Trace(err)
-		}
-		col.DefaultIsExpr = true
-		return str, false, nil
-	case ast.DateFormat:
-		// Support DATE_FORMAT(NOW(),'%Y-%m'), DATE_FORMAT(NOW(),'%Y-%m-%d'),
-		// DATE_FORMAT(NOW(),'%Y-%m-%d %H.%i.%s'), DATE_FORMAT(NOW(),'%Y-%m-%d %H:%i:%s').
-		if err := expression.VerifyArgsWrapper(expr.FnName.L, len(expr.Args)); err != nil {
-			return nil, false, errors.Trace(err)
-		}
-		nowFunc, ok := expr.Args[0].(*ast.FuncCallExpr)
-		if ok && nowFunc.FnName.L == ast.Now {
-			if err := expression.VerifyArgsWrapper(nowFunc.FnName.L, len(nowFunc.Args)); err != nil {
-				return nil, false, errors.Trace(err)
-			}
-			valExpr, isValue := expr.Args[1].(ast.ValueExpr)
-			if !isValue || (valExpr.GetString() != "%Y-%m" && valExpr.GetString() != "%Y-%m-%d" &&
-				valExpr.GetString() != "%Y-%m-%d %H.%i.%s" && valExpr.GetString() != "%Y-%m-%d %H:%i:%s") {
-				return nil, false, dbterror.ErrDefValGeneratedNamedFunctionIsNotAllowed.GenWithStackByArgs(col.Name.String(), valExpr)
-			}
-			str, err := restoreFuncCall(expr)
-			if err != nil {
-				return nil, false, errors.Trace(err)
-			}
-			col.DefaultIsExpr = true
-			return str, false, nil
-		}
-		return nil, false, dbterror.ErrDefValGeneratedNamedFunctionIsNotAllowed.GenWithStackByArgs(col.Name.String(), nowFunc.FnName.String())
-	case ast.Replace:
-		// Support REPLACE(UPPER(UUID()), '-', '').
-		if err := expression.VerifyArgsWrapper(expr.FnName.L, len(expr.Args)); err != nil {
-			return nil, false, errors.Trace(err)
-		}
-		funcCall := expr.Args[0]
-		// Support REPLACE(CONVERT(UPPER(UUID()) USING UTF8MB4), '-', ''))
-		if convertFunc, ok := funcCall.(*ast.FuncCallExpr); ok && convertFunc.FnName.L == ast.Convert {
-			if err := expression.VerifyArgsWrapper(convertFunc.FnName.L, len(convertFunc.Args)); err != nil {
-				return nil, false, errors.Trace(err)
-			}
-			funcCall = convertFunc.Args[0]
-		}
-		if upperFunc, ok := funcCall.(*ast.FuncCallExpr); ok && upperFunc.FnName.L == ast.Upper {
-			if err := expression.VerifyArgsWrapper(upperFunc.FnName.L, len(upperFunc.Args)); err != nil {
-				return nil, false, errors.Trace(err)
-			}
-			if uuidFunc, ok := upperFunc.Args[0].(*ast.FuncCallExpr); ok && uuidFunc.FnName.L == ast.UUID {
-				if err := expression.VerifyArgsWrapper(uuidFunc.FnName.L, len(uuidFunc.Args)); err != nil {
-					return nil, false, errors.Trace(err)
-				}
-				str, err := restoreFuncCall(expr)
-				if err != nil {
-					return nil, false, errors.Trace(err)
-				}
-				col.DefaultIsExpr = true
-				return str, false, nil
-			}
-		}
-		return nil, false, dbterror.ErrDefValGeneratedNamedFunctionIsNotAllowed.GenWithStackByArgs(col.Name.String(), expr.FnName.String())
-	case ast.Upper:
-		// Support UPPER(SUBSTRING_INDEX(USER(), '@', 1)).
-		if err := expression.VerifyArgsWrapper(expr.FnName.L, len(expr.Args)); err != nil {
-			return nil, false, errors.Trace(err)
-		}
-		if substringIndexFunc, ok := expr.Args[0].(*ast.FuncCallExpr); ok && substringIndexFunc.FnName.L == ast.SubstringIndex {
-			if err := expression.VerifyArgsWrapper(substringIndexFunc.FnName.L, len(substringIndexFunc.Args)); err != nil {
-				return nil, false, errors.Trace(err)
-			}
-			if userFunc, ok := substringIndexFunc.Args[0].(*ast.FuncCallExpr); ok && userFunc.FnName.L == ast.User {
-				if err := expression.VerifyArgsWrapper(userFunc.FnName.L, len(userFunc.Args)); err != nil {
-					return nil, false, errors.Trace(err)
-				}
-				valExpr, isValue := substringIndexFunc.Args[1].(ast.ValueExpr)
-				if !isValue || valExpr.GetString() != "@" {
-					return nil, false, dbterror.ErrDefValGeneratedNamedFunctionIsNotAllowed.GenWithStackByArgs(col.Name.String(), valExpr)
-				}
-				str, err := restoreFuncCall(expr)
-				if err != nil {
-					return nil, false, errors.Trace(err)
-				}
-				col.DefaultIsExpr = true
-				return str, false, nil
-			}
-		}
-		return nil, false, dbterror.ErrDefValGeneratedNamedFunctionIsNotAllowed.GenWithStackByArgs(col.Name.String(), expr.FnName.String())
-	case ast.StrToDate:
-		// Support STR_TO_DATE('1980-01-01', '%Y-%m-%d').
-		if err := expression.VerifyArgsWrapper(expr.FnName.L, len(expr.Args)); err != nil {
-			return nil, false, errors.Trace(err)
-		}
-		if _, ok1 := expr.Args[0].(ast.ValueExpr); ok1 {
-			if _, ok2 := expr.Args[1].(ast.ValueExpr); ok2 {
-				str, err := restoreFuncCall(expr)
-				if err != nil {
-					return nil, false, errors.Trace(err)
-				}
-				col.DefaultIsExpr = true
-				return str, false, nil
-			}
-		}
-		return nil, false, dbterror.ErrDefValGeneratedNamedFunctionIsNotAllowed.GenWithStackByArgs(col.Name.String(), fmt.Sprintf("%s with these args", expr.FnName.String()))
 	default:
-		return nil, false, dbterror.ErrDefValGeneratedNamedFunctionIsNotAllowed.GenWithStackByArgs(col.Name.String(), expr.FnName.String())
+		return getExprDefaultValue(col, expr)
 	}
 }
 
@@ -1423,6 +1464,9 @@ func getFuncCallDefaultValue(col *table.Column, option *ast.ColumnOption, expr *
 func getDefaultValue(ctx sessionctx.Context, col *table.Column, option *ast.ColumnOption) (any, bool, error) {
 	// handle default value with function call
 	tp, fsp := col.FieldType.GetType(), col.FieldType.GetDecimal()
+	if x, ok := option.Expr.(*ast.ParenthesesExpr); ok {
+		return getExprDefaultValue(col, x)
+	}
 	if x, ok := option.Expr.(*ast.FuncCallExpr); ok {
 		val, isSeqExpr, err := getFuncCallDefaultValue(col, option, x)
 		if val != nil || isSeqExpr || err != nil {
@@ -1850,6 +1894,19 @@ func checkTooManyIndexes(idxDefs []*model.IndexInfo) error {
 	return nil
 }
 
+// checkVisibleColumns checks whether there is at least one column visible to users.
+func checkVisibleColumns(cols []*model.ColumnInfo) error {
+	if len(cols) == 0 {
+		return nil
+	}
+	for _, col := range cols {
+		if !col.Hidden && !col.Invisible {
+			return nil
+		}
+	}
+	return dbterror.ErrTableMustHaveAVisibleColumn
+}
+
 // checkColumnsAttributes checks attributes for multiple columns.
 func checkColumnsAttributes(colDefs []*model.ColumnInfo) error {
 	for _, colDef := range colDefs {
@@ -1881,6 +1938,10 @@ func checkColumnAttributes(colName string, tp *types.FieldType) error {
 		if tp.GetDecimal() != types.UnspecifiedFsp && (tp.GetDecimal() < types.MinFsp || tp.GetDecimal() > types.MaxFsp) {
 			return types.ErrTooBigPrecision.GenWithStackByArgs(tp.GetDecimal(), colName, types.MaxFsp)
 		}
+	case mysql.TypeTiDBVectorFloat32:
+		if tp.GetFlen() != types.UnspecifiedLength && (tp.GetFlen() <= 0 || tp.GetFlen() > types.MaxVectorDimension) {
+			return types.ErrWrongFieldSpec.GenWithStackByArgs(colName)
+		}
 	}
 	return nil
 }
@@ -2095,8 +2156,20 @@ func BuildTableInfo(
 	}
 	foreignKeyID := tbInfo.MaxForeignKeyID
 	for _, constr := range constraints {
+		var (
+			keys         = constr.Keys
+			vectorOption *ast.IndexOption
+			err          error
+		)
+		// The key part of a vector index is a distance function, which is resolved before
+		// building hidden columns for expression indexes.
+		if constr.Tp == ast.ConstraintVector {
+			if vectorOption, keys, err = VectorIndexOption(constr.Option, constr.Keys); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 		// Build hidden columns if necessary.
-		hiddenCols, err := buildHiddenColumnInfoWithCheck(ctx, constr.Keys, model.NewCIStr(constr.Name), tbInfo, tblColumns)
+		hiddenCols, err := buildHiddenColumnInfoWithCheck(ctx, keys, model.NewCIStr(constr.Name), tbInfo, tblColumns)
 		if err != nil {
 			return nil, err
 		}
@@ -2157,15 +2230,19 @@ func BuildTableInfo(
 			}
 		}
 
-		if constr.Tp == ast.ConstraintFulltext {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.FastGenByArgs())
-			continue
-		}
-
 		var (
 			indexName       = constr.Name
+			indexOption     = constr.Option
 			primary, unique bool
 		)
+		if constr.Tp == ast.ConstraintFulltext {
+			if indexOption, err = FullTextIndexOption(constr.Option); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if constr.Tp == ast.ConstraintVector {
+			indexOption = vectorOption
+		}
 
 		// Check if the index is primary or unique.
 		switch constr.Tp {
@@ -2248,8 +2325,8 @@ func BuildTableInfo(
 			primary,
 			unique,
 			false,
-			constr.Keys,
-			constr.Option,
+			keys,
+			indexOption,
 			model.StatePublic,
 		)
 		if err != nil {
@@ -2381,6 +2458,9 @@ func checkTableInfoValidExtra(tbInfo *model.TableInfo) error {
 	if err := checkColumnsAttributes(tbInfo.Columns); err != nil {
 		return errors.Trace(err)
 	}
+	if err := checkVisibleColumns(tbInfo.Columns); err != nil {
+		return errors.Trace(err)
+	}
 
 	// FIXME: perform checkConstraintNames
 	if err := checkCharsetAndCollation(tbInfo.Charset, tbInfo.Collate); err != nil {
@@ -2428,6 +2508,9 @@ func checkTableInfoValidWithStmt(ctx sessionctx.Context, tbInfo *model.TableInfo
 			return errors.Trace(err)
 		}
 	}
+	if err := checkIntervalPolicyValid(ctx, tbInfo); err != nil {
+		return errors.Trace(err)
+	}
 
 	return nil
 }
@@ -2571,9 +2654,59 @@ func BuildSessionTemporaryTableInfo(ctx sessionctx.Context, is infoschema.InfoSc
 	return tbInfo, err
 }
 
+// buildColumnDefsWithGIPK adds an invisible auto-increment primary key in front of the columns
+// if the table is created without a primary key when sql_generated_invisible_primary_key is on.
+func buildColumnDefsWithGIPK(ctx sessionctx.Context, s *ast.CreateTableStmt) ([]*ast.ColumnDef, error) {
+	vars := ctx.GetSessionVars()
+	if vars.InRestrictedSQL || !vars.GenerateInvisiblePrimaryKey || s.TemporaryKeyword != ast.TemporaryNone {
+		return s.Cols, nil
+	}
+	for _, constr := range s.Constraints {
+		if constr.Tp == ast.ConstraintPrimaryKey {
+			return s.Cols, nil
+		}
+	}
+	for _, colDef := range s.Cols {
+		for _, op := range colDef.Options {
+			if op.Tp == ast.ColumnOptionPrimaryKey {
+				return s.Cols, nil
+			}
+		}
+	}
+	for _, colDef := range s.Cols {
+		if colDef.Name.Name.L == table.GIPKColumnName {
+			return nil, dbterror.ErrGIPKColumnExists.GenWithStackByArgs(table.GIPKColumnName)
+		}
+		for _, op := range colDef.Options {
+			if op.Tp == ast.ColumnOptionAutoIncrement {
+				return nil, dbterror.ErrGIPKFailedAutoIncColumnExists
+			}
+		}
+	}
+
+	tp := types.NewFieldType(mysql.TypeLonglong)
+	tp.AddFlag(mysql.UnsignedFlag)
+	gipk := &ast.ColumnDef{
+		Name: &ast.ColumnName{Name: model.NewCIStr(table.GIPKColumnName)},
+		Tp:   tp,
+		Options: []*ast.ColumnOption{
+			{Tp: ast.ColumnOptionNotNull},
+			{Tp: ast.ColumnOptionAutoIncrement},
+			{Tp: ast.ColumnOptionInvisible},
+			{Tp: ast.ColumnOptionPrimaryKey},
+		},
+	}
+	colDefs := make([]*ast.ColumnDef, 0, len(s.Cols)+1)
+	colDefs = append(colDefs, gipk)
+	return append(colDefs, s.Cols...), nil
+}
+
 // BuildTableInfoWithStmt builds model.TableInfo from a SQL statement without validity check
 func BuildTableInfoWithStmt(ctx sessionctx.Context, s *ast.CreateTableStmt, dbCharset, dbCollate string, placementPolicyRef *model.PolicyRefInfo) (*model.TableInfo, error) {
-	colDefs := s.Cols
+	colDefs, err := buildColumnDefsWithGIPK(ctx, s)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	tableCharset, tableCollate, err := GetCharsetAndCollateInTableOption(ctx.GetSessionVars(), 0, s.Options)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -2613,6 +2746,14 @@ func BuildTableInfoWithStmt(ctx sessionctx.Context, s *ast.CreateTableStmt, dbCh
 		return nil, errors.Trace(err)
 	}
 
+	if err = setExternalTableInfo(tbInfo, s); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err = setFederatedTableInfo(tbInfo, s); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	if _, err = validateCommentLength(ctx.GetSessionVars(), tbInfo.Name.L, &tbInfo.Comment, dbterror.ErrTooLongTableComment); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -2652,23 +2793,41 @@ func (d *ddl) assignPartitionIDs(defs []model.PartitionDefinition) error {
 }
 
 func (d *ddl) CreateTable(ctx sessionctx.Context, s *ast.CreateTableStmt) (err error) {
+	schema, tbInfo, err := BuildTableInfoForCreateTable(ctx, d.GetInfoSchemaWithInterceptor(ctx), s)
+	if err != nil {
+		return err
+	}
+
+	onExist := OnExistError
+	if s.IfNotExists {
+		onExist = OnExistIgnore
+	}
+
+	return d.CreateTableWithInfo(ctx, schema.Name, tbInfo, onExist)
+}
+
+// BuildTableInfoForCreateTable builds and checks the table info of the CREATE TABLE statement as
+// CreateTable does, for the callers which adjust the table info before it's created by CreateTableWithInfo.
+func BuildTableInfoForCreateTable(ctx sessionctx.Context, is infoschema.InfoSchema, s *ast.CreateTableStmt) (*model.DBInfo, *model.TableInfo, error) {
 	ident := ast.Ident{Schema: s.Table.Schema, Name: s.Table.Name}
-	is := d.GetInfoSchemaWithInterceptor(ctx)
 	schema, ok := is.SchemaByName(ident.Schema)
 	if !ok {
-		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ident.Schema)
+		return nil, nil, infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ident.Schema)
 	}
 
-	var referTbl table.Table
+	var (
+		referTbl table.Table
+		err      error
+	)
 	if s.ReferTable != nil {
 		referIdent := ast.Ident{Schema: s.ReferTable.Schema, Name: s.ReferTable.Name}
 		_, ok := is.SchemaByName(referIdent.Schema)
 		if !ok {
-			return infoschema.ErrTableNotExists.GenWithStackByArgs(referIdent.Schema, referIdent.Name)
+			return nil, nil, infoschema.ErrTableNotExists.GenWithStackByArgs(referIdent.Schema, referIdent.Name)
 		}
 		referTbl, err = is.TableByName(referIdent.Schema, referIdent.Name)
 		if err != nil {
-			return infoschema.ErrTableNotExists.GenWithStackByArgs(referIdent.Schema, referIdent.Name)
+			return nil, nil, infoschema.ErrTableNotExists.GenWithStackByArgs(referIdent.Schema, referIdent.Name)
 		}
 	}
 
@@ -2680,22 +2839,16 @@ func (d *ddl) CreateTable(ctx sessionctx.Context, s *ast.CreateTableStmt) (err e
 		tbInfo, err = BuildTableInfoWithStmt(ctx, s, schema.Charset, schema.Collate, schema.PlacementPolicyRef)
 	}
 	if err != nil {
-		return errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 
 	if err = checkTableInfoValidWithStmt(ctx, tbInfo, s); err != nil {
-		return err
+		return nil, nil, err
 	}
 	if err = checkTableForeignKeysValid(ctx, is, schema.Name.L, tbInfo); err != nil {
-		return err
+		return nil, nil, err
 	}
-
-	onExist := OnExistError
-	if s.IfNotExists {
-		onExist = OnExistIgnore
-	}
-
-	return d.CreateTableWithInfo(ctx, schema.Name, tbInfo, onExist)
+	return schema, tbInfo, nil
 }
 
 func setTemporaryType(_ sessionctx.Context, tbInfo *model.TableInfo, s *ast.CreateTableStmt) error {
@@ -2714,6 +2867,110 @@ func setTemporaryType(_ sessionctx.Context, tbInfo *model.TableInfo, s *ast.Crea
 	return nil
 }
 
+// setExternalTableInfo sets the ExternalInfo of the table created by CREATE EXTERNAL TABLE.
+func setExternalTableInfo(tbInfo *model.TableInfo, s *ast.CreateTableStmt) error {
+	var info *model.ExternalTableInfo
+	for _, op := range s.Options {
+		switch op.Tp {
+		case ast.TableOptionLocation, ast.TableOptionFormat:
+			if info == nil {
+				info = &model.ExternalTableInfo{}
+			}
+			if op.Tp == ast.TableOptionLocation {
+				info.Location = op.StrValue
+			} else {
+				info.Format = strings.ToLower(op.StrValue)
+			}
+		}
+	}
+	if !s.IsExternal {
+		if info != nil {
+			return dbterror.ErrInvalidExternalTableDef.GenWithStackByArgs("LOCATION and FORMAT can only be used with CREATE EXTERNAL TABLE")
+		}
+		return nil
+	}
+	if info == nil || info.Location == "" {
+		return dbterror.ErrInvalidExternalTableDef.GenWithStackByArgs("LOCATION is required")
+	}
+	if _, err := storage.ParseRawURL(info.Location); err != nil {
+		return dbterror.ErrInvalidExternalTableDef.GenWithStackByArgs(err.Error())
+	}
+	switch info.Format {
+	case model.ExternalFormatCSV, model.ExternalFormatParquet:
+	case "":
+		return dbterror.ErrInvalidExternalTableDef.GenWithStackByArgs("FORMAT is required")
+	default:
+		return dbterror.ErrInvalidExternalTableDef.GenWithStackByArgs(fmt.Sprintf("unsupported FORMAT '%s'", info.Format))
+	}
+	if err := checkExternalTableDef(tbInfo); err != nil {
+		return err
+	}
+	tbInfo.ExternalInfo = info
+	return nil
+}
+
+// setFederatedTableInfo sets the FederatedInfo of the table created with ENGINE=FEDERATED.
+// The CONNECTION option is in the format of 'server_name[/tbl_name]', and the name of the
+// table is used if the tbl_name is omitted.
+func setFederatedTableInfo(tbInfo *model.TableInfo, s *ast.CreateTableStmt) error {
+	var engine, connection string
+	for _, op := range s.Options {
+		switch op.Tp {
+		case ast.TableOptionEngine:
+			engine = op.StrValue
+		case ast.TableOptionConnection:
+			connection = op.StrValue
+		}
+	}
+	if !strings.EqualFold(engine, "FEDERATED") {
+		return nil
+	}
+	if s.IsExternal || tbInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrOptOnExternalTable.GenWithStackByArgs("ENGINE=FEDERATED")
+	}
+	if s.Partition != nil {
+		return dbterror.ErrOptOnExternalTable.GenWithStackByArgs("partition")
+	}
+	if s.Select != nil {
+		return dbterror.ErrOptOnExternalTable.GenWithStackByArgs("CREATE TABLE ... SELECT")
+	}
+	server, table, _ := strings.Cut(connection, "/")
+	if server == "" || strings.Contains(server, ":") || strings.Contains(table, "/") {
+		return dbterror.ErrForeignDataStringInvalidCantCreate.GenWithStackByArgs(connection)
+	}
+	if table == "" {
+		table = tbInfo.Name.O
+	}
+	if err := checkExternalTableDef(tbInfo); err != nil {
+		return err
+	}
+	tbInfo.FederatedInfo = &model.FederatedTableInfo{
+		Server: strings.ToLower(server),
+		Table:  table,
+	}
+	return nil
+}
+
+// checkExternalTableDef checks the definition of a table whose rows are not stored in TiKV,
+// the indexes and the columns which need to be filled on write are not supported.
+func checkExternalTableDef(tbInfo *model.TableInfo) error {
+	if tbInfo.PKIsHandle || len(tbInfo.Indices) > 0 {
+		return dbterror.ErrOptOnExternalTable.GenWithStackByArgs("index")
+	}
+	if len(tbInfo.ForeignKeys) > 0 {
+		return dbterror.ErrOptOnExternalTable.GenWithStackByArgs("foreign key")
+	}
+	for _, col := range tbInfo.Columns {
+		if col.IsGenerated() {
+			return dbterror.ErrOptOnExternalTable.GenWithStackByArgs("generated column")
+		}
+		if mysql.HasAutoIncrementFlag(col.GetFlag()) {
+			return dbterror.ErrOptOnExternalTable.GenWithStackByArgs("auto_increment")
+		}
+	}
+	return nil
+}
+
 // createTableWithInfoJob returns the table creation job.
 // WARNING: it may return a nil job, which means you don't need to submit any DDL job.
 // WARNING!!!: if retainID == true, it will not allocate ID by itself. That means if the caller
@@ -3269,7 +3526,7 @@ func checkPartitionByList(ctx sessionctx.Context, tbInfo *model.TableInfo) error
 
 func isValidKeyPartitionColType(fieldType types.FieldType) bool {
 	switch fieldType.GetType() {
-	case mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeJSON, mysql.TypeGeometry:
+	case mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeJSON, mysql.TypeGeometry, mysql.TypeTiDBVectorFloat32:
 		return false
 	default:
 		return true
@@ -3606,6 +3863,8 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 			tbInfo.PreSplitRegions = op.UintValue
 		case ast.TableOptionCharset, ast.TableOptionCollate:
 			// We don't handle charset and collate here since they're handled in `GetCharsetAndCollateInTableOption`.
+		case ast.TableOptionLocation, ast.TableOptionFormat:
+			// We don't handle location and format here since they're handled in `setExternalTableInfo`.
 		case ast.TableOptionPlacementPolicy:
 			tbInfo.PlacementPolicyRef = &model.PolicyRefInfo{
 				Name: model.NewCIStr(op.StrValue),
@@ -3632,6 +3891,9 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 
 			tbInfo.TTLInfo = ttlInfo
 			ttlOptionsHandled = true
+		case ast.TableOptionIntervalPrecreate, ast.TableOptionIntervalRetention:
+			precreate, retention := getIntervalPolicyInOptions(options)
+			tbInfo.IntervalPolicy = mergeIntervalPolicy(nil, precreate, retention)
 		}
 	}
 	shardingBits := shardingBits(tbInfo)
@@ -3828,6 +4090,9 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 	if tb.Meta().IsView() || tb.Meta().IsSequence() {
 		return dbterror.ErrWrongObject.GenWithStackByArgs(ident.Schema, ident.Name, "BASE TABLE")
 	}
+	if tb.Meta().IsExternal() || tb.Meta().IsFederated() {
+		return dbterror.ErrOptOnExternalTable.GenWithStackByArgs("Alter Table")
+	}
 	if tb.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		if len(validSpecs) != 1 {
 			return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Alter Table")
@@ -3854,6 +4119,7 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 	for _, spec := range validSpecs {
 		var handledCharsetOrCollate bool
 		var ttlOptionsHandled bool
+		var intervalPolicyHandled bool
 		switch spec.Tp {
 		case ast.AlterTableAddColumns:
 			err = d.AddColumn(sctx, ident, spec)
@@ -3861,7 +4127,7 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 			err = d.AddTablePartitions(sctx, ident, spec)
 		case ast.AlterTableCoalescePartitions:
 			err = d.CoalescePartitions(sctx, ident, spec)
-		case ast.AlterTableReorganizePartition:
+		case ast.AlterTableReorganizePartition, ast.AlterTableSplitPartition, ast.AlterTableMergePartitions:
 			err = d.ReorganizePartitions(sctx, ident, spec)
 		case ast.AlterTableReorganizeFirstPartition:
 			err = dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("MERGE FIRST PARTITION")
@@ -3925,7 +4191,11 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 			case ast.ConstraintPrimaryKey:
 				err = d.CreatePrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintFulltext:
-				sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt)
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeFullText, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
+			case ast.ConstraintVector:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeVector, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
 			case ast.ConstraintCheck:
 				if !variable.EnableCheckConstraint.Load() {
 					sctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackError("the switch of check constraint is off"))
@@ -4009,6 +4279,14 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 					err = d.AlterTableTTLInfoOrEnable(sctx, ident, ttlInfo, ttlEnable, ttlJobInterval)
 
 					ttlOptionsHandled = true
+				case ast.TableOptionIntervalPrecreate, ast.TableOptionIntervalRetention:
+					if intervalPolicyHandled {
+						continue
+					}
+					precreate, retention := getIntervalPolicyInOptions(spec.Options)
+					err = d.AlterTableIntervalPolicy(sctx, ident, precreate, retention)
+
+					intervalPolicyHandled = true
 				default:
 					err = dbterror.ErrUnsupportedAlterTableOption
 				}
@@ -4293,16 +4571,16 @@ func CreateNewColumn(ctx sessionctx.Context, schema *model.DBInfo, spec *ast.Alt
 		// known rows with specific sequence next value under current add column logic.
 		// More explanation can refer: TestSequenceDefaultLogic's comment in sequence_test.go
 		if option.Tp == ast.ColumnOptionDefaultValue {
-			if f, ok := option.Expr.(*ast.FuncCallExpr); ok {
-				switch f.FnName.L {
-				case ast.NextVal:
-					if _, err := getSequenceDefaultValue(option); err != nil {
-						return nil, errors.Trace(err)
-					}
-					return nil, errors.Trace(dbterror.ErrAddColumnWithSequenceAsDefault.GenWithStackByArgs(specNewColumn.Name.Name.O))
-				case ast.Rand, ast.UUID, ast.UUIDToBin, ast.Replace, ast.Upper, ast.DateFormat, ast.StrToDate:
-					return nil, errors.Trace(dbterror.ErrBinlogUnsafeSystemFunction.GenWithStackByArgs())
+			if f, ok := option.Expr.(*ast.FuncCallExpr); ok && f.FnName.L == ast.NextVal {
+				if _, err := getSequenceDefaultValue(option); err != nil {
+					return nil, errors.Trace(err)
 				}
+				return nil, errors.Trace(dbterror.ErrAddColumnWithSequenceAsDefault.GenWithStackByArgs(specNewColumn.Name.Name.O))
+			}
+			// The known rows are filled with the default value evaluated once by the DDL statement,
+			// so the default value expression which varies between rows is forbidden like MySQL 8.0.
+			if nonDeterministic, _ := checkDefaultValueExpr(specNewColumn.Name.Name.O, option.Expr); nonDeterministic {
+				return nil, errors.Trace(dbterror.ErrBinlogUnsafeSystemFunction.GenWithStackByArgs())
 			}
 		}
 	}
@@ -4328,7 +4606,12 @@ func CreateNewColumn(ctx sessionctx.Context, schema *model.DBInfo, spec *ast.Alt
 		return nil, errors.Trace(err)
 	}
 
-	originDefVal, err := generateOriginDefaultValue(col.ToInfo(), ctx)
+	var originDefVal any
+	if col.DefaultIsExpr {
+		originDefVal, err = getExprOriginDefaultValue(ctx, col)
+	} else {
+		originDefVal, err = generateOriginDefaultValue(col.ToInfo(), ctx)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -4337,6 +4620,29 @@ func CreateNewColumn(ctx sessionctx.Context, schema *model.DBInfo, spec *ast.Alt
 	return col, err
 }
 
+// getExprOriginDefaultValue evaluates the default value expression of the new column to fill the known rows.
+func getExprOriginDefaultValue(ctx sessionctx.Context, col *table.Column) (any, error) {
+	d, err := table.GetColDefaultValue(ctx.GetExprCtx(), col.ToInfo())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if d.IsNull() {
+		if mysql.HasNotNullFlag(col.GetFlag()) {
+			return nil, dbterror.ErrInvalidDefaultValue.GenWithStackByArgs(col.Name.O)
+		}
+		return nil, nil
+	}
+	str, err := d.ToString()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The origin default value is stored as a string in the JSON encoded table info, the binary one can't be kept.
+	if col.GetType() != mysql.TypeBit && !utf8.ValidString(str) {
+		return nil, dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("adding a column with a binary default value expression")
+	}
+	return convertTimestampDefaultValToUTC(ctx, str, col)
+}
+
 // AddColumn will add a new column to the table.
 func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTableSpec) error {
 	specNewColumn := spec.NewColumns[0]
@@ -4579,6 +4885,9 @@ func (d *ddl) AlterTablePartitioning(ctx sessionctx.Context, ident ast.Ident, sp
 	}
 
 	meta := t.Meta().Clone()
+	if err = checkGlobalIndexesForReorgPartition(meta); err != nil {
+		return errors.Trace(err)
+	}
 	piOld := meta.GetPartitionInfo()
 	var partNames []string
 	if piOld != nil {
@@ -4596,6 +4905,14 @@ func (d *ddl) AlterTablePartitioning(ctx sessionctx.Context, ident ast.Ident, sp
 	if err != nil {
 		return err
 	}
+	// Every unique key must include the new partitioning columns, or it has to be
+	// a global index, which can't be built by the reorganization yet.
+	if err = checkPartitioningKeysConstraints(ctx, &ast.CreateTableStmt{Partition: spec.Partition}, newMeta); err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkGlobalIndexesForReorgPartition(newMeta); err != nil {
+		return errors.Trace(err)
+	}
 	newPartInfo := newMeta.Partition
 
 	if err = handlePartitionPlacement(ctx, newPartInfo); err != nil {
@@ -4651,6 +4968,9 @@ func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec
 	if pi == nil {
 		return dbterror.ErrPartitionMgmtOnNonpartitioned
 	}
+	if err = checkGlobalIndexesForReorgPartition(meta); err != nil {
+		return errors.Trace(err)
+	}
 	switch pi.Type {
 	case model.PartitionTypeRange, model.PartitionTypeList:
 	case model.PartitionTypeHash, model.PartitionTypeKey:
@@ -4669,6 +4989,12 @@ func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if spec.Tp == ast.AlterTableSplitPartition || spec.Tp == ast.AlterTableMergePartitions {
+		spec, err = buildSplitOrMergePartitionSpec(spec, pi, lastPartIdx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	partInfo, err := BuildAddedPartitionInfo(ctx.GetExprCtx(), meta, spec)
 	if err != nil {
 		return errors.Trace(err)
@@ -4705,6 +5031,59 @@ func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec
 	return errors.Trace(err)
 }
 
+// buildSplitOrMergePartitionSpec converts SPLIT PARTITION and MERGE PARTITIONS into
+// the equivalent REORGANIZE PARTITION spec. The last new partition inherits the upper
+// bound of the last replaced partition, unless it is given explicitly.
+func buildSplitOrMergePartitionSpec(spec *ast.AlterTableSpec, pi *model.PartitionInfo, lastPartIdx int) (*ast.AlterTableSpec, error) {
+	if pi.Type != model.PartitionTypeRange {
+		return nil, dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("SPLIT/MERGE PARTITION of non-RANGE partitioned table")
+	}
+	switch spec.Tp {
+	case ast.AlterTableSplitPartition:
+		if len(spec.PartDefinitions) < 2 {
+			return nil, dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("SPLIT PARTITION into less than two partitions")
+		}
+	case ast.AlterTableMergePartitions:
+		if len(spec.PartitionNames) < 2 {
+			return nil, dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("MERGE PARTITIONS of less than two partitions")
+		}
+	}
+	newSpec := *spec
+	newSpec.Tp = ast.AlterTableReorganizePartition
+	newSpec.PartDefinitions = make([]*ast.PartitionDefinition, 0, len(spec.PartDefinitions))
+	for _, def := range spec.PartDefinitions {
+		newDef := *def
+		newSpec.PartDefinitions = append(newSpec.PartDefinitions, &newDef)
+	}
+	lastDef := newSpec.PartDefinitions[len(newSpec.PartDefinitions)-1]
+	if _, ok := lastDef.Clause.(*ast.PartitionDefinitionClauseNone); ok {
+		clause, err := buildLessThanClause(pi.Definitions[lastPartIdx].LessThan)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		lastDef.Clause = clause
+	}
+	return &newSpec, nil
+}
+
+// buildLessThanClause builds the VALUES LESS THAN clause from the values stored in a
+// range partition definition.
+func buildLessThanClause(lessThan []string) (*ast.PartitionDefinitionClauseLessThan, error) {
+	clause := &ast.PartitionDefinitionClauseLessThan{Exprs: make([]ast.ExprNode, 0, len(lessThan))}
+	for _, val := range lessThan {
+		if strings.EqualFold(val, partitionMaxValue) {
+			clause.Exprs = append(clause.Exprs, &ast.MaxValueExpr{})
+			continue
+		}
+		stmts, _, err := parser.New().ParseSQL("SELECT " + val)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		clause.Exprs = append(clause.Exprs, stmts[0].(*ast.SelectStmt).Fields.Fields[0].Expr)
+	}
+	return clause, nil
+}
+
 // RemovePartitioning removes partitioning from a table.
 func (d *ddl) RemovePartitioning(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
@@ -4717,6 +5096,9 @@ func (d *ddl) RemovePartitioning(ctx sessionctx.Context, ident ast.Ident, spec *
 	if pi == nil {
 		return dbterror.ErrPartitionMgmtOnNonpartitioned
 	}
+	if err = checkGlobalIndexesForReorgPartition(meta); err != nil {
+		return errors.Trace(err)
+	}
 	// TODO: Optimize for remove partitioning with a single partition
 	// TODO: Add the support for this in onReorganizePartition
 	// skip if only one partition
@@ -5163,13 +5545,17 @@ func checkTableDefCompatible(source *model.TableInfo, target *model.TableInfo) e
 			return dbterror.ErrPartitionExchangeDifferentOption.GenWithStackByArgs(fmt.Sprintf("column: %s", sourceCol.Name))
 		}
 	}
-	if len(source.Indices) != len(target.Indices) {
-		return errors.Trace(dbterror.ErrTablesDifferentMetadata)
-	}
+	// The entries of a global index cover all the partitions, they can't be exchanged
+	// with the index of a non-partitioned table.
 	for _, sourceIdx := range source.Indices {
 		if sourceIdx.Global {
 			return dbterror.ErrPartitionExchangeDifferentOption.GenWithStackByArgs(fmt.Sprintf("global index: %s", sourceIdx.Name))
 		}
+	}
+	if len(source.Indices) != len(target.Indices) {
+		return errors.Trace(dbterror.ErrTablesDifferentMetadata)
+	}
+	for _, sourceIdx := range source.Indices {
 		var compatIdx *model.IndexInfo
 		for _, targetIdx := range target.Indices {
 			if strings.EqualFold(sourceIdx.Name.L, targetIdx.Name.L) {
@@ -5454,7 +5840,8 @@ func checkModifyTypes(origin *types.FieldType, to *types.FieldType, needRewriteC
 	err = checkModifyCharsetAndCollation(to.GetCharset(), to.GetCollate(), origin.GetCharset(), origin.GetCollate(), needRewriteCollationData)
 
 	if err != nil {
-		if to.GetCharset() == charset.CharsetGBK || origin.GetCharset() == charset.CharsetGBK {
+		if to.GetCharset() == charset.CharsetGBK || origin.GetCharset() == charset.CharsetGBK ||
+			to.GetCharset() == charset.CharsetGB18030 || origin.GetCharset() == charset.CharsetGB18030 {
 			return errors.Trace(err)
 		}
 		// column type change can handle the charset change between these two types in the process of the reorg.
@@ -5589,6 +5976,10 @@ func ProcessColumnOptions(ctx sessionctx.Context, col *table.Column, options []*
 			return errors.Trace(dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't modify with full text"))
 		case ast.ColumnOptionCheck:
 			return errors.Trace(dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't modify with check"))
+		case ast.ColumnOptionVisible:
+			col.Invisible = false
+		case ast.ColumnOptionInvisible:
+			col.Invisible = true
 		// Ignore ColumnOptionAutoRandom. It will be handled later.
 		case ast.ColumnOptionAutoRandom:
 		default:
@@ -5775,6 +6166,17 @@ func GetModifiableColumnJob(
 	if err = ProcessColumnOptions(sctx, newCol, specNewColumn.Options); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if newCol.Invisible {
+		cols := make([]*model.ColumnInfo, 0, len(t.Meta().Columns))
+		for _, c := range t.Meta().Columns {
+			if c.ID != col.ID {
+				cols = append(cols, c)
+			}
+		}
+		if err = checkVisibleColumns(append(cols, newCol.ColumnInfo)); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 
 	if err = checkModifyTypes(&col.FieldType, &newCol.FieldType, isColumnWithIndex(col.Name.L, t.Meta().Indices)); err != nil {
 		if strings.Contains(err.Error(), "Unsupported modifying collation") {
@@ -6089,7 +6491,7 @@ func checkAutoRandom(tableInfo *model.TableInfo, originCol *table.Column, specNe
 		if newShardBits == 0 {
 			return 0, dbterror.ErrInvalidAutoRandom.GenWithStackByArgs(autoid.AutoRandomAlterErrMsg)
 		}
-		return 0, dbterror.ErrInvalidAutoRandom.GenWithStackByArgs(autoid.AutoRandomDecreaseBitErrMsg)
+		// decreasing auto_random shard bits is allowed, the allocator is rebased in the DDL job.
 	}
 
 	modifyingAutoRandCol := oldShardBits > 0 || newShardBits > 0
@@ -6289,6 +6691,8 @@ func (d *ddl) AlterColumn(ctx sessionctx.Context, ident ast.Ident, spec *ast.Alt
 		if IsAutoRandomColumnID(t.Meta(), col.ID) {
 			return dbterror.ErrInvalidAutoRandom.GenWithStackByArgs(autoid.AutoRandomIncompatibleWithDefaultValueErrMsg)
 		}
+		// The new default value may not be an expression even if the old one is.
+		col.DefaultIsExpr = false
 		hasDefaultValue, err := SetDefaultValue(ctx, col, specNewColumn.Options[0])
 		if err != nil {
 			return errors.Trace(err)
@@ -6597,6 +7001,43 @@ func (d *ddl) AlterTableRemoveTTL(ctx sessionctx.Context, ident ast.Ident) error
 	return nil
 }
 
+// AlterTableIntervalPolicy submits ddl job to change the INTERVAL_PRECREATE and INTERVAL_RETENTION
+// of the table. The values not set are kept unchanged.
+func (d *ddl) AlterTableIntervalPolicy(ctx sessionctx.Context, ident ast.Ident, precreate, retention *uint64) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ident.Schema)
+	}
+
+	tb, err := is.TableByName(ident.Schema, ident.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
+	}
+
+	tblInfo := tb.Meta().Clone()
+	tblInfo.IntervalPolicy = mergeIntervalPolicy(tblInfo.IntervalPolicy, precreate, retention)
+	if err = checkIntervalPolicyValid(ctx, tblInfo); err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionAlterIntervalPolicy,
+		BinlogInfo:     &model.HistoryInfo{},
+		Args:           []any{precreate, retention},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
 func isTableTiFlashSupported(schema *model.DBInfo, tb table.Table) error {
 	// Memory tables and system tables are not supported by TiFlash
 	if util.IsMemOrSysDB(schema.Name.L) {
@@ -6860,9 +7301,10 @@ const (
 	tableObject objectType = iota
 	viewObject
 	sequenceObject
+	materializedViewObject
 )
 
-// dropTableObject provides common logic to DROP TABLE/VIEW/SEQUENCE.
+// dropTableObject provides common logic to DROP TABLE/VIEW/SEQUENCE/MATERIALIZED VIEW.
 func (d *ddl) dropTableObject(
 	ctx sessionctx.Context,
 	objects []*ast.TableName,
@@ -6879,7 +7321,7 @@ func (d *ddl) dropTableObject(
 
 	var jobArgs []any
 	switch tableObjectType {
-	case tableObject:
+	case tableObject, materializedViewObject:
 		dropExistErr = infoschema.ErrTableDropExists
 		jobType = model.ActionDropTable
 		objectIdents := make([]ast.Ident, len(objects))
@@ -6930,6 +7372,9 @@ func (d *ddl) dropTableObject(
 				notExistTables = append(notExistTables, fullti.String())
 				continue
 			}
+			if tableInfo.Meta().IsMaterializedView() {
+				return dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "BASE TABLE")
+			}
 
 			tempTableType := tableInfo.Meta().TempTableType
 			if config.CheckTableBeforeDrop && tempTableType == model.TempTableNone {
@@ -6952,6 +7397,10 @@ func (d *ddl) dropTableObject(
 			if !tableInfo.Meta().IsView() {
 				return dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "VIEW")
 			}
+		case materializedViewObject:
+			if !tableInfo.Meta().IsMaterializedView() {
+				return dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "MATERIALIZED VIEW")
+			}
 		case sequenceObject:
 			if !tableInfo.Meta().IsSequence() {
 				err = dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "SEQUENCE")
@@ -7010,6 +7459,9 @@ func (d *ddl) dropTableObject(
 
 // DropTable will proceed even if some table in the list does not exists.
 func (d *ddl) DropTable(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err error) {
+	if stmt.IsMaterializedView {
+		return d.dropTableObject(ctx, stmt.Tables, stmt.IfExists, materializedViewObject)
+	}
 	return d.dropTableObject(ctx, stmt.Tables, stmt.IfExists, tableObject)
 }
 
@@ -7029,6 +7481,9 @@ func (d *ddl) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
 	if tb.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Truncate Table")
 	}
+	if tb.Meta().IsExternal() || tb.Meta().IsFederated() {
+		return dbterror.ErrOptOnExternalTable.GenWithStackByArgs("Truncate Table")
+	}
 	fkCheck := ctx.GetSessionVars().ForeignKeyChecks
 	referredFK := checkTableHasForeignKeyReferred(d.GetInfoSchemaWithInterceptor(ctx), ti.Schema.L, ti.Name.L, []ast.Ident{{Name: ti.Name, Schema: ti.Schema}}, fkCheck)
 	if referredFK != nil {
@@ -7516,7 +7971,7 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, stmt *ast.CreateIndexStmt) err
 }
 
 // addHypoIndexIntoCtx adds this index as a hypo-index into this ctx.
-func (*ddl) addHypoIndexIntoCtx(ctx sessionctx.Context, schemaName, tableName model.CIStr, indexInfo *model.IndexInfo) error {
+func (*ddl) addHypoIndexIntoCtx(ctx sessionctx.Context, schemaName, tableName model.CIStr, indexInfo *model.IndexInfo, ifNotExists bool) error {
 	sctx := ctx.GetSessionVars()
 	indexName := indexInfo.Name
 
@@ -7530,7 +7985,12 @@ func (*ddl) addHypoIndexIntoCtx(ctx sessionctx.Context, schemaName, tableName mo
 		sctx.HypoIndexes[schemaName.L][tableName.L] = make(map[string]*model.IndexInfo)
 	}
 	if _, exist := sctx.HypoIndexes[schemaName.L][tableName.L][indexName.L]; exist {
-		return errors.Trace(errors.Errorf("conflict hypo index name %s", indexName.L))
+		err := errors.Errorf("conflict hypo index name %s", indexName.L)
+		if ifNotExists {
+			sctx.StmtCtx.AppendNote(err)
+			return nil
+		}
+		return errors.Trace(err)
 	}
 
 	sctx.HypoIndexes[schemaName.L][tableName.L][indexName.L] = indexInfo
@@ -7539,11 +7999,23 @@ func (*ddl) addHypoIndexIntoCtx(ctx sessionctx.Context, schemaName, tableName mo
 
 func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.IndexKeyType, indexName model.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
-	// not support Spatial and FullText index
-	if keyType == ast.IndexKeyTypeFullText || keyType == ast.IndexKeyTypeSpatial {
-		return dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT and SPATIAL index is not supported")
+	// not support Spatial index
+	if keyType == ast.IndexKeyTypeSpatial {
+		return dbterror.ErrUnsupportedIndexType.GenWithStack("SPATIAL index is not supported")
 	}
 	unique := keyType == ast.IndexKeyTypeUnique
+	if keyType == ast.IndexKeyTypeFullText {
+		var err error
+		if indexOption, err = FullTextIndexOption(indexOption); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if keyType == ast.IndexKeyTypeVector {
+		var err error
+		if indexOption, indexPartSpecifications, err = VectorIndexOption(indexOption, indexPartSpecifications); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
 		return errors.Trace(err)
@@ -7552,6 +8024,9 @@ func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	if t.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Create Index"))
 	}
+	if t.Meta().IsExternal() || t.Meta().IsFederated() {
+		return errors.Trace(dbterror.ErrOptOnExternalTable.GenWithStackByArgs("Create Index"))
+	}
 	// Deal with anonymous index.
 	if len(indexName.L) == 0 {
 		colName := model.NewCIStr("expression_index")
@@ -7601,7 +8076,14 @@ func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	// After DDL job is put to the queue, and if the check fail, TiDB will run the DDL cancel logic.
 	// The recover step causes DDL wait a few seconds, makes the unit test painfully slow.
 	// For same reason, decide whether index is global here.
-	indexColumns, _, err := buildIndexColumns(ctx, finalColumns, indexPartSpecifications)
+	var indexColumns []*model.IndexColumn
+	if keyType == ast.IndexKeyTypeFullText {
+		indexColumns, err = buildFullTextIndexColumns(finalColumns, indexPartSpecifications)
+	} else if keyType == ast.IndexKeyTypeVector {
+		indexColumns, err = buildVectorIndexColumns(finalColumns, indexPartSpecifications)
+	} else {
+		indexColumns, _, err = buildIndexColumns(ctx, finalColumns, indexPartSpecifications)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -7628,12 +8110,16 @@ func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	}
 
 	if indexOption != nil && indexOption.Tp == model.IndexTypeHypo { // for hypo-index
+		// The hidden columns of the expression index can't be added to the table without a real DDL.
+		if len(hiddenCols) > 0 {
+			return dbterror.ErrUnsupportedIndexType.GenWithStack("hypo index on expressions is not supported")
+		}
 		indexInfo, err := BuildIndexInfo(ctx, tblInfo.Columns, indexName, false, unique, global,
 			indexPartSpecifications, indexOption, model.StatePublic)
 		if err != nil {
 			return err
 		}
-		return d.addHypoIndexIntoCtx(ctx, ti.Schema, ti.Name, indexInfo)
+		return d.addHypoIndexIntoCtx(ctx, ti.Schema, ti.Name, indexInfo, ifNotExists)
 	}
 
 	chs, coll := ctx.GetSessionVars().GetCharsetInfo()
@@ -8011,6 +8497,15 @@ func isDroppableColumn(tblInfo *model.TableInfo, colName model.CIStr) error {
 		return dbterror.ErrCantRemoveAllFields.GenWithStack("can't drop only column %s in table %s",
 			colName, tblInfo.Name)
 	}
+	remainCols := make([]*model.ColumnInfo, 0, len(tblInfo.Columns)-1)
+	for _, col := range tblInfo.Columns {
+		if col.Name.L != colName.L {
+			remainCols = append(remainCols, col)
+		}
+	}
+	if err := checkVisibleColumns(remainCols); err != nil {
+		return err
+	}
 	// We only support dropping column with single-value none Primary Key index covered now.
 	err := isColumnCanDropWithIndex(colName.L, tblInfo.Indices)
 	if err != nil {
@@ -8104,10 +8599,12 @@ func BuildAddedPartitionInfo(ctx expression.BuildContext, meta *model.TableInfo,
 	}
 
 	part := &model.PartitionInfo{
-		Type:    meta.Partition.Type,
-		Expr:    meta.Partition.Expr,
-		Columns: meta.Partition.Columns,
-		Enable:  meta.Partition.Enable,
+		Type:         meta.Partition.Type,
+		Expr:         meta.Partition.Expr,
+		Columns:      meta.Partition.Columns,
+		Enable:       meta.Partition.Enable,
+		IsLinear:     meta.Partition.IsLinear,
+		KeyAlgorithm: meta.Partition.KeyAlgorithm,
 	}
 
 	defs, err := buildPartitionDefinitionsInfo(ctx, spec.PartDefinitions, meta, numParts)
@@ -8563,6 +9060,132 @@ func (d *ddl) DropSequence(ctx sessionctx.Context, stmt *ast.DropSequenceStmt) (
 	return d.dropTableObject(ctx, stmt.Sequences, stmt.IfExists, sequenceObject)
 }
 
+// CreateTrigger creates a row-level trigger on the table.
+func (d *ddl) CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error {
+	if stmt.Name.Schema.L != stmt.Table.Schema.L {
+		return dbterror.ErrTrgInWrongSchema
+	}
+	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ast.Ident{Schema: stmt.Table.Schema, Name: stmt.Table.Name})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo := tb.Meta()
+	if err = checkTriggerTable(tblInfo, stmt.Event); err != nil {
+		return err
+	}
+	if findTriggerTable(d.GetInfoSchemaWithInterceptor(ctx), schema.Name, stmt.Name.Name) != nil {
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(dbterror.ErrTrgAlreadyExists)
+			return nil
+		}
+		return dbterror.ErrTrgAlreadyExists
+	}
+	if err = checkTriggerBody(tblInfo, stmt.Timing, stmt.Event, stmt.Body); err != nil {
+		return err
+	}
+
+	sessVars := ctx.GetSessionVars()
+	var definer string
+	if sessVars.User != nil {
+		definer = (&auth.UserIdentity{Username: sessVars.User.AuthUsername, Hostname: sessVars.User.AuthHostname}).String()
+	}
+	charsetClient, _ := sessVars.GetSystemVar(variable.CharacterSetClient)
+	collationConnection, _ := sessVars.GetSystemVar(variable.CollationConnection)
+	trigger := &model.TriggerInfo{
+		Name:                stmt.Name.Name,
+		Timing:              stmt.Timing,
+		Event:               stmt.Event,
+		Body:                stmt.Body.Text(),
+		Definer:             definer,
+		SQLMode:             sessVars.SQLMode,
+		CharacterSetClient:  charsetClient,
+		CollationConnection: collationConnection,
+		Created:             time.Now(),
+	}
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionCreateTrigger,
+		BinlogInfo:     &model.HistoryInfo{},
+		Args:           []any{trigger},
+		CDCWriteSource: sessVars.CDCWriteSource,
+		SQLMode:        sessVars.SQLMode,
+	}
+
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// DropTrigger drops a trigger.
+func (d *ddl) DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(stmt.Name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.Name.Schema)
+	}
+	tblInfo := findTriggerTable(is, schema.Name, stmt.Name.Name)
+	if tblInfo == nil {
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(dbterror.ErrTrgDoesNotExist)
+			return nil
+		}
+		return dbterror.ErrTrgDoesNotExist
+	}
+
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionDropTrigger,
+		BinlogInfo:     &model.HistoryInfo{},
+		Args:           []any{stmt.Name.Name},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+
+	err := d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// RecordMaterializedViewRefresh records the snapshot which the refreshed data of the materialized
+// view is selected from. tableID is the ID of the view whose data is refreshed, the job fails if the
+// view is dropped or replaced by another table.
+func (d *ddl) RecordMaterializedViewRefresh(ctx sessionctx.Context, ident ast.Ident, tableID int64, lastRefreshTS uint64) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ident.Schema)
+	}
+	tb, err := is.TableByName(ident.Schema, ident.Name)
+	if err != nil || tb.Meta().ID != tableID {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
+	}
+	if !tb.Meta().IsMaterializedView() {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(ident.Schema, ident.Name, "MATERIALIZED VIEW")
+	}
+
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tableID,
+		SchemaName:     schema.Name.L,
+		TableName:      tb.Meta().Name.L,
+		Type:           model.ActionRefreshMaterializedView,
+		BinlogInfo:     &model.HistoryInfo{},
+		Args:           []any{lastRefreshTS},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) AlterIndexVisibility(ctx sessionctx.Context, ident ast.Ident, indexName model.CIStr, visibility ast.IndexVisibility) error {
 	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ident)
 	if err != nil {
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
 	sess "github.com/pingcap/tidb/pkg/ddl/internal/session"
@@ -66,6 +68,60 @@ func addHistoryDDLJob2Table(sess *sess.Session, job *model.Job, updateRawArgs bo
 	return errors.Trace(err)
 }
 
+// CompactHistoryDDLJobs deletes the history DDL jobs finished before `before` from both the meta and
+// mysql.tidb_ddl_history, and returns the number of the deleted jobs. The jobs are checked in the ascending order of
+// the job ID, and it stops at the first job finished after `before`.
+func CompactHistoryDDLJobs(sctx sessionctx.Context, before time.Time) (int, error) {
+	se := sess.NewSession(sctx)
+	deleted := 0
+	for {
+		var ids []int64
+		done := false
+		err := se.RunInTxn(func(se *sess.Session) error {
+			txn, err := se.Txn()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			m := meta.NewMeta(txn)
+			jobs, err := m.GetFirstHistoryDDLJobs(batchNumHistoryJobs)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			done = len(jobs) < batchNumHistoryJobs
+			strIDs := make([]string, 0, len(jobs))
+			for _, job := range jobs {
+				finishTS := job.StartTS
+				if job.BinlogInfo != nil && job.BinlogInfo.FinishedTS > 0 {
+					finishTS = job.BinlogInfo.FinishedTS
+				}
+				if !model.TSConvert2Time(finishTS).Before(before) {
+					done = true
+					break
+				}
+				ids = append(ids, job.ID)
+				strIDs = append(strIDs, strconv.FormatInt(job.ID, 10))
+			}
+			if len(ids) == 0 {
+				return nil
+			}
+			if err = m.DeleteHistoryDDLJobs(ids...); err != nil {
+				return errors.Trace(err)
+			}
+			_, err = se.Execute(context.Background(),
+				fmt.Sprintf("delete from mysql.tidb_ddl_history where job_id in (%s)", strings.Join(strIDs, ",")),
+				"compact_history")
+			return errors.Trace(err)
+		})
+		if err != nil {
+			return deleted, err
+		}
+		deleted += len(ids)
+		if done || len(ids) == 0 {
+			return deleted, nil
+		}
+	}
+}
+
 // GetHistoryJobByID return history DDL job by ID.
 func GetHistoryJobByID(sess sessionctx.Context, id int64) (*model.Job, error) {
 	err := sessiontxn.NewTxn(context.Background(), sess)
@@ -1213,6 +1213,8 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = w.onRecoverSchema(d, t, job)
 	case model.ActionModifySchemaDefaultPlacement:
 		ver, err = onModifySchemaDefaultPlacement(d, t, job)
+	case model.ActionModifySchemaReadOnly:
+		ver, err = onModifySchemaReadOnly(d, t, job)
 	case model.ActionCreateTable:
 		ver, err = onCreateTable(d, t, job)
 	case model.ActionCreateTables:
@@ -1320,6 +1322,14 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onTTLInfoChange(d, t, job)
 	case model.ActionAlterTTLRemove:
 		ver, err = onTTLInfoRemove(d, t, job)
+	case model.ActionAlterIntervalPolicy:
+		ver, err = onAlterIntervalPolicy(d, t, job)
+	case model.ActionCreateTrigger:
+		ver, err = onCreateTrigger(d, t, job)
+	case model.ActionDropTrigger:
+		ver, err = onDropTrigger(d, t, job)
+	case model.ActionRefreshMaterializedView:
+		ver, err = onRefreshMaterializedView(d, t, job)
 	case model.ActionAddCheckConstraint:
 		ver, err = w.onAddCheckConstraint(d, t, job)
 	case model.ActionDropCheckConstraint:
@@ -1684,6 +1694,8 @@ func updateSchemaVersion(d *ddlCtx, t *meta.Meta, job *model.Job, multiInfos ...
 		}
 		// Reserved recoverSchemaCheckFlag value for gc work judgment.
 		job.Args[checkFlagIndexInJobArgs] = recoverSchemaCheckFlag
+		// The schema exists in the info schema, so it's rebuilt to load the recovered tables.
+		diff.RegenerateSchemaMap = recoverSchemaInfo.SchemaExists
 		recoverTabsInfo := recoverSchemaInfo.RecoverTabsInfo
 		diff.AffectedOpts = make([]*model.AffectedOption, len(recoverTabsInfo))
 		for i := range recoverTabsInfo {
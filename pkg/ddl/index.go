@@ -60,6 +60,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/backoff"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	decoder "github.com/pingcap/tidb/pkg/util/rowDecoder"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
@@ -147,6 +148,103 @@ func buildIndexColumns(ctx sessionctx.Context, columns []*model.ColumnInfo, inde
 	return idxParts, mvIndex, nil
 }
 
+// buildFullTextIndexColumns builds the columns of a fulltext index. The whole values of the
+// columns are tokenized, so the prefix length can't be specified.
+func buildFullTextIndexColumns(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification) ([]*model.IndexColumn, error) {
+	idxParts := make([]*model.IndexColumn, 0, len(indexPartSpecifications))
+	for _, ip := range indexPartSpecifications {
+		// the expressions are converted to the hidden virtual columns, which are rejected below.
+		col := model.FindColumnInfo(columns, ip.Column.Name.L)
+		if col == nil {
+			return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+		}
+		tp := col.GetType()
+		isText := types.IsTypeChar(tp) || types.IsTypeVarchar(tp) || types.IsTypeBlob(tp)
+		if !isText || col.GetCharset() == charset.CharsetBin || col.IsGenerated() && !col.GeneratedStored {
+			return nil, dbterror.ErrBadFtColumn.GenWithStackByArgs(col.Name.O)
+		}
+		if ip.Length != types.UnspecifiedLength {
+			return nil, errors.Trace(dbterror.ErrIncorrectPrefixKey)
+		}
+		idxParts = append(idxParts, &model.IndexColumn{
+			Name:   col.Name,
+			Offset: col.Offset,
+			Length: types.UnspecifiedLength,
+		})
+	}
+	return idxParts, nil
+}
+
+// FullTextIndexOption returns the index option of a fulltext index, which is passed to
+// BuildIndexInfo and the DDL job. The parser is checked here.
+func FullTextIndexOption(indexOption *ast.IndexOption) (*ast.IndexOption, error) {
+	opt := &ast.IndexOption{}
+	if indexOption != nil {
+		*opt = *indexOption
+	}
+	if opt.Tp != model.IndexTypeInvalid && opt.Tp != model.IndexTypeFullText {
+		return nil, dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT index can't be used with index type %s", opt.Tp)
+	}
+	if opt.ParserName.L != "" && !fulltext.IsValidParser(opt.ParserName.L) {
+		return nil, dbterror.ErrPluginIsNotLoaded.GenWithStackByArgs(opt.ParserName.O)
+	}
+	opt.Tp = model.IndexTypeFullText
+	return opt, nil
+}
+
+// vectorDistanceMetrics maps the distance functions to the distance metrics of vector indexes.
+var vectorDistanceMetrics = map[string]model.DistanceMetric{
+	ast.VecCosineDistance: model.DistanceMetricCosine,
+	ast.VecL2Distance:     model.DistanceMetricL2,
+}
+
+// VectorIndexOption resolves the key part of a vector index like `(VEC_COSINE_DISTANCE(col))`.
+// It returns the index option with the distance metric and the key part of the vector column,
+// which are passed to BuildIndexInfo and the DDL job.
+func VectorIndexOption(indexOption *ast.IndexOption, indexPartSpecifications []*ast.IndexPartSpecification) (*ast.IndexOption, []*ast.IndexPartSpecification, error) {
+	opt := &ast.IndexOption{}
+	if indexOption != nil {
+		*opt = *indexOption
+	}
+	if opt.Tp != model.IndexTypeInvalid && opt.Tp != model.IndexTypeHNSW {
+		return nil, nil, dbterror.ErrUnsupportedIndexType.GenWithStack("VECTOR index can't be used with index type %s", opt.Tp)
+	}
+	opt.Tp = model.IndexTypeHNSW
+	if len(indexPartSpecifications) == 1 && indexPartSpecifications[0].Expr != nil {
+		if fn, ok := indexPartSpecifications[0].Expr.(*ast.FuncCallExpr); ok && len(fn.Args) == 1 {
+			metric, ok1 := vectorDistanceMetrics[fn.FnName.L]
+			col, ok2 := fn.Args[0].(*ast.ColumnNameExpr)
+			if ok1 && ok2 {
+				opt.DistanceMetric = metric
+				return opt, []*ast.IndexPartSpecification{{Column: col.Name, Length: types.UnspecifiedLength}}, nil
+			}
+		}
+	}
+	return nil, nil, dbterror.ErrUnsupportedIndexType.GenWithStack(
+		"VECTOR index must be defined on a distance function of a column, like ((VEC_COSINE_DISTANCE(col)))")
+}
+
+// buildVectorIndexColumns builds the column of a vector index, which must be a VECTOR column
+// with the dimension.
+func buildVectorIndexColumns(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification) ([]*model.IndexColumn, error) {
+	ip := indexPartSpecifications[0]
+	col := model.FindColumnInfo(columns, ip.Column.Name.L)
+	if col == nil {
+		return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+	}
+	if col.GetType() != mysql.TypeTiDBVectorFloat32 || col.GetFlen() == types.UnspecifiedLength {
+		return nil, dbterror.ErrUnsupportedIndexType.GenWithStack("VECTOR index can only be defined on a VECTOR column with the dimension, like VECTOR(3)")
+	}
+	if col.IsGenerated() && !col.GeneratedStored {
+		return nil, dbterror.ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("VECTOR index on virtual generated column")
+	}
+	return []*model.IndexColumn{{
+		Name:   col.Name,
+		Offset: col.Offset,
+		Length: types.UnspecifiedLength,
+	}}, nil
+}
+
 // CheckPKOnGeneratedColumn checks the specification of PK is valid.
 func CheckPKOnGeneratedColumn(tblInfo *model.TableInfo, indexPartSpecifications []*ast.IndexPartSpecification) (*model.ColumnInfo, error) {
 	var lastCol *model.ColumnInfo
@@ -194,6 +292,11 @@ func checkIndexColumn(ctx sessionctx.Context, col *model.ColumnInfo, indexColumn
 		return errors.Trace(dbterror.ErrJSONUsedAsKey.GenWithStackByArgs(col.Name.O))
 	}
 
+	// VECTOR column can only be indexed by a vector index.
+	if col.FieldType.GetType() == mysql.TypeTiDBVectorFloat32 {
+		return dbterror.ErrUnsupportedIndexType.GenWithStack("VECTOR column '%s' can only be indexed by a VECTOR INDEX", col.Name.O)
+	}
+
 	// Length must be specified and non-zero for BLOB and TEXT column indexes.
 	if types.IsTypeBlob(col.FieldType.GetType()) {
 		if indexColumnLen == types.UnspecifiedLength {
@@ -299,6 +402,13 @@ func BuildIndexInfo(
 		return nil, errors.Trace(err)
 	}
 
+	if indexOption != nil && indexOption.Tp == model.IndexTypeFullText {
+		return buildFullTextIndexInfo(allTableColumns, indexName, indexPartSpecifications, indexOption, state)
+	}
+	if indexOption != nil && indexOption.Tp == model.IndexTypeHNSW {
+		return buildVectorIndexInfo(allTableColumns, indexName, indexPartSpecifications, indexOption, state)
+	}
+
 	idxColumns, mvIndex, err := buildIndexColumns(ctx, allTableColumns, indexPartSpecifications)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -334,6 +444,60 @@ func BuildIndexInfo(
 	return idxInfo, nil
 }
 
+func buildFullTextIndexInfo(
+	allTableColumns []*model.ColumnInfo,
+	indexName model.CIStr,
+	indexPartSpecifications []*ast.IndexPartSpecification,
+	indexOption *ast.IndexOption,
+	state model.SchemaState,
+) (*model.IndexInfo, error) {
+	idxColumns, err := buildFullTextIndexColumns(allTableColumns, indexPartSpecifications)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	parserType := model.FullTextParserTypeWhitespace
+	if indexOption.ParserName.L != "" {
+		parserType = model.FullTextParserType(indexOption.ParserName.L)
+	}
+	return &model.IndexInfo{
+		Name:         indexName,
+		Columns:      idxColumns,
+		State:        state,
+		Comment:      indexOption.Comment,
+		Invisible:    indexOption.Visibility == ast.IndexVisibilityInvisible,
+		Tp:           model.IndexTypeFullText,
+		FullTextInfo: &model.FullTextIndexInfo{ParserType: parserType},
+	}, nil
+}
+
+func buildVectorIndexInfo(
+	allTableColumns []*model.ColumnInfo,
+	indexName model.CIStr,
+	indexPartSpecifications []*ast.IndexPartSpecification,
+	indexOption *ast.IndexOption,
+	state model.SchemaState,
+) (*model.IndexInfo, error) {
+	if indexOption.DistanceMetric == "" {
+		return nil, dbterror.ErrUnsupportedIndexType.GenWithStack("the distance metric of VECTOR index is not specified")
+	}
+	idxColumns, err := buildVectorIndexColumns(allTableColumns, indexPartSpecifications)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &model.IndexInfo{
+		Name:      indexName,
+		Columns:   idxColumns,
+		State:     state,
+		Comment:   indexOption.Comment,
+		Invisible: indexOption.Visibility == ast.IndexVisibilityInvisible,
+		Tp:        model.IndexTypeHNSW,
+		VectorInfo: &model.VectorIndexInfo{
+			Dimension:      uint64(allTableColumns[idxColumns[0].Offset].GetFlen()),
+			DistanceMetric: indexOption.DistanceMetric,
+		},
+	}, nil
+}
+
 // AddIndexColumnFlag aligns the column flags of columns in TableInfo to IndexInfo.
 func AddIndexColumnFlag(tblInfo *model.TableInfo, indexInfo *model.IndexInfo) {
 	if indexInfo.Primary {
@@ -0,0 +1,208 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	driver "github.com/pingcap/tidb/pkg/types/parser_driver"
+	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+func onAlterIntervalPolicy(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	var precreate, retention *uint64
+	if err := job.DecodeArgs(&precreate, &retention); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo.IntervalPolicy = mergeIntervalPolicy(tblInfo.IntervalPolicy, precreate, retention)
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+// getIntervalPolicyInOptions returns the INTERVAL_PRECREATE and INTERVAL_RETENTION
+// values set in the options, nil if the option is not set.
+func getIntervalPolicyInOptions(options []*ast.TableOption) (precreate, retention *uint64) {
+	for _, op := range options {
+		val := op.UintValue
+		switch op.Tp {
+		case ast.TableOptionIntervalPrecreate:
+			precreate = &val
+		case ast.TableOptionIntervalRetention:
+			retention = &val
+		}
+	}
+	return precreate, retention
+}
+
+// mergeIntervalPolicy applies the changed values to the policy, returns nil if nothing is
+// left to be maintained.
+func mergeIntervalPolicy(policy *model.IntervalPartitionPolicy, precreate, retention *uint64) *model.IntervalPartitionPolicy {
+	if policy == nil {
+		policy = &model.IntervalPartitionPolicy{}
+	} else {
+		policy = policy.Clone()
+	}
+	if precreate != nil {
+		policy.PrecreateCount = *precreate
+	}
+	if retention != nil {
+		policy.RetentionCount = *retention
+	}
+	if policy.IsEmpty() {
+		return nil
+	}
+	return policy
+}
+
+// checkIntervalPolicyValid checks that the partitions of the table can be maintained by the policy,
+// i.e. it is RANGE COLUMNS partitioned by INTERVAL on a date or datetime column.
+func checkIntervalPolicyValid(ctx sessionctx.Context, tblInfo *model.TableInfo) error {
+	policy := tblInfo.IntervalPolicy
+	if policy == nil || policy.IsEmpty() {
+		return nil
+	}
+	interval := getPartitionIntervalFromTable(ctx.GetExprCtx(), tblInfo)
+	if interval == nil || len(tblInfo.Partition.Columns) != 1 || interval.IntervalExpr.TimeUnit == ast.TimeUnitInvalid {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
+			"INTERVAL_PRECREATE or INTERVAL_RETENTION on a table not INTERVAL partitioned on a date or datetime column")
+	}
+	if policy.PrecreateCount > 0 && interval.MaxValPart {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("INTERVAL_PRECREATE when MAXVALUE partition exists")
+	}
+	if policy.PrecreateCount >= mysql.PartitionCountLimit || policy.RetentionCount >= mysql.PartitionCountLimit {
+		return errors.Trace(dbterror.ErrTooManyPartitions)
+	}
+	return nil
+}
+
+// GetIntervalPartitionMaintenance returns the range ends to use for ALTER TABLE ... LAST PARTITION LESS THAN
+// and ALTER TABLE ... FIRST PARTITION LESS THAN, so that the partitions of the table satisfy its interval
+// partition policy at the time `now`. An empty string means the corresponding statement is not needed.
+func GetIntervalPartitionMaintenance(ctx expression.BuildContext, tblInfo *model.TableInfo, now types.Time) (lastRangeEnd, firstRangeEnd string, err error) {
+	policy := tblInfo.IntervalPolicy
+	if policy == nil || policy.IsEmpty() {
+		return "", "", nil
+	}
+	interval := getPartitionIntervalFromTable(ctx, tblInfo)
+	if interval == nil || len(tblInfo.Partition.Columns) != 1 || interval.IntervalExpr.TimeUnit == ast.TimeUnitInvalid {
+		return "", "", dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("interval partition policy on a table not INTERVAL partitioned")
+	}
+	partCol := findColumnByName(tblInfo.Partition.Columns[0].L, tblInfo)
+	tc := ctx.GetSessionVars().StmtCtx.TypeCtx()
+	// addIntervals evaluates start + n * INTERVAL as a value of the partitioning column.
+	addIntervals := func(start string, n int64) (types.Datum, error) {
+		expr := &ast.FuncCallExpr{
+			FnName: model.NewCIStr("DATE_ADD"),
+			Args: []ast.ExprNode{
+				ast.NewValueExpr(start, "", ""),
+				&ast.BinaryOperationExpr{
+					Op: opcode.Mul,
+					L:  ast.NewValueExpr(n, "", ""),
+					R:  interval.IntervalExpr.Expr,
+				},
+				&ast.TimeUnitExpr{Unit: interval.IntervalExpr.TimeUnit},
+			},
+		}
+		val, err := expression.EvalSimpleAst(ctx, expr)
+		if err != nil {
+			return val, err
+		}
+		return val.ConvertTo(tc, &partCol.FieldType)
+	}
+	compare := func(a, b types.Datum) (int, error) {
+		return a.Compare(tc, &b, collate.GetBinaryCollator())
+	}
+	nowStr := now.String()
+	defs := tblInfo.Partition.Definitions
+	startIdx, endIdx := 0, len(defs)-1
+	if interval.NullPart {
+		startIdx++
+	}
+	if interval.MaxValPart {
+		endIdx--
+	}
+
+	if policy.PrecreateCount > 0 {
+		target, err := addIntervals(nowStr, int64(policy.PrecreateCount))
+		if err != nil {
+			return "", "", errors.Trace(err)
+		}
+		last := driver.UnwrapFromSingleQuotes(defs[endIdx].LessThan[0])
+		for i := int64(0); i < mysql.PartitionCountLimit; i++ {
+			val, err := addIntervals(last, i)
+			if err != nil {
+				return "", "", errors.Trace(err)
+			}
+			cmp, err := compare(val, target)
+			if err != nil {
+				return "", "", errors.Trace(err)
+			}
+			if cmp >= 0 {
+				if i > 0 {
+					if lastRangeEnd, err = val.ToString(); err != nil {
+						return "", "", errors.Trace(err)
+					}
+				}
+				break
+			}
+		}
+	}
+
+	if policy.RetentionCount > 0 {
+		cutoff, err := addIntervals(nowStr, -int64(policy.RetentionCount))
+		if err != nil {
+			return "", "", errors.Trace(err)
+		}
+		// All the rows of a partition are older than the cutoff if its range end is not after it.
+		// The last partition is always kept.
+		keepIdx := startIdx
+		for ; keepIdx < endIdx; keepIdx++ {
+			val, err := addIntervals(driver.UnwrapFromSingleQuotes(defs[keepIdx].LessThan[0]), 0)
+			if err != nil {
+				return "", "", errors.Trace(err)
+			}
+			cmp, err := compare(val, cutoff)
+			if err != nil {
+				return "", "", errors.Trace(err)
+			}
+			if cmp > 0 {
+				break
+			}
+		}
+		if keepIdx > startIdx {
+			firstRangeEnd = driver.UnwrapFromSingleQuotes(defs[keepIdx].LessThan[0])
+		}
+	}
+	return lastRangeEnd, firstRangeEnd, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// onRefreshMaterializedView records the snapshot which the refreshed data of the materialized
+// view is selected from. The data is refreshed before the job is submitted.
+func onRefreshMaterializedView(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	var lastRefreshTS uint64
+	if err := job.DecodeArgs(&lastRefreshTS); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if !tblInfo.IsMaterializedView() {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrWrongObject.GenWithStackByArgs(job.SchemaName, tblInfo.Name, "MATERIALIZED VIEW")
+	}
+
+	// a refresh which selects from an older snapshot may finish later than a newer one.
+	if lastRefreshTS > tblInfo.MaterializedView.LastRefreshTS {
+		tblInfo.MaterializedView.LastRefreshTS = lastRefreshTS
+	}
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
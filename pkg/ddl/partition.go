@@ -532,10 +532,6 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 			// Subpartitioning only allowed with Range or List
 			return ast.ErrSubpartition
 		}
-		// Note that linear hash is simply ignored, and creates non-linear hash/key.
-		if s.Linear {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedCreatePartition.FastGen(fmt.Sprintf("LINEAR %s is not supported, using non-linear %s instead", s.Tp.String(), s.Tp.String())))
-		}
 		if s.Tp == model.PartitionTypeHash || len(s.ColumnNames) != 0 {
 			enable = true
 		}
@@ -553,9 +549,10 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 	}
 
 	pi := &model.PartitionInfo{
-		Type:   s.Tp,
-		Enable: enable,
-		Num:    s.Num,
+		Type:     s.Tp,
+		Enable:   enable,
+		Num:      s.Num,
+		IsLinear: s.Linear,
 	}
 	tbInfo.Partition = pi
 	if s.Expr != nil {
@@ -590,6 +587,9 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 			return err
 		}
 	}
+	if pi.Type == model.PartitionTypeKey {
+		setKeyPartitionAlgorithm(ctx, s, tbInfo)
+	}
 
 	exprCtx := ctx.GetExprCtx()
 	err := generatePartitionDefinitionsFromInterval(exprCtx, s, tbInfo)
@@ -633,6 +633,34 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 	return nil
 }
 
+// setKeyPartitionAlgorithm sets the KeyAlgorithm of KEY partitioning. The MySQL hash function is used
+// when it is asked for by ALGORITHM or LINEAR and all the partitioning columns are supported by it,
+// otherwise the TiDB hash function is kept for compatibility with the existing tables.
+func setKeyPartitionAlgorithm(ctx sessionctx.Context, s *ast.PartitionOptions, tbInfo *model.TableInfo) {
+	pi := tbInfo.Partition
+	var algorithm uint64
+	if s.KeyAlgorithm != nil {
+		algorithm = s.KeyAlgorithm.Type
+	} else if s.Linear {
+		algorithm = 2
+	}
+	if algorithm == 0 {
+		return
+	}
+	for _, name := range pi.Columns {
+		col := findColumnByName(name.L, tbInfo)
+		if col == nil {
+			return
+		}
+		if !tables.IsMySQLKeyHashSupported(&col.FieldType) {
+			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedCreatePartition.FastGen(
+				fmt.Sprintf("KEY partitioning with MySQL compatible hash is not supported on column %s, using the TiDB hash function instead", col.Name.O)))
+			return
+		}
+	}
+	pi.KeyAlgorithm = algorithm
+}
+
 func getPartitionColSlices(sctx expression.BuildContext, tblInfo *model.TableInfo, s *ast.PartitionOptions) (partCols stringSlice, err error) {
 	if s.Expr != nil {
 		extractCols := newPartitionExprChecker(sctx, tblInfo)
@@ -1896,6 +1924,20 @@ func hasGlobalIndex(tblInfo *model.TableInfo) bool {
 	return false
 }
 
+// checkGlobalIndexesForReorgPartition returns an error if the table has global indexes,
+// which can't be rebuilt when the partitions are reorganized yet, so REORGANIZE PARTITION,
+// COALESCE PARTITION, ALTER TABLE ... PARTITION BY and REMOVE PARTITIONING are rejected
+// before the job is submitted.
+func checkGlobalIndexesForReorgPartition(tblInfo *model.TableInfo) error {
+	for _, idxInfo := range tblInfo.Indices {
+		if idxInfo.Global {
+			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
+				fmt.Sprintf("reorganize partition with global index '%s'", idxInfo.Name.O))
+		}
+	}
+	return nil
+}
+
 // getTableInfoWithDroppingPartitions builds oldTableInfo including dropping partitions, only used by onDropTablePartition.
 func getTableInfoWithDroppingPartitions(t *model.TableInfo) *model.TableInfo {
 	p := t.Partition
@@ -1992,6 +2034,8 @@ func (w *worker) onDropTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (
 				tblInfo.Partition.NewTableID = 0
 				tblInfo.Partition.DDLExpr = ""
 				tblInfo.Partition.DDLColumns = nil
+				tblInfo.Partition.DDLIsLinear = false
+				tblInfo.Partition.DDLKeyAlgorithm = 0
 				tblInfo.Partition.DDLType = model.PartitionTypeNone
 			}
 		}
@@ -2759,6 +2803,8 @@ func getReorgPartitionInfo(t *meta.Meta, job *model.Job) (*model.TableInfo, []st
 		tblInfo.Partition.DDLType = partInfo.Type
 		tblInfo.Partition.DDLExpr = partInfo.Expr
 		tblInfo.Partition.DDLColumns = partInfo.Columns
+		tblInfo.Partition.DDLIsLinear = partInfo.IsLinear
+		tblInfo.Partition.DDLKeyAlgorithm = partInfo.KeyAlgorithm
 	} else {
 		tblInfo.Partition = getPartitionInfoTypeNone()
 		tblInfo.Partition.NewTableID = partInfo.NewTableID
@@ -2766,6 +2812,8 @@ func getReorgPartitionInfo(t *meta.Meta, job *model.Job) (*model.TableInfo, []st
 		tblInfo.Partition.DDLType = partInfo.Type
 		tblInfo.Partition.DDLExpr = partInfo.Expr
 		tblInfo.Partition.DDLColumns = partInfo.Columns
+		tblInfo.Partition.DDLIsLinear = partInfo.IsLinear
+		tblInfo.Partition.DDLKeyAlgorithm = partInfo.KeyAlgorithm
 	}
 	if len(addingDefs) == 0 {
 		addingDefs = []model.PartitionDefinition{}
@@ -2999,6 +3047,8 @@ func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job)
 			tblInfo.Partition.Type, tblInfo.Partition.DDLType = tblInfo.Partition.DDLType, tblInfo.Partition.Type
 			tblInfo.Partition.Expr, tblInfo.Partition.DDLExpr = tblInfo.Partition.DDLExpr, tblInfo.Partition.Expr
 			tblInfo.Partition.Columns, tblInfo.Partition.DDLColumns = tblInfo.Partition.DDLColumns, tblInfo.Partition.Columns
+			tblInfo.Partition.IsLinear, tblInfo.Partition.DDLIsLinear = tblInfo.Partition.DDLIsLinear, tblInfo.Partition.IsLinear
+			tblInfo.Partition.KeyAlgorithm, tblInfo.Partition.DDLKeyAlgorithm = tblInfo.Partition.DDLKeyAlgorithm, tblInfo.Partition.KeyAlgorithm
 		}
 
 		// Now all the data copying is done, but we cannot simply remove the droppingDefinitions
@@ -3063,6 +3113,8 @@ func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job)
 				tblInfo.Partition.DDLType = model.PartitionTypeNone
 				tblInfo.Partition.DDLExpr = ""
 				tblInfo.Partition.DDLColumns = nil
+				tblInfo.Partition.DDLIsLinear = false
+				tblInfo.Partition.DDLKeyAlgorithm = 0
 				tblInfo.Partition.NewTableID = 0
 			}
 			err = t.GetAutoIDAccessors(job.SchemaID, tblInfo.ID).Put(autoIDs)
@@ -3573,6 +3625,10 @@ func checkExchangePartitionRecordValidation(w *worker, ptbl, ntbl table.Table, p
 	case model.PartitionTypeHash:
 		if pi.Num == 1 {
 			checkNt = false
+		} else if pi.IsLinear {
+			conds, params := buildCheckSQLConditionForLinearHashPartition(pi, index)
+			buf.WriteString(conds)
+			paramList = append(paramList, params...)
 		} else {
 			buf.WriteString("mod(")
 			buf.WriteString(pi.Expr)
@@ -3688,6 +3744,25 @@ func checkExchangePartitionPlacementPolicy(t *meta.Meta, ntPPRef, ptPPRef, partP
 	return nil
 }
 
+// buildCheckSQLConditionForLinearHashPartition builds the condition of the rows not in the partition,
+// the same as tables.HashPartitionIndex for LINEAR HASH.
+func buildCheckSQLConditionForLinearHashPartition(pi *model.PartitionInfo, index int) (string, []any) {
+	mask := uint64(1)
+	for mask < pi.Num {
+		mask <<= 1
+	}
+	mask--
+	expr := "(" + pi.Expr + ")"
+	var buf strings.Builder
+	buf.WriteString("if(" + expr + " & %? >= %?, " + expr + " & %?, " + expr + " & %?) != %?")
+	params := []any{mask, pi.Num, ((mask + 1) >> 1) - 1, mask, index}
+	if index != 0 {
+		// The rows with NULL are in the first partition.
+		buf.WriteString(" or " + expr + " is null")
+	}
+	return buf.String(), params
+}
+
 func buildCheckSQLConditionForRangeExprPartition(pi *model.PartitionInfo, index int) (string, []any) {
 	var buf strings.Builder
 	paramList := make([]any, 0, 2)
@@ -4275,6 +4350,18 @@ func writeColumnListToBuffer(partitionInfo *model.PartitionInfo, sqlMode mysql.S
 	}
 }
 
+// partitionMethodString returns the partitioning type with the LINEAR and ALGORITHM modifiers.
+func partitionMethodString(pi *model.PartitionInfo) string {
+	method := pi.Type.String()
+	if pi.IsLinear {
+		method = "LINEAR " + method
+	}
+	if pi.KeyAlgorithm != 0 {
+		method += fmt.Sprintf(" ALGORITHM = %d", pi.KeyAlgorithm)
+	}
+	return method
+}
+
 // AppendPartitionInfo is used in SHOW CREATE TABLE as well as generation the SQL syntax
 // for the PartitionInfo during validation of various DDL commands
 func AppendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer, sqlMode mysql.SQLMode) {
@@ -4301,9 +4388,9 @@ func AppendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 
 		if defaultPartitionDefinitions {
 			if partitionInfo.Type == model.PartitionTypeHash {
-				fmt.Fprintf(buf, "\nPARTITION BY HASH (%s) PARTITIONS %d", partitionInfo.Expr, partitionInfo.Num)
+				fmt.Fprintf(buf, "\nPARTITION BY %s (%s) PARTITIONS %d", partitionMethodString(partitionInfo), partitionInfo.Expr, partitionInfo.Num)
 			} else {
-				buf.WriteString("\nPARTITION BY KEY (")
+				fmt.Fprintf(buf, "\nPARTITION BY %s (", partitionMethodString(partitionInfo))
 				writeColumnListToBuffer(partitionInfo, sqlMode, buf)
 				buf.WriteString(")")
 				fmt.Fprintf(buf, " PARTITIONS %d", partitionInfo.Num)
@@ -4317,14 +4404,14 @@ func AppendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 		// || partitionInfo.Type == model.PartitionTypeKey
 		// Notice that MySQL uses two spaces between LIST and COLUMNS...
 		if partitionInfo.Type == model.PartitionTypeKey {
-			fmt.Fprintf(buf, "\nPARTITION BY %s (", partitionInfo.Type.String())
+			fmt.Fprintf(buf, "\nPARTITION BY %s (", partitionMethodString(partitionInfo))
 		} else {
 			fmt.Fprintf(buf, "\nPARTITION BY %s COLUMNS(", partitionInfo.Type.String())
 		}
 		writeColumnListToBuffer(partitionInfo, sqlMode, buf)
 		buf.WriteString(")\n(")
 	} else {
-		fmt.Fprintf(buf, "\nPARTITION BY %s (%s)\n(", partitionInfo.Type.String(), partitionInfo.Expr)
+		fmt.Fprintf(buf, "\nPARTITION BY %s (%s)\n(", partitionMethodString(partitionInfo), partitionInfo.Expr)
 	}
 
 	AppendPartitionDefs(partitionInfo, buf, sqlMode)
@@ -233,7 +233,11 @@ func (d *ddl) checkHistoryJobInTest(ctx sessionctx.Context, historyJob *model.Jo
 				panic(fmt.Sprintf("job ID %d, parse ddl job failed, query %s", historyJob.ID, historyJob.Query))
 			}
 		case model.ActionCreateTable:
-			if _, ok := st.(*ast.CreateTableStmt); !ok {
+			// the tables of materialized views are created by CREATE/REFRESH MATERIALIZED VIEW.
+			_, isCreateTable := st.(*ast.CreateTableStmt)
+			_, isCreateMV := st.(*ast.CreateMaterializedViewStmt)
+			_, isRefreshMV := st.(*ast.RefreshMaterializedViewStmt)
+			if !isCreateTable && !isCreateMV && !isRefreshMV {
 				panic(fmt.Sprintf("job ID %d, parse ddl job failed, query %s", historyJob.ID, historyJob.Query))
 			}
 		case model.ActionCreateSchema:
@@ -177,6 +177,35 @@ func onModifySchemaDefaultPlacement(d *ddlCtx, t *meta.Meta, job *model.Job) (ve
 	return ver, nil
 }
 
+func onModifySchemaReadOnly(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var readOnly bool
+	if err := job.DecodeArgs(&readOnly); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	if dbInfo.ReadOnly == readOnly {
+		job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, dbInfo)
+		return ver, nil
+	}
+
+	dbInfo.ReadOnly = readOnly
+
+	if err = t.UpdateDatabase(dbInfo); err != nil {
+		return ver, errors.Trace(err)
+	}
+	if ver, err = updateSchemaVersion(d, t, job); err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, dbInfo)
+	return ver, nil
+}
+
 func onDropSchema(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
 	if err != nil {
@@ -279,6 +308,21 @@ func (w *worker) onRecoverSchema(d *ddlCtx, t *meta.Meta, job *model.Job) (ver i
 		} else {
 			job.Args[checkFlagIndexInJobArgs] = recoverCheckFlagDisableGC
 		}
+		if recoverSchemaInfo.SchemaExists {
+			if _, err = checkSchemaExistAndCancelNotExistJob(t, job); err != nil {
+				return ver, errors.Trace(err)
+			}
+			for _, recoverTabInfo := range recoverSchemaInfo.RecoverTabsInfo {
+				tblInfo := recoverTabInfo.TableInfo
+				if err = checkTableNotExists(d, t, schemaInfo.ID, tblInfo.Name.L); err == nil {
+					err = checkTableIDNotExists(t, schemaInfo.ID, tblInfo.ID)
+				}
+				if err != nil {
+					job.State = model.JobStateCancelled
+					return ver, errors.Trace(err)
+				}
+			}
+		}
 		// Clear all placement when recover
 		for _, recoverTabInfo := range recoverSchemaInfo.RecoverTabsInfo {
 			err = clearTablePlacementAndBundles(recoverTabInfo.TableInfo)
@@ -299,11 +343,13 @@ func (w *worker) onRecoverSchema(d *ddlCtx, t *meta.Meta, job *model.Job) (ver i
 				return ver, errors.Errorf("disable gc failed, try again later. err: %v", err)
 			}
 		}
-		dbInfo := schemaInfo.Clone()
-		dbInfo.State = model.StatePublic
-		err = t.CreateDatabase(dbInfo)
-		if err != nil {
-			return ver, errors.Trace(err)
+		if !recoverSchemaInfo.SchemaExists {
+			dbInfo := schemaInfo.Clone()
+			dbInfo.State = model.StatePublic
+			err = t.CreateDatabase(dbInfo)
+			if err != nil {
+				return ver, errors.Trace(err)
+			}
 		}
 		// check GC safe point
 		err = checkSafePoint(w, recoverSchemaInfo.SnapshotTS)
@@ -413,6 +413,21 @@ func (*Checker) AlterSequence(_ sessionctx.Context, _ *ast.AlterSequenceStmt) er
 	panic("implement me")
 }
 
+// CreateTrigger implements the DDL interface.
+func (d *Checker) CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error {
+	return d.realDDL.CreateTrigger(ctx, stmt)
+}
+
+// DropTrigger implements the DDL interface.
+func (d *Checker) DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error {
+	return d.realDDL.DropTrigger(ctx, stmt)
+}
+
+// RecordMaterializedViewRefresh implements the DDL interface.
+func (d *Checker) RecordMaterializedViewRefresh(ctx sessionctx.Context, ident ast.Ident, tableID int64, lastRefreshTS uint64) error {
+	return d.realDDL.RecordMaterializedViewRefresh(ctx, ident, tableID, lastRefreshTS)
+}
+
 // CreatePlacementPolicy implements the DDL interface.
 func (*Checker) CreatePlacementPolicy(_ sessionctx.Context, _ *ast.CreatePlacementPolicyStmt) error {
 	//TODO implement me
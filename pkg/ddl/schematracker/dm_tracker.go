@@ -130,7 +130,9 @@ func (d SchemaTracker) AlterSchema(ctx sessionctx.Context, stmt *ast.AlterDataba
 
 	// Resolve target charset and collation from options.
 	var (
-		toCharset, toCollate string
+		toCharset, toCollate     string
+		isAlterCharsetAndCollate bool
+		hasReadOnly              bool
 	)
 
 	for _, val := range stmt.Options {
@@ -141,6 +143,7 @@ func (d SchemaTracker) AlterSchema(ctx sessionctx.Context, stmt *ast.AlterDataba
 			} else if toCharset != val.Value {
 				return dbterror.ErrConflictingDeclarations.GenWithStackByArgs(toCharset, val.Value)
 			}
+			isAlterCharsetAndCollate = true
 		case ast.DatabaseOptionCollate:
 			info, errGetCollate := collate.GetCollationByName(val.Value)
 			if errGetCollate != nil {
@@ -152,8 +155,15 @@ func (d SchemaTracker) AlterSchema(ctx sessionctx.Context, stmt *ast.AlterDataba
 				return dbterror.ErrConflictingDeclarations.GenWithStackByArgs(toCharset, info.CharsetName)
 			}
 			toCollate = info.Name
+			isAlterCharsetAndCollate = true
+		case ast.DatabaseSetReadOnly:
+			dbInfo.ReadOnly = val.UintValue == 1
+			hasReadOnly = true
 		}
 	}
+	if hasReadOnly && !isAlterCharsetAndCollate {
+		return nil
+	}
 	if toCollate == "" {
 		if toCollate, err = ddl.GetDefaultCollation(ctx.GetSessionVars(), toCharset); err != nil {
 			return errors.Trace(err)
@@ -390,6 +400,16 @@ func (d SchemaTracker) createIndex(
 	ifNotExists bool,
 ) (err error) {
 	unique := keyType == ast.IndexKeyTypeUnique
+	if keyType == ast.IndexKeyTypeFullText {
+		if indexOption, err = ddl.FullTextIndexOption(indexOption); err != nil {
+			return err
+		}
+	}
+	if keyType == ast.IndexKeyTypeVector {
+		if indexOption, indexPartSpecifications, err = ddl.VectorIndexOption(indexOption, indexPartSpecifications); err != nil {
+			return err
+		}
+	}
 	tblInfo, err := d.TableClonedByName(ti.Schema, ti.Name)
 	if err != nil {
 		return err
@@ -948,8 +968,13 @@ func (d SchemaTracker) AlterTable(ctx context.Context, sctx sessionctx.Context,
 					spec.Constraint.Keys, constr.Option, false) // IfNotExists should be not applied
 			case ast.ConstraintPrimaryKey:
 				err = d.createPrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
+			case ast.ConstraintFulltext:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeFullText, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
+			case ast.ConstraintVector:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeVector, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
 			case ast.ConstraintForeignKey,
-				ast.ConstraintFulltext,
 				ast.ConstraintCheck:
 			default:
 				// Nothing to do now.
@@ -1033,6 +1058,8 @@ func (d SchemaTracker) AlterTable(ctx context.Context, sctx sessionctx.Context,
 			ast.AlterTableDropForeignKey,
 			ast.AlterTableCoalescePartitions,
 			ast.AlterTableReorganizePartition,
+			ast.AlterTableSplitPartition,
+			ast.AlterTableMergePartitions,
 			ast.AlterTableCheckPartitions,
 			ast.AlterTableRebuildPartition,
 			ast.AlterTableOptimizePartition,
@@ -1156,6 +1183,21 @@ func (SchemaTracker) AlterSequence(_ sessionctx.Context, _ *ast.AlterSequenceStm
 	return nil
 }
 
+// CreateTrigger implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) CreateTrigger(_ sessionctx.Context, _ *ast.CreateTriggerStmt) error {
+	return nil
+}
+
+// DropTrigger implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) DropTrigger(_ sessionctx.Context, _ *ast.DropTriggerStmt) error {
+	return nil
+}
+
+// RecordMaterializedViewRefresh implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) RecordMaterializedViewRefresh(_ sessionctx.Context, _ ast.Ident, _ int64, _ uint64) error {
+	return nil
+}
+
 // CreatePlacementPolicy implements the DDL interface, it's no-op in DM's case.
 func (SchemaTracker) CreatePlacementPolicy(_ sessionctx.Context, _ *ast.CreatePlacementPolicyStmt) error {
 	return nil
@@ -165,6 +165,30 @@ func TestFullTextIndex(t *testing.T) {
 	tracker := schematracker.NewSchemaTracker(2)
 	tracker.CreateTestDB(nil)
 	execCreate(t, tracker, sql)
+
+	sql = "alter table test.t add fulltext key ft_a (a) with parser ngram"
+	execAlter(t, tracker, sql)
+	tblInfo := mustTableByName(t, tracker, "test", "t")
+	require.Len(t, tblInfo.Indices, 2)
+	require.Equal(t, model.FullTextParserTypeWhitespace, tblInfo.Indices[0].FullTextInfo.ParserType)
+	require.Equal(t, model.FullTextParserTypeNgram, tblInfo.Indices[1].FullTextInfo.ParserType)
+}
+
+func TestVectorIndex(t *testing.T) {
+	sql := "create table test.t (a int, v vector(3), vector index ((vec_cosine_distance(v))))"
+
+	tracker := schematracker.NewSchemaTracker(2)
+	tracker.CreateTestDB(nil)
+	execCreate(t, tracker, sql)
+
+	sql = "alter table test.t add vector index idx_l2 ((vec_l2_distance(v))) using hnsw"
+	execAlter(t, tracker, sql)
+	tblInfo := mustTableByName(t, tracker, "test", "t")
+	require.Len(t, tblInfo.Indices, 2)
+	require.Len(t, tblInfo.Columns, 2)
+	require.Equal(t, model.VectorIndexInfo{Dimension: 3, DistanceMetric: model.DistanceMetricCosine}, *tblInfo.Indices[0].VectorInfo)
+	require.Equal(t, model.VectorIndexInfo{Dimension: 3, DistanceMetric: model.DistanceMetricL2}, *tblInfo.Indices[1].VectorInfo)
+	require.Equal(t, model.IndexTypeHNSW, tblInfo.Indices[1].Tp)
 }
 
 func checkShowCreateTable(t *testing.T, tblInfo *model.TableInfo, expected string) {
@@ -1140,6 +1140,11 @@ func checkAndRenameTables(t *meta.Meta, job *model.Job, tblInfo *model.TableInfo
 	}
 
 	tblInfo.Name = *tableName
+	// The staging table of CREATE TABLE ... SELECT is published by renaming it to the target name.
+	tblInfo.Staging = nil
+	// The consumers keyed by the table info, such as the stats cache and the TTL cache, find the table is changed
+	// by UpdateTS, so it's updated even if only the schema of the table is changed.
+	tblInfo.UpdateTS = t.StartTS
 	err = t.CreateTableOrView(newSchemaID, job.SchemaName, tblInfo)
 	if err != nil {
 		job.State = model.JobStateCancelled
@@ -386,13 +386,13 @@ func TestCreateTableWithHashPartition(t *testing.T) {
 ) PARTITION BY HASH(store_id) PARTITIONS 102400000000;`, errno.ErrTooManyPartitions)
 
 	tk.MustExec("CREATE TABLE t_linear (a int, b varchar(128)) PARTITION BY LINEAR HASH(a) PARTITIONS 4")
-	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 8200 LINEAR HASH is not supported, using non-linear HASH instead"))
+	tk.MustQuery(`show warnings`).Check(testkit.Rows())
 	tk.MustQuery(`show create table t_linear`).Check(testkit.Rows("" +
 		"t_linear CREATE TABLE `t_linear` (\n" +
 		"  `a` int(11) DEFAULT NULL,\n" +
 		"  `b` varchar(128) DEFAULT NULL\n" +
 		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
-		"PARTITION BY HASH (`a`) PARTITIONS 4"))
+		"PARTITION BY LINEAR HASH (`a`) PARTITIONS 4"))
 	tk.MustQuery("select * from t_linear partition (p0)").Check(testkit.Rows())
 
 	tk.MustExec(`CREATE TABLE t_sub (a int, b varchar(128)) PARTITION BY RANGE( a ) SUBPARTITION BY HASH( a )
@@ -1728,6 +1728,234 @@ partition p2 values less than (10))`)
 	require.Equal(t, len(rs), 3)
 }
 
+func TestGlobalIndexPointGetWithPartitionSelection(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set tidb_enable_global_index=true")
+	defer func() {
+		tk.MustExec("set tidb_enable_global_index=default")
+	}()
+	tk.MustExec("drop table if exists t")
+	tk.MustExec(`create table t (a int, b int, c int, unique key idx_b(b)) partition by range (a) (
+partition p0 values less than (10),
+partition p1 values less than (20))`)
+	tk.MustExec("insert into t values (1, 1, 1), (11, 11, 11)")
+	tk.MustGetErrCode("insert into t values (2, 11, 2)", errno.ErrDupEntry)
+
+	tk.MustQuery("select * from t where b = 11").Check(testkit.Rows("11 11 11"))
+	tk.MustQuery("select * from t partition(p1) where b = 11").Check(testkit.Rows("11 11 11"))
+	tk.MustQuery("select * from t partition(p0) where b = 11").Check(testkit.Rows())
+	tk.MustQuery("select * from t partition(p0) where b = 1").Check(testkit.Rows("1 1 1"))
+	tk.MustQuery("select * from t where b = 1 and a = 1").Check(testkit.Rows("1 1 1"))
+	tk.MustQuery("select * from t where b = 1 and a = 11").Check(testkit.Rows())
+	tk.MustQuery("select * from t where b in (1, 11) order by a").Check(testkit.Rows("1 1 1", "11 11 11"))
+	tk.MustQuery("select * from t partition(p0) where b in (1, 11)").Check(testkit.Rows("1 1 1"))
+	tk.MustQuery("select * from t partition(p1) where b in (1, 11)").Check(testkit.Rows("11 11 11"))
+	tk.MustExec("admin check table t")
+}
+
+func TestGlobalIndexReorganizePartitionNotSupported(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set tidb_enable_global_index=true")
+	defer func() {
+		tk.MustExec("set tidb_enable_global_index=default")
+	}()
+	tk.MustExec("drop table if exists t, nt")
+	tk.MustExec("create table t (a int, b int, c int, unique key idx_b(b)) partition by hash(a) partitions 2")
+	tk.MustContainErrMsg("alter table t reorganize partition p0, p1 into (partition q0, partition q1)",
+		"Unsupported reorganize partition with global index 'idx_b'")
+	tk.MustContainErrMsg("alter table t coalesce partition 1",
+		"Unsupported reorganize partition with global index 'idx_b'")
+	tk.MustContainErrMsg("alter table t add partition partitions 1",
+		"Unsupported reorganize partition with global index 'idx_b'")
+	tk.MustContainErrMsg("alter table t remove partitioning",
+		"Unsupported reorganize partition with global index 'idx_b'")
+	tk.MustContainErrMsg("alter table t partition by hash(a) partitions 3",
+		"Unsupported reorganize partition with global index 'idx_b'")
+
+	// A unique key that doesn't cover the new partitioning columns would need a global index.
+	tk.MustExec("create table nt (a int, b int, c int, unique key idx_b(b), key idx_c(c))")
+	tk.MustExec("insert into nt values (1, 1, 1), (11, 11, 11)")
+	tk.MustContainErrMsg("alter table nt partition by hash(a) partitions 2",
+		"Unsupported reorganize partition with global index 'idx_b'")
+	tk.MustExec("set tidb_enable_global_index=default")
+	tk.MustGetErrCode("alter table nt partition by hash(a) partitions 2", errno.ErrUniqueKeyNeedAllFieldsInPf)
+	tk.MustExec("alter table nt partition by hash(b) partitions 2")
+	tk.MustGetErrCode("insert into nt values (2, 11, 2)", errno.ErrDupEntry)
+	tk.MustExec("admin check table nt")
+}
+
+func TestExchangePartitionWithGlobalIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set tidb_enable_global_index=true")
+	defer func() {
+		tk.MustExec("set tidb_enable_global_index=default")
+	}()
+	tk.MustExec("drop table if exists t, nt1, nt2")
+	tk.MustExec("create table t (a int, b int, c int, unique key idx_b(b)) partition by hash(a) partitions 2")
+	tk.MustExec("create table nt1 (a int, b int, c int, unique key idx_b(b))")
+	tk.MustExec("create table nt2 (a int, b int, c int)")
+	tk.MustContainErrMsg("alter table t exchange partition p0 with table nt1", "global index: idx_b")
+	tk.MustContainErrMsg("alter table t exchange partition p0 with table nt2", "global index: idx_b")
+}
+
+func TestSplitAndMergeRangePartitions(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, tc, th")
+	tk.MustExec(`create table t (a int, b int, key idx_b(b)) partition by range (a) (
+partition p0 values less than (10),
+partition p1 values less than (100),
+partition p2 values less than (200))`)
+	tk.MustExec("insert into t values (1, 1), (15, 15), (50, 50), (99, 99), (150, 150)")
+
+	tk.MustExec("alter table t split partition p1 into (partition p1a values less than (20), partition p1b values less than (50), partition p1c)")
+	tk.MustQuery("select partition_name, partition_description from information_schema.partitions where table_schema = 'test' and table_name = 't' order by partition_ordinal_position").Check(testkit.Rows(
+		"p0 10", "p1a 20", "p1b 50", "p1c 100", "p2 200"))
+	tk.MustQuery("select * from t partition(p1a)").Check(testkit.Rows("15 15"))
+	tk.MustQuery("select * from t partition(p1c) order by a").Check(testkit.Rows("50 50", "99 99"))
+	tk.MustExec("admin check table t")
+
+	tk.MustExec("alter table t merge partitions p1a, p1b, p1c into partition p1")
+	tk.MustQuery("select partition_name, partition_description from information_schema.partitions where table_schema = 'test' and table_name = 't' order by partition_ordinal_position").Check(testkit.Rows(
+		"p0 10", "p1 100", "p2 200"))
+	tk.MustQuery("select * from t partition(p1) order by a").Check(testkit.Rows("15 15", "50 50", "99 99"))
+	tk.MustExec("admin check table t")
+
+	// The upper bound of the split partition can't be changed, except for the last one.
+	tk.MustGetErrCode("alter table t split partition p1 into (partition p1a values less than (20), partition p1b values less than (90))", errno.ErrRangeNotIncreasing)
+	tk.MustExec("alter table t split partition p2 into (partition p2a values less than (180), partition p2b values less than maxvalue)")
+	tk.MustQuery("select partition_name, partition_description from information_schema.partitions where table_schema = 'test' and table_name = 't' order by partition_ordinal_position").Check(testkit.Rows(
+		"p0 10", "p1 100", "p2a 180", "p2b MAXVALUE"))
+	tk.MustContainErrMsg("alter table t split partition p1 into (partition p1a)", "SPLIT PARTITION into less than two partitions")
+	tk.MustContainErrMsg("alter table t merge partitions p1 into partition p", "MERGE PARTITIONS of less than two partitions")
+	tk.MustContainErrMsg("alter table t merge partitions p0, p2a into partition p", "not adjacent partitions")
+	tk.MustGetErrCode("alter table t merge partitions p0, p3 into partition p", errno.ErrWrongPartitionName)
+	tk.MustGetErrCode("alter table t merge partitions p0, p1 into partition p2a", errno.ErrSameNamePartition)
+
+	tk.MustExec(`create table tc (a varchar(10), b datetime) partition by range columns (a, b) (
+partition p0 values less than ('m', '2024-01-01 00:00:00'),
+partition p1 values less than ('z', '2024-01-01 00:00:00'))`)
+	tk.MustExec("insert into tc values ('a', '2023-01-01'), ('n', '2023-01-01'), ('y', '2023-01-01')")
+	tk.MustExec("alter table tc split partition p1 into (partition p1a values less than ('p', '2024-01-01 00:00:00'), partition p1b)")
+	tk.MustQuery("select partition_name, partition_description from information_schema.partitions where table_schema = 'test' and table_name = 'tc' order by partition_ordinal_position").Check(testkit.Rows(
+		"p0 'm','2024-01-01 00:00:00'", "p1a 'p','2024-01-01 00:00:00'", "p1b 'z','2024-01-01 00:00:00'"))
+	tk.MustExec("alter table tc merge partitions p0, p1a into partition p0")
+	tk.MustQuery("select a from tc partition(p0) order by a").Check(testkit.Rows("a", "n"))
+	tk.MustExec("admin check table tc")
+
+	tk.MustExec("create table th (a int) partition by hash(a) partitions 2")
+	tk.MustGetErrCode("alter table th merge partitions p0, p1 into partition p", errno.ErrUnsupportedDDLOperation)
+}
+
+func TestIntervalPartitionPolicy(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create table t (id int, d datetime) interval_precreate = 3 interval_retention = 2
+partition by range columns (d) interval (1 day) first partition less than ('2024-01-02') last partition less than ('2024-01-12')`)
+	tk.MustQuery("show create table t").CheckContain("/*T![interval_partition] INTERVAL_PRECREATE=3 INTERVAL_RETENTION=2 */")
+	tk.MustQuery("select count(*) from information_schema.partitions where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("11"))
+
+	dom.MaintainIntervalPartitions(time.Date(2024, 1, 10, 0, 0, 0, 0, time.Local))
+	tk.MustQuery("select partition_description from information_schema.partitions where table_schema = 'test' and table_name = 't' order by partition_ordinal_position").Check(testkit.Rows(
+		"'2024-01-09 00:00:00'", "'2024-01-10 00:00:00'", "'2024-01-11 00:00:00'", "'2024-01-12 00:00:00'", "'2024-01-13 00:00:00'"))
+	// Nothing to do when the policy is already satisfied.
+	dom.MaintainIntervalPartitions(time.Date(2024, 1, 10, 0, 0, 0, 0, time.Local))
+	tk.MustQuery("select count(*) from information_schema.partitions where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("5"))
+
+	tk.MustExec("alter table t interval_precreate = 0")
+	tk.MustQuery("show create table t").CheckContain("INTERVAL_PRECREATE=0 INTERVAL_RETENTION=2")
+	dom.MaintainIntervalPartitions(time.Date(2024, 1, 11, 12, 0, 0, 0, time.Local))
+	tk.MustQuery("select partition_description from information_schema.partitions where table_schema = 'test' and table_name = 't' order by partition_ordinal_position").Check(testkit.Rows(
+		"'2024-01-10 00:00:00'", "'2024-01-11 00:00:00'", "'2024-01-12 00:00:00'", "'2024-01-13 00:00:00'"))
+	tk.MustExec("alter table t interval_retention = 0")
+	tk.MustQuery("show create table t").CheckNotContain("INTERVAL_PRECREATE")
+
+	tk.MustGetErrCode("create table t1 (id int) interval_precreate = 1 partition by range (id) (partition p0 values less than (10))", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t1 (id int, d date) interval_retention = 1 partition by hash (id) partitions 2", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode(`create table t1 (id int, d date) interval_precreate = 1
+partition by range columns (d) interval (1 month) first partition less than ('2024-01-01') last partition less than ('2024-06-01') maxvalue partition`, errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t interval_precreate = 8192", errno.ErrTooManyPartitions)
+	tk.MustExec("create table t2 (id int) partition by range (id) interval (10) first partition less than (10) last partition less than (100)")
+	tk.MustGetErrCode("alter table t2 interval_retention = 1", errno.ErrUnsupportedDDLOperation)
+}
+
+func TestLinearHashAndKeyPartition(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	// The LINEAR HASH example of the MySQL reference manual.
+	tk.MustExec("create table th (id int, d date) partition by linear hash (year(d)) partitions 6")
+	tk.MustExec("insert into th values (1, '2003-04-14'), (2, '1998-10-19'), (3, null)")
+	tk.MustQuery("select id from th partition (p3)").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from th partition (p2)").Check(testkit.Rows("2"))
+	tk.MustQuery("select id from th partition (p0)").Check(testkit.Rows("3"))
+	tk.MustQuery("select partition_method from information_schema.partitions where table_schema = 'test' and table_name = 'th' and partition_name = 'p0'").Check(testkit.Rows("LINEAR HASH"))
+
+	tk.MustExec("create table th2 (a int primary key) partition by linear hash (a) partitions 6")
+	tk.MustExec("insert into th2 values (1), (2), (3), (4), (5), (6), (7), (8), (9), (10), (11), (12), (13), (14)")
+	tk.MustQuery("select a from th2 partition (p2) order by a").Check(testkit.Rows("2", "6", "10", "14"))
+	tk.MustQuery("select a from th2 where a = 14").Check(testkit.Rows("14"))
+	tk.MustQuery("select a from th2 where a in (6, 7, 14) order by a").Check(testkit.Rows("6", "7", "14"))
+	tk.MustQuery("select a from th2 where a between 5 and 7 order by a").Check(testkit.Rows("5", "6", "7"))
+	tk.MustExec("alter table th2 add partition partitions 2")
+	tk.MustQuery("select a from th2 partition (p2) order by a").Check(testkit.Rows("2", "10"))
+	tk.MustExec("alter table th2 coalesce partition 3")
+	tk.MustQuery("select a from th2 partition (p2) order by a").Check(testkit.Rows("2", "6", "10", "14"))
+	tk.MustExec("admin check table th2")
+	tk.MustExec("create table nt (a int primary key)")
+	tk.MustExec("insert into nt values (6)")
+	tk.MustExec("alter table th2 exchange partition p2 with table nt")
+	tk.MustQuery("select a from nt order by a").Check(testkit.Rows("2", "6", "10", "14"))
+	tk.MustExec("insert into nt values (7)")
+	tk.MustGetErrCode("alter table th2 exchange partition p2 with table nt", errno.ErrRowDoesNotMatchPartition)
+
+	// The MySQL KEY hash is used when ALGORITHM or LINEAR is specified.
+	tk.MustExec("create table tk (a int) partition by key algorithm = 2 (a) partitions 4")
+	tk.MustQuery("show create table tk").CheckContain("PARTITION BY KEY ALGORITHM = 2 (`a`) PARTITIONS 4")
+	tk.MustExec("insert into tk values (1), (2), (3), (4), (5), (6), (7), (8), (null)")
+	tk.MustQuery("select a from tk partition (p0) order by a").Check(testkit.Rows("1", "5"))
+	tk.MustQuery("select a from tk partition (p1) order by a").Check(testkit.Rows("4", "8"))
+	tk.MustQuery("select a from tk partition (p2) order by a").Check(testkit.Rows("<nil>", "3", "7"))
+	tk.MustQuery("select a from tk partition (p3) order by a").Check(testkit.Rows("2", "6"))
+	tk.MustQuery("select a from tk where a = 6").Check(testkit.Rows("6"))
+	tk.MustQuery("select a from tk where a between 2 and 3 order by a").Check(testkit.Rows("2", "3"))
+
+	tk.MustExec("create table tlk (a int, b varchar(10)) partition by linear key (a) partitions 5")
+	tk.MustQuery("show create table tlk").CheckContain("PARTITION BY LINEAR KEY ALGORITHM = 2 (`a`) PARTITIONS 5")
+	tk.MustQuery("select partition_method from information_schema.partitions where table_schema = 'test' and table_name = 'tlk' and partition_name = 'p0'").Check(testkit.Rows("LINEAR KEY"))
+	tk.MustExec("insert into tlk (a) values (1), (2), (3), (4), (5), (6), (7), (8)")
+	tk.MustQuery("select a from tlk partition (p1) order by a").Check(testkit.Rows("4", "8"))
+	tk.MustQuery("select a from tlk partition (p3) order by a").Check(testkit.Rows("2", "6"))
+	tk.MustQuery("select a from tlk partition (p4) order by a").Check(testkit.Rows("1"))
+	tk.MustQuery("select a from tlk where a = 8").Check(testkit.Rows("8"))
+
+	tk.MustExec("create table tmk (a int, b varchar(10)) partition by key algorithm = 2 (a, b) partitions 3")
+	tk.MustExec("insert into tmk values (1, 'a'), (2, 'b'), (3, 'c')")
+	tk.MustQuery("select a from tmk partition (p2) order by a").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select a from tmk where a = 2 and b = 'b'").Check(testkit.Rows("2"))
+
+	tk.MustExec("create table tks (b varchar(10)) partition by key algorithm = 2 (b) partitions 4")
+	tk.MustExec("insert into tks values ('a'), ('b'), ('abc'), ('abc  ')")
+	tk.MustQuery("select concat(b, '|') from tks partition (p2) order by b").Check(testkit.Rows("abc|", "abc  |"))
+	tk.MustQuery("select b from tks where b = 'b'").Check(testkit.Rows("b"))
+
+	// Without ALGORITHM and LINEAR, or for the column types not supported, the TiDB hash function is kept.
+	tk.MustExec("create table tk2 (a int) partition by key (a) partitions 4")
+	tk.MustQuery("show create table tk2").CheckContain("PARTITION BY KEY (`a`) PARTITIONS 4")
+	tk.MustExec("create table tkd (a decimal(10, 2)) partition by key algorithm = 2 (a) partitions 3")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 8200 KEY partitioning with MySQL compatible hash is not supported on column a, using the TiDB hash function instead"))
+	tk.MustQuery("show create table tkd").CheckContain("PARTITION BY KEY (`a`) PARTITIONS 3")
+}
+
 func TestAlterTableExchangePartition(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -893,9 +893,6 @@ func TestAutoRandom(t *testing.T) {
 	assertOnlyChangeFromAutoIncPK := func(sql string) {
 		assertInvalidAutoRandomErr(sql, autoid.AutoRandomAlterChangeFromAutoInc)
 	}
-	assertDecreaseBitErr := func(sql string) {
-		assertInvalidAutoRandomErr(sql, autoid.AutoRandomDecreaseBitErrMsg)
-	}
 	assertWithAutoInc := func(sql string) {
 		assertInvalidAutoRandomErr(sql, autoid.AutoRandomIncompatibleWithAutoIncErrMsg)
 	}
@@ -1037,15 +1034,19 @@ func TestAutoRandom(t *testing.T) {
 		assertAddColumn("alter table t add column c bigint auto_random primary key", "c")
 	})
 
-	// Decrease auto_random bits is not allowed.
-	mustExecAndDrop("create table t (a bigint auto_random(10) primary key)", func() {
-		assertDecreaseBitErr("alter table t modify column a bigint auto_random(6)")
-	})
+	// Decrease auto_random bits.
 	mustExecAndDrop("create table t (a bigint auto_random(10) primary key)", func() {
-		assertDecreaseBitErr("alter table t modify column a bigint auto_random(1)")
+		tk.MustExec("alter table t modify column a bigint auto_random(6)")
+		tk.MustExec("alter table t modify column a bigint auto_random(1)")
+		assertAlterValue("alter table t modify column a bigint")
 	})
 	mustExecAndDrop("create table t (a bigint auto_random(10), b int, primary key (a, b) clustered)", func() {
-		assertDecreaseBitErr("alter table t modify column a bigint auto_random(6)")
+		tk.MustExec("alter table t modify column a bigint auto_random(6)")
+	})
+	mustExecAndDrop("create table t (a bigint auto_random(10, 48) primary key)", func() {
+		tk.MustExec("alter table t modify column a bigint auto_random(6, 48)")
+		tk.MustGetErrMsg("alter table t modify column a bigint auto_random(5, 56)",
+			dbterror.ErrInvalidAutoRandom.GenWithStackByArgs(autoid.AutoRandomUnsupportedAlterRangeBits).Error())
 	})
 
 	originStep := autoid.GetStep()
@@ -1106,6 +1107,25 @@ func TestAutoRandom(t *testing.T) {
 	})
 }
 
+func TestAutoRandomDecreaseShardBits(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@allow_auto_random_explicit_insert = true")
+	tk.MustExec("create table t (a bigint auto_random(10) primary key, b int)")
+	// The ID with all the shard bits set: shard = 1023, incremental part = 1.
+	tk.MustExec("insert into t values ((1023 << 53) | 1, 1)")
+	tk.MustExec("insert into t (b) values (2)")
+	tk.MustExec("alter table t modify column a bigint auto_random(5)")
+	require.Equal(t, uint64(5), external.GetTableByName(t, tk, "test", "t").Meta().AutoRandomBits)
+	tk.MustExec("insert into t (b) values (3), (4)")
+	tk.MustQuery("select count(distinct a) from t").Check(testkit.Rows("4"))
+	// The new incremental part must skip the old shard bits which are moved into it.
+	tk.MustQuery("select b, (a & ((1 << 58) - 1)) > ((31 << 53) | 1) from t where b > 2 order by b").
+		Check(testkit.Rows("3 1", "4 1"))
+	tk.MustQuery("select b, a >> 58 < 32 from t where b > 2 order by b").Check(testkit.Rows("3 1", "4 1"))
+}
+
 func TestAutoRandomWithPreSplitRegion(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
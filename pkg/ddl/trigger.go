@@ -0,0 +1,203 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+func onCreateTrigger(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	trigger := &model.TriggerInfo{}
+	if err := job.DecodeArgs(trigger); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if tblInfo.FindTrigger(trigger.Name.L) != nil {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrTrgAlreadyExists
+	}
+
+	tblInfo.Triggers = append(tblInfo.Triggers, trigger)
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func onDropTrigger(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var triggerName model.CIStr
+	if err := job.DecodeArgs(&triggerName); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	triggers := make([]*model.TriggerInfo, 0, len(tblInfo.Triggers))
+	for _, trigger := range tblInfo.Triggers {
+		if trigger.Name.L != triggerName.L {
+			triggers = append(triggers, trigger)
+		}
+	}
+	if len(triggers) == len(tblInfo.Triggers) {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrTrgDoesNotExist
+	}
+
+	tblInfo.Triggers = triggers
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+// findTriggerTable returns the table which the trigger belongs to, the trigger names are unique in a schema.
+func findTriggerTable(is infoschema.InfoSchema, schema, trigger model.CIStr) *model.TableInfo {
+	for _, tbl := range is.SchemaTables(schema) {
+		if tbl.Meta().FindTrigger(trigger.L) != nil {
+			return tbl.Meta()
+		}
+	}
+	return nil
+}
+
+func checkTriggerTable(tblInfo *model.TableInfo, event model.TriggerEvent) error {
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrTrgOnViewOrTempTable.GenWithStackByArgs(tblInfo.Name.O)
+	}
+	if tblInfo.IsMaterializedView() || tblInfo.IsExternal() || tblInfo.IsFederated() {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("CREATE TRIGGER on a table whose rows are not stored in TiDB")
+	}
+	if tblInfo.GetPartitionInfo() != nil {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("CREATE TRIGGER on a partitioned table")
+	}
+	if event != model.TriggerEventInsert {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(fmt.Sprintf("CREATE TRIGGER for %s event", event))
+	}
+	return nil
+}
+
+// checkTriggerBody checks the statements of the trigger body. Only `SET NEW.col = expr` is supported in
+// BEFORE triggers, and only INSERT, REPLACE, UPDATE and DELETE are supported in AFTER triggers.
+func checkTriggerBody(tblInfo *model.TableInfo, timing model.TriggerTiming, event model.TriggerEvent, body ast.StmtNode) error {
+	stmts := []ast.StmtNode{body}
+	if block, ok := body.(*ast.ProcedureBlock); ok {
+		if len(block.ProcedureVars) > 0 {
+			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("DECLARE in trigger body")
+		}
+		stmts = block.ProcedureProcStmts
+	}
+	for _, stmt := range stmts {
+		switch x := stmt.(type) {
+		case *ast.SetStmt:
+			for _, v := range x.Variables {
+				row, col, ok := triggerRowVariable(v)
+				if !ok {
+					return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("SET statement other than SET NEW.col in trigger body")
+				}
+				if row != "new" {
+					return dbterror.ErrTrgCantChangeRow.GenWithStackByArgs(strings.ToUpper(row), "")
+				}
+				if timing == model.TriggerTimingAfter {
+					return dbterror.ErrTrgCantChangeRow.GenWithStackByArgs("NEW", "after ")
+				}
+				if model.FindColumnInfo(tblInfo.Columns, col) == nil {
+					return infoschema.ErrColumnNotExists.GenWithStackByArgs(col, "NEW")
+				}
+			}
+		case *ast.InsertStmt, *ast.UpdateStmt, *ast.DeleteStmt:
+			if timing == model.TriggerTimingBefore {
+				return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(fmt.Sprintf("%s statement in BEFORE trigger", ast.GetStmtLabel(stmt)))
+			}
+		default:
+			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(fmt.Sprintf("%s statement in trigger body", ast.GetStmtLabel(stmt)))
+		}
+		checker := &triggerRowChecker{tblInfo: tblInfo, event: event}
+		stmt.Accept(checker)
+		if checker.err != nil {
+			return checker.err
+		}
+	}
+	return nil
+}
+
+// triggerRowVariable returns the row and the column name if the variable is `NEW.col` or `OLD.col`.
+func triggerRowVariable(v *ast.VariableAssignment) (row, col string, ok bool) {
+	if !v.IsSystem || v.IsGlobal {
+		return "", "", false
+	}
+	row, col, ok = strings.Cut(strings.ToLower(v.Name), ".")
+	if !ok || (row != "new" && row != "old") {
+		return "", "", false
+	}
+	return row, col, true
+}
+
+// triggerRowChecker checks the references to the NEW and OLD rows in the trigger body.
+type triggerRowChecker struct {
+	tblInfo *model.TableInfo
+	event   model.TriggerEvent
+	err     error
+}
+
+// Enter implements ast.Visitor interface.
+func (c *triggerRowChecker) Enter(in ast.Node) (ast.Node, bool) {
+	if c.err != nil {
+		return in, true
+	}
+	col, ok := in.(*ast.ColumnName)
+	if !ok || col.Schema.L != "" {
+		return in, false
+	}
+	switch col.Table.L {
+	case "new":
+		if c.event == model.TriggerEventDelete {
+			c.err = dbterror.ErrTrgNoSuchRowInTrg.GenWithStackByArgs("NEW", "on DELETE")
+		} else if model.FindColumnInfo(c.tblInfo.Columns, col.Name.L) == nil {
+			c.err = infoschema.ErrColumnNotExists.GenWithStackByArgs(col.Name.O, "NEW")
+		}
+	case "old":
+		if c.event == model.TriggerEventInsert {
+			c.err = dbterror.ErrTrgNoSuchRowInTrg.GenWithStackByArgs("OLD", "on INSERT")
+		} else if model.FindColumnInfo(c.tblInfo.Columns, col.Name.L) == nil {
+			c.err = infoschema.ErrColumnNotExists.GenWithStackByArgs(col.Name.O, "OLD")
+		}
+	}
+	return in, false
+}
+
+// Leave implements ast.Visitor interface.
+func (c *triggerRowChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, c.err == nil
+}
@@ -38,6 +38,7 @@ import (
 	tikvstore "github.com/tikv/client-go/v2/kv"
 	"github.com/tikv/client-go/v2/tikv"
 	"github.com/tikv/client-go/v2/tikvrpc"
+	clientutil "github.com/tikv/client-go/v2/util"
 )
 
 func TestSelectNormal(t *testing.T) {
@@ -150,6 +151,28 @@ func TestSelectResultRuntimeStats(t *testing.T) {
 	s1.procKeys.Add(100)
 	expect = "cop_task: {num: 1, max: 1s, proc_keys: 100, tot_proc: 1s, tot_wait: 1s, copr_cache_hit_ratio: 0.00}, backoff{RegionMiss: 1ms}"
 	require.Equal(t, expect, s1.String())
+	// the wall time in TiKV, the time of resolving locks and the RU are reported if there are any.
+	s1.totalKvReadWallTime = 300 * time.Millisecond
+	s1.totalRPCWallTime = 3 * time.Second
+	s1.resolveLockTime = 5 * time.Millisecond
+	s1.mergeRUDetails(clientutil.NewRUDetailsWith(1.5, 0, 0))
+	s1.mergeRUDetails(clientutil.NewRUDetailsWith(2, 1, 10*time.Millisecond))
+	expect = "cop_task: {num: 1, max: 1s, proc_keys: 100, tot_proc: 1s, tot_wait: 1s, tot_kv_read_wall: 300ms, tot_rpc_wall: 3s, copr_cache_hit_ratio: 0.00}, " +
+		"backoff{RegionMiss: 1ms}, resolve_lock: 5ms, ru:{rru:3.50, wru:1.00, wait:10ms}"
+	require.Equal(t, expect, s1.String())
+	s3 := &selectResultRuntimeStats{
+		backoffSleep:     map[string]time.Duration{},
+		totalProcessTime: time.Second,
+		resolveLockTime:  5 * time.Millisecond,
+		rpcStat:          tikv.NewRegionRequestRuntimeStats(),
+	}
+	s3.copRespTime.Add(execdetails.Duration(time.Second))
+	s3.procKeys.Add(100)
+	s3.mergeRUDetails(clientutil.NewRUDetailsWith(0.5, 0, 0))
+	s1.Merge(s3)
+	expect = "cop_task: {num: 2, max: 1s, min: 1s, avg: 1s, p95: 1s, max_proc_keys: 100, p95_proc_keys: 100, tot_proc: 2s, tot_wait: 1s, tot_kv_read_wall: 300ms, tot_rpc_wall: 3s, copr_cache_hit_ratio: 0.00}, " +
+		"backoff{RegionMiss: 1ms}, resolve_lock: 10ms, ru:{rru:4.00, wru:1.00, wait:10ms}"
+	require.Equal(t, expect, s1.Clone().String())
 }
 
 func TestAnalyze(t *testing.T) {
@@ -630,6 +630,10 @@ type selectResultRuntimeStats struct {
 	backoffSleep            map[string]time.Duration
 	totalProcessTime        time.Duration
 	totalWaitTime           time.Duration
+	totalKvReadWallTime     time.Duration
+	totalRPCWallTime        time.Duration
+	resolveLockTime         time.Duration
+	ruDetails               *clientutil.RUDetails
 	rpcStat                 tikv.RegionRequestRuntimeStats
 	distSQLConcurrency      int
 	extraConcurrency        int
@@ -649,12 +653,27 @@ func (s *selectResultRuntimeStats) mergeCopRuntimeStats(copStats *copr.CopRuntim
 	maps.Copy(s.backoffSleep, copStats.BackoffSleep)
 	s.totalProcessTime += copStats.TimeDetail.ProcessTime
 	s.totalWaitTime += copStats.TimeDetail.WaitTime
+	s.totalKvReadWallTime += copStats.TimeDetail.KvReadWallTime
+	s.totalRPCWallTime += copStats.TimeDetail.TotalRPCWallTime
+	s.resolveLockTime += copStats.ResolveLockTime
+	s.mergeRUDetails(copStats.RUDetails)
 	s.rpcStat.Merge(copStats.RegionRequestRuntimeStats)
 	if copStats.CoprCacheHit {
 		s.CoprCacheHitNum++
 	}
 }
 
+func (s *selectResultRuntimeStats) mergeRUDetails(ruDetails *clientutil.RUDetails) {
+	if ruDetails == nil {
+		return
+	}
+	if s.ruDetails == nil {
+		s.ruDetails = ruDetails.Clone()
+	} else {
+		s.ruDetails.Merge(ruDetails)
+	}
+}
+
 func (s *selectResultRuntimeStats) Clone() execdetails.RuntimeStats {
 	newRs := selectResultRuntimeStats{
 		copRespTime:             execdetails.Percentile[execdetails.Duration]{},
@@ -675,6 +694,10 @@ func (s *selectResultRuntimeStats) Clone() execdetails.RuntimeStats {
 	}
 	newRs.totalProcessTime += s.totalProcessTime
 	newRs.totalWaitTime += s.totalWaitTime
+	newRs.totalKvReadWallTime += s.totalKvReadWallTime
+	newRs.totalRPCWallTime += s.totalRPCWallTime
+	newRs.resolveLockTime += s.resolveLockTime
+	newRs.mergeRUDetails(s.ruDetails)
 	maps.Copy(newRs.rpcStat.Stats, s.rpcStat.Stats)
 	return &newRs
 }
@@ -692,6 +715,10 @@ func (s *selectResultRuntimeStats) Merge(rs execdetails.RuntimeStats) {
 	}
 	s.totalProcessTime += other.totalProcessTime
 	s.totalWaitTime += other.totalWaitTime
+	s.totalKvReadWallTime += other.totalKvReadWallTime
+	s.totalRPCWallTime += other.totalRPCWallTime
+	s.resolveLockTime += other.resolveLockTime
+	s.mergeRUDetails(other.ruDetails)
 	s.rpcStat.Merge(other.rpcStat)
 	s.CoprCacheHitNum += other.CoprCacheHitNum
 	if other.distSQLConcurrency > s.distSQLConcurrency {
@@ -738,6 +765,14 @@ func (s *selectResultRuntimeStats) String() string {
 				buf.WriteString(execdetails.FormatDuration(s.totalWaitTime))
 			}
 		}
+		if s.totalKvReadWallTime > 0 {
+			buf.WriteString(", tot_kv_read_wall: ")
+			buf.WriteString(execdetails.FormatDuration(s.totalKvReadWallTime))
+		}
+		if s.totalRPCWallTime > 0 {
+			buf.WriteString(", tot_rpc_wall: ")
+			buf.WriteString(execdetails.FormatDuration(s.totalRPCWallTime))
+		}
 		copRPC := rpcStat.Stats[tikvrpc.CmdCop]
 		if copRPC != nil && copRPC.Count > 0 {
 			rpcStat = rpcStat.Clone()
@@ -794,6 +829,14 @@ func (s *selectResultRuntimeStats) String() string {
 		}
 		buf.WriteString("}")
 	}
+	if s.resolveLockTime > 0 {
+		buf.WriteString(", resolve_lock: ")
+		buf.WriteString(execdetails.FormatDuration(s.resolveLockTime))
+	}
+	if ruStr := execdetails.FormatRUDetails(s.ruDetails); ruStr != "" {
+		buf.WriteString(", ")
+		buf.WriteString(ruStr)
+	}
 	return buf.String()
 }
 
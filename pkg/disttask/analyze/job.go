@@ -0,0 +1,97 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/tikv/client-go/v2/util"
+	"go.uber.org/zap"
+)
+
+// TaskKey returns the key of the analyze task of the table, ts makes the keys
+// of the statements on the same table unique.
+func TaskKey(tableID int64, ts uint64) string {
+	return fmt.Sprintf("%s/%d/%d", proto.Analyze, tableID, ts)
+}
+
+func taskKeyPrefix(tableID int64) string {
+	return fmt.Sprintf("%s/%d/", proto.Analyze, tableID)
+}
+
+// SubmitOrResumeTask submits the analyze task of the table. If there is an unfinished
+// analyze task of the same statement, which is left by an interrupted ANALYZE TABLE,
+// the task is resumed instead, so the analyzed partitions aren't analyzed again.
+func SubmitOrResumeTask(ctx context.Context, taskMeta *TaskMeta, ts uint64, concurrency int) (*proto.TaskBase, error) {
+	ctx = util.WithInternalSourceType(ctx, kv.InternalDistTask)
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := taskManager.GetTasksInStates(ctx,
+		proto.TaskStatePending, proto.TaskStateRunning, proto.TaskStatePausing,
+		proto.TaskStatePaused, proto.TaskStateResuming)
+	if err != nil {
+		return nil, err
+	}
+	prefix := taskKeyPrefix(taskMeta.TableID)
+	for _, task := range tasks {
+		if task.Type != proto.Analyze || !strings.HasPrefix(task.Key, prefix) {
+			continue
+		}
+		unfinished := &TaskMeta{}
+		if err = json.Unmarshal(task.Meta, unfinished); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !sameStatement(unfinished, taskMeta) {
+			continue
+		}
+		logutil.Logger(ctx).Info("resume unfinished analyze task",
+			zap.Int64("task-id", task.ID), zap.String("task-key", task.Key),
+			zap.Stringer("state", task.State))
+		if task.State == proto.TaskStatePaused {
+			if err = handle.ResumeTask(ctx, task.Key); err != nil {
+				return nil, err
+			}
+		}
+		return &task.TaskBase, nil
+	}
+
+	bs, err := json.Marshal(taskMeta)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	task, err := handle.SubmitTask(ctx, TaskKey(taskMeta.TableID, ts), proto.Analyze, concurrency, bs)
+	if err != nil {
+		return nil, err
+	}
+	return &task.TaskBase, nil
+}
+
+func sameStatement(a, b *TaskMeta) bool {
+	return a.Stmt == b.Stmt && slices.Equal(a.Partitions, b.Partitions) &&
+		a.StatsVer == b.StatsVer && a.PruneMode == b.PruneMode &&
+		a.AnalyzeSnapshot == b.AnalyzeSnapshot
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/disttask/analyze"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/util"
+)
+
+func TestAnalyzeTableOnDistTask(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_partition_prune_mode = 'dynamic'")
+	tk.MustExec("create table t (a int primary key, b int, key idx(b)) partition by hash(a) partitions 3")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3), (4, 4)")
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tblInfo := tbl.Meta()
+	ctx := util.WithInternalSourceType(context.Background(), kv.InternalDistTask)
+	taskManager, err := storage.GetTaskManager()
+	require.NoError(t, err)
+
+	tk.MustExec("set global tidb_enable_dist_task = on")
+	t.Cleanup(func() {
+		tk.MustExec("set global tidb_enable_dist_task = off")
+	})
+	tk.MustExec("analyze table t")
+	tasks, err := taskManager.GetTasksWithHistory(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Equal(t, proto.Analyze, tasks[0].Type)
+	require.Equal(t, proto.TaskStateSucceed, tasks[0].State)
+	// the partitions are analyzed by the subtasks, and the global stats are
+	// merged by the last one.
+	subtasks, err := taskManager.GetSubtasksOfTaskWithHistory(ctx, tasks[0].ID)
+	require.NoError(t, err)
+	require.Len(t, subtasks, 3)
+	for _, def := range tblInfo.Partition.Definitions {
+		tk.MustQuery(fmt.Sprintf("select count from mysql.stats_meta where table_id = %d", def.ID)).Check(testkit.Rows(fmt.Sprint(tk.MustQuery(
+			fmt.Sprintf("select count(*) from t partition (%s)", def.Name.O)).Rows()[0][0])))
+	}
+	tk.MustQuery(fmt.Sprintf("select count from mysql.stats_meta where table_id = %d", tblInfo.ID)).Check(testkit.Rows("4"))
+
+	// a single partition is analyzed locally.
+	tk.MustExec("analyze table t partition p0")
+	tasks, err = taskManager.GetTasksWithHistory(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+}
+
+func TestResumeTask(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int) partition by hash(a) partitions 2")
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tblID := tbl.Meta().ID
+	ctx := util.WithInternalSourceType(context.Background(), kv.InternalDistTask)
+
+	// simulate the task paused by an interrupted ANALYZE TABLE.
+	taskMeta := &analyze.TaskMeta{
+		DBName:     "test",
+		TableName:  "t",
+		TableID:    tblID,
+		Stmt:       "ANALYZE TABLE `test`.`t`",
+		Partitions: []string{"p0", "p1"},
+		StatsVer:   2,
+		PruneMode:  "dynamic",
+	}
+	bs, err := json.Marshal(taskMeta)
+	require.NoError(t, err)
+	taskManager, err := storage.GetTaskManager()
+	require.NoError(t, err)
+	taskKey := analyze.TaskKey(tblID, 1)
+	taskID, err := taskManager.CreateTask(ctx, taskKey, proto.Analyze, 1, bs)
+	require.NoError(t, err)
+	found, err := taskManager.PauseTask(ctx, taskKey)
+	require.NoError(t, err)
+	require.True(t, found)
+	_, err = handle.WaitTask(ctx, taskID, func(task *proto.TaskBase) bool {
+		return task.State == proto.TaskStatePaused
+	})
+	require.NoError(t, err)
+
+	// the paused task of the same statement is resumed instead of submitting a new one.
+	task, err := analyze.SubmitOrResumeTask(ctx, taskMeta, 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, taskID, task.ID)
+	require.Equal(t, taskKey, task.Key)
+	task, err = handle.WaitTask(ctx, taskID, func(task *proto.TaskBase) bool {
+		return task.IsDone()
+	})
+	require.NoError(t, err)
+	require.Equal(t, proto.TaskStateSucceed, task.State)
+
+	// a different statement submits a new task.
+	taskMeta.Stmt = "ANALYZE TABLE `test`.`t` WITH 2 TOPN"
+	task, err = analyze.SubmitOrResumeTask(ctx, taskMeta, 3, 1)
+	require.NoError(t, err)
+	require.NotEqual(t, taskID, task.ID)
+	require.Equal(t, analyze.TaskKey(tblID, 3), task.Key)
+	task, err = handle.WaitTask(ctx, task.ID, func(task *proto.TaskBase) bool {
+		return task.IsDone()
+	})
+	require.NoError(t, err)
+	require.Equal(t, proto.TaskStateSucceed, task.State)
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+// TaskMeta is the task of ANALYZE TABLE on a partitioned table, one task is created
+// for each statement, and the partitions are analyzed by the subtasks.
+// All the field should be serializable.
+type TaskMeta struct {
+	DBName    string
+	TableName string
+	TableID   int64
+	// Stmt is the restored ANALYZE TABLE statement of the table, each subtask
+	// replaces its PARTITION clause with the partition it analyzes.
+	Stmt string
+	// Partitions are the names of the partitions to analyze.
+	Partitions      []string
+	StatsVer        int
+	PruneMode       string
+	AnalyzeSnapshot bool
+}
+
+// SubtaskMeta is the meta of the subtasks, each subtask analyzes one partition.
+// All the field should be serializable.
+type SubtaskMeta struct {
+	Partition string
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+type schedulerExt struct{}
+
+var _ scheduler.Extension = (*schedulerExt)(nil)
+
+// OnTick implements scheduler.Extension interface.
+func (*schedulerExt) OnTick(context.Context, *proto.Task) {}
+
+// OnNextSubtasksBatch implements scheduler.Extension interface.
+func (*schedulerExt) OnNextSubtasksBatch(
+	ctx context.Context,
+	_ storage.TaskHandle,
+	task *proto.Task,
+	_ []string,
+	nextStep proto.Step,
+) ([][]byte, error) {
+	taskMeta := &TaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	partitions := stepPartitions(taskMeta.Partitions, nextStep)
+	metaBytes := make([][]byte, 0, len(partitions))
+	for _, p := range partitions {
+		bs, err := json.Marshal(&SubtaskMeta{Partition: p})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		metaBytes = append(metaBytes, bs)
+	}
+	logutil.Logger(ctx).Info("generate analyze subtasks",
+		zap.Int64("task-id", task.ID), zap.String("table", taskMeta.TableName),
+		zap.String("step", proto.Step2Str(task.Type, nextStep)),
+		zap.Int("subtask-count", len(metaBytes)))
+	return metaBytes, nil
+}
+
+// stepPartitions returns the partitions analyzed in the step. The last partition
+// is analyzed alone in the last step, when all the other partitions have their
+// stats, so the global stats are merged only once.
+func stepPartitions(partitions []string, step proto.Step) []string {
+	if len(partitions) == 0 {
+		return nil
+	}
+	switch step {
+	case proto.AnalyzeStepAnalyzePartitions:
+		return partitions[:len(partitions)-1]
+	case proto.AnalyzeStepMergeGlobalStats:
+		return partitions[len(partitions)-1:]
+	default:
+		return nil
+	}
+}
+
+// OnDone implements scheduler.Extension interface.
+func (*schedulerExt) OnDone(context.Context, storage.TaskHandle, *proto.Task) error {
+	return nil
+}
+
+// GetEligibleInstances implements scheduler.Extension interface.
+func (*schedulerExt) GetEligibleInstances(context.Context, *proto.Task) ([]string, error) {
+	return nil, nil
+}
+
+// IsRetryableErr implements scheduler.Extension interface.
+func (*schedulerExt) IsRetryableErr(error) bool {
+	return true
+}
+
+// GetNextStep implements scheduler.Extension interface.
+func (*schedulerExt) GetNextStep(task *proto.TaskBase) proto.Step {
+	switch task.Step {
+	case proto.StepInit:
+		return proto.AnalyzeStepAnalyzePartitions
+	case proto.AnalyzeStepAnalyzePartitions:
+		return proto.AnalyzeStepMergeGlobalStats
+	default:
+		return proto.StepDone
+	}
+}
+
+type analyzeScheduler struct {
+	*scheduler.BaseScheduler
+}
+
+// NewScheduler creates a new scheduler of ANALYZE TABLE.
+func NewScheduler(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
+	return &analyzeScheduler{
+		BaseScheduler: scheduler.NewBaseScheduler(ctx, task, param),
+	}
+}
+
+// Init implements scheduler.Scheduler interface.
+func (sch *analyzeScheduler) Init() error {
+	sch.BaseScheduler.Extension = &schedulerExt{}
+	return sch.BaseScheduler.Init()
+}
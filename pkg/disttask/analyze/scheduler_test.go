@@ -0,0 +1,43 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/disttask/analyze"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepPartitions(t *testing.T) {
+	partitions := []string{"p0", "p1", "p2"}
+	require.Equal(t, []string{"p0", "p1"}, analyze.StepPartitions(partitions, proto.AnalyzeStepAnalyzePartitions))
+	require.Equal(t, []string{"p2"}, analyze.StepPartitions(partitions, proto.AnalyzeStepMergeGlobalStats))
+	require.Empty(t, analyze.StepPartitions(partitions, proto.StepDone))
+	require.Empty(t, analyze.StepPartitions(nil, proto.AnalyzeStepAnalyzePartitions))
+	require.Empty(t, analyze.StepPartitions(nil, proto.AnalyzeStepMergeGlobalStats))
+}
+
+func TestBuildPartitionSQL(t *testing.T) {
+	sql, err := analyze.BuildPartitionSQL("ANALYZE TABLE `test`.`t` INDEX `idx` WITH 2 TOPN", "p1")
+	require.NoError(t, err)
+	require.Equal(t, "ANALYZE TABLE `test`.`t` PARTITION `p1` INDEX `idx` WITH 2 TOPN", sql)
+	sql, err = analyze.BuildPartitionSQL("ANALYZE TABLE `test`.`t` PARTITION `p0`,`p1`", "p1")
+	require.NoError(t, err)
+	require.Equal(t, "ANALYZE TABLE `test`.`t` PARTITION `p1`", sql)
+	_, err = analyze.BuildPartitionSQL("SELECT 1", "p1")
+	require.ErrorContains(t, err, "unexpected statement of analyze task")
+}
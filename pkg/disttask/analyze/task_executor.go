@@ -0,0 +1,164 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/common"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/execute"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+// analyzeStepExecutor analyzes the partition of each subtask by running
+// ANALYZE TABLE ... PARTITION on an internal session.
+type analyzeStepExecutor struct {
+	taskexecutor.EmptyStepExecutor
+	taskMeta *TaskMeta
+	step     proto.Step
+	logger   *zap.Logger
+}
+
+var _ execute.StepExecutor = &analyzeStepExecutor{}
+
+func (e *analyzeStepExecutor) RunSubtask(ctx context.Context, subtask *proto.Subtask) error {
+	subtaskMeta := &SubtaskMeta{}
+	if err := json.Unmarshal(subtask.Meta, subtaskMeta); err != nil {
+		return errors.Trace(err)
+	}
+	sql, err := buildPartitionSQL(e.taskMeta.Stmt, subtaskMeta.Partition)
+	if err != nil {
+		return err
+	}
+	// the global stats are only merged after the last partition is analyzed,
+	// when the stats of all the other partitions are ready.
+	pruneMode := string(variable.Static)
+	if e.step == proto.AnalyzeStepMergeGlobalStats {
+		pruneMode = e.taskMeta.PruneMode
+	}
+	optFuncs := []sqlexec.OptionFuncAlias{
+		sqlexec.ExecOptionUseCurSession,
+		sqlexec.GetAnalyzeSnapshotOption(e.taskMeta.AnalyzeSnapshot),
+		sqlexec.GetPartitionPruneModeOption(pruneMode),
+	}
+	if e.taskMeta.StatsVer == statistics.Version2 {
+		optFuncs = append(optFuncs, sqlexec.ExecOptionAnalyzeVer2)
+	} else {
+		optFuncs = append(optFuncs, sqlexec.ExecOptionAnalyzeVer1)
+	}
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnStats)
+	err = taskManager.WithNewSession(func(se sessionctx.Context) error {
+		_, _, err := se.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(ctx, optFuncs, sql)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	e.logger.Info("analyze subtask finished",
+		zap.Int64("subtask-id", subtask.ID),
+		zap.String("partition", subtaskMeta.Partition),
+		zap.String("prune-mode", pruneMode))
+	return nil
+}
+
+// buildPartitionSQL replaces the PARTITION clause of the ANALYZE TABLE statement
+// with the partition.
+func buildPartitionSQL(stmt, partition string) (string, error) {
+	node, err := parser.New().ParseOneStmt(stmt, "", "")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	analyzeStmt, ok := node.(*ast.AnalyzeTableStmt)
+	if !ok {
+		return "", errors.Errorf("unexpected statement of analyze task: %s", stmt)
+	}
+	analyzeStmt.PartitionNames = []model.CIStr{model.NewCIStr(partition)}
+	var sb strings.Builder
+	if err = analyzeStmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return "", errors.Trace(err)
+	}
+	return sb.String(), nil
+}
+
+type analyzeExecutor struct {
+	*taskexecutor.BaseTaskExecutor
+}
+
+// NewTaskExecutor creates a new task executor of ANALYZE TABLE.
+func NewTaskExecutor(
+	ctx context.Context,
+	id string,
+	task *proto.Task,
+	taskTable taskexecutor.TaskTable,
+) taskexecutor.TaskExecutor {
+	e := &analyzeExecutor{
+		BaseTaskExecutor: taskexecutor.NewBaseTaskExecutor(ctx, id, task, taskTable),
+	}
+	e.BaseTaskExecutor.Extension = e
+	return e
+}
+
+// IsIdempotent implements taskexecutor.Extension interface.
+func (*analyzeExecutor) IsIdempotent(*proto.Subtask) bool {
+	// the stats of the partition are overwritten when it's analyzed again.
+	return true
+}
+
+// IsRetryableError implements taskexecutor.Extension interface.
+func (*analyzeExecutor) IsRetryableError(err error) bool {
+	return common.IsRetryableError(err)
+}
+
+// GetStepExecutor implements taskexecutor.Extension interface.
+func (*analyzeExecutor) GetStepExecutor(task *proto.Task, _ *proto.StepResource) (execute.StepExecutor, error) {
+	taskMeta := &TaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch task.Step {
+	case proto.AnalyzeStepAnalyzePartitions, proto.AnalyzeStepMergeGlobalStats:
+		return &analyzeStepExecutor{
+			taskMeta: taskMeta,
+			step:     task.Step,
+			logger: logutil.BgLogger().With(
+				zap.Stringer("type", proto.Analyze),
+				zap.Int64("task-id", task.ID),
+				zap.String("step", proto.Step2Str(task.Type, task.Step)),
+			),
+		}, nil
+	default:
+		return nil, errors.Errorf("unknown step %d for analyze task %d", task.Step, task.ID)
+	}
+}
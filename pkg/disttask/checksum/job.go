@@ -0,0 +1,156 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/tikv/client-go/v2/util"
+	"go.uber.org/zap"
+)
+
+// TaskKey returns the key of the checksum task of the table on the snapshot.
+func TaskKey(tableID int64, startTS uint64) string {
+	return fmt.Sprintf("%s/%d/%d", proto.ChecksumTable, tableID, startTS)
+}
+
+func taskKeyPrefix(tableID int64) string {
+	return fmt.Sprintf("%s/%d/", proto.ChecksumTable, tableID)
+}
+
+// SubmitOrResumeTask submits the checksum task of the table. If there is an unfinished
+// checksum task of the same table, which is left by an interrupted ADMIN CHECKSUM TABLE,
+// the task is resumed instead, so the finished subtasks aren't calculated again, and
+// the checksum is calculated on the snapshot of that task.
+func SubmitOrResumeTask(ctx context.Context, taskMeta *TaskMeta, concurrency int) (*proto.TaskBase, error) {
+	ctx = util.WithInternalSourceType(ctx, kv.InternalDistTask)
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := taskManager.GetTasksInStates(ctx,
+		proto.TaskStatePending, proto.TaskStateRunning, proto.TaskStatePausing,
+		proto.TaskStatePaused, proto.TaskStateResuming)
+	if err != nil {
+		return nil, err
+	}
+	prefix := taskKeyPrefix(taskMeta.TableInfo.ID)
+	for _, task := range tasks {
+		if task.Type != proto.ChecksumTable || !strings.HasPrefix(task.Key, prefix) {
+			continue
+		}
+		logutil.Logger(ctx).Info("resume unfinished checksum task",
+			zap.Int64("task-id", task.ID), zap.String("task-key", task.Key),
+			zap.Stringer("state", task.State))
+		if task.State == proto.TaskStatePaused {
+			if err = handle.ResumeTask(ctx, task.Key); err != nil {
+				return nil, err
+			}
+		}
+		return &task.TaskBase, nil
+	}
+
+	bs, err := json.Marshal(taskMeta)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	task, err := handle.SubmitTask(ctx, TaskKey(taskMeta.TableInfo.ID, taskMeta.StartTS), proto.ChecksumTable, concurrency, bs)
+	if err != nil {
+		return nil, err
+	}
+	return &task.TaskBase, nil
+}
+
+// GetTaskResult returns the checksum of the table by merging the results of the subtasks.
+func GetTaskResult(ctx context.Context, taskID int64) (*Result, error) {
+	ctx = util.WithInternalSourceType(ctx, kv.InternalDistTask)
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return nil, err
+	}
+	subtasks, err := taskManager.GetSubtasksWithHistory(ctx, taskID, proto.ChecksumStepChecksum)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{}
+	for _, subtask := range subtasks {
+		subtaskMeta := &SubtaskMeta{}
+		if err = json.Unmarshal(subtask.Meta, subtaskMeta); err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.Checksum ^= subtaskMeta.Result.Checksum
+		result.TotalKvs += subtaskMeta.Result.TotalKvs
+		result.TotalBytes += subtaskMeta.Result.TotalBytes
+	}
+	return result, nil
+}
+
+// TaskProgress is the progress of a checksum task.
+type TaskProgress struct {
+	Task             *proto.Task
+	Meta             *TaskMeta
+	TotalSubtasks    int64
+	FinishedSubtasks int64
+}
+
+// GetTaskProgresses returns the progress of the checksum tasks which are not moved
+// to the history table yet.
+func GetTaskProgresses(ctx context.Context) ([]*TaskProgress, error) {
+	ctx = util.WithInternalSourceType(ctx, kv.InternalDistTask)
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := taskManager.GetTasksInStates(ctx,
+		proto.TaskStatePending, proto.TaskStateRunning, proto.TaskStateSucceed,
+		proto.TaskStateFailed, proto.TaskStateReverting, proto.TaskStateReverted,
+		proto.TaskStateCancelling, proto.TaskStatePausing, proto.TaskStatePaused,
+		proto.TaskStateResuming)
+	if err != nil {
+		return nil, err
+	}
+	progresses := make([]*TaskProgress, 0, len(tasks))
+	for _, task := range tasks {
+		if task.Type != proto.ChecksumTable {
+			continue
+		}
+		taskMeta := &TaskMeta{}
+		if err = json.Unmarshal(task.Meta, taskMeta); err != nil {
+			return nil, errors.Trace(err)
+		}
+		cntByStates, err := taskManager.GetSubtaskCntGroupByStates(ctx, task.ID, proto.ChecksumStepChecksum)
+		if err != nil {
+			return nil, err
+		}
+		progress := &TaskProgress{Task: task, Meta: taskMeta}
+		for state, cnt := range cntByStates {
+			progress.TotalSubtasks += cnt
+			if state == proto.SubtaskStateSucceed {
+				progress.FinishedSubtasks += cnt
+			}
+		}
+		progresses = append(progresses, progress)
+	}
+	return progresses, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksum_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/disttask/checksum"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/util"
+)
+
+func TestChecksumTableOnDistTask(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int, key idx(b))")
+	tk.MustExec("create table tp (a int primary key, b int, key idx(b)) partition by hash(a) partitions 3")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("insert into tp values (1, 1), (2, 2), (3, 3)")
+
+	expected := tk.MustQuery("admin checksum table t").Rows()
+	tk.MustExec("set global tidb_enable_dist_task = on")
+	t.Cleanup(func() {
+		tk.MustExec("set global tidb_enable_dist_task = off")
+	})
+	tk.MustQuery("admin checksum table t").Check(expected)
+	// the mock store returns 1 for the checksum of each key range, there are
+	// 3 partitions, each of them has a record range and an index range.
+	tk.MustQuery("admin checksum table tp").Check(testkit.Rows("test tp 0 6 6"))
+
+}
+
+func TestResumeTask(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int, key idx(b))")
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tblID := tbl.Meta().ID
+	ver, err := store.CurrentVersion(kv.GlobalTxnScope)
+	require.NoError(t, err)
+	ctx := util.WithInternalSourceType(context.Background(), kv.InternalDistTask)
+
+	// simulate the task paused by an interrupted ADMIN CHECKSUM TABLE.
+	taskMeta := &checksum.TaskMeta{DBName: "test", TableInfo: tbl.Meta(), StartTS: ver.Ver, ScanConcurrency: 1}
+	bs, err := json.Marshal(taskMeta)
+	require.NoError(t, err)
+	taskManager, err := storage.GetTaskManager()
+	require.NoError(t, err)
+	taskKey := checksum.TaskKey(tblID, ver.Ver)
+	taskID, err := taskManager.CreateTask(ctx, taskKey, proto.ChecksumTable, 1, bs)
+	require.NoError(t, err)
+	found, err := taskManager.PauseTask(ctx, taskKey)
+	require.NoError(t, err)
+	require.True(t, found)
+	_, err = handle.WaitTask(ctx, taskID, func(task *proto.TaskBase) bool {
+		return task.State == proto.TaskStatePaused
+	})
+	require.NoError(t, err)
+	tk.MustQuery(`select task_id, table_schema, table_name, table_id, state, snapshot_ts
+		from information_schema.checksum_table_progress`).Check(
+		testkit.Rows(fmt.Sprintf("%d test t %d paused %d", taskID, tblID, ver.Ver)))
+
+	// the paused task of the same table is resumed instead of submitting a new one.
+	taskMeta.StartTS = ver.Ver + 1
+	task, err := checksum.SubmitOrResumeTask(ctx, taskMeta, 1)
+	require.NoError(t, err)
+	require.Equal(t, taskID, task.ID)
+	require.Equal(t, taskKey, task.Key)
+	task, err = handle.WaitTask(ctx, taskID, func(task *proto.TaskBase) bool {
+		return task.IsDone()
+	})
+	require.NoError(t, err)
+	require.Equal(t, proto.TaskStateSucceed, task.State)
+	result, err := checksum.GetTaskResult(ctx, taskID)
+	require.NoError(t, err)
+	require.Equal(t, checksum.Result{Checksum: 0, TotalKvs: 2, TotalBytes: 2}, *result)
+}
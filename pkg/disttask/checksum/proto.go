@@ -0,0 +1,60 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksum
+
+import (
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+// TaskMeta is the task of ADMIN CHECKSUM TABLE, one task is created for each table.
+// All the field should be serializable.
+type TaskMeta struct {
+	DBName    string
+	TableInfo *model.TableInfo
+	// StartTS is the snapshot ts the checksum is calculated on, a resumed task
+	// keeps using the snapshot ts it's created with.
+	StartTS uint64
+	// ScanConcurrency is the concurrency of the checksum request of each subtask.
+	ScanConcurrency   int
+	ResourceGroupName string
+}
+
+// SubtaskMeta is the meta of checksum step.
+// Scheduler splits the record and the public indexes of each physical table into
+// key ranges by regions, and each subtask calculates the checksum of one key range.
+// All the field should be serializable.
+type SubtaskMeta struct {
+	PhysicalTableID int64
+	// IndexID is 0 if the subtask calculates the checksum of the records.
+	IndexID  int64
+	StartKey kv.Key
+	EndKey   kv.Key
+	Result   Result
+}
+
+// Result is the checksum result of a subtask.
+type Result struct {
+	Checksum   uint64
+	TotalKvs   uint64
+	TotalBytes uint64
+}
+
+func (r *Result) update(resp *tipb.ChecksumResponse) {
+	r.Checksum ^= resp.Checksum
+	r.TotalKvs += resp.TotalKvs
+	r.TotalBytes += resp.TotalBytes
+}
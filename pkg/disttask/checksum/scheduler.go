@@ -0,0 +1,182 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksum
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/tikv/client-go/v2/tikv"
+	"go.uber.org/zap"
+)
+
+// RegionsPerSubtask is the max count of regions a subtask calculates the checksum on.
+// exported for test.
+var RegionsPerSubtask = 64
+
+type schedulerExt struct {
+	store kv.Storage
+}
+
+var _ scheduler.Extension = (*schedulerExt)(nil)
+
+// OnTick implements scheduler.Extension interface.
+func (*schedulerExt) OnTick(context.Context, *proto.Task) {}
+
+// OnNextSubtasksBatch implements scheduler.Extension interface.
+func (sch *schedulerExt) OnNextSubtasksBatch(
+	ctx context.Context,
+	_ storage.TaskHandle,
+	task *proto.Task,
+	_ []string,
+	nextStep proto.Step,
+) ([][]byte, error) {
+	if nextStep != proto.ChecksumStepChecksum {
+		return nil, nil
+	}
+	taskMeta := &TaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	subtaskMetas, err := buildSubtaskMetas(ctx, sch.store, taskMeta.TableInfo)
+	if err != nil {
+		return nil, err
+	}
+	metaBytes := make([][]byte, 0, len(subtaskMetas))
+	for _, m := range subtaskMetas {
+		bs, err := json.Marshal(m)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		metaBytes = append(metaBytes, bs)
+	}
+	logutil.Logger(ctx).Info("generate checksum subtasks",
+		zap.Int64("task-id", task.ID), zap.String("table", taskMeta.TableInfo.Name.O),
+		zap.Int("subtask-count", len(metaBytes)))
+	return metaBytes, nil
+}
+
+// OnDone implements scheduler.Extension interface.
+func (*schedulerExt) OnDone(context.Context, storage.TaskHandle, *proto.Task) error {
+	return nil
+}
+
+// GetEligibleInstances implements scheduler.Extension interface.
+func (*schedulerExt) GetEligibleInstances(context.Context, *proto.Task) ([]string, error) {
+	return nil, nil
+}
+
+// IsRetryableErr implements scheduler.Extension interface.
+func (*schedulerExt) IsRetryableErr(error) bool {
+	return true
+}
+
+// GetNextStep implements scheduler.Extension interface.
+func (*schedulerExt) GetNextStep(task *proto.TaskBase) proto.Step {
+	switch task.Step {
+	case proto.StepInit:
+		return proto.ChecksumStepChecksum
+	default:
+		return proto.StepDone
+	}
+}
+
+type checksumScheduler struct {
+	*scheduler.BaseScheduler
+	store kv.Storage
+}
+
+// NewScheduler creates a new scheduler of ADMIN CHECKSUM TABLE.
+func NewScheduler(ctx context.Context, task *proto.Task, param scheduler.Param, store kv.Storage) scheduler.Scheduler {
+	return &checksumScheduler{
+		BaseScheduler: scheduler.NewBaseScheduler(ctx, task, param),
+		store:         store,
+	}
+}
+
+// Init implements scheduler.Scheduler interface.
+func (sch *checksumScheduler) Init() error {
+	sch.BaseScheduler.Extension = &schedulerExt{store: sch.store}
+	return sch.BaseScheduler.Init()
+}
+
+// buildSubtaskMetas splits the records and the public indexes of every physical
+// table into key ranges, each key range covers at most RegionsPerSubtask regions.
+func buildSubtaskMetas(ctx context.Context, store kv.Storage, tblInfo *model.TableInfo) ([]*SubtaskMeta, error) {
+	physicalIDs := []int64{tblInfo.ID}
+	if pi := tblInfo.GetPartitionInfo(); pi != nil {
+		physicalIDs = physicalIDs[:0]
+		for _, def := range pi.Definitions {
+			physicalIDs = append(physicalIDs, def.ID)
+		}
+	}
+	var metas []*SubtaskMeta
+	for _, pid := range physicalIDs {
+		prefix := tablecodec.GenTableRecordPrefix(pid)
+		ranges, err := splitKeyRange(ctx, store, prefix, prefix.PrefixNext())
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range ranges {
+			metas = append(metas, &SubtaskMeta{PhysicalTableID: pid, StartKey: r.StartKey, EndKey: r.EndKey})
+		}
+		for _, idx := range tblInfo.Indices {
+			if idx.State != model.StatePublic {
+				continue
+			}
+			prefix := kv.Key(tablecodec.EncodeTableIndexPrefix(pid, idx.ID))
+			ranges, err := splitKeyRange(ctx, store, prefix, prefix.PrefixNext())
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range ranges {
+				metas = append(metas, &SubtaskMeta{PhysicalTableID: pid, IndexID: idx.ID, StartKey: r.StartKey, EndKey: r.EndKey})
+			}
+		}
+	}
+	return metas, nil
+}
+
+func splitKeyRange(ctx context.Context, store kv.Storage, startKey, endKey kv.Key) ([]kv.KeyRange, error) {
+	s, ok := store.(tikv.Storage)
+	if !ok {
+		return []kv.KeyRange{{StartKey: startKey, EndKey: endKey}}, nil
+	}
+	maxSleep := 10000 // ms
+	bo := tikv.NewBackofferWithVars(ctx, maxSleep, nil)
+	regions, err := s.GetRegionCache().LoadRegionsInKeyRange(bo, startKey, endKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ranges := make([]kv.KeyRange, 0, len(regions)/RegionsPerSubtask+1)
+	start := startKey
+	for i := RegionsPerSubtask - 1; i < len(regions)-1; i += RegionsPerSubtask {
+		end := kv.Key(regions[i].EndKey())
+		if len(end) == 0 || end.Cmp(endKey) >= 0 {
+			break
+		}
+		ranges = append(ranges, kv.KeyRange{StartKey: start, EndKey: end})
+		start = end
+	}
+	return append(ranges, kv.KeyRange{StartKey: start, EndKey: endKey}), nil
+}
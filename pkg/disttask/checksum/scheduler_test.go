@@ -0,0 +1,72 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksum_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/disttask/checksum"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSubtaskMetas(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	ctx := context.Background()
+
+	// each partition is split into the records and the public indexes.
+	tk.MustExec("create table tp (a int primary key, b int, key idx(b)) partition by hash(a) partitions 2")
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("tp"))
+	require.NoError(t, err)
+	tblInfo := tbl.Meta()
+	metas, err := checksum.BuildSubtaskMetas(ctx, store, tblInfo)
+	require.NoError(t, err)
+	require.Len(t, metas, 4)
+	for i, def := range tblInfo.Partition.Definitions {
+		record, index := metas[i*2], metas[i*2+1]
+		require.Equal(t, def.ID, record.PhysicalTableID)
+		require.Zero(t, record.IndexID)
+		require.Equal(t, tablecodec.GenTableRecordPrefix(def.ID), record.StartKey)
+		require.Equal(t, def.ID, index.PhysicalTableID)
+		require.Equal(t, tblInfo.Indices[0].ID, index.IndexID)
+		require.Equal(t, kv.Key(tablecodec.EncodeTableIndexPrefix(def.ID, tblInfo.Indices[0].ID)), index.StartKey)
+	}
+
+	// the key range is split by regions.
+	bak := checksum.RegionsPerSubtask
+	checksum.RegionsPerSubtask = 2
+	t.Cleanup(func() {
+		checksum.RegionsPerSubtask = bak
+	})
+	tk.MustExec("create table t (a int primary key, b int)")
+	tk.MustQuery("split table t between (0) and (100000) regions 4").Check(testkit.Rows("3 1"))
+	tbl, err = dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	metas, err = checksum.BuildSubtaskMetas(ctx, store, tbl.Meta())
+	require.NoError(t, err)
+	require.Len(t, metas, 2)
+	prefix := tablecodec.GenTableRecordPrefix(tbl.Meta().ID)
+	require.Equal(t, prefix, metas[0].StartKey)
+	require.Equal(t, prefix.PrefixNext(), metas[1].EndKey)
+	for i := 1; i < len(metas); i++ {
+		require.Equal(t, metas[i-1].EndKey, metas[i].StartKey)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksum
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/common"
+	"github.com/pingcap/tidb/pkg/distsql"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/execute"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tipb/go-tipb"
+	"go.uber.org/zap"
+)
+
+// checksumStepExecutor calculates the checksum of the key range of each subtask.
+type checksumStepExecutor struct {
+	taskexecutor.EmptyStepExecutor
+	taskMeta *TaskMeta
+	store    kv.Storage
+	logger   *zap.Logger
+	// subtask id -> *Result
+	results sync.Map
+}
+
+var _ execute.StepExecutor = &checksumStepExecutor{}
+
+func (e *checksumStepExecutor) RunSubtask(ctx context.Context, subtask *proto.Subtask) error {
+	subtaskMeta := &SubtaskMeta{}
+	if err := json.Unmarshal(subtask.Meta, subtaskMeta); err != nil {
+		return errors.Trace(err)
+	}
+	req, err := e.buildRequest(subtaskMeta)
+	if err != nil {
+		return err
+	}
+	// Killed is a place holder here, the task is paused or cancelled through the framework.
+	killed := uint32(0)
+	res, err := distsql.Checksum(ctx, e.store.GetClient(), req, kv.NewVariables(&killed))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err := res.Close(); err != nil {
+			e.logger.Warn("close checksum result failed", zap.Error(err))
+		}
+	}()
+
+	result := &Result{}
+	for {
+		data, err := res.NextRaw(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if data == nil {
+			break
+		}
+		resp := &tipb.ChecksumResponse{}
+		if err = resp.Unmarshal(data); err != nil {
+			return errors.Trace(err)
+		}
+		result.update(resp)
+	}
+	// the coprocessor doesn't return error when the context is done, make sure
+	// we don't take an incomplete result.
+	if err = ctx.Err(); err != nil {
+		return errors.Trace(err)
+	}
+	e.logger.Info("checksum subtask finished",
+		zap.Int64("subtask-id", subtask.ID),
+		zap.Int64("physical-table-id", subtaskMeta.PhysicalTableID),
+		zap.Int64("index-id", subtaskMeta.IndexID),
+		zap.Uint64("checksum", result.Checksum),
+		zap.Uint64("total-kvs", result.TotalKvs),
+		zap.Uint64("total-bytes", result.TotalBytes))
+	e.results.Store(subtask.ID, result)
+	return nil
+}
+
+func (e *checksumStepExecutor) OnFinished(_ context.Context, subtask *proto.Subtask) error {
+	val, ok := e.results.LoadAndDelete(subtask.ID)
+	if !ok {
+		return errors.Errorf("checksum result of subtask %d not found", subtask.ID)
+	}
+	subtaskMeta := &SubtaskMeta{}
+	if err := json.Unmarshal(subtask.Meta, subtaskMeta); err != nil {
+		return errors.Trace(err)
+	}
+	subtaskMeta.Result = *val.(*Result)
+	newMeta, err := json.Marshal(subtaskMeta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	subtask.Meta = newMeta
+	return nil
+}
+
+func (e *checksumStepExecutor) buildRequest(subtaskMeta *SubtaskMeta) (*kv.Request, error) {
+	checksum := &tipb.ChecksumRequest{
+		ScanOn:    tipb.ChecksumScanOn_Table,
+		Algorithm: tipb.ChecksumAlgorithm_Crc64_Xor,
+	}
+	if subtaskMeta.IndexID != 0 {
+		checksum.ScanOn = tipb.ChecksumScanOn_Index
+	}
+	var builder distsql.RequestBuilder
+	// Use low priority to reduce the impact to other requests.
+	builder.Request.Priority = kv.PriorityLow
+	return builder.SetKeyRanges([]kv.KeyRange{{StartKey: subtaskMeta.StartKey, EndKey: subtaskMeta.EndKey}}).
+		SetChecksumRequest(checksum).
+		SetStartTS(e.taskMeta.StartTS).
+		SetConcurrency(e.taskMeta.ScanConcurrency).
+		SetResourceGroupName(e.taskMeta.ResourceGroupName).
+		Build()
+}
+
+type checksumExecutor struct {
+	*taskexecutor.BaseTaskExecutor
+	store kv.Storage
+}
+
+// NewTaskExecutor creates a new task executor of ADMIN CHECKSUM TABLE.
+func NewTaskExecutor(
+	ctx context.Context,
+	id string,
+	task *proto.Task,
+	taskTable taskexecutor.TaskTable,
+	store kv.Storage,
+) taskexecutor.TaskExecutor {
+	e := &checksumExecutor{
+		BaseTaskExecutor: taskexecutor.NewBaseTaskExecutor(ctx, id, task, taskTable),
+		store:            store,
+	}
+	e.BaseTaskExecutor.Extension = e
+	return e
+}
+
+// IsIdempotent implements taskexecutor.Extension interface.
+func (*checksumExecutor) IsIdempotent(*proto.Subtask) bool {
+	// checksum is read only, it's ok to calculate it again.
+	return true
+}
+
+// IsRetryableError implements taskexecutor.Extension interface.
+func (*checksumExecutor) IsRetryableError(err error) bool {
+	return common.IsRetryableError(err)
+}
+
+// GetStepExecutor implements taskexecutor.Extension interface.
+func (e *checksumExecutor) GetStepExecutor(task *proto.Task, _ *proto.StepResource) (execute.StepExecutor, error) {
+	taskMeta := &TaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch task.Step {
+	case proto.ChecksumStepChecksum:
+		return &checksumStepExecutor{
+			taskMeta: taskMeta,
+			store:    e.store,
+			logger: logutil.BgLogger().With(
+				zap.Stringer("type", proto.ChecksumTable),
+				zap.Int64("task-id", task.ID),
+				zap.String("step", proto.Step2Str(task.Type, task.Step)),
+			),
+		}, nil
+	default:
+		return nil, errors.Errorf("unknown step %d for checksum task %d", task.Step, task.ID)
+	}
+}
@@ -43,6 +43,10 @@ func Step2Str(t TaskType, s Step) string {
 		return importIntoStep2Str(s)
 	case TaskTypeExample:
 		return exampleStep2Str(s)
+	case ChecksumTable:
+		return checksumTableStep2Str(s)
+	case Analyze:
+		return analyzeStep2Str(s)
 	}
 	return fmt.Sprintf("unknown type %s", t)
 }
@@ -136,3 +140,41 @@ func backfillStep2Str(s Step) string {
 		return fmt.Sprintf("unknown step %d", s)
 	}
 }
+
+// Steps of ADMIN CHECKSUM TABLE.
+// StepInit -> ChecksumStepChecksum -> StepDone
+const (
+	// ChecksumStepChecksum we calculate the checksum of the key ranges in this step.
+	ChecksumStepChecksum Step = 1
+)
+
+func checksumTableStep2Str(s Step) string {
+	switch s {
+	case ChecksumStepChecksum:
+		return "checksum"
+	default:
+		return fmt.Sprintf("unknown step %d", s)
+	}
+}
+
+// Steps of ANALYZE TABLE on partitioned tables.
+// StepInit -> AnalyzeStepAnalyzePartitions -> AnalyzeStepMergeGlobalStats -> StepDone
+const (
+	// AnalyzeStepAnalyzePartitions we analyze the partitions except the last one in this step.
+	AnalyzeStepAnalyzePartitions Step = 1
+	// AnalyzeStepMergeGlobalStats we analyze the last partition in this step, and
+	// merge the stats of all the partitions to the global stats if the prune mode
+	// is dynamic.
+	AnalyzeStepMergeGlobalStats Step = 2
+)
+
+func analyzeStep2Str(s Step) string {
+	switch s {
+	case AnalyzeStepAnalyzePartitions:
+		return "analyze-partitions"
+	case AnalyzeStepMergeGlobalStats:
+		return "merge-global-stats"
+	default:
+		return fmt.Sprintf("unknown step %d", s)
+	}
+}
@@ -46,6 +46,19 @@ func TestStep(t *testing.T) {
 	require.Equal(t, "done", Step2Str(TaskTypeExample, StepDone))
 	require.Equal(t, "unknown step 333", Step2Str(TaskTypeExample, 333))
 
+	// checksum table
+	require.Equal(t, "init", Step2Str(ChecksumTable, StepInit))
+	require.Equal(t, "checksum", Step2Str(ChecksumTable, ChecksumStepChecksum))
+	require.Equal(t, "done", Step2Str(ChecksumTable, StepDone))
+	require.Equal(t, "unknown step 444", Step2Str(ChecksumTable, 444))
+
+	// analyze
+	require.Equal(t, "init", Step2Str(Analyze, StepInit))
+	require.Equal(t, "analyze-partitions", Step2Str(Analyze, AnalyzeStepAnalyzePartitions))
+	require.Equal(t, "merge-global-stats", Step2Str(Analyze, AnalyzeStepMergeGlobalStats))
+	require.Equal(t, "done", Step2Str(Analyze, StepDone))
+	require.Equal(t, "unknown step 555", Step2Str(Analyze, 555))
+
 	// unknown type
 	require.Equal(t, "unknown type 123", Step2Str(TaskType("123"), 123))
 }
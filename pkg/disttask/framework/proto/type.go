@@ -21,6 +21,10 @@ const (
 	ImportInto TaskType = "ImportInto"
 	// Backfill is TaskType of add index Backfilling process.
 	Backfill TaskType = "backfill"
+	// ChecksumTable is TaskType of ADMIN CHECKSUM TABLE.
+	ChecksumTable TaskType = "ChecksumTable"
+	// Analyze is TaskType of ANALYZE TABLE on partitioned tables.
+	Analyze TaskType = "Analyze"
 )
 
 // Type2Int converts task type to int.
@@ -32,6 +36,10 @@ func Type2Int(t TaskType) int {
 		return 2
 	case Backfill:
 		return 3
+	case ChecksumTable:
+		return 4
+	case Analyze:
+		return 5
 	default:
 		return 0
 	}
@@ -46,6 +54,10 @@ func Int2Type(i int) TaskType {
 		return ImportInto
 	case 3:
 		return Backfill
+	case 4:
+		return ChecksumTable
+	case 5:
+		return Analyze
 	default:
 		return ""
 	}
@@ -28,6 +28,8 @@ func TestTaskType(t *testing.T) {
 		{TaskTypeExample, 1},
 		{ImportInto, 2},
 		{Backfill, 3},
+		{ChecksumTable, 4},
+		{Analyze, 5},
 		{"", 0},
 	}
 	for _, c := range cases {
@@ -805,6 +805,9 @@ func TestSubtaskHistoryTable(t *testing.T) {
 	subTasks, err = sm.GetSubtasksWithHistory(ctx, taskID, proto.StepInit)
 	require.NoError(t, err)
 	require.Len(t, subTasks, 3)
+	subTasks, err = sm.GetSubtasksOfTaskWithHistory(ctx, taskID)
+	require.NoError(t, err)
+	require.Len(t, subTasks, 3)
 
 	// test TransferSubTasks2History
 	require.NoError(t, testutil.TransferSubTasks2History(ctx, sm, taskID))
@@ -818,6 +821,9 @@ func TestSubtaskHistoryTable(t *testing.T) {
 	subTasks, err = sm.GetSubtasksWithHistory(ctx, taskID, proto.StepInit)
 	require.NoError(t, err)
 	require.Len(t, subTasks, 3)
+	subTasks, err = sm.GetSubtasksOfTaskWithHistory(ctx, taskID)
+	require.NoError(t, err)
+	require.Len(t, subTasks, 3)
 
 	// test GC history table.
 	testkit.EnableFailPoint(t, "github.com/pingcap/tidb/pkg/disttask/framework/storage/subtaskHistoryKeepSeconds", "return(1)")
@@ -872,6 +878,12 @@ func TestTaskHistoryTable(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, task)
 
+	tasks, err = gm.GetTasksWithHistory(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	require.Equal(t, oldTasks[0].ID, tasks[0].ID)
+	require.Equal(t, oldTasks[1].ID, tasks[1].ID)
+
 	// task with fail transfer
 	_, err = gm.CreateTask(ctx, "3", proto.TaskTypeExample, 1, nil)
 	require.NoError(t, err)
@@ -340,6 +340,20 @@ func (mgr *TaskManager) GetTaskByIDWithHistory(ctx context.Context, taskID int64
 	return Row2Task(rs[0]), nil
 }
 
+// GetTasksWithHistory gets all the tasks from both tidb_global_task and tidb_global_task_history, order by id.
+func (mgr *TaskManager) GetTasksWithHistory(ctx context.Context) (task []*proto.Task, err error) {
+	rs, err := mgr.ExecuteSQLWithNewSession(ctx, "select "+TaskColumns+" from mysql.tidb_global_task t "+
+		"union select "+TaskColumns+" from mysql.tidb_global_task_history t order by id")
+	if err != nil {
+		return task, err
+	}
+
+	for _, r := range rs {
+		task = append(task, Row2Task(r))
+	}
+	return task, nil
+}
+
 // GetTaskBaseByIDWithHistory gets the task by the task ID from both tidb_global_task and tidb_global_task_history.
 func (mgr *TaskManager) GetTaskBaseByIDWithHistory(ctx context.Context, taskID int64) (task *proto.TaskBase, err error) {
 	rs, err := mgr.ExecuteSQLWithNewSession(ctx, "select "+basicTaskColumns+" from mysql.tidb_global_task t where id = %? "+
@@ -773,6 +787,26 @@ func (mgr *TaskManager) GetSubtasksWithHistory(ctx context.Context, taskID int64
 	return subtasks, nil
 }
 
+// GetSubtasksOfTaskWithHistory gets the subtasks of all steps of the task from
+// both tidb_background_subtask and tidb_background_subtask_history, order by id.
+func (mgr *TaskManager) GetSubtasksOfTaskWithHistory(ctx context.Context, taskID int64) ([]*proto.Subtask, error) {
+	rs, err := mgr.ExecuteSQLWithNewSession(ctx,
+		`select `+SubtaskColumns+` from mysql.tidb_background_subtask where task_key = %? `+
+			`union select `+SubtaskColumns+` from mysql.tidb_background_subtask_history where task_key = %? order by id`,
+		taskID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rs) == 0 {
+		return nil, nil
+	}
+	subtasks := make([]*proto.Subtask, 0, len(rs))
+	for _, r := range rs {
+		subtasks = append(subtasks, Row2SubTask(r))
+	}
+	return subtasks, nil
+}
+
 // GetAllSubtasks gets all subtasks with basic columns.
 func (mgr *TaskManager) GetAllSubtasks(ctx context.Context) ([]*proto.SubtaskBase, error) {
 	rs, err := mgr.ExecuteSQLWithNewSession(ctx, `select `+basicSubtaskColumns+` from mysql.tidb_background_subtask`)
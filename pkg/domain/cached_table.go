@@ -0,0 +1,92 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+// cachedTableDemoteCheckInterval is the interval to check whether the cached tables exceed the size limit.
+var cachedTableDemoteCheckInterval = 10 * time.Second
+
+// cachedTableDemoteLoop demotes the cached tables which exceed the size limit to normal tables.
+// The size of a cached table is only known by the TiDB instances which load its data, so the
+// work is done on every instance rather than only on the DDL owner.
+func (do *Domain) cachedTableDemoteLoop() {
+	defer util.Recover(metrics.LabelDomain, "cachedTableDemoteLoop", nil, false)
+	ticker := time.NewTicker(cachedTableDemoteCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-do.exit:
+			return
+		case <-ticker.C:
+			do.DemoteOversizeCachedTables()
+		}
+	}
+}
+
+// DemoteOversizeCachedTables runs ALTER TABLE ... NOCACHE for all the cached tables which exceed
+// the size limit. Errors are logged and the next table is processed.
+func (do *Domain) DemoteOversizeCachedTables() {
+	is := do.InfoSchema()
+	for _, db := range is.ListTablesWithSpecialAttribute(infoschema.CachedTableAttribute) {
+		if util.IsMemOrSysDB(db.DBName.L) {
+			continue
+		}
+		for _, tbl := range db.Tables {
+			if tbl.Meta().TableCacheStatusType != model.TableCacheStatusEnable {
+				continue
+			}
+			cached, ok := tbl.(table.CachedTable)
+			if !ok || !cached.CacheStatus().TooLarge {
+				continue
+			}
+			if err := do.demoteCachedTable(db.DBName, tbl.Meta()); err != nil {
+				logutil.BgLogger().Warn("demote cached table failed",
+					zap.String("schema", db.DBName.O), zap.String("table", tbl.Meta().Name.O), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (do *Domain) demoteCachedTable(dbName model.CIStr, tblInfo *model.TableInfo) error {
+	se, err := do.sysSessionPool.Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer do.sysSessionPool.Put(se)
+	sctx := se.(sessionctx.Context)
+
+	logutil.BgLogger().Info("demote cached table exceeding the size limit",
+		zap.String("schema", dbName.O), zap.String("table", tblInfo.Name.O))
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
+	_, _, err = sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession},
+		"ALTER TABLE %n.%n NOCACHE", dbName.O, tblInfo.Name.O)
+	return errors.Trace(err)
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// checkTableJobResumeInterval is the interval to check whether there are online check table jobs
+// left by the instances which are gone.
+var checkTableJobResumeInterval = time.Minute
+
+// CheckTableJobResumer resumes the online check table jobs left by the instances which are gone.
+type CheckTableJobResumer func(ctx context.Context) error
+
+// StartCheckTableJobResumer starts the loop which resumes the running online check table jobs whose
+// instances are gone or restarted. Only the DDL owner resumes the jobs.
+func (do *Domain) StartCheckTableJobResumer(resume CheckTableJobResumer) {
+	do.wg.Run(func() {
+		defer func() {
+			logutil.BgLogger().Info("checkTableJobResumeLoop exited.")
+		}()
+		defer util.Recover(metrics.LabelDomain, "checkTableJobResumeLoop", nil, false)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ticker := time.NewTicker(checkTableJobResumeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-do.exit:
+				return
+			case <-ticker.C:
+				if !do.ddl.OwnerManager().IsOwner() {
+					continue
+				}
+				if err := resume(ctx); err != nil {
+					logutil.BgLogger().Warn("resume check table jobs failed", zap.Error(err))
+				}
+			}
+		}
+	}, "checkTableJobResumeLoop")
+}
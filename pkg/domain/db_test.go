@@ -24,9 +24,15 @@ import (
 	"github.com/pingcap/tidb/pkg/ddl"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/keyspace"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/server"
 	"github.com/pingcap/tidb/pkg/session"
 	"github.com/pingcap/tidb/pkg/store/mockstore"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -125,3 +131,91 @@ func TestAbnormalSessionPool(t *testing.T) {
 	failpoint.Disable("github.com/pingcap/tidb/pkg/domain/mockSessionPoolReturnError")
 	require.Equal(t, svr.InternalSessionExists(se), false)
 }
+
+// createTableInMeta creates a table in the meta like the DDL executed by another TiDB instance, so the domain
+// doesn't load the new schema version until it's reloaded.
+func createTableInMeta(t *testing.T, store kv.Storage, dbInfo *model.DBInfo, tblName string) {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
+	err := kv.RunInNewTxn(ctx, store, true, func(_ context.Context, txn kv.Transaction) error {
+		m := meta.NewMeta(txn)
+		ids, err := m.GenGlobalIDs(2)
+		if err != nil {
+			return err
+		}
+		tblInfo := &model.TableInfo{
+			ID:   ids[0],
+			Name: model.NewCIStr(tblName),
+			Columns: []*model.ColumnInfo{{
+				ID:        ids[1],
+				Name:      model.NewCIStr("a"),
+				FieldType: *types.NewFieldType(mysql.TypeLonglong),
+				State:     model.StatePublic,
+			}},
+			State: model.StatePublic,
+		}
+		if err := m.CreateTableOrView(dbInfo.ID, dbInfo.Name.L, tblInfo); err != nil {
+			return err
+		}
+		ver, err := m.GenSchemaVersion()
+		if err != nil {
+			return err
+		}
+		return m.SetSchemaDiff(&model.SchemaDiff{Version: ver, Type: model.ActionCreateTable, SchemaID: dbInfo.ID, TableID: tblInfo.ID})
+	})
+	require.NoError(t, err)
+}
+
+func TestSchemaLoadFaults(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	dbInfo, ok := dom.InfoSchema().SchemaByName(model.NewCIStr("test"))
+	require.True(t, ok)
+
+	// The stale schema diff makes the infoschema fully loaded, which fails if the tables of a schema can't be listed.
+	staleDiff := testkit.InjectStaleSchemaDiff(t, dom.InfoSchema().SchemaMetaVersion()+1)
+	listTables := testkit.InjectListTablesError(t, "test")
+	createTableInMeta(t, store, dbInfo, "t1")
+	require.ErrorContains(t, dom.Reload(), "mock list tables error of schema test")
+
+	// The full load recovers after the tables can be listed.
+	listTables.Remove()
+	testkit.RequireSchemaLoadRecovered(t, dom)
+	tk.MustQuery("select count(*) from test.t1").Check(testkit.Rows("0"))
+
+	// The infoschema falls back to the full load if a schema diff can't be applied.
+	staleDiff.Remove()
+	testkit.InjectApplyDiffError(t, model.ActionCreateTable)
+	createTableInMeta(t, store, dbInfo, "t2")
+	testkit.RequireSchemaLoadRecovered(t, dom)
+	tk.MustQuery("select count(*) from test.t2").Check(testkit.Rows("0"))
+}
+
+func TestLaggingNodeSchemaSync(t *testing.T) {
+	leases := []time.Duration{500 * time.Millisecond, time.Second}
+	tc := testkit.NewDistExecutionContextWithSchemaLeases(t, leases)
+	defer tc.Close()
+	for i, lease := range leases {
+		require.Equal(t, lease, tc.GetDomain(i).DDL().GetLease())
+	}
+
+	// The last domain is the DDL owner, and the first one lags behind the latest schema version.
+	owner, lagging := tc.NewTestKit(1), tc.NewTestKit(0)
+	owner.MustExec("create table test.t (a int)")
+	owner.MustExec("insert into test.t values (1)")
+	require.NoError(t, tc.GetDomain(0).Reload())
+	lagging.MustQuery("select * from test.t").Check(testkit.Rows("1"))
+
+	tc.PauseSchemaLoad(0)
+	laggingVer := tc.SchemaVersion(0)
+	owner.MustExec("alter table test.t add column b int default 2")
+	require.Greater(t, tc.SchemaVersion(1), laggingVer)
+	// The schema isn't loaded in the loop of the paused domain either.
+	time.Sleep(leases[0])
+	require.Equal(t, laggingVer, tc.SchemaVersion(0))
+	lagging.MustQuery("select * from test.t").Check(testkit.Rows("1"))
+	owner.MustQuery("select * from test.t").Check(testkit.Rows("1 2"))
+
+	tc.ResumeSchemaLoad(0)
+	require.Equal(t, tc.SchemaVersion(1), tc.SchemaVersion(0))
+	lagging.MustQuery("select * from test.t").Check(testkit.Rows("1 2"))
+}
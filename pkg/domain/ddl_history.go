@@ -0,0 +1,72 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/ddl"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// ddlHistoryCompactionInterval is the interval to delete the history DDL jobs out of the retention.
+var ddlHistoryCompactionInterval = 10 * time.Minute
+
+// ddlHistoryCompactionLoop deletes the history DDL jobs finished longer than tidb_ddl_history_retention ago.
+// Only the DDL owner does the work.
+func (do *Domain) ddlHistoryCompactionLoop() {
+	defer util.Recover(metrics.LabelDomain, "ddlHistoryCompactionLoop", nil, false)
+	ticker := time.NewTicker(ddlHistoryCompactionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-do.exit:
+			return
+		case <-ticker.C:
+			if !do.ddl.OwnerManager().IsOwner() {
+				continue
+			}
+			if err := do.CompactDDLHistory(); err != nil {
+				logutil.BgLogger().Warn("compact DDL history failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// CompactDDLHistory deletes the history DDL jobs out of the retention. It does nothing if the retention is 0.
+func (do *Domain) CompactDDLHistory() error {
+	retention := variable.DDLHistoryRetention.Load()
+	if retention <= 0 {
+		return nil
+	}
+	se, err := do.sysSessionPool.Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer do.sysSessionPool.Put(se)
+
+	deleted, err := ddl.CompactHistoryDDLJobs(se.(sessionctx.Context), time.Now().Add(-retention))
+	if deleted > 0 {
+		logutil.BgLogger().Info("delete history DDL jobs out of the retention",
+			zap.Duration("retention", retention), zap.Int("count", deleted))
+	}
+	return errors.Trace(err)
+}
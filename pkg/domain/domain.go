@@ -16,6 +16,7 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 	"math/rand"
@@ -84,10 +85,12 @@ import (
 	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/pingcap/tidb/pkg/util/memoryusagealarm"
 	"github.com/pingcap/tidb/pkg/util/replayer"
+	"github.com/pingcap/tidb/pkg/util/resultcache"
 	"github.com/pingcap/tidb/pkg/util/servermemorylimit"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
 	"github.com/pingcap/tidb/pkg/util/sqlkiller"
 	"github.com/pingcap/tidb/pkg/util/syncutil"
+	"github.com/tikv/client-go/v2/oracle"
 	"github.com/tikv/client-go/v2/tikv"
 	"github.com/tikv/client-go/v2/txnkv/transaction"
 	pd "github.com/tikv/pd/client"
@@ -174,6 +177,9 @@ type Domain struct {
 
 	logBackupAdvancer        *daemon.OwnerDaemon
 	historicalStatsWorker    *HistoricalStatsWorker
+	eventRunner              EventRunner
+	mvRefresher              MaterializedViewRefresher
+	hotspotMitigation        hotspotMitigation
 	ttlJobManager            atomic.Pointer[ttlworker.JobManager]
 	runawayManager           *resourcegroup.RunawayManager
 	runawaySyncer            *runawaySyncer
@@ -198,6 +204,10 @@ type Domain struct {
 
 	mdlCheckCh      chan struct{}
 	stopAutoAnalyze atomicutil.Bool
+	// schemaLoadPaused is only used in the tests to simulate a TiDB node lagging behind the latest schema version.
+	schemaLoadPaused atomicutil.Bool
+
+	resultCache *resultcache.Cache
 }
 
 type mdlCheckTableInfo struct {
@@ -274,6 +284,8 @@ func (do *Domain) loadInfoSchema(startTS uint64) (infoschema.InfoSchema, bool, i
 		is, relatedChanges, diffTypes, err := do.tryLoadSchemaDiffs(m, currentSchemaVersion, neededSchemaVersion)
 		if err == nil {
 			infoschema_metrics.LoadSchemaDurationLoadDiff.Observe(time.Since(startTime).Seconds())
+			infoschema_metrics.DiffLoadDurationOK.Observe(time.Since(startTime).Seconds())
+			observeSchemaSyncLag(schemaTs)
 			do.infoCache.Insert(is, uint64(schemaTs))
 			logutil.BgLogger().Info("diff load InfoSchema success",
 				zap.Int64("currentSchemaVersion", currentSchemaVersion),
@@ -286,37 +298,91 @@ func (do *Domain) loadInfoSchema(startTS uint64) (infoschema.InfoSchema, bool, i
 			return is, false, currentSchemaVersion, relatedChanges, nil
 		}
 		// We can fall back to full load, don't need to return the error.
+		infoschema_metrics.DiffLoadDurationErr.Observe(time.Since(startTime).Seconds())
 		logutil.BgLogger().Error("failed to load schema diff", zap.Error(err))
 	}
 
-	schemas, err := do.fetchAllSchemasWithTables(m)
+	fullLoadStartTime := time.Now()
+	is, err := do.fullLoadInfoSchema(m, neededSchemaVersion)
 	if err != nil {
+		infoschema_metrics.FullLoadDurationErr.Observe(time.Since(fullLoadStartTime).Seconds())
 		return nil, false, currentSchemaVersion, nil, err
 	}
+	infoschema_metrics.FullLoadDurationOK.Observe(time.Since(fullLoadStartTime).Seconds())
+	infoschema_metrics.LoadSchemaDurationLoadAll.Observe(time.Since(startTime).Seconds())
+	if currentSchemaVersion != 0 && neededSchemaVersion > currentSchemaVersion {
+		observeSchemaSyncLag(schemaTs)
+	}
+	logutil.BgLogger().Info("full load InfoSchema success",
+		zap.Int64("currentSchemaVersion", currentSchemaVersion),
+		zap.Int64("neededSchemaVersion", neededSchemaVersion),
+		zap.Duration("start time", time.Since(startTime)))
+
+	do.infoCache.Insert(is, uint64(schemaTs))
+	return is, false, currentSchemaVersion, nil, nil
+}
+
+// observeSchemaSyncLag records the time from the schema version being committed
+// to being loaded by this node.
+func observeSchemaSyncLag(schemaTs int64) {
+	if schemaTs <= 0 {
+		return
+	}
+	lag := time.Since(oracle.GetTimeFromTS(uint64(schemaTs)))
+	infoschema_metrics.SchemaSyncLag.Observe(max(lag, 0).Seconds())
+}
+
+func (do *Domain) fullLoadInfoSchema(m *meta.Meta, neededSchemaVersion int64) (infoschema.InfoSchema, error) {
+	schemas, err := do.fetchAllSchemasWithTables(m)
+	if err != nil {
+		return nil, err
+	}
 
 	policies, err := do.fetchPolicies(m)
 	if err != nil {
-		return nil, false, currentSchemaVersion, nil, err
+		return nil, err
 	}
 
 	resourceGroups, err := do.fetchResourceGroups(m)
 	if err != nil {
-		return nil, false, currentSchemaVersion, nil, err
+		return nil, err
 	}
 
-	newISBuilder, err := infoschema.NewBuilder(do, do.sysFacHack, do.infoCache.Data).InitWithDBInfos(schemas, policies, resourceGroups, neededSchemaVersion)
+	newISBuilder, err := infoschema.NewBuilder(do, do.sysFacHack, do.infoCache.Data).ShareTablesWith(do.infoCache).InitWithDBInfos(schemas, policies, resourceGroups, neededSchemaVersion)
 	if err != nil {
-		return nil, false, currentSchemaVersion, nil, err
+		return nil, err
 	}
-	infoschema_metrics.LoadSchemaDurationLoadAll.Observe(time.Since(startTime).Seconds())
-	logutil.BgLogger().Info("full load InfoSchema success",
-		zap.Int64("currentSchemaVersion", currentSchemaVersion),
-		zap.Int64("neededSchemaVersion", neededSchemaVersion),
-		zap.Duration("start time", time.Since(startTime)))
+	return newISBuilder.Build(), nil
+}
 
-	is := newISBuilder.Build()
-	do.infoCache.Insert(is, uint64(schemaTs))
-	return is, false, currentSchemaVersion, nil, nil
+// ReloadMetricSchema fully reloads the InfoSchema so that the changes of the
+// user-defined metric tables take effect in metrics_schema. The schema version
+// is unchanged, so the cached InfoSchema of the same version is replaced.
+func (do *Domain) ReloadMetricSchema() error {
+	do.m.Lock()
+	defer do.m.Unlock()
+
+	startTime := time.Now()
+	ver, err := do.store.CurrentVersion(kv.GlobalTxnScope)
+	if err != nil {
+		return err
+	}
+	m := meta.NewSnapshotMeta(do.store.GetSnapshot(ver))
+	neededSchemaVersion, err := m.GetSchemaVersionWithNonEmptyDiff()
+	if err != nil {
+		return err
+	}
+	is, err := do.fullLoadInfoSchema(m, neededSchemaVersion)
+	if err != nil {
+		return err
+	}
+	if !do.infoCache.Replace(is) {
+		do.infoCache.Insert(is, 0)
+	}
+	logutil.BgLogger().Info("reload metric schema success",
+		zap.Int64("schemaVersion", neededSchemaVersion),
+		zap.Duration("take time", time.Since(startTime)))
+	return nil
 }
 
 // Returns the timestamp of a schema version, which is the commit timestamp of the schema diff
@@ -406,6 +472,12 @@ func (*Domain) fetchSchemasWithTables(schemas []*model.DBInfo, m *meta.Meta, don
 			// schema is not public, can't be used outside.
 			continue
 		}
+		failpoint.Inject("mockListTablesError", func(val failpoint.Value) {
+			if val.(string) == di.Name.L {
+				done <- errors.Errorf("mock list tables error of schema %s", di.Name.O)
+				failpoint.Return()
+			}
+		})
 		tables, err := m.ListTables(di.ID)
 		if err != nil {
 			done <- err
@@ -446,6 +518,11 @@ func (do *Domain) tryLoadSchemaDiffs(m *meta.Meta, usedVersion, newVersion int64
 		if err != nil {
 			return nil, nil, nil, err
 		}
+		failpoint.Inject("mockStaleSchemaDiff", func(val failpoint.Value) {
+			if int64(val.(int)) == usedVersion {
+				failpoint.Return(nil, nil, nil, errors.Errorf("mock stale schema diff of version %d", usedVersion))
+			}
+		})
 		if diff == nil {
 			// Empty diff means the txn of generating schema version is committed, but the txn of `runDDLJob` is not or fail.
 			// It is safe to skip the empty diff because the infoschema is new enough and consistent.
@@ -453,7 +530,7 @@ func (do *Domain) tryLoadSchemaDiffs(m *meta.Meta, usedVersion, newVersion int64
 		}
 		diffs = append(diffs, diff)
 	}
-	builder, err := infoschema.NewBuilder(do, do.sysFacHack, do.infoCache.Data).InitWithOldInfoSchema(do.infoCache.GetLatest())
+	builder, err := infoschema.NewBuilder(do, do.sysFacHack, do.infoCache.Data).ShareTablesWith(do.infoCache).InitWithOldInfoSchema(do.infoCache.GetLatest())
 	if err != nil {
 		return nil, nil, nil, errors.Trace(err)
 	}
@@ -510,6 +587,31 @@ func (do *Domain) GetSnapshotInfoSchema(snapshotTS uint64) (infoschema.InfoSchem
 	return is, err
 }
 
+// GetInfoSchemaByVersion gets the infoschema of the schema version, it's loaded
+// from the snapshot at the commit ts of the schema diff if it's not cached.
+func (do *Domain) GetInfoSchemaByVersion(version int64) (infoschema.InfoSchema, error) {
+	if is := do.infoCache.GetByVersion(version); is != nil {
+		return is, nil
+	}
+	ver, err := do.store.CurrentVersion(kv.GlobalTxnScope)
+	if err != nil {
+		return nil, err
+	}
+	m := meta.NewSnapshotMeta(do.store.GetSnapshot(ver))
+	diff, err := m.GetSchemaDiff(version)
+	if err != nil {
+		return nil, err
+	}
+	if diff == nil {
+		return nil, errors.Errorf("the schema diff of version %d is not found", version)
+	}
+	commitTS, err := do.getTimestampForSchemaVersionWithNonEmptyDiff(m, version, ver.Ver)
+	if err != nil {
+		return nil, err
+	}
+	return do.GetSnapshotInfoSchema(uint64(commitTS))
+}
+
 // GetSnapshotMeta gets a new snapshot meta at startTS.
 func (do *Domain) GetSnapshotMeta(startTS uint64) (*meta.Meta, error) {
 	snapshot := do.store.GetSnapshot(kv.NewVersion(startTS))
@@ -588,6 +690,9 @@ func (do *Domain) Reload() error {
 			failpoint.Return(errors.New("mock reload failed"))
 		}
 	})
+	if intest.InTest && do.schemaLoadPaused.Load() {
+		return nil
+	}
 
 	// Lock here for only once at the same time.
 	do.m.Lock()
@@ -613,6 +718,7 @@ func (do *Domain) Reload() error {
 		return err
 	}
 	metrics.LoadSchemaCounter.WithLabelValues("succ").Inc()
+	infoschema_metrics.SchemaVersion.Set(float64(is.SchemaMetaVersion()))
 
 	// only update if it is not from cache
 	if !hitCache {
@@ -631,6 +737,9 @@ func (do *Domain) Reload() error {
 		if changes == nil {
 			logutil.BgLogger().Info("full load and reset schema validator")
 			do.SchemaValidator.Reset()
+			do.resultCache.InvalidateAll()
+		} else {
+			do.resultCache.InvalidateTables(changes.PhyTblIDS...)
 		}
 	}
 
@@ -712,10 +821,15 @@ func (do *Domain) infoSyncerKeeper() {
 
 	ticker := time.NewTicker(infosync.ReportInterval)
 	defer ticker.Stop()
+	serversHistoryTicker := time.NewTicker(infosync.ServersHistoryRefreshInterval)
+	defer serversHistoryTicker.Stop()
+	do.updateServersHistory()
 	for {
 		select {
 		case <-ticker.C:
 			do.info.ReportMinStartTS(do.Store())
+		case <-serversHistoryTicker.C:
+			do.updateServersHistory()
 		case <-do.info.Done():
 			logutil.BgLogger().Info("server info syncer need to restart")
 			if err := do.info.Restart(context.Background()); err != nil {
@@ -729,6 +843,14 @@ func (do *Domain) infoSyncerKeeper() {
 	}
 }
 
+func (do *Domain) updateServersHistory() {
+	ctx, cancel := context.WithTimeout(context.Background(), infosync.ServersHistoryRefreshInterval)
+	defer cancel()
+	if err := infosync.UpdateServersHistory(ctx); err != nil {
+		logutil.BgLogger().Warn("update servers history failed", zap.Error(err))
+	}
+}
+
 func (do *Domain) globalConfigSyncerKeeper() {
 	defer func() {
 		logutil.BgLogger().Info("globalConfigSyncerKeeper exited.")
@@ -1086,7 +1208,8 @@ func NewDomain(store kv.Storage, ddlLease time.Duration, statsLease time.Duratio
 			jobsVerMap: make(map[int64]int64),
 			jobsIDsMap: make(map[int64]string),
 		},
-		mdlCheckCh: make(chan struct{}),
+		mdlCheckCh:  make(chan struct{}),
+		resultCache: resultcache.New(variable.ResultCacheMaxSize.Load()),
 	}
 
 	do.infoCache = infoschema.NewCache(do, int(variable.SchemaVersionCacheLimit.Load()))
@@ -1289,6 +1412,10 @@ func (do *Domain) Init(
 	do.wg.Run(do.runawayRecordFlushLoop, "runawayRecordFlushLoop")
 	do.wg.Run(do.runawayWatchSyncLoop, "runawayWatchSyncLoop")
 	do.wg.Run(do.requestUnitsWriterLoop, "requestUnitsWriterLoop")
+	do.wg.Run(do.intervalPartitionLoop, "intervalPartitionLoop")
+	do.wg.Run(do.stagingTableCleanupLoop, "stagingTableCleanupLoop")
+	do.wg.Run(do.ddlHistoryCompactionLoop, "ddlHistoryCompactionLoop")
+	do.wg.Run(do.cachedTableDemoteLoop, "cachedTableDemoteLoop")
 	if !skipRegisterToDashboard {
 		do.wg.Run(do.topologySyncerKeeper, "topologySyncerKeeper")
 	}
@@ -1311,6 +1438,17 @@ func (do *Domain) InitInfo4Test() {
 	infosync.MockGlobalServerInfoManagerEntry.Add(do.ddl.GetID(), do.ServerID)
 }
 
+// PauseSchemaLoad4Test makes the domain stop loading the newer schema and renewing the schema lease, until
+// ResumeSchemaLoad4Test is called. It's used to simulate a TiDB node lagging behind the latest schema version.
+func (do *Domain) PauseSchemaLoad4Test() {
+	do.schemaLoadPaused.Store(true)
+}
+
+// ResumeSchemaLoad4Test resumes the schema loading paused by PauseSchemaLoad4Test.
+func (do *Domain) ResumeSchemaLoad4Test() {
+	do.schemaLoadPaused.Store(false)
+}
+
 // SetOnClose used to set do.onClose func.
 func (do *Domain) SetOnClose(onClose func()) {
 	do.onClose = onClose
@@ -1633,6 +1771,26 @@ func (do *Domain) SysSessionPool() *sessionPool {
 	return do.sysSessionPool
 }
 
+// RunBackgroundJob runs fn in a goroutine which is waited when the domain is closing.
+// The context passed to fn is canceled once the domain starts to close. The label
+// must be unique among the running background jobs.
+func (do *Domain) RunBackgroundJob(label string, fn func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	do.wg.RunWithRecover(func() {
+		defer cancel()
+		go func() {
+			select {
+			case <-do.exit:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		fn(ctx)
+	}, func(r any) {
+		logutil.BgLogger().Error("background job panicked", zap.String("label", label), zap.Any("recover", r), zap.Stack("stack"))
+	}, label)
+}
+
 // SysProcTracker returns the system processes tracker.
 func (do *Domain) SysProcTracker() sessionctx.SysProcTracker {
 	return &do.sysProcesses
@@ -1695,10 +1853,13 @@ func (do *Domain) LoadPrivilegeLoop(sctx sessionctx.Context) error {
 		var count int
 		for {
 			ok := true
+			var users []string
 			select {
 			case <-do.exit:
 				return
-			case _, ok = <-watchCh:
+			case resp, open := <-watchCh:
+				ok = open
+				users = usersToUpdatePrivilege(resp)
 			case <-time.After(duration):
 			}
 			if !ok {
@@ -1712,7 +1873,12 @@ func (do *Domain) LoadPrivilegeLoop(sctx sessionctx.Context) error {
 			}
 
 			count = 0
-			err := do.privHandle.Update(sctx)
+			var err error
+			if len(users) > 0 {
+				err = do.privHandle.UpdateUsers(sctx, users)
+			} else {
+				err = do.privHandle.Update(sctx)
+			}
 			metrics.LoadPrivilegeCounter.WithLabelValues(metrics.RetLabel(err)).Inc()
 			if err != nil {
 				logutil.BgLogger().Error("load privilege failed", zap.Error(err))
@@ -1785,6 +1951,62 @@ func (do *Domain) LoadSysVarCacheLoop(ctx sessionctx.Context) error {
 	return nil
 }
 
+// LoadMetricTablesLoop loads the user-defined metric tables by load and reloads metrics_schema if they're changed,
+// then creates a goroutine to do it again when the other TiDB instances notify the changes by
+// NotifyReloadMetricTables, or periodically in case the notification is lost.
+// It should be called only once in BootstrapSession.
+func (do *Domain) LoadMetricTablesLoop(sctx sessionctx.Context, load func(context.Context, sessionctx.Context) (bool, error)) error {
+	sctx.GetSessionVars().InRestrictedSQL = true
+	reload := func() error {
+		ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+		changed, err := load(ctx, sctx)
+		if err != nil || !changed {
+			return err
+		}
+		return do.ReloadMetricSchema()
+	}
+	if err := reload(); err != nil {
+		return err
+	}
+	var watchCh clientv3.WatchChan
+	duration := time.Minute
+	if do.etcdClient != nil {
+		watchCh = do.etcdClient.Watch(context.Background(), metricTablesKey)
+	}
+
+	do.wg.Run(func() {
+		defer func() {
+			logutil.BgLogger().Info("LoadMetricTablesLoop exited.")
+		}()
+		defer util.Recover(metrics.LabelDomain, "LoadMetricTablesLoop", nil, false)
+
+		var count int
+		for {
+			ok := true
+			select {
+			case <-do.exit:
+				return
+			case _, ok = <-watchCh:
+			case <-time.After(duration):
+			}
+			if !ok {
+				logutil.BgLogger().Error("LoadMetricTablesLoop loop watch channel closed")
+				watchCh = do.etcdClient.Watch(context.Background(), metricTablesKey)
+				count++
+				if count > 10 {
+					time.Sleep(time.Duration(count) * time.Second)
+				}
+				continue
+			}
+			count = 0
+			if err := reload(); err != nil {
+				logutil.BgLogger().Error("LoadMetricTablesLoop failed", zap.Error(err))
+			}
+		}
+	}, "LoadMetricTablesLoop")
+	return nil
+}
+
 // WatchTiFlashComputeNodeChange create a routine to watch if the topology of tiflash_compute node is changed.
 // TODO: tiflashComputeNodeKey is not put to etcd yet(finish this when AutoScaler is done)
 //
@@ -1937,15 +2159,21 @@ func (do *Domain) SetupPlanReplayerHandle(collectorSctx sessionctx.Context, work
 	do.planReplayerHandle.planReplayerTaskDumpHandle.workers = make([]*planReplayerTaskDumpWorker, 0)
 	for i := 0; i < len(workersSctxs); i++ {
 		worker := &planReplayerTaskDumpWorker{
-			ctx:    ctx,
-			sctx:   workersSctxs[i],
-			taskCH: taskCH,
-			status: taskStatus,
+			ctx:       ctx,
+			sctx:      workersSctxs[i],
+			taskCH:    taskCH,
+			status:    taskStatus,
+			gcChecker: do.dumpFileGcChecker,
 		}
 		do.planReplayerHandle.planReplayerTaskDumpHandle.workers = append(do.planReplayerHandle.planReplayerTaskDumpHandle.workers, worker)
 	}
 }
 
+// ResultCache returns the result cache of the queries.
+func (do *Domain) ResultCache() *resultcache.Cache {
+	return do.resultCache
+}
+
 // RunawayManager returns the runaway manager.
 func (do *Domain) RunawayManager() *resourcegroup.RunawayManager {
 	return do.runawayManager
@@ -2527,18 +2755,62 @@ func (do *Domain) ServerMemoryLimitHandle() *servermemorylimit.Handle {
 const (
 	privilegeKey          = "/tidb/privilege"
 	sysVarCacheKey        = "/tidb/sysvars"
+	metricTablesKey       = "/tidb/metric_tables"
 	tiflashComputeNodeKey = "/tiflash/new_tiflash_compute_nodes"
 )
 
+// privilegeEvent is the value put to the privilege key in etcd when only the
+// privileges of some users are changed. An empty value means all the privileges
+// need to be reloaded, which is also what the old TiDB instances do for any value.
+type privilegeEvent struct {
+	UserList []string `json:"user_list"`
+}
+
+// usersToUpdatePrivilege returns the users whose privileges need to be reloaded
+// for the watch response, nil means all the privileges need to be reloaded.
+func usersToUpdatePrivilege(resp clientv3.WatchResponse) []string {
+	var users []string
+	for _, ev := range resp.Events {
+		if ev.Kv == nil || len(ev.Kv.Value) == 0 {
+			return nil
+		}
+		var event privilegeEvent
+		if err := json.Unmarshal(ev.Kv.Value, &event); err != nil || len(event.UserList) == 0 {
+			return nil
+		}
+		users = append(users, event.UserList...)
+	}
+	return users
+}
+
 // NotifyUpdatePrivilege updates privilege key in etcd, TiDB client that watches
 // the key will get notification.
 func (do *Domain) NotifyUpdatePrivilege() error {
+	return do.notifyUpdatePrivilege(nil)
+}
+
+// NotifyUpdatePrivilegeForUsers is like NotifyUpdatePrivilege, but only the
+// privileges of the given users are reloaded, both locally and by the other
+// TiDB instances. All the privileges are reloaded if users is empty.
+func (do *Domain) NotifyUpdatePrivilegeForUsers(users []string) error {
+	return do.notifyUpdatePrivilege(users)
+}
+
+func (do *Domain) notifyUpdatePrivilege(users []string) error {
 	// No matter skip-grant-table is configured or not, sending an etcd message is required.
 	// Because we need to tell other TiDB instances to update privilege data, say, we're changing the
 	// password using a special TiDB instance and want the new password to take effect.
 	if do.etcdClient != nil {
+		var value string
+		if len(users) > 0 {
+			data, err := json.Marshal(privilegeEvent{UserList: users})
+			if err != nil {
+				return errors.Trace(err)
+			}
+			value = string(data)
+		}
 		row := do.etcdClient.KV
-		_, err := row.Put(context.Background(), privilegeKey, "")
+		_, err := row.Put(context.Background(), privilegeKey, value)
 		if err != nil {
 			logutil.BgLogger().Warn("notify update privilege failed", zap.Error(err))
 		}
@@ -2558,6 +2830,9 @@ func (do *Domain) NotifyUpdatePrivilege() error {
 		return err
 	}
 	defer sysSessionPool.Put(ctx)
+	if len(users) > 0 {
+		return do.PrivilegeHandle().UpdateUsers(ctx.(sessionctx.Context), users)
+	}
 	return do.PrivilegeHandle().Update(ctx.(sessionctx.Context))
 }
 
@@ -2580,6 +2855,18 @@ func (do *Domain) NotifyUpdateSysVarCache(updateLocal bool) {
 	}
 }
 
+// NotifyReloadMetricTables updates the metric tables key in etcd, the other TiDB instances watching the key
+// reload the user-defined metric tables.
+func (do *Domain) NotifyReloadMetricTables() {
+	if do.etcdClient != nil {
+		row := do.etcdClient.KV
+		_, err := row.Put(context.Background(), metricTablesKey, "")
+		if err != nil {
+			logutil.BgLogger().Warn("notify reload metric tables failed", zap.Error(err))
+		}
+	}
+}
+
 // LoadSigningCertLoop loads the signing cert periodically to make sure it's fresh new.
 func (do *Domain) LoadSigningCertLoop(signingCert, signingKey string) {
 	sessionstates.SetCertPath(signingCert)
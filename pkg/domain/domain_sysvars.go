@@ -31,6 +31,8 @@ import (
 func (do *Domain) initDomainSysVars() {
 	setStatsCacheCapacityFunc := do.setStatsCacheCapacity
 	variable.SetStatsCacheCapacity.Store(&setStatsCacheCapacityFunc)
+	setResultCacheCapacityFunc := do.resultCache.SetCapacity
+	variable.SetResultCacheCapacity.Store(&setResultCacheCapacityFunc)
 	pdClientDynamicOptionFunc := do.setPDClientDynamicOption
 	variable.SetPDClientDynamicOption.Store(&pdClientDynamicOptionFunc)
 
@@ -42,6 +42,8 @@ import (
 	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 	pd "github.com/tikv/pd/client"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/tests/v3/integration"
 )
 
@@ -467,3 +469,21 @@ func TestIsAnalyzeTableSQL(t *testing.T) {
 		require.True(t, isAnalyzeTableSQL(tt.sql))
 	}
 }
+
+func TestUsersToUpdatePrivilege(t *testing.T) {
+	watchResp := func(values ...string) clientv3.WatchResponse {
+		var resp clientv3.WatchResponse
+		for _, v := range values {
+			resp.Events = append(resp.Events, &clientv3.Event{Kv: &mvccpb.KeyValue{Key: []byte(privilegeKey), Value: []byte(v)}})
+		}
+		return resp
+	}
+	require.Nil(t, usersToUpdatePrivilege(clientv3.WatchResponse{}))
+	require.Nil(t, usersToUpdatePrivilege(watchResp("")))
+	require.Nil(t, usersToUpdatePrivilege(watchResp("invalid")))
+	require.Nil(t, usersToUpdatePrivilege(watchResp(`{"user_list":[]}`)))
+	require.Equal(t, []string{"u1", "u2"}, usersToUpdatePrivilege(watchResp(`{"user_list":["u1","u2"]}`)))
+	require.Equal(t, []string{"u1", "u2", "u3"}, usersToUpdatePrivilege(watchResp(`{"user_list":["u1","u2"]}`, `{"user_list":["u3"]}`)))
+	// Reload all the privileges if any of the events requires.
+	require.Nil(t, usersToUpdatePrivilege(watchResp(`{"user_list":["u1"]}`, "")))
+}
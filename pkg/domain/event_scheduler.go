@@ -0,0 +1,213 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+const (
+	eventSchedulerPrompt   = "event-scheduler"
+	eventSchedulerOwnerKey = "/tidb/event/owner"
+)
+
+// eventSchedulerCheckInterval is the interval to check whether there are events to be executed.
+var eventSchedulerCheckInterval = time.Second
+
+// EventInfo is an event to be executed by the event scheduler.
+type EventInfo struct {
+	Schema   string
+	Name     string
+	Definer  string
+	TimeZone string
+	SQLMode  string
+	Body     string
+}
+
+// EventRunner executes the body of an event.
+type EventRunner func(ctx context.Context, event *EventInfo) error
+
+// StartEventScheduler starts the loop which executes the events created by CREATE EVENT. Only the
+// owner of the event scheduler executes the events, and only when `event_scheduler` is ON.
+func (do *Domain) StartEventScheduler(run EventRunner) {
+	do.eventRunner = run
+	owner := do.newOwnerManager(eventSchedulerPrompt, eventSchedulerOwnerKey)
+	do.wg.Run(func() {
+		defer func() {
+			logutil.BgLogger().Info("eventSchedulerLoop exited.")
+		}()
+		defer util.Recover(metrics.LabelDomain, "eventSchedulerLoop", nil, false)
+
+		ticker := time.NewTicker(eventSchedulerCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-do.exit:
+				owner.Cancel()
+				return
+			case <-ticker.C:
+				if !owner.IsOwner() || !variable.EnableEventScheduler.Load() {
+					continue
+				}
+				if err := do.RunDueEvents(time.Now()); err != nil {
+					logutil.BgLogger().Warn("run events failed", zap.Error(err))
+				}
+			}
+		}
+	}, "eventSchedulerLoop")
+}
+
+// RunDueEvents executes the enabled events which are due at `now`, and records the status of the
+// executions in `mysql.events`. The completed events are dropped, or disabled if they are preserved.
+func (do *Domain) RunDueEvents(now time.Time) error {
+	if do.eventRunner == nil {
+		return nil
+	}
+	se, err := do.sysSessionPool.Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer do.sysSessionPool.Put(se)
+	exec := se.(sqlexec.RestrictedSQLExecutor)
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession},
+		`SELECT event_schema, event_name, definer, time_zone, sql_mode, body, UNIX_TIMESTAMP(execute_at), interval_value, interval_field,
+		UNIX_TIMESTAMP(starts), UNIX_TIMESTAMP(ends), on_completion, UNIX_TIMESTAMP(last_executed) FROM mysql.events WHERE status='ENABLED'`)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, row := range rows {
+		event := &EventInfo{
+			Schema:   row.GetString(0),
+			Name:     row.GetString(1),
+			Definer:  row.GetString(2),
+			TimeZone: row.GetString(3),
+			SQLMode:  row.GetString(4),
+			Body:     row.GetString(5),
+		}
+		due, completed := eventSchedule(row, now)
+		if due {
+			do.runEvent(ctx, exec, event, now)
+		}
+		if !completed {
+			continue
+		}
+		sql := "DELETE FROM mysql.events WHERE event_schema=%? AND event_name=%?"
+		if row.GetEnum(11).String() == "PRESERVE" {
+			sql = "UPDATE mysql.events SET status='DISABLED' WHERE event_schema=%? AND event_name=%?"
+		}
+		if _, _, err := exec.ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession},
+			sql, event.Schema, event.Name); err != nil {
+			logutil.BgLogger().Warn("complete event failed",
+				zap.String("schema", event.Schema), zap.String("event", event.Name), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (do *Domain) runEvent(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, event *EventInfo, now time.Time) {
+	lastStatus, lastError := "SUCCESS", any(nil)
+	if err := do.eventRunner(ctx, event); err != nil {
+		logutil.BgLogger().Warn("execute event failed",
+			zap.String("schema", event.Schema), zap.String("event", event.Name), zap.Error(err))
+		lastStatus, lastError = "FAILED", err.Error()
+	}
+	if _, _, err := exec.ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession},
+		"UPDATE mysql.events SET last_executed=FROM_UNIXTIME(%?), last_status=%?, last_error=%? WHERE event_schema=%? AND event_name=%?",
+		now.Unix(), lastStatus, lastError, event.Schema, event.Name); err != nil {
+		logutil.BgLogger().Warn("record event status failed",
+			zap.String("schema", event.Schema), zap.String("event", event.Name), zap.Error(err))
+	}
+}
+
+// eventSchedule returns whether the event in the row is due at `now`, and whether it's completed after
+// the execution at `now`.
+func eventSchedule(row chunk.Row, now time.Time) (due, completed bool) {
+	if !row.IsNull(6) {
+		// a one-time event is completed once it's executed.
+		due = !time.Unix(row.GetInt64(6), 0).After(now)
+		return due, due
+	}
+	value, unit := row.GetInt64(7), row.GetEnum(8).String()
+	starts := time.Unix(row.GetInt64(9), 0)
+	var lastExecuted time.Time
+	if !row.IsNull(12) {
+		lastExecuted = time.Unix(row.GetInt64(12), 0)
+	}
+	next := nextEventTime(starts, lastExecuted, value, unit)
+	if row.IsNull(10) {
+		return !next.After(now), false
+	}
+	ends := time.Unix(row.GetInt64(10), 0)
+	if next.After(ends) {
+		return false, true
+	}
+	due = !next.After(now)
+	if due {
+		completed = nextEventTime(starts, now, value, unit).After(ends)
+	}
+	return due, completed
+}
+
+// nextEventTime returns the first time after `after` in the schedule `starts + k * interval`.
+func nextEventTime(starts, after time.Time, value int64, unit string) time.Time {
+	if after.Before(starts) {
+		return starts
+	}
+	var months int64
+	var interval time.Duration
+	switch unit {
+	case "YEAR":
+		months = 12 * value
+	case "QUARTER":
+		months = 3 * value
+	case "MONTH":
+		months = value
+	case "WEEK":
+		interval = 7 * 24 * time.Hour
+	case "DAY":
+		interval = 24 * time.Hour
+	case "HOUR":
+		interval = time.Hour
+	case "MINUTE":
+		interval = time.Minute
+	default:
+		interval = time.Second
+	}
+	if months == 0 {
+		interval *= time.Duration(value)
+		return starts.Add(time.Duration(after.Sub(starts)/interval+1) * interval)
+	}
+	// the months between the times is an estimation of the number of intervals, it may be one less.
+	k := (int64(after.Year()-starts.Year())*12 + int64(after.Month()-starts.Month())) / months
+	for {
+		next := starts.AddDate(0, int(k*months), 0)
+		if next.After(after) {
+			return next
+		}
+		k++
+	}
+}
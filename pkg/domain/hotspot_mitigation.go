@@ -0,0 +1,341 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/store/helper"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+const (
+	hotspotMitigationPrompt   = "hotspot-mitigation"
+	hotspotMitigationOwnerKey = "/tidb/hotspot/owner"
+
+	// hotspotPreSplitRegions is the number of regions pre-split ahead of the hot key.
+	hotspotPreSplitRegions = 8
+	// hotspotPreSplitIDs is the range of the auto ids covered by the pre-split regions.
+	hotspotPreSplitIDs = 1 << 20
+	// hotspotPreSplitSeconds is the range of the time covered by the pre-split regions.
+	hotspotPreSplitSeconds = 60 * 60
+	// hotspotAutoIDCache is the auto id cache size the hot tables are enlarged to, so the ids cached by different
+	// TiDB instances fall into different pre-split regions.
+	hotspotAutoIDCache = hotspotPreSplitIDs / hotspotPreSplitRegions
+
+	hotspotAutoIncrement = "AUTO_INCREMENT"
+	hotspotTimestamp     = "TIMESTAMP"
+)
+
+var (
+	// hotspotMitigationInterval is the interval to fetch the hot write regions from PD.
+	hotspotMitigationInterval = time.Minute
+	// hotspotMitigationCooldown is the min interval to handle the hotspot on the same key again.
+	hotspotMitigationCooldown = 30 * time.Minute
+)
+
+// hotspotKey identifies the keys of a hotspot, indexID is 0 for the record keys.
+type hotspotKey struct {
+	physicalID int64
+	indexID    int64
+}
+
+// hotspot is a write hotspot caused by the monotonically increasing auto-increment or timestamp prefix of the keys.
+type hotspot struct {
+	hotspotKey
+	schema    string
+	table     *model.TableInfo
+	partition string
+	// index is nil if the hotspot is on the record keys.
+	index *model.IndexInfo
+	// column is the prefix column of the keys.
+	column    *model.ColumnInfo
+	tp        string
+	regionID  uint64
+	flowBytes uint64
+}
+
+// hotspotMitigation records when the hotspots are handled, to avoid handling the same hotspot repeatedly.
+type hotspotMitigation struct {
+	sync.Mutex
+	handledAt map[hotspotKey]time.Time
+}
+
+func (m *hotspotMitigation) tryHandle(key hotspotKey, now time.Time) bool {
+	m.Lock()
+	defer m.Unlock()
+	if m.handledAt == nil {
+		m.handledAt = make(map[hotspotKey]time.Time)
+	}
+	for k, t := range m.handledAt {
+		if now.Sub(t) >= hotspotMitigationCooldown {
+			delete(m.handledAt, k)
+		}
+	}
+	if _, ok := m.handledAt[key]; ok {
+		return false
+	}
+	m.handledAt[key] = now
+	return true
+}
+
+// StartHotspotMitigation starts the loop which detects the single-region write hotspots on the auto-increment or
+// timestamp prefixed keys from the hot regions of PD, and mitigates them according to `tidb_hotspot_mitigation_policy`.
+// Only the owner of the hotspot mitigation runs the loop.
+func (do *Domain) StartHotspotMitigation() {
+	owner := do.newOwnerManager(hotspotMitigationPrompt, hotspotMitigationOwnerKey)
+	do.wg.Run(func() {
+		defer func() {
+			logutil.BgLogger().Info("hotspotMitigationLoop exited.")
+		}()
+		defer util.Recover(metrics.LabelDomain, "hotspotMitigationLoop", nil, false)
+
+		ticker := time.NewTicker(hotspotMitigationInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-do.exit:
+				owner.Cancel()
+				return
+			case <-ticker.C:
+				if !owner.IsOwner() || variable.HotspotMitigationPolicy.Load() == variable.HotspotMitigationOff {
+					continue
+				}
+				hotRegions, err := do.fetchHotWriteRegions()
+				if err != nil {
+					logutil.BgLogger().Warn("fetch hot write regions failed", zap.Error(err))
+					continue
+				}
+				if err := do.MitigateHotspots(hotRegions, time.Now()); err != nil {
+					logutil.BgLogger().Warn("mitigate hotspots failed", zap.Error(err))
+				}
+			}
+		}
+	}, "hotspotMitigationLoop")
+}
+
+func (do *Domain) fetchHotWriteRegions() ([]helper.HotTableIndex, error) {
+	store, ok := do.store.(helper.Storage)
+	if !ok {
+		return nil, nil
+	}
+	h := helper.NewHelper(store)
+	ctx, cancel := context.WithTimeout(context.Background(), hotspotMitigationInterval)
+	defer cancel()
+	return h.ScrapeHotInfo(ctx, helper.HotWrite, do.InfoSchema().AllSchemas())
+}
+
+// MitigateHotspots detects the single-region write hotspots on the auto-increment or timestamp prefixed keys in the
+// hot write regions, and handles them according to `tidb_hotspot_mitigation_policy`. The hotspots and the applied
+// mitigations are logged into `mysql.hotspot_mitigation_history`.
+func (do *Domain) MitigateHotspots(hotRegions []helper.HotTableIndex, now time.Time) error {
+	policy := variable.HotspotMitigationPolicy.Load()
+	if policy == variable.HotspotMitigationOff {
+		return nil
+	}
+	hotspots := detectHotspots(do.InfoSchema(), hotRegions)
+	if len(hotspots) == 0 {
+		return nil
+	}
+	se, err := do.sysSessionPool.Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer do.sysSessionPool.Put(se)
+	exec := se.(sqlexec.RestrictedSQLExecutor)
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	for _, hs := range hotspots {
+		if !do.hotspotMitigation.tryHandle(hs.hotspotKey, now) {
+			continue
+		}
+		logutil.BgLogger().Info("detect write hotspot", zap.String("schema", hs.schema),
+			zap.String("table", hs.table.Name.O), zap.String("partition", hs.partition),
+			zap.Uint64("region", hs.regionID), zap.String("type", hs.tp))
+		if policy == variable.HotspotMitigationLog {
+			recordHotspotAction(ctx, exec, hs, now, "LOG", "", nil)
+			continue
+		}
+		sql, err := preSplitHotspot(ctx, exec, hs)
+		recordHotspotAction(ctx, exec, hs, now, "PRE_SPLIT", sql, err)
+		if policy == variable.HotspotMitigationAuto && hs.tp == hotspotAutoIncrement &&
+			hs.table.AutoIdCache > 1 && hs.table.AutoIdCache < hotspotAutoIDCache {
+			// AUTO_ID_CACHE 1 can't be altered, and the default cache size grows adaptively.
+			sql, err := sqlescape.EscapeSQL("ALTER TABLE %n.%n AUTO_ID_CACHE = %?", hs.schema, hs.table.Name.O, hotspotAutoIDCache)
+			if err == nil {
+				_, _, err = exec.ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession}, sql)
+			}
+			recordHotspotAction(ctx, exec, hs, now, "ENLARGE_AUTO_ID_CACHE", sql, err)
+		}
+	}
+	return nil
+}
+
+// detectHotspots returns the hotspots on the auto-increment or timestamp prefixed keys. The writes on such keys always
+// go to the last region of them, so only the keys with exactly one hot region are considered.
+func detectHotspots(is infoschema.InfoSchema, hotRegions []helper.HotTableIndex) []*hotspot {
+	regions := make(map[hotspotKey][]helper.HotTableIndex)
+	for _, region := range hotRegions {
+		if region.TableID == 0 {
+			continue
+		}
+		key := hotspotKey{physicalID: region.TableID, indexID: region.IndexID}
+		regions[key] = append(regions[key], region)
+	}
+
+	hotspots := make([]*hotspot, 0, len(regions))
+	for key, rs := range regions {
+		if len(rs) != 1 {
+			continue
+		}
+		hs := &hotspot{hotspotKey: key, regionID: rs[0].RegionID}
+		if rs[0].RegionMetric != nil {
+			hs.flowBytes = rs[0].RegionMetric.FlowBytes
+		}
+		if tbl, ok := is.TableByID(key.physicalID); ok {
+			hs.table = tbl.Meta()
+			if db, ok := is.SchemaByID(hs.table.DBID); ok {
+				hs.schema = db.Name.O
+			}
+		} else if tbl, db, def := is.FindTableByPartitionID(key.physicalID); tbl != nil {
+			hs.table, hs.schema, hs.partition = tbl.Meta(), db.Name.O, def.Name.O
+		}
+		if hs.table == nil || hs.schema == "" || util.IsMemOrSysDB(model.NewCIStr(hs.schema).L) {
+			continue
+		}
+		hs.index, hs.column = hotspotColumn(hs.table, key.indexID)
+		if hs.column == nil {
+			continue
+		}
+		switch {
+		case mysql.HasAutoIncrementFlag(hs.column.GetFlag()) || hs.column.ID == model.ExtraHandleID:
+			hs.tp = hotspotAutoIncrement
+		case hs.column.GetType() == mysql.TypeTimestamp || hs.column.GetType() == mysql.TypeDatetime:
+			hs.tp = hotspotTimestamp
+		default:
+			continue
+		}
+		hotspots = append(hotspots, hs)
+	}
+	sort.Slice(hotspots, func(i, j int) bool {
+		if hotspots[i].physicalID != hotspots[j].physicalID {
+			return hotspots[i].physicalID < hotspots[j].physicalID
+		}
+		return hotspots[i].indexID < hotspots[j].indexID
+	})
+	return hotspots
+}
+
+// hotspotColumn returns the index and the prefix column of the keys, the index is nil for the record keys.
+func hotspotColumn(tblInfo *model.TableInfo, indexID int64) (*model.IndexInfo, *model.ColumnInfo) {
+	if indexID != 0 {
+		for _, idx := range tblInfo.Indices {
+			if idx.ID == indexID && idx.State == model.StatePublic {
+				return idx, tblInfo.Columns[idx.Columns[0].Offset]
+			}
+		}
+		return nil, nil
+	}
+	switch {
+	case tblInfo.PKIsHandle:
+		return nil, tblInfo.GetPkColInfo()
+	case tblInfo.IsCommonHandle:
+		// the regions can only be pre-split by all the columns of the clustered index.
+		if pk := tblInfo.GetPrimaryKey(); pk != nil && len(pk.Columns) == 1 {
+			return nil, tblInfo.Columns[pk.Columns[0].Offset]
+		}
+		return nil, nil
+	case tblInfo.ShardRowIDBits == 0:
+		// the row ids are allocated in order unless they are sharded.
+		return nil, model.NewExtraHandleColInfo()
+	}
+	return nil, nil
+}
+
+// preSplitHotspot splits the range ahead of the max key of the hotspot into regions and scatters them, so the
+// following writes are spread over the stores. It returns the executed statement.
+func preSplitHotspot(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, hs *hotspot) (string, error) {
+	query := "SELECT CAST(MAX(%n) AS CHAR), CAST(MAX(%n) + %? AS CHAR) FROM %n.%n"
+	args := []any{hs.column.Name.O, hs.column.Name.O, hotspotPreSplitIDs, hs.schema, hs.table.Name.O}
+	if hs.tp == hotspotTimestamp {
+		query = "SELECT CAST(MAX(%n) AS CHAR), CAST(MAX(%n) + INTERVAL %? SECOND AS CHAR) FROM %n.%n"
+		args[2] = hotspotPreSplitSeconds
+	}
+	split := "SPLIT TABLE %n.%n"
+	splitArgs := []any{hs.schema, hs.table.Name.O}
+	if hs.partition != "" {
+		query += " PARTITION (%n)"
+		args = append(args, hs.partition)
+		split += " PARTITION (%n)"
+		splitArgs = append(splitArgs, hs.partition)
+	}
+	rows, _, err := exec.ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession}, query, args...)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(rows) == 0 || rows[0].IsNull(0) || rows[0].IsNull(1) {
+		return "", nil
+	}
+	if hs.index != nil {
+		split += " INDEX %n"
+		splitArgs = append(splitArgs, hs.index.Name.O)
+	}
+	split += " BETWEEN (%?) AND (%?) REGIONS %? SCATTER"
+	splitArgs = append(splitArgs, rows[0].GetString(0), rows[0].GetString(1), hotspotPreSplitRegions)
+	sql, err := sqlescape.EscapeSQL(split, splitArgs...)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	_, _, err = exec.ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession}, sql)
+	return sql, errors.Trace(err)
+}
+
+func recordHotspotAction(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, hs *hotspot, now time.Time,
+	action, stmt string, actionErr error) {
+	status, errMsg, stmtValue := "SUCCESS", any(nil), any(nil)
+	if actionErr != nil {
+		logutil.BgLogger().Warn("mitigate write hotspot failed", zap.String("schema", hs.schema),
+			zap.String("table", hs.table.Name.O), zap.String("action", action), zap.Error(actionErr))
+		status, errMsg = "FAILED", actionErr.Error()
+	}
+	if stmt != "" {
+		stmtValue = stmt
+	}
+	indexName := ""
+	if hs.index != nil {
+		indexName = hs.index.Name.O
+	}
+	if _, _, err := exec.ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession},
+		`INSERT INTO mysql.hotspot_mitigation_history (detect_time, table_schema, table_name, partition_name, index_name,
+		region_id, flow_bytes, hotspot_type, action, statement, status, error) VALUES (FROM_UNIXTIME(%?), %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
+		now.Unix(), hs.schema, hs.table.Name.O, hs.partition, indexName, hs.regionID, hs.flowBytes, hs.tp, action,
+		stmtValue, status, errMsg); err != nil {
+		logutil.BgLogger().Warn("record hotspot mitigation failed", zap.String("schema", hs.schema),
+			zap.String("table", hs.table.Name.O), zap.Error(err))
+	}
+}
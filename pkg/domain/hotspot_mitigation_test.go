@@ -0,0 +1,83 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/store/helper"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMitigateHotspots(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (id bigint primary key auto_increment, v int) auto_id_cache 100")
+	tk.MustExec("create table t2 (id bigint primary key, ts datetime, v int, key idx_ts (ts, v))")
+	tk.MustExec("create table t3 (id bigint primary key, v int, key idx_v (v))")
+	tk.MustExec("insert into t1 (v) values (1), (2), (3)")
+	tk.MustExec("insert into t2 values (1, '2024-01-01 00:00:00', 1), (2, '2024-01-01 00:30:00', 2)")
+	tk.MustExec("insert into t3 values (1, 1)")
+
+	tableInfo := func(name string) *model.TableInfo {
+		tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr(name))
+		require.NoError(t, err)
+		return tbl.Meta()
+	}
+	t1, t2, t3 := tableInfo("t1"), tableInfo("t2"), tableInfo("t3")
+	hotRegions := []helper.HotTableIndex{
+		// the auto-increment record keys of t1.
+		{RegionID: 1, RegionMetric: &helper.RegionMetric{FlowBytes: 1024}, DbName: "test", TableName: "t1", TableID: t1.ID},
+		// the datetime prefixed index of t2.
+		{RegionID: 2, DbName: "test", TableName: "t2", TableID: t2.ID, IndexName: "idx_ts", IndexID: t2.Indices[0].ID},
+		// the record keys of t2 are neither auto-increment nor timestamp prefixed.
+		{RegionID: 3, DbName: "test", TableName: "t2", TableID: t2.ID},
+		// the index of t3 is hot on two regions, it's not caused by a monotonic key.
+		{RegionID: 4, DbName: "test", TableName: "t3", TableID: t3.ID, IndexName: "idx_v", IndexID: t3.Indices[0].ID},
+		{RegionID: 5, DbName: "test", TableName: "t3", TableID: t3.ID, IndexName: "idx_v", IndexID: t3.Indices[0].ID},
+	}
+
+	now := time.Now()
+	// nothing is done when the policy is OFF.
+	require.NoError(t, dom.MitigateHotspots(hotRegions, now))
+	tk.MustQuery("select count(*) from mysql.hotspot_mitigation_history").Check(testkit.Rows("0"))
+
+	tk.MustExec("set @@global.tidb_hotspot_mitigation_policy = 'LOG'")
+	defer tk.MustExec("set @@global.tidb_hotspot_mitigation_policy = default")
+	require.NoError(t, dom.MitigateHotspots(hotRegions, now))
+	tk.MustQuery("select table_name, index_name, region_id, flow_bytes, hotspot_type, action, status from mysql.hotspot_mitigation_history order by id").Check(testkit.Rows(
+		"t1  1 1024 AUTO_INCREMENT LOG SUCCESS",
+		"t2 idx_ts 2 0 TIMESTAMP LOG SUCCESS",
+	))
+	// the hotspots are not handled again within the cooldown.
+	require.NoError(t, dom.MitigateHotspots(hotRegions, now.Add(time.Minute)))
+	tk.MustQuery("select count(*) from mysql.hotspot_mitigation_history").Check(testkit.Rows("2"))
+
+	tk.MustExec("delete from mysql.hotspot_mitigation_history")
+	tk.MustExec("set @@global.tidb_hotspot_mitigation_policy = 'AUTO'")
+	require.NoError(t, dom.MitigateHotspots(hotRegions, now.Add(time.Hour)))
+	tk.MustQuery("select table_name, hotspot_type, action, status, statement from mysql.hotspot_mitigation_history order by id").Check(testkit.Rows(
+		"t1 AUTO_INCREMENT PRE_SPLIT SUCCESS SPLIT TABLE `test`.`t1` BETWEEN ('3') AND ('1048579') REGIONS 8 SCATTER",
+		"t1 AUTO_INCREMENT ENLARGE_AUTO_ID_CACHE SUCCESS ALTER TABLE `test`.`t1` AUTO_ID_CACHE = 131072",
+		"t2 TIMESTAMP PRE_SPLIT SUCCESS SPLIT TABLE `test`.`t2` INDEX `idx_ts` BETWEEN ('2024-01-01 00:30:00') AND ('2024-01-01 01:30:00') REGIONS 8 SCATTER",
+	))
+	require.Len(t, tk.MustQuery("show table t1 regions").Rows(), 8)
+	require.Len(t, tk.MustQuery("show table t2 index idx_ts regions").Rows(), 8)
+	require.Equal(t, int64(131072), tableInfo("t1").AutoIdCache)
+}
@@ -126,6 +126,7 @@ type InfoSyncer struct {
 	scheduleManager       ScheduleManager
 	tiflashReplicaManager TiFlashReplicaManager
 	resourceManagerClient pd.ResourceManagerClient
+	serversHistory        *ServersHistory
 }
 
 // ServerInfo is server static information.
@@ -214,6 +215,7 @@ func GlobalInfoSyncerInit(
 		info:              getServerInfo(id, serverIDGetter),
 		serverInfoPath:    fmt.Sprintf("%s/%s", ServerInformationPath, id),
 		minStartTSPath:    fmt.Sprintf("%s/%s", ServerMinStartTSPath, id),
+		serversHistory:    NewServersHistory(serversHistoryCapacity),
 	}
 	err := is.init(ctx, skipRegisterToDashBoard)
 	if err != nil {
@@ -763,11 +765,18 @@ func (is *InfoSyncer) ReportMinStartTS(store kv.Storage) {
 	now := oracle.GetTimeFromTS(currentVer.Ver)
 	// GCMaxWaitTime is in seconds, GCMaxWaitTime * 1000 converts it to milliseconds.
 	startTSLowerLimit := oracle.GoTimeToLowerLimitStartTS(now, variable.GCMaxWaitTime.Load()*1000)
+	// The sessions enabling tidb_enable_query_gc_protection delay GC longer.
+	protectedStartTSLowerLimit := oracle.GoTimeToLowerLimitStartTS(now, QueryGCProtectionDuration().Milliseconds())
 	minStartTS := oracle.GoTimeToTS(now)
 	logutil.BgLogger().Debug("ReportMinStartTS", zap.Uint64("initial minStartTS", minStartTS),
-		zap.Uint64("StartTSLowerLimit", startTSLowerLimit))
+		zap.Uint64("StartTSLowerLimit", startTSLowerLimit),
+		zap.Uint64("ProtectedStartTSLowerLimit", protectedStartTSLowerLimit))
 	for _, info := range pl {
-		if info.CurTxnStartTS > startTSLowerLimit && info.CurTxnStartTS < minStartTS {
+		lowerLimit := startTSLowerLimit
+		if info.GCProtected {
+			lowerLimit = protectedStartTSLowerLimit
+		}
+		if info.CurTxnStartTS > lowerLimit && info.CurTxnStartTS < minStartTS {
 			minStartTS = info.CurTxnStartTS
 		}
 	}
@@ -789,6 +798,12 @@ func (is *InfoSyncer) ReportMinStartTS(store kv.Storage) {
 	logutil.BgLogger().Debug("ReportMinStartTS", zap.Uint64("final minStartTS", is.minStartTS))
 }
 
+// QueryGCProtectionDuration returns how long the start ts of the sessions enabling tidb_enable_query_gc_protection
+// delays the GC safe point, it's never shorter than tidb_gc_max_wait_time.
+func QueryGCProtectionDuration() time.Duration {
+	return time.Duration(max(variable.QueryGCProtectionMaxTime.Load(), variable.GCMaxWaitTime.Load())) * time.Second
+}
+
 // Done returns a channel that closes when the info syncer is no longer being refreshed.
 func (is *InfoSyncer) Done() <-chan struct{} {
 	if is.etcdCli == nil {
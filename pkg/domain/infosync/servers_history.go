@@ -0,0 +1,147 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infosync
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/util/syncutil"
+)
+
+const (
+	// ServerEventJoin means a TiDB server joins the cluster.
+	ServerEventJoin = "JOIN"
+	// ServerEventLeave means a TiDB server leaves the cluster.
+	ServerEventLeave = "LEAVE"
+	// ServersHistoryRefreshInterval is the interval of infoSyncerKeeper refreshing the servers history.
+	ServersHistoryRefreshInterval = 10 * time.Second
+	// serversHistoryCapacity is the max number of events kept in the servers history.
+	serversHistoryCapacity = 1024
+)
+
+// ServerEvent is a TiDB server joining or leaving the cluster, observed from the server information in etcd.
+type ServerEvent struct {
+	Time time.Time
+	Type string
+	Info *ServerInfo
+}
+
+// ServersHistory keeps the latest events of the TiDB servers joining and leaving the cluster in a ring buffer.
+type ServersHistory struct {
+	mu syncutil.Mutex
+	// servers is the servers observed in the last update, it's nil before the first update.
+	servers map[string]*ServerInfo
+	events  []ServerEvent
+	// next is the position of the next event in the ring buffer.
+	next     int
+	capacity int
+}
+
+// NewServersHistory creates a ServersHistory which keeps at most `capacity` events.
+func NewServersHistory(capacity int) *ServersHistory {
+	return &ServersHistory{capacity: capacity}
+}
+
+// Update compares the servers with the servers observed in the last update, and records the servers
+// joining and leaving the cluster. A server joins at its start time, and leaves at the time it's found
+// missing, or at the start time of the new instance if it restarts with the same ID. In the first update,
+// all the servers are recorded as joining.
+func (h *ServersHistory) Update(servers map[string]*ServerInfo, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	events := make([]ServerEvent, 0)
+	for id, info := range h.servers {
+		cur, ok := servers[id]
+		if !ok {
+			events = append(events, ServerEvent{Time: now, Type: ServerEventLeave, Info: info})
+		} else if cur.StartTimestamp != info.StartTimestamp {
+			// the server restarted with the same ID, it must have left before it started again.
+			events = append(events, ServerEvent{Time: serverStartTime(cur, now), Type: ServerEventLeave, Info: info})
+		}
+	}
+	for id, info := range servers {
+		if last, ok := h.servers[id]; !ok || last.StartTimestamp != info.StartTimestamp {
+			events = append(events, ServerEvent{Time: serverStartTime(info, now), Type: ServerEventJoin, Info: info})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].Time.Equal(events[j].Time) {
+			return events[i].Time.Before(events[j].Time)
+		}
+		if events[i].Type != events[j].Type {
+			// a restarted server leaves before it joins again.
+			return events[i].Type == ServerEventLeave
+		}
+		return events[i].Info.ID < events[j].Info.ID
+	})
+	for _, event := range events {
+		if len(h.events) < h.capacity {
+			h.events = append(h.events, event)
+		} else {
+			h.events[h.next] = event
+		}
+		h.next = (h.next + 1) % h.capacity
+	}
+	h.servers = make(map[string]*ServerInfo, len(servers))
+	for id, info := range servers {
+		h.servers[id] = info
+	}
+}
+
+// serverStartTime returns the start time of the server, or `now` if the start time is unknown or in the future.
+func serverStartTime(info *ServerInfo, now time.Time) time.Time {
+	if info.StartTimestamp > 0 && info.StartTimestamp < now.Unix() {
+		return time.Unix(info.StartTimestamp, 0)
+	}
+	return now
+}
+
+// Events returns the recorded events from the oldest to the latest.
+func (h *ServersHistory) Events() []ServerEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	events := make([]ServerEvent, 0, len(h.events))
+	if len(h.events) < h.capacity {
+		return append(events, h.events...)
+	}
+	events = append(events, h.events[h.next:]...)
+	return append(events, h.events[:h.next]...)
+}
+
+// UpdateServersHistory reads the information of all the TiDB servers, and records the servers joining and
+// leaving the cluster since the last update.
+func UpdateServersHistory(ctx context.Context) error {
+	is, err := getGlobalInfoSyncer()
+	if err != nil {
+		return err
+	}
+	servers, err := GetAllServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+	is.serversHistory.Update(servers, time.Now())
+	return nil
+}
+
+// GetServersHistory returns the recorded events of the TiDB servers joining and leaving the cluster.
+func GetServersHistory() ([]ServerEvent, error) {
+	is, err := getGlobalInfoSyncer()
+	if err != nil {
+		return nil, err
+	}
+	return is.serversHistory.Events(), nil
+}
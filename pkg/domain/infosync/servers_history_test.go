@@ -0,0 +1,54 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infosync
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServersHistory(t *testing.T) {
+	check := func(h *ServersHistory, expected ...string) {
+		events := h.Events()
+		actual := make([]string, 0, len(events))
+		for _, event := range events {
+			actual = append(actual, fmt.Sprintf("%d %s %s", event.Time.Unix(), event.Type, event.Info.ID))
+		}
+		require.Equal(t, expected, actual)
+	}
+	h := NewServersHistory(4)
+	s1 := &ServerInfo{ID: "s1", StartTimestamp: 100}
+	s2 := &ServerInfo{ID: "s2", StartTimestamp: 90}
+	// the servers found in the first update joined at their start time.
+	h.Update(map[string]*ServerInfo{"s1": s1, "s2": s2}, time.Unix(200, 0))
+	check(h, "90 JOIN s2", "100 JOIN s1")
+	h.Update(map[string]*ServerInfo{"s1": s1, "s2": s2}, time.Unix(210, 0))
+	check(h, "90 JOIN s2", "100 JOIN s1")
+
+	// s2 leaves, and s3 joins with a start time in the future.
+	s3 := &ServerInfo{ID: "s3", StartTimestamp: 1000}
+	h.Update(map[string]*ServerInfo{"s1": s1, "s3": s3}, time.Unix(220, 0))
+	check(h, "90 JOIN s2", "100 JOIN s1", "220 LEAVE s2", "220 JOIN s3")
+
+	// s1 restarts with the same ID, the oldest events are overwritten.
+	s1Restarted := &ServerInfo{ID: "s1", StartTimestamp: 225}
+	h.Update(map[string]*ServerInfo{"s1": s1Restarted, "s3": s3}, time.Unix(230, 0))
+	check(h, "220 LEAVE s2", "220 JOIN s3", "225 LEAVE s1", "225 JOIN s1")
+	h.Update(map[string]*ServerInfo{}, time.Unix(240, 0))
+	check(h, "225 LEAVE s1", "225 JOIN s1", "240 LEAVE s1", "240 LEAVE s3")
+}
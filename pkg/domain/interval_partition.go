@@ -0,0 +1,113 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/ddl"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+// intervalPartitionCheckInterval is the interval to check whether the INTERVAL partitioned
+// tables need partitions to be created or dropped.
+var intervalPartitionCheckInterval = time.Minute
+
+// intervalPartitionLoop pre-creates and drops partitions according to the interval partition
+// policies of the tables. Only the DDL owner does the work.
+func (do *Domain) intervalPartitionLoop() {
+	defer util.Recover(metrics.LabelDomain, "intervalPartitionLoop", nil, false)
+	ticker := time.NewTicker(intervalPartitionCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-do.exit:
+			return
+		case <-ticker.C:
+			if !do.ddl.OwnerManager().IsOwner() {
+				continue
+			}
+			do.MaintainIntervalPartitions(time.Now())
+		}
+	}
+}
+
+// MaintainIntervalPartitions creates the partitions ahead of `now` and drops the expired partitions
+// of all the tables with an interval partition policy. Errors are logged and the next table is processed.
+func (do *Domain) MaintainIntervalPartitions(now time.Time) {
+	is := do.InfoSchema()
+	for _, db := range is.AllSchemas() {
+		if util.IsMemOrSysDB(db.Name.L) {
+			continue
+		}
+		for _, tbl := range is.SchemaTables(db.Name) {
+			tblInfo := tbl.Meta()
+			if tblInfo.IntervalPolicy == nil || tblInfo.Partition == nil || tblInfo.State != model.StatePublic {
+				continue
+			}
+			if err := do.maintainIntervalPartitions(db.Name, tblInfo, now); err != nil {
+				logutil.BgLogger().Warn("maintain interval partitions failed",
+					zap.String("schema", db.Name.O), zap.String("table", tblInfo.Name.O), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (do *Domain) maintainIntervalPartitions(dbName model.CIStr, tblInfo *model.TableInfo, now time.Time) error {
+	se, err := do.sysSessionPool.Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer do.sysSessionPool.Put(se)
+	sctx := se.(sessionctx.Context)
+
+	nowTime := types.NewTime(types.FromGoTime(now), mysql.TypeDatetime, 0)
+	lastRangeEnd, firstRangeEnd, err := ddl.GetIntervalPartitionMaintenance(sctx.GetExprCtx(), tblInfo, nowTime)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	if len(lastRangeEnd) > 0 {
+		logutil.BgLogger().Info("pre-create interval partitions",
+			zap.String("schema", dbName.O), zap.String("table", tblInfo.Name.O), zap.String("lessThan", lastRangeEnd))
+		_, _, err = exec.ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession},
+			"ALTER TABLE %n.%n LAST PARTITION LESS THAN (%?)", dbName.O, tblInfo.Name.O, lastRangeEnd)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(firstRangeEnd) > 0 {
+		logutil.BgLogger().Info("drop expired interval partitions",
+			zap.String("schema", dbName.O), zap.String("table", tblInfo.Name.O), zap.String("lessThan", firstRangeEnd))
+		_, _, err = exec.ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession},
+			"ALTER TABLE %n.%n FIRST PARTITION LESS THAN (%?)", dbName.O, tblInfo.Name.O, firstRangeEnd)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+// materializedViewRefreshCheckInterval is the interval to check whether there are materialized
+// views to be refreshed.
+var materializedViewRefreshCheckInterval = time.Minute
+
+// MaterializedViewRefresher refreshes the data of a materialized view.
+type MaterializedViewRefresher func(ctx context.Context, schema model.CIStr, tblInfo *model.TableInfo) error
+
+// StartMaterializedViewRefresher starts the loop which refreshes the materialized views created with
+// REFRESH EVERY when their refresh is due. Only the DDL owner refreshes the views.
+func (do *Domain) StartMaterializedViewRefresher(refresh MaterializedViewRefresher) {
+	do.mvRefresher = refresh
+	do.wg.Run(func() {
+		defer func() {
+			logutil.BgLogger().Info("materializedViewRefreshLoop exited.")
+		}()
+		defer util.Recover(metrics.LabelDomain, "materializedViewRefreshLoop", nil, false)
+
+		ticker := time.NewTicker(materializedViewRefreshCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-do.exit:
+				return
+			case <-ticker.C:
+				if !do.ddl.OwnerManager().IsOwner() {
+					continue
+				}
+				do.RefreshDueMaterializedViews(time.Now())
+			}
+		}
+	}, "materializedViewRefreshLoop")
+}
+
+// RefreshDueMaterializedViews refreshes the materialized views whose refresh is due at `now`, the
+// next refresh of a view is due one interval after the snapshot its data is selected from. Errors
+// are logged and the next view is processed.
+func (do *Domain) RefreshDueMaterializedViews(now time.Time) {
+	if do.mvRefresher == nil {
+		return
+	}
+	is := do.InfoSchema()
+	for _, db := range is.AllSchemas() {
+		if util.IsMemOrSysDB(db.Name.L) {
+			continue
+		}
+		for _, tbl := range is.SchemaTables(db.Name) {
+			mv := tbl.Meta().MaterializedView
+			if mv == nil || mv.RefreshEvery == 0 || tbl.Meta().IsStaging() {
+				continue
+			}
+			lastRefresh := oracle.GetTimeFromTS(mv.LastRefreshTS)
+			if nextEventTime(lastRefresh, lastRefresh, int64(mv.RefreshEvery), mv.RefreshUnit).After(now) {
+				continue
+			}
+			ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
+			if err := do.mvRefresher(ctx, db.Name, tbl.Meta()); err != nil {
+				logutil.BgLogger().Warn("refresh materialized view failed",
+					zap.String("schema", db.Name.O), zap.String("view", tbl.Meta().Name.O), zap.Error(err))
+			}
+		}
+	}
+}
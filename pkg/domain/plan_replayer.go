@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -75,6 +76,79 @@ func (p *dumpFileGcChecker) GCDumpFiles(gcDurationDefault, gcDurationForCapture
 	for _, path := range p.paths {
 		p.gcDumpFilesByPath(path, gcDurationDefault, gcDurationForCapture)
 	}
+	p.gcCaptureFilesByQuota(variable.PlanReplayerCaptureStorageQuota.Load())
+}
+
+// GCCaptureFilesByQuota removes the oldest plan replayer capture files until their total size is within the quota.
+func (p *dumpFileGcChecker) GCCaptureFilesByQuota(quota int64) {
+	p.Lock()
+	defer p.Unlock()
+	p.gcCaptureFilesByQuota(quota)
+}
+
+func (p *dumpFileGcChecker) gcCaptureFilesByQuota(quota int64) {
+	if quota <= 0 {
+		return
+	}
+	path := replayer.GetPlanReplayerDirName()
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logutil.BgLogger().Warn("open plan replayer directory failed", zap.String("category", "dumpFileGcChecker"), zap.Error(err))
+		}
+		return
+	}
+	type captureFile struct {
+		name       string
+		size       int64
+		createTime time.Time
+	}
+	files := make([]captureFile, 0, len(entries))
+	var totalSize int64
+	for _, entry := range entries {
+		fileName := entry.Name()
+		if !strings.Contains(fileName, "replayer") || !strings.Contains(fileName, "capture") {
+			continue
+		}
+		f, err := entry.Info()
+		if err != nil {
+			logutil.BgLogger().Warn("open plan replayer file failed", zap.String("category", "dumpFileGcChecker"), zap.Error(err))
+			continue
+		}
+		createTime, err := parseTime(fileName)
+		if err != nil {
+			logutil.BgLogger().Error("parseTime failed", zap.String("category", "dumpFileGcChecker"), zap.Error(err), zap.String("filename", fileName))
+			continue
+		}
+		files = append(files, captureFile{name: fileName, size: f.Size(), createTime: createTime})
+		totalSize += f.Size()
+	}
+	if totalSize <= quota {
+		return
+	}
+	slices.SortFunc(files, func(a, b captureFile) int {
+		return a.createTime.Compare(b.createTime)
+	})
+	removed := false
+	for _, f := range files {
+		if totalSize <= quota {
+			break
+		}
+		if err := os.Remove(filepath.Join(path, f.name)); err != nil {
+			logutil.BgLogger().Warn("remove file failed", zap.String("category", "dumpFileGcChecker"), zap.Error(err), zap.String("filename", f.name))
+			continue
+		}
+		totalSize -= f.size
+		removed = true
+		logutil.BgLogger().Info("remove plan replayer capture file exceeding the storage quota", zap.String("category", "dumpFileGcChecker"),
+			zap.String("filename", f.name), zap.Int64("quota", quota))
+		if p.sctx != nil {
+			deletePlanReplayerStatus(context.Background(), p.sctx, f.name)
+		}
+	}
+	if removed && p.planReplayerTaskStatus != nil {
+		p.planReplayerTaskStatus.clearFinishedTask()
+	}
 }
 
 func (p *dumpFileGcChecker) setupSctx(sctx sessionctx.Context) {
@@ -403,6 +477,8 @@ type planReplayerTaskDumpWorker struct {
 	sctx   sessionctx.Context
 	taskCH <-chan *PlanReplayerDumpTask
 	status *planReplayerDumpTaskStatus
+	// gcChecker is used to keep the capture files within the storage quota after dumping.
+	gcChecker *dumpFileGcChecker
 }
 
 func (w *planReplayerTaskDumpWorker) run() {
@@ -482,6 +558,9 @@ func (w *planReplayerTaskDumpWorker) HandleTask(task *PlanReplayerDumpTask) (suc
 			zap.Error(err))
 		return false
 	}
+	if w.gcChecker != nil {
+		w.gcChecker.GCCaptureFilesByQuota(variable.PlanReplayerCaptureStorageQuota.Load())
+	}
 	return true
 }
 
@@ -136,3 +136,36 @@ func TestDumpGCFileParseTime(t *testing.T) {
 	_, err = parseTime(pName)
 	require.NoError(t, err)
 }
+
+func TestPlanReplayerCaptureStorageQuota(t *testing.T) {
+	dirName := replayer.GetPlanReplayerDirName()
+	require.NoError(t, os.MkdirAll(dirName, os.ModePerm))
+	defer os.RemoveAll(dirName)
+	now := time.Now()
+	writeFile := func(name string, size int) string {
+		filePath := filepath.Join(dirName, name)
+		require.NoError(t, os.WriteFile(filePath, make([]byte, size), 0600))
+		return filePath
+	}
+	capture1 := writeFile(fmt.Sprintf("capture_replayer_k1_%d.zip", now.Add(-3*time.Hour).UnixNano()), 100)
+	capture2 := writeFile(fmt.Sprintf("capture_normal_replayer_k2_%d.zip", now.Add(-2*time.Hour).UnixNano()), 100)
+	capture3 := writeFile(fmt.Sprintf("capture_replayer_k3_%d.zip", now.Add(-time.Hour).UnixNano()), 100)
+	// the files not from capture are not counted in the quota.
+	normal := writeFile(fmt.Sprintf("replayer_single_k4_%d.zip", now.Add(-4*time.Hour).UnixNano()), 1000)
+
+	handler := &dumpFileGcChecker{
+		paths: []string{dirName},
+	}
+	handler.GCCaptureFilesByQuota(0)
+	require.FileExists(t, capture1)
+	handler.GCCaptureFilesByQuota(300)
+	require.FileExists(t, capture1)
+	handler.GCCaptureFilesByQuota(250)
+	require.NoFileExists(t, capture1)
+	require.FileExists(t, capture2)
+	require.FileExists(t, capture3)
+	handler.GCCaptureFilesByQuota(50)
+	require.NoFileExists(t, capture2)
+	require.NoFileExists(t, capture3)
+	require.FileExists(t, normal)
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+// stagingTableCleanupInterval is the interval to check whether there are staging tables
+// left by the TiDB servers which are gone.
+var stagingTableCleanupInterval = time.Minute
+
+// stagingTableCleanupLoop drops the staging tables of CREATE TABLE ... SELECT whose loaders are
+// gone, e.g. the TiDB server crashed while loading them. Only the DDL owner does the work.
+func (do *Domain) stagingTableCleanupLoop() {
+	defer util.Recover(metrics.LabelDomain, "stagingTableCleanupLoop", nil, false)
+	ticker := time.NewTicker(stagingTableCleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-do.exit:
+			return
+		case <-ticker.C:
+			if !do.ddl.OwnerManager().IsOwner() {
+				continue
+			}
+			if err := do.CleanupStagingTables(); err != nil {
+				logutil.BgLogger().Warn("cleanup staging tables failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// CleanupStagingTables drops the staging tables whose loading TiDB servers are not alive.
+// Errors of dropping a table are logged and the next table is processed.
+func (do *Domain) CleanupStagingTables() error {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
+	is := do.InfoSchema()
+	var servers map[string]*infosync.ServerInfo
+	for _, db := range is.AllSchemas() {
+		if util.IsMemOrSysDB(db.Name.L) {
+			continue
+		}
+		for _, tbl := range is.SchemaTables(db.Name) {
+			tblInfo := tbl.Meta()
+			if !tblInfo.IsStaging() {
+				continue
+			}
+			if servers == nil {
+				var err error
+				if servers, err = infosync.GetAllServerInfo(ctx); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			if _, ok := servers[tblInfo.Staging.ServerID]; ok {
+				continue
+			}
+			if err := do.dropStagingTable(ctx, db.Name, tblInfo); err != nil {
+				logutil.BgLogger().Warn("drop staging table failed",
+					zap.String("schema", db.Name.O), zap.String("table", tblInfo.Name.O), zap.Error(err))
+			}
+		}
+	}
+	return nil
+}
+
+func (do *Domain) dropStagingTable(ctx context.Context, dbName model.CIStr, tblInfo *model.TableInfo) error {
+	se, err := do.sysSessionPool.Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer do.sysSessionPool.Put(se)
+
+	logutil.BgLogger().Info("drop staging table whose loader is gone",
+		zap.String("schema", dbName.O), zap.String("table", tblInfo.Name.O),
+		zap.String("server", tblInfo.Staging.ServerID), zap.Uint64("conn", tblInfo.Staging.ConnectionID))
+	sql := "DROP TABLE %n.%n"
+	if tblInfo.IsMaterializedView() {
+		sql = "DROP MATERIALIZED VIEW %n.%n"
+	}
+	exec := se.(sessionctx.Context).(sqlexec.RestrictedSQLExecutor)
+	_, _, err = exec.ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession},
+		sql, dbName.O, tblInfo.Name.O)
+	return errors.Trace(err)
+}
@@ -896,6 +896,7 @@ const (
 	ErrCTEMaxRecursionDepth                                  = 3636
 	ErrNotHintUpdatable                                      = 3637
 	ErrExistsInHistoryPassword                               = 3638
+	ErrRoleGrantedToItself                                   = 3665
 	ErrInvalidDefaultUTF8MB4Collation                        = 3721
 	ErrForeignKeyCannotDropParent                            = 3730
 	ErrForeignKeyCannotUseVirtualColumn                      = 3733
@@ -914,6 +915,8 @@ const (
 	ErrFunctionalIndexOnField                                = 3762
 	ErrGeneratedColumnRowValueIsNotAllowed                   = 3764
 	ErrDefValGeneratedNamedFunctionIsNotAllowed              = 3770
+	ErrDefValGeneratedFunctionIsNotAllowed                   = 3771
+	ErrDefValGeneratedVariables                              = 3774
 	ErrFKIncompatibleColumns                                 = 3780
 	ErrFunctionalIndexRowValueIsNotAllowed                   = 3800
 	ErrNonBooleanExprForCheckConstraint                      = 3812
@@ -938,7 +941,11 @@ const (
 	ErUserAccessDeniedForUserAccountBlockedByPasswordLock    = 3955
 	ErrDependentByCheckConstraint                            = 3959
 	ErrJSONInBooleanContext                                  = 3986
+	ErrSchemaReadOnly                                        = 3989
 	ErrTableWithoutPrimaryKey                                = 3750
+	ErrTableMustHaveAVisibleColumn                           = 4028
+	ErrGIPKColumnExists                                      = 4108
+	ErrGIPKFailedAutoIncColumnExists                         = 4109
 	// MariaDB errors.
 	ErrOnlyOneDefaultPartionAllowed         = 4030
 	ErrWrongPartitionTypeExpectedSystemTime = 4113
@@ -1136,6 +1143,22 @@ const (
 	ErrPausedDDLJob       = 8262
 	ErrBDRRestrictedDDL   = 8263
 
+	// Masking policy errors.
+	ErrMaskingPolicyExists    = 8264
+	ErrMaskingPolicyNotExists = 8265
+	ErrMaskedTableNotWritable = 8271
+
+	// External table errors.
+	ErrOptOnExternalTable      = 8266
+	ErrInvalidExternalTableDef = 8267
+
+	// Vector errors.
+	ErrVectorDimensionMismatch = 8268
+	ErrInvalidVectorValue      = 8269
+
+	// CTE errors.
+	ErrCTEMaxRecursionRows = 8270
+
 	// Resource group errors.
 	ErrResourceGroupExists                    = 8248
 	ErrResourceGroupNotExists                 = 8249
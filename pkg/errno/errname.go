@@ -828,6 +828,8 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrGeneratedColumnFunctionIsNotAllowed:                   mysql.Message("Expression of generated column '%s' contains a disallowed function.", nil),
 	ErrGeneratedColumnRowValueIsNotAllowed:                   mysql.Message("Expression of generated column '%s' cannot refer to a row value", nil),
 	ErrDefValGeneratedNamedFunctionIsNotAllowed:              mysql.Message("Default value expression of column '%s' contains a disallowed function: `%s`.", nil),
+	ErrDefValGeneratedFunctionIsNotAllowed:                   mysql.Message("Default value expression of column '%s' contains a disallowed function.", nil),
+	ErrDefValGeneratedVariables:                              mysql.Message("Default value expression of column '%s' cannot refer user or system variables.", nil),
 	ErrUnsupportedAlterInplaceOnVirtualColumn:                mysql.Message("INPLACE ADD or DROP of virtual columns cannot be combined with other ALTER TABLE actions.", nil),
 	ErrWrongFKOptionForGeneratedColumn:                       mysql.Message("Cannot define foreign key with %s clause on a generated column.", nil),
 	ErrBadGeneratedColumn:                                    mysql.Message("The value specified for generated column '%s' in table '%s' is not allowed.", nil),
@@ -892,6 +894,7 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrLockAcquireFailAndNoWaitSet:                           mysql.Message("Statement aborted because lock(s) could not be acquired immediately and NOWAIT is set.", nil),
 	ErrNotHintUpdatable:                                      mysql.Message("Variable '%s' might not be affected by SET_VAR hint.", nil),
 	ErrExistsInHistoryPassword:                               mysql.Message("Cannot use these credentials for '%s@%s' because they contradict the password history policy.", nil),
+	ErrRoleGrantedToItself:                                   mysql.Message("User account %s is directly or indirectly granted to the role %s. The GRANT would create a loop in the role graph.", nil),
 	ErrInvalidDefaultUTF8MB4Collation:                        mysql.Message("Invalid default collation %s: utf8mb4_0900_ai_ci or utf8mb4_general_ci or utf8mb4_bin expected", nil),
 	ErrForeignKeyCannotDropParent:                            mysql.Message("Cannot drop table '%s' referenced by a foreign key constraint '%s' on table '%s'.", nil),
 	ErrForeignKeyCannotUseVirtualColumn:                      mysql.Message("Foreign key '%s' uses virtual column '%s' which is not supported.", nil),
@@ -940,6 +943,10 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrConstraintNotFound:                                    mysql.Message("Constraint '%s' does not exist.", nil),
 	ErrDependentByCheckConstraint:                            mysql.Message("Check constraint '%s' uses column '%s', hence column cannot be dropped or renamed.", nil),
 	ErrJSONInBooleanContext:                                  mysql.Message("Evaluating a JSON value in SQL boolean context does an implicit comparison against JSON integer 0; if this is not what you want, consider converting JSON to a SQL numeric type with JSON_VALUE RETURNING", nil),
+	ErrSchemaReadOnly:                                        mysql.Message("Schema '%-.192s' is in read only mode.", nil),
+	ErrTableMustHaveAVisibleColumn:                           mysql.Message("A table must have at least 1 visible column.", nil),
+	ErrGIPKColumnExists:                                      mysql.Message("Failed to generate invisible primary key. Column '%s' already exists.", nil),
+	ErrGIPKFailedAutoIncColumnExists:                         mysql.Message("Failed to generate invisible primary key. Auto-increment column already exists.", nil),
 	// MariaDB errors.
 	ErrOnlyOneDefaultPartionAllowed:         mysql.Message("Only one DEFAULT partition allowed", nil),
 	ErrWrongPartitionTypeExpectedSystemTime: mysql.Message("Wrong partitioning type, expected type: `SYSTEM_TIME`", nil),
@@ -1166,4 +1173,16 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrCannotResumeDDLJob: mysql.Message("Job [%v] can't be resumed: %s", nil),
 	ErrPausedDDLJob:       mysql.Message("Job [%v] has already been paused", nil),
 	ErrBDRRestrictedDDL:   mysql.Message("The operation is not allowed while the bdr role of this cluster is set to %s.", nil),
+
+	ErrMaskingPolicyExists:    mysql.Message("Masking policy '%-.192s' already exists", nil),
+	ErrMaskingPolicyNotExists: mysql.Message("Unknown masking policy '%-.192s'", nil),
+	ErrMaskedTableNotWritable: mysql.Message("Table '%-.192s' has masked columns for the current user and can't be modified by %s", nil),
+
+	ErrOptOnExternalTable:      mysql.Message("'%s' is unsupported on external tables.", nil),
+	ErrInvalidExternalTableDef: mysql.Message("Invalid external table definition: %s", nil),
+
+	ErrVectorDimensionMismatch: mysql.Message("Vector dimension mismatch, expect %d, got %d", nil),
+	ErrInvalidVectorValue:      mysql.Message("Invalid vector value: '%-.128s'", []int{0}),
+
+	ErrCTEMaxRecursionRows: mysql.Message("Recursive query aborted after producing more than %d rows. Try increasing @@tidb_cte_max_recursion_rows to a larger value", nil),
 }
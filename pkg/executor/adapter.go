@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math"
 	"runtime/trace"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -56,18 +57,22 @@ import (
 	"github.com/pingcap/tidb/pkg/sessiontxn/staleread"
 	"github.com/pingcap/tidb/pkg/types"
 	util2 "github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/audit"
 	"github.com/pingcap/tidb/pkg/util/breakpoint"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/hint"
 	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/perfevents"
 	"github.com/pingcap/tidb/pkg/util/plancodec"
 	"github.com/pingcap/tidb/pkg/util/replayer"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
 	"github.com/pingcap/tidb/pkg/util/stmtsummary"
 	stmtsummaryv2 "github.com/pingcap/tidb/pkg/util/stmtsummary/v2"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
+	"github.com/pingcap/tidb/pkg/util/tablelatency"
+	"github.com/pingcap/tidb/pkg/util/tiflashcompute"
 	"github.com/pingcap/tidb/pkg/util/topsql"
 	topsqlstate "github.com/pingcap/tidb/pkg/util/topsql/state"
 	"github.com/pingcap/tidb/pkg/util/tracing"
@@ -249,6 +254,9 @@ type ExecStmt struct {
 	// OutputNames will be set if using cached plan
 	OutputNames []*types.FieldName
 	PsStmt      *plannercore.PlanCacheStmt
+
+	// perfEvent is the statement event recorded in PERFORMANCE_SCHEMA, it's built when the statement starts.
+	perfEvent *perfevents.StatementEvent
 }
 
 // GetStmtNode returns the stmtNode inside Statement
@@ -465,6 +473,7 @@ func (a *ExecStmt) Exec(ctx context.Context) (_ sqlexec.RecordSet, err error) {
 	})
 	sctx := a.Ctx
 	ctx = util.SetSessionID(ctx, sctx.GetSessionVars().ConnectionID)
+	a.startPerfSchemaEvent()
 	if _, ok := a.Plan.(*plannercore.Analyze); ok && sctx.GetSessionVars().InRestrictedSQL {
 		oriStats, ok := sctx.GetSessionVars().GetSystemVar(variable.TiDBBuildStatsConcurrency)
 		if !ok {
@@ -501,7 +510,7 @@ func (a *ExecStmt) Exec(ctx context.Context) (_ sqlexec.RecordSet, err error) {
 		sctx.GetSessionVars().MemTracker.SetBytesLimit(sctx.GetSessionVars().StmtCtx.MemQuotaQuery)
 	}
 
-	e, err := a.buildExecutor()
+	e, err := a.buildExecutorWithResultCache()
 	if err != nil {
 		return nil, err
 	}
@@ -585,6 +594,11 @@ func (a *ExecStmt) getSQLForProcessInfo() string {
 
 func (a *ExecStmt) handleStmtForeignKeyTrigger(ctx context.Context, e exec.Executor) error {
 	stmtCtx := a.Ctx.GetSessionVars().StmtCtx
+	if w, ok := e.(WithAfterTrigger); ok && len(w.GetAfterTriggers()) > 0 {
+		if err := a.lockKeysBeforeStmtCommit(ctx); err != nil {
+			return err
+		}
+	}
 	if stmtCtx.ForeignKeyTriggerCtx.HasFKCascades {
 		// If the ExecStmt has foreign key cascade to be executed, we need call `StmtCommit` to commit the ExecStmt itself
 		// change first.
@@ -627,9 +641,89 @@ func (a *ExecStmt) handleForeignKeyTrigger(ctx context.Context, e exec.Executor,
 			return err
 		}
 	}
+	if w, ok := e.(WithAfterTrigger); ok {
+		for _, tr := range w.GetAfterTriggers() {
+			err := a.handleAfterTrigger(ctx, tr, depth)
+			if err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
+// handleAfterTrigger executes the statements of an AFTER trigger for each row written by the statement. It's
+// handled like the foreign key cascade, the changes of each statement are flushed into the txn mem-buffer by
+// `StmtCommit`, so the later statements can see them.
+func (a *ExecStmt) handleAfterTrigger(ctx context.Context, tr *TriggerExec, depth int) error {
+	restore, err := tr.switchToDefiner()
+	if err != nil {
+		return err
+	}
+	defer restore()
+	sc := a.Ctx.GetSessionVars().StmtCtx
+	inHandleFKTrigger := sc.InHandleForeignKeyTrigger
+	sc.InHandleForeignKeyTrigger = true
+	defer func() {
+		sc.InHandleForeignKeyTrigger = inHandleFKTrigger
+	}()
+	for {
+		e, err := tr.buildExecutor(ctx)
+		if err != nil || e == nil {
+			return err
+		}
+		if err := exec.Open(ctx, e); err != nil {
+			terror.Log(exec.Close(e))
+			return err
+		}
+		err = exec.Next(ctx, e, exec.NewFirstChunk(e))
+		closeErr := exec.Close(e)
+		if err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		if err := a.lockKeysBeforeStmtCommit(ctx); err != nil {
+			return err
+		}
+		a.Ctx.StmtCommit(ctx)
+		err = a.handleForeignKeyTrigger(ctx, e, depth+1)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// lockKeysBeforeStmtCommit acquires the pessimistic locks of the keys written by the statement so far. The AFTER
+// triggers need the changes to be flushed by `StmtCommit`, then `handlePessimisticDML` can't find the keys in the
+// statement buffer anymore, so they are locked here, and the duplicate keys of the inserted rows are reported too.
+func (a *ExecStmt) lockKeysBeforeStmtCommit(ctx context.Context) error {
+	sctx := a.Ctx
+	seVars := sctx.GetSessionVars()
+	if !seVars.TxnCtx.IsPessimistic {
+		return nil
+	}
+	txn, err := sctx.Txn(false)
+	if err != nil || !txn.Valid() {
+		return err
+	}
+	keys, err := txn.(pessimisticTxn).KeysNeedToLock()
+	if err != nil {
+		return err
+	}
+	keys = filterTemporaryTableKeys(seVars, keys)
+	keys = filterLockTableKeys(seVars.StmtCtx, keys)
+	if len(keys) == 0 {
+		return nil
+	}
+	lockCtx, err := newLockCtx(sctx, seVars.LockWaitTimeout, len(keys))
+	if err != nil {
+		return err
+	}
+	return txn.LockKeys(ctx, lockCtx, keys...)
+}
+
 // handleForeignKeyCascade uses to execute foreign key cascade behaviour, the progress is:
 //  1. Build delete/update executor for foreign key on delete/update behaviour.
 //     a. Construct delete/update AST. We used to try generated SQL string first and then parse the SQL to get AST,
@@ -923,7 +1017,7 @@ func (a *ExecStmt) handleNoDelayExecutor(ctx context.Context, e exec.Executor) (
 	var err error
 	defer func() {
 		terror.Log(exec.Close(e))
-		a.logAudit()
+		a.logAudit(err)
 	}()
 
 	// Check if "tidb_snapshot" is set for the write executors.
@@ -1214,18 +1308,19 @@ func (a *ExecStmt) resetPhaseDurations() {
 // QueryReplacer replaces new line and tab for grep result including query string.
 var QueryReplacer = strings.NewReplacer("\r", " ", "\n", " ", "\t", " ")
 
-func (a *ExecStmt) logAudit() {
+func (a *ExecStmt) logAudit(execErr error) {
 	sessVars := a.Ctx.GetSessionVars()
 	if sessVars.InRestrictedSQL {
 		return
 	}
 
+	a.logBuiltinAudit(execErr)
 	err := plugin.ForeachPlugin(plugin.Audit, func(p *plugin.Plugin) error {
-		audit := plugin.DeclareAuditManifest(p.Manifest)
-		if audit.OnGeneralEvent != nil {
+		auditManifest := plugin.DeclareAuditManifest(p.Manifest)
+		if auditManifest.OnGeneralEvent != nil {
 			cmd := mysql.Command2Str[byte(atomic.LoadUint32(&a.Ctx.GetSessionVars().CommandValue))]
 			ctx := context.WithValue(context.Background(), plugin.ExecStartTimeCtxKey, a.Ctx.GetSessionVars().StartTime)
-			audit.OnGeneralEvent(ctx, sessVars, plugin.Completed, cmd)
+			auditManifest.OnGeneralEvent(ctx, sessVars, plugin.Completed, cmd)
 		}
 		return nil
 	})
@@ -1234,6 +1329,153 @@ func (a *ExecStmt) logAudit() {
 	}
 }
 
+// LogFailedStmtAudit writes the statement which fails before it's executed, e.g. it's rejected by the privilege
+// check when it's compiled, to the built-in audit log if it matches the audit rules.
+func LogFailedStmtAudit(sctx sessionctx.Context, stmtNode ast.StmtNode, err error) {
+	if sctx.GetSessionVars().InRestrictedSQL {
+		return
+	}
+	logBuiltinAudit(sctx, stmtNode, err)
+}
+
+// logBuiltinAudit writes the statement to the built-in audit log if it matches the audit rules.
+func (a *ExecStmt) logBuiltinAudit(execErr error) {
+	logBuiltinAudit(a.Ctx, a.StmtNode, execErr)
+}
+
+func logBuiltinAudit(sctx sessionctx.Context, stmtNode ast.StmtNode, execErr error) {
+	if !audit.Enabled() {
+		return
+	}
+	sessVars := sctx.GetSessionVars()
+	var user, host string
+	if sessVars.User != nil {
+		user, host = sessVars.User.Username, sessVars.User.Hostname
+	}
+	stmtClass := audit.ClassifyStmt(stmtNode)
+	tables := sessVars.StmtCtx.Tables
+	dbs := make([]string, 0, len(tables)+1)
+	dbs = append(dbs, sessVars.CurrentDB)
+	tableNames := make([]string, 0, len(tables))
+	for _, t := range tables {
+		dbs = append(dbs, t.DB)
+		tableNames = append(tableNames, t.DB+"."+t.Table)
+	}
+	if !audit.ShouldLog(user, dbs, stmtClass) {
+		return
+	}
+	query := sessVars.StmtCtx.OriginalSQL
+	if sensitiveStmt, ok := stmtNode.(ast.SensitiveStmtNode); ok {
+		query = sensitiveStmt.SecureText()
+	}
+	entry := &audit.Entry{
+		Time:      sessVars.StartTime,
+		ConnID:    sessVars.ConnectionID,
+		User:      user,
+		Host:      host,
+		DB:        sessVars.CurrentDB,
+		Tables:    tableNames,
+		StmtClass: stmtClass,
+		Query:     query,
+		Succ:      execErr == nil,
+		CostTime:  time.Since(sessVars.StartTime).Seconds(),
+	}
+	if execErr != nil {
+		entry.Error = execErr.Error()
+	}
+	audit.Log(entry)
+}
+
+// needPerfSchemaEvent returns whether the statement should be recorded in the
+// events_statements_* and events_waits_* tables of PERFORMANCE_SCHEMA.
+func (a *ExecStmt) needPerfSchemaEvent() bool {
+	sessVars := a.Ctx.GetSessionVars()
+	return perfevents.Enabled() && !sessVars.InRestrictedSQL && sessVars.ConnectionID != 0
+}
+
+func (a *ExecStmt) newPerfSchemaEvent() *perfevents.StatementEvent {
+	sessVars := a.Ctx.GetSessionVars()
+	stmtCtx := sessVars.StmtCtx
+	normalizedSQL, digest := stmtCtx.SQLDigest()
+	e := &perfevents.StatementEvent{
+		ThreadID:      sessVars.ConnectionID,
+		EventName:     perfevents.StatementEventName(ast.GetStmtLabel(a.StmtNode)),
+		StartTime:     sessVars.StartTime,
+		SQLText:       a.GetTextToLog(false),
+		Digest:        digest.String(),
+		DigestText:    normalizedSQL,
+		CurrentSchema: sessVars.CurrentDB,
+	}
+	if sessVars.User != nil {
+		e.User = sessVars.User.Username
+	}
+	return e
+}
+
+// startPerfSchemaEvent records the statement as the current statement of the connection.
+func (a *ExecStmt) startPerfSchemaEvent() {
+	if !a.needPerfSchemaEvent() {
+		return
+	}
+	a.perfEvent = a.newPerfSchemaEvent()
+	perfevents.StartStatement(a.perfEvent)
+}
+
+// endPerfSchemaEvent records the finished statement and the waits during its execution.
+func (a *ExecStmt) endPerfSchemaEvent(execErr error, execDetail *execdetails.ExecDetails) {
+	if !a.needPerfSchemaEvent() {
+		return
+	}
+	stmtCtx := a.Ctx.GetSessionVars().StmtCtx
+	e := a.perfEvent
+	if e == nil {
+		e = a.newPerfSchemaEvent()
+	}
+	e.EndTime = time.Now()
+	e.LockTime = execDetail.LockKeysDuration
+	e.Warnings = uint64(stmtCtx.WarningCount())
+	e.RowsAffected = stmtCtx.AffectedRows()
+	e.RowsSent = uint64(GetResultRowsCount(stmtCtx, a.Plan))
+	if execDetail.ScanDetail != nil {
+		e.RowsExamined = uint64(execDetail.ScanDetail.ProcessedKeys)
+	}
+	if execErr != nil {
+		e.Errors = 1
+		if tErr, ok := errors.Cause(execErr).(*terror.Error); ok {
+			sqlErr := terror.ToSQLError(tErr)
+			e.MySQLErrno, e.SQLState, e.MessageText = sqlErr.Code, sqlErr.State, sqlErr.Message
+		} else {
+			e.MySQLErrno, e.SQLState, e.MessageText = mysql.ErrUnknown, mysql.DefaultMySQLState, execErr.Error()
+		}
+	}
+	var tikvExecDetail util.ExecDetails
+	if tikvExecDetailRaw := a.GoCtx.Value(util.ExecDetailsKey); tikvExecDetailRaw != nil {
+		tikvExecDetail = *(tikvExecDetailRaw.(*util.ExecDetails))
+	}
+	perfevents.EndStatement(e, collectPerfSchemaWaits(execDetail, &tikvExecDetail))
+}
+
+// collectPerfSchemaWaits aggregates the time the statement spent waiting for
+// TiKV, PD, pessimistic locks and backoff into wait events.
+func collectPerfSchemaWaits(execDetail *execdetails.ExecDetails, tikvExecDetail *util.ExecDetails) []perfevents.WaitEvent {
+	var waits []perfevents.WaitEvent
+	addWait := func(name, operation string, count uint64, d time.Duration) {
+		if d > 0 {
+			waits = append(waits, perfevents.WaitEvent{EventName: name, Operation: operation, Count: max(count, 1), Duration: d})
+		}
+	}
+	addWait(perfevents.WaitKVRequest, "request", uint64(execDetail.RequestCount), time.Duration(tikvExecDetail.WaitKVRespDuration))
+	addWait(perfevents.WaitPDRequest, "request", 1, time.Duration(tikvExecDetail.WaitPDRespDuration))
+	if execDetail.ScanDetail != nil {
+		addWait(perfevents.WaitRocksDBBlockRead, "read", uint64(execDetail.ScanDetail.RocksdbBlockReadCount), execDetail.ScanDetail.RocksdbBlockReadDuration)
+	}
+	if execDetail.LockKeysDetail != nil {
+		addWait(perfevents.WaitPessimisticLock, "lock", uint64(execDetail.LockKeysDetail.LockRPCCount), execDetail.LockKeysDetail.TotalTime)
+	}
+	addWait(perfevents.WaitBackoff, "sleep", uint64(tikvExecDetail.BackoffCount), execDetail.BackoffTime)
+	return waits
+}
+
 // FormatSQL is used to format the original SQL, e.g. truncating long SQL, appending prepared arguments.
 func FormatSQL(sql string) stringutil.StringerFunc {
 	return func() string {
@@ -1348,10 +1590,15 @@ func (a *ExecStmt) FinishExecuteStmt(txnTS uint64, err error, hasMoreResults boo
 	// `LowSlowQuery` and `SummaryStmt` must be called before recording `PrevStmt`.
 	a.LogSlowQuery(txnTS, succ, hasMoreResults)
 	a.SummaryStmt(succ)
+	a.recordTableLatency(succ)
+	a.endPerfSchemaEvent(err, &execDetail)
 	a.observeStmtFinishedForTopSQL()
 	if sessVars.StmtCtx.IsTiFlash.Load() {
 		if succ {
 			executor_metrics.TotalTiFlashQuerySuccCounter.Inc()
+			if config.GetGlobalConfig().DisaggregatedTiFlash {
+				a.recordTiFlashCacheResidency()
+			}
 		} else {
 			metrics.TiFlashQueryTotalCounter.WithLabelValues(metrics.ExecuteErrorToLabel(err), metrics.LblError).Inc()
 		}
@@ -1441,9 +1688,12 @@ func (a *ExecStmt) checkPlanReplayerCapture(txnTS uint64) {
 		return
 	}
 	se := a.Ctx
-	if !se.GetSessionVars().InRestrictedSQL && se.GetSessionVars().IsPlanReplayerCaptureEnabled() {
+	sessVars := se.GetSessionVars()
+	if !sessVars.InRestrictedSQL && sessVars.IsPlanReplayerCaptureEnabled() {
 		stmtNode := a.GetStmtNode()
-		if se.GetSessionVars().EnablePlanReplayedContinuesCapture {
+		// the statements faster than the threshold are not captured continuously, but they can still be
+		// captured by the registered plan replayer capture tasks.
+		if sessVars.EnablePlanReplayedContinuesCapture && time.Since(sessVars.StartTime) >= sessVars.PlanReplayerContinuousCaptureThreshold {
 			if checkPlanReplayerContinuesCaptureValidStmt(stmtNode) {
 				checkPlanReplayerContinuesCapture(se, stmtNode, txnTS)
 			}
@@ -1456,7 +1706,7 @@ func (a *ExecStmt) checkPlanReplayerCapture(txnTS uint64) {
 // CloseRecordSet will finish the execution of current statement and do some record work
 func (a *ExecStmt) CloseRecordSet(txnStartTS uint64, lastErr error) {
 	a.FinishExecuteStmt(txnStartTS, lastErr, false)
-	a.logAudit()
+	a.logAudit(lastErr)
 	a.Ctx.GetSessionVars().StmtCtx.DetachMemDiskTracker()
 }
 
@@ -1597,6 +1847,10 @@ func (a *ExecStmt) LogSlowQuery(txnTS uint64, succ bool, hasMoreResults bool) {
 		KVTotal:           time.Duration(atomic.LoadInt64(&tikvExecDetail.WaitKVRespDuration)),
 		PDTotal:           time.Duration(atomic.LoadInt64(&tikvExecDetail.WaitPDRespDuration)),
 		BackoffTotal:      time.Duration(atomic.LoadInt64(&tikvExecDetail.BackoffDuration)),
+		BackoffStrategy:   variable.KVBackoffStrategy.Load(),
+		BackoffBudget:     time.Duration(stmtDetail.BackoffBudgetMs) * time.Millisecond,
+		BackoffBudgetUsed: time.Duration(stmtDetail.BackoffBudgetUsedMs) * time.Millisecond,
+		BackoffExhausted:  stmtDetail.BackoffBudgetExhausted,
 		WriteSQLRespTotal: stmtDetail.WriteSQLRespDuration,
 		ResultRows:        resultRows,
 		ExecRetryCount:    a.retryCount,
@@ -1623,7 +1877,12 @@ func (a *ExecStmt) LogSlowQuery(txnTS uint64, succ bool, hasMoreResults bool) {
 	if _, ok := a.StmtNode.(*ast.CommitStmt); ok && sessVars.PrevStmt != nil {
 		slowItems.PrevStmt = sessVars.PrevStmt.String()
 	}
-	slowLog := sessVars.SlowLogFormat(slowItems)
+	var slowLog string
+	if logutil.IsSlowQueryLogJSON() {
+		slowLog = sessVars.SlowLogFormatJSON(slowItems)
+	} else {
+		slowLog = sessVars.SlowLogFormat(slowItems)
+	}
 	if trace.IsEnabled() {
 		trace.Log(a.GoCtx, "details", slowLog)
 	}
@@ -1803,6 +2062,51 @@ func getEncodedPlan(stmtCtx *stmtctx.StatementContext, genHint bool) (encodedPla
 	return
 }
 
+// recordTableLatency collects the latency of the statement per table and index it accesses for
+// information_schema.table_latency_stats.
+func (a *ExecStmt) recordTableLatency(succ bool) {
+	sessVars := a.Ctx.GetSessionVars()
+	stmtCtx := sessVars.StmtCtx
+	if sessVars.InRestrictedSQL || a.InfoSchema == nil || len(stmtCtx.Tables) == 0 {
+		return
+	}
+	// only the queries and DMLs are recorded.
+	if _, ok := a.StmtNode.(ast.DMLNode); !ok {
+		return
+	}
+	objects := make([]tablelatency.ObjectID, 0, len(stmtCtx.Tables)+len(stmtCtx.IndexNames))
+	for _, entry := range stmtCtx.Tables {
+		if util2.IsMemOrSysDB(strings.ToLower(entry.DB)) {
+			continue
+		}
+		// the CTEs and the tables not existing are skipped.
+		tbl, err := a.InfoSchema.TableByName(model.NewCIStr(entry.DB), model.NewCIStr(entry.Table))
+		if err != nil {
+			continue
+		}
+		tblInfo := tbl.Meta()
+		if id := (tablelatency.ObjectID{TableID: tblInfo.ID, IndexID: tablelatency.TableLevelIndexID}); !slices.Contains(objects, id) {
+			objects = append(objects, id)
+		}
+		// the index names are recorded as "table:index" by the executor builder.
+		for _, name := range stmtCtx.IndexNames {
+			idxName, ok := strings.CutPrefix(name, tblInfo.Name.O+":")
+			if !ok {
+				continue
+			}
+			idx := tblInfo.FindIndexByName(strings.ToLower(idxName))
+			if idx == nil {
+				continue
+			}
+			id := tablelatency.ObjectID{TableID: tblInfo.ID, IndexID: idx.ID}
+			if !slices.Contains(objects, id) {
+				objects = append(objects, id)
+			}
+		}
+	}
+	tablelatency.GlobalCollector.Record(objects, time.Since(sessVars.StartTime)+sessVars.DurationParse, succ)
+}
+
 // SummaryStmt collects statements for information_schema.statements_summary
 func (a *ExecStmt) SummaryStmt(succ bool) {
 	sessVars := a.Ctx.GetSessionVars()
@@ -2025,6 +2329,28 @@ func (a *ExecStmt) observeStmtFinishedForTopSQL() {
 	}
 }
 
+// recordTiFlashCacheResidency records how much data the TiFlash table scans of the statement read from the local
+// cache of tiflash_compute nodes, the cost model uses it to estimate the cost of reading S3 in disaggregated TiFlash.
+func (a *ExecStmt) recordTiFlashCacheResidency() {
+	statsColl := a.Ctx.GetSessionVars().StmtCtx.RuntimeStatsColl
+	if statsColl == nil || a.Plan == nil {
+		return
+	}
+	flat := plannercore.FlattenPhysicalPlan(a.Plan, false)
+	if flat == nil {
+		return
+	}
+	for _, op := range flat.Main {
+		ts, ok := op.Origin.(*plannercore.PhysicalTableScan)
+		if !ok || ts.StoreType != kv.TiFlash || !statsColl.ExistsCopStats(ts.ID()) {
+			continue
+		}
+		_, _, _, _, _, scanCtx := statsColl.GetCopStats(ts.ID()).MergeBasicStats()
+		hit, miss := scanCtx.DisaggReadCacheSize()
+		tiflashcompute.UpdateTableCacheResidency(ts.Table.ID, hit, miss)
+	}
+}
+
 func (a *ExecStmt) getSQLPlanDigest() ([]byte, []byte) {
 	var sqlDigest, planDigest []byte
 	vars := a.Ctx.GetSessionVars()
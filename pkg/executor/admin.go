@@ -594,12 +594,15 @@ type CleanupIndexExec struct {
 	idxChunk         *chunk.Chunk
 	handleCols       plannercore.HandleCols
 
-	idxValues   *kv.HandleMap // kv.Handle -> [][]types.Datum
-	batchSize   uint64
-	batchKeys   []kv.Key
-	idxValsBufs [][]types.Datum
-	lastIdxKey  []byte
-	scanRowCnt  uint64
+	idxValues *kv.HandleMap // kv.Handle -> [][]types.Datum
+	// batchHandles keeps the scanned handles in order, the handles of a global
+	// index are kv.PartitionHandle.
+	batchHandles []kv.Handle
+	batchSize    uint64
+	batchKeys    []kv.Key
+	idxValsBufs  [][]types.Datum
+	lastIdxKey   []byte
+	scanRowCnt   uint64
 }
 
 func (e *CleanupIndexExec) getIdxColTypes() []*types.FieldType {
@@ -614,10 +617,13 @@ func (e *CleanupIndexExec) getIdxColTypes() []*types.FieldType {
 }
 
 func (e *CleanupIndexExec) batchGetRecord(txn kv.Transaction) (map[string][]byte, error) {
-	e.idxValues.Range(func(h kv.Handle, _ any) bool {
+	for _, h := range e.batchHandles {
+		if ph, ok := h.(kv.PartitionHandle); ok {
+			e.batchKeys = append(e.batchKeys, tablecodec.EncodeRowKeyWithHandle(ph.PartitionID, ph.Handle))
+			continue
+		}
 		e.batchKeys = append(e.batchKeys, tablecodec.EncodeRecordKey(e.table.RecordPrefix(), h))
-		return true
-	})
+	}
 	values, err := txn.BatchGet(context.Background(), e.batchKeys)
 	if err != nil {
 		return nil, err
@@ -625,19 +631,34 @@ func (e *CleanupIndexExec) batchGetRecord(txn kv.Transaction) (map[string][]byte
 	return values, nil
 }
 
+// indexForHandle returns the index to delete the entry of the handle from. The
+// entry of a global index has to be deleted by the index of its partition.
+func (e *CleanupIndexExec) indexForHandle(h kv.Handle) (table.Index, kv.Handle) {
+	ph, ok := h.(kv.PartitionHandle)
+	if !ok {
+		return e.index, h
+	}
+	if tbl, ok := e.table.(table.PartitionedTable); ok {
+		if p := tbl.GetPartition(ph.PartitionID); p != nil {
+			if idx := tables.GetWritableIndexByName(e.index.Meta().Name.L, p); idx != nil {
+				return idx, ph.Handle
+			}
+		}
+	}
+	// The partition has been dropped, the entry is dangling anyway.
+	return e.index, ph.Handle
+}
+
 func (e *CleanupIndexExec) deleteDanglingIdx(txn kv.Transaction, values map[string][]byte) error {
-	for _, k := range e.batchKeys {
+	for i, k := range e.batchKeys {
 		if _, found := values[string(k)]; !found {
-			_, handle, err := tablecodec.DecodeRecordKey(k)
-			if err != nil {
-				return err
-			}
-			handleIdxValsGroup, ok := e.idxValues.Get(handle)
+			handleIdxValsGroup, ok := e.idxValues.Get(e.batchHandles[i])
 			if !ok {
 				return errors.Trace(errors.Errorf("batch keys are inconsistent with handles"))
 			}
+			index, handle := e.indexForHandle(e.batchHandles[i])
 			for _, handleIdxVals := range handleIdxValsGroup.([][]types.Datum) {
-				if err := e.index.Delete(e.Ctx().GetTableCtx(), txn, handleIdxVals, handle); err != nil {
+				if err := index.Delete(e.Ctx().GetTableCtx(), txn, handleIdxVals, handle); err != nil {
 					return err
 				}
 				e.removeCnt++
@@ -675,8 +696,9 @@ func (e *CleanupIndexExec) fetchIndex(ctx context.Context, txn kv.Transaction) e
 
 	sc := e.Ctx().GetSessionVars().StmtCtx
 	idxColLen := len(e.index.Meta().Columns)
+	isGlobal := e.index.Meta().Global
 	for {
-		err := result.Next(ctx, e.idxChunk)
+		err = result.Next(ctx, e.idxChunk)
 		if err != nil {
 			return err
 		}
@@ -685,9 +707,19 @@ func (e *CleanupIndexExec) fetchIndex(ctx context.Context, txn kv.Transaction) e
 		}
 		iter := chunk.NewIterator4Chunk(e.idxChunk)
 		for row := iter.Begin(); row != iter.End(); row = iter.Next() {
-			handle, err := e.handleCols.BuildHandle(row)
-			if err != nil {
-				return err
+			var handle, keyHandle kv.Handle
+			if isGlobal {
+				ph, err := e.handleCols.BuildPartitionHandleFromIndexRow(row)
+				if err != nil {
+					return err
+				}
+				handle, keyHandle = ph, ph.Handle
+			} else {
+				handle, err = e.handleCols.BuildHandle(row)
+				if err != nil {
+					return err
+				}
+				keyHandle = handle
 			}
 			idxVals := extractIdxVals(row, e.idxValsBufs[e.scanRowCnt], e.idxColFieldTypes, idxColLen)
 			e.idxValsBufs[e.scanRowCnt] = idxVals
@@ -697,8 +729,9 @@ func (e *CleanupIndexExec) fetchIndex(ctx context.Context, txn kv.Transaction) e
 				e.idxValues.Set(handle, updatedIdxVals)
 			} else {
 				e.idxValues.Set(handle, [][]types.Datum{idxVals})
+				e.batchHandles = append(e.batchHandles, handle)
 			}
-			idxKey, _, err := e.index.GenIndexKey(sc.ErrCtx(), sc.TimeZone(), idxVals, handle, nil)
+			idxKey, _, err := e.index.GenIndexKey(sc.ErrCtx(), sc.TimeZone(), idxVals, keyHandle, nil)
 			if err != nil {
 				return err
 			}
@@ -725,7 +758,9 @@ func (e *CleanupIndexExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	}
 
 	var err error
-	if tbl, ok := e.table.(table.PartitionedTable); ok {
+	// The entries of a global index are stored with the partitioned table ID, so
+	// the index is scanned as a whole rather than partition by partition.
+	if tbl, ok := e.table.(table.PartitionedTable); ok && !e.index.Meta().Global {
 		pi := e.table.Meta().GetPartitionInfo()
 		for _, p := range pi.Definitions {
 			e.table = tbl.GetPartition(p.ID)
@@ -779,10 +814,8 @@ func (e *CleanupIndexExec) cleanTableIndex(ctx context.Context) error {
 		}
 		e.scanRowCnt = 0
 		e.batchKeys = e.batchKeys[:0]
-		e.idxValues.Range(func(h kv.Handle, _ any) bool {
-			e.idxValues.Delete(h)
-			return true
-		})
+		e.batchHandles = e.batchHandles[:0]
+		e.idxValues = kv.NewHandleMap()
 	}
 	return nil
 }
@@ -836,6 +869,7 @@ func (e *CleanupIndexExec) init() error {
 	e.idxChunk = chunk.New(e.getIdxColTypes(), e.InitCap(), e.MaxChunkSize())
 	e.idxValues = kv.NewHandleMap()
 	e.batchKeys = make([]kv.Key, 0, e.batchSize)
+	e.batchHandles = make([]kv.Handle, 0, e.batchSize)
 	e.idxValsBufs = make([][]types.Datum, e.batchSize)
 	sc := e.Ctx().GetSessionVars().StmtCtx
 	idxKey, _, err := e.index.GenIndexKey(sc.ErrCtx(), sc.TimeZone(), []types.Datum{{}}, kv.IntHandle(math.MinInt64), nil)
@@ -623,6 +623,8 @@ func buildJSONArrayagg(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFun
 	switch aggFuncDesc.Mode {
 	case aggregation.DedupMode:
 		return nil
+	case aggregation.FinalMode, aggregation.Partial2Mode:
+		return &jsonArrayagg4Final{jsonArrayagg{base}}
 	default:
 		return &jsonArrayagg{base}
 	}
@@ -637,6 +639,8 @@ func buildJSONObjectAgg(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFu
 	switch aggFuncDesc.Mode {
 	case aggregation.DedupMode:
 		return nil
+	case aggregation.FinalMode, aggregation.Partial2Mode:
+		return &jsonObjectAgg4Final{jsonObjectAgg{base}}
 	default:
 		return &jsonObjectAgg{base}
 	}
@@ -18,6 +18,7 @@ import (
 	"unsafe"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression/aggregation"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 )
@@ -69,7 +70,7 @@ func (e *jsonArrayagg) UpdatePartialResult(ctx AggFuncUpdateContext, rowsInGroup
 			return 0, errors.Trace(err)
 		}
 
-		realItem, err := getRealJSONValue(item, e.args[0].GetType())
+		realItem, err := aggregation.GetRealJSONValue(item, e.args[0].GetType())
 		if err != nil {
 			return 0, errors.Trace(err)
 		}
@@ -110,3 +111,31 @@ func (e *jsonArrayagg) deserializeForSpill(helper *deserializeHelper) (PartialRe
 	}
 	return pr, memDelta
 }
+
+// jsonArrayagg4Final merges the JSON arrays produced by the partial json_arrayagg, e.g. the one pushed down to TiKV.
+type jsonArrayagg4Final struct {
+	jsonArrayagg
+}
+
+func (e *jsonArrayagg4Final) UpdatePartialResult(ctx AggFuncUpdateContext, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	p := (*partialResult4JsonArrayagg)(pr)
+	for _, row := range rowsInGroup {
+		partial, isNull, err := e.args[0].EvalJSON(ctx, row)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		if isNull {
+			continue
+		}
+		if partial.TypeCode != types.JSONTypeCodeArray {
+			return 0, errors.Errorf("the partial result of json_arrayagg should be an array, but got %s", partial.Type())
+		}
+		for i := 0; i < partial.GetElemCount(); i++ {
+			// The elements reference the memory of the row, copy them before keeping them.
+			elem := partial.ArrayGetElem(i).Copy()
+			p.entries = append(p.entries, elem)
+			memDelta += getValMemDelta(elem)
+		}
+	}
+	return memDelta, nil
+}
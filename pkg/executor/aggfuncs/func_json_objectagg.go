@@ -19,8 +19,8 @@ import (
 	"unsafe"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression/aggregation"
 	"github.com/pingcap/tidb/pkg/parser/charset"
-	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/hack"
@@ -90,21 +90,14 @@ func (e *jsonObjectAgg) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGro
 			return 0, errors.Trace(err)
 		}
 
-		realVal, err := getRealJSONValue(value, e.args[1].GetType())
+		realVal, err := aggregation.GetRealJSONValue(value, e.args[1].GetType())
 		if err != nil {
 			return 0, errors.Trace(err)
 		}
 
 		switch x := realVal.(type) {
 		case nil, bool, int64, uint64, float64, string, types.BinaryJSON, types.Opaque, types.Time, types.Duration:
-			if _, ok := p.entries[key]; !ok {
-				memDelta += int64(len(key)) + getValMemDelta(realVal)
-				if len(p.entries)+1 > (1<<p.bInMap)*hack.LoadFactorNum/hack.LoadFactorDen {
-					memDelta += (1 << p.bInMap) * hack.DefBucketMemoryUsageForMapStringToAny
-					p.bInMap++
-				}
-			}
-			p.entries[key] = realVal
+			memDelta += p.put(key, realVal)
 
 		default:
 			return 0, types.ErrUnsupportedSecondArgumentType.GenWithStackByArgs(x)
@@ -133,50 +126,44 @@ func (e *jsonObjectAgg) deserializeForSpill(helper *deserializeHelper) (PartialR
 	return pr, memDelta + deserializeMemDelta
 }
 
-func getRealJSONValue(value types.Datum, ft *types.FieldType) (any, error) {
-	realVal := value.Clone().GetValue()
-	switch value.Kind() {
-	case types.KindBinaryLiteral, types.KindMysqlBit, types.KindBytes:
-		buf := value.GetBytes()
-		realVal = types.Opaque{
-			TypeCode: ft.GetType(),
-			Buf:      buf,
-		}
-	case types.KindString:
-		if ft.GetCharset() == charset.CharsetBin {
-			buf := value.GetBytes()
-			resultBuf := buf
-			if ft.GetType() == mysql.TypeString {
-				// the tailing zero should also be in the opaque json
-				resultBuf = make([]byte, ft.GetFlen())
-				copy(resultBuf, buf)
-			}
-			realVal = types.Opaque{
-				TypeCode: ft.GetType(),
-				Buf:      resultBuf,
-			}
+// put puts the key and the value into the entries, and returns the memory delta.
+func (p *partialResult4JsonObjectAgg) put(key string, val any) (memDelta int64) {
+	if _, ok := p.entries[key]; !ok {
+		memDelta += int64(len(key)) + getValMemDelta(val)
+		if len(p.entries)+1 > (1<<p.bInMap)*hack.LoadFactorNum/hack.LoadFactorDen {
+			memDelta += (1 << p.bInMap) * hack.DefBucketMemoryUsageForMapStringToAny
+			p.bInMap++
 		}
 	}
+	p.entries[key] = val
+	return memDelta
+}
 
-	// appendBinary does not support some type such as uint8、types.time，so convert is needed here
-	switch x := realVal.(type) {
-	case float32:
-		realVal = float64(x)
-	case *types.MyDecimal:
-		float64Val, err := x.ToFloat64()
+// jsonObjectAgg4Final merges the JSON objects produced by the partial json_objectagg, e.g. the one pushed down to TiKV.
+type jsonObjectAgg4Final struct {
+	jsonObjectAgg
+}
+
+func (e *jsonObjectAgg4Final) UpdatePartialResult(ctx AggFuncUpdateContext, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	p := (*partialResult4JsonObjectAgg)(pr)
+	for _, row := range rowsInGroup {
+		partial, isNull, err := e.args[0].EvalJSON(ctx, row)
 		if err != nil {
-			return nil, errors.Trace(err)
+			return 0, errors.Trace(err)
 		}
-		realVal = float64Val
-	case []uint8:
-		strVal, err := types.ToString(x)
-		if err != nil {
-			return nil, errors.Trace(err)
+		if isNull {
+			continue
+		}
+		if partial.TypeCode != types.JSONTypeCodeObject {
+			return 0, errors.Errorf("the partial result of json_objectagg should be an object, but got %s", partial.Type())
+		}
+		for i := 0; i < partial.GetElemCount(); i++ {
+			// The keys and values reference the memory of the row, copy them before keeping them.
+			key := string(partial.ObjectGetKey(i))
+			memDelta += p.put(key, partial.ObjectGetVal(i).Copy())
 		}
-		realVal = strVal
 	}
-
-	return realVal, nil
+	return memDelta, nil
 }
 
 func getValMemDelta(val any) (memDelta int64) {
@@ -105,6 +105,16 @@ func (e *AnalyzeExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 		return nil
 	}
 
+	if len(skippedTables) == 0 {
+		distributed, err := e.tryRunDistTask(ctx, tasks, infoSchema)
+		if err != nil {
+			return err
+		}
+		if distributed {
+			return statsHandle.Update(infoSchema)
+		}
+	}
+
 	// Get the min number of goroutines for parallel execution.
 	concurrency, err := getBuildStatsConcurrency(e.Ctx())
 	if err != nil {
@@ -0,0 +1,185 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/analyze"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	fstorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// tryRunDistTask runs the ANALYZE TABLE on the distributed task framework if the
+// statement analyzes multiple partitions of a partitioned table, so the partitions
+// are analyzed on multiple TiDB nodes, and the analyzed ones are not analyzed
+// again when the task is resumed. It returns false if the statement should be
+// executed locally.
+func (e *AnalyzeExec) tryRunDistTask(ctx context.Context, tasks []*analyzeTask, is infoschema.InfoSchema) (bool, error) {
+	sessVars := e.Ctx().GetSessionVars()
+	// the subtasks are executed by internal sessions, and each of them analyzes
+	// one partition, they never run on the distributed task framework again.
+	if !variable.EnableDistTask.Load() || sessVars.InRestrictedSQL {
+		return false, nil
+	}
+	taskMeta, err := e.buildDistTaskMeta(tasks, is)
+	if err != nil || taskMeta == nil {
+		return false, err
+	}
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	concurrency, err := getBuildStatsConcurrency(e.Ctx())
+	if err != nil {
+		return false, err
+	}
+	cpuCount, err := handle.GetCPUCountOfManagedNode(ctx)
+	if err != nil {
+		return false, err
+	}
+	concurrency = max(min(concurrency, cpuCount), 1)
+	ver, err := e.Ctx().GetStore().CurrentVersion(kv.GlobalTxnScope)
+	if err != nil {
+		return false, err
+	}
+	task, err := analyze.SubmitOrResumeTask(ctx, taskMeta, ver.Ver, concurrency)
+	if err != nil {
+		return false, err
+	}
+	logutil.Logger(ctx).Info("analyze table on the distributed task framework",
+		zap.Int64("task-id", task.ID), zap.String("task-key", task.Key),
+		zap.String("table", taskMeta.TableName), zap.Int("partitions", len(taskMeta.Partitions)))
+	return true, e.waitDistTask(ctx, task)
+}
+
+// buildDistTaskMeta returns nil if the statement doesn't analyze multiple partitions
+// of one partitioned table.
+func (e *AnalyzeExec) buildDistTaskMeta(tasks []*analyzeTask, is infoschema.InfoSchema) (*analyze.TaskMeta, error) {
+	var tableID int64
+	partitionIDs := make(map[int64]struct{}, len(tasks))
+	for _, task := range tasks {
+		id := getTableIDFromTask(task)
+		if !id.IsPartitionTable() || (tableID != 0 && tableID != id.TableID) {
+			return nil, nil
+		}
+		tableID = id.TableID
+		partitionIDs[id.PartitionID] = struct{}{}
+	}
+	if len(partitionIDs) < 2 {
+		return nil, nil
+	}
+	tbl, ok := is.TableByID(tableID)
+	if !ok {
+		return nil, nil
+	}
+	tblInfo := tbl.Meta()
+	schema, ok := infoschema.SchemaByTable(is, tblInfo)
+	if !ok {
+		return nil, nil
+	}
+
+	// the subtasks analyze the partitions with the original statement, so that
+	// the columns, indexes and options to analyze are kept.
+	sessVars := e.Ctx().GetSessionVars()
+	p := parser.New()
+	p.SetSQLMode(sessVars.SQLMode)
+	p.SetParserConfig(sessVars.BuildParserConfig())
+	charset, collation := sessVars.GetCharsetInfo()
+	stmtNode, err := p.ParseOneStmt(sessVars.StmtCtx.OriginalSQL, charset, collation)
+	if err != nil {
+		// e.g. the statement is executed by EXECUTE.
+		return nil, nil
+	}
+	stmt, ok := stmtNode.(*ast.AnalyzeTableStmt)
+	if !ok || len(stmt.TableNames) != 1 || stmt.Incremental ||
+		stmt.HistogramOperation != ast.HistogramOperationNop ||
+		stmt.TableNames[0].Name.L != tblInfo.Name.L {
+		return nil, nil
+	}
+	stmt.TableNames[0].Schema = schema.Name
+	stmt.PartitionNames = nil
+	var sb strings.Builder
+	if err = stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	partitions := make([]string, 0, len(partitionIDs))
+	for _, def := range tblInfo.GetPartitionInfo().Definitions {
+		if _, ok := partitionIDs[def.ID]; ok {
+			partitions = append(partitions, def.Name.O)
+		}
+	}
+	return &analyze.TaskMeta{
+		DBName:          schema.Name.O,
+		TableName:       tblInfo.Name.O,
+		TableID:         tableID,
+		Stmt:            sb.String(),
+		Partitions:      partitions,
+		StatsVer:        sessVars.AnalyzeVersion,
+		PruneMode:       sessVars.PartitionPruneMode.Load(),
+		AnalyzeSnapshot: sessVars.EnableAnalyzeSnapshot,
+	}, nil
+}
+
+func (e *AnalyzeExec) waitDistTask(ctx context.Context, task *proto.TaskBase) error {
+	var killErr error
+	found, err := handle.WaitTask(ctx, task.ID, func(t *proto.TaskBase) bool {
+		killErr = e.Ctx().GetSessionVars().SQLKiller.HandleSignal()
+		return killErr != nil || t.IsDone() || t.State == proto.TaskStatePaused
+	})
+	if killErr != nil || errors.Cause(err) == context.Canceled {
+		// pause the task instead of cancelling it, so the analyzed partitions
+		// are skipped when the task is resumed.
+		// use background, since ctx might be canceled already.
+		if err2 := handle.PauseTask(context.Background(), task.Key); err2 != nil {
+			logutil.Logger(ctx).Warn("pause analyze task failed", zap.Int64("task-id", task.ID), zap.Error(err2))
+		}
+		if killErr != nil {
+			return killErr
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	switch found.State {
+	case proto.TaskStateSucceed:
+		return nil
+	case proto.TaskStatePaused:
+		return errors.Errorf("analyze task %d is paused, run ANALYZE TABLE again to resume it", task.ID)
+	}
+	taskManager, err := fstorage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	stopped, err := taskManager.GetTaskByIDWithHistory(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if stopped.Error != nil {
+		return stopped.Error
+	}
+	return errors.Errorf("analyze task %d stopped with state %s", task.ID, stopped.State)
+}
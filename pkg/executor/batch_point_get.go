@@ -15,6 +15,7 @@
 package executor
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"slices"
@@ -248,7 +249,8 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 			}
 
 			// If this BatchPointGetExec is built only for the specific table partition, skip those filters not matching this partition.
-			if e.singlePart && e.partTblID != physID {
+			// The partition of a row in the global index is known after the index is read.
+			if e.singlePart && e.partPos != core.GlobalWithoutColumnPos && e.partTblID != physID {
 				continue
 			}
 			idxKey, err1 := EncodeUniqueIndexKey(e.Ctx(), e.tblInfo, e.idxInfo, idxVals, physID)
@@ -266,11 +268,12 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 			toFetchIndexKeys = append(toFetchIndexKeys, idxKey)
 		}
 		if e.keepOrder {
+			// The keys may belong to different partitions, so they are ordered by the index values only.
 			slices.SortFunc(toFetchIndexKeys, func(i, j kv.Key) int {
 				if e.desc {
-					return j.Cmp(i)
+					i, j = j, i
 				}
-				return i.Cmp(j)
+				return bytes.Compare(tablecodec.CutIndexPrefix(i), tablecodec.CutIndexPrefix(j))
 			})
 		}
 
@@ -303,6 +306,17 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 			if len(handleVal) == 0 {
 				continue
 			}
+			var globalPID int64
+			if e.idxInfo.Global {
+				segs := tablecodec.SplitIndexValue(handleVal)
+				_, globalPID, err = codec.DecodeInt(segs.PartitionID)
+				if err != nil {
+					return err
+				}
+				if e.singlePart && e.partTblID != globalPID {
+					continue
+				}
+			}
 			handle, err1 := tablecodec.DecodeHandleInUniqueIndexValue(handleVal, e.tblInfo.IsCommonHandle)
 			if err1 != nil {
 				return err1
@@ -314,11 +328,7 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 			if e.tblInfo.Partition != nil {
 				var pid int64
 				if e.idxInfo.Global {
-					segs := tablecodec.SplitIndexValue(handleVal)
-					_, pid, err = codec.DecodeInt(segs.PartitionID)
-					if err != nil {
-						return err
-					}
+					pid = globalPID
 					e.physIDs = append(e.physIDs, pid)
 				} else {
 					pid = tablecodec.DecodeTableID(key)
@@ -372,7 +382,24 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 				return uintComparator(i, j)
 			}
 		}
-		slices.SortFunc(e.handles, less)
+		if len(e.planPhysIDs) > 0 {
+			// The partition IDs calculated by the plan are sorted along with the handles.
+			order := make([]int, len(e.handles))
+			for i := range order {
+				order[i] = i
+			}
+			slices.SortFunc(order, func(i, j int) int {
+				return less(e.handles[i], e.handles[j])
+			})
+			handles := make([]kv.Handle, len(order))
+			physIDs := make([]int64, len(order))
+			for i, pos := range order {
+				handles[i], physIDs[i] = e.handles[pos], e.planPhysIDs[pos]
+			}
+			e.handles, e.planPhysIDs = handles, physIDs
+		} else {
+			slices.SortFunc(e.handles, less)
+		}
 	}
 
 	keys := make([]kv.Key, 0, len(e.handles))
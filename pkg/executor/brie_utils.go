@@ -24,9 +24,12 @@ import (
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/generatedexpr"
 	"go.uber.org/zap"
 )
 
@@ -181,3 +184,160 @@ func splitBatchCreateTable(sctx sessionctx.Context, schema model.CIStr,
 	}
 	return err
 }
+
+// TableIDRewrite records how the IDs of a backed up table are mapped to the
+// IDs of the table restored into the cluster.
+type TableIDRewrite struct {
+	DBName     model.CIStr
+	TableName  model.CIStr
+	OldTableID int64
+	NewTableID int64
+	// Partitions maps the old partition IDs to the new ones.
+	Partitions map[int64]int64
+}
+
+// BRIERestoreTableSchemas restores the table schemas into a running cluster.
+// Unlike BRIECreateTables, the table IDs in the backup are never reused: fresh
+// IDs are allocated for the tables and their partitions, and the references
+// to other schemas (foreign keys and sequence defaults) are rewritten by
+// dbNameRewrite, which maps the backed up database names to the target ones.
+// The tables already existing in the cluster are kept as is. The returned
+// rewrites are keyed by the old table IDs and are resolved from the latest
+// infoschema, so they point to the existing tables for the skipped ones.
+func BRIERestoreTableSchemas(
+	sctx sessionctx.Context,
+	tables map[string][]*model.TableInfo,
+	dbNameRewrite map[string]string,
+	brComment string,
+) (map[int64]*TableIDRewrite, error) {
+	rewriteDBName := func(name model.CIStr) model.CIStr {
+		if newName, ok := dbNameRewrite[name.L]; ok {
+			return model.NewCIStr(newName)
+		}
+		return name
+	}
+
+	restoreTables := make(map[string][]*model.TableInfo, len(tables))
+	originTables := make(map[string][]*model.TableInfo, len(tables))
+	for db, tablesInDB := range tables {
+		dbName := rewriteDBName(model.NewCIStr(db))
+		cloneTables := make([]*model.TableInfo, 0, len(tablesInDB))
+		for _, table := range tablesInDB {
+			cloneTable := table.Clone()
+			if err := rewriteTableSchemaReferences(cloneTable, rewriteDBName); err != nil {
+				return nil, errors.Trace(err)
+			}
+			cloneTables = append(cloneTables, cloneTable)
+		}
+		restoreTables[dbName.O] = append(restoreTables[dbName.O], cloneTables...)
+		originTables[dbName.O] = append(originTables[dbName.O], tablesInDB...)
+	}
+
+	allocAll := ddl.AllocTableIDIf(func(*model.TableInfo) bool { return true })
+	if err := BRIECreateTables(sctx, restoreTables, brComment, allocAll); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	is := domain.GetDomain(sctx).InfoSchema()
+	rewrites := make(map[int64]*TableIDRewrite)
+	for db, tablesInDB := range originTables {
+		dbName := model.NewCIStr(db)
+		for _, table := range tablesInDB {
+			newTable, err := is.TableByName(dbName, table.Name)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			rewrite, err := buildTableIDRewrite(dbName, table, newTable.Meta())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			rewrites[table.ID] = rewrite
+		}
+	}
+	return rewrites, nil
+}
+
+// rewriteTableSchemaReferences rewrites the database names referenced by the
+// table, and resets the states which only make sense for the original table.
+func rewriteTableSchemaReferences(table *model.TableInfo, rewriteDBName func(model.CIStr) model.CIStr) error {
+	for _, fk := range table.ForeignKeys {
+		fk.RefSchema = rewriteDBName(fk.RefSchema)
+	}
+	for _, col := range table.Columns {
+		if !col.DefaultIsExpr {
+			continue
+		}
+		expr, ok := col.DefaultValue.(string)
+		if !ok {
+			continue
+		}
+		newExpr, err := rewriteSequenceDefaultValue(expr, rewriteDBName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		col.DefaultValue = newExpr
+	}
+	if table.TiFlashReplica != nil {
+		table.TiFlashReplica.Available = false
+		table.TiFlashReplica.AvailablePartitionIDs = nil
+	}
+	return nil
+}
+
+// rewriteSequenceDefaultValue rewrites the schema of the sequence referenced
+// by a `nextval` default value. Other expressions are returned unchanged.
+func rewriteSequenceDefaultValue(expr string, rewriteDBName func(model.CIStr) model.CIStr) (string, error) {
+	node, err := generatedexpr.ParseExpression(expr)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	fn, ok := node.(*ast.FuncCallExpr)
+	if !ok || fn.FnName.L != ast.NextVal || len(fn.Args) != 1 {
+		return expr, nil
+	}
+	seq, ok := fn.Args[0].(*ast.TableNameExpr)
+	if !ok || seq.Name.Schema.L == "" {
+		return expr, nil
+	}
+	seq.Name.Schema = rewriteDBName(seq.Name.Schema)
+
+	var sb strings.Builder
+	// keep the same format as the sequence default value stored by DDL.
+	restoreFlags := format.RestoreStringSingleQuotes | format.RestoreKeyWordLowercase | format.RestoreNameBackQuotes |
+		format.RestoreSpacesAroundBinaryOperation
+	if err := fn.Restore(format.NewRestoreCtx(restoreFlags, &sb)); err != nil {
+		return "", errors.Trace(err)
+	}
+	return sb.String(), nil
+}
+
+// buildTableIDRewrite maps the IDs of the backed up table to the restored one,
+// the partitions are matched by their names.
+func buildTableIDRewrite(dbName model.CIStr, oldTable, newTable *model.TableInfo) (*TableIDRewrite, error) {
+	rewrite := &TableIDRewrite{
+		DBName:     dbName,
+		TableName:  newTable.Name,
+		OldTableID: oldTable.ID,
+		NewTableID: newTable.ID,
+	}
+	oldParts, newParts := oldTable.GetPartitionInfo(), newTable.GetPartitionInfo()
+	if oldParts == nil && newParts == nil {
+		return rewrite, nil
+	}
+	if oldParts == nil || newParts == nil || len(oldParts.Definitions) != len(newParts.Definitions) {
+		return nil, errors.Errorf("the partitions of table %s.%s mismatch the backup", dbName.O, newTable.Name.O)
+	}
+	newPartIDs := make(map[string]int64, len(newParts.Definitions))
+	for _, def := range newParts.Definitions {
+		newPartIDs[def.Name.L] = def.ID
+	}
+	rewrite.Partitions = make(map[int64]int64, len(oldParts.Definitions))
+	for _, def := range oldParts.Definitions {
+		newID, ok := newPartIDs[def.Name.L]
+		if !ok {
+			return nil, errors.Errorf("partition %s of table %s.%s is not found", def.Name.O, dbName.O, newTable.Name.O)
+		}
+		rewrite.Partitions[def.ID] = newID
+	}
+	return rewrite, nil
+}
@@ -320,3 +320,64 @@ func TestBRIECreateTables(t *testing.T) {
 		tk.MustExec(fmt.Sprintf("desc table_%d", i))
 	}
 }
+
+func TestBRIERestoreTableSchemas(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database src")
+	tk.MustExec("create database dst")
+	tk.MustExec("create sequence src.seq")
+	tk.MustExec("create sequence dst.seq")
+	tk.MustExec("create table src.parent (id int primary key)")
+	tk.MustExec("create table src.child (id int primary key, pid int, s int default nextval(src.seq), " +
+		"foreign key (pid) references src.parent(id))")
+	tk.MustExec("create table src.part (id int primary key) partition by hash(id) partitions 2")
+	// the existing table is kept.
+	tk.MustExec("create table dst.parent (id int primary key, v int)")
+
+	is := dom.InfoSchema()
+	var infos []*model.TableInfo
+	for _, name := range []string{"parent", "child", "part"} {
+		tbl, err := is.TableByName(model.NewCIStr("src"), model.NewCIStr(name))
+		require.NoError(t, err)
+		infos = append(infos, tbl.Meta())
+	}
+
+	sctx := tk.Session()
+	originQueryString := sctx.Value(sessionctx.QueryString)
+	rewrites, err := executor.BRIERestoreTableSchemas(sctx, map[string][]*model.TableInfo{"src": infos},
+		map[string]string{"src": "dst"}, "/* from test */")
+	require.NoError(t, err)
+	require.Equal(t, originQueryString, sctx.Value(sessionctx.QueryString))
+	require.Len(t, rewrites, 3)
+
+	is = dom.InfoSchema()
+	parent, err := is.TableByName(model.NewCIStr("dst"), model.NewCIStr("parent"))
+	require.NoError(t, err)
+	require.Len(t, parent.Meta().Columns, 2)
+	require.Equal(t, parent.Meta().ID, rewrites[infos[0].ID].NewTableID)
+
+	child, err := is.TableByName(model.NewCIStr("dst"), model.NewCIStr("child"))
+	require.NoError(t, err)
+	childInfo := child.Meta()
+	require.NotEqual(t, infos[1].ID, childInfo.ID)
+	require.Equal(t, childInfo.ID, rewrites[infos[1].ID].NewTableID)
+	require.Equal(t, "dst", childInfo.ForeignKeys[0].RefSchema.L)
+	require.Equal(t, "nextval(`dst`.`seq`)", childInfo.Columns[2].DefaultValue)
+	// the backed up table info is untouched.
+	require.Equal(t, "src", infos[1].ForeignKeys[0].RefSchema.L)
+
+	part, err := is.TableByName(model.NewCIStr("dst"), model.NewCIStr("part"))
+	require.NoError(t, err)
+	partInfo := part.Meta()
+	require.Equal(t, partInfo.ID, rewrites[infos[2].ID].NewTableID)
+	for i, def := range infos[2].Partition.Definitions {
+		newDef := partInfo.Partition.Definitions[i]
+		require.NotEqual(t, def.ID, newDef.ID)
+		require.Equal(t, newDef.ID, rewrites[infos[2].ID].Partitions[def.ID])
+	}
+
+	tk.MustExec("insert into dst.parent values (1, 1)")
+	tk.MustExec("insert into dst.child (id, pid) values (1, 1)")
+	tk.MustQuery("select id, pid, s from dst.child").Check(testkit.Rows("1 1 1"))
+}
@@ -113,6 +113,9 @@ type executorBuilder struct {
 
 	// Used when building MPPGather.
 	encounterUnionScan bool
+
+	// runtimeFilters are created by the TiKV table readers, and are waiting to be attached to the hash joins generating them.
+	runtimeFilters map[int]*joinRuntimeFilter
 }
 
 // CTEStorages stores resTbl and iterInTbl for CTEExec.
@@ -122,6 +125,8 @@ type CTEStorages struct {
 	ResTbl    cteutil.Storage
 	IterInTbl cteutil.Storage
 	Producer  *cteProducer
+	// key is used to share the storages with the CTEs of the same definition, see cteCacheKey.
+	key string
 }
 
 func newExecutorBuilder(ctx sessionctx.Context, is infoschema.InfoSchema) *executorBuilder {
@@ -160,6 +165,14 @@ func (b *executorBuilder) build(p plannercore.Plan) exec.Executor {
 		return b.buildChange(v)
 	case *plannercore.CheckTable:
 		return b.buildCheckTable(v)
+	case *plannercore.CheckTableOnline:
+		return b.buildCheckTableOnline(v)
+	case *plannercore.CancelCheckTableJobs:
+		return b.buildCancelCheckTableJobs(v)
+	case *plannercore.DiffSchema:
+		return b.buildDiffSchema(v)
+	case *plannercore.CompareOptimizerTrace:
+		return b.buildCompareOptimizerTrace(v)
 	case *plannercore.RecoverIndex:
 		return b.buildRecoverIndex(v)
 	case *plannercore.CleanupIndex:
@@ -172,10 +185,16 @@ func (b *executorBuilder) build(p plannercore.Plan) exec.Executor {
 		return b.buildReloadExprPushdownBlacklist(v)
 	case *plannercore.ReloadOptRuleBlacklist:
 		return b.buildReloadOptRuleBlacklist(v)
+	case *plannercore.ReloadMetricTables:
+		return b.buildReloadMetricTables(v)
 	case *plannercore.AdminPlugins:
 		return b.buildAdminPlugins(v)
 	case *plannercore.DDL:
 		return b.buildDDL(v)
+	case *plannercore.CreateTableAsSelect:
+		return b.buildCreateTableAsSelect(v)
+	case *plannercore.RefreshMaterializedView:
+		return b.buildRefreshMaterializedView(v)
 	case *plannercore.Deallocate:
 		return b.buildDeallocate(v)
 	case *plannercore.Delete:
@@ -190,6 +209,8 @@ func (b *executorBuilder) build(p plannercore.Plan) exec.Executor {
 		return b.buildPointGet(v)
 	case *plannercore.BatchPointGetPlan:
 		return b.buildBatchPointGet(v)
+	case *plannercore.PhysicalVectorIndexReader:
+		return b.buildVectorIndexReader(v)
 	case *plannercore.Insert:
 		return b.buildInsert(v)
 	case *plannercore.ImportInto:
@@ -329,6 +350,17 @@ func (b *executorBuilder) buildCancelDDLJobs(v *plannercore.CancelDDLJobs) exec.
 	return e
 }
 
+func (b *executorBuilder) buildCancelCheckTableJobs(v *plannercore.CancelCheckTableJobs) exec.Executor {
+	e := &CancelCheckTableJobsExec{
+		CommandDDLJobsExec: &CommandDDLJobsExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+			jobIDs:       v.JobIDs,
+			execute:      cancelCheckTableJobs,
+		},
+	}
+	return e
+}
+
 func (b *executorBuilder) buildPauseDDLJobs(v *plannercore.PauseDDLJobs) exec.Executor {
 	e := &PauseDDLJobsExec{
 		CommandDDLJobsExec: &CommandDDLJobsExec{
@@ -402,7 +434,7 @@ func (b *executorBuilder) buildShowDDL(v *plannercore.ShowDDL) exec.Executor {
 
 func (b *executorBuilder) buildShowDDLJobs(v *plannercore.PhysicalShowDDLJobs) exec.Executor {
 	loc := b.ctx.GetSessionVars().Location()
-	ddlJobRetriever := DDLJobRetriever{TZLoc: loc}
+	ddlJobRetriever := DDLJobRetriever{TZLoc: loc, extractor: v.Extractor}
 	e := &ShowDDLJobsExec{
 		jobNumber:       int(v.JobNumber),
 		is:              b.is,
@@ -670,9 +702,37 @@ func (b *executorBuilder) buildCleanupIndex(v *plannercore.CleanupIndex) exec.Ex
 	}
 	sessCtx := e.Ctx().GetSessionVars().StmtCtx
 	e.handleCols = buildHandleColsForExec(sessCtx, tblInfo, e.columns)
+	if index.Meta().Global {
+		// Read the partition ID stored in the global index value after the handle.
+		e.columns = append(e.columns, model.NewExtraPartitionIDColInfo())
+	}
 	return e
 }
 
+func (b *executorBuilder) buildCheckTableOnline(v *plannercore.CheckTableOnline) exec.Executor {
+	return &CheckTableOnlineExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		tables:       v.Tables,
+	}
+}
+
+func (b *executorBuilder) buildDiffSchema(v *plannercore.DiffSchema) exec.Executor {
+	return &DiffSchemaExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		fromTS:       v.FromTS,
+		toTS:         v.ToTS,
+		dbName:       v.DBName,
+		tblName:      v.TblName,
+	}
+}
+
+func (b *executorBuilder) buildCompareOptimizerTrace(v *plannercore.CompareOptimizerTrace) exec.Executor {
+	return &CompareOptimizerTraceExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		planDigests:  v.PlanDigests,
+	}
+}
+
 func (b *executorBuilder) buildCheckIndexRange(v *plannercore.CheckIndexRange) exec.Executor {
 	tb, err := b.is.TableByName(v.Table.Schema, v.Table.Name)
 	if err != nil {
@@ -723,6 +783,11 @@ func (b *executorBuilder) buildReloadOptRuleBlacklist(_ *plannercore.ReloadOptRu
 	return &ReloadOptRuleBlacklistExec{BaseExecutor: base}
 }
 
+func (b *executorBuilder) buildReloadMetricTables(_ *plannercore.ReloadMetricTables) exec.Executor {
+	base := exec.NewBaseExecutor(b.ctx, nil, 0)
+	return &ReloadMetricTablesExec{BaseExecutor: base}
+}
+
 func (b *executorBuilder) buildAdminPlugins(v *plannercore.AdminPlugins) exec.Executor {
 	base := exec.NewBaseExecutor(b.ctx, nil, 0)
 	return &AdminPluginsExec{BaseExecutor: base, Action: v.Action, Plugins: v.Plugins}
@@ -864,8 +929,10 @@ func (b *executorBuilder) buildShow(v *plannercore.PhysicalShow) exec.Executor {
 		IfNotExists:           v.IfNotExists,
 		GlobalScope:           v.GlobalScope,
 		Extended:              v.Extended,
+		RegionsSummary:        v.RegionsSummary,
 		Extractor:             v.Extractor,
 		ImportJobID:           v.ImportJobID,
+		DistJobID:             v.DistJobID,
 	}
 	if e.Tp == ast.ShowMasterStatus || e.Tp == ast.ShowBinlogStatus {
 		// show master status need start ts.
@@ -901,6 +968,19 @@ func (b *executorBuilder) buildSimple(v *plannercore.Simple) exec.Executor {
 			tp:           s.Tp,
 			jobID:        s.JobID,
 		}
+	case *ast.DistJobActionStmt:
+		return &DistJobActionExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, nil, 0),
+			tp:           s.Tp,
+			jobID:        s.JobID,
+		}
+	case *ast.RecommendIndexStmt:
+		return &RecommendIndexExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), 0),
+			action:       s.Action,
+			sql:          s.SQL,
+			options:      s.Options,
+		}
 	}
 	base := exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID())
 	base.SetInitCap(chunk.ZeroCapacity)
@@ -955,6 +1035,7 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) exec.Executor {
 		Columns:                   v.Columns,
 		Lists:                     v.Lists,
 		GenExprs:                  v.GenCols.Exprs,
+		beforeTriggers:            v.BeforeTriggers,
 		allAssignmentsAreConstant: v.AllAssignmentsAreConstant,
 		hasRefCols:                v.NeedFillDefaultValue,
 		SelectExec:                selectExec,
@@ -973,6 +1054,7 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	ivs.afterTriggers = b.buildAfterTriggerExecs(ivs.Table, model.TriggerEventInsert)
 
 	if v.IsReplace {
 		return b.buildReplace(ivs)
@@ -1019,6 +1101,35 @@ func (b *executorBuilder) buildImportInto(v *plannercore.ImportInto) exec.Execut
 	return executor
 }
 
+func (b *executorBuilder) buildCreateTableAsSelect(v *plannercore.CreateTableAsSelect) exec.Executor {
+	selectExec := b.build(v.SelectPlan)
+	if b.err != nil {
+		return nil
+	}
+	return &CreateTableAsSelectExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), selectExec),
+		plan:         v,
+		selectExec:   selectExec,
+	}
+}
+
+func (b *executorBuilder) buildRefreshMaterializedView(v *plannercore.RefreshMaterializedView) exec.Executor {
+	deleteExec := b.build(v.Delete)
+	if b.err != nil {
+		return nil
+	}
+	insertExec := b.build(v.Insert)
+	if b.err != nil {
+		return nil
+	}
+	return &RefreshMaterializedViewExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), deleteExec, insertExec),
+		plan:         v,
+		deleteExec:   deleteExec,
+		insertExec:   insertExec,
+	}
+}
+
 func (b *executorBuilder) buildLoadData(v *plannercore.LoadData) exec.Executor {
 	tbl, ok := b.is.TableByID(v.Table.TableInfo.ID)
 	if !ok {
@@ -1615,6 +1726,7 @@ func (b *executorBuilder) buildHashJoin(v *plannercore.PhysicalHashJoin) exec.Ex
 	} else {
 		e.buildTypes, e.probeTypes = rightTypes, leftTypes
 	}
+	b.setRuntimeFiltersForHashJoin(e, v, buildSideExec)
 	return e
 }
 
@@ -1838,6 +1950,24 @@ func (b *executorBuilder) getSnapshot() (kv.Snapshot, error) {
 }
 
 func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Executor {
+	if v.Table.IsExternal() {
+		return &MemTableReaderExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+			table:        v.Table,
+			retriever:    &externalTableRetriever{table: v.Table},
+		}
+	}
+	if v.Table.IsFederated() {
+		return &MemTableReaderExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+			table:        v.Table,
+			retriever: &federatedTableRetriever{
+				table:     v.Table,
+				columns:   v.Columns,
+				extractor: v.Extractor.(*plannercore.FederatedTableExtractor),
+			},
+		}
+	}
 	switch v.DBName.L {
 	case util.MetricSchemaName.L:
 		return &MemTableReaderExec{
@@ -1960,6 +2090,9 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableStatistics),
 			strings.ToLower(infoschema.TableTiDBIndexes),
 			strings.ToLower(infoschema.TableViews),
+			strings.ToLower(infoschema.TableTriggers),
+			strings.ToLower(infoschema.TableRoutines),
+			strings.ToLower(infoschema.TableEvents),
 			strings.ToLower(infoschema.TableTables),
 			strings.ToLower(infoschema.TableReferConst),
 			strings.ToLower(infoschema.TableSequences),
@@ -1972,6 +2105,7 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableCharacterSets),
 			strings.ToLower(infoschema.TableKeyColumn),
 			strings.ToLower(infoschema.TableUserPrivileges),
+			strings.ToLower(infoschema.TableColumnPrivileges),
 			strings.ToLower(infoschema.TableMetricTables),
 			strings.ToLower(infoschema.TableCollationCharacterSetApplicability),
 			strings.ToLower(infoschema.TableProcesslist),
@@ -2002,7 +2136,22 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableTiDBCheckConstraints),
 			strings.ToLower(infoschema.TableKeywords),
 			strings.ToLower(infoschema.TableTiDBIndexUsage),
-			strings.ToLower(infoschema.ClusterTableTiDBIndexUsage):
+			strings.ToLower(infoschema.ClusterTableTiDBIndexUsage),
+			strings.ToLower(infoschema.TableAuditRules),
+			strings.ToLower(infoschema.TableTiDBGeneralLog),
+			strings.ToLower(infoschema.TableTiDBMaterializedViews),
+			strings.ToLower(infoschema.TableClusterEncryptionStatus),
+			strings.ToLower(infoschema.TableTiDBUserResourceUsage),
+			strings.ToLower(infoschema.TableTiDBServersHistory),
+			strings.ToLower(infoschema.TableLogBackupTasks),
+			strings.ToLower(infoschema.TableLogBackupCheckpoints),
+			strings.ToLower(infoschema.TableLogBackupErrors),
+			strings.ToLower(infoschema.TableChecksumTableProgress),
+			strings.ToLower(infoschema.TableTiDBGCProtections),
+			strings.ToLower(infoschema.TableDataLockWaitsSummary),
+			strings.ToLower(infoschema.TableTableLatencyStats),
+			strings.ToLower(infoschema.TableTiDBRegionCacheStats),
+			strings.ToLower(infoschema.TableOptimizerFixControls):
 			memTracker := memory.NewTracker(v.ID(), -1)
 			memTracker.AttachTo(b.ctx.GetSessionVars().StmtCtx.MemTracker)
 			return &MemTableReaderExec{
@@ -2094,13 +2243,16 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 					extractor:  v.Extractor.(*plannercore.TableStorageStatsExtractor),
 				},
 			}
-		case strings.ToLower(infoschema.TableDDLJobs):
+		case strings.ToLower(infoschema.TableDDLJobs),
+			strings.ToLower(infoschema.TableTiDBDDLHistory):
 			loc := b.ctx.GetSessionVars().Location()
-			ddlJobRetriever := DDLJobRetriever{TZLoc: loc}
+			extractor, _ := v.Extractor.(*plannercore.DDLJobsExtractor)
+			ddlJobRetriever := DDLJobRetriever{TZLoc: loc, extractor: extractor}
 			return &DDLJobsReaderExec{
 				BaseExecutor:    exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
 				is:              b.is,
 				DDLJobRetriever: ddlJobRetriever,
+				historyOnly:     v.Table.Name.L == strings.ToLower(infoschema.TableTiDBDDLHistory),
 			}
 		case strings.ToLower(infoschema.TableTiFlashTables),
 			strings.ToLower(infoschema.TableTiFlashSegments):
@@ -2310,16 +2462,20 @@ func (b *executorBuilder) buildSplitRegion(v *plannercore.SplitRegion) exec.Exec
 			upper:          v.Upper,
 			num:            v.Num,
 			valueLists:     v.ValueLists,
+			encodedValues:  v.EncodedValues,
+			scatter:        v.Scatter,
 		}
 	}
 	handleCols := buildHandleColsForSplit(b.ctx.GetSessionVars().StmtCtx, v.TableInfo)
-	if len(v.ValueLists) > 0 {
+	if len(v.ValueLists) > 0 || len(v.EncodedValues) > 0 {
 		return &SplitTableRegionExec{
 			BaseExecutor:   base,
 			tableInfo:      v.TableInfo,
 			partitionNames: v.PartitionNames,
 			handleCols:     handleCols,
 			valueLists:     v.ValueLists,
+			encodedValues:  v.EncodedValues,
+			scatter:        v.Scatter,
 		}
 	}
 	return &SplitTableRegionExec{
@@ -2330,6 +2486,7 @@ func (b *executorBuilder) buildSplitRegion(v *plannercore.SplitRegion) exec.Exec
 		lower:          v.Lower,
 		upper:          v.Upper,
 		num:            v.Num,
+		scatter:        v.Scatter,
 	}
 }
 
@@ -3365,6 +3522,9 @@ func (b *executorBuilder) buildTableReader(v *plannercore.PhysicalTableReader) e
 	if ret.table.Meta().TempTableType != model.TempTableNone {
 		ret.dummy = true
 	}
+	if v.StoreType == kv.TiKV && len(ts.RuntimeFilterList()) > 0 {
+		b.setRuntimeFiltersForTableReader(ret, ts)
+	}
 
 	ret.ranges = ts.Ranges
 	sctx := b.ctx.GetSessionVars().StmtCtx
@@ -4916,6 +5076,23 @@ func NewRowDecoder(ctx sessionctx.Context, schema *expression.Schema, tbl *model
 	return rowcodec.NewChunkDecoder(reqCols, pkCols, defVal, ctx.GetSessionVars().Location())
 }
 
+func (b *executorBuilder) buildVectorIndexReader(plan *plannercore.PhysicalVectorIndexReader) exec.Executor {
+	snapshot, err := b.getSnapshot()
+	if err != nil {
+		b.err = err
+		return nil
+	}
+	return &VectorIndexReaderExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, plan.Schema(), plan.ID()),
+		tblInfo:      plan.Table,
+		idxInfo:      plan.Index,
+		query:        plan.Query,
+		k:            int(plan.K),
+		snapshot:     snapshot,
+		rowDecoder:   NewRowDecoder(b.ctx, plan.Schema(), plan.Table),
+	}
+}
+
 func (b *executorBuilder) buildBatchPointGet(plan *plannercore.BatchPointGetPlan) exec.Executor {
 	var err error
 	if err = b.validCanReadTemporaryOrCacheTable(plan.TblInfo); err != nil {
@@ -5203,18 +5380,34 @@ func (b *executorBuilder) buildCTE(v *plannercore.PhysicalCTE) exec.Executor {
 	var iterInTbl cteutil.Storage
 	var producer *cteProducer
 	storages, ok := storageMap[v.CTE.IDForStorage]
+	var corCols []*expression.CorrelatedColumn
+	var cacheKey string
+	if !ok {
+		if v.SeedPlan == nil {
+			b.err = errors.New("cte.seedPlan cannot be nil")
+			return nil
+		}
+		corCols = plannercore.ExtractOuterApplyCorrelatedCols(v.SeedPlan)
+		if v.RecurPlan != nil {
+			corCols = append(corCols, plannercore.ExtractOuterApplyCorrelatedCols(v.RecurPlan)...)
+		}
+		if cacheKey, b.err = b.cteCacheKey(v, corCols); b.err != nil {
+			return nil
+		}
+		if cacheKey != "" {
+			if storages, b.err = b.shareCTEStorages(storageMap, v, cacheKey); b.err != nil {
+				return nil
+			}
+			ok = storages != nil
+		}
+	}
 	if ok {
 		// Storage already setup.
 		resTbl = storages.ResTbl
 		iterInTbl = storages.IterInTbl
 		producer = storages.Producer
 	} else {
-		if v.SeedPlan == nil {
-			b.err = errors.New("cte.seedPlan cannot be nil")
-			return nil
-		}
 		// Build seed part.
-		corCols := plannercore.ExtractOuterApplyCorrelatedCols(v.SeedPlan)
 		seedExec := b.build(v.SeedPlan)
 		if b.err != nil {
 			return nil
@@ -5232,7 +5425,13 @@ func (b *executorBuilder) buildCTE(v *plannercore.PhysicalCTE) exec.Executor {
 			b.err = err
 			return nil
 		}
-		storageMap[v.CTE.IDForStorage] = &CTEStorages{ResTbl: resTbl, IterInTbl: iterInTbl}
+		storageMap[v.CTE.IDForStorage] = &CTEStorages{ResTbl: resTbl, IterInTbl: iterInTbl, key: cacheKey}
+		var txnCache *txnCTECache
+		if cacheKey != "" {
+			if txnCache, b.err = b.cacheCTEResult(cacheKey, resTbl); b.err != nil {
+				return nil
+			}
+		}
 
 		// Build recursive part.
 		var recursiveExec exec.Executor
@@ -5241,7 +5440,6 @@ func (b *executorBuilder) buildCTE(v *plannercore.PhysicalCTE) exec.Executor {
 			if b.err != nil {
 				return nil
 			}
-			corCols = append(corCols, plannercore.ExtractOuterApplyCorrelatedCols(v.RecurPlan)...)
 		}
 
 		var sel []int
@@ -5270,6 +5468,8 @@ func (b *executorBuilder) buildCTE(v *plannercore.PhysicalCTE) exec.Executor {
 			limitEnd:        v.CTE.LimitEnd,
 			corCols:         corCols,
 			corColHashCodes: corColHashCodes,
+			txnCache:        txnCache,
+			cacheKey:        cacheKey,
 		}
 		storageMap[v.CTE.IDForStorage].Producer = producer
 	}
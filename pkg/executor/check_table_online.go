@@ -0,0 +1,516 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+const (
+	checkTableJobStatusRunning   = "running"
+	checkTableJobStatusFinished  = "finished"
+	checkTableJobStatusFailed    = "failed"
+	checkTableJobStatusCancelled = "cancelled"
+)
+
+// runningCheckTableJobs records the IDs of the online check table jobs running on this instance.
+var runningCheckTableJobs sync.Map
+
+// CheckTableOnlineExec represents an executor submitting online check table jobs.
+// It is built from the "admin check table ... online" statement. Each table is
+// checked by a background job which verifies the indexes against the records
+// range by range, and records its checkpoint in mysql.tidb_check_table_jobs.
+// A cancelled or interrupted job is resumed from its checkpoint when the table
+// is checked online again.
+type CheckTableOnlineExec struct {
+	exec.BaseExecutor
+
+	tables []*ast.TableName
+	done   bool
+}
+
+// Next implements the Executor Next interface.
+func (e *CheckTableOnlineExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnAdmin)
+	se, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, se)
+
+	is := domain.GetDomain(e.Ctx()).InfoSchema()
+	createdBy := ""
+	if user := e.Ctx().GetSessionVars().User; user != nil {
+		createdBy = user.String()
+	}
+	for _, tn := range e.tables {
+		tbl, err := is.TableByName(tn.Schema, tn.Name)
+		if err != nil {
+			return err
+		}
+		jobID, err := submitCheckTableJob(ctx, se, tn.Schema.O, tbl, createdBy)
+		if err != nil {
+			return err
+		}
+		req.AppendString(0, tn.Schema.O)
+		req.AppendString(1, tbl.Meta().Name.O)
+		req.AppendInt64(2, jobID)
+	}
+	return nil
+}
+
+func checkTableJobInstance() string {
+	cfg := config.GetGlobalConfig()
+	return net.JoinHostPort(cfg.AdvertiseAddress, strconv.Itoa(int(cfg.Port)))
+}
+
+func checkTableHandleColumn(tblInfo *model.TableInfo) string {
+	if tblInfo.PKIsHandle {
+		return tblInfo.GetPkName().O
+	}
+	return model.ExtraHandleName.O
+}
+
+func execCheckTableSQL(ctx context.Context, se sessionctx.Context, sql string, args ...any) ([]chunk.Row, error) {
+	rs, err := se.(sqlexec.SQLExecutor).ExecuteInternal(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	defer func() {
+		if err := rs.Close(); err != nil {
+			logutil.BgLogger().Warn("close result set failed", zap.Error(err))
+		}
+	}()
+	return sqlexec.DrainRecordSet(ctx, rs, 8)
+}
+
+// submitCheckTableJob resumes the unfinished job of the table, or creates a new one
+// if there is none, and returns the job ID. The unfinished job of a table is locked by
+// its unique active_table_id in a pessimistic transaction, so the concurrent submissions
+// of the same table are serialized and at most one unfinished job exists for a table.
+func submitCheckTableJob(ctx context.Context, se sessionctx.Context, dbName string, tbl table.Table, createdBy string) (jobID int64, err error) {
+	tblInfo := tbl.Meta()
+	instance := checkTableJobInstance()
+	if _, err = execCheckTableSQL(ctx, se, "BEGIN PESSIMISTIC"); err != nil {
+		return 0, err
+	}
+	start := false
+	defer func() {
+		if err == nil {
+			_, err = execCheckTableSQL(ctx, se, "COMMIT")
+		}
+		if err != nil {
+			if _, rollbackErr := execCheckTableSQL(ctx, se, "ROLLBACK"); rollbackErr != nil {
+				logutil.BgLogger().Warn("rollback check table job submission failed", zap.Error(rollbackErr))
+			}
+			return
+		}
+		if start {
+			startCheckTableJob(domain.GetDomain(se), jobID, instance)
+		}
+	}()
+
+	rows, err := execCheckTableSQL(ctx, se, `SELECT id, status, instance FROM mysql.tidb_check_table_jobs
+		WHERE active_table_id = %? FOR UPDATE`, tblInfo.ID)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) > 0 {
+		jobID, status, owner := rows[0].GetInt64(0), rows[0].GetString(1), rows[0].GetString(2)
+		if _, ok := runningCheckTableJobs.Load(jobID); ok {
+			if status == checkTableJobStatusRunning {
+				return jobID, nil
+			}
+			return 0, errors.Errorf("check table job %d is being cancelled, please retry later", jobID)
+		}
+		if status == checkTableJobStatusRunning && owner != instance {
+			// The job is running on another instance. If that instance is gone, the
+			// job is resumed by the DDL owner, see ResumeCheckTableJobs.
+			return jobID, nil
+		}
+		_, err = execCheckTableSQL(ctx, se, `UPDATE mysql.tidb_check_table_jobs
+			SET status = %?, instance = %?, update_time = CURRENT_TIMESTAMP(6), end_time = NULL, error_message = NULL
+			WHERE id = %?`, checkTableJobStatusRunning, instance, jobID)
+		if err != nil {
+			return 0, err
+		}
+		start = true
+		return jobID, nil
+	}
+
+	handleCol := checkTableHandleColumn(tblInfo)
+	rows, err = execCheckTableSQL(ctx, se, "SELECT MIN(%n), MAX(%n) FROM %n.%n", handleCol, handleCol, dbName, tblInfo.Name.O)
+	if err != nil {
+		return 0, err
+	}
+	// An empty table gets an empty range, and the job finishes at once.
+	startHandle, endHandle := int64(0), int64(-1)
+	if len(rows) > 0 && !rows[0].IsNull(0) {
+		startHandle, endHandle = rows[0].GetInt64(0), rows[0].GetInt64(1)
+	}
+	_, err = execCheckTableSQL(ctx, se, `INSERT INTO mysql.tidb_check_table_jobs
+		(update_time, table_schema, table_name, table_id, active_table_id, created_by, instance, status, start_handle, end_handle, checkpoint)
+		VALUES (CURRENT_TIMESTAMP(6), %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
+		dbName, tblInfo.Name.O, tblInfo.ID, tblInfo.ID, createdBy, instance, checkTableJobStatusRunning, startHandle, endHandle, startHandle)
+	if err != nil {
+		return 0, err
+	}
+	rows, err = execCheckTableSQL(ctx, se, "SELECT LAST_INSERT_ID()")
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) != 1 {
+		return 0, errors.Errorf("unexpected result length: %d", len(rows))
+	}
+	jobID = rows[0].GetInt64(0)
+	start = true
+	return jobID, nil
+}
+
+// ResumeCheckTableJobs resumes the running jobs whose instances are gone or restarted
+// from their checkpoints on this instance. It's called by the DDL owner periodically.
+func ResumeCheckTableJobs(ctx context.Context, dom *domain.Domain) error {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnAdmin)
+	servers, err := infosync.GetAllServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+	// A job is still running on its instance only if it has made progress since the instance started.
+	startTimes := make(map[string]int64, len(servers))
+	for _, info := range servers {
+		startTimes[net.JoinHostPort(info.IP, strconv.Itoa(int(info.Port)))] = info.StartTimestamp
+	}
+
+	res, err := dom.SysSessionPool().Get()
+	if err != nil {
+		return err
+	}
+	defer dom.SysSessionPool().Put(res)
+	se := res.(sessionctx.Context)
+	rows, err := execCheckTableSQL(ctx, se, `SELECT id, instance, CAST(update_time AS CHAR), UNIX_TIMESTAMP(update_time)
+		FROM mysql.tidb_check_table_jobs WHERE status = %?`, checkTableJobStatusRunning)
+	if err != nil {
+		return err
+	}
+	instance := checkTableJobInstance()
+	for _, row := range rows {
+		jobID, owner := row.GetInt64(0), row.GetString(1)
+		if _, ok := runningCheckTableJobs.Load(jobID); ok {
+			continue
+		}
+		if startTime, ok := startTimes[owner]; ok && !row.IsNull(3) {
+			updateTime, err := row.GetMyDecimal(3).ToFloat64()
+			if err == nil && float64(startTime) <= updateTime {
+				continue
+			}
+		}
+		var lastUpdate any
+		if !row.IsNull(2) {
+			lastUpdate = row.GetString(2)
+		}
+		// The job may be taken over concurrently, only the one who updates it resumes it.
+		_, err = execCheckTableSQL(ctx, se, `UPDATE mysql.tidb_check_table_jobs
+			SET instance = %?, update_time = CURRENT_TIMESTAMP(6)
+			WHERE id = %? AND status = %? AND instance = %? AND update_time <=> %?`,
+			instance, jobID, checkTableJobStatusRunning, owner, lastUpdate)
+		if err != nil {
+			return err
+		}
+		if se.GetSessionVars().StmtCtx.AffectedRows() == 0 {
+			continue
+		}
+		logutil.BgLogger().Info("resume check table job", zap.Int64("jobID", jobID), zap.String("from", owner))
+		startCheckTableJob(dom, jobID, instance)
+	}
+	return nil
+}
+
+func startCheckTableJob(dom *domain.Domain, jobID int64, instance string) {
+	runningCheckTableJobs.Store(jobID, struct{}{})
+	dom.RunBackgroundJob(fmt.Sprintf("checkTableJob-%d", jobID), func(ctx context.Context) {
+		defer runningCheckTableJobs.Delete(jobID)
+		ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnAdmin)
+		res, err := dom.SysSessionPool().Get()
+		if err != nil {
+			logutil.BgLogger().Warn("get session for check table job failed", zap.Int64("jobID", jobID), zap.Error(err))
+			return
+		}
+		se := res.(sessionctx.Context)
+		se.GetSessionVars().InRestrictedSQL = true
+		defer func() {
+			if _, err := se.(sqlexec.SQLExecutor).ExecuteInternal(ctx, "rollback"); err != nil {
+				res.Close()
+				return
+			}
+			dom.SysSessionPool().Put(res)
+		}()
+		job := &checkTableJob{id: jobID, instance: instance, se: se}
+		job.run(ctx, dom.InfoSchema())
+	})
+}
+
+type checkTableJob struct {
+	id       int64
+	instance string
+	se       sessionctx.Context
+
+	dbName    string
+	tblInfo   *model.TableInfo
+	handleCol string
+	indices   []*model.IndexInfo
+}
+
+func (j *checkTableJob) run(ctx context.Context, is infoschema.InfoSchema) {
+	sessVars := j.se.GetSessionVars()
+	originOptUseInvisibleIdx := sessVars.OptimizerUseInvisibleIndexes
+	sessVars.OptimizerUseInvisibleIndexes = true
+	defer func() {
+		sessVars.OptimizerUseInvisibleIndexes = originOptUseInvisibleIdx
+	}()
+
+	stopped, err := j.check(ctx, is)
+	if stopped {
+		return
+	}
+	if ctx.Err() != nil {
+		// The instance is shutting down, leave the job to be resumed later.
+		logutil.BgLogger().Info("check table job is interrupted", zap.Int64("jobID", j.id))
+		return
+	}
+	status, errMsg := checkTableJobStatusFinished, ""
+	if err != nil {
+		status, errMsg = checkTableJobStatusFailed, err.Error()
+		logutil.BgLogger().Warn("check table job failed", zap.Int64("jobID", j.id), zap.Error(err))
+	}
+	_, err = execCheckTableSQL(ctx, j.se, `UPDATE mysql.tidb_check_table_jobs
+		SET status = %?, error_message = %?, active_table_id = NULL, update_time = CURRENT_TIMESTAMP(6), end_time = CURRENT_TIMESTAMP(6)
+		WHERE id = %? AND status = %? AND instance = %?`, status, errMsg, j.id, checkTableJobStatusRunning, j.instance)
+	if err != nil {
+		logutil.BgLogger().Warn("update check table job status failed", zap.Int64("jobID", j.id), zap.Error(err))
+	}
+}
+
+// check verifies the table range by range. It returns stopped as true if the
+// job is cancelled or taken over by another instance.
+func (j *checkTableJob) check(ctx context.Context, is infoschema.InfoSchema) (stopped bool, err error) {
+	rows, err := execCheckTableSQL(ctx, j.se, "SELECT table_id, checkpoint, end_handle FROM mysql.tidb_check_table_jobs WHERE id = %?", j.id)
+	if err != nil {
+		return false, err
+	}
+	if len(rows) == 0 {
+		return true, nil
+	}
+	tableID, checkpoint, endHandle := rows[0].GetInt64(0), rows[0].GetInt64(1), rows[0].GetInt64(2)
+	tbl, ok := is.TableByID(tableID)
+	if !ok {
+		return false, errors.Errorf("table %d has been dropped", tableID)
+	}
+	db, ok := infoschema.SchemaByTable(is, tbl.Meta())
+	if !ok {
+		return false, errors.Errorf("database of table %d has been dropped", tableID)
+	}
+	j.dbName, j.tblInfo = db.Name.O, tbl.Meta()
+	j.handleCol = checkTableHandleColumn(j.tblInfo)
+	for _, idx := range tbl.Indices() {
+		idxInfo := idx.Meta()
+		// The multi-valued index has several entries for one row, which can't be
+		// compared with the records by checksum.
+		if idxInfo.State != model.StatePublic || idxInfo.MVIndex || idxInfo.IsFullText() || idxInfo.IsVector() {
+			continue
+		}
+		j.indices = append(j.indices, idxInfo)
+	}
+
+	for checkpoint <= endHandle {
+		if ctx.Err() != nil {
+			return false, nil
+		}
+		start := time.Now()
+		upper, err := j.nextBatchUpper(ctx, checkpoint, endHandle)
+		if err != nil {
+			return false, err
+		}
+		checkedRows, err := j.checkRange(ctx, checkpoint, upper)
+		if err != nil {
+			return false, err
+		}
+		next := upper
+		if upper < math.MaxInt64 {
+			next = upper + 1
+		}
+		_, err = execCheckTableSQL(ctx, j.se, `UPDATE mysql.tidb_check_table_jobs
+			SET checkpoint = %?, checked_rows = checked_rows + %?, update_time = CURRENT_TIMESTAMP(6)
+			WHERE id = %? AND status = %? AND instance = %?`, next, checkedRows, j.id, checkTableJobStatusRunning, j.instance)
+		if err != nil {
+			return false, err
+		}
+		if j.se.GetSessionVars().StmtCtx.AffectedRows() == 0 {
+			logutil.BgLogger().Info("check table job is stopped", zap.Int64("jobID", j.id))
+			return true, nil
+		}
+		if upper >= endHandle {
+			break
+		}
+		checkpoint = next
+		j.throttle(ctx, checkedRows, time.Since(start))
+	}
+	return false, nil
+}
+
+// nextBatchUpper returns the inclusive upper bound of the range which starts
+// from lower and contains at most tidb_check_table_online_batch_size rows.
+func (j *checkTableJob) nextBatchUpper(ctx context.Context, lower, endHandle int64) (int64, error) {
+	batchSize := variable.CheckTableOnlineBatchSize.Load()
+	rows, err := execCheckTableSQL(ctx, j.se, "SELECT %n FROM %n.%n USE INDEX() WHERE %n >= %? ORDER BY %n LIMIT %?, 1",
+		j.handleCol, j.dbName, j.tblInfo.Name.O, j.handleCol, lower, j.handleCol, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 || rows[0].GetInt64(0) > endHandle {
+		return endHandle, nil
+	}
+	return rows[0].GetInt64(0) - 1, nil
+}
+
+// checkRange compares the checksum of the records in the handle range [lower, upper]
+// with the checksum of each index, and returns the number of records checked.
+func (j *checkTableJob) checkRange(ctx context.Context, lower, upper int64) (int64, error) {
+	tblName := TableName(j.dbName, j.tblInfo.Name.O)
+	where := fmt.Sprintf("%s BETWEEN %d AND %d", ColumnName(j.handleCol), lower, upper)
+	// Read the records and the indexes in the same snapshot.
+	if _, err := execCheckTableSQL(ctx, j.se, "begin"); err != nil {
+		return 0, err
+	}
+	defer func() {
+		if _, err := execCheckTableSQL(ctx, j.se, "rollback"); err != nil {
+			logutil.BgLogger().Warn("rollback check table transaction failed", zap.Int64("jobID", j.id), zap.Error(err))
+		}
+	}()
+
+	var checkedRows int64
+	for _, idxInfo := range j.indices {
+		checksum := j.checksumExpr(idxInfo)
+		tblRows, err := execCheckTableSQL(ctx, j.se, fmt.Sprintf("SELECT /*+ read_from_storage(tikv[%s]) */ BIT_XOR(%s), COUNT(*) FROM %s USE INDEX() WHERE %s",
+			tblName, checksum, tblName, where))
+		if err != nil {
+			return 0, err
+		}
+		idxRows, err := execCheckTableSQL(ctx, j.se, fmt.Sprintf("SELECT BIT_XOR(%s), COUNT(*) FROM %s USE INDEX(%s) WHERE %s",
+			checksum, tblName, ColumnName(idxInfo.Name.O), where))
+		if err != nil {
+			return 0, err
+		}
+		tblChecksum, tblCount := tblRows[0].GetUint64(0), tblRows[0].GetInt64(1)
+		idxChecksum, idxCount := idxRows[0].GetUint64(0), idxRows[0].GetInt64(1)
+		if tblChecksum != idxChecksum || tblCount != idxCount {
+			return 0, errors.Errorf("index %s is inconsistent with table %s in handle range [%d, %d], table rows: %d, index rows: %d",
+				idxInfo.Name.O, j.tblInfo.Name.O, lower, upper, tblCount, idxCount)
+		}
+		checkedRows = tblCount
+	}
+	if len(j.indices) == 0 {
+		rows, err := execCheckTableSQL(ctx, j.se, fmt.Sprintf("SELECT COUNT(*) FROM %s USE INDEX() WHERE %s", tblName, where))
+		if err != nil {
+			return 0, err
+		}
+		checkedRows = rows[0].GetInt64(0)
+	}
+	return checkedRows, nil
+}
+
+// checksumExpr returns the checksum expression of the handle and the index columns.
+func (j *checkTableJob) checksumExpr(idxInfo *model.IndexInfo) string {
+	var sb strings.Builder
+	sb.WriteString("CRC32(MD5(CONCAT_WS(0x2, ")
+	sb.WriteString(ColumnName(j.handleCol))
+	for _, col := range idxInfo.Columns {
+		sb.WriteString(", ")
+		tblCol := j.tblInfo.Columns[col.Offset]
+		if tblCol.IsGenerated() && !tblCol.GeneratedStored {
+			sb.WriteString(tblCol.GeneratedExprString)
+		} else {
+			sb.WriteString(ColumnName(col.Name.O))
+		}
+	}
+	sb.WriteString(")))")
+	return sb.String()
+}
+
+// throttle sleeps to keep the speed under tidb_check_table_online_rate_limit.
+func (*checkTableJob) throttle(ctx context.Context, rows int64, elapsed time.Duration) {
+	limit := variable.CheckTableOnlineRateLimit.Load()
+	if limit <= 0 || rows <= 0 {
+		return
+	}
+	wait := time.Duration(float64(rows)/float64(limit)*float64(time.Second)) - elapsed
+	if wait <= 0 {
+		return
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(wait):
+	}
+}
+
+// cancelCheckTableJobs cancels the running online check table jobs.
+func cancelCheckTableJobs(se sessionctx.Context, ids []int64) ([]error, error) {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnAdmin)
+	errs := make([]error, len(ids))
+	for i, id := range ids {
+		_, err := execCheckTableSQL(ctx, se, `UPDATE mysql.tidb_check_table_jobs
+			SET status = %?, update_time = CURRENT_TIMESTAMP(6), end_time = CURRENT_TIMESTAMP(6)
+			WHERE id = %? AND status = %?`, checkTableJobStatusCancelled, id, checkTableJobStatusRunning)
+		if err != nil {
+			return nil, err
+		}
+		if se.GetSessionVars().StmtCtx.AffectedRows() == 0 {
+			errs[i] = errors.Errorf("check table job %d is not running", id)
+		}
+	}
+	return errs, nil
+}
@@ -18,14 +18,22 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/distsql"
+	"github.com/pingcap/tidb/pkg/disttask/checksum"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	fstorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/ranger"
 	"github.com/pingcap/tipb/go-tipb"
@@ -53,6 +61,11 @@ func (e *ChecksumTableExec) Open(ctx context.Context) error {
 		return err
 	}
 
+	// if tidb_enable_dist_task=true, we calculate the checksum distributively.
+	if variable.EnableDistTask.Load() {
+		return e.runDistTasks(ctx, concurrency)
+	}
+
 	tasks, err := e.buildTasks()
 	if err != nil {
 		return err
@@ -111,6 +124,92 @@ func (e *ChecksumTableExec) Next(_ context.Context, req *chunk.Chunk) error {
 	return nil
 }
 
+// runDistTasks calculates the checksum of the tables on the distributed task framework.
+// A task is submitted for each table, and the record and indexes of each partition are
+// split into subtasks by key ranges. The finished subtasks are persisted, so the task
+// interrupted by KILL is paused and resumed by the next ADMIN CHECKSUM TABLE.
+func (e *ChecksumTableExec) runDistTasks(ctx context.Context, concurrency int) error {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	cpuCount, err := handle.GetCPUCountOfManagedNode(ctx)
+	if err != nil {
+		return err
+	}
+	concurrency = max(min(concurrency, cpuCount), 1)
+	sessVars := e.Ctx().GetSessionVars()
+	for _, t := range e.tables {
+		taskMeta := &checksum.TaskMeta{
+			DBName:            t.dbInfo.Name.O,
+			TableInfo:         t.tableInfo,
+			StartTS:           t.startTs,
+			ScanConcurrency:   sessVars.DistSQLScanConcurrency(),
+			ResourceGroupName: sessVars.StmtCtx.ResourceGroupName,
+		}
+		task, err := checksum.SubmitOrResumeTask(ctx, taskMeta, concurrency)
+		if err != nil {
+			return err
+		}
+		result, err := e.waitDistTask(ctx, task)
+		if err != nil {
+			return err
+		}
+		logutil.Logger(ctx).Info(
+			"got checksum result of the distributed task",
+			zap.Int64("task-id", task.ID),
+			zap.Int64("tableID", t.tableInfo.ID),
+			zap.Uint64("checksum", result.Checksum),
+			zap.Uint64("totalKvs", result.TotalKvs),
+			zap.Uint64("totalBytes", result.TotalBytes),
+		)
+		t.response = &tipb.ChecksumResponse{
+			Checksum:   result.Checksum,
+			TotalKvs:   result.TotalKvs,
+			TotalBytes: result.TotalBytes,
+		}
+	}
+	return nil
+}
+
+func (e *ChecksumTableExec) waitDistTask(ctx context.Context, task *proto.TaskBase) (*checksum.Result, error) {
+	var killErr error
+	found, err := handle.WaitTask(ctx, task.ID, func(t *proto.TaskBase) bool {
+		killErr = e.Ctx().GetSessionVars().SQLKiller.HandleSignal()
+		return killErr != nil || t.IsDone() || t.State == proto.TaskStatePaused
+	})
+	if killErr != nil || errors.Cause(err) == context.Canceled {
+		// pause the task instead of cancelling it, so the calculated checksum of
+		// the finished subtasks can be reused when the task is resumed.
+		// use background, since ctx might be canceled already.
+		if err2 := handle.PauseTask(context.Background(), task.Key); err2 != nil {
+			logutil.Logger(ctx).Warn("pause checksum task failed", zap.Int64("task-id", task.ID), zap.Error(err2))
+		}
+		if killErr != nil {
+			return nil, killErr
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch found.State {
+	case proto.TaskStateSucceed:
+		return checksum.GetTaskResult(ctx, task.ID)
+	case proto.TaskStatePaused:
+		return nil, errors.Errorf("checksum task %d is paused, run ADMIN CHECKSUM TABLE again to resume it", task.ID)
+	}
+	taskManager, err := fstorage.GetTaskManager()
+	if err != nil {
+		return nil, err
+	}
+	stopped, err := taskManager.GetTaskByIDWithHistory(ctx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	if stopped.Error != nil {
+		return nil, stopped.Error
+	}
+	return nil, errors.Errorf("checksum task %d stopped with state %s", task.ID, stopped.State)
+}
+
 func (e *ChecksumTableExec) buildTasks() ([]*checksumTask, error) {
 	allTasks := make([][]*checksumTask, 0, len(e.tables))
 	taskCnt := 0
@@ -334,3 +433,40 @@ func updateChecksumResponse(resp, update *tipb.ChecksumResponse) {
 	resp.TotalKvs += update.TotalKvs
 	resp.TotalBytes += update.TotalBytes
 }
+
+func (e *memtableRetriever) setDataForChecksumTableProgress(ctx context.Context, sctx sessionctx.Context) error {
+	if !hasPriv(sctx, mysql.SuperPriv) {
+		return plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+	}
+	progresses, err := checksum.GetTaskProgresses(ctx)
+	if err != nil {
+		return err
+	}
+	loc := sctx.GetSessionVars().Location()
+	rows := make([][]types.Datum, 0, len(progresses))
+	for _, p := range progresses {
+		var progress float64
+		if p.TotalSubtasks > 0 {
+			progress = float64(p.FinishedSubtasks) * 100 / float64(p.TotalSubtasks)
+		}
+		row := types.MakeDatums(
+			p.Task.ID,
+			p.Meta.DBName,
+			p.Meta.TableInfo.Name.O,
+			p.Meta.TableInfo.ID,
+			p.Task.State.String(),
+			p.Meta.StartTS,
+			types.NewTime(types.FromGoTime(p.Task.CreateTime.In(loc)), mysql.TypeDatetime, types.DefaultFsp),
+			p.TotalSubtasks,
+			p.FinishedSubtasks,
+			progress,
+			nil,
+		)
+		if p.Task.Error != nil {
+			row[10].SetString(p.Task.Error.Error(), mysql.DefaultCollationName)
+		}
+		rows = append(rows, row)
+	}
+	e.rows = rows
+	return nil
+}
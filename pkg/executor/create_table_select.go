@@ -0,0 +1,341 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/ddl"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/executor/importer"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+// ctasStagingTablePrefix is the name prefix of the table that CREATE TABLE ... SELECT
+// creates and fills before it's renamed to the target name.
+const ctasStagingTablePrefix = "_tidb_ctas_"
+
+// CreateTableAsSelectExec represents a CREATE TABLE ... SELECT executor.
+// The table is created with a staging name and filled by the physical import of
+// IMPORT INTO ... FROM SELECT, which encodes the selected rows into SST files and
+// ingests them into TiKV, the rows are inserted by transactions if the store isn't TiKV.
+// The staging table is hidden from the table listings and renamed to the target name
+// after all the rows are imported, so the table becomes visible with all its data at
+// once, and it's dropped if the import fails. The progress is recorded as an import
+// job, which can be seen in SHOW IMPORT JOBS.
+// CREATE MATERIALIZED VIEW is executed by it too, the table is created with the
+// definition of the view.
+type CreateTableAsSelectExec struct {
+	exec.BaseExecutor
+
+	plan       *plannercore.CreateTableAsSelect
+	selectExec exec.Executor
+	done       bool
+}
+
+// Next implements the Executor Next interface.
+func (e *CreateTableAsSelectExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnDDL)
+	dom := domain.GetDomain(e.Ctx())
+	stmt := e.plan.Stmt
+	dbName, tblName := stmt.Table.Schema, stmt.Table.Name
+	is := dom.InfoSchema()
+	if is.TableExists(dbName, tblName) {
+		err := infoschema.ErrTableExists.FastGenByArgs(ast.Ident{Schema: dbName, Name: tblName})
+		if stmt.IfNotExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	stagingName := model.NewCIStr(fmt.Sprintf("%s%d", ctasStagingTablePrefix, e.Ctx().GetSessionVars().ConnectionID))
+	stagingTable := &ast.TableName{Schema: dbName, Name: stagingName}
+	if err := dropLeftoverTable(e.Ctx(), is, stagingTable); err != nil {
+		return err
+	}
+	if err := e.createStagingTable(stagingTable); err != nil {
+		return err
+	}
+
+	selectText := ""
+	if e.plan.MaterializedView != nil {
+		selectText = e.plan.MaterializedView.SelectStmt
+	} else {
+		var sb strings.Builder
+		if err := stmt.Select.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+			return err
+		}
+		selectText = sb.String()
+	}
+	err := importSelectIntoTable(ctx, &e.BaseExecutor, e.selectExec, e.plan.SelectPlan, e.plan.Columns, stagingTable, tblName.O, selectText)
+	if err == nil {
+		err = dom.DDL().RenameTable(e.Ctx(), &ast.RenameTableStmt{TableToTables: []*ast.TableToTable{{
+			OldTable: stagingTable,
+			NewTable: &ast.TableName{Schema: dbName, Name: tblName},
+		}}})
+	}
+	if err != nil {
+		if err2 := dropTable(e.Ctx(), stagingTable, e.plan.MaterializedView != nil); err2 != nil {
+			logutil.Logger(ctx).Warn("drop staging table of CREATE TABLE ... SELECT failed",
+				zap.Stringer("table", stagingName), zap.Error(err2))
+		}
+		return err
+	}
+	e.Ctx().GetSessionVars().TxnCtx.InfoSchema = dom.InfoSchema()
+	return nil
+}
+
+// createStagingTable creates the table to import the selected rows into. The table is hidden
+// from the table listings until it's renamed to the target name, and it's dropped by the DDL
+// owner if this TiDB server is gone before that.
+func (e *CreateTableAsSelectExec) createStagingTable(stagingTable *ast.TableName) error {
+	dom := domain.GetDomain(e.Ctx())
+	createStmt := *e.plan.Stmt
+	createStmt.Table = stagingTable
+	createStmt.Select = nil
+	createStmt.IfNotExists = false
+	dbInfo, tbInfo, err := ddl.BuildTableInfoForCreateTable(e.Ctx(), dom.InfoSchema(), &createStmt)
+	if err != nil {
+		return err
+	}
+	tbInfo.Staging = &model.StagingInfo{
+		ServerID:     dom.DDL().GetID(),
+		ConnectionID: e.Ctx().GetSessionVars().ConnectionID,
+	}
+	if e.plan.MaterializedView != nil {
+		readTS, err := sessiontxn.GetTxnManager(e.Ctx()).GetStmtReadTS()
+		if err != nil {
+			return err
+		}
+		mv := *e.plan.MaterializedView
+		mv.LastRefreshTS = readTS
+		tbInfo.MaterializedView = &mv
+	}
+	return dom.DDL().CreateTableWithInfo(e.Ctx(), dbInfo.Name, tbInfo, ddl.OnExistError)
+}
+
+// RefreshMaterializedViewExec represents a REFRESH MATERIALIZED VIEW executor.
+// The data of the view is deleted and the data selected with the definition of the view is
+// inserted in one transaction, so queries on the view read either the old data or the
+// refreshed data. The snapshot which the data is selected from is recorded in the meta of
+// the view by a DDL job after the transaction is committed.
+type RefreshMaterializedViewExec struct {
+	exec.BaseExecutor
+
+	plan       *plannercore.RefreshMaterializedView
+	deleteExec exec.Executor
+	insertExec exec.Executor
+	done       bool
+}
+
+// Next implements the Executor Next interface.
+func (e *RefreshMaterializedViewExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+
+	readTS, err := sessiontxn.GetTxnManager(e.Ctx()).GetStmtReadTS()
+	if err != nil {
+		return err
+	}
+	for _, child := range []exec.Executor{e.deleteExec, e.insertExec} {
+		if err := exec.Next(ctx, child, exec.NewFirstChunk(child)); err != nil {
+			return err
+		}
+	}
+	// commit the refreshed data before the refresh is recorded.
+	e.Ctx().StmtCommit(ctx)
+	if err := sessiontxn.NewTxnInStmt(ctx, e.Ctx()); err != nil {
+		return err
+	}
+
+	dom := domain.GetDomain(e.Ctx())
+	ident := ast.Ident{Schema: e.plan.Table.Schema, Name: e.plan.Table.Name}
+	if err := dom.DDL().RecordMaterializedViewRefresh(e.Ctx(), ident, e.plan.Table.TableInfo.ID, readTS); err != nil {
+		return err
+	}
+	e.Ctx().GetSessionVars().TxnCtx.InfoSchema = dom.InfoSchema()
+	return nil
+}
+
+// importSelectIntoTable imports the selected rows into the table, and records the
+// progress in an import job whose target is shown as targetName.
+func importSelectIntoTable(
+	ctx context.Context,
+	e *exec.BaseExecutor,
+	selectExec exec.Executor,
+	selectPlan plannercore.PhysicalPlan,
+	columns []*ast.ColumnName,
+	tn *ast.TableName,
+	targetName, selectText string,
+) (err error) {
+	is := domain.GetDomain(e.Ctx()).InfoSchema()
+	dbInfo, ok := is.SchemaByName(tn.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(tn.Schema.O)
+	}
+	tbl, err := is.TableByName(tn.Schema, tn.Name)
+	if err != nil {
+		return err
+	}
+	sysSctx, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSctx)
+	conn := sysSctx.(sqlexec.SQLExecutor)
+	parameters := &importer.ImportParameters{
+		FileLocation: selectText,
+		Format:       string(importer.DataSourceTypeQuery),
+	}
+	jobID, err := importer.CreateJob(ctx, conn, tn.Schema.O, targetName, tbl.Meta().ID,
+		e.Ctx().GetSessionVars().User.String(), parameters, 0)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if err2 := importer.FailJob(ctx, conn, jobID, err.Error()); err2 != nil {
+				logutil.Logger(ctx).Warn("fail import job failed", zap.Int64("job-id", jobID), zap.Error(err2))
+			}
+		}
+	}()
+	if err = importer.StartJob(ctx, conn, jobID, importer.JobStepImporting); err != nil {
+		return err
+	}
+	if config.GetGlobalConfig().Store == "tikv" {
+		err = importSelectedRows(ctx, e, selectExec, selectPlan, dbInfo, tbl, columns)
+	} else {
+		// the physical import only runs on TiKV, the rows are inserted by transactions on the other stores.
+		err = insertSelectedRows(ctx, e, selectExec, tbl, columns)
+	}
+	if err != nil {
+		return err
+	}
+	if err = importer.Job2Step(ctx, conn, jobID, importer.JobStepValidating); err != nil {
+		return err
+	}
+	return importer.FinishJob(ctx, conn, jobID, &importer.JobSummary{
+		ImportedRows: e.Ctx().GetSessionVars().StmtCtx.AffectedRows(),
+	})
+}
+
+func importSelectedRows(
+	ctx context.Context,
+	e *exec.BaseExecutor,
+	selectExec exec.Executor,
+	selectPlan plannercore.PhysicalPlan,
+	dbInfo *model.DBInfo,
+	tbl table.Table,
+	columns []*ast.ColumnName,
+) error {
+	columnsAndUserVars := make([]*ast.ColumnNameOrUserVar, 0, len(columns))
+	for _, col := range columns {
+		columnsAndUserVars = append(columnsAndUserVars, &ast.ColumnNameOrUserVar{ColumnName: col})
+	}
+	importPlan := &plannercore.ImportInto{
+		Table: &ast.TableName{
+			Schema:    dbInfo.Name,
+			Name:      tbl.Meta().Name,
+			DBInfo:    dbInfo,
+			TableInfo: tbl.Meta(),
+		},
+		ColumnsAndUserVars: columnsAndUserVars,
+		Stmt:               e.Ctx().GetSessionVars().StmtCtx.OriginalSQL,
+		SelectPlan:         selectPlan,
+	}
+	base := exec.NewBaseExecutor(e.Ctx(), importPlan.Schema(), e.ID())
+	importExec, err := newImportIntoExec(base, selectExec, e.Ctx(), importPlan, tbl)
+	if err != nil {
+		return err
+	}
+	return importExec.Next(ctx, exec.NewFirstChunk(importExec))
+}
+
+func insertSelectedRows(ctx context.Context, e *exec.BaseExecutor, selectExec exec.Executor, tbl table.Table, columns []*ast.ColumnName) error {
+	sctx := e.Ctx()
+	// the table is created after the transaction of the statement starts.
+	if err := sessiontxn.NewTxnInStmt(ctx, sctx); err != nil {
+		return err
+	}
+	insertExec := &InsertExec{InsertValues: &InsertValues{
+		BaseExecutor: exec.NewBaseExecutor(sctx, nil, e.ID(), selectExec),
+		Table:        tbl,
+		Columns:      columns,
+		SelectExec:   selectExec,
+		rowLen:       len(columns),
+	}}
+	if err := insertExec.initInsertColumns(); err != nil {
+		return err
+	}
+	// the select executor is opened as the child of the CREATE TABLE ... SELECT executor.
+	insertExec.memTracker = memory.NewTracker(e.ID(), -1)
+	insertExec.memTracker.AttachTo(sctx.GetSessionVars().StmtCtx.MemTracker)
+	if err := insertRowsFromSelect(ctx, insertExec); err != nil {
+		return err
+	}
+	sctx.StmtCommit(ctx)
+	return sessiontxn.NewTxnInStmt(ctx, sctx)
+}
+
+// dropLeftoverTable drops the staging table left by a previous statement of this connection
+// which failed to clean it up.
+func dropLeftoverTable(sctx sessionctx.Context, is infoschema.InfoSchema, tn *ast.TableName) error {
+	if !is.TableExists(tn.Schema, tn.Name) {
+		return nil
+	}
+	tbl, err := is.TableByName(tn.Schema, tn.Name)
+	if err != nil {
+		return err
+	}
+	staging := tbl.Meta().Staging
+	if staging == nil || staging.ServerID != domain.GetDomain(sctx).DDL().GetID() {
+		return nil
+	}
+	return dropTable(sctx, tn, tbl.Meta().IsMaterializedView())
+}
+
+func dropTable(sctx sessionctx.Context, tn *ast.TableName, isMaterializedView bool) error {
+	return domain.GetDomain(sctx).DDL().DropTable(sctx, &ast.DropTableStmt{
+		Tables:             []*ast.TableName{tn},
+		IsMaterializedView: isMaterializedView,
+	})
+}
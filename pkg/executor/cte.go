@@ -17,6 +17,9 @@ package executor
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"slices"
+	"strconv"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
@@ -30,6 +33,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/cteutil"
 	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/disk"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/memory"
 	"go.uber.org/zap"
@@ -163,6 +167,18 @@ type cteProducer struct {
 	opened   bool
 	produced bool
 	closed   bool
+	// fromCache indicates that the result has been materialized by a former statement of the transaction,
+	// the producer has no seedExec and recursiveExec.
+	fromCache bool
+	// txnCache is set if the result is cached for the following statements of the transaction with cacheKey,
+	// the chunks allocated by the current statement need to be copied before being added to resTbl.
+	txnCache *txnCTECache
+	cacheKey string
+	// recursionStopped indicates that the recursion is stopped by the recursion limits and the result is partial,
+	// see variable.TiDBCTERecursionPartialResult.
+	recursionStopped bool
+	// levelRows is the number of the rows produced by each recursion level, the level 0 is the seed part.
+	levelRows []int64
 
 	// cteProducer is shared by multiple operators, so if the first operator tries to open
 	// and got error, the second should return open error directly instead of open again.
@@ -210,6 +226,17 @@ func (p *cteProducer) openProducer(ctx context.Context, cteExec *CTEExec) (err e
 			p.opened = false
 		}
 	}()
+	if p.fromCache {
+		p.resetTracker()
+		p.memTracker = memory.NewTracker(cteExec.ID(), -1)
+		p.diskTracker = disk.NewTracker(cteExec.ID(), -1)
+		p.memTracker.AttachTo(p.ctx.GetSessionVars().StmtCtx.MemTracker)
+		p.diskTracker.AttachTo(p.ctx.GetSessionVars().StmtCtx.DiskTracker)
+		// The result is materialized by a former statement, track its memory usage in the current statement.
+		p.resTbl.GetMemTracker().AttachTo(p.memTracker)
+		p.resTbl.GetDiskTracker().AttachTo(p.diskTracker)
+		return nil
+	}
 	if p.seedExec == nil {
 		return errors.New("seedExec for CTEExec is nil")
 	}
@@ -252,6 +279,10 @@ func (p *cteProducer) openProducer(ctx context.Context, cteExec *CTEExec) (err e
 }
 
 func (p *cteProducer) closeProducer() (firstErr error) {
+	if p.fromCache {
+		p.closed = true
+		return nil
+	}
 	err := exec.Close(p.seedExec)
 	firstErr = setFirstErr(firstErr, err, "close seedExec err")
 
@@ -358,6 +389,13 @@ func (p *cteProducer) produce(ctx context.Context, cteExec *CTEExec) (err error)
 		}
 	})
 
+	if p.recursiveExec != nil && cteExec.RuntimeStats() != nil {
+		defer func() {
+			stats := &cteRuntimeStats{levelRows: p.levelRows, partial: p.recursionStopped}
+			cteExec.Ctx().GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(cteExec.ID(), stats)
+		}()
+	}
+
 	if err = p.computeSeedPart(ctx); err != nil {
 		p.resTbl.SetError(err)
 		return err
@@ -398,11 +436,23 @@ func (p *cteProducer) computeSeedPart(ctx context.Context) (err error) {
 	}
 	// Initial resTbl is empty, so no need to deduplicate chk using resTbl.
 	// Just adding is ok.
+	var rows int64
 	for _, chk := range chks {
+		if p.txnCache != nil {
+			chk = chk.CopyConstruct()
+		}
+		if chk, err = p.checkRowsLimit(chk); err != nil {
+			return
+		}
 		if err = p.resTbl.Add(chk); err != nil {
 			return
 		}
+		rows += int64(chk.NumRows())
+		if p.recursionStopped {
+			break
+		}
 	}
+	p.levelRows = append(p.levelRows, rows)
 	p.curIter++
 	p.iterInTbl.SetIter(p.curIter)
 
@@ -416,12 +466,12 @@ func (p *cteProducer) computeRecursivePart(ctx context.Context) (err error) {
 		}
 	}()
 	failpoint.Inject("testCTERecursivePanic", nil)
-	if p.recursiveExec == nil || p.iterInTbl.NumChunks() == 0 {
+	if p.recursiveExec == nil || p.iterInTbl.NumChunks() == 0 || p.recursionStopped {
 		return
 	}
 
 	if p.curIter > p.ctx.GetSessionVars().CTEMaxRecursionDepth {
-		return exeerrors.ErrCTEMaxRecursionDepth.GenWithStackByArgs(p.curIter)
+		return p.recursionLimitExceeded(exeerrors.ErrCTEMaxRecursionDepth.GenWithStackByArgs(p.curIter))
 	}
 
 	if p.limitDone(p.resTbl) {
@@ -437,7 +487,7 @@ func (p *cteProducer) computeRecursivePart(ctx context.Context) (err error) {
 			if err = p.setupTblsForNewIteration(); err != nil {
 				return
 			}
-			if p.limitDone(p.resTbl) {
+			if p.limitDone(p.resTbl) || p.recursionStopped {
 				break
 			}
 			if p.iterInTbl.NumChunks() == 0 {
@@ -447,7 +497,7 @@ func (p *cteProducer) computeRecursivePart(ctx context.Context) (err error) {
 			p.curIter++
 			p.iterInTbl.SetIter(p.curIter)
 			if p.curIter > p.ctx.GetSessionVars().CTEMaxRecursionDepth {
-				return exeerrors.ErrCTEMaxRecursionDepth.GenWithStackByArgs(p.curIter)
+				return p.recursionLimitExceeded(exeerrors.ErrCTEMaxRecursionDepth.GenWithStackByArgs(p.curIter))
 			}
 			// Make sure iterInTbl is setup before Close/Open,
 			// because some executors will read iterInTbl in Open() (like IndexLookupJoin).
@@ -470,6 +520,7 @@ func (p *cteProducer) setupTblsForNewIteration() (err error) {
 	num := p.iterOutTbl.NumChunks()
 	chks := make([]*chunk.Chunk, 0, num)
 	// Setup resTbl's data.
+	var rows int64
 	for i := 0; i < num; i++ {
 		chk, err := p.iterOutTbl.GetChunk(i)
 		if err != nil {
@@ -478,15 +529,27 @@ func (p *cteProducer) setupTblsForNewIteration() (err error) {
 		// Data should be copied in UNION DISTINCT.
 		// Because deduplicate() will change data in iterOutTbl,
 		// which will cause panic when spilling data into disk concurrently.
-		if p.isDistinct {
+		if p.isDistinct || p.txnCache != nil {
 			chk = chk.CopyConstruct()
 		}
-		chk, err = p.tryDedupAndAdd(chk, p.resTbl, p.hashTbl)
-		if err != nil {
+		if p.isDistinct {
+			if chk, err = p.deduplicate(chk, p.resTbl, p.hashTbl); err != nil {
+				return err
+			}
+		}
+		if chk, err = p.checkRowsLimit(chk); err != nil {
+			return err
+		}
+		if err = p.resTbl.Add(chk); err != nil {
 			return err
 		}
+		rows += int64(chk.NumRows())
 		chks = append(chks, chk)
+		if p.recursionStopped {
+			break
+		}
 	}
+	p.levelRows = append(p.levelRows, rows)
 
 	// Setup new iteration data in iterInTbl.
 	if err = p.iterInTbl.Reopen(); err != nil {
@@ -512,6 +575,8 @@ func (p *cteProducer) setupTblsForNewIteration() (err error) {
 func (p *cteProducer) reset() {
 	p.curIter = 0
 	p.hashTbl = nil
+	p.recursionStopped = false
+	p.levelRows = nil
 
 	p.opened = false
 	p.openErr = nil
@@ -567,6 +632,42 @@ func setupCTEStorageTracker(tbl cteutil.Storage, ctx sessionctx.Context, parentM
 	return actionSpill
 }
 
+// checkRowsLimit checks whether adding chk into resTbl exceeds tidb_cte_max_recursion_rows. If so, it returns an error,
+// or returns the rows of chk within the limit and stops the recursion if partial results are allowed.
+func (p *cteProducer) checkRowsLimit(chk *chunk.Chunk) (*chunk.Chunk, error) {
+	maxRows := p.ctx.GetSessionVars().CTEMaxRecursionRows
+	if maxRows <= 0 || p.recursiveExec == nil {
+		return chk, nil
+	}
+	remain := maxRows - int64(p.resTbl.NumRows())
+	if int64(chk.NumRows()) <= remain {
+		return chk, nil
+	}
+	if err := p.recursionLimitExceeded(exeerrors.ErrCTEMaxRecursionRows.GenWithStackByArgs(maxRows)); err != nil {
+		return nil, err
+	}
+	chk = chk.CopyConstructSel()
+	chk.TruncateTo(int(max(remain, 0)))
+	return chk, nil
+}
+
+// recursionLimitExceeded returns err if partial results are not allowed, otherwise it appends err as a warning
+// and stops the recursion.
+func (p *cteProducer) recursionLimitExceeded(err error) error {
+	sessVars := p.ctx.GetSessionVars()
+	if !sessVars.CTERecursionPartialResult {
+		return err
+	}
+	sessVars.StmtCtx.AppendWarning(err)
+	p.recursionStopped = true
+	// The partial result depends on the limits of the session, don't share it with the following statements.
+	if p.txnCache != nil {
+		p.txnCache.evict(p.cacheKey)
+		p.txnCache = nil
+	}
+	return nil
+}
+
 func (p *cteProducer) tryDedupAndAdd(chk *chunk.Chunk,
 	storage cteutil.Storage,
 	hashTbl baseHashTable) (res *chunk.Chunk, err error) {
@@ -735,3 +836,69 @@ func (p *cteProducer) checkAndUpdateCorColHashCode() bool {
 	}
 	return changed
 }
+
+// cteRuntimeStats is the runtime stats of the recursive CTE.
+type cteRuntimeStats struct {
+	// levelRows is the number of the rows produced by each recursion level, the level 0 is the seed part.
+	levelRows []int64
+	// partial indicates that the recursion is stopped by the recursion limits.
+	partial bool
+}
+
+// maxShownCTELevels is the max number of the recursion levels whose rows are shown in the runtime stats,
+// the rows of the first and the last levels are shown if there are more levels.
+const maxShownCTELevels = 10
+
+// String implements the RuntimeStats interface.
+func (e *cteRuntimeStats) String() string {
+	var totalRows int64
+	for _, rows := range e.levelRows {
+		totalRows += rows
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, 64))
+	fmt.Fprintf(buf, "recursion:{levels:%d, rows:%d, rows_per_level:[", len(e.levelRows), totalRows)
+	for i, rows := range e.levelRows {
+		if len(e.levelRows) > maxShownCTELevels && i >= maxShownCTELevels/2 && i < len(e.levelRows)-maxShownCTELevels/2 {
+			if i == maxShownCTELevels/2 {
+				buf.WriteString(" ...")
+			}
+			continue
+		}
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(strconv.FormatInt(rows, 10))
+	}
+	buf.WriteString("]")
+	if e.partial {
+		buf.WriteString(", partial:true")
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+// Clone implements the RuntimeStats interface.
+func (e *cteRuntimeStats) Clone() execdetails.RuntimeStats {
+	return &cteRuntimeStats{levelRows: slices.Clone(e.levelRows), partial: e.partial}
+}
+
+// Merge implements the RuntimeStats interface.
+func (e *cteRuntimeStats) Merge(other execdetails.RuntimeStats) {
+	tmp, ok := other.(*cteRuntimeStats)
+	if !ok {
+		return
+	}
+	for i, rows := range tmp.levelRows {
+		if i < len(e.levelRows) {
+			e.levelRows[i] += rows
+		} else {
+			e.levelRows = append(e.levelRows, rows)
+		}
+	}
+	e.partial = e.partial || tmp.partial
+}
+
+// Tp implements the RuntimeStats interface.
+func (*cteRuntimeStats) Tp() int {
+	return execdetails.TpCTERuntimeStats
+}
@@ -0,0 +1,217 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/cteutil"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// The materialized result of a CTE is shared by the CTEs with the same definition, i.e. the same
+// plannercore.CTEClass.Digest, the same session states affecting the result and the same values of the param markers:
+//  1. In a statement, the CTEs with the same definition share the CTEStorages in the same way as the
+//     references of one CTE.
+//  2. If tidb_enable_txn_cte_cache is on, the result is also cached in the transaction context, and the
+//     following reads of the transaction reuse it until the transaction writes or ends.
+//
+// The results of the correlated CTEs depend on the outer rows, they are never shared.
+
+// txnCTECache caches the materialized CTE results of a transaction.
+type txnCTECache struct {
+	startTS uint64
+	results map[string]cteutil.Storage
+}
+
+// Release implements the variable.TxnCTECache interface.
+func (c *txnCTECache) Release() {
+	for key, resTbl := range c.results {
+		resTbl.Lock()
+		err := resTbl.DerefAndClose()
+		resTbl.Unlock()
+		if err != nil {
+			logutil.BgLogger().Warn("release the cached cte result failed", zap.Error(err))
+		}
+		delete(c.results, key)
+	}
+}
+
+// evict removes the cached result of the key.
+func (c *txnCTECache) evict(key string) {
+	resTbl, ok := c.results[key]
+	if !ok {
+		return
+	}
+	delete(c.results, key)
+	if err := resTbl.DerefAndClose(); err != nil {
+		logutil.BgLogger().Warn("release the cached cte result failed", zap.Error(err))
+	}
+}
+
+// getTxnCTECache returns the CTE cache of the transaction, it returns nil if the statement can't use the cache.
+func (b *executorBuilder) getTxnCTECache() *txnCTECache {
+	sessVars := b.ctx.GetSessionVars()
+	// Only the plain reads of an explicit transaction read the same snapshot in every statement.
+	if !sessVars.EnableTxnCTECache || !sessVars.InTxn() || sessVars.IsPessimisticReadConsistency() ||
+		!sessVars.StmtCtx.InSelectStmt || b.hasLock || b.inSelectLockStmt || b.isStaleness {
+		return nil
+	}
+	txn, err := b.ctx.Txn(true)
+	if err != nil || !txn.Valid() {
+		return nil
+	}
+	txnCtx := sessVars.TxnCtx
+	cache, ok := txnCtx.CTECache.(*txnCTECache)
+	if !ok || cache.startTS != txnCtx.StartTS {
+		if ok {
+			cache.Release()
+		}
+		cache = &txnCTECache{startTS: txnCtx.StartTS, results: make(map[string]cteutil.Storage)}
+		txnCtx.CTECache = cache
+	}
+	// The cached results don't see the writes of the transaction.
+	if !txn.IsReadOnly() {
+		cache.Release()
+		return nil
+	}
+	return cache
+}
+
+// cteCacheKey returns the key to share the materialized result of the CTE, an empty key means that the
+// result can't be shared.
+func (b *executorBuilder) cteCacheKey(v *plannercore.PhysicalCTE, corCols []*expression.CorrelatedColumn) (string, error) {
+	if v.CTE.Digest == "" || len(corCols) > 0 {
+		return "", nil
+	}
+	sessVars := b.ctx.GetSessionVars()
+	digest := v.CTE.Digest + cteSessionStates(sessVars)
+	if !v.CTE.HasParamMarker {
+		return digest, nil
+	}
+	key, err := codec.EncodeValue(sessVars.StmtCtx.TimeZone(), []byte(digest), sessVars.PlanCacheParams.AllParamValues()...)
+	if err != nil {
+		return "", err
+	}
+	return string(key), nil
+}
+
+// cteSessionStateVars are the session variables which affect the result of a CTE.
+var cteSessionStateVars = []string{
+	variable.TimeZone,
+	variable.SQLModeVar,
+	"div_precision_increment",
+	variable.CharacterSetConnection,
+	variable.CollationConnection,
+	variable.DefaultWeekFormat,
+}
+
+// cteSessionStates returns the session states which affect the result of a CTE, including the active roles
+// which decide the masked columns. They are a part of the key so the results cached by the former statements
+// of the transaction are not reused after the states are changed by SET or SET ROLE.
+func cteSessionStates(sessVars *variable.SessionVars) string {
+	var sb strings.Builder
+	for _, name := range cteSessionStateVars {
+		val, _ := sessVars.GetSystemVar(name)
+		sb.WriteByte(0)
+		sb.WriteString(val)
+	}
+	for _, role := range sessVars.ActiveRoles {
+		sb.WriteByte(0)
+		sb.WriteString(role.String())
+	}
+	return sb.String()
+}
+
+// shareCTEStorages finds the materialized result of the same CTE, which is built by another CTE of the
+// statement or cached by the former statements of the transaction. It returns nil if there is no such result.
+func (b *executorBuilder) shareCTEStorages(storageMap map[int]*CTEStorages, v *plannercore.PhysicalCTE, key string) (*CTEStorages, error) {
+	for _, storages := range storageMap {
+		if storages.key != key {
+			continue
+		}
+		// The shared storages are closed by resetCTEStorageMap as many times as they are referenced in the map.
+		if err := storages.ResTbl.OpenAndRef(); err != nil {
+			return nil, err
+		}
+		if err := storages.IterInTbl.OpenAndRef(); err != nil {
+			return nil, err
+		}
+		storageMap[v.CTE.IDForStorage] = storages
+		return storages, nil
+	}
+
+	cache := b.getTxnCTECache()
+	if cache == nil {
+		return nil, nil
+	}
+	resTbl, ok := cache.results[key]
+	if !ok {
+		return nil, nil
+	}
+	if !resTbl.Done() || resTbl.Error() != nil {
+		// The former statement didn't finish materializing the result.
+		delete(cache.results, key)
+		return nil, resTbl.DerefAndClose()
+	}
+	if err := resTbl.OpenAndRef(); err != nil {
+		return nil, err
+	}
+	// The iterInTbl is only read by the recursive part, which is not built for the cached result.
+	tps := make([]*types.FieldType, 0, v.Schema().Len())
+	for _, col := range v.Schema().Columns {
+		tps = append(tps, col.RetType)
+	}
+	iterInTbl := cteutil.NewStorageRowContainer(tps, b.ctx.GetSessionVars().MaxChunkSize)
+	if err := iterInTbl.OpenAndRef(); err != nil {
+		return nil, err
+	}
+	storages := &CTEStorages{ResTbl: resTbl, IterInTbl: iterInTbl, key: key}
+	storages.Producer = &cteProducer{
+		ctx:       b.ctx,
+		resTbl:    resTbl,
+		iterInTbl: iterInTbl,
+		fromCache: true,
+		hasLimit:  v.CTE.HasLimit,
+		limitBeg:  v.CTE.LimitBeg,
+		limitEnd:  v.CTE.LimitEnd,
+	}
+	storageMap[v.CTE.IDForStorage] = storages
+	return storages, nil
+}
+
+// cacheCTEResult caches the materialized result of the CTE for the following statements of the transaction,
+// it returns the cache if the result is cached.
+func (b *executorBuilder) cacheCTEResult(key string, resTbl cteutil.Storage) (*txnCTECache, error) {
+	cache := b.getTxnCTECache()
+	if cache == nil {
+		return nil, nil
+	}
+	if _, ok := cache.results[key]; ok {
+		return nil, nil
+	}
+	// The cache holds a reference, so the result isn't closed at the end of the statement.
+	if err := resTbl.OpenAndRef(); err != nil {
+		return nil, err
+	}
+	cache.results[key] = resTbl
+	return cache, nil
+}
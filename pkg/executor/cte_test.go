@@ -18,12 +18,14 @@ import (
 	"fmt"
 	"math/rand"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -194,3 +196,98 @@ func TestCTEShareCorColumn(t *testing.T) {
 	tk.MustExec("insert into t1 values(1), (2);")
 	tk.MustQuery("SELECT * FROM t1 dt WHERE EXISTS( WITH RECURSIVE qn AS (SELECT a AS b UNION ALL SELECT b+1 FROM qn WHERE b=0 or b = 1) SELECT * FROM qn dtqn1 where exists (select /*+ NO_DECORRELATE() */ b from qn where dtqn1.b+1));").Check(testkit.Rows("1", "2"))
 }
+
+func TestCTEShareResult(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test;")
+	tk.MustExec("drop table if exists t;")
+	tk.MustExec("create table t(a int);")
+	tk.MustExec("insert into t values (1), (2), (3);")
+
+	// The CTEs with the same definition in a statement share the result.
+	// The CTEs are referenced twice, so they are not inlined.
+	sql := "select * from (with c as (select sum(a) s from t) select c1.s from c c1, c c2) x, " +
+		"(with c as (select sum(a) s from t) select c1.s + 1 from c c1, c c2) y;"
+	tk.MustQuery(sql).Check(testkit.Rows("6 7"))
+	executedTableReaders := func(sql string) int {
+		cnt := 0
+		for _, row := range tk.MustQuery("explain analyze " + sql).Rows() {
+			if strings.Contains(row[0].(string), "TableReader") && row[2].(string) != "0" {
+				cnt++
+			}
+		}
+		return cnt
+	}
+	require.Equal(t, 1, executedTableReaders(sql))
+	// The non-deterministic CTEs are not shared.
+	tk.MustQuery("select count(*) from (with c as (select rand() r) select c1.r from c c1, c c2) x, " +
+		"(with c as (select rand() r) select c1.r from c c1, c c2) y where x.r = y.r;").Check(testkit.Rows("0"))
+	tk.MustQuery("select * from (with c as (select sum(a) s from t where a > 1) select c1.s from c c1, c c2) x, " +
+		"(with c as (select sum(a) s from t) select c1.s from c c1, c c2) y;").Check(testkit.Rows("5 6"))
+	// The result filtered by the pushed-down predicates is not shared.
+	require.Equal(t, 2, executedTableReaders("select * from (with c as (select sum(a) s from t) select c1.s from c c1, c c2 where c1.s > 1) x, "+
+		"(with c as (select sum(a) s from t) select c1.s from c c1, c c2) y;"))
+
+	// The CTE results are cached in a read-only transaction.
+	tk.MustExec("set tidb_enable_txn_cte_cache = 1;")
+	tk.MustExec("begin;")
+	sql = "with c as (select sum(a) s from t) select c1.s from c c1, c c2;"
+	tk.MustQuery(sql).Check(testkit.Rows("6"))
+	tk.MustQuery(sql).Check(testkit.Rows("6"))
+	require.Equal(t, 0, executedTableReaders(sql))
+	tk.MustExec("insert into t values (4);")
+	tk.MustQuery(sql).Check(testkit.Rows("10"))
+	tk.MustExec("commit;")
+	tk.MustQuery(sql).Check(testkit.Rows("10"))
+	require.Nil(t, tk.Session().GetSessionVars().TxnCtx.CTECache)
+
+	// The cached results are not reused after the session states affecting them are changed.
+	tk.MustExec("set time_zone = '+00:00';")
+	tk.MustExec("begin;")
+	sql = "with c as (select from_unixtime(0) d) select c1.d from c c1, c c2;"
+	tk.MustQuery(sql).Check(testkit.Rows("1970-01-01 00:00:00"))
+	tk.MustQuery(sql).Check(testkit.Rows("1970-01-01 00:00:00"))
+	tk.MustExec("set time_zone = '+08:00';")
+	tk.MustQuery(sql).Check(testkit.Rows("1970-01-01 08:00:00"))
+	tk.MustExec("commit;")
+	tk.MustExec("set time_zone = default;")
+}
+
+func TestCTERecursionLimits(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test;")
+
+	sql := "with recursive c(n) as (select 1 union all select n + 1 from c where n < 100) select count(*), max(n) from c;"
+	tk.MustExec("set @@cte_max_recursion_depth = 10;")
+	require.True(t, exeerrors.ErrCTEMaxRecursionDepth.Equal(tk.QueryToErr(sql)))
+	tk.MustExec("set @@cte_max_recursion_depth = 1000;")
+	tk.MustExec("set @@tidb_cte_max_recursion_rows = 20;")
+	require.True(t, exeerrors.ErrCTEMaxRecursionRows.Equal(tk.QueryToErr(sql)))
+	tk.MustQuery("with recursive c(n) as (select 1 union all select n + 1 from c where n < 20) select count(*) from c;").Check(testkit.Rows("20"))
+
+	// Return the rows produced so far with a warning.
+	tk.MustExec("set @@tidb_cte_recursion_partial_result = 1;")
+	tk.MustQuery(sql).Check(testkit.Rows("20 20"))
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 8270 Recursive query aborted after producing more than 20 rows. Try increasing @@tidb_cte_max_recursion_rows to a larger value"))
+	tk.MustExec("set @@tidb_cte_max_recursion_rows = 14;")
+	tk.MustQuery("with recursive c(n) as (select 1 union all select n + 1 from c where n < 10 union all select n + 10 from c where n < 10) select count(*) from c;").Check(testkit.Rows("14"))
+	tk.MustExec("set @@tidb_cte_max_recursion_rows = 0;")
+	tk.MustExec("set @@cte_max_recursion_depth = 10;")
+	tk.MustQuery(sql).Check(testkit.Rows("11 11"))
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 3636 Recursive query aborted after 11 iterations. Try increasing @@cte_max_recursion_depth to a larger value"))
+
+	// The rows of each recursion level are shown in the runtime stats.
+	cteExecInfo := func(sql string) string {
+		for _, row := range tk.MustQuery("explain analyze " + sql).Rows() {
+			if strings.HasPrefix(row[0].(string), "CTE_") {
+				return row[5].(string)
+			}
+		}
+		return ""
+	}
+	require.Contains(t, cteExecInfo("with recursive c(n) as (select 1 union all select n + 1 from c where n < 3) select * from c;"),
+		"recursion:{levels:4, rows:3, rows_per_level:[1 1 1 0]}")
+	require.Contains(t, cteExecInfo(sql), "recursion:{levels:11, rows:11, rows_per_level:[1 1 1 1 1 ... 1 1 1 1 1], partial:true}")
+}
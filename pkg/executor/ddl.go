@@ -15,9 +15,12 @@
 package executor
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/config"
@@ -36,12 +39,14 @@ import (
 	"github.com/pingcap/tidb/pkg/sessiontxn/staleread"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/table/temptable"
+	"github.com/pingcap/tidb/pkg/tablecodec"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
 	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 	"github.com/pingcap/tidb/pkg/util/gcutil"
 	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
 )
 
@@ -103,7 +108,7 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 			return e.createSessionTemporaryTable(s)
 		}
 	case *ast.DropTableStmt:
-		if s.IsView {
+		if s.IsView || s.IsMaterializedView {
 			break
 		}
 
@@ -181,7 +186,7 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		if len(x.Tables) != 0 {
 			err = dbterror.ErrGeneralUnsupportedDDL.GenWithStack("Unsupported FLASHBACK table TO TIMESTAMP")
 		} else if x.DBName.O != "" {
-			err = dbterror.ErrGeneralUnsupportedDDL.GenWithStack("Unsupported FLASHBACK database TO TIMESTAMP")
+			err = e.executeFlashbackDatabaseToTimestamp(x)
 		} else {
 			err = e.executeFlashBackCluster(x)
 		}
@@ -203,6 +208,10 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeDropSequence(x)
 	case *ast.AlterSequenceStmt:
 		err = e.executeAlterSequence(x)
+	case *ast.CreateTriggerStmt:
+		err = e.executeCreateTrigger(x)
+	case *ast.DropTriggerStmt:
+		err = e.executeDropTrigger(x)
 	case *ast.CreatePlacementPolicyStmt:
 		err = e.executeCreatePlacementPolicy(x)
 	case *ast.DropPlacementPolicyStmt:
@@ -357,6 +366,14 @@ func (e *DDLExec) executeDropSequence(s *ast.DropSequenceStmt) error {
 	return domain.GetDomain(e.Ctx()).DDL().DropSequence(e.Ctx(), s)
 }
 
+func (e *DDLExec) executeCreateTrigger(s *ast.CreateTriggerStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().CreateTrigger(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeDropTrigger(s *ast.DropTriggerStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().DropTrigger(e.Ctx(), s)
+}
+
 func (e *DDLExec) dropLocalTemporaryTables(localTempTables []*ast.TableName) error {
 	if len(localTempTables) == 0 {
 		return nil
@@ -385,7 +402,16 @@ func (e *DDLExec) executeAlterTable(ctx context.Context, s *ast.AlterTableStmt)
 		return dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("ALTER TABLE")
 	}
 
-	return domain.GetDomain(e.Ctx()).DDL().AlterTable(ctx, e.Ctx(), s)
+	if err := domain.GetDomain(e.Ctx()).DDL().AlterTable(ctx, e.Ctx(), s); err != nil {
+		return err
+	}
+	for _, spec := range s.Specs {
+		if spec.Tp == ast.AlterTableSetTiFlashReplica && spec.TiFlashReplica.Wait != nil && spec.TiFlashReplica.Count > 0 {
+			timeout := time.Duration(spec.TiFlashReplica.Wait.Timeout) * time.Second
+			return waitTiFlashReplicaAvailable(ctx, e.Ctx(), []*ast.TableName{s.Table}, timeout)
+		}
+	}
+	return nil
 }
 
 // executeRecoverTable represents a recover table executor.
@@ -608,7 +634,7 @@ func (e *DDLExec) executeFlashbackDatabase(s *ast.FlashBackDatabaseStmt) error {
 	if is.SchemaExists(dbName) {
 		return infoschema.ErrDatabaseExists.GenWithStackByArgs(dbName)
 	}
-	recoverSchemaInfo, err := e.getRecoverDBByName(s.DBName)
+	recoverSchemaInfo, err := e.getRecoverDBByName(s.DBName, 0)
 	if err != nil {
 		return err
 	}
@@ -622,7 +648,253 @@ func (e *DDLExec) executeFlashbackDatabase(s *ast.FlashBackDatabaseStmt) error {
 	return err
 }
 
-func (e *DDLExec) getRecoverDBByName(schemaName model.CIStr) (recoverSchemaInfo *ddl.RecoverSchemaInfo, err error) {
+// executeFlashbackDatabaseToTimestamp restores a database to the state at the flashback timestamp. It is built
+// from "flashback database ... to timestamp" statement. The database and the tables dropped after the timestamp
+// are recovered with their original IDs by a single DDL job, so the database is restored entirely or not changed.
+// The data of the tables can't be rewound, so the statement is rejected if any table of the database is created,
+// truncated, renamed, altered or written after the timestamp, use "flashback cluster" for these cases.
+func (e *DDLExec) executeFlashbackDatabaseToTimestamp(s *ast.FlashBackToTimestampStmt) error {
+	flashbackTS := s.FlashbackTSO
+	if flashbackTS == 0 {
+		var err error
+		flashbackTS, err = staleread.CalculateAsOfTsExpr(context.Background(), e.Ctx().GetPlanCtx(), s.FlashbackTS)
+		if err != nil {
+			return err
+		}
+	}
+	currentVer, err := e.Ctx().GetStore().CurrentVersion(oracle.GlobalTxnScope)
+	if err != nil {
+		return err
+	}
+	if flashbackTS > currentVer.Ver {
+		return errors.Errorf("cannot set flashback timestamp to future time")
+	}
+	if err = gcutil.ValidateSnapshot(e.Ctx(), flashbackTS); err != nil {
+		return err
+	}
+
+	dom := domain.GetDomain(e.Ctx())
+	snapIS, err := dom.GetSnapshotInfoSchema(flashbackTS)
+	if err != nil {
+		return err
+	}
+	snapDB, ok := snapIS.SchemaByName(s.DBName)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.DBName)
+	}
+	snapTables := make(map[int64]*model.TableInfo)
+	for _, tbl := range snapIS.SchemaTables(snapDB.Name) {
+		tblInfo := tbl.Meta()
+		if !tblInfo.IsBaseTable() || tblInfo.TempTableType != model.TempTableNone {
+			e.Ctx().GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("flashback database skips %s.%s, only normal tables can be flashback", snapDB.Name.O, tblInfo.Name.O))
+			continue
+		}
+		snapTables[tblInfo.ID] = tblInfo
+	}
+
+	// curTables are the tables of the database now, or when the database was dropped.
+	var recoverSchemaInfo *ddl.RecoverSchemaInfo
+	curTables := make(map[int64]*model.TableInfo)
+	is := dom.InfoSchema()
+	if dbInfo, ok := is.SchemaByID(snapDB.ID); ok {
+		recoverSchemaInfo = &ddl.RecoverSchemaInfo{DBInfo: dbInfo.Clone(), SnapshotTS: flashbackTS, OldSchemaName: dbInfo.Name, SchemaExists: true}
+		for _, tbl := range is.SchemaTables(dbInfo.Name) {
+			curTables[tbl.Meta().ID] = tbl.Meta()
+		}
+	} else {
+		if is.SchemaExists(snapDB.Name) {
+			return infoschema.ErrDatabaseExists.GenWithStack("Schema '%-.192s' has been dropped and created again after the flashback timestamp, can't be flashback", snapDB.Name.O)
+		}
+		recoverSchemaInfo, err = e.getRecoverDBByName(snapDB.Name, snapDB.ID)
+		if err != nil {
+			return err
+		}
+		for _, recoverInfo := range recoverSchemaInfo.RecoverTabsInfo {
+			curTables[recoverInfo.TableInfo.ID] = recoverInfo.TableInfo
+		}
+	}
+
+	for id, tblInfo := range curTables {
+		if !tblInfo.IsBaseTable() || tblInfo.TempTableType != model.TempTableNone {
+			continue
+		}
+		snapTblInfo, ok := snapTables[id]
+		if !ok {
+			if _, ok := snapIS.TableByID(id); ok {
+				return errors.Errorf("Table '%s' is moved into database '%s' after the flashback timestamp, move it back before flashback database", tblInfo.Name.O, snapDB.Name.O)
+			}
+			return errors.Errorf("Table '%s' is created or truncated after the flashback timestamp, can't flashback database '%s', use flashback cluster instead", tblInfo.Name.O, snapDB.Name.O)
+		}
+		if snapTblInfo.Name.L != tblInfo.Name.L {
+			return errors.Errorf("Table '%s' is renamed to '%s' after the flashback timestamp, rename it back before flashback database", snapTblInfo.Name.O, tblInfo.Name.O)
+		}
+		if err = e.checkTableNotChanged(snapTblInfo, tblInfo, flashbackTS, currentVer.Ver); err != nil {
+			return err
+		}
+	}
+
+	// Recover the tables which are dropped after the flashback timestamp with the database.
+	missingTables := make(map[int64]*model.TableInfo)
+	for id, tblInfo := range snapTables {
+		if _, ok := curTables[id]; ok {
+			continue
+		}
+		if tbl, ok := is.TableByID(id); ok {
+			return errors.Errorf("Table '%s' is moved out of database '%s' after the flashback timestamp, move it back before flashback database", tbl.Meta().Name.O, snapDB.Name.O)
+		}
+		missingTables[id] = tblInfo
+	}
+	if len(missingTables) > 0 {
+		recoverInfos, err := e.getRecoverTablesByID(snapDB, missingTables)
+		if err != nil {
+			return err
+		}
+		for _, recoverInfo := range recoverInfos {
+			// The data of the dropped tables are kept until the GC safe point passes the drop.
+			if err = e.checkTableNotChanged(missingTables[recoverInfo.TableInfo.ID], recoverInfo.TableInfo, flashbackTS, currentVer.Ver); err != nil {
+				return err
+			}
+		}
+		recoverSchemaInfo.RecoverTabsInfo = append(recoverSchemaInfo.RecoverTabsInfo, recoverInfos...)
+	}
+	if recoverSchemaInfo.SchemaExists && len(recoverSchemaInfo.RecoverTabsInfo) == 0 {
+		return nil
+	}
+	return dom.DDL().RecoverSchema(e.Ctx(), recoverSchemaInfo)
+}
+
+// checkTableNotChanged returns an error if the table is altered or written between the flashback timestamp and ts,
+// flashback database can't rewind such changes.
+func (e *DDLExec) checkTableNotChanged(snapTblInfo, tblInfo *model.TableInfo, flashbackTS, ts uint64) error {
+	// UpdateTS is changed by recovering the table too, so the definitions are compared without it.
+	snapDef, curDef := snapTblInfo.Clone(), tblInfo.Clone()
+	snapDef.UpdateTS, curDef.UpdateTS = 0, 0
+	snapJSON, err := json.Marshal(snapDef)
+	if err != nil {
+		return err
+	}
+	curJSON, err := json.Marshal(curDef)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(snapJSON, curJSON) {
+		return errors.Errorf("Table '%s' is altered after the flashback timestamp, can't flashback database, use flashback cluster instead", snapTblInfo.Name.O)
+	}
+	store := e.Ctx().GetStore()
+	for _, physicalID := range getTablePhysicalIDs(snapTblInfo) {
+		changed, err := tableDataChanged(store, physicalID, flashbackTS, ts)
+		if err != nil {
+			return err
+		}
+		if changed {
+			return errors.Errorf("Table '%s' is written after the flashback timestamp, can't flashback database, use flashback cluster instead", snapTblInfo.Name.O)
+		}
+	}
+	return nil
+}
+
+func getTablePhysicalIDs(tblInfo *model.TableInfo) []int64 {
+	ids := []int64{tblInfo.ID}
+	if pi := tblInfo.GetPartitionInfo(); pi != nil {
+		for _, def := range pi.Definitions {
+			ids = append(ids, def.ID)
+		}
+	}
+	return ids
+}
+
+// tableDataChanged compares the records and indexes of the physical table at the two timestamps.
+func tableDataChanged(store kv.Storage, physicalID int64, ts1, ts2 uint64) (bool, error) {
+	prefix := tablecodec.GenTablePrefix(physicalID)
+	iter1, err := store.GetSnapshot(kv.NewVersion(ts1)).Iter(prefix, prefix.PrefixNext())
+	if err != nil {
+		return false, err
+	}
+	defer iter1.Close()
+	iter2, err := store.GetSnapshot(kv.NewVersion(ts2)).Iter(prefix, prefix.PrefixNext())
+	if err != nil {
+		return false, err
+	}
+	defer iter2.Close()
+	for iter1.Valid() && iter2.Valid() {
+		if iter1.Key().Cmp(iter2.Key()) != 0 || !bytes.Equal(iter1.Value(), iter2.Value()) {
+			return true, nil
+		}
+		if err = iter1.Next(); err != nil {
+			return false, err
+		}
+		if err = iter2.Next(); err != nil {
+			return false, err
+		}
+	}
+	return iter1.Valid() || iter2.Valid(), nil
+}
+
+// getRecoverTablesByID finds the latest drop or truncate jobs of the tables in the DDL history,
+// and builds the information to recover them into the schema with their names in tables.
+func (e *DDLExec) getRecoverTablesByID(schema *model.DBInfo, tables map[int64]*model.TableInfo) ([]*ddl.RecoverInfo, error) {
+	txn, err := e.Ctx().Txn(true)
+	if err != nil {
+		return nil, err
+	}
+	gcSafePoint, err := gcutil.GetGCSafePoint(e.Ctx())
+	if err != nil {
+		return nil, err
+	}
+	dom := domain.GetDomain(e.Ctx())
+	recoverInfos := make([]*ddl.RecoverInfo, 0, len(tables))
+	found := make(map[int64]struct{}, len(tables))
+	handleJobAndTableInfo := func(job *model.Job, tblInfo *model.TableInfo) (bool, error) {
+		snapTblInfo, ok := tables[tblInfo.ID]
+		if !ok {
+			return false, nil
+		}
+		if _, ok := found[tblInfo.ID]; ok {
+			return false, nil
+		}
+		if job.SchemaID != schema.ID {
+			return false, errors.Errorf("Table '%s' is moved out of database '%s' before dropped, use flashback table instead", snapTblInfo.Name.O, schema.Name.O)
+		}
+		snapMeta, err := dom.GetSnapshotMeta(job.StartTS)
+		if err != nil {
+			return false, err
+		}
+		autoIDs, err := snapMeta.GetAutoIDAccessors(job.SchemaID, job.TableID).Get()
+		if err != nil {
+			return false, err
+		}
+		oldTableName := tblInfo.Name.L
+		tblInfo.Name = snapTblInfo.Name
+		recoverInfos = append(recoverInfos, &ddl.RecoverInfo{
+			SchemaID:      job.SchemaID,
+			TableInfo:     tblInfo,
+			DropJobID:     job.ID,
+			SnapshotTS:    job.StartTS,
+			AutoIDs:       autoIDs,
+			OldSchemaName: job.SchemaName,
+			OldTableName:  oldTableName,
+		})
+		found[tblInfo.ID] = struct{}{}
+		return len(found) == len(tables), nil
+	}
+	fn := func(jobs []*model.Job) (bool, error) {
+		return GetDropOrTruncateTableInfoFromJobs(jobs, gcSafePoint, dom, handleJobAndTableInfo)
+	}
+	err = ddl.IterHistoryDDLJobs(txn, fn)
+	if err != nil && !terror.ErrorEqual(variable.ErrSnapshotTooOld, err) {
+		return nil, err
+	}
+	for id, tblInfo := range tables {
+		if _, ok := found[id]; !ok {
+			return nil, errors.Errorf("Can't find dropped/truncated table: %v in DDL history jobs", tblInfo.Name)
+		}
+	}
+	return recoverInfos, nil
+}
+
+// getRecoverDBByName finds the latest dropped schema named schemaName in the DDL history.
+// If schemaID is not 0, only the schema with that ID is considered.
+func (e *DDLExec) getRecoverDBByName(schemaName model.CIStr, schemaID int64) (recoverSchemaInfo *ddl.RecoverSchemaInfo, err error) {
 	txn, err := e.Ctx().Txn(true)
 	if err != nil {
 		return nil, err
@@ -656,7 +928,7 @@ func (e *DDLExec) getRecoverDBByName(schemaName model.CIStr) (recoverSchemaInfo
 				// see more in TestParallelDropSchemaAndDropTable.
 				continue
 			}
-			if schemaInfo.Name.L != schemaName.L {
+			if schemaInfo.Name.L != schemaName.L || (schemaID != 0 && schemaInfo.ID != schemaID) {
 				continue
 			}
 			tables, err := snapMeta.ListTables(job.SchemaID)
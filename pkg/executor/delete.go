@@ -143,6 +143,9 @@ func (e *DeleteExec) deleteSingleTableByChunk(ctx context.Context) error {
 			}
 			rowCount++
 		}
+		if err := flushPipelinedMemDB(e.Ctx()); err != nil {
+			return err
+		}
 		chk = chunk.Renew(chk, e.MaxChunkSize())
 	}
 
@@ -228,10 +231,18 @@ func (e *DeleteExec) deleteMultiTablesByChunk(ctx context.Context) error {
 }
 
 func (e *DeleteExec) removeRowsInTblRowMap(tblRowMap tableRowMapType) error {
+	rowCount := 0
 	for id, rowMap := range tblRowMap {
 		var err error
 		rowMap.Range(func(h kv.Handle, val []types.Datum) bool {
 			err = e.removeRow(e.Ctx(), e.tblID2Table[id], h, val)
+			if err != nil {
+				return false
+			}
+			rowCount++
+			if rowCount%e.MaxChunkSize() == 0 {
+				err = flushPipelinedMemDB(e.Ctx())
+			}
 			return err == nil
 		})
 		if err != nil {
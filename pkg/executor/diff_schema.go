@@ -0,0 +1,318 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessiontxn/staleread"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/gcutil"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+const (
+	schemaDiffAdded     = "added"
+	schemaDiffDropped   = "dropped"
+	schemaDiffAltered   = "altered"
+	schemaDiffRecreated = "recreated"
+)
+
+// schemaDiff is a row of the "admin diff schema" result.
+type schemaDiff struct {
+	dbName     string
+	tblName    string
+	objectType string
+	objectName string
+	change     string
+	oldDef     string
+	newDef     string
+}
+
+// DiffSchemaExec represents an executor comparing the schemas at two timestamps.
+// It is built from the "admin diff schema" statement. The snapshot InfoSchemas at
+// both timestamps are loaded and the tables are matched by name, then the added,
+// dropped and altered columns and indexes of each table are reported.
+type DiffSchemaExec struct {
+	exec.BaseExecutor
+
+	fromTS  ast.ExprNode
+	toTS    ast.ExprNode
+	dbName  model.CIStr
+	tblName model.CIStr
+
+	diffs  []schemaDiff
+	cursor int
+	done   bool
+}
+
+// Next implements the Executor Next interface.
+func (e *DiffSchemaExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if !e.done {
+		e.done = true
+		fromIS, err := e.snapshotInfoSchema(ctx, e.fromTS)
+		if err != nil {
+			return err
+		}
+		toIS, err := e.snapshotInfoSchema(ctx, e.toTS)
+		if err != nil {
+			return err
+		}
+		e.diffs = diffInfoSchemas(fromIS, toIS, e.dbName, e.tblName)
+	}
+	for ; e.cursor < len(e.diffs) && !req.IsFull(); e.cursor++ {
+		d := e.diffs[e.cursor]
+		req.AppendString(0, d.dbName)
+		req.AppendString(1, d.tblName)
+		req.AppendString(2, d.objectType)
+		req.AppendString(3, d.objectName)
+		req.AppendString(4, d.change)
+		req.AppendString(5, d.oldDef)
+		req.AppendString(6, d.newDef)
+	}
+	return nil
+}
+
+func (e *DiffSchemaExec) snapshotInfoSchema(ctx context.Context, tsExpr ast.ExprNode) (infoschema.InfoSchema, error) {
+	ts, err := staleread.CalculateAsOfTsExpr(ctx, e.Ctx().GetPlanCtx(), tsExpr)
+	if err != nil {
+		return nil, err
+	}
+	currentVer, err := e.Ctx().GetStore().CurrentVersion(oracle.GlobalTxnScope)
+	if err != nil {
+		return nil, err
+	}
+	if ts > currentVer.Ver {
+		return nil, errors.Errorf("cannot diff schema at future time %s", oracle.GetTimeFromTS(ts))
+	}
+	if err = gcutil.ValidateSnapshot(e.Ctx(), ts); err != nil {
+		return nil, err
+	}
+	return domain.GetDomain(e.Ctx()).GetSnapshotInfoSchema(ts)
+}
+
+// diffInfoSchemas compares the schemas of two InfoSchemas. If dbName is set, only the
+// schema is compared, and if tblName is set too, only the table is compared.
+func diffInfoSchemas(fromIS, toIS infoschema.InfoSchema, dbName, tblName model.CIStr) []schemaDiff {
+	var dbNames []model.CIStr
+	if dbName.L != "" {
+		dbNames = append(dbNames, dbName)
+	} else {
+		for _, is := range []infoschema.InfoSchema{fromIS, toIS} {
+			for _, db := range is.AllSchemas() {
+				if !util.IsMemDB(db.Name.L) && !slices.ContainsFunc(dbNames, func(n model.CIStr) bool { return n.L == db.Name.L }) {
+					dbNames = append(dbNames, db.Name)
+				}
+			}
+		}
+		slices.SortFunc(dbNames, func(a, b model.CIStr) int { return strings.Compare(a.L, b.L) })
+	}
+
+	var diffs []schemaDiff
+	for _, name := range dbNames {
+		fromDB, inFrom := fromIS.SchemaByName(name)
+		toDB, inTo := toIS.SchemaByName(name)
+		switch {
+		case !inFrom && !inTo:
+			continue
+		case !inFrom:
+			diffs = append(diffs, schemaDiff{dbName: toDB.Name.O, objectType: "database", objectName: toDB.Name.O, change: schemaDiffAdded})
+			continue
+		case !inTo:
+			diffs = append(diffs, schemaDiff{dbName: fromDB.Name.O, objectType: "database", objectName: fromDB.Name.O, change: schemaDiffDropped})
+			continue
+		}
+		diffs = append(diffs, diffSchemaTables(toDB.Name.O, fromIS.SchemaTables(name), toIS.SchemaTables(name), tblName)...)
+	}
+	return diffs
+}
+
+func diffSchemaTables(dbName string, fromTables, toTables []table.Table, tblName model.CIStr) []schemaDiff {
+	tableInfos := func(tables []table.Table) map[string]*model.TableInfo {
+		infos := make(map[string]*model.TableInfo, len(tables))
+		for _, tbl := range tables {
+			if tblName.L == "" || tbl.Meta().Name.L == tblName.L {
+				infos[tbl.Meta().Name.L] = tbl.Meta()
+			}
+		}
+		return infos
+	}
+	fromInfos, toInfos := tableInfos(fromTables), tableInfos(toTables)
+	names := make([]string, 0, len(toInfos))
+	for name := range fromInfos {
+		names = append(names, name)
+	}
+	for name := range toInfos {
+		if _, ok := fromInfos[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var diffs []schemaDiff
+	for _, name := range names {
+		from, to := fromInfos[name], toInfos[name]
+		switch {
+		case from == nil:
+			diffs = append(diffs, schemaDiff{dbName: dbName, tblName: to.Name.O, objectType: tableTypeName(to), objectName: to.Name.O, change: schemaDiffAdded})
+			continue
+		case to == nil:
+			diffs = append(diffs, schemaDiff{dbName: dbName, tblName: from.Name.O, objectType: tableTypeName(from), objectName: from.Name.O, change: schemaDiffDropped})
+			continue
+		}
+		if from.ID != to.ID {
+			// The table is truncated, or dropped and created again.
+			diffs = append(diffs, schemaDiff{
+				dbName: dbName, tblName: to.Name.O, objectType: tableTypeName(to), objectName: to.Name.O, change: schemaDiffRecreated,
+				oldDef: fmt.Sprintf("ID %d", from.ID), newDef: fmt.Sprintf("ID %d", to.ID),
+			})
+		}
+		diffs = append(diffs, diffTableObjects(dbName, to.Name.O, "column", tableColumnDefs(from), tableColumnDefs(to))...)
+		diffs = append(diffs, diffTableObjects(dbName, to.Name.O, "index", tableIndexDefs(from), tableIndexDefs(to))...)
+	}
+	return diffs
+}
+
+// diffTableObjects compares the definitions of the columns or indexes of a table,
+// the objects are reported in the order of the new table with the dropped ones last.
+func diffTableObjects(dbName, tblName, objectType string, from, to [][2]string) []schemaDiff {
+	var diffs []schemaDiff
+	find := func(defs [][2]string, name string) (string, bool) {
+		for _, def := range defs {
+			if strings.EqualFold(def[0], name) {
+				return def[1], true
+			}
+		}
+		return "", false
+	}
+	for _, def := range to {
+		oldDef, ok := find(from, def[0])
+		if !ok {
+			diffs = append(diffs, schemaDiff{dbName: dbName, tblName: tblName, objectType: objectType, objectName: def[0], change: schemaDiffAdded, newDef: def[1]})
+		} else if oldDef != def[1] {
+			diffs = append(diffs, schemaDiff{dbName: dbName, tblName: tblName, objectType: objectType, objectName: def[0], change: schemaDiffAltered, oldDef: oldDef, newDef: def[1]})
+		}
+	}
+	for _, def := range from {
+		if _, ok := find(to, def[0]); !ok {
+			diffs = append(diffs, schemaDiff{dbName: dbName, tblName: tblName, objectType: objectType, objectName: def[0], change: schemaDiffDropped, oldDef: def[1]})
+		}
+	}
+	return diffs
+}
+
+func tableTypeName(tblInfo *model.TableInfo) string {
+	switch {
+	case tblInfo.IsView():
+		return "view"
+	case tblInfo.IsSequence():
+		return "sequence"
+	default:
+		return "table"
+	}
+}
+
+// tableColumnDefs returns the names and definitions of the public columns.
+func tableColumnDefs(tblInfo *model.TableInfo) [][2]string {
+	defs := make([][2]string, 0, len(tblInfo.Columns))
+	for _, col := range tblInfo.Columns {
+		if col.State != model.StatePublic || col.Hidden {
+			continue
+		}
+		var sb strings.Builder
+		sb.WriteString(col.GetTypeDesc())
+		if col.IsGenerated() {
+			fmt.Fprintf(&sb, " GENERATED ALWAYS AS (%s)", col.GeneratedExprString)
+			if col.GeneratedStored {
+				sb.WriteString(" STORED")
+			} else {
+				sb.WriteString(" VIRTUAL")
+			}
+		}
+		if mysql.HasNotNullFlag(col.GetFlag()) {
+			sb.WriteString(" NOT NULL")
+		}
+		if def := col.GetDefaultValue(); def != nil {
+			fmt.Fprintf(&sb, " DEFAULT %v", def)
+		}
+		if mysql.HasAutoIncrementFlag(col.GetFlag()) {
+			sb.WriteString(" AUTO_INCREMENT")
+		}
+		if col.Comment != "" {
+			fmt.Fprintf(&sb, " COMMENT '%s'", col.Comment)
+		}
+		defs = append(defs, [2]string{col.Name.O, sb.String()})
+	}
+	return defs
+}
+
+// tableIndexDefs returns the names and definitions of the public indexes,
+// including the primary key which is the row handle.
+func tableIndexDefs(tblInfo *model.TableInfo) [][2]string {
+	defs := make([][2]string, 0, len(tblInfo.Indices)+1)
+	if tblInfo.PKIsHandle {
+		if pk := tblInfo.GetPkColInfo(); pk != nil {
+			defs = append(defs, [2]string{mysql.PrimaryKeyName, fmt.Sprintf("PRIMARY KEY (%s) CLUSTERED", pk.Name.O)})
+		}
+	}
+	for _, idx := range tblInfo.Indices {
+		if idx.State != model.StatePublic {
+			continue
+		}
+		var sb strings.Builder
+		switch {
+		case idx.Primary:
+			sb.WriteString("PRIMARY KEY")
+		case idx.Unique:
+			sb.WriteString("UNIQUE KEY")
+		default:
+			sb.WriteString("KEY")
+		}
+		cols := make([]string, 0, len(idx.Columns))
+		for _, col := range idx.Columns {
+			if col.Length != types.UnspecifiedLength {
+				cols = append(cols, fmt.Sprintf("%s(%d)", col.Name.O, col.Length))
+			} else {
+				cols = append(cols, col.Name.O)
+			}
+		}
+		fmt.Fprintf(&sb, " (%s)", strings.Join(cols, ", "))
+		if idx.Primary && tblInfo.IsCommonHandle {
+			sb.WriteString(" CLUSTERED")
+		}
+		if idx.Global {
+			sb.WriteString(" GLOBAL")
+		}
+		if idx.Invisible {
+			sb.WriteString(" INVISIBLE")
+		}
+		defs = append(defs, [2]string{idx.Name.O, sb.String()})
+	}
+	return defs
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	fstorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// DistJobActionExec represents the executor of CANCEL/PAUSE/RESUME JOB, which operates
+// the job of the distributed task framework, such as IMPORT INTO and ADD INDEX.
+// The action is asynchronous, the state of the job can be checked by SHOW JOBS.
+type DistJobActionExec struct {
+	exec.BaseExecutor
+	tp    ast.DistJobActionTp
+	jobID int64
+}
+
+var (
+	_ exec.Executor = (*DistJobActionExec)(nil)
+)
+
+// Next implements the Executor Next interface.
+func (e *DistJobActionExec) Next(ctx context.Context, _ *chunk.Chunk) error {
+	// we use sessionCtx from GetTaskManager, user ctx might not have system table privileges.
+	taskManager, err := fstorage.GetTaskManager()
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	if err != nil {
+		return err
+	}
+	task, err := taskManager.GetTaskBaseByIDWithHistory(ctx, e.jobID)
+	if err != nil {
+		if errors.ErrorEqual(err, fstorage.ErrTaskNotFound) {
+			return exeerrors.ErrLoadDataJobNotFound.GenWithStackByArgs(e.jobID)
+		}
+		return err
+	}
+
+	found := false
+	switch e.tp {
+	case ast.DistJobCancel:
+		// only the pending and running task can be cancelled, see CancelTask.
+		if task.State == proto.TaskStatePending || task.State == proto.TaskStateRunning {
+			found = true
+			err = taskManager.CancelTask(ctx, task.ID)
+		}
+	case ast.DistJobPause:
+		found, err = taskManager.PauseTask(ctx, task.Key)
+	case ast.DistJobResume:
+		found, err = taskManager.ResumeTask(ctx, task.Key)
+	default:
+		return errors.Errorf("unknown job action type: %s", e.tp)
+	}
+	if err != nil {
+		return err
+	}
+	if !found {
+		return exeerrors.ErrLoadDataInvalidOperation.FastGenByArgs(strings.ToUpper(string(e.tp)))
+	}
+	logutil.Logger(ctx).Info("operate job of distributed task framework",
+		zap.Int64("job-id", task.ID), zap.String("task-key", task.Key),
+		zap.Stringer("state", task.State), zap.String("action", string(e.tp)))
+	return nil
+}
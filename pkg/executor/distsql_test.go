@@ -570,6 +570,40 @@ func TestCoprocessorBatchByStore(t *testing.T) {
 	}
 }
 
+func TestCoprocessorBatchWait(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int primary key, c1 int, c2 int, key i(c1))")
+	for i := 0; i < 10; i++ {
+		tk.MustExec("insert into t values(?, ?, ?)", i*10000, i*10000, i%2)
+	}
+	tk.MustQuery("split table t between (0) and (100000) regions 20").Check(testkit.Rows("20 1"))
+	tk.MustExec("set session tidb_store_batch_size = 0")
+	tk.MustExec("set session tidb_enable_paging = off")
+	tk.MustExec("set global tidb_cop_batch_wait_time = '1s'")
+	tk.MustExec("set global tidb_cop_batch_wait_size = 4")
+	defer func() {
+		tk.MustExec("set global tidb_cop_batch_wait_time = default")
+		tk.MustExec("set global tidb_cop_batch_wait_size = default")
+	}()
+
+	readHistogram := func() (uint64, float64) {
+		var metric dto.Metric
+		require.Nil(t, metrics.DistSQLCoprBatchWaitSize.Write(&metric))
+		return metric.Histogram.GetSampleCount(), metric.Histogram.GetSampleSum()
+	}
+	beforeCount, beforeSum := readHistogram()
+	sql := "select * from t force index(i) where c1 >= 0 and c2 = 0"
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("0 0 0", "20000 20000 0", "40000 40000 0", "60000 60000 0", "80000 80000 0"))
+	tk.MustQuery(sql + " order by c1 desc").Check(testkit.Rows("80000 80000 0", "60000 60000 0", "40000 40000 0", "20000 20000 0", "0 0 0"))
+	count, sum := readHistogram()
+	// The table tasks of the regions are merged into fewer RPCs.
+	require.Greater(t, count, beforeCount)
+	require.Greater(t, sum-beforeSum, float64(count-beforeCount))
+}
+
 func TestCoprCacheWithoutExecutionInfo(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -17,6 +17,7 @@ package executor
 import (
 	"cmp"
 	"context"
+	"encoding/json"
 	stderrors "errors"
 	"fmt"
 	"math"
@@ -76,6 +77,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/disk"
 	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/intest"
+	"github.com/pingcap/tidb/pkg/util/keydecoder"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/logutil/consistency"
 	"github.com/pingcap/tidb/pkg/util/memory"
@@ -270,6 +272,11 @@ type CancelDDLJobsExec struct {
 	*CommandDDLJobsExec
 }
 
+// CancelCheckTableJobsExec represents a cancel online check table jobs executor.
+type CancelCheckTableJobsExec struct {
+	*CommandDDLJobsExec
+}
+
 // PauseDDLJobsExec indicates an Executor for Pause a DDL Job.
 type PauseDDLJobsExec struct {
 	*CommandDDLJobsExec
@@ -404,6 +411,9 @@ type ShowDDLJobsExec struct {
 	jobNumber int
 	is        infoschema.InfoSchema
 	sess      sessionctx.Context
+
+	// historyJobCount is the number of the shown history jobs.
+	historyJobCount int
 }
 
 // DDLJobRetriever retrieve the DDLJobs.
@@ -416,6 +426,11 @@ type DDLJobRetriever struct {
 	activeRoles    []*auth.RoleIdentity
 	cacheJobs      []*model.Job
 	TZLoc          *time.Location
+	extractor      *plannercore.DDLJobsExtractor
+
+	// readHistoryByIDs means the history jobs are read by the job IDs of the extractor instead of being iterated.
+	readHistoryByIDs bool
+	historyJobs      []*model.Job
 }
 
 func (e *DDLJobRetriever) initial(txn kv.Transaction, sess sessionctx.Context) error {
@@ -433,12 +448,61 @@ func (e *DDLJobRetriever) initial(txn kv.Transaction, sess sessionctx.Context) e
 	return nil
 }
 
-func (e *DDLJobRetriever) appendJobToChunk(req *chunk.Chunk, job *model.Job, checker privilege.Manager) {
-	schemaName := job.SchemaName
-	tableName := ""
-	finishTS := uint64(0)
+func (e *DDLJobRetriever) readHistoryJobsByIDs(m *meta.Meta) error {
+	e.readHistoryByIDs = true
+	ids := e.extractor.JobIDs
+	for i := len(ids) - 1; i >= 0; i-- {
+		if slices.ContainsFunc(e.runningJobs, func(job *model.Job) bool { return job.ID == ids[i] }) {
+			continue
+		}
+		job, err := m.GetHistoryDDLJob(ids[i])
+		if err != nil {
+			return err
+		}
+		if job != nil {
+			e.historyJobs = append(e.historyJobs, job)
+		}
+	}
+	return nil
+}
+
+// nextHistoryJobs returns at most num history jobs in the descending order of the job ID.
+func (e *DDLJobRetriever) nextHistoryJobs(num int) ([]*model.Job, error) {
+	if e.readHistoryByIDs {
+		num = min(num, len(e.historyJobs))
+		jobs := e.historyJobs[:num]
+		e.historyJobs = e.historyJobs[num:]
+		return jobs, nil
+	}
+	var err error
+	e.cacheJobs, err = e.historyJobIter.GetLastJobs(num, e.cacheJobs)
+	return e.cacheJobs, err
+}
+
+// skipJob returns true if the job can't match the predicates pushed down to the extractor.
+func (e *DDLJobRetriever) skipJob(job *model.Job) bool {
+	if e.extractor == nil {
+		return false
+	}
+	if len(e.extractor.JobIDs) > 0 {
+		if _, found := slices.BinarySearch(e.extractor.JobIDs, job.ID); !found {
+			return true
+		}
+	}
+	schemaName, tableName := e.jobSchemaAndTableName(job)
+	states := []string{job.State.String()}
+	if job.MultiSchemaInfo != nil {
+		for _, subJob := range job.MultiSchemaInfo.SubJobs {
+			states = append(states, subJob.State.String())
+		}
+	}
+	return e.extractor.Filter(schemaName, tableName, states, model.TSConvert2Time(job.StartTS))
+}
+
+// jobSchemaAndTableName returns the schema name and the table name of the job shown in the result.
+func (e *DDLJobRetriever) jobSchemaAndTableName(job *model.Job) (schemaName, tableName string) {
+	schemaName = job.SchemaName
 	if job.BinlogInfo != nil {
-		finishTS = job.BinlogInfo.FinishedTS
 		if job.BinlogInfo.TableInfo != nil {
 			tableName = job.BinlogInfo.TableInfo.Name.L
 		}
@@ -466,6 +530,17 @@ func (e *DDLJobRetriever) appendJobToChunk(req *chunk.Chunk, job *model.Job, che
 	if len(tableName) == 0 {
 		tableName = getTableName(e.is, job.TableID)
 	}
+	return schemaName, tableName
+}
+
+// appendJobToChunk appends the rows of the job except the query, it returns false if the job isn't appended
+// because of the privilege.
+func (e *DDLJobRetriever) appendJobToChunk(req *chunk.Chunk, job *model.Job, checker privilege.Manager) bool {
+	schemaName, tableName := e.jobSchemaAndTableName(job)
+	finishTS := uint64(0)
+	if job.BinlogInfo != nil {
+		finishTS = job.BinlogInfo.FinishedTS
+	}
 
 	createTime := ts2Time(job.StartTS, e.TZLoc)
 	startTime := ts2Time(job.RealStartTS, e.TZLoc)
@@ -473,7 +548,7 @@ func (e *DDLJobRetriever) appendJobToChunk(req *chunk.Chunk, job *model.Job, che
 
 	// Check the privilege.
 	if checker != nil && !checker.RequestVerification(e.activeRoles, strings.ToLower(schemaName), strings.ToLower(tableName), "", mysql.AllPrivMask) {
-		return
+		return false
 	}
 
 	req.AppendInt64(0, job.ID)
@@ -522,6 +597,7 @@ func (e *DDLJobRetriever) appendJobToChunk(req *chunk.Chunk, job *model.Job, che
 			req.AppendString(11, subJob.State.String())
 		}
 	}
+	return true
 }
 
 func showAddIdxReorgTp(job *model.Job) string {
@@ -773,41 +849,49 @@ func (e *ShowDDLJobsExec) Open(ctx context.Context) error {
 	}
 	sess.GetSessionVars().SetInTxn(true)
 	err = e.DDLJobRetriever.initial(txn, sess)
-	return err
+	if err != nil {
+		return err
+	}
+	if e.extractor != nil && len(e.extractor.JobIDs) > 0 {
+		return e.readHistoryJobsByIDs(meta.NewMeta(txn))
+	}
+	return nil
 }
 
 // Next implements the Executor Next interface.
+// The jobs which can't match the pushed down predicates are skipped, so at most jobNumber of the matched history
+// jobs are shown.
 func (e *ShowDDLJobsExec) Next(_ context.Context, req *chunk.Chunk) error {
 	req.GrowAndReset(e.MaxChunkSize())
-	if (e.cursor - len(e.runningJobs)) >= e.jobNumber {
+	if e.extractor != nil && e.extractor.SkipRequest {
 		return nil
 	}
-	count := 0
 
 	// Append running ddl jobs.
-	if e.cursor < len(e.runningJobs) {
-		numCurBatch := min(req.Capacity(), len(e.runningJobs)-e.cursor)
-		for i := e.cursor; i < e.cursor+numCurBatch; i++ {
-			e.appendJobToChunk(req, e.runningJobs[i], nil)
+	for e.cursor < len(e.runningJobs) && req.NumRows() < req.Capacity() {
+		if !e.skipJob(e.runningJobs[e.cursor]) {
+			e.appendJobToChunk(req, e.runningJobs[e.cursor], nil)
 		}
-		e.cursor += numCurBatch
-		count += numCurBatch
+		e.cursor++
 	}
 
 	// Append history ddl jobs.
-	var err error
-	if count < req.Capacity() {
-		num := req.Capacity() - count
-		remainNum := e.jobNumber - (e.cursor - len(e.runningJobs))
-		num = min(num, remainNum)
-		e.cacheJobs, err = e.historyJobIter.GetLastJobs(num, e.cacheJobs)
+	for req.NumRows() < req.Capacity() && e.historyJobCount < e.jobNumber {
+		jobs, err := e.nextHistoryJobs(min(req.Capacity()-req.NumRows(), e.jobNumber-e.historyJobCount))
 		if err != nil {
 			return err
 		}
-		for _, job := range e.cacheJobs {
+		if len(jobs) == 0 {
+			break
+		}
+		for _, job := range jobs {
+			if e.skipJob(job) {
+				continue
+			}
 			e.appendJobToChunk(req, job, nil)
+			e.historyJobCount++
 		}
-		e.cursor += len(e.cacheJobs)
+		e.cursor += len(jobs)
 	}
 	return nil
 }
@@ -1250,6 +1334,7 @@ func newLockCtx(sctx sessionctx.Context, lockWaitTime int64, numKeys int) (*tikv
 			return
 		}
 		rec := deadlockhistory.ErrDeadlockToDeadlockRecord(deadlock)
+		resolveDeadlockKeyInfo(sctx, rec)
 		deadlockhistory.GlobalDeadlockHistory.Push(rec)
 	}
 	if lockCtx.ForUpdateTS > 0 && seVars.AssertionLevel != variable.AssertionLevelOff {
@@ -1258,6 +1343,33 @@ func newLockCtx(sctx sessionctx.Context, lockWaitTime int64, numKeys int) (*tikv
 	return lockCtx, nil
 }
 
+// resolveDeadlockKeyInfo decodes the keys in the wait chain to the table and index names when the deadlock occurs,
+// so that the record keeps making sense after the tables are changed or the history is loaded from the disk.
+func resolveDeadlockKeyInfo(sctx sessionctx.Context, rec *deadlockhistory.DeadlockRecord) {
+	dom := domain.GetDomain(sctx)
+	if dom == nil {
+		return
+	}
+	is := dom.InfoSchema()
+	for i := range rec.WaitChain {
+		item := &rec.WaitChain[i]
+		if len(item.Key) == 0 {
+			continue
+		}
+		decodedKey, err := keydecoder.DecodeKey(item.Key, is)
+		if err != nil {
+			logutil.BgLogger().Warn("decode key failed", zap.Error(err))
+			continue
+		}
+		decodedKeyJSON, err := json.Marshal(decodedKey)
+		if err != nil {
+			logutil.BgLogger().Warn("marshal decoded key info to JSON failed", zap.Error(err))
+			continue
+		}
+		item.KeyInfo = string(decodedKeyJSON)
+	}
+}
+
 // doLockKeys is the main entry for pessimistic lock keys
 // waitTime means the lock operation will wait in milliseconds if target key is already
 // locked by others. used for (select for update nowait) situation
@@ -2251,6 +2363,11 @@ func ResetContextOfStmt(ctx sessionctx.Context, s ast.StmtNode) (err error) {
 
 	sc.ForcePlanCache = fixcontrol.GetBoolWithDefault(vars.OptimizerFixControl, fixcontrol.Fix49736, false)
 	sc.TblInfo2UnionScan = make(map[*model.TableInfo]bool)
+	if vars.EnableResultCache {
+		if dom := domain.GetDomain(ctx); dom != nil {
+			sc.ResultCacheEpoch = dom.ResultCache().Epoch()
+		}
+	}
 	errCount, warnCount := vars.StmtCtx.NumErrorWarnings()
 	vars.SysErrorCount = errCount
 	vars.SysWarningCount = warnCount
@@ -2260,6 +2377,8 @@ func ResetContextOfStmt(ctx sessionctx.Context, s ast.StmtNode) (err error) {
 	vars.FoundInPlanCache = false
 	vars.PrevFoundInBinding = vars.FoundInBinding
 	vars.FoundInBinding = false
+	vars.PrevFoundInResultCache = vars.FoundInResultCache
+	vars.FoundInResultCache = false
 	vars.DurationWaitTS = 0
 	vars.CurrInsertBatchExtraCols = nil
 	vars.CurrInsertValues = chunk.Row{}
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/execdetails"
@@ -164,6 +165,11 @@ func (e *ExplainExec) generateExplainInfo(ctx context.Context) (rows [][]string,
 	if err = e.executeAnalyzeExec(ctx); err != nil {
 		return nil, err
 	}
+	if ddlPlan, ok := e.explain.TargetPlan.(*core.DDL); ok && !e.explain.Analyze {
+		if alterStmt, ok := ddlPlan.Statement.(*ast.AlterTableStmt); ok {
+			return e.explainAlterTable(ctx, alterStmt)
+		}
+	}
 	if err = e.explain.RenderResult(); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,498 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/ddl"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/table/tables"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/memory"
+)
+
+// The ways an ALTER TABLE spec is executed, from the cheapest to the most expensive.
+const (
+	// ddlExecutionInstant means only the table meta is changed.
+	ddlExecutionInstant = "instant"
+	// ddlExecutionMetadataOnly means the table meta goes through several schema states, the rows aren't rewritten,
+	// but they may be read to validate them, and the dropped data is cleaned up by GC in the background.
+	ddlExecutionMetadataOnly = "metadata-only"
+	// ddlExecutionReorg means the rows are backfilled or rewritten.
+	ddlExecutionReorg = "reorg"
+)
+
+// pseudoDDLColSize is the size of a column used when there are no stats of it, it's the same as the planner's.
+const pseudoDDLColSize = 8.0
+
+// alterTableSpecExplain is how an ALTER TABLE spec would be executed.
+type alterTableSpecExplain struct {
+	action model.ActionType
+	// name is the name of the spec which has no DDL action, e.g. it's ignored.
+	name      string
+	execution string
+	// estRows is the number of the rows to backfill, rewrite or validate.
+	estRows float64
+	// partitions are the partitions the spec reads or changes, the whole table is accessed if it's empty.
+	partitions []string
+	// tempSpace is the estimated size of the temporary files, it's only used by the ingest backfill.
+	tempSpace float64
+	// blockWrites means the writes to the table are blocked after the spec is executed.
+	blockWrites bool
+	info        []string
+}
+
+// alterTableExplainer explains the specs of an ALTER TABLE statement without executing them, so the operators
+// can know whether an ALTER TABLE is cheap or needs to reorganize the data before scheduling it.
+type alterTableExplainer struct {
+	sctx   sessionctx.Context
+	is     infoschema.InfoSchema
+	schema *model.DBInfo
+	tbl    table.Table
+	stats  *statistics.Table
+}
+
+// explainAlterTable returns the explain rows of the ALTER TABLE statement, one row for each spec.
+func (e *ExplainExec) explainAlterTable(ctx context.Context, s *ast.AlterTableStmt) ([][]string, error) {
+	format := strings.ToLower(e.explain.Format)
+	if format == types.ExplainFormatTraditional {
+		format = types.ExplainFormatROW
+	}
+	if format != types.ExplainFormatROW && format != types.ExplainFormatBrief {
+		return nil, errors.Errorf("explain format '%s' is not supported for ALTER TABLE", e.explain.Format)
+	}
+	is := e.Ctx().GetInfoSchema().(infoschema.InfoSchema)
+	schemaName := s.Table.Schema
+	if schemaName.L == "" {
+		schemaName = model.NewCIStr(e.Ctx().GetSessionVars().CurrentDB)
+	}
+	schema, ok := is.SchemaByName(schemaName)
+	if !ok {
+		return nil, infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schemaName)
+	}
+	tbl, err := is.TableByName(schemaName, s.Table.Name)
+	if err != nil {
+		return nil, err
+	}
+	x := &alterTableExplainer{sctx: e.Ctx(), is: is, schema: schema, tbl: tbl}
+	x.stats = x.tableStats(tbl.Meta(), tbl.Meta().ID)
+
+	rows := make([][]string, 0, len(s.Specs))
+	for _, spec := range s.Specs {
+		// The table options are executed one by one.
+		if spec.Tp == ast.AlterTableOption && len(spec.Options) > 1 {
+			for _, opt := range spec.Options {
+				optSpec := *spec
+				optSpec.Options = []*ast.TableOption{opt}
+				r, err := x.explainSpec(ctx, &optSpec)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, x.toRow(r))
+			}
+			continue
+		}
+		r, err := x.explainSpec(ctx, spec)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, x.toRow(r))
+	}
+	return rows, nil
+}
+
+func (x *alterTableExplainer) toRow(r *alterTableSpecExplain) []string {
+	accessObject := "table:" + x.tbl.Meta().Name.O
+	if len(r.partitions) > 0 {
+		accessObject += ", partition:" + strings.Join(r.partitions, ",")
+	}
+	info := make([]string, 0, len(r.info)+3)
+	info = append(info, "execution:"+r.execution)
+	if r.tempSpace > 0 {
+		info = append(info, "temp_space:"+memory.FormatBytes(int64(r.tempSpace)))
+	}
+	info = append(info, "block_writes:"+strconv.FormatBool(r.blockWrites))
+	info = append(info, r.info...)
+	id := r.action.String()
+	if r.action == model.ActionNone {
+		id = r.name
+	}
+	return []string{id, strconv.FormatFloat(r.estRows, 'f', 2, 64), "root", accessObject, strings.Join(info, ", ")}
+}
+
+func (x *alterTableExplainer) explainSpec(ctx context.Context, spec *ast.AlterTableSpec) (*alterTableSpecExplain, error) {
+	tblInfo := x.tbl.Meta()
+	r := &alterTableSpecExplain{execution: ddlExecutionInstant}
+	switch spec.Tp {
+	case ast.AlterTableAddColumns:
+		r.action = model.ActionAddColumn
+	case ast.AlterTableDropColumn:
+		r.action = model.ActionDropColumn
+		r.execution = ddlExecutionMetadataOnly
+	case ast.AlterTableAddConstraint:
+		x.explainAddConstraint(spec.Constraint, r)
+	case ast.AlterTableDropIndex, ast.AlterTableDropPrimaryKey:
+		r.action = model.ActionDropIndex
+		if spec.Tp == ast.AlterTableDropPrimaryKey {
+			r.action = model.ActionDropPrimaryKey
+		}
+		r.execution = ddlExecutionMetadataOnly
+	case ast.AlterTableDropForeignKey:
+		r.action = model.ActionDropForeignKey
+	case ast.AlterTableModifyColumn, ast.AlterTableChangeColumn:
+		if err := x.explainModifyColumn(ctx, spec, r); err != nil {
+			return nil, err
+		}
+	case ast.AlterTableRenameColumn:
+		r.action = model.ActionModifyColumn
+	case ast.AlterTableAlterColumn:
+		r.action = model.ActionSetDefaultValue
+	case ast.AlterTableRenameTable:
+		r.action = model.ActionRenameTable
+	case ast.AlterTableRenameIndex:
+		r.action = model.ActionRenameIndex
+	case ast.AlterTableIndexInvisible:
+		r.action = model.ActionAlterIndexVisibility
+	case ast.AlterTableAddPartitions, ast.AlterTableAddLastPartition:
+		r.action = model.ActionAddTablePartition
+		r.execution = ddlExecutionMetadataOnly
+	case ast.AlterTableDropPartition, ast.AlterTableDropFirstPartition:
+		r.action = model.ActionDropTablePartition
+		r.execution = ddlExecutionMetadataOnly
+		r.partitions = partitionNames(spec.PartitionNames)
+	case ast.AlterTableTruncatePartition:
+		r.action = model.ActionTruncateTablePartition
+		r.execution = ddlExecutionMetadataOnly
+		r.partitions = partitionNames(spec.PartitionNames)
+	case ast.AlterTableExchangePartition:
+		r.action = model.ActionExchangeTablePartition
+		r.execution = ddlExecutionMetadataOnly
+		r.partitions = partitionNames(spec.PartitionNames)
+		if spec.WithValidation && spec.NewTable != nil {
+			// The rows of the non-partitioned table are read to check they belong to the partition.
+			ntSchema := spec.NewTable.Schema
+			if ntSchema.L == "" {
+				ntSchema = x.schema.Name
+			}
+			if nt, err := x.is.TableByName(ntSchema, spec.NewTable.Name); err == nil {
+				r.estRows = float64(x.tableStats(nt.Meta(), nt.Meta().ID).RealtimeCount)
+				r.info = append(r.info, "validate:"+nt.Meta().Name.O)
+			}
+		}
+	case ast.AlterTableReorganizePartition, ast.AlterTableSplitPartition, ast.AlterTableMergePartitions:
+		r.action = model.ActionReorganizePartition
+		r.execution = ddlExecutionReorg
+		r.partitions = partitionNames(spec.PartitionNames)
+		r.estRows = x.partitionRows(spec.PartitionNames)
+		r.info = append(r.info, "backfill:txn")
+	case ast.AlterTableCoalescePartitions, ast.AlterTableReorganizeFirstPartition, ast.AlterTableReorganizeLastPartition:
+		r.action = model.ActionReorganizePartition
+		r.execution = ddlExecutionReorg
+		r.estRows = float64(x.stats.RealtimeCount)
+		r.info = append(r.info, "backfill:txn")
+	case ast.AlterTablePartition:
+		r.action = model.ActionAlterTablePartitioning
+		r.execution = ddlExecutionReorg
+		r.estRows = float64(x.stats.RealtimeCount)
+		r.info = append(r.info, "backfill:txn")
+	case ast.AlterTableRemovePartitioning:
+		r.action = model.ActionRemovePartitioning
+		r.execution = ddlExecutionReorg
+		r.estRows = float64(x.stats.RealtimeCount)
+		r.info = append(r.info, "backfill:txn")
+	case ast.AlterTableAlterCheck:
+		r.action = model.ActionAlterCheckConstraint
+		if spec.Constraint.Enforced && variable.EnableCheckConstraint.Load() {
+			r.execution = ddlExecutionMetadataOnly
+			r.estRows = float64(x.stats.RealtimeCount)
+			r.info = append(r.info, "validate:true")
+		}
+	case ast.AlterTableDropCheck:
+		r.action = model.ActionDropCheckConstraint
+	case ast.AlterTableOption:
+		r.action = tableOptionAction(spec.Options)
+		if r.action == model.ActionNone {
+			r.name = alterTableSpecName(spec)
+			r.info = append(r.info, "note:ignored")
+		}
+		if r.action == model.ActionAlterTablePlacement {
+			r.info = append(r.info, "note:the data is moved by PD in the background")
+		}
+	case ast.AlterTablePartitionOptions:
+		r.action = model.ActionAlterTablePartitionPlacement
+		r.partitions = partitionNames(spec.PartitionNames)
+		r.info = append(r.info, "note:the data is moved by PD in the background")
+	case ast.AlterTableSetTiFlashReplica:
+		r.action = model.ActionSetTiFlashReplica
+		r.info = append(r.info, "note:the replicas are built by TiFlash in the background")
+	case ast.AlterTableAttributes:
+		r.action = model.ActionAlterTableAttributes
+	case ast.AlterTablePartitionAttributes:
+		r.action = model.ActionAlterTablePartitionAttributes
+		r.partitions = partitionNames(spec.PartitionNames)
+	case ast.AlterTableCache:
+		r.action = model.ActionAlterCacheTable
+		r.blockWrites = true
+		r.info = append(r.info, "note:the writes wait for the cache lease to expire")
+	case ast.AlterTableNoCache:
+		r.action = model.ActionAlterNoCacheTable
+	case ast.AlterTableWriteable:
+		r.action = model.ActionLockTable
+		r.blockWrites = !spec.Writeable
+		if spec.Writeable {
+			r.action = model.ActionUnlockTable
+		}
+	case ast.AlterTableRemoveTTL:
+		r.action = model.ActionAlterTTLRemove
+	case ast.AlterTableAddStatistics, ast.AlterTableDropStatistics:
+		r.name = alterTableSpecName(spec)
+	case ast.AlterTableLock, ast.AlterTableAlgorithm, ast.AlterTableForce, ast.AlterTableOrderByColumns,
+		ast.AlterTableWithValidation, ast.AlterTableWithoutValidation, ast.AlterTableEnableKeys,
+		ast.AlterTableDisableKeys:
+		r.name = alterTableSpecName(spec)
+		r.info = append(r.info, "note:ignored")
+	default:
+		return nil, errors.Errorf("explain is not supported for the ALTER TABLE spec of the type %d", spec.Tp)
+	}
+	if r.estRows > 0 && x.stats.Pseudo {
+		r.info = append(r.info, "stats:pseudo")
+	}
+	if tblInfo.TempTableType != model.TempTableNone && r.execution == ddlExecutionReorg {
+		r.info = append(r.info, "note:the temporary table has no data to reorganize")
+		r.estRows = 0
+	}
+	return r, nil
+}
+
+func (x *alterTableExplainer) explainAddConstraint(constr *ast.Constraint, r *alterTableSpecExplain) {
+	switch constr.Tp {
+	case ast.ConstraintKey, ast.ConstraintIndex, ast.ConstraintUniq, ast.ConstraintUniqIndex, ast.ConstraintUniqKey,
+		ast.ConstraintPrimaryKey, ast.ConstraintFulltext, ast.ConstraintVector:
+		r.action = model.ActionAddIndex
+		unique := false
+		switch constr.Tp {
+		case ast.ConstraintPrimaryKey:
+			r.action = model.ActionAddPrimaryKey
+			unique = true
+		case ast.ConstraintUniq, ast.ConstraintUniqIndex, ast.ConstraintUniqKey:
+			unique = true
+		}
+		r.execution = ddlExecutionReorg
+		r.estRows = float64(x.stats.RealtimeCount)
+		if !variable.EnableFastReorg.Load() {
+			r.info = append(r.info, "backfill:txn")
+			break
+		}
+		r.info = append(r.info, "backfill:ingest")
+		r.tempSpace = r.estRows * x.indexKVSize(constr.Keys, unique)
+		if variable.CloudStorageURI.Load() != "" {
+			r.info = append(r.info, "sort:global")
+		}
+	case ast.ConstraintForeignKey:
+		r.action = model.ActionAddForeignKey
+		if x.sctx.GetSessionVars().ForeignKeyChecks {
+			// The existing rows are checked to reference the rows in the parent table.
+			r.execution = ddlExecutionMetadataOnly
+			r.estRows = float64(x.stats.RealtimeCount)
+			r.info = append(r.info, "validate:true")
+		}
+	case ast.ConstraintCheck:
+		r.action = model.ActionAddCheckConstraint
+		if constr.Enforced && variable.EnableCheckConstraint.Load() {
+			r.execution = ddlExecutionMetadataOnly
+			r.estRows = float64(x.stats.RealtimeCount)
+			r.info = append(r.info, "validate:true")
+		}
+	default:
+		r.name = "add constraint"
+		r.info = append(r.info, "note:ignored")
+	}
+}
+
+func (x *alterTableExplainer) explainModifyColumn(ctx context.Context, spec *ast.AlterTableSpec, r *alterTableSpecExplain) error {
+	r.action = model.ActionModifyColumn
+	originalColName := spec.NewColumns[0].Name.Name
+	if spec.Tp == ast.AlterTableChangeColumn {
+		originalColName = spec.OldColumnName.Name
+	}
+	ident := ast.Ident{Schema: x.schema.Name, Name: x.tbl.Meta().Name}
+	job, err := ddl.GetModifiableColumnJob(ctx, x.sctx, x.is, ident, originalColName, x.schema, x.tbl, spec)
+	if err != nil {
+		return err
+	}
+	if needChangeColData, ok := job.CtxVars[0].(bool); !ok || !needChangeColData {
+		return nil
+	}
+	r.execution = ddlExecutionReorg
+	r.estRows = float64(x.stats.RealtimeCount)
+	r.info = append(r.info, "backfill:txn")
+	indexes := 0
+	for _, idx := range x.tbl.Meta().Indices {
+		for _, col := range idx.Columns {
+			if col.Name.L == originalColName.L {
+				indexes++
+				break
+			}
+		}
+	}
+	if indexes > 0 {
+		r.info = append(r.info, fmt.Sprintf("rebuild_indexes:%d", indexes))
+	}
+	return nil
+}
+
+// indexKVSize estimates the size of an index KV pair of the table in the same way as the planner.
+func (x *alterTableExplainer) indexKVSize(keys []*ast.IndexPartSpecification, unique bool) float64 {
+	tblInfo := x.tbl.Meta()
+	// tablePrefix(1) + tableID(8) + indexPrefix(2) + indexID(8)
+	size := 19.0
+	for _, key := range keys {
+		if key.Column == nil {
+			size += pseudoDDLColSize
+			continue
+		}
+		colSize := x.columnSize(model.FindColumnInfo(tblInfo.Columns, key.Column.Name.L))
+		if key.Length > 0 {
+			colSize = min(colSize, float64(key.Length))
+		}
+		size += colSize
+	}
+	switch {
+	case tblInfo.IsCommonHandle:
+		for _, col := range tables.FindPrimaryIndex(tblInfo).Columns {
+			size += x.columnSize(tblInfo.Columns[col.Offset])
+		}
+	default:
+		// The int handle.
+		size += 8
+	}
+	if !unique {
+		// add the len("_")
+		size++
+	}
+	return size
+}
+
+func (x *alterTableExplainer) columnSize(col *model.ColumnInfo) float64 {
+	if col == nil || x.stats.Pseudo || x.stats.RealtimeCount == 0 {
+		return pseudoDDLColSize
+	}
+	colStats, ok := x.stats.Columns[col.ID]
+	if !ok || colStats.TotColSize == 0 {
+		return pseudoDDLColSize
+	}
+	return cardinality.AvgColSize(colStats, x.stats.RealtimeCount, true)
+}
+
+// partitionRows returns the number of the rows in the partitions of the table.
+func (x *alterTableExplainer) partitionRows(names []model.CIStr) float64 {
+	pi := x.tbl.Meta().GetPartitionInfo()
+	if pi == nil {
+		return 0
+	}
+	rows := 0.0
+	for _, name := range names {
+		pid, err := tables.FindPartitionByName(x.tbl.Meta(), name.L)
+		if err != nil {
+			continue
+		}
+		rows += float64(x.tableStats(x.tbl.Meta(), pid).RealtimeCount)
+	}
+	return rows
+}
+
+// tableStats returns the stats of the table or the partition, the pseudo stats are returned if there are none.
+func (x *alterTableExplainer) tableStats(tblInfo *model.TableInfo, pid int64) *statistics.Table {
+	statsHandle := domain.GetDomain(x.sctx).StatsHandle()
+	if statsHandle == nil {
+		return statistics.PseudoTable(tblInfo, false)
+	}
+	if pid != tblInfo.ID {
+		return statsHandle.GetPartitionStats(tblInfo, pid)
+	}
+	return statsHandle.GetTableStats(tblInfo)
+}
+
+func partitionNames(names []model.CIStr) []string {
+	res := make([]string, 0, len(names))
+	for _, name := range names {
+		res = append(res, name.O)
+	}
+	return res
+}
+
+// tableOptionAction returns the DDL action of the table option.
+func tableOptionAction(opts []*ast.TableOption) model.ActionType {
+	if len(opts) == 0 {
+		return model.ActionNone
+	}
+	switch opts[0].Tp {
+	case ast.TableOptionShardRowID:
+		return model.ActionShardRowID
+	case ast.TableOptionAutoIncrement, ast.TableOptionAutoRandomBase:
+		return model.ActionRebaseAutoID
+	case ast.TableOptionAutoIdCache:
+		return model.ActionModifyTableAutoIdCache
+	case ast.TableOptionComment:
+		return model.ActionModifyTableComment
+	case ast.TableOptionCharset, ast.TableOptionCollate:
+		return model.ActionModifyTableCharsetAndCollate
+	case ast.TableOptionPlacementPolicy:
+		return model.ActionAlterTablePlacement
+	case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval:
+		return model.ActionAlterTTLInfo
+	}
+	return model.ActionNone
+}
+
+// alterTableSpecName returns the name of the spec which doesn't have a DDL action.
+func alterTableSpecName(spec *ast.AlterTableSpec) string {
+	switch spec.Tp {
+	case ast.AlterTableLock:
+		return "lock"
+	case ast.AlterTableAlgorithm:
+		return "algorithm"
+	case ast.AlterTableForce:
+		return "force"
+	case ast.AlterTableOrderByColumns:
+		return "order by"
+	case ast.AlterTableWithValidation:
+		return "with validation"
+	case ast.AlterTableWithoutValidation:
+		return "without validation"
+	case ast.AlterTableEnableKeys:
+		return "enable keys"
+	case ast.AlterTableDisableKeys:
+		return "disable keys"
+	case ast.AlterTableAddStatistics:
+		return "add stats_extended"
+	case ast.AlterTableDropStatistics:
+		return "drop stats_extended"
+	}
+	return "table option"
+}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/infoschema"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/types"
@@ -485,3 +486,57 @@ func TestExplainImportFromSelect(t *testing.T) {
 	require.Contains(t, rs[1][0], "TableReader")
 	require.Contains(t, rs[2][0], "TableFullScan")
 }
+
+func TestExplainAlterTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b varchar(10), c int, key idx_c(c))")
+	tk.MustExec("insert into t values (1, 'a', 1), (2, 'b', 2), (3, 'c', 3), (4, 'd', 4)")
+	tk.MustExec("analyze table t")
+	tk.MustExec("create table tp (a int, b int) partition by range (a) (partition p0 values less than (10), partition p1 values less than (20))")
+
+	tk.MustExec("set @@global.tidb_ddl_enable_fast_reorg = on")
+	defer tk.MustExec("set @@global.tidb_ddl_enable_fast_reorg = default")
+	rows := tk.MustQuery("explain alter table t add index idx_b(b)").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "add index", rows[0][0])
+	require.Equal(t, "4.00", rows[0][1])
+	require.Equal(t, "table:t", rows[0][3])
+	require.Regexp(t, "^execution:reorg, temp_space:[0-9.]+ Bytes, block_writes:false, backfill:ingest$", rows[0][4])
+	tk.MustExec("set @@global.tidb_ddl_enable_fast_reorg = off")
+	tk.MustQuery("explain alter table t add unique index idx_b(b)").Check(testkit.Rows(
+		"add index 4.00 root table:t execution:reorg, block_writes:false, backfill:txn"))
+
+	// The column type change which doesn't need to rewrite the data is instant.
+	tk.MustQuery("explain format = 'brief' alter table t modify column b varchar(20), modify column c bigint, add column d int").Check(testkit.Rows(
+		"modify column 0.00 root table:t execution:instant, block_writes:false",
+		"modify column 0.00 root table:t execution:instant, block_writes:false",
+		"add column 0.00 root table:t execution:instant, block_writes:false"))
+	tk.MustQuery("explain alter table t modify column c varchar(10)").Check(testkit.Rows(
+		"modify column 4.00 root table:t execution:reorg, block_writes:false, backfill:txn, rebuild_indexes:1"))
+	tk.MustQuery("explain alter table t drop column a, rename index idx_c to idx_c1, comment = 'x', lock = none").Check(testkit.Rows(
+		"drop column 0.00 root table:t execution:metadata-only, block_writes:false",
+		"rename index 0.00 root table:t execution:instant, block_writes:false",
+		"modify table comment 0.00 root table:t execution:instant, block_writes:false",
+		"lock 0.00 root table:t execution:instant, block_writes:false, note:ignored"))
+	tk.MustQuery("explain alter table t read only").Check(testkit.Rows(
+		"lock table 0.00 root table:t execution:instant, block_writes:true"))
+
+	tk.MustQuery("explain alter table tp truncate partition p0").Check(testkit.Rows(
+		"truncate partition 0.00 root table:tp, partition:p0 execution:metadata-only, block_writes:false"))
+	rows = tk.MustQuery("explain alter table tp reorganize partition p0, p1 into (partition p2 values less than (20))").Rows()
+	require.Equal(t, "alter table reorganize partition", rows[0][0])
+	// The table isn't analyzed, so the rows are estimated by the pseudo stats of the partitions.
+	require.Equal(t, "20000.00", rows[0][1])
+	require.Equal(t, "table:tp, partition:p0,p1", rows[0][3])
+	require.Equal(t, "execution:reorg, block_writes:false, backfill:txn, stats:pseudo", rows[0][4])
+
+	// Nothing is executed.
+	tk.MustQuery("show create table t").CheckContain("KEY `idx_c` (`c`)")
+	tk.MustQuery("select count(*) from information_schema.columns where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("3"))
+	err := tk.QueryToErr("explain format = 'dot' alter table t add index idx_b(b)")
+	require.EqualError(t, err, "explain format 'dot' is not supported for ALTER TABLE")
+	err = tk.QueryToErr("explain alter table t modify column x int")
+	require.True(t, infoschema.ErrColumnNotExists.Equal(err), err)
+}
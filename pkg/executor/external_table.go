@@ -0,0 +1,243 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/config"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/executor/importer"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/stringutil"
+)
+
+// externalTableSource is used in the errors about the location of external tables.
+const externalTableSource = "external table location"
+
+// externalTableRetriever reads the rows of an external table from the data files in the
+// external storage. The files are read one by one, and the values are converted to the
+// types of the columns.
+type externalTableRetriever struct {
+	dummyCloser
+	table *model.TableInfo
+
+	initialized bool
+	store       storage.ExternalStorage
+	files       []*mydump.SourceFileMeta
+	fileIdx     int
+	parser      mydump.Parser
+	// colOffsets maps the offset of a value in the rows read by the parser to the offset
+	// of the column, -1 means the value is ignored.
+	colOffsets []int
+}
+
+func (e *externalTableRetriever) retrieve(ctx context.Context, sctx sessionctx.Context) ([][]types.Datum, error) {
+	if !e.initialized {
+		e.initialized = true
+		if err := e.initialize(ctx); err != nil {
+			return nil, err
+		}
+	}
+	batchSize := sctx.GetSessionVars().MaxChunkSize
+	rows := make([][]types.Datum, 0, batchSize)
+	for len(rows) < batchSize {
+		if e.parser == nil {
+			if e.fileIdx >= len(e.files) {
+				break
+			}
+			if err := e.openFile(ctx); err != nil {
+				return nil, err
+			}
+		}
+		err := e.parser.ReadRow()
+		if errors.Cause(err) == io.EOF {
+			if err = e.closeFile(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, exeerrors.ErrLoadDataCantRead.GenWithStackByArgs(err.Error(), "Please check the format of the file "+e.files[e.fileIdx].Path)
+		}
+		row, err := e.convertRow(sctx, e.parser.LastRow().Row)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// initialize opens the external storage and lists the data files matching the location.
+func (e *externalTableRetriever) initialize(ctx context.Context) error {
+	info := e.table.ExternalInfo
+	u, err := storage.ParseRawURL(info.Location)
+	if err != nil {
+		return exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs(externalTableSource, err.Error())
+	}
+	// see importer.LoadDataController.InitDataFiles, the local directory is walked from the
+	// parent directory, while the cloud storage is walked from the root with a common prefix.
+	var pattern, commonPrefix string
+	if storage.IsLocal(u) {
+		pattern = filepath.Base(u.Path)
+		u.Path = filepath.Dir(u.Path)
+	} else {
+		pattern = strings.Trim(u.Path, "/")
+		u.Path = ""
+		if idx := strings.IndexByte(pattern, '*'); idx >= 0 {
+			commonPrefix = pattern[:idx]
+		} else {
+			commonPrefix = pattern
+		}
+	}
+	pattern = stringutil.EscapeGlobExceptAsterisk(pattern)
+	if _, err = filepath.Match(pattern, ""); err != nil {
+		return exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs(externalTableSource, "Glob pattern error: "+err.Error())
+	}
+
+	b, err := storage.ParseBackendFromURL(u, nil)
+	if err != nil {
+		return exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs(externalTableSource, importer.GetMsgFromBRError(err))
+	}
+	e.store, err = storage.NewWithDefaultOpt(ctx, b)
+	if err != nil {
+		return exeerrors.ErrLoadDataCantAccess.GenWithStackByArgs(externalTableSource, importer.GetMsgFromBRError(err))
+	}
+
+	sourceType := mydump.SourceTypeCSV
+	if info.Format == model.ExternalFormatParquet {
+		sourceType = mydump.SourceTypeParquet
+	}
+	err = e.store.WalkDir(ctx, &storage.WalkOption{ObjPrefix: commonPrefix, SkipSubDir: true},
+		func(path string, size int64) error {
+			// the pattern is checked above.
+			//nolint: errcheck
+			if match, _ := filepath.Match(pattern, path); !match {
+				return nil
+			}
+			e.files = append(e.files, &mydump.SourceFileMeta{
+				Path:        path,
+				Type:        sourceType,
+				Compression: mydump.ParseCompressionOnFileExtension(path),
+				FileSize:    size,
+			})
+			return nil
+		})
+	if err != nil {
+		return exeerrors.ErrLoadDataCantRead.GenWithStackByArgs(importer.GetMsgFromBRError(err), "Please check the location of the external table is correct")
+	}
+	slices.SortFunc(e.files, func(a, b *mydump.SourceFileMeta) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return nil
+}
+
+// openFile creates the parser of the current data file.
+func (e *externalTableRetriever) openFile(ctx context.Context) error {
+	file := e.files[e.fileIdx]
+	reader, err := mydump.OpenReader(ctx, file, e.store, storage.DecompressConfig{})
+	if err != nil {
+		return exeerrors.ErrLoadDataCantRead.GenWithStackByArgs(importer.GetMsgFromBRError(err), "Please check the file "+file.Path+" is readable")
+	}
+	var parser mydump.Parser
+	switch file.Type {
+	case mydump.SourceTypeParquet:
+		parser, err = mydump.NewParquetParser(ctx, e.store, reader, file.Path)
+	default:
+		csvConfig := &config.CSVConfig{
+			Separator: ",",
+			Delimiter: `"`,
+			EscapedBy: `\`,
+			Null:      []string{`\N`},
+		}
+		parser, err = mydump.NewCSVParser(ctx, csvConfig, reader, importer.LoadDataReadBlockSize, nil, false, nil)
+	}
+	if err != nil {
+		//nolint: errcheck
+		reader.Close()
+		return exeerrors.ErrLoadDataWrongFormatConfig.GenWithStack(err.Error())
+	}
+	e.parser = parser
+
+	// the values of CSV files are matched to the columns by position, and the values of
+	// parquet files are matched by name.
+	e.colOffsets = e.colOffsets[:0]
+	if names := parser.Columns(); len(names) > 0 {
+		for _, name := range names {
+			offset := -1
+			if col := model.FindColumnInfo(e.table.Columns, name); col != nil {
+				offset = col.Offset
+			}
+			e.colOffsets = append(e.colOffsets, offset)
+		}
+	} else {
+		for i := range e.table.Columns {
+			e.colOffsets = append(e.colOffsets, i)
+		}
+	}
+	return nil
+}
+
+func (e *externalTableRetriever) closeFile() error {
+	err := e.parser.Close()
+	e.parser = nil
+	e.fileIdx++
+	return err
+}
+
+// convertRow converts the values read from the data file to the types of the columns. The
+// columns without values are filled with NULL, and the extra values are ignored.
+func (e *externalTableRetriever) convertRow(sctx sessionctx.Context, values []types.Datum) ([]types.Datum, error) {
+	row := make([]types.Datum, len(e.table.Columns))
+	for i, val := range values {
+		if i >= len(e.colOffsets) {
+			break
+		}
+		offset := e.colOffsets[i]
+		if offset < 0 || val.IsNull() {
+			continue
+		}
+		casted, err := table.CastValue(sctx, val, e.table.Columns[offset], false, false)
+		if err != nil {
+			return nil, err
+		}
+		row[offset] = casted
+	}
+	return row, nil
+}
+
+func (e *externalTableRetriever) close() error {
+	var err error
+	if e.parser != nil {
+		err = e.parser.Close()
+		e.parser = nil
+	}
+	if e.store != nil {
+		e.store.Close()
+		e.store = nil
+	}
+	return err
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.csv"), []byte("1,a\n2,b\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.csv"), []byte("3,\"c,d\"\n4,\\N,extra\n5\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.txt"), []byte("6,e\n"), 0o644))
+	location := filepath.Join(dir, "*.csv")
+
+	tk.MustExec(fmt.Sprintf("create external table t (a int, b varchar(10)) location = '%s' format = 'csv'", location))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 a", "2 b", "3 c,d", "4 <nil>", "5 <nil>"))
+	tk.MustQuery("select b from t where a > 2 and b is not null").Check(testkit.Rows("c,d"))
+	tk.MustQuery("select count(*), sum(a) from t").Check(testkit.Rows("5 15"))
+	tk.MustExec("create table n (a int, name varchar(10))")
+	tk.MustExec("insert into n values (1, 'one'), (3, 'three')")
+	tk.MustQuery("select n.name, t.b from n join t on n.a = t.a order by n.a").Check(testkit.Rows("one a", "three c,d"))
+
+	tk.MustQuery("show create table t").Check(testkit.Rows(fmt.Sprintf("t CREATE EXTERNAL TABLE `t` (\n"+
+		"  `a` int(11) DEFAULT NULL,\n"+
+		"  `b` varchar(10) DEFAULT NULL\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin LOCATION='%s' FORMAT='csv'", location)))
+	tk.MustQuery("select table_type from information_schema.tables where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("EXTERNAL TABLE"))
+	tk.MustQuery("show full tables like 't'").Check(testkit.Rows("t EXTERNAL TABLE"))
+
+	// external tables are read-only.
+	tk.MustGetErrCode("insert into t values (1, 'a')", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("update t set a = 1", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("delete from t", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("alter table t add column c int", errno.ErrOptOnExternalTable)
+	tk.MustGetErrCode("create index idx on t (a)", errno.ErrOptOnExternalTable)
+	tk.MustGetErrCode("truncate table t", errno.ErrOptOnExternalTable)
+	tk.MustExec("drop table t")
+
+	// invalid definitions.
+	tk.MustGetErrCode("create external table t (a int)", errno.ErrInvalidExternalTableDef)
+	tk.MustGetErrCode(fmt.Sprintf("create external table t (a int) location = '%s' format = 'json'", location), errno.ErrInvalidExternalTableDef)
+	tk.MustGetErrCode(fmt.Sprintf("create external table t (a int primary key) location = '%s' format = 'csv'", location), errno.ErrOptOnExternalTable)
+	tk.MustGetErrCode(fmt.Sprintf("create external table t (a int, index (a)) location = '%s' format = 'csv'", location), errno.ErrOptOnExternalTable)
+	tk.MustGetErrCode(fmt.Sprintf("create external table t (a int auto_increment) location = '%s' format = 'csv'", location), errno.ErrOptOnExternalTable)
+	tk.MustGetErrCode(fmt.Sprintf("create external table t (a int) location = '%s'", location), errno.ErrInvalidExternalTableDef)
+	tk.MustGetErrCode(fmt.Sprintf("create table t (a int) location = '%s' format = 'csv'", location), errno.ErrInvalidExternalTableDef)
+
+	// the files are listed when the table is read.
+	tk.MustExec(fmt.Sprintf("create external table t (a int) location = '%s' format = 'csv'", filepath.Join(dir, "*.txt")))
+	tk.MustQuery("select * from t").Check(testkit.Rows("6"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "d.txt"), []byte("7\n"), 0o644))
+	tk.MustQuery("select * from t").Check(testkit.Rows("6", "7"))
+}
@@ -0,0 +1,172 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"strconv"
+	"strings"
+
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// federatedDefaultPort is used if the port of the server is not specified.
+const federatedDefaultPort = 3306
+
+// federatedTableRetriever reads the rows of a federated table from the remote MySQL server.
+// Only the used columns are selected, and the predicates extracted by the planner are sent
+// to the remote server in the WHERE clause.
+type federatedTableRetriever struct {
+	dummyCloser
+	table     *model.TableInfo
+	columns   []*model.ColumnInfo
+	extractor *plannercore.FederatedTableExtractor
+
+	retrieved bool
+	db        *sql.DB
+	rows      *sql.Rows
+}
+
+func (e *federatedTableRetriever) retrieve(ctx context.Context, sctx sessionctx.Context) ([][]types.Datum, error) {
+	if e.retrieved {
+		return nil, nil
+	}
+	if e.rows == nil {
+		if err := e.query(ctx, sctx); err != nil {
+			return nil, err
+		}
+	}
+	batchSize := sctx.GetSessionVars().MaxChunkSize
+	values := make([]sql.NullString, len(e.columns))
+	dest := make([]any, len(e.columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	rows := make([][]types.Datum, 0, batchSize)
+	for len(rows) < batchSize {
+		if !e.rows.Next() {
+			e.retrieved = true
+			if err := e.rows.Err(); err != nil {
+				return nil, exeerrors.ErrQueryOnForeignDataSource.GenWithStackByArgs(err.Error())
+			}
+			break
+		}
+		if err := e.rows.Scan(dest...); err != nil {
+			return nil, exeerrors.ErrQueryOnForeignDataSource.GenWithStackByArgs(err.Error())
+		}
+		row := make([]types.Datum, len(e.columns))
+		for i, col := range e.columns {
+			if !values[i].Valid {
+				continue
+			}
+			// the values are converted with the same rules of inserting them into the table.
+			casted, err := table.CastValue(sctx, types.NewStringDatum(values[i].String), col, false, false)
+			if err != nil {
+				return nil, err
+			}
+			row[i] = casted
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// query connects to the remote server and sends the query.
+func (e *federatedTableRetriever) query(ctx context.Context, sctx sessionctx.Context) error {
+	info := e.table.FederatedInfo
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	chunkRows, _, err := exec.ExecRestrictedSQL(ctx, nil, `SELECT Host, Db, Username, Password, Port, Socket FROM mysql.servers WHERE Server_name = %?`, info.Server)
+	if err != nil {
+		return err
+	}
+	if len(chunkRows) == 0 {
+		return exeerrors.ErrForeignServerDoesntExist.GenWithStackByArgs(info.Server)
+	}
+	server := chunkRows[0]
+	cfg := gomysql.NewConfig()
+	cfg.User = server.GetString(2)
+	cfg.Passwd = server.GetString(3)
+	cfg.DBName = server.GetString(1)
+	if socket := server.GetString(5); socket != "" {
+		cfg.Net = "unix"
+		cfg.Addr = socket
+	} else {
+		port := server.GetInt64(4)
+		if port == 0 {
+			port = federatedDefaultPort
+		}
+		cfg.Net = "tcp"
+		cfg.Addr = net.JoinHostPort(server.GetString(0), strconv.FormatInt(port, 10))
+	}
+	connector, err := gomysql.NewConnector(cfg)
+	if err != nil {
+		return exeerrors.ErrConnectToForeignDataSource.GenWithStackByArgs(err.Error())
+	}
+	e.db = sql.OpenDB(connector)
+	if err = e.db.PingContext(ctx); err != nil {
+		return exeerrors.ErrConnectToForeignDataSource.GenWithStackByArgs(err.Error())
+	}
+
+	e.rows, err = e.db.QueryContext(ctx, e.buildQuery())
+	if err != nil {
+		return exeerrors.ErrQueryOnForeignDataSource.GenWithStackByArgs(err.Error())
+	}
+	return nil
+}
+
+// buildQuery builds the query sent to the remote server.
+func (e *federatedTableRetriever) buildQuery() string {
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	for i, col := range e.columns {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sqlescape.MustFormatSQL(&sb, "%n", col.Name.O)
+	}
+	sqlescape.MustFormatSQL(&sb, " FROM %n", e.table.FederatedInfo.Table)
+	if e.extractor != nil && len(e.extractor.Conditions) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(e.extractor.Conditions, " AND "))
+	}
+	return sb.String()
+}
+
+func (e *federatedTableRetriever) close() error {
+	var err error
+	if e.rows != nil {
+		err = e.rows.Close()
+		e.rows = nil
+	}
+	if e.db != nil {
+		if closeErr := e.db.Close(); err == nil {
+			err = closeErr
+		}
+		e.db = nil
+	}
+	return err
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+// countIndexKeys counts the committed entries of the index.
+func countIndexKeys(t *testing.T, store kv.Storage, tk *testkit.TestKit, tblName, idxName string) int {
+	tbl, err := domain.GetDomain(tk.Session()).InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr(tblName))
+	require.NoError(t, err)
+	idx := tbl.Meta().FindIndexByName(idxName)
+	require.NotNil(t, idx)
+	prefix := tablecodec.EncodeTableIndexPrefix(tbl.Meta().ID, idx.ID)
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, txn.Rollback())
+	}()
+	iter, err := txn.Iter(prefix, prefix.PrefixNext())
+	require.NoError(t, err)
+	defer iter.Close()
+	cnt := 0
+	for iter.Valid() {
+		cnt++
+		require.NoError(t, iter.Next())
+	}
+	return cnt
+}
+
+func TestFullTextIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create table t (id int primary key, title varchar(100), body text, fulltext key ft (title, body))")
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	tk.MustExec(`insert into t values
+		(1, 'TiDB introduction', 'TiDB is a distributed SQL database'),
+		(2, 'MySQL tutorial', 'MySQL is a popular database, TiDB is compatible with MySQL'),
+		(3, 'Cooking', 'How to cook rice'),
+		(4, NULL, NULL)`)
+	// the distinct tokens of every row are indexed.
+	require.Equal(t, 21, countIndexKeys(t, store, tk, "t", "ft"))
+
+	// natural language mode
+	tk.MustQuery("select id from t where match (title, body) against ('database') order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id, match (body, title) against ('TiDB MySQL') from t order by id").Check(
+		testkit.Rows("1 2", "2 4", "3 0", "4 0"))
+	tk.MustQuery("select id from t where match (title, body) against ('tidb mysql') order by match (title, body) against ('tidb mysql') desc").Check(
+		testkit.Rows("2", "1"))
+	tk.MustQuery("select id from t where match (title, body) against ('oracle')").Check(testkit.Rows())
+
+	// boolean mode
+	tk.MustQuery("select id from t where match (title, body) against ('+tidb -mysql' in boolean mode)").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t where match (title, body) against ('+databas* +popular' in boolean mode)").Check(testkit.Rows("2"))
+	tk.MustQuery(`select id from t where match (title, body) against ('"distributed sql"' in boolean mode)`).Check(testkit.Rows("1"))
+	tk.MustQuery(`select id from t where match (title, body) against ('"sql distributed"' in boolean mode)`).Check(testkit.Rows())
+	tk.MustQuery("select id from t where match (title, body) against (? in boolean mode)", "rice cook*").Check(testkit.Rows("3"))
+
+	// the index is maintained on writes.
+	tk.MustExec("update t set body = 'How to cook noodles' where id = 3")
+	tk.MustQuery("select id from t where match (title, body) against ('rice')").Check(testkit.Rows())
+	tk.MustQuery("select id from t where match (title, body) against ('noodles')").Check(testkit.Rows("3"))
+	require.Equal(t, 21, countIndexKeys(t, store, tk, "t", "ft"))
+	tk.MustExec("delete from t where id in (1, 3)")
+	require.Equal(t, 9, countIndexKeys(t, store, tk, "t", "ft"))
+	tk.MustExec("admin check table t")
+
+	// the ngram parser
+	tk.MustExec("create table t2 (id int, content text)")
+	tk.MustExec("insert into t2 values (1, '分布式数据库'), (2, '数据分析'), (3, 'abc')")
+	tk.MustExec("alter table t2 add fulltext index ft_ngram (content) with parser ngram")
+	require.Equal(t, 10, countIndexKeys(t, store, tk, "t2", "ft_ngram"))
+	tk.MustQuery("select id from t2 where match (content) against ('数据' in boolean mode) order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t2 where match (content) against ('数据库' in boolean mode)").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t2 where match (content) against ('bc')").Check(testkit.Rows("3"))
+	tk.MustExec("create fulltext index ft_ws on t2 (content)")
+	tk.MustQuery("show create table t2").Check(testkit.Rows("t2 CREATE TABLE `t2` (\n" +
+		"  `id` int(11) DEFAULT NULL,\n" +
+		"  `content` text DEFAULT NULL,\n" +
+		"  FULLTEXT KEY `ft_ngram` (`content`) WITH PARSER `ngram`,\n" +
+		"  FULLTEXT KEY `ft_ws` (`content`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("show index from t2").CheckAt([]int{2, 10}, testkit.Rows("ft_ngram FULLTEXT", "ft_ws FULLTEXT"))
+
+	// invalid definitions and usages.
+	tk.MustGetErrCode("alter table t add fulltext key (id)", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("alter table t add fulltext key (body(10))", errno.ErrWrongSubKey)
+	tk.MustGetErrCode("alter table t add fulltext key (body) with parser mecab", errno.ErrPluginIsNotLoaded)
+	tk.MustGetErrCode("select * from t where match (title) against ('tidb')", errno.ErrFtMatchingKeyNotFound)
+	tk.MustGetErrCode("select * from t where match (title, body) against (title)", errno.ErrWrongArguments)
+	tk.MustGetErrCode("select * from t where match (title, body) against ('tidb' with query expansion)", errno.ErrNotSupportedYet)
+}
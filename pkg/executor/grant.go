@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/domain"
@@ -25,15 +26,18 @@ import (
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/plugin"
 	"github.com/pingcap/tidb/pkg/privilege"
 	"github.com/pingcap/tidb/pkg/privilege/privileges"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/audit"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/logutil"
@@ -246,7 +250,82 @@ func (e *GrantExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 		return err
 	}
 	isCommit = true
-	return domain.GetDomain(e.Ctx()).NotifyUpdatePrivilege()
+	auditPrivilegeChange(e.Ctx(), plugin.Grant, e.Privs, e.Level, e.Users, e.WithGrant)
+	return domain.GetDomain(e.Ctx()).NotifyUpdatePrivilegeForUsers(userSpecNames(e.Users))
+}
+
+// userSpecNames returns the user names of the users, which is used to reload
+// the privileges of them only.
+func userSpecNames(users []*ast.UserSpec) []string {
+	names := make([]string, 0, len(users))
+	for _, user := range users {
+		names = append(names, user.User.Username)
+	}
+	return names
+}
+
+// auditPrivilegeChange notifies the audit plugins of the privileges changed by GRANT or REVOKE, and writes
+// them to the built-in audit log if they match the audit rules.
+func auditPrivilegeChange(sctx sessionctx.Context, event plugin.PrivilegeEvent, privs []*ast.PrivElem,
+	level *ast.GrantLevel, users []*ast.UserSpec, withGrant bool) {
+	sessVars := sctx.GetSessionVars()
+	info := &plugin.PrivilegeInfo{
+		ConnectionID:    sessVars.ConnectionID,
+		Privileges:      make([]string, 0, len(privs)),
+		Grantees:        make([]string, 0, len(users)),
+		WithGrantOption: withGrant,
+	}
+	if sessVars.User != nil {
+		info.User = sessVars.User.Username
+		info.Host = sessVars.User.Hostname
+	}
+	var sb strings.Builder
+	restoreCtx := format.NewRestoreCtx(format.RestoreKeyWordUppercase, &sb)
+	for _, priv := range privs {
+		sb.Reset()
+		if err := priv.Restore(restoreCtx); err != nil {
+			logutil.BgLogger().Warn("restore privilege for audit failed", zap.Error(err))
+			continue
+		}
+		info.Privileges = append(info.Privileges, sb.String())
+	}
+	dbName := level.DBName
+	if len(dbName) == 0 && level.Level != ast.GrantLevelGlobal {
+		dbName = sessVars.CurrentDB
+	}
+	sb.Reset()
+	levelWithDB := &ast.GrantLevel{Level: level.Level, DBName: dbName, TableName: level.TableName}
+	if err := levelWithDB.Restore(restoreCtx); err == nil {
+		info.Level = sb.String()
+	}
+	for _, user := range users {
+		info.Grantees = append(info.Grantees, user.User.Username+"@"+user.User.Hostname)
+	}
+	err := plugin.ForeachPlugin(plugin.Audit, func(p *plugin.Plugin) error {
+		auditPlugin := plugin.DeclareAuditManifest(p.Manifest)
+		if auditPlugin.OnPrivilegeEvent != nil {
+			auditPlugin.OnPrivilegeEvent(context.Background(), sessVars, event, info)
+		}
+		return nil
+	})
+	if err != nil {
+		logutil.BgLogger().Warn("audit privilege change failed", zap.Error(err))
+	}
+	if audit.ShouldLog(info.User, []string{sessVars.CurrentDB, dbName}, audit.StmtClassDCL) {
+		audit.Log(&audit.Entry{
+			Time:       time.Now(),
+			ConnID:     info.ConnectionID,
+			User:       info.User,
+			Host:       info.Host,
+			DB:         sessVars.CurrentDB,
+			StmtClass:  audit.StmtClassDCL,
+			Succ:       true,
+			Event:      strings.ToUpper(event.String()),
+			Privileges: info.Privileges,
+			Level:      info.Level,
+			Grantees:   info.Grantees,
+		})
+	}
 }
 
 func containsNonDynamicPriv(privList []*ast.PrivElem) bool {
@@ -16,11 +16,14 @@ package executor
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
+	"net/http"
 	"slices"
 	"strconv"
 	"strings"
@@ -42,12 +45,15 @@ import (
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/charset"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/util/fixcontrol"
 	"github.com/pingcap/tidb/pkg/privilege"
 	"github.com/pingcap/tidb/pkg/privilege/privileges"
 	"github.com/pingcap/tidb/pkg/session/txninfo"
@@ -57,16 +63,19 @@ import (
 	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/statistics/handle/cache"
 	"github.com/pingcap/tidb/pkg/store/helper"
+	"github.com/pingcap/tidb/pkg/store/regioncache"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/tablecodec"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/audit"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/collate"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 	"github.com/pingcap/tidb/pkg/util/deadlockhistory"
 	"github.com/pingcap/tidb/pkg/util/execdetails"
+	"github.com/pingcap/tidb/pkg/util/generallog"
 	"github.com/pingcap/tidb/pkg/util/hint"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/keydecoder"
@@ -79,6 +88,8 @@ import (
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
 	"github.com/pingcap/tidb/pkg/util/syncutil"
+	"github.com/pingcap/tidb/pkg/util/tablelatency"
+	"github.com/tikv/client-go/v2/oracle"
 	"github.com/tikv/client-go/v2/tikv"
 	"github.com/tikv/client-go/v2/tikvrpc"
 	"github.com/tikv/client-go/v2/txnkv/txnlock"
@@ -134,6 +145,10 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			e.setDataFromIndexes(sctx, dbs)
 		case infoschema.TableViews:
 			e.setDataFromViews(sctx, dbs)
+		case infoschema.TableTriggers:
+			e.setDataFromTriggers(sctx, dbs)
+		case infoschema.TableTiDBMaterializedViews:
+			e.setDataFromMaterializedViews(sctx, dbs)
 		case infoschema.TableEngines:
 			e.setDataFromEngines()
 		case infoschema.TableCharacterSets:
@@ -150,10 +165,24 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			e.dataForCollationCharacterSetApplicability()
 		case infoschema.TableProcesslist:
 			e.setDataForProcessList(sctx)
+		case infoschema.TableTiDBGCProtections:
+			e.setDataForGCProtections(sctx)
+		case infoschema.TableDataLockWaitsSummary:
+			err = e.setDataForDataLockWaitsSummary(ctx, sctx)
+		case infoschema.TableTableLatencyStats:
+			e.setDataForTableLatencyStats(sctx)
+		case infoschema.TableTiDBRegionCacheStats:
+			e.setDataForTiDBRegionCacheStats(sctx)
+		case infoschema.TableOptimizerFixControls:
+			err = e.setDataForOptimizerFixControls(ctx, sctx)
+		case infoschema.TableTiDBUserResourceUsage:
+			e.setDataForUserResourceUsage(sctx)
 		case infoschema.ClusterTableProcesslist:
 			err = e.setDataForClusterProcessList(sctx)
 		case infoschema.TableUserPrivileges:
 			e.setDataFromUserPrivileges(sctx)
+		case infoschema.TableColumnPrivileges:
+			e.setDataFromColumnPrivileges(sctx)
 		case infoschema.TableTiKVRegionStatus:
 			err = e.setDataForTiKVRegionStatus(ctx, sctx)
 		case infoschema.TableTiDBHotRegions:
@@ -164,10 +193,22 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			e.rows, err = infoschema.GetDataFromSessionVariables(ctx, sctx)
 		case infoschema.TableTiDBServersInfo:
 			err = e.setDataForServersInfo(sctx)
+		case infoschema.TableTiDBServersHistory:
+			err = e.setDataForServersHistory(sctx)
 		case infoschema.TableTiFlashReplica:
 			e.dataForTableTiFlashReplica(sctx, dbs)
 		case infoschema.TableTiKVStoreStatus:
 			err = e.dataForTiKVStoreStatus(ctx, sctx)
+		case infoschema.TableClusterEncryptionStatus:
+			err = e.setDataForClusterEncryptionStatus(ctx, sctx)
+		case infoschema.TableLogBackupTasks:
+			err = e.setDataForLogBackupTasks(ctx, sctx)
+		case infoschema.TableLogBackupCheckpoints:
+			err = e.setDataForLogBackupCheckpoints(ctx, sctx)
+		case infoschema.TableLogBackupErrors:
+			err = e.setDataForLogBackupErrors(ctx, sctx)
+		case infoschema.TableChecksumTableProgress:
+			err = e.setDataForChecksumTableProgress(ctx, sctx)
 		case infoschema.TableClientErrorsSummaryGlobal,
 			infoschema.TableClientErrorsSummaryByUser,
 			infoschema.TableClientErrorsSummaryByHost:
@@ -184,6 +225,10 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataForVariablesInfo(sctx)
 		case infoschema.TableUserAttributes:
 			err = e.setDataForUserAttributes(ctx, sctx)
+		case infoschema.TableRoutines:
+			err = e.setDataForRoutines(ctx, sctx)
+		case infoschema.TableEvents:
+			err = e.setDataForEvents(ctx, sctx)
 		case infoschema.TableMemoryUsage:
 			err = e.setDataForMemoryUsage()
 		case infoschema.ClusterTableMemoryUsage:
@@ -206,6 +251,10 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			e.setDataFromIndexUsage(sctx, dbs)
 		case infoschema.ClusterTableTiDBIndexUsage:
 			err = e.setDataForClusterIndexUsage(sctx, dbs)
+		case infoschema.TableAuditRules:
+			e.setDataFromAuditRules(sctx)
+		case infoschema.TableTiDBGeneralLog:
+			e.setDataFromGeneralLog(sctx)
 		}
 		if err != nil {
 			return nil, err
@@ -331,6 +380,148 @@ func (e *memtableRetriever) setDataForUserAttributes(ctx context.Context, sctx s
 	return nil
 }
 
+func (e *memtableRetriever) setDataForRoutines(ctx context.Context, sctx sessionctx.Context) error {
+	exec, _ := sctx.(sqlexec.RestrictedSQLExecutor)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	chunkRows, _, err := exec.ExecRestrictedSQL(ctx, nil, `SELECT route_schema, name, type, definition, is_deterministic, security_type, created, last_altered,
+		sql_mode, comment, definer, character_set_client, connection_collation, schema_collation FROM mysql.routines ORDER BY route_schema, name`)
+	if err != nil {
+		return err
+	}
+	checker := privilege.GetPrivilegeManager(sctx)
+	rows := make([][]types.Datum, 0, len(chunkRows))
+	for _, chunkRow := range chunkRows {
+		schema := chunkRow.GetString(0)
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, schema, "", "", mysql.AllPrivMask) {
+			continue
+		}
+		name := chunkRow.GetString(1)
+		created := chunkRow.GetTime(6)
+		created.SetType(mysql.TypeDatetime)
+		lastAltered := chunkRow.GetTime(7)
+		lastAltered.SetType(mysql.TypeDatetime)
+		var comment any
+		if !chunkRow.IsNull(9) {
+			comment = chunkRow.GetString(9)
+		}
+		record := types.MakeDatums(
+			name,                         // SPECIFIC_NAME
+			infoschema.CatalogVal,        // ROUTINE_CATALOG
+			schema,                       // ROUTINE_SCHEMA
+			name,                         // ROUTINE_NAME
+			chunkRow.GetEnum(2).String(), // ROUTINE_TYPE
+			"",                           // DATA_TYPE
+			nil,                          // CHARACTER_MAXIMUM_LENGTH
+			nil,                          // CHARACTER_OCTET_LENGTH
+			nil,                          // NUMERIC_PRECISION
+			nil,                          // NUMERIC_SCALE
+			nil,                          // DATETIME_PRECISION
+			nil,                          // CHARACTER_SET_NAME
+			nil,                          // COLLATION_NAME
+			nil,                          // DTD_IDENTIFIER
+			"SQL",                        // ROUTINE_BODY
+			chunkRow.GetString(3),        // ROUTINE_DEFINITION
+			nil,                          // EXTERNAL_NAME
+			"SQL",                        // EXTERNAL_LANGUAGE
+			"SQL",                        // PARAMETER_STYLE
+			chunkRow.GetEnum(4).String(), // IS_DETERMINISTIC
+			"CONTAINS SQL",               // SQL_DATA_ACCESS
+			nil,                          // SQL_PATH
+			chunkRow.GetEnum(5).String(), // SECURITY_TYPE
+			created,                      // CREATED
+			lastAltered,                  // LAST_ALTERED
+			chunkRow.GetString(8),        // SQL_MODE
+			comment,                      // ROUTINE_COMMENT
+			chunkRow.GetString(10),       // DEFINER
+			chunkRow.GetString(11),       // CHARACTER_SET_CLIENT
+			chunkRow.GetString(12),       // COLLATION_CONNECTION
+			chunkRow.GetString(13),       // DATABASE_COLLATION
+		)
+		rows = append(rows, record)
+	}
+	e.rows = rows
+	return nil
+}
+
+func (e *memtableRetriever) setDataForEvents(ctx context.Context, sctx sessionctx.Context) error {
+	exec, _ := sctx.(sqlexec.RestrictedSQLExecutor)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	// the times are read as unix timestamps and converted to the session time zone.
+	chunkRows, _, err := exec.ExecRestrictedSQL(ctx, nil, `SELECT event_schema, event_name, definer, time_zone, body, UNIX_TIMESTAMP(execute_at),
+		interval_value, interval_field, sql_mode, UNIX_TIMESTAMP(starts), UNIX_TIMESTAMP(ends), status, on_completion, UNIX_TIMESTAMP(created),
+		UNIX_TIMESTAMP(last_altered), UNIX_TIMESTAMP(last_executed), comment, character_set_client, collation_connection, db_collation,
+		last_status, last_error FROM mysql.events ORDER BY event_schema, event_name`)
+	if err != nil {
+		return err
+	}
+	checker := privilege.GetPrivilegeManager(sctx)
+	loc := sctx.GetSessionVars().TimeZone
+	if loc == nil {
+		loc = time.Local
+	}
+	getTime := func(row chunk.Row, idx int) any {
+		if row.IsNull(idx) {
+			return nil
+		}
+		return types.NewTime(types.FromGoTime(time.Unix(row.GetInt64(idx), 0).In(loc)), mysql.TypeDatetime, 0)
+	}
+	rows := make([][]types.Datum, 0, len(chunkRows))
+	for _, chunkRow := range chunkRows {
+		schema := chunkRow.GetString(0)
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, schema, "", "", mysql.EventPriv) {
+			continue
+		}
+		eventType, intervalValue, intervalField := "ONE TIME", any(nil), any(nil)
+		if !chunkRow.IsNull(6) {
+			eventType = "RECURRING"
+			intervalValue = strconv.FormatInt(chunkRow.GetInt64(6), 10)
+			intervalField = chunkRow.GetEnum(7).String()
+		}
+		onCompletion := "NOT PRESERVE"
+		if chunkRow.GetEnum(12).String() == "PRESERVE" {
+			onCompletion = "PRESERVE"
+		}
+		var lastStatus, lastError any
+		if !chunkRow.IsNull(20) {
+			lastStatus = chunkRow.GetEnum(20).String()
+		}
+		if !chunkRow.IsNull(21) {
+			lastError = chunkRow.GetString(21)
+		}
+		record := types.MakeDatums(
+			infoschema.CatalogVal,         // EVENT_CATALOG
+			schema,                        // EVENT_SCHEMA
+			chunkRow.GetString(1),         // EVENT_NAME
+			chunkRow.GetString(2),         // DEFINER
+			chunkRow.GetString(3),         // TIME_ZONE
+			"SQL",                         // EVENT_BODY
+			chunkRow.GetString(4),         // EVENT_DEFINITION
+			eventType,                     // EVENT_TYPE
+			getTime(chunkRow, 5),          // EXECUTE_AT
+			intervalValue,                 // INTERVAL_VALUE
+			intervalField,                 // INTERVAL_FIELD
+			chunkRow.GetString(8),         // SQL_MODE
+			getTime(chunkRow, 9),          // STARTS
+			getTime(chunkRow, 10),         // ENDS
+			chunkRow.GetEnum(11).String(), // STATUS
+			onCompletion,                  // ON_COMPLETION
+			getTime(chunkRow, 13),         // CREATED
+			getTime(chunkRow, 14),         // LAST_ALTERED
+			getTime(chunkRow, 15),         // LAST_EXECUTED
+			chunkRow.GetString(16),        // EVENT_COMMENT
+			0,                             // ORIGINATOR
+			chunkRow.GetString(17),        // CHARACTER_SET_CLIENT
+			chunkRow.GetString(18),        // COLLATION_CONNECTION
+			chunkRow.GetString(19),        // DATABASE_COLLATION
+			lastStatus,                    // TIDB_LAST_STATUS
+			lastError,                     // TIDB_LAST_ERROR
+		)
+		rows = append(rows, record)
+	}
+	e.rows = rows
+	return nil
+}
+
 func (e *memtableRetriever) setDataFromSchemata(ctx sessionctx.Context, schemas []*model.DBInfo) {
 	checker := privilege.GetPrivilegeManager(ctx)
 	rows := make([][]types.Datum, 0, len(schemas))
@@ -351,6 +542,11 @@ func (e *memtableRetriever) setDataFromSchemata(ctx sessionctx.Context, schemas
 			policyName = schema.PlacementPolicyRef.Name.O
 		}
 
+		readOnly := "NO"
+		if schema.ReadOnly {
+			readOnly = "YES"
+		}
+
 		if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, "", "", mysql.AllPrivMask) {
 			continue
 		}
@@ -361,6 +557,7 @@ func (e *memtableRetriever) setDataFromSchemata(ctx sessionctx.Context, schemas
 			collation,             // DEFAULT_COLLATION_NAME
 			nil,                   // SQL_PATH
 			policyName,            // TIDB_PLACEMENT_POLICY_NAME
+			readOnly,              // TIDB_READ_ONLY
 		)
 		rows = append(rows, record)
 	}
@@ -476,6 +673,7 @@ func (e *memtableRetriever) setDataForStatisticsInTable(schema *model.DBInfo, ta
 
 func (e *memtableRetriever) setDataFromReferConst(sctx sessionctx.Context, schemas []*model.DBInfo) error {
 	checker := privilege.GetPrivilegeManager(sctx)
+	is := sctx.GetInfoSchema().(infoschema.InfoSchema)
 	var rows [][]types.Datum
 	extractor, ok := e.extractor.(*plannercore.InfoSchemaTablesExtractor)
 	if ok && extractor.SkipRequest {
@@ -503,13 +701,20 @@ func (e *memtableRetriever) setDataFromReferConst(sctx sessionctx.Context, schem
 				if model.ReferOptionType(fk.OnDelete) != 0 {
 					deleteRule = model.ReferOptionType(fk.OnDelete).String()
 				}
+				refSchema := fkRefSchema(schema, fk)
+				var uniqueName any
+				if refTbl, err := is.TableByName(refSchema, fk.RefTable); err == nil {
+					if name := fkRefIndexName(refTbl.Meta(), fk); name != "" {
+						uniqueName = name
+					}
+				}
 				record := types.MakeDatums(
 					infoschema.CatalogVal, // CONSTRAINT_CATALOG
 					schema.Name.O,         // CONSTRAINT_SCHEMA
 					fk.Name.O,             // CONSTRAINT_NAME
 					infoschema.CatalogVal, // UNIQUE_CONSTRAINT_CATALOG
-					schema.Name.O,         // UNIQUE_CONSTRAINT_SCHEMA
-					"PRIMARY",             // UNIQUE_CONSTRAINT_NAME
+					refSchema.O,           // UNIQUE_CONSTRAINT_SCHEMA
+					uniqueName,            // UNIQUE_CONSTRAINT_NAME
 					"NONE",                // MATCH_OPTION
 					updateRule,            // UPDATE_RULE
 					deleteRule,            // DELETE_RULE
@@ -524,6 +729,47 @@ func (e *memtableRetriever) setDataFromReferConst(sctx sessionctx.Context, schem
 	return nil
 }
 
+// fkRefSchema returns the schema of the table referenced by the foreign key. The foreign keys created by the old
+// versions don't record the referenced schema, they can only reference the tables in the same schema.
+func fkRefSchema(schema *model.DBInfo, fk *model.FKInfo) model.CIStr {
+	if fk.RefSchema.L == "" {
+		return schema.Name
+	}
+	return fk.RefSchema
+}
+
+// fkRefIndexName returns the name of the index of the referenced table used by the foreign key, it prefers the
+// primary key and the unique keys like MySQL. An empty string is returned if there is no such index, e.g. the
+// referenced table is dropped when foreign_key_checks is off.
+func fkRefIndexName(refTblInfo *model.TableInfo, fk *model.FKInfo) string {
+	if len(fk.RefCols) == 1 && refTblInfo.PKIsHandle {
+		if pk := refTblInfo.GetPkColInfo(); pk != nil && pk.Name.L == fk.RefCols[0].L {
+			return mysql.PrimaryKeyName
+		}
+	}
+	var candidate *model.IndexInfo
+	for _, idx := range refTblInfo.Indices {
+		if idx.State != model.StatePublic || !model.IsIndexPrefixCovered(refTblInfo, idx, fk.RefCols...) {
+			continue
+		}
+		if (idx.Primary || idx.Unique) && len(idx.Columns) == len(fk.RefCols) {
+			candidate = idx
+			break
+		}
+		if candidate == nil {
+			candidate = idx
+		}
+	}
+	switch {
+	case candidate == nil:
+		return ""
+	case candidate.Primary:
+		return mysql.PrimaryKeyName
+	default:
+		return candidate.Name.O
+	}
+}
+
 func fetchColumnsFromStatsCache(table *model.TableInfo) (rowCount uint64, avgRowLength uint64, dataLength uint64, indexLength uint64) {
 	cache := cache.TableRowStatsCache
 	if table.GetPartitionInfo() == nil {
@@ -574,6 +820,7 @@ func (e *memtableRetriever) setDataFromTables(sctx sessionctx.Context, schemas [
 	if ok && extractor.SkipRequest {
 		return nil
 	}
+	is := sctx.GetInfoSchema().(infoschema.InfoSchema)
 	for _, schema := range schemas {
 		if ok && extractor.Filter("table_schema", schema.Name.L) {
 			continue
@@ -582,6 +829,9 @@ func (e *memtableRetriever) setDataFromTables(sctx sessionctx.Context, schemas [
 			if ok && extractor.Filter("table_name", table.Name.L) {
 				continue
 			}
+			if table.IsStaging() {
+				continue
+			}
 			collation := table.Collate
 			if collation == "" {
 				collation = mysql.DefaultCollationName
@@ -616,6 +866,9 @@ func (e *memtableRetriever) setDataFromTables(sctx sessionctx.Context, schemas [
 				if table.IsSequence() {
 					tableType = "SEQUENCE"
 				}
+				if table.IsExternal() {
+					tableType = "EXTERNAL TABLE"
+				}
 				if table.HasClusteredIndex() {
 					pkType = "CLUSTERED"
 				}
@@ -625,6 +878,12 @@ func (e *memtableRetriever) setDataFromTables(sctx sessionctx.Context, schemas [
 					policyName = table.PlacementPolicyRef.Name.O
 				}
 
+				cacheStatus := strings.ToUpper(table.TableCacheStatusType.String())
+				var cacheMode, cacheSize any
+				if table.TableCacheStatusType == model.TableCacheStatusEnable {
+					cacheMode, cacheSize = getCachedTableStatus(is, table.ID)
+				}
+
 				var rowCount, avgRowLength, dataLength, indexLength uint64
 				if useStatsCache {
 					err := cache.TableRowStatsCache.UpdateByID(sctx, table.ID)
@@ -660,6 +919,9 @@ func (e *memtableRetriever) setDataFromTables(sctx sessionctx.Context, schemas [
 					shardingInfo,          // TIDB_ROW_ID_SHARDING_INFO
 					pkType,                // TIDB_PK_TYPE
 					policyName,            // TIDB_PLACEMENT_POLICY_NAME
+					cacheStatus,           // TIDB_TABLE_CACHE_STATUS
+					cacheMode,             // TIDB_TABLE_CACHE_MODE
+					cacheSize,             // TIDB_TABLE_CACHE_SIZE
 				)
 				rows = append(rows, record)
 			} else {
@@ -689,6 +951,9 @@ func (e *memtableRetriever) setDataFromTables(sctx sessionctx.Context, schemas [
 					nil,                   // TIDB_ROW_ID_SHARDING_INFO
 					pkType,                // TIDB_PK_TYPE
 					nil,                   // TIDB_PLACEMENT_POLICY_NAME
+					nil,                   // TIDB_TABLE_CACHE_STATUS
+					nil,                   // TIDB_TABLE_CACHE_MODE
+					nil,                   // TIDB_TABLE_CACHE_SIZE
 				)
 				rows = append(rows, record)
 			}
@@ -698,6 +963,28 @@ func (e *memtableRetriever) setDataFromTables(sctx sessionctx.Context, schemas [
 	return nil
 }
 
+// getCachedTableStatus returns the cache mode and the cache size of a cached table on the current TiDB instance.
+func getCachedTableStatus(is infoschema.InfoSchema, tableID int64) (mode any, size any) {
+	tbl, ok := is.TableByID(tableID)
+	if !ok {
+		return nil, nil
+	}
+	cached, ok := tbl.(table.CachedTable)
+	if !ok {
+		return nil, nil
+	}
+	status := cached.CacheStatus()
+	switch {
+	case status.TooLarge:
+		mode = "DEMOTING"
+	case status.WriteThrough:
+		mode = "WRITE_THROUGH"
+	default:
+		mode = "READ"
+	}
+	return mode, status.Size
+}
+
 // Data for inforation_schema.CHECK_CONSTRAINTS
 // This is standards (ISO/IEC 9075-11) compliant and is compatible with the implementation in MySQL as well.
 func (e *memtableRetriever) setDataFromCheckConstraints(sctx sessionctx.Context, schemas []*model.DBInfo) error {
@@ -769,6 +1056,9 @@ func (e *hugeMemTableRetriever) setDataForColumns(ctx context.Context, sctx sess
 		for e.tblIdx < len(schema.Tables) {
 			table := schema.Tables[e.tblIdx]
 			e.tblIdx++
+			if table.IsStaging() {
+				continue
+			}
 			hasPrivs := false
 			var priv mysql.PrivilegeType
 			if checker != nil {
@@ -848,9 +1138,10 @@ func (e *hugeMemTableRetriever) dataForColumnsInTable(ctx context.Context, sctx
 		}
 	}
 	i := 0
+	hideGIPK := !sctx.GetSessionVars().ShowGIPK
 ForColumnsTag:
 	for _, col := range tbl.Columns {
-		if col.Hidden {
+		if col.Hidden || (hideGIPK && table.IsGIPKColumn(col)) {
 			continue
 		}
 		i++
@@ -1112,6 +1403,9 @@ func (e *memtableRetriever) setDataFromPartitions(sctx sessionctx.Context, schem
 						}
 						partitionExpr = buf.String()
 					}
+					if table.Partition.IsLinear {
+						partitionMethod = "LINEAR " + partitionMethod
+					}
 
 					var policyName any
 					if pi.PlacementPolicyRef != nil {
@@ -1276,6 +1570,109 @@ func (e *memtableRetriever) setDataFromViews(ctx sessionctx.Context, schemas []*
 	e.rows = rows
 }
 
+func (e *memtableRetriever) setDataFromTriggers(ctx sessionctx.Context, schemas []*model.DBInfo) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	loc := ctx.GetSessionVars().TimeZone
+	if loc == nil {
+		loc = time.Local
+	}
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		collation := schema.Collate
+		if collation == "" {
+			collation = mysql.DefaultCollationName
+		}
+		for _, table := range schema.Tables {
+			if len(table.Triggers) == 0 {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, table.Name.L, "", mysql.TriggerPriv) {
+				continue
+			}
+			// ACTION_ORDER is the order of the trigger among the triggers with the same timing and event on the table.
+			orders := make(map[[2]int]int)
+			for _, trigger := range table.Triggers {
+				key := [2]int{int(trigger.Timing), int(trigger.Event)}
+				orders[key]++
+				created := types.NewTime(types.FromGoTime(trigger.Created.In(loc)), mysql.TypeDatetime, 2)
+				record := types.MakeDatums(
+					infoschema.CatalogVal,       // TRIGGER_CATALOG
+					schema.Name.O,               // TRIGGER_SCHEMA
+					trigger.Name.O,              // TRIGGER_NAME
+					trigger.Event.String(),      // EVENT_MANIPULATION
+					infoschema.CatalogVal,       // EVENT_OBJECT_CATALOG
+					schema.Name.O,               // EVENT_OBJECT_SCHEMA
+					table.Name.O,                // EVENT_OBJECT_TABLE
+					orders[key],                 // ACTION_ORDER
+					nil,                         // ACTION_CONDITION
+					trigger.Body,                // ACTION_STATEMENT
+					"ROW",                       // ACTION_ORIENTATION
+					trigger.Timing.String(),     // ACTION_TIMING
+					nil,                         // ACTION_REFERENCE_OLD_TABLE
+					nil,                         // ACTION_REFERENCE_NEW_TABLE
+					"OLD",                       // ACTION_REFERENCE_OLD_ROW
+					"NEW",                       // ACTION_REFERENCE_NEW_ROW
+					created,                     // CREATED
+					trigger.SQLMode.String(),    // SQL_MODE
+					trigger.Definer,             // DEFINER
+					trigger.CharacterSetClient,  // CHARACTER_SET_CLIENT
+					trigger.CollationConnection, // COLLATION_CONNECTION
+					collation,                   // DATABASE_COLLATION
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataFromMaterializedViews(ctx sessionctx.Context, schemas []*model.DBInfo) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	loc := ctx.GetSessionVars().TimeZone
+	if loc == nil {
+		loc = time.Local
+	}
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, table := range schema.Tables {
+			if !table.IsMaterializedView() || table.IsStaging() {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, table.Name.L, "", mysql.AllPrivMask) {
+				continue
+			}
+			mv := table.MaterializedView
+			var lastRefreshTime any
+			if mv.LastRefreshTS > 0 {
+				t := oracle.GetTimeFromTS(mv.LastRefreshTS).In(loc)
+				lastRefreshTime = types.NewTime(types.FromGoTime(t), mysql.TypeDatetime, 0)
+			}
+			definer := ""
+			if mv.Definer != nil {
+				definer = mv.Definer.String()
+			}
+			var refreshEvery, refreshUnit any
+			if mv.RefreshEvery > 0 {
+				refreshEvery, refreshUnit = mv.RefreshEvery, mv.RefreshUnit
+			}
+			record := types.MakeDatums(
+				schema.Name.O,          // TABLE_SCHEMA
+				table.Name.O,           // TABLE_NAME
+				table.ID,               // TIDB_TABLE_ID
+				mv.SelectStmt,          // VIEW_DEFINITION
+				definer,                // DEFINER
+				mv.CharacterSetClient,  // CHARACTER_SET_CLIENT
+				mv.CollationConnection, // COLLATION_CONNECTION
+				lastRefreshTime,        // LAST_REFRESH_TIME
+				refreshEvery,           // REFRESH_INTERVAL_VALUE
+				refreshUnit,            // REFRESH_INTERVAL_FIELD
+			)
+			rows = append(rows, record)
+		}
+	}
+	e.rows = rows
+}
+
 func (e *memtableRetriever) dataForTiKVStoreStatus(ctx context.Context, sctx sessionctx.Context) (err error) {
 	tikvStore, ok := sctx.GetStore().(helper.Storage)
 	if !ok {
@@ -1341,14 +1738,115 @@ func (e *memtableRetriever) dataForTiKVStoreStatus(ctx context.Context, sctx ses
 	return nil
 }
 
+const (
+	tikvEncryptionStatusPath = "/encryption/status"
+	tikvEncryptionRotatePath = "/encryption/rotate"
+)
+
+// tikvEncryptionStatus is the encryption at rest status reported by the status server of TiKV.
+type tikvEncryptionStatus struct {
+	Method        string `json:"method"`
+	MasterKeyType string `json:"master_key_type"`
+	CurrentKeyID  uint64 `json:"current_key_id"`
+	// KeyCreationTime is the unix timestamp in seconds when the current data key is created.
+	KeyCreationTime  int64 `json:"key_creation_time"`
+	DataKeyCount     int64 `json:"data_key_count"`
+	FileCount        int64 `json:"file_count"`
+	RotatedFileCount int64 `json:"rotated_file_count"`
+}
+
+func getTiKVServerInfo(sctx sessionctx.Context) ([]infoschema.ServerInfo, error) {
+	serversInfo, err := infoschema.GetClusterServerInfo(sctx)
+	if err != nil {
+		return nil, err
+	}
+	tikvServers := make([]infoschema.ServerInfo, 0, len(serversInfo))
+	for _, srv := range serversInfo {
+		if srv.ServerType == "tikv" {
+			tikvServers = append(tikvServers, srv)
+		}
+	}
+	return tikvServers, nil
+}
+
+func requestTiKVEncryptionAPI(ctx context.Context, method, statusAddr, path string) ([]byte, error) {
+	url := fmt.Sprintf("%s://%s%s", util.InternalHTTPSchema(), statusAddr, path)
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := util.InternalHTTPClient().Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		terror.Log(resp.Body.Close())
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("request %s failed: %s %s", url, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+func (e *memtableRetriever) setDataForClusterEncryptionStatus(ctx context.Context, sctx sessionctx.Context) error {
+	if !hasPriv(sctx, mysql.SuperPriv) {
+		return plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+	}
+	servers, err := getTiKVServerInfo(sctx)
+	if err != nil {
+		return err
+	}
+	loc := sctx.GetSessionVars().Location()
+	for _, srv := range servers {
+		body, err := requestTiKVEncryptionAPI(ctx, http.MethodGet, srv.StatusAddr, tikvEncryptionStatusPath)
+		if err != nil {
+			sctx.GetSessionVars().StmtCtx.AppendWarning(err)
+			continue
+		}
+		var status tikvEncryptionStatus
+		if err = json.Unmarshal(body, &status); err != nil {
+			sctx.GetSessionVars().StmtCtx.AppendWarning(errors.Trace(err))
+			continue
+		}
+		row := types.MakeDatums(
+			srv.ServerType,
+			srv.Address,
+			status.Method,
+			status.MasterKeyType,
+			status.CurrentKeyID,
+			nil,
+			status.DataKeyCount,
+			status.FileCount,
+			status.RotatedFileCount,
+			nil,
+		)
+		if status.KeyCreationTime > 0 {
+			row[5].SetMysqlTime(types.NewTime(types.FromGoTime(time.Unix(status.KeyCreationTime, 0).In(loc)), mysql.TypeDatetime, types.DefaultFsp))
+		}
+		// All the files are already encrypted by the current data key if there is no file.
+		progress := 100.0
+		if status.FileCount > 0 {
+			progress = float64(status.RotatedFileCount) * 100 / float64(status.FileCount)
+		}
+		row[9].SetFloat64(progress)
+		e.rows = append(e.rows, row)
+	}
+	return nil
+}
+
 // DDLJobsReaderExec executes DDLJobs information retrieving.
 type DDLJobsReaderExec struct {
 	exec.BaseExecutor
 	DDLJobRetriever
 
-	cacheJobs []*model.Job
-	is        infoschema.InfoSchema
-	sess      sessionctx.Context
+	is   infoschema.InfoSchema
+	sess sessionctx.Context
+	// historyOnly means only the finished jobs in the DDL history are read, it's used by TIDB_DDL_HISTORY.
+	historyOnly bool
 }
 
 // Open implements the Executor Next interface.
@@ -1376,52 +1874,59 @@ func (e *DDLJobsReaderExec) Open(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if e.historyOnly {
+		e.runningJobs = nil
+	}
+	if e.extractor != nil && len(e.extractor.JobIDs) > 0 {
+		return e.readHistoryJobsByIDs(meta.NewMeta(txn))
+	}
 	return nil
 }
 
 // Next implements the Executor Next interface.
 func (e *DDLJobsReaderExec) Next(_ context.Context, req *chunk.Chunk) error {
 	req.GrowAndReset(e.MaxChunkSize())
+	if e.extractor != nil && e.extractor.SkipRequest {
+		return nil
+	}
 	checker := privilege.GetPrivilegeManager(e.Ctx())
-	count := 0
 
 	// Append running DDL jobs.
-	if e.cursor < len(e.runningJobs) {
-		num := min(req.Capacity(), len(e.runningJobs)-e.cursor)
-		for i := e.cursor; i < e.cursor+num; i++ {
-			e.appendJobToChunk(req, e.runningJobs[i], checker)
-			req.AppendString(12, e.runningJobs[i].Query)
-			if e.runningJobs[i].MultiSchemaInfo != nil {
-				for range e.runningJobs[i].MultiSchemaInfo.SubJobs {
-					req.AppendString(12, e.runningJobs[i].Query)
-				}
-			}
-		}
-		e.cursor += num
-		count += num
+	for e.cursor < len(e.runningJobs) && req.NumRows() < req.Capacity() {
+		e.appendJob(req, e.runningJobs[e.cursor], checker)
+		e.cursor++
 	}
-	var err error
 
-	// Append history DDL jobs.
-	if count < req.Capacity() {
-		e.cacheJobs, err = e.historyJobIter.GetLastJobs(req.Capacity()-count, e.cacheJobs)
+	// Append history DDL jobs. The jobs may be filtered, so keep reading until the chunk is full or no job is left.
+	for req.NumRows() < req.Capacity() {
+		jobs, err := e.nextHistoryJobs(req.Capacity() - req.NumRows())
 		if err != nil {
 			return err
 		}
-		for _, job := range e.cacheJobs {
-			e.appendJobToChunk(req, job, checker)
-			req.AppendString(12, job.Query)
-			if job.MultiSchemaInfo != nil {
-				for range job.MultiSchemaInfo.SubJobs {
-					req.AppendString(12, job.Query)
-				}
-			}
+		if len(jobs) == 0 {
+			break
+		}
+		for _, job := range jobs {
+			e.appendJob(req, job, checker)
 		}
-		e.cursor += len(e.cacheJobs)
+		e.cursor += len(jobs)
 	}
 	return nil
 }
 
+// appendJob appends the rows of the job if it may match the predicates.
+func (e *DDLJobsReaderExec) appendJob(req *chunk.Chunk, job *model.Job, checker privilege.Manager) {
+	if e.skipJob(job) || !e.appendJobToChunk(req, job, checker) {
+		return
+	}
+	req.AppendString(12, job.Query)
+	if job.MultiSchemaInfo != nil {
+		for range job.MultiSchemaInfo.SubJobs {
+			req.AppendString(12, job.Query)
+		}
+	}
+}
+
 // Close implements the Executor Close interface.
 func (e *DDLJobsReaderExec) Close() error {
 	e.ReleaseSysSession(kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL), e.sess)
@@ -1584,21 +2089,110 @@ func (e *memtableRetriever) setDataForProcessList(ctx sessionctx.Context) {
 	e.rows = records
 }
 
+func (e *memtableRetriever) setDataForGCProtections(ctx sessionctx.Context) {
+	sm := ctx.GetSessionManager()
+	if sm == nil {
+		return
+	}
+
+	loginUser := ctx.GetSessionVars().User
+	// Like PROCESSLIST, only the users with the PROCESS privilege can see all the sessions.
+	hasProcessPriv := hasPriv(ctx, mysql.ProcessPriv)
+	protection := infosync.QueryGCProtectionDuration()
+	loc := ctx.GetSessionVars().Location()
+	now := time.Now()
+	pl := sm.ShowProcessList()
+
+	records := make([][]types.Datum, 0, len(pl))
+	for _, pi := range pl {
+		if !pi.GCProtected || pi.CurTxnStartTS == 0 {
+			continue
+		}
+		if !hasProcessPriv && loginUser != nil && pi.User != loginUser.Username {
+			continue
+		}
+		startTime := oracle.GetTimeFromTS(pi.CurTxnStartTS)
+		expireTime := startTime.Add(protection)
+		// the start ts isn't protected any more, GC may have removed the data it reads.
+		if expireTime.Before(now) {
+			continue
+		}
+		var db any
+		if len(pi.DB) > 0 {
+			db = pi.DB
+		}
+		records = append(records, types.MakeDatums(
+			pi.ID,
+			pi.User,
+			db,
+			pi.CurTxnStartTS,
+			types.NewTime(types.FromGoTime(startTime.In(loc)), mysql.TypeDatetime, types.MaxFsp),
+			types.NewTime(types.FromGoTime(expireTime.In(loc)), mysql.TypeDatetime, types.MaxFsp),
+		))
+	}
+	e.rows = records
+}
+
+func (e *memtableRetriever) setDataForUserResourceUsage(ctx sessionctx.Context) {
+	sm := ctx.GetSessionManager()
+	if sm == nil {
+		return
+	}
+
+	loginUser := ctx.GetSessionVars().User
+	// Like PROCESSLIST, only the users with the PROCESS privilege can see all the accounts.
+	hasProcessPriv := hasPriv(ctx, mysql.ProcessPriv)
+	pm := privilege.GetPrivilegeManager(ctx)
+	usages := sm.GetUserResourceUsage()
+
+	records := make([][]types.Datum, 0, len(usages))
+	for _, usage := range usages {
+		if !hasProcessPriv && loginUser != nil && (usage.User != loginUser.AuthUsername || usage.Host != loginUser.AuthHostname) {
+			continue
+		}
+		var maxQuestions, maxUserConnections int64
+		if pm != nil {
+			maxQuestions, maxUserConnections = pm.GetUserResources(usage.User, usage.Host)
+		}
+		var hourStart any
+		if !usage.HourStart.IsZero() {
+			hourStart = types.NewTime(types.FromGoTime(usage.HourStart.In(ctx.GetSessionVars().Location())), mysql.TypeDatetime, 0)
+		}
+		records = append(records, types.MakeDatums(
+			usage.User,
+			usage.Host,
+			usage.Connections,
+			maxUserConnections,
+			usage.Questions,
+			maxQuestions,
+			hourStart,
+		))
+	}
+	e.rows = records
+}
+
 func (e *memtableRetriever) setDataFromUserPrivileges(ctx sessionctx.Context) {
 	pm := privilege.GetPrivilegeManager(ctx)
 	// The results depend on the user querying the information.
 	e.rows = pm.UserPrivilegesTable(ctx.GetSessionVars().ActiveRoles, ctx.GetSessionVars().User.Username, ctx.GetSessionVars().User.Hostname)
 }
 
+func (e *memtableRetriever) setDataFromColumnPrivileges(ctx sessionctx.Context) {
+	pm := privilege.GetPrivilegeManager(ctx)
+	// The results depend on the user querying the information.
+	e.rows = pm.ColumnPrivilegesTable(ctx.GetSessionVars().ActiveRoles, ctx.GetSessionVars().User.Username, ctx.GetSessionVars().User.Hostname)
+}
+
 func (e *memtableRetriever) setDataForMetricTables() {
-	tables := make([]string, 0, len(infoschema.MetricTableMap))
-	for name := range infoschema.MetricTableMap {
+	defs := infoschema.GetMetricTableDefs()
+	tables := make([]string, 0, len(defs))
+	for name := range defs {
 		tables = append(tables, name)
 	}
 	slices.Sort(tables)
 	rows := make([][]types.Datum, 0, len(tables))
 	for _, name := range tables {
-		schema := infoschema.MetricTableMap[name]
+		schema := defs[name]
 		record := types.MakeDatums(
 			name,                             // METRICS_NAME
 			schema.PromQL,                    // PROMQL
@@ -1672,6 +2266,7 @@ func keyColumnUsageInTable(schema *model.DBInfo, table *model.TableInfo) [][]typ
 		}
 	}
 	for _, fk := range table.ForeignKeys {
+		refSchema := fkRefSchema(schema, fk)
 		for i, key := range fk.Cols {
 			fkRefCol := ""
 			if len(fk.RefCols) > i {
@@ -1687,8 +2282,8 @@ func keyColumnUsageInTable(schema *model.DBInfo, table *model.TableInfo) [][]typ
 				table.Name.O,          // TABLE_NAME
 				col.Name.O,            // COLUMN_NAME
 				i+1,                   // ORDINAL_POSITION,
-				1,                     // POSITION_IN_UNIQUE_CONSTRAINT
-				schema.Name.O,         // REFERENCED_TABLE_SCHEMA
+				i+1,                   // POSITION_IN_UNIQUE_CONSTRAINT
+				refSchema.O,           // REFERENCED_TABLE_SCHEMA
 				fk.RefTable.O,         // REFERENCED_TABLE_NAME
 				fkRefCol,              // REFERENCED_COLUMN_NAME
 			)
@@ -1927,6 +2522,7 @@ func (e *memtableRetriever) setDataFromTableConstraints(ctx sessionctx.Context,
 					schema.Name.O,             // TABLE_SCHEMA
 					tbl.Name.O,                // TABLE_NAME
 					infoschema.PrimaryKeyType, // CONSTRAINT_TYPE
+					"YES",                     // ENFORCED
 				)
 				rows = append(rows, record)
 			}
@@ -1950,10 +2546,10 @@ func (e *memtableRetriever) setDataFromTableConstraints(ctx sessionctx.Context,
 					schema.Name.O,         // TABLE_SCHEMA
 					tbl.Name.O,            // TABLE_NAME
 					ctype,                 // CONSTRAINT_TYPE
+					"YES",                 // ENFORCED
 				)
 				rows = append(rows, record)
 			}
-			//  TiDB includes foreign key information for compatibility but foreign keys are not yet enforced.
 			for _, fk := range tbl.ForeignKeys {
 				record := types.MakeDatums(
 					infoschema.CatalogVal,     // CONSTRAINT_CATALOG
@@ -1962,6 +2558,26 @@ func (e *memtableRetriever) setDataFromTableConstraints(ctx sessionctx.Context,
 					schema.Name.O,             // TABLE_SCHEMA
 					tbl.Name.O,                // TABLE_NAME
 					infoschema.ForeignKeyType, // CONSTRAINT_TYPE
+					"YES",                     // ENFORCED
+				)
+				rows = append(rows, record)
+			}
+			for _, constraint := range tbl.Constraints {
+				if constraint.State != model.StatePublic {
+					continue
+				}
+				enforced := "NO"
+				if constraint.Enforced {
+					enforced = "YES"
+				}
+				record := types.MakeDatums(
+					infoschema.CatalogVal,          // CONSTRAINT_CATALOG
+					schema.Name.O,                  // CONSTRAINT_SCHEMA
+					constraint.Name.O,              // CONSTRAINT_NAME
+					schema.Name.O,                  // TABLE_SCHEMA
+					tbl.Name.O,                     // TABLE_NAME
+					infoschema.CheckConstraintType, // CONSTRAINT_TYPE
+					enforced,                       // ENFORCED
 				)
 				rows = append(rows, record)
 			}
@@ -2350,6 +2966,44 @@ func (e *memtableRetriever) setDataForServersInfo(ctx sessionctx.Context) error
 	return nil
 }
 
+func (e *memtableRetriever) setDataForServersHistory(ctx sessionctx.Context) error {
+	events, err := infosync.GetServersHistory()
+	if err != nil {
+		return err
+	}
+	loc := ctx.GetSessionVars().Location()
+	hideIP := false
+	if sem.IsEnabled() {
+		checker := privilege.GetPrivilegeManager(ctx)
+		hideIP = checker == nil || !checker.RequestDynamicVerification(ctx.GetSessionVars().ActiveRoles, "RESTRICTED_TABLES_ADMIN", false)
+	}
+	rows := make([][]types.Datum, 0, len(events))
+	for _, event := range events {
+		info := event.Info
+		row := types.MakeDatums(
+			types.NewTime(types.FromGoTime(event.Time.In(loc)), mysql.TypeDatetime, 0), // TIME
+			event.Type,           // EVENT
+			info.ID,              // DDL_ID
+			info.IP,              // IP
+			int(info.Port),       // PORT
+			int(info.StatusPort), // STATUS_PORT
+			info.Version,         // VERSION
+			info.GitHash,         // GIT_HASH
+			nil,                  // START_TIME
+			stringutil.BuildStringFromLabels(info.Labels), // LABELS
+		)
+		if info.StartTimestamp > 0 {
+			row[8].SetMysqlTime(types.NewTime(types.FromGoTime(time.Unix(info.StartTimestamp, 0).In(loc)), mysql.TypeDatetime, 0))
+		}
+		if hideIP {
+			row[3].SetNull()
+		}
+		rows = append(rows, row)
+	}
+	e.rows = rows
+	return nil
+}
+
 func (e *memtableRetriever) setDataFromSequences(ctx sessionctx.Context, schemas []*model.DBInfo) {
 	checker := privilege.GetPrivilegeManager(ctx)
 	var rows [][]types.Datum
@@ -2395,23 +3049,7 @@ func (e *memtableRetriever) dataForTableTiFlashReplica(ctx sessionctx.Context, s
 			if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, tbl.Name.L, "", mysql.AllPrivMask) {
 				continue
 			}
-			var progress float64
-			if pi := tbl.GetPartitionInfo(); pi != nil && len(pi.Definitions) > 0 {
-				for _, p := range pi.Definitions {
-					progressOfPartition, err := infosync.MustGetTiFlashProgress(p.ID, tbl.TiFlashReplica.Count, &tiFlashStores)
-					if err != nil {
-						logutil.BgLogger().Error("dataForTableTiFlashReplica error", zap.Int64("tableID", tbl.ID), zap.Int64("partitionID", p.ID), zap.Error(err))
-					}
-					progress += progressOfPartition
-				}
-				progress = progress / float64(len(pi.Definitions))
-			} else {
-				var err error
-				progress, err = infosync.MustGetTiFlashProgress(tbl.ID, tbl.TiFlashReplica.Count, &tiFlashStores)
-				if err != nil {
-					logutil.BgLogger().Error("dataForTableTiFlashReplica error", zap.Int64("tableID", tbl.ID), zap.Error(err))
-				}
-			}
+			progress := getTiFlashReplicaProgress(tbl, &tiFlashStores)
 			progressString := types.TruncateFloatToString(progress, 2)
 			progress, _ = strconv.ParseFloat(progressString, 64)
 			record := types.MakeDatums(
@@ -2884,6 +3522,121 @@ func (r *dataLockWaitsTableRetriever) retrieve(ctx context.Context, sctx session
 	return res, nil
 }
 
+type lockWaitsSummaryKey struct {
+	waitingDigest string
+	holdingDigest string
+}
+
+type lockWaitsSummary struct {
+	lockWaitsSummaryKey
+	waitCount  uint64
+	waitingTxn map[uint64]struct{}
+	holdingTxn map[uint64]struct{}
+}
+
+// setDataForDataLockWaitsSummary aggregates the current pessimistic lock waits by the digests of the SQL waiting for the
+// lock and the SQL of the transaction holding the lock, which helps find the statements that contend with each other
+// systemically.
+func (e *memtableRetriever) setDataForDataLockWaitsSummary(ctx context.Context, sctx sessionctx.Context) error {
+	if !hasPriv(sctx, mysql.ProcessPriv) {
+		return plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("PROCESS")
+	}
+	lockWaits, err := sctx.GetStore().GetLockWaits()
+	if err != nil {
+		return err
+	}
+
+	// The digest of the SQL a transaction is waiting on. A transaction holding a lock may be waiting for another lock
+	// itself, otherwise it's the SQL it's running on this instance, if any.
+	txnDigests := make(map[uint64]string, len(lockWaits))
+	waitingDigests := make([]string, len(lockWaits))
+	for i, lockWait := range lockWaits {
+		digest, err := resourcegrouptag.DecodeResourceGroupTag(lockWait.ResourceGroupTag)
+		if err != nil {
+			logutil.Logger(ctx).Warn("failed to decode resource group tag", zap.Error(err))
+			continue
+		}
+		waitingDigests[i] = hex.EncodeToString(digest)
+		txnDigests[lockWait.Txn] = waitingDigests[i]
+	}
+	if sm := sctx.GetSessionManager(); sm != nil {
+		for _, info := range sm.ShowTxnList() {
+			if _, ok := txnDigests[info.StartTS]; !ok && len(info.CurrentSQLDigest) > 0 {
+				txnDigests[info.StartTS] = info.CurrentSQLDigest
+			}
+		}
+	}
+
+	summaries := make(map[lockWaitsSummaryKey]*lockWaitsSummary)
+	for i, lockWait := range lockWaits {
+		key := lockWaitsSummaryKey{waitingDigest: waitingDigests[i], holdingDigest: txnDigests[lockWait.WaitForTxn]}
+		summary, ok := summaries[key]
+		if !ok {
+			summary = &lockWaitsSummary{
+				lockWaitsSummaryKey: key,
+				waitingTxn:          make(map[uint64]struct{}),
+				holdingTxn:          make(map[uint64]struct{}),
+			}
+			summaries[key] = summary
+		}
+		summary.waitCount++
+		summary.waitingTxn[lockWait.Txn] = struct{}{}
+		summary.holdingTxn[lockWait.WaitForTxn] = struct{}{}
+	}
+
+	sqlRetriever := expression.NewSQLDigestTextRetriever()
+	sortedSummaries := make([]*lockWaitsSummary, 0, len(summaries))
+	for key, summary := range summaries {
+		for _, digest := range []string{key.waitingDigest, key.holdingDigest} {
+			if len(digest) > 0 {
+				sqlRetriever.SQLDigestsMap[digest] = ""
+			}
+		}
+		sortedSummaries = append(sortedSummaries, summary)
+	}
+	if len(sqlRetriever.SQLDigestsMap) > 0 {
+		if err := sqlRetriever.RetrieveGlobal(ctx, sctx.GetExprCtx()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	slices.SortFunc(sortedSummaries, func(a, b *lockWaitsSummary) int {
+		if c := cmp.Compare(b.waitCount, a.waitCount); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.waitingDigest, b.waitingDigest); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.holdingDigest, b.holdingDigest)
+	})
+
+	digestDatum := func(digest string) types.Datum {
+		if len(digest) == 0 {
+			return types.NewDatum(nil)
+		}
+		return types.NewDatum(digest)
+	}
+	digestTextDatum := func(digest string) types.Datum {
+		if text := sqlRetriever.SQLDigestsMap[digest]; len(digest) > 0 && len(text) > 0 {
+			return types.NewDatum(text)
+		}
+		return types.NewDatum(nil)
+	}
+	rows := make([][]types.Datum, 0, len(sortedSummaries))
+	for _, summary := range sortedSummaries {
+		rows = append(rows, []types.Datum{
+			digestDatum(summary.waitingDigest),
+			digestTextDatum(summary.waitingDigest),
+			digestDatum(summary.holdingDigest),
+			digestTextDatum(summary.holdingDigest),
+			types.NewDatum(summary.waitCount),
+			types.NewDatum(uint64(len(summary.waitingTxn))),
+			types.NewDatum(uint64(len(summary.holdingTxn))),
+		})
+	}
+	e.rows = rows
+	return nil
+}
+
 // deadlocksTableRetriever is the memtable retriever for the DEADLOCKS and CLUSTER_DEADLOCKS table.
 type deadlocksTableRetriever struct {
 	dummyCloser
@@ -2998,7 +3751,9 @@ func (r *deadlocksTableRetriever) retrieve(ctx context.Context, sctx sessionctx.
 					}
 				} else if c.Name.O == deadlockhistory.ColKeyInfoStr {
 					value := types.NewDatum(nil)
-					if len(waitChainItem.Key) > 0 {
+					if len(waitChainItem.KeyInfo) > 0 {
+						value = types.NewDatum(waitChainItem.KeyInfo)
+					} else if len(waitChainItem.Key) > 0 {
 						decodedKey, err := keydecoder.DecodeKey(waitChainItem.Key, infoSchema)
 						if err == nil {
 							decodedKeyJSON, err := json.Marshal(decodedKey)
@@ -3523,6 +4278,44 @@ func (e *memtableRetriever) setDataFromKeywords() error {
 	return nil
 }
 
+// setDataFromAuditRules sets the rules of the built-in audit log, which are
+// only visible to users with the SUPER privilege.
+func (e *memtableRetriever) setDataFromAuditRules(sctx sessionctx.Context) {
+	if !hasPriv(sctx, mysql.SuperPriv) {
+		return
+	}
+	rules := audit.GetRules()
+	rows := make([][]types.Datum, 0, len(rules))
+	for i, r := range rules {
+		rows = append(rows, types.MakeDatums(i+1, r.User, r.DB, r.StmtClass))
+	}
+	e.rows = rows
+}
+
+// setDataFromGeneralLog sets the recent statements in the general log. Users
+// without the PROCESS privilege can only see their own statements.
+func (e *memtableRetriever) setDataFromGeneralLog(sctx sessionctx.Context) {
+	hasProcessPriv := hasPriv(sctx, mysql.ProcessPriv)
+	loginUser := sctx.GetSessionVars().User
+	records := generallog.GlobalHistory.GetAll()
+	rows := make([][]types.Datum, 0, len(records))
+	for _, r := range records {
+		if !hasProcessPriv && loginUser != nil && r.User != loginUser.Username {
+			continue
+		}
+		rows = append(rows, types.MakeDatums(
+			r.ID,
+			types.NewTime(types.FromGoTime(r.Time), mysql.TypeTimestamp, types.MaxFsp),
+			r.ConnID,
+			r.User,
+			r.Host,
+			r.DB,
+			r.Query,
+		))
+	}
+	e.rows = rows
+}
+
 func (e *memtableRetriever) setDataFromIndexUsage(ctx sessionctx.Context, schemas []*model.DBInfo) {
 	dom := domain.GetDomain(ctx)
 	rows := make([][]types.Datum, 0, 100)
@@ -3565,6 +4358,87 @@ func (e *memtableRetriever) setDataFromIndexUsage(ctx sessionctx.Context, schema
 	e.rows = rows
 }
 
+func (e *memtableRetriever) setDataForTableLatencyStats(ctx sessionctx.Context) {
+	is := ctx.GetInfoSchema().(infoschema.InfoSchema)
+	tableMetaLookup := func(id int64) (*model.TableInfo, bool) {
+		tbl, ok := is.TableByID(id)
+		if !ok {
+			return nil, false
+		}
+		return tbl.Meta(), true
+	}
+	// the latency of the dropped objects are removed, so the IDs can be mapped to the names here.
+	tablelatency.GlobalCollector.GC(tableMetaLookup)
+
+	checker := privilege.GetPrivilegeManager(ctx)
+	now := time.Now()
+	seconds := func(d time.Duration) types.Datum {
+		return types.NewFloat64Datum(d.Seconds())
+	}
+	allStats := tablelatency.GlobalCollector.GetAll()
+	rows := make([][]types.Datum, 0, len(allStats))
+	for _, stats := range allStats {
+		tbl, ok := tableMetaLookup(stats.TableID)
+		if !ok {
+			continue
+		}
+		schema, ok := infoschema.SchemaByTable(is, tbl)
+		if !ok {
+			continue
+		}
+		if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, tbl.Name.L, "", mysql.AllPrivMask) {
+			continue
+		}
+		indexName := types.NewDatum(nil)
+		if stats.IndexID != tablelatency.TableLevelIndexID {
+			i := slices.IndexFunc(tbl.Indices, func(idx *model.IndexInfo) bool {
+				return idx.ID == stats.IndexID
+			})
+			if i < 0 {
+				continue
+			}
+			indexName = types.NewStringDatum(tbl.Indices[i].Name.O)
+		}
+		var avgLatency time.Duration
+		if stats.QueryTotal > 0 {
+			avgLatency = stats.SumLatency / time.Duration(stats.QueryTotal)
+		}
+		histogram := make([]string, 0, len(stats.Buckets))
+		for i, count := range stats.Buckets {
+			bound := "+Inf"
+			if i < len(tablelatency.BucketBounds) {
+				bound = strconv.FormatFloat(tablelatency.BucketBounds[i].Seconds(), 'f', -1, 64)
+			}
+			histogram = append(histogram, fmt.Sprintf("%q:%d", bound, count))
+		}
+		rows = append(rows, []types.Datum{
+			types.NewStringDatum(schema.Name.O),
+			types.NewStringDatum(tbl.Name.O),
+			indexName,
+			types.NewUintDatum(stats.QueryTotal),
+			types.NewUintDatum(stats.ErrorTotal),
+			types.NewFloat64Datum(stats.QPS(now)),
+			seconds(stats.SumLatency),
+			seconds(avgLatency),
+			seconds(stats.MaxLatency),
+			seconds(stats.Quantile(0.5)),
+			seconds(stats.Quantile(0.95)),
+			seconds(stats.Quantile(0.99)),
+			types.NewStringDatum("{" + strings.Join(histogram, ",") + "}"),
+			types.NewTimeDatum(types.NewTime(types.FromGoTime(stats.StartTime), mysql.TypeTimestamp, types.DefaultFsp)),
+		})
+	}
+	slices.SortFunc(rows, func(a, b []types.Datum) int {
+		for i := 0; i < 3; i++ {
+			if c := cmp.Compare(a[i].GetString(), b[i].GetString()); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	e.rows = rows
+}
+
 func (e *memtableRetriever) setDataForClusterIndexUsage(ctx sessionctx.Context, schemas []*model.DBInfo) error {
 	e.setDataFromIndexUsage(ctx, schemas)
 	rows, err := infoschema.AppendHostInfoToRows(ctx, e.rows)
@@ -3648,3 +4522,54 @@ func tableOrPartitionNotExist(dbName string, tableName string, partitionName str
 	}
 	return false
 }
+
+func (e *memtableRetriever) setDataForTiDBRegionCacheStats(ctx sessionctx.Context) {
+	var cache *tikv.RegionCache
+	if tikvStore, ok := ctx.GetStore().(helper.Storage); ok {
+		cache = tikvStore.GetRegionCache()
+	}
+	for _, item := range regioncache.Collect(cache) {
+		e.rows = append(e.rows, types.MakeDatums(
+			item.Category, // CATEGORY
+			item.Name,     // NAME
+			item.Value,    // VALUE
+		))
+	}
+}
+
+func (e *memtableRetriever) setDataForOptimizerFixControls(ctx context.Context, sctx sessionctx.Context) error {
+	globalVal, err := sctx.GetSessionVars().GetGlobalSystemVar(ctx, variable.TiDBOptFixControl)
+	if err != nil {
+		return err
+	}
+	globalMap, _, err := fixcontrol.ParseToMap(globalVal)
+	if err != nil {
+		return err
+	}
+	sessionMap := sctx.GetSessionVars().GetOptimizerFixControlMap()
+	for _, info := range fixcontrol.AllInfos() {
+		row := types.MakeDatums(
+			info.ID,          // FIX_ID
+			info.Type,        // VALUE_TYPE
+			info.Default,     // DEFAULT_VALUE
+			nil,              // GLOBAL_VALUE
+			nil,              // SESSION_VALUE
+			"DEFAULT",        // SCOPE
+			info.Description, // DESCRIPTION
+		)
+		globalValue, globalExists := fixcontrol.GetStr(globalMap, info.ID)
+		if globalExists {
+			row[3].SetString(globalValue, mysql.DefaultCollationName)
+		}
+		if sessionValue, exists := fixcontrol.GetStr(sessionMap, info.ID); exists {
+			row[4].SetString(sessionValue, mysql.DefaultCollationName)
+			if globalExists && sessionValue == globalValue {
+				row[5].SetString("GLOBAL", mysql.DefaultCollationName)
+			} else {
+				row[5].SetString("SESSION", mysql.DefaultCollationName)
+			}
+		}
+		e.rows = append(e.rows, row)
+	}
+	return nil
+}
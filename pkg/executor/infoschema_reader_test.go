@@ -16,22 +16,32 @@ package executor_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/kvproto/pkg/kvrpcpb"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/store/mockstore"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/audit"
+	"github.com/pingcap/tidb/pkg/util/generallog"
+	"github.com/pingcap/tidb/pkg/util/sem"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
+	"github.com/pingcap/tidb/pkg/util/tablelatency"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/client-go/v2/tikv"
 	"github.com/tikv/client-go/v2/tikvrpc"
@@ -372,6 +382,87 @@ func TestForServersInfo(t *testing.T) {
 	require.Equal(t, stringutil.BuildStringFromLabels(info.Labels), rows[0][8])
 }
 
+func TestForServersHistory(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set time_zone = '+00:00'")
+	info, err := infosync.GetServerInfo()
+	require.NoError(t, err)
+	// the servers found when the domain starts are recorded as joining.
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from information_schema.TIDB_SERVERS_HISTORY").Rows()) > 0
+	}, 5*time.Second, 100*time.Millisecond)
+	tk.MustQuery("select event, ddl_id, ip, port, version from information_schema.TIDB_SERVERS_HISTORY").Check(testkit.Rows(
+		fmt.Sprintf("JOIN %s %s %d %s", info.ID, info.IP, info.Port, info.Version)))
+
+	// the IPs are hidden from the users without RESTRICTED_TABLES_ADMIN when SEM is enabled.
+	tk.MustExec("create user 'servers_history_user'")
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "servers_history_user", Hostname: "%"}, nil, nil, nil))
+	sem.Enable()
+	tk2.MustQuery("select ddl_id, ip from information_schema.TIDB_SERVERS_HISTORY").Check(testkit.Rows(info.ID + " <nil>"))
+	tk.MustExec("grant restricted_tables_admin on *.* to 'servers_history_user'")
+	tk2.MustQuery("select ddl_id, ip from information_schema.TIDB_SERVERS_HISTORY").Check(testkit.Rows(info.ID + " " + info.IP))
+	sem.Disable()
+
+	fpName := "github.com/pingcap/tidb/pkg/domain/infosync/mockGetAllServerInfo"
+	require.NoError(t, failpoint.Enable(fpName, "return(`{\"s2\":{\"ddl_id\":\"s2\",\"ip\":\"127.0.0.2\",\"listening_port\":4001,"+
+		"\"status_port\":10081,\"version\":\"v2\",\"git_hash\":\"h2\",\"start_timestamp\":1700000000}}`)"))
+	defer func() { require.NoError(t, failpoint.Disable(fpName)) }()
+	require.NoError(t, infosync.UpdateServersHistory(context.Background()))
+	tk.MustQuery("select event, ddl_id from information_schema.TIDB_SERVERS_HISTORY").Check(testkit.Rows(
+		"JOIN "+info.ID, "JOIN s2", "LEAVE "+info.ID))
+	tk.MustQuery("select time, ip, port, status_port, version, git_hash, start_time from information_schema.TIDB_SERVERS_HISTORY where ddl_id = 's2'").Check(testkit.Rows(
+		"2023-11-14 22:13:20 127.0.0.2 4001 10081 v2 h2 2023-11-14 22:13:20"))
+}
+
+func TestTableLatencyStats(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tablelatency.GlobalCollector.Reset()
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int, c int, key ib (b), key ic (c))")
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("insert into t values (1, 1, 1), (2, 2, 2)")
+	tk.MustQuery("select a from t use index (ib) where b = 1").Check(testkit.Rows("1"))
+	tk.MustQuery("select c from t use index (ic) where c > 1").Check(testkit.Rows("2"))
+	tk.MustQuery("select a from t use index (ib) where b = 2").Check(testkit.Rows("2"))
+	tk.MustExecToErr("insert into t values (1, 1, 1)")
+
+	sql := "select table_schema, table_name, index_name, query_total, error_total, " +
+		"max_latency >= p50_latency and p99_latency >= p50_latency and sum_latency >= max_latency, qps > 0 " +
+		"from information_schema.table_latency_stats"
+	tk.MustQuery(sql).Check(testkit.Rows(
+		"test t <nil> 5 1 1 1",
+		"test t ib 2 0 1 1",
+		"test t ic 1 0 1 1",
+	))
+	rows := tk.MustQuery("select latency_histogram from information_schema.table_latency_stats where index_name = 'ic'").Rows()
+	require.Len(t, rows, 1)
+	histogram := make(map[string]uint64)
+	require.NoError(t, json.Unmarshal([]byte(rows[0][0].(string)), &histogram))
+	require.Len(t, histogram, len(tablelatency.BucketBounds)+1)
+	var total uint64
+	for _, count := range histogram {
+		total += count
+	}
+	require.Equal(t, uint64(1), total)
+
+	// the latency of the dropped objects are removed.
+	tk.MustExec("alter table t drop index ic")
+	tk.MustQuery("select * from t2").Check(testkit.Rows())
+	tk.MustQuery("select table_name, index_name, query_total from information_schema.table_latency_stats").Check(testkit.Rows(
+		"t <nil> 5", "t ib 2", "t2 <nil> 1"))
+
+	// the objects without any privileges are filtered out.
+	tk.MustExec("create user 'latency_user'@'%'")
+	tk.MustExec("grant select on test.t2 to 'latency_user'@'%'")
+	tkUser := testkit.NewTestKit(t, store)
+	require.NoError(t, tkUser.Session().Auth(&auth.UserIdentity{Username: "latency_user", Hostname: "%"}, nil, nil, nil))
+	tkUser.MustQuery("select table_name, index_name, query_total from information_schema.table_latency_stats").Check(testkit.Rows(
+		"t2 <nil> 1"))
+}
+
 func TestTiFlashSystemTableWithTiFlashV620(t *testing.T) {
 	instances := []string{
 		"tiflash,127.0.0.1:3933,127.0.0.1:7777,,",
@@ -605,3 +696,286 @@ func newGetTiFlashSystemTableRequestMocker(t *testing.T) *getTiFlashSystemTableR
 		t:        t,
 	}
 }
+
+func TestAuditRulesAndLog(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	filename := filepath.Join(t.TempDir(), "tidb-audit.log")
+	require.NoError(t, audit.Setup(&audit.Config{Filename: filename}))
+	defer func() {
+		tk.MustExec("set global tidb_audit_log_enabled = off")
+		tk.MustExec("set global tidb_audit_log_rules = ''")
+		audit.Close()
+	}()
+
+	tk.MustGetErrMsg(`set global tidb_audit_log_rules = '[{"class":"unknown"}]'`, "invalid statement class 'UNKNOWN' in audit log rules")
+	tk.MustExec(`set global tidb_audit_log_rules = '[{"user":"root","db":"test","class":"ddl"},{"class":"dml"}]'`)
+	tk.MustQuery("select * from information_schema.audit_rules").Check(testkit.Rows(
+		"1 root test DDL",
+		"2 * * DML",
+	))
+	tk.MustExec("create user 'audit_user'")
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "audit_user", Hostname: "%"}, nil, nil, nil))
+	tk2.MustQuery("select * from information_schema.audit_rules").Check(testkit.Rows())
+
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table audit_t (a int primary key, b varchar(10))")
+	tk.MustExec("set global tidb_audit_log_enabled = on")
+	tk.MustExec("create table audit_t2 (a int)")
+	tk.MustExec("insert into audit_t values (1, 'secret')")
+	tk.MustQuery("select * from audit_t").Check(testkit.Rows("1 secret"))
+	tk.MustGetErrCode("insert into audit_t values ('x', 'y')", mysql.ErrTruncatedWrongValueForField)
+	audit.Close()
+
+	content, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 3)
+	entries := make([]audit.Entry, len(lines))
+	for i, line := range lines {
+		require.NoError(t, json.Unmarshal([]byte(line), &entries[i]))
+		require.Equal(t, "root", entries[i].User)
+		require.Equal(t, "test", entries[i].DB)
+	}
+	require.Equal(t, audit.StmtClassDDL, entries[0].StmtClass)
+	require.Equal(t, "create table `audit_t2` ( `a` int )", entries[0].Query)
+	require.Equal(t, audit.StmtClassDML, entries[1].StmtClass)
+	require.Equal(t, "insert into `audit_t` values ( ... )", entries[1].Query)
+	require.Equal(t, []string{"test.audit_t"}, entries[1].Tables)
+	require.True(t, entries[1].Succ)
+	require.False(t, entries[2].Succ)
+	require.Contains(t, entries[2].Error, "Incorrect int value")
+}
+
+func TestAuditLogPrivilegeChange(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	filename := filepath.Join(t.TempDir(), "tidb-audit.log")
+	require.NoError(t, audit.Setup(&audit.Config{Filename: filename}))
+	defer func() {
+		tk.MustExec("set global tidb_audit_log_enabled = off")
+		tk.MustExec("set global tidb_audit_log_rules = ''")
+		audit.Close()
+	}()
+
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create user 'audit_grantee'")
+	tk.MustExec(`set global tidb_audit_log_rules = '[{"class":"dcl"}]'`)
+	tk.MustExec("set global tidb_audit_log_enabled = on")
+	tk.MustExec("grant select, update (a) on t to 'audit_grantee' with grant option")
+	tk.MustExec("revoke select on test.t from 'audit_grantee'")
+	audit.Close()
+
+	content, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	var events []audit.Entry
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		var entry audit.Entry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		require.Equal(t, audit.StmtClassDCL, entry.StmtClass)
+		if entry.Event != "" {
+			events = append(events, entry)
+		}
+	}
+	require.Len(t, events, 2)
+	require.Equal(t, "GRANT", events[0].Event)
+	require.Equal(t, "root", events[0].User)
+	require.Equal(t, []string{"SELECT", "UPDATE (a)"}, events[0].Privileges)
+	require.Equal(t, "test.t", events[0].Level)
+	require.Equal(t, []string{"audit_grantee@%"}, events[0].Grantees)
+	require.Equal(t, "REVOKE", events[1].Event)
+	require.Equal(t, []string{"SELECT"}, events[1].Privileges)
+	require.Equal(t, "test.t", events[1].Level)
+}
+
+func TestAuditLogFailedStmt(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	filename := filepath.Join(t.TempDir(), "tidb-audit.log")
+	require.NoError(t, audit.Setup(&audit.Config{Filename: filename}))
+	defer func() {
+		tk.MustExec("set global tidb_audit_log_enabled = off")
+		tk.MustExec("set global tidb_audit_log_rules = ''")
+		audit.Close()
+	}()
+
+	tk.MustExec("create table test.t (a int)")
+	tk.MustExec("create user 'audit_denied'")
+	tk.MustExec(`set global tidb_audit_log_rules = '[{"user":"audit_denied"}]'`)
+	tk.MustExec("set global tidb_audit_log_enabled = on")
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "audit_denied", Hostname: "%"}, nil, nil, nil))
+	// the statements rejected by the privilege check or failing to compile are audited too.
+	tk2.MustGetErrCode("select * from test.t", mysql.ErrTableaccessDenied)
+	tk2.MustGetErrCode("insert into test.t values (1)", mysql.ErrTableaccessDenied)
+	tk2.MustGetErrCode("select not_exist from dual", mysql.ErrBadField)
+	audit.Close()
+
+	content, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 3)
+	entries := make([]audit.Entry, len(lines))
+	for i, line := range lines {
+		require.NoError(t, json.Unmarshal([]byte(line), &entries[i]))
+		require.Equal(t, "audit_denied", entries[i].User)
+		require.False(t, entries[i].Succ)
+	}
+	require.Equal(t, audit.StmtClassQuery, entries[0].StmtClass)
+	require.Equal(t, "select * from `test` . `t`", entries[0].Query)
+	require.Contains(t, entries[0].Error, "SELECT command denied")
+	require.Equal(t, audit.StmtClassDML, entries[1].StmtClass)
+	require.Contains(t, entries[1].Error, "INSERT command denied")
+	require.Contains(t, entries[2].Error, "Unknown column")
+}
+
+func TestGeneralLogTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	generallog.GlobalHistory.Clear()
+	defer func() {
+		tk.MustExec("set @@global.tidb_general_log = off")
+		tk.MustExec("set @@global.tidb_general_log_output = default")
+		tk.MustExec("set @@global.tidb_general_log_users = default")
+		tk.MustExec("set @@global.tidb_general_log_sample_rate = default")
+		generallog.GlobalHistory.Clear()
+	}()
+
+	tk.MustGetErrMsg("set @@global.tidb_general_log_output = 'none'", "invalid general log output 'none'")
+	tk.MustExec("set @@global.tidb_general_log_output = 'table,file'")
+	tk.MustQuery("select @@global.tidb_general_log_output").Check(testkit.Rows("FILE,TABLE"))
+	tk.MustExec("set @@global.tidb_general_log_output = 'table'")
+	tk.MustExec("create user 'general_log_user'")
+	tk.MustExec("grant select on test.* to 'general_log_user'")
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "general_log_user", Hostname: "%"}, nil, nil, nil))
+
+	tk.MustExec("set @@global.tidb_general_log = on")
+	tk.MustExec("use test")
+	tk.MustExec("select 1")
+	tk2.MustExec("select 2")
+	tk.MustExec("set @@global.tidb_general_log_users = 'general_log_user'")
+	tk.MustExec("select 3")
+	tk2.MustExec("select 4")
+	tk.MustExec("set @@global.tidb_general_log_sample_rate = 0")
+	tk2.MustExec("select 5")
+	tk.MustExec("set @@global.tidb_general_log = off")
+
+	tk.MustQuery("select user, db, query from information_schema.tidb_general_log where query like 'select _'").Check(testkit.Rows(
+		"root test select 1",
+		"general_log_user  select 2",
+		"general_log_user  select 4",
+	))
+	tk2.MustQuery("select user, query from information_schema.tidb_general_log where query like 'select _'").Check(testkit.Rows(
+		"general_log_user select 2",
+		"general_log_user select 4",
+	))
+}
+
+func TestClusterEncryptionStatus(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+
+	var rotated atomic.Int64
+	mux := http.NewServeMux()
+	mux.HandleFunc("/encryption/status", func(w http.ResponseWriter, _ *http.Request) {
+		keyID := 1 + rotated.Load()
+		fmt.Fprintf(w, `{"method":"aes256-ctr","master_key_type":"kms","current_key_id":%d,"key_creation_time":0,"data_key_count":%d,"file_count":4,"rotated_file_count":%d}`,
+			keyID, keyID, 4-rotated.Load())
+	})
+	mux.HandleFunc("/encryption/rotate", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		rotated.Add(1)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	statusAddr := strings.TrimPrefix(server.URL, "http://")
+
+	instances := []string{
+		"tidb,127.0.0.1:4000,127.0.0.1:10080,mock-version,mock-githash,1001",
+		"tikv,127.0.0.1:20160," + statusAddr + ",mock-version,mock-githash,0",
+	}
+	fpName := "github.com/pingcap/tidb/pkg/infoschema/mockClusterInfo"
+	require.NoError(t, failpoint.Enable(fpName, `return("`+strings.Join(instances, ";")+`")`))
+	defer func() { require.NoError(t, failpoint.Disable(fpName)) }()
+
+	query := "select type, instance, encryption_method, master_key_type, current_key_id, key_creation_time, data_key_count, file_count, rotated_file_count, rotation_progress from information_schema.cluster_encryption_status"
+	tk.MustQuery(query).Check(testkit.Rows("tikv 127.0.0.1:20160 aes256-ctr kms 1 <nil> 1 4 4 100"))
+	tk.MustExec("admin rotate encryption key")
+	tk.MustQuery(query).Check(testkit.Rows("tikv 127.0.0.1:20160 aes256-ctr kms 2 <nil> 2 4 3 75"))
+
+	tk.MustExec("create user 'encryption_user'")
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "encryption_user", Hostname: "%"}, nil, nil, nil))
+	require.ErrorContains(t, tk2.QueryToErr(query), "you need (at least one of) the SUPER privilege(s)")
+	tk2.MustGetErrCode("admin rotate encryption key", errno.ErrPrivilegeCheckFail)
+}
+
+func TestLogBackupTables(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+
+	// the mock store has no log backup metadata.
+	tk.MustQuery("select * from information_schema.log_backup_tasks").Check(testkit.Rows())
+	tk.MustQuery("select * from information_schema.log_backup_checkpoints").Check(testkit.Rows())
+	tk.MustQuery("select * from information_schema.log_backup_errors").Check(testkit.Rows())
+	tk.MustContainErrMsg("admin pause log backup", "log backup is not supported by the current storage")
+	tk.MustContainErrMsg("admin resume log backup 'pitr'", "log backup is not supported by the current storage")
+
+	tk.MustExec("create user 'log_backup_user'")
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "log_backup_user", Hostname: "%"}, nil, nil, nil))
+	require.ErrorContains(t, tk2.QueryToErr("select * from information_schema.log_backup_tasks"), "you need (at least one of) the SUPER privilege(s)")
+	tk2.MustGetErrCode("admin pause log backup", errno.ErrPrivilegeCheckFail)
+}
+
+func TestForeignKeyConstraints(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database fk_parent")
+	tk.MustExec("create database fk_child")
+	tk.MustExec("create table fk_parent.p (id int primary key, a int, b int, c int, unique key uk_ab(a, b), key idx_c(c))")
+	tk.MustExec("create table fk_child.c (id int primary key, pid int, a int, b int, c int, " +
+		"constraint fk_pk foreign key (pid) references fk_parent.p(id) on delete cascade, " +
+		"constraint fk_uk foreign key (a, b) references fk_parent.p(a, b) on update set null, " +
+		"constraint fk_idx foreign key (c) references fk_parent.p(c) on update restrict on delete set null)")
+
+	tk.MustQuery("select constraint_schema, constraint_name, unique_constraint_schema, unique_constraint_name, update_rule, delete_rule, table_name, referenced_table_name " +
+		"from information_schema.referential_constraints where constraint_schema = 'fk_child' order by constraint_name").Check(testkit.Rows(
+		"fk_child fk_idx fk_parent idx_c RESTRICT SET NULL c p",
+		"fk_child fk_pk fk_parent PRIMARY NO ACTION CASCADE c p",
+		"fk_child fk_uk fk_parent uk_ab SET NULL NO ACTION c p",
+	))
+	tk.MustQuery("select constraint_name, column_name, ordinal_position, position_in_unique_constraint, referenced_table_schema, referenced_table_name, referenced_column_name " +
+		"from information_schema.key_column_usage where table_schema = 'fk_child' and referenced_table_name is not null order by constraint_name, ordinal_position").Check(testkit.Rows(
+		"fk_idx c 1 1 fk_parent p c",
+		"fk_pk pid 1 1 fk_parent p id",
+		"fk_uk a 1 1 fk_parent p a",
+		"fk_uk b 2 2 fk_parent p b",
+	))
+	tk.MustQuery("select constraint_schema, constraint_name, table_schema, table_name, constraint_type from information_schema.table_constraints " +
+		"where table_schema = 'fk_child' order by constraint_name").Check(testkit.Rows(
+		"fk_child PRIMARY fk_child c PRIMARY KEY",
+		"fk_child fk_idx fk_child c FOREIGN KEY",
+		"fk_child fk_pk fk_child c FOREIGN KEY",
+		"fk_child fk_uk fk_child c FOREIGN KEY",
+	))
+
+	// The referenced table is dropped when the foreign key checks are off.
+	tk.MustExec("set @@foreign_key_checks = 0")
+	tk.MustExec("drop table fk_parent.p")
+	tk.MustQuery("select constraint_name, unique_constraint_schema, unique_constraint_name from information_schema.referential_constraints " +
+		"where constraint_schema = 'fk_child' order by constraint_name").Check(testkit.Rows(
+		"fk_idx fk_parent <nil>",
+		"fk_pk fk_parent <nil>",
+		"fk_uk fk_parent <nil>",
+	))
+}
@@ -50,6 +50,9 @@ type InsertExec struct {
 	evalBuffer4Dup chunk.MutRow
 	curInsertVals  chunk.MutRow
 	row4Update     []types.Datum
+	// dupUpdateMayChangeKeys indicates whether updating the duplicated rows may change the handles or the unique keys,
+	// see initDupUpdateMayChangeKeys.
+	dupUpdateMayChangeKeys bool
 
 	Priority mysql.PriorityEnum
 }
@@ -173,18 +176,53 @@ func prefetchConflictedOldRows(ctx context.Context, txn kv.Transaction, rows []t
 	return err
 }
 
-func (e *InsertValues) prefetchDataCache(ctx context.Context, txn kv.Transaction, rows []toBeCheckedRow) error {
+// prefetchDataCache fills the cache of the transaction with the keys of the rows and the conflicted old rows, it
+// returns the existing values of the keys, or nil if the table is not prefetched.
+func (e *InsertValues) prefetchDataCache(ctx context.Context, txn kv.Transaction, rows []toBeCheckedRow) (map[string][]byte, error) {
 	// Temporary table need not to do prefetch because its all data are stored in the memory.
 	if e.Table.Meta().TempTableType != model.TempTableNone {
-		return nil
+		return nil, nil
 	}
 
 	defer tracing.StartRegion(ctx, "prefetchDataCache").End()
 	values, err := prefetchUniqueIndices(ctx, txn, rows)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return values, prefetchConflictedOldRows(ctx, txn, rows, values)
+}
+
+// rowsWithoutConflict returns whether each row can be inserted without checking the duplicated keys one by one, i.e.
+// none of its keys exists according to the prefetched values or is shared with other rows of the batch.
+func rowsWithoutConflict(rows []toBeCheckedRow, values map[string][]byte) []bool {
+	keyCnt := make(map[string]int, len(rows))
+	for _, r := range rows {
+		if r.handleKey != nil {
+			keyCnt[string(r.handleKey.newKey)]++
+		}
+		for _, uk := range r.uniqueKeys {
+			keyCnt[string(uk.newKey)]++
+		}
+	}
+	noConflict := make([]bool, len(rows))
+	isConflicted := func(key kv.Key) bool {
+		_, found := values[string(key)]
+		// The value of a temp index key may be a deleted mark, leave it to FetchDuplicatedHandle.
+		return found || keyCnt[string(key)] > 1 || tablecodec.IsTempIndexKey(key)
 	}
-	return prefetchConflictedOldRows(ctx, txn, rows, values)
+	for i, r := range rows {
+		if r.ignored || (r.handleKey != nil && isConflicted(r.handleKey.newKey)) {
+			continue
+		}
+		noConflict[i] = true
+		for _, uk := range r.uniqueKeys {
+			if isConflicted(uk.newKey) {
+				noConflict[i] = false
+				break
+			}
+		}
+	}
+	return noConflict
 }
 
 // updateDupRow updates a duplicate row to a new row.
@@ -229,15 +267,30 @@ func (e *InsertExec) batchUpdateDupRows(ctx context.Context, newRows [][]types.D
 	}
 	prefetchStart := time.Now()
 	// Use BatchGet to fill cache.
-	// It's an optimization and could be removed without affecting correctness.
-	if err = e.prefetchDataCache(ctx, txn, toBeCheckedRows); err != nil {
+	values, err := e.prefetchDataCache(ctx, txn, toBeCheckedRows)
+	if err != nil {
 		return err
 	}
 	if e.stats != nil {
 		e.stats.Prefetch += time.Since(prefetchStart)
 	}
 
+	// Split the rows into the ones which can be inserted directly and the ones which may conflict with the existing
+	// rows or the other rows of the batch, the latter are checked and updated one by one in the order of the rows.
+	var noConflict []bool
+	if values != nil {
+		noConflict = rowsWithoutConflict(toBeCheckedRows, values)
+	}
+	updated := false
 	for i, r := range toBeCheckedRows {
+		// An updated row may take the keys of the following rows, which are not known when prefetching.
+		if noConflict != nil && noConflict[i] && !(updated && e.dupUpdateMayChangeKeys) {
+			if err := e.addRecord(ctx, newRows[i]); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if r.handleKey != nil {
 			handle, err := tablecodec.DecodeRowKey(r.handleKey.newKey)
 			if err != nil {
@@ -246,6 +299,7 @@ func (e *InsertExec) batchUpdateDupRows(ctx context.Context, newRows [][]types.D
 
 			err = e.updateDupRow(ctx, i, txn, r, handle, e.OnDuplicate)
 			if err == nil {
+				updated = true
 				continue
 			}
 			if !kv.IsErrNotFound(err) {
@@ -274,6 +328,7 @@ func (e *InsertExec) batchUpdateDupRows(ctx context.Context, newRows [][]types.D
 				return err
 			}
 
+			updated = true
 			newRows[i] = nil
 			break
 		}
@@ -337,6 +392,7 @@ func (e *InsertExec) Open(ctx context.Context) error {
 
 	if e.OnDuplicate != nil {
 		e.initEvalBuffer4Dup()
+		e.initDupUpdateMayChangeKeys()
 	}
 	if e.SelectExec != nil {
 		return exec.Open(ctx, e.SelectExec)
@@ -378,6 +434,53 @@ func (e *InsertExec) initEvalBuffer4Dup() {
 	e.row4Update = make([]types.Datum, 0, len(evalBufferTypes))
 }
 
+// initDupUpdateMayChangeKeys checks whether updating the duplicated rows may change the handles, the unique keys or
+// the partitions of the rows, it's conservative for the generated columns, the columns with ON UPDATE CURRENT_TIMESTAMP,
+// the non-public columns and the foreign key cascades.
+func (e *InsertExec) initDupUpdateMayChangeKeys() {
+	e.dupUpdateMayChangeKeys = len(e.fkCascades) > 0
+	if e.dupUpdateMayChangeKeys {
+		return
+	}
+	tblInfo := e.Table.Meta()
+	keyCols := make(map[int64]struct{})
+	if tblInfo.PKIsHandle {
+		if pkCol := tblInfo.GetPkColInfo(); pkCol != nil {
+			keyCols[pkCol.ID] = struct{}{}
+		}
+	}
+	for _, idx := range e.Table.Indices() {
+		if !tables.IsIndexWritable(idx) || !idx.Meta().Unique {
+			continue
+		}
+		for _, idxCol := range idx.Meta().Columns {
+			keyCols[tblInfo.Columns[idxCol.Offset].ID] = struct{}{}
+		}
+	}
+	if pt, ok := e.Table.(table.PartitionedTable); ok {
+		for _, id := range pt.GetPartitionColumnIDs() {
+			keyCols[id] = struct{}{}
+		}
+	}
+	assigned := make([]bool, len(e.Table.WritableCols()))
+	for _, assign := range e.OnDuplicate {
+		assigned[assign.Col.Index] = true
+	}
+	for i, col := range e.Table.WritableCols() {
+		if col.State != model.StatePublic {
+			e.dupUpdateMayChangeKeys = true
+			return
+		}
+		if _, ok := keyCols[col.ID]; !ok {
+			continue
+		}
+		if assigned[i] || col.IsGenerated() || mysql.HasOnUpdateNowFlag(col.GetFlag()) {
+			e.dupUpdateMayChangeKeys = true
+			return
+		}
+	}
+}
+
 // doDupRowUpdate updates the duplicate row.
 func (e *InsertExec) doDupRowUpdate(ctx context.Context, handle kv.Handle, oldRow []types.Datum, newRow []types.Datum,
 	extraCols []types.Datum, cols []*expression.Assignment, idxInBatch int) error {
@@ -468,5 +571,5 @@ func (e *InsertExec) GetFKCascades() []*FKCascadeExec {
 
 // HasFKCascades implements WithForeignKeyTrigger interface.
 func (e *InsertExec) HasFKCascades() bool {
-	return len(e.fkCascades) > 0
+	return len(e.fkCascades) > 0 || len(e.afterTriggers) > 0
 }
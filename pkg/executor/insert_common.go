@@ -95,6 +95,10 @@ type InsertValues struct {
 	// fkChecks contains the foreign key checkers.
 	fkChecks   []*FKCheckExec
 	fkCascades []*FKCascadeExec
+
+	// beforeTriggers are the assignments to the `NEW` row of the BEFORE INSERT triggers.
+	beforeTriggers []*expression.Assignment
+	afterTriggers  []*TriggerExec
 }
 
 type defaultVal struct {
@@ -144,8 +148,13 @@ func (e *InsertValues) initInsertColumns() error {
 			)
 		}
 	} else {
-		// If e.Columns are empty, use all columns instead.
-		cols = tableCols
+		// If e.Columns are empty, use all columns except the invisible ones instead.
+		cols = make([]*table.Column, 0, len(tableCols))
+		for _, col := range tableCols {
+			if !col.Invisible {
+				cols = append(cols, col)
+			}
+		}
 	}
 	for _, col := range cols {
 		if !col.IsGenerated() {
@@ -512,6 +521,9 @@ func insertRowsFromSelect(ctx context.Context, base insertCommon) error {
 		if err != nil {
 			return err
 		}
+		if err = flushPipelinedMemDB(e.Ctx()); err != nil {
+			return err
+		}
 		rows = rows[:0]
 		extraColsInSel = extraColsInSel[:0]
 		memTracker.Consume(-memUsageOfRows)
@@ -708,6 +720,22 @@ func (e *InsertValues) fillRow(ctx context.Context, row []types.Datum, hasValue
 	sctx := e.Ctx()
 	exprCtx := sctx.GetExprCtx()
 	sc := sctx.GetSessionVars().StmtCtx
+	// The BEFORE INSERT triggers are executed after the real columns are set, and the generated columns are
+	// evaluated with the values assigned by the triggers.
+	for _, asgn := range e.beforeTriggers {
+		col := tCols[asgn.Col.Index]
+		val, err := asgn.Expr.Eval(exprCtx, chunk.MutRowFromDatums(row).ToRow())
+		if err != nil {
+			return nil, err
+		}
+		row[asgn.Col.Index], err = table.CastValue(sctx, val, col.ToInfo(), false, false)
+		if err = e.handleErr(col, &val, rowIdx, err); err != nil {
+			return nil, err
+		}
+		if err = col.HandleBadNull(sc.ErrCtx(), &row[asgn.Col.Index], rowCntInLoadData); err != nil {
+			return nil, err
+		}
+	}
 	warnCnt := int(sc.WarningCount())
 	for i, gCol := range gCols {
 		colIdx := gCol.ColumnInfo.Offset
@@ -1422,9 +1450,17 @@ func (e *InsertValues) addRecordWithAutoIDHint(
 			}
 		}
 	}
+	for _, tr := range e.afterTriggers {
+		tr.onInsertRow(row)
+	}
 	return nil
 }
 
+// GetAfterTriggers implements WithAfterTrigger interface.
+func (e *InsertValues) GetAfterTriggers() []*TriggerExec {
+	return e.afterTriggers
+}
+
 // CreateSession will be assigned by session package.
 var CreateSession func(ctx sessionctx.Context) (sessionctx.Context, error)
 
@@ -592,3 +592,37 @@ func TestInsertLockUnchangedKeys(t *testing.T) {
 		}
 	}
 }
+
+func TestInsertOnDuplicateKeyBatchConflict(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	for _, pk := range []string{"clustered", "nonclustered"} {
+		tk.MustExec("drop table if exists t")
+		tk.MustExec(fmt.Sprintf("create table t(a varchar(10), b int, c int, primary key(a) %s, unique key(b))", pk))
+		tk.MustExec("insert into t values ('1', 10, 0), ('2', 20, 0)")
+		// The rows conflict with the existing rows and the former rows of the batch.
+		tk.MustExec("insert into t values ('3', 30, 1), ('1', 11, 1), ('4', 40, 1), ('3', 31, 1), ('5', 20, 1) on duplicate key update c = c + values(c)")
+		require.Equal(t, uint64(8), tk.Session().AffectedRows())
+		tk.CheckLastMessage("Records: 5  Duplicates: 3  Warnings: 0")
+		tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 10 1", "2 20 1", "3 30 2", "4 40 1"))
+
+		// The updated row takes the unique key of the following row.
+		tk.MustExec("delete from t")
+		tk.MustExec("insert into t values ('1', 10, 0)")
+		tk.MustExec("insert into t values ('1', 10, 0), ('2', 20, 0) on duplicate key update b = values(b) + 10")
+		require.Equal(t, uint64(4), tk.Session().AffectedRows())
+		tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 30 0"))
+
+		// The rows are inserted in a transaction with uncommitted changes.
+		tk.MustExec("begin pessimistic")
+		tk.MustExec("delete from t where a = '1'")
+		tk.MustExec("insert into t values ('1', 30, 1), ('6', 60, 1) on duplicate key update c = c + values(c)")
+		require.Equal(t, uint64(2), tk.Session().AffectedRows())
+		tk.MustExec("insert into t values ('6', 61, 1), ('7', 70, 1) on duplicate key update c = c + values(c)")
+		require.Equal(t, uint64(3), tk.Session().AffectedRows())
+		tk.MustExec("commit")
+		tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 30 1", "6 60 2", "7 70 1"))
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/memory"
+	"github.com/tikv/client-go/v2/tikv"
 	"go.uber.org/zap"
 )
 
@@ -78,11 +79,18 @@ func NewExecutorWithRetry(ctx context.Context, sctx sessionctx.Context, parentTr
 	disaggTiFlashWithAutoScaler := config.GetGlobalConfig().DisaggregatedTiFlash && config.GetGlobalConfig().UseAutoScaler
 	_, allowTiFlashFallback := sctx.GetSessionVars().AllowFallbackToTiKV[kv.TiFlash]
 
-	// 1. For now, mpp err recovery only support MemLimit, which is only useful when AutoScaler is used.
+	// 1. For now, mpp err recovery only support MemLimit and node failure. MemLimit recovery is only useful when AutoScaler is used,
+	//    node failure recovery is enabled by tidb_enable_mpp_node_failure_recovery.
 	// 2. When enable fallback to tikv, the returned mpp err will be ErrTiFlashServerTimeout,
 	//    which we cannot handle for now. Also there is no need to recovery because tikv will retry the query.
 	// 3. For cached table, will not dispatch tasks to TiFlash, so no need to recovery.
-	enableMPPRecovery := disaggTiFlashWithAutoScaler && !allowTiFlashFallback
+	var tikvClient tikv.Client
+	if sctx.GetSessionVars().EnableMPPNodeFailureRecovery {
+		if tikvStore, ok := sctx.GetStore().(tikv.Storage); ok {
+			tikvClient = tikvStore.GetTiKVClient()
+		}
+	}
+	enableMPPRecovery := (disaggTiFlashWithAutoScaler || tikvClient != nil) && !allowTiFlashFallback
 
 	failpoint.Inject("mpp_recovery_test_mock_enable", func() {
 		if !allowTiFlashFallback {
@@ -91,7 +99,7 @@ func NewExecutorWithRetry(ctx context.Context, sctx sessionctx.Context, parentTr
 	})
 
 	recoveryHandler := NewRecoveryHandler(disaggTiFlashWithAutoScaler,
-		uint64(holdCap), enableMPPRecovery, parentTracker)
+		uint64(holdCap), enableMPPRecovery, parentTracker, tikvClient)
 	memTracker := memory.NewTracker(parentTracker.Label(), 0)
 	memTracker.AttachTo(parentTracker)
 	retryer := &ExecutorWithRetry{
@@ -94,6 +94,28 @@ func (m *mppResponse) RespTime() time.Duration {
 	return m.respTime
 }
 
+// nodeFailureError indicates that an mpp task fails because its TiFlash node can't be reached,
+// the tasks of the query can be rescheduled onto the other TiFlash nodes to recover it.
+type nodeFailureError struct {
+	addr string
+	err  error
+}
+
+// Error implements the error interface.
+func (e *nodeFailureError) Error() string {
+	return e.err.Error()
+}
+
+// Cause keeps the original error visible to errors.Cause.
+func (e *nodeFailureError) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the original error.
+func (e *nodeFailureError) Unwrap() error {
+	return e.err
+}
+
 type mppRequestReport struct {
 	mppReq             *kv.MPPDispatchRequest
 	errMsg             string
@@ -439,6 +461,8 @@ func (c *localMppCoordinator) handleDispatchReq(ctx context.Context, bo *backoff
 		// if NeedTriggerFallback is true, we return timeout to trigger tikv's fallback
 		if c.needTriggerFallback {
 			err = derr.ErrTiFlashServerTimeout
+		} else {
+			err = &nodeFailureError{addr: req.Meta.GetAddress(), err: err}
 		}
 		c.sendError(err)
 		return
@@ -502,7 +526,7 @@ func (c *localMppCoordinator) receiveResults(req *kv.MPPDispatchRequest, taskMet
 		if c.needTriggerFallback {
 			c.sendError(derr.ErrTiFlashServerTimeout)
 		} else {
-			c.sendError(err)
+			c.sendError(&nodeFailureError{addr: req.Meta.GetAddress(), err: err})
 		}
 		return
 	}
@@ -533,7 +557,7 @@ func (c *localMppCoordinator) receiveResults(req *kv.MPPDispatchRequest, taskMet
 			if c.needTriggerFallback {
 				c.sendError(derr.ErrTiFlashServerTimeout)
 			} else {
-				c.sendError(err)
+				c.sendError(&nodeFailureError{addr: req.Meta.GetAddress(), err: err})
 			}
 			return
 		}
@@ -15,11 +15,17 @@
 package mpp
 
 import (
+	"context"
+	goerrors "errors"
 	"strings"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/store/copr"
+	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/pingcap/tidb/pkg/util/tiflashcompute"
+	"github.com/tikv/client-go/v2/tikv"
+	"go.uber.org/zap"
 )
 
 // RecoveryHandler tries to recovery mpp error.
@@ -44,10 +50,15 @@ const (
 )
 
 // NewRecoveryHandler returns new instance of RecoveryHandler.
-func NewRecoveryHandler(useAutoScaler bool, holderCap uint64, enable bool, parent *memory.Tracker) *RecoveryHandler {
+// If tikvClient is not nil, the mpp err caused by an unreachable TiFlash node is recovered by rescheduling the tasks.
+func NewRecoveryHandler(useAutoScaler bool, holderCap uint64, enable bool, parent *memory.Tracker, tikvClient tikv.Client) *RecoveryHandler {
+	handlers := []handlerImpl{newMemLimitHandlerImpl(useAutoScaler)}
+	if tikvClient != nil {
+		handlers = append(handlers, newNodeFailureHandlerImpl(tikvClient))
+	}
 	return &RecoveryHandler{
 		enable:   enable,
-		handlers: []handlerImpl{newMemLimitHandlerImpl(useAutoScaler)},
+		handlers: handlers,
 		holder:   newMPPResultHolder(holderCap, parent),
 		// Default recovery 3 time.
 		maxRecoveryCnt: 3,
@@ -157,6 +168,37 @@ func (*memLimitHandlerImpl) doRecovery(info *RecoveryInfo) error {
 	return nil
 }
 
+var _ handlerImpl = &nodeFailureHandlerImpl{}
+
+// nodeFailureHandlerImpl recovers the mpp err caused by an unreachable TiFlash node.
+// The fragments of the query are kept, only the tasks are rescheduled when they're dispatched again.
+// Since the failed node is marked in the failed store prober, the tasks are dispatched to the alive
+// TiFlash nodes which hold the other replicas of the regions.
+type nodeFailureHandlerImpl struct {
+	tikvClient tikv.Client
+}
+
+func newNodeFailureHandlerImpl(tikvClient tikv.Client) *nodeFailureHandlerImpl {
+	return &nodeFailureHandlerImpl{
+		tikvClient: tikvClient,
+	}
+}
+
+func (*nodeFailureHandlerImpl) chooseHandlerImpl(mppErr error) bool {
+	var nodeErr *nodeFailureError
+	return goerrors.As(mppErr, &nodeErr)
+}
+
+func (h *nodeFailureHandlerImpl) doRecovery(info *RecoveryInfo) error {
+	var nodeErr *nodeFailureError
+	if !goerrors.As(info.MPPErr, &nodeErr) {
+		return errors.New("mpp err is not caused by node failure")
+	}
+	logutil.BgLogger().Info("mark the failed tiflash node to reschedule mpp tasks", zap.String("address", nodeErr.addr))
+	copr.GlobalMPPFailedStoreProber.Add(context.Background(), nodeErr.addr, h.tikvClient)
+	return nil
+}
+
 type mppResultHolder struct {
 	memTracker *memory.Tracker
 	resps      []*mppResponse
@@ -0,0 +1,49 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/store/copr"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeFailureRecovery(t *testing.T) {
+	const addr = "tiflash-node-failure:3930"
+	originErr := errors.New("rpc error: code = Unavailable")
+	mppErr := errors.Trace(&nodeFailureError{addr: addr, err: originErr})
+	require.Equal(t, originErr.Error(), mppErr.Error())
+	require.Equal(t, originErr, errors.Cause(mppErr))
+
+	h := newNodeFailureHandlerImpl(nil)
+	require.True(t, h.chooseHandlerImpl(mppErr))
+	require.False(t, h.chooseHandlerImpl(originErr))
+	require.False(t, newMemLimitHandlerImpl(true).chooseHandlerImpl(mppErr))
+
+	ctx := context.Background()
+	require.True(t, copr.GlobalMPPFailedStoreProber.IsRecovery(ctx, addr, 0))
+	require.NoError(t, h.doRecovery(&RecoveryInfo{MPPErr: mppErr, NodeCnt: 2}))
+	require.False(t, copr.GlobalMPPFailedStoreProber.IsRecovery(ctx, addr, 0))
+	copr.GlobalMPPFailedStoreProber.Delete(addr)
+	require.Error(t, h.doRecovery(&RecoveryInfo{MPPErr: originErr, NodeCnt: 2}))
+
+	// The node failure is not recovered if the handler isn't registered.
+	handler := NewRecoveryHandler(false, 2, true, memory.NewTracker(-1, -1), nil)
+	require.ErrorContains(t, handler.Recovery(&RecoveryInfo{MPPErr: mppErr, NodeCnt: 2}), "no handler to recovery")
+}
@@ -73,6 +73,8 @@ type hashJoinCtx struct {
 	isNullAware        bool
 	memTracker         *memory.Tracker // track memory usage.
 	diskTracker        *disk.Tracker   // track disk usage.
+	// runtimeFilters are built from the build side and applied by the TiKV table readers of the probe side.
+	runtimeFilters []*joinRuntimeFilter
 }
 
 // probeSideTupleFetcher reads tuples from probeSideExec and send them to probeWorkers.
@@ -83,6 +85,10 @@ type probeSideTupleFetcher struct {
 	probeChkResourceCh chan *probeChkResource
 	probeResultChs     []chan *chunk.Chunk
 	requiredRows       int64
+	// sampledChks are the chunks read by the adaptive hash join before probing, they are probed first.
+	sampledChks []*chunk.Chunk
+	// drained indicates that probeSideExec has been drained while sampling.
+	drained bool
 }
 
 type probeWorker struct {
@@ -108,6 +114,8 @@ type probeWorker struct {
 	probeChkResourceCh   chan *probeChkResource
 	joinChkResourceCh    chan *chunk.Chunk
 	probeResultCh        chan *chunk.Chunk
+	// skewedKeyRows caches the matched build rows of the skewed join keys, it's nil if the adaptive hash join is disabled.
+	skewedKeyRows map[uint64][]chunk.Row
 }
 
 type buildWorker struct {
@@ -115,6 +123,10 @@ type buildWorker struct {
 	buildSideExec    exec.Executor
 	buildKeyColIdx   []int
 	buildNAKeyColIdx []int
+	// sampledChks are the chunks read by the adaptive hash join before building, they are built first.
+	sampledChks []*chunk.Chunk
+	// drained indicates that buildSideExec has been drained while sampling.
+	drained bool
 }
 
 // HashJoinExec implements the hash join algorithm.
@@ -130,6 +142,8 @@ type HashJoinExec struct {
 	waiterWg util.WaitGroupWrapper
 
 	prepared bool
+	// sidesSwapped indicates that the build side and the probe side are swapped by the adaptive hash join.
+	sidesSwapped bool
 }
 
 // probeChkResource stores the result of the join probe side fetch worker,
@@ -152,6 +166,8 @@ type hashjoinWorkerResult struct {
 
 // Close implements the Executor Close interface.
 func (e *HashJoinExec) Close() error {
+	// release the table readers waiting for the runtime filters
+	e.finishRuntimeFilters(false)
 	if e.closeCh != nil {
 		close(e.closeCh)
 	}
@@ -221,6 +237,16 @@ func (e *HashJoinExec) Open(ctx context.Context) error {
 	e.waiterWg = util.WaitGroupWrapper{}
 	e.closeCh = make(chan struct{})
 	e.finished.Store(false)
+	for _, rf := range e.runtimeFilters {
+		rf.reset()
+	}
+	e.resetAdaptiveState()
+	for _, w := range e.probeWorkers {
+		w.skewedKeyRows = nil
+		if e.Ctx().GetSessionVars().EnableAdaptiveHashJoin {
+			w.skewedKeyRows = make(map[uint64][]chunk.Row)
+		}
+	}
 
 	if e.RuntimeStats() != nil {
 		e.stats = &hashJoinRuntimeStats{
@@ -254,7 +280,7 @@ func (fetcher *probeSideTupleFetcher) fetchProbeSideChunks(ctx context.Context,
 			required := int(atomic.LoadInt64(&fetcher.requiredRows))
 			probeSideResult.SetRequiredRows(required, maxChunkSize)
 		}
-		err := exec.Next(ctx, fetcher.probeSideExec, probeSideResult)
+		err := fetcher.nextProbeSideChunk(ctx, probeSideResult)
 		failpoint.Inject("ConsumeRandomPanic", nil)
 		if err != nil {
 			fetcher.joinResultCh <- &hashjoinWorkerResult{
@@ -327,6 +353,19 @@ func (w *buildWorker) fetchBuildSideRows(ctx context.Context, chkCh chan<- *chun
 			return
 		}
 	})
+	for _, chk := range w.sampledChks {
+		select {
+		case <-doneCh:
+			return
+		case <-w.hashJoinCtx.closeCh:
+			return
+		case chkCh <- chk:
+		}
+	}
+	w.sampledChks = nil
+	if w.drained {
+		return
+	}
 	sessVars := w.hashJoinCtx.sessCtx.GetSessionVars()
 	for {
 		if w.hashJoinCtx.finished.Load() {
@@ -932,9 +971,14 @@ func (w *probeWorker) joinMatchedProbeSideRow2Chunk(probeKey uint64, probeSideRo
 		if rowPtr != nil {
 			buildSideRows = append(buildSideRows, *rowPtr)
 		}
+	} else if rows, ok := w.getSkewedKeyRows(probeKey, probeSideRow, hCtx); ok {
+		buildSideRows = rows
 	} else {
 		w.buildSideRows, err = w.rowContainerForProbe.GetMatchedRows(probeKey, probeSideRow, hCtx, w.buildSideRows)
 		buildSideRows = w.buildSideRows
+		if err == nil {
+			w.tryCacheSkewedKey(probeKey, buildSideRows)
+		}
 	}
 
 	if err != nil {
@@ -1125,6 +1169,9 @@ func (w *probeWorker) join2ChunkForOuterHashJoin(probeSideChk *chunk.Chunk, hCtx
 // step 2. fetch data from probe child in a background goroutine and probe the hash table in multiple join workers.
 func (e *HashJoinExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 	if !e.prepared {
+		if err = e.adaptSides(ctx); err != nil {
+			return err
+		}
 		e.buildFinished = make(chan error, 1)
 		hCtx := &hashContext{
 			allTypes:    e.buildTypes,
@@ -1182,6 +1229,12 @@ func (e *HashJoinExec) fetchAndBuildHashTable(ctx context.Context) {
 			e.stats.fetchAndBuildHashTable = time.Since(start)
 		}()
 	}
+	// The runtime filters are published after the build side is fully consumed,
+	// they are disabled if the build fails or is interrupted.
+	buildSucceed := false
+	defer func() {
+		e.finishRuntimeFilters(buildSucceed)
+	}()
 	// buildSideResultCh transfers build side chunk from build side fetch to build hash table.
 	buildSideResultCh := make(chan *chunk.Chunk, 1)
 	doneCh := make(chan struct{})
@@ -1215,6 +1268,7 @@ func (e *HashJoinExec) fetchAndBuildHashTable(ctx context.Context) {
 			e.buildFinished <- err
 		}
 	}
+	buildSucceed = err == nil && !e.finished.Load()
 }
 
 // buildHashTableForList builds hash table from `list`.
@@ -1240,6 +1294,9 @@ func (w *buildWorker) buildHashTableForList(buildSideResultCh <-chan *chunk.Chun
 		if w.hashJoinCtx.finished.Load() {
 			return nil
 		}
+		if err = w.hashJoinCtx.collectRuntimeFilters(chk); err != nil {
+			return err
+		}
 		if !w.hashJoinCtx.useOuterToBuild {
 			err = rowContainer.PutChunk(chk, w.hashJoinCtx.isNullEQ)
 		} else {
@@ -1594,6 +1651,7 @@ type hashJoinRuntimeStats struct {
 	probe                  int64
 	concurrent             int
 	maxFetchAndProbe       int64
+	adaptive               adaptiveJoinStats
 }
 
 func (e *hashJoinRuntimeStats) setMaxFetchAndProbeTime(t int64) {
@@ -1641,6 +1699,20 @@ func (e *hashJoinRuntimeStats) String() string {
 		}
 		buf.WriteString("}")
 	}
+	if e.adaptive.swapped {
+		buf.WriteString(", adaptive:{swap_sides:true, sampled_probe_rows:")
+		buf.WriteString(strconv.FormatInt(e.adaptive.sampledProbeRows, 10))
+		buf.WriteString(", sampled_build_rows:")
+		buf.WriteString(strconv.FormatInt(e.adaptive.sampledBuildRows, 10))
+		buf.WriteString("}")
+	}
+	if skewedKeys := atomic.LoadInt64(&e.adaptive.skewedKeys); skewedKeys > 0 {
+		buf.WriteString(", skew:{cached_keys:")
+		buf.WriteString(strconv.FormatInt(skewedKeys, 10))
+		buf.WriteString(", hits:")
+		buf.WriteString(strconv.FormatInt(atomic.LoadInt64(&e.adaptive.skewedKeyHits), 10))
+		buf.WriteString("}")
+	}
 	return buf.String()
 }
 
@@ -1652,6 +1724,7 @@ func (e *hashJoinRuntimeStats) Clone() execdetails.RuntimeStats {
 		probe:                  e.probe,
 		concurrent:             e.concurrent,
 		maxFetchAndProbe:       e.maxFetchAndProbe,
+		adaptive:               e.adaptive,
 	}
 }
 
@@ -1668,4 +1741,11 @@ func (e *hashJoinRuntimeStats) Merge(rs execdetails.RuntimeStats) {
 	if e.maxFetchAndProbe < tmp.maxFetchAndProbe {
 		e.maxFetchAndProbe = tmp.maxFetchAndProbe
 	}
+	if tmp.adaptive.swapped {
+		e.adaptive.swapped = true
+		e.adaptive.sampledProbeRows += tmp.adaptive.sampledProbeRows
+		e.adaptive.sampledBuildRows += tmp.adaptive.sampledBuildRows
+	}
+	e.adaptive.skewedKeys += tmp.adaptive.skewedKeys
+	e.adaptive.skewedKeyHits += tmp.adaptive.skewedKeyHits
 }
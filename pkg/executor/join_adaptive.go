@@ -0,0 +1,190 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+// The adaptive hash join adapts the hash join to the data it reads at runtime, it's enabled by
+// tidb_enable_adaptive_hash_join:
+//  1. Before building the hash table of an inner join, it samples the probe side. If the probe side is drained
+//     within one chunk, it samples the build side too, and swaps the two sides if the build side has more than
+//     adaptiveSwapRatio times rows than the probe side. The swapped join builds the hash table from the original
+//     probe side and probes it with the original build side in the same way as the outer join which uses the
+//     outer side to build. The sampled chunks are not read again, they are handed to the new sides.
+//  2. While probing, a probe worker caches the matched build rows of the join keys which match more than
+//     adaptiveSkewedKeyThreshold rows, so the following probe rows of these skewed keys don't traverse the long
+//     hash chains again. Every worker holds its own copy of the cached rows to avoid the synchronization.
+//
+// The decisions are shown in the execution info of EXPLAIN ANALYZE.
+
+// adaptiveSwapRatio is the least ratio of the sampled build rows to the probe rows to swap the sides.
+const adaptiveSwapRatio = 4
+
+// adaptiveSkewedKeyThreshold is the least number of the matched build rows to treat a join key as skewed.
+var adaptiveSkewedKeyThreshold = 1024
+
+// adaptiveMaxSkewedKeysPerWorker is the max number of the skewed keys cached by one probe worker.
+const adaptiveMaxSkewedKeysPerWorker = 16
+
+// adaptiveJoinStats records the decisions of the adaptive hash join.
+type adaptiveJoinStats struct {
+	swapped          bool
+	sampledProbeRows int64
+	sampledBuildRows int64
+	// skewedKeys is the number of the skewed keys cached by all the probe workers, the same key cached by
+	// different workers is counted repeatedly.
+	skewedKeys    int64
+	skewedKeyHits int64
+}
+
+// canSwapSides returns whether the build side and the probe side can be swapped at runtime.
+// Only the inner join is swapped as its result doesn't depend on which side builds the hash table.
+func (e *HashJoinExec) canSwapSides() bool {
+	return e.Ctx().GetSessionVars().EnableAdaptiveHashJoin &&
+		e.joinType == plannercore.InnerJoin &&
+		!e.useOuterToBuild &&
+		!e.isNullAware &&
+		len(e.runtimeFilters) == 0 &&
+		!slices.Contains(e.isNullEQ, true)
+}
+
+// sampleChunks reads chunks from the executor until it has read more than limit rows or the executor is drained.
+func sampleChunks(ctx context.Context, e exec.Executor, limit int) (chks []*chunk.Chunk, rows int, drained bool, err error) {
+	for rows <= limit {
+		chk := exec.NewFirstChunk(e)
+		if err = exec.Next(ctx, e, chk); err != nil {
+			return nil, 0, false, err
+		}
+		if chk.NumRows() == 0 {
+			return chks, rows, true, nil
+		}
+		chks = append(chks, chk)
+		rows += chk.NumRows()
+	}
+	return chks, rows, false, nil
+}
+
+// adaptSides samples the two sides of the join and swaps them if the probe side turns out to be much smaller.
+func (e *HashJoinExec) adaptSides(ctx context.Context) error {
+	if !e.canSwapSides() {
+		return nil
+	}
+	fetcher, builder := e.probeSideTupleFetcher, e.buildWorker
+	var probeRows, buildRows int
+	var err error
+	fetcher.sampledChks, probeRows, fetcher.drained, err = sampleChunks(ctx, fetcher.probeSideExec, e.MaxChunkSize())
+	if err != nil {
+		return err
+	}
+	if !fetcher.drained || probeRows == 0 {
+		return nil
+	}
+	builder.sampledChks, buildRows, builder.drained, err = sampleChunks(ctx, builder.buildSideExec, probeRows*adaptiveSwapRatio)
+	if err != nil {
+		return err
+	}
+	if buildRows <= probeRows*adaptiveSwapRatio {
+		return nil
+	}
+	e.swapSides()
+	if e.stats != nil {
+		e.stats.adaptive.swapped = true
+		e.stats.adaptive.sampledProbeRows = int64(probeRows)
+		e.stats.adaptive.sampledBuildRows = int64(buildRows)
+	}
+	return nil
+}
+
+// swapSides swaps the build side and the probe side. The join uses the original probe side,
+// which is the outer side of the joiner, to build the hash table after swapping.
+func (e *HashJoinExec) swapSides() {
+	fetcher, builder := e.probeSideTupleFetcher, e.buildWorker
+	fetcher.probeSideExec, builder.buildSideExec = builder.buildSideExec, fetcher.probeSideExec
+	fetcher.sampledChks, builder.sampledChks = builder.sampledChks, fetcher.sampledChks
+	fetcher.drained, builder.drained = builder.drained, fetcher.drained
+	probeKeyColIdx := builder.buildKeyColIdx
+	builder.buildKeyColIdx = e.probeWorkers[0].probeKeyColIdx
+	for _, w := range e.probeWorkers {
+		w.probeKeyColIdx = probeKeyColIdx
+	}
+	e.buildTypes, e.probeTypes = e.probeTypes, e.buildTypes
+	e.useOuterToBuild = !e.useOuterToBuild
+	e.sidesSwapped = !e.sidesSwapped
+}
+
+// resetAdaptiveState drops the sampled chunks and restores the sides swapped by the last execution.
+func (e *HashJoinExec) resetAdaptiveState() {
+	if e.sidesSwapped {
+		e.swapSides()
+	}
+	e.probeSideTupleFetcher.sampledChks, e.probeSideTupleFetcher.drained = nil, false
+	e.buildWorker.sampledChks, e.buildWorker.drained = nil, false
+}
+
+// nextProbeSideChunk reads the next chunk of the probe side, the sampled chunks are returned first.
+func (fetcher *probeSideTupleFetcher) nextProbeSideChunk(ctx context.Context, chk *chunk.Chunk) error {
+	if len(fetcher.sampledChks) > 0 {
+		chk.SwapColumns(fetcher.sampledChks[0])
+		fetcher.sampledChks = fetcher.sampledChks[1:]
+		return nil
+	}
+	if fetcher.drained {
+		chk.Reset()
+		return nil
+	}
+	return exec.Next(ctx, fetcher.probeSideExec, chk)
+}
+
+// getSkewedKeyRows returns the cached build rows matched by the probe row if its join key is skewed.
+func (w *probeWorker) getSkewedKeyRows(probeKey uint64, probeSideRow chunk.Row, hCtx *hashContext) ([]chunk.Row, bool) {
+	rows, ok := w.skewedKeyRows[probeKey]
+	if !ok {
+		return nil, false
+	}
+	// The cached rows share the same join key, the probe row matches all of them if it matches the first one.
+	matched, err := w.rowContainerForProbe.matchJoinKey(rows[0], probeSideRow, hCtx)
+	if err != nil || !matched {
+		return nil, false
+	}
+	if w.hashJoinCtx.stats != nil {
+		atomic.AddInt64(&w.hashJoinCtx.stats.adaptive.skewedKeyHits, 1)
+	}
+	return rows, true
+}
+
+// tryCacheSkewedKey caches the matched build rows if the join key is skewed.
+func (w *probeWorker) tryCacheSkewedKey(probeKey uint64, buildSideRows []chunk.Row) {
+	if w.skewedKeyRows == nil || len(buildSideRows) < adaptiveSkewedKeyThreshold ||
+		len(w.skewedKeyRows) >= adaptiveMaxSkewedKeysPerWorker {
+		return
+	}
+	// The rows read from the disk are in a reused buffer, they can't be cached.
+	if w.rowContainerForProbe.rowContainer.AlreadySpilled() {
+		return
+	}
+	w.skewedKeyRows[probeKey] = slices.Clone(buildSideRows)
+	w.hashJoinCtx.memTracker.Consume(int64(len(buildSideRows)) * rowSize)
+	if w.hashJoinCtx.stats != nil {
+		atomic.AddInt64(&w.hashJoinCtx.stats.adaptive.skewedKeys, 1)
+	}
+}
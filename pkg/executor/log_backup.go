@@ -0,0 +1,226 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/streamhelper"
+	"github.com/pingcap/tidb/br/pkg/task"
+	"github.com/pingcap/tidb/br/pkg/utils"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+const (
+	logBackupStatusNormal = "NORMAL"
+	logBackupStatusPaused = "PAUSED"
+	logBackupStatusError  = "ERROR"
+)
+
+// getLogBackupMetaClient returns the client of the log backup metadata stored in PD,
+// nil is returned if the cluster isn't backed by PD.
+func getLogBackupMetaClient(sctx sessionctx.Context) *streamhelper.MetaDataClient {
+	etcdCli := domain.GetDomain(sctx).GetEtcdClient()
+	if etcdCli == nil {
+		return nil
+	}
+	return streamhelper.NewMetaDataClient(etcdCli)
+}
+
+// logBackupCheckpointTime converts the checkpoint ts to the datetime in the session time zone
+// and returns the lag of the checkpoint in seconds.
+func logBackupCheckpointTime(sctx sessionctx.Context, ts uint64) (types.Time, int64) {
+	physical := oracle.GetTimeFromTS(ts)
+	t := types.NewTime(types.FromGoTime(physical.In(sctx.GetSessionVars().Location())), mysql.TypeDatetime, types.DefaultFsp)
+	return t, int64(time.Since(physical).Seconds())
+}
+
+func (e *memtableRetriever) setDataForLogBackupTasks(ctx context.Context, sctx sessionctx.Context) error {
+	if !hasPriv(sctx, mysql.SuperPriv) {
+		return plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+	}
+	cli := getLogBackupMetaClient(sctx)
+	if cli == nil {
+		return nil
+	}
+	tasks, err := cli.GetAllTasks(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rows := make([][]types.Datum, 0, len(tasks))
+	for i := range tasks {
+		t := &tasks[i]
+		paused, err := t.IsPaused(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		lastErrors, err := t.LastError(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		checkpointTS, err := t.GetGlobalCheckPointTS(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		status := logBackupStatusNormal
+		if paused && len(lastErrors) > 0 {
+			status = logBackupStatusError
+		} else if paused {
+			status = logBackupStatusPaused
+		}
+		checkpointTime, lag := logBackupCheckpointTime(sctx, checkpointTS)
+		backendURL := storage.FormatBackendURL(t.Info.GetStorage())
+		row := types.MakeDatums(
+			t.Info.Name,
+			status,
+			t.Info.StartTs,
+			nil,
+			checkpointTS,
+			checkpointTime,
+			lag,
+			backendURL.String(),
+			strings.Join(t.Info.TableFilter, ","),
+		)
+		if t.Info.EndTs > 0 {
+			row[3].SetUint64(t.Info.EndTs)
+		}
+		rows = append(rows, row)
+	}
+	e.rows = rows
+	return nil
+}
+
+func (e *memtableRetriever) setDataForLogBackupCheckpoints(ctx context.Context, sctx sessionctx.Context) error {
+	if !hasPriv(sctx, mysql.SuperPriv) {
+		return plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+	}
+	cli := getLogBackupMetaClient(sctx)
+	if cli == nil {
+		return nil
+	}
+	tasks, err := cli.GetAllTasks(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var rows [][]types.Datum
+	for i := range tasks {
+		checkpoints, err := tasks[i].NextBackupTSList(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, cp := range checkpoints {
+			if cp.Type() != streamhelper.CheckpointTypeStore {
+				continue
+			}
+			checkpointTime, lag := logBackupCheckpointTime(sctx, cp.TS)
+			rows = append(rows, types.MakeDatums(
+				tasks[i].Info.Name,
+				cp.ID,
+				cp.TS,
+				checkpointTime,
+				lag,
+			))
+		}
+	}
+	e.rows = rows
+	return nil
+}
+
+func (e *memtableRetriever) setDataForLogBackupErrors(ctx context.Context, sctx sessionctx.Context) error {
+	if !hasPriv(sctx, mysql.SuperPriv) {
+		return plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+	}
+	cli := getLogBackupMetaClient(sctx)
+	if cli == nil {
+		return nil
+	}
+	tasks, err := cli.GetAllTasks(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	loc := sctx.GetSessionVars().Location()
+	var rows [][]types.Datum
+	for i := range tasks {
+		lastErrors, err := tasks[i].LastError(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for storeID, lastErr := range lastErrors {
+			// HappenAt is the physical time in milliseconds.
+			happenAt := time.UnixMilli(int64(lastErr.HappenAt)).In(loc)
+			rows = append(rows, types.MakeDatums(
+				tasks[i].Info.Name,
+				storeID,
+				types.NewTime(types.FromGoTime(happenAt), mysql.TypeDatetime, types.DefaultFsp),
+				lastErr.ErrorCode,
+				lastErr.ErrorMessage,
+			))
+		}
+	}
+	e.rows = rows
+	return nil
+}
+
+// executeAdminLogBackup pauses or resumes the log backup tasks, all the tasks are affected
+// if the task name isn't specified.
+func (e *SimpleExec) executeAdminLogBackup(ctx context.Context, s *ast.AdminStmt) error {
+	cli := getLogBackupMetaClient(e.Ctx())
+	store, ok := e.Ctx().GetStore().(kv.StorageWithPD)
+	if cli == nil || !ok {
+		return errors.New("log backup is not supported by the current storage")
+	}
+	var taskNames []string
+	if s.LogBackupTask != "" {
+		taskNames = append(taskNames, s.LogBackupTask)
+	} else {
+		tasks, err := cli.GetAllTasks(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, t := range tasks {
+			taskNames = append(taskNames, t.Info.Name)
+		}
+		if len(taskNames) == 0 {
+			return errors.New("there is no log backup task")
+		}
+	}
+	for _, name := range taskNames {
+		var err error
+		if s.Tp == ast.AdminPauseLogBackup {
+			_, err = task.PauseStreamTask(ctx, cli, store.GetPDClient(), name, utils.DefaultStreamPauseSafePointTTL)
+		} else {
+			_, err = task.ResumeStreamTask(ctx, cli, store.GetPDClient(), name)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		logutil.Logger(ctx).Info("log backup task is paused or resumed by SQL",
+			zap.String("task", name), zap.Bool("pause", s.Tp == ast.AdminPauseLogBackup))
+	}
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
 	"slices"
 	"strings"
 	"sync"
@@ -348,6 +349,14 @@ type clusterLogRetriever struct {
 	heap       *logResponseHeap
 	extractor  *plannercore.ClusterLogTableExtractor
 	cancel     context.CancelFunc
+
+	// maxRowsPerNode limits the number of log rows read from each node, 0 means no limit.
+	maxRowsPerNode int64
+	// cursor is the time (in milliseconds) of the last row read from the first truncated node.
+	// The rows not earlier than it are not returned, and the user can continue the search
+	// by `time >= cursor`. It is 0 if no node is truncated.
+	cursor       int64
+	cursorSource string
 }
 
 type logStreamResult struct {
@@ -358,6 +367,8 @@ type logStreamResult struct {
 	typ      string
 	messages []*diagnosticspb.LogMessage
 	err      error
+	// truncated indicates the node reaches maxRowsPerNode, and it is the last result of the node.
+	truncated bool
 }
 
 type logResponseHeap []logStreamResult
@@ -426,6 +437,13 @@ func (e *clusterLogRetriever) initialize(ctx context.Context, sctx sessionctx.Co
 	if len(patterns) == 0 && len(levels) == 0 && len(instances) == 0 && len(nodeTypes) == 0 {
 		return nil, errors.New("denied to scan full logs (use `SELECT * FROM cluster_log WHERE message LIKE '%'` explicitly if intentionally)")
 	}
+	// Validate the patterns before sending them to all the nodes.
+	for _, pattern := range patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, errors.Errorf("invalid log search pattern '%s': %v", pattern, err)
+		}
+	}
+	e.maxRowsPerNode = sctx.GetSessionVars().ClusterLogMaxRowsPerNode
 
 	req := &diagnosticspb.SearchLogRequest{
 		StartTime: e.extractor.StartTime,
@@ -456,6 +474,7 @@ func (e *clusterLogRetriever) startRetrieving(
 
 	// The retrieve progress may be abort
 	ctx, e.cancel = context.WithCancel(ctx)
+	maxRows := e.maxRowsPerNode
 
 	var results []chan logStreamResult //nolint: prealloc
 	for _, srv := range serversInfo {
@@ -492,6 +511,7 @@ func (e *clusterLogRetriever) startRetrieving(
 					return
 				}
 
+				var scanned int64
 				for {
 					res, err := stream.Recv()
 					if err != nil && err == io.EOF {
@@ -506,11 +526,20 @@ func (e *clusterLogRetriever) startRetrieving(
 					}
 
 					result := logStreamResult{next: ch, addr: address, typ: serverType, messages: res.Messages}
+					scanned += int64(len(res.Messages))
+					if maxRows > 0 && scanned >= maxRows {
+						// Stop scanning the node, the remaining logs can be read by the cursor.
+						result.messages = result.messages[:int64(len(result.messages))-(scanned-maxRows)]
+						result.truncated = true
+					}
 					select {
 					case ch <- result:
 					case <-ctx.Done():
 						return
 					}
+					if result.truncated {
+						return
+					}
 				}
 			}, nil)
 		}(ch, typ, address, statusAddr)
@@ -542,6 +571,7 @@ func (e *clusterLogRetriever) retrieve(ctx context.Context, sctx sessionctx.Cont
 				}
 				continue
 			}
+			e.updateCursor(result)
 			*e.heap = append(*e.heap, result)
 		}
 		heap.Init(e.heap)
@@ -550,6 +580,12 @@ func (e *clusterLogRetriever) retrieve(ctx context.Context, sctx sessionctx.Cont
 	// Merge the results
 	var finalRows [][]types.Datum
 	for e.heap.Len() > 0 && len(finalRows) < clusterLogBatchSize {
+		if e.cursor > 0 && (*e.heap)[0].messages[0].Time >= e.cursor {
+			// The logs of the truncated node after the cursor are not read, stop here
+			// so that the rows are continuous with the ones searched by the cursor.
+			e.heap = &logResponseHeap{}
+			break
+		}
 		minTimeItem := heap.Pop(e.heap).(logStreamResult)
 		headMessage := minTimeItem.messages[0]
 		loggingTime := time.UnixMilli(headMessage.Time)
@@ -569,6 +605,7 @@ func (e *clusterLogRetriever) retrieve(ctx context.Context, sctx sessionctx.Cont
 				continue
 			}
 			if len(result.messages) > 0 {
+				e.updateCursor(result)
 				heap.Push(e.heap, result)
 			}
 		} else {
@@ -578,10 +615,28 @@ func (e *clusterLogRetriever) retrieve(ctx context.Context, sctx sessionctx.Cont
 
 	// All streams are drained
 	e.isDrained = e.heap.Len() == 0
+	if e.isDrained && e.cursor > 0 {
+		cursor := time.UnixMilli(e.cursor)
+		sctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf(
+			"cluster log is truncated because %s reads more than %d rows (%s), continue the search with `time >= '%s'`",
+			e.cursorSource, e.maxRowsPerNode, variable.TiDBClusterLogMaxRowsPerNode, cursor.Format("2006/01/02 15:04:05.000")))
+	}
 
 	return finalRows, nil
 }
 
+// updateCursor moves the cursor backward to the last row of the result if the node is truncated.
+func (e *clusterLogRetriever) updateCursor(result logStreamResult) {
+	if !result.truncated || len(result.messages) == 0 {
+		return
+	}
+	last := result.messages[len(result.messages)-1].Time
+	if e.cursor == 0 || last < e.cursor {
+		e.cursor = last
+		e.cursorSource = fmt.Sprintf("%s node %s", result.typ, result.addr)
+	}
+}
+
 func (e *clusterLogRetriever) close() error {
 	if e.cancel != nil {
 		e.cancel()
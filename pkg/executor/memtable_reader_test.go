@@ -957,6 +957,35 @@ func TestTiDBClusterLog(t *testing.T) {
 				{"2019/08/26 06:25:17.011", "tidb", "critical", "[test log message tidb 14, bar]"},
 			},
 		},
+		{
+			conditions: []string{
+				"time>='2019/08/26 06:18:13.011'",
+				"time<='2099/08/26 06:28:19.011'",
+				"type='tidb'",
+				"level>='error'",
+				"message like '%foo%'",
+			},
+			expected: [][]string{
+				{"2019/08/26 06:19:15.011", "tidb", "error", "[test log message tidb 3, foo]"},
+				{"2019/08/26 06:19:17.011", "tidb", "CRITICAL", "[test log message tidb 5, foo]"},
+			},
+		},
+		{
+			// The level range is compared by the severity: debug < info < error < critical,
+			// both INFO and error are out of the range by the string order.
+			conditions: []string{
+				"time>='2019/08/26 06:18:13.011'",
+				"time<='2099/08/26 06:28:19.011'",
+				"type='tidb'",
+				"level>'debug'",
+				"level<'critical'",
+				"message like '%foo%'",
+			},
+			expected: [][]string{
+				{"2019/08/26 06:19:13.011", "tidb", "INFO", "[test log message tidb 1, foo]"},
+				{"2019/08/26 06:19:15.011", "tidb", "error", "[test log message tidb 3, foo]"},
+			},
+		},
 	}
 
 	var servers = make([]string, 0, len(testServers))
@@ -991,6 +1020,22 @@ func TestTiDBClusterLog(t *testing.T) {
 		}
 		result.Check(testkit.Rows(expected...))
 	}
+
+	// The rows are truncated at the cursor when a node reads too many rows.
+	tk.MustExec("set @@tidb_cluster_log_max_rows_per_node = 2")
+	sql := "select time, message from information_schema.cluster_log where type='tidb' and time>='%s' and time<='2099/08/26 06:28:19.011'"
+	tk.MustQuery(fmt.Sprintf(sql, "2019/08/26 06:18:13.011")).Check(testkit.Rows(
+		restime("2019/08/26 06:19:13.011") + " [test log message tidb 1, foo]"))
+	tk.MustQuery("show warnings").Check(testkit.Rows(fmt.Sprintf(
+		"Warning 1105 cluster log is truncated because tidb node %s reads more than 2 rows (tidb_cluster_log_max_rows_per_node), continue the search with `time >= '%s'`",
+		testServers["tidb"].address, restime("2019/08/26 06:19:14.011"))))
+	tk.MustQuery(fmt.Sprintf(sql, restime("2019/08/26 06:19:14.011"))).Check(testkit.Rows(
+		restime("2019/08/26 06:19:14.011") + " [test log message tidb 2, foo]"))
+	tk.MustExec("set @@tidb_cluster_log_max_rows_per_node = 0")
+	tk.MustQuery(fmt.Sprintf(sql, restime("2019/08/26 06:25:16.011"))).Check(testkit.Rows(
+		restime("2019/08/26 06:25:16.011")+" [test log message tidb 13, bar]",
+		restime("2019/08/26 06:25:17.011")+" [test log message tidb 14, bar]"))
+	require.Len(t, tk.Session().GetSessionVars().StmtCtx.GetWarnings(), 0)
 }
 
 func TestTiDBClusterLogError(t *testing.T) {
@@ -1011,4 +1056,8 @@ func TestTiDBClusterLogError(t *testing.T) {
 	// Test without specified message error.
 	err = tk.QueryToErr("select * from information_schema.cluster_log where time>='2019/08/26 06:18:13.011' and time<'2019/08/26 16:18:13.011'")
 	require.EqualError(t, err, "denied to scan full logs (use `SELECT * FROM cluster_log WHERE message LIKE '%'` explicitly if intentionally)")
+
+	// Test invalid regular expression error.
+	err = tk.QueryToErr("select * from information_schema.cluster_log where time>='2019/08/26 06:18:13.011' and time<'2019/08/26 16:18:13.011' and message regexp 'gc.*['")
+	require.ErrorContains(t, err, "invalid log search pattern 'gc.*['")
 }
@@ -201,9 +201,10 @@ func (e *MetricsSummaryRetriever) retrieve(ctx context.Context, sctx sessionctx.
 		return nil, nil
 	}
 	e.retrieved = true
-	totalRows := make([][]types.Datum, 0, len(infoschema.MetricTableMap))
-	tables := make([]string, 0, len(infoschema.MetricTableMap))
-	for name := range infoschema.MetricTableMap {
+	defs := infoschema.GetMetricTableDefs()
+	totalRows := make([][]types.Datum, 0, len(defs))
+	tables := make([]string, 0, len(defs))
+	for name := range defs {
 		tables = append(tables, name)
 	}
 	slices.Sort(tables)
@@ -215,7 +216,7 @@ func (e *MetricsSummaryRetriever) retrieve(ctx context.Context, sctx sessionctx.
 		if !filter.enable(name) {
 			continue
 		}
-		def, found := infoschema.MetricTableMap[name]
+		def, found := defs[name]
 		if !found {
 			sctx.GetSessionVars().StmtCtx.AppendWarning(fmt.Errorf("metrics table: %s not found", name))
 			continue
@@ -278,9 +279,10 @@ func (e *MetricsSummaryByLabelRetriever) retrieve(ctx context.Context, sctx sess
 		return nil, nil
 	}
 	e.retrieved = true
-	totalRows := make([][]types.Datum, 0, len(infoschema.MetricTableMap))
-	tables := make([]string, 0, len(infoschema.MetricTableMap))
-	for name := range infoschema.MetricTableMap {
+	defs := infoschema.GetMetricTableDefs()
+	totalRows := make([][]types.Datum, 0, len(defs))
+	tables := make([]string, 0, len(defs))
+	for name := range defs {
 		tables = append(tables, name)
 	}
 	slices.Sort(tables)
@@ -292,7 +294,7 @@ func (e *MetricsSummaryByLabelRetriever) retrieve(ctx context.Context, sctx sess
 		if !filter.enable(name) {
 			continue
 		}
-		def, found := infoschema.MetricTableMap[name]
+		def, found := defs[name]
 		if !found {
 			sctx.GetSessionVars().StmtCtx.AppendWarning(fmt.Errorf("metrics table: %s not found", name))
 			continue
@@ -0,0 +1,106 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/pingcap/tidb/pkg/util/tracing"
+)
+
+// persistOptimizerTrace saves the optimizer trace of the statement into mysql.optimizer_trace, so the traces of
+// the different plans of a statement can be compared by "admin compare optimizer trace" later.
+func persistOptimizerTrace(ctx context.Context, sctx sessionctx.Context, stmtNode ast.StmtNode, p core.Plan, trace string) error {
+	_, sqlDigest := parser.NormalizeDigest(stmtNode.Text())
+	_, planDigest := core.NormalizeFlatPlan(core.FlattenPhysicalPlan(p, false))
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	_, _, err := exec.ExecRestrictedSQL(ctx, nil,
+		"REPLACE INTO mysql.optimizer_trace (sql_digest, plan_digest, origin_sql, trace) VALUES (%?, %?, %?, %?)",
+		sqlDigest.String(), planDigest.String(), stmtNode.Text(), trace)
+	return err
+}
+
+// CompareOptimizerTraceExec represents an executor comparing the persisted optimizer traces of two plan digests.
+// It is built from the "admin compare optimizer trace" statement, and reports the differences of the logical rule
+// applications, the costs of the candidate physical plans and the final plans.
+type CompareOptimizerTraceExec struct {
+	exec.BaseExecutor
+
+	planDigests []string
+
+	diffs  []tracing.OptimizeTraceDiff
+	cursor int
+	done   bool
+}
+
+// Next implements the Executor Next interface.
+func (e *CompareOptimizerTraceExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if !e.done {
+		e.done = true
+		left, err := e.loadOptimizerTrace(ctx, e.planDigests[0])
+		if err != nil {
+			return err
+		}
+		right, err := e.loadOptimizerTrace(ctx, e.planDigests[1])
+		if err != nil {
+			return err
+		}
+		e.diffs = tracing.CompareOptimizeTrace(left, right)
+	}
+	for ; e.cursor < len(e.diffs) && !req.IsFull(); e.cursor++ {
+		d := e.diffs[e.cursor]
+		req.AppendString(0, d.Section)
+		req.AppendString(1, d.Item)
+		for i, v := range []string{d.Left, d.Right} {
+			if v == "" {
+				req.AppendNull(2 + i)
+			} else {
+				req.AppendString(2+i, v)
+			}
+		}
+	}
+	return nil
+}
+
+// loadOptimizerTrace loads the latest optimizer trace of the plan digest.
+func (e *CompareOptimizerTraceExec) loadOptimizerTrace(ctx context.Context, planDigest string) (*tracing.OptimizeTracer, error) {
+	exec := e.Ctx().(sqlexec.RestrictedSQLExecutor)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil,
+		"SELECT trace FROM mysql.optimizer_trace WHERE plan_digest = %? ORDER BY create_time DESC LIMIT 1", planDigest)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.Errorf("optimizer trace of plan digest '%s' is not found", planDigest)
+	}
+	tracer := &tracing.OptimizeTracer{}
+	if err := json.Unmarshal([]byte(rows[0].GetString(0)), tracer); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return tracer, nil
+}
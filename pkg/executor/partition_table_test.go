@@ -1027,6 +1027,59 @@ func TestBatchGetforRangeandListPartitionTable(t *testing.T) {
 	tk.MustQuery(queryRange).Sort().Check(tk.MustQuery(queryRegular).Sort().Rows())
 }
 
+func TestBatchPointGetAcrossPartitions(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_partition_prune_mode = 'dynamic'")
+	tk.MustExec("set tidb_enable_global_index = true")
+	defer tk.MustExec("set tidb_enable_global_index = default")
+
+	tk.MustExec(`create table trange (a int, b int, c int, unique key ua (a), unique key ub (b)) partition by range (a) (
+		partition p0 values less than (10), partition p1 values less than (20), partition p2 values less than (30))`)
+	tk.MustExec("create table thash (a int primary key, b int) partition by hash (a) partitions 4")
+	tk.MustExec("create table tkey (a int, b int, unique key ua (a)) partition by key (a) partitions 3")
+	tk.MustExec(`create table tcols (a int, b varchar(10), unique key ua (a)) partition by range columns (b) (
+		partition p0 values less than ('m'), partition p1 values less than (maxvalue))`)
+	tk.MustExec("insert into trange values (1, 1, 1), (11, 11, 11), (21, 21, 21), (5, 25, 5)")
+	tk.MustExec("insert into thash values (1, 1), (2, 2), (3, 3), (6, 6)")
+	tk.MustExec("insert into tkey values (1, 1), (2, 2), (3, 3), (6, 6)")
+	tk.MustExec("insert into tcols values (1, 'a'), (2, 'n'), (3, 'z')")
+
+	// the IN-lists with other filters are not handled by the fast plan.
+	sql := "select * from trange where a in (1, 11, 21, 29) and c > 0"
+	tk.MustHavePlan(sql, "Batch_Point_Get")
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("1 1 1", "11 11 11", "21 21 21"))
+	sql = "select * from trange where a in (21, 1, 11) order by a desc"
+	tk.MustHavePlan(sql, "Batch_Point_Get")
+	tk.MustQuery(sql).Check(testkit.Rows("21 21 21", "11 11 11", "1 1 1"))
+	sql = "select a from thash where a in (1, 2, 3, 6, 7) and b < 6"
+	tk.MustHavePlan(sql, "Batch_Point_Get")
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("1", "2", "3"))
+	sql = "select a from thash where a in (1, 3, 6) and b > 0 order by a desc"
+	tk.MustHavePlan(sql, "Batch_Point_Get")
+	tk.MustQuery(sql).Check(testkit.Rows("6", "3", "1"))
+	sql = "select * from tkey where a in (1, 3, 6, 7)"
+	tk.MustHavePlan(sql, "Batch_Point_Get")
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("1 1", "3 3", "6 6"))
+
+	// the global indexes locate the partitions by the index values.
+	sql = "select * from trange where b in (1, 11, 25, 30)"
+	tk.MustHavePlan(sql, "Batch_Point_Get")
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("1 1 1", "11 11 11", "5 25 5"))
+	sql = "select * from trange where b in (1, 11, 25) and c < 10 order by b"
+	tk.MustHavePlan(sql, "Batch_Point_Get")
+	tk.MustQuery(sql).Check(testkit.Rows("1 1 1", "5 25 5"))
+	sql = "select * from tcols where a in (1, 2, 3, 4)"
+	tk.MustHavePlan(sql, "Batch_Point_Get")
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("1 a", "2 n", "3 z"))
+
+	// the partition selection is not supported.
+	sql = "select * from trange partition (p0, p1) where a in (1, 11, 21) and c > 0"
+	tk.MustNotHavePlan(sql, "Batch_Point_Get")
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("1 1 1", "11 11 11"))
+}
+
 func TestPartitionTableWithDifferentJoin(t *testing.T) {
 	failpoint.Enable("github.com/pingcap/tidb/pkg/planner/core/forceDynamicPrune", `return(true)`)
 	defer failpoint.Disable("github.com/pingcap/tidb/pkg/planner/core/forceDynamicPrune")
@@ -248,7 +248,12 @@ func (e *PointGetExecutor) Next(ctx context.Context, req *chunk.Chunk) error {
 				return err
 			}
 		} else {
-			e.idxKey, err = EncodeUniqueIndexKey(e.Ctx(), e.tblInfo, e.idxInfo, e.idxVals, tblID)
+			idxTblID := tblID
+			if e.idxInfo.Global {
+				// the entries of a global index are stored under the ID of the partitioned table.
+				idxTblID = e.tblInfo.ID
+			}
+			e.idxKey, err = EncodeUniqueIndexKey(e.Ctx(), e.tblInfo, e.idxInfo, e.idxVals, idxTblID)
 			if err != nil && !kv.ErrNotExist.Equal(err) {
 				return err
 			}
@@ -314,6 +319,10 @@ func (e *PointGetExecutor) Next(ctx context.Context, req *chunk.Chunk) error {
 				if err != nil {
 					return err
 				}
+				// the row is not in the partition which the plan reads from.
+				if e.partitionDef != nil && e.partitionDef.ID != pid {
+					return nil
+				}
 				tblID = pid
 			}
 		}
@@ -0,0 +1,87 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/planner/indexadvisor"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+// RecommendIndexExec represents the executor of RECOMMEND INDEX, which recommends indexes
+// for a query or the workload in the statement summary.
+type RecommendIndexExec struct {
+	exec.BaseExecutor
+
+	action  string
+	sql     string
+	options []ast.RecommendIndexOption
+	opts    *indexadvisor.Options
+	done    bool
+}
+
+// Open implements the Executor Open interface.
+func (e *RecommendIndexExec) Open(context.Context) error {
+	if e.action != "run" {
+		return errors.Errorf("unsupported action %s of RECOMMEND INDEX", e.action)
+	}
+	e.opts = indexadvisor.DefaultOptions()
+	for _, opt := range e.options {
+		if err := e.opts.SetOption(opt.Name, opt.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Next implements the Executor Next interface.
+func (e *RecommendIndexExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	// The hypothetical indexes are injected into a dedicated session to keep the user session untouched.
+	se, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, se)
+
+	is := domain.GetDomain(e.Ctx()).InfoSchema()
+	results, err := indexadvisor.AdviseIndexes(ctx, se, is, e.Ctx().GetSessionVars().CurrentDB, e.sql, e.opts)
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		req.AppendString(0, r.Database)
+		req.AppendString(1, r.Table)
+		req.AppendString(2, r.IndexName)
+		req.AppendString(3, strings.Join(r.IndexColumns, ","))
+		req.AppendFloat64(4, r.CostReduction)
+		req.AppendString(5, r.TopImpactedQuery)
+		req.AppendString(6, r.CreateIndexStatement())
+	}
+	return nil
+}
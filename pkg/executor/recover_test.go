@@ -248,6 +248,91 @@ func TestFlashbackTable(t *testing.T) {
 	tk.MustQuery("select a from t order by a").Check(testkit.Rows("1", "2", "3"))
 }
 
+func TestFlashbackDatabaseToTimestamp(t *testing.T) {
+	store := testkit.CreateMockStore(t, mockstore.WithStoreType(mockstore.EmbedUnistore))
+
+	tk := testkit.NewTestKit(t, store)
+	timeBeforeDrop, _, safePointSQL, resetGC := MockGC(tk)
+	defer resetGC()
+	tk.MustExec(fmt.Sprintf(safePointSQL, timeBeforeDrop))
+	require.NoError(t, gcutil.EnableGC(tk.Session()))
+
+	tk.MustExec("create database test_flashback_db")
+	tk.MustExec("use test_flashback_db")
+	tk.MustExec("create table t1 (a int)")
+	tk.MustExec("create table t2 (a int primary key auto_increment)")
+	tk.MustExec("create table t3 (a int, key(a))")
+	tk.MustExec("create table t4 (a int)")
+	tk.MustExec("create view v1 as select * from t1")
+	tk.MustExec("insert into t1 values (1), (2)")
+	tk.MustExec("insert into t2 values (), ()")
+	tk.MustExec("insert into t3 values (3)")
+	tk.MustExec("insert into t4 values (4)")
+
+	tk.MustExec("begin")
+	flashbackTS := tk.MustQuery("select @@tidb_current_ts").Rows()[0][0].(string)
+	tk.MustExec("commit")
+	flashbackSQL := "flashback database test_flashback_db to tso " + flashbackTS
+	jobCntSQL := "select count(*) from information_schema.ddl_jobs where job_type = 'flashback schema'"
+
+	// The dropped tables are recovered by one DDL job.
+	tk.MustExec("drop table t1")
+	tk.MustExec("drop table t3")
+	tk.MustGetErrCode("flashback database test_flashback_not_exists to tso "+flashbackTS, errno.ErrBadDB)
+	tk.MustExec(flashbackSQL)
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 flashback database skips test_flashback_db.v1, only normal tables can be flashback"))
+	tk.MustQuery(jobCntSQL).Check(testkit.Rows("1"))
+	tk.MustQuery("show full tables").Check(testkit.Rows("t1 BASE TABLE", "t2 BASE TABLE", "t3 BASE TABLE", "t4 BASE TABLE", "v1 VIEW"))
+	tk.MustQuery("select * from t1 order by a").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select * from t3 use index(a)").Check(testkit.Rows("3"))
+	// Nothing to recover.
+	tk.MustExec(flashbackSQL)
+	tk.MustQuery(jobCntSQL).Check(testkit.Rows("1"))
+
+	// The changes which can't be rewound are rejected.
+	tk.MustExec("create table t5 (a int)")
+	tk.MustGetErrMsg(flashbackSQL, "Table 't5' is created or truncated after the flashback timestamp, can't flashback database 'test_flashback_db', use flashback cluster instead")
+	tk.MustExec("drop table t5")
+	tk.MustExec("insert into t4 values (5)")
+	tk.MustGetErrMsg(flashbackSQL, "Table 't4' is written after the flashback timestamp, can't flashback database, use flashback cluster instead")
+	tk.MustExec("delete from t4 where a = 5")
+	tk.MustExec("rename table t3 to t3_new")
+	tk.MustGetErrMsg(flashbackSQL, "Table 't3' is renamed to 't3_new' after the flashback timestamp, rename it back before flashback database")
+	tk.MustExec("rename table t3_new to test.t3")
+	tk.MustGetErrMsg(flashbackSQL, "Table 't3' is moved out of database 'test_flashback_db' after the flashback timestamp, move it back before flashback database")
+	tk.MustExec("rename table test.t3 to t3")
+	tk.MustExec("drop table t1")
+	tk.MustExec("create table t1 (b int)")
+	tk.MustGetErrMsg(flashbackSQL, "Table 't1' is created or truncated after the flashback timestamp, can't flashback database 'test_flashback_db', use flashback cluster instead")
+	tk.MustExec("drop table t1")
+	tk.MustQuery(jobCntSQL).Check(testkit.Rows("1"))
+
+	// Flashback a dropped database, the tables dropped before the database are recovered too.
+	tk.MustExec("drop database test_flashback_db")
+	tk.MustExec(flashbackSQL)
+	tk.MustQuery(jobCntSQL).Check(testkit.Rows("2"))
+	tk.MustExec("use test_flashback_db")
+	tk.MustQuery("show full tables").Check(testkit.Rows("t1 BASE TABLE", "t2 BASE TABLE", "t3 BASE TABLE", "t4 BASE TABLE", "v1 VIEW"))
+	tk.MustQuery("select * from t1 order by a").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select * from t4 order by a").Check(testkit.Rows("4"))
+	// The auto ID of the recovered table is restored too.
+	tk.MustExec("insert into t2 values ()")
+	tk.MustQuery("select count(*) from t2").Check(testkit.Rows("3"))
+	tk.MustExec("delete from t2 where a > 2")
+
+	tk.MustExec("truncate table t2")
+	tk.MustGetErrMsg(flashbackSQL, "Table 't2' is created or truncated after the flashback timestamp, can't flashback database 'test_flashback_db', use flashback cluster instead")
+	tk.MustExec("drop table t2")
+	tk.MustExec("alter table t4 add column b int")
+	tk.MustGetErrMsg(flashbackSQL, "Table 't4' is altered after the flashback timestamp, can't flashback database, use flashback cluster instead")
+
+	// A database created again with the same name can't be flashback.
+	tk.MustExec("drop database test_flashback_db")
+	tk.MustExec("create database test_flashback_db")
+	tk.MustGetErrMsg(flashbackSQL,
+		"[schema:1007]Schema 'test_flashback_db' has been dropped and created again after the flashback timestamp, can't be flashback")
+}
+
 func TestRecoverTempTable(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 
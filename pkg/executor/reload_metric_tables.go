@@ -0,0 +1,95 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+// ReloadMetricTablesExec indicates ReloadMetricTables executor.
+type ReloadMetricTablesExec struct {
+	exec.BaseExecutor
+}
+
+// Next implements the Executor Next interface.
+func (e *ReloadMetricTablesExec) Next(context.Context, *chunk.Chunk) error {
+	internalCtx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	changed, err := LoadUserMetricTables(internalCtx, e.Ctx())
+	if err != nil {
+		return err
+	}
+	dom := domain.GetDomain(e.Ctx())
+	if changed {
+		if err = dom.ReloadMetricSchema(); err != nil {
+			return err
+		}
+	}
+	// The other TiDB instances reload the metric tables when they're notified.
+	dom.NotifyReloadMetricTables()
+	return nil
+}
+
+// LoadUserMetricTables loads the user-defined metric tables from table mysql.metric_table_defs.
+// The invalid definitions are skipped with warnings. It returns whether the loaded tables are changed,
+// and the caller should reload the metric schema to make the changes visible.
+func LoadUserMetricTables(ctx context.Context, sctx sessionctx.Context) (bool, error) {
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, "select HIGH_PRIORITY table_name, promql, labels, quantile, comment from mysql.metric_table_defs order by table_name")
+	if err != nil {
+		return false, err
+	}
+	defs := make(map[string]infoschema.MetricTableDef, len(rows))
+	ids := make(map[int64]string, len(rows))
+	for _, row := range rows {
+		name := strings.ToLower(row.GetString(0))
+		def := infoschema.MetricTableDef{
+			PromQL:   row.GetString(1),
+			Quantile: row.GetFloat64(3),
+			Comment:  row.GetString(4),
+		}
+		if labels := row.GetString(2); labels != "" {
+			for _, label := range strings.Split(labels, ",") {
+				def.Labels = append(def.Labels, strings.TrimSpace(label))
+			}
+		}
+		if err := infoschema.ValidateUserMetricTableDef(name, &def); err != nil {
+			logutil.BgLogger().Warn("skip invalid metric table definition", zap.String("table", name), zap.Error(err))
+			sctx.GetSessionVars().StmtCtx.AppendWarning(err)
+			continue
+		}
+		id := infoschema.UserMetricTableID(name)
+		if other, ok := ids[id]; ok {
+			err := errors.Errorf("metric table '%s' and '%s' have the same table ID, rename one of them", other, name)
+			logutil.BgLogger().Warn("skip invalid metric table definition", zap.String("table", name), zap.Error(err))
+			sctx.GetSessionVars().StmtCtx.AppendWarning(err)
+			continue
+		}
+		ids[id] = name
+		defs[name] = def
+	}
+	return infoschema.SetUserMetricTables(defs)
+}
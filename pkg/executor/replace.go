@@ -173,7 +173,7 @@ func (e *ReplaceExec) exec(ctx context.Context, newRows [][]types.Datum) error {
 	prefetchStart := time.Now()
 	// Use BatchGet to fill cache.
 	// It's an optimization and could be removed without affecting correctness.
-	if err = e.prefetchDataCache(ctx, txn, toBeCheckedRows); err != nil {
+	if _, err = e.prefetchDataCache(ctx, txn, toBeCheckedRows); err != nil {
 		return err
 	}
 
@@ -228,5 +228,5 @@ func (e *ReplaceExec) GetFKCascades() []*FKCascadeExec {
 
 // HasFKCascades implements WithForeignKeyTrigger interface.
 func (e *ReplaceExec) HasFKCascades() bool {
-	return len(e.fkCascades) > 0
+	return len(e.fkCascades) > 0 || len(e.afterTriggers) > 0
 }
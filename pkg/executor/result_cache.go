@@ -0,0 +1,305 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/sessiontxn/staleread"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/resultcache"
+)
+
+// resultCacheKeyVars are the system variables which affect the result of a query.
+var resultCacheKeyVars = []string{
+	variable.SQLModeVar,
+	variable.TimeZone,
+	variable.CollationConnection,
+	variable.DefaultWeekFormat,
+	variable.GroupConcatMaxLen,
+	variable.BlockEncryptionMode,
+	variable.SQLSelectLimit,
+}
+
+// nonDeterministicFunctions are the functions whose results are not only decided by the arguments,
+// besides the ones in expression.IllegalFunctions4GeneratedColumns.
+var nonDeterministicFunctions = map[string]struct{}{
+	ast.RandomBytes:          {},
+	ast.CurrentRole:          {},
+	ast.CurrentResourceGroup: {},
+	ast.NextVal:              {},
+	ast.LastVal:              {},
+	ast.SetVal:               {},
+	ast.TiDBCurrentTso:       {},
+	ast.TiDBBoundedStaleness: {},
+	ast.TiDBIsDDLOwner:       {},
+	ast.TiDBVersion:          {},
+}
+
+// resultCacheChecker checks whether the result of a query can be cached, and collects the tables
+// read by the query.
+type resultCacheChecker struct {
+	cacheable bool
+	tables    []*ast.TableName
+}
+
+// Enter implements ast.Visitor interface.
+func (c *resultCacheChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch node := in.(type) {
+	case *ast.SelectStmt:
+		if node.SelectIntoOpt != nil || (node.LockInfo != nil && node.LockInfo.LockType != ast.SelectLockNone) ||
+			(node.SelectStmtOpts != nil && !node.SelectStmtOpts.SQLCache) {
+			c.cacheable = false
+		}
+	case *ast.FuncCallExpr:
+		if _, ok := expression.IllegalFunctions4GeneratedColumns[node.FnName.L]; ok {
+			c.cacheable = false
+		} else if _, ok := nonDeterministicFunctions[node.FnName.L]; ok {
+			c.cacheable = false
+		}
+	case *ast.VariableExpr:
+		c.cacheable = false
+	case *ast.TableName:
+		if node.AsOf != nil {
+			c.cacheable = false
+		}
+		c.tables = append(c.tables, node)
+	}
+	return in, !c.cacheable
+}
+
+// Leave implements ast.Visitor interface.
+func (c *resultCacheChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, c.cacheable
+}
+
+// resultCacheLookup is a query whose result may be read from or put into the result cache.
+type resultCacheLookup struct {
+	cache    *resultcache.Cache
+	key      string
+	tableIDs []int64
+	epoch    uint64
+}
+
+// buildExecutorWithResultCache builds the executor of the statement. If the result of the
+// statement is cached, the executor reads the cached result instead of executing the plan.
+// Otherwise, the result is put into the cache after it's fully read.
+func (a *ExecStmt) buildExecutorWithResultCache() (exec.Executor, error) {
+	lookup := a.lookupResultCache()
+	if lookup == nil {
+		return a.buildExecutor()
+	}
+	if result, ok := lookup.cache.Get(lookup.key); ok {
+		return a.buildResultCacheReader(result), nil
+	}
+	e, err := a.buildExecutor()
+	if err != nil {
+		return nil, err
+	}
+	return &resultCacheCollectExec{
+		BaseExecutor: exec.NewBaseExecutor(a.Ctx, e.Schema(), 0, e),
+		lookup:       lookup,
+		maxSize:      variable.ResultCacheMaxEntrySize.Load(),
+	}, nil
+}
+
+// lookupResultCache returns nil if the result of the statement can't be cached.
+func (a *ExecStmt) lookupResultCache() *resultCacheLookup {
+	sessVars := a.Ctx.GetSessionVars()
+	if !sessVars.EnableResultCache || sessVars.InRestrictedSQL || sessVars.InTxn() || !sessVars.IsAutocommit() ||
+		sessVars.SnapshotTS != 0 || staleread.IsStmtStaleness(a.Ctx) {
+		return nil
+	}
+	dom := domain.GetDomain(a.Ctx)
+	if dom == nil || dom.ResultCache() == nil {
+		return nil
+	}
+	stmtNode, text := a.StmtNode, a.Text
+	var params []types.Datum
+	prepStmt := a.PsStmt
+	if ep, ok := a.Plan.(*plannercore.Execute); ok {
+		prepStmt = ep.PrepStmt
+	}
+	if prepStmt != nil {
+		stmtNode, text = prepStmt.PreparedAst.Stmt, prepStmt.StmtText
+		params = sessVars.PlanCacheParams.AllParamValues()
+	}
+	switch stmtNode.(type) {
+	case *ast.SelectStmt, *ast.SetOprStmt:
+	default:
+		return nil
+	}
+	// the epoch must be got before the query reads any data, see resultcache.Cache.Put.
+	lookup := &resultCacheLookup{cache: dom.ResultCache(), epoch: sessVars.StmtCtx.ResultCacheEpoch}
+	checker := &resultCacheChecker{cacheable: true}
+	stmtNode.Accept(checker)
+	if !checker.cacheable {
+		return nil
+	}
+
+	key := codec.EncodeCompactBytes(nil, []byte(text))
+	key = codec.EncodeCompactBytes(key, []byte(sessVars.CurrentDB))
+	// the result may be different for the users with different privileges, e.g. the masked columns.
+	key = codec.EncodeCompactBytes(key, []byte(sessVars.User.String()))
+	for _, role := range sessVars.ActiveRoles {
+		key = codec.EncodeCompactBytes(key, []byte(role.String()))
+	}
+	for _, name := range resultCacheKeyVars {
+		val, _ := sessVars.GetSystemVar(name)
+		key = codec.EncodeCompactBytes(key, []byte(val))
+	}
+	key, err := codec.EncodeValue(sessVars.Location(), key, params...)
+	if err != nil {
+		return nil
+	}
+	pm := privilege.GetPrivilegeManager(a.Ctx)
+	for _, tn := range checker.tables {
+		schema := tn.Schema
+		if schema.L == "" {
+			schema = model.NewCIStr(sessVars.CurrentDB)
+		}
+		tbl, err := a.InfoSchema.TableByName(schema, tn.Name)
+		if err != nil {
+			// it's the name of a CTE.
+			continue
+		}
+		tblInfo := tbl.Meta()
+		if util.IsMemOrSysDB(schema.L) || tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.IsExternal() || tblInfo.IsFederated() ||
+			tblInfo.TempTableType != model.TempTableNone {
+			return nil
+		}
+		// the cached results are not invalidated by the changes of the masking policies.
+		if pm != nil && len(pm.GetMaskingPolicies(sessVars.ActiveRoles, schema.L, tblInfo.Name.L)) > 0 {
+			return nil
+		}
+		key = codec.EncodeVarint(key, tblInfo.ID)
+		key = codec.EncodeUvarint(key, tblInfo.UpdateTS)
+		lookup.tableIDs = append(lookup.tableIDs, tblInfo.ID)
+		if pi := tblInfo.GetPartitionInfo(); pi != nil {
+			for _, def := range pi.Definitions {
+				lookup.tableIDs = append(lookup.tableIDs, def.ID)
+			}
+		}
+	}
+	// a result is only reused in the same time bucket, which bounds the staleness of the result
+	// as the changes made by other TiDB instances are not notified.
+	bucket := sessVars.ResultCacheTSBucket
+	key = codec.EncodeVarint(key, bucket)
+	key = codec.EncodeVarint(key, time.Now().Unix()/bucket)
+	lookup.key = string(key)
+	return lookup
+}
+
+// buildResultCacheReader builds an executor which reads the cached result.
+func (a *ExecStmt) buildResultCacheReader(result *resultcache.Result) exec.Executor {
+	if ep, ok := a.Plan.(*plannercore.Execute); ok {
+		// see buildExecutor, the plan of the prepared statement is used as if it's built by ExecuteExec.
+		a.Ctx.SetValue(sessionctx.QueryString, ep.Stmt.Text())
+		a.OutputNames = ep.OutputNames()
+		a.isPreparedStmt = true
+		a.Plan = ep.Plan
+		a.Ctx.GetSessionVars().StmtCtx.SetPlan(ep.Plan)
+	}
+	a.Ctx.GetSessionVars().FoundInResultCache = true
+	return &resultCacheReaderExec{
+		BaseExecutor: exec.NewBaseExecutor(a.Ctx, a.Plan.Schema(), a.Plan.ID()),
+		result:       result,
+	}
+}
+
+// resultCacheReaderExec reads the rows of a cached result.
+type resultCacheReaderExec struct {
+	exec.BaseExecutor
+
+	result *resultcache.Result
+	chkIdx int
+	rowIdx int
+}
+
+// Next implements the Executor Next interface.
+func (e *resultCacheReaderExec) Next(_ context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	for !req.IsFull() && e.chkIdx < len(e.result.Chunks) {
+		chk := e.result.Chunks[e.chkIdx]
+		end := min(chk.NumRows(), e.rowIdx+req.RequiredRows()-req.NumRows())
+		req.Append(chk, e.rowIdx, end)
+		e.rowIdx = end
+		if e.rowIdx >= chk.NumRows() {
+			e.chkIdx++
+			e.rowIdx = 0
+		}
+	}
+	return nil
+}
+
+// resultCacheCollectExec collects the rows read from its child, and puts them into the result
+// cache if all the rows are read without errors and warnings.
+type resultCacheCollectExec struct {
+	exec.BaseExecutor
+
+	lookup  *resultCacheLookup
+	maxSize int64
+	size    int64
+	chunks  []*chunk.Chunk
+	drained bool
+	// abandoned indicates the result can't be cached.
+	abandoned bool
+}
+
+// Next implements the Executor Next interface.
+func (e *resultCacheCollectExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	if err := exec.Next(ctx, e.Children(0), req); err != nil {
+		e.abandoned = true
+		return err
+	}
+	if req.NumRows() == 0 {
+		e.drained = true
+		return nil
+	}
+	if e.abandoned {
+		return nil
+	}
+	chk := req.CopyConstructSel()
+	e.size += chk.MemoryUsage()
+	if e.size > e.maxSize {
+		e.abandoned = true
+		e.chunks = nil
+		return nil
+	}
+	e.chunks = append(e.chunks, chk)
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *resultCacheCollectExec) Close() error {
+	if e.drained && !e.abandoned && e.Ctx().GetSessionVars().StmtCtx.WarningCount() == 0 {
+		e.lookup.cache.Put(e.lookup.key, e.lookup.epoch, resultcache.NewResult(e.chunks, e.lookup.tableIDs))
+	}
+	e.chunks = nil
+	return e.BaseExecutor.Close()
+}
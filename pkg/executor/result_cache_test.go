@@ -0,0 +1,164 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCache(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int)")
+	tk.MustExec("insert into t values (1, 1), (2, 2)")
+	tk.MustExec("set @@tidb_enable_result_cache = 1")
+	// use a large bucket to make sure the queries are in the same bucket.
+	tk.MustExec("set @@tidb_result_cache_ts_bucket = 86400")
+	cache := dom.ResultCache()
+
+	query := "select b from t where a > 0 order by a"
+	tk.MustQuery(query).Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("0"))
+	require.Equal(t, 1, cache.Len())
+	tk.MustQuery(query).Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("1"))
+
+	// the results are invalidated by the writes of the instance.
+	tk.MustExec("insert into t values (3, 3)")
+	require.Equal(t, 0, cache.Len())
+	tk.MustQuery(query).Check(testkit.Rows("1", "2", "3"))
+	tk.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery(query).Check(testkit.Rows("1", "2", "3"))
+	tk.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("1"))
+
+	// the results are invalidated by the schema changes.
+	tk.MustExec("alter table t add column c int default 10")
+	require.Equal(t, 0, cache.Len())
+	tk.MustQuery("select a, c from t order by a").Check(testkit.Rows("1 10", "2 10", "3 10"))
+	tk.MustQuery("select a, c from t order by a").Check(testkit.Rows("1 10", "2 10", "3 10"))
+	tk.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("1"))
+
+	// the queries whose results can't be cached.
+	tk.MustExec("create temporary table tmp (a int)")
+	tk.MustExec("create view v as select a from t")
+	for _, sql := range []string{
+		"select b, rand() from t",
+		"select b, now() from t",
+		"select sql_no_cache b from t",
+		"select b from t for update",
+		"select b, @a from t",
+		"select count(*) from information_schema.tables where table_name = 't'",
+		"select * from tmp",
+		"select * from v",
+	} {
+		tk.MustQuery(sql)
+		tk.MustQuery(sql)
+		tk.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("0"))
+	}
+
+	// the results in the explicit transactions are not cached.
+	tk.MustExec("begin")
+	tk.MustQuery(query).Check(testkit.Rows("1", "2", "3"))
+	tk.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("0"))
+	tk.MustExec("insert into t values (4, 4, 4)")
+	tk.MustQuery(query).Check(testkit.Rows("1", "2", "3", "4"))
+	tk.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("0"))
+	tk.MustExec("commit")
+	tk.MustQuery(query).Check(testkit.Rows("1", "2", "3", "4"))
+	tk.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("0"))
+
+	// the parameters of the prepared statements are part of the key.
+	tk.MustExec("prepare stmt from 'select b from t where a > ? order by a'")
+	tk.MustExec("set @a = 2")
+	tk.MustQuery("execute stmt using @a").Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("execute stmt using @a").Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("1"))
+	tk.MustExec("set @a = 3")
+	tk.MustQuery("execute stmt using @a").Check(testkit.Rows("4"))
+	tk.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("0"))
+
+	// the results larger than tidb_result_cache_max_entry_size are not cached.
+	tk.MustExec("set @@global.tidb_result_cache_max_entry_size = 1")
+	defer tk.MustExec("set @@global.tidb_result_cache_max_entry_size = default")
+	tk.MustQuery("select a from t order by a").Check(testkit.Rows("1", "2", "3", "4"))
+	tk.MustQuery("select a from t order by a").Check(testkit.Rows("1", "2", "3", "4"))
+	tk.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("0"))
+
+	tk.MustExec("set @@global.tidb_result_cache_max_size = 0")
+	defer tk.MustExec("set @@global.tidb_result_cache_max_size = default")
+	require.Equal(t, 0, cache.Len())
+
+	tk.MustExec("set @@tidb_enable_result_cache = 0")
+	tk.MustExec("set @@global.tidb_result_cache_max_entry_size = default")
+	tk.MustExec("set @@global.tidb_result_cache_max_size = default")
+	tk.MustQuery(query).Check(testkit.Rows("1", "2", "3", "4"))
+	tk.MustQuery(query).Check(testkit.Rows("1", "2", "3", "4"))
+	tk.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("0"))
+	require.Equal(t, 0, cache.Len())
+}
+
+func TestResultCacheWithPrivileges(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	rootTk := testkit.NewTestKit(t, store)
+	require.NoError(t, rootTk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	rootTk.MustExec("use test")
+	rootTk.MustExec("create table t (a int primary key, b varchar(20))")
+	rootTk.MustExec("insert into t values (1, '13812345678')")
+	rootTk.MustExec("create table t2 (a int primary key)")
+	rootTk.MustExec("insert into t2 values (1)")
+	rootTk.MustExec("create user u1, u2")
+	rootTk.MustExec("create role r_masked")
+	rootTk.MustExec("grant select on test.* to u1, u2, r_masked")
+	rootTk.MustExec("grant r_masked to u2")
+	rootTk.MustExec("set default role all to u2")
+	rootTk.MustExec("create masking policy p_b on test.t (b) using mask_partial(b, 3, 4) to r_masked")
+	cache := dom.ResultCache()
+
+	newTk := func(user string) *testkit.TestKit {
+		tk := testkit.NewTestKit(t, store)
+		require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: user, Hostname: "%"}, nil, nil, nil))
+		tk.MustExec("use test")
+		tk.MustExec("set @@tidb_enable_result_cache = 1")
+		tk.MustExec("set @@tidb_result_cache_ts_bucket = 86400")
+		return tk
+	}
+	tk1, tk2 := newTk("u1"), newTk("u2")
+
+	// the results of the masked tables are never cached.
+	tk1.MustQuery("select b from t").Check(testkit.Rows("13812345678"))
+	tk1.MustQuery("select b from t").Check(testkit.Rows("13812345678"))
+	tk1.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("1"))
+	tk2.MustQuery("select b from t").Check(testkit.Rows("138XXXX5678"))
+	tk2.MustQuery("select b from t").Check(testkit.Rows("138XXXX5678"))
+	tk2.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("0"))
+	tk1.MustQuery("select b from t").Check(testkit.Rows("13812345678"))
+	tk1.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("1"))
+
+	// the results are not shared by the users.
+	cache.InvalidateAll()
+	tk1.MustQuery("select a from t2").Check(testkit.Rows("1"))
+	tk2.MustQuery("select a from t2").Check(testkit.Rows("1"))
+	tk2.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("0"))
+	tk2.MustQuery("select a from t2").Check(testkit.Rows("1"))
+	tk2.MustQuery("select @@last_result_from_cache").Check(testkit.Rows("1"))
+	require.Equal(t, 2, cache.Len())
+}
@@ -0,0 +1,410 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	exprctx "github.com/pingcap/tidb/pkg/expression/context"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
+	"github.com/pingcap/tidb/pkg/util/ranger"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+// maxRuntimeFilterInValues is the max number of the distinct values in an IN runtime filter.
+// The IN runtime filter is skipped if the build side has more distinct values.
+const maxRuntimeFilterInValues = 1024
+
+// runtimeFilterMaxWaitTime is the max time that a table reader waits for its runtime filters.
+// The table reader sends the requests without the filters which are not ready in time.
+var runtimeFilterMaxWaitTime = 10 * time.Second
+
+// joinRuntimeFilter is a runtime filter generated by the hash join executed in TiDB.
+// The hash join collects the values of the join key from its build side, and the TiKV table reader on
+// its probe side waits for them and pushes down the filter conditions before sending the requests.
+type joinRuntimeFilter struct {
+	id     int
+	rfType variable.RuntimeFilterType
+	// srcColIdx is the offset of the join key in the build side.
+	srcColIdx int
+	srcType   *types.FieldType
+
+	ready chan struct{}
+	once  *sync.Once
+
+	// The following fields are written by the build worker of the hash join,
+	// and are only read by the table reader after ready is closed.
+	enabled  bool
+	overflow bool
+	seen     map[string]struct{}
+	values   []types.Datum
+	minVal   types.Datum
+	maxVal   types.Datum
+}
+
+func newJoinRuntimeFilter(rf *plannercore.RuntimeFilter) *joinRuntimeFilter {
+	filter := &joinRuntimeFilter{
+		id:     rf.ID(),
+		rfType: rf.Type(),
+	}
+	filter.reset()
+	return filter
+}
+
+// reset resets the runtime filter before the hash join is executed again.
+func (rf *joinRuntimeFilter) reset() {
+	rf.ready = make(chan struct{})
+	rf.once = &sync.Once{}
+	rf.enabled = false
+	rf.overflow = false
+	rf.seen = make(map[string]struct{})
+	rf.values = nil
+	rf.minVal.SetNull()
+	rf.maxVal.SetNull()
+}
+
+// collect collects the join keys of a build side chunk.
+func (rf *joinRuntimeFilter) collect(tc types.Context, chk *chunk.Chunk) error {
+	if rf.overflow {
+		return nil
+	}
+	collator := collate.GetCollator(rf.srcType.GetCollate())
+	for i := 0; i < chk.NumRows(); i++ {
+		row := chk.GetRow(i)
+		// the null key never matches in the join
+		if row.IsNull(rf.srcColIdx) {
+			continue
+		}
+		switch rf.rfType {
+		case variable.In:
+			key := string(row.GetRaw(rf.srcColIdx))
+			if _, ok := rf.seen[key]; ok {
+				continue
+			}
+			if len(rf.values) >= maxRuntimeFilterInValues {
+				rf.overflow = true
+				rf.seen, rf.values = nil, nil
+				return nil
+			}
+			rf.seen[key] = struct{}{}
+			var val types.Datum
+			d := row.GetDatum(rf.srcColIdx, rf.srcType)
+			d.Copy(&val)
+			rf.values = append(rf.values, val)
+		case variable.MinMax:
+			d := row.GetDatum(rf.srcColIdx, rf.srcType)
+			if rf.minVal.IsNull() {
+				d.Copy(&rf.minVal)
+				d.Copy(&rf.maxVal)
+				continue
+			}
+			cmp, err := d.Compare(tc, &rf.minVal, collator)
+			if err != nil {
+				return err
+			}
+			if cmp < 0 {
+				d.Copy(&rf.minVal)
+			}
+			cmp, err = d.Compare(tc, &rf.maxVal, collator)
+			if err != nil {
+				return err
+			}
+			if cmp > 0 {
+				d.Copy(&rf.maxVal)
+			}
+		}
+	}
+	return nil
+}
+
+// finish publishes the runtime filter to the table reader. The filter is disabled if the build side
+// is not fully collected.
+func (rf *joinRuntimeFilter) finish(succeed bool) {
+	rf.once.Do(func() {
+		rf.enabled = succeed && !rf.overflow
+		close(rf.ready)
+	})
+}
+
+// wait waits for the runtime filter to be published, it returns whether the filter is ready and enabled.
+func (rf *joinRuntimeFilter) wait(ctx context.Context) bool {
+	select {
+	case <-rf.ready:
+		return rf.enabled
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// buildConditions builds the filter conditions on the target column. isEmpty is true if the build side
+// has no matched key, then no row of the probe side can be joined.
+func (rf *joinRuntimeFilter) buildConditions(ctx exprctx.BuildContext, target *expression.Column) (conds []expression.Expression, isEmpty bool, err error) {
+	retType := types.NewFieldType(mysql.TypeLonglong)
+	switch rf.rfType {
+	case variable.In:
+		if len(rf.values) == 0 {
+			return nil, true, nil
+		}
+		args := make([]expression.Expression, 0, len(rf.values)+1)
+		args = append(args, target)
+		for _, val := range rf.values {
+			args = append(args, &expression.Constant{Value: val, RetType: target.RetType})
+		}
+		cond, err := expression.NewFunction(ctx, ast.In, retType, args...)
+		if err != nil {
+			return nil, false, err
+		}
+		return []expression.Expression{cond}, false, nil
+	case variable.MinMax:
+		if rf.minVal.IsNull() {
+			return nil, true, nil
+		}
+		ge, err := expression.NewFunction(ctx, ast.GE, retType, target, &expression.Constant{Value: rf.minVal, RetType: target.RetType})
+		if err != nil {
+			return nil, false, err
+		}
+		le, err := expression.NewFunction(ctx, ast.LE, retType, target, &expression.Constant{Value: rf.maxVal, RetType: target.RetType})
+		if err != nil {
+			return nil, false, err
+		}
+		return []expression.Expression{ge, le}, false, nil
+	}
+	return nil, false, nil
+}
+
+// explainInfo describes the effect of the runtime filter.
+func (rf *joinRuntimeFilter) explainInfo(applied bool) string {
+	prefix := fmt.Sprintf("%d[%s]:", rf.id, rf.rfType)
+	if !applied {
+		return prefix + "skipped"
+	}
+	if rf.rfType == variable.In {
+		return fmt.Sprintf("%s%d values", prefix, len(rf.values))
+	}
+	if rf.minVal.IsNull() {
+		return prefix + "empty"
+	}
+	minStr, err := rf.minVal.ToString()
+	if err != nil {
+		return prefix + "range"
+	}
+	maxStr, err := rf.maxVal.ToString()
+	if err != nil {
+		return prefix + "range"
+	}
+	return fmt.Sprintf("%s[%s,%s]", prefix, minStr, maxStr)
+}
+
+// tableReaderRuntimeFilters is the runtime filters applied by the TiKV table reader.
+// The conditions of the filters are appended to the selection after the table scan.
+type tableReaderRuntimeFilters struct {
+	filters []*joinRuntimeFilter
+	// targets are the filtered columns, whose indices are resolved by the schema of table scan.
+	targets []*expression.Column
+	// baseExecutors are the executors of the request without the runtime filters.
+	baseExecutors []*tipb.Executor
+
+	// pending indicates the requests are not sent since the runtime filters are not applied yet.
+	pending          bool
+	firstPartRanges  []*ranger.Range
+	secondPartRanges []*ranger.Range
+	// filteredOut indicates no row can pass the runtime filters, so the requests are not sent.
+	filteredOut bool
+	// skipSelection indicates the selection is removed from the request because it has no condition.
+	skipSelection bool
+}
+
+// setRuntimeFiltersForTableReader creates the runtime filters of the TiKV table reader, they are attached to
+// the hash join generating them when building the hash join.
+func (b *executorBuilder) setRuntimeFiltersForTableReader(e *TableReaderExecutor, ts *plannercore.PhysicalTableScan) {
+	execs := e.dagPB.Executors
+	if e.dummy || len(execs) != 2 || execs[1].Tp != tipb.ExecType_TypeSelection {
+		return
+	}
+	rfs := &tableReaderRuntimeFilters{baseExecutors: execs}
+	for _, rf := range ts.RuntimeFilterList() {
+		target := rf.TargetExprList()[0]
+		idx := ts.Schema().ColumnIndex(target)
+		if idx < 0 {
+			continue
+		}
+		targetCol := target.Clone().(*expression.Column)
+		targetCol.Index = idx
+		filter := newJoinRuntimeFilter(rf)
+		if b.runtimeFilters == nil {
+			b.runtimeFilters = make(map[int]*joinRuntimeFilter)
+		}
+		b.runtimeFilters[rf.ID()] = filter
+		rfs.filters = append(rfs.filters, filter)
+		rfs.targets = append(rfs.targets, targetCol)
+	}
+	// the selection is removed from the request if there is no filter applied and it has no condition
+	e.runtimeFilters = rfs
+}
+
+// setRuntimeFiltersForHashJoin attaches the runtime filters created by the table readers to the hash join.
+func (b *executorBuilder) setRuntimeFiltersForHashJoin(e *HashJoinExec, v *plannercore.PhysicalHashJoin, buildSideExec exec.Executor) {
+	for _, rf := range v.RuntimeFilterList() {
+		filter, ok := b.runtimeFilters[rf.ID()]
+		if !ok {
+			continue
+		}
+		// The table reader is built again with a new runtime filter when the join is built repeatedly, e.g.
+		// the inner side of the parallel apply.
+		delete(b.runtimeFilters, rf.ID())
+		idx := buildSideExec.Schema().ColumnIndex(rf.SrcExprList()[0])
+		if idx < 0 {
+			filter.finish(false)
+			continue
+		}
+		filter.srcColIdx = idx
+		filter.srcType = buildSideExec.Schema().Columns[idx].RetType
+		e.runtimeFilters = append(e.runtimeFilters, filter)
+	}
+}
+
+// collectRuntimeFilters collects the join keys of a build side chunk for the runtime filters.
+func (hCtx *hashJoinCtx) collectRuntimeFilters(chk *chunk.Chunk) error {
+	tc := hCtx.sessCtx.GetSessionVars().StmtCtx.TypeCtx()
+	for _, rf := range hCtx.runtimeFilters {
+		if err := rf.collect(tc, chk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finishRuntimeFilters publishes the runtime filters to the table readers.
+func (hCtx *hashJoinCtx) finishRuntimeFilters(succeed bool) {
+	for _, rf := range hCtx.runtimeFilters {
+		rf.finish(succeed)
+	}
+}
+
+// applyRuntimeFilters waits for the runtime filters and appends their conditions to the selection after the table
+// scan. The filters which are not ready in time or cannot be pushed down are skipped.
+func (e *TableReaderExecutor) applyRuntimeFilters(ctx context.Context) error {
+	rfs := e.runtimeFilters
+	start := time.Now()
+	waitCtx, cancel := context.WithTimeout(ctx, runtimeFilterMaxWaitTime)
+	defer cancel()
+	var conds []expression.Expression
+	filterInfos := make([]string, 0, len(rfs.filters))
+	for i, rf := range rfs.filters {
+		if !rf.wait(waitCtx) {
+			filterInfos = append(filterInfos, rf.explainInfo(false))
+			continue
+		}
+		rfConds, isEmpty, err := rf.buildConditions(e.ectx, rfs.targets[i])
+		if err != nil {
+			return err
+		}
+		if !isEmpty && !expression.CanExprsPushDown(e.ectx, rfConds, e.pctx.GetClient(), kv.TiKV) {
+			filterInfos = append(filterInfos, rf.explainInfo(false))
+			continue
+		}
+		rfs.filteredOut = rfs.filteredOut || isEmpty
+		conds = append(conds, rfConds...)
+		filterInfos = append(filterInfos, rf.explainInfo(true))
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if e.RuntimeStats() != nil && len(rfs.filters) > 0 {
+		e.GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(e.ID(), &runtimeFilterRuntimeStats{
+			wait:    time.Since(start),
+			filters: filterInfos,
+		})
+	}
+	if rfs.filteredOut {
+		return nil
+	}
+
+	pbConds, err := expression.ExpressionsToPBList(e.ectx, conds, e.pctx.GetClient())
+	if err != nil {
+		return err
+	}
+	scanExec, selExec := rfs.baseExecutors[0], rfs.baseExecutors[1]
+	pbConds = append(slices.Clone(selExec.Selection.Conditions), pbConds...)
+	if len(pbConds) == 0 {
+		e.dagPB.Executors = []*tipb.Executor{scanExec}
+		rfs.skipSelection = true
+		return nil
+	}
+	newSelExec := *selExec
+	newSelExec.Selection = &tipb.Selection{Conditions: pbConds}
+	e.dagPB.Executors = []*tipb.Executor{scanExec, &newSelExec}
+	rfs.skipSelection = false
+	return nil
+}
+
+// runtimeFilterRuntimeStats is the runtime stats of the runtime filters applied by the table reader.
+type runtimeFilterRuntimeStats struct {
+	wait    time.Duration
+	filters []string
+}
+
+// String implements the RuntimeStats interface.
+func (s *runtimeFilterRuntimeStats) String() string {
+	buf := bytes.NewBuffer(make([]byte, 0, 64))
+	buf.WriteString("runtime_filter:{wait:")
+	buf.WriteString(execdetails.FormatDuration(s.wait))
+	for _, filter := range s.filters {
+		buf.WriteString(", ")
+		buf.WriteString(filter)
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+// Clone implements the RuntimeStats interface.
+func (s *runtimeFilterRuntimeStats) Clone() execdetails.RuntimeStats {
+	return &runtimeFilterRuntimeStats{
+		wait:    s.wait,
+		filters: slices.Clone(s.filters),
+	}
+}
+
+// Merge implements the RuntimeStats interface.
+func (s *runtimeFilterRuntimeStats) Merge(other execdetails.RuntimeStats) {
+	tmp, ok := other.(*runtimeFilterRuntimeStats)
+	if !ok {
+		return
+	}
+	s.wait += tmp.wait
+	if len(s.filters) == 0 {
+		s.filters = slices.Clone(tmp.filters)
+	}
+}
+
+// Tp implements the RuntimeStats interface.
+func (*runtimeFilterRuntimeStats) Tp() int {
+	return execdetails.TpRuntimeFilterRuntimeStats
+}
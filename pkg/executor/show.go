@@ -32,6 +32,7 @@ import (
 	"github.com/pingcap/tidb/pkg/bindinfo"
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	fstorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/disttask/importinto"
 	"github.com/pingcap/tidb/pkg/domain"
@@ -114,7 +115,10 @@ type ShowExec struct {
 	GlobalScope bool // GlobalScope is used by show variables
 	Extended    bool // Used for `show extended columns from ...`
 
+	RegionsSummary bool // Used for `show table regions summary`.
+
 	ImportJobID *int64
+	DistJobID   int64
 }
 
 type showTableRegionRowItem struct {
@@ -286,6 +290,10 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 		return e.fetchShowSessionStates(ctx)
 	case ast.ShowImportJobs:
 		return e.fetchShowImportJobs(ctx)
+	case ast.ShowDistJobs:
+		return e.fetchShowDistJobs(ctx)
+	case ast.ShowDistJobSubtasks:
+		return e.fetchShowDistJobSubtasks(ctx)
 	}
 	return nil
 }
@@ -521,6 +529,8 @@ func (e *ShowExec) fetchShowTables() error {
 		// TODO: Should consider column privileges, which also make a table visible.
 		if checker != nil && !checker.RequestVerification(activeRoles, e.DBName.O, v.Meta().Name.O, "", mysql.AllPrivMask) {
 			continue
+		} else if v.Meta().IsStaging() {
+			continue
 		} else if fieldFilter != "" && v.Meta().Name.L != fieldFilter {
 			continue
 		} else if fieldPatternsLike != nil && !fieldPatternsLike.DoMatch(v.Meta().Name.L) {
@@ -531,6 +541,8 @@ func (e *ShowExec) fetchShowTables() error {
 			tableTypes[v.Meta().Name.O] = "VIEW"
 		} else if v.Meta().IsSequence() {
 			tableTypes[v.Meta().Name.O] = "SEQUENCE"
+		} else if v.Meta().IsExternal() {
+			tableTypes[v.Meta().Name.O] = "EXTERNAL TABLE"
 		} else if util.IsSystemView(e.DBName.L) {
 			tableTypes[v.Meta().Name.O] = "SYSTEM VIEW"
 		} else {
@@ -637,6 +649,15 @@ func (e *ShowExec) fetchShowColumns(ctx context.Context) error {
 	} else {
 		cols = tb.VisibleCols()
 	}
+	if !e.Ctx().GetSessionVars().ShowGIPK {
+		visibleCols := make([]*table.Column, 0, len(cols))
+		for _, col := range cols {
+			if !table.IsGIPKColumn(col.ColumnInfo) {
+				visibleCols = append(visibleCols, col)
+			}
+		}
+		cols = visibleCols
+	}
 	if err := tryFillViewColumnType(ctx, e.Ctx(), e.is, e.DBName, tb.Meta()); err != nil {
 		return err
 	}
@@ -946,6 +967,12 @@ func getDefaultCollate(charsetName string) string {
 	return ch.DefaultCollation
 }
 
+// vectorDistanceFuncs maps the distance metrics of vector indexes to the distance functions.
+var vectorDistanceFuncs = map[model.DistanceMetric]string{
+	model.DistanceMetricCosine: strings.ToUpper(ast.VecCosineDistance),
+	model.DistanceMetricL2:     strings.ToUpper(ast.VecL2Distance),
+}
+
 // ConstructResultOfShowCreateTable constructs the result for show create table.
 func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.TableInfo, allocators autoid.Allocators, buf *bytes.Buffer) (err error) {
 	return constructResultOfShowCreateTable(ctx, nil, tableInfo, allocators, buf)
@@ -972,6 +999,8 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 	}
 
 	sqlMode := ctx.GetSessionVars().SQLMode
+	// In the MySQL compatible mode, the TiDB specific clauses are omitted, so the output can be replayed into MySQL.
+	mysqlCompatible := ctx.GetSessionVars().ShowCreateTableMySQLCompatible
 	tableName := stringutil.Escape(tableInfo.Name.O, sqlMode)
 	switch tableInfo.TempTableType {
 	case model.TempTableGlobal:
@@ -979,13 +1008,18 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 	case model.TempTableLocal:
 		fmt.Fprintf(buf, "CREATE TEMPORARY TABLE %s (\n", tableName)
 	default:
-		fmt.Fprintf(buf, "CREATE TABLE %s (\n", tableName)
+		if tableInfo.IsExternal() {
+			fmt.Fprintf(buf, "CREATE EXTERNAL TABLE %s (\n", tableName)
+		} else {
+			fmt.Fprintf(buf, "CREATE TABLE %s (\n", tableName)
+		}
 	}
 	var pkCol *model.ColumnInfo
 	var hasAutoIncID bool
 	needAddComma := false
+	hideGIPK := !ctx.GetSessionVars().ShowGIPK
 	for i, col := range tableInfo.Cols() {
-		if col.Hidden {
+		if col.Hidden || (hideGIPK && table.IsGIPKColumn(col)) {
 			continue
 		}
 		if needAddComma {
@@ -1066,7 +1100,7 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 				buf.WriteString(table.OptionalFsp(&col.FieldType))
 			}
 		}
-		if ddl.IsAutoRandomColumnID(tableInfo, col.ID) {
+		if !mysqlCompatible && ddl.IsAutoRandomColumnID(tableInfo, col.ID) {
 			s, r := tableInfo.AutoRandomBits, tableInfo.AutoRandomRangeBits
 			if r == 0 || r == autoid.AutoRandomRangeBitsDefault {
 				fmt.Fprintf(buf, " /*T![auto_rand] AUTO_RANDOM(%d) */", s)
@@ -1074,6 +1108,9 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 				fmt.Fprintf(buf, " /*T![auto_rand] AUTO_RANDOM(%d, %d) */", s, r)
 			}
 		}
+		if col.Invisible {
+			buf.WriteString(" /*!80023 INVISIBLE */")
+		}
 		if len(col.Comment) > 0 {
 			fmt.Fprintf(buf, " COMMENT '%s'", format.OutputFormat(col.Comment))
 		}
@@ -1089,14 +1126,20 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		// If PKIsHandle, pk info is not in tb.Indices(). We should handle it here.
 		buf.WriteString(",\n")
 		fmt.Fprintf(buf, "  PRIMARY KEY (%s)", stringutil.Escape(pkCol.Name.O, sqlMode))
-		buf.WriteString(" /*T![clustered_index] CLUSTERED */")
+		if !mysqlCompatible {
+			buf.WriteString(" /*T![clustered_index] CLUSTERED */")
+		}
 	}
 
 	publicIndices := make([]*model.IndexInfo, 0, len(tableInfo.Indices))
 	for _, idx := range tableInfo.Indices {
-		if idx.State == model.StatePublic {
-			publicIndices = append(publicIndices, idx)
+		if idx.State != model.StatePublic {
+			continue
 		}
+		if hideGIPK && idx.Primary && len(idx.Columns) == 1 && table.IsGIPKColumn(tableInfo.Columns[idx.Columns[0].Offset]) {
+			continue
+		}
+		publicIndices = append(publicIndices, idx)
 	}
 
 	// consider hypo-indexes
@@ -1124,6 +1167,10 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 			buf.WriteString("  PRIMARY KEY ")
 		} else if idxInfo.Unique {
 			fmt.Fprintf(buf, "  UNIQUE KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.IsFullText() {
+			fmt.Fprintf(buf, "  FULLTEXT KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.IsVector() {
+			fmt.Fprintf(buf, "  VECTOR INDEX %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		} else {
 			fmt.Fprintf(buf, "  KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		}
@@ -1133,6 +1180,8 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		for _, c := range idxInfo.Columns {
 			if tableInfo.Columns[c.Offset].Hidden {
 				colInfo = fmt.Sprintf("(%s)", tableInfo.Columns[c.Offset].GeneratedExprString)
+			} else if idxInfo.IsVector() {
+				colInfo = fmt.Sprintf("(%s(%s))", vectorDistanceFuncs[idxInfo.VectorInfo.DistanceMetric], stringutil.Escape(c.Name.O, sqlMode))
 			} else {
 				colInfo = stringutil.Escape(c.Name.O, sqlMode)
 				if c.Length != types.UnspecifiedLength {
@@ -1142,6 +1191,12 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 			cols = append(cols, colInfo)
 		}
 		fmt.Fprintf(buf, "(%s)", strings.Join(cols, ","))
+		if idxInfo.IsFullText() && idxInfo.FullTextInfo.ParserType != model.FullTextParserTypeWhitespace {
+			fmt.Fprintf(buf, " WITH PARSER %s", stringutil.Escape(string(idxInfo.FullTextInfo.ParserType), sqlMode))
+		}
+		if idxInfo.IsVector() {
+			fmt.Fprintf(buf, " USING %s", idxInfo.Tp)
+		}
 		if idxInfo.Invisible {
 			fmt.Fprintf(buf, ` /*!80000 INVISIBLE */`)
 		}
@@ -1151,7 +1206,7 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		if idxInfo.Tp == model.IndexTypeHypo {
 			fmt.Fprintf(buf, ` /* HYPO INDEX */`)
 		}
-		if idxInfo.Primary {
+		if idxInfo.Primary && !mysqlCompatible {
 			if tableInfo.HasClusteredIndex() {
 				buf.WriteString(" /*T![clustered_index] CLUSTERED */")
 			} else {
@@ -1214,7 +1269,11 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 
 	buf.WriteString("\n")
 
-	buf.WriteString(") ENGINE=InnoDB")
+	if tableInfo.IsFederated() {
+		buf.WriteString(") ENGINE=FEDERATED")
+	} else {
+		buf.WriteString(") ENGINE=InnoDB")
+	}
 	// We need to explicitly set the default charset and collation
 	// to make it work on MySQL server which has default collate utf8_general_ci.
 	if len(tblCollate) == 0 || tblCollate == "binary" {
@@ -1244,12 +1303,12 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		}
 	}
 
-	if tableInfo.AutoIdCache != 0 {
+	if tableInfo.AutoIdCache != 0 && !mysqlCompatible {
 		fmt.Fprintf(buf, " /*T![auto_id_cache] AUTO_ID_CACHE=%d */", tableInfo.AutoIdCache)
 	}
 
 	randomAllocator := allocators.Get(autoid.AutoRandomType)
-	if randomAllocator != nil {
+	if randomAllocator != nil && !mysqlCompatible {
 		autoRandID, err := randomAllocator.NextGlobalAutoID()
 		if err != nil {
 			return errors.Trace(err)
@@ -1260,7 +1319,7 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		}
 	}
 
-	if tableInfo.ShardRowIDBits > 0 {
+	if tableInfo.ShardRowIDBits > 0 && !mysqlCompatible {
 		fmt.Fprintf(buf, " /*T! SHARD_ROW_ID_BITS=%d ", tableInfo.ShardRowIDBits)
 		if tableInfo.PreSplitRegions > 0 {
 			fmt.Fprintf(buf, "PRE_SPLIT_REGIONS=%d ", tableInfo.PreSplitRegions)
@@ -1268,7 +1327,7 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		buf.WriteString("*/")
 	}
 
-	if tableInfo.AutoRandomBits > 0 && tableInfo.PreSplitRegions > 0 {
+	if tableInfo.AutoRandomBits > 0 && tableInfo.PreSplitRegions > 0 && !mysqlCompatible {
 		fmt.Fprintf(buf, " /*T! PRE_SPLIT_REGIONS=%d */", tableInfo.PreSplitRegions)
 	}
 
@@ -1280,7 +1339,15 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		fmt.Fprintf(buf, " ON COMMIT DELETE ROWS")
 	}
 
-	if tableInfo.PlacementPolicyRef != nil {
+	if info := tableInfo.ExternalInfo; info != nil {
+		fmt.Fprintf(buf, " LOCATION='%s' FORMAT='%s'", format.OutputFormat(ast.RedactURL(info.Location)), info.Format)
+	}
+
+	if info := tableInfo.FederatedInfo; info != nil {
+		fmt.Fprintf(buf, " CONNECTION='%s/%s'", format.OutputFormat(info.Server), format.OutputFormat(info.Table))
+	}
+
+	if tableInfo.PlacementPolicyRef != nil && !mysqlCompatible {
 		fmt.Fprintf(buf, " /*T![placement] PLACEMENT POLICY=%s */", stringutil.Escape(tableInfo.PlacementPolicyRef.Name.String(), sqlMode))
 	}
 
@@ -1290,10 +1357,21 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		fmt.Fprintf(buf, " /* CACHED ON */")
 	}
 
+	if policy := tableInfo.IntervalPolicy; policy != nil && !mysqlCompatible {
+		fmt.Fprintf(buf, " /*T![interval_partition] INTERVAL_PRECREATE=%d INTERVAL_RETENTION=%d */", policy.PrecreateCount, policy.RetentionCount)
+	}
+
 	// add partition info here.
-	ddl.AppendPartitionInfo(tableInfo.Partition, buf, sqlMode)
+	partitionInfo := tableInfo.Partition
+	if partitionInfo != nil && mysqlCompatible {
+		partitionInfo = partitionInfo.Clone()
+		for i := range partitionInfo.Definitions {
+			partitionInfo.Definitions[i].PlacementPolicyRef = nil
+		}
+	}
+	ddl.AppendPartitionInfo(partitionInfo, buf, sqlMode)
 
-	if tableInfo.TTLInfo != nil {
+	if tableInfo.TTLInfo != nil && !mysqlCompatible {
 		restoreFlags := parserformat.RestoreStringSingleQuotes | parserformat.RestoreNameBackQuotes | parserformat.RestoreTiDBSpecialComment
 		restoreCtx := parserformat.NewRestoreCtx(restoreFlags, buf)
 
@@ -1651,7 +1729,8 @@ func (e *ShowExec) fetchShowCreateUser(ctx context.Context) error {
 		`SELECT plugin, Account_locked, user_attributes->>'$.metadata', Token_issuer,
         Password_reuse_history, Password_reuse_time, Password_expired, Password_lifetime,
         user_attributes->>'$.Password_locking.failed_login_attempts',
-        user_attributes->>'$.Password_locking.password_lock_time_days'
+        user_attributes->>'$.Password_locking.password_lock_time_days',
+        max_questions, max_user_connections
 		FROM %n.%n WHERE User=%? AND Host=%?`,
 		mysql.SystemDB, mysql.UserTable, userName, strings.ToLower(hostName))
 	if err != nil {
@@ -1726,6 +1805,17 @@ func (e *ShowExec) fetchShowCreateUser(ctx context.Context) error {
 			passwordLockTimeDays = " PASSWORD_LOCK_TIME " + passwordLockTimeDays
 		}
 	}
+
+	var resourceOptions string
+	if maxQuestions := rows[0].GetUint64(10); maxQuestions > 0 {
+		resourceOptions += fmt.Sprintf(" MAX_QUERIES_PER_HOUR %d", maxQuestions)
+	}
+	if maxUserConnections := rows[0].GetUint64(11); maxUserConnections > 0 {
+		resourceOptions += fmt.Sprintf(" MAX_USER_CONNECTIONS %d", maxUserConnections)
+	}
+	if len(resourceOptions) > 0 {
+		resourceOptions = " WITH" + resourceOptions
+	}
 	rows, _, err = exec.ExecRestrictedSQL(ctx, nil, `SELECT Priv FROM %n.%n WHERE User=%? AND Host=%?`, mysql.SystemDB, mysql.GlobalPrivTable, userName, hostName)
 	if err != nil {
 		return errors.Trace(err)
@@ -1749,8 +1839,8 @@ func (e *ShowExec) fetchShowCreateUser(ctx context.Context) error {
 	}
 
 	// FIXME: the returned string is not escaped safely
-	showStr := fmt.Sprintf("CREATE USER '%s'@'%s' IDENTIFIED WITH '%s'%s REQUIRE %s%s %s ACCOUNT %s PASSWORD HISTORY %s PASSWORD REUSE INTERVAL %s%s%s%s",
-		e.User.Username, e.User.Hostname, authplugin, authStr, require, tokenIssuer, passwordExpiredStr, accountLocked, passwordHistory, passwordReuseInterval, failedLoginAttempts, passwordLockTimeDays, userAttributes)
+	showStr := fmt.Sprintf("CREATE USER '%s'@'%s' IDENTIFIED WITH '%s'%s REQUIRE %s%s%s %s ACCOUNT %s PASSWORD HISTORY %s PASSWORD REUSE INTERVAL %s%s%s%s",
+		e.User.Username, e.User.Hostname, authplugin, authStr, require, tokenIssuer, resourceOptions, passwordExpiredStr, accountLocked, passwordHistory, passwordReuseInterval, failedLoginAttempts, passwordLockTimeDays, userAttributes)
 	e.appendRow([]any{showStr})
 	return nil
 }
@@ -2071,6 +2161,18 @@ func (e *ShowExec) fetchShowTableRegions(ctx context.Context) error {
 		return err
 	}
 
+	if e.RegionsSummary {
+		e.fillRegionsSummaryToChunk(regions)
+		return nil
+	}
+	// A table may have too many regions to be streamed to the client, only show the first ones by default.
+	sessVars := e.Ctx().GetSessionVars()
+	if maxRows := sessVars.ShowTableRegionsMaxRows; maxRows > 0 && len(regions) > maxRows {
+		sessVars.StmtCtx.AppendWarning(errors.NewNoStackErrorf("Only %d of %d regions are shown, use `SHOW TABLE ... REGIONS SUMMARY` "+
+			"or increase tidb_show_table_regions_max_rows to see all of them", maxRows, len(regions)))
+		regions = regions[:maxRows]
+	}
+
 	regionRowItem, err := e.fetchSchedulingInfo(ctx, regions, tb.Meta())
 	if err != nil {
 		return err
@@ -2190,6 +2292,54 @@ func (e *ShowExec) fillRegionsToChunk(regions []showTableRegionRowItem) {
 	}
 }
 
+// fillRegionsSummaryToChunk groups the regions by store, and sums up the flow and size of the regions whose leader is
+// on the store.
+func (e *ShowExec) fillRegionsSummaryToChunk(regions []regionMeta) {
+	type storeSummary struct {
+		leaderCount     uint64
+		peerCount       uint64
+		writtenBytes    uint64
+		readBytes       uint64
+		approximateSize int64
+		approximateKeys int64
+	}
+	summaries := make(map[uint64]*storeSummary)
+	getSummary := func(storeID uint64) *storeSummary {
+		s, ok := summaries[storeID]
+		if !ok {
+			s = &storeSummary{}
+			summaries[storeID] = s
+		}
+		return s
+	}
+	for i := range regions {
+		for _, peer := range regions[i].region.Peers {
+			getSummary(peer.StoreId).peerCount++
+		}
+		s := getSummary(regions[i].storeID)
+		s.leaderCount++
+		s.writtenBytes += regions[i].writtenBytes
+		s.readBytes += regions[i].readBytes
+		s.approximateSize += regions[i].approximateSize
+		s.approximateKeys += regions[i].approximateKeys
+	}
+	storeIDs := make([]uint64, 0, len(summaries))
+	for storeID := range summaries {
+		storeIDs = append(storeIDs, storeID)
+	}
+	slices.Sort(storeIDs)
+	for _, storeID := range storeIDs {
+		s := summaries[storeID]
+		e.result.AppendUint64(0, storeID)
+		e.result.AppendUint64(1, s.leaderCount)
+		e.result.AppendUint64(2, s.peerCount)
+		e.result.AppendUint64(3, s.writtenBytes)
+		e.result.AppendUint64(4, s.readBytes)
+		e.result.AppendInt64(5, s.approximateSize)
+		e.result.AppendInt64(6, s.approximateKeys)
+	}
+}
+
 func (e *ShowExec) fetchShowBuiltins() error {
 	for _, f := range expression.GetBuiltinList() {
 		e.appendRow([]any{f})
@@ -2266,7 +2416,10 @@ func fillOneImportJobInfo(info *importer.JobInfo, result *chunk.Chunk, importedR
 
 func handleImportJobInfo(ctx context.Context, info *importer.JobInfo, result *chunk.Chunk) error {
 	var importedRowCount int64 = -1
-	if info.Summary == nil && info.Status == importer.JobStatusRunning {
+	// jobs of CREATE TABLE ... SELECT are not run by the distributed framework,
+	// their imported rows are only known after they finish.
+	if info.Summary == nil && info.Status == importer.JobStatusRunning &&
+		info.Parameters.Format != string(importer.DataSourceTypeQuery) {
 		// for running jobs, need get from distributed framework.
 		rows, err := importinto.GetTaskImportedRows(ctx, info.ID)
 		if err != nil {
@@ -2323,6 +2476,114 @@ func (e *ShowExec) fetchShowImportJobs(ctx context.Context) error {
 	return nil
 }
 
+// fetchShowDistJobs fills the result with the schema:
+// {"Job_ID", "Job_Key", "Type", "State", "Step", "Concurrency", "Create_Time", "Start_Time",
+// "Update_Time", "Total_Subtasks", "Finished_Subtasks", "Progress", "Error"}
+// the subtask counts and the progress are of the current step, they are NULL if
+// the job is finished.
+func (e *ShowExec) fetchShowDistJobs(ctx context.Context) error {
+	// we use sessionCtx from GetTaskManager, user ctx might not have system table privileges.
+	taskManager, err := fstorage.GetTaskManager()
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	if err != nil {
+		return err
+	}
+	tasks, err := taskManager.GetTasksWithHistory(ctx)
+	if err != nil {
+		return err
+	}
+	loc := e.Ctx().GetSessionVars().Location()
+	for _, task := range tasks {
+		e.result.AppendInt64(0, task.ID)
+		e.result.AppendString(1, task.Key)
+		e.result.AppendString(2, task.Type.String())
+		e.result.AppendString(3, task.State.String())
+		e.result.AppendString(4, proto.Step2Str(task.Type, task.Step))
+		e.result.AppendInt64(5, int64(task.Concurrency))
+		appendDistJobTime(e.result, 6, task.CreateTime, loc)
+		appendDistJobTime(e.result, 7, task.StartTime, loc)
+		appendDistJobTime(e.result, 8, task.StateUpdateTime, loc)
+		if task.IsDone() {
+			e.result.AppendNull(9)
+			e.result.AppendNull(10)
+			e.result.AppendNull(11)
+		} else {
+			cntByStates, err := taskManager.GetSubtaskCntGroupByStates(ctx, task.ID, task.Step)
+			if err != nil {
+				return err
+			}
+			var total, finished int64
+			for state, cnt := range cntByStates {
+				total += cnt
+				if state == proto.SubtaskStateSucceed {
+					finished += cnt
+				}
+			}
+			var progress float64
+			if total > 0 {
+				progress = float64(finished) * 100 / float64(total)
+			}
+			e.result.AppendInt64(9, total)
+			e.result.AppendInt64(10, finished)
+			e.result.AppendFloat64(11, progress)
+		}
+		if task.Error != nil {
+			e.result.AppendString(12, task.Error.Error())
+		} else {
+			e.result.AppendNull(12)
+		}
+	}
+	return nil
+}
+
+// fetchShowDistJobSubtasks fills the result with the subtasks of all steps of the job,
+// in the schema:
+// {"Subtask_ID", "Step", "Exec_ID", "State", "Concurrency", "Create_Time", "Start_Time",
+// "Update_Time", "Summary"}
+func (e *ShowExec) fetchShowDistJobSubtasks(ctx context.Context) error {
+	taskManager, err := fstorage.GetTaskManager()
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	if err != nil {
+		return err
+	}
+	task, err := taskManager.GetTaskBaseByIDWithHistory(ctx, e.DistJobID)
+	if err != nil {
+		if errors.ErrorEqual(err, fstorage.ErrTaskNotFound) {
+			return exeerrors.ErrLoadDataJobNotFound.GenWithStackByArgs(e.DistJobID)
+		}
+		return err
+	}
+	subtasks, err := taskManager.GetSubtasksOfTaskWithHistory(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	loc := e.Ctx().GetSessionVars().Location()
+	for _, subtask := range subtasks {
+		e.result.AppendInt64(0, subtask.ID)
+		e.result.AppendString(1, proto.Step2Str(task.Type, subtask.Step))
+		e.result.AppendString(2, subtask.ExecID)
+		e.result.AppendString(3, subtask.State.String())
+		e.result.AppendInt64(4, int64(subtask.Concurrency))
+		appendDistJobTime(e.result, 5, subtask.CreateTime, loc)
+		appendDistJobTime(e.result, 6, subtask.StartTime, loc)
+		appendDistJobTime(e.result, 7, subtask.UpdateTime, loc)
+		summary, err := types.ParseBinaryJSONFromString(subtask.Summary)
+		if err != nil {
+			return err
+		}
+		e.result.AppendJSON(8, summary)
+	}
+	return nil
+}
+
+func appendDistJobTime(result *chunk.Chunk, colIdx int, t time.Time, loc *time.Location) {
+	if t.IsZero() {
+		result.AppendNull(colIdx)
+		return
+	}
+	result.AppendTime(colIdx, types.NewTime(types.FromGoTime(t.In(loc)), mysql.TypeDatetime, types.DefaultFsp))
+}
+
 // tryFillViewColumnType fill the columns type info of a view.
 // Because view's underlying table's column could change or recreate, so view's column type may change over time.
 // To avoid this situation we need to generate a logical plan and extract current column types from Schema.
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -44,6 +45,7 @@ import (
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/planner/core"
@@ -57,6 +59,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
 	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 	"github.com/pingcap/tidb/pkg/util/globalconn"
@@ -192,13 +195,29 @@ func (e *SimpleExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 	case *ast.ShutdownStmt:
 		err = e.executeShutdown()
 	case *ast.AdminStmt:
-		err = e.executeAdmin(x)
+		err = e.executeAdmin(ctx, x)
 	case *ast.SetResourceGroupStmt:
 		err = e.executeSetResourceGroupName(x)
 	case *ast.AlterRangeStmt:
 		err = e.executeAlterRange(x)
 	case *ast.DropQueryWatchStmt:
 		err = e.executeDropQueryWatch(x)
+	case *ast.CreateMaskingPolicyStmt:
+		err = e.executeCreateMaskingPolicy(x)
+	case *ast.DropMaskingPolicyStmt:
+		err = e.executeDropMaskingPolicy(x)
+	case *ast.CreateServerStmt:
+		err = e.executeCreateServer(x)
+	case *ast.DropServerStmt:
+		err = e.executeDropServer(x)
+	case *ast.ProcedureInfo:
+		err = e.executeCreateProcedure(x)
+	case *ast.DropProcedureStmt:
+		err = e.executeDropProcedure(x)
+	case *ast.CreateEventStmt:
+		err = e.executeCreateEvent(x)
+	case *ast.DropEventStmt:
+		err = e.executeDropEvent(x)
 	}
 	e.done = true
 	return err
@@ -1111,7 +1130,16 @@ func (e *SimpleExec) executeCreateUser(ctx context.Context, s *ast.CreateUserStm
 	passwordInit := true
 	// Get changed user password reuse info.
 	savePasswdHistory := whetherSavePasswordHistory(plOptions)
-	sqlTemplate := "INSERT INTO %n.%n (Host, User, authentication_string, plugin, user_attributes, Account_locked, Token_issuer, Password_expired, Password_lifetime,  Password_reuse_time, Password_reuse_history) VALUES "
+	maxQuestions, maxUserConnections := int64(0), int64(0)
+	for _, option := range s.ResourceOptions {
+		switch option.Type {
+		case ast.MaxQueriesPerHour:
+			maxQuestions = option.Count
+		case ast.MaxUserConnections:
+			maxUserConnections = option.Count
+		}
+	}
+	sqlTemplate := "INSERT INTO %n.%n (Host, User, authentication_string, plugin, user_attributes, Account_locked, Token_issuer, Password_expired, Password_lifetime,  Password_reuse_time, Password_reuse_history, max_questions, max_user_connections) VALUES "
 	valueTemplate := "(%?, %?, %?, %?, %?, %?, %?, %?, %?"
 
 	sqlescape.MustFormatSQL(sql, sqlTemplate, mysql.SystemDB, mysql.UserTable)
@@ -1199,7 +1227,7 @@ func (e *SimpleExec) executeCreateUser(ctx context.Context, s *ast.CreateUserStm
 		} else {
 			sqlescape.MustFormatSQL(sql, `, %?`, nil)
 		}
-		sqlescape.MustFormatSQL(sql, `)`)
+		sqlescape.MustFormatSQL(sql, `, %?, %?)`, maxQuestions, maxUserConnections)
 		// The empty password does not count in the password history and is subject to reuse at any time.
 		// AuthTiDBAuthToken is the token login method on the cloud,
 		// and the Password Reuse Policy does not take effect.
@@ -1878,6 +1906,14 @@ func (e *SimpleExec) executeAlterUser(ctx context.Context, s *ast.AlterUserStmt)
 		if plOptions.passwordLifetime != notSpecified {
 			fields = append(fields, alterField{"password_lifetime=%?", plOptions.passwordLifetime})
 		}
+		for _, option := range s.ResourceOptions {
+			switch option.Type {
+			case ast.MaxQueriesPerHour:
+				fields = append(fields, alterField{"max_questions=%?", option.Count})
+			case ast.MaxUserConnections:
+				fields = append(fields, alterField{"max_user_connections=%?", option.Count})
+			}
+		}
 
 		var newAttributes []string
 		if s.CommentOrAttributeOption != nil {
@@ -2032,6 +2068,16 @@ func (e *SimpleExec) executeGrantRole(ctx context.Context, s *ast.GrantRoleStmt)
 			return exeerrors.ErrCannotUser.GenWithStackByArgs("GRANT ROLE", user.String())
 		}
 	}
+	// Granting a role to any of its nested roles creates a loop in the role graph.
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	for _, user := range s.Users {
+		grantee := &auth.RoleIdentity{Username: user.Username, Hostname: user.Hostname}
+		for _, role := range s.Roles {
+			if (role.Username == user.Username && role.Hostname == user.Hostname) || checker.IsRoleReachable(role, grantee) {
+				return exeerrors.ErrRoleGrantedToItself.GenWithStackByArgs(user.String(), role.String())
+			}
+		}
+	}
 
 	restrictedCtx, err := e.GetSysSession()
 	if err != nil {
@@ -2049,7 +2095,11 @@ func (e *SimpleExec) executeGrantRole(ctx context.Context, s *ast.GrantRoleStmt)
 	for _, user := range s.Users {
 		for _, role := range s.Roles {
 			sql.Reset()
-			sqlescape.MustFormatSQL(sql, `INSERT IGNORE INTO %n.%n (FROM_HOST, FROM_USER, TO_HOST, TO_USER) VALUES (%?,%?,%?,%?)`, mysql.SystemDB, mysql.RoleEdgeTable, role.Hostname, role.Username, user.Hostname, user.Username)
+			if s.WithAdminOption {
+				sqlescape.MustFormatSQL(sql, `INSERT INTO %n.%n (FROM_HOST, FROM_USER, TO_HOST, TO_USER, WITH_ADMIN_OPTION) VALUES (%?,%?,%?,%?,'Y') ON DUPLICATE KEY UPDATE WITH_ADMIN_OPTION='Y'`, mysql.SystemDB, mysql.RoleEdgeTable, role.Hostname, role.Username, user.Hostname, user.Username)
+			} else {
+				sqlescape.MustFormatSQL(sql, `INSERT IGNORE INTO %n.%n (FROM_HOST, FROM_USER, TO_HOST, TO_USER) VALUES (%?,%?,%?,%?)`, mysql.SystemDB, mysql.RoleEdgeTable, role.Hostname, role.Username, user.Hostname, user.Username)
+			}
 			if _, err := sqlExecutor.ExecuteInternal(internalCtx, sql.String()); err != nil {
 				logutil.BgLogger().Error(fmt.Sprintf("Error occur when executing %s", sql))
 				if _, err := sqlExecutor.ExecuteInternal(internalCtx, "rollback"); err != nil {
@@ -2195,6 +2245,589 @@ func (e *SimpleExec) executeDropQueryWatch(s *ast.DropQueryWatchStmt) error {
 	return querywatch.ExecDropQueryWatch(e.Ctx(), s.IntValue)
 }
 
+func maskingPolicyExists(ctx context.Context, sqlExecutor sqlexec.SQLExecutor, name string) (bool, error) {
+	sql := new(strings.Builder)
+	sqlescape.MustFormatSQL(sql, `SELECT 1 FROM mysql.tidb_masking_policy WHERE policy_name=%? FOR UPDATE`, name)
+	recordSet, err := sqlExecutor.ExecuteInternal(ctx, sql.String())
+	if err != nil {
+		return false, err
+	}
+	rows, err := sqlexec.DrainRecordSet(ctx, recordSet, 1)
+	if errClose := recordSet.Close(); err == nil {
+		err = errClose
+	}
+	return len(rows) > 0, err
+}
+
+func (e *SimpleExec) executeCreateMaskingPolicy(s *ast.CreateMaskingPolicyStmt) error {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnPrivilege)
+	tblInfo := s.Table.TableInfo
+	if tblInfo == nil {
+		return infoschema.ErrTableNotExists.GenWithStackByArgs(s.Table.Schema.O, s.Table.Name.O)
+	}
+	col := model.FindColumnInfo(tblInfo.Columns, s.Column.Name.L)
+	if col == nil {
+		return infoschema.ErrColumnNotExists.GenWithStackByArgs(s.Column.Name.O, tblInfo.Name.O)
+	}
+	var sb strings.Builder
+	restoreFlags := format.RestoreStringSingleQuotes | format.RestoreKeyWordLowercase | format.RestoreNameBackQuotes
+	if err := s.Expr.Restore(format.NewRestoreCtx(restoreFlags, &sb)); err != nil {
+		return err
+	}
+	expr := sb.String()
+
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.(sqlexec.SQLExecutor)
+	if _, err := sqlExecutor.ExecuteInternal(ctx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	exists, err := maskingPolicyExists(ctx, sqlExecutor, s.PolicyName.O)
+	if err == nil && exists {
+		if s.IfNotExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrMaskingPolicyExists.FastGenByArgs(s.PolicyName.O))
+		} else {
+			err = exeerrors.ErrMaskingPolicyExists.GenWithStackByArgs(s.PolicyName.O)
+		}
+	}
+	if err == nil && !exists {
+		sql := new(strings.Builder)
+		for _, role := range s.Roles {
+			sql.Reset()
+			sqlescape.MustFormatSQL(sql, `INSERT INTO mysql.tidb_masking_policy (policy_name, table_schema, table_name, column_name, masking_expr, role_user, role_host, created_by) VALUES (%?, %?, %?, %?, %?, %?, %?, %?)`,
+				s.PolicyName.O, s.Table.Schema.L, tblInfo.Name.L, col.Name.L, expr, role.Username, strings.ToLower(role.Hostname), e.Ctx().GetSessionVars().User.String())
+			if _, err = sqlExecutor.ExecuteInternal(ctx, sql.String()); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil || exists {
+		if _, rollbackErr := sqlExecutor.ExecuteInternal(ctx, "rollback"); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+	if _, err := sqlExecutor.ExecuteInternal(ctx, "commit"); err != nil {
+		return err
+	}
+	return domain.GetDomain(e.Ctx()).NotifyUpdatePrivilege()
+}
+
+func (e *SimpleExec) executeDropMaskingPolicy(s *ast.DropMaskingPolicyStmt) error {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnPrivilege)
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.(sqlexec.SQLExecutor)
+	if _, err := sqlExecutor.ExecuteInternal(ctx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	exists, err := maskingPolicyExists(ctx, sqlExecutor, s.PolicyName.O)
+	if err == nil && !exists {
+		if s.IfExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrMaskingPolicyNotExists.FastGenByArgs(s.PolicyName.O))
+		} else {
+			err = exeerrors.ErrMaskingPolicyNotExists.GenWithStackByArgs(s.PolicyName.O)
+		}
+	}
+	if err == nil && exists {
+		sql := new(strings.Builder)
+		sqlescape.MustFormatSQL(sql, `DELETE FROM mysql.tidb_masking_policy WHERE policy_name=%?`, s.PolicyName.O)
+		_, err = sqlExecutor.ExecuteInternal(ctx, sql.String())
+	}
+	if err != nil || !exists {
+		if _, rollbackErr := sqlExecutor.ExecuteInternal(ctx, "rollback"); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+	if _, err := sqlExecutor.ExecuteInternal(ctx, "commit"); err != nil {
+		return err
+	}
+	return domain.GetDomain(e.Ctx()).NotifyUpdatePrivilege()
+}
+
+func serverExists(ctx context.Context, sqlExecutor sqlexec.SQLExecutor, name string) (bool, error) {
+	sql := new(strings.Builder)
+	sqlescape.MustFormatSQL(sql, `SELECT 1 FROM mysql.servers WHERE Server_name=%? FOR UPDATE`, name)
+	recordSet, err := sqlExecutor.ExecuteInternal(ctx, sql.String())
+	if err != nil {
+		return false, err
+	}
+	rows, err := sqlexec.DrainRecordSet(ctx, recordSet, 1)
+	if errClose := recordSet.Close(); err == nil {
+		err = errClose
+	}
+	return len(rows) > 0, err
+}
+
+func (e *SimpleExec) executeCreateServer(s *ast.CreateServerStmt) error {
+	if s.WrapperName != "mysql" {
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs(fmt.Sprintf("FOREIGN DATA WRAPPER %s", s.WrapperName))
+	}
+	var host, db, user, password, socket, owner string
+	var port uint64
+	for _, opt := range s.Options {
+		switch opt.Tp {
+		case ast.ServerOptionHost:
+			host = opt.StrValue
+		case ast.ServerOptionDatabase:
+			db = opt.StrValue
+		case ast.ServerOptionUser:
+			user = opt.StrValue
+		case ast.ServerOptionPassword:
+			password = opt.StrValue
+		case ast.ServerOptionSocket:
+			socket = opt.StrValue
+		case ast.ServerOptionOwner:
+			owner = opt.StrValue
+		case ast.ServerOptionPort:
+			port = opt.UintValue
+		}
+	}
+
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.(sqlexec.SQLExecutor)
+	if _, err := sqlExecutor.ExecuteInternal(ctx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	exists, err := serverExists(ctx, sqlExecutor, s.ServerName.L)
+	if err == nil && exists {
+		if s.IfNotExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrForeignServerExists.FastGenByArgs(s.ServerName.O))
+		} else {
+			err = exeerrors.ErrForeignServerExists.GenWithStackByArgs(s.ServerName.O)
+		}
+	}
+	if err == nil && !exists {
+		sql := new(strings.Builder)
+		sqlescape.MustFormatSQL(sql, `INSERT INTO mysql.servers (Server_name, Host, Db, Username, Password, Port, Socket, Wrapper, Owner) VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?)`,
+			s.ServerName.L, host, db, user, password, port, socket, s.WrapperName, owner)
+		_, err = sqlExecutor.ExecuteInternal(ctx, sql.String())
+	}
+	if err != nil || exists {
+		if _, rollbackErr := sqlExecutor.ExecuteInternal(ctx, "rollback"); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(ctx, "commit")
+	return err
+}
+
+func (e *SimpleExec) executeDropServer(s *ast.DropServerStmt) error {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.(sqlexec.SQLExecutor)
+	if _, err := sqlExecutor.ExecuteInternal(ctx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	exists, err := serverExists(ctx, sqlExecutor, s.ServerName.L)
+	if err == nil && !exists {
+		if s.IfExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrForeignServerDoesntExist.FastGenByArgs(s.ServerName.O))
+		} else {
+			err = exeerrors.ErrForeignServerDoesntExist.GenWithStackByArgs(s.ServerName.O)
+		}
+	}
+	if err == nil && exists {
+		sql := new(strings.Builder)
+		sqlescape.MustFormatSQL(sql, `DELETE FROM mysql.servers WHERE Server_name=%?`, s.ServerName.L)
+		_, err = sqlExecutor.ExecuteInternal(ctx, sql.String())
+	}
+	if err != nil || !exists {
+		if _, rollbackErr := sqlExecutor.ExecuteInternal(ctx, "rollback"); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(ctx, "commit")
+	return err
+}
+
+func procedureExists(ctx context.Context, sqlExecutor sqlexec.SQLExecutor, schema, name string) (bool, error) {
+	sql := new(strings.Builder)
+	sqlescape.MustFormatSQL(sql, `SELECT 1 FROM mysql.routines WHERE route_schema=%? AND name=%? AND type='PROCEDURE' FOR UPDATE`, schema, name)
+	recordSet, err := sqlExecutor.ExecuteInternal(ctx, sql.String())
+	if err != nil {
+		return false, err
+	}
+	rows, err := sqlexec.DrainRecordSet(ctx, recordSet, 1)
+	if errClose := recordSet.Close(); err == nil {
+		err = errClose
+	}
+	return len(rows) > 0, err
+}
+
+// checkProcedureParams checks the parameters of a stored procedure have distinct names.
+func checkProcedureParams(params []*ast.StoreParameter) error {
+	names := make(map[string]struct{}, len(params))
+	for _, param := range params {
+		name := strings.ToLower(param.ParamName)
+		if _, ok := names[name]; ok {
+			return exeerrors.ErrSpDupParam.GenWithStackByArgs(param.ParamName)
+		}
+		names[name] = struct{}{}
+	}
+	return nil
+}
+
+// checkProcedureBody checks the body of a stored procedure only uses the supported statements,
+// and the variables declared in a block have distinct names.
+func checkProcedureBody(node ast.StmtNode) error {
+	switch x := node.(type) {
+	case *ast.ProcedureBlock:
+		names := make(map[string]struct{}, len(x.ProcedureVars))
+		for _, v := range x.ProcedureVars {
+			decl, ok := v.(*ast.ProcedureDecl)
+			if !ok {
+				return dbterror.ErrNotSupportedYet.GenWithStackByArgs("cursors and handlers in stored procedures")
+			}
+			for _, name := range decl.DeclNames {
+				lowerName := strings.ToLower(name)
+				if _, ok := names[lowerName]; ok {
+					return exeerrors.ErrSpDupVar.GenWithStackByArgs(name)
+				}
+				names[lowerName] = struct{}{}
+			}
+		}
+		return checkProcedureStmts(x.ProcedureProcStmts)
+	case *ast.ProcedureIfInfo:
+		return checkProcedureBody(x.IfBody)
+	case *ast.ProcedureIfBlock:
+		if err := checkProcedureStmts(x.ProcedureIfStmts); err != nil {
+			return err
+		}
+		if x.ProcedureElseStmt != nil {
+			return checkProcedureBody(x.ProcedureElseStmt)
+		}
+		return nil
+	case *ast.ProcedureElseIfBlock:
+		return checkProcedureBody(x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return checkProcedureStmts(x.ProcedureIfStmts)
+	case *ast.ProcedureWhileStmt:
+		return checkProcedureStmts(x.Body)
+	case *ast.ProcedureOpenCur, *ast.ProcedureCloseCur, *ast.ProcedureFetchInto:
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs("cursors in stored procedures")
+	case *ast.SimpleCaseStmt, *ast.SearchCaseStmt, *ast.ProcedureRepeatStmt,
+		*ast.ProcedureLabelBlock, *ast.ProcedureLabelLoop, *ast.ProcedureJump:
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs("CASE, REPEAT and labels in stored procedures")
+	case *ast.ProcedureInfo:
+		return exeerrors.ErrSpNoRecursiveCreate.GenWithStackByArgs("PROCEDURE")
+	}
+	return nil
+}
+
+func checkProcedureStmts(stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := checkProcedureBody(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *SimpleExec) executeCreateProcedure(s *ast.ProcedureInfo) error {
+	schema, name := s.ProcedureName.Schema, s.ProcedureName.Name
+	dbInfo, ok := e.is.SchemaByName(schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema.O)
+	}
+	if err := checkProcedureParams(s.ProcedureParam); err != nil {
+		return err
+	}
+	if err := checkProcedureBody(s.ProcedureBody); err != nil {
+		return err
+	}
+	sessVars := e.Ctx().GetSessionVars()
+	var definer string
+	if sessVars.User != nil {
+		definer = (&auth.UserIdentity{Username: sessVars.User.AuthUsername, Hostname: sessVars.User.AuthHostname}).String()
+	}
+	charsetClient, _ := sessVars.GetSystemVar(variable.CharacterSetClient)
+	collationConnection, _ := sessVars.GetSystemVar(variable.CollationConnection)
+	schemaCollation := dbInfo.Collate
+	if schemaCollation == "" {
+		schemaCollation = getDefaultCollate(dbInfo.Charset)
+	}
+
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.(sqlexec.SQLExecutor)
+	if _, err := sqlExecutor.ExecuteInternal(ctx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	exists, err := procedureExists(ctx, sqlExecutor, schema.L, name.L)
+	if err == nil && exists {
+		if s.IfNotExists {
+			sessVars.StmtCtx.AppendNote(exeerrors.ErrSpAlreadyExists.FastGenByArgs("PROCEDURE", name.O))
+		} else {
+			err = exeerrors.ErrSpAlreadyExists.GenWithStackByArgs("PROCEDURE", name.O)
+		}
+	}
+	if err == nil && !exists {
+		sql := new(strings.Builder)
+		sqlescape.MustFormatSQL(sql, `INSERT INTO mysql.routines (route_schema, name, type, definition, parameter_str, security_type, definer,
+			sql_mode, character_set_client, connection_collation, schema_collation) VALUES (%?, %?, 'PROCEDURE', %?, %?, %?, %?, %?, %?, %?, %?)`,
+			schema.L, name.L, s.ProcedureBody.Text(), s.ProcedureParamStr, s.SQLSecurity.String(), definer, sessVars.SQLMode.String(), charsetClient, collationConnection, schemaCollation)
+		_, err = sqlExecutor.ExecuteInternal(ctx, sql.String())
+	}
+	if err != nil || exists {
+		if _, rollbackErr := sqlExecutor.ExecuteInternal(ctx, "rollback"); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(ctx, "commit")
+	return err
+}
+
+func (e *SimpleExec) executeDropProcedure(s *ast.DropProcedureStmt) error {
+	schema, name := s.ProcedureName.Schema, s.ProcedureName.Name
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.(sqlexec.SQLExecutor)
+	if _, err := sqlExecutor.ExecuteInternal(ctx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	exists, err := procedureExists(ctx, sqlExecutor, schema.L, name.L)
+	if err == nil && !exists {
+		if s.IfExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrSpDoesNotExist.FastGenByArgs("PROCEDURE", schema.O+"."+name.O))
+		} else {
+			err = exeerrors.ErrSpDoesNotExist.GenWithStackByArgs("PROCEDURE", schema.O+"."+name.O)
+		}
+	}
+	if err == nil && exists {
+		sql := new(strings.Builder)
+		sqlescape.MustFormatSQL(sql, `DELETE FROM mysql.routines WHERE route_schema=%? AND name=%? AND type='PROCEDURE'`, schema.L, name.L)
+		_, err = sqlExecutor.ExecuteInternal(ctx, sql.String())
+	}
+	if err != nil || !exists {
+		if _, rollbackErr := sqlExecutor.ExecuteInternal(ctx, "rollback"); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(ctx, "commit")
+	return err
+}
+
+func eventExists(ctx context.Context, sqlExecutor sqlexec.SQLExecutor, schema, name string) (bool, error) {
+	sql := new(strings.Builder)
+	sqlescape.MustFormatSQL(sql, `SELECT 1 FROM mysql.events WHERE event_schema=%? AND event_name=%? FOR UPDATE`, schema, name)
+	recordSet, err := sqlExecutor.ExecuteInternal(ctx, sql.String())
+	if err != nil {
+		return false, err
+	}
+	rows, err := sqlexec.DrainRecordSet(ctx, recordSet, 1)
+	if errClose := recordSet.Close(); err == nil {
+		err = errClose
+	}
+	return len(rows) > 0, err
+}
+
+// evalEventTime evaluates the AT, STARTS or ENDS expression of an event to a time in the session time zone.
+func (e *SimpleExec) evalEventTime(expr ast.ExprNode, clause string) (time.Time, error) {
+	d, err := expression.EvalSimpleAst(e.Ctx().GetExprCtx(), expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	sessVars := e.Ctx().GetSessionVars()
+	converted, err := d.ConvertTo(sessVars.StmtCtx.TypeCtx(), types.NewFieldType(mysql.TypeDatetime))
+	if err != nil || converted.IsNull() || converted.GetMysqlTime().IsZero() {
+		return time.Time{}, types.ErrWrongValue.GenWithStackByArgs(clause, d.String())
+	}
+	return converted.GetMysqlTime().CoreTime().GoTime(sessVars.Location())
+}
+
+func (e *SimpleExec) executeCreateEvent(s *ast.CreateEventStmt) error {
+	schema, name := s.Name.Schema, s.Name.Name
+	dbInfo, ok := e.is.SchemaByName(schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema.O)
+	}
+	if err := checkProcedureBody(s.Body); err != nil {
+		return err
+	}
+	sessVars := e.Ctx().GetSessionVars()
+	now := time.Now()
+	var (
+		executeAt, starts, ends any
+		intervalValue           any
+		intervalField           any
+		// completed indicates the event won't be executed anymore because its schedule is in the past.
+		completed bool
+	)
+	sched := s.Schedule
+	if sched.At != nil {
+		at, err := e.evalEventTime(sched.At, "AT")
+		if err != nil {
+			return err
+		}
+		executeAt = at.Unix()
+		completed = at.Before(now)
+	} else {
+		switch sched.Unit {
+		case ast.TimeUnitYear, ast.TimeUnitQuarter, ast.TimeUnitMonth, ast.TimeUnitWeek,
+			ast.TimeUnitDay, ast.TimeUnitHour, ast.TimeUnitMinute, ast.TimeUnitSecond:
+		default:
+			return dbterror.ErrNotSupportedYet.GenWithStackByArgs(fmt.Sprintf("the interval unit %s of event", sched.Unit.String()))
+		}
+		d, err := expression.EvalSimpleAst(e.Ctx().GetExprCtx(), sched.Every)
+		if err != nil {
+			return err
+		}
+		val, err := d.ToInt64(sessVars.StmtCtx.TypeCtx())
+		if err != nil || d.IsNull() || val <= 0 {
+			return exeerrors.ErrEventIntervalNotPositiveOrTooBig.GenWithStackByArgs()
+		}
+		intervalValue, intervalField = val, sched.Unit.String()
+		startTime := now
+		if sched.Starts != nil {
+			if startTime, err = e.evalEventTime(sched.Starts, "STARTS"); err != nil {
+				return err
+			}
+		}
+		starts = startTime.Unix()
+		if sched.Ends != nil {
+			endTime, err := e.evalEventTime(sched.Ends, "ENDS")
+			if err != nil {
+				return err
+			}
+			if endTime.Before(startTime) {
+				return exeerrors.ErrEventEndsBeforeStarts.GenWithStackByArgs()
+			}
+			ends = endTime.Unix()
+			completed = endTime.Before(now)
+		}
+	}
+	status := "ENABLED"
+	if s.Disabled {
+		status = "DISABLED"
+	}
+	onCompletion := "DROP"
+	if s.Preserve {
+		onCompletion = "PRESERVE"
+	}
+	if completed {
+		// the same as MySQL, an event whose schedule is in the past is dropped immediately, or disabled
+		// if it's preserved.
+		if !s.Preserve {
+			sessVars.StmtCtx.AppendNote(exeerrors.ErrEventCannotCreateInThePast.FastGenByArgs())
+			return nil
+		}
+		sessVars.StmtCtx.AppendNote(exeerrors.ErrEventExecTimeInThePast.FastGenByArgs())
+		status = "DISABLED"
+	}
+
+	var definer string
+	if sessVars.User != nil {
+		definer = (&auth.UserIdentity{Username: sessVars.User.AuthUsername, Hostname: sessVars.User.AuthHostname}).String()
+	}
+	timeZone, _ := sessVars.GetSystemVar(variable.TimeZone)
+	charsetClient, _ := sessVars.GetSystemVar(variable.CharacterSetClient)
+	collationConnection, _ := sessVars.GetSystemVar(variable.CollationConnection)
+	dbCollation := dbInfo.Collate
+	if dbCollation == "" {
+		dbCollation = getDefaultCollate(dbInfo.Charset)
+	}
+
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.(sqlexec.SQLExecutor)
+	if _, err := sqlExecutor.ExecuteInternal(ctx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	exists, err := eventExists(ctx, sqlExecutor, schema.L, name.L)
+	if err == nil && exists {
+		if s.IfNotExists {
+			sessVars.StmtCtx.AppendNote(exeerrors.ErrEventAlreadyExists.FastGenByArgs(name.O))
+		} else {
+			err = exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(name.O)
+		}
+	}
+	if err == nil && !exists {
+		sql := new(strings.Builder)
+		sqlescape.MustFormatSQL(sql, `INSERT INTO mysql.events (event_schema, event_name, definer, time_zone, body, execute_at, interval_value,
+			interval_field, starts, ends, status, on_completion, sql_mode, comment, character_set_client, collation_connection, db_collation)
+			VALUES (%?, %?, %?, %?, %?, FROM_UNIXTIME(%?), %?, %?, FROM_UNIXTIME(%?), FROM_UNIXTIME(%?), %?, %?, %?, %?, %?, %?, %?)`,
+			schema.L, name.L, definer, timeZone, s.Body.Text(), executeAt, intervalValue, intervalField, starts, ends, status, onCompletion,
+			sessVars.SQLMode.String(), s.Comment, charsetClient, collationConnection, dbCollation)
+		_, err = sqlExecutor.ExecuteInternal(ctx, sql.String())
+	}
+	if err != nil || exists {
+		if _, rollbackErr := sqlExecutor.ExecuteInternal(ctx, "rollback"); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(ctx, "commit")
+	return err
+}
+
+func (e *SimpleExec) executeDropEvent(s *ast.DropEventStmt) error {
+	schema, name := s.Name.Schema, s.Name.Name
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.(sqlexec.SQLExecutor)
+	if _, err := sqlExecutor.ExecuteInternal(ctx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	exists, err := eventExists(ctx, sqlExecutor, schema.L, name.L)
+	if err == nil && !exists {
+		if s.IfExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventDoesNotExist.FastGenByArgs(name.O))
+		} else {
+			err = exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(name.O)
+		}
+	}
+	if err == nil && exists {
+		sql := new(strings.Builder)
+		sqlescape.MustFormatSQL(sql, `DELETE FROM mysql.events WHERE event_schema=%? AND event_name=%?`, schema.L, name.L)
+		_, err = sqlExecutor.ExecuteInternal(ctx, sql.String())
+	}
+	if err != nil || !exists {
+		if _, rollbackErr := sqlExecutor.ExecuteInternal(ctx, "rollback"); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(ctx, "commit")
+	return err
+}
+
 func (e *SimpleExec) executeDropUser(ctx context.Context, s *ast.DropUserStmt) error {
 	internalCtx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnPrivilege)
 	// Check privileges.
@@ -2720,7 +3353,9 @@ func (e *SimpleExec) autoNewTxn() bool {
 	// Data definition language (DDL) statements that define or modify database objects.
 	// (handled in DDL package)
 	// Statements that implicitly use or modify tables in the mysql database.
-	case *ast.CreateUserStmt, *ast.AlterUserStmt, *ast.DropUserStmt, *ast.RenameUserStmt, *ast.RevokeRoleStmt, *ast.GrantRoleStmt:
+	case *ast.CreateUserStmt, *ast.AlterUserStmt, *ast.DropUserStmt, *ast.RenameUserStmt, *ast.RevokeRoleStmt, *ast.GrantRoleStmt,
+		*ast.CreateMaskingPolicyStmt, *ast.DropMaskingPolicyStmt, *ast.CreateServerStmt, *ast.DropServerStmt,
+		*ast.ProcedureInfo, *ast.DropProcedureStmt, *ast.CreateEventStmt, *ast.DropEventStmt:
 		return true
 	// Transaction-control and locking statements.  BEGIN, LOCK TABLES, SET autocommit = 1 (if the value is not already 1), START TRANSACTION, UNLOCK TABLES.
 	// (handled in other place)
@@ -2783,7 +3418,7 @@ func (e *SimpleExec) executeSetSessionStates(ctx context.Context, s *ast.SetSess
 	return e.Ctx().DecodeSessionStates(ctx, e.Ctx(), &sessionStates)
 }
 
-func (e *SimpleExec) executeAdmin(s *ast.AdminStmt) error {
+func (e *SimpleExec) executeAdmin(ctx context.Context, s *ast.AdminStmt) error {
 	switch s.Tp {
 	case ast.AdminReloadStatistics:
 		return e.executeAdminReloadStatistics(s)
@@ -2793,6 +3428,13 @@ func (e *SimpleExec) executeAdmin(s *ast.AdminStmt) error {
 		return e.executeAdminSetBDRRole(s)
 	case ast.AdminUnsetBDRRole:
 		return e.executeAdminUnsetBDRRole()
+	case ast.AdminRotateEncryptionKey:
+		return e.executeAdminRotateEncryptionKey()
+	case ast.AdminWaitTiFlashReplica:
+		timeout := time.Duration(s.TiFlashReplicaWait.Timeout) * time.Second
+		return waitTiFlashReplicaAvailable(ctx, e.Ctx(), s.Tables, timeout)
+	case ast.AdminPauseLogBackup, ast.AdminResumeLogBackup:
+		return e.executeAdminLogBackup(ctx, s)
 	}
 	return nil
 }
@@ -2849,6 +3491,25 @@ func (e *SimpleExec) executeAdminUnsetBDRRole() error {
 	return errors.Trace(meta.NewMeta(txn).ClearBDRRole())
 }
 
+// executeAdminRotateEncryptionKey asks every TiKV instance to rotate its data key of encryption at rest,
+// the progress can be observed through INFORMATION_SCHEMA.CLUSTER_ENCRYPTION_STATUS.
+func (e *SimpleExec) executeAdminRotateEncryptionKey() error {
+	servers, err := getTiKVServerInfo(e.Ctx())
+	if err != nil {
+		return err
+	}
+	if len(servers) == 0 {
+		return errors.New("there is no TiKV instance to rotate the encryption key")
+	}
+	ctx := context.Background()
+	for _, srv := range servers {
+		if _, err := requestTiKVEncryptionAPI(ctx, http.MethodPost, srv.StatusAddr, tikvEncryptionRotatePath); err != nil {
+			return errors.Annotatef(err, "rotate the encryption key of tikv %s", srv.Address)
+		}
+	}
+	return nil
+}
+
 func (e *SimpleExec) executeSetResourceGroupName(s *ast.SetResourceGroupStmt) error {
 	if s.Name.L != "" {
 		if _, ok := e.is.ResourceGroupByName(s.Name); !ok {
@@ -54,6 +54,9 @@ import (
 
 type signalsKey struct{}
 
+// compressedLogFileSuffix is the suffix of the compressed rotated log files.
+const compressedLogFileSuffix = ".gz"
+
 // ParseSlowLogBatchSize is the batch size of slow-log lines for a worker to parse, exported for testing.
 var ParseSlowLogBatchSize = 64
 
@@ -294,6 +297,7 @@ func (e *slowQueryRetriever) getBatchLog(ctx context.Context, reader *bufio.Read
 	log := make([]string, 0, num)
 	var err error
 	for i := 0; i < num; i++ {
+		entryStart := len(log)
 		for {
 			if isCtxDone(ctx) {
 				return nil, ctx.Err()
@@ -315,6 +319,11 @@ func (e *slowQueryRetriever) getBatchLog(ctx context.Context, reader *bufio.Read
 			}
 			line = string(hack.String(lineByte))
 			log = append(log, line)
+			// A JSON slow log is a whole entry in one line, so only the first line of an entry
+			// can be a JSON slow log. A line of a multi-line query in a text entry may also start with '{'.
+			if len(log) == entryStart+1 && !strings.HasPrefix(line, variable.SlowLogStartPrefixStr) && isJSONSlowLog(line) {
+				break
+			}
 			if strings.HasSuffix(line, variable.SlowLogSQLSuffixStr) {
 				if strings.HasPrefix(line, "use") || strings.HasPrefix(line, variable.SlowLogRowPrefixStr) {
 					continue
@@ -366,6 +375,13 @@ func (e *slowQueryRetriever) getBatchLogForReversedScan(ctx context.Context, rea
 			return nil, err
 		}
 		line = string(hack.String(lineByte))
+		if !hasStartFlag && isJSONSlowLog(line) {
+			logs = append(logs, slowLogBlock{line})
+			if scanPreviousFile {
+				break
+			}
+			continue
+		}
 		if !hasStartFlag && strings.HasPrefix(line, variable.SlowLogStartPrefixStr) {
 			hasStartFlag = true
 		}
@@ -558,11 +574,24 @@ func (e *slowQueryRetriever) parseLog(ctx context.Context, sctx sessionctx.Conte
 	user := ""
 	tz := sctx.GetSessionVars().Location()
 	startFlag := false
+	// The log always starts at the beginning of an entry, see getBatchLog.
+	entryStart := true
 	for index, line := range log {
 		if isCtxDone(ctx) {
 			return nil, ctx.Err()
 		}
+		// Only the first line of an entry can be a JSON slow log, a line of a multi-line query may also start with '{'.
+		atEntryStart := entryStart
+		entryStart = (atEntryStart && isJSONSlowLog(line)) || (strings.HasSuffix(line, variable.SlowLogSQLSuffixStr) &&
+			!strings.HasPrefix(line, "use") && !strings.HasPrefix(line, variable.SlowLogRowPrefixStr))
 		fileLine := getLineIndex(offset, index)
+		if !startFlag && atEntryStart && isJSONSlowLog(line) {
+			if row := e.parseJSONLog(sctx, tz, line, fileLine); row != nil {
+				e.memConsume(types.EstimatedMemUsage(row, 1))
+				data = append(data, row)
+			}
+			continue
+		}
 		if !startFlag && strings.HasPrefix(line, variable.SlowLogStartPrefixStr) {
 			row = make([]types.Datum, len(e.outputCols))
 			user = ""
@@ -873,6 +902,11 @@ func (e *slowQueryRetriever) getAllFiles(ctx context.Context, sctx sessionctx.Co
 		if !strings.HasPrefix(path, prefix) {
 			return nil
 		}
+		// The rotated log files compressed by slow-query-compress are skipped, so the slow
+		// queries in them can't be queried from the SLOW_QUERY table.
+		if strings.HasSuffix(path, compressedLogFileSuffix) {
+			return nil
+		}
 		if isCtxDone(ctx) {
 			return ctx.Err()
 		}
@@ -962,6 +996,9 @@ func (*slowQueryRetriever) getFileStartTime(ctx context.Context, file *os.File)
 		if strings.HasPrefix(line, variable.SlowLogStartPrefixStr) {
 			return ParseTime(line[len(variable.SlowLogStartPrefixStr):])
 		}
+		if isJSONSlowLog(line) {
+			return getJSONSlowLogTime(line)
+		}
 		maxNum--
 		if maxNum <= 0 {
 			break
@@ -1043,6 +1080,12 @@ func (*slowQueryRetriever) getFileEndTime(ctx context.Context, file *os.File) (t
 			if strings.HasPrefix(lines[i], variable.SlowLogStartPrefixStr) {
 				return ParseTime(lines[i][len(variable.SlowLogStartPrefixStr):])
 			}
+			// The line may also be a line of a multi-line query in a text entry.
+			if isJSONSlowLog(lines[i]) {
+				if t, err := getJSONSlowLogTime(lines[i]); err == nil {
+					return t, nil
+				}
+			}
 		}
 		tried += len(lines)
 		if tried >= maxLineNum {
@@ -0,0 +1,123 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// The JSON slow log writes each slow query as a single line like below:
+// {"Time":"2019-04-28T15:24:04.309074+08:00","Txn_start_ts":"406315658548871171","User":"root","Host":"127.0.0.1",...,"Query":"select * from t;"}
+// The field names are the same as the column names of INFORMATION_SCHEMA.SLOW_QUERY,
+// and all the values are strings in the same format as the text slow log.
+
+// isJSONSlowLog returns whether the line is a slow log in JSON format.
+func isJSONSlowLog(line string) bool {
+	return strings.HasPrefix(line, "{")
+}
+
+// decodeJSONSlowLog decodes the fields of a JSON slow log in order.
+func decodeJSONSlowLog(line string) (fields []string, values []string, err error) {
+	dec := json.NewDecoder(strings.NewReader(line))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, nil, errors.Errorf("invalid slow log %s", line)
+	}
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		field, ok := tok.(string)
+		if !ok {
+			return nil, nil, errors.Errorf("invalid slow log %s", line)
+		}
+		var value any
+		if err = dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		fields = append(fields, field)
+		switch v := value.(type) {
+		case string:
+			values = append(values, v)
+		case nil:
+			values = append(values, "")
+		default:
+			// Be tolerant to the non-string values written by other tools.
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, nil, err
+			}
+			values = append(values, string(b))
+		}
+	}
+	if _, err = dec.Token(); err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+	return fields, values, nil
+}
+
+// getJSONSlowLogTime returns the time of a JSON slow log.
+func getJSONSlowLogTime(line string) (time.Time, error) {
+	fields, values, err := decodeJSONSlowLog(line)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for i, field := range fields {
+		if field == variable.SlowLogTimeStr {
+			return ParseTime(values[i])
+		}
+	}
+	return time.Time{}, errors.Errorf("invalid slow log %s, the time field is missing", line)
+}
+
+// parseJSONLog parses a JSON slow log into a row. It returns nil if the slow
+// log is filtered out or invalid.
+func (e *slowQueryRetriever) parseJSONLog(sctx sessionctx.Context, tz *time.Location, line string, fileLine int) []types.Datum {
+	fields, values, err := decodeJSONSlowLog(line)
+	if err != nil {
+		sctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("Parse slow log at line %v, error is %v", fileLine, err))
+		return nil
+	}
+	user := ""
+	for i, field := range fields {
+		if field == variable.SlowLogUserStr {
+			user = values[i]
+			break
+		}
+	}
+	if e.checker != nil && !e.checker.hasPrivilege(user) {
+		return nil
+	}
+	row := make([]types.Datum, len(e.outputCols))
+	for i, field := range fields {
+		if !e.setColumnValue(sctx, row, tz, field, values[i], e.checker, fileLine) {
+			return nil
+		}
+	}
+	e.setDefaultValue(row)
+	return row
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/mock"
 	"github.com/stretchr/testify/require"
@@ -246,6 +247,87 @@ select * from t;
 	require.Equal(t, warnings[0].Err.Error(), "Parse slow log at line 2, failed field is Succ, failed value is abc, error is strconv.ParseBool: parsing \"abc\": invalid syntax")
 }
 
+func TestParseJSONSlowLogFile(t *testing.T) {
+	jsonLog := `{"Time":"2019-04-28T15:24:04.309074+08:00","Txn_start_ts":"405888132465033227","User":"root","Host":"localhost",` +
+		`"Session_alias":"alias123","Query_time":"0.216905","Cop_time":"0.38","Process_time":"0.021","Request_count":"1",` +
+		`"Total_keys":"637","Processed_keys":"436","Is_internal":"true",` +
+		`"Digest":"42a1c8aae6f133e934d4bf0147491709a8812ea05ff8819ec522780fe657b772","Succ":"false",` +
+		`"Plan_digest":"60e9378c746d9a2be1c791047e008967cf252eb6de9167ad3aa6098fa2d523f4","Prev_stmt":"update t set i = 1;",` +
+		`"Backoff_Detail":"Cop_backoff_rpcPD_total_times: 200 Cop_backoff_rpcPD_total_time: 0.2 ` +
+		`Cop_backoff_rpcTiKV_total_times: 100 Cop_backoff_rpcTiKV_total_time: 0.1","Query":"select * from t where a = \"x\";"}`
+	require.True(t, isJSONSlowLog(jsonLog))
+	ts, err := getJSONSlowLogTime(jsonLog)
+	require.NoError(t, err)
+	require.Equal(t, int64(1556436244), ts.Unix())
+
+	textLog := `# Time: 2019-04-28T15:24:03.309074+08:00
+# Txn_start_ts: 405888132465033226
+select 1;`
+	reader := bufio.NewReader(bytes.NewBufferString(textLog + "\n" + jsonLog + "\n"))
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	ctx := mock.NewContext()
+	ctx.ResetSessionAndStmtTimeZone(loc)
+	rows, err := parseSlowLog(ctx, reader)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Len(t, ctx.GetSessionVars().StmtCtx.GetWarnings(), 0)
+
+	retriever, err := newSlowQueryRetriever()
+	require.NoError(t, err)
+	getColumn := func(row []types.Datum, name string) string {
+		for i, col := range retriever.outputCols {
+			if col.Name.O == name {
+				str, err := row[i].ToString()
+				require.NoError(t, err)
+				return str
+			}
+		}
+		require.FailNow(t, "column not found", name)
+		return ""
+	}
+	require.Equal(t, "select 1;", getColumn(rows[0], variable.SlowLogQuerySQLStr))
+	require.Equal(t, "2019-04-28 15:24:04.309074", getColumn(rows[1], variable.SlowLogTimeStr))
+	require.Equal(t, "405888132465033227", getColumn(rows[1], variable.SlowLogTxnStartTSStr))
+	require.Equal(t, "root", getColumn(rows[1], variable.SlowLogUserStr))
+	require.Equal(t, "localhost", getColumn(rows[1], variable.SlowLogHostStr))
+	require.Equal(t, "alias123", getColumn(rows[1], variable.SlowLogSessAliasStr))
+	require.Equal(t, "0.021", getColumn(rows[1], execdetails.ProcessTimeStr))
+	require.Equal(t, "637", getColumn(rows[1], execdetails.TotalKeysStr))
+	require.Equal(t, "Cop_backoff_rpcPD_total_times: 200 Cop_backoff_rpcPD_total_time: 0.2 "+
+		"Cop_backoff_rpcTiKV_total_times: 100 Cop_backoff_rpcTiKV_total_time: 0.1", getColumn(rows[1], variable.SlowLogBackoffDetail))
+	require.Equal(t, "60e9378c746d9a2be1c791047e008967cf252eb6de9167ad3aa6098fa2d523f4", getColumn(rows[1], variable.SlowLogPlanDigest))
+	require.Equal(t, "update t set i = 1;", getColumn(rows[1], variable.SlowLogPrevStmt))
+	require.Equal(t, `select * from t where a = "x";`, getColumn(rows[1], variable.SlowLogQuerySQLStr))
+
+	// Malformed JSON slow log is skipped with a warning.
+	reader = bufio.NewReader(bytes.NewBufferString("{\"Time\":\n"))
+	rows, err = parseSlowLog(ctx, reader)
+	require.NoError(t, err)
+	require.Len(t, rows, 0)
+	require.Len(t, ctx.GetSessionVars().StmtCtx.GetWarnings(), 1)
+
+	// A line of a multi-line query starting with '{' is not a JSON slow log.
+	textLog = `# Time: 2019-04-28T15:24:03.309074+08:00
+# Txn_start_ts: 405888132465033226
+select json_extract('
+{"a": 1}', '$.a');`
+	reader = bufio.NewReader(bytes.NewBufferString(textLog + "\n" + jsonLog + "\n"))
+	batch, err := retriever.getBatchLog(context.Background(), reader, &offset{}, 1)
+	require.NoError(t, err)
+	require.Equal(t, strings.Split(textLog, "\n"), batch[0])
+	batch, err = retriever.getBatchLog(context.Background(), reader, &offset{}, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{jsonLog}, batch[0])
+	ctx.GetSessionVars().StmtCtx.SetWarnings(nil)
+	reader = bufio.NewReader(bytes.NewBufferString(textLog + "\n" + jsonLog + "\n"))
+	rows, err = parseSlowLog(ctx, reader)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Len(t, ctx.GetSessionVars().StmtCtx.GetWarnings(), 0)
+	require.Equal(t, `select * from t where a = "x";`, getColumn(rows[0], variable.SlowLogQuerySQLStr))
+}
+
 // It changes variable.MaxOfMaxAllowedPacket, so must be stayed in SerialSuite.
 func TestParseSlowLogFileSerial(t *testing.T) {
 	loc, err := time.LoadLocation("Asia/Shanghai")
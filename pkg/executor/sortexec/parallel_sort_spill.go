@@ -0,0 +1,118 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sortexec
+
+import (
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"go.uber.org/zap"
+)
+
+// parallelSortSpillAction implements memory.ActionOnExceed for the parallel sort. If the memory
+// quota of a query is exceeded, it asks every worker holding rows to spill them as a sorted run
+// to disk, the workers spill when they receive the next chunk.
+type parallelSortSpillAction struct {
+	memory.BaseOOMAction
+	e *SortExec
+}
+
+// GetPriority get the priority of the Action.
+func (*parallelSortSpillAction) GetPriority() int64 {
+	return memory.DefSpillPriority
+}
+
+func (p *parallelSortSpillAction) Action(t *memory.Tracker) {
+	if p.e.triggerSpillForParallel() {
+		logutil.BgLogger().Info("memory exceeds quota, parallel sort workers spill to disk now.",
+			zap.Int64("consumed", t.BytesConsumed()), zap.Int64("quota", t.GetBytesLimit()))
+		return
+	}
+
+	if !t.CheckExceed() {
+		return
+	}
+
+	if fallBack := p.GetFallback(); fallBack != nil {
+		fallBack.Action(t)
+	}
+}
+
+// triggerSpillForParallel marks the workers to spill if they hold enough data,
+// it returns false if there is not enough data to spill.
+func (e *SortExec) triggerSpillForParallel() bool {
+	totalMemUsage := int64(0)
+	for _, worker := range e.Parallel.workers {
+		totalMemUsage += worker.memUsage.Load()
+	}
+	if totalMemUsage == 0 || totalMemUsage < e.spillLimit {
+		return false
+	}
+
+	for _, worker := range e.Parallel.workers {
+		if worker.memUsage.Load() > 0 {
+			worker.needSpill.Store(true)
+		}
+	}
+	return true
+}
+
+// sortedRowsInDisk reads a sorted run spilled by a parallel sort worker chunk by chunk,
+// so that merging the runs only holds one chunk of each run in memory.
+type sortedRowsInDisk struct {
+	inDisk  *chunk.DataInDiskByChunks
+	chkIdx  int
+	chkIter *chunk.Iterator4Chunk
+	err     error
+}
+
+func newSortedRowsInDisk(inDisk *chunk.DataInDiskByChunks) *sortedRowsInDisk {
+	return &sortedRowsInDisk{
+		inDisk:  inDisk,
+		chkIdx:  -1,
+		chkIter: chunk.NewIterator4Chunk(nil),
+	}
+}
+
+// Begin implements the sortedRows interface.
+func (s *sortedRowsInDisk) Begin() chunk.Row {
+	s.chkIdx = -1
+	return s.nextChunk()
+}
+
+// Next implements the sortedRows interface.
+func (s *sortedRowsInDisk) Next() chunk.Row {
+	row := s.chkIter.Next()
+	if row.IsEmpty() {
+		return s.nextChunk()
+	}
+	return row
+}
+
+func (s *sortedRowsInDisk) nextChunk() chunk.Row {
+	for s.err == nil && s.chkIdx+1 < s.inDisk.NumChunks() {
+		s.chkIdx++
+		chk, err := s.inDisk.GetChunk(s.chkIdx)
+		if err != nil {
+			s.err = err
+			break
+		}
+		s.chkIter = chunk.NewIterator4Chunk(chk)
+		if row := s.chkIter.Begin(); !row.IsEmpty() {
+			return row
+		}
+	}
+	return chunk.Row{}
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/pingcap/tidb/pkg/executor/internal/testutil"
 	"github.com/pingcap/tidb/pkg/executor/sortexec"
 	"github.com/pingcap/tidb/pkg/expression"
+	plannerutil "github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/pingcap/tidb/pkg/util/mock"
 	"github.com/stretchr/testify/require"
@@ -138,3 +140,68 @@ func TestFailpoint(t *testing.T) {
 		failpointTest(t, ctx, exe, sortCase, dataSource)
 	}
 }
+
+func TestParallelSortSpillDisk(t *testing.T) {
+	sortexec.SetSmallSpillChunkSizeForTest()
+	ctx := mock.NewContext()
+	sortCase := &testutil.SortCase{Rows: 8192, OrderByIdx: []int{0, 1}, Ndvs: []int{0, 0}, Ctx: ctx}
+	failpoint.Enable("github.com/pingcap/tidb/pkg/executor/sortexec/SignalCheckpointForSort", `return(true)`)
+	ctx.GetSessionVars().InitChunkSize = 32
+	ctx.GetSessionVars().MaxChunkSize = 32
+
+	schema := expression.NewSchema(sortCase.Columns()...)
+	dataSource := buildDataSource(ctx, sortCase, schema)
+	for i := 0; i < 20; i++ {
+		ctx.GetSessionVars().MemTracker = memory.NewTracker(memory.LabelForSQLText, 20000)
+		ctx.GetSessionVars().StmtCtx.MemTracker = memory.NewTracker(memory.LabelForSQLText, -1)
+		ctx.GetSessionVars().StmtCtx.MemTracker.AttachTo(ctx.GetSessionVars().MemTracker)
+		ctx.GetSessionVars().StmtCtx.RuntimeStatsColl = execdetails.NewRuntimeStatsColl(nil)
+		ctx.GetSessionVars().EnableParallelSort = true
+
+		dataSource.PrepareChunks()
+		exe := &sortexec.SortExec{
+			BaseExecutor: exec.NewBaseExecutor(ctx, dataSource.Schema(), 1, dataSource),
+			ByItems:      make([]*plannerutil.ByItems, 0, len(sortCase.OrderByIdx)),
+			ExecSchema:   dataSource.Schema(),
+		}
+		for _, idx := range sortCase.OrderByIdx {
+			exe.ByItems = append(exe.ByItems, &plannerutil.ByItems{Expr: sortCase.Columns()[idx]})
+		}
+		resultChunks := executeSortExecutor(t, exe)
+		require.Greater(t, exe.GetSpilledRunNumForTest(), 0)
+
+		err := exe.Close()
+		require.NoError(t, err)
+		require.True(t, checkCorrectness(schema, exe, dataSource, resultChunks))
+
+		stats := exe.GetRuntimeStatsForTest()
+		require.NotNil(t, stats)
+		require.Len(t, stats.WorkerStats, ctx.GetSessionVars().ExecutorConcurrency)
+		taskNum := int64(0)
+		for _, workerStat := range stats.WorkerStats {
+			taskNum += workerStat.TaskNum
+		}
+		require.Equal(t, int64(sortCase.Rows/32), taskNum)
+		str := stats.String()
+		require.Contains(t, str, "sort_worker:{concurrency:")
+		require.Contains(t, str, "spill:{num:")
+		require.Contains(t, str, "merge_time:")
+	}
+}
+
+func TestSortRuntimeStats(t *testing.T) {
+	stats := &sortexec.SortRuntimeStats{
+		Concurrency: 2,
+		MergeTime:   int64(time.Second),
+		WorkerStats: []*sortexec.SortWorkerStat{
+			{TaskNum: 3, WaitTime: int64(time.Millisecond), SortTime: int64(2 * time.Millisecond), WorkerTime: int64(4 * time.Millisecond)},
+			{TaskNum: 5, WaitTime: int64(time.Millisecond), SortTime: int64(3 * time.Millisecond), SpillNum: 2, SpillTime: int64(5 * time.Millisecond), WorkerTime: int64(10 * time.Millisecond)},
+		},
+	}
+	expected := "sort_worker:{concurrency:2, task_num:8, tot_wait:2ms, tot_sort:5ms, tot_time:14ms, max:10ms, p95:10ms}, spill:{num:2, tot_time:5ms}, merge_time:1s"
+	require.Equal(t, expected, stats.String())
+	cloned := stats.Clone()
+	require.Equal(t, expected, cloned.String())
+	stats.Merge(cloned)
+	require.Equal(t, "sort_worker:{concurrency:2, task_num:16, tot_wait:4ms, tot_sort:10ms, tot_time:28ms, max:10ms, p95:10ms}, spill:{num:4, tot_time:10ms}, merge_time:2s", stats.String())
+}
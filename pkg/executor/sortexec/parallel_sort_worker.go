@@ -17,10 +17,13 @@ package sortexec
 import (
 	"math/rand"
 	"slices"
+	"sync/atomic"
 	"time"
 
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/disk"
 	"github.com/pingcap/tidb/pkg/util/memory"
 )
 
@@ -38,13 +41,26 @@ type parallelSortWorker struct {
 
 	timesOfRowCompare uint
 
-	memTracker *memory.Tracker
+	memTracker  *memory.Tracker
+	diskTracker *disk.Tracker
+	fieldTypes  []*types.FieldType
 
 	localSortedRows    []*chunk.Iterator4Slice
 	sortedRowsIter     *chunk.Iterator4Slice
 	maxSortedRowsLimit int
 	batchRows          []chunk.Row
 	merger             *multiWayMerger
+
+	// memUsage is the memory usage of the chunks held by the worker, which has been consumed
+	// by the chunk fetcher. It's released after the rows are spilled.
+	memUsage atomic.Int64
+	// needSpill is set by parallelSortSpillAction, the worker spills its rows when it receives
+	// the next chunk.
+	needSpill atomic.Bool
+	// spilledRuns are the sorted runs spilled by the worker, each of them is sorted.
+	spilledRuns []*chunk.DataInDiskByChunks
+
+	stats *SortWorkerStat
 }
 
 func newParallelSortWorker(
@@ -54,8 +70,11 @@ func newParallelSortWorker(
 	errOutputChan chan rowWithError,
 	finishCh chan struct{},
 	memTracker *memory.Tracker,
+	diskTracker *disk.Tracker,
+	fieldTypes []*types.FieldType,
 	sortedRowsIter *chunk.Iterator4Slice,
-	maxChunkSize int) *parallelSortWorker {
+	maxChunkSize int,
+	stats *SortWorkerStat) *parallelSortWorker {
 	maxSortedRowsLimit := maxChunkSize * 30
 	return &parallelSortWorker{
 		workerIDForTest:    workerIDForTest,
@@ -65,9 +84,12 @@ func newParallelSortWorker(
 		finishCh:           finishCh,
 		timesOfRowCompare:  0,
 		memTracker:         memTracker,
+		diskTracker:        diskTracker,
+		fieldTypes:         fieldTypes,
 		sortedRowsIter:     sortedRowsIter,
 		maxSortedRowsLimit: maxSortedRowsLimit,
 		batchRows:          make([]chunk.Row, 0, maxSortedRowsLimit),
+		stats:              stats,
 	}
 }
 
@@ -91,7 +113,11 @@ func (p *parallelSortWorker) multiWayMergeLocalSortedRows() []chunk.Row {
 		totalRowNum += rows.Len()
 	}
 	resultSortedRows := make([]chunk.Row, 0, totalRowNum)
-	p.merger = newMultiWayMerger(p.localSortedRows, p.lessRowFunc)
+	sources := make([]sortedRows, 0, len(p.localSortedRows))
+	for _, rows := range p.localSortedRows {
+		sources = append(sources, rows)
+	}
+	p.merger = newMultiWayMerger(sources, p.lessRowFunc)
 	p.merger.init()
 
 	for {
@@ -105,6 +131,9 @@ func (p *parallelSortWorker) multiWayMergeLocalSortedRows() []chunk.Row {
 }
 
 func (p *parallelSortWorker) sortBatchRows(batchRows []chunk.Row) {
+	if p.stats != nil {
+		defer addDuration(&p.stats.SortTime, time.Now())
+	}
 	slices.SortFunc(batchRows, p.keyColumnsLess)
 	p.localSortedRows = append(p.localSortedRows, chunk.NewIterator4Slice(batchRows))
 }
@@ -115,9 +144,59 @@ func (p *parallelSortWorker) sortLocalRows() []chunk.Row {
 		p.sortBatchRows(p.batchRows)
 	}
 
+	if p.stats != nil {
+		defer addDuration(&p.stats.SortTime, time.Now())
+	}
 	return p.multiWayMergeLocalSortedRows()
 }
 
+// spillLocalRows sorts all the rows held by the worker and spills them to disk as a sorted run.
+func (p *parallelSortWorker) spillLocalRows() error {
+	p.needSpill.Store(false)
+	rows := p.sortLocalRows()
+	p.localSortedRows = nil
+	p.batchRows = make([]chunk.Row, 0, p.maxSortedRowsLimit)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if p.stats != nil {
+		defer addDuration(&p.stats.SpillTime, time.Now())
+		atomic.AddInt64(&p.stats.SpillNum, 1)
+	}
+
+	inDisk := chunk.NewDataInDiskByChunks(p.fieldTypes)
+	inDisk.GetDiskTracker().AttachTo(p.diskTracker)
+	inDisk.GetDiskTracker().SetLabel(memory.LabelForRowChunks)
+	// Record the run before writing it so that it can always be closed.
+	p.spilledRuns = append(p.spilledRuns, inDisk)
+
+	tmpChk := chunk.NewChunkWithCapacity(p.fieldTypes, spillChunkSize)
+	for _, row := range rows {
+		tmpChk.AppendRow(row)
+		if tmpChk.IsFull() {
+			err := inDisk.Add(tmpChk)
+			if err != nil {
+				return err
+			}
+			tmpChk.Reset()
+			p.memTracker.HandleKillSignal()
+		}
+	}
+
+	// Do not spill when tmpChk is empty as `Add` function requires a non-empty chunk
+	if tmpChk.NumRows() > 0 {
+		err := inDisk.Add(tmpChk)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Release memory as all rows have been spilled to disk
+	p.memTracker.Consume(-p.memUsage.Swap(0))
+	return nil
+}
+
 // Fetching a bunch of chunks from chunkChannel and sort them.
 // After receiving all chunks, we will get several sorted rows slices and we use k-way merge to sort them.
 func (p *parallelSortWorker) fetchChunksAndSort() {
@@ -126,11 +205,19 @@ func (p *parallelSortWorker) fetchChunksAndSort() {
 		ok  bool
 	)
 
+	if p.stats != nil {
+		defer addDuration(&p.stats.WorkerTime, time.Now())
+	}
+
 	for {
+		waitStart := time.Now()
 		select {
 		case <-p.finishCh:
 			return
 		case chk, ok = <-p.chunkChannel:
+			if p.stats != nil {
+				addDuration(&p.stats.WaitTime, waitStart)
+			}
 			// Memory usage of the chunk has been consumed at the chunk fetcher
 			if !ok {
 				rows := p.sortLocalRows()
@@ -139,6 +226,10 @@ func (p *parallelSortWorker) fetchChunksAndSort() {
 			}
 		}
 
+		if p.stats != nil {
+			atomic.AddInt64(&p.stats.TaskNum, 1)
+		}
+		p.memUsage.Add(chk.MemoryUsage() + chunk.RowSize*int64(chk.NumRows()))
 		chkIter := chunk.NewIterator4Chunk(chk)
 		row := chkIter.Begin()
 		for !row.IsEmpty() {
@@ -152,6 +243,16 @@ func (p *parallelSortWorker) fetchChunksAndSort() {
 			p.batchRows = make([]chunk.Row, 0, p.maxSortedRowsLimit)
 		}
 
+		if p.needSpill.Load() {
+			if err := p.spillLocalRows(); err != nil {
+				select {
+				case <-p.finishCh:
+				case p.errOutputChan <- rowWithError{err: err}:
+				}
+				return
+			}
+		}
+
 		p.injectFailPointForParallelSortWorker()
 	}
 }
@@ -80,13 +80,22 @@ type SortExec struct {
 		sortedRowsIters []*chunk.Iterator4Slice
 
 		resultChannel chan rowWithError
+
+		// spillAction save the Action for spill disk.
+		spillAction *parallelSortSpillAction
 	}
 
 	enableTmpStorageOnOOM bool
+
+	stats *SortRuntimeStats
 }
 
 // Close implements the Executor Close interface.
 func (e *SortExec) Close() error {
+	if e.stats != nil {
+		defer e.Ctx().GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(e.ID(), e.stats)
+	}
+
 	// TopN not initialize `e.finishCh` but it will call the Close function
 	if e.finishCh != nil {
 		close(e.finishCh)
@@ -94,6 +103,9 @@ func (e *SortExec) Close() error {
 	if e.Unparallel.spillAction != nil {
 		e.Unparallel.spillAction.SetFinished()
 	}
+	if e.Parallel.spillAction != nil {
+		e.Parallel.spillAction.SetFinished()
+	}
 
 	if e.IsUnparallel {
 		for _, partition := range e.Unparallel.sortPartitions {
@@ -126,6 +138,7 @@ func (e *SortExec) Open(ctx context.Context) error {
 	e.fetched.Store(false)
 	e.enableTmpStorageOnOOM = variable.EnableTmpStorageOnOOM.Load()
 	e.finishCh = make(chan struct{}, 1)
+	e.stats = nil
 
 	// To avoid duplicated initialization for TopNExec.
 	if e.memTracker == nil {
@@ -147,6 +160,8 @@ func (e *SortExec) Open(ctx context.Context) error {
 		for i := range e.Parallel.sortedRowsIters {
 			e.Parallel.sortedRowsIters[i] = chunk.NewIterator4Slice(nil)
 		}
+		e.Parallel.spillAction = &parallelSortSpillAction{e: e}
+		e.initRuntimeStats()
 	}
 
 	e.Unparallel.sortPartitions = e.Unparallel.sortPartitions[:0]
@@ -168,10 +183,12 @@ func (e *SortExec) Open(ctx context.Context) error {
 //  2. Parallel Sort Worker: Worker receives chunks from channel it will sort these chunks after the
 //     number of rows in these chunks exceeds limit, we call them as sorted rows after chunks are sorted.
 //     Then each worker will have several sorted rows, we use multi-way merge to sort them and each worker
-//     will have only one sorted rows in the end.
-//  3. Result Generator: Generator gets n sorted rows from n workers, it will use multi-way merge to sort
-//     these rows, once it gets the next row, it will send it into `resultChannel` and the goroutine who
-//     calls `Next()` will fetch result from `resultChannel`.
+//     will have only one sorted rows in the end. If memory quota is triggered, workers merge their sorted
+//     rows and spill them into disk as a sorted run, then release the memory and continue to receive chunks.
+//  3. Result Generator: Generator gets n sorted rows from n workers and all the sorted runs in disk, it will
+//     use multi-way merge to sort these rows, sorted runs in disk are read chunk by chunk so that the memory
+//     usage of merging is bounded. Once it gets the next row, it will send it into `resultChannel` and the
+//     goroutine who calls `Next()` will fetch result from `resultChannel`.
 /*
                         ┌─────────┐
                         │  Child  │
@@ -272,6 +289,19 @@ func (e *SortExec) generateResult(waitGroups ...*util.WaitGroupWrapper) {
 		waitGroup.Wait()
 	}
 
+	sources := make([]sortedRows, 0, len(e.Parallel.sortedRowsIters))
+	for _, iter := range e.Parallel.sortedRowsIters {
+		sources = append(sources, iter)
+	}
+	spilledRuns := make([]*sortedRowsInDisk, 0)
+	for _, worker := range e.Parallel.workers {
+		for _, inDisk := range worker.spilledRuns {
+			run := newSortedRowsInDisk(inDisk)
+			spilledRuns = append(spilledRuns, run)
+			sources = append(sources, run)
+		}
+	}
+
 	defer func() {
 		if r := recover(); r != nil {
 			processPanicAndLog(e.Parallel.resultChannel, r)
@@ -281,9 +311,16 @@ func (e *SortExec) generateResult(waitGroups ...*util.WaitGroupWrapper) {
 		for i := range e.Parallel.sortedRowsIters {
 			e.Parallel.sortedRowsIters[i].Reset(nil)
 		}
+		for _, run := range spilledRuns {
+			run.inDisk.Close()
+		}
 	}()
 
-	merger := newMultiWayMerger(e.Parallel.sortedRowsIters, e.lessRow)
+	if e.stats != nil {
+		defer addDuration(&e.stats.MergeTime, time.Now())
+	}
+
+	merger := newMultiWayMerger(sources, e.lessRow)
 	merger.init()
 
 	maxChunkSize := e.MaxChunkSize()
@@ -298,6 +335,16 @@ func (e *SortExec) generateResult(waitGroups ...*util.WaitGroupWrapper) {
 			resBuf = append(resBuf, row)
 		}
 
+		for _, run := range spilledRuns {
+			if run.err != nil {
+				select {
+				case <-e.finishCh:
+				case e.Parallel.resultChannel <- rowWithError{err: run.err}:
+				}
+				return
+			}
+		}
+
 		if len(resBuf) == 0 {
 			break
 		}
@@ -530,14 +577,28 @@ func (e *SortExec) fetchChunksParallel(ctx context.Context) error {
 	// Wait for the finish of chunk fetcher
 	fetcherWaiter := util.WaitGroupWrapper{}
 
+	fields := exec.RetTypes(e)
+	for i := range e.Parallel.workers {
+		var stats *SortWorkerStat
+		if e.stats != nil {
+			stats = &SortWorkerStat{}
+			e.stats.WorkerStats = append(e.stats.WorkerStats, stats)
+		}
+		e.Parallel.workers[i] = newParallelSortWorker(i, e.lessRow, e.Parallel.chunkChannel, e.Parallel.resultChannel, e.finishCh, e.memTracker, e.diskTracker, fields, e.Parallel.sortedRowsIters[i], e.MaxChunkSize(), stats)
+	}
+
+	// Workers must be initialized before the spill action is registered, as the action reads their memory usage.
+	if e.enableTmpStorageOnOOM {
+		e.Ctx().GetSessionVars().MemTracker.FallbackOldAndSetNewAction(e.Parallel.spillAction)
+	}
+
 	// Fetch chunks from child and put chunks into chunkChannel
 	fetcherWaiter.Run(func() {
 		e.fetchChunksFromChild(ctx)
 	})
 
-	for i := range e.Parallel.workers {
-		e.Parallel.workers[i] = newParallelSortWorker(i, e.lessRow, e.Parallel.chunkChannel, e.Parallel.resultChannel, e.finishCh, e.memTracker, e.Parallel.sortedRowsIters[i], e.MaxChunkSize())
-		worker := e.Parallel.workers[i]
+	for _, worker := range e.Parallel.workers {
+		worker := worker
 		workersWaiter.Run(func() {
 			worker.run()
 		})
@@ -581,6 +642,15 @@ func (e *SortExec) fetchChunksFromChild(ctx context.Context) {
 	}
 }
 
+func (e *SortExec) initRuntimeStats() {
+	if e.RuntimeStats() != nil {
+		e.stats = &SortRuntimeStats{
+			Concurrency: len(e.Parallel.workers),
+			WorkerStats: make([]*SortWorkerStat, 0, len(e.Parallel.workers)),
+		}
+	}
+}
+
 func (e *SortExec) initCompareFuncs() {
 	e.keyCmpFuncs = make([]chunk.CompareFunc, len(e.ByItems))
 	for i := range e.ByItems {
@@ -645,6 +715,20 @@ func (e *SortExec) GetSortPartitionListLenForTest() int {
 	return len(e.Unparallel.sortPartitions)
 }
 
+// GetSpilledRunNumForTest returns the number of sorted runs spilled by parallel sort workers, it's only used in test.
+func (e *SortExec) GetSpilledRunNumForTest() int {
+	num := 0
+	for _, worker := range e.Parallel.workers {
+		num += len(worker.spilledRuns)
+	}
+	return num
+}
+
+// GetRuntimeStatsForTest returns the runtime stats of parallel sort, it's only used in test.
+func (e *SortExec) GetRuntimeStatsForTest() *SortRuntimeStats {
+	return e.stats
+}
+
 // GetSortMetaForTest returns some sort meta, it's only used in test.
 func (e *SortExec) GetSortMetaForTest() (keyColumns []int, keyCmpFuncs []chunk.CompareFunc, byItemsDesc []bool) {
 	keyColumns = e.keyColumns
@@ -15,12 +15,19 @@
 package sortexec
 
 import (
+	"bytes"
+	"cmp"
 	"container/heap"
+	"fmt"
 	"math/rand"
+	"slices"
+	"sync/atomic"
+	"time"
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"go.uber.org/zap"
 )
@@ -59,13 +66,20 @@ func (h *multiWayMergeImpl) Swap(i, j int) {
 	h.elements[i], h.elements[j] = h.elements[j], h.elements[i]
 }
 
+// sortedRows is a source of sorted rows which can be merged by multiWayMerger.
+// The rows may be held in memory or be spilled to disk.
+type sortedRows interface {
+	Begin() chunk.Row
+	Next() chunk.Row
+}
+
 type multiWayMerger struct {
-	sortedRowsIters []*chunk.Iterator4Slice
+	sortedRowsIters []sortedRows
 	multiWayMerge   *multiWayMergeImpl
 }
 
 func newMultiWayMerger(
-	sortedRowsIters []*chunk.Iterator4Slice,
+	sortedRowsIters []sortedRows,
 	lessRowFunction func(rowI chunk.Row, rowJ chunk.Row) int) *multiWayMerger {
 	return &multiWayMerger{
 		sortedRowsIters: sortedRowsIters,
@@ -123,3 +137,96 @@ func injectParallelSortRandomFail() {
 		}
 	})
 }
+
+// SortRuntimeStats records the runtime stats of the parallel sort.
+type SortRuntimeStats struct {
+	Concurrency int
+	// MergeTime is the time the result generator spends on merging the sorted rows of all workers.
+	MergeTime   int64
+	WorkerStats []*SortWorkerStat
+}
+
+// SortWorkerStat records the runtime stats of a parallel sort worker.
+type SortWorkerStat struct {
+	TaskNum    int64
+	WaitTime   int64
+	SortTime   int64
+	SpillNum   int64
+	SpillTime  int64
+	WorkerTime int64
+}
+
+// Clone implements the RuntimeStats interface.
+func (w *SortWorkerStat) Clone() *SortWorkerStat {
+	return &SortWorkerStat{
+		TaskNum:    atomic.LoadInt64(&w.TaskNum),
+		WaitTime:   atomic.LoadInt64(&w.WaitTime),
+		SortTime:   atomic.LoadInt64(&w.SortTime),
+		SpillNum:   atomic.LoadInt64(&w.SpillNum),
+		SpillTime:  atomic.LoadInt64(&w.SpillTime),
+		WorkerTime: atomic.LoadInt64(&w.WorkerTime),
+	}
+}
+
+// String implements the RuntimeStats interface.
+func (e *SortRuntimeStats) String() string {
+	var totalTime, totalWait, totalSort, totalSpill, totalTaskNum, totalSpillNum int64
+	workerStats := make([]*SortWorkerStat, 0, len(e.WorkerStats))
+	for _, w := range e.WorkerStats {
+		w = w.Clone()
+		totalTime += w.WorkerTime
+		totalWait += w.WaitTime
+		totalSort += w.SortTime
+		totalSpill += w.SpillTime
+		totalTaskNum += w.TaskNum
+		totalSpillNum += w.SpillNum
+		workerStats = append(workerStats, w)
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, 64))
+	fmt.Fprintf(buf, "sort_worker:{concurrency:%d, task_num:%d, tot_wait:%s, tot_sort:%s, tot_time:%s",
+		e.Concurrency, totalTaskNum, time.Duration(totalWait), time.Duration(totalSort), time.Duration(totalTime))
+	n := len(workerStats)
+	if n > 0 {
+		slices.SortFunc(workerStats, func(i, j *SortWorkerStat) int { return cmp.Compare(i.WorkerTime, j.WorkerTime) })
+		fmt.Fprintf(buf, ", max:%v, p95:%v",
+			time.Duration(workerStats[n-1].WorkerTime), time.Duration(workerStats[n*19/20].WorkerTime))
+	}
+	buf.WriteString("}")
+	if totalSpillNum > 0 {
+		fmt.Fprintf(buf, ", spill:{num:%d, tot_time:%s}", totalSpillNum, time.Duration(totalSpill))
+	}
+	fmt.Fprintf(buf, ", merge_time:%s", time.Duration(atomic.LoadInt64(&e.MergeTime)))
+	return buf.String()
+}
+
+// Clone implements the RuntimeStats interface.
+func (e *SortRuntimeStats) Clone() execdetails.RuntimeStats {
+	newRs := &SortRuntimeStats{
+		Concurrency: e.Concurrency,
+		MergeTime:   atomic.LoadInt64(&e.MergeTime),
+		WorkerStats: make([]*SortWorkerStat, 0, len(e.WorkerStats)),
+	}
+	for _, s := range e.WorkerStats {
+		newRs.WorkerStats = append(newRs.WorkerStats, s.Clone())
+	}
+	return newRs
+}
+
+// Merge implements the RuntimeStats interface.
+func (e *SortRuntimeStats) Merge(other execdetails.RuntimeStats) {
+	tmp, ok := other.(*SortRuntimeStats)
+	if !ok {
+		return
+	}
+	atomic.AddInt64(&e.MergeTime, atomic.LoadInt64(&tmp.MergeTime))
+	e.WorkerStats = append(e.WorkerStats, tmp.WorkerStats...)
+}
+
+// Tp implements the RuntimeStats interface.
+func (*SortRuntimeStats) Tp() int {
+	return execdetails.TpSortRuntimeStats
+}
+
+func addDuration(stat *int64, startTime time.Time) {
+	atomic.AddInt64(stat, int64(time.Since(startTime)))
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/store/helper"
+	"github.com/pingcap/tidb/pkg/store/regioncache"
 	"github.com/pingcap/tidb/pkg/table/tables"
 	"github.com/pingcap/tidb/pkg/tablecodec"
 	"github.com/pingcap/tidb/pkg/types"
@@ -52,6 +53,8 @@ type SplitIndexRegionExec struct {
 	upper          []types.Datum
 	num            int
 	valueLists     [][]types.Datum
+	encodedValues  [][]byte
+	scatter        bool
 	splitIdxKeys   [][]byte
 
 	done bool
@@ -111,7 +114,7 @@ func (e *SplitIndexRegionExec) splitIndexRegion(ctx context.Context) error {
 		return nil
 	}
 
-	if !e.Ctx().GetSessionVars().WaitSplitRegionFinish {
+	if !e.scatter && !e.Ctx().GetSessionVars().WaitSplitRegionFinish {
 		return nil
 	}
 	e.finishScatterNum = waitScatterRegionFinish(ctxWithTimeout, e.Ctx(), start, s, regionIDs, e.tableInfo.Name.L, e.indexInfo.Name.L)
@@ -119,8 +122,8 @@ func (e *SplitIndexRegionExec) splitIndexRegion(ctx context.Context) error {
 }
 
 func (e *SplitIndexRegionExec) getSplitIdxKeys() ([][]byte, error) {
-	// Split index regions by user specified value lists.
-	if len(e.valueLists) > 0 {
+	// Split index regions by user specified value lists or the encoded values computed from the statistics.
+	if len(e.valueLists) > 0 || len(e.encodedValues) > 0 {
 		return e.getSplitIdxKeysFromValueList()
 	}
 
@@ -130,13 +133,13 @@ func (e *SplitIndexRegionExec) getSplitIdxKeys() ([][]byte, error) {
 func (e *SplitIndexRegionExec) getSplitIdxKeysFromValueList() (keys [][]byte, err error) {
 	pi := e.tableInfo.GetPartitionInfo()
 	if pi == nil {
-		keys = make([][]byte, 0, len(e.valueLists)+1)
+		keys = make([][]byte, 0, len(e.valueLists)+len(e.encodedValues)+1)
 		return e.getSplitIdxPhysicalKeysFromValueList(e.tableInfo.ID, keys)
 	}
 
 	// Split for all table partitions.
 	if len(e.partitionNames) == 0 {
-		keys = make([][]byte, 0, (len(e.valueLists)+1)*len(pi.Definitions))
+		keys = make([][]byte, 0, (len(e.valueLists)+len(e.encodedValues)+1)*len(pi.Definitions))
 		for _, p := range pi.Definitions {
 			keys, err = e.getSplitIdxPhysicalKeysFromValueList(p.ID, keys)
 			if err != nil {
@@ -147,7 +150,7 @@ func (e *SplitIndexRegionExec) getSplitIdxKeysFromValueList() (keys [][]byte, er
 	}
 
 	// Split for specified table partitions.
-	keys = make([][]byte, 0, (len(e.valueLists)+1)*len(e.partitionNames))
+	keys = make([][]byte, 0, (len(e.valueLists)+len(e.encodedValues)+1)*len(e.partitionNames))
 	for _, name := range e.partitionNames {
 		pid, err := tables.FindPartitionByName(e.tableInfo, name.L)
 		if err != nil {
@@ -172,6 +175,9 @@ func (e *SplitIndexRegionExec) getSplitIdxPhysicalKeysFromValueList(physicalID i
 		}
 		keys = append(keys, idxKey)
 	}
+	for _, v := range e.encodedValues {
+		keys = append(keys, tablecodec.EncodeIndexSeekKey(physicalID, e.indexInfo.ID, v))
+	}
 	return keys, nil
 }
 
@@ -332,6 +338,8 @@ type SplitTableRegionExec struct {
 	num            int
 	handleCols     core.HandleCols
 	valueLists     [][]types.Datum
+	encodedValues  [][]byte
+	scatter        bool
 	splitKeys      [][]byte
 
 	done bool
@@ -382,7 +390,7 @@ func (e *SplitTableRegionExec) splitTableRegion(ctx context.Context) error {
 		return nil
 	}
 
-	if !e.Ctx().GetSessionVars().WaitSplitRegionFinish {
+	if !e.scatter && !e.Ctx().GetSessionVars().WaitSplitRegionFinish {
 		return nil
 	}
 
@@ -446,7 +454,7 @@ func isCtxDone(ctx context.Context) bool {
 var minRegionStepValue = int64(1000)
 
 func (e *SplitTableRegionExec) getSplitTableKeys() ([][]byte, error) {
-	if len(e.valueLists) > 0 {
+	if len(e.valueLists) > 0 || len(e.encodedValues) > 0 {
 		return e.getSplitTableKeysFromValueList()
 	}
 
@@ -457,13 +465,13 @@ func (e *SplitTableRegionExec) getSplitTableKeysFromValueList() ([][]byte, error
 	var keys [][]byte
 	pi := e.tableInfo.GetPartitionInfo()
 	if pi == nil {
-		keys = make([][]byte, 0, len(e.valueLists))
+		keys = make([][]byte, 0, len(e.valueLists)+len(e.encodedValues))
 		return e.getSplitTablePhysicalKeysFromValueList(e.tableInfo.ID, keys)
 	}
 
 	// Split for all table partitions.
 	if len(e.partitionNames) == 0 {
-		keys = make([][]byte, 0, len(e.valueLists)*len(pi.Definitions))
+		keys = make([][]byte, 0, (len(e.valueLists)+len(e.encodedValues))*len(pi.Definitions))
 		for _, p := range pi.Definitions {
 			var err error
 			keys, err = e.getSplitTablePhysicalKeysFromValueList(p.ID, keys)
@@ -475,7 +483,7 @@ func (e *SplitTableRegionExec) getSplitTableKeysFromValueList() ([][]byte, error
 	}
 
 	// Split for specified table partitions.
-	keys = make([][]byte, 0, len(e.valueLists)*len(e.partitionNames))
+	keys = make([][]byte, 0, (len(e.valueLists)+len(e.encodedValues))*len(e.partitionNames))
 	for _, name := range e.partitionNames {
 		pid, err := tables.FindPartitionByName(e.tableInfo, name.L)
 		if err != nil {
@@ -499,6 +507,9 @@ func (e *SplitTableRegionExec) getSplitTablePhysicalKeysFromValueList(physicalID
 		key := tablecodec.EncodeRecordKey(recordPrefix, handle)
 		keys = append(keys, key)
 	}
+	for _, v := range e.encodedValues {
+		keys = append(keys, tablecodec.EncodeRowKey(physicalID, v))
+	}
 	return keys, nil
 }
 
@@ -641,7 +652,7 @@ func getPhysicalTableRegions(physicalTableID int64, tableInfo *model.TableInfo,
 	// for record
 	startKey, endKey := tablecodec.GetTableHandleKeyRange(physicalTableID)
 	regionCache := tikvStore.GetRegionCache()
-	recordRegionMetas, err := regionCache.LoadRegionsInKeyRange(tikv.NewBackofferWithVars(context.Background(), 20000, nil), startKey, endKey)
+	recordRegionMetas, _, err := regioncache.RepairRegionsInKeyRange(tikv.NewBackofferWithVars(context.Background(), 20000, nil), regionCache, startKey, endKey)
 	if err != nil {
 		return nil, err
 	}
@@ -659,7 +670,7 @@ func getPhysicalTableRegions(physicalTableID int64, tableInfo *model.TableInfo,
 			continue
 		}
 		startKey, endKey := tablecodec.GetTableIndexKeyRange(physicalTableID, index.ID)
-		regionMetas, err := regionCache.LoadRegionsInKeyRange(tikv.NewBackofferWithVars(context.Background(), 20000, nil), startKey, endKey)
+		regionMetas, _, err := regioncache.RepairRegionsInKeyRange(tikv.NewBackofferWithVars(context.Background(), 20000, nil), regionCache, startKey, endKey)
 		if err != nil {
 			return nil, err
 		}
@@ -684,7 +695,7 @@ func getPhysicalIndexRegions(physicalTableID int64, indexInfo *model.IndexInfo,
 
 	startKey, endKey := tablecodec.GetTableIndexKeyRange(physicalTableID, indexInfo.ID)
 	regionCache := tikvStore.GetRegionCache()
-	regions, err := regionCache.LoadRegionsInKeyRange(tikv.NewBackofferWithVars(context.Background(), 20000, nil), startKey, endKey)
+	regions, _, err := regioncache.RepairRegionsInKeyRange(tikv.NewBackofferWithVars(context.Background(), 20000, nil), regionCache, startKey, endKey)
 	if err != nil {
 		return nil, err
 	}
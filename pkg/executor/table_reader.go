@@ -185,6 +185,9 @@ type TableReaderExecutor struct {
 	// If dummy flag is set, this is not a real TableReader, it just provides the KV ranges for UnionScan.
 	// Used by the temporary table, cached table.
 	dummy bool
+
+	// runtimeFilters are generated by the hash join executed in TiDB, the requests are sent after they are applied.
+	runtimeFilters *tableReaderRuntimeFilters
 }
 
 // Table implements the dataSourceExecutor interface.
@@ -240,6 +243,9 @@ func (e *TableReaderExecutor) Open(ctx context.Context) error {
 			if err != nil {
 				return err
 			}
+			if e.runtimeFilters != nil {
+				e.runtimeFilters.baseExecutors = e.dagPB.Executors
+			}
 		}
 	}
 	if e.GetSessionVars().StmtCtx.RuntimeStatsColl != nil {
@@ -288,6 +294,18 @@ func (e *TableReaderExecutor) Open(ctx context.Context) error {
 		return nil
 	}
 
+	if e.runtimeFilters != nil {
+		// The requests are sent when the first chunk is requested, since the runtime filters are
+		// built after the build side of the hash join is fetched.
+		e.runtimeFilters.pending = true
+		e.runtimeFilters.filteredOut = false
+		e.runtimeFilters.firstPartRanges, e.runtimeFilters.secondPartRanges = firstPartRanges, secondPartRanges
+		return nil
+	}
+	return e.openResultHandler(ctx, firstPartRanges, secondPartRanges)
+}
+
+func (e *TableReaderExecutor) openResultHandler(ctx context.Context, firstPartRanges, secondPartRanges []*ranger.Range) error {
 	firstResult, err := e.buildResp(ctx, firstPartRanges)
 	if err != nil {
 		return err
@@ -321,6 +339,23 @@ func (e *TableReaderExecutor) Next(ctx context.Context, req *chunk.Chunk) error
 		}
 		return tableName
 	}), e.ranges)
+	if rfs := e.runtimeFilters; rfs != nil {
+		if rfs.pending {
+			rfs.pending = false
+			if err := e.applyRuntimeFilters(ctx); err != nil {
+				return err
+			}
+			if !rfs.filteredOut {
+				if err := e.openResultHandler(ctx, rfs.firstPartRanges, rfs.secondPartRanges); err != nil {
+					return err
+				}
+			}
+		}
+		if rfs.filteredOut {
+			req.Reset()
+			return nil
+		}
+	}
 	if err := e.resultHandler.nextChunk(ctx, req); err != nil {
 		return err
 	}
@@ -358,7 +393,7 @@ func (e *TableReaderExecutor) buildResp(ctx context.Context, ranges []*ranger.Ra
 			}
 			var results []distsql.SelectResult
 			for _, kvReq := range kvReqs {
-				result, err := e.SelectResult(ctx, e.dctx, kvReq, exec.RetTypes(e), getPhysicalPlanIDs(e.plans), e.ID())
+				result, err := e.SelectResult(ctx, e.dctx, kvReq, exec.RetTypes(e), e.copPlanIDs(), e.ID())
 				if err != nil {
 					return nil, err
 				}
@@ -371,7 +406,7 @@ func (e *TableReaderExecutor) buildResp(ctx context.Context, ranges []*ranger.Ra
 		if err != nil {
 			return nil, err
 		}
-		result, err := e.SelectResult(ctx, e.dctx, kvReq, exec.RetTypes(e), getPhysicalPlanIDs(e.plans), e.ID())
+		result, err := e.SelectResult(ctx, e.dctx, kvReq, exec.RetTypes(e), e.copPlanIDs(), e.ID())
 		if err != nil {
 			return nil, err
 		}
@@ -386,7 +421,7 @@ func (e *TableReaderExecutor) buildResp(ctx context.Context, ranges []*ranger.Ra
 		}
 		var results []distsql.SelectResult
 		for _, kvReq := range kvReqs {
-			result, err := e.SelectResult(ctx, e.dctx, kvReq, exec.RetTypes(e), getPhysicalPlanIDs(e.plans), e.ID())
+			result, err := e.SelectResult(ctx, e.dctx, kvReq, exec.RetTypes(e), e.copPlanIDs(), e.ID())
 			if err != nil {
 				return nil, err
 			}
@@ -407,13 +442,21 @@ func (e *TableReaderExecutor) buildResp(ctx context.Context, ranges []*ranger.Ra
 	})
 	e.kvRanges = kvReq.KeyRanges.AppendSelfTo(e.kvRanges)
 
-	result, err := e.SelectResult(ctx, e.dctx, kvReq, exec.RetTypes(e), getPhysicalPlanIDs(e.plans), e.ID())
+	result, err := e.SelectResult(ctx, e.dctx, kvReq, exec.RetTypes(e), e.copPlanIDs(), e.ID())
 	if err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
+// copPlanIDs returns the ids of the plans executed by the coprocessor requests.
+func (e *TableReaderExecutor) copPlanIDs() []int {
+	if e.runtimeFilters != nil && e.runtimeFilters.skipSelection {
+		return getPhysicalPlanIDs(e.plans[:1])
+	}
+	return getPhysicalPlanIDs(e.plans)
+}
+
 func (e *TableReaderExecutor) buildKVReqSeparately(ctx context.Context, ranges []*ranger.Range) ([]*kv.Request, error) {
 	pids, kvRanges, err := e.kvRangeBuilder.buildKeyRangeSeparately(ranges)
 	if err != nil {
@@ -20,6 +20,7 @@ import (
 	"math/rand"
 	"regexp"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	mysql "github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/executor"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/session"
 	"github.com/pingcap/tidb/pkg/sessionctx"
@@ -35,6 +37,7 @@ import (
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/table/tables"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/testkit/external"
 	"github.com/pingcap/tidb/pkg/testkit/testutil"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/codec"
@@ -42,6 +45,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/logutil/consistency"
 	"github.com/pingcap/tidb/pkg/util/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
 )
 
@@ -641,6 +645,65 @@ func TestAdminCleanupIndexForPartitionTable(t *testing.T) {
 	}
 }
 
+func TestAdminRecoverAndCleanupGlobalIndex(t *testing.T) {
+	store, domain := testkit.CreateMockStoreAndDomain(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set tidb_enable_global_index = 1")
+	defer tk.MustExec("set tidb_enable_global_index = default")
+
+	runTest := func(createSQL string, handle func(a int) kv.Handle) {
+		tk.MustExec("drop table if exists admin_test")
+		tk.MustExec(createSQL)
+		tk.MustExec("insert admin_test (a, b, c) values (1, 1, 1), (2, 2, 2), (3, 3, 3), (4, 4, 4)")
+		tk.MustQuery("admin cleanup index admin_test ub").Check(testkit.Rows("0"))
+
+		tbl, err := domain.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("admin_test"))
+		require.NoError(t, err)
+		tblInfo := tbl.Meta()
+		idxInfo := tblInfo.FindIndexByName("ub")
+		require.True(t, idxInfo.Global)
+		pi := tblInfo.GetPartitionInfo()
+		require.NotNil(t, pi)
+		// Row (2, 2, 2) lives in the partition p2.
+		pid := pi.Definitions[2].ID
+		indexOpr := tables.NewIndex(pid, tblInfo, idxInfo)
+
+		// Remove an index entry and recover it.
+		txn, err := store.Begin()
+		require.NoError(t, err)
+		err = indexOpr.Delete(mock.NewContext().GetTableCtx(), txn, types.MakeDatums(2), handle(2))
+		require.NoError(t, err)
+		require.NoError(t, txn.Commit(context.Background()))
+		tk.MustQuery("select count(*) from admin_test use index(ub)").Check(testkit.Rows("3"))
+		tk.MustQuery("admin recover index admin_test ub").Check(testkit.Rows("1 4"))
+		tk.MustQuery("select count(*) from admin_test use index(ub)").Check(testkit.Rows("4"))
+
+		// Add a dangling index entry and clean it up.
+		txn, err = store.Begin()
+		require.NoError(t, err)
+		_, err = indexOpr.Create(mock.NewContext().GetTableCtx(), txn, types.MakeDatums(5), handle(5), nil)
+		require.NoError(t, err)
+		require.NoError(t, txn.Commit(context.Background()))
+		tk.MustQuery("select count(*) from admin_test use index(ub)").Check(testkit.Rows("5"))
+		tk.MustQuery("admin cleanup index admin_test ub").Check(testkit.Rows("1"))
+		tk.MustQuery("select count(*) from admin_test use index(ub)").Check(testkit.Rows("4"))
+		tk.MustExec("admin check table admin_test")
+	}
+
+	runTest("create table admin_test (a int, b int, c int, unique key ub(b), key kc(c)) partition by hash(a) partitions 4",
+		func(a int) kv.Handle { return kv.IntHandle(a) })
+	runTest("create table admin_test (a int, b int, c int, primary key(a, c) clustered, unique key ub(b)) partition by hash(a) partitions 4",
+		func(a int) kv.Handle {
+			encoded, err := codec.EncodeKey(time.UTC, nil, types.MakeDatums(a, a)...)
+			require.NoError(t, err)
+			h, err := kv.NewCommonHandle(encoded)
+			require.NoError(t, err)
+			return h
+		})
+}
+
 func TestAdminCleanupIndexPKNotHandle(t *testing.T) {
 	store, domain := testkit.CreateMockStoreAndDomain(t)
 
@@ -1749,3 +1812,231 @@ func TestAdminCheckTableErrorLocateForClusterIndex(t *testing.T) {
 		tk.MustExec("admin check table admin_test")
 	}
 }
+
+func TestAdminReloadMetricTables(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	defer func() {
+		tk.MustExec("delete from mysql.metric_table_defs")
+		tk.MustExec("admin reload metric_tables")
+	}()
+
+	tk.MustExec(`insert into mysql.metric_table_defs values ('my_up', 'up{$LABEL_CONDITIONS}', 'instance,job', 0, 'targets up')`)
+	tk.MustExec(`insert into mysql.metric_table_defs values ('bad', '', '', 0, '')`)
+	tk.MustQuery("show tables from metrics_schema like 'my_up'").Check(testkit.Rows())
+	tk.MustExec("admin reload metric_tables")
+	require.Len(t, tk.Session().GetSessionVars().StmtCtx.GetWarnings(), 1)
+	tk.MustQuery("show tables from metrics_schema like 'my_up'").Check(testkit.Rows("my_up"))
+	tk.MustQuery("show tables from metrics_schema like 'bad'").Check(testkit.Rows())
+	tk.MustQuery("select column_name from information_schema.columns where table_schema = 'metrics_schema' and table_name = 'my_up'").
+		Check(testkit.Rows("time", "instance", "job", "value"))
+	tk.MustQuery("select promql, labels, comment from information_schema.metrics_tables where table_name = 'my_up'").
+		Check(testkit.Rows("up{$LABEL_CONDITIONS} instance,job targets up"))
+
+	// The table ID doesn't change when the other metric tables are added.
+	idSQL := "select tidb_table_id from information_schema.tables where table_schema = 'metrics_schema' and table_name = 'my_up'"
+	id := tk.MustQuery(idSQL).Rows()[0][0]
+	tk.MustExec(`insert into mysql.metric_table_defs values ('a_up', 'up{$LABEL_CONDITIONS}', 'instance', 0, '')`)
+	tk.MustExec("admin reload metric_tables")
+	tk.MustQuery("show tables from metrics_schema like 'a_up'").Check(testkit.Rows("a_up"))
+	tk.MustQuery(idSQL).Check(testkit.Rows(fmt.Sprint(id)))
+	tk.MustExec("delete from mysql.metric_table_defs where table_name = 'a_up'")
+
+	tk.MustExec("delete from mysql.metric_table_defs where table_name = 'my_up'")
+	tk.MustExec("admin reload metric_tables")
+	tk.MustQuery("show tables from metrics_schema like 'my_up'").Check(testkit.Rows())
+
+	tk.MustExec("create user 'metric_user'")
+	userTk := testkit.NewTestKit(t, store)
+	require.NoError(t, userTk.Session().Auth(&auth.UserIdentity{Username: "metric_user", Hostname: "%"}, nil, nil, nil))
+	userTk.MustGetErrCode("admin reload metric_tables", mysql.ErrPrivilegeCheckFail)
+}
+
+func TestAdminDiffSchema(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+
+	// For mocktikv, safe point is not initialized, we manually insert it for snapshot to use.
+	tk.MustExec(`INSERT INTO mysql.tidb VALUES ('tikv_gc_safe_point', '20060102-15:04:05 -0700', '')
+	ON DUPLICATE KEY
+	UPDATE variable_value = '20060102-15:04:05 -0700'`)
+	currentTime := func() string {
+		time.Sleep(10 * time.Millisecond)
+		tk.MustExec("begin")
+		ts := tk.MustQuery("select @@tidb_current_ts").Rows()[0][0].(string)
+		tk.MustExec("commit")
+		tso, err := strconv.ParseUint(ts, 10, 64)
+		require.NoError(t, err)
+		time.Sleep(10 * time.Millisecond)
+		return oracle.GetTimeFromTS(tso).Format("2006-01-02 15:04:05.000000")
+	}
+
+	tk.MustExec("create database diff_db")
+	tk.MustExec("use diff_db")
+	tk.MustExec("create table t1 (a int, b varchar(10), key ka(a))")
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("create table t3 (a int)")
+	from := currentTime()
+	tk.MustExec("alter table t1 add column c int not null default 1 comment 'new'")
+	tk.MustExec("alter table t1 modify column b varchar(20)")
+	tk.MustExec("alter table t1 drop index ka")
+	tk.MustExec("alter table t1 add unique index ub(b)")
+	tk.MustExec("drop table t2")
+	oldID := tk.MustQuery("select tidb_table_id from information_schema.tables where table_schema = 'diff_db' and table_name = 't3'").Rows()[0][0]
+	tk.MustExec("truncate table t3")
+	newID := tk.MustQuery("select tidb_table_id from information_schema.tables where table_schema = 'diff_db' and table_name = 't3'").Rows()[0][0]
+	tk.MustExec("create table t4 (a int primary key)")
+	to := currentTime()
+	tk.MustExec("create table t5 (a int)")
+
+	tk.MustQuery(fmt.Sprintf("admin diff schema between timestamp '%s' and '%s' for diff_db", from, to)).Sort().Check(testkit.Rows(
+		"diff_db t1 column b altered varchar(10) varchar(20)",
+		"diff_db t1 column c added  int(11) NOT NULL DEFAULT 1 COMMENT 'new'",
+		"diff_db t1 index ka dropped KEY (a) ",
+		"diff_db t1 index ub added  UNIQUE KEY (b)",
+		"diff_db t2 table t2 dropped  ",
+		fmt.Sprintf("diff_db t3 table t3 recreated ID %s ID %s", oldID, newID),
+		"diff_db t4 table t4 added  ",
+	))
+	tk.MustQuery(fmt.Sprintf("admin diff schema between timestamp '%s' and '%s' for diff_db.t1", from, to)).Check(testkit.Rows(
+		"diff_db t1 column b altered varchar(10) varchar(20)",
+		"diff_db t1 column c added  int(11) NOT NULL DEFAULT 1 COMMENT 'new'",
+		"diff_db t1 index ub added  UNIQUE KEY (b)",
+		"diff_db t1 index ka dropped KEY (a) ",
+	))
+	tk.MustQuery(fmt.Sprintf("admin diff schema between timestamp '%s' and '%s' for diff_db.t1", to, to)).Check(testkit.Rows())
+	// The reverse diff reports the opposite changes.
+	tk.MustQuery(fmt.Sprintf("admin diff schema between timestamp '%s' and '%s' for diff_db.t4", to, from)).Check(testkit.Rows(
+		"diff_db t4 table t4 dropped  ",
+	))
+
+	tk.MustExec("drop database diff_db")
+	tk.MustQuery(fmt.Sprintf("admin diff schema between timestamp '%s' and '%s'", to, currentTime())).Check(testkit.Rows(
+		"diff_db  database diff_db dropped  ",
+	))
+	err := tk.QueryToErr(fmt.Sprintf("admin diff schema between timestamp '%s' and '2037-01-01 00:00:00'", from))
+	require.ErrorContains(t, err, "cannot diff schema at future time")
+}
+
+func TestAdminCheckTableOnline(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@global.tidb_check_table_online_batch_size = 3")
+	defer func() {
+		tk.MustExec("set @@global.tidb_check_table_online_batch_size = default")
+		tk.MustExec("set @@global.tidb_check_table_online_rate_limit = default")
+	}()
+	tk.MustExec("create table admin_test (c1 int, c2 int, c3 int default 1, primary key(c1), index (c2))")
+	tk.MustExec("insert admin_test (c1, c2) values (1, 1), (2, 2), (3, 3), (5, 5), (8, 8), (13, 13), (21, 21), (34, 34)")
+
+	waitJob := func(jobID any, status string) {
+		require.Eventually(t, func() bool {
+			rows := tk.MustQuery("select status from mysql.tidb_check_table_jobs where id = ?", jobID).Rows()
+			return rows[0][0] == status
+		}, 10*time.Second, 50*time.Millisecond)
+	}
+	rows := tk.MustQuery("admin check table admin_test online").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, []any{"test", "admin_test"}, rows[0][:2])
+	jobID := rows[0][2]
+	waitJob(jobID, "finished")
+	tk.MustQuery("select start_handle, end_handle, checkpoint, checked_rows from mysql.tidb_check_table_jobs where id = ?", jobID).
+		Check(testkit.Rows("1 34 35 8"))
+
+	// Make some corrupted index.
+	tblInfo := external.GetTableByName(t, tk, "test", "admin_test").Meta()
+	idxInfo := tblInfo.FindIndexByName("c2")
+	indexOpr := tables.NewIndex(tblInfo.ID, tblInfo, idxInfo)
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	err = indexOpr.Delete(tk.Session().GetTableCtx(), txn, types.MakeDatums(8), kv.IntHandle(8))
+	require.NoError(t, err)
+	require.NoError(t, txn.Commit(context.Background()))
+	jobID = tk.MustQuery("admin check table admin_test online").Rows()[0][2]
+	waitJob(jobID, "failed")
+	tk.MustQuery("select checkpoint, checked_rows, error_message from mysql.tidb_check_table_jobs where id = ?", jobID).
+		Check(testkit.Rows("5 3 index c2 is inconsistent with table admin_test in handle range [5, 20], table rows: 3, index rows: 2"))
+	tk.MustQuery("admin recover index admin_test c2").Check(testkit.Rows("1 8"))
+
+	// Cancel a throttled job and resume it from the checkpoint.
+	tk.MustExec("set @@global.tidb_check_table_online_rate_limit = 1")
+	jobID = tk.MustQuery("admin check table admin_test online").Rows()[0][2]
+	require.Eventually(t, func() bool {
+		rows := tk.MustQuery("select checked_rows from mysql.tidb_check_table_jobs where id = ?", jobID).Rows()
+		return rows[0][0] == "3"
+	}, 10*time.Second, 50*time.Millisecond)
+	tk.MustQuery(fmt.Sprintf("admin cancel check table jobs %v", jobID)).Check(testkit.Rows(fmt.Sprintf("%v successful", jobID)))
+	tk.MustQuery("select status from mysql.tidb_check_table_jobs where id = ?", jobID).Check(testkit.Rows("cancelled"))
+	tk.MustQuery(fmt.Sprintf("admin cancel check table jobs %v", jobID)).
+		Check(testkit.Rows(fmt.Sprintf("%v error: check table job %v is not running", jobID, jobID)))
+	tk.MustExec("set @@global.tidb_check_table_online_rate_limit = 0")
+	require.Eventually(t, func() bool {
+		return tk.QueryToErr("admin check table admin_test online") == nil
+	}, 10*time.Second, 100*time.Millisecond)
+	waitJob(jobID, "finished")
+	tk.MustQuery("select checkpoint, checked_rows from mysql.tidb_check_table_jobs where id = ?", jobID).Check(testkit.Rows("35 8"))
+	tk.MustQuery("select count(*) from mysql.tidb_check_table_jobs").Check(testkit.Rows("3"))
+
+	tk.MustExec("create table admin_clustered (c1 varchar(10) primary key clustered, c2 int, index (c2))")
+	tk.MustContainErrMsg("admin check table admin_clustered online", "not supported on table admin_clustered with clustered index")
+	tk.MustExec("create temporary table admin_temp (c1 int, index (c1))")
+	tk.MustGetErrCode("admin check table admin_temp online", mysql.ErrOptOnTemporaryTable)
+
+	tk.MustExec("create user 'check_user'")
+	userTk := testkit.NewTestKit(t, store)
+	require.NoError(t, userTk.Session().Auth(&auth.UserIdentity{Username: "check_user", Hostname: "%"}, nil, nil, nil))
+	userTk.MustGetErrCode("admin check table test.admin_test online", mysql.ErrPrivilegeCheckFail)
+	userTk.MustGetErrCode("admin cancel check table jobs 1", mysql.ErrPrivilegeCheckFail)
+}
+
+func TestAdminCheckTableOnlineSubmitAndResume(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@global.tidb_check_table_online_batch_size = 3")
+	tk.MustExec("set @@global.tidb_check_table_online_rate_limit = 1")
+	defer func() {
+		tk.MustExec("set @@global.tidb_check_table_online_batch_size = default")
+		tk.MustExec("set @@global.tidb_check_table_online_rate_limit = default")
+	}()
+	tk.MustExec("create table admin_test (c1 int, c2 int, primary key(c1), index (c2))")
+	tk.MustExec("insert admin_test values (1, 1), (2, 2), (3, 3), (5, 5), (8, 8), (13, 13), (21, 21), (34, 34)")
+
+	// The concurrent submissions of a table share one job.
+	jobIDs := make([]any, 5)
+	var wg sync.WaitGroup
+	for i := range jobIDs {
+		submitTk := testkit.NewTestKit(t, store)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			jobIDs[i] = submitTk.MustQuery("admin check table test.admin_test online").Rows()[0][2]
+		}(i)
+	}
+	wg.Wait()
+	for _, jobID := range jobIDs {
+		require.Equal(t, jobIDs[0], jobID)
+	}
+	tk.MustQuery("select count(*) from mysql.tidb_check_table_jobs where active_table_id is not null").Check(testkit.Rows("1"))
+	tk.MustQuery(fmt.Sprintf("admin cancel check table jobs %v", jobIDs[0])).Check(testkit.Rows(fmt.Sprintf("%v successful", jobIDs[0])))
+
+	// The running job left by a gone instance is resumed from its checkpoint.
+	tk.MustExec("set @@global.tidb_check_table_online_rate_limit = 0")
+	tk.MustExec("create table admin_test2 like admin_test")
+	tk.MustExec("insert admin_test2 select * from admin_test")
+	tblID := external.GetTableByName(t, tk, "test", "admin_test2").Meta().ID
+	tk.MustExec(`insert into mysql.tidb_check_table_jobs (update_time, table_schema, table_name, table_id, active_table_id,
+		created_by, instance, status, start_handle, end_handle, checkpoint, checked_rows)
+		values (now(6), 'test', 'admin_test2', ?, ?, 'root@%', 'gone:4000', 'running', 1, 34, 5, 3)`, tblID, tblID)
+	jobID := tk.MustQuery("select last_insert_id()").Rows()[0][0]
+	// The job is running on another instance, so it's not taken over by the submission.
+	tk.MustQuery("admin check table admin_test2 online").Check(testkit.Rows(fmt.Sprintf("test admin_test2 %v", jobID)))
+	require.NoError(t, executor.ResumeCheckTableJobs(context.Background(), dom))
+	require.Eventually(t, func() bool {
+		rows := tk.MustQuery("select status from mysql.tidb_check_table_jobs where id = ?", jobID).Rows()
+		return rows[0][0] == "finished"
+	}, 10*time.Second, 50*time.Millisecond)
+	tk.MustQuery("select instance != 'gone:4000', checkpoint, checked_rows, active_table_id from mysql.tidb_check_table_jobs where id = ?", jobID).
+		Check(testkit.Rows("1 35 8 <nil>"))
+}
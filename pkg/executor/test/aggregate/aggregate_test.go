@@ -28,6 +28,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/executor/aggregate"
 	"github.com/pingcap/tidb/pkg/session"
 	"github.com/pingcap/tidb/pkg/testkit"
@@ -474,3 +475,69 @@ func TestIssue50849(t *testing.T) {
 	// Check the error contains stack information
 	require.True(t, errors.HasStack(err))
 }
+
+func TestConcatAggPushDownToTiKV(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(id int primary key, g int, s varchar(10), n int)")
+	vals := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		vals = append(vals, fmt.Sprintf("(%d, %d, 's%d', %d)", i, i%3, i%7, i))
+	}
+	tk.MustExec("insert into t values " + strings.Join(vals, ","))
+	// Split the table so that the partial results come from several regions.
+	tk.MustQuery("split table t by (25), (50), (75)").Check(testkit.Rows("3 1"))
+	tk.MustExec("analyze table t")
+
+	queries := []string{
+		"select g, length(group_concat(s)) from t group by g",
+		"select g, group_concat(distinct s order by s desc separator ';') from t group by g",
+		"select g, json_length(json_arrayagg(n)) from t group by g",
+		"select g, json_objectagg(n, s) from t group by g",
+		"select length(group_concat(s, n)), json_length(json_arrayagg(s)), json_length(json_objectagg(s, g)) from t",
+	}
+	results := make([][][]any, 0, len(queries))
+	for _, q := range queries {
+		results = append(results, tk.MustQuery(q).Sort().Rows())
+	}
+
+	tk.MustExec("set @@tidb_opt_enable_tikv_concat_agg_push_down = 1")
+	tk.MustExec("set @@tidb_opt_distinct_agg_push_down = 1")
+	for i, q := range queries {
+		rows := tk.MustQuery("explain format = 'brief' " + q).Rows()
+		pushedDown := false
+		for _, row := range rows {
+			if strings.Contains(row[2].(string), "cop[tikv]") && strings.Contains(row[0].(string), "Agg") {
+				pushedDown = true
+			}
+		}
+		require.True(t, pushedDown, q)
+		tk.MustQuery(q).Sort().Check(results[i])
+	}
+
+	// group_concat with order by can't be merged from the partial results, it is not pushed down.
+	rows := tk.MustQuery("explain format = 'brief' select group_concat(s order by n) from t").Rows()
+	for _, row := range rows {
+		require.False(t, strings.Contains(row[2].(string), "cop[tikv]") && strings.Contains(row[0].(string), "Agg"))
+	}
+	// The arguments that TiKV can't format are not pushed down.
+	tk.MustExec("drop table if exists t1")
+	tk.MustExec("create table t1(a double, b int)")
+	rows = tk.MustQuery("explain format = 'brief' select group_concat(a) from t1 group by b").Rows()
+	for _, row := range rows {
+		require.False(t, strings.Contains(row[2].(string), "cop[tikv]") && strings.Contains(row[0].(string), "Agg"))
+	}
+	// Only the coprocessor of unistore supports them, they are not pushed down to the other stores.
+	defer config.RestoreFunc()()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.Store = "tikv"
+	})
+	for _, q := range queries {
+		rows = tk.MustQuery("explain format = 'brief' " + q).Rows()
+		for _, row := range rows {
+			require.False(t, strings.Contains(row[2].(string), "cop[tikv]") && strings.Contains(row[0].(string), "Agg"), q)
+		}
+	}
+}
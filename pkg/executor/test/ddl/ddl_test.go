@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/ddl"
 	"github.com/pingcap/tidb/pkg/ddl/schematracker"
 	ddltestutil "github.com/pingcap/tidb/pkg/ddl/testutil"
 	ddlutil "github.com/pingcap/tidb/pkg/ddl/util"
@@ -31,6 +32,7 @@ import (
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
@@ -1019,3 +1021,156 @@ func TestRenameMultiTables(t *testing.T) {
 	tk.MustExec("drop database rename2")
 	tk.MustExec("drop database rename3")
 }
+
+func TestCreateTableAsSelect(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table src (a int primary key, b varchar(10))")
+	tk.MustExec("insert into src values (1, 'a'), (2, 'b')")
+
+	tk.MustGetErrCode("create table src select * from src", errno.ErrTableExists)
+	tk.MustExec("create table if not exists src select * from src")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1050 Table 'test.src' already exists"))
+	tk.MustGetErrCode("create table dst ignore select * from src", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create table dst replace select * from src", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create temporary table dst select * from src", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create table dst (c int as (a + 1)) select * from src", errno.ErrNotSupportedYet)
+	tk.MustExec("begin")
+	tk.MustGetErrCode("create table dst select * from src", errno.ErrNotSupportedYet)
+	tk.MustExec("rollback")
+
+	// the rows are inserted by transactions on mock store.
+	tk.MustExec("create table dst (x int default 10, key(b)) select a, b, a + 1 as c from src")
+	require.Equal(t, uint64(2), tk.Session().GetSessionVars().StmtCtx.AffectedRows())
+	tk.MustQuery("select * from dst").Sort().Check(testkit.Rows("10 1 a 2", "10 2 b 3"))
+	tk.MustQuery("show tables").Check(testkit.Rows("dst", "src"))
+	tk.MustExec("admin check table dst")
+	rows := tk.MustQuery("show import jobs").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "SELECT `a`,`b`,`a`+1 AS `c` FROM `test`.`src`", rows[0][1])
+	require.Equal(t, "`test`.`dst`", rows[0][2])
+	require.Equal(t, "finished", rows[0][5])
+	require.Equal(t, "2", rows[0][7])
+
+	// the table is invisible and the staging table is dropped when the load fails.
+	tk.MustGetErrCode("create table dst2 (unique key(b)) select 'x' as b from src", errno.ErrDupEntry)
+	tk.MustQuery("show tables").Check(testkit.Rows("dst", "src"))
+	rows = tk.MustQuery("show import jobs").Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, "failed", rows[1][5])
+}
+
+func TestCreateTableAsSelectStagingTable(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+
+	createStagingTable := func(name, serverID string) {
+		tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+		require.NoError(t, err)
+		tblInfo := tbl.Meta().Clone()
+		tblInfo.Name = model.NewCIStr(name)
+		tblInfo.Staging = &model.StagingInfo{ServerID: serverID, ConnectionID: 1}
+		require.NoError(t, dom.DDL().CreateTableWithInfo(tk.Session(), model.NewCIStr("test"), tblInfo, ddl.OnExistError))
+	}
+	createStagingTable("_tidb_ctas_1", dom.DDL().GetID())
+	createStagingTable("_tidb_ctas_2", "gone")
+
+	// the staging tables are hidden from the table listings.
+	tk.MustQuery("show tables").Check(testkit.Rows("t"))
+	tk.MustQuery("select table_name from information_schema.tables where table_schema = 'test'").Check(testkit.Rows("t"))
+	tk.MustQuery("select distinct table_name from information_schema.columns where table_schema = 'test'").Check(testkit.Rows("t"))
+
+	// the staging table is published by renaming it.
+	tk.MustExec("rename table _tidb_ctas_1 to t1")
+	tk.MustQuery("show tables").Check(testkit.Rows("t", "t1"))
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t1"))
+	require.NoError(t, err)
+	require.False(t, tbl.Meta().IsStaging())
+
+	// the staging table whose loader is gone is dropped.
+	require.NoError(t, dom.CleanupStagingTables())
+	tk.MustGetErrCode("select * from _tidb_ctas_2", errno.ErrNoSuchTable)
+	tk.MustQuery("select * from t1").Check(testkit.Rows())
+}
+
+func TestMaterializedView(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (1, 2), (2, 3)")
+
+	tk.MustExec("create materialized view mv as select a, sum(b) as s from t group by a")
+	tk.MustQuery("show tables").Sort().Check(testkit.Rows("mv", "t"))
+	tk.MustQuery("select * from mv").Sort().Check(testkit.Rows("1 3", "2 3"))
+	tk.MustQuery("select table_name, view_definition, last_refresh_time is not null, refresh_interval_value from information_schema.tidb_materialized_views").Check(
+		testkit.Rows("mv SELECT `a`,SUM(`b`) AS `s` FROM `test`.`t` GROUP BY `a` 1 <nil>"))
+	tk.MustGetErrCode("create materialized view mv as select * from t", errno.ErrTableExists)
+	tk.MustExec("create materialized view if not exists mv as select * from t")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1050 Table 'test.mv' already exists"))
+
+	// the view can only be changed by refresh.
+	tk.MustGetErrCode("insert into mv values (1, 1)", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("replace into mv values (1, 1)", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("update mv set s = 1", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("delete from mv", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("drop table mv", errno.ErrWrongObject)
+	tk.MustGetErrCode("drop materialized view t", errno.ErrWrongObject)
+	tk.MustGetErrCode("refresh materialized view t", errno.ErrWrongObject)
+	tk.MustGetErrCode("refresh materialized view not_exist", errno.ErrNoSuchTable)
+
+	// the data is refreshed in place, the ID of the view is kept.
+	tableID := tk.MustQuery("select tidb_table_id from information_schema.tidb_materialized_views").Rows()[0][0]
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery("select * from mv").Sort().Check(testkit.Rows("1 3", "2 3"))
+	tk.MustQuery("show tables").Sort().Check(testkit.Rows("mv", "t"))
+	tk.MustQuery("select tidb_table_id from information_schema.tidb_materialized_views").Check(testkit.Rows(fmt.Sprint(tableID)))
+	tk.MustQuery("select job_type, table_name, state from information_schema.ddl_jobs limit 1").Check(
+		testkit.Rows("refresh materialized view mv synced"))
+	tk.MustExec("insert into t values (3, 4), (1, 5)")
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery("select * from mv").Sort().Check(testkit.Rows("1 8", "2 3", "3 4"))
+	rows := tk.MustQuery("show import jobs").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "`test`.`mv`", rows[0][2])
+	require.Equal(t, "finished", rows[0][5])
+	tk.MustExec("begin")
+	tk.MustGetErrCode("refresh materialized view mv", errno.ErrNotSupportedYet)
+	tk.MustExec("rollback")
+
+	// the query is answered by the view only when the rewrite is enabled.
+	tk.MustExec("insert into t values (2, 1)")
+	query := "select a, sum(b) as s from t group by a"
+	tk.MustQuery(query).Sort().Check(testkit.Rows("1 8", "2 4", "3 4"))
+	tk.MustExec("set @@tidb_enable_materialized_view_rewrite = 1")
+	tk.MustQuery(query).Sort().Check(testkit.Rows("1 8", "2 3", "3 4"))
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1105 query is answered by materialized view test.mv"))
+	tk.MustQuery("select a from t").Sort().Check(testkit.Rows("1", "1", "1", "2", "2", "3"))
+	tk.MustQuery(query + " for update").Sort().Check(testkit.Rows("1 8", "2 4", "3 4"))
+	tk.MustExec("set @@tidb_enable_materialized_view_rewrite = 0")
+
+	// the view created with REFRESH EVERY is refreshed by the DDL owner when the refresh is due.
+	tk.MustGetErrCode("create materialized view mv2 refresh every 1 day_hour as select * from t", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create materialized view mv2 refresh every 0 hour as select * from t", errno.ErrEventIntervalNotPositiveOrTooBig)
+	tk.MustExec("create materialized view mv2 refresh every 1 hour as select a, count(*) as c from t group by a")
+	tk.MustQuery("select refresh_interval_value, refresh_interval_field from information_schema.tidb_materialized_views where table_name = 'mv2'").Check(
+		testkit.Rows("1 HOUR"))
+	tk.MustQuery("select * from mv2").Sort().Check(testkit.Rows("1 3", "2 2", "3 1"))
+	tk.MustExec("insert into t values (4, 1)")
+	dom := domain.GetDomain(tk.Session())
+	dom.RefreshDueMaterializedViews(time.Now())
+	tk.MustQuery("select * from mv2").Sort().Check(testkit.Rows("1 3", "2 2", "3 1"))
+	dom.RefreshDueMaterializedViews(time.Now().Add(time.Hour + time.Minute))
+	tk.MustQuery("select * from mv2").Sort().Check(testkit.Rows("1 3", "2 2", "3 1", "4 1"))
+	// the view without REFRESH EVERY is not refreshed.
+	tk.MustQuery("select * from mv").Sort().Check(testkit.Rows("1 8", "2 3", "3 4"))
+
+	tk.MustExec("drop materialized view mv, mv2")
+	tk.MustExec("drop materialized view if exists mv")
+	tk.MustQuery("show tables").Check(testkit.Rows("t"))
+	tk.MustQuery("select * from information_schema.tidb_materialized_views").Check(testkit.Rows())
+}
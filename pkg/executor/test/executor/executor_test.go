@@ -201,6 +201,39 @@ func TestPlanReplayerContinuesCapture(t *testing.T) {
 	tk.MustQuery("select count(*) from mysql.plan_replayer_status").Check(testkit.Rows("1"))
 }
 
+func TestPlanReplayerContinuesCaptureThresholdAndQuota(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set @@global.tidb_enable_historical_stats='ON'")
+	tk.MustExec("set @@global.tidb_enable_plan_replayer_continuous_capture='ON'")
+	defer tk.MustExec("set @@global.tidb_plan_replayer_capture_storage_quota = default")
+	tk.MustExec("delete from mysql.plan_replayer_status")
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int)")
+	tk.MustExec("set @@tidb_enable_plan_replayer_continuous_capture = 'ON'")
+
+	// the statements faster than the threshold are not captured.
+	tk.MustExec("set @@tidb_plan_replayer_continuous_capture_threshold = 100000")
+	tk.MustQuery("select * from t where id = 1")
+	tk.MustExec("set @@tidb_plan_replayer_continuous_capture_threshold = 0")
+	tk.MustQuery("select count(*) from t")
+	prHandle := dom.GetPlanReplayerHandle()
+	task := prHandle.DrainTask()
+	require.NotNil(t, task)
+	require.Len(t, task.ExecStmts, 1)
+	require.Equal(t, "select count(*) from t", task.ExecStmts[0].Text())
+
+	// the capture files exceeding the storage quota are removed after dumping.
+	tk.MustExec("set @@global.tidb_plan_replayer_capture_storage_quota = 1")
+	defer os.RemoveAll(replayer.GetPlanReplayerDirName())
+	require.True(t, prHandle.GetWorker().HandleTask(task))
+	entries, err := os.ReadDir(replayer.GetPlanReplayerDirName())
+	require.NoError(t, err)
+	for _, entry := range entries {
+		require.NotContains(t, entry.Name(), "capture")
+	}
+}
+
 func TestPlanReplayerDumpSingle(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -1622,6 +1655,114 @@ func TestAdminShowDDLJobsInfo(t *testing.T) {
 	require.Equal(t, "alter table nocache", tk.MustQuery("admin show ddl jobs 1").Rows()[0][3])
 }
 
+func TestInfoSchemaDDLJobsPredicates(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database test_ddl_jobs_predicates")
+	tk.MustExec("use test_ddl_jobs_predicates")
+	tk.MustExec("create table t1 (a int)")
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("alter table t1 add column b int, add index idx(a)")
+	tk.MustExec("alter table t2 add index idx(a)")
+
+	const cols = "job_id, db_name, table_name, job_type, create_time, state, query"
+	all := tk.MustQuery("select " + cols + " from information_schema.ddl_jobs where db_name = 'test_ddl_jobs_predicates'").Sort().Rows()
+	// create schema, create table t1, create table t2, the multi-schema change of t1 with 2 sub-jobs and the add
+	// index of t2.
+	require.Len(t, all, 7)
+
+	// The history jobs are read by the IDs.
+	jobIDs := make([]string, 0, len(all))
+	for _, row := range all {
+		jobIDs = append(jobIDs, row[0].(string))
+	}
+	tk.MustQuery("select " + cols + " from information_schema.ddl_jobs where job_id in (" + strings.Join(jobIDs, ",") + ")").Sort().Check(all)
+	tk.MustQuery("select " + cols + " from information_schema.ddl_jobs where job_id = " + jobIDs[0]).Check(all[:1])
+	tk.MustQuery("select count(*) from information_schema.ddl_jobs where job_id = 0").Check(testkit.Rows("0"))
+
+	rows := tk.MustQuery("select " + cols + " from information_schema.ddl_jobs where db_name = 'test_ddl_jobs_predicates' and table_name = 't1'").Sort().Rows()
+	require.Len(t, rows, 4)
+	for _, row := range rows {
+		require.Equal(t, "t1", row[2])
+	}
+	// The sub-jobs of the multi-schema change job have their own states.
+	tk.MustQuery("select count(*) from information_schema.ddl_jobs where db_name = 'test_ddl_jobs_predicates' and state = 'synced'").Check(testkit.Rows("5"))
+	tk.MustQuery("select count(*) from information_schema.ddl_jobs where db_name = 'test_ddl_jobs_predicates' and state = 'done'").Check(testkit.Rows("2"))
+	tk.MustQuery("select count(*) from information_schema.ddl_jobs where db_name = 'test_ddl_jobs_predicates' and state = 'cancelled'").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from information_schema.ddl_jobs where db_name = 'test_ddl_jobs_predicates' and db_name = 'test'").Check(testkit.Rows("0"))
+	// The pushed down predicates only skip the jobs, they are still evaluated in the collation of the columns.
+	tk.MustQuery("select count(*) from information_schema.ddl_jobs where db_name = 'TEST_DDL_JOBS_PREDICATES'").Check(testkit.Rows("0"))
+
+	// The create time is compared in the session time zone.
+	tk.MustExec("set @@time_zone = 'Asia/Shanghai'")
+	createTime := tk.MustQuery("select min(create_time) from information_schema.ddl_jobs where db_name = 'test_ddl_jobs_predicates'").Rows()[0][0].(string)
+	tk.MustQuery("select count(*) from information_schema.ddl_jobs where db_name = 'test_ddl_jobs_predicates' and create_time >= '" + createTime + "'").Check(testkit.Rows("7"))
+	tk.MustQuery("select count(*) from information_schema.ddl_jobs where db_name = 'test_ddl_jobs_predicates' and create_time < '" + createTime + "'").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from information_schema.ddl_jobs where create_time > '2000-01-01 00:00:00' and create_time < '1999-01-01 00:00:00'").Check(testkit.Rows("0"))
+
+	tk.MustQuery("explain format = 'brief' select * from information_schema.ddl_jobs where job_id in (2, 1) and table_name = 'T1' and state = 'synced'").CheckContain("job_ids:[1,2], table_name:[\"t1\"], state:[\"synced\"]")
+}
+
+func TestAdminShowDDLJobsPredicates(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database test_admin_show_ddl_jobs_predicates")
+	tk.MustExec("use test_admin_show_ddl_jobs_predicates")
+	tk.MustExec("create table t1 (a int)")
+	tk.MustExec("create table t2 (a int)")
+	for i := 0; i < 5; i++ {
+		tk.MustExec(fmt.Sprintf("alter table t2 add column c%d int", i))
+	}
+
+	// The predicates on the table name are applied before counting the jobs, so the jobs of t1 are found even
+	// though they are not in the last 2 jobs.
+	rows := tk.MustQuery("admin show ddl jobs 2 where table_name = 't1'").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "create table", rows[0][3])
+	tk.MustQuery("admin show ddl jobs 2 where db_name = 'test_admin_show_ddl_jobs_predicates' and table_name = 't2'").CheckContain("add column")
+	require.Len(t, tk.MustQuery("admin show ddl jobs 2 where table_name = 't2'").Rows(), 2)
+	require.Len(t, tk.MustQuery("admin show ddl jobs 20 where table_name = 't2'").Rows(), 6)
+	tk.MustQuery("admin show ddl jobs where table_name = 't1' and table_name = 't2'").Check(testkit.Rows())
+	tk.MustQuery("admin show ddl jobs where table_name = 'T1'").Check(testkit.Rows())
+
+	jobID := rows[0][0].(string)
+	tk.MustQuery("admin show ddl jobs where job_id = " + jobID).Check(testkit.Rows(strings.Join(func() []string {
+		row := make([]string, 0, len(rows[0]))
+		for _, v := range rows[0] {
+			row = append(row, fmt.Sprint(v))
+		}
+		return row
+	}(), " ")))
+	tk.MustQuery("admin show ddl jobs where state = 'cancelled'").Check(testkit.Rows())
+}
+
+func TestInfoSchemaTiDBDDLHistory(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database test_ddl_history")
+	tk.MustExec("use test_ddl_history")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("alter table t add index idx(a)")
+
+	tk.MustQuery("select job_type, state from information_schema.tidb_ddl_history where db_name = 'test_ddl_history' order by job_id").
+		Check(testkit.Rows("create schema synced", "create table synced", "add index /* txn-merge */ synced"))
+	tk.MustQuery("select count(*) from information_schema.tidb_ddl_history where state = 'queueing'").Check(testkit.Rows("0"))
+
+	// The compaction does nothing unless the retention is set.
+	total := tk.MustQuery("select count(*) from information_schema.tidb_ddl_history").Rows()[0][0].(string)
+	require.NoError(t, dom.CompactDDLHistory())
+	tk.MustQuery("select count(*) from information_schema.tidb_ddl_history").Check(testkit.Rows(total))
+
+	tk.MustExec("set @@global.tidb_ddl_history_retention = '1s'")
+	defer tk.MustExec("set @@global.tidb_ddl_history_retention = default")
+	time.Sleep(1500 * time.Millisecond)
+	tk.MustExec("create table t2 (a int)")
+	require.NoError(t, dom.CompactDDLHistory())
+	tk.MustQuery("select job_type from information_schema.tidb_ddl_history where db_name = 'test_ddl_history'").Check(testkit.Rows("create table"))
+	tk.MustQuery("select count(*) from information_schema.tidb_ddl_history").Check(testkit.Rows("1"))
+	tk.MustQuery("select count(*) from mysql.tidb_ddl_history").Check(testkit.Rows("1"))
+}
+
 func TestUnion2(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
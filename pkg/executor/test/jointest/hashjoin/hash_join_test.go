@@ -461,3 +461,140 @@ func TestIssue31129(t *testing.T) {
 	require.NoError(t, failpoint.Disable(fpName1))
 	require.NoError(t, failpoint.Disable(fpName2))
 }
+
+func TestRuntimeFilterOnTiKV(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table fact (id int primary key, k int, s varchar(10))")
+	tk.MustExec("create table dim (k int, s varchar(10), v int)")
+	values := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		values = append(values, fmt.Sprintf("(%d, %d, 's%d')", i, i%20, i%20))
+	}
+	tk.MustExec("insert into fact values " + strings.Join(values, ","))
+	tk.MustExec("insert into dim values (1, 's1', 1), (3, 's3', 0), (5, 's5', 1), (null, null, 1), (100, 's100', 1)")
+	tk.MustExec("analyze table fact, dim")
+
+	queries := []string{
+		"select /*+ HASH_JOIN_BUILD(dim) */ fact.id, dim.v from fact join dim on fact.k = dim.k",
+		"select /*+ HASH_JOIN_BUILD(dim) */ fact.id, dim.v from fact join dim on fact.k = dim.k where dim.v = 1 and fact.id > 10",
+		"select /*+ HASH_JOIN_BUILD(dim) */ fact.id from fact join dim on fact.s = dim.s",
+		"select /*+ HASH_JOIN_BUILD(dim) */ fact.id from fact join dim on fact.k = dim.k where dim.v = 2",
+		"select /*+ HASH_JOIN_BUILD(dim) */ fact.id, dim.v from dim left join fact on fact.k = dim.k",
+		"select fact.id from fact where fact.k in (select k from dim)",
+	}
+	expected := make([][][]any, 0, len(queries))
+	for _, sql := range queries {
+		expected = append(expected, tk.MustQuery(sql).Sort().Rows())
+	}
+
+	tk.MustExec("set @@tidb_runtime_filter_mode = 'LOCAL'")
+	for _, rfType := range []string{"IN", "MIN_MAX", "IN,MIN_MAX"} {
+		tk.MustExec(fmt.Sprintf("set @@tidb_runtime_filter_type = '%s'", rfType))
+		for i, sql := range queries {
+			tk.MustQuery(sql).Sort().Check(expected[i])
+		}
+	}
+
+	tk.MustExec("set @@tidb_runtime_filter_type = 'IN'")
+	rows := tk.MustQuery("explain " + queries[0]).Rows()
+	explain := fmt.Sprint(rows)
+	require.Contains(t, explain, "runtime filter:0[IN] <- test.dim.k")
+	require.Contains(t, explain, "with runtime filters")
+	require.Contains(t, explain, "runtime filter:0[IN] -> test.fact.k")
+
+	// the rows of fact are filtered in the coprocessor.
+	rows = tk.MustQuery("explain analyze " + queries[0]).Rows()
+	for _, row := range rows {
+		if strings.Contains(row[0].(string), "TableReader") && strings.Contains(row[5].(string), "runtime_filter") {
+			require.Regexp(t, "runtime_filter:\\{wait:.*, 0\\[IN\\]:4 values\\}", row[5])
+			require.Equal(t, "15", row[2])
+		}
+		if strings.Contains(row[4].(string), "with runtime filters") {
+			require.Equal(t, "15", row[2])
+		}
+	}
+	require.Contains(t, fmt.Sprint(rows), "runtime_filter:")
+
+	// the requests are not sent if the build side is empty.
+	rows = tk.MustQuery("explain analyze " + queries[3]).Rows()
+	require.Contains(t, fmt.Sprint(rows), "0[IN]:0 values")
+
+	// the IN filter is skipped if the build side has too many distinct values.
+	tk.MustExec("insert into dim select id, s, 1 from fact")
+	tk.MustExec("insert into dim select id + 100, s, 1 from fact")
+	for i := 0; i < 4; i++ {
+		tk.MustExec("insert into dim select k + 200 * (select count(*) from dim), s, v from dim")
+	}
+	rows = tk.MustQuery("explain analyze " + queries[0]).Rows()
+	require.Contains(t, fmt.Sprint(rows), "0[IN]:skipped")
+	tk.MustExec("set @@tidb_runtime_filter_type = 'MIN_MAX'")
+	rows = tk.MustQuery("explain analyze " + queries[0]).Rows()
+	require.Contains(t, fmt.Sprint(rows), "0[MIN_MAX]:[")
+}
+
+func TestAdaptiveHashJoin(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table small (k int, v int)")
+	tk.MustExec("create table big (k int, v int)")
+	tk.MustExec("insert into small values (1, 1), (3, 3), (5, 5), (null, 7)")
+	values := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		values = append(values, fmt.Sprintf("(%d, %d)", i%10, i))
+	}
+	tk.MustExec("insert into big values " + strings.Join(values, ","))
+
+	queries := []string{
+		"select /*+ HASH_JOIN_BUILD(big) */ small.v, big.v from small join big on small.k = big.k",
+		"select /*+ HASH_JOIN_BUILD(big) */ small.v, big.v from small join big on small.k = big.k and small.v < big.v",
+		"select /*+ HASH_JOIN_BUILD(big) */ big.v, small.k from big join small on small.k = big.k where small.v > 1",
+		"select /*+ HASH_JOIN_BUILD(big) */ small.v, big.v from small left join big on small.k = big.k",
+	}
+	expected := make([][][]any, 0, len(queries))
+	for _, sql := range queries {
+		expected = append(expected, tk.MustQuery(sql).Sort().Rows())
+	}
+	tk.MustExec("set @@tidb_enable_adaptive_hash_join = 1")
+	for i, sql := range queries {
+		tk.MustQuery(sql).Sort().Check(expected[i])
+	}
+
+	// the inner join builds the hash table from the small table at runtime.
+	rows := tk.MustQuery("explain analyze " + queries[0]).Rows()
+	require.Regexp(t, "adaptive:\\{swap_sides:true, sampled_probe_rows:3, sampled_build_rows:100\\}", rows[0][5])
+	// the outer join is never swapped.
+	rows = tk.MustQuery("explain analyze " + queries[3]).Rows()
+	require.NotContains(t, rows[0][5], "adaptive:")
+	// the sides are not swapped if the probe side is not small enough.
+	tk.MustExec("insert into small select k, v from big")
+	rows = tk.MustQuery("explain analyze " + queries[0]).Rows()
+	require.NotContains(t, rows[0][5], "adaptive:")
+}
+
+func TestAdaptiveHashJoinSkewedKeys(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table p (k int, v int)")
+	tk.MustExec("create table b (k int, v int)")
+	tk.MustExec("insert into b values (1, 1), (1, 2), (2, 3), (3, 4)")
+	for i := 0; i < 10; i++ {
+		tk.MustExec("insert into b select k, v + (select count(*) from b) from b where k = 1")
+	}
+	values := make([]string, 0, 20)
+	for i := 0; i < 20; i++ {
+		values = append(values, fmt.Sprintf("(%d, %d)", i%2+1, i))
+	}
+	tk.MustExec("insert into p values " + strings.Join(values, ","))
+
+	sql := "select /*+ HASH_JOIN_BUILD(b) */ count(*), sum(b.v) from p left join b on p.k = b.k"
+	expected := tk.MustQuery(sql).Rows()
+	tk.MustExec("set @@tidb_enable_adaptive_hash_join = 1")
+	tk.MustQuery(sql).Check(expected)
+	// the rows of k = 1 are cached by the probe workers, the outer join is not swapped.
+	rows := tk.MustQuery("explain analyze " + sql).Rows()
+	require.Regexp(t, "skew:\\{cached_keys:.*, hits:.*\\}", fmt.Sprint(rows))
+}
@@ -16,6 +16,7 @@ package showtest
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -23,8 +24,13 @@ import (
 
 	"github.com/pingcap/failpoint"
 	_ "github.com/pingcap/tidb/pkg/autoid_service"
+	"github.com/pingcap/tidb/pkg/disttask/checksum"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/executor"
 	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
@@ -656,6 +662,13 @@ func TestShowCreateUser(t *testing.T) {
 	tk.MustQuery("SHOW CREATE USER failed_login_user").Check(testkit.Rows(`CREATE USER 'failed_login_user'@'%' IDENTIFIED WITH 'mysql_native_password' AS '' REQUIRE NONE PASSWORD EXPIRE DEFAULT ACCOUNT UNLOCK PASSWORD HISTORY DEFAULT PASSWORD REUSE INTERVAL DEFAULT FAILED_LOGIN_ATTEMPTS 1 PASSWORD_LOCK_TIME UNBOUNDED ATTRIBUTE '{"comment": "testcomment"}'`))
 	tk.MustExec("ALTER USER failed_login_user  ATTRIBUTE '{\"attribute\": \"testattribute\"}'")
 	tk.MustQuery("SHOW CREATE USER failed_login_user").Check(testkit.Rows(`CREATE USER 'failed_login_user'@'%' IDENTIFIED WITH 'mysql_native_password' AS '' REQUIRE NONE PASSWORD EXPIRE DEFAULT ACCOUNT UNLOCK PASSWORD HISTORY DEFAULT PASSWORD REUSE INTERVAL DEFAULT FAILED_LOGIN_ATTEMPTS 1 PASSWORD_LOCK_TIME UNBOUNDED ATTRIBUTE '{"attribute": "testattribute", "comment": "testcomment"}'`))
+
+	// Test the account resource limits.
+	tk.MustExec("CREATE USER limited_user WITH MAX_QUERIES_PER_HOUR 10 MAX_USER_CONNECTIONS 2")
+	tk.MustQuery("SHOW CREATE USER limited_user").Check(testkit.Rows(`CREATE USER 'limited_user'@'%' IDENTIFIED WITH 'mysql_native_password' AS '' REQUIRE NONE WITH MAX_QUERIES_PER_HOUR 10 MAX_USER_CONNECTIONS 2 PASSWORD EXPIRE DEFAULT ACCOUNT UNLOCK PASSWORD HISTORY DEFAULT PASSWORD REUSE INTERVAL DEFAULT`))
+	tk.MustQuery("SELECT max_questions, max_user_connections FROM mysql.user WHERE user = 'limited_user'").Check(testkit.Rows("10 2"))
+	tk.MustExec("ALTER USER limited_user WITH MAX_USER_CONNECTIONS 0 MAX_UPDATES_PER_HOUR 5")
+	tk.MustQuery("SHOW CREATE USER limited_user").Check(testkit.Rows(`CREATE USER 'limited_user'@'%' IDENTIFIED WITH 'mysql_native_password' AS '' REQUIRE NONE WITH MAX_QUERIES_PER_HOUR 10 PASSWORD EXPIRE DEFAULT ACCOUNT UNLOCK PASSWORD HISTORY DEFAULT PASSWORD REUSE INTERVAL DEFAULT`))
 }
 
 func TestUnprivilegedShow(t *testing.T) {
@@ -801,6 +814,51 @@ func TestAutoRandomBase(t *testing.T) {
 	))
 }
 
+func TestShowCreateTableMySQLCompatible(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create placement policy x followers=2")
+	defer tk.MustExec("drop placement policy if exists x")
+	defer tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1 (a bigint primary key auto_random(5), b int unique key auto_increment) " +
+		"auto_random_base = 100, auto_increment = 100, auto_id_cache = 1, pre_split_regions = 2 placement policy x")
+	tk.MustExec("create table t2 (a int, b datetime, primary key (a) nonclustered) shard_row_id_bits = 4 " +
+		"ttl = b + interval 1 day partition by hash(a) (partition p0 placement policy x, partition p1)")
+
+	tk.MustExec("set @@tidb_show_create_table_mysql_compatible = on")
+	tk.MustQuery("show create table t1").Check(testkit.RowsWithSep("|",
+		""+
+			"t1 CREATE TABLE `t1` (\n"+
+			"  `a` bigint(20) NOT NULL,\n"+
+			"  `b` int(11) NOT NULL AUTO_INCREMENT,\n"+
+			"  PRIMARY KEY (`a`),\n"+
+			"  UNIQUE KEY `b` (`b`)\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin AUTO_INCREMENT=100",
+	))
+	tk.MustQuery("show create table t2").Check(testkit.RowsWithSep("|",
+		""+
+			"t2 CREATE TABLE `t2` (\n"+
+			"  `a` int(11) NOT NULL,\n"+
+			"  `b` datetime DEFAULT NULL,\n"+
+			"  PRIMARY KEY (`a`)\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n"+
+			"PARTITION BY HASH (`a`) PARTITIONS 2",
+	))
+
+	// The TiDB specific clauses are shown again once the variable is off.
+	tk.MustExec("set @@tidb_show_create_table_mysql_compatible = off")
+	tk.MustQuery("show create table t1").Check(testkit.RowsWithSep("|",
+		""+
+			"t1 CREATE TABLE `t1` (\n"+
+			"  `a` bigint(20) NOT NULL /*T![auto_rand] AUTO_RANDOM(5) */,\n"+
+			"  `b` int(11) NOT NULL AUTO_INCREMENT,\n"+
+			"  PRIMARY KEY (`a`) /*T![clustered_index] CLUSTERED */,\n"+
+			"  UNIQUE KEY `b` (`b`)\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin AUTO_INCREMENT=100 /*T![auto_id_cache] AUTO_ID_CACHE=1 */ /*T![auto_rand_base] AUTO_RANDOM_BASE=100 */ /*T! PRE_SPLIT_REGIONS=2 */ /*T![placement] PLACEMENT POLICY=`x` */",
+	))
+}
+
 func TestAutoRandomWithLargeSignedShowTableRegions(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -1222,3 +1280,85 @@ func TestShowBindingDigestField(t *testing.T) {
 	result = tk.MustQuery("show global bindings;")
 	require.Equal(t, len(result.Rows()), 0)
 }
+
+func TestShowAndOperateDistJobs(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int, key idx(b))")
+	tk.MustExec("insert into t values (1, 1), (2, 2)")
+	tk.MustQuery("show jobs").Check(testkit.Rows())
+	err := tk.QueryToErr("show job 1")
+	require.True(t, exeerrors.ErrLoadDataJobNotFound.Equal(err), err)
+	tk.MustGetDBError("cancel job 1", exeerrors.ErrLoadDataJobNotFound)
+
+	// the job of ADMIN CHECKSUM TABLE is moved to the history table after it finishes.
+	tk.MustExec("set global tidb_enable_dist_task = on")
+	t.Cleanup(func() {
+		tk.MustExec("set global tidb_enable_dist_task = off")
+	})
+	tk.MustExec("admin checksum table t")
+	rows := tk.MustQuery("show jobs").Rows()
+	require.Len(t, rows, 1)
+	jobID := rows[0][0].(string)
+	require.Equal(t, []any{"ChecksumTable", "succeed", "done", "<nil>", "<nil>", "<nil>", "<nil>"},
+		[]any{rows[0][2], rows[0][3], rows[0][4], rows[0][9], rows[0][10], rows[0][11], rows[0][12]})
+	rows = tk.MustQuery("show job " + jobID).Rows()
+	// one subtask for the records and one for the index.
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		require.Equal(t, []any{"checksum", "succeed"}, []any{row[1], row[3]})
+	}
+	tk.MustGetDBError("pause job "+jobID, exeerrors.ErrLoadDataInvalidOperation)
+	tk.MustGetDBError("resume job "+jobID, exeerrors.ErrLoadDataInvalidOperation)
+	tk.MustGetDBError("cancel job "+jobID, exeerrors.ErrLoadDataInvalidOperation)
+
+	// pause and resume a job.
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	ver, err := store.CurrentVersion(kv.GlobalTxnScope)
+	require.NoError(t, err)
+	taskMeta := &checksum.TaskMeta{DBName: "test", TableInfo: tbl.Meta(), StartTS: ver.Ver, ScanConcurrency: 1}
+	bs, err := json.Marshal(taskMeta)
+	require.NoError(t, err)
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalDistTask)
+	taskManager, err := storage.GetTaskManager()
+	require.NoError(t, err)
+	taskID, err := taskManager.CreateTask(ctx, checksum.TaskKey(tbl.Meta().ID, ver.Ver), proto.ChecksumTable, 1, bs)
+	require.NoError(t, err)
+	tk.MustExec(fmt.Sprintf("pause job %d", taskID))
+	_, err = handle.WaitTask(ctx, taskID, func(task *proto.TaskBase) bool {
+		return task.State == proto.TaskStatePaused
+	})
+	require.NoError(t, err)
+	rows = tk.MustQuery("show jobs where state = 'paused'").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, fmt.Sprint(taskID), rows[0][0])
+	tk.MustGetDBError(fmt.Sprintf("cancel job %d", taskID), exeerrors.ErrLoadDataInvalidOperation)
+	tk.MustExec(fmt.Sprintf("resume job %d", taskID))
+	task, err := handle.WaitTask(ctx, taskID, func(task *proto.TaskBase) bool {
+		return task.IsDone()
+	})
+	require.NoError(t, err)
+	require.Equal(t, proto.TaskStateSucceed, task.State)
+	require.Len(t, tk.MustQuery(fmt.Sprintf("show job %d", taskID)).Rows(), 2)
+
+	// cancel a job.
+	taskID, err = taskManager.CreateTask(ctx, checksum.TaskKey(tbl.Meta().ID, ver.Ver+1), proto.ChecksumTable, 1, bs)
+	require.NoError(t, err)
+	tk.MustExec(fmt.Sprintf("cancel job %d", taskID))
+	task, err = handle.WaitTask(ctx, taskID, func(task *proto.TaskBase) bool {
+		return task.IsDone()
+	})
+	require.NoError(t, err)
+	require.Equal(t, proto.TaskStateReverted, task.State)
+	require.Len(t, tk.MustQuery("show jobs").Rows(), 3)
+
+	// SUPER is required.
+	tk.MustExec("create user 'u1'@'%'")
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustGetErrCode("show jobs", mysql.ErrSpecificAccessDenied)
+	tk1.MustGetErrCode("show job "+jobID, mysql.ErrSpecificAccessDenied)
+	tk1.MustGetErrCode("pause job "+jobID, mysql.ErrSpecificAccessDenied)
+}
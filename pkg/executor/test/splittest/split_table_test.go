@@ -17,6 +17,8 @@ package splittest
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync/atomic"
 	"testing"
 
@@ -28,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/pkg/store/copr"
 	"github.com/pingcap/tidb/pkg/store/driver/backoff"
 	"github.com/pingcap/tidb/pkg/store/helper"
+	"github.com/pingcap/tidb/pkg/store/regioncache"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/testkit/external"
@@ -70,6 +73,56 @@ func TestClusterIndexShowTableRegion(t *testing.T) {
 	tk.MustQuery("split table t between (0, 0, 0) and (0, 0, 1) regions 1000;").Check(testkit.Rows("999 1"))
 }
 
+func TestSplitRegionByQuantiles(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	atomic.StoreUint32(&ddl.EnableSplitTableRegion, 1)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_wait_split_region_finish = 0")
+	tk.MustExec("create table t (a int primary key, b int, key idx_b(b))")
+	tk.MustGetErrMsg("split table t by quantiles regions 4", "Split table region by quantiles requires the statistics, please analyze table `t` first")
+
+	values := make([]string, 0, 100)
+	for i := 1; i <= 100; i++ {
+		values = append(values, fmt.Sprintf("(%d, %d)", i, i%4))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+	tk.MustExec("analyze table t")
+	tbl := external.GetTableByName(t, tk, "test", "t")
+
+	// The rows are split into 4 regions evenly.
+	tk.MustQuery("split table t by quantiles regions 4 scatter").Check(testkit.Rows("3 1"))
+	rows := tk.MustQuery("show table t regions").Rows()
+	require.Len(t, rows, 4)
+	for i, start := range []int{26, 51, 76} {
+		require.Equal(t, fmt.Sprintf("t_%d_r_%d", tbl.Meta().ID, start), rows[i+1][1])
+	}
+
+	// The index values are all in the TopN, and the end of the index is split as well.
+	tk.MustQuery("split table t index idx_b by quantiles regions 2").Check(testkit.Rows("2 0"))
+	rows = tk.MustQuery("show table t index idx_b regions").Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, fmt.Sprintf("t_%d_i_%d_038000000000000002", tbl.Meta().ID, tbl.Meta().Indices[0].ID), rows[1][1])
+	require.Equal(t, fmt.Sprintf("t_%d_i_%d_", tbl.Meta().ID, tbl.Meta().Indices[0].ID+1), rows[1][2])
+
+	tk.MustGetErrMsg("split table t by quantiles regions 0", "Split table region num should more than 0")
+	tk.MustGetErrMsg("split table t index idx_c by quantiles regions 2", "[planner:1176]Key 'idx_c' doesn't exist in table 't'")
+	tk.MustGetErrMsg("split table t by quantiles regions 1", "Split table region by quantiles found no split point, please analyze table `t` first")
+
+	// The common handle is split by the statistics of the primary key.
+	tk.MustExec("create table t3 (a varchar(10), b int, primary key(a) clustered)")
+	tk.MustExec("insert into t3 select concat('k', a), b from t")
+	tk.MustExec("analyze table t3")
+	tk.MustQuery("split table t3 by quantiles regions 2 scatter").Check(testkit.Rows("1 1"))
+	tbl = external.GetTableByName(t, tk, "test", "t3")
+	rows = tk.MustQuery("show table t3 regions").Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, fmt.Sprintf("t_%d_r_016b35340000000000fa", tbl.Meta().ID), rows[1][1])
+
+	tk.MustExec("create table t2 (a int, b int)")
+	tk.MustGetErrMsg("split table t2 by quantiles regions 4", "Split table region by quantiles requires the table to be clustered by the primary key")
+}
+
 func TestShowTableRegion(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -578,6 +631,50 @@ func TestShowTableRegion(t *testing.T) {
 	}
 }
 
+func TestShowTableRegionSummary(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	atomic.StoreUint32(&ddl.EnableSplitTableRegion, 1)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_wait_split_region_finish = 1")
+	tk.MustExec("create table t (a int primary key, b int, key idx(b))")
+	tk.MustQuery("split table t between (0) and (100000) regions 10").Check(testkit.Rows("10 1"))
+	tk.MustQuery("split table t index idx between (0) and (1000) regions 4").Check(testkit.Rows("4 1"))
+	regionCount := len(tk.MustQuery("show table t regions").Rows())
+	indexRegionCount := len(tk.MustQuery("show table t index idx regions").Rows())
+	require.Greater(t, regionCount, 10)
+
+	// The regions are grouped by the store.
+	rows := tk.MustQuery("show table t regions summary").Rows()
+	require.Len(t, rows, 1)
+	require.Len(t, rows[0], 7)
+	require.Equal(t, strconv.Itoa(regionCount), rows[0][1])
+	require.Equal(t, strconv.Itoa(regionCount), rows[0][2])
+	tk.MustQuery("show table t index idx regions summary").CheckAt([]int{1, 2},
+		testkit.Rows(fmt.Sprintf("%d %d", indexRegionCount, indexRegionCount)))
+	tk.MustQuery(fmt.Sprintf("show table t regions summary where STORE_ID = %s", rows[0][0])).CheckAt([]int{1},
+		testkit.Rows(strconv.Itoa(regionCount)))
+	tk.MustQuery("show table t regions summary where STORE_ID = 0").Check(testkit.Rows())
+
+	// The detailed regions are limited by tidb_show_table_regions_max_rows.
+	tk.MustExec("set @@tidb_show_table_regions_max_rows = 3")
+	require.Len(t, tk.MustQuery("show table t regions").Rows(), 3)
+	tk.MustQuery("show warnings").Check(testkit.Rows(fmt.Sprintf("Warning 1105 Only 3 of %d regions are shown, "+
+		"use `SHOW TABLE ... REGIONS SUMMARY` or increase tidb_show_table_regions_max_rows to see all of them", regionCount)))
+	tk.MustQuery("show table t regions summary").CheckAt([]int{1}, testkit.Rows(strconv.Itoa(regionCount)))
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	tk.MustExec("set @@tidb_show_table_regions_max_rows = 0")
+	require.Len(t, tk.MustQuery("show table t regions").Rows(), regionCount)
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+
+	// The region cache is repaired by the queries above, and the statistics are shown in the memory table.
+	regioncache.ResetStats()
+	tk.MustQuery("show table t regions summary")
+	tk.MustQuery("select value from information_schema.tidb_region_cache_stats where category = 'repair' and name = 'calls'").Check(testkit.Rows("2"))
+	tk.MustQuery("select value from information_schema.tidb_region_cache_stats where category = 'repair' and name = 'stale_regions'").Check(testkit.Rows("0"))
+	tk.MustQuery("select value from information_schema.tidb_region_cache_stats where category = 'size' and name = 'stores'").Check(testkit.Rows("1"))
+}
+
 func BenchmarkLocateRegion(t *testing.B) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -1988,3 +1988,43 @@ func TestIssue50358(t *testing.T) {
 		tk.MustQuery("select 8 from t join t1").Check(testkit.Rows("8", "8"))
 	}
 }
+
+func TestWaitTiFlashReplica(t *testing.T) {
+	store := testkit.CreateMockStore(t, withMockTiFlash(1))
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, t1, t2")
+	tk.MustExec("create table t(a int)")
+	tk.MustExec("create table t1(a int)")
+	tk.MustExec("create table t2(a int) partition by hash(a) partitions 2")
+
+	err := tk.ExecToErr("admin wait tiflash replica t timeout 1")
+	require.ErrorContains(t, err, "table `test`.`t` doesn't have TiFlash replicas")
+
+	err = tk.ExecToErr("alter table t set tiflash replica 1 wait timeout 1")
+	require.ErrorContains(t, err, "wait for TiFlash replicas timeout after 1s, the progress of table `test`.`t`")
+	// The replica is still set even if the waiting times out.
+	tb := external.GetTableByName(t, tk, "test", "t")
+	require.Equal(t, uint64(1), tb.Meta().TiFlashReplica.Count)
+	require.False(t, tb.Meta().TiFlashReplica.Available)
+
+	tk.MustExec("alter table t1 set tiflash replica 1")
+	tk.MustExec("alter table t2 set tiflash replica 1")
+	for _, name := range []string{"t", "t1"} {
+		tb = external.GetTableByName(t, tk, "test", name)
+		err = domain.GetDomain(tk.Session()).DDL().UpdateTableReplicaInfo(tk.Session(), tb.Meta().ID, true)
+		require.NoError(t, err)
+	}
+	tk.MustExec("admin wait tiflash replica t, test.t1")
+	err = tk.ExecToErr("admin wait tiflash replica t, t1, t2 timeout 1")
+	require.ErrorContains(t, err, "the progress of table `test`.`t2`")
+
+	tb = external.GetTableByName(t, tk, "test", "t2")
+	for _, def := range tb.Meta().GetPartitionInfo().Definitions {
+		err = domain.GetDomain(tk.Session()).DDL().UpdateTableReplicaInfo(tk.Session(), def.ID, true)
+		require.NoError(t, err)
+	}
+	tk.MustExec("admin wait tiflash replica t, t1, t2 timeout 1")
+	tk.MustExec("alter table t1 set tiflash replica 0 wait")
+	tk.MustExec("alter table t set tiflash replica 1 wait timeout 10")
+}
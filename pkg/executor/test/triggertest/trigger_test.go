@@ -0,0 +1,242 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggertest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDropTrigger(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+
+	tk.MustQuery("select count(*) from information_schema.triggers").Check(testkit.Rows("0"))
+	tk.MustExec("create trigger tr1 before insert on t for each row set new.b = new.a * 2")
+	tk.MustExec("create trigger test.tr2 before insert on t for each row set new.a = new.a + 1")
+	tk.MustQuery("select trigger_schema, trigger_name, event_manipulation, event_object_table, action_order, action_statement, " +
+		"action_orientation, action_timing, definer from information_schema.triggers order by trigger_name").Check(testkit.Rows(
+		"test tr1 INSERT t 1 set new.b = new.a * 2 ROW BEFORE root@%",
+		"test tr2 INSERT t 2 set new.a = new.a + 1 ROW BEFORE root@%",
+	))
+
+	tk.MustGetErrCode("create trigger tr1 after insert on t for each row insert into t values (1, 1)", errno.ErrTrgAlreadyExists)
+	tk.MustExec("create trigger if not exists tr1 before insert on t for each row set new.b = 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1359 Trigger already exists"))
+	tk.MustGetErrCode("create trigger mysql.tr3 before insert on test.t for each row set new.b = 1", errno.ErrTrgInWrongSchema)
+	tk.MustGetErrCode("create trigger tr3 before insert on not_exist for each row set new.b = 1", errno.ErrNoSuchTable)
+
+	tk.MustExec("drop trigger tr1")
+	tk.MustExec("drop trigger test.tr2")
+	tk.MustQuery("select count(*) from information_schema.triggers").Check(testkit.Rows("0"))
+	tk.MustGetErrCode("drop trigger tr1", errno.ErrTrgDoesNotExist)
+	tk.MustExec("drop trigger if exists tr1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1360 Trigger does not exist"))
+}
+
+func TestCreateTriggerErrors(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("create table pt (a int) partition by hash(a) partitions 4")
+	tk.MustExec("create view v as select * from t")
+	tk.MustExec("create global temporary table tmp (a int) on commit delete rows")
+
+	tk.MustGetErrCode("create trigger tr before insert on v for each row set new.a = 1", errno.ErrTrgOnViewOrTempTable)
+	tk.MustGetErrCode("create trigger tr before insert on tmp for each row set new.a = 1", errno.ErrTrgOnViewOrTempTable)
+	tk.MustGetErrCode("create trigger tr before insert on pt for each row set new.a = 1", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create trigger tr before update on t for each row set new.a = 1", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create trigger tr before insert on t for each row set old.a = 1", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger tr after insert on t for each row set new.a = 1", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger tr before insert on t for each row set new.c = 1", errno.ErrBadField)
+	tk.MustGetErrCode("create trigger tr before insert on t for each row set new.a = old.b", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger tr before insert on t for each row insert into t values (1, 1)", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create trigger tr after insert on t for each row select 1", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("create table log (a int)")
+	tk.MustGetErrCode("create trigger tr after insert on t for each row insert into log values (old.a)", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger tr after insert on t for each row insert into log values (new.c)", errno.ErrBadField)
+	tk.MustGetErrCode("create trigger tr after insert on t for each row update log set a = old.b", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustQuery("select count(*) from information_schema.triggers").Check(testkit.Rows("0"))
+}
+
+func TestBeforeInsertTrigger(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key auto_increment, a int, b varchar(10), c int as (a + 1))")
+
+	tk.MustExec(`create trigger tr1 before insert on t for each row
+begin
+	set new.a = ifnull(new.a, 0) * 10;
+	set new.b = concat('v', new.a);
+end`)
+	tk.MustExec("insert into t (a) values (1), (null)")
+	tk.MustExec("insert into t (a, b) select 3, 'x'")
+	tk.MustExec("replace into t (id, a) values (1, 5)")
+	tk.MustQuery("select id, a, b, c from t order by id").Check(testkit.Rows("1 50 v50 51", "2 0 v0 1", "3 30 v30 31"))
+
+	// the triggers are executed in the order of creation.
+	tk.MustExec("create trigger tr2 before insert on t for each row set new.b = upper(new.b)")
+	tk.MustExec("insert into t (a) values (4)")
+	tk.MustQuery("select a, b from t where id = 4").Check(testkit.Rows("40 V40"))
+
+	// the values assigned by the triggers are checked like the inserted values.
+	tk.MustExec("drop trigger tr2")
+	tk.MustExec("create trigger tr3 before insert on t for each row set new.b = repeat('x', 20)")
+	tk.MustGetErrCode("insert into t (a) values (5)", errno.ErrDataTooLong)
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("4"))
+}
+
+func TestAfterInsertTrigger(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v varchar(10))")
+	tk.MustExec("create table log (id int, v varchar(10), note varchar(20))")
+	tk.MustExec("create table cnt (n int)")
+	tk.MustExec("insert into cnt values (0)")
+
+	tk.MustExec(`create trigger tr after insert on t for each row
+begin
+	insert into log values (new.id, new.v, 'inserted');
+	update cnt set n = n + 1;
+end`)
+	tk.MustExec("insert into t values (1, 'a'), (2, 'b')")
+	require.Equal(t, uint64(2), tk.Session().AffectedRows())
+	tk.MustQuery("select id, v, note from log order by id").Check(testkit.Rows("1 a inserted", "2 b inserted"))
+	tk.MustQuery("select n from cnt").Check(testkit.Rows("2"))
+
+	// the changes of the triggers are rolled back with the statement.
+	tk.MustExec("begin")
+	tk.MustGetErrCode("insert into t values (3, 'c'), (1, 'd')", errno.ErrDupEntry)
+	tk.MustExec("insert into t values (3, 'c')")
+	tk.MustExec("rollback")
+	tk.MustQuery("select count(*) from log").Check(testkit.Rows("2"))
+	tk.MustQuery("select n from cnt").Check(testkit.Rows("2"))
+
+	// the triggers of the tables written by the trigger are executed too.
+	tk.MustExec("create trigger tr_log after insert on log for each row update cnt set n = n + 100")
+	tk.MustExec("insert into t values (4, 'd')")
+	tk.MustQuery("select n from cnt").Check(testkit.Rows("103"))
+
+	// the trigger can't modify the tables written by the statements which invoke it.
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("create trigger tr_self after insert on t2 for each row insert into t2 values (new.a + 1)")
+	tk.MustGetErrCode("insert into t2 values (1)", errno.ErrCantUpdateUsedTableInSfOrTrg)
+	tk.MustExec("drop trigger tr_log")
+	tk.MustExec("create trigger tr_log after insert on log for each row delete from t where id = new.id")
+	tk.MustGetErrCode("insert into t values (5, 'e')", errno.ErrCantUpdateUsedTableInSfOrTrg)
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("3"))
+	tk.MustQuery("select count(*) from t2").Check(testkit.Rows("0"))
+}
+
+func TestAfterInsertTriggerPrivilege(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	rootTk := testkit.NewTestKit(t, store)
+	require.NoError(t, rootTk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	rootTk.MustExec("use test")
+	rootTk.MustExec("create table t (id int primary key)")
+	rootTk.MustExec("create table log (id int)")
+	rootTk.MustExec("create table secret (id int)")
+	rootTk.MustExec("create user trigger_definer, invoker")
+	rootTk.MustExec("grant trigger on test.t to trigger_definer")
+	rootTk.MustExec("grant insert on test.log to trigger_definer")
+	rootTk.MustExec("grant insert on test.t to invoker")
+
+	definerTk := testkit.NewTestKit(t, store)
+	require.NoError(t, definerTk.Session().Auth(&auth.UserIdentity{Username: "trigger_definer", Hostname: "%"}, nil, nil, nil))
+	definerTk.MustExec("use test")
+	definerTk.MustExec("create trigger tr after insert on t for each row insert into log values (new.id)")
+	rootTk.MustQuery("select definer from information_schema.triggers where trigger_name = 'tr'").Check(testkit.Rows("trigger_definer@%"))
+
+	// the statements of the trigger are executed with the privileges of the definer rather than the invoker.
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "invoker", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("insert into t values (1)")
+	rootTk.MustQuery("select id from log").Check(testkit.Rows("1"))
+	require.Equal(t, "invoker", tk.Session().GetSessionVars().User.Username)
+
+	// every statement of the trigger is checked.
+	rootTk.MustExec("drop trigger tr")
+	definerTk.MustExec(`create trigger tr after insert on t for each row
+begin
+	insert into log values (new.id);
+	insert into secret values (new.id);
+end`)
+	tk.MustGetErrCode("insert into t values (2)", errno.ErrTableaccessDenied)
+	rootTk.MustExec("grant insert on test.secret to invoker")
+	tk.MustGetErrCode("insert into t values (2)", errno.ErrTableaccessDenied)
+	rootTk.MustExec("grant insert on test.secret to trigger_definer")
+	tk.MustExec("insert into t values (2)")
+	rootTk.MustQuery("select id from secret").Check(testkit.Rows("2"))
+
+	rootTk.MustExec("drop user trigger_definer")
+	tk.MustGetErrCode("insert into t values (3)", errno.ErrNoSuchUser)
+	rootTk.MustQuery("select count(*) from t").Check(testkit.Rows("2"))
+}
+
+func TestAfterInsertTriggerPessimisticLock(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int)")
+	tk.MustExec("create table cnt (id int primary key, n int)")
+	tk.MustExec("insert into cnt values (1, 0)")
+	tk.MustExec("create trigger tr after insert on t for each row update cnt set n = n + new.v where id = 1")
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	tk1.MustExec("set innodb_lock_wait_timeout = 1")
+
+	// the keys written by the statement and by the trigger are locked until the transaction ends.
+	tk.MustExec("begin pessimistic")
+	tk.MustExec("insert into t values (1, 10)")
+	tk1.MustExec("begin pessimistic")
+	tk1.MustGetErrCode("insert into t values (1, 20)", errno.ErrLockWaitTimeout)
+	tk1.MustGetErrCode("update cnt set n = 0 where id = 1", errno.ErrLockWaitTimeout)
+	tk1.MustExec("rollback")
+	tk.MustGetErrCode("insert into t values (2, 10), (1, 10)", errno.ErrDupEntry)
+	tk.MustExec("commit")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 10"))
+	tk.MustQuery("select n from cnt").Check(testkit.Rows("10"))
+	tk1.MustExec("update cnt set n = 0 where id = 1")
+}
+
+func TestAfterInsertTriggerMemTracker(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v varchar(100))")
+	tk.MustExec("create table log (id int)")
+	tk.MustExec("create trigger tr after insert on t for each row insert into log values (new.id)")
+
+	// the rows buffered for the trigger are tracked by the statement and released after the trigger is executed.
+	tk.MustExec("insert into t values (1, repeat('a', 100)), (2, repeat('b', 100)), (3, repeat('c', 100))")
+	tracker := tk.Session().GetSessionVars().StmtCtx.MemTracker.SearchTrackerWithoutLock(memory.LabelForAfterTriggerRows)
+	require.NotNil(t, tracker)
+	require.Greater(t, tracker.MaxConsumed(), int64(300))
+	require.Zero(t, tracker.BytesConsumed())
+	tk.MustQuery("select count(*) from log").Check(testkit.Rows("3"))
+}
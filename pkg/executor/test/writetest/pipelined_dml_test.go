@@ -0,0 +1,99 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writetest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelinedDMLWithSecondaryIndexes(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table src (id int primary key)")
+	tk.MustExec("set @@cte_max_recursion_depth = 20000")
+	tk.MustExec("insert into src with recursive c(n) as (select 1 union all select n + 1 from c where n < 12000) select n from c")
+	tk.MustExec(`create table t (id int primary key, a int, b int, c int, d int, pad varchar(2000),
+		key ka(a), key kb(b), key kc(c), unique key kd(d))`)
+
+	// The mutations of the statements exceed the flush threshold, so they are flushed in several generations.
+	tk.MustExec("set @@tidb_dml_type = 'bulk'")
+	tk.MustExec("insert into t select id, id, id, id, id, repeat('x', 1500) from src")
+	require.True(t, tk.Session().GetSessionVars().TxnCtx.IsPipelined)
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	tk.MustQuery("select count(*), sum(a), sum(d) from t").Check(testkit.Rows("12000 72006000 72006000"))
+	tk.MustExec("admin check table t")
+
+	tk.MustExec("update t set a = a + 1, b = b * 2, d = d + 12000, pad = repeat('y', 1500)")
+	require.True(t, tk.Session().GetSessionVars().TxnCtx.IsPipelined)
+	require.Equal(t, uint64(12000), tk.Session().AffectedRows())
+	tk.MustQuery("select count(*) from t where pad = repeat('y', 1500)").Check(testkit.Rows("12000"))
+	tk.MustQuery("select id, a, b, c, d from t use index(kd) where d in (12001, 24000)").Sort().Check(testkit.Rows(
+		"1 2 2 1 12001",
+		"12000 12001 24000 12000 24000",
+	))
+	tk.MustExec("admin check table t")
+
+	tk.MustExec("delete from t where id % 3 = 0")
+	require.True(t, tk.Session().GetSessionVars().TxnCtx.IsPipelined)
+	require.Equal(t, uint64(4000), tk.Session().AffectedRows())
+	tk.MustQuery("select count(*) from t use index(kb)").Check(testkit.Rows("8000"))
+	tk.MustExec("admin check table t")
+
+	tk.MustExec("set @@tidb_dml_type = 'standard'")
+	tk.MustExec("delete from t where id < 10")
+	require.False(t, tk.Session().GetSessionVars().TxnCtx.IsPipelined)
+	tk.MustExec("admin check table t")
+}
+
+func TestPipelinedDMLFallback(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int)")
+	tk.MustExec("create table parent (id int primary key)")
+	tk.MustExec("create table child (id int primary key, pid int, foreign key (pid) references parent(id))")
+	tk.MustGetErrMsg("set @@tidb_dml_type = 'unknown'", "[variable:1231]Variable 'tidb_dml_type' can't be set to the value of 'unknown'")
+	tk.MustExec("set @@tidb_dml_type = 'bulk'")
+
+	tk.MustExec("insert into t values (1, 1)")
+	require.True(t, tk.Session().GetSessionVars().TxnCtx.IsPipelined)
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1"))
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+
+	checkFallback := func(sql, reason string) {
+		tk.MustExec(sql)
+		require.False(t, tk.Session().GetSessionVars().TxnCtx.IsPipelined)
+		tk.MustQuery("show warnings").Check(testkit.Rows(
+			"Warning 1105 Pipelined DML can not be used " + reason + ". Fallback to standard mode"))
+	}
+	checkFallback("insert ignore into t values (2, 2)", "for the statement with IGNORE")
+	checkFallback("insert into t values (2, 2) on duplicate key update v = 3", "for INSERT ... ON DUPLICATE KEY UPDATE")
+	checkFallback("insert into parent values (1)", "on tables with foreign keys")
+	checkFallback("insert into child values (1, 1)", "on tables with foreign keys")
+	tk.MustExec("begin")
+	checkFallback("update t set v = v + 1", "in an explicit transaction")
+	tk.MustExec("commit")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 2", "2 4"))
+
+	tk.MustExec("set @@foreign_key_checks = 0")
+	tk.MustExec("delete from child")
+	require.True(t, tk.Session().GetSessionVars().TxnCtx.IsPipelined)
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	tk.MustQuery("select * from child").Check(testkit.Rows())
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	pd "github.com/tikv/pd/client/http"
+	"go.uber.org/zap"
+)
+
+// tiflashReplicaWaitInterval is the interval to poll the progress of the TiFlash replicas.
+var tiflashReplicaWaitInterval = time.Second
+
+// getTiFlashReplicaProgress returns the replication progress of the TiFlash replicas of the table,
+// it's the average progress of the partitions for a partitioned table.
+func getTiFlashReplicaProgress(tbl *model.TableInfo, tiFlashStores *map[int64]pd.StoreInfo) float64 {
+	pi := tbl.GetPartitionInfo()
+	if pi == nil || len(pi.Definitions) == 0 {
+		progress, err := infosync.MustGetTiFlashProgress(tbl.ID, tbl.TiFlashReplica.Count, tiFlashStores)
+		if err != nil {
+			logutil.BgLogger().Error("get tiflash replica progress error", zap.Int64("tableID", tbl.ID), zap.Error(err))
+		}
+		return progress
+	}
+	var progress float64
+	for _, p := range pi.Definitions {
+		progressOfPartition, err := infosync.MustGetTiFlashProgress(p.ID, tbl.TiFlashReplica.Count, tiFlashStores)
+		if err != nil {
+			logutil.BgLogger().Error("get tiflash replica progress error", zap.Int64("tableID", tbl.ID), zap.Int64("partitionID", p.ID), zap.Error(err))
+		}
+		progress += progressOfPartition
+	}
+	return progress / float64(len(pi.Definitions))
+}
+
+// waitTiFlashReplicaAvailable polls the latest schema until the TiFlash replicas of all the tables are available.
+// It returns an error if the timeout is reached first, a zero timeout means waiting without a limit, the statement
+// can still be killed.
+func waitTiFlashReplicaAvailable(ctx context.Context, sctx sessionctx.Context, tables []*ast.TableName, timeout time.Duration) error {
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	ticker := time.NewTicker(tiflashReplicaWaitInterval)
+	defer ticker.Stop()
+	pending := make([]*ast.TableName, 0, len(tables))
+	for _, tn := range tables {
+		schema := tn.Schema
+		if schema.L == "" {
+			schema = model.NewCIStr(sctx.GetSessionVars().CurrentDB)
+		}
+		pending = append(pending, &ast.TableName{Schema: schema, Name: tn.Name})
+	}
+	for {
+		is := domain.GetDomain(sctx).InfoSchema()
+		var waiting *model.TableInfo
+		remaining := pending[:0]
+		for _, tn := range pending {
+			tbl, err := is.TableByName(tn.Schema, tn.Name)
+			if err != nil {
+				return err
+			}
+			replica := tbl.Meta().TiFlashReplica
+			if replica == nil || replica.Count == 0 {
+				return errors.Errorf("table `%s`.`%s` doesn't have TiFlash replicas", tn.Schema.O, tn.Name.O)
+			}
+			if !replica.Available {
+				remaining = append(remaining, tn)
+				if waiting == nil {
+					waiting = tbl.Meta()
+				}
+			}
+		}
+		if len(remaining) == 0 {
+			return nil
+		}
+		pending = remaining
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			var tiFlashStores map[int64]pd.StoreInfo
+			progress := getTiFlashReplicaProgress(waiting, &tiFlashStores)
+			return errors.Errorf("wait for TiFlash replicas timeout after %v, the progress of table `%s`.`%s` is %.2f",
+				timeout, pending[0].Schema.O, pending[0].Name.O, progress)
+		case <-ticker.C:
+		}
+		if err := sctx.GetSessionVars().SQLKiller.HandleSignal(); err != nil {
+			return err
+		}
+	}
+}
@@ -188,7 +188,7 @@ func (e *TraceExec) nextOptimizerPlanTrace(ctx context.Context, se sessionctx.Co
 	defer func() {
 		stmtCtx.EnableOptimizeTrace = origin
 	}()
-	_, _, err = core.OptimizeAstNode(ctx, se, e.stmtNode, se.GetInfoSchema().(infoschema.InfoSchema))
+	p, _, err := core.OptimizeAstNode(ctx, se, e.stmtNode, se.GetInfoSchema().(infoschema.InfoSchema))
 	if err != nil {
 		return err
 	}
@@ -202,6 +202,10 @@ func (e *TraceExec) nextOptimizerPlanTrace(ctx context.Context, se sessionctx.Co
 		return errors.AddStack(err)
 	}
 	res := []byte(writer.String())
+	// the trace is still dumped into the file if it fails to be persisted.
+	if err := persistOptimizerTrace(ctx, se, e.stmtNode, p, writer.String()); err != nil {
+		se.GetSessionVars().StmtCtx.AppendWarning(err)
+	}
 
 	_, err = traceZW.Write(res)
 	if err != nil {
@@ -15,6 +15,7 @@
 package executor_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/testkit"
@@ -86,3 +87,29 @@ func TestTracePlanStmt(t *testing.T) {
 	require.Len(t, rows[0], 1)
 	require.Regexp(t, ".*zip", rows[0][0])
 }
+
+func TestCompareOptimizerTrace(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int)")
+	tk.MustQuery("trace plan select * from t where a = 1")
+	tk.MustExec("alter table t add index ia(a)")
+	tk.MustQuery("trace plan select * from t where a = 1")
+	// the traces of the two plans are persisted with the same sql digest.
+	rows := tk.MustQuery("select plan_digest from mysql.optimizer_trace order by create_time, plan_digest").Rows()
+	require.Len(t, rows, 2)
+	tk.MustQuery("select count(distinct sql_digest), count(distinct plan_digest) from mysql.optimizer_trace").Check(testkit.Rows("1 2"))
+	d1, d2 := rows[0][0].(string), rows[1][0].(string)
+
+	tk.MustQuery(fmt.Sprintf("admin compare optimizer trace '%s' '%s'", d1, d1)).Check(testkit.Rows())
+	diffs := tk.MustQuery(fmt.Sprintf("admin compare optimizer trace '%s' '%s'", d1, d2)).Rows()
+	require.NotEmpty(t, diffs)
+	sections := make(map[string]struct{})
+	for _, row := range diffs {
+		sections[row[0].(string)] = struct{}{}
+	}
+	require.Contains(t, sections, "final_plan")
+	err := tk.QueryToErr("admin compare optimizer trace 'not_exist' 'not_exist'")
+	require.EqualError(t, err, "optimizer trace of plan digest 'not_exist' is not found")
+}
@@ -0,0 +1,238 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/extension"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/planner"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/privilege/privileges"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/memory"
+)
+
+// WithAfterTrigger indicates the executor has AFTER triggers to be executed when the statement finishes.
+type WithAfterTrigger interface {
+	GetAfterTriggers() []*TriggerExec
+}
+
+// TriggerExec uses to execute the statements of an AFTER trigger for each row written by the statement.
+type TriggerExec struct {
+	b       *executorBuilder
+	dbName  model.CIStr
+	tbl     table.Table
+	trigger *model.TriggerInfo
+	// usedTables contains the tables written by the statements which invoke this trigger, the trigger can't
+	// modify them again.
+	usedTables map[int64]struct{}
+	// rows are the rows written by the statement, the statements of the trigger are executed for each of them.
+	rows [][]types.Datum
+	// memTracker tracks the memory of the buffered rows, it's attached to the memory tracker of the statement.
+	memTracker *memory.Tracker
+	// stmtCnt is the number of statements in the trigger body, it's -1 before the body is parsed.
+	stmtCnt int
+	// next is the position of the next statement to be executed, it's in the range [0, len(rows)*stmtCnt].
+	next int
+}
+
+func (b *executorBuilder) buildAfterTriggerExecs(tbl table.Table, event model.TriggerEvent) []*TriggerExec {
+	tblInfo := tbl.Meta()
+	var triggerExecs []*TriggerExec
+	for _, trigger := range tblInfo.Triggers {
+		if trigger.Timing != model.TriggerTimingAfter || trigger.Event != event {
+			continue
+		}
+		dbInfo, ok := infoschema.SchemaByTable(b.is, tblInfo)
+		if !ok {
+			continue
+		}
+		memTracker := memory.NewTracker(memory.LabelForAfterTriggerRows, -1)
+		memTracker.AttachTo(b.ctx.GetSessionVars().StmtCtx.MemTracker)
+		triggerExecs = append(triggerExecs, &TriggerExec{
+			b:          b,
+			dbName:     dbInfo.Name,
+			tbl:        tbl,
+			trigger:    trigger,
+			usedTables: map[int64]struct{}{tblInfo.ID: {}},
+			memTracker: memTracker,
+			stmtCnt:    -1,
+		})
+	}
+	return triggerExecs
+}
+
+func (tr *TriggerExec) onInsertRow(row []types.Datum) {
+	row = types.CloneRow(row)
+	tr.rows = append(tr.rows, row)
+	tr.memTracker.Consume(types.EstimatedMemUsage(row, 1))
+}
+
+// releaseRows releases the buffered rows after the statements of the trigger are executed for all of them.
+func (tr *TriggerExec) releaseRows() {
+	tr.rows = nil
+	tr.memTracker.Consume(-tr.memTracker.BytesConsumed())
+}
+
+// buildExecutor builds the executor of the next statement to be executed, it returns nil when all the
+// statements are executed for all the rows.
+func (tr *TriggerExec) buildExecutor(ctx context.Context) (exec.Executor, error) {
+	if len(tr.rows) == 0 || tr.stmtCnt == 0 || (tr.stmtCnt > 0 && tr.next >= len(tr.rows)*tr.stmtCnt) {
+		tr.releaseRows()
+		return nil, nil
+	}
+	// The body is parsed again for each statement to be executed, because the `NEW` references are replaced
+	// with the values of the row in place.
+	stmts, err := plannercore.ParseTriggerBody(tr.b.ctx.GetPlanCtx(), tr.trigger)
+	if err != nil {
+		return nil, err
+	}
+	if tr.stmtCnt < 0 {
+		tr.stmtCnt = len(stmts)
+		if tr.stmtCnt == 0 {
+			tr.releaseRows()
+			return nil, nil
+		}
+	}
+	row := tr.rows[tr.next/tr.stmtCnt]
+	newStmt, _ := stmts[tr.next%tr.stmtCnt].Accept(&triggerRowReplacer{dbName: tr.dbName, cols: tr.tbl.Cols(), row: row})
+	stmt := newStmt.(ast.StmtNode)
+	tr.next++
+
+	sctx := tr.b.ctx
+	if err := plannercore.Preprocess(ctx, sctx, stmt); err != nil {
+		return nil, err
+	}
+	p, err := planner.OptimizeForTrigger(ctx, sctx.GetPlanCtx(), stmt, tr.b.is)
+	if err != nil {
+		return nil, err
+	}
+	if err := tr.checkUsedTables(p); err != nil {
+		return nil, err
+	}
+	e := tr.b.build(p)
+	if tr.b.err != nil {
+		return nil, tr.b.err
+	}
+	if w, ok := e.(WithAfterTrigger); ok {
+		for _, child := range w.GetAfterTriggers() {
+			for id := range tr.usedTables {
+				child.usedTables[id] = struct{}{}
+			}
+		}
+	}
+	return e, nil
+}
+
+// switchToDefiner switches the session to the definer of the trigger. Like MySQL, the statements of the trigger
+// are checked and executed with the privileges of the definer. The returned function switches it back.
+func (tr *TriggerExec) switchToDefiner() (func(), error) {
+	sctx := tr.b.ctx
+	pm := privilege.GetPrivilegeManager(sctx)
+	if pm == nil || tr.trigger.Definer == "" {
+		return func() {}, nil
+	}
+	definer := &auth.UserIdentity{Username: tr.trigger.Definer}
+	if idx := strings.LastIndex(tr.trigger.Definer, "@"); idx >= 0 {
+		definer.Username, definer.Hostname = tr.trigger.Definer[:idx], tr.trigger.Definer[idx+1:]
+	}
+	extensions, err := extension.GetExtensions()
+	if err != nil {
+		return nil, err
+	}
+	// the privilege manager keeps the authenticated user, so a new one is bound for the definer.
+	definerPM := privileges.NewUserPrivileges(domain.GetDomain(sctx).PrivilegeHandle(), extensions)
+	if !definerPM.GetAuthWithoutVerification(definer.Username, definer.Hostname) {
+		return nil, exeerrors.ErrNoSuchUser.GenWithStackByArgs(definer.Username, definer.Hostname)
+	}
+	definer.AuthUsername, definer.AuthHostname = definer.Username, definer.Hostname
+	sessVars := sctx.GetSessionVars()
+	user, roles := sessVars.User, sessVars.ActiveRoles
+	privilege.BindPrivilegeManager(sctx, definerPM)
+	sessVars.User, sessVars.ActiveRoles = definer, definerPM.GetDefaultRoles(definer.Username, definer.Hostname)
+	return func() {
+		privilege.BindPrivilegeManager(sctx, pm)
+		sessVars.User, sessVars.ActiveRoles = user, roles
+	}, nil
+}
+
+// checkUsedTables returns an error if the statement of the trigger modifies a table which is written by the
+// statements invoking the trigger.
+func (tr *TriggerExec) checkUsedTables(p plannercore.Plan) error {
+	var modified []int64
+	switch x := p.(type) {
+	case *plannercore.Insert:
+		modified = append(modified, x.Table.Meta().ID)
+	case *plannercore.Update:
+		for _, info := range x.TblColPosInfos {
+			modified = append(modified, info.TblID)
+		}
+	case *plannercore.Delete:
+		for _, info := range x.TblColPosInfos {
+			modified = append(modified, info.TblID)
+		}
+	}
+	for _, id := range modified {
+		if _, ok := tr.usedTables[id]; ok {
+			tblName := tr.tbl.Meta().Name.O
+			if tbl, ok := tr.b.is.TableByID(id); ok {
+				tblName = tbl.Meta().Name.O
+			}
+			return exeerrors.ErrCantUpdateUsedTableInSfOrTrg.GenWithStackByArgs(tblName)
+		}
+	}
+	return nil
+}
+
+// triggerRowReplacer replaces the references of the `NEW` row with the values of the row, and qualifies the
+// table names with the schema of the trigger.
+type triggerRowReplacer struct {
+	dbName model.CIStr
+	cols   []*table.Column
+	row    []types.Datum
+}
+
+// Enter implements ast.Visitor interface.
+func (r *triggerRowReplacer) Enter(in ast.Node) (ast.Node, bool) {
+	if tn, ok := in.(*ast.TableName); ok && tn.Schema.L == "" {
+		tn.Schema = r.dbName
+	}
+	return in, false
+}
+
+// Leave implements ast.Visitor interface.
+func (r *triggerRowReplacer) Leave(in ast.Node) (ast.Node, bool) {
+	col, ok := in.(*ast.ColumnNameExpr)
+	if !ok || col.Name.Schema.L != "" || col.Name.Table.L != plannercore.TriggerNewRow.L {
+		return in, true
+	}
+	c := table.FindColLowerCase(r.cols, col.Name.Name.L)
+	if c == nil {
+		return in, true
+	}
+	d := r.row[c.Offset]
+	return ast.NewValueExpr(d.GetValue(), c.GetCharset(), c.GetCollate()), true
+}
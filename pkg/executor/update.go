@@ -323,6 +323,9 @@ func (e *UpdateExec) updateRows(ctx context.Context) (int, error) {
 			}
 		}
 		totalNumRows += chk.NumRows()
+		if err := flushPipelinedMemDB(e.Ctx()); err != nil {
+			return 0, err
+		}
 		chk = chunk.Renew(chk, e.MaxChunkSize())
 	}
 	return totalNumRows, nil
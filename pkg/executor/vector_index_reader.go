@@ -0,0 +1,276 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/hnsw"
+	"github.com/pingcap/tidb/pkg/util/rowcodec"
+)
+
+// vectorIndexGraphs caches the HNSW graphs of the vector indexes, which are built in memory
+// from the rows of the tables when the vector indexes are read.
+var vectorIndexGraphs sync.Map // vectorIndexGraphKey -> *vectorIndexGraph
+
+type vectorIndexGraphKey struct {
+	tableID int64
+	indexID int64
+}
+
+// vectorIndexNodeKey identifies a node of the graph by the row and the vector of the row.
+type vectorIndexNodeKey struct {
+	handle string
+	vec    string
+}
+
+// vectorIndexGraph is the HNSW graph of a vector index. The nodes of the rows which are updated
+// or deleted are not removed from the graph, they are filtered out by the rows in the snapshot.
+type vectorIndexGraph struct {
+	mu      sync.Mutex
+	metric  model.DistanceMetric
+	graph   *hnsw.Graph
+	handles []kv.Handle
+	keys    []vectorIndexNodeKey
+	nodes   map[vectorIndexNodeKey]int
+}
+
+func newVectorIndexGraph(metric model.DistanceMetric) *vectorIndexGraph {
+	distance := func(a, b types.VectorFloat32) float64 {
+		d, _ := a.L2Distance(b)
+		return d
+	}
+	if metric == model.DistanceMetricCosine {
+		distance = func(a, b types.VectorFloat32) float64 {
+			d, _ := a.CosineDistance(b)
+			return d
+		}
+	}
+	return &vectorIndexGraph{
+		metric: metric,
+		graph:  hnsw.NewGraph(distance),
+		nodes:  make(map[vectorIndexNodeKey]int),
+	}
+}
+
+func getVectorIndexGraph(tblInfo *model.TableInfo, idxInfo *model.IndexInfo) *vectorIndexGraph {
+	key := vectorIndexGraphKey{tableID: tblInfo.ID, indexID: idxInfo.ID}
+	g, _ := vectorIndexGraphs.LoadOrStore(key, newVectorIndexGraph(idxInfo.VectorInfo.DistanceMetric))
+	return g.(*vectorIndexGraph)
+}
+
+// vectorIndexRow is a row read from the snapshot with the vector in the text format.
+type vectorIndexRow struct {
+	handle kv.Handle
+	vec    string
+}
+
+// sync adds the rows which are not in the graph yet. The graph is rebuilt if most of the nodes
+// are outdated. The caller must hold the lock.
+func (g *vectorIndexGraph) sync(rows []vectorIndexRow) error {
+	if g.graph.Len() > 2*len(rows)+1024 {
+		fresh := newVectorIndexGraph(g.metric)
+		g.graph, g.handles, g.keys, g.nodes = fresh.graph, nil, nil, fresh.nodes
+	}
+	for _, row := range rows {
+		key := vectorIndexNodeKey{handle: string(row.handle.Encoded()), vec: row.vec}
+		if _, ok := g.nodes[key]; ok {
+			continue
+		}
+		vec, err := types.ParseVectorFloat32(row.vec)
+		if err != nil {
+			return err
+		}
+		// The cosine distance of a zero vector is NULL, which is never the nearest one.
+		if g.metric == model.DistanceMetricCosine && vec.L2Norm() == 0 {
+			continue
+		}
+		g.nodes[key] = g.graph.Add(vec)
+		g.handles = append(g.handles, row.handle)
+		g.keys = append(g.keys, key)
+	}
+	return nil
+}
+
+// search returns the handles of the k nearest rows in the snapshot. live is the vectors of
+// the rows in the snapshot. The caller must hold the lock.
+func (g *vectorIndexGraph) search(query types.VectorFloat32, k int, live map[string]string) []kv.Handle {
+	for n := k; ; n *= 2 {
+		neighbors := g.graph.Search(query, n, max(hnsw.DefaultEfSearch, n))
+		handles := make([]kv.Handle, 0, k)
+		for _, neighbor := range neighbors {
+			key := g.keys[neighbor.ID]
+			if vec, ok := live[key.handle]; ok && vec == key.vec {
+				handles = append(handles, g.handles[neighbor.ID])
+				if len(handles) == k {
+					break
+				}
+			}
+		}
+		if len(handles) == k || len(neighbors) < n {
+			return handles
+		}
+	}
+}
+
+// VectorIndexReaderExec reads the k nearest rows of the query vector by the vector index.
+type VectorIndexReaderExec struct {
+	exec.BaseExecutor
+
+	tblInfo    *model.TableInfo
+	idxInfo    *model.IndexInfo
+	query      *expression.Constant
+	k          int
+	snapshot   kv.Snapshot
+	rowDecoder *rowcodec.ChunkDecoder
+
+	inited  bool
+	handles []kv.Handle
+	values  [][]byte
+	index   int
+}
+
+// Open implements the Executor interface.
+func (e *VectorIndexReaderExec) Open(context.Context) error {
+	e.inited = false
+	e.handles, e.values, e.index = nil, nil, 0
+	return nil
+}
+
+// Next implements the Executor interface.
+func (e *VectorIndexReaderExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if !e.inited {
+		if err := e.initialize(ctx); err != nil {
+			return err
+		}
+		e.inited = true
+	}
+	for !req.IsFull() && e.index < len(e.values) {
+		err := DecodeRowValToChunk(e.Ctx(), e.Schema(), e.tblInfo, e.handles[e.index], e.values[e.index], req, e.rowDecoder)
+		if err != nil {
+			return err
+		}
+		e.index++
+	}
+	return nil
+}
+
+func (e *VectorIndexReaderExec) initialize(ctx context.Context) error {
+	rows, nullHandles, err := e.scanVectors(ctx)
+	if err != nil {
+		return err
+	}
+	d, err := e.query.Eval(e.Ctx().GetExprCtx(), chunk.Row{})
+	if err != nil {
+		return err
+	}
+	var query types.VectorFloat32
+	if !d.IsNull() {
+		if query, err = types.ParseVectorFloat32(d.GetString()); err != nil {
+			return err
+		}
+		if err = query.CheckDimsFitColumn(int(e.idxInfo.VectorInfo.Dimension)); err != nil {
+			return err
+		}
+	}
+
+	// The distances of the NULL vectors are NULL, which are the smallest ones.
+	handles := nullHandles[:min(len(nullHandles), e.k)]
+	k := e.k - len(handles)
+	if k == 0 {
+		return e.readRows(ctx, handles)
+	}
+	if d.IsNull() || (e.idxInfo.VectorInfo.DistanceMetric == model.DistanceMetricCosine && query.L2Norm() == 0) {
+		// All the distances are NULL, any k rows are the nearest ones.
+		for i := 0; i < len(rows) && i < k; i++ {
+			handles = append(handles, rows[i].handle)
+		}
+	} else {
+		live := make(map[string]string, len(rows))
+		for _, row := range rows {
+			live[string(row.handle.Encoded())] = row.vec
+		}
+		g := getVectorIndexGraph(e.tblInfo, e.idxInfo)
+		g.mu.Lock()
+		if err = g.sync(rows); err == nil {
+			handles = append(handles, g.search(query, k, live)...)
+		}
+		g.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return e.readRows(ctx, handles)
+}
+
+// scanVectors reads the vectors of the rows in the snapshot, the handles of the rows whose
+// vectors are NULL are returned separately.
+func (e *VectorIndexReaderExec) scanVectors(ctx context.Context) (rows []vectorIndexRow, nullHandles []kv.Handle, err error) {
+	col := e.tblInfo.Columns[e.idxInfo.Columns[0].Offset]
+	colTps := map[int64]*types.FieldType{col.ID: &col.FieldType}
+	prefix := tablecodec.GenTableRecordPrefix(e.tblInfo.ID)
+	iter, err := e.snapshot.Iter(prefix, prefix.PrefixNext())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer iter.Close()
+	for ; err == nil && iter.Valid(); err = iter.Next() {
+		if err = ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		handle, err := tablecodec.DecodeRowKey(iter.Key())
+		if err != nil {
+			return nil, nil, err
+		}
+		row, err := tablecodec.DecodeRowToDatumMap(iter.Value(), colTps, e.Ctx().GetSessionVars().Location())
+		if err != nil {
+			return nil, nil, err
+		}
+		if d, ok := row[col.ID]; ok && !d.IsNull() {
+			rows = append(rows, vectorIndexRow{handle: handle, vec: d.GetString()})
+		} else {
+			nullHandles = append(nullHandles, handle)
+		}
+	}
+	return rows, nullHandles, errors.Trace(err)
+}
+
+func (e *VectorIndexReaderExec) readRows(ctx context.Context, handles []kv.Handle) error {
+	keys := make([]kv.Key, 0, len(handles))
+	for _, h := range handles {
+		keys = append(keys, tablecodec.EncodeRowKeyWithHandle(e.tblInfo.ID, h))
+	}
+	values, err := e.snapshot.BatchGet(ctx, keys)
+	if err != nil {
+		return err
+	}
+	for i, key := range keys {
+		if val, ok := values[string(key)]; ok && len(val) > 0 {
+			e.handles = append(e.handles, handles[i])
+			e.values = append(e.values, val)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectorType(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create table t (id int primary key, v vector(3), w vector)")
+	tk.MustExec("insert into t values (1, '[1,2,3]', '[1]'), (2, ' [ 1.5 , -2, 0 ] ', '[1,2,3,4]'), (3, NULL, NULL)")
+	tk.MustQuery("select id, v, w from t order by id").Check(testkit.Rows("1 [1,2,3] [1]", "2 [1.5,-2,0] [1,2,3,4]", "3 <nil> <nil>"))
+	tk.MustQuery("select vec_dims(v), vec_l2_norm(v), vec_as_text(w) from t where id = 1").Check(testkit.Rows("3 3.7416573867739413 [1]"))
+	tk.MustQuery("select vec_l2_distance(v, '[1,2,4]'), vec_cosine_distance(v, '[2,4,6]') from t where id = 1").Check(testkit.Rows("1 0"))
+	tk.MustQuery("select vec_cosine_distance('[0,0]', '[1,2]'), vec_from_text('[1.0, 2]')").Check(testkit.Rows("<nil> [1,2]"))
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `v` vector(3) DEFAULT NULL,\n" +
+		"  `w` vector DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	tk.MustGetErrCode("insert into t values (4, '[1,2]', NULL)", errno.ErrVectorDimensionMismatch)
+	tk.MustGetErrCode("insert into t values (4, '[1,2,a]', NULL)", errno.ErrInvalidVectorValue)
+	tk.MustGetErrCode("insert into t values (4, 123, NULL)", errno.ErrInvalidVectorValue)
+	err := tk.QueryToErr("select vec_l2_distance(v, w) from t where id = 1")
+	require.True(t, types.ErrVectorDimensionMismatch.Equal(err))
+	tk.MustGetErrCode("create table t2 (v vector(0))", errno.ErrWrongFieldSpec)
+	tk.MustGetErrCode("create table t2 (v vector(16384))", errno.ErrWrongFieldSpec)
+	tk.MustGetErrCode("create table t2 (v vector(3) default '[1,2,3]')", errno.ErrBlobCantHaveDefault)
+	tk.MustGetErrCode("create table t2 (v vector(3), key (v))", errno.ErrUnsupportedDDLOperation)
+}
+
+func TestVectorIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create table t (id int primary key, v vector(2), vector index idx_cos ((vec_cosine_distance(v))))")
+	values := make([]string, 0, 100)
+	for i := 1; i <= 100; i++ {
+		values = append(values, fmt.Sprintf("(%d, '[%d,%d]')", i, i, 101-i))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+	// the vector index has no entries.
+	require.Equal(t, 0, countIndexKeys(t, store, tk, "t", "idx_cos"))
+	tk.MustExec("admin check table t")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `v` vector(2) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  VECTOR INDEX `idx_cos` ((VEC_COSINE_DISTANCE(`v`))) USING HNSW\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("show index from t where key_name = 'idx_cos'").CheckAt([]int{2, 4, 10}, testkit.Rows("idx_cos v HNSW"))
+
+	query := "select id from t order by vec_cosine_distance(v, '[1,2]') limit 3"
+	tk.MustHavePlan(query, "VectorIndexReader")
+	tk.MustQuery(query).Check(testkit.Rows("34", "33", "35"))
+	tk.MustHavePlan("select * from t order by vec_cosine_distance('[1,2]', v) limit 1, 2", "VectorIndexReader")
+	tk.MustQuery("select * from t order by vec_cosine_distance('[1,2]', v) limit 1, 2").Check(testkit.Rows("33 [33,68]", "35 [35,66]"))
+	// the vector index is not used for other metrics, filters or orders.
+	tk.MustNotHavePlan("select id from t order by vec_l2_distance(v, '[1,2]') limit 3", "VectorIndexReader")
+	tk.MustNotHavePlan("select id from t where id > 50 order by vec_cosine_distance(v, '[1,2]') limit 3", "VectorIndexReader")
+	tk.MustNotHavePlan("select id from t order by vec_cosine_distance(v, '[1,2]') desc limit 3", "VectorIndexReader")
+	err := tk.QueryToErr("select id from t order by vec_cosine_distance(v, '[1,2,3]') limit 3")
+	require.True(t, types.ErrVectorDimensionMismatch.Equal(err))
+
+	// the changes of the rows are visible to the vector search.
+	tk.MustExec("delete from t where id = 34")
+	tk.MustExec("update t set v = '[1,2]' where id = 1")
+	tk.MustExec("insert into t values (101, NULL)")
+	tk.MustQuery(query).Check(testkit.Rows("101", "1", "33"))
+	tk.MustQuery("select id from t order by vec_cosine_distance(v, '[0,0]') limit 1").Check(testkit.Rows("101"))
+
+	// add the vector index by ALTER TABLE and CREATE INDEX.
+	tk.MustExec("alter table t add vector index idx_l2 ((vec_l2_distance(v)))")
+	tk.MustExec("create vector index idx_l2_2 on t ((vec_l2_distance(v))) using hnsw")
+	tk.MustQuery("select id from t order by vec_l2_distance(v, '[50,50]') limit 3").Check(testkit.Rows("101", "50", "51"))
+	tk.MustHavePlan("select id from t order by vec_l2_distance(v, '[50,50]') limit 3", "VectorIndexReader")
+	tk.MustExec("drop index idx_l2 on t")
+	tk.MustExec("drop index idx_l2_2 on t")
+	tk.MustNotHavePlan("select id from t order by vec_l2_distance(v, '[50,50]') limit 3", "VectorIndexReader")
+
+	// invalid definitions.
+	tk.MustGetErrCode("alter table t add vector index ((vec_l2_norm(v)))", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add vector index ((vec_l2_distance(id)))", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add vector index (v)", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add vector index ((vec_l2_distance(v))) using btree", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("create table t2 (v vector)")
+	tk.MustGetErrCode("alter table t2 add vector index ((vec_l2_distance(v)))", errno.ErrUnsupportedDDLOperation)
+}
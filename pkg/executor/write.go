@@ -317,6 +317,22 @@ func resetErrDataTooLong(colName string, rowIdx int, _ error) error {
 	return newErr
 }
 
+// flushPipelinedMemDB flushes the mutations of a pipelined transaction in the background once they reach the flush
+// threshold. It's called between the batches of rows, so the record key and the index keys of a row are always
+// flushed in the same generation, and each generation is written in key order region by region instead of locking
+// the same regions again and again for every few rows.
+func flushPipelinedMemDB(sctx sessionctx.Context) error {
+	txn, err := sctx.Txn(false)
+	if err != nil {
+		return err
+	}
+	if !txn.Valid() || !txn.IsPipelined() {
+		return nil
+	}
+	_, err = txn.GetMemBuffer().Flush(false)
+	return err
+}
+
 // checkRowForExchangePartition is only used for ExchangePartition by non-partitionTable during write only state.
 // It check if rowData inserted or updated violate partition definition or checkConstraints of partitionTable.
 func checkRowForExchangePartition(sctx table.MutateContext, row []types.Datum, tbl *model.TableInfo) error {
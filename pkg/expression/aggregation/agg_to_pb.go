@@ -208,6 +208,10 @@ func PBExprToAggFuncDesc(ctx expression.BuildContext, aggFunc *tipb.Expr, fieldT
 		name = ast.AggFuncBitXor
 	case tipb.ExprType_Agg_BitAnd:
 		name = ast.AggFuncBitAnd
+	case tipb.ExprType_JsonArrayAgg:
+		name = ast.AggFuncJsonArrayagg
+	case tipb.ExprType_JsonObjectAgg:
+		name = ast.AggFuncJsonObjectAgg
 	default:
 		return nil, errors.Errorf("unknown aggregation function type: %v", aggFunc.Tp)
 	}
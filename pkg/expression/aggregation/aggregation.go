@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/ast"
@@ -26,6 +27,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/collate"
 	"github.com/pingcap/tipb/go-tipb"
 )
@@ -73,9 +75,26 @@ func NewDistAggFunc(expr *tipb.Expr, fieldTps []*types.FieldType, ctx expression
 		aggF.Mode = AggFunctionMode(*expr.AggFuncMode)
 		return &avgFunction{aggFunction: aggF}, aggF.AggFuncDesc, nil
 	case tipb.ExprType_GroupConcat:
-		aggF := newAggFunc(ast.AggFuncGroupConcat, args, false)
+		aggF := newAggFunc(ast.AggFuncGroupConcat, args, expr.HasDistinct)
 		aggF.Mode = AggFunctionMode(*expr.AggFuncMode)
-		return &concatFunction{aggFunction: aggF}, aggF.AggFuncDesc, nil
+		// the collation of the result is required to build the result datum.
+		aggF.RetTp = expression.FieldTypeFromPB(expr.FieldType)
+		var maxLen uint64
+		if len(expr.Val) > 0 {
+			var err error
+			if _, maxLen, err = codec.DecodeUint(expr.Val); err != nil {
+				return nil, nil, err
+			}
+		}
+		return &concatFunction{aggFunction: aggF, maxLen: maxLen}, aggF.AggFuncDesc, nil
+	case tipb.ExprType_JsonArrayAgg:
+		aggF := newAggFunc(ast.AggFuncJsonArrayagg, args, false)
+		aggF.Mode = AggFunctionMode(*expr.AggFuncMode)
+		return &jsonArrayAggFunction{aggFunction: aggF}, aggF.AggFuncDesc, nil
+	case tipb.ExprType_JsonObjectAgg:
+		aggF := newAggFunc(ast.AggFuncJsonObjectAgg, args, false)
+		aggF.Mode = AggFunctionMode(*expr.AggFuncMode)
+		return &jsonObjectAggFunction{aggFunction: aggF}, aggF.AggFuncDesc, nil
 	case tipb.ExprType_Max:
 		aggF := newAggFunc(ast.AggFuncMax, args, false)
 		aggF.Mode = AggFunctionMode(*expr.AggFuncMode)
@@ -112,6 +131,10 @@ type AggEvaluateContext struct {
 	Value           types.Datum
 	Buffer          *bytes.Buffer // Buffer is used for group_concat.
 	GotFirstRow     bool          // It will check if the agg has met the first row key.
+	// JSONArrayEntries is used for json_arrayagg.
+	JSONArrayEntries []any
+	// JSONObjectEntries is used for json_objectagg.
+	JSONObjectEntries map[string]any
 }
 
 // AggFunctionMode stands for the aggregation function's mode.
@@ -197,7 +220,8 @@ func NeedCount(name string) bool {
 func NeedValue(name string) bool {
 	switch name {
 	case ast.AggFuncSum, ast.AggFuncAvg, ast.AggFuncFirstRow, ast.AggFuncMax, ast.AggFuncMin,
-		ast.AggFuncGroupConcat, ast.AggFuncBitOr, ast.AggFuncBitAnd, ast.AggFuncBitXor, ast.AggFuncApproxPercentile:
+		ast.AggFuncGroupConcat, ast.AggFuncBitOr, ast.AggFuncBitAnd, ast.AggFuncBitXor, ast.AggFuncApproxPercentile,
+		ast.AggFuncJsonArrayagg, ast.AggFuncJsonObjectAgg:
 		return true
 	default:
 		return false
@@ -227,8 +251,7 @@ func CheckAggPushDown(aggFunc *AggFuncDesc, storeType kv.StoreType) bool {
 	case kv.TiFlash:
 		ret = CheckAggPushFlash(aggFunc)
 	case kv.TiKV:
-		// TiKV does not support group_concat now
-		ret = aggFunc.Name != ast.AggFuncGroupConcat
+		ret = CheckAggPushTiKV(aggFunc)
 	}
 	if ret {
 		ret = expression.IsPushDownEnabled(strings.ToLower(aggFunc.Name), storeType)
@@ -236,6 +259,44 @@ func CheckAggPushDown(aggFunc *AggFuncDesc, storeType kv.StoreType) bool {
 	return ret
 }
 
+// IsConcatAgg checks whether the agg function concatenates the values of a group, whose partial result is
+// as large as the values, e.g. GROUP_CONCAT, JSON_ARRAYAGG and JSON_OBJECTAGG.
+func IsConcatAgg(name string) bool {
+	switch name {
+	case ast.AggFuncGroupConcat, ast.AggFuncJsonArrayagg, ast.AggFuncJsonObjectAgg:
+		return true
+	}
+	return false
+}
+
+// CheckAggPushTiKV checks whether an agg function can be pushed to TiKV.
+func CheckAggPushTiKV(aggFunc *AggFuncDesc) bool {
+	if !IsConcatAgg(aggFunc.Name) {
+		return true
+	}
+	// Only the coprocessor of unistore supports GROUP_CONCAT, JSON_ARRAYAGG and JSON_OBJECTAGG for now, the TiKV
+	// coprocessor would reject the request.
+	if config.GetGlobalConfig().Store != "unistore" {
+		return false
+	}
+	if aggFunc.Name != ast.AggFuncGroupConcat {
+		return true
+	}
+	// The coprocessor formats the values to concat by itself, only the strings and the integers
+	// are formatted in the same way as TiDB. The last argument is the separator.
+	for _, arg := range aggFunc.Args[:len(aggFunc.Args)-1] {
+		switch tp := arg.GetType(); tp.EvalType() {
+		case types.ETString, types.ETInt:
+			if tp.GetType() == mysql.TypeBit || tp.GetType() == mysql.TypeEnum || tp.GetType() == mysql.TypeSet {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // CheckAggPushFlash checks whether an agg function can be pushed to flash storage.
 func CheckAggPushFlash(aggFunc *AggFuncDesc) bool {
 	for _, arg := range aggFunc.Args {
@@ -252,6 +252,10 @@ func (a *AggFuncDesc) GetAggFunc(ctx expression.BuildContext) Aggregation {
 		return &bitXorFunction{aggFunction: aggFunc}
 	case ast.AggFuncBitAnd:
 		return &bitAndFunction{aggFunction: aggFunc}
+	case ast.AggFuncJsonArrayagg:
+		return &jsonArrayAggFunction{aggFunction: aggFunc}
+	case ast.AggFuncJsonObjectAgg:
+		return &jsonObjectAggFunction{aggFunction: aggFunc}
 	default:
 		panic("unsupported agg function")
 	}
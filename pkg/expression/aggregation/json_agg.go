@@ -0,0 +1,217 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+type jsonArrayAggFunction struct {
+	aggFunction
+}
+
+// Update implements Aggregation interface.
+func (jf *jsonArrayAggFunction) Update(evalCtx *AggEvaluateContext, _ *stmtctx.StatementContext, row chunk.Row) error {
+	value, err := jf.Args[0].Eval(evalCtx.Ctx, row)
+	if err != nil {
+		return err
+	}
+	if evalCtx.JSONArrayEntries == nil {
+		evalCtx.JSONArrayEntries = make([]any, 0, 1)
+	}
+	if jf.Mode == FinalMode || jf.Mode == Partial2Mode {
+		// merge the arrays produced by the partial aggregation.
+		if value.IsNull() {
+			return nil
+		}
+		partial := value.GetMysqlJSON()
+		if partial.TypeCode != types.JSONTypeCodeArray {
+			return errors.Errorf("the partial result of json_arrayagg should be an array, but got %s", partial.Type())
+		}
+		for i := 0; i < partial.GetElemCount(); i++ {
+			evalCtx.JSONArrayEntries = append(evalCtx.JSONArrayEntries, partial.ArrayGetElem(i).Copy())
+		}
+		return nil
+	}
+	realVal, err := GetRealJSONValue(value, jf.Args[0].GetType())
+	if err != nil {
+		return err
+	}
+	if err = checkJSONValue(realVal); err != nil {
+		return err
+	}
+	evalCtx.JSONArrayEntries = append(evalCtx.JSONArrayEntries, realVal)
+	return nil
+}
+
+// GetResult implements Aggregation interface.
+func (*jsonArrayAggFunction) GetResult(evalCtx *AggEvaluateContext) (d types.Datum) {
+	if len(evalCtx.JSONArrayEntries) == 0 {
+		d.SetNull()
+		return d
+	}
+	// the entries have been checked in Update, so it won't panic.
+	d.SetMysqlJSON(types.CreateBinaryJSON(evalCtx.JSONArrayEntries))
+	return d
+}
+
+func (*jsonArrayAggFunction) ResetContext(ctx expression.EvalContext, evalCtx *AggEvaluateContext) {
+	evalCtx.Ctx = ctx
+	evalCtx.JSONArrayEntries = nil
+}
+
+// GetPartialResult implements Aggregation interface.
+func (jf *jsonArrayAggFunction) GetPartialResult(evalCtx *AggEvaluateContext) []types.Datum {
+	return []types.Datum{jf.GetResult(evalCtx)}
+}
+
+type jsonObjectAggFunction struct {
+	aggFunction
+}
+
+// Update implements Aggregation interface.
+func (jf *jsonObjectAggFunction) Update(evalCtx *AggEvaluateContext, _ *stmtctx.StatementContext, row chunk.Row) error {
+	if evalCtx.JSONObjectEntries == nil {
+		evalCtx.JSONObjectEntries = make(map[string]any)
+	}
+	if jf.Mode == FinalMode || jf.Mode == Partial2Mode {
+		// merge the objects produced by the partial aggregation.
+		value, err := jf.Args[0].Eval(evalCtx.Ctx, row)
+		if err != nil || value.IsNull() {
+			return err
+		}
+		partial := value.GetMysqlJSON()
+		if partial.TypeCode != types.JSONTypeCodeObject {
+			return errors.Errorf("the partial result of json_objectagg should be an object, but got %s", partial.Type())
+		}
+		for i := 0; i < partial.GetElemCount(); i++ {
+			evalCtx.JSONObjectEntries[string(partial.ObjectGetKey(i))] = partial.ObjectGetVal(i).Copy()
+		}
+		return nil
+	}
+	key, err := jf.Args[0].Eval(evalCtx.Ctx, row)
+	if err != nil {
+		return err
+	}
+	if key.IsNull() {
+		return types.ErrJSONDocumentNULLKey
+	}
+	if jf.Args[0].GetType().GetCharset() == charset.CharsetBin {
+		return types.ErrInvalidJSONCharset.GenWithStackByArgs(jf.Args[0].GetType().GetCharset())
+	}
+	keyStr, err := key.ToString()
+	if err != nil {
+		return err
+	}
+	value, err := jf.Args[1].Eval(evalCtx.Ctx, row)
+	if err != nil {
+		return err
+	}
+	realVal, err := GetRealJSONValue(value, jf.Args[1].GetType())
+	if err != nil {
+		return err
+	}
+	if err = checkJSONValue(realVal); err != nil {
+		return err
+	}
+	evalCtx.JSONObjectEntries[strings.Clone(keyStr)] = realVal
+	return nil
+}
+
+// GetResult implements Aggregation interface.
+func (*jsonObjectAggFunction) GetResult(evalCtx *AggEvaluateContext) (d types.Datum) {
+	if len(evalCtx.JSONObjectEntries) == 0 {
+		d.SetNull()
+		return d
+	}
+	// the entries have been checked in Update, so it won't panic.
+	d.SetMysqlJSON(types.CreateBinaryJSON(evalCtx.JSONObjectEntries))
+	return d
+}
+
+func (*jsonObjectAggFunction) ResetContext(ctx expression.EvalContext, evalCtx *AggEvaluateContext) {
+	evalCtx.Ctx = ctx
+	evalCtx.JSONObjectEntries = nil
+}
+
+// GetPartialResult implements Aggregation interface.
+func (jf *jsonObjectAggFunction) GetPartialResult(evalCtx *AggEvaluateContext) []types.Datum {
+	return []types.Datum{jf.GetResult(evalCtx)}
+}
+
+// checkJSONValue checks whether the value can be an element of a JSON array or object.
+func checkJSONValue(val any) error {
+	switch x := val.(type) {
+	case nil, bool, int64, uint64, float64, string, types.BinaryJSON, types.Opaque, types.Time, types.Duration:
+		return nil
+	default:
+		return types.ErrUnsupportedSecondArgumentType.GenWithStackByArgs(x)
+	}
+}
+
+// GetRealJSONValue converts the datum to the value which is used to build the result of json_arrayagg and json_objectagg.
+func GetRealJSONValue(value types.Datum, ft *types.FieldType) (any, error) {
+	realVal := value.Clone().GetValue()
+	switch value.Kind() {
+	case types.KindBinaryLiteral, types.KindMysqlBit, types.KindBytes:
+		buf := value.GetBytes()
+		realVal = types.Opaque{
+			TypeCode: ft.GetType(),
+			Buf:      buf,
+		}
+	case types.KindString:
+		if ft.GetCharset() == charset.CharsetBin {
+			buf := value.GetBytes()
+			resultBuf := buf
+			if ft.GetType() == mysql.TypeString {
+				// the tailing zero should also be in the opaque json
+				resultBuf = make([]byte, ft.GetFlen())
+				copy(resultBuf, buf)
+			}
+			realVal = types.Opaque{
+				TypeCode: ft.GetType(),
+				Buf:      resultBuf,
+			}
+		}
+	}
+
+	// appendBinary does not support some type such as uint8、types.time，so convert is needed here
+	switch x := realVal.(type) {
+	case float32:
+		realVal = float64(x)
+	case *types.MyDecimal:
+		float64Val, err := x.ToFloat64()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		realVal = float64Val
+	case []uint8:
+		strVal, err := types.ToString(x)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		realVal = strVal
+	}
+
+	return realVal, nil
+}
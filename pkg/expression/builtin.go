@@ -809,6 +809,9 @@ var funcs = map[string]functionClass{
 	ast.TiDBRowChecksum: &tidbRowChecksumFunctionClass{baseFunctionClass{ast.TiDBRowChecksum, 0, 0}},
 	ast.Grouping:        &groupingImplFunctionClass{baseFunctionClass{ast.Grouping, 1, 1}},
 
+	// fulltext search functions
+	ast.MatchAgainstFunc: &matchAgainstFunctionClass{baseFunctionClass{ast.MatchAgainstFunc, 4, -1}},
+
 	ast.GetLock:     &lockFunctionClass{baseFunctionClass{ast.GetLock, 2, 2}},
 	ast.ReleaseLock: &releaseLockFunctionClass{baseFunctionClass{ast.ReleaseLock, 1, 1}},
 
@@ -874,6 +877,11 @@ var funcs = map[string]functionClass{
 	ast.UncompressedLength:       &uncompressedLengthFunctionClass{baseFunctionClass{ast.UncompressedLength, 1, 1}},
 	ast.ValidatePasswordStrength: &validatePasswordStrengthFunctionClass{baseFunctionClass{ast.ValidatePasswordStrength, 1, 1}},
 
+	// data masking functions
+	ast.MaskEmail:   &maskEmailFunctionClass{baseFunctionClass{ast.MaskEmail, 1, 1}},
+	ast.MaskHash:    &maskHashFunctionClass{baseFunctionClass{ast.MaskHash, 1, 1}},
+	ast.MaskPartial: &maskPartialFunctionClass{baseFunctionClass{ast.MaskPartial, 3, 4}},
+
 	// json functions
 	ast.JSONType:          &jsonTypeFunctionClass{baseFunctionClass{ast.JSONType, 1, 1}},
 	ast.JSONExtract:       &jsonExtractFunctionClass{baseFunctionClass{ast.JSONExtract, 2, -1}},
@@ -903,6 +911,18 @@ var funcs = map[string]functionClass{
 	ast.JSONKeys:          &jsonKeysFunctionClass{baseFunctionClass{ast.JSONKeys, 1, 2}},
 	ast.JSONLength:        &jsonLengthFunctionClass{baseFunctionClass{ast.JSONLength, 1, 2}},
 
+	// vector functions
+	ast.VecDims:   &vecDimsFunctionClass{baseFunctionClass{ast.VecDims, 1, 1}},
+	ast.VecL2Norm: &vecL2NormFunctionClass{baseFunctionClass{ast.VecL2Norm, 1, 1}},
+	ast.VecL2Distance: &vecDistanceFunctionClass{baseFunctionClass{ast.VecL2Distance, 2, 2}, func(bf baseBuiltinFunc) builtinFunc {
+		return &builtinVecL2DistanceSig{bf}
+	}},
+	ast.VecCosineDistance: &vecDistanceFunctionClass{baseFunctionClass{ast.VecCosineDistance, 2, 2}, func(bf baseBuiltinFunc) builtinFunc {
+		return &builtinVecCosineDistanceSig{bf}
+	}},
+	ast.VecFromText: &vecFromTextFunctionClass{baseFunctionClass{ast.VecFromText, 1, 1}},
+	ast.VecAsText:   &vecAsTextFunctionClass{baseFunctionClass{ast.VecAsText, 1, 1}},
+
 	// TiDB internal function.
 	ast.TiDBDecodeKey: &tidbDecodeKeyFunctionClass{baseFunctionClass{ast.TiDBDecodeKey, 1, 1}},
 	// This function is used to show tidb-server version info.
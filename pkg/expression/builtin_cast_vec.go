@@ -1099,23 +1099,20 @@ func (b *builtinCastIntAsDecimalSig) vecEvalDecimal(ctx EvalContext, input *chun
 	result.ResizeDecimal(n, false)
 	result.MergeNulls(buf)
 	decs := result.Decimals()
+	unsigned := isUnsignedTp || isUnsignedArgs0
+	types.FromInts(nums, unsigned, decs)
 	tc, ec := typeCtx(ctx), errCtx(ctx)
-	dec := new(types.MyDecimal)
 	for i := 0; i < n; i++ {
 		if result.IsNull(i) {
 			continue
 		}
 
-		*dec = types.MyDecimal{}
-		if !isUnsignedTp && !isUnsignedArgs0 {
-			dec.FromInt(nums[i])
-		} else if b.inUnion && !isUnsignedArgs0 && nums[i] < 0 {
-			dec.FromUint(0)
-		} else {
-			dec.FromUint(uint64(nums[i]))
+		if unsigned && b.inUnion && !isUnsignedArgs0 && nums[i] < 0 {
+			decs[i] = types.MyDecimal{}
+			decs[i].FromUint(0)
 		}
 
-		dec, err = types.ProduceDecWithSpecifiedTp(tc, dec, b.tp)
+		dec, err := types.ProduceDecWithSpecifiedTp(tc, &decs[i], b.tp)
 		if err = ec.HandleError(err); err != nil {
 			return err
 		}
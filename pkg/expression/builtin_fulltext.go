@@ -0,0 +1,110 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
+)
+
+var (
+	_ functionClass = &matchAgainstFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinMatchAgainstSig{}
+)
+
+const (
+	// MatchAgainstNaturalLanguageMode is the mode argument of MATCH ... AGAINST in natural language mode.
+	MatchAgainstNaturalLanguageMode = 0
+	// MatchAgainstBooleanMode is the mode argument of MATCH ... AGAINST in boolean mode.
+	MatchAgainstBooleanMode = 1
+)
+
+// matchAgainstFunctionClass builds MATCH_AGAINST(search_str, mode, parser, col1, col2, ...), which
+// is rewritten from MATCH (col1, col2, ...) AGAINST (search_str [mode]). The mode and the parser
+// of the fulltext index on the columns must be constants.
+type matchAgainstFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *matchAgainstFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	mode, ok := args[1].(*Constant)
+	if !ok || mode.Value.Kind() != types.KindInt64 {
+		return nil, errIncorrectArgs.GenWithStackByArgs(ast.MatchAgainstFunc)
+	}
+	parser, ok := args[2].(*Constant)
+	if !ok || !fulltext.IsValidParser(parser.Value.GetString()) {
+		return nil, errIncorrectArgs.GenWithStackByArgs(ast.MatchAgainstFunc)
+	}
+	argTps := make([]types.EvalType, 0, len(args))
+	argTps = append(argTps, types.ETString, types.ETInt, types.ETString)
+	for range args[3:] {
+		argTps = append(argTps, types.ETString)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinMatchAgainstSig{
+		baseBuiltinFunc: bf,
+		parser:          model.FullTextParserType(parser.Value.GetString()),
+		booleanMode:     mode.Value.GetInt64() == MatchAgainstBooleanMode,
+	}
+	return sig, nil
+}
+
+type builtinMatchAgainstSig struct {
+	baseBuiltinFunc
+
+	parser      model.FullTextParserType
+	booleanMode bool
+}
+
+func (b *builtinMatchAgainstSig) Clone() builtinFunc {
+	newSig := &builtinMatchAgainstSig{parser: b.parser, booleanMode: b.booleanMode}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals MATCH (col1, col2, ...) AGAINST (search_str [mode]).
+// The columns are tokenized with the parser of the fulltext index, and the relevance is the
+// number of the occurrences of the matched terms in the search string. It returns 0 if the row
+// is not matched, so it can be used as a filter directly.
+func (b *builtinMatchAgainstSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	search, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, false, err
+	}
+	var doc []string
+	for _, arg := range b.args[3:] {
+		text, isNull, err := arg.EvalString(ctx, row)
+		if err != nil {
+			return 0, false, err
+		}
+		if !isNull {
+			doc = append(doc, fulltext.Tokenize(b.parser, text)...)
+		}
+	}
+	query := fulltext.ParseQuery(b.parser, search, b.booleanMode)
+	return query.Relevance(doc), false, nil
+}
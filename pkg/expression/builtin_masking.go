@@ -0,0 +1,244 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+var (
+	_ functionClass = &maskPartialFunctionClass{}
+	_ functionClass = &maskEmailFunctionClass{}
+	_ functionClass = &maskHashFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinMaskPartialSig{}
+	_ builtinFunc = &builtinMaskEmailSig{}
+	_ builtinFunc = &builtinMaskHashSig{}
+)
+
+// defaultMaskChar is the character used to mask the values if it's not specified.
+const defaultMaskChar = 'X'
+
+type maskPartialFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *maskPartialFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt, types.ETInt}
+	if len(args) == 4 {
+		argTps = append(argTps, types.ETString)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	argType := args[0].GetType()
+	bf.tp.SetFlen(argType.GetFlen())
+	SetBinFlagOrBinStr(argType, bf.tp)
+	sig := &builtinMaskPartialSig{bf}
+	return sig, nil
+}
+
+type builtinMaskPartialSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinMaskPartialSig) Clone() builtinFunc {
+	newSig := &builtinMaskPartialSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals MASK_PARTIAL(str, prefix_len, suffix_len[, mask_char]).
+// It keeps the first prefix_len and the last suffix_len characters and masks the others.
+// The string is returned unchanged if there is nothing to mask.
+func (b *builtinMaskPartialSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	str, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	prefix, isNull, err := b.args[1].EvalInt(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	suffix, isNull, err := b.args[2].EvalInt(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	maskChar := defaultMaskChar
+	if len(b.args) == 4 {
+		maskStr, isNull, err := b.args[3].EvalString(ctx, row)
+		if isNull || err != nil {
+			return "", isNull, err
+		}
+		if maskStr != "" {
+			maskChar, _ = utf8.DecodeRuneInString(maskStr)
+		}
+	}
+	prefix, suffix = max(prefix, 0), max(suffix, 0)
+	runes := []rune(str)
+	if prefix+suffix >= int64(len(runes)) {
+		return str, false, nil
+	}
+	for i := prefix; i < int64(len(runes))-suffix; i++ {
+		runes[i] = maskChar
+	}
+	return string(runes), false, nil
+}
+
+type maskEmailFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *maskEmailFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	argType := args[0].GetType()
+	bf.tp.SetFlen(argType.GetFlen())
+	SetBinFlagOrBinStr(argType, bf.tp)
+	sig := &builtinMaskEmailSig{bf}
+	return sig, nil
+}
+
+type builtinMaskEmailSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinMaskEmailSig) Clone() builtinFunc {
+	newSig := &builtinMaskEmailSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals MASK_EMAIL(str).
+// It keeps the first character of the local part and the domain, e.g. 'jXXX@example.com'.
+// The whole string is masked if it's not an email address.
+func (b *builtinMaskEmailSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	str, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	idx := strings.LastIndexByte(str, '@')
+	if idx < 0 {
+		return strings.Repeat(string(defaultMaskChar), utf8.RuneCountInString(str)), false, nil
+	}
+	local, domain := str[:idx], str[idx:]
+	var sb strings.Builder
+	sb.Grow(len(str))
+	for i, r := range local {
+		if i == 0 {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune(defaultMaskChar)
+		}
+	}
+	sb.WriteString(domain)
+	return sb.String(), false, nil
+}
+
+type maskHashFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *maskHashFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	salt, err := getMaskHashSalt()
+	if err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	charset, collate := ctx.GetSessionVars().GetCharsetInfo()
+	bf.tp.SetCharset(charset)
+	bf.tp.SetCollate(collate)
+	bf.tp.SetFlen(sha256.Size * 2)
+	sig := &builtinMaskHashSig{bf, salt}
+	return sig, nil
+}
+
+type builtinMaskHashSig struct {
+	baseBuiltinFunc
+	salt []byte
+}
+
+func (b *builtinMaskHashSig) Clone() builtinFunc {
+	newSig := &builtinMaskHashSig{salt: b.salt}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals MASK_HASH(str).
+// The value is returned as the hex encoded SHA-256 digest of the salt and the value.
+func (b *builtinMaskHashSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	str, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	hasher := sha256.New()
+	hasher.Write(b.salt)
+	hasher.Write([]byte(str))
+	return hex.EncodeToString(hasher.Sum(nil)), false, nil
+}
+
+// maskHashKeyring caches the salt loaded from the data masking keyring file.
+var maskHashKeyring struct {
+	sync.Mutex
+	path string
+	salt []byte
+}
+
+// getMaskHashSalt returns the salt of MASK_HASH, which is read from the keyring file in the config.
+func getMaskHashSalt() ([]byte, error) {
+	path := config.GetGlobalConfig().Security.DataMaskingKeyring
+	if path == "" {
+		return nil, nil
+	}
+	maskHashKeyring.Lock()
+	defer maskHashKeyring.Unlock()
+	if maskHashKeyring.path == path {
+		return maskHashKeyring.salt, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to read the data masking keyring")
+	}
+	maskHashKeyring.path, maskHashKeyring.salt = path, bytes.TrimSpace(data)
+	return maskHashKeyring.salt, nil
+}
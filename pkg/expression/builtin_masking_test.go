@@ -0,0 +1,125 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskPartial(t *testing.T) {
+	ctx := createContext(t)
+	cases := []struct {
+		args  []any
+		isNil bool
+		res   string
+	}{
+		{[]any{"13812345678", 3, 4}, false, "138XXXX5678"},
+		{[]any{"13812345678", 0, 4, "*"}, false, "*******5678"},
+		{[]any{"中文名字", 1, 1, "#"}, false, "中##字"},
+		{[]any{"abc", 2, 1}, false, "abc"},
+		{[]any{"abc", -1, -1}, false, "XXX"},
+		{[]any{"abc", 1, 0, ""}, false, "aXX"},
+		{[]any{nil, 1, 1}, true, ""},
+		{[]any{"abc", nil, 1}, true, ""},
+		{[]any{"abc", 1, 1, nil}, true, ""},
+	}
+	for _, c := range cases {
+		f, err := newFunctionForTest(ctx, ast.MaskPartial, primitiveValsToConstants(ctx, c.args)...)
+		require.NoError(t, err)
+		d, err := f.Eval(ctx, chunk.Row{})
+		require.NoError(t, err)
+		if c.isNil {
+			require.Equal(t, types.KindNull, d.Kind())
+		} else {
+			require.Equal(t, c.res, d.GetString())
+		}
+	}
+}
+
+func TestMaskEmail(t *testing.T) {
+	ctx := createContext(t)
+	cases := []struct {
+		arg   any
+		isNil bool
+		res   string
+	}{
+		{"john.doe@example.com", false, "jXXXXXXX@example.com"},
+		{"a@b.c", false, "a@b.c"},
+		{"@example.com", false, "@example.com"},
+		{"not an email", false, "XXXXXXXXXXXX"},
+		{"", false, ""},
+		{nil, true, ""},
+	}
+	for _, c := range cases {
+		f, err := newFunctionForTest(ctx, ast.MaskEmail, primitiveValsToConstants(ctx, []any{c.arg})...)
+		require.NoError(t, err)
+		d, err := f.Eval(ctx, chunk.Row{})
+		require.NoError(t, err)
+		if c.isNil {
+			require.Equal(t, types.KindNull, d.Kind())
+		} else {
+			require.Equal(t, c.res, d.GetString())
+		}
+	}
+}
+
+func TestMaskHash(t *testing.T) {
+	ctx := createContext(t)
+	hash := func(salt, s string) string {
+		sum := sha256.Sum256([]byte(salt + s))
+		return hex.EncodeToString(sum[:])
+	}
+
+	f, err := newFunctionForTest(ctx, ast.MaskHash, primitiveValsToConstants(ctx, []any{"abc"})...)
+	require.NoError(t, err)
+	d, err := f.Eval(ctx, chunk.Row{})
+	require.NoError(t, err)
+	require.Equal(t, hash("", "abc"), d.GetString())
+
+	keyring := filepath.Join(t.TempDir(), "keyring")
+	require.NoError(t, os.WriteFile(keyring, []byte("secret\n"), 0600))
+	restore := config.RestoreFunc()
+	defer restore()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.Security.DataMaskingKeyring = keyring
+	})
+	f, err = newFunctionForTest(ctx, ast.MaskHash, primitiveValsToConstants(ctx, []any{"abc"})...)
+	require.NoError(t, err)
+	d, err = f.Eval(ctx, chunk.Row{})
+	require.NoError(t, err)
+	require.Equal(t, hash("secret", "abc"), d.GetString())
+
+	f, err = newFunctionForTest(ctx, ast.MaskHash, primitiveValsToConstants(ctx, []any{nil})...)
+	require.NoError(t, err)
+	d, err = f.Eval(ctx, chunk.Row{})
+	require.NoError(t, err)
+	require.Equal(t, types.KindNull, d.Kind())
+
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.Security.DataMaskingKeyring = filepath.Join(t.TempDir(), "missing")
+	})
+	_, err = newFunctionForTest(ctx, ast.MaskHash, primitiveValsToConstants(ctx, []any{"abc"})...)
+	require.ErrorContains(t, err, "failed to read the data masking keyring")
+}
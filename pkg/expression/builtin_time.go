@@ -25,6 +25,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -40,6 +41,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/mathutil"
 	"github.com/pingcap/tidb/pkg/util/parser"
+	"github.com/pingcap/tidb/pkg/util/timeutil"
 	"github.com/pingcap/tipb/go-tipb"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
@@ -5127,6 +5129,14 @@ func (c *convertTzFunctionClass) getFunction(ctx BuildContext, args []Expression
 type builtinConvertTzSig struct {
 	baseBuiltinFunc
 	timezoneRegex *regexp.Regexp
+	// zoneCache caches the time zones resolved in a statement to avoid loading named zones for every row.
+	zoneCache builtinFuncCache[*convertTzZoneCache]
+}
+
+// convertTzZoneCache maps the time zone names to the locations, nil for invalid time zones.
+type convertTzZoneCache struct {
+	sync.Mutex
+	zones map[string]*time.Location
 }
 
 func (b *builtinConvertTzSig) Clone() builtinFunc {
@@ -5156,29 +5166,16 @@ func (b *builtinConvertTzSig) evalTime(ctx EvalContext, row chunk.Row) (types.Ti
 		return types.ZeroTime, true, nil
 	}
 
-	return b.convertTz(dt, fromTzStr, toTzStr)
+	return b.convertTz(ctx, dt, fromTzStr, toTzStr)
 }
 
-func (b *builtinConvertTzSig) convertTz(dt types.Time, fromTzStr, toTzStr string) (types.Time, bool, error) {
+func (b *builtinConvertTzSig) convertTz(ctx EvalContext, dt types.Time, fromTzStr, toTzStr string) (types.Time, bool, error) {
 	if fromTzStr == "" || toTzStr == "" {
 		return types.ZeroTime, true, nil
 	}
-	fromTzMatched := b.timezoneRegex.MatchString(fromTzStr)
-	toTzMatched := b.timezoneRegex.MatchString(toTzStr)
-
-	var fromTz, toTz *time.Location
-	var err error
-
-	if fromTzMatched {
-		fromTz = time.FixedZone(fromTzStr, timeZone2int(fromTzStr))
-	} else {
-		if strings.EqualFold(fromTzStr, "SYSTEM") {
-			fromTzStr = "Local"
-		}
-		fromTz, err = time.LoadLocation(fromTzStr)
-		if err != nil {
-			return types.ZeroTime, true, nil
-		}
+	fromTz, err := b.getLocation(ctx, fromTzStr)
+	if err != nil {
+		return types.ZeroTime, true, nil
 	}
 
 	t, err := dt.AdjustedGoTime(fromTz)
@@ -5187,19 +5184,48 @@ func (b *builtinConvertTzSig) convertTz(dt types.Time, fromTzStr, toTzStr string
 	}
 	t = t.In(time.UTC)
 
-	if toTzMatched {
-		toTz = time.FixedZone(toTzStr, timeZone2int(toTzStr))
-	} else {
-		if strings.EqualFold(toTzStr, "SYSTEM") {
-			toTzStr = "Local"
-		}
-		toTz, err = time.LoadLocation(toTzStr)
+	toTz, err := b.getLocation(ctx, toTzStr)
+	if err != nil {
+		return types.ZeroTime, true, nil
+	}
+
+	return types.NewTime(types.FromGoTime(t.In(toTz)), mysql.TypeDatetime, b.tp.GetDecimal()), false, nil
+}
+
+// getLocation returns the location of the time zone, which is cached in the statement.
+func (b *builtinConvertTzSig) getLocation(ctx EvalContext, name string) (*time.Location, error) {
+	cache, err := b.zoneCache.getOrInitCache(ctx, func() (*convertTzZoneCache, error) {
+		return &convertTzZoneCache{zones: make(map[string]*time.Location)}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	cache.Lock()
+	loc, ok := cache.zones[name]
+	cache.Unlock()
+	if !ok {
+		loc, err = b.loadLocation(name)
 		if err != nil {
-			return types.ZeroTime, true, nil
+			loc = nil
 		}
+		cache.Lock()
+		cache.zones[name] = loc
+		cache.Unlock()
 	}
+	if loc == nil {
+		return nil, timeutil.ErrUnknownTimeZone.GenWithStackByArgs(name)
+	}
+	return loc, nil
+}
 
-	return types.NewTime(types.FromGoTime(t.In(toTz)), mysql.TypeDatetime, b.tp.GetDecimal()), false, nil
+func (b *builtinConvertTzSig) loadLocation(name string) (*time.Location, error) {
+	if b.timezoneRegex.MatchString(name) {
+		return time.FixedZone(name, timeZone2int(name)), nil
+	}
+	if strings.EqualFold(name, "SYSTEM") {
+		name = "Local"
+	}
+	return timeutil.LoadLocation(name)
 }
 
 type makeDateFunctionClass struct {
@@ -2849,6 +2849,47 @@ func TestConvertTz(t *testing.T) {
 	}
 }
 
+func TestConvertTzZoneCache(t *testing.T) {
+	ctx := createContext(t)
+	args := []Expression{
+		&Column{Index: 0, RetType: types.NewFieldType(mysql.TypeDatetime)},
+		&Column{Index: 1, RetType: types.NewFieldType(mysql.TypeVarchar)},
+		&Column{Index: 2, RetType: types.NewFieldType(mysql.TypeVarchar)},
+	}
+	f, err := funcs[ast.ConvertTz].getFunction(ctx, args)
+	require.NoError(t, err)
+	sig := f.(*builtinConvertTzSig)
+
+	dt := types.NewTime(types.FromDate(2021, 10, 22, 10, 0, 0, 0), mysql.TypeDatetime, 0)
+	tests := []struct {
+		fromTz string
+		toTz   string
+		expect string
+	}{
+		{"UTC", "Asia/Shanghai", "2021-10-22 18:00:00.000000"},
+		{"UTC", "Asia/Shanghai", "2021-10-22 18:00:00.000000"},
+		{"Asia/Shanghai", "+01:00", "2021-10-22 03:00:00.000000"},
+		{"UTC", "not/exist", ""},
+		{"UTC", "not/exist", ""},
+	}
+	for _, test := range tests {
+		row := chunk.MutRowFromDatums([]types.Datum{types.NewTimeDatum(dt), types.NewStringDatum(test.fromTz), types.NewStringDatum(test.toTz)})
+		d, err := evalBuiltinFunc(sig, ctx, row.ToRow())
+		require.NoError(t, err)
+		result, _ := d.ToString()
+		require.Equal(t, test.expect, result)
+	}
+
+	cache, ok := sig.zoneCache.getCache(ctx.GetSessionVars().StmtCtx.CtxID())
+	require.True(t, ok)
+	require.Len(t, cache.zones, 4)
+	require.Equal(t, "Asia/Shanghai", cache.zones["Asia/Shanghai"].String())
+	require.Nil(t, cache.zones["not/exist"])
+	// the cloned function has its own cache.
+	_, ok = sig.Clone().(*builtinConvertTzSig).zoneCache.getCache(ctx.GetSessionVars().StmtCtx.CtxID())
+	require.False(t, ok)
+}
+
 func TestPeriodDiff(t *testing.T) {
 	ctx := createContext(t)
 	tests := []struct {
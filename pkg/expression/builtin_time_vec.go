@@ -2615,7 +2615,7 @@ func (b *builtinConvertTzSig) vecEvalTime(ctx EvalContext, input *chunk.Chunk, r
 			continue
 		}
 
-		ts[i], isNull, err = b.convertTz(ts[i], fromTzBuf.GetString(i), toTzBuf.GetString(i))
+		ts[i], isNull, err = b.convertTz(ctx, ts[i], fromTzBuf.GetString(i), toTzBuf.GetString(i))
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,275 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+var (
+	_ functionClass = &vecDimsFunctionClass{}
+	_ functionClass = &vecL2NormFunctionClass{}
+	_ functionClass = &vecDistanceFunctionClass{}
+	_ functionClass = &vecFromTextFunctionClass{}
+	_ functionClass = &vecAsTextFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinVecDimsSig{}
+	_ builtinFunc = &builtinVecL2NormSig{}
+	_ builtinFunc = &builtinVecL2DistanceSig{}
+	_ builtinFunc = &builtinVecCosineDistanceSig{}
+	_ builtinFunc = &builtinVecFromTextSig{}
+	_ builtinFunc = &builtinVecAsTextSig{}
+)
+
+// The values of VECTOR columns are evaluated as strings in the text format like '[1,2.5,3]',
+// so the arguments of the vector functions can be either VECTOR columns or strings.
+func evalVectorFloat32(ctx EvalContext, arg Expression, row chunk.Row) (types.VectorFloat32, bool, error) {
+	s, isNull, err := arg.EvalString(ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	v, err := types.ParseVectorFloat32(s)
+	if err != nil {
+		return nil, true, err
+	}
+	return v, false, nil
+}
+
+type vecDimsFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *vecDimsFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinVecDimsSig{bf}
+	return sig, nil
+}
+
+type builtinVecDimsSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVecDimsSig) Clone() builtinFunc {
+	newSig := &builtinVecDimsSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals VEC_DIMS(v).
+func (b *builtinVecDimsSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	v, isNull, err := evalVectorFloat32(ctx, b.args[0], row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return int64(v.Len()), false, nil
+}
+
+type vecL2NormFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *vecL2NormFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinVecL2NormSig{bf}
+	return sig, nil
+}
+
+type builtinVecL2NormSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVecL2NormSig) Clone() builtinFunc {
+	newSig := &builtinVecL2NormSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals VEC_L2_NORM(v).
+func (b *builtinVecL2NormSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	v, isNull, err := evalVectorFloat32(ctx, b.args[0], row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return v.L2Norm(), false, nil
+}
+
+// vecDistanceFunctionClass builds the distance functions between two vectors.
+type vecDistanceFunctionClass struct {
+	baseFunctionClass
+	newSig func(bf baseBuiltinFunc) builtinFunc
+}
+
+func (c *vecDistanceFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	return c.newSig(bf), nil
+}
+
+type builtinVecL2DistanceSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVecL2DistanceSig) Clone() builtinFunc {
+	newSig := &builtinVecL2DistanceSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals VEC_L2_DISTANCE(a, b).
+func (b *builtinVecL2DistanceSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	v1, isNull, err := evalVectorFloat32(ctx, b.args[0], row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	v2, isNull, err := evalVectorFloat32(ctx, b.args[1], row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	d, err := v1.L2Distance(v2)
+	if err != nil {
+		return 0, true, err
+	}
+	return d, false, nil
+}
+
+type builtinVecCosineDistanceSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVecCosineDistanceSig) Clone() builtinFunc {
+	newSig := &builtinVecCosineDistanceSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals VEC_COSINE_DISTANCE(a, b). It returns NULL if any of the vectors is a zero vector.
+func (b *builtinVecCosineDistanceSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	v1, isNull, err := evalVectorFloat32(ctx, b.args[0], row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	v2, isNull, err := evalVectorFloat32(ctx, b.args[1], row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	d, err := v1.CosineDistance(v2)
+	if err != nil {
+		return 0, true, err
+	}
+	if math.IsNaN(d) {
+		return 0, true, nil
+	}
+	return d, false, nil
+}
+
+type vecFromTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *vecFromTextFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetType(mysql.TypeTiDBVectorFloat32)
+	bf.tp.SetFlen(types.UnspecifiedLength)
+	bf.tp.SetDecimal(0)
+	bf.tp.SetCharset(charset.CharsetBin)
+	bf.tp.SetCollate(charset.CollationBin)
+	sig := &builtinVecFromTextSig{bf}
+	return sig, nil
+}
+
+type builtinVecFromTextSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVecFromTextSig) Clone() builtinFunc {
+	newSig := &builtinVecFromTextSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals VEC_FROM_TEXT(s).
+func (b *builtinVecFromTextSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	v, isNull, err := evalVectorFloat32(ctx, b.args[0], row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return v.String(), false, nil
+}
+
+type vecAsTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *vecAsTextFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	sig := &builtinVecAsTextSig{bf}
+	return sig, nil
+}
+
+type builtinVecAsTextSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVecAsTextSig) Clone() builtinFunc {
+	newSig := &builtinVecAsTextSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals VEC_AS_TEXT(v).
+func (b *builtinVecAsTextSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	v, isNull, err := evalVectorFloat32(ctx, b.args[0], row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return v.String(), false, nil
+}
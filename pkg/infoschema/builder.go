@@ -21,12 +21,14 @@ import (
 	"math"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/ngaut/pools"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl/placement"
+	infoschema_metrics "github.com/pingcap/tidb/pkg/infoschema/metrics"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
@@ -183,12 +185,48 @@ type Builder struct {
 	factory func() (pools.Resource, error)
 	bundleInfoBuilder
 	infoData *Data
+
+	// verifySchemaIDs are the IDs of the schemas affected by the applied diffs, they're only recorded when the
+	// verifyApplyDiff failpoint is enabled.
+	verifySchemaIDs map[int64]struct{}
+
+	// interner shares the unchanged tables with the latest cached InfoSchema, and tableChanges are the tables
+	// added and dropped by the builder, see ShareTablesWith.
+	interner     *tableInterner
+	tableChanges *tableChanges
 }
 
 // ApplyDiff applies SchemaDiff to the new InfoSchema.
 // Return the detail updated table IDs that are produced from SchemaDiff and an error.
 func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, error) {
+	tblIDs, err := b.applyDiff(m, diff)
+	if err != nil {
+		return tblIDs, err
+	}
+	// verifyApplyDiff cross-checks the schemas affected by the applied diffs against the meta, the divergence is
+	// logged if the value is "log", or it panics if the value is "panic".
+	failpoint.Inject("verifyApplyDiff", func(val failpoint.Value) {
+		if err := b.verifyAppliedDiff(m, diff); err != nil {
+			if val.(string) == "panic" {
+				panic(err)
+			}
+			logutil.BgLogger().Error("the applied schema diffs diverge from the meta", zap.Int64("version", diff.Version),
+				zap.Stringer("type", diff.Type), zap.Error(err))
+		}
+	})
+	return tblIDs, nil
+}
+
+func (b *Builder) applyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, error) {
+	defer func(start time.Time) {
+		infoschema_metrics.ObserveApplyDiffDuration(diff.Type.String(), time.Since(start))
+	}(time.Now())
 	b.schemaMetaVersion = diff.Version
+	failpoint.Inject("mockApplyDiffError", func(val failpoint.Value) {
+		if val.(string) == diff.Type.String() {
+			failpoint.Return(nil, errors.Errorf("mock apply diff error of %s", diff.Type))
+		}
+	})
 	switch diff.Type {
 	case model.ActionCreateSchema:
 		return nil, b.applyCreateSchema(m, diff)
@@ -200,6 +238,8 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 		return nil, b.applyModifySchemaCharsetAndCollate(m, diff)
 	case model.ActionModifySchemaDefaultPlacement:
 		return nil, b.applyModifySchemaDefaultPlacement(m, diff)
+	case model.ActionModifySchemaReadOnly:
+		return nil, b.applyModifySchemaReadOnly(m, diff)
 	case model.ActionCreatePlacementPolicy:
 		return nil, b.applyCreatePolicy(m, diff)
 	case model.ActionDropPlacementPolicy:
@@ -232,6 +272,135 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 	}
 }
 
+// verifyAppliedDiff records the schemas affected by the diff, and checks they're the same as the ones in the meta
+// once the diff of the latest schema version is applied. The schemas can't be checked before that, because the meta
+// is the snapshot of the latest schema version.
+func (b *Builder) verifyAppliedDiff(m *meta.Meta, diff *model.SchemaDiff) error {
+	switch diff.Type {
+	case model.ActionCreatePlacementPolicy, model.ActionDropPlacementPolicy, model.ActionAlterPlacementPolicy,
+		model.ActionCreateResourceGroup, model.ActionAlterResourceGroup, model.ActionDropResourceGroup,
+		model.ActionFlashbackCluster:
+		// The schema ID of the diff isn't the ID of a schema.
+	default:
+		if b.verifySchemaIDs == nil {
+			b.verifySchemaIDs = make(map[int64]struct{})
+		}
+		b.verifySchemaIDs[diff.SchemaID] = struct{}{}
+		if diff.OldSchemaID != 0 {
+			b.verifySchemaIDs[diff.OldSchemaID] = struct{}{}
+		}
+		for _, opt := range diff.AffectedOpts {
+			b.verifySchemaIDs[opt.SchemaID] = struct{}{}
+			if opt.OldSchemaID != 0 {
+				b.verifySchemaIDs[opt.OldSchemaID] = struct{}{}
+			}
+		}
+	}
+	latest, err := m.GetSchemaVersionWithNonEmptyDiff()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if diff.Version < latest {
+		return nil
+	}
+	schemaIDs := b.verifySchemaIDs
+	b.verifySchemaIDs = nil
+	is := b.appliedInfoSchema()
+	for schemaID := range schemaIDs {
+		if err := verifySchema(m, is, schemaID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appliedInfoSchema returns the InfoSchema with the diffs applied so far, without building it.
+func (b *Builder) appliedInfoSchema() InfoSchema {
+	if b.enableV2 {
+		is := b.infoschemaV2
+		is.ts = math.MaxUint64
+		is.schemaVersion = b.infoSchema.SchemaMetaVersion()
+		return &is
+	}
+	return b.infoSchema
+}
+
+// verifySchema checks the schema and its tables in the InfoSchema are the same as the ones in the meta.
+func verifySchema(m *meta.Meta, is InfoSchema, schemaID int64) error {
+	dbInfo, err := m.GetDatabase(schemaID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appliedDBInfo, ok := is.SchemaByID(schemaID)
+	if dbInfo == nil {
+		if ok {
+			return errors.Errorf("schema %s (ID %d) is dropped in the meta, but it exists in the infoschema",
+				appliedDBInfo.Name, schemaID)
+		}
+		return nil
+	}
+	if !ok {
+		// The schema being dropped is removed from the infoschema before it's removed from the meta.
+		if dbInfo.State != model.StatePublic {
+			return nil
+		}
+		return errors.Errorf("schema %s (ID %d) exists in the meta, but it's missing in the infoschema", dbInfo.Name, schemaID)
+	}
+	if dbInfo.Name.L != appliedDBInfo.Name.L {
+		return errors.Errorf("schema ID %d is named %s in the meta, but it's named %s in the infoschema",
+			schemaID, dbInfo.Name, appliedDBInfo.Name)
+	}
+
+	tblInfos, err := m.ListTables(schemaID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appliedTbls := is.SchemaTables(appliedDBInfo.Name)
+	appliedTblInfos := make(map[int64]*model.TableInfo, len(appliedTbls))
+	for _, tbl := range appliedTbls {
+		appliedTblInfos[tbl.Meta().ID] = tbl.Meta()
+	}
+	tblIDs := make(map[int64]struct{}, len(tblInfos))
+	for _, tblInfo := range tblInfos {
+		tblIDs[tblInfo.ID] = struct{}{}
+		applied, ok := appliedTblInfos[tblInfo.ID]
+		if !ok {
+			// The table being dropped is removed from the infoschema before it's removed from the meta.
+			if tblInfo.State != model.StatePublic {
+				continue
+			}
+			return errors.Errorf("table %s.%s (ID %d) exists in the meta, but it's missing in the infoschema",
+				dbInfo.Name, tblInfo.Name, tblInfo.ID)
+		}
+		if tblInfo.Name.L != applied.Name.L || tblInfo.UpdateTS != applied.UpdateTS {
+			return errors.Errorf("table ID %d is %s.%s updated at %d in the meta, but it's %s.%s updated at %d in the infoschema",
+				tblInfo.ID, dbInfo.Name, tblInfo.Name, tblInfo.UpdateTS, dbInfo.Name, applied.Name, applied.UpdateTS)
+		}
+		if !slices.Equal(partitionIDs(tblInfo), partitionIDs(applied)) {
+			return errors.Errorf("table %s.%s (ID %d) has partitions %v in the meta, but it has partitions %v in the infoschema",
+				dbInfo.Name, tblInfo.Name, tblInfo.ID, partitionIDs(tblInfo), partitionIDs(applied))
+		}
+	}
+	for _, tbl := range appliedTbls {
+		if _, ok := tblIDs[tbl.Meta().ID]; !ok {
+			return errors.Errorf("table %s.%s (ID %d) is dropped in the meta, but it exists in the infoschema",
+				dbInfo.Name, tbl.Meta().Name, tbl.Meta().ID)
+		}
+	}
+	return nil
+}
+
+func partitionIDs(tblInfo *model.TableInfo) []int64 {
+	if tblInfo.Partition == nil {
+		return nil
+	}
+	ids := make([]int64, 0, len(tblInfo.Partition.Definitions))
+	for _, def := range tblInfo.Partition.Definitions {
+		ids = append(ids, def.ID)
+	}
+	return ids
+}
+
 func (b *Builder) applyCreateTables(m *meta.Meta, diff *model.SchemaDiff) ([]int64, error) {
 	tblIDs := make([]int64, 0, len(diff.AffectedOpts))
 	if diff.AffectedOpts != nil {
@@ -244,7 +413,7 @@ func (b *Builder) applyCreateTables(m *meta.Meta, diff *model.SchemaDiff) ([]int
 				OldSchemaID: opt.OldSchemaID,
 				OldTableID:  opt.OldTableID,
 			}
-			affectedIDs, err := b.ApplyDiff(m, affectedDiff)
+			affectedIDs, err := b.applyDiff(m, affectedDiff)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
@@ -448,7 +617,7 @@ func (b *Builder) applyDefaultAction(m *meta.Meta, diff *model.SchemaDiff) ([]in
 			OldSchemaID: opt.OldSchemaID,
 			OldTableID:  opt.OldTableID,
 		}
-		affectedIDs, err := b.ApplyDiff(m, affectedDiff)
+		affectedIDs, err := b.applyDiff(m, affectedDiff)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -502,6 +671,9 @@ func (b *Builder) updateBundleForTableUpdate(diff *model.SchemaDiff, newTableID,
 		b.markTableBundleShouldUpdate(newTableID)
 	case model.ActionAlterTablePlacement:
 		b.markTableBundleShouldUpdate(newTableID)
+	case model.ActionRenameTable, model.ActionRenameTables:
+		// The bundle is keyed by the table ID, rebuild it so it follows the table moved across the schemas.
+		b.markTableBundleShouldUpdate(newTableID)
 	}
 }
 
@@ -561,6 +733,13 @@ func (b *Builder) applyTableUpdate(m *meta.Meta, diff *model.SchemaDiff) ([]int6
 	b.updateBundleForTableUpdate(diff, newTableID, oldTableID)
 	b.copySortedTables(oldTableID, newTableID)
 
+	var oldAutoRandBits uint64
+	if diff.Type == model.ActionModifyColumn {
+		if tbl, ok := b.infoSchema.TableByID(oldTableID); ok {
+			oldAutoRandBits = tbl.Meta().AutoRandomBits
+		}
+	}
+
 	tblIDs, allocs, err := b.dropTableForUpdate(newTableID, oldTableID, dbInfo, diff)
 	if err != nil {
 		return nil, err
@@ -569,6 +748,12 @@ func (b *Builder) applyTableUpdate(m *meta.Meta, diff *model.SchemaDiff) ([]int6
 	if tableIDIsValid(newTableID) {
 		// All types except DropTableOrView.
 		var err error
+		if oldAutoRandBits > 0 {
+			allocs, err = b.rebuildAutoRandomAllocIfBitsChanged(m, dbInfo, newTableID, oldAutoRandBits, allocs)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 		tblIDs, err = b.applyCreateTable(m, dbInfo, newTableID, allocs, diff.Type, tblIDs, diff.Version)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -577,6 +762,25 @@ func (b *Builder) applyTableUpdate(m *meta.Meta, diff *model.SchemaDiff) ([]int6
 	return tblIDs, nil
 }
 
+// rebuildAutoRandomAllocIfBitsChanged rebuilds the auto_random allocator if the shard bits are changed
+// by modifying column, because the cached IDs of the old allocator are rebased in the DDL job.
+func (b *Builder) rebuildAutoRandomAllocIfBitsChanged(m *meta.Meta, dbInfo *model.DBInfo, tableID int64,
+	oldAutoRandBits uint64, allocs autoid.Allocators) (autoid.Allocators, error) {
+	if len(allocs.Allocs) == 0 {
+		return allocs, nil
+	}
+	tblInfo, err := m.GetTable(dbInfo.ID, tableID)
+	if err != nil || tblInfo == nil || tblInfo.AutoRandomBits == oldAutoRandBits {
+		return allocs, errors.Trace(err)
+	}
+	allocs = allocs.Filter(func(a autoid.Allocator) bool {
+		return a.GetType() != autoid.AutoRandomType
+	})
+	tblVer := autoid.AllocOptionTableInfoVersion(tblInfo.Version)
+	newAlloc := autoid.NewAllocator(b.Requirement, dbInfo.ID, tblInfo.ID, tblInfo.IsAutoRandomBitColUnsigned(), autoid.AutoRandomType, tblVer)
+	return allocs.Append(newAlloc), nil
+}
+
 func filterAllocators(diff *model.SchemaDiff, oldAllocs autoid.Allocators) autoid.Allocators {
 	var newAllocs autoid.Allocators
 	switch diff.Type {
@@ -647,6 +851,11 @@ func (b *Builder) applyCreatePolicy(m *meta.Meta, diff *model.SchemaDiff) error
 	if err != nil {
 		return errors.Trace(err)
 	}
+	failpoint.Inject("mockPolicyMissing", func(val failpoint.Value) {
+		if int64(val.(int)) == diff.SchemaID {
+			po = nil
+		}
+	})
 	if po == nil {
 		return ErrPlacementPolicyNotExists.GenWithStackByArgs(
 			fmt.Sprintf("(Policy ID %d)", diff.SchemaID),
@@ -668,6 +877,11 @@ func (b *Builder) applyAlterPolicy(m *meta.Meta, diff *model.SchemaDiff) ([]int6
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	failpoint.Inject("mockPolicyMissing", func(val failpoint.Value) {
+		if int64(val.(int)) == diff.SchemaID {
+			po = nil
+		}
+	})
 
 	if po == nil {
 		return nil, ErrPlacementPolicyNotExists.GenWithStackByArgs(
@@ -730,6 +944,22 @@ func (b *Builder) applyModifySchemaDefaultPlacement(m *meta.Meta, diff *model.Sc
 	return nil
 }
 
+func (b *Builder) applyModifySchemaReadOnly(m *meta.Meta, diff *model.SchemaDiff) error {
+	di, err := m.GetDatabase(diff.SchemaID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if di == nil {
+		// This should never happen.
+		return ErrDatabaseNotExists.GenWithStackByArgs(
+			fmt.Sprintf("(Schema ID %d)", diff.SchemaID),
+		)
+	}
+	newDbInfo := b.getSchemaAndCopyIfNecessary(di.Name.L)
+	newDbInfo.ReadOnly = di.ReadOnly
+	return nil
+}
+
 func (b *Builder) applyDropPolicy(PolicyID int64) []int64 {
 	po, ok := b.infoSchema.PolicyByID(PolicyID)
 	if !ok {
@@ -936,6 +1166,7 @@ func ConvertOldVersionUTF8ToUTF8MB4IfNeed(tbInfo *model.TableInfo) {
 }
 
 func (b *Builder) applyDropTable(dbInfo *model.DBInfo, tableID int64, affected []int64) []int64 {
+	b.deleteSpecialTable(tableID)
 	bucketIdx := tableBucketIdx(tableID)
 	sortedTbls := b.infoSchema.sortedTablesBuckets[bucketIdx]
 	idx := sortedTbls.searchTable(tableID)
@@ -949,6 +1180,9 @@ func (b *Builder) applyDropTable(dbInfo *model.DBInfo, tableID int64, affected [
 	}
 	// Remove the table in sorted table slice.
 	b.infoSchema.sortedTablesBuckets[bucketIdx] = append(sortedTbls[0:idx], sortedTbls[idx+1:]...)
+	if b.tableChanges != nil {
+		b.tableChanges.ops = append(b.tableChanges.ops, tableChange{id: tableID})
+	}
 
 	// Remove the table in temporaryTables
 	if b.infoSchema.temporaryTableIDs != nil {
@@ -984,9 +1218,23 @@ func (b *Builder) Build() InfoSchema {
 		b.infoschemaV2.schemaVersion = b.infoSchema.SchemaMetaVersion()
 		return &b.infoschemaV2
 	}
+	b.infoSchema.tableChanges = b.tableChanges
 	return b.infoSchema
 }
 
+// ShareTablesWith makes the builder share the tables with the latest InfoSchema in the cache. When the InfoSchema
+// is fully loaded, the tables whose meta is unchanged reuse the ones of the latest InfoSchema instead of being
+// wrapped again. The tables of the built InfoSchema are shared with the later versions after it's inserted into
+// the cache as the latest one. It's a no-op for the infoschema v2, whose tables are shared by the Data.
+// It must be called before initializing the builder.
+func (b *Builder) ShareTablesWith(cache *InfoCache) *Builder {
+	if !b.enableV2 {
+		b.interner = cache.tables
+		b.tableChanges = &tableChanges{}
+	}
+	return b
+}
+
 // InitWithOldInfoSchema initializes an empty new InfoSchema by copies all the data from old InfoSchema.
 func (b *Builder) InitWithOldInfoSchema(oldSchema InfoSchema) (*Builder, error) {
 	// Do not mix infoschema v1 and infoschema v2 building, this can simplify the logic.
@@ -1003,12 +1251,16 @@ func (b *Builder) InitWithOldInfoSchema(oldSchema InfoSchema) (*Builder, error)
 	}
 	b.initBundleInfoBuilder()
 	b.infoSchema.schemaMetaVersion = oldIS.schemaMetaVersion
+	if b.tableChanges != nil {
+		b.tableChanges.base = oldIS.schemaMetaVersion
+	}
 	b.copySchemasMap(oldIS)
 	b.copyBundlesMap(oldIS)
 	b.copyPoliciesMap(oldIS)
 	b.copyResourceGroupMap(oldIS)
 	b.copyTemporaryTableIDsMap(oldIS)
 	b.copyReferredForeignKeyMap(oldIS)
+	b.copySpecialTablesMap(oldIS)
 
 	copy(b.infoSchema.sortedTablesBuckets, oldIS.sortedTablesBuckets)
 	return b, nil
@@ -1104,7 +1356,12 @@ func (b *Builder) initMisc(dbInfos []*model.DBInfo, policies []*model.PolicyInfo
 func (b *Builder) initVirtualTables(schemaVersion int64) error {
 	// Initialize virtual tables.
 	for _, driver := range drivers {
-		err := b.createSchemaTablesForDB(driver.DBInfo, driver.TableFromMeta, schemaVersion)
+		dbInfo := driver.DBInfo
+		if driver.extraTables != nil {
+			dbInfo = dbInfo.Copy()
+			dbInfo.Tables = append(dbInfo.Tables, driver.extraTables()...)
+		}
+		err := b.createSchemaTablesForDB(dbInfo, driver.TableFromMeta, schemaVersion)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -1125,6 +1382,9 @@ func (b *Builder) sortAllTablesByID() {
 func (b *Builder) InitWithDBInfos(dbInfos []*model.DBInfo, policies []*model.PolicyInfo, resourceGroups []*model.ResourceGroupInfo, schemaVersion int64) (*Builder, error) {
 	info := b.infoSchema
 	info.schemaMetaVersion = schemaVersion
+	if b.tableChanges != nil {
+		b.tableChanges.full = true
+	}
 
 	b.initBundleInfoBuilder()
 
@@ -1174,12 +1434,22 @@ func (b *Builder) createSchemaTablesForDB(di *model.DBInfo, tableFromMeta tableF
 		dbInfo: di,
 		tables: make(map[string]table.Table, len(di.Tables)),
 	}
-	for _, t := range di.Tables {
-		allocs := autoid.NewAllocatorsFromTblInfo(b.Requirement, di.ID, t)
+	for i, t := range di.Tables {
 		var tbl table.Table
-		tbl, err := tableFromMeta(allocs, t)
-		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("Build table `%s`.`%s` schema failed", di.Name.O, t.Name.O))
+		if b.interner != nil {
+			if tbl = b.interner.get(t); tbl != nil {
+				// Share the table info too, so the newly loaded one can be released.
+				t = tbl.Meta()
+				di.Tables[i] = t
+			}
+		}
+		if tbl == nil {
+			allocs := autoid.NewAllocatorsFromTblInfo(b.Requirement, di.ID, t)
+			var err error
+			tbl, err = tableFromMeta(allocs, t)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("Build table `%s`.`%s` schema failed", di.Name.O, t.Name.O))
+			}
 		}
 
 		schTbls.tables[t.Name.L] = tbl
@@ -1207,6 +1477,7 @@ func (b *Builder) addDB(schemaVersion int64, di *model.DBInfo, schTbls *schemaTa
 }
 
 func (b *Builder) addTable(schemaVersion int64, di *model.DBInfo, tblInfo *model.TableInfo, tbl table.Table) {
+	b.addSpecialTable(di, tblInfo)
 	if b.enableV2 {
 		b.infoData.add(tableItem{
 			dbName:        di.Name.L,
@@ -1218,6 +1489,9 @@ func (b *Builder) addTable(schemaVersion int64, di *model.DBInfo, tblInfo *model
 	} else {
 		sortedTbls := b.infoSchema.sortedTablesBuckets[tableBucketIdx(tblInfo.ID)]
 		b.infoSchema.sortedTablesBuckets[tableBucketIdx(tblInfo.ID)] = append(sortedTbls, tbl)
+		if b.tableChanges != nil {
+			b.tableChanges.ops = append(b.tableChanges.ops, tableChange{id: tblInfo.ID, tbl: tbl})
+		}
 	}
 }
 
@@ -1231,13 +1505,20 @@ func (b *Builder) addTemporaryTable(tblID int64) {
 type virtualTableDriver struct {
 	*model.DBInfo
 	TableFromMeta tableFromMetaFunc
+	// extraTables returns the tables which can be changed at runtime, they are
+	// added to the DB when the InfoSchema is fully loaded.
+	extraTables func() []*model.TableInfo
 }
 
 var drivers []*virtualTableDriver
 
 // RegisterVirtualTable register virtual tables to the builder.
 func RegisterVirtualTable(dbInfo *model.DBInfo, tableFromMeta tableFromMetaFunc) {
-	drivers = append(drivers, &virtualTableDriver{dbInfo, tableFromMeta})
+	drivers = append(drivers, &virtualTableDriver{DBInfo: dbInfo, TableFromMeta: tableFromMeta})
+}
+
+func registerVirtualTableWithExtraTables(dbInfo *model.DBInfo, tableFromMeta tableFromMetaFunc, extraTables func() []*model.TableInfo) {
+	drivers = append(drivers, &virtualTableDriver{DBInfo: dbInfo, TableFromMeta: tableFromMeta, extraTables: extraTables})
 }
 
 // NewBuilder creates a new Builder with a Handle.
@@ -20,6 +20,7 @@ import (
 
 	infoschema_metrics "github.com/pingcap/tidb/pkg/infoschema/metrics"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
+	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"go.uber.org/zap"
 )
@@ -34,6 +35,9 @@ type InfoCache struct {
 
 	r    autoid.Requirement
 	Data *Data
+
+	// tables shares the unchanged tables across the cached infoschema v1, see Builder.ShareTablesWith.
+	tables *tableInterner
 }
 
 type schemaAndTimestamp struct {
@@ -45,9 +49,10 @@ type schemaAndTimestamp struct {
 func NewCache(r autoid.Requirement, capacity int) *InfoCache {
 	infoData := NewData()
 	return &InfoCache{
-		cache: make([]schemaAndTimestamp, 0, capacity),
-		r:     r,
-		Data:  infoData,
+		cache:  make([]schemaAndTimestamp, 0, capacity),
+		r:      r,
+		Data:   infoData,
+		tables: newTableInterner(),
 	}
 }
 
@@ -66,6 +71,7 @@ func (h *InfoCache) ReSize(capacity int) {
 		}
 		h.cache = append(h.cache, v)
 	}
+	h.gcTablesNoLock()
 }
 
 // Size returns the size of the cache, export for test.
@@ -80,6 +86,44 @@ func (h *InfoCache) Reset(capacity int) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	h.cache = make([]schemaAndTimestamp, 0, capacity)
+	h.tables = newTableInterner()
+}
+
+// TableRefs returns the number of the cached infoschema sharing the table, export for test.
+func (h *InfoCache) TableRefs(tbl table.Table) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	versions := make([]int64, 0, len(h.cache))
+	for _, v := range h.cache {
+		versions = append(versions, v.infoschema.SchemaMetaVersion())
+	}
+	return h.tables.refs(tbl, versions)
+}
+
+// SharedTables returns the number of the tables shared by the cached infoschema, export for test.
+func (h *InfoCache) SharedTables() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.tables.size()
+}
+
+// commitTablesNoLock makes the tables of the infoschema be shared with the later versions if it's the latest one.
+func (h *InfoCache) commitTablesNoLock(is InfoSchema, latest bool) {
+	v1, ok := is.(*infoSchema)
+	if !ok || v1.tableChanges == nil {
+		return
+	}
+	if latest {
+		h.tables.commit(v1.schemaMetaVersion, v1.tableChanges)
+	}
+	v1.tableChanges = nil
+}
+
+// gcTablesNoLock releases the shared tables which are not referenced by any cached infoschema.
+func (h *InfoCache) gcTablesNoLock() {
+	if len(h.cache) > 0 {
+		h.tables.gc(h.cache[len(h.cache)-1].infoschema.SchemaMetaVersion())
+	}
 }
 
 // GetLatest gets the newest information schema.
@@ -183,6 +227,24 @@ func (h *InfoCache) GetBySnapshotTS(snapshotTS uint64) InfoSchema {
 	return nil
 }
 
+// Replace replaces the cached infoschema of the same schema version, it is used
+// when the infoschema is rebuilt without changing the schema version.
+// It returns false if there is no cached infoschema of the version.
+func (h *InfoCache) Replace(is InfoSchema) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	version := is.SchemaMetaVersion()
+	for i := range h.cache {
+		if h.cache[i].infoschema.SchemaMetaVersion() == version && IsV2(h.cache[i].infoschema) == IsV2(is) {
+			h.cache[i].infoschema = is
+			h.commitTablesNoLock(is, i == 0)
+			return true
+		}
+	}
+	return false
+}
+
 // Insert will **TRY** to insert the infoschema into the cache.
 // It only promised to cache the newest infoschema.
 // It returns 'true' if it is cached, 'false' otherwise.
@@ -229,5 +291,7 @@ func (h *InfoCache) Insert(is InfoSchema, schemaTS uint64) bool {
 		return false
 	}
 
+	h.commitTablesNoLock(is, i == 0)
+	h.gcTablesNoLock()
 	return true
 }
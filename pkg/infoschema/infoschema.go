@@ -46,6 +46,9 @@ type InfoSchema interface {
 	SchemaTables(schema model.CIStr) []table.Table
 	SchemaMetaVersion() int64
 	FindTableByPartitionID(partitionID int64) (table.Table, *model.DBInfo, *model.PartitionDefinition)
+	// ListTablesWithSpecialAttribute lists the tables carrying any of the special attributes grouped by the schemas,
+	// it's used by the background workers to avoid scanning all the tables.
+	ListTablesWithSpecialAttribute(attr SpecialAttribute) []TableInfoResult
 	Misc
 }
 
@@ -97,6 +100,13 @@ type infoSchema struct {
 
 	// schemaMetaVersion is the version of schema, and we should check version when change schema.
 	schemaMetaVersion int64
+
+	// specialTables indexes the tables carrying the special attributes by the table ID.
+	specialTables map[int64]specialTable
+
+	// tableChanges are the tables changed by the builder, they're committed to the tableInterner of the InfoCache
+	// when the InfoSchema is cached as the latest one.
+	tableChanges *tableChanges
 }
 
 type infoSchemaMisc struct {
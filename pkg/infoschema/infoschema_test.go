@@ -17,6 +17,7 @@ package infoschema_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -1135,6 +1136,19 @@ func (tc *infoschemaTestContext) applyDiffAddCheck(diff *model.SchemaDiff, check
 	checkFn(tc)
 }
 
+// applyDiff applies the diff to the infoschema, the infoschema is unchanged if it fails.
+func (tc *infoschemaTestContext) applyDiff(diff *model.SchemaDiff) error {
+	txn, err := tc.re.Store().Begin()
+	require.NoError(tc.t, err)
+	builder, err := infoschema.NewBuilder(tc.re, nil, nil).InitWithOldInfoSchema(tc.is)
+	require.NoError(tc.t, err)
+	if _, err = builder.ApplyDiff(meta.NewMeta(txn), diff); err != nil {
+		return err
+	}
+	tc.is = builder.Build()
+	return nil
+}
+
 func (tc *infoschemaTestContext) clear() {
 	tc.dbInfo = nil
 	tc.is = nil
@@ -1167,3 +1181,161 @@ func TestApplyDiff(t *testing.T) {
 	tc.runModifyTable("test", model.ActionModifyColumn)
 	// TODO check all actions..
 }
+
+func TestApplyDiffFaults(t *testing.T) {
+	re := createAutoIDRequirement(t)
+	defer func() {
+		err := re.Store().Close()
+		require.NoError(t, err)
+	}()
+
+	tc := &infoschemaTestContext{
+		t:   t,
+		re:  re,
+		ctx: kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL),
+	}
+	tc.runCreateSchema()
+
+	// The failed diff doesn't change the infoschema, and it can be applied again after the fault is removed.
+	tblID := tc.createTable("t")
+	diff := &model.SchemaDiff{Type: model.ActionCreateTable, SchemaID: tc.dbInfo.ID, TableID: tblID}
+	fault := testkit.InjectApplyDiffError(t, model.ActionCreateTable)
+	require.ErrorContains(t, tc.applyDiff(diff), "mock apply diff error of create table")
+	_, ok := tc.is.TableByID(tblID)
+	require.False(t, ok)
+	fault.Remove()
+	tc.applyDiffAddCheck(diff, func(tc *infoschemaTestContext) {
+		_, ok := tc.is.TableByID(tblID)
+		require.True(tc.t, ok)
+	})
+
+	policyID, err := genGlobalID(re.Store())
+	require.NoError(t, err)
+	policy := &model.PolicyInfo{
+		ID:                policyID,
+		Name:              model.NewCIStr("p1"),
+		PlacementSettings: &model.PlacementSettings{Followers: 2},
+		State:             model.StatePublic,
+	}
+	err = kv.RunInNewTxn(tc.ctx, re.Store(), true, func(ctx context.Context, txn kv.Transaction) error {
+		return meta.NewMeta(txn).CreatePolicy(policy)
+	})
+	require.NoError(t, err)
+	diff = &model.SchemaDiff{Type: model.ActionCreatePlacementPolicy, SchemaID: policyID}
+	fault = testkit.InjectPolicyMissing(t, policyID)
+	err = tc.applyDiff(diff)
+	require.True(t, infoschema.ErrPlacementPolicyNotExists.Equal(err), err)
+	_, ok = tc.is.PolicyByName(policy.Name)
+	require.False(t, ok)
+	fault.Remove()
+	tc.applyDiffAddCheck(diff, func(tc *infoschemaTestContext) {
+		po, ok := tc.is.PolicyByName(policy.Name)
+		require.True(tc.t, ok)
+		require.Equal(tc.t, policyID, po.ID)
+	})
+}
+
+func TestVerifyApplyDiff(t *testing.T) {
+	re := createAutoIDRequirement(t)
+	defer func() {
+		err := re.Store().Close()
+		require.NoError(t, err)
+	}()
+
+	tc := &infoschemaTestContext{
+		t:   t,
+		re:  re,
+		ctx: kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL),
+	}
+	testkit.EnableFailPoint(t, "github.com/pingcap/tidb/pkg/infoschema/verifyApplyDiff", `return("panic")`)
+	tc.runCreateSchema()
+	tc.runCreateTable("t1")
+	tc.runDropTable("t2")
+
+	// The diff of t3 is lost, so the infoschema diverges from the meta after the diff of t4 is applied.
+	t3 := tc.createTable("t3")
+	t4 := tc.createTable("t4")
+	diff := &model.SchemaDiff{Type: model.ActionCreateTable, SchemaID: tc.dbInfo.ID, TableID: t4}
+	require.PanicsWithError(t, fmt.Sprintf("table test.t3 (ID %d) exists in the meta, but it's missing in the infoschema", t3), func() {
+		_ = tc.applyDiff(diff)
+	})
+
+	// The divergence is only logged if the value of the failpoint is "log".
+	testkit.EnableFailPoint(t, "github.com/pingcap/tidb/pkg/infoschema/verifyApplyDiff", `return("log")`)
+	require.NoError(t, tc.applyDiff(diff))
+	_, ok := tc.is.TableByID(t4)
+	require.True(t, ok)
+}
+
+func TestShareTablesAcrossVersions(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (a int)")
+	tk.MustExec("create table t2 (a int)")
+	cache := dom.InfoCache()
+	tableByName := func(name string) table.Table {
+		tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr(name))
+		require.NoError(t, err)
+		return tbl
+	}
+	t1, oldT2 := tableByName("t1"), tableByName("t2")
+
+	// The unchanged tables are shared with the versions loaded by applying the diffs.
+	tk.MustExec("alter table t2 add column b int")
+	require.True(t, t1 == tableByName("t1"))
+	t2 := tableByName("t2")
+	require.False(t, oldT2 == t2)
+	require.Greater(t, cache.TableRefs(t1), cache.TableRefs(t2))
+
+	// The unchanged tables are shared with the version fully loaded too.
+	testkit.InjectStaleSchemaDiff(t, dom.InfoSchema().SchemaMetaVersion()+1)
+	tk.MustExec("create table t3 (a int)")
+	require.True(t, t1 == tableByName("t1"))
+	require.True(t, t2 == tableByName("t2"))
+
+	// The tables are released once no cached version references them.
+	require.Greater(t, cache.TableRefs(oldT2), 0)
+	shared := cache.SharedTables()
+	cache.ReSize(1)
+	require.Equal(t, 0, cache.TableRefs(oldT2))
+	require.Equal(t, 1, cache.TableRefs(t1))
+	require.Less(t, cache.SharedTables(), shared)
+}
+
+func TestListTablesWithSpecialAttribute(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t_plain (a int, t datetime)")
+	tk.MustExec("create table t_ttl (a int, t datetime) ttl = t + interval 1 day")
+	tk.MustExec("create table t_parent (a int primary key)")
+	tk.MustExec("create table t_child (a int, foreign key (a) references t_parent(a))")
+	tk.MustExec("create table t_cached (a int)")
+	tk.MustExec("alter table t_cached cache")
+	tk.MustExec("create database test2")
+	tk.MustExec("create table test2.t_ttl (a int, t datetime) ttl = t + interval 1 day")
+
+	listTables := func(attr infoschema.SpecialAttribute) []string {
+		var names []string
+		for _, r := range dom.InfoSchema().ListTablesWithSpecialAttribute(attr) {
+			for _, tbl := range r.Tables {
+				names = append(names, r.DBName.L+"."+tbl.Meta().Name.L)
+			}
+		}
+		return names
+	}
+	require.Equal(t, []string{"test.t_ttl", "test2.t_ttl"}, listTables(infoschema.TTLAttribute))
+	require.Equal(t, []string{"test.t_child"}, listTables(infoschema.ForeignKeysAttribute))
+	require.Equal(t, []string{"test.t_cached"}, listTables(infoschema.CachedTableAttribute))
+	require.Equal(t, []string{"test.t_ttl", "test.t_child", "test.t_cached", "test2.t_ttl"},
+		listTables(infoschema.TTLAttribute|infoschema.ForeignKeysAttribute|infoschema.CachedTableAttribute))
+
+	// The index is updated by the schema diffs.
+	tk.MustExec("alter table t_ttl remove ttl")
+	tk.MustExec("alter table t_plain ttl = t + interval 1 day")
+	tk.MustExec("drop database test2")
+	tk.MustExec("alter table t_cached nocache")
+	require.Equal(t, []string{"test.t_plain"}, listTables(infoschema.TTLAttribute))
+	require.Empty(t, listTables(infoschema.CachedTableAttribute))
+}
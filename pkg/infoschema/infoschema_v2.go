@@ -20,10 +20,12 @@ import (
 	"sync"
 
 	"github.com/pingcap/errors"
+	infoschema_metrics "github.com/pingcap/tidb/pkg/infoschema/metrics"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
 	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/table/tables"
 	"github.com/pingcap/tidb/pkg/util"
@@ -130,6 +132,10 @@ func (isd *Data) getVersionByTSNoLock(ts uint64) (int64, bool) {
 	return 0, false
 }
 
+// tableCacheCapacity is the max number of tables in Data.tableCache.
+// TODO: limit by size instead of by table count.
+const tableCacheCapacity = 1000
+
 type tableCacheKey struct {
 	tableID       int64
 	schemaVersion int64
@@ -138,11 +144,10 @@ type tableCacheKey struct {
 // NewData creates an infoschema V2 data struct.
 func NewData() *Data {
 	ret := &Data{
-		byID:      btree.NewBTreeG[tableItem](compareByID),
-		byName:    btree.NewBTreeG[tableItem](compareByName),
-		schemaMap: btree.NewBTreeG[schemaItem](compareSchemaItem),
-		// TODO: limit by size instead of by table count.
-		tableCache: sieve.New[tableCacheKey, table.Table](1000),
+		byID:       btree.NewBTreeG[tableItem](compareByID),
+		byName:     btree.NewBTreeG[tableItem](compareByName),
+		schemaMap:  btree.NewBTreeG[schemaItem](compareSchemaItem),
+		tableCache: sieve.New[tableCacheKey, table.Table](tableCacheCapacity),
 		specials:   make(map[string]*schemaTables),
 	}
 	return ret
@@ -151,7 +156,16 @@ func NewData() *Data {
 func (isd *Data) add(item tableItem, tbl table.Table) {
 	isd.byID.Set(item)
 	isd.byName.Set(item)
-	isd.tableCache.Set(tableCacheKey{item.tableID, item.schemaVersion}, tbl)
+	isd.cacheTable(tableCacheKey{item.tableID, item.schemaVersion}, tbl)
+}
+
+// cacheTable puts the table into the table cache, and records the eviction if the cache is full.
+func (isd *Data) cacheTable(key tableCacheKey, tbl table.Table) {
+	if isd.tableCache.Len() >= tableCacheCapacity && !isd.tableCache.Contains(key) {
+		infoschema_metrics.TableCacheEvictCounter.Inc()
+	}
+	isd.tableCache.Set(key, tbl)
+	infoschema_metrics.TableCacheTableCount.Set(float64(isd.tableCache.Len()))
 }
 
 func (isd *Data) addSpecialDB(di *model.DBInfo, tables *schemaTables) {
@@ -243,6 +257,7 @@ func (is *infoschemaV2) TableByID(id int64) (val table.Table, ok bool) {
 	key := tableCacheKey{id, is.schemaVersion}
 	tbl, found := is.tableCache.Get(key)
 	if found && tbl != nil {
+		infoschema_metrics.TableCacheHitCounter.Inc()
 		return tbl, true
 	}
 
@@ -262,9 +277,10 @@ func (is *infoschemaV2) TableByID(id int64) (val table.Table, ok bool) {
 	}
 
 	// Maybe the table is evicted? need to reload.
+	infoschema_metrics.TableCacheMissCounter.Inc()
 	ret, err := loadTableInfo(is.r, is.Data, id, itm.dbID, is.ts, is.schemaVersion)
 	if err == nil {
-		is.tableCache.Set(key, ret)
+		is.cacheTable(key, ret)
 		return ret, true
 	}
 	return nil, false
@@ -289,23 +305,66 @@ func (is *infoschemaV2) TableByName(schema, tbl model.CIStr) (t table.Table, err
 	eq := func(a, b *tableItem) bool { return a.dbName == b.dbName && a.tableName == b.tableName }
 	itm, ok := search(is.byName, is.schemaVersion, tableItem{dbName: schema.L, tableName: tbl.L, tableID: math.MaxInt64}, eq)
 	if !ok {
-		// TODO: in the future, this may happen and we need to check tikv to see whether table exists.
-		return nil, ErrTableNotExists.GenWithStackByArgs(schema, tbl)
+		return is.tableByNameFromMeta(schema, tbl)
 	}
 
 	// Get from the cache.
 	key := tableCacheKey{itm.tableID, is.schemaVersion}
 	res, found := is.tableCache.Get(key)
 	if found && res != nil {
+		infoschema_metrics.TableCacheHitCounter.Inc()
 		return res, nil
 	}
 
 	// Maybe the table is evicted? need to reload.
+	infoschema_metrics.TableCacheMissCounter.Inc()
 	ret, err := loadTableInfo(is.r, is.Data, itm.tableID, itm.dbID, is.ts, is.schemaVersion)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	is.tableCache.Set(key, ret)
+	is.cacheTable(key, ret)
+	return ret, nil
+}
+
+// tableByNameFromMeta looks up the table missing in the name index by the table names in the meta, so a miss costs
+// one meta get instead of listing all the tables of the schema. The table names are only maintained in the meta
+// when the fast create table is enabled.
+func (is *infoschemaV2) tableByNameFromMeta(schema, tbl model.CIStr) (table.Table, error) {
+	if !variable.EnableFastCreateTable.Load() {
+		return nil, ErrTableNotExists.GenWithStackByArgs(schema, tbl)
+	}
+	dbInfo, ok := is.SchemaByName(schema)
+	if !ok {
+		return nil, ErrTableNotExists.GenWithStackByArgs(schema, tbl)
+	}
+	snapshot := is.r.Store().GetSnapshot(kv.NewVersion(is.ts))
+	// Using the KV timeout read feature to address the issue of potential DDL lease expiration when
+	// the meta region leader is slow.
+	snapshot.SetOption(kv.TiKVClientReadTimeout, uint64(3000)) // 3000ms.
+	m := meta.NewSnapshotMeta(snapshot)
+	if is.ts == math.MaxUint64 {
+		// The snapshot is the latest one, the table found in it may be created after the schema version.
+		ver, err := m.GetSchemaVersion()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ver > is.schemaVersion {
+			return nil, ErrTableNotExists.GenWithStackByArgs(schema, tbl)
+		}
+	}
+	tblID, err := m.GetTableIDByName(schema.L, tbl.L)
+	if err != nil {
+		if meta.ErrTableNotExists.Equal(err) {
+			return nil, ErrTableNotExists.GenWithStackByArgs(schema, tbl)
+		}
+		return nil, errors.Trace(err)
+	}
+	infoschema_metrics.TableCacheMissCounter.Inc()
+	ret, err := loadTableInfo(is.r, is.Data, tblID, dbInfo.ID, is.ts, is.schemaVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	is.cacheTable(tableCacheKey{tblID, is.schemaVersion}, ret)
 	return ret, nil
 }
 
@@ -437,6 +496,10 @@ func (is *infoschemaV2) SchemaTables(schema model.CIStr) (tables []table.Table)
 	return
 }
 
+func (is *infoschemaV2) ListTablesWithSpecialAttribute(attr SpecialAttribute) []TableInfoResult {
+	return listTablesWithSpecialAttribute(is, is.infoSchema.specialTables, attr)
+}
+
 func loadTableInfo(r autoid.Requirement, infoData *Data, tblID, dbID int64, ts uint64, schemaVersion int64) (table.Table, error) {
 	// Try to avoid repeated concurrency loading.
 	res, err, _ := loadTableSF.Do(fmt.Sprintf("%d-%d-%d", dbID, tblID, schemaVersion), func() (ret any, err error) {
@@ -0,0 +1,176 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infoschema
+
+import (
+	"math"
+	"sync"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/table"
+)
+
+// tableInterner shares the tables of the unchanged *model.TableInfo across the InfoSchema versions in the
+// InfoCache. The tables are naturally shared when the InfoSchema is built by applying the schema diffs, but a
+// full load used to wrap all the tables again, so the versions before and after a full load shared nothing.
+// With the interner, a full load reuses the table of the latest version if its meta is unchanged, so keeping N
+// versions costs roughly the memory of one version plus the changes between them.
+//
+// An interned table is referenced by the versions in [since, until), so the references are maintained in
+// O(changes) when a new version is cached, and the table is released once no cached version is in the range.
+type tableInterner struct {
+	mu sync.Mutex
+	// version is the schema version of the latest InfoSchema committed to the interner.
+	version int64
+	// latest are the tables of the latest version by the table ID.
+	latest map[int64]*internedTable
+	// released are the tables no longer in the latest version, they are ordered by until.
+	released []*internedTable
+}
+
+type internedTable struct {
+	tbl   table.Table
+	since int64
+	until int64
+}
+
+// tableChanges are the tables added and dropped by a builder, they're committed to the interner when the built
+// InfoSchema is cached as the latest version.
+type tableChanges struct {
+	// full means the InfoSchema is fully loaded, so all its tables are in ops.
+	full bool
+	// base is the version the InfoSchema is built from if it's not fully loaded.
+	base int64
+	// ops are in the order of the changes, a nil table means the table is dropped.
+	ops []tableChange
+}
+
+type tableChange struct {
+	id  int64
+	tbl table.Table
+}
+
+func newTableInterner() *tableInterner {
+	return &tableInterner{latest: make(map[int64]*internedTable)}
+}
+
+// get returns the table of the latest version if its meta is the same as the table info, or nil if there is none.
+// The tables without UpdateTS are never shared, because the changes of them can't be told.
+func (t *tableInterner) get(tblInfo *model.TableInfo) table.Table {
+	if tblInfo.UpdateTS == 0 {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, ok := t.latest[tblInfo.ID]
+	if !ok {
+		return nil
+	}
+	if old := e.tbl.Meta(); old.UpdateTS != tblInfo.UpdateTS || old.Name.L != tblInfo.Name.L {
+		return nil
+	}
+	return e.tbl
+}
+
+// commit makes the tables of the InfoSchema of the version the latest ones.
+func (t *tableInterner) commit(version int64, changes *tableChanges) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !changes.full && changes.base != t.version {
+		// The InfoSchema isn't built from the latest committed one, the changes can't be applied.
+		return
+	}
+	if changes.full {
+		old := t.latest
+		t.latest = make(map[int64]*internedTable, len(changes.ops))
+		for _, op := range changes.ops {
+			if e, ok := old[op.id]; ok && e.tbl == op.tbl {
+				t.latest[op.id] = e
+				delete(old, op.id)
+				continue
+			}
+			t.latest[op.id] = &internedTable{tbl: op.tbl, since: version, until: math.MaxInt64}
+		}
+		for _, e := range old {
+			t.releaseTable(e, version)
+		}
+	} else {
+		for _, op := range changes.ops {
+			if e, ok := t.latest[op.id]; ok {
+				if e.tbl == op.tbl {
+					continue
+				}
+				delete(t.latest, op.id)
+				t.releaseTable(e, version)
+			}
+			if op.tbl != nil {
+				t.latest[op.id] = &internedTable{tbl: op.tbl, since: version, until: math.MaxInt64}
+			}
+		}
+	}
+	t.version = version
+}
+
+func (t *tableInterner) releaseTable(e *internedTable, version int64) {
+	e.until = version
+	if e.since < e.until {
+		t.released = append(t.released, e)
+	}
+}
+
+// gc drops the released tables which are not referenced by the versions since the oldest one.
+func (t *tableInterner) gc(oldest int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	i := 0
+	for i < len(t.released) && t.released[i].until <= oldest {
+		t.released[i] = nil
+		i++
+	}
+	t.released = t.released[i:]
+}
+
+// refs returns the number of the versions referencing the interned table.
+func (t *tableInterner) refs(tbl table.Table, versions []int64) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, ok := t.latest[tbl.Meta().ID]
+	if !ok || e.tbl != tbl {
+		e = nil
+		for _, released := range t.released {
+			if released.tbl == tbl {
+				e = released
+				break
+			}
+		}
+	}
+	if e == nil {
+		return 0
+	}
+	cnt := 0
+	for _, v := range versions {
+		if e.since <= v && v < e.until {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// size returns the number of the interned tables, including the released ones still referenced.
+func (t *tableInterner) size() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.latest) + len(t.released)
+}
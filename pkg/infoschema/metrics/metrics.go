@@ -15,6 +15,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -34,6 +36,18 @@ var (
 	LoadSchemaDurationTotal    prometheus.Observer
 	LoadSchemaDurationLoadDiff prometheus.Observer
 	LoadSchemaDurationLoadAll  prometheus.Observer
+
+	FullLoadDurationOK  prometheus.Observer
+	FullLoadDurationErr prometheus.Observer
+	DiffLoadDurationOK  prometheus.Observer
+	DiffLoadDurationErr prometheus.Observer
+	SchemaSyncLag       prometheus.Observer
+	SchemaVersion       prometheus.Gauge
+
+	TableCacheHitCounter   prometheus.Counter
+	TableCacheMissCounter  prometheus.Counter
+	TableCacheEvictCounter prometheus.Counter
+	TableCacheTableCount   prometheus.Gauge
 )
 
 func init() {
@@ -55,4 +69,21 @@ func InitMetricsVars() {
 	LoadSchemaDurationTotal = metrics.LoadSchemaDuration.WithLabelValues("total")
 	LoadSchemaDurationLoadDiff = metrics.LoadSchemaDuration.WithLabelValues("load-diff")
 	LoadSchemaDurationLoadAll = metrics.LoadSchemaDuration.WithLabelValues("load-all")
+
+	FullLoadDurationOK = metrics.InfoSchemaLoadDuration.WithLabelValues("full", "ok")
+	FullLoadDurationErr = metrics.InfoSchemaLoadDuration.WithLabelValues("full", "err")
+	DiffLoadDurationOK = metrics.InfoSchemaLoadDuration.WithLabelValues("diff", "ok")
+	DiffLoadDurationErr = metrics.InfoSchemaLoadDuration.WithLabelValues("diff", "err")
+	SchemaSyncLag = metrics.SchemaSyncLagDuration
+	SchemaVersion = metrics.SchemaVersionGauge
+
+	TableCacheHitCounter = metrics.InfoSchemaV2CacheCounter.WithLabelValues("hit")
+	TableCacheMissCounter = metrics.InfoSchemaV2CacheCounter.WithLabelValues("miss")
+	TableCacheEvictCounter = metrics.InfoSchemaV2CacheCounter.WithLabelValues("evict")
+	TableCacheTableCount = metrics.InfoSchemaV2CacheTableCount
+}
+
+// ObserveApplyDiffDuration observes the duration of applying a schema diff of the action type.
+func ObserveApplyDiffDuration(actionType string, d time.Duration) {
+	metrics.InfoSchemaApplyDiffDuration.WithLabelValues(actionType).Observe(d.Seconds())
 }
@@ -17,9 +17,12 @@ package infoschema
 import (
 	"bytes"
 	"fmt"
+	"hash/fnv"
+	"reflect"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
@@ -36,21 +39,27 @@ const (
 	promQRangeDurationKey   = "$RANGE_DURATION"
 )
 
+// MaxUserMetricTables is the max number of user-defined metric tables.
+const MaxUserMetricTables = 1024
+
+// userMetricTableIDBase is the base of the user-defined metric table IDs, it's far away from the IDs of
+// the built-in metric tables and the other virtual schemas.
+const userMetricTableIDBase = autoid.MetricSchemaDBID + 1<<32
+
+// userMetricTables records the user-defined metric tables loaded from mysql.metric_table_defs.
+var userMetricTables struct {
+	sync.RWMutex
+	defs map[string]MetricTableDef
+}
+
 func init() {
 	// Initialize the metric schema database and register the driver to `drivers`.
 	dbID := autoid.MetricSchemaDBID
 	tableID := dbID + 1
 	metricTables := make([]*model.TableInfo, 0, len(MetricTableMap))
 	for name, def := range MetricTableMap {
-		cols := def.genColumnInfos()
-		tableInfo := buildTableMeta(name, cols)
-		tableInfo.ID = tableID
-		tableInfo.Comment = def.Comment
-		tableInfo.DBID = dbID
+		metricTables = append(metricTables, def.buildTableInfo(name, tableID))
 		tableID++
-		metricTables = append(metricTables, tableInfo)
-		tableInfo.MaxColumnID = int64(len(tableInfo.Columns))
-		tableInfo.MaxIndexID = int64(len(tableInfo.Indices))
 	}
 	dbInfo := &model.DBInfo{
 		ID:      dbID,
@@ -59,7 +68,126 @@ func init() {
 		Collate: mysql.DefaultCollationName,
 		Tables:  metricTables,
 	}
-	RegisterVirtualTable(dbInfo, tableFromMeta)
+	registerVirtualTableWithExtraTables(dbInfo, tableFromMeta, userMetricTableInfos)
+}
+
+func (def *MetricTableDef) buildTableInfo(name string, tableID int64) *model.TableInfo {
+	cols := def.genColumnInfos()
+	tableInfo := buildTableMeta(name, cols)
+	tableInfo.ID = tableID
+	tableInfo.Comment = def.Comment
+	tableInfo.DBID = autoid.MetricSchemaDBID
+	tableInfo.MaxColumnID = int64(len(tableInfo.Columns))
+	tableInfo.MaxIndexID = int64(len(tableInfo.Indices))
+	return tableInfo
+}
+
+// UserMetricTableID returns the ID of the user-defined metric table. It's derived from the name, so the ID
+// is the same on all the TiDB instances and doesn't change when the other metric tables are added or removed.
+func UserMetricTableID(name string) int64 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return userMetricTableIDBase + int64(h.Sum32())
+}
+
+// userMetricTableInfos builds the table infos of the user-defined metric tables.
+func userMetricTableInfos() []*model.TableInfo {
+	userMetricTables.RLock()
+	defer userMetricTables.RUnlock()
+	names := make([]string, 0, len(userMetricTables.defs))
+	for name := range userMetricTables.defs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	tblInfos := make([]*model.TableInfo, 0, len(names))
+	for _, name := range names {
+		def := userMetricTables.defs[name]
+		tblInfos = append(tblInfos, def.buildTableInfo(name, UserMetricTableID(name)))
+	}
+	return tblInfos
+}
+
+// ValidateUserMetricTableDef checks whether the user-defined metric table is valid.
+func ValidateUserMetricTableDef(name string, def *MetricTableDef) error {
+	if name == "" {
+		return errors.New("empty metric table name")
+	}
+	if name != strings.ToLower(name) {
+		return errors.Errorf("metric table name '%s' should be in lower case", name)
+	}
+	if _, ok := MetricTableMap[name]; ok {
+		return errors.Errorf("metric table '%s' is a built-in metric table", name)
+	}
+	if strings.TrimSpace(def.PromQL) == "" {
+		return errors.Errorf("empty PromQL for metric table '%s'", name)
+	}
+	if def.Quantile < 0 || def.Quantile >= 1 {
+		return errors.Errorf("invalid quantile %v for metric table '%s'", def.Quantile, name)
+	}
+	if def.Quantile > 0 && !strings.Contains(def.PromQL, promQLQuantileKey) {
+		return errors.Errorf("PromQL of metric table '%s' should contain %s", name, promQLQuantileKey)
+	}
+	labels := make(map[string]struct{}, len(def.Labels))
+	for _, label := range def.Labels {
+		switch label {
+		case "":
+			return errors.Errorf("empty label for metric table '%s'", name)
+		case "time", "quantile", "value":
+			return errors.Errorf("label '%s' of metric table '%s' is reserved", label, name)
+		}
+		if _, ok := labels[label]; ok {
+			return errors.Errorf("duplicated label '%s' for metric table '%s'", label, name)
+		}
+		labels[label] = struct{}{}
+	}
+	return nil
+}
+
+// SetUserMetricTables replaces the user-defined metric tables and returns whether they're changed.
+// They are added to metrics_schema when the InfoSchema is fully loaded next time.
+func SetUserMetricTables(defs map[string]MetricTableDef) (bool, error) {
+	if len(defs) > MaxUserMetricTables {
+		return false, errors.Errorf("too many user-defined metric tables, the max is %d", MaxUserMetricTables)
+	}
+	ids := make(map[int64]string, len(defs))
+	for name, def := range defs {
+		if err := ValidateUserMetricTableDef(name, &def); err != nil {
+			return false, err
+		}
+		id := UserMetricTableID(name)
+		if other, ok := ids[id]; ok {
+			return false, errors.Errorf("metric table '%s' and '%s' have the same table ID, rename one of them", other, name)
+		}
+		ids[id] = name
+	}
+	userMetricTables.Lock()
+	defer userMetricTables.Unlock()
+	if len(defs) == 0 && len(userMetricTables.defs) == 0 || reflect.DeepEqual(defs, userMetricTables.defs) {
+		return false, nil
+	}
+	userMetricTables.defs = defs
+	return true, nil
+}
+
+// GetMetricTableDefs returns the definitions of all the built-in and user-defined metric tables.
+func GetMetricTableDefs() map[string]MetricTableDef {
+	userMetricTables.RLock()
+	defer userMetricTables.RUnlock()
+	defs := make(map[string]MetricTableDef, len(MetricTableMap)+len(userMetricTables.defs))
+	for name, def := range MetricTableMap {
+		defs[name] = def
+	}
+	for name, def := range userMetricTables.defs {
+		defs[name] = def
+	}
+	return defs
+}
+
+func getUserMetricTableDef(lowerTableName string) (MetricTableDef, bool) {
+	userMetricTables.RLock()
+	defer userMetricTables.RUnlock()
+	def, ok := userMetricTables.defs[lowerTableName]
+	return def, ok
 }
 
 // MetricTableDef is the metric table define.
@@ -72,7 +200,10 @@ type MetricTableDef struct {
 
 // IsMetricTable uses to checks whether the table is a metric table.
 func IsMetricTable(lowerTableName string) bool {
-	_, ok := MetricTableMap[lowerTableName]
+	if _, ok := MetricTableMap[lowerTableName]; ok {
+		return true
+	}
+	_, ok := getUserMetricTableDef(lowerTableName)
 	return ok
 }
 
@@ -80,7 +211,9 @@ func IsMetricTable(lowerTableName string) bool {
 func GetMetricTableDef(lowerTableName string) (*MetricTableDef, error) {
 	def, ok := MetricTableMap[lowerTableName]
 	if !ok {
-		return nil, errors.Errorf("can not find metric table: %v", lowerTableName)
+		if def, ok = getUserMetricTableDef(lowerTableName); !ok {
+			return nil, errors.Errorf("can not find metric table: %v", lowerTableName)
+		}
 	}
 	return &def, nil
 }
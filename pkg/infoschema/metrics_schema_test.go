@@ -67,3 +67,52 @@ func TestMetricSchemaDef(t *testing.T) {
 		require.NoError(t, err, "fail to parser PromQL %s", def.PromQL)
 	}
 }
+
+func TestUserMetricTables(t *testing.T) {
+	defer func() {
+		_, err := infoschema.SetUserMetricTables(nil)
+		require.NoError(t, err)
+	}()
+	cases := []struct {
+		name string
+		def  infoschema.MetricTableDef
+		err  string
+	}{
+		{"", infoschema.MetricTableDef{PromQL: "up"}, "empty metric table name"},
+		{"My_Table", infoschema.MetricTableDef{PromQL: "up"}, "should be in lower case"},
+		{"tidb_query_duration", infoschema.MetricTableDef{PromQL: "up"}, "is a built-in metric table"},
+		{"my_table", infoschema.MetricTableDef{PromQL: " "}, "empty PromQL"},
+		{"my_table", infoschema.MetricTableDef{PromQL: "up", Quantile: 0.9}, "$QUANTILE"},
+		{"my_table", infoschema.MetricTableDef{PromQL: "up{$LABEL_CONDITIONS}", Labels: []string{"time"}}, "reserved"},
+		{"my_table", infoschema.MetricTableDef{PromQL: "up{$LABEL_CONDITIONS}", Labels: []string{"instance", "instance"}}, "duplicate"},
+	}
+	for _, c := range cases {
+		require.ErrorContains(t, infoschema.ValidateUserMetricTableDef(c.name, &c.def), c.err, c.name)
+	}
+
+	def := infoschema.MetricTableDef{PromQL: "up{$LABEL_CONDITIONS}", Labels: []string{"instance", "job"}, Comment: "targets up"}
+	changed, err := infoschema.SetUserMetricTables(map[string]infoschema.MetricTableDef{"my_up": def})
+	require.NoError(t, err)
+	require.True(t, changed)
+	changed, err = infoschema.SetUserMetricTables(map[string]infoschema.MetricTableDef{"my_up": def})
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.True(t, infoschema.IsMetricTable("my_up"))
+	got, err := infoschema.GetMetricTableDef("my_up")
+	require.NoError(t, err)
+	require.Equal(t, def, *got)
+	defs := infoschema.GetMetricTableDefs()
+	require.Len(t, defs, len(infoschema.MetricTableMap)+1)
+
+	_, err = infoschema.SetUserMetricTables(map[string]infoschema.MetricTableDef{"up": {}})
+	require.Error(t, err)
+	require.True(t, infoschema.IsMetricTable("my_up"))
+	changed, err = infoschema.SetUserMetricTables(nil)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.False(t, infoschema.IsMetricTable("my_up"))
+
+	// The table IDs are derived from the names.
+	require.Equal(t, infoschema.UserMetricTableID("my_up"), infoschema.UserMetricTableID("my_up"))
+	require.NotEqual(t, infoschema.UserMetricTableID("my_up"), infoschema.UserMetricTableID("a_up"))
+}
@@ -27,6 +27,8 @@ var perfSchemaTables = []string{
 	tableStmtsCurrent,
 	tableStmtsHistory,
 	tableStmtsHistoryLong,
+	tableWaitsHistory,
+	tableWaitsSummaryGlobalByEventName,
 	tablePreparedStmtsInstances,
 	tableTransCurrent,
 	tableTransHistory,
@@ -100,7 +102,7 @@ const tableStmtsCurrent = "CREATE TABLE if not exists performance_schema." + tab
 	"TIMER_WAIT		BIGINT(20) UNSIGNED," +
 	"LOCK_TIME		BIGINT(20) UNSIGNED NOT NULL," +
 	"SQL_TEXT		LONGTEXT," +
-	"DIGEST			VARCHAR(32)," +
+	"DIGEST			VARCHAR(64)," +
 	"DIGEST_TEXT		LONGTEXT," +
 	"CURRENT_SCHEMA	VARCHAR(64)," +
 	"OBJECT_TYPE		VARCHAR(64)," +
@@ -144,7 +146,7 @@ const tableStmtsHistory = "CREATE TABLE if not exists performance_schema." + tab
 	"TIMER_WAIT		BIGINT(20) UNSIGNED," +
 	"LOCK_TIME		BIGINT(20) UNSIGNED NOT NULL," +
 	"SQL_TEXT		LONGTEXT," +
-	"DIGEST			VARCHAR(32)," +
+	"DIGEST			VARCHAR(64)," +
 	"DIGEST_TEXT		LONGTEXT," +
 	"CURRENT_SCHEMA 	VARCHAR(64)," +
 	"OBJECT_TYPE		VARCHAR(64)," +
@@ -188,7 +190,7 @@ const tableStmtsHistoryLong = "CREATE TABLE if not exists performance_schema." +
 	"TIMER_WAIT		BIGINT(20) UNSIGNED," +
 	"LOCK_TIME		BIGINT(20) UNSIGNED NOT NULL," +
 	"SQL_TEXT		LONGTEXT," +
-	"DIGEST			VARCHAR(32)," +
+	"DIGEST			VARCHAR(64)," +
 	"DIGEST_TEXT		LONGTEXT," +
 	"CURRENT_SCHEMA	VARCHAR(64)," +
 	"OBJECT_TYPE		VARCHAR(64)," +
@@ -220,6 +222,38 @@ const tableStmtsHistoryLong = "CREATE TABLE if not exists performance_schema." +
 	"NESTING_EVENT_TYPE		ENUM('TRANSACTION','STATEMENT','STAGE')," +
 	"NESTING_EVENT_LEVEL		INT(11));"
 
+// tableWaitsHistory contains the column name definitions for table events_waits_history, same as MySQL.
+const tableWaitsHistory = "CREATE TABLE if not exists performance_schema." + tableNameEventsWaitsHistory + " (" +
+	"THREAD_ID		BIGINT(20) UNSIGNED NOT NULL," +
+	"EVENT_ID		BIGINT(20) UNSIGNED NOT NULL," +
+	"END_EVENT_ID	BIGINT(20) UNSIGNED," +
+	"EVENT_NAME		VARCHAR(128) NOT NULL," +
+	"SOURCE			VARCHAR(64)," +
+	"TIMER_START		BIGINT(20) UNSIGNED," +
+	"TIMER_END		BIGINT(20) UNSIGNED," +
+	"TIMER_WAIT		BIGINT(20) UNSIGNED," +
+	"SPINS			INT(10) UNSIGNED," +
+	"OBJECT_SCHEMA	VARCHAR(64)," +
+	"OBJECT_NAME		VARCHAR(512)," +
+	"INDEX_NAME		VARCHAR(64)," +
+	"OBJECT_TYPE		VARCHAR(64)," +
+	"OBJECT_INSTANCE_BEGIN	BIGINT(20) UNSIGNED NOT NULL," +
+	"NESTING_EVENT_ID		BIGINT(20) UNSIGNED," +
+	"NESTING_EVENT_TYPE		ENUM('TRANSACTION','STATEMENT','STAGE','WAIT')," +
+	"OPERATION		VARCHAR(32) NOT NULL," +
+	"NUMBER_OF_BYTES	BIGINT(20)," +
+	"FLAGS			INT(10) UNSIGNED);"
+
+// tableWaitsSummaryGlobalByEventName contains the column name definitions for table
+// events_waits_summary_global_by_event_name, same as MySQL.
+const tableWaitsSummaryGlobalByEventName = "CREATE TABLE if not exists performance_schema." + tableNameEventsWaitsSummaryGlobalByEventName + " (" +
+	"EVENT_NAME		VARCHAR(128) NOT NULL," +
+	"COUNT_STAR		BIGINT(20) UNSIGNED NOT NULL," +
+	"SUM_TIMER_WAIT	BIGINT(20) UNSIGNED NOT NULL," +
+	"MIN_TIMER_WAIT	BIGINT(20) UNSIGNED NOT NULL," +
+	"AVG_TIMER_WAIT	BIGINT(20) UNSIGNED NOT NULL," +
+	"MAX_TIMER_WAIT	BIGINT(20) UNSIGNED NOT NULL);"
+
 // tablePreparedStmtsInstances contains the column name definitions for table prepared_statements_instances, same as MySQL.
 const tablePreparedStmtsInstances = "CREATE TABLE if not exists performance_schema." + tableNamePreparedStatementsInstances + " (" +
 	"OBJECT_INSTANCE_BEGIN	BIGINT(20) UNSIGNED NOT NULL," +
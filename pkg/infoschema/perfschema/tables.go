@@ -30,86 +30,93 @@ import (
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
 	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/privilege"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/table/tables"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/perfevents"
 	"github.com/pingcap/tidb/pkg/util/profile"
 	pd "github.com/tikv/pd/client/http"
 )
 
 const (
-	tableNameGlobalStatus                    = "global_status"
-	tableNameSessionStatus                   = "session_status"
-	tableNameSetupActors                     = "setup_actors"
-	tableNameSetupObjects                    = "setup_objects"
-	tableNameSetupInstruments                = "setup_instruments"
-	tableNameSetupConsumers                  = "setup_consumers"
-	tableNameEventsStatementsCurrent         = "events_statements_current"
-	tableNameEventsStatementsHistory         = "events_statements_history"
-	tableNameEventsStatementsHistoryLong     = "events_statements_history_long"
-	tableNamePreparedStatementsInstances     = "prepared_statements_instances"
-	tableNameEventsTransactionsCurrent       = "events_transactions_current"
-	tableNameEventsTransactionsHistory       = "events_transactions_history"
-	tableNameEventsTransactionsHistoryLong   = "events_transactions_history_long"
-	tableNameEventsStagesCurrent             = "events_stages_current"
-	tableNameEventsStagesHistory             = "events_stages_history"
-	tableNameEventsStagesHistoryLong         = "events_stages_history_long"
-	tableNameEventsStatementsSummaryByDigest = "events_statements_summary_by_digest"
-	tableNameTiDBProfileCPU                  = "tidb_profile_cpu"
-	tableNameTiDBProfileMemory               = "tidb_profile_memory"
-	tableNameTiDBProfileMutex                = "tidb_profile_mutex"
-	tableNameTiDBProfileAllocs               = "tidb_profile_allocs"
-	tableNameTiDBProfileBlock                = "tidb_profile_block"
-	tableNameTiDBProfileGoroutines           = "tidb_profile_goroutines"
-	tableNameTiKVProfileCPU                  = "tikv_profile_cpu"
-	tableNamePDProfileCPU                    = "pd_profile_cpu"
-	tableNamePDProfileMemory                 = "pd_profile_memory"
-	tableNamePDProfileMutex                  = "pd_profile_mutex"
-	tableNamePDProfileAllocs                 = "pd_profile_allocs"
-	tableNamePDProfileBlock                  = "pd_profile_block"
-	tableNamePDProfileGoroutines             = "pd_profile_goroutines"
-	tableNameSessionAccountConnectAttrs      = "session_account_connect_attrs"
-	tableNameSessionConnectAttrs             = "session_connect_attrs"
-	tableNameSessionVariables                = "session_variables"
+	tableNameGlobalStatus                        = "global_status"
+	tableNameSessionStatus                       = "session_status"
+	tableNameSetupActors                         = "setup_actors"
+	tableNameSetupObjects                        = "setup_objects"
+	tableNameSetupInstruments                    = "setup_instruments"
+	tableNameSetupConsumers                      = "setup_consumers"
+	tableNameEventsStatementsCurrent             = "events_statements_current"
+	tableNameEventsStatementsHistory             = "events_statements_history"
+	tableNameEventsStatementsHistoryLong         = "events_statements_history_long"
+	tableNamePreparedStatementsInstances         = "prepared_statements_instances"
+	tableNameEventsTransactionsCurrent           = "events_transactions_current"
+	tableNameEventsTransactionsHistory           = "events_transactions_history"
+	tableNameEventsTransactionsHistoryLong       = "events_transactions_history_long"
+	tableNameEventsStagesCurrent                 = "events_stages_current"
+	tableNameEventsStagesHistory                 = "events_stages_history"
+	tableNameEventsStagesHistoryLong             = "events_stages_history_long"
+	tableNameEventsStatementsSummaryByDigest     = "events_statements_summary_by_digest"
+	tableNameTiDBProfileCPU                      = "tidb_profile_cpu"
+	tableNameTiDBProfileMemory                   = "tidb_profile_memory"
+	tableNameTiDBProfileMutex                    = "tidb_profile_mutex"
+	tableNameTiDBProfileAllocs                   = "tidb_profile_allocs"
+	tableNameTiDBProfileBlock                    = "tidb_profile_block"
+	tableNameTiDBProfileGoroutines               = "tidb_profile_goroutines"
+	tableNameTiKVProfileCPU                      = "tikv_profile_cpu"
+	tableNamePDProfileCPU                        = "pd_profile_cpu"
+	tableNamePDProfileMemory                     = "pd_profile_memory"
+	tableNamePDProfileMutex                      = "pd_profile_mutex"
+	tableNamePDProfileAllocs                     = "pd_profile_allocs"
+	tableNamePDProfileBlock                      = "pd_profile_block"
+	tableNamePDProfileGoroutines                 = "pd_profile_goroutines"
+	tableNameSessionAccountConnectAttrs          = "session_account_connect_attrs"
+	tableNameSessionConnectAttrs                 = "session_connect_attrs"
+	tableNameSessionVariables                    = "session_variables"
+	tableNameEventsWaitsHistory                  = "events_waits_history"
+	tableNameEventsWaitsSummaryGlobalByEventName = "events_waits_summary_global_by_event_name"
 )
 
 var tableIDMap = map[string]int64{
-	tableNameGlobalStatus:                    autoid.PerformanceSchemaDBID + 1,
-	tableNameSessionStatus:                   autoid.PerformanceSchemaDBID + 2,
-	tableNameSetupActors:                     autoid.PerformanceSchemaDBID + 3,
-	tableNameSetupObjects:                    autoid.PerformanceSchemaDBID + 4,
-	tableNameSetupInstruments:                autoid.PerformanceSchemaDBID + 5,
-	tableNameSetupConsumers:                  autoid.PerformanceSchemaDBID + 6,
-	tableNameEventsStatementsCurrent:         autoid.PerformanceSchemaDBID + 7,
-	tableNameEventsStatementsHistory:         autoid.PerformanceSchemaDBID + 8,
-	tableNameEventsStatementsHistoryLong:     autoid.PerformanceSchemaDBID + 9,
-	tableNamePreparedStatementsInstances:     autoid.PerformanceSchemaDBID + 10,
-	tableNameEventsTransactionsCurrent:       autoid.PerformanceSchemaDBID + 11,
-	tableNameEventsTransactionsHistory:       autoid.PerformanceSchemaDBID + 12,
-	tableNameEventsTransactionsHistoryLong:   autoid.PerformanceSchemaDBID + 13,
-	tableNameEventsStagesCurrent:             autoid.PerformanceSchemaDBID + 14,
-	tableNameEventsStagesHistory:             autoid.PerformanceSchemaDBID + 15,
-	tableNameEventsStagesHistoryLong:         autoid.PerformanceSchemaDBID + 16,
-	tableNameEventsStatementsSummaryByDigest: autoid.PerformanceSchemaDBID + 17,
-	tableNameTiDBProfileCPU:                  autoid.PerformanceSchemaDBID + 18,
-	tableNameTiDBProfileMemory:               autoid.PerformanceSchemaDBID + 19,
-	tableNameTiDBProfileMutex:                autoid.PerformanceSchemaDBID + 20,
-	tableNameTiDBProfileAllocs:               autoid.PerformanceSchemaDBID + 21,
-	tableNameTiDBProfileBlock:                autoid.PerformanceSchemaDBID + 22,
-	tableNameTiDBProfileGoroutines:           autoid.PerformanceSchemaDBID + 23,
-	tableNameTiKVProfileCPU:                  autoid.PerformanceSchemaDBID + 24,
-	tableNamePDProfileCPU:                    autoid.PerformanceSchemaDBID + 25,
-	tableNamePDProfileMemory:                 autoid.PerformanceSchemaDBID + 26,
-	tableNamePDProfileMutex:                  autoid.PerformanceSchemaDBID + 27,
-	tableNamePDProfileAllocs:                 autoid.PerformanceSchemaDBID + 28,
-	tableNamePDProfileBlock:                  autoid.PerformanceSchemaDBID + 29,
-	tableNamePDProfileGoroutines:             autoid.PerformanceSchemaDBID + 30,
-	tableNameSessionVariables:                autoid.PerformanceSchemaDBID + 31,
-	tableNameSessionConnectAttrs:             autoid.PerformanceSchemaDBID + 32,
-	tableNameSessionAccountConnectAttrs:      autoid.PerformanceSchemaDBID + 33,
+	tableNameGlobalStatus:                        autoid.PerformanceSchemaDBID + 1,
+	tableNameSessionStatus:                       autoid.PerformanceSchemaDBID + 2,
+	tableNameSetupActors:                         autoid.PerformanceSchemaDBID + 3,
+	tableNameSetupObjects:                        autoid.PerformanceSchemaDBID + 4,
+	tableNameSetupInstruments:                    autoid.PerformanceSchemaDBID + 5,
+	tableNameSetupConsumers:                      autoid.PerformanceSchemaDBID + 6,
+	tableNameEventsStatementsCurrent:             autoid.PerformanceSchemaDBID + 7,
+	tableNameEventsStatementsHistory:             autoid.PerformanceSchemaDBID + 8,
+	tableNameEventsStatementsHistoryLong:         autoid.PerformanceSchemaDBID + 9,
+	tableNamePreparedStatementsInstances:         autoid.PerformanceSchemaDBID + 10,
+	tableNameEventsTransactionsCurrent:           autoid.PerformanceSchemaDBID + 11,
+	tableNameEventsTransactionsHistory:           autoid.PerformanceSchemaDBID + 12,
+	tableNameEventsTransactionsHistoryLong:       autoid.PerformanceSchemaDBID + 13,
+	tableNameEventsStagesCurrent:                 autoid.PerformanceSchemaDBID + 14,
+	tableNameEventsStagesHistory:                 autoid.PerformanceSchemaDBID + 15,
+	tableNameEventsStagesHistoryLong:             autoid.PerformanceSchemaDBID + 16,
+	tableNameEventsStatementsSummaryByDigest:     autoid.PerformanceSchemaDBID + 17,
+	tableNameTiDBProfileCPU:                      autoid.PerformanceSchemaDBID + 18,
+	tableNameTiDBProfileMemory:                   autoid.PerformanceSchemaDBID + 19,
+	tableNameTiDBProfileMutex:                    autoid.PerformanceSchemaDBID + 20,
+	tableNameTiDBProfileAllocs:                   autoid.PerformanceSchemaDBID + 21,
+	tableNameTiDBProfileBlock:                    autoid.PerformanceSchemaDBID + 22,
+	tableNameTiDBProfileGoroutines:               autoid.PerformanceSchemaDBID + 23,
+	tableNameTiKVProfileCPU:                      autoid.PerformanceSchemaDBID + 24,
+	tableNamePDProfileCPU:                        autoid.PerformanceSchemaDBID + 25,
+	tableNamePDProfileMemory:                     autoid.PerformanceSchemaDBID + 26,
+	tableNamePDProfileMutex:                      autoid.PerformanceSchemaDBID + 27,
+	tableNamePDProfileAllocs:                     autoid.PerformanceSchemaDBID + 28,
+	tableNamePDProfileBlock:                      autoid.PerformanceSchemaDBID + 29,
+	tableNamePDProfileGoroutines:                 autoid.PerformanceSchemaDBID + 30,
+	tableNameSessionVariables:                    autoid.PerformanceSchemaDBID + 31,
+	tableNameSessionConnectAttrs:                 autoid.PerformanceSchemaDBID + 32,
+	tableNameSessionAccountConnectAttrs:          autoid.PerformanceSchemaDBID + 33,
+	tableNameEventsWaitsHistory:                  autoid.PerformanceSchemaDBID + 34,
+	tableNameEventsWaitsSummaryGlobalByEventName: autoid.PerformanceSchemaDBID + 35,
 }
 
 // perfSchemaTable stands for the fake table all its data is in the memory.
@@ -259,6 +266,16 @@ func (vt *perfSchemaTable) getRows(ctx context.Context, sctx sessionctx.Context,
 		fullRows, err = infoschema.GetDataFromSessionConnectAttrs(sctx, false)
 	case tableNameSessionAccountConnectAttrs:
 		fullRows, err = infoschema.GetDataFromSessionConnectAttrs(sctx, true)
+	case tableNameEventsStatementsCurrent:
+		fullRows = dataForStatementEvents(sctx, perfevents.StatementsCurrent())
+	case tableNameEventsStatementsHistory:
+		fullRows = dataForStatementEvents(sctx, perfevents.StatementsHistory())
+	case tableNameEventsStatementsHistoryLong:
+		fullRows = dataForStatementEvents(sctx, perfevents.StatementsHistoryLong())
+	case tableNameEventsWaitsHistory:
+		fullRows = dataForWaitEvents(sctx, perfevents.WaitsHistory())
+	case tableNameEventsWaitsSummaryGlobalByEventName:
+		fullRows = dataForWaitsSummary(perfevents.WaitsSummary())
 	}
 	if err != nil {
 		return
@@ -412,3 +429,143 @@ func dataForRemoteProfile(ctx sessionctx.Context, nodeType, uri string, isGorout
 	}
 	return finalRows, nil
 }
+
+// canSeeAllEvents returns whether the user can see the events of other users.
+func canSeeAllEvents(sctx sessionctx.Context) bool {
+	pm := privilege.GetPrivilegeManager(sctx)
+	return pm == nil || pm.RequestVerification(sctx.GetSessionVars().ActiveRoles, "", "", "", mysql.ProcessPriv)
+}
+
+func currentUsername(sctx sessionctx.Context) string {
+	if user := sctx.GetSessionVars().User; user != nil {
+		return user.Username
+	}
+	return ""
+}
+
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func dataForStatementEvents(sctx sessionctx.Context, events []perfevents.StatementEvent) [][]types.Datum {
+	seeAll, user := canSeeAllEvents(sctx), currentUsername(sctx)
+	now := time.Now()
+	rows := make([][]types.Datum, 0, len(events))
+	for i := range events {
+		e := &events[i]
+		if !seeAll && e.User != user {
+			continue
+		}
+		var endEventID, sqlState, messageText any
+		endTime := now
+		if !e.Running() {
+			endEventID, endTime = e.EndEventID, e.EndTime
+		}
+		if e.Errors > 0 {
+			sqlState, messageText = e.SQLState, e.MessageText
+		}
+		timerStart, timerEnd := perfevents.Timer(e.StartTime), perfevents.Timer(endTime)
+		rows = append(rows, types.MakeDatums(
+			e.ThreadID,                            // THREAD_ID
+			e.EventID,                             // EVENT_ID
+			endEventID,                            // END_EVENT_ID
+			e.EventName,                           // EVENT_NAME
+			nil,                                   // SOURCE
+			timerStart,                            // TIMER_START
+			timerEnd,                              // TIMER_END
+			timerEnd-min(timerStart, timerEnd),    // TIMER_WAIT
+			uint64(e.LockTime.Nanoseconds())*1000, // LOCK_TIME
+			e.SQLText,                             // SQL_TEXT
+			nullIfEmpty(e.Digest),                 // DIGEST
+			nullIfEmpty(e.DigestText),             // DIGEST_TEXT
+			nullIfEmpty(e.CurrentSchema),          // CURRENT_SCHEMA
+			nil,                                   // OBJECT_TYPE
+			nil,                                   // OBJECT_SCHEMA
+			nil,                                   // OBJECT_NAME
+			nil,                                   // OBJECT_INSTANCE_BEGIN
+			int64(e.MySQLErrno),                   // MYSQL_ERRNO
+			sqlState,                              // RETURNED_SQLSTATE
+			messageText,                           // MESSAGE_TEXT
+			e.Errors,                              // ERRORS
+			e.Warnings,                            // WARNINGS
+			e.RowsAffected,                        // ROWS_AFFECTED
+			e.RowsSent,                            // ROWS_SENT
+			e.RowsExamined,                        // ROWS_EXAMINED
+			uint64(0),                             // CREATED_TMP_DISK_TABLES
+			uint64(0),                             // CREATED_TMP_TABLES
+			uint64(0),                             // SELECT_FULL_JOIN
+			uint64(0),                             // SELECT_FULL_RANGE_JOIN
+			uint64(0),                             // SELECT_RANGE
+			uint64(0),                             // SELECT_RANGE_CHECK
+			uint64(0),                             // SELECT_SCAN
+			uint64(0),                             // SORT_MERGE_PASSES
+			uint64(0),                             // SORT_RANGE
+			uint64(0),                             // SORT_ROWS
+			uint64(0),                             // SORT_SCAN
+			uint64(0),                             // NO_INDEX_USED
+			uint64(0),                             // NO_GOOD_INDEX_USED
+			nil,                                   // NESTING_EVENT_ID
+			nil,                                   // NESTING_EVENT_TYPE
+			nil,                                   // NESTING_EVENT_LEVEL
+		))
+	}
+	return rows
+}
+
+func dataForWaitEvents(sctx sessionctx.Context, events []perfevents.WaitEvent) [][]types.Datum {
+	seeAll, user := canSeeAllEvents(sctx), currentUsername(sctx)
+	rows := make([][]types.Datum, 0, len(events))
+	for i := range events {
+		e := &events[i]
+		if !seeAll && e.User != user {
+			continue
+		}
+		// The waits are aggregated per statement, so they have no start and end time.
+		row := types.MakeDatums(
+			e.ThreadID,                            // THREAD_ID
+			e.EventID,                             // EVENT_ID
+			e.EventID,                             // END_EVENT_ID
+			e.EventName,                           // EVENT_NAME
+			nil,                                   // SOURCE
+			nil,                                   // TIMER_START
+			nil,                                   // TIMER_END
+			uint64(e.Duration.Nanoseconds())*1000, // TIMER_WAIT
+			nil,                                   // SPINS
+			nil,                                   // OBJECT_SCHEMA
+			nil,                                   // OBJECT_NAME
+			nil,                                   // INDEX_NAME
+			nil,                                   // OBJECT_TYPE
+			uint64(0),                             // OBJECT_INSTANCE_BEGIN
+			e.NestingEventID,                      // NESTING_EVENT_ID
+			nil,                                   // NESTING_EVENT_TYPE
+			e.Operation,                           // OPERATION
+			nil,                                   // NUMBER_OF_BYTES
+			nil,                                   // FLAGS
+		)
+		row[15] = types.NewMysqlEnumDatum(types.Enum{Name: "STATEMENT", Value: 2})
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func dataForWaitsSummary(summaries []perfevents.WaitSummary) [][]types.Datum {
+	rows := make([][]types.Datum, 0, len(summaries))
+	for _, s := range summaries {
+		var avg time.Duration
+		if s.Count > 0 {
+			avg = s.Sum / time.Duration(s.Count)
+		}
+		rows = append(rows, types.MakeDatums(
+			s.EventName,
+			s.Count,
+			uint64(s.Sum.Nanoseconds())*1000,
+			uint64(s.Min.Nanoseconds())*1000,
+			uint64(avg.Nanoseconds())*1000,
+			uint64(s.Max.Nanoseconds())*1000,
+		))
+	}
+	return rows
+}
@@ -25,8 +25,10 @@ import (
 	"testing"
 
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/infoschema/perfschema"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/session"
 	"github.com/pingcap/tidb/pkg/store/mockstore"
@@ -200,6 +202,44 @@ func TestSessionConnectAttrs(t *testing.T) {
 	tk.MustQuery("SELECT PROCESSLIST_ID,ATTR_NAME,ATTR_VALUE,ORDINAL_POSITION FROM performance_schema.SESSION_CONNECT_ATTRS").Check(testkit.Rows("123456 _client_name libmysql 0"))
 }
 
+func TestStatementAndWaitEvents(t *testing.T) {
+	store := newMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	connID := tk.Session().GetSessionVars().ConnectionID
+	tk.MustExec("set global tidb_enable_perfschema_events = on")
+	defer tk.MustExec("set global tidb_enable_perfschema_events = off")
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key)")
+	tk.MustExec("insert into t values (1), (2)")
+	tk.MustGetErrCode("insert into t values (1)", errno.ErrDupEntry)
+
+	where := fmt.Sprintf(" where thread_id = %d", connID)
+	tk.MustQuery("select event_name, sql_text, current_schema, end_event_id is null from performance_schema.events_statements_current" + where).Check(
+		testkit.Rows("statement/sql/select select event_name, sql_text, current_schema, end_event_id is null from performance_schema.events_statements_current" + where + " test 1"))
+	tk.MustQuery("select event_name, errors, mysql_errno, returned_sqlstate from performance_schema.events_statements_history" + where + " and event_name != 'statement/sql/select' order by event_id").Check(testkit.Rows(
+		"statement/sql/set 0 0 <nil>",
+		"statement/sql/use 0 0 <nil>",
+		"statement/sql/create_table 0 0 <nil>",
+		"statement/sql/insert 0 0 <nil>",
+		"statement/sql/insert 1 1062 23000",
+	))
+	tk.MustQuery("select rows_affected from performance_schema.events_statements_history" + where + " and sql_text = 'insert into t values (1), (2)'").Check(testkit.Rows("2"))
+	tk.MustQuery("select count(*) from performance_schema.events_statements_history_long" + where + " and sql_text like 'insert%'").Check(testkit.Rows("2"))
+
+	// Users without the PROCESS privilege can only see their own events.
+	tk.MustExec("create user 'perfuser'@'%'")
+	tk.MustExec("grant select on performance_schema.* to 'perfuser'@'%'")
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "perfuser", Hostname: "%"}, nil, nil, nil))
+	tk1.MustQuery("select count(*) from performance_schema.events_statements_history" + where).Check(testkit.Rows("0"))
+	tk1.MustQuery("select count(*) from performance_schema.events_statements_current where thread_id = " + fmt.Sprint(tk1.Session().GetSessionVars().ConnectionID)).Check(testkit.Rows("1"))
+
+	tk.MustExec("set global tidb_enable_perfschema_events = off")
+	tk.MustExec("select 1")
+	tk.MustQuery("select event_name from performance_schema.events_statements_current" + where).Check(testkit.Rows("statement/sql/set"))
+}
+
 func newMockStore(t *testing.T) kv.Storage {
 	store, err := mockstore.NewMockStore()
 	require.NoError(t, err)
@@ -0,0 +1,152 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infoschema
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/table"
+)
+
+// SpecialAttribute is the kind of the special attributes of the tables, which are handled by the background
+// workers. The attributes are bit flags, so they can be combined to list the tables with any of them.
+type SpecialAttribute uint8
+
+const (
+	// TTLAttribute is the attribute of the tables with TTL.
+	TTLAttribute SpecialAttribute = 1 << iota
+	// PlacementPolicyAttribute is the attribute of the tables or partitions with the placement policies.
+	PlacementPolicyAttribute
+	// ForeignKeysAttribute is the attribute of the tables with the foreign keys.
+	ForeignKeysAttribute
+	// TableLockAttribute is the attribute of the tables locked by LOCK TABLES.
+	TableLockAttribute
+	// CachedTableAttribute is the attribute of the cached tables.
+	CachedTableAttribute
+
+	// AllSpecialAttributes is all the special attributes.
+	AllSpecialAttributes = TTLAttribute | PlacementPolicyAttribute | ForeignKeysAttribute | TableLockAttribute |
+		CachedTableAttribute
+)
+
+// specialAttributesOf returns the special attributes the table carries.
+func specialAttributesOf(tblInfo *model.TableInfo) SpecialAttribute {
+	var attrs SpecialAttribute
+	if tblInfo.TTLInfo != nil {
+		attrs |= TTLAttribute
+	}
+	if tblInfo.PlacementPolicyRef != nil {
+		attrs |= PlacementPolicyAttribute
+	} else if pi := tblInfo.GetPartitionInfo(); pi != nil {
+		for _, def := range pi.Definitions {
+			if def.PlacementPolicyRef != nil {
+				attrs |= PlacementPolicyAttribute
+				break
+			}
+		}
+	}
+	if len(tblInfo.ForeignKeys) > 0 {
+		attrs |= ForeignKeysAttribute
+	}
+	if tblInfo.Lock != nil {
+		attrs |= TableLockAttribute
+	}
+	if tblInfo.TableCacheStatusType != model.TableCacheStatusDisable {
+		attrs |= CachedTableAttribute
+	}
+	return attrs
+}
+
+// specialTable is an entry of the index of the tables with the special attributes.
+type specialTable struct {
+	dbID   int64
+	dbName model.CIStr
+	attrs  SpecialAttribute
+}
+
+// TableInfoResult is the tables with the special attributes in a schema, see ListTablesWithSpecialAttribute.
+type TableInfoResult struct {
+	DBID   int64
+	DBName model.CIStr
+	Tables []table.Table
+}
+
+func (is *infoSchema) ListTablesWithSpecialAttribute(attr SpecialAttribute) []TableInfoResult {
+	return listTablesWithSpecialAttribute(is, is.specialTables, attr)
+}
+
+// listTablesWithSpecialAttribute lists the tables in the index which carry any of the special attributes, grouped
+// by the schemas. The tables are got from the InfoSchema and checked again, so the index is allowed to have the
+// tables which are dropped or no longer carry the attributes.
+func listTablesWithSpecialAttribute(is InfoSchema, specials map[int64]specialTable, attr SpecialAttribute) []TableInfoResult {
+	var results []TableInfoResult
+	dbIdx := make(map[int64]int)
+	for id, special := range specials {
+		if special.attrs&attr == 0 {
+			continue
+		}
+		tbl, ok := is.TableByID(id)
+		if !ok || specialAttributesOf(tbl.Meta())&attr == 0 {
+			continue
+		}
+		idx, ok := dbIdx[special.dbID]
+		if !ok {
+			idx = len(results)
+			dbIdx[special.dbID] = idx
+			results = append(results, TableInfoResult{DBID: special.dbID, DBName: special.dbName})
+		}
+		results[idx].Tables = append(results[idx].Tables, tbl)
+	}
+	slices.SortFunc(results, func(a, b TableInfoResult) int {
+		return cmp.Compare(a.DBName.L, b.DBName.L)
+	})
+	for _, r := range results {
+		slices.SortFunc(r.Tables, func(a, b table.Table) int {
+			return cmp.Compare(a.Meta().ID, b.Meta().ID)
+		})
+	}
+	return results
+}
+
+// addSpecialTable adds the table to the index if it carries any special attribute.
+func (b *Builder) addSpecialTable(di *model.DBInfo, tblInfo *model.TableInfo) {
+	attrs := specialAttributesOf(tblInfo)
+	if attrs == 0 {
+		return
+	}
+	if b.infoSchema.specialTables == nil {
+		b.infoSchema.specialTables = make(map[int64]specialTable)
+	}
+	b.infoSchema.specialTables[tblInfo.ID] = specialTable{dbID: di.ID, dbName: di.Name, attrs: attrs}
+}
+
+func (b *Builder) deleteSpecialTable(tblID int64) {
+	delete(b.infoSchema.specialTables, tblID)
+}
+
+func (b *Builder) copySpecialTablesMap(oldIS *infoSchema) {
+	is := b.infoSchema
+	if len(oldIS.specialTables) == 0 {
+		is.specialTables = nil
+		return
+	}
+
+	is.specialTables = make(map[int64]specialTable, len(oldIS.specialTables))
+	for tblID, special := range oldIS.specialTables {
+		is.specialTables[tblID] = special
+	}
+}
@@ -95,19 +95,23 @@ const (
 	tablePlugins    = "PLUGINS"
 	// TableConstraints is the string constant of TABLE_CONSTRAINTS.
 	TableConstraints = "TABLE_CONSTRAINTS"
-	tableTriggers    = "TRIGGERS"
+	// TableTriggers is the string constant of infoschema table.
+	TableTriggers = "TRIGGERS"
 	// TableUserPrivileges is the string constant of infoschema user privilege table.
 	TableUserPrivileges   = "USER_PRIVILEGES"
 	tableSchemaPrivileges = "SCHEMA_PRIVILEGES"
 	tableTablePrivileges  = "TABLE_PRIVILEGES"
-	tableColumnPrivileges = "COLUMN_PRIVILEGES"
+	// TableColumnPrivileges is the string constant of infoschema column privilege table.
+	TableColumnPrivileges = "COLUMN_PRIVILEGES"
 	// TableEngines is the string constant of infoschema table.
 	TableEngines = "ENGINES"
 	// TableViews is the string constant of infoschema table.
-	TableViews           = "VIEWS"
-	tableRoutines        = "ROUTINES"
-	tableParameters      = "PARAMETERS"
-	tableEvents          = "EVENTS"
+	TableViews = "VIEWS"
+	// TableRoutines is the string constant of infoschema table.
+	TableRoutines   = "ROUTINES"
+	tableParameters = "PARAMETERS"
+	// TableEvents is the string constant of infoschema table.
+	TableEvents          = "EVENTS"
 	tableGlobalStatus    = "GLOBAL_STATUS"
 	tableGlobalVariables = "GLOBAL_VARIABLES"
 	tableSessionStatus   = "SESSION_STATUS"
@@ -189,6 +193,16 @@ const (
 	TableDeadlocks = "DEADLOCKS"
 	// TableDataLockWaits is current lock waiting status table.
 	TableDataLockWaits = "DATA_LOCK_WAITS"
+	// TableDataLockWaitsSummary is the current lock waiting status aggregated by the SQL digests.
+	TableDataLockWaitsSummary = "DATA_LOCK_WAITS_SUMMARY"
+	// TableTableLatencyStats is the latency of the statements per table and index in the current instance.
+	TableTableLatencyStats = "TABLE_LATENCY_STATS"
+	// TableTiDBRegionCacheStats is the statistics of the region cache in the current instance.
+	TableTiDBRegionCacheStats = "TIDB_REGION_CACHE_STATS"
+	// TableOptimizerFixControls is the optimizer fix controls and their values.
+	TableOptimizerFixControls = "OPTIMIZER_FIX_CONTROLS"
+	// TableTiDBDDLHistory is the finished DDL jobs kept in the DDL history.
+	TableTiDBDDLHistory = "TIDB_DDL_HISTORY"
 	// TableAttributes is the string constant of attributes table.
 	TableAttributes = "ATTRIBUTES"
 	// TablePlacementPolicies is the string constant of placement policies table.
@@ -215,6 +229,28 @@ const (
 	TableKeywords = "KEYWORDS"
 	// TableTiDBIndexUsage is a table to show the usage stats of indexes in the current instance.
 	TableTiDBIndexUsage = "TIDB_INDEX_USAGE"
+	// TableAuditRules is the list of rules of the built-in audit log.
+	TableAuditRules = "AUDIT_RULES"
+	// TableTiDBGeneralLog is the list of recent statements in the general log of the current instance.
+	TableTiDBGeneralLog = "TIDB_GENERAL_LOG"
+	// TableTiDBMaterializedViews is the list of materialized views.
+	TableTiDBMaterializedViews = "TIDB_MATERIALIZED_VIEWS"
+	// TableClusterEncryptionStatus is the encryption at rest status of the TiKV instances.
+	TableClusterEncryptionStatus = "CLUSTER_ENCRYPTION_STATUS"
+	// TableTiDBUserResourceUsage is the resource usage of the accounts limited by the resource options.
+	TableTiDBUserResourceUsage = "TIDB_USER_RESOURCE_USAGE"
+	// TableTiDBServersHistory is the history of the TiDB servers joining and leaving the cluster.
+	TableTiDBServersHistory = "TIDB_SERVERS_HISTORY"
+	// TableLogBackupTasks is the status of the log backup tasks.
+	TableLogBackupTasks = "LOG_BACKUP_TASKS"
+	// TableLogBackupCheckpoints is the checkpoints of the log backup tasks on each store.
+	TableLogBackupCheckpoints = "LOG_BACKUP_CHECKPOINTS"
+	// TableLogBackupErrors is the last errors of the log backup tasks reported by each store.
+	TableLogBackupErrors = "LOG_BACKUP_ERRORS"
+	// TableChecksumTableProgress is the progress of the ADMIN CHECKSUM TABLE tasks run on the distributed task framework.
+	TableChecksumTableProgress = "CHECKSUM_TABLE_PROGRESS"
+	// TableTiDBGCProtections is the start ts of the sessions protected from GC by tidb_enable_query_gc_protection.
+	TableTiDBGCProtections = "TIDB_GC_PROTECTIONS"
 )
 
 const (
@@ -257,16 +293,16 @@ var tableIDMap = map[string]int64{
 	TableSessionVar:                         autoid.InformationSchemaDBID + 14,
 	tablePlugins:                            autoid.InformationSchemaDBID + 15,
 	TableConstraints:                        autoid.InformationSchemaDBID + 16,
-	tableTriggers:                           autoid.InformationSchemaDBID + 17,
+	TableTriggers:                           autoid.InformationSchemaDBID + 17,
 	TableUserPrivileges:                     autoid.InformationSchemaDBID + 18,
 	tableSchemaPrivileges:                   autoid.InformationSchemaDBID + 19,
 	tableTablePrivileges:                    autoid.InformationSchemaDBID + 20,
-	tableColumnPrivileges:                   autoid.InformationSchemaDBID + 21,
+	TableColumnPrivileges:                   autoid.InformationSchemaDBID + 21,
 	TableEngines:                            autoid.InformationSchemaDBID + 22,
 	TableViews:                              autoid.InformationSchemaDBID + 23,
-	tableRoutines:                           autoid.InformationSchemaDBID + 24,
+	TableRoutines:                           autoid.InformationSchemaDBID + 24,
 	tableParameters:                         autoid.InformationSchemaDBID + 25,
-	tableEvents:                             autoid.InformationSchemaDBID + 26,
+	TableEvents:                             autoid.InformationSchemaDBID + 26,
 	tableGlobalStatus:                       autoid.InformationSchemaDBID + 27,
 	tableGlobalVariables:                    autoid.InformationSchemaDBID + 28,
 	tableSessionStatus:                      autoid.InformationSchemaDBID + 29,
@@ -336,6 +372,22 @@ var tableIDMap = map[string]int64{
 	TableKeywords:                        autoid.InformationSchemaDBID + 92,
 	TableTiDBIndexUsage:                  autoid.InformationSchemaDBID + 93,
 	ClusterTableTiDBIndexUsage:           autoid.InformationSchemaDBID + 94,
+	TableAuditRules:                      autoid.InformationSchemaDBID + 95,
+	TableTiDBGeneralLog:                  autoid.InformationSchemaDBID + 96,
+	TableTiDBMaterializedViews:           autoid.InformationSchemaDBID + 97,
+	TableClusterEncryptionStatus:         autoid.InformationSchemaDBID + 98,
+	TableTiDBUserResourceUsage:           autoid.InformationSchemaDBID + 99,
+	TableTiDBServersHistory:              autoid.InformationSchemaDBID + 100,
+	TableLogBackupTasks:                  autoid.InformationSchemaDBID + 101,
+	TableLogBackupCheckpoints:            autoid.InformationSchemaDBID + 102,
+	TableLogBackupErrors:                 autoid.InformationSchemaDBID + 103,
+	TableChecksumTableProgress:           autoid.InformationSchemaDBID + 104,
+	TableTiDBGCProtections:               autoid.InformationSchemaDBID + 105,
+	TableDataLockWaitsSummary:            autoid.InformationSchemaDBID + 106,
+	TableTableLatencyStats:               autoid.InformationSchemaDBID + 107,
+	TableTiDBRegionCacheStats:            autoid.InformationSchemaDBID + 108,
+	TableOptimizerFixControls:            autoid.InformationSchemaDBID + 109,
+	TableTiDBDDLHistory:                  autoid.InformationSchemaDBID + 110,
 }
 
 // columnInfo represents the basic column information of all kinds of INFORMATION_SCHEMA tables
@@ -428,6 +480,7 @@ var schemataCols = []columnInfo{
 	{name: "DEFAULT_COLLATION_NAME", tp: mysql.TypeVarchar, size: 32},
 	{name: "SQL_PATH", tp: mysql.TypeVarchar, size: 512},
 	{name: "TIDB_PLACEMENT_POLICY_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "TIDB_READ_ONLY", tp: mysql.TypeVarchar, size: 3},
 }
 
 var tablesCols = []columnInfo{
@@ -456,6 +509,9 @@ var tablesCols = []columnInfo{
 	{name: "TIDB_ROW_ID_SHARDING_INFO", tp: mysql.TypeVarchar, size: 255},
 	{name: "TIDB_PK_TYPE", tp: mysql.TypeVarchar, size: 64},
 	{name: "TIDB_PLACEMENT_POLICY_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "TIDB_TABLE_CACHE_STATUS", tp: mysql.TypeVarchar, size: 16},
+	{name: "TIDB_TABLE_CACHE_MODE", tp: mysql.TypeVarchar, size: 16},
+	{name: "TIDB_TABLE_CACHE_SIZE", tp: mysql.TypeLonglong, size: 21},
 }
 
 // See: http://dev.mysql.com/doc/refman/5.7/en/information-schema-columns-table.html
@@ -637,6 +693,7 @@ var tableConstraintsCols = []columnInfo{
 	{name: "TABLE_SCHEMA", tp: mysql.TypeVarchar, size: 64},
 	{name: "TABLE_NAME", tp: mysql.TypeVarchar, size: 64},
 	{name: "CONSTRAINT_TYPE", tp: mysql.TypeVarchar, size: 64},
+	{name: "ENFORCED", tp: mysql.TypeVarchar, size: 3},
 }
 
 var tableTriggersCols = []columnInfo{
@@ -798,6 +855,8 @@ var tableEventsCols = []columnInfo{
 	{name: "CHARACTER_SET_CLIENT", tp: mysql.TypeVarchar, size: 32, flag: mysql.NotNullFlag},
 	{name: "COLLATION_CONNECTION", tp: mysql.TypeVarchar, size: 32, flag: mysql.NotNullFlag},
 	{name: "DATABASE_COLLATION", tp: mysql.TypeVarchar, size: 32, flag: mysql.NotNullFlag},
+	{name: "TIDB_LAST_STATUS", tp: mysql.TypeVarchar, size: 16},
+	{name: "TIDB_LAST_ERROR", tp: mysql.TypeLongBlob},
 }
 
 var tableGlobalStatusCols = []columnInfo{
@@ -854,6 +913,13 @@ var tableProcesslistCols = []columnInfo{
 	{name: "TxnStart", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag, deflt: ""},
 	{name: "RESOURCE_GROUP", tp: mysql.TypeVarchar, size: resourcegroup.MaxGroupNameLength, flag: mysql.NotNullFlag, deflt: ""},
 	{name: "SESSION_ALIAS", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag, deflt: ""},
+	{name: "PROGRAM_NAME", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag, deflt: ""},
+	{name: "CONNECT_ATTRS", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+	{name: "COMPRESSION", tp: mysql.TypeVarchar, size: 8, flag: mysql.NotNullFlag, deflt: ""},
+	{name: "BYTES_SENT", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "COMPRESSED_BYTES_SENT", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "BYTES_RECEIVED", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "COMPRESSED_BYTES_RECEIVED", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
 }
 
 var tableTiDBIndexesCols = []columnInfo{
@@ -1090,6 +1156,19 @@ var tableTiDBServersInfoCols = []columnInfo{
 	{name: "LABELS", tp: mysql.TypeVarchar, size: 128},
 }
 
+var tableTiDBServersHistoryCols = []columnInfo{
+	{name: "TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "EVENT", tp: mysql.TypeVarchar, size: 16},
+	{name: "DDL_ID", tp: mysql.TypeVarchar, size: 64},
+	{name: "IP", tp: mysql.TypeVarchar, size: 64},
+	{name: "PORT", tp: mysql.TypeLonglong, size: 21},
+	{name: "STATUS_PORT", tp: mysql.TypeLonglong, size: 21},
+	{name: "VERSION", tp: mysql.TypeVarchar, size: 64},
+	{name: "GIT_HASH", tp: mysql.TypeVarchar, size: 64},
+	{name: "START_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "LABELS", tp: mysql.TypeVarchar, size: 128},
+}
+
 var tableClusterConfigCols = []columnInfo{
 	{name: "TYPE", tp: mysql.TypeVarchar, size: 64},
 	{name: "INSTANCE", tp: mysql.TypeVarchar, size: 64},
@@ -1560,6 +1639,49 @@ var tableDataLockWaitsCols = []columnInfo{
 	{name: DataLockWaitsColumnSQLDigestText, tp: mysql.TypeBlob, size: types.UnspecifiedLength, comment: "Digest of the SQL that's trying to acquire the lock"},
 }
 
+var tableDataLockWaitsSummaryCols = []columnInfo{
+	{name: "WAITING_SQL_DIGEST", tp: mysql.TypeVarchar, size: 64, comment: "Digest of the SQL that's waiting for the lock"},
+	{name: "WAITING_SQL_DIGEST_TEXT", tp: mysql.TypeBlob, size: types.UnspecifiedLength, comment: "The normalized SQL that's waiting for the lock"},
+	{name: "HOLDING_SQL_DIGEST", tp: mysql.TypeVarchar, size: 64, comment: "Digest of the SQL that the transaction holding the lock is running or waiting on"},
+	{name: "HOLDING_SQL_DIGEST_TEXT", tp: mysql.TypeBlob, size: types.UnspecifiedLength, comment: "The normalized SQL that the transaction holding the lock is running or waiting on"},
+	{name: "WAIT_COUNT", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag, comment: "The number of the lock waits"},
+	{name: "WAITING_TRX_COUNT", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag, comment: "The number of the distinct transactions that are waiting for the lock"},
+	{name: "HOLDING_TRX_COUNT", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag, comment: "The number of the distinct transactions that are holding the lock"},
+}
+
+var tableTableLatencyStatsCols = []columnInfo{
+	{name: "TABLE_SCHEMA", tp: mysql.TypeVarchar, size: 64},
+	{name: "TABLE_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "INDEX_NAME", tp: mysql.TypeVarchar, size: 64, comment: "NULL for the statements on the table"},
+	{name: "QUERY_TOTAL", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag, comment: "The number of the statements"},
+	{name: "ERROR_TOTAL", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag, comment: "The number of the failed statements"},
+	{name: "QPS", tp: mysql.TypeDouble, size: 22, flag: mysql.NotNullFlag, comment: "The average number of the statements per second since START_TIME"},
+	{name: "SUM_LATENCY", tp: mysql.TypeDouble, size: 22, flag: mysql.NotNullFlag, comment: "Sum latency of the statements in seconds"},
+	{name: "AVG_LATENCY", tp: mysql.TypeDouble, size: 22, flag: mysql.NotNullFlag, comment: "Average latency of the statements in seconds"},
+	{name: "MAX_LATENCY", tp: mysql.TypeDouble, size: 22, flag: mysql.NotNullFlag, comment: "Max latency of the statements in seconds"},
+	{name: "P50_LATENCY", tp: mysql.TypeDouble, size: 22, flag: mysql.NotNullFlag, comment: "Estimated P50 latency of the statements in seconds"},
+	{name: "P95_LATENCY", tp: mysql.TypeDouble, size: 22, flag: mysql.NotNullFlag, comment: "Estimated P95 latency of the statements in seconds"},
+	{name: "P99_LATENCY", tp: mysql.TypeDouble, size: 22, flag: mysql.NotNullFlag, comment: "Estimated P99 latency of the statements in seconds"},
+	{name: "LATENCY_HISTOGRAM", tp: mysql.TypeBlob, size: types.UnspecifiedLength, comment: "The number of the statements by the upper bound of latency in seconds"},
+	{name: "START_TIME", tp: mysql.TypeTimestamp, size: 26, comment: "The time when the first statement is recorded"},
+}
+
+var tableTiDBRegionCacheStatsCols = []columnInfo{
+	{name: "CATEGORY", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag, comment: "The category of the statistics, such as size, operation, reload, invalidate and repair"},
+	{name: "NAME", tp: mysql.TypeVarchar, size: 128, flag: mysql.NotNullFlag},
+	{name: "VALUE", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag},
+}
+
+var tableOptimizerFixControlsCols = []columnInfo{
+	{name: "FIX_ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag},
+	{name: "VALUE_TYPE", tp: mysql.TypeVarchar, size: 16, flag: mysql.NotNullFlag},
+	{name: "DEFAULT_VALUE", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "GLOBAL_VALUE", tp: mysql.TypeVarchar, size: 64, comment: "The value set by `SET GLOBAL tidb_opt_fix_control`, NULL if it's not set"},
+	{name: "SESSION_VALUE", tp: mysql.TypeVarchar, size: 64, comment: "The value set by `SET tidb_opt_fix_control`, NULL if it's not set"},
+	{name: "SCOPE", tp: mysql.TypeVarchar, size: 16, flag: mysql.NotNullFlag, comment: "Where the value in effect comes from, one of DEFAULT, GLOBAL and SESSION"},
+	{name: "DESCRIPTION", tp: mysql.TypeVarchar, size: 512, flag: mysql.NotNullFlag},
+}
+
 var tableStatementsSummaryEvictedCols = []columnInfo{
 	{name: "BEGIN_TIME", tp: mysql.TypeTimestamp, size: 26},
 	{name: "END_TIME", tp: mysql.TypeTimestamp, size: 26},
@@ -1682,6 +1804,110 @@ var tableKeywords = []columnInfo{
 	{name: "RESERVED", tp: mysql.TypeLong, size: 11},
 }
 
+var tableAuditRulesCols = []columnInfo{
+	{name: "RULE_ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag},
+	{name: "USER", tp: mysql.TypeVarchar, size: 64},
+	{name: "DB", tp: mysql.TypeVarchar, size: 64},
+	{name: "STATEMENT_CLASS", tp: mysql.TypeVarchar, size: 16},
+}
+
+var tableTiDBGeneralLogCols = []columnInfo{
+	{name: "ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag},
+	{name: "TIME", tp: mysql.TypeTimestamp, decimal: 6, size: 26},
+	{name: "CONN_ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "USER", tp: mysql.TypeVarchar, size: 64},
+	{name: "HOST", tp: mysql.TypeVarchar, size: 255},
+	{name: "DB", tp: mysql.TypeVarchar, size: 64},
+	{name: "QUERY", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+}
+
+var tableClusterEncryptionStatusCols = []columnInfo{
+	{name: "TYPE", tp: mysql.TypeVarchar, size: 64},
+	{name: "INSTANCE", tp: mysql.TypeVarchar, size: 64},
+	{name: "ENCRYPTION_METHOD", tp: mysql.TypeVarchar, size: 32},
+	{name: "MASTER_KEY_TYPE", tp: mysql.TypeVarchar, size: 32},
+	{name: "CURRENT_KEY_ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "KEY_CREATION_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "DATA_KEY_COUNT", tp: mysql.TypeLonglong, size: 21},
+	{name: "FILE_COUNT", tp: mysql.TypeLonglong, size: 21},
+	{name: "ROTATED_FILE_COUNT", tp: mysql.TypeLonglong, size: 21},
+	{name: "ROTATION_PROGRESS", tp: mysql.TypeDouble, size: 22},
+}
+
+var tableTiDBUserResourceUsageCols = []columnInfo{
+	{name: "USER", tp: mysql.TypeVarchar, size: 32},
+	{name: "HOST", tp: mysql.TypeVarchar, size: 255},
+	{name: "CURRENT_CONNECTIONS", tp: mysql.TypeLonglong, size: 21},
+	{name: "MAX_USER_CONNECTIONS", tp: mysql.TypeLonglong, size: 21},
+	{name: "QUESTIONS", tp: mysql.TypeLonglong, size: 21},
+	{name: "MAX_QUESTIONS", tp: mysql.TypeLonglong, size: 21},
+	{name: "HOUR_START", tp: mysql.TypeDatetime, size: 19},
+}
+
+var tableLogBackupTasksCols = []columnInfo{
+	{name: "TASK_NAME", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "STATUS", tp: mysql.TypeVarchar, size: 16},
+	{name: "START_TS", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "END_TS", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "CHECKPOINT_TS", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "CHECKPOINT_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "CHECKPOINT_LAG_SECONDS", tp: mysql.TypeLonglong, size: 21},
+	{name: "STORAGE", tp: mysql.TypeVarchar, size: 1024},
+	{name: "TABLE_FILTER", tp: mysql.TypeBlob, size: types.UnspecifiedLength},
+}
+
+var tableLogBackupCheckpointsCols = []columnInfo{
+	{name: "TASK_NAME", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "STORE_ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "CHECKPOINT_TS", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "CHECKPOINT_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "CHECKPOINT_LAG_SECONDS", tp: mysql.TypeLonglong, size: 21},
+}
+
+var tableLogBackupErrorsCols = []columnInfo{
+	{name: "TASK_NAME", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "STORE_ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "HAPPEN_AT", tp: mysql.TypeDatetime, size: 19},
+	{name: "ERROR_CODE", tp: mysql.TypeVarchar, size: 64},
+	{name: "ERROR_MESSAGE", tp: mysql.TypeBlob, size: types.UnspecifiedLength},
+}
+
+var tableChecksumTableProgressCols = []columnInfo{
+	{name: "TASK_ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag},
+	{name: "TABLE_SCHEMA", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "TABLE_NAME", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "TABLE_ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag},
+	{name: "STATE", tp: mysql.TypeVarchar, size: 16},
+	{name: "SNAPSHOT_TS", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "CREATE_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "TOTAL_SUBTASKS", tp: mysql.TypeLonglong, size: 21},
+	{name: "FINISHED_SUBTASKS", tp: mysql.TypeLonglong, size: 21},
+	{name: "PROGRESS", tp: mysql.TypeDouble, size: 22, comment: "The percentage of the finished subtasks"},
+	{name: "ERROR", tp: mysql.TypeBlob, size: types.UnspecifiedLength},
+}
+
+var tableTiDBGCProtectionsCols = []columnInfo{
+	{name: "ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag},
+	{name: "USER", tp: mysql.TypeVarchar, size: 16, flag: mysql.NotNullFlag},
+	{name: "DB", tp: mysql.TypeVarchar, size: 64},
+	{name: "START_TS", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag},
+	{name: "START_TIME", tp: mysql.TypeDatetime, size: 26, decimal: 6},
+	{name: "EXPIRE_TIME", tp: mysql.TypeDatetime, size: 26, decimal: 6, comment: "The time when the start ts stops delaying the GC safe point"},
+}
+
+var tableTiDBMaterializedViewsCols = []columnInfo{
+	{name: "TABLE_SCHEMA", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "TABLE_NAME", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "TIDB_TABLE_ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag},
+	{name: "VIEW_DEFINITION", tp: mysql.TypeLongBlob, flag: mysql.NotNullFlag},
+	{name: "DEFINER", tp: mysql.TypeVarchar, size: 77, flag: mysql.NotNullFlag},
+	{name: "CHARACTER_SET_CLIENT", tp: mysql.TypeVarchar, size: 32, flag: mysql.NotNullFlag},
+	{name: "COLLATION_CONNECTION", tp: mysql.TypeVarchar, size: 32, flag: mysql.NotNullFlag},
+	{name: "LAST_REFRESH_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "REFRESH_INTERVAL_VALUE", tp: mysql.TypeLonglong, size: 21},
+	{name: "REFRESH_INTERVAL_FIELD", tp: mysql.TypeVarchar, size: 18},
+}
+
 var tableTiDBIndexUsage = []columnInfo{
 	{name: "TABLE_SCHEMA", tp: mysql.TypeVarchar, size: 64},
 	{name: "TABLE_NAME", tp: mysql.TypeVarchar, size: 64},
@@ -1738,6 +1964,8 @@ const (
 	UniqueKeyType = "UNIQUE"
 	// ForeignKeyType is the string constant of Foreign Key.
 	ForeignKeyType = "FOREIGN KEY"
+	// CheckConstraintType is the string constant of CHECK.
+	CheckConstraintType = "CHECK"
 )
 
 const (
@@ -2282,16 +2510,16 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableSessionVar:                         sessionVarCols,
 	tablePlugins:                            pluginsCols,
 	TableConstraints:                        tableConstraintsCols,
-	tableTriggers:                           tableTriggersCols,
+	TableTriggers:                           tableTriggersCols,
 	TableUserPrivileges:                     tableUserPrivilegesCols,
 	tableSchemaPrivileges:                   tableSchemaPrivilegesCols,
 	tableTablePrivileges:                    tableTablePrivilegesCols,
-	tableColumnPrivileges:                   tableColumnPrivilegesCols,
+	TableColumnPrivileges:                   tableColumnPrivilegesCols,
 	TableEngines:                            tableEnginesCols,
 	TableViews:                              tableViewsCols,
-	tableRoutines:                           tableRoutinesCols,
+	TableRoutines:                           tableRoutinesCols,
 	tableParameters:                         tableParametersCols,
-	tableEvents:                             tableEventsCols,
+	TableEvents:                             tableEventsCols,
 	tableGlobalStatus:                       tableGlobalStatusCols,
 	tableGlobalVariables:                    tableGlobalVariablesCols,
 	tableSessionStatus:                      tableSessionStatusCols,
@@ -2322,6 +2550,7 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableInspectionSummary:                  tableInspectionSummaryCols,
 	TableInspectionRules:                    tableInspectionRulesCols,
 	TableDDLJobs:                            tableDDLJobsCols,
+	TableTiDBDDLHistory:                     tableDDLJobsCols,
 	TableSequences:                          tableSequencesCols,
 	TableStatementsSummary:                  tableStatementsSummaryCols,
 	TableStatementsSummaryHistory:           tableStatementsSummaryCols,
@@ -2335,6 +2564,10 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableTiDBTrx:                            tableTiDBTrxCols,
 	TableDeadlocks:                          tableDeadlocksCols,
 	TableDataLockWaits:                      tableDataLockWaitsCols,
+	TableDataLockWaitsSummary:               tableDataLockWaitsSummaryCols,
+	TableTableLatencyStats:                  tableTableLatencyStatsCols,
+	TableTiDBRegionCacheStats:               tableTiDBRegionCacheStatsCols,
+	TableOptimizerFixControls:               tableOptimizerFixControlsCols,
 	TableAttributes:                         tableAttributesCols,
 	TablePlacementPolicies:                  tablePlacementPoliciesCols,
 	TableTrxSummary:                         tableTrxSummaryCols,
@@ -2348,6 +2581,17 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableTiDBCheckConstraints:               tableTiDBCheckConstraintsCols,
 	TableKeywords:                           tableKeywords,
 	TableTiDBIndexUsage:                     tableTiDBIndexUsage,
+	TableAuditRules:                         tableAuditRulesCols,
+	TableTiDBGeneralLog:                     tableTiDBGeneralLogCols,
+	TableTiDBMaterializedViews:              tableTiDBMaterializedViewsCols,
+	TableClusterEncryptionStatus:            tableClusterEncryptionStatusCols,
+	TableTiDBUserResourceUsage:              tableTiDBUserResourceUsageCols,
+	TableTiDBServersHistory:                 tableTiDBServersHistoryCols,
+	TableLogBackupTasks:                     tableLogBackupTasksCols,
+	TableLogBackupCheckpoints:               tableLogBackupCheckpointsCols,
+	TableLogBackupErrors:                    tableLogBackupErrorsCols,
+	TableChecksumTableProgress:              tableChecksumTableProgressCols,
+	TableTiDBGCProtections:                  tableTiDBGCProtectionsCols,
 }
 
 func createInfoSchemaTable(_ autoid.Allocators, meta *model.TableInfo) (table.Table, error) {
@@ -291,3 +291,17 @@ func TestCacheWithSchemaTsZero(t *testing.T) {
 	checkFn(85, 100, true)
 	require.Equal(t, 16, ic.Size())
 }
+
+func TestReplace(t *testing.T) {
+	ic := infoschema.NewCache(nil, 3)
+	is2 := infoschema.MockInfoSchemaWithSchemaVer(nil, 2)
+	ic.Insert(is2, 2)
+
+	newIs2 := infoschema.MockInfoSchemaWithSchemaVer(nil, 2)
+	require.True(t, ic.Replace(newIs2))
+	require.Same(t, newIs2, ic.GetByVersion(2))
+	require.Same(t, newIs2, ic.GetBySnapshotTS(10))
+
+	require.False(t, ic.Replace(infoschema.MockInfoSchemaWithSchemaVer(nil, 3)))
+	require.Nil(t, ic.GetByVersion(3))
+}
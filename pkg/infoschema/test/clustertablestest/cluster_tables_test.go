@@ -186,6 +186,51 @@ func TestTestDataLockWaits(t *testing.T) {
 		"6B657934 <nil> 7 8 <nil> <nil>"))
 }
 
+func TestDataLockWaitsSummary(t *testing.T) {
+	// setup suite
+	s := new(clusterTablesSuite)
+	s.store, s.dom = testkit.CreateMockStoreAndDomain(t)
+	s.rpcserver, s.listenAddr = s.setUpRPCService(t, "127.0.0.1:0", nil)
+	s.httpServer, s.mockAddr = s.setUpMockPDHTTPServer()
+	s.startTime = time.Now()
+	defer s.httpServer.Close()
+	defer s.rpcserver.Stop()
+
+	_, digest1 := parser.NormalizeDigest("select * from test_data_lock_waits for update")
+	_, digest2 := parser.NormalizeDigest("update test_data_lock_waits set f1=1 where id=2")
+	tag1 := resourcegrouptag.EncodeResourceGroupTag(digest1, nil, tipb.ResourceGroupTagLabel_ResourceGroupTagLabelUnknown)
+	tag2 := resourcegrouptag.EncodeResourceGroupTag(digest2, nil, tipb.ResourceGroupTagLabel_ResourceGroupTagLabelUnknown)
+	s.store.(mockstorage.MockLockWaitSetter).SetMockLockWaits([]*deadlock.WaitForEntry{
+		// The holding transaction 2 is waiting for transaction 4 with digest2.
+		{Txn: 1, WaitForTxn: 2, Key: []byte("key1"), ResourceGroupTag: tag1},
+		{Txn: 3, WaitForTxn: 2, Key: []byte("key1"), ResourceGroupTag: tag1},
+		{Txn: 8, WaitForTxn: 2, Key: []byte("key5"), ResourceGroupTag: tag1},
+		// The holding transactions 4 and 10 are unknown.
+		{Txn: 2, WaitForTxn: 4, Key: []byte("key2"), ResourceGroupTag: tag2},
+		{Txn: 5, WaitForTxn: 10, Key: []byte("key3"), ResourceGroupTag: tag2},
+		// Invalid digest
+		{Txn: 6, WaitForTxn: 7, Key: []byte("key4"), ResourceGroupTag: []byte("asdfghjkl")},
+	})
+	tk := s.newTestKitWithRoot(t)
+
+	// Execute one of the query once, so it's stored into statements_summary.
+	tk.MustExec("create table test_data_lock_waits (id int primary key, f1 int)")
+	tk.MustExec("select * from test_data_lock_waits for update")
+
+	tk.MustQuery("select * from information_schema.DATA_LOCK_WAITS_SUMMARY").Check(testkit.Rows(
+		digest1.String()+" select * from `test_data_lock_waits` for update "+digest2.String()+" <nil> 3 3 1",
+		digest2.String()+" <nil> <nil> <nil> 2 2 2",
+		"<nil> <nil> <nil> <nil> 1 1 1"))
+	tk.MustQuery("select holding_sql_digest, wait_count from information_schema.DATA_LOCK_WAITS_SUMMARY where waiting_sql_digest = ?", digest1.String()).Check(testkit.Rows(
+		digest2.String() + " 3"))
+
+	tk.MustExec("create user 'testuser'@'localhost'")
+	tk1 := s.newTestKitWithRoot(t)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "testuser", Hostname: "localhost"}, nil, nil, nil))
+	err := tk1.QueryToErr("select * from information_schema.DATA_LOCK_WAITS_SUMMARY")
+	require.EqualError(t, err, "[planner:1227]Access denied; you need (at least one of) the PROCESS privilege(s) for this operation")
+}
+
 func SubTestDataLockWaitsPrivilege(t *testing.T) {
 	// setup suite
 	s := new(clusterTablesSuite)
@@ -324,7 +369,7 @@ select * from t3;
 	tk.MustQuery("select count(*) from `CLUSTER_SLOW_QUERY`").Check(testkit.Rows("4"))
 	tk.MustQuery("select count(*) from `SLOW_QUERY`").Check(testkit.Rows("4"))
 	tk.MustQuery("select count(*) from `CLUSTER_PROCESSLIST`").Check(testkit.Rows("1"))
-	tk.MustQuery("select * from `CLUSTER_PROCESSLIST`").Check(testkit.Rows(fmt.Sprintf(":10080 1 root 127.0.0.1 <nil> Query 9223372036 %s <nil>  0 0 ", "")))
+	tk.MustQuery("select * from `CLUSTER_PROCESSLIST`").Check(testkit.Rows(fmt.Sprintf(":10080 1 root 127.0.0.1 <nil> Query 9223372036 %s <nil>  0 0   <nil>  <nil> <nil> <nil> <nil>", "")))
 	tk.MustExec("create user user1")
 	tk.MustExec("create user user2")
 	user1 := testkit.NewTestKit(t, s.store)
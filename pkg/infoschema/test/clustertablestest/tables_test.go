@@ -165,7 +165,14 @@ func TestInfoSchemaFieldValue(t *testing.T) {
 			"  `DISK` bigint(21) unsigned DEFAULT NULL,\n" +
 			"  `TxnStart` varchar(64) NOT NULL DEFAULT '',\n" +
 			"  `RESOURCE_GROUP` varchar(32) NOT NULL DEFAULT '',\n" +
-			"  `SESSION_ALIAS` varchar(64) NOT NULL DEFAULT ''\n" +
+			"  `SESSION_ALIAS` varchar(64) NOT NULL DEFAULT '',\n" +
+			"  `PROGRAM_NAME` varchar(64) NOT NULL DEFAULT '',\n" +
+			"  `CONNECT_ATTRS` longtext DEFAULT NULL,\n" +
+			"  `COMPRESSION` varchar(8) NOT NULL DEFAULT '',\n" +
+			"  `BYTES_SENT` bigint(21) unsigned DEFAULT NULL,\n" +
+			"  `COMPRESSED_BYTES_SENT` bigint(21) unsigned DEFAULT NULL,\n" +
+			"  `BYTES_RECEIVED` bigint(21) unsigned DEFAULT NULL,\n" +
+			"  `COMPRESSED_BYTES_RECEIVED` bigint(21) unsigned DEFAULT NULL\n" +
 			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
 	tk.MustQuery("show create table information_schema.cluster_log").Check(
 		testkit.Rows("" +
@@ -178,6 +185,55 @@ func TestInfoSchemaFieldValue(t *testing.T) {
 			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
 }
 
+func TestGCProtections(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustQuery("select @@tidb_enable_query_gc_protection, @@global.tidb_query_gc_protection_max_time").Check(testkit.Rows("0 172800"))
+	tk.MustExec("set @@tidb_enable_query_gc_protection = on")
+	require.True(t, tk.Session().GetSessionVars().EnableQueryGCProtection)
+	tk.MustGetErrCode("set @@tidb_query_gc_protection_max_time = 3600", errno.ErrGlobalVariable)
+	tk.MustExec("set @@global.tidb_query_gc_protection_max_time = 100")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1292 Truncated incorrect tidb_query_gc_protection_max_time value: '100'"))
+	tk.MustQuery("select @@global.tidb_query_gc_protection_max_time").Check(testkit.Rows("600"))
+	tk.MustExec("set @@global.tidb_query_gc_protection_max_time = 259200")
+	tk.MustExec("set @@global.tidb_query_gc_protection_max_time = 172800")
+	t.Cleanup(func() {
+		tk.MustExec("set @@global.tidb_query_gc_protection_max_time = default")
+	})
+
+	now := time.Now()
+	sm := &testkit.MockSessionManager{PS: []*util.ProcessInfo{
+		{ID: 1, User: "user-1", DB: "test", CurTxnStartTS: oracle.GoTimeToTS(now.Add(-time.Hour)), GCProtected: true},
+		// the session doesn't enable the protection.
+		{ID: 2, User: "user-2", CurTxnStartTS: oracle.GoTimeToTS(now.Add(-time.Hour))},
+		// the session isn't running a transaction.
+		{ID: 3, User: "user-1", GCProtected: true},
+		{ID: 4, User: "user-2", CurTxnStartTS: oracle.GoTimeToTS(now.Add(-30 * time.Hour)), GCProtected: true},
+		// the protection has expired.
+		{ID: 5, User: "user-1", CurTxnStartTS: oracle.GoTimeToTS(now.Add(-50 * time.Hour)), GCProtected: true},
+	}}
+	tk.Session().SetSessionManager(sm)
+	tk.MustQuery("select id, user, db, start_ts from information_schema.tidb_gc_protections order by id").Check(testkit.Rows(
+		fmt.Sprintf("1 user-1 test %d", sm.PS[0].CurTxnStartTS),
+		fmt.Sprintf("4 user-2 <nil> %d", sm.PS[3].CurTxnStartTS),
+	))
+	tk.MustQuery("select timestampdiff(hour, start_time, expire_time) from information_schema.tidb_gc_protections").Check(testkit.Rows("48", "48"))
+
+	// only the users with the PROCESS privilege can see the sessions of the others.
+	tk.MustExec("create user 'user-1'")
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "user-1", Hostname: "%"}, nil, nil, nil))
+	tk1.Session().SetSessionManager(sm)
+	tk1.MustQuery("select id from information_schema.tidb_gc_protections").Check(testkit.Rows("1"))
+
+	// the protection delays the GC of the whole cluster, so it requires the SYSTEM_VARIABLES_ADMIN privilege.
+	tk1.MustGetErrCode("set @@tidb_enable_query_gc_protection = on", errno.ErrSpecificAccessDenied)
+	require.False(t, tk1.Session().GetSessionVars().EnableQueryGCProtection)
+	tk.MustExec("grant SYSTEM_VARIABLES_ADMIN on *.* to 'user-1'")
+	tk1.MustExec("set @@tidb_enable_query_gc_protection = on")
+	require.True(t, tk1.Session().GetSessionVars().EnableQueryGCProtection)
+}
+
 func TestSomeTables(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 
@@ -199,7 +255,11 @@ func TestSomeTables(t *testing.T) {
 		StmtCtx:           tk.Session().GetSessionVars().StmtCtx,
 		ResourceGroupName: "rg1",
 		SessionAlias:      "alias1",
+		ConnectAttrs:      map[string]string{"program_name": "myapp", "_client_name": "libmysql"},
+		CompressionStats:  &util.CompressionStats{Algorithm: "zstd"},
 	})
+	sm.PS[0].CompressionStats.BytesSent.Store(1000)
+	sm.PS[0].CompressionStats.CompressedBytesSent.Store(100)
 	sm.PS = append(sm.PS, &util.ProcessInfo{
 		ID:                2,
 		User:              "user-2",
@@ -230,9 +290,9 @@ func TestSomeTables(t *testing.T) {
 	tk.Session().SetSessionManager(sm)
 	tk.MustQuery("select * from information_schema.PROCESSLIST order by ID;").Sort().Check(
 		testkit.Rows(
-			fmt.Sprintf("1 user-1 localhost information_schema Quit 9223372036 %s %s abc1 0 0  rg1 alias1", "in transaction", "do something"),
-			fmt.Sprintf("2 user-2 localhost test Init DB 9223372036 %s %s abc2 0 0  rg2 ", "autocommit", strings.Repeat("x", 101)),
-			fmt.Sprintf("3 user-3 127.0.0.1:12345 test Init DB 9223372036 %s %s abc3 0 0  rg3 中文alias", "in transaction", "check port"),
+			fmt.Sprintf("1 user-1 localhost information_schema Quit 9223372036 %s %s abc1 0 0  rg1 alias1 myapp {\"_client_name\":\"libmysql\",\"program_name\":\"myapp\"} zstd 1000 100 0 0", "in transaction", "do something"),
+			fmt.Sprintf("2 user-2 localhost test Init DB 9223372036 %s %s abc2 0 0  rg2   <nil>  <nil> <nil> <nil> <nil>", "autocommit", strings.Repeat("x", 101)),
+			fmt.Sprintf("3 user-3 127.0.0.1:12345 test Init DB 9223372036 %s %s abc3 0 0  rg3 中文alias  <nil>  <nil> <nil> <nil> <nil>", "in transaction", "check port"),
 		))
 	tk.MustQuery("SHOW PROCESSLIST;").Sort().Check(
 		testkit.Rows(
@@ -274,8 +334,8 @@ func TestSomeTables(t *testing.T) {
 	tk.Session().GetSessionVars().TimeZone = time.UTC
 	tk.MustQuery("select * from information_schema.PROCESSLIST order by ID;").Check(
 		testkit.Rows(
-			fmt.Sprintf("1 user-1 localhost information_schema Quit 9223372036 %s %s abc1 0 0  rg1 ", "in transaction", "<nil>"),
-			fmt.Sprintf("2 user-2 localhost <nil> Init DB 9223372036 %s %s abc2 0 0 07-29 03:26:05.158(410090409861578752) rg2 alias3", "autocommit", strings.Repeat("x", 101)),
+			fmt.Sprintf("1 user-1 localhost information_schema Quit 9223372036 %s %s abc1 0 0  rg1   <nil>  <nil> <nil> <nil> <nil>", "in transaction", "<nil>"),
+			fmt.Sprintf("2 user-2 localhost <nil> Init DB 9223372036 %s %s abc2 0 0 07-29 03:26:05.158(410090409861578752) rg2 alias3  <nil>  <nil> <nil> <nil> <nil>", "autocommit", strings.Repeat("x", 101)),
 		))
 	tk.MustQuery("SHOW PROCESSLIST;").Sort().Check(
 		testkit.Rows(
@@ -289,11 +349,11 @@ func TestSomeTables(t *testing.T) {
 		))
 	tk.MustQuery("select * from information_schema.PROCESSLIST where db is null;").Check(
 		testkit.Rows(
-			fmt.Sprintf("2 user-2 localhost <nil> Init DB 9223372036 %s %s abc2 0 0 07-29 03:26:05.158(410090409861578752) rg2 alias3", "autocommit", strings.Repeat("x", 101)),
+			fmt.Sprintf("2 user-2 localhost <nil> Init DB 9223372036 %s %s abc2 0 0 07-29 03:26:05.158(410090409861578752) rg2 alias3  <nil>  <nil> <nil> <nil> <nil>", "autocommit", strings.Repeat("x", 101)),
 		))
 	tk.MustQuery("select * from information_schema.PROCESSLIST where Info is null;").Check(
 		testkit.Rows(
-			fmt.Sprintf("1 user-1 localhost information_schema Quit 9223372036 %s %s abc1 0 0  rg1 ", "in transaction", "<nil>"),
+			fmt.Sprintf("1 user-1 localhost information_schema Quit 9223372036 %s %s abc1 0 0  rg1   <nil>  <nil> <nil> <nil> <nil>", "in transaction", "<nil>"),
 		))
 }
 
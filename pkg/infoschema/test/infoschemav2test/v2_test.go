@@ -15,12 +15,19 @@
 package infoschemav2test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/infoschema"
+	infoschema_metrics "github.com/pingcap/tidb/pkg/infoschema/metrics"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 )
 
@@ -65,3 +72,67 @@ func TestSpecialSchemas(t *testing.T) {
 
 	tk.MustExec("set @@global.tidb_schema_cache_size = default;")
 }
+
+func readMetric(t *testing.T, m prometheus.Metric) *dto.Metric {
+	pb := &dto.Metric{}
+	require.NoError(t, m.Write(pb))
+	return pb
+}
+
+func TestV2Metrics(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@global.tidb_schema_cache_size = 1024;")
+	defer tk.MustExec("set @@global.tidb_schema_cache_size = default;")
+	tk.MustExec("create table t (id int);")
+	is := dom.InfoSchema()
+	require.True(t, infoschema.IsV2(is))
+	require.Equal(t, float64(is.SchemaMetaVersion()), readMetric(t, infoschema_metrics.SchemaVersion).GetGauge().GetValue())
+	require.Greater(t, readMetric(t, infoschema_metrics.TableCacheTableCount).GetGauge().GetValue(), float64(0))
+
+	hit := readMetric(t, infoschema_metrics.TableCacheHitCounter).GetCounter().GetValue()
+	_, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	require.Greater(t, readMetric(t, infoschema_metrics.TableCacheHitCounter).GetCounter().GetValue(), hit)
+}
+
+func TestTableByNameFromMeta(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@global.tidb_schema_cache_size = 1024;")
+	defer tk.MustExec("set @@global.tidb_schema_cache_size = default;")
+	tk.MustExec("set @@global.tidb_enable_fast_create_table = on;")
+	defer tk.MustExec("set @@global.tidb_enable_fast_create_table = default;")
+	tk.MustExec("create table t (id int);")
+	is := dom.InfoSchema()
+	require.True(t, infoschema.IsV2(is))
+	dbInfo, ok := is.SchemaByName(model.NewCIStr("test"))
+	require.True(t, ok)
+
+	// The table is only in the meta, so it's missing in the name index of the infoschema.
+	var tblID int64
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
+	err := kv.RunInNewTxn(ctx, store, true, func(_ context.Context, txn kv.Transaction) error {
+		m := meta.NewMeta(txn, meta.WithUpdateTableName())
+		var err error
+		if tblID, err = m.GenGlobalID(); err != nil {
+			return err
+		}
+		tblInfo := &model.TableInfo{ID: tblID, Name: model.NewCIStr("t_meta"), State: model.StatePublic}
+		return m.CreateTableOrView(dbInfo.ID, dbInfo.Name.L, tblInfo)
+	})
+	require.NoError(t, err)
+
+	tbl, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr("t_meta"))
+	require.NoError(t, err)
+	require.Equal(t, tblID, tbl.Meta().ID)
+	_, err = is.TableByName(model.NewCIStr("test"), model.NewCIStr("t_none"))
+	require.True(t, infoschema.ErrTableNotExists.Equal(err))
+
+	// The table names are not maintained in the meta if the fast create table is disabled.
+	tk.MustExec("set @@global.tidb_enable_fast_create_table = off;")
+	_, err = is.TableByName(model.NewCIStr("test"), model.NewCIStr("t_meta"))
+	require.True(t, infoschema.ErrTableNotExists.Equal(err))
+}
@@ -168,6 +168,10 @@ func (t *mockTxn) UpdateMemBufferFlags(_ []byte, _ ...FlagsOp) {
 
 }
 
+func (t *mockTxn) IsPipelined() bool {
+	return false
+}
+
 func (t *mockTxn) SetMemoryFootprintChangeHook(func(uint64)) {
 
 }
@@ -188,6 +188,13 @@ type MemBuffer interface {
 
 	// RemoveFromBuffer removes the entry from the buffer. It's used for testing.
 	RemoveFromBuffer(Key)
+
+	// Flush flushes the buffered mutations of a pipelined transaction to the storage in the background when they
+	// reach the flush threshold, or unconditionally if force is true. It returns whether a flush is triggered.
+	// It's a no-op for a normal transaction.
+	Flush(force bool) (bool, error)
+	// FlushWait waits for the ongoing flush of a pipelined transaction to finish and returns its error.
+	FlushWait() error
 }
 
 // FindKeysInStage returns all keys in the given stage that satisfies the given condition.
@@ -276,6 +283,10 @@ type Transaction interface {
 
 	// UpdateMemBufferFlags updates the flags of a node in the mem buffer.
 	UpdateMemBufferFlags(key []byte, flags ...FlagsOp)
+
+	// IsPipelined returns whether the mutations of the transaction are flushed to the storage during execution.
+	// The staging buffers, the snapshot iterators and the checkpoints of the MemBuffer are unavailable in this mode.
+	IsPipelined() bool
 }
 
 // AssertionProto is an interface defined for the assertion protocol.
@@ -585,6 +596,9 @@ type Request struct {
 	StoreBusyThreshold time.Duration
 	// TiKVClientReadTimeout is the timeout of kv read request
 	TiKVClientReadTimeout uint64
+	// IsPipelinedTxn indicates the request is sent by a pipelined DML transaction. The locks flushed by the
+	// transaction itself are skipped when reading, because their start ts equals to the StartTs of the request.
+	IsPipelinedTxn bool
 
 	RunawayChecker *resourcegroup.RunawayChecker
 
@@ -1372,6 +1372,32 @@ func (m *Meta) GetHistoryDDLJob(id int64) (*model.Job, error) {
 	return job, errors.Trace(err)
 }
 
+// GetFirstHistoryDDLJobs gets at most num history DDL jobs in the ascending order of the job ID.
+func (m *Meta) GetFirstHistoryDDLJobs(num int) ([]*model.Job, error) {
+	pairs, err := m.txn.HGetFirstN(mDDLJobHistoryKey, num)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	jobs := make([]*model.Job, 0, len(pairs))
+	for _, pair := range pairs {
+		job := &model.Job{}
+		if err = job.Decode(pair.Value); err != nil {
+			return nil, errors.Trace(err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// DeleteHistoryDDLJobs deletes the history DDL jobs by the job IDs.
+func (m *Meta) DeleteHistoryDDLJobs(ids ...int64) error {
+	fields := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		fields = append(fields, m.jobIDKey(id))
+	}
+	return errors.Trace(m.txn.HDel(mDDLJobHistoryKey, fields...))
+}
+
 // GetHistoryDDLCount the count of all history DDL jobs.
 func (m *Meta) GetHistoryDDLCount() (uint64, error) {
 	return m.txn.HGetLen(mDDLJobHistoryKey)
@@ -1561,6 +1587,21 @@ func (m *Meta) CheckTableNameNotExists(name []byte) error {
 	return errors.Trace(err)
 }
 
+// GetTableIDByName gets the table ID by the table name, it returns ErrTableNotExists if the name doesn't exist.
+// The names are only maintained when DDL v2 is initialized, see GetDDLV2Initialized.
+func (m *Meta) GetTableIDByName(dbName string, tableName string) (int64, error) {
+	key := m.TableNameKey(dbName, tableName)
+	v, err := m.txn.Get(key)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if v == nil {
+		return 0, ErrTableNotExists.FastGenByArgs(string(key))
+	}
+	id, err := strconv.ParseInt(string(v), 10, 64)
+	return id, errors.Trace(err)
+}
+
 // CreateTableName creates a table name.
 // Used by CreateTable/RenameTable/TruncateTable/RecoverTable/RecoverSchema/CreateView...
 func (m *Meta) CreateTableName(dbName string, tableName string, tableID int64) error {
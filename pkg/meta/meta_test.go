@@ -683,6 +683,13 @@ func TestName(t *testing.T) {
 	err = m.CreateTableName("db", "tb", 3)
 	require.True(t, meta.ErrTableExists.Equal(err))
 
+	// TestGetTableIDByName
+	id, err := m.GetTableIDByName("DB", "Tb")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), id)
+	_, err = m.GetTableIDByName("db", "t1")
+	require.True(t, meta.ErrTableNotExists.Equal(err))
+
 	err = m.CreateTableName("d", "btb", 3)
 	require.NoError(t, err)
 	err = m.CheckTableNameExists(m.TableNameKey("d", "btb"))
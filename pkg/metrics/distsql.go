@@ -27,6 +27,7 @@ var (
 	DistSQLCoprCacheCounter         *prometheus.CounterVec
 	DistSQLCoprClosestReadCounter   *prometheus.CounterVec
 	DistSQLCoprRespBodySize         *prometheus.HistogramVec
+	DistSQLCoprBatchWaitSize        prometheus.Histogram
 )
 
 // InitDistSQLMetrics initializes distsql metrics.
@@ -91,4 +92,13 @@ func InitDistSQLMetrics() {
 			Help:      "copr task response data size in bytes.",
 			Buckets:   prometheus.ExponentialBuckets(1024, 2, 20),
 		}, []string{LblStore})
+
+	DistSQLCoprBatchWaitSize = NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "tidb",
+			Subsystem: "distsql",
+			Name:      "copr_batch_wait_size",
+			Help:      "number of the coprocessor tasks of the concurrent requests merged into one RPC by the batch wait.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 11), // 1 ~ 1024
+		})
 }
@@ -0,0 +1,87 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics for the infoschema cache and the schema sync.
+var (
+	// InfoSchemaLoadDuration records the duration of loading the infoschema, by full load or by applying diffs.
+	InfoSchemaLoadDuration *prometheus.HistogramVec
+	// InfoSchemaApplyDiffDuration records the duration of applying a schema diff by the action type.
+	InfoSchemaApplyDiffDuration *prometheus.HistogramVec
+	// InfoSchemaV2CacheCounter records the hit/miss/evict of the table cache in infoschema v2.
+	InfoSchemaV2CacheCounter *prometheus.CounterVec
+	// InfoSchemaV2CacheTableCount records the number of tables in the table cache of infoschema v2.
+	InfoSchemaV2CacheTableCount prometheus.Gauge
+	// SchemaSyncLagDuration records the duration from a schema version being committed to being loaded by this node.
+	SchemaSyncLagDuration prometheus.Histogram
+	// SchemaVersionGauge records the latest schema version loaded by this node.
+	SchemaVersionGauge prometheus.Gauge
+)
+
+// InitInfoSchemaMetrics initializes infoschema metrics.
+func InitInfoSchemaMetrics() {
+	InfoSchemaLoadDuration = NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb",
+			Subsystem: "infoschema",
+			Name:      "load_duration_seconds",
+			Help:      "Bucketed histogram of processing time (s) of loading the infoschema.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 20), // 1ms ~ 524s
+		}, []string{LblType, LblResult})
+
+	InfoSchemaApplyDiffDuration = NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb",
+			Subsystem: "infoschema",
+			Name:      "apply_diff_duration_seconds",
+			Help:      "Bucketed histogram of processing time (s) of applying a schema diff.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 20), // 100us ~ 52s
+		}, []string{LblType})
+
+	InfoSchemaV2CacheCounter = NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "infoschema",
+			Name:      "v2_cache_total",
+			Help:      "Counter of hit/miss/evict of the table cache in infoschema v2.",
+		}, []string{LblType})
+
+	InfoSchemaV2CacheTableCount = NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "tidb",
+			Subsystem: "infoschema",
+			Name:      "v2_cache_tables",
+			Help:      "Number of tables in the table cache of infoschema v2.",
+		})
+
+	SchemaSyncLagDuration = NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "tidb",
+			Subsystem: "infoschema",
+			Name:      "schema_sync_lag_seconds",
+			Help:      "Bucketed histogram of the time (s) from a schema version being committed to being loaded by this node.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 20), // 1ms ~ 524s
+		})
+
+	SchemaVersionGauge = NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "tidb",
+			Subsystem: "infoschema",
+			Name:      "schema_version",
+			Help:      "The latest schema version loaded by this node.",
+		})
+}
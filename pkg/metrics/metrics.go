@@ -81,6 +81,7 @@ func InitMetrics() {
 	InitDomainMetrics()
 	InitExecutorMetrics()
 	InitGCWorkerMetrics()
+	InitInfoSchemaMetrics()
 	InitLogBackupMetrics()
 	InitMetaMetrics()
 	InitOwnerMetrics()
@@ -141,6 +142,7 @@ func RegisterMetrics() {
 	prometheus.MustRegister(DistSQLPartialCountHistogram)
 	prometheus.MustRegister(DistSQLCoprCacheCounter)
 	prometheus.MustRegister(DistSQLCoprClosestReadCounter)
+	prometheus.MustRegister(DistSQLCoprBatchWaitSize)
 	prometheus.MustRegister(DistSQLCoprRespBodySize)
 	prometheus.MustRegister(DistSQLQueryHistogram)
 	prometheus.MustRegister(DistSQLScanKeysHistogram)
@@ -158,6 +160,12 @@ func RegisterMetrics() {
 	prometheus.MustRegister(JobsGauge)
 	prometheus.MustRegister(LoadPrivilegeCounter)
 	prometheus.MustRegister(InfoCacheCounters)
+	prometheus.MustRegister(InfoSchemaLoadDuration)
+	prometheus.MustRegister(InfoSchemaApplyDiffDuration)
+	prometheus.MustRegister(InfoSchemaV2CacheCounter)
+	prometheus.MustRegister(InfoSchemaV2CacheTableCount)
+	prometheus.MustRegister(SchemaSyncLagDuration)
+	prometheus.MustRegister(SchemaVersionGauge)
 	prometheus.MustRegister(LeaseExpireTime)
 	prometheus.MustRegister(LoadSchemaCounter)
 	prometheus.MustRegister(LoadSchemaDuration)
@@ -226,6 +234,7 @@ func RegisterMetrics() {
 	prometheus.MustRegister(PDAPIExecutionHistogram)
 	prometheus.MustRegister(PDAPIRequestCounter)
 	prometheus.MustRegister(CPUProfileCounter)
+	prometheus.MustRegister(AuditLogDroppedCounter)
 	prometheus.MustRegister(ReadFromTableCacheCounter)
 	prometheus.MustRegister(LoadTableCacheDurationHistogram)
 	prometheus.MustRegister(NonTransactionalDMLCount)
@@ -68,6 +68,7 @@ var (
 	PDAPIExecutionHistogram         *prometheus.HistogramVec
 	PDAPIRequestCounter             *prometheus.CounterVec
 	CPUProfileCounter               prometheus.Counter
+	AuditLogDroppedCounter          prometheus.Counter
 	LoadTableCacheDurationHistogram prometheus.Histogram
 	RCCheckTSWriteConfilictCounter  *prometheus.CounterVec
 )
@@ -357,6 +358,14 @@ func InitServerMetrics() {
 			Help:      "Counter of cpu profiling",
 		})
 
+	AuditLogDroppedCounter = NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "server",
+			Name:      "audit_log_dropped_total",
+			Help:      "Counter of audit log entries dropped because the queue is full",
+		})
+
 	LoadTableCacheDurationHistogram = NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "tidb",
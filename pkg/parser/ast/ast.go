@@ -245,6 +245,8 @@ func GetStmtLabel(stmtNode StmtNode) string {
 		return "CreateBinding"
 	case *IndexAdviseStmt:
 		return "IndexAdvise"
+	case *RecommendIndexStmt:
+		return "RecommendIndex"
 	case *DropBindingStmt:
 		return "DropBinding"
 	case *TraceStmt:
@@ -36,7 +36,10 @@ var (
 	_ DDLNode = &CreateIndexStmt{}
 	_ DDLNode = &CreateTableStmt{}
 	_ DDLNode = &CreateViewStmt{}
+	_ DDLNode = &CreateMaterializedViewStmt{}
+	_ DDLNode = &RefreshMaterializedViewStmt{}
 	_ DDLNode = &CreateSequenceStmt{}
+	_ DDLNode = &CreateTriggerStmt{}
 	_ DDLNode = &CreatePlacementPolicyStmt{}
 	_ DDLNode = &CreateResourceGroupStmt{}
 	_ DDLNode = &DropDatabaseStmt{}
@@ -44,6 +47,7 @@ var (
 	_ DDLNode = &DropIndexStmt{}
 	_ DDLNode = &DropTableStmt{}
 	_ DDLNode = &DropSequenceStmt{}
+	_ DDLNode = &DropTriggerStmt{}
 	_ DDLNode = &DropPlacementPolicyStmt{}
 	_ DDLNode = &DropResourceGroupStmt{}
 	_ DDLNode = &OptimizeTableStmt{}
@@ -82,6 +86,7 @@ const (
 	DatabaseOptionCollate
 	DatabaseOptionEncryption
 	DatabaseSetTiFlashReplica
+	DatabaseSetReadOnly
 	DatabaseOptionPlacementPolicy = DatabaseOptionType(PlacementOptionPolicy)
 )
 
@@ -118,16 +123,25 @@ func (n *DatabaseOption) Restore(ctx *format.RestoreCtx) error {
 	case DatabaseSetTiFlashReplica:
 		ctx.WriteKeyWord("SET TIFLASH REPLICA ")
 		ctx.WritePlainf("%d", n.TiFlashReplica.Count)
-		if len(n.TiFlashReplica.Labels) == 0 {
-			break
+		if len(n.TiFlashReplica.Labels) > 0 {
+			ctx.WriteKeyWord(" LOCATION LABELS ")
+			for i, v := range n.TiFlashReplica.Labels {
+				if i > 0 {
+					ctx.WritePlain(", ")
+				}
+				ctx.WriteString(v)
+			}
 		}
-		ctx.WriteKeyWord(" LOCATION LABELS ")
-		for i, v := range n.TiFlashReplica.Labels {
-			if i > 0 {
-				ctx.WritePlain(", ")
+		if n.TiFlashReplica.Wait != nil {
+			ctx.WritePlain(" ")
+			if err := n.TiFlashReplica.Wait.Restore(ctx); err != nil {
+				return errors.Annotate(err, "An error occurred while restore AlterTableSpec.TiFlashReplica.Wait")
 			}
-			ctx.WriteString(v)
 		}
+	case DatabaseSetReadOnly:
+		ctx.WriteKeyWord("SET READ ONLY ")
+		ctx.WritePlain("= ")
+		ctx.WritePlainf("%d", n.UintValue)
 	default:
 		return errors.Errorf("invalid DatabaseOptionType: %d", n.Tp)
 	}
@@ -526,6 +540,8 @@ const (
 	ColumnOptionColumnFormat
 	ColumnOptionStorage
 	ColumnOptionAutoRandom
+	ColumnOptionVisible
+	ColumnOptionInvisible
 )
 
 var (
@@ -656,6 +672,10 @@ func (n *ColumnOption) Restore(ctx *format.RestoreCtx) error {
 			}
 			return nil
 		})
+	case ColumnOptionVisible:
+		ctx.WriteKeyWord("VISIBLE")
+	case ColumnOptionInvisible:
+		ctx.WriteKeyWord("INVISIBLE")
 	default:
 		return errors.New("An error occurred while splicing ColumnOption")
 	}
@@ -714,6 +734,9 @@ type IndexOption struct {
 	ParserName   model.CIStr
 	Visibility   IndexVisibility
 	PrimaryKeyTp model.PrimaryKeyType
+	// DistanceMetric is not a part of the syntax, it's resolved from the key part of a vector index
+	// like `(VEC_COSINE_DISTANCE(col))` and passed to the DDL job with the index option.
+	DistanceMetric model.DistanceMetric
 }
 
 // Restore implements Node interface.
@@ -801,6 +824,7 @@ const (
 	ConstraintForeignKey
 	ConstraintFulltext
 	ConstraintCheck
+	ConstraintVector
 )
 
 // Constraint is constraint for table definition.
@@ -855,6 +879,11 @@ func (n *Constraint) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("UNIQUE INDEX")
 	case ConstraintFulltext:
 		ctx.WriteKeyWord("FULLTEXT")
+	case ConstraintVector:
+		ctx.WriteKeyWord("VECTOR INDEX")
+		if n.IfNotExists {
+			ctx.WriteKeyWord(" IF NOT EXISTS")
+		}
 	case ConstraintCheck:
 		if n.Name != "" {
 			ctx.WriteKeyWord("CONSTRAINT ")
@@ -1048,21 +1077,28 @@ type CreateTableStmt struct {
 	// ON COMMIT DELETE ROWS => true
 	// ON COMMIT PRESERVE ROW => false
 	OnCommitDelete bool
-	Table          *TableName
-	ReferTable     *TableName
-	Cols           []*ColumnDef
-	Constraints    []*Constraint
-	Options        []*TableOption
-	Partition      *PartitionOptions
-	OnDuplicate    OnDuplicateKeyHandlingType
-	Select         ResultSetNode
+	// IsExternal indicates the rows of the table are read from the files specified by the
+	// LOCATION and FORMAT options, i.e. CREATE EXTERNAL TABLE.
+	IsExternal  bool
+	Table       *TableName
+	ReferTable  *TableName
+	Cols        []*ColumnDef
+	Constraints []*Constraint
+	Options     []*TableOption
+	Partition   *PartitionOptions
+	OnDuplicate OnDuplicateKeyHandlingType
+	Select      ResultSetNode
 }
 
 // Restore implements Node interface.
 func (n *CreateTableStmt) Restore(ctx *format.RestoreCtx) error {
 	switch n.TemporaryKeyword {
 	case TemporaryNone:
-		ctx.WriteKeyWord("CREATE TABLE ")
+		if n.IsExternal {
+			ctx.WriteKeyWord("CREATE EXTERNAL TABLE ")
+		} else {
+			ctx.WriteKeyWord("CREATE TABLE ")
+		}
 	case TemporaryGlobal:
 		ctx.WriteKeyWord("CREATE GLOBAL TEMPORARY TABLE ")
 	case TemporaryLocal:
@@ -1209,16 +1245,19 @@ func (n *CreateTableStmt) Accept(v Visitor) (Node, bool) {
 type DropTableStmt struct {
 	ddlNode
 
-	IfExists         bool
-	Tables           []*TableName
-	IsView           bool
-	TemporaryKeyword // make sense ONLY if/when IsView == false
+	IfExists           bool
+	Tables             []*TableName
+	IsView             bool
+	IsMaterializedView bool
+	TemporaryKeyword   // make sense ONLY if/when IsView == false
 }
 
 // Restore implements Node interface.
 func (n *DropTableStmt) Restore(ctx *format.RestoreCtx) error {
 	if n.IsView {
 		ctx.WriteKeyWord("DROP VIEW ")
+	} else if n.IsMaterializedView {
+		ctx.WriteKeyWord("DROP MATERIALIZED VIEW ")
 	} else {
 		switch n.TemporaryKeyword {
 		case TemporaryNone:
@@ -1573,6 +1612,91 @@ func (n *CreateViewStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// CreateMaterializedViewStmt is a statement to create a materialized view.
+type CreateMaterializedViewStmt struct {
+	ddlNode
+
+	IfNotExists bool
+	ViewName    *TableName
+	// RefreshEvery and RefreshUnit are the interval of the automatic refresh, RefreshUnit is
+	// TimeUnitInvalid if the view is only refreshed by REFRESH MATERIALIZED VIEW.
+	RefreshEvery uint64
+	RefreshUnit  TimeUnitType
+	Select       StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE MATERIALIZED VIEW ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.ViewName")
+	}
+	if n.RefreshUnit != TimeUnitInvalid {
+		ctx.WriteKeyWord(" REFRESH EVERY ")
+		ctx.WritePlainf("%d ", n.RefreshEvery)
+		ctx.WriteKeyWord(n.RefreshUnit.String())
+	}
+	ctx.WriteKeyWord(" AS ")
+	if err := n.Select.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.Select")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	selnode, ok := n.Select.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Select = selnode.(StmtNode)
+	return v.Leave(n)
+}
+
+// RefreshMaterializedViewStmt is a statement to refresh the data of a materialized view.
+type RefreshMaterializedViewStmt struct {
+	ddlNode
+
+	ViewName *TableName
+}
+
+// Restore implements Node interface.
+func (n *RefreshMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("REFRESH MATERIALIZED VIEW ")
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore RefreshMaterializedViewStmt.ViewName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *RefreshMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RefreshMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	return v.Leave(n)
+}
+
 // CreatePlacementPolicyStmt is a statement to create a policy.
 type CreatePlacementPolicyStmt struct {
 	ddlNode
@@ -1760,6 +1884,7 @@ const (
 	IndexKeyTypeUnique
 	IndexKeyTypeSpatial
 	IndexKeyTypeFullText
+	IndexKeyTypeVector
 )
 
 // CreateIndexStmt is a statement to create an index.
@@ -1789,6 +1914,8 @@ func (n *CreateIndexStmt) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("SPATIAL ")
 	case IndexKeyTypeFullText:
 		ctx.WriteKeyWord("FULLTEXT ")
+	case IndexKeyTypeVector:
+		ctx.WriteKeyWord("VECTOR ")
 	}
 	ctx.WriteKeyWord("INDEX ")
 	if n.IfNotExists {
@@ -2373,6 +2500,10 @@ const (
 	TableOptionTTL
 	TableOptionTTLEnable
 	TableOptionTTLJobInterval
+	TableOptionIntervalPrecreate
+	TableOptionIntervalRetention
+	TableOptionLocation
+	TableOptionFormat
 	TableOptionPlacementPolicy = TableOptionType(PlacementOptionPolicy)
 	TableOptionStatsBuckets    = TableOptionType(StatsOptionBuckets)
 	TableOptionStatsTopN       = TableOptionType(StatsOptionTopN)
@@ -2738,6 +2869,28 @@ func (n *TableOption) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteString(n.StrValue)
 			return nil
 		})
+	case TableOptionIntervalPrecreate:
+		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDIntervalPartition, func() error {
+			ctx.WriteKeyWord("INTERVAL_PRECREATE ")
+			ctx.WritePlain("= ")
+			ctx.WritePlainf("%d", n.UintValue)
+			return nil
+		})
+	case TableOptionIntervalRetention:
+		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDIntervalPartition, func() error {
+			ctx.WriteKeyWord("INTERVAL_RETENTION ")
+			ctx.WritePlain("= ")
+			ctx.WritePlainf("%d", n.UintValue)
+			return nil
+		})
+	case TableOptionLocation:
+		ctx.WriteKeyWord("LOCATION ")
+		ctx.WritePlain("= ")
+		ctx.WriteString(n.StrValue)
+	case TableOptionFormat:
+		ctx.WriteKeyWord("FORMAT ")
+		ctx.WritePlain("= ")
+		ctx.WriteString(n.StrValue)
 	default:
 		return errors.Errorf("invalid TableOption: %d", n.Tp)
 	}
@@ -2957,6 +3110,8 @@ const (
 	AlterTableReorganizeLastPartition
 	AlterTableReorganizeFirstPartition
 	AlterTableRemoveTTL
+	AlterTableSplitPartition
+	AlterTableMergePartitions
 )
 
 // LockType is the type for AlterTableSpec.
@@ -3063,6 +3218,24 @@ type TiFlashReplicaSpec struct {
 	Count  uint64
 	Labels []string
 	Hypo   bool // hypothetical replica is used by index advisor
+	// Wait is set if the statement waits for the replicas to be available.
+	Wait *TiFlashReplicaWaitOption
+}
+
+// TiFlashReplicaWaitOption is the option to wait for the TiFlash replicas to be available.
+type TiFlashReplicaWaitOption struct {
+	// Timeout is the max seconds to wait, 0 means waiting until the replicas are available.
+	Timeout uint64
+}
+
+// Restore implements Node interface.
+func (n *TiFlashReplicaWaitOption) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("WAIT")
+	if n.Timeout > 0 {
+		ctx.WriteKeyWord(" TIMEOUT ")
+		ctx.WritePlainf("%d", n.Timeout)
+	}
+	return nil
 }
 
 // AlterOrderItem represents an item in order by at alter table stmt.
@@ -3106,15 +3279,20 @@ func (n *AlterTableSpec) Restore(ctx *format.RestoreCtx) error {
 	case AlterTableSetTiFlashReplica:
 		ctx.WriteKeyWord("SET TIFLASH REPLICA ")
 		ctx.WritePlainf("%d", n.TiFlashReplica.Count)
-		if len(n.TiFlashReplica.Labels) == 0 {
-			break
+		if len(n.TiFlashReplica.Labels) > 0 {
+			ctx.WriteKeyWord(" LOCATION LABELS ")
+			for i, v := range n.TiFlashReplica.Labels {
+				if i > 0 {
+					ctx.WritePlain(", ")
+				}
+				ctx.WriteString(v)
+			}
 		}
-		ctx.WriteKeyWord(" LOCATION LABELS ")
-		for i, v := range n.TiFlashReplica.Labels {
-			if i > 0 {
-				ctx.WritePlain(", ")
+		if n.TiFlashReplica.Wait != nil {
+			ctx.WritePlain(" ")
+			if err := n.TiFlashReplica.Wait.Restore(ctx); err != nil {
+				return errors.Annotate(err, "An error occurred while restore AlterTableSpec.TiFlashReplica.Wait")
 			}
-			ctx.WriteString(v)
 		}
 	case AlterTableAddStatistics:
 		ctx.WriteKeyWord("ADD STATS_EXTENDED ")
@@ -3292,11 +3470,12 @@ func (n *AlterTableSpec) Restore(ctx *format.RestoreCtx) error {
 		if len(n.NewColumns[0].Options) == 1 {
 			ctx.WriteKeyWord("SET DEFAULT ")
 			expr := n.NewColumns[0].Options[0].Expr
-			if valueExpr, ok := expr.(ValueExpr); ok {
-				if err := valueExpr.Restore(ctx); err != nil {
+			switch expr.(type) {
+			case ValueExpr, *ParenthesesExpr:
+				if err := expr.Restore(ctx); err != nil {
 					return errors.Annotate(err, "An error occurred while restore AlterTableSpec.NewColumns[0].Options[0].Expr")
 				}
-			} else {
+			default:
 				ctx.WritePlain("(")
 				if err := expr.Restore(ctx); err != nil {
 					return errors.Annotate(err, "An error occurred while restore AlterTableSpec.NewColumns[0].Options[0].Expr")
@@ -3590,6 +3769,30 @@ func (n *AlterTableSpec) Restore(ctx *format.RestoreCtx) error {
 			}
 			ctx.WritePlain(")")
 		}
+	case AlterTableSplitPartition:
+		ctx.WriteKeyWord("SPLIT PARTITION ")
+		ctx.WriteName(n.PartitionNames[0].O)
+		ctx.WriteKeyWord(" INTO ")
+		ctx.WritePlain("(")
+		for i, def := range n.PartDefinitions {
+			if i != 0 {
+				ctx.WritePlain(", ")
+			}
+			if err := def.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore AlterTableSpec.PartDefinitions[%d]", i)
+			}
+		}
+		ctx.WritePlain(")")
+	case AlterTableMergePartitions:
+		ctx.WriteKeyWord("MERGE PARTITIONS ")
+		for i, name := range n.PartitionNames {
+			if i != 0 {
+				ctx.WritePlain(",")
+			}
+			ctx.WriteName(name.O)
+		}
+		ctx.WriteKeyWord(" INTO PARTITION ")
+		ctx.WriteName(n.PartDefinitions[0].Name.O)
 	case AlterTableExchangePartition:
 		ctx.WriteKeyWord("EXCHANGE PARTITION ")
 		ctx.WriteName(n.PartitionNames[0].O)
@@ -4758,6 +4961,93 @@ func (n *AlterSequenceStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// CreateTriggerStmt is a statement to create a row-level trigger.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-trigger.html
+type CreateTriggerStmt struct {
+	ddlNode
+
+	IfNotExists bool
+	Name        *TableName
+	Timing      model.TriggerTiming
+	Event       model.TriggerEvent
+	Table       *TableName
+	Body        StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateTriggerStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE TRIGGER ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.Name.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.Name")
+	}
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Timing.String())
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Event.String())
+	ctx.WriteKeyWord(" ON ")
+	if err := n.Table.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.Table")
+	}
+	ctx.WriteKeyWord(" FOR EACH ROW ")
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateTriggerStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateTriggerStmt)
+	node, ok := n.Table.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Table = node.(*TableName)
+	node, ok = n.Body.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Body = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// DropTriggerStmt is a statement to drop a trigger.
+type DropTriggerStmt struct {
+	ddlNode
+
+	IfExists bool
+	Name     *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropTriggerStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP TRIGGER ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.Name.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropTriggerStmt.Name")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropTriggerStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropTriggerStmt)
+	return v.Leave(n)
+}
+
 func restorePlacementStmtInSpecialComment(ctx *format.RestoreCtx, n DDLNode) error {
 	return restoreStmtInSpecialComment(ctx, n, tidb.FeatureIDPlacement)
 }
@@ -39,6 +39,8 @@ func TestDDLVisitorCover(t *testing.T) {
 		{&DropTableStmt{Tables: []*TableName{{}, {}}}, 0, 0},
 		{&RenameTableStmt{TableToTables: []*TableToTable{}}, 0, 0},
 		{&TruncateTableStmt{Table: &TableName{}}, 0, 0},
+		{&CreateTriggerStmt{Name: &TableName{}, Table: &TableName{}, Body: &SetStmt{Variables: []*VariableAssignment{{Value: ce}}}}, 1, 1},
+		{&DropTriggerStmt{Name: &TableName{}}, 0, 0},
 
 		// TODO: cover children
 		{&AlterTableStmt{Table: &TableName{}, Specs: []*AlterTableSpec{alterTableSpec}}, 0, 0},
@@ -305,6 +305,10 @@ func (n *TableName) restoreName(ctx *format.RestoreCtx) {
 		}
 	}
 	// restore table name
+	if ctx.Flags.HasTableNameAsWildcardFlag() {
+		ctx.WriteName("*")
+		return
+	}
 	ctx.WriteName(n.Name.String())
 }
 
@@ -3029,6 +3033,8 @@ const (
 	ShowCreateProcedure
 	ShowBinlogStatus
 	ShowReplicaStatus
+	ShowDistJobs
+	ShowDistJobSubtasks
 )
 
 const (
@@ -3065,6 +3071,7 @@ type ShowStmt struct {
 	IfNotExists       bool                 // Used for `show create database if not exists`
 	Extended          bool                 // Used for `show extended columns from ...`
 	Limit             *Limit               // Used for partial Show STMTs to limit Result Set row numbers.
+	RegionsSummary    bool                 // Used for `show table regions summary`.
 
 	CountWarningsOrErrors bool // Used for showing count(*) warnings | errors
 
@@ -3078,6 +3085,7 @@ type ShowStmt struct {
 	ShowProfileLimit *Limit // Used for `SHOW PROFILE` syntax
 
 	ImportJobID *int64 // Used for `SHOW IMPORT JOB <ID>` syntax
+	DistJobID   int64  // Used for `SHOW JOB <ID>` syntax
 }
 
 // Restore implements Node interface.
@@ -3296,6 +3304,9 @@ func (n *ShowStmt) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteKeyWord("IMPORT JOBS")
 			restoreShowLikeOrWhereOpt()
 		}
+	case ShowDistJobSubtasks:
+		ctx.WriteKeyWord("JOB ")
+		ctx.WritePlainf("%d", n.DistJobID)
 	// ShowTargetFilterable
 	default:
 		switch n.Tp {
@@ -3387,6 +3398,9 @@ func (n *ShowStmt) Restore(ctx *format.RestoreCtx) error {
 				ctx.WriteName(n.IndexName.String())
 			}
 			ctx.WriteKeyWord(" REGIONS")
+			if n.RegionsSummary {
+				ctx.WriteKeyWord(" SUMMARY")
+			}
 			if err := restoreShowLikeOrWhereOpt(); err != nil {
 				return err
 			}
@@ -3404,6 +3418,8 @@ func (n *ShowStmt) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteKeyWord("RESTORES")
 		case ShowImports:
 			ctx.WriteKeyWord("IMPORTS")
+		case ShowDistJobs:
+			ctx.WriteKeyWord("JOBS")
 		case ShowPlacement:
 			ctx.WriteKeyWord("PLACEMENT")
 		case ShowPlacementLabels:
@@ -3819,6 +3835,9 @@ type SplitRegionStmt struct {
 	SplitSyntaxOpt *SplitSyntaxOption
 
 	SplitOpt *SplitOption
+	// Scatter means to wait for the new regions to be scattered by PD, no matter whether
+	// tidb_wait_split_region_finish is set.
+	Scatter bool
 }
 
 type SplitOption struct {
@@ -3826,6 +3845,8 @@ type SplitOption struct {
 	Upper      []ExprNode
 	Num        int64
 	ValueLists [][]ExprNode
+	// ByQuantiles means to split the regions at the quantiles computed from the statistics.
+	ByQuantiles bool
 }
 
 type SplitSyntaxOption struct {
@@ -3865,8 +3886,13 @@ func (n *SplitRegionStmt) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteName(n.IndexName.String())
 	}
 	ctx.WritePlain(" ")
-	err := n.SplitOpt.Restore(ctx)
-	return err
+	if err := n.SplitOpt.Restore(ctx); err != nil {
+		return err
+	}
+	if n.Scatter {
+		ctx.WriteKeyWord(" SCATTER")
+	}
+	return nil
 }
 
 func (n *SplitRegionStmt) Accept(v Visitor) (Node, bool) {
@@ -3909,6 +3935,11 @@ func (n *SplitRegionStmt) Accept(v Visitor) (Node, bool) {
 }
 
 func (n *SplitOption) Restore(ctx *format.RestoreCtx) error {
+	if n.ByQuantiles {
+		ctx.WriteKeyWord("BY QUANTILES REGIONS")
+		ctx.WritePlainf(" %d", n.Num)
+		return nil
+	}
 	if len(n.ValueLists) == 0 {
 		ctx.WriteKeyWord("BETWEEN ")
 		ctx.WritePlain("(")
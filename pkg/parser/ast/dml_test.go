@@ -538,6 +538,35 @@ func TestImportActions(t *testing.T) {
 	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
 }
 
+func TestDistJobActions(t *testing.T) {
+	testCases := []NodeRestoreTestCase{
+		{
+			sourceSQL: "show jobs",
+			expectSQL: "SHOW JOBS",
+		},
+		{
+			sourceSQL: "show job 123",
+			expectSQL: "SHOW JOB 123",
+		},
+		{
+			sourceSQL: "cancel job 123",
+			expectSQL: "CANCEL JOB 123",
+		},
+		{
+			sourceSQL: "pause job 123",
+			expectSQL: "PAUSE JOB 123",
+		},
+		{
+			sourceSQL: "resume job 123",
+			expectSQL: "RESUME JOB 123",
+		},
+	}
+	extractNodeFunc := func(node Node) Node {
+		return node
+	}
+	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
+}
+
 func TestImportIntoRestore(t *testing.T) {
 	testCases := []NodeRestoreTestCase{
 		{
@@ -304,6 +304,8 @@ const (
 	GetLock         = "get_lock"
 	ReleaseLock     = "release_lock"
 	Grouping        = "grouping"
+	// MatchAgainstFunc is the function which MATCH ... AGAINST is rewritten to.
+	MatchAgainstFunc = "match_against"
 
 	// encryption and compression functions
 	AesDecrypt               = "aes_decrypt"
@@ -322,6 +324,9 @@ const (
 	SHA                      = "sha"
 	SHA2                     = "sha2"
 	SM3                      = "sm3"
+	MaskEmail                = "mask_email"
+	MaskHash                 = "mask_hash"
+	MaskPartial              = "mask_partial"
 	Uncompress               = "uncompress"
 	UncompressedLength       = "uncompressed_length"
 	ValidatePasswordStrength = "validate_password_strength"
@@ -355,6 +360,14 @@ const (
 	JSONKeys          = "json_keys"
 	JSONLength        = "json_length"
 
+	// vector functions
+	VecDims           = "vec_dims"
+	VecL2Norm         = "vec_l2_norm"
+	VecL2Distance     = "vec_l2_distance"
+	VecCosineDistance = "vec_cosine_distance"
+	VecFromText       = "vec_from_text"
+	VecAsText         = "vec_as_text"
+
 	// TiDB internal function.
 	TiDBDecodeKey       = "tidb_decode_key"
 	TiDBDecodeBase64Key = "tidb_decode_base64_key"
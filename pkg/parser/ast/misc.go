@@ -2333,6 +2333,15 @@ const (
 	AdminSetBDRRole
 	AdminShowBDRRole
 	AdminUnsetBDRRole
+	AdminReloadMetricTables
+	AdminCheckTableOnline
+	AdminCancelCheckTableJobs
+	AdminDiffSchema
+	AdminRotateEncryptionKey
+	AdminCompareOptimizerTrace
+	AdminWaitTiFlashReplica
+	AdminPauseLogBackup
+	AdminResumeLogBackup
 )
 
 // HandleRange represents a range where handle value >= Begin and < End.
@@ -2449,6 +2458,37 @@ func (n *ShowSlow) Restore(ctx *format.RestoreCtx) error {
 	return nil
 }
 
+// DiffSchemaOption is used for parsing ADMIN DIFF SCHEMA statement.
+//
+//	admin diff schema between timestamp 'from' and 'to' [for db[.table]]
+type DiffSchemaOption struct {
+	FromTS ExprNode
+	ToTS   ExprNode
+	Schema model.CIStr
+	Table  model.CIStr
+}
+
+// Restore implements Node interface.
+func (n *DiffSchemaOption) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("BETWEEN TIMESTAMP ")
+	if err := n.FromTS.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DiffSchemaOption.FromTS")
+	}
+	ctx.WriteKeyWord(" AND ")
+	if err := n.ToTS.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DiffSchemaOption.ToTS")
+	}
+	if n.Schema.O != "" {
+		ctx.WriteKeyWord(" FOR ")
+		ctx.WriteName(n.Schema.O)
+		if n.Table.O != "" {
+			ctx.WritePlain(".")
+			ctx.WriteName(n.Table.O)
+		}
+	}
+	return nil
+}
+
 // LimitSimple is the struct for Admin statement limit option.
 type LimitSimple struct {
 	Count  uint64
@@ -2472,6 +2512,14 @@ type AdminStmt struct {
 	StatementScope StatementScope
 	LimitSimple    LimitSimple
 	BDRRole        BDRRole
+	DiffSchema     *DiffSchemaOption
+	// PlanDigests is the plan digests of the optimizer traces compared by ADMIN COMPARE OPTIMIZER TRACE.
+	PlanDigests []string
+	// TiFlashReplicaWait is the wait option of ADMIN WAIT TIFLASH REPLICA.
+	TiFlashReplicaWait *TiFlashReplicaWaitOption
+	// LogBackupTask is the log backup task paused or resumed by ADMIN PAUSE/RESUME LOG BACKUP,
+	// all the tasks are affected if it's empty.
+	LogBackupTask string
 }
 
 // Restore implements Node interface.
@@ -2522,6 +2570,12 @@ func (n *AdminStmt) Restore(ctx *format.RestoreCtx) error {
 		if err := restoreTables(); err != nil {
 			return err
 		}
+	case AdminCheckTableOnline:
+		ctx.WriteKeyWord("CHECK TABLE ")
+		if err := restoreTables(); err != nil {
+			return err
+		}
+		ctx.WriteKeyWord(" ONLINE")
 	case AdminCheckIndex:
 		ctx.WriteKeyWord("CHECK INDEX ")
 		if err := restoreTables(); err != nil {
@@ -2563,6 +2617,9 @@ func (n *AdminStmt) Restore(ctx *format.RestoreCtx) error {
 	case AdminCancelDDLJobs:
 		ctx.WriteKeyWord("CANCEL DDL JOBS ")
 		restoreJobIDs()
+	case AdminCancelCheckTableJobs:
+		ctx.WriteKeyWord("CANCEL CHECK TABLE JOBS ")
+		restoreJobIDs()
 	case AdminPauseDDLJobs:
 		ctx.WriteKeyWord("PAUSE DDL JOBS ")
 		restoreJobIDs()
@@ -2584,6 +2641,42 @@ func (n *AdminStmt) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("RELOAD EXPR_PUSHDOWN_BLACKLIST")
 	case AdminReloadOptRuleBlacklist:
 		ctx.WriteKeyWord("RELOAD OPT_RULE_BLACKLIST")
+	case AdminReloadMetricTables:
+		ctx.WriteKeyWord("RELOAD METRIC_TABLES")
+	case AdminDiffSchema:
+		ctx.WriteKeyWord("DIFF SCHEMA ")
+		if err := n.DiffSchema.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AdminStmt.DiffSchema")
+		}
+	case AdminRotateEncryptionKey:
+		ctx.WriteKeyWord("ROTATE ENCRYPTION KEY")
+	case AdminWaitTiFlashReplica:
+		ctx.WriteKeyWord("WAIT TIFLASH REPLICA ")
+		if err := restoreTables(); err != nil {
+			return err
+		}
+		if n.TiFlashReplicaWait.Timeout > 0 {
+			ctx.WriteKeyWord(" TIMEOUT ")
+			ctx.WritePlainf("%d", n.TiFlashReplicaWait.Timeout)
+		}
+	case AdminPauseLogBackup, AdminResumeLogBackup:
+		if n.Tp == AdminPauseLogBackup {
+			ctx.WriteKeyWord("PAUSE LOG BACKUP")
+		} else {
+			ctx.WriteKeyWord("RESUME LOG BACKUP")
+		}
+		if n.LogBackupTask != "" {
+			ctx.WritePlain(" ")
+			ctx.WriteString(n.LogBackupTask)
+		}
+	case AdminCompareOptimizerTrace:
+		ctx.WriteKeyWord("COMPARE OPTIMIZER TRACE ")
+		for i, digest := range n.PlanDigests {
+			if i != 0 {
+				ctx.WritePlain(" ")
+			}
+			ctx.WriteString(digest)
+		}
 	case AdminPluginEnable:
 		ctx.WriteKeyWord("PLUGINS ENABLE")
 		for i, v := range n.Plugins {
@@ -3061,8 +3154,9 @@ func (n *GrantProxyStmt) Restore(ctx *format.RestoreCtx) error {
 type GrantRoleStmt struct {
 	stmtNode
 
-	Roles []*auth.RoleIdentity
-	Users []*auth.UserIdentity
+	Roles           []*auth.RoleIdentity
+	Users           []*auth.UserIdentity
+	WithAdminOption bool
 }
 
 // Accept implements Node Accept interface.
@@ -3097,6 +3191,9 @@ func (n *GrantRoleStmt) Restore(ctx *format.RestoreCtx) error {
 			return errors.Annotatef(err, "An error occurred while restore GrantStmt.Users[%d]", i)
 		}
 	}
+	if n.WithAdminOption {
+		ctx.WriteKeyWord(" WITH ADMIN OPTION")
+	}
 	return nil
 }
 
@@ -3111,6 +3208,399 @@ func (n *GrantRoleStmt) SecureText() string {
 	return text
 }
 
+// CreateMaskingPolicyStmt is the statement to create a masking policy on a column.
+// The masking expression is applied to the column when it's read by the users with the roles.
+type CreateMaskingPolicyStmt struct {
+	stmtNode
+
+	IfNotExists bool
+	PolicyName  model.CIStr
+	Table       *TableName
+	Column      *ColumnName
+	Expr        ExprNode
+	Roles       []*auth.RoleIdentity
+}
+
+// Restore implements Node interface.
+func (n *CreateMaskingPolicyStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE MASKING POLICY ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	ctx.WriteName(n.PolicyName.O)
+	ctx.WriteKeyWord(" ON ")
+	if err := n.Table.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaskingPolicyStmt.Table")
+	}
+	ctx.WritePlain(" (")
+	if err := n.Column.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaskingPolicyStmt.Column")
+	}
+	ctx.WritePlain(")")
+	ctx.WriteKeyWord(" USING ")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaskingPolicyStmt.Expr")
+	}
+	ctx.WriteKeyWord(" TO ")
+	for i, role := range n.Roles {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := role.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore CreateMaskingPolicyStmt.Roles[%d]", i)
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateMaskingPolicyStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateMaskingPolicyStmt)
+	node, ok := n.Table.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Table = node.(*TableName)
+	node, ok = n.Column.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Column = node.(*ColumnName)
+	node, ok = n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
+// DropMaskingPolicyStmt is the statement to drop a masking policy.
+type DropMaskingPolicyStmt struct {
+	stmtNode
+
+	IfExists   bool
+	PolicyName model.CIStr
+}
+
+// Restore implements Node interface.
+func (n *DropMaskingPolicyStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP MASKING POLICY ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	ctx.WriteName(n.PolicyName.O)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropMaskingPolicyStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropMaskingPolicyStmt)
+	return v.Leave(n)
+}
+
+// ServerOptionType is the type of the option in CREATE SERVER statement.
+type ServerOptionType int
+
+// ServerOption types.
+const (
+	ServerOptionHost ServerOptionType = iota + 1
+	ServerOptionDatabase
+	ServerOptionUser
+	ServerOptionPassword
+	ServerOptionSocket
+	ServerOptionOwner
+	ServerOptionPort
+)
+
+// ServerOption is an option of the server in CREATE SERVER statement.
+type ServerOption struct {
+	Tp        ServerOptionType
+	StrValue  string
+	UintValue uint64
+}
+
+// Restore implements Node interface.
+func (n *ServerOption) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case ServerOptionHost:
+		ctx.WriteKeyWord("HOST ")
+	case ServerOptionDatabase:
+		ctx.WriteKeyWord("DATABASE ")
+	case ServerOptionUser:
+		ctx.WriteKeyWord("USER ")
+	case ServerOptionPassword:
+		ctx.WriteKeyWord("PASSWORD ")
+	case ServerOptionSocket:
+		ctx.WriteKeyWord("SOCKET ")
+	case ServerOptionOwner:
+		ctx.WriteKeyWord("OWNER ")
+	case ServerOptionPort:
+		ctx.WriteKeyWord("PORT ")
+		ctx.WritePlainf("%d", n.UintValue)
+		return nil
+	default:
+		return errors.Errorf("invalid ServerOption: %d", n.Tp)
+	}
+	ctx.WriteString(n.StrValue)
+	return nil
+}
+
+// CreateServerStmt is the statement to create a server, which stores the connection
+// information of a remote MySQL server used by the FEDERATED tables.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-server.html
+type CreateServerStmt struct {
+	stmtNode
+
+	IfNotExists bool
+	ServerName  model.CIStr
+	WrapperName string
+	Options     []*ServerOption
+}
+
+// Restore implements Node interface.
+func (n *CreateServerStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE SERVER ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	ctx.WriteName(n.ServerName.O)
+	ctx.WriteKeyWord(" FOREIGN DATA WRAPPER ")
+	ctx.WriteName(n.WrapperName)
+	ctx.WriteKeyWord(" OPTIONS ")
+	ctx.WritePlain("(")
+	for i, opt := range n.Options {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := opt.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore CreateServerStmt.Options[%d]", i)
+		}
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// SecureText implements SensitiveStmtNode interface.
+func (n *CreateServerStmt) SecureText() string {
+	secured := *n
+	secured.Options = make([]*ServerOption, 0, len(n.Options))
+	for _, opt := range n.Options {
+		if opt.Tp == ServerOptionPassword {
+			opt = &ServerOption{Tp: ServerOptionPassword, StrValue: "xxxxxx"}
+		}
+		secured.Options = append(secured.Options, opt)
+	}
+	var sb strings.Builder
+	if err := secured.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return ""
+	}
+	return sb.String()
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateServerStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateServerStmt)
+	return v.Leave(n)
+}
+
+// DropServerStmt is the statement to drop a server.
+// See https://dev.mysql.com/doc/refman/8.0/en/drop-server.html
+type DropServerStmt struct {
+	stmtNode
+
+	IfExists   bool
+	ServerName model.CIStr
+}
+
+// Restore implements Node interface.
+func (n *DropServerStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP SERVER ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	ctx.WriteName(n.ServerName.O)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropServerStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropServerStmt)
+	return v.Leave(n)
+}
+
+// EventSchedule is the schedule of an event, it's either `AT timestamp` for a one-time event or
+// `EVERY interval [STARTS timestamp] [ENDS timestamp]` for a recurring event.
+type EventSchedule struct {
+	node
+
+	At     ExprNode
+	Every  ExprNode
+	Unit   TimeUnitType
+	Starts ExprNode
+	Ends   ExprNode
+}
+
+// Restore implements Node interface.
+func (n *EventSchedule) Restore(ctx *format.RestoreCtx) error {
+	if n.At != nil {
+		ctx.WriteKeyWord("AT ")
+		if err := n.At.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.At")
+		}
+		return nil
+	}
+	ctx.WriteKeyWord("EVERY ")
+	if err := n.Every.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore EventSchedule.Every")
+	}
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Unit.String())
+	if n.Starts != nil {
+		ctx.WriteKeyWord(" STARTS ")
+		if err := n.Starts.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.Starts")
+		}
+	}
+	if n.Ends != nil {
+		ctx.WriteKeyWord(" ENDS ")
+		if err := n.Ends.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.Ends")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *EventSchedule) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*EventSchedule)
+	for _, expr := range []*ExprNode{&n.At, &n.Every, &n.Starts, &n.Ends} {
+		if *expr == nil {
+			continue
+		}
+		node, ok := (*expr).Accept(v)
+		if !ok {
+			return n, false
+		}
+		*expr = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
+// CreateEventStmt is a statement to create a scheduled event.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-event.html
+type CreateEventStmt struct {
+	stmtNode
+
+	IfNotExists bool
+	Name        *TableName
+	Schedule    *EventSchedule
+	// Preserve indicates the event is kept after it's completed.
+	Preserve bool
+	Disabled bool
+	Comment  string
+	Body     StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE EVENT ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.Name.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Name")
+	}
+	ctx.WriteKeyWord(" ON SCHEDULE ")
+	if err := n.Schedule.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Schedule")
+	}
+	if n.Preserve {
+		ctx.WriteKeyWord(" ON COMPLETION PRESERVE")
+	}
+	if n.Disabled {
+		ctx.WriteKeyWord(" DISABLE")
+	}
+	if n.Comment != "" {
+		ctx.WriteKeyWord(" COMMENT ")
+		ctx.WriteString(n.Comment)
+	}
+	ctx.WriteKeyWord(" DO ")
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateEventStmt)
+	node, ok := n.Schedule.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Schedule = node.(*EventSchedule)
+	node, ok = n.Body.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Body = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// DropEventStmt is a statement to drop an event.
+type DropEventStmt struct {
+	stmtNode
+
+	IfExists bool
+	Name     *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP EVENT ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.Name.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropEventStmt.Name")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropEventStmt)
+	return v.Leave(n)
+}
+
 // ShutdownStmt is a statement to stop the TiDB server.
 // See https://dev.mysql.com/doc/refman/5.7/en/shutdown.html
 type ShutdownStmt struct {
@@ -3614,6 +4104,44 @@ func (n *ImportIntoActionStmt) Restore(ctx *format.RestoreCtx) error {
 	return nil
 }
 
+// DistJobActionTp is the action type of the job of the distributed task framework.
+type DistJobActionTp string
+
+const (
+	DistJobCancel DistJobActionTp = "cancel"
+	DistJobPause  DistJobActionTp = "pause"
+	DistJobResume DistJobActionTp = "resume"
+)
+
+// DistJobActionStmt represents CANCEL/PAUSE/RESUME JOB statement, which operates
+// the job of the distributed task framework.
+type DistJobActionStmt struct {
+	stmtNode
+
+	Tp    DistJobActionTp
+	JobID int64
+}
+
+func (n *DistJobActionStmt) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+func (n *DistJobActionStmt) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case DistJobCancel:
+		ctx.WriteKeyWord("CANCEL JOB ")
+	case DistJobPause:
+		ctx.WriteKeyWord("PAUSE JOB ")
+	case DistJobResume:
+		ctx.WriteKeyWord("RESUME JOB ")
+	default:
+		return errors.Errorf("invalid JOB action type: %s", n.Tp)
+	}
+	ctx.WritePlainf("%d", n.JobID)
+	return nil
+}
+
 // Ident is the table identifier composed of schema name and table name.
 type Ident struct {
 	Schema model.CIStr
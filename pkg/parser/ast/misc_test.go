@@ -83,6 +83,12 @@ func TestMiscVisitorCover(t *testing.T) {
 			},
 		},
 		&ast.ShutdownStmt{},
+		&ast.CreateEventStmt{
+			Name:     &ast.TableName{},
+			Schedule: &ast.EventSchedule{Every: valueExpr, Starts: valueExpr},
+			Body:     &ast.DoStmt{Exprs: []ast.ExprNode{valueExpr}},
+		},
+		&ast.DropEventStmt{Name: &ast.TableName{}},
 	}
 
 	for _, v := range stmts {
@@ -363,6 +369,15 @@ func TestBRIESecureText(t *testing.T) {
 	}
 }
 
+func TestCreateServerSecureText(t *testing.T) {
+	p := parser.New()
+	node, err := p.ParseOneStmt("create server s1 foreign data wrapper mysql options (host 'h', user 'u', password 'secret')", "", "")
+	require.NoError(t, err)
+	n, ok := node.(ast.SensitiveStmtNode)
+	require.True(t, ok)
+	require.Equal(t, "CREATE SERVER `s1` FOREIGN DATA WRAPPER `mysql` OPTIONS (HOST 'h', USER 'u', PASSWORD 'xxxxxx')", n.SecureText())
+}
+
 func TestCompactTableStmtRestore(t *testing.T) {
 	testCases := []NodeRestoreTestCase{
 		{"alter table abc compact tiflash replica", "ALTER TABLE `abc` COMPACT TIFLASH REPLICA"},
@@ -18,6 +18,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/types"
 )
 
@@ -237,6 +238,8 @@ type ProcedureInfo struct {
 	ProcedureParam    []*StoreParameter //procedure param
 	ProcedureBody     StmtNode          //procedure body statement
 	ProcedureParamStr string            //procedure parameter string
+	// SQLSecurity is the `SQL SECURITY` characteristic, the body is executed with the privileges of the definer by default.
+	SQLSecurity model.ViewSecurity
 }
 
 // Restore implements Node interface.
@@ -260,6 +263,9 @@ func (n *ProcedureInfo) Restore(ctx *format.RestoreCtx) error {
 		}
 	}
 	ctx.WritePlain(") ")
+	if n.SQLSecurity == model.SecurityInvoker {
+		ctx.WriteKeyWord("SQL SECURITY INVOKER ")
+	}
 	err = (n.ProcedureBody).Restore(ctx)
 	if err != nil {
 		return err
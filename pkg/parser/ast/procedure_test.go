@@ -99,6 +99,7 @@ func TestProcedure(t *testing.T) {
 		`create procedure proc_2() begin labelname: while id < 10 do set id = id + 1; select 1; end while; end`,
 		`create procedure proc_2() begin labelname: while id < 10 do set id = id + 1; select 1; end while labelname; end`,
 		`create procedure proc_2(id int) begin labelname: REPEAT set id = id + 1; select 1; UNTIL id < 10 end REPEAT labelname; end`,
+		`create procedure proc_2(in id int, out res int) begin call proc_1(id, res); call test.proc_3; end`,
 	}
 	for _, testcase := range testcases {
 		stmt, _, err := p.Parse(testcase, "", "")
@@ -161,6 +162,14 @@ func TestProcedureRestore(t *testing.T) {
 			"CREATE PROCEDURE `proc_2`() BEGIN SELECT * FROM `t1`;IF `i`>1 THEN SELECT 2;ELSE SELECT 5;END IF; END",
 			"CREATE PROCEDURE `proc_2`() BEGIN SELECT * FROM `t1`;IF `i`>1 THEN SELECT 2;ELSE SELECT 5;END IF; END",
 		},
+		{
+			"CREATE PROCEDURE `proc_2`() SQL SECURITY INVOKER SELECT * FROM `t1`",
+			"CREATE PROCEDURE `proc_2`() SQL SECURITY INVOKER SELECT * FROM `t1`",
+		},
+		{
+			"CREATE PROCEDURE `proc_2`() SQL SECURITY DEFINER SELECT * FROM `t1`",
+			"CREATE PROCEDURE `proc_2`() SELECT * FROM `t1`",
+		},
 		{
 			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN WHILE `id`<10 DO SET @@SESSION.`id`=`id`+1;SELECT 1;END WHILE; END",
 			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN WHILE `id`<10 DO SET @@SESSION.`id`=`id`+1;SELECT 1;END WHILE; END",
@@ -93,3 +93,68 @@ func (checker *readOnlyChecker) Enter(in Node) (out Node, skipChildren bool) {
 func (checker *readOnlyChecker) Leave(in Node) (out Node, ok bool) {
 	return in, checker.readOnly
 }
+
+// nonDeterministicFuncs stores the functions whose results or side effects may differ when a statement is replayed.
+var nonDeterministicFuncs = map[string]struct{}{
+	Rand:            {},
+	RandomBytes:     {},
+	UUID:            {},
+	UUIDShort:       {},
+	Sysdate:         {},
+	Sleep:           {},
+	GetLock:         {},
+	ReleaseLock:     {},
+	ReleaseAllLocks: {},
+	IsFreeLock:      {},
+	IsUsedLock:      {},
+	NextVal:         {},
+	LastVal:         {},
+	SetVal:          {},
+}
+
+// IsDeterministic checks whether the input ast is a DML statement which can be replayed safely, that is,
+// it neither calls the non-deterministic functions nor assigns the user variables.
+func IsDeterministic(node Node) bool {
+	switch node.(type) {
+	case *SelectStmt, *SetOprStmt, *InsertStmt, *UpdateStmt, *DeleteStmt:
+	default:
+		return false
+	}
+	checker := deterministicChecker{
+		deterministic: true,
+	}
+	node.Accept(&checker)
+	return checker.deterministic
+}
+
+// deterministicChecker checks whether a statement's ast is deterministic.
+type deterministicChecker struct {
+	deterministic bool
+}
+
+// Enter implements Visitor interface.
+func (checker *deterministicChecker) Enter(in Node) (out Node, skipChildren bool) {
+	switch node := in.(type) {
+	case *VariableExpr:
+		if !node.IsSystem && node.Value != nil {
+			checker.deterministic = false
+			return in, true
+		}
+	case *FuncCallExpr:
+		if _, ok := nonDeterministicFuncs[node.FnName.L]; ok {
+			checker.deterministic = false
+			return in, true
+		}
+		// LAST_INSERT_ID(expr) sets the value returned by the next LAST_INSERT_ID().
+		if node.FnName.L == LastInsertId && len(node.Args) > 0 {
+			checker.deterministic = false
+			return in, true
+		}
+	}
+	return in, false
+}
+
+// Leave implements Visitor interface.
+func (checker *deterministicChecker) Leave(in Node) (out Node, ok bool) {
+	return in, checker.deterministic
+}
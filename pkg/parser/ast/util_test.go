@@ -107,6 +107,36 @@ func TestUnionReadOnly(t *testing.T) {
 	require.False(t, IsReadOnly(setOprStmt))
 }
 
+func TestIsDeterministic(t *testing.T) {
+	cases := []struct {
+		sql           string
+		deterministic bool
+	}{
+		{"select * from t where a = 1 for update", true},
+		{"insert into t values (1, 2) on duplicate key update b = b + 1", true},
+		{"update t set b = b + 1 where a in (select a from s)", true},
+		{"delete from t where a < now()", true},
+		{"select a from t union select a from s", true},
+		{"update t set b = last_insert_id()", true},
+		{"update t set b = rand() where a = 1", false},
+		{"insert into t values (uuid(), 1)", false},
+		{"select * from t where a = sleep(1)", false},
+		{"update t set b = sysdate()", false},
+		{"update t set b = @a := @a + 1", false},
+		{"insert into t values (next value for seq, 1)", false},
+		{"update t set b = last_insert_id(b + 1)", false},
+		{"select a from t union select get_lock('l', 1)", false},
+		{"set @a = 1", false},
+		{"create table t (a int)", false},
+	}
+	p := parser.New()
+	for _, c := range cases {
+		stmt, err := p.ParseOneStmt(c.sql, "", "")
+		require.NoError(t, err, c.sql)
+		require.Equal(t, c.deterministic, IsDeterministic(stmt), c.sql)
+	}
+}
+
 // CleanNodeText set the text of node and all child node empty.
 // For test only.
 func CleanNodeText(node Node) {
@@ -62,16 +62,18 @@ var CharacterSetInfos = map[string]*Charset{
 	CharsetLatin1:  {CharsetLatin1, CollationLatin1, make(map[string]*Collation), "Latin1", 1},
 	CharsetBin:     {CharsetBin, CollationBin, make(map[string]*Collation), "binary", 1},
 	CharsetGBK:     {CharsetGBK, CollationGBKBin, make(map[string]*Collation), "Chinese Internal Code Specification", 2},
+	CharsetGB18030: {CharsetGB18030, CollationGB18030Bin, make(map[string]*Collation), "China National Standard GB18030", 4},
 }
 
 // All the names supported collations should be in the following table.
 var supportedCollationNames = map[string]struct{}{
-	CollationUTF8:    {},
-	CollationUTF8MB4: {},
-	CollationASCII:   {},
-	CollationLatin1:  {},
-	CollationBin:     {},
-	CollationGBKBin:  {},
+	CollationUTF8:       {},
+	CollationUTF8MB4:    {},
+	CollationASCII:      {},
+	CollationLatin1:     {},
+	CollationBin:        {},
+	CollationGBKBin:     {},
+	CollationGB18030Bin: {},
 }
 
 // TiFlashSupportedCharsets is a map which contains TiFlash supports charsets.
@@ -229,6 +231,10 @@ const (
 	CollationGBKBin = "gbk_bin"
 	// CollationGBKChineseCI is the default collation for CharsetGBK when new collation is enabled.
 	CollationGBKChineseCI = "gbk_chinese_ci"
+	// CollationGB18030Bin is the default collation for CharsetGB18030 when new collation is disabled.
+	CollationGB18030Bin = "gb18030_bin"
+	// CollationGB18030ChineseCI is the default collation for CharsetGB18030 when new collation is enabled.
+	CollationGB18030ChineseCI = "gb18030_chinese_ci"
 )
 
 const (
@@ -23,6 +23,7 @@ var (
 	_ Encoding = &encodingLatin1{}
 	_ Encoding = &encodingBin{}
 	_ Encoding = &encodingGBK{}
+	_ Encoding = &encodingGB18030{}
 )
 
 // IsSupportedEncoding checks if the charset is fully supported.
@@ -57,6 +58,7 @@ var encodingMap = map[string]Encoding{
 	CharsetUTF8MB4: EncodingUTF8Impl,
 	CharsetUTF8:    EncodingUTF8Impl,
 	CharsetGBK:     EncodingGBKImpl,
+	CharsetGB18030: EncodingGB18030Impl,
 	CharsetLatin1:  EncodingLatin1Impl,
 	CharsetBin:     EncodingBinImpl,
 	CharsetASCII:   EncodingASCIIImpl,
@@ -100,6 +102,7 @@ const (
 	EncodingTpLatin1
 	EncodingTpBin
 	EncodingTpGBK
+	EncodingTpGB18030
 )
 
 //revive:enable
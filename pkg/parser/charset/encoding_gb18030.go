@@ -0,0 +1,121 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package charset
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+// EncodingGB18030Impl is the instance of encodingGB18030
+var EncodingGB18030Impl = &encodingGB18030{encodingBase{enc: customGB18030{}}}
+
+func init() {
+	EncodingGB18030Impl.self = EncodingGB18030Impl
+}
+
+// encodingGB18030 is GB18030 encoding.
+type encodingGB18030 struct {
+	encodingBase
+}
+
+// Name implements Encoding interface.
+func (*encodingGB18030) Name() string {
+	return CharsetGB18030
+}
+
+// Tp implements Encoding interface.
+func (*encodingGB18030) Tp() EncodingTp {
+	return EncodingTpGB18030
+}
+
+// Peek implements Encoding interface.
+func (*encodingGB18030) Peek(src []byte) []byte {
+	charLen := 2
+	if len(src) == 0 || src[0] < 0x80 {
+		// A byte in the range 00–7F is a single byte that means the same thing as it does in ASCII.
+		charLen = 1
+	} else if len(src) >= 4 && isGB18030FourBytesSecond(src[1]) {
+		charLen = 4
+	}
+	if charLen < len(src) {
+		return src[:charLen]
+	}
+	return src
+}
+
+// MbLen implements Encoding interface.
+func (*encodingGB18030) MbLen(bs string) int {
+	if len(bs) < 2 || bs[0] < 0x81 || bs[0] > 0xfe {
+		return 0
+	}
+	if (0x40 <= bs[1] && bs[1] <= 0x7e) || (0x80 <= bs[1] && bs[1] <= 0xfe) {
+		return 2
+	}
+	if len(bs) >= 4 && isGB18030FourBytesSecond(bs[1]) &&
+		0x81 <= bs[2] && bs[2] <= 0xfe && isGB18030FourBytesSecond(bs[3]) {
+		return 4
+	}
+	return 0
+}
+
+// isGB18030FourBytesSecond checks whether the byte is the second or the fourth byte of a four-byte character.
+func isGB18030FourBytesSecond(b byte) bool {
+	return 0x30 <= b && b <= 0x39
+}
+
+// customGB18030 is a simplifiedchinese.GB18030 wrapper.
+type customGB18030 struct{}
+
+// NewGB18030Encoder return a GB18030 encoder.
+func NewGB18030Encoder() *encoding.Encoder {
+	return customGB18030{}.NewEncoder()
+}
+
+// NewDecoder returns simplifiedchinese.GB18030.NewDecoder() which rejects the single byte 0x80.
+func (customGB18030) NewDecoder() *encoding.Decoder {
+	return &encoding.Decoder{
+		Transformer: customGB18030Decoder{
+			gb18030Decoder: simplifiedchinese.GB18030.NewDecoder(),
+		},
+	}
+}
+
+// NewEncoder returns simplifiedchinese.GB18030.NewEncoder().
+func (customGB18030) NewEncoder() *encoding.Encoder {
+	return simplifiedchinese.GB18030.NewEncoder()
+}
+
+type customGB18030Decoder struct {
+	gb18030Decoder *encoding.Decoder
+}
+
+// Transform special treatment for 0x80, which is not a valid character in GB18030,
+// the decoder of simplifiedchinese.GB18030 decodes it to '€' as GBK does.
+func (c customGB18030Decoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	if len(src) == 0 {
+		return 0, 0, nil
+	}
+	if src[0] == 0x80 {
+		return utf8.EncodeRune(dst[:], utf8.RuneError), 1, nil
+	}
+	return c.gb18030Decoder.Transform(dst, src, atEOF)
+}
+
+// Reset is same as simplifiedchinese.GB18030.Reset().
+func (c customGB18030Decoder) Reset() {
+	c.gb18030Decoder.Reset()
+}
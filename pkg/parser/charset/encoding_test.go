@@ -136,6 +136,9 @@ func TestEncodingValidate(t *testing.T) {
 		{charset.CharsetGBK, "À", "?", 0, false},
 		{charset.CharsetGBK, "中文À中文", "中文?中文", 6, false},
 		{charset.CharsetGBK, "asdfÀ", "asdf?", 4, false},
+		{charset.CharsetGB18030, "asdf", "asdf", 4, true},
+		{charset.CharsetGB18030, "中文", "中文", 6, true},
+		{charset.CharsetGB18030, "À€😂", "À€😂", 9, true},
 	}
 	for _, tc := range testCases {
 		msg := fmt.Sprintf("%v", tc)
@@ -149,3 +152,51 @@ func TestEncodingValidate(t *testing.T) {
 		require.Equal(t, tc.expected, string(replace), msg)
 	}
 }
+
+func TestGB18030Encoding(t *testing.T) {
+	enc := charset.FindEncoding(charset.CharsetGB18030)
+	require.Equal(t, charset.CharsetGB18030, enc.Name())
+
+	testCases := []struct {
+		utf8Str string
+		gb18030 []byte
+	}{
+		{"abc", []byte("abc")},
+		{"一二三", []byte{0xd2, 0xbb, 0xb6, 0xfe, 0xc8, 0xfd}},
+		{"€", []byte{0xa2, 0xe3}},
+		{"À", []byte{0x81, 0x30, 0x86, 0x38}},
+		{"a😂b", []byte{0x61, 0x94, 0x39, 0xfc, 0x38, 0x62}},
+	}
+	for _, tc := range testCases {
+		cmt := fmt.Sprintf("%v", tc)
+		result, err := enc.Transform(nil, []byte(tc.utf8Str), charset.OpEncode)
+		require.NoError(t, err, cmt)
+		require.Equal(t, tc.gb18030, result, cmt)
+		result, err = enc.Transform(nil, tc.gb18030, charset.OpDecode)
+		require.NoError(t, err, cmt)
+		require.Equal(t, tc.utf8Str, string(result), cmt)
+	}
+
+	invalidCases := []struct {
+		gb18030 string
+		result  string
+	}{
+		{"\x80", "?"},
+		{"a\x80bc", "a?c"},
+		{"\xff", "?"},
+		{"\x81\x30", "?"},
+	}
+	for _, tc := range invalidCases {
+		cmt := fmt.Sprintf("%v", tc)
+		result, err := enc.Transform(nil, []byte(tc.gb18030), charset.OpDecodeReplace)
+		require.Error(t, err, cmt)
+		require.Equal(t, tc.result, string(result), cmt)
+	}
+
+	require.Equal(t, 1, len(enc.Peek([]byte("ab"))))
+	require.Equal(t, 2, len(enc.Peek([]byte{0xd2, 0xbb, 0x61})))
+	require.Equal(t, 4, len(enc.Peek([]byte{0x81, 0x30, 0x86, 0x38, 0x61})))
+	require.Equal(t, 2, enc.MbLen("\xd2\xbb"))
+	require.Equal(t, 4, enc.MbLen("\x81\x30\x86\x38"))
+	require.Equal(t, 0, enc.MbLen("a"))
+}
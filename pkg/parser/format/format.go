@@ -239,6 +239,7 @@ const (
 	RestoreWithoutSchemaName
 	RestoreWithoutTableName
 	RestoreForNonPrepPlanCache
+	RestoreTableNameAsWildcard
 )
 
 const (
@@ -349,6 +350,11 @@ func (rf RestoreFlags) HasRestoreForNonPrepPlanCache() bool {
 	return rf.has(RestoreForNonPrepPlanCache)
 }
 
+// HasTableNameAsWildcardFlag returns a boolean indicating whether `rf` has `RestoreTableNameAsWildcard` flag.
+func (rf RestoreFlags) HasTableNameAsWildcardFlag() bool {
+	return rf.has(RestoreTableNameAsWildcard)
+}
+
 // RestoreWriter is the interface for `Restore` to write.
 type RestoreWriter interface {
 	io.Writer
@@ -32,6 +32,7 @@ var Keywords = []KeywordsType{
 	{"ARRAY", true, "reserved"},
 	{"AS", true, "reserved"},
 	{"ASC", true, "reserved"},
+	{"AT", false, "unreserved"},
 	{"BETWEEN", true, "reserved"},
 	{"BIGINT", true, "reserved"},
 	{"BINARY", true, "reserved"},
@@ -47,6 +48,7 @@ var Keywords = []KeywordsType{
 	{"CHECK", true, "reserved"},
 	{"COLLATE", true, "reserved"},
 	{"COLUMN", true, "reserved"},
+	{"COMPLETION", false, "unreserved"},
 	{"CONSTRAINT", true, "reserved"},
 	{"CONTINUE", true, "reserved"},
 	{"CONVERT", true, "reserved"},
@@ -81,7 +83,9 @@ var Keywords = []KeywordsType{
 	{"ELSE", true, "reserved"},
 	{"ELSEIF", true, "reserved"},
 	{"ENCLOSED", true, "reserved"},
+	{"ENDS", false, "unreserved"},
 	{"ESCAPED", true, "reserved"},
+	{"EVERY", false, "unreserved"},
 	{"EXCEPT", true, "reserved"},
 	{"EXISTS", true, "reserved"},
 	{"EXIT", true, "reserved"},
@@ -215,6 +219,7 @@ var Keywords = []KeywordsType{
 	{"SQL_SMALL_RESULT", true, "reserved"},
 	{"SSL", true, "reserved"},
 	{"STARTING", true, "reserved"},
+	{"STARTS", false, "unreserved"},
 	{"STATS_EXTENDED", true, "reserved"},
 	{"STORED", true, "reserved"},
 	{"STRAIGHT_JOIN", true, "reserved"},
@@ -279,6 +284,7 @@ var Keywords = []KeywordsType{
 	{"BACKUP", false, "unreserved"},
 	{"BACKUPS", false, "unreserved"},
 	{"BDR", false, "unreserved"},
+	{"BEFORE", false, "unreserved"},
 	{"BEGIN", false, "unreserved"},
 	{"BERNOULLI", false, "unreserved"},
 	{"BINDING", false, "unreserved"},
@@ -350,6 +356,7 @@ var Keywords = []KeywordsType{
 	{"DO", false, "unreserved"},
 	{"DUPLICATE", false, "unreserved"},
 	{"DYNAMIC", false, "unreserved"},
+	{"EACH", false, "unreserved"},
 	{"ENABLE", false, "unreserved"},
 	{"ENABLED", false, "unreserved"},
 	{"ENCRYPTION", false, "unreserved"},
@@ -370,6 +377,7 @@ var Keywords = []KeywordsType{
 	{"EXPANSION", false, "unreserved"},
 	{"EXPIRE", false, "unreserved"},
 	{"EXTENDED", false, "unreserved"},
+	{"EXTERNAL", false, "unreserved"},
 	{"FAILED_LOGIN_ATTEMPTS", false, "unreserved"},
 	{"FAULTS", false, "unreserved"},
 	{"FIELDS", false, "unreserved"},
@@ -390,6 +398,7 @@ var Keywords = []KeywordsType{
 	{"HELP", false, "unreserved"},
 	{"HISTOGRAM", false, "unreserved"},
 	{"HISTORY", false, "unreserved"},
+	{"HNSW", false, "unreserved"},
 	{"HOSTS", false, "unreserved"},
 	{"HOUR", false, "unreserved"},
 	{"HYPO", false, "unreserved"},
@@ -401,6 +410,8 @@ var Keywords = []KeywordsType{
 	{"INDEXES", false, "unreserved"},
 	{"INSERT_METHOD", false, "unreserved"},
 	{"INSTANCE", false, "unreserved"},
+	{"INTERVAL_PRECREATE", false, "unreserved"},
+	{"INTERVAL_RETENTION", false, "unreserved"},
 	{"INVISIBLE", false, "unreserved"},
 	{"INVOKER", false, "unreserved"},
 	{"IO", false, "unreserved"},
@@ -421,6 +432,7 @@ var Keywords = []KeywordsType{
 	{"LOCATION", false, "unreserved"},
 	{"LOCKED", false, "unreserved"},
 	{"LOGS", false, "unreserved"},
+	{"MASKING", false, "unreserved"},
 	{"MASTER", false, "unreserved"},
 	{"MAX_CONNECTIONS_PER_HOUR", false, "unreserved"},
 	{"MAX_IDXNUM", false, "unreserved"},
@@ -467,6 +479,7 @@ var Keywords = []KeywordsType{
 	{"ON_DUPLICATE", false, "unreserved"},
 	{"OPEN", false, "unreserved"},
 	{"OPTIONAL", false, "unreserved"},
+	{"OPTIONS", false, "unreserved"},
 	{"PACK_KEYS", false, "unreserved"},
 	{"PAGE", false, "unreserved"},
 	{"PARSER", false, "unreserved"},
@@ -521,6 +534,7 @@ var Keywords = []KeywordsType{
 	{"ROLE", false, "unreserved"},
 	{"ROLLBACK", false, "unreserved"},
 	{"ROLLUP", false, "unreserved"},
+	{"ROTATE", false, "unreserved"},
 	{"ROUTINE", false, "unreserved"},
 	{"ROW_COUNT", false, "unreserved"},
 	{"ROW_FORMAT", false, "unreserved"},
@@ -538,6 +552,7 @@ var Keywords = []KeywordsType{
 	{"SEQUENCE", false, "unreserved"},
 	{"SERIAL", false, "unreserved"},
 	{"SERIALIZABLE", false, "unreserved"},
+	{"SERVER", false, "unreserved"},
 	{"SESSION", false, "unreserved"},
 	{"SETVAL", false, "unreserved"},
 	{"SHARD_ROW_ID_BITS", false, "unreserved"},
@@ -616,6 +631,7 @@ var Keywords = []KeywordsType{
 	{"VALIDATION", false, "unreserved"},
 	{"VALUE", false, "unreserved"},
 	{"VARIABLES", false, "unreserved"},
+	{"VECTOR", false, "unreserved"},
 	{"VIEW", false, "unreserved"},
 	{"VISIBLE", false, "unreserved"},
 	{"WAIT", false, "unreserved"},
@@ -624,6 +640,7 @@ var Keywords = []KeywordsType{
 	{"WEIGHT_STRING", false, "unreserved"},
 	{"WITHOUT", false, "unreserved"},
 	{"WORKLOAD", false, "unreserved"},
+	{"WRAPPER", false, "unreserved"},
 	{"X509", false, "unreserved"},
 	{"YEAR", false, "unreserved"},
 	{"ADMIN", false, "tidb"},
@@ -648,12 +665,14 @@ var Keywords = []KeywordsType{
 	{"OPTIMISTIC", false, "tidb"},
 	{"PESSIMISTIC", false, "tidb"},
 	{"PUMP", false, "tidb"},
+	{"QUANTILES", false, "tidb"},
 	{"REGION", false, "tidb"},
 	{"REGIONS", false, "tidb"},
 	{"RESET", false, "tidb"},
 	{"RUN", false, "tidb"},
 	{"SAMPLERATE", false, "tidb"},
 	{"SAMPLES", false, "tidb"},
+	{"SCATTER", false, "tidb"},
 	{"SESSION_STATES", false, "tidb"},
 	{"SPLIT", false, "tidb"},
 	{"STATISTICS", false, "tidb"},
@@ -664,6 +683,7 @@ var Keywords = []KeywordsType{
 	{"STATS_LOCKED", false, "tidb"},
 	{"STATS_META", false, "tidb"},
 	{"STATS_TOPN", false, "tidb"},
+	{"SUMMARY", false, "tidb"},
 	{"TIDB", false, "tidb"},
 	{"TIFLASH", false, "tidb"},
 	{"TOPN", false, "tidb"},
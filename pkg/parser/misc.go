@@ -179,6 +179,7 @@ var tokenMap = map[string]int{
 	"ASC":                      asc,
 	"ASCII":                    ascii,
 	"ATTRIBUTE":                attribute,
+	"AT":                       at,
 	"ATTRIBUTES":               attributes,
 	"BATCH":                    batch,
 	"BACKGROUND":               background,
@@ -196,6 +197,7 @@ var tokenMap = map[string]int{
 	"BACKUP":                   backup,
 	"BACKUPS":                  backups,
 	"BDR":                      bdr,
+	"BEFORE":                   before,
 	"BEGIN":                    begin,
 	"BETWEEN":                  between,
 	"BERNOULLI":                bernoulli,
@@ -261,6 +263,8 @@ var tokenMap = map[string]int{
 	"COMMIT":                   commit,
 	"COMMITTED":                committed,
 	"COMPACT":                  compact,
+	"COMPARE":                  compare,
+	"COMPLETION":               completion,
 	"COMPRESSED":               compressed,
 	"COMPRESSION":              compression,
 	"CONCURRENCY":              concurrency,
@@ -323,6 +327,7 @@ var tokenMap = map[string]int{
 	"DEPTH":                    depth,
 	"DESC":                     desc,
 	"DESCRIBE":                 describe,
+	"DIFF":                     diff,
 	"DIGEST":                   digest,
 	"DIRECTORY":                directory,
 	"DISABLE":                  disable,
@@ -344,6 +349,7 @@ var tokenMap = map[string]int{
 	"DUPLICATE":                duplicate,
 	"DURATION":                 timeDuration,
 	"DYNAMIC":                  dynamic,
+	"EACH":                     each,
 	"ELSE":                     elseKwd,
 	"ELSEIF":                   elseIfKwd,
 	"ENABLE":                   enable,
@@ -351,6 +357,7 @@ var tokenMap = map[string]int{
 	"ENCLOSED":                 enclosed,
 	"ENCRYPTION":               encryption,
 	"END":                      end,
+	"ENDS":                     ends,
 	"END_TIME":                 endTime,
 	"ENFORCED":                 enforced,
 	"ENGINE":                   engine,
@@ -362,6 +369,7 @@ var tokenMap = map[string]int{
 	"ESCAPED":                  escaped,
 	"EVENT":                    event,
 	"EVENTS":                   events,
+	"EVERY":                    every,
 	"EVOLVE":                   evolve,
 	"EXACT":                    exact,
 	"EXEC_ELAPSED":             execElapsed,
@@ -376,6 +384,7 @@ var tokenMap = map[string]int{
 	"EXPLAIN":                  explain,
 	"EXPR_PUSHDOWN_BLACKLIST":  exprPushdownBlacklist,
 	"EXTENDED":                 extended,
+	"EXTERNAL":                 external,
 	"EXTRACT":                  extract,
 	"FALSE":                    falseKwd,
 	"FAULTS":                   faultsSym,
@@ -453,6 +462,8 @@ var tokenMap = map[string]int{
 	"INTERNAL":                 internal,
 	"INTERSECT":                intersect,
 	"INTERVAL":                 interval,
+	"INTERVAL_PRECREATE":       intervalPrecreate,
+	"INTERVAL_RETENTION":       intervalRetention,
 	"INTO":                     into,
 	"INVISIBLE":                invisible,
 	"INVOKER":                  invoker,
@@ -512,8 +523,10 @@ var tokenMap = map[string]int{
 	"LONGBLOB":                 longblobType,
 	"LONGTEXT":                 longtextType,
 	"LOW_PRIORITY":             lowPriority,
+	"MASKING":                  masking,
 	"MASTER":                   master,
 	"MATCH":                    match,
+	"MATERIALIZED":             materialized,
 	"MAX_CONNECTIONS_PER_HOUR": maxConnectionsPerHour,
 	"MAX_IDXNUM":               max_idxnum,
 	"MAX_MINUTES":              max_minutes,
@@ -531,6 +544,7 @@ var tokenMap = map[string]int{
 	"MEMBER":                   member,
 	"MERGE":                    merge,
 	"METADATA":                 metadata,
+	"METRIC_TABLES":            metricTables,
 	"MICROSECOND":              microsecond,
 	"MIDDLEINT":                middleIntType,
 	"MIN_ROWS":                 minRows,
@@ -584,8 +598,10 @@ var tokenMap = map[string]int{
 	"OPT_RULE_BLACKLIST":       optRuleBlacklist,
 	"OPTIMISTIC":               optimistic,
 	"OPTIMIZE":                 optimize,
+	"OPTIMIZER":                optimizer,
 	"OPTION":                   option,
 	"OPTIONAL":                 optional,
+	"OPTIONS":                  options,
 	"OPTIONALLY":               optionally,
 	"OR":                       or,
 	"ORDER":                    order,
@@ -629,6 +645,7 @@ var tokenMap = map[string]int{
 	"PROXY":                    proxy,
 	"PUMP":                     pump,
 	"PURGE":                    purge,
+	"QUANTILES":                quantiles,
 	"QUARTER":                  quarter,
 	"QUERIES":                  queries,
 	"QUERY":                    query,
@@ -641,9 +658,11 @@ var tokenMap = map[string]int{
 	"REBUILD":                  rebuild,
 	"RECENT":                   recent,
 	"RECOVER":                  recover,
+	"RECOMMEND":                recommend,
 	"RECURSIVE":                recursive,
 	"REDUNDANT":                redundant,
 	"REFERENCES":               references,
+	"REFRESH":                  refresh,
 	"REGEXP":                   regexpKwd,
 	"REGION":                   region,
 	"REGIONS":                  regions,
@@ -677,6 +696,7 @@ var tokenMap = map[string]int{
 	"ROLE":                     role,
 	"ROLLBACK":                 rollback,
 	"ROLLUP":                   rollup,
+	"ROTATE":                   rotate,
 	"ROUTINE":                  routine,
 	"ROW_COUNT":                rowCount,
 	"ROW_FORMAT":               rowFormat,
@@ -684,6 +704,7 @@ var tokenMap = map[string]int{
 	"ROWS":                     rows,
 	"RTREE":                    rtree,
 	"HYPO":                     hypo,
+	"HNSW":                     hnsw,
 	"RESUME":                   resume,
 	"RUN":                      run,
 	"RUNNING":                  running,
@@ -692,6 +713,7 @@ var tokenMap = map[string]int{
 	"SAMPLERATE":               sampleRate,
 	"SAN":                      san,
 	"SAVEPOINT":                savepoint,
+	"SCATTER":                  scatter,
 	"SCHEDULE":                 schedule,
 	"SCHEMA":                   database,
 	"SCHEMAS":                  databases,
@@ -708,6 +730,7 @@ var tokenMap = map[string]int{
 	"SEQUENCE":                 sequence,
 	"SERIAL":                   serial,
 	"SERIALIZABLE":             serializable,
+	"SERVER":                   server,
 	"SESSION":                  session,
 	"SESSION_STATES":           sessionStates,
 	"SET":                      set,
@@ -751,6 +774,7 @@ var tokenMap = map[string]int{
 	"SSL":                      ssl,
 	"STALENESS":                staleness,
 	"START":                    start,
+	"STARTS":                   starts,
 	"START_TIME":               startTime,
 	"START_TS":                 startTS,
 	"STARTING":                 starting,
@@ -786,6 +810,7 @@ var tokenMap = map[string]int{
 	"SUBSTR":                   substring,
 	"SUBSTRING":                substring,
 	"SUM":                      sum,
+	"SUMMARY":                  summary,
 	"SUPER":                    super,
 	"SURVIVAL_PREFERENCES":     survivalPreferences,
 	"SWAPS":                    swaps,
@@ -811,6 +836,7 @@ var tokenMap = map[string]int{
 	"TIFLASH":                  tiFlash,
 	"TIKV_IMPORTER":            tikvImporter,
 	"TIME":                     timeType,
+	"TIMEOUT":                  timeout,
 	"TIMESTAMP":                timestampType,
 	"TIMESTAMPADD":             timestampAdd,
 	"TIMESTAMPDIFF":            timestampDiff,
@@ -878,6 +904,7 @@ var tokenMap = map[string]int{
 	"VARCHARACTER":             varcharacter,
 	"VARIABLES":                variables,
 	"VARIANCE":                 varPop,
+	"VECTOR":                   vector,
 	"VARYING":                  varying,
 	"VERBOSE":                  verboseType,
 	"VOTER":                    voter,
@@ -898,6 +925,7 @@ var tokenMap = map[string]int{
 	"WITHOUT":                  without,
 	"WRITE":                    write,
 	"WORKLOAD":                 workload,
+	"WRAPPER":                  wrapper,
 	"X509":                     x509,
 	"XOR":                      xor,
 	"YEAR_MONTH":               yearMonth,
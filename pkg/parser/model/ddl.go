@@ -92,20 +92,25 @@ const (
 	ActionAlterTablePlacement           ActionType = 56
 	ActionAlterCacheTable               ActionType = 57
 	// not used
-	ActionAlterTableStatsOptions ActionType = 58
-	ActionAlterNoCacheTable      ActionType = 59
-	ActionCreateTables           ActionType = 60
-	ActionMultiSchemaChange      ActionType = 61
-	ActionFlashbackCluster       ActionType = 62
-	ActionRecoverSchema          ActionType = 63
-	ActionReorganizePartition    ActionType = 64
-	ActionAlterTTLInfo           ActionType = 65
-	ActionAlterTTLRemove         ActionType = 67
-	ActionCreateResourceGroup    ActionType = 68
-	ActionAlterResourceGroup     ActionType = 69
-	ActionDropResourceGroup      ActionType = 70
-	ActionAlterTablePartitioning ActionType = 71
-	ActionRemovePartitioning     ActionType = 72
+	ActionAlterTableStatsOptions  ActionType = 58
+	ActionAlterNoCacheTable       ActionType = 59
+	ActionCreateTables            ActionType = 60
+	ActionMultiSchemaChange       ActionType = 61
+	ActionFlashbackCluster        ActionType = 62
+	ActionRecoverSchema           ActionType = 63
+	ActionReorganizePartition     ActionType = 64
+	ActionAlterTTLInfo            ActionType = 65
+	ActionAlterTTLRemove          ActionType = 67
+	ActionCreateResourceGroup     ActionType = 68
+	ActionAlterResourceGroup      ActionType = 69
+	ActionDropResourceGroup       ActionType = 70
+	ActionAlterTablePartitioning  ActionType = 71
+	ActionRemovePartitioning      ActionType = 72
+	ActionAlterIntervalPolicy     ActionType = 73
+	ActionModifySchemaReadOnly    ActionType = 74
+	ActionCreateTrigger           ActionType = 75
+	ActionDropTrigger             ActionType = 76
+	ActionRefreshMaterializedView ActionType = 77
 )
 
 // ActionMap is the map of DDL ActionType to string.
@@ -177,6 +182,11 @@ var ActionMap = map[ActionType]string{
 	ActionDropResourceGroup:             "drop resource group",
 	ActionAlterTablePartitioning:        "alter table partition by",
 	ActionRemovePartitioning:            "alter table remove partitioning",
+	ActionAlterIntervalPolicy:           "alter table interval policy",
+	ActionModifySchemaReadOnly:          "modify schema read only",
+	ActionCreateTrigger:                 "create trigger",
+	ActionDropTrigger:                   "drop trigger",
+	ActionRefreshMaterializedView:       "refresh materialized view",
 
 	// `ActionAlterTableAlterPartition` is removed and will never be used.
 	// Just left a tombstone here for compatibility.
@@ -218,6 +228,7 @@ var BDRActionMap = map[DDLBDRType][]ActionType{
 		ActionCreateTables,
 		ActionAlterTTLInfo,
 		ActionAlterTTLRemove,
+		ActionAlterIntervalPolicy,
 		ActionCreateView,
 		ActionDropView,
 	},
@@ -256,6 +267,7 @@ var BDRActionMap = map[DDLBDRType][]ActionType{
 		ActionAlterTablePartitionAttributes,
 		ActionAlterTablePartitionPlacement,
 		ActionModifySchemaDefaultPlacement,
+		ActionModifySchemaReadOnly,
 		ActionAlterTablePlacement,
 		ActionAlterCacheTable,
 		ActionAlterTableStatsOptions,
@@ -266,6 +278,9 @@ var BDRActionMap = map[DDLBDRType][]ActionType{
 		ActionReorganizePartition,
 		ActionAlterTablePartitioning,
 		ActionRemovePartitioning,
+		ActionCreateTrigger,
+		ActionDropTrigger,
+		ActionRefreshMaterializedView,
 	},
 	UnmanagementDDL: {
 		ActionCreatePlacementPolicy,
@@ -118,7 +118,10 @@ type ColumnInfo struct {
 	State               SchemaState         `json:"state"`
 	Comment             string              `json:"comment"`
 	// A hidden column is used internally(expression index) and are not accessible by users.
-	Hidden           bool `json:"hidden"`
+	Hidden bool `json:"hidden"`
+	// An invisible column is accessible by users when it's referenced explicitly,
+	// but it's excluded from `SELECT *` and the INSERT without a column list.
+	Invisible        bool `json:"invisible,omitempty"`
 	*ChangeStateInfo `json:"change_state_info"`
 	// Version means the version of the column info.
 	// Version = 0: For OriginDefaultValue and DefaultValue of timestamp column will stores the default time in system time zone.
@@ -518,6 +521,13 @@ type TableInfo struct {
 
 	Sequence *SequenceInfo `json:"sequence"`
 
+	// MaterializedView is set when the table stores the data of a materialized view.
+	MaterializedView *MaterializedViewInfo `json:"materialized_view,omitempty"`
+
+	// Staging is set when the table is being filled by CREATE TABLE ... SELECT, it's cleared
+	// when the table is renamed to the target name.
+	Staging *StagingInfo `json:"staging,omitempty"`
+
 	// Lock represent the table lock info.
 	Lock *TableLockInfo `json:"Lock"`
 
@@ -542,6 +552,19 @@ type TableInfo struct {
 
 	TTLInfo *TTLInfo `json:"ttl_info"`
 
+	// IntervalPolicy is the policy to pre-create and drop the partitions of an
+	// INTERVAL partitioned table automatically.
+	IntervalPolicy *IntervalPartitionPolicy `json:"interval_policy,omitempty"`
+
+	// ExternalInfo is not nil if the rows of the table are read from the files in the external storage.
+	ExternalInfo *ExternalTableInfo `json:"external_info,omitempty"`
+
+	// FederatedInfo is not nil if the rows of the table are read from a remote MySQL server.
+	FederatedInfo *FederatedTableInfo `json:"federated_info,omitempty"`
+
+	// Triggers are the row-level triggers of the table, listed in the order of creation.
+	Triggers []*TriggerInfo `json:"triggers,omitempty"`
+
 	DBID int64 `json:"-"`
 }
 
@@ -759,6 +782,21 @@ func (t *TableInfo) Clone() *TableInfo {
 	if t.TTLInfo != nil {
 		nt.TTLInfo = t.TTLInfo.Clone()
 	}
+	if t.IntervalPolicy != nil {
+		nt.IntervalPolicy = t.IntervalPolicy.Clone()
+	}
+	if t.ExternalInfo != nil {
+		nt.ExternalInfo = t.ExternalInfo.Clone()
+	}
+	if t.FederatedInfo != nil {
+		nt.FederatedInfo = t.FederatedInfo.Clone()
+	}
+	if t.Triggers != nil {
+		nt.Triggers = make([]*TriggerInfo, len(t.Triggers))
+		for i := range t.Triggers {
+			nt.Triggers[i] = t.Triggers[i].Clone()
+		}
+	}
 
 	return &nt
 }
@@ -1025,6 +1063,36 @@ func (t *TableInfo) IsSequence() bool {
 	return t.Sequence != nil
 }
 
+// IsMaterializedView checks if TableInfo is a materialized view.
+func (t *TableInfo) IsMaterializedView() bool {
+	return t.MaterializedView != nil
+}
+
+// IsStaging checks if TableInfo is a table being filled by CREATE TABLE ... SELECT.
+func (t *TableInfo) IsStaging() bool {
+	return t.Staging != nil
+}
+
+// IsExternal checks if TableInfo is an external table.
+func (t *TableInfo) IsExternal() bool {
+	return t.ExternalInfo != nil
+}
+
+// IsFederated checks if TableInfo is a federated table.
+func (t *TableInfo) IsFederated() bool {
+	return t.FederatedInfo != nil
+}
+
+// FindTrigger finds the trigger by the name.
+func (t *TableInfo) FindTrigger(name string) *TriggerInfo {
+	for _, trigger := range t.Triggers {
+		if trigger.Name.L == name {
+			return trigger
+		}
+	}
+	return nil
+}
+
 // IsBaseTable checks to see the table is neither a view or a sequence.
 func (t *TableInfo) IsBaseTable() bool {
 	return t.Sequence == nil && t.View == nil
@@ -1142,6 +1210,31 @@ type SequenceInfo struct {
 
 //revive:enable:exported
 
+// MaterializedViewInfo provides meta data describing a materialized view.
+// The data of a materialized view is stored in the table, it's only updated
+// when the materialized view is refreshed.
+type MaterializedViewInfo struct {
+	Definer             *auth.UserIdentity `json:"definer"`
+	SelectStmt          string             `json:"select"`
+	CharacterSetClient  string             `json:"character_set_client"`
+	CollationConnection string             `json:"collation_connection"`
+	// LastRefreshTS is the TSO of the snapshot which the data is selected from.
+	LastRefreshTS uint64 `json:"last_refresh_ts"`
+	// RefreshEvery and RefreshUnit are the interval of the automatic refresh, the view is only
+	// refreshed by REFRESH MATERIALIZED VIEW if RefreshEvery is 0.
+	RefreshEvery uint64 `json:"refresh_every,omitempty"`
+	RefreshUnit  string `json:"refresh_unit,omitempty"`
+}
+
+// StagingInfo provides meta data describing the loader of a staging table. The staging table
+// is hidden from the table listings, and it's dropped by the DDL owner if the TiDB server
+// which loads it is gone.
+type StagingInfo struct {
+	// ServerID is the ID of the TiDB server which loads the table.
+	ServerID     string `json:"server_id"`
+	ConnectionID uint64 `json:"connection_id"`
+}
+
 // PartitionType is the type for PartitionInfo
 type PartitionType int
 
@@ -1201,6 +1294,13 @@ type PartitionInfo struct {
 	// When IsEmptyColums is true, it will not display column name in `show create table` stmt.
 	IsEmptyColumns bool `json:"is_empty_columns"`
 
+	// IsLinear is for `partition by linear hash` and `partition by linear key`,
+	// the partitions are located by the powers-of-two algorithm like MySQL.
+	IsLinear bool `json:"is_linear,omitempty"`
+	// KeyAlgorithm is the ALGORITHM of `partition by key`. When it is not 0, the
+	// partitions are located by the same hash function as MySQL instead of the TiDB one.
+	KeyAlgorithm uint64 `json:"key_algorithm,omitempty"`
+
 	Definitions []PartitionDefinition `json:"definitions"`
 	// AddingDefinitions is filled when adding partitions that is in the mid state.
 	AddingDefinitions []PartitionDefinition `json:"adding_definitions"`
@@ -1223,6 +1323,9 @@ type PartitionInfo struct {
 	DDLType    PartitionType `json:"ddl_type"`
 	DDLExpr    string        `json:"ddl_expr"`
 	DDLColumns []CIStr       `json:"ddl_columns"`
+	// DDLIsLinear and DDLKeyAlgorithm follow DDLType like IsLinear and KeyAlgorithm.
+	DDLIsLinear     bool   `json:"ddl_is_linear,omitempty"`
+	DDLKeyAlgorithm uint64 `json:"ddl_key_algorithm,omitempty"`
 }
 
 // Clone clones itself.
@@ -1443,6 +1546,10 @@ func (t IndexType) String() string {
 		return "RTREE"
 	case IndexTypeHypo:
 		return "HYPO"
+	case IndexTypeFullText:
+		return "FULLTEXT"
+	case IndexTypeHNSW:
+		return "HNSW"
 	default:
 		return ""
 	}
@@ -1455,8 +1562,41 @@ const (
 	IndexTypeHash
 	IndexTypeRtree
 	IndexTypeHypo
+	IndexTypeFullText
+	IndexTypeHNSW
+)
+
+// FullTextParserType is the type of the parser which splits the text into the tokens of a fulltext index.
+type FullTextParserType string
+
+// FullTextParserTypes
+const (
+	// FullTextParserTypeWhitespace splits the text into words separated by whitespaces and punctuations.
+	FullTextParserTypeWhitespace FullTextParserType = "whitespace"
+	// FullTextParserTypeNgram splits the words into the sequences of n continuous characters.
+	FullTextParserTypeNgram FullTextParserType = "ngram"
 )
 
+// FullTextIndexInfo is the information of a fulltext index.
+type FullTextIndexInfo struct {
+	ParserType FullTextParserType `json:"parser_type"`
+}
+
+// DistanceMetric is the distance metric used by a vector index.
+type DistanceMetric string
+
+// DistanceMetrics
+const (
+	DistanceMetricL2     DistanceMetric = "L2"
+	DistanceMetricCosine DistanceMetric = "COSINE"
+)
+
+// VectorIndexInfo is the information of a vector index.
+type VectorIndexInfo struct {
+	Dimension      uint64         `json:"dimension"`
+	DistanceMetric DistanceMetric `json:"distance_metric"`
+}
+
 // IndexInfo provides meta data describing a DB index.
 // It corresponds to the statement `CREATE INDEX Name ON Table (Column);`
 // See https://dev.mysql.com/doc/refman/5.7/en/create-index.html
@@ -1474,6 +1614,10 @@ type IndexInfo struct {
 	Invisible     bool           `json:"is_invisible"` // Whether the index is invisible.
 	Global        bool           `json:"is_global"`    // Whether the index is global.
 	MVIndex       bool           `json:"mv_index"`     // Whether the index is multivalued index.
+	// FullTextInfo is the information of the fulltext index, it's nil for the other indexes.
+	FullTextInfo *FullTextIndexInfo `json:"fulltext_info,omitempty"`
+	// VectorInfo is the information of the vector index, it's nil for the other indexes.
+	VectorInfo *VectorIndexInfo `json:"vector_info,omitempty"`
 }
 
 // Clone clones IndexInfo.
@@ -1486,9 +1630,27 @@ func (index *IndexInfo) Clone() *IndexInfo {
 	for i := range index.Columns {
 		ni.Columns[i] = index.Columns[i].Clone()
 	}
+	if index.FullTextInfo != nil {
+		info := *index.FullTextInfo
+		ni.FullTextInfo = &info
+	}
+	if index.VectorInfo != nil {
+		info := *index.VectorInfo
+		ni.VectorInfo = &info
+	}
 	return &ni
 }
 
+// IsFullText checks whether the index is a fulltext index.
+func (index *IndexInfo) IsFullText() bool {
+	return index.FullTextInfo != nil
+}
+
+// IsVector checks whether the index is a vector index.
+func (index *IndexInfo) IsVector() bool {
+	return index.VectorInfo != nil
+}
+
 // HasPrefixIndex returns whether any columns of this index uses prefix length.
 func (index *IndexInfo) HasPrefixIndex() bool {
 	for _, ic := range index.Columns {
@@ -1698,6 +1860,8 @@ type DBInfo struct {
 	Tables             []*TableInfo   `json:"-"` // Tables in the DB.
 	State              SchemaState    `json:"state"`
 	PlacementPolicyRef *PolicyRefInfo `json:"policy_ref_info"`
+	// ReadOnly means the tables in the DB can't be written and the DB can't be changed by DDL.
+	ReadOnly bool `json:"read_only,omitempty"`
 }
 
 // Clone clones DBInfo.
@@ -1847,6 +2011,127 @@ func (t *TTLInfo) GetJobInterval() (time.Duration, error) {
 	return duration.ParseDuration(t.JobInterval)
 }
 
+// IntervalPartitionPolicy records how the partitions of an INTERVAL partitioned table
+// are maintained by the background worker. Both counts are in number of intervals.
+type IntervalPartitionPolicy struct {
+	// PrecreateCount is the number of intervals after the current time that
+	// must be covered by partitions. 0 means no partitions are created.
+	PrecreateCount uint64 `json:"precreate_count"`
+	// RetentionCount is the number of intervals before the current time to keep.
+	// Partitions holding only older rows are dropped. 0 means no partitions are dropped.
+	RetentionCount uint64 `json:"retention_count"`
+}
+
+// Clone clones IntervalPartitionPolicy
+func (p *IntervalPartitionPolicy) Clone() *IntervalPartitionPolicy {
+	cloned := *p
+	return &cloned
+}
+
+// IsEmpty returns true if the policy neither creates nor drops partitions.
+func (p *IntervalPartitionPolicy) IsEmpty() bool {
+	return p.PrecreateCount == 0 && p.RetentionCount == 0
+}
+
+// External table formats.
+const (
+	ExternalFormatCSV     = "csv"
+	ExternalFormatParquet = "parquet"
+)
+
+// ExternalTableInfo records where and how the rows of an external table are stored.
+type ExternalTableInfo struct {
+	// Location is the URI of the data files, e.g. s3://bucket/prefix/*.csv. The `*` in the
+	// file name matches any files in the directory.
+	Location string `json:"location"`
+	// Format is the format of the data files, see ExternalFormatCSV and ExternalFormatParquet.
+	Format string `json:"format"`
+}
+
+// Clone clones ExternalTableInfo
+func (e *ExternalTableInfo) Clone() *ExternalTableInfo {
+	cloned := *e
+	return &cloned
+}
+
+// FederatedTableInfo records the remote table of a federated table.
+type FederatedTableInfo struct {
+	// Server is the name of the server created by CREATE SERVER, which stores the
+	// connection information of the remote MySQL server.
+	Server string `json:"server"`
+	// Table is the name of the remote table in the database of the server options.
+	Table string `json:"table"`
+}
+
+// Clone clones FederatedTableInfo
+func (f *FederatedTableInfo) Clone() *FederatedTableInfo {
+	cloned := *f
+	return &cloned
+}
+
+// TriggerTiming is the action time of a trigger.
+type TriggerTiming byte
+
+// TriggerTiming types.
+const (
+	TriggerTimingBefore TriggerTiming = iota
+	TriggerTimingAfter
+)
+
+// String implements fmt.Stringer interface.
+func (t TriggerTiming) String() string {
+	switch t {
+	case TriggerTimingBefore:
+		return "BEFORE"
+	case TriggerTimingAfter:
+		return "AFTER"
+	}
+	return ""
+}
+
+// TriggerEvent is the kind of the operation that activates a trigger.
+type TriggerEvent byte
+
+// TriggerEvent types.
+const (
+	TriggerEventInsert TriggerEvent = iota
+	TriggerEventUpdate
+	TriggerEventDelete
+)
+
+// String implements fmt.Stringer interface.
+func (e TriggerEvent) String() string {
+	switch e {
+	case TriggerEventInsert:
+		return "INSERT"
+	case TriggerEventUpdate:
+		return "UPDATE"
+	case TriggerEventDelete:
+		return "DELETE"
+	}
+	return ""
+}
+
+// TriggerInfo provides meta data describing a row-level trigger.
+type TriggerInfo struct {
+	Name   CIStr         `json:"name"`
+	Timing TriggerTiming `json:"timing"`
+	Event  TriggerEvent  `json:"event"`
+	// Body is the original text of the statement executed for each row.
+	Body                string        `json:"body"`
+	Definer             string        `json:"definer"`
+	SQLMode             mysql.SQLMode `json:"sql_mode"`
+	CharacterSetClient  string        `json:"character_set_client"`
+	CollationConnection string        `json:"collation_connection"`
+	Created             time.Time     `json:"created"`
+}
+
+// Clone clones TriggerInfo
+func (t *TriggerInfo) Clone() *TriggerInfo {
+	cloned := *t
+	return &cloned
+}
+
 func writeSettingItemToBuilder(sb *strings.Builder, item string, separatorFns ...func()) {
 	if sb.Len() != 0 {
 		for _, fn := range separatorFns {
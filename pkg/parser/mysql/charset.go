@@ -75,6 +75,7 @@ var CharsetIDs = map[string]uint8{
 	"geostd8":  92,
 	"cp932":    95,
 	"eucjpms":  97,
+	"gb18030":  248,
 }
 
 // Collations maps MySQL collation ID to its name.
@@ -298,6 +299,9 @@ var Collations = map[uint16]string{
 	245: "utf8mb4_croatian_ci",
 	246: "utf8mb4_unicode_520_ci",
 	247: "utf8mb4_vietnamese_ci",
+	248: "gb18030_chinese_ci",
+	249: "gb18030_bin",
+	250: "gb18030_unicode_520_ci",
 	255: "utf8mb4_0900_ai_ci",
 	309: "utf8mb4_0900_bin",
 }
@@ -523,6 +527,9 @@ var CollationNames = map[string]uint16{
 	"utf8mb4_croatian_ci":      245,
 	"utf8mb4_unicode_520_ci":   246,
 	"utf8mb4_vietnamese_ci":    247,
+	"gb18030_chinese_ci":       248,
+	"gb18030_bin":              249,
+	"gb18030_unicode_520_ci":   250,
 	"utf8mb4_0900_ai_ci":       255,
 	"utf8mb4_0900_bin":         309,
 }
@@ -415,6 +415,23 @@ func (m SQLMode) HasAllowInvalidDatesMode() bool {
 	return m&ModeAllowInvalidDates == ModeAllowInvalidDates
 }
 
+// String returns the sql mode in the format of the 'SQL_MODE' variable, e.g. "STRICT_TRANS_TABLES,NO_ZERO_DATE".
+func (m SQLMode) String() string {
+	modes := make([]string, 0, 8)
+	for mode := ModeRealAsFloat; mode <= ModeAllowInvalidDates; mode <<= 1 {
+		if m&mode == 0 {
+			continue
+		}
+		for str, v := range Str2SQLMode {
+			if v == mode {
+				modes = append(modes, str)
+				break
+			}
+		}
+	}
+	return strings.Join(modes, ",")
+}
+
 // DelSQLMode delete sql mode from ori
 func DelSQLMode(ori SQLMode, del SQLMode) SQLMode {
 	return ori & (^del)
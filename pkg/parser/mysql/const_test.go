@@ -94,3 +94,11 @@ func TestSQLMode(t *testing.T) {
 		require.Equal(t, ca.value, int(ca.code))
 	}
 }
+
+func TestSQLModeString(t *testing.T) {
+	mode, err := GetSQLMode(DefaultSQLMode)
+	require.NoError(t, err)
+	require.Equal(t, DefaultSQLMode, mode.String())
+	require.Equal(t, "", SQLMode(ModeNone).String())
+	require.Equal(t, "ANSI_QUOTES,ALLOW_INVALID_DATES", (ModeAllowInvalidDates | ModeANSIQuotes).String())
+}
@@ -322,6 +322,7 @@ import (
 	always                "ALWAYS"
 	any                   "ANY"
 	ascii                 "ASCII"
+	at                    "AT"
 	attribute             "ATTRIBUTE"
 	attributes            "ATTRIBUTES"
 	autoIdCache           "AUTO_ID_CACHE"
@@ -334,6 +335,7 @@ import (
 	backup                "BACKUP"
 	backups               "BACKUPS"
 	bdr                   "BDR"
+	before                "BEFORE"
 	begin                 "BEGIN"
 	bernoulli             "BERNOULLI"
 	binding               "BINDING"
@@ -370,6 +372,7 @@ import (
 	commit                "COMMIT"
 	committed             "COMMITTED"
 	compact               "COMPACT"
+	completion            "COMPLETION"
 	compressed            "COMPRESSED"
 	compression           "COMPRESSION"
 	concurrency           "CONCURRENCY"
@@ -405,10 +408,12 @@ import (
 	do                    "DO"
 	duplicate             "DUPLICATE"
 	dynamic               "DYNAMIC"
+	each                  "EACH"
 	enable                "ENABLE"
 	enabled               "ENABLED"
 	encryption            "ENCRYPTION"
 	end                   "END"
+	ends                  "ENDS"
 	enforced              "ENFORCED"
 	engine                "ENGINE"
 	engines               "ENGINES"
@@ -418,6 +423,7 @@ import (
 	escape                "ESCAPE"
 	event                 "EVENT"
 	events                "EVENTS"
+	every                 "EVERY"
 	evolve                "EVOLVE"
 	exchange              "EXCHANGE"
 	exclusive             "EXCLUSIVE"
@@ -425,6 +431,7 @@ import (
 	expansion             "EXPANSION"
 	expire                "EXPIRE"
 	extended              "EXTENDED"
+	external              "EXTERNAL"
 	failedLoginAttempts   "FAILED_LOGIN_ATTEMPTS"
 	faultsSym             "FAULTS"
 	fields                "FIELDS"
@@ -447,6 +454,7 @@ import (
 	history               "HISTORY"
 	hosts                 "HOSTS"
 	hour                  "HOUR"
+	hnsw                  "HNSW"
 	hypo                  "HYPO"
 	identified            "IDENTIFIED"
 	importKwd             "IMPORT"
@@ -456,6 +464,8 @@ import (
 	indexes               "INDEXES"
 	insertMethod          "INSERT_METHOD"
 	instance              "INSTANCE"
+	intervalPrecreate     "INTERVAL_PRECREATE"
+	intervalRetention     "INTERVAL_RETENTION"
 	invisible             "INVISIBLE"
 	invoker               "INVOKER"
 	io                    "IO"
@@ -476,6 +486,7 @@ import (
 	location              "LOCATION"
 	locked                "LOCKED"
 	logs                  "LOGS"
+	masking               "MASKING"
 	master                "MASTER"
 	maxConnectionsPerHour "MAX_CONNECTIONS_PER_HOUR"
 	max_idxnum            "MAX_IDXNUM"
@@ -522,6 +533,7 @@ import (
 	onDuplicate           "ON_DUPLICATE"
 	open                  "OPEN"
 	optional              "OPTIONAL"
+	options               "OPTIONS"
 	packKeys              "PACK_KEYS"
 	pageSym               "PAGE"
 	parser                "PARSER"
@@ -576,6 +588,7 @@ import (
 	reverse               "REVERSE"
 	role                  "ROLE"
 	rollback              "ROLLBACK"
+	rotate                "ROTATE"
 	rollup                "ROLLUP"
 	routine               "ROUTINE"
 	rowCount              "ROW_COUNT"
@@ -594,6 +607,7 @@ import (
 	sequence              "SEQUENCE"
 	serial                "SERIAL"
 	serializable          "SERIALIZABLE"
+	server                "SERVER"
 	session               "SESSION"
 	setval                "SETVAL"
 	shardRowIDBits        "SHARD_ROW_ID_BITS"
@@ -621,6 +635,7 @@ import (
 	sqlTsiWeek            "SQL_TSI_WEEK"
 	sqlTsiYear            "SQL_TSI_YEAR"
 	start                 "START"
+	starts                "STARTS"
 	statsAutoRecalc       "STATS_AUTO_RECALC"
 	statsColChoice        "STATS_COL_CHOICE"
 	statsColList          "STATS_COL_LIST"
@@ -672,6 +687,7 @@ import (
 	validation            "VALIDATION"
 	value                 "VALUE"
 	variables             "VARIABLES"
+	vector                "VECTOR"
 	view                  "VIEW"
 	visible               "VISIBLE"
 	wait                  "WAIT"
@@ -680,6 +696,7 @@ import (
 	weightString          "WEIGHT_STRING"
 	without               "WITHOUT"
 	workload              "WORKLOAD"
+	wrapper               "WRAPPER"
 	x509                  "X509"
 	yearType              "YEAR"
 
@@ -696,6 +713,7 @@ import (
 	briefType             "BRIEF"
 	burstable             "BURSTABLE"
 	cast                  "CAST"
+	compare               "COMPARE"
 	constraints           "CONSTRAINTS"
 	cooldown              "COOLDOWN"
 	copyKwd               "COPY"
@@ -704,6 +722,7 @@ import (
 	dateAdd               "DATE_ADD"
 	dateSub               "DATE_SUB"
 	defined               "DEFINED"
+	diff                  "DIFF"
 	dotType               "DOT"
 	dryRun                "DRYRUN"
 	dump                  "DUMP"
@@ -735,13 +754,16 @@ import (
 	learners              "LEARNERS"
 	log                   "LOG"
 	low                   "LOW"
+	materialized          "MATERIALIZED"
 	max                   "MAX"
 	medium                "MEDIUM"
 	metadata              "METADATA"
+	metricTables          "METRIC_TABLES"
 	min                   "MIN"
 	next_row_id           "NEXT_ROW_ID"
 	now                   "NOW"
 	optRuleBlacklist      "OPT_RULE_BLACKLIST"
+	optimizer             "OPTIMIZER"
 	placement             "PLACEMENT"
 	planCache             "PLAN_CACHE"
 	plan                  "PLAN"
@@ -751,6 +773,8 @@ import (
 	priority              "PRIORITY"
 	queryLimit            "QUERY_LIMIT"
 	recent                "RECENT"
+	recommend             "RECOMMEND"
+	refresh               "REFRESH"
 	replayer              "REPLAYER"
 	restoredTS            "RESTORED_TS"
 	running               "RUNNING"
@@ -776,6 +800,7 @@ import (
 	taskTypes             "TASK_TYPES"
 	tidbJson              "TIDB_JSON"
 	timeDuration          "DURATION"
+	timeout               "TIMEOUT"
 	timestampAdd          "TIMESTAMPADD"
 	timestampDiff         "TIMESTAMPDIFF"
 	tls                   "TLS"
@@ -852,12 +877,14 @@ import (
 	optimistic                 "OPTIMISTIC"
 	pessimistic                "PESSIMISTIC"
 	pump                       "PUMP"
+	quantiles                  "QUANTILES"
 	region                     "REGION"
 	regions                    "REGIONS"
 	reset                      "RESET"
 	run                        "RUN"
 	sampleRate                 "SAMPLERATE"
 	samples                    "SAMPLES"
+	scatter                    "SCATTER"
 	sessionStates              "SESSION_STATES"
 	split                      "SPLIT"
 	statistics                 "STATISTICS"
@@ -868,6 +895,7 @@ import (
 	statsLocked                "STATS_LOCKED"
 	statsMeta                  "STATS_META"
 	statsTopN                  "STATS_TOPN"
+	summary                    "SUMMARY"
 	tidb                       "TIDB"
 	tiFlash                    "TIFLASH"
 	topn                       "TOPN"
@@ -929,153 +957,167 @@ import (
 	SignedLiteral                   "Literal or NumLiteral with sign"
 	DefaultValueExpr                "DefaultValueExpr(Now or Signed Literal)"
 	NowSymOptionFraction            "NowSym with optional fraction part"
-	NowSymOptionFractionParentheses "NowSym with optional fraction part within potential parentheses"
 	CharsetNameOrDefault            "Character set name or default"
 	NextValueForSequence            "Default nextval expression"
 	BuiltinFunction                 "Default builtin functions for columns"
+	BuiltinFunctionCall             "Default builtin function call for columns"
 	FunctionNameSequence            "Function with sequence function call"
 	WindowFuncCall                  "WINDOW function call"
 	RepeatableOpt                   "Repeatable optional in sample clause"
 	ProcedureCall                   "Procedure call with Identifier or identifier"
 
 %type	<statement>
-	AdminStmt                  "Check table statement or show ddl statement"
-	AlterDatabaseStmt          "Alter database statement"
-	AlterTableStmt             "Alter table statement"
-	AlterUserStmt              "Alter user statement"
-	AlterInstanceStmt          "Alter instance statement"
-	AlterRangeStmt             "Alter data range configuration statement"
-	AlterPolicyStmt            "Alter Placement Policy statement"
-	AlterResourceGroupStmt     "Alter Resource Group statement"
-	AlterSequenceStmt          "Alter sequence statement"
-	AnalyzeTableStmt           "Analyze table statement"
-	BeginTransactionStmt       "BEGIN TRANSACTION statement"
-	BinlogStmt                 "Binlog base64 statement"
-	BRIEStmt                   "BACKUP or RESTORE statement"
-	CalibrateResourceStmt      "CALIBRATE RESOURCE statement"
-	CommitStmt                 "COMMIT statement"
-	CreateTableStmt            "CREATE TABLE statement"
-	CreateViewStmt             "CREATE VIEW  statement"
-	CreateUserStmt             "CREATE User statement"
-	CreateRoleStmt             "CREATE Role statement"
-	CreateDatabaseStmt         "Create Database Statement"
-	CreateIndexStmt            "CREATE INDEX statement"
-	CreateBindingStmt          "CREATE BINDING statement"
-	CreatePolicyStmt           "CREATE PLACEMENT POLICY statement"
-	CreateProcedureStmt        "CREATE PROCEDURE statement"
-	AddQueryWatchStmt          "ADD QUERY WATCH statement"
-	CreateResourceGroupStmt    "CREATE RESOURCE GROUP statement"
-	CreateSequenceStmt         "CREATE SEQUENCE statement"
-	CreateStatisticsStmt       "CREATE STATISTICS statement"
-	DoStmt                     "Do statement"
-	DropDatabaseStmt           "DROP DATABASE statement"
-	DropIndexStmt              "DROP INDEX statement"
-	DropProcedureStmt          "DROP PROCEDURE statement"
-	DropQueryWatchStmt         "DROP QUERY WATCH statement"
-	DropResourceGroupStmt      "DROP RESOURCE GROUP statement"
-	DropStatisticsStmt         "DROP STATISTICS statement"
-	DropStatsStmt              "DROP STATS statement"
-	DropTableStmt              "DROP TABLE statement"
-	DropSequenceStmt           "DROP SEQUENCE statement"
-	DropUserStmt               "DROP USER"
-	DropRoleStmt               "DROP ROLE"
-	DropViewStmt               "DROP VIEW statement"
-	DropBindingStmt            "DROP BINDING  statement"
-	DropPolicyStmt             "DROP PLACEMENT POLICY statement"
-	DeallocateStmt             "Deallocate prepared statement"
-	DeleteFromStmt             "DELETE FROM statement"
-	DeleteWithoutUsingStmt     "Normal DELETE statement"
-	DeleteWithUsingStmt        "DELETE USING statement"
-	EmptyStmt                  "empty statement"
-	ExecuteStmt                "Execute statement"
-	ExplainStmt                "EXPLAIN statement"
-	ExplainableStmt            "explainable statement"
-	FlushStmt                  "Flush statement"
-	FlashbackTableStmt         "Flashback table statement"
-	FlashbackToTimestampStmt   "Flashback cluster statement"
-	FlashbackDatabaseStmt      "Flashback Database statement"
-	GrantStmt                  "Grant statement"
-	GrantProxyStmt             "Grant proxy statement"
-	GrantRoleStmt              "Grant role statement"
-	InsertIntoStmt             "INSERT INTO statement"
-	CallStmt                   "CALL statement"
-	IndexAdviseStmt            "INDEX ADVISE statement"
-	ImportIntoStmt             "IMPORT INTO statement"
-	ImportFromSelectStmt       "SELECT statement of IMPORT INTO"
-	KillStmt                   "Kill statement"
-	LoadDataStmt               "Load data statement"
-	LoadStatsStmt              "Load statistic statement"
-	LockStatsStmt              "Lock statistic statement"
-	UnlockStatsStmt            "Unlock statistic statement"
-	LockTablesStmt             "Lock tables statement"
-	NonTransactionalDMLStmt    "Non-transactional DML statement"
-	OptimizeTableStmt          "OPTIMIZE statement"
-	PlanReplayerStmt           "Plan replayer statement"
-	PreparedStmt               "PreparedStmt"
-	ProcedureProcStmt          "The entrance of procedure statements which contains all kinds of statements in procedure"
-	ProcedureStatementStmt     "The normal statements in procedure, such as dml, select, set ..."
-	SelectStmt                 "SELECT statement"
-	SelectStmtWithClause       "common table expression SELECT statement"
-	RenameTableStmt            "rename table statement"
-	RenameUserStmt             "rename user statement"
-	ReplaceIntoStmt            "REPLACE INTO statement"
-	RecoverTableStmt           "recover table statement"
-	RevokeStmt                 "Revoke statement"
-	RevokeRoleStmt             "Revoke role statement"
-	RollbackStmt               "ROLLBACK statement"
-	ReleaseSavepointStmt       "RELEASE SAVEPOINT statement"
-	SavepointStmt              "SAVEPOINT statement"
-	SplitRegionStmt            "Split index region statement"
-	SetStmt                    "Set variable statement"
-	ChangeStmt                 "Change statement"
-	SetBindingStmt             "Set binding statement"
-	SetRoleStmt                "Set active role statement"
-	SetDefaultRoleStmt         "Set default statement for some user"
-	ShowStmt                   "Show engines/databases/tables/user/columns/warnings/status statement"
-	Statement                  "statement"
-	TraceStmt                  "TRACE statement"
-	TraceableStmt              "traceable statement"
-	TruncateTableStmt          "TRUNCATE TABLE statement"
-	UnlockTablesStmt           "Unlock tables statement"
-	UpdateStmt                 "UPDATE statement"
-	SetOprStmt                 "Union/Except/Intersect select statement"
-	SetOprStmtWithLimitOrderBy "Union/Except/Intersect select statement with limit and order by"
-	SetOprStmtWoutLimitOrderBy "Union/Except/Intersect select statement without limit and order by"
-	UseStmt                    "USE statement"
-	ShutdownStmt               "SHUTDOWN statement"
-	RestartStmt                "RESTART statement"
-	CreateViewSelectOpt        "Select/Union/Except/Intersect statement in CREATE VIEW ... AS SELECT"
-	BindableStmt               "Statement that can be created binding on"
-	UpdateStmtNoWith           "Update statement without CTE clause"
-	HelpStmt                   "HELP statement"
-	ShardableStmt              "Shardable statement that can be used in non-transactional DMLs"
-	CancelImportStmt           "CANCEL IMPORT JOB statement"
-	ProcedureUnlabeledBlock    "The statement block without label in procedure"
-	ProcedureBlockContent      "The statement block in procedure expressed with 'Begin ... End'"
-	SimpleWhenThen             "Procedure case when then"
-	SearchWhenThen             "Procedure search when then"
-	ProcedureIfstmt            "The if statement in procedure, expressed by if ... elseif .. else ... end if"
-	procedurceElseIfs          "The else block in procedure, expressed by elseif or else or nil"
-	ProcedureIf                "The if block in procedure, expressed by expr then statement procedurceElseIfs"
-	ProcedureUnlabelLoopBlock  "The loop block without label in procedure "
-	ProcedureUnlabelLoopStmt   "The loop statement in procedure, expressed by repeat/do while/loop"
-	ProcedureCaseStmt          "Case statement in procedure, expressed by `case ... when.. then ..`"
-	ProcedureSimpleCase        "The simpe case statement in procedure, expressed by `case expr when expr then statement ... end case`"
-	ProcedureSearchedCase      "The searched case statement in procedure, expressed by `case when expr then statement ... end case`"
-	ProcedureCursorSelectStmt  "The select stmt can used in procedure cursor."
-	ProcedureOpenCur           "The open cursor statement in procedure, expressed by `open ...`"
-	ProcedureCloseCur          "The close cursor statement in procedure, expressed by `close ...`"
-	ProcedureFetchInto         "The fetch into statement in procedure, expressed by `fetch ... into ...`"
-	ProcedureHcond             "The handler value statement in procedure, expressed by condition_value"
-	ProcedurceCond             "The handler code statement in procedure, expressed by code error num or `sqlstate ...`"
-	ProcedureLabeledBlock      "The statement block with label in procedure"
-	ProcedurelabeledLoopStmt   "The loop block with label in procedure"
-	ProcedureIterate           "The iterate statement in procedure, expressed by `iterate ...`"
-	ProcedureLeave             "The leave statement in procedure, expressed by `leave ...`"
+	AdminStmt                   "Check table statement or show ddl statement"
+	AlterDatabaseStmt           "Alter database statement"
+	AlterTableStmt              "Alter table statement"
+	AlterUserStmt               "Alter user statement"
+	AlterInstanceStmt           "Alter instance statement"
+	AlterRangeStmt              "Alter data range configuration statement"
+	AlterPolicyStmt             "Alter Placement Policy statement"
+	AlterResourceGroupStmt      "Alter Resource Group statement"
+	AlterSequenceStmt           "Alter sequence statement"
+	AnalyzeTableStmt            "Analyze table statement"
+	BeginTransactionStmt        "BEGIN TRANSACTION statement"
+	BinlogStmt                  "Binlog base64 statement"
+	BRIEStmt                    "BACKUP or RESTORE statement"
+	CalibrateResourceStmt       "CALIBRATE RESOURCE statement"
+	CommitStmt                  "COMMIT statement"
+	CreateTableStmt             "CREATE TABLE statement"
+	CreateViewStmt              "CREATE VIEW  statement"
+	CreateMaskingPolicyStmt     "CREATE MASKING POLICY statement"
+	CreateServerStmt            "CREATE SERVER statement"
+	CreateMaterializedViewStmt  "CREATE MATERIALIZED VIEW statement"
+	CreateUserStmt              "CREATE User statement"
+	CreateRoleStmt              "CREATE Role statement"
+	CreateDatabaseStmt          "Create Database Statement"
+	CreateIndexStmt             "CREATE INDEX statement"
+	CreateBindingStmt           "CREATE BINDING statement"
+	CreatePolicyStmt            "CREATE PLACEMENT POLICY statement"
+	CreateProcedureStmt         "CREATE PROCEDURE statement"
+	CreateTriggerStmt           "CREATE TRIGGER statement"
+	CreateEventStmt             "CREATE EVENT statement"
+	AddQueryWatchStmt           "ADD QUERY WATCH statement"
+	CreateResourceGroupStmt     "CREATE RESOURCE GROUP statement"
+	CreateSequenceStmt          "CREATE SEQUENCE statement"
+	CreateStatisticsStmt        "CREATE STATISTICS statement"
+	DoStmt                      "Do statement"
+	DropDatabaseStmt            "DROP DATABASE statement"
+	DropIndexStmt               "DROP INDEX statement"
+	DropProcedureStmt           "DROP PROCEDURE statement"
+	DropTriggerStmt             "DROP TRIGGER statement"
+	DropEventStmt               "DROP EVENT statement"
+	DropQueryWatchStmt          "DROP QUERY WATCH statement"
+	DropResourceGroupStmt       "DROP RESOURCE GROUP statement"
+	DropStatisticsStmt          "DROP STATISTICS statement"
+	DropStatsStmt               "DROP STATS statement"
+	DropTableStmt               "DROP TABLE statement"
+	DropSequenceStmt            "DROP SEQUENCE statement"
+	DropUserStmt                "DROP USER"
+	DropRoleStmt                "DROP ROLE"
+	DropViewStmt                "DROP VIEW statement"
+	DropMaskingPolicyStmt       "DROP MASKING POLICY statement"
+	DropServerStmt              "DROP SERVER statement"
+	DropMaterializedViewStmt    "DROP MATERIALIZED VIEW statement"
+	DropBindingStmt             "DROP BINDING  statement"
+	DropPolicyStmt              "DROP PLACEMENT POLICY statement"
+	DeallocateStmt              "Deallocate prepared statement"
+	DeleteFromStmt              "DELETE FROM statement"
+	DeleteWithoutUsingStmt      "Normal DELETE statement"
+	DeleteWithUsingStmt         "DELETE USING statement"
+	EmptyStmt                   "empty statement"
+	ExecuteStmt                 "Execute statement"
+	ExplainStmt                 "EXPLAIN statement"
+	ExplainableStmt             "explainable statement"
+	FlushStmt                   "Flush statement"
+	FlashbackTableStmt          "Flashback table statement"
+	FlashbackToTimestampStmt    "Flashback cluster statement"
+	FlashbackDatabaseStmt       "Flashback Database statement"
+	GrantStmt                   "Grant statement"
+	GrantProxyStmt              "Grant proxy statement"
+	GrantRoleStmt               "Grant role statement"
+	InsertIntoStmt              "INSERT INTO statement"
+	CallStmt                    "CALL statement"
+	IndexAdviseStmt             "INDEX ADVISE statement"
+	ImportIntoStmt              "IMPORT INTO statement"
+	ImportFromSelectStmt        "SELECT statement of IMPORT INTO"
+	KillStmt                    "Kill statement"
+	LoadDataStmt                "Load data statement"
+	LoadStatsStmt               "Load statistic statement"
+	LockStatsStmt               "Lock statistic statement"
+	UnlockStatsStmt             "Unlock statistic statement"
+	LockTablesStmt              "Lock tables statement"
+	NonTransactionalDMLStmt     "Non-transactional DML statement"
+	OptimizeTableStmt           "OPTIMIZE statement"
+	PlanReplayerStmt            "Plan replayer statement"
+	PreparedStmt                "PreparedStmt"
+	ProcedureProcStmt           "The entrance of procedure statements which contains all kinds of statements in procedure"
+	ProcedureStatementStmt      "The normal statements in procedure, such as dml, select, set ..."
+	SelectStmt                  "SELECT statement"
+	SelectStmtWithClause        "common table expression SELECT statement"
+	RenameTableStmt             "rename table statement"
+	RenameUserStmt              "rename user statement"
+	ReplaceIntoStmt             "REPLACE INTO statement"
+	RecoverTableStmt            "recover table statement"
+	RefreshMaterializedViewStmt "REFRESH MATERIALIZED VIEW statement"
+	RecommendIndexStmt          "RECOMMEND INDEX statement"
+	RevokeStmt                  "Revoke statement"
+	RevokeRoleStmt              "Revoke role statement"
+	RollbackStmt                "ROLLBACK statement"
+	ReleaseSavepointStmt        "RELEASE SAVEPOINT statement"
+	SavepointStmt               "SAVEPOINT statement"
+	SplitRegionStmt             "Split index region statement"
+	SetStmt                     "Set variable statement"
+	ChangeStmt                  "Change statement"
+	SetBindingStmt              "Set binding statement"
+	SetRoleStmt                 "Set active role statement"
+	SetDefaultRoleStmt          "Set default statement for some user"
+	ShowStmt                    "Show engines/databases/tables/user/columns/warnings/status statement"
+	Statement                   "statement"
+	TraceStmt                   "TRACE statement"
+	TraceableStmt               "traceable statement"
+	TruncateTableStmt           "TRUNCATE TABLE statement"
+	UnlockTablesStmt            "Unlock tables statement"
+	UpdateStmt                  "UPDATE statement"
+	SetOprStmt                  "Union/Except/Intersect select statement"
+	SetOprStmtWithLimitOrderBy  "Union/Except/Intersect select statement with limit and order by"
+	SetOprStmtWoutLimitOrderBy  "Union/Except/Intersect select statement without limit and order by"
+	UseStmt                     "USE statement"
+	ShutdownStmt                "SHUTDOWN statement"
+	RestartStmt                 "RESTART statement"
+	CreateViewSelectOpt         "Select/Union/Except/Intersect statement in CREATE VIEW ... AS SELECT"
+	BindableStmt                "Statement that can be created binding on"
+	UpdateStmtNoWith            "Update statement without CTE clause"
+	HelpStmt                    "HELP statement"
+	ShardableStmt               "Shardable statement that can be used in non-transactional DMLs"
+	CancelImportStmt            "CANCEL IMPORT JOB statement"
+	DistJobActionStmt           "CANCEL/PAUSE/RESUME JOB statement"
+	ProcedureUnlabeledBlock     "The statement block without label in procedure"
+	ProcedureBlockContent       "The statement block in procedure expressed with 'Begin ... End'"
+	SimpleWhenThen              "Procedure case when then"
+	SearchWhenThen              "Procedure search when then"
+	ProcedureIfstmt             "The if statement in procedure, expressed by if ... elseif .. else ... end if"
+	procedurceElseIfs           "The else block in procedure, expressed by elseif or else or nil"
+	ProcedureIf                 "The if block in procedure, expressed by expr then statement procedurceElseIfs"
+	ProcedureUnlabelLoopBlock   "The loop block without label in procedure "
+	ProcedureUnlabelLoopStmt    "The loop statement in procedure, expressed by repeat/do while/loop"
+	ProcedureCaseStmt           "Case statement in procedure, expressed by `case ... when.. then ..`"
+	ProcedureSimpleCase         "The simpe case statement in procedure, expressed by `case expr when expr then statement ... end case`"
+	ProcedureSearchedCase       "The searched case statement in procedure, expressed by `case when expr then statement ... end case`"
+	ProcedureCursorSelectStmt   "The select stmt can used in procedure cursor."
+	ProcedureOpenCur            "The open cursor statement in procedure, expressed by `open ...`"
+	ProcedureCloseCur           "The close cursor statement in procedure, expressed by `close ...`"
+	ProcedureFetchInto          "The fetch into statement in procedure, expressed by `fetch ... into ...`"
+	ProcedureHcond              "The handler value statement in procedure, expressed by condition_value"
+	ProcedurceCond              "The handler code statement in procedure, expressed by code error num or `sqlstate ...`"
+	ProcedureLabeledBlock       "The statement block with label in procedure"
+	ProcedurelabeledLoopStmt    "The loop block with label in procedure"
+	ProcedureIterate            "The iterate statement in procedure, expressed by `iterate ...`"
+	ProcedureLeave              "The leave statement in procedure, expressed by `leave ...`"
 
 %type	<item>
 	AdminShowSlow                          "Admin Show Slow statement"
 	AdminStmtLimitOpt                      "Admin show ddl jobs limit option"
+	AdminDiffSchemaForOpt                  "Admin diff schema for option"
 	AllOrPartitionNameList                 "All or partition name list"
 	AlgorithmClause                        "Alter table algorithm"
 	AlterTableSpecSingleOpt                "Alter table single option"
@@ -1123,6 +1165,7 @@ import (
 	ConnectionOptionList                   "connection options for CREATE USER statement"
 	ConnectionOptions                      "optional connection options for CREATE USER statement"
 	Constraint                             "table constraint"
+	VectorIndexDef                         "vector index definition"
 	ConstraintElem                         "table constraint element"
 	ConstraintKeywordOpt                   "Constraint Keyword or empty"
 	CreateSequenceOptionListOpt            "create sequence list opt"
@@ -1141,6 +1184,7 @@ import (
 	EscapedTableRef                        "escaped table reference"
 	ExpressionList                         "expression list"
 	ExtendedPriv                           "Extended privileges like LOAD FROM S3 or dynamic privileges"
+	MaterializedViewRefreshOpt             "optional REFRESH EVERY clause of CREATE MATERIALIZED VIEW"
 	MaxValueOrExpressionList               "maxvalue or expression list"
 	DefaultOrExpressionList                "default or expression list"
 	ExpressionListOpt                      "expression list opt"
@@ -1261,6 +1305,7 @@ import (
 	PrivLevel                              "Privilege scope"
 	PrivType                               "Privilege type"
 	ReferDef                               "Reference definition"
+	RegionsSummaryOpt                      "Optional SUMMARY for SHOW TABLE REGIONS"
 	OnDelete                               "ON DELETE clause"
 	OnUpdate                               "ON UPDATE clause"
 	OnDeleteUpdateOpt                      "optional ON DELETE and UPDATE clause"
@@ -1311,6 +1356,7 @@ import (
 	ShowProfileTypes                       "Show profile types"
 	SplitOption                            "Split Option"
 	SplitSyntaxOption                      "Split syntax Option"
+	SplitScatterOpt                        "Split scatter option"
 	Starting                               "Starting by"
 	StatementList                          "statement list"
 	StatsPersistentVal                     "stats_persistent value"
@@ -1350,6 +1396,8 @@ import (
 	TextStringList                         "text string list"
 	TimeUnit                               "Time unit for 'DATE_ADD', 'DATE_SUB', 'ADDDATE', 'SUBDATE', 'EXTRACT'"
 	TimestampUnit                          "Time unit for 'TIMESTAMPADD' and 'TIMESTAMPDIFF'"
+	TiFlashReplicaWaitOpt                  "Wait for the TiFlash replicas option"
+	TiFlashReplicaWaitTimeoutOpt           "Wait for the TiFlash replicas timeout option"
 	LockType                               "Table locks type"
 	TransactionChar                        "Transaction characteristic"
 	TransactionChars                       "Transaction characteristic list"
@@ -1383,6 +1431,7 @@ import (
 	WithClause                             "With Clause"
 	WithList                               "With list"
 	WithReadLockOpt                        "With Read Lock opt"
+	WithAdminOptionOpt                     "With Admin Option opt"
 	WithGrantOptionOpt                     "With Grant Option opt"
 	WithValidation                         "with validation"
 	WithValidationOpt                      "optional with validation"
@@ -1452,6 +1501,9 @@ import (
 	Match                                  "[MATCH FULL | MATCH PARTIAL | MATCH SIMPLE]"
 	MatchOpt                               "optional MATCH clause"
 	MaxMinutesOpt                          "MAX_MINUTES num(int)"
+	RecommendIndexWithOpt                  "RECOMMEND INDEX WITH options"
+	RecommendIndexOptionList               "RECOMMEND INDEX option list"
+	RecommendIndexOption                   "RECOMMEND INDEX option"
 	MaxIndexNumOpt                         "MAX_IDXNUM clause"
 	PerTable                               "Max index number PER_TABLE"
 	PerDB                                  "Max index number PER_DB"
@@ -1482,6 +1534,15 @@ import (
 	CalibrateResourceWorkloadOption        "Calibrate Resource workload option"
 	QueryWatchOptionList                   "Query watch option list"
 	QueryWatchOption                       "Query watch option"
+	ServerOption                           "Server option"
+	TriggerEvent                           "Trigger event"
+	TriggerTime                            "Trigger action time"
+	EventSchedule                          "Event schedule"
+	EventStartsOpt                         "Event schedule starts option"
+	EventEndsOpt                           "Event schedule ends option"
+	EventOnCompletionOpt                   "Event on completion option"
+	EventStatusOpt                         "Event status option"
+	ServerOptionList                       "Server option list"
 	QueryWatchTextOption                   "Query watch text option"
 	AttributesOpt                          "Attributes options"
 	AllColumnsOrPredicateColumnsOpt        "all columns or predicate columns option"
@@ -1508,6 +1569,8 @@ import (
 
 %type	<ident>
 	AsOpt             "AS or EmptyString"
+	EventCommentOpt   "Event comment option"
+	RecommendIndexForOpt "RECOMMEND INDEX FOR query"
 	KeyOrIndex        "{KEY|INDEX}"
 	ColumnKeywordOpt  "Column keyword or empty"
 	PrimaryOpt        "Optional primary keyword"
@@ -1575,6 +1638,7 @@ import (
 	FlashbackToNewName              "Flashback to new name"
 	HashString                      "Hashed string"
 	LikeOrIlikeEscapeOpt            "like or ilike escape option"
+	LogBackupTaskOpt                "Optional log backup task name"
 	OptCharset                      "Optional Character setting"
 	OptCollate                      "Optional Collate setting"
 	PasswordOpt                     "Password option"
@@ -1586,6 +1650,7 @@ import (
 	ProcedurceLabelOpt              "Optional Procedure label name"
 
 %precedence empty
+%precedence vector
 %precedence as
 %precedence placement
 %precedence lowerThanSelectOpt
@@ -2079,6 +2144,25 @@ AlterTableSpecSingleOpt:
 			Partition: &ast.PartitionOptions{PartitionMethod: partitionMethod},
 		}
 	}
+|	"SPLIT" "PARTITION" Identifier "INTO" '(' PartitionDefinitionList ')'
+	{
+		$$ = &ast.AlterTableSpec{
+			Tp:              ast.AlterTableSplitPartition,
+			PartitionNames:  []model.CIStr{model.NewCIStr($3)},
+			PartDefinitions: $6.([]*ast.PartitionDefinition),
+		}
+	}
+|	"MERGE" "PARTITIONS" PartitionNameList "INTO" "PARTITION" Identifier
+	{
+		$$ = &ast.AlterTableSpec{
+			Tp:             ast.AlterTableMergePartitions,
+			PartitionNames: $3.([]model.CIStr),
+			PartDefinitions: []*ast.PartitionDefinition{{
+				Name:   model.NewCIStr($6),
+				Clause: &ast.PartitionDefinitionClauseNone{},
+			}},
+		}
+	}
 |	"PARTITION" Identifier AttributesOpt
 	{
 		$$ = &ast.AlterTableSpec{
@@ -2111,6 +2195,30 @@ LocationLabelList:
 		$$ = $3
 	}
 
+LogBackupTaskOpt:
+	{
+		$$ = ""
+	}
+|	stringLit
+
+TiFlashReplicaWaitOpt:
+	{
+		$$ = nil
+	}
+|	"WAIT" TiFlashReplicaWaitTimeoutOpt
+	{
+		$$ = &ast.TiFlashReplicaWaitOption{Timeout: $2.(uint64)}
+	}
+
+TiFlashReplicaWaitTimeoutOpt:
+	{
+		$$ = uint64(0)
+	}
+|	"TIMEOUT" LengthNum
+	{
+		$$ = $2
+	}
+
 AlterTableSpec:
 	TableOptionList %prec higherThanComma
 	{
@@ -2119,12 +2227,15 @@ AlterTableSpec:
 			Options: $1.([]*ast.TableOption),
 		}
 	}
-|	"SET" "TIFLASH" "REPLICA" LengthNum LocationLabelList
+|	"SET" "TIFLASH" "REPLICA" LengthNum LocationLabelList TiFlashReplicaWaitOpt
 	{
 		tiflashReplicaSpec := &ast.TiFlashReplicaSpec{
 			Count:  $4.(uint64),
 			Labels: $5.([]string),
 		}
+		if $6 != nil {
+			tiflashReplicaSpec.Wait = $6.(*ast.TiFlashReplicaWaitOption)
+		}
 		$$ = &ast.AlterTableSpec{
 			Tp:             ast.AlterTableSetTiFlashReplica,
 			TiFlashReplica: tiflashReplicaSpec,
@@ -2203,6 +2314,14 @@ AlterTableSpec:
 			Constraint: constraint,
 		}
 	}
+|	"ADD" VectorIndexDef
+	{
+		constraint := $2.(*ast.Constraint)
+		$$ = &ast.AlterTableSpec{
+			Tp:         ast.AlterTableAddConstraint,
+			Constraint: constraint,
+		}
+	}
 |	"ADD" "PARTITION" IfNotExists NoWriteToBinLogAliasOpt PartitionDefinitionListOpt
 	{
 		var defs []*ast.PartitionDefinition
@@ -2527,7 +2646,7 @@ AlterTableSpec:
 	}
 |	"ALTER" ColumnKeywordOpt ColumnName "SET" "DEFAULT" '(' Expression ')'
 	{
-		option := &ast.ColumnOption{Expr: $7}
+		option := &ast.ColumnOption{Expr: newDefaultValueExpr($7, true)}
 		colDef := &ast.ColumnDef{
 			Name:    $3.(*ast.ColumnName),
 			Options: []*ast.ColumnOption{option},
@@ -3085,19 +3204,21 @@ FlashbackDatabaseStmt:
  *
  *  Example:
  *      SPLIT TABLE table_name INDEX index_name BY (val1...),(val2...)...
+ *      SPLIT TABLE table_name [INDEX index_name] BY QUANTILES REGIONS 16 SCATTER
  *
  *******************************************************************/
 SplitRegionStmt:
-	"SPLIT" SplitSyntaxOption "TABLE" TableName PartitionNameListOpt SplitOption
+	"SPLIT" SplitSyntaxOption "TABLE" TableName PartitionNameListOpt SplitOption SplitScatterOpt
 	{
 		$$ = &ast.SplitRegionStmt{
 			SplitSyntaxOpt: $2.(*ast.SplitSyntaxOption),
 			Table:          $4.(*ast.TableName),
 			PartitionNames: $5.([]model.CIStr),
 			SplitOpt:       $6.(*ast.SplitOption),
+			Scatter:        $7.(bool),
 		}
 	}
-|	"SPLIT" SplitSyntaxOption "TABLE" TableName PartitionNameListOpt "INDEX" Identifier SplitOption
+|	"SPLIT" SplitSyntaxOption "TABLE" TableName PartitionNameListOpt "INDEX" Identifier SplitOption SplitScatterOpt
 	{
 		$$ = &ast.SplitRegionStmt{
 			SplitSyntaxOpt: $2.(*ast.SplitSyntaxOption),
@@ -3105,6 +3226,7 @@ SplitRegionStmt:
 			PartitionNames: $5.([]model.CIStr),
 			IndexName:      model.NewCIStr($7),
 			SplitOpt:       $8.(*ast.SplitOption),
+			Scatter:        $9.(bool),
 		}
 	}
 
@@ -3123,6 +3245,23 @@ SplitOption:
 			ValueLists: $2.([][]ast.ExprNode),
 		}
 	}
+|	"BY" "QUANTILES" "REGIONS" Int64Num
+	{
+		$$ = &ast.SplitOption{
+			ByQuantiles: true,
+			Num:         $4.(int64),
+		}
+	}
+
+SplitScatterOpt:
+	/* empty */
+	{
+		$$ = false
+	}
+|	"SCATTER"
+	{
+		$$ = true
+	}
 
 SplitSyntaxOption:
 	/* empty */
@@ -3583,6 +3722,14 @@ ColumnOption:
 	{
 		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionDefaultValue, Expr: $2}
 	}
+|	"VISIBLE"
+	{
+		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionVisible}
+	}
+|	"INVISIBLE"
+	{
+		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionInvisible}
+	}
 |	"SERIAL" "DEFAULT" "VALUE"
 	{
 		$$ = []*ast.ColumnOption{{Tp: ast.ColumnOptionNotNull}, {Tp: ast.ColumnOptionAutoIncrement}, {Tp: ast.ColumnOptionUniqKey}}
@@ -3935,17 +4082,24 @@ ReferOpt:
  *     https://dev.mysql.com/doc/refman/8.0/en/data-type-defaults.html
  */
 DefaultValueExpr:
-	NowSymOptionFractionParentheses
+	NowSymOptionFraction
 |	SignedLiteral
 |	NextValueForSequence
-|	BuiltinFunction
+|	BuiltinFunctionCall
+|	'(' Expression ')'
+	{
+		$$ = newDefaultValueExpr($2, false)
+	}
 
 BuiltinFunction:
 	'(' BuiltinFunction ')'
 	{
 		$$ = $2.(*ast.FuncCallExpr)
 	}
-|	identifier '(' ')'
+|	BuiltinFunctionCall
+
+BuiltinFunctionCall:
+	identifier '(' ')'
 	{
 		$$ = &ast.FuncCallExpr{
 			FnName: model.NewCIStr($1),
@@ -3966,13 +4120,6 @@ BuiltinFunction:
 		}
 	}
 
-NowSymOptionFractionParentheses:
-	'(' NowSymOptionFractionParentheses ')'
-	{
-		$$ = $2.(*ast.FuncCallExpr)
-	}
-|	NowSymOptionFraction
-
 NowSymOptionFraction:
 	NowSym
 	{
@@ -4239,6 +4386,10 @@ IndexKeyTypeOpt:
 	{
 		$$ = ast.IndexKeyTypeFullText
 	}
+|	"VECTOR"
+	{
+		$$ = ast.IndexKeyTypeVector
+	}
 
 /**************************************AlterDatabaseStmt***************************************
  * See https://dev.mysql.com/doc/refman/5.7/en/alter-database.html
@@ -4345,6 +4496,19 @@ DatabaseOption:
 			TiFlashReplica: tiflashReplicaSpec,
 		}
 	}
+|	"SET" "READ" "ONLY" EqOpt LengthNum
+	{
+		readOnly := $5.(uint64)
+		if readOnly > 1 {
+			yylex.AppendError(ErrWrongArguments.GenWithStackByArgs("READ ONLY"))
+			return 1
+		}
+		$$ = &ast.DatabaseOption{Tp: ast.DatabaseSetReadOnly, UintValue: readOnly}
+	}
+|	"SET" "READ" "ONLY" EqOpt "DEFAULT"
+	{
+		$$ = &ast.DatabaseOption{Tp: ast.DatabaseSetReadOnly, UintValue: 0}
+	}
 
 DatabaseOptionListOpt:
 	{
@@ -4399,6 +4563,15 @@ CreateTableStmt:
 		}
 		$$ = stmt
 	}
+|	"CREATE" "EXTERNAL" "TABLE" IfNotExists TableName TableElementListOpt CreateTableOptionListOpt
+	{
+		stmt := $6.(*ast.CreateTableStmt)
+		stmt.Table = $5.(*ast.TableName)
+		stmt.IfNotExists = $4.(bool)
+		stmt.IsExternal = true
+		stmt.Options = $7.([]*ast.TableOption)
+		$$ = stmt
+	}
 |	"CREATE" OptTemporary "TABLE" IfNotExists TableName LikeTableWithOrWithoutParen OnCommitOpt
 	{
 		tmp := &ast.CreateTableStmt{
@@ -4938,6 +5111,34 @@ CreateViewStmt:
 		$$ = x
 	}
 
+CreateMaterializedViewStmt:
+	"CREATE" "MATERIALIZED" "VIEW" IfNotExists TableName MaterializedViewRefreshOpt "AS" CreateViewSelectOpt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		selStmt := $8.(ast.StmtNode)
+		selStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:]))
+		x := $6.(*ast.CreateMaterializedViewStmt)
+		x.IfNotExists = $4.(bool)
+		x.ViewName = $5.(*ast.TableName)
+		x.Select = selStmt
+		$$ = x
+	}
+
+MaterializedViewRefreshOpt:
+	{
+		$$ = &ast.CreateMaterializedViewStmt{}
+	}
+|	"REFRESH" "EVERY" LengthNum TimeUnit
+	{
+		$$ = &ast.CreateMaterializedViewStmt{RefreshEvery: $3.(uint64), RefreshUnit: $4.(ast.TimeUnitType)}
+	}
+
+RefreshMaterializedViewStmt:
+	"REFRESH" "MATERIALIZED" "VIEW" TableName
+	{
+		$$ = &ast.RefreshMaterializedViewStmt{ViewName: $4.(*ast.TableName)}
+	}
+
 OrReplace:
 	/* EMPTY */
 	{
@@ -5200,6 +5401,12 @@ DropViewStmt:
 		$$ = &ast.DropTableStmt{IfExists: true, Tables: $5.([]*ast.TableName), IsView: true}
 	}
 
+DropMaterializedViewStmt:
+	"DROP" "MATERIALIZED" "VIEW" IfExists TableNameList
+	{
+		$$ = &ast.DropTableStmt{IfExists: $4.(bool), Tables: $5.([]*ast.TableName), IsMaterializedView: true}
+	}
+
 DropUserStmt:
 	"DROP" "USER" UsernameList
 	{
@@ -5875,6 +6082,29 @@ CancelImportStmt:
 		}
 	}
 
+DistJobActionStmt:
+	"CANCEL" "JOB" Int64Num
+	{
+		$$ = &ast.DistJobActionStmt{
+			Tp:    ast.DistJobCancel,
+			JobID: $3.(int64),
+		}
+	}
+|	"PAUSE" "JOB" Int64Num
+	{
+		$$ = &ast.DistJobActionStmt{
+			Tp:    ast.DistJobPause,
+			JobID: $3.(int64),
+		}
+	}
+|	"RESUME" "JOB" Int64Num
+	{
+		$$ = &ast.DistJobActionStmt{
+			Tp:    ast.DistJobResume,
+			JobID: $3.(int64),
+		}
+	}
+
 Expression:
 	singleAtIdentifier assignmentEq Expression %prec assignmentEq
 	{
@@ -6521,6 +6751,10 @@ IndexTypeName:
 	{
 		$$ = model.IndexTypeHypo
 	}
+|	"HNSW"
+	{
+		$$ = model.IndexTypeHNSW
+	}
 
 IndexInvisible:
 	"VISIBLE"
@@ -6544,6 +6778,7 @@ UnReservedKeyword:
 |	"ADVISE"
 |	"ASCII"
 |	"ATTRIBUTE"
+|	"AT"
 |	"ATTRIBUTES"
 |	"BINDING_CACHE"
 |	"STATS_OPTIONS"
@@ -6556,6 +6791,7 @@ UnReservedKeyword:
 |	"ALWAYS"
 |	"AVG"
 |	"BDR"
+|	"BEFORE"
 |	"BEGIN"
 |	"BIT"
 |	"BOOL"
@@ -6573,6 +6809,7 @@ UnReservedKeyword:
 |	"SAN"
 |	"COMMIT"
 |	"COMPACT"
+|	"COMPLETION"
 |	"COMPRESSED"
 |	"CONSISTENCY"
 |	"CONSISTENT"
@@ -6587,6 +6824,7 @@ UnReservedKeyword:
 |	"DYNAMIC"
 |	"ENCRYPTION"
 |	"END"
+|	"ENDS"
 |	"ENFORCED"
 |	"ENGINE"
 |	"ENGINES"
@@ -6595,8 +6833,10 @@ UnReservedKeyword:
 |	"ERRORS"
 |	"ESCAPE"
 |	"EVOLVE"
+|	"EVERY"
 |	"EXECUTE"
 |	"EXTENDED"
+|	"EXTERNAL"
 |	"FIELDS"
 |	"FILE"
 |	"FIRST"
@@ -6631,6 +6871,7 @@ UnReservedKeyword:
 |	"RESTART"
 |	"ROLE"
 |	"ROLLBACK"
+|	"ROTATE"
 |	"ROLLUP"
 |	"SESSION"
 |	"SIGNED"
@@ -6638,6 +6879,7 @@ UnReservedKeyword:
 |	"SHUTDOWN"
 |	"SNAPSHOT"
 |	"START"
+|	"STARTS"
 |	"STATUS"
 |	"OPEN"
 |	"POINT"
@@ -6673,6 +6915,7 @@ UnReservedKeyword:
 |	"CHECKSUM"
 |	"COMPRESSION"
 |	"KEY_BLOCK_SIZE"
+|	"MASKING"
 |	"MASTER"
 |	"MAX_ROWS"
 |	"MIN_ROWS"
@@ -6692,6 +6935,7 @@ UnReservedKeyword:
 |	"ONLY"
 |	"SERIAL"
 |	"SERIALIZABLE"
+|	"SERVER"
 |	"LEVEL"
 |	"VARIABLES"
 |	"SQL_CACHE"
@@ -6700,6 +6944,7 @@ UnReservedKeyword:
 |	"SQL_NO_CACHE"
 |	"DISABLE"
 |	"DISABLED"
+|	"EACH"
 |	"ENABLE"
 |	"ENABLED"
 |	"REVERSE"
@@ -6798,8 +7043,12 @@ UnReservedKeyword:
 |	"SECONDARY_UNLOAD"
 |	"VALIDATION"
 |	"WITHOUT"
+|	"WRAPPER"
+|	"OPTIONS"
 |	"RTREE"
 |	"HYPO"
+|	"HNSW"
+|	"VECTOR"
 |	"EXCHANGE"
 |	"COLUMN_FORMAT"
 |	"REPAIR"
@@ -6895,6 +7144,8 @@ UnReservedKeyword:
 |	"TTL"
 |	"TTL_ENABLE"
 |	"TTL_JOB_INTERVAL"
+|	"INTERVAL_PRECREATE"
+|	"INTERVAL_RETENTION"
 |	"FAILED_LOGIN_ATTEMPTS"
 |	"PASSWORD_LOCK_TIME"
 |	"DIGEST"
@@ -6953,6 +7204,9 @@ TiDBKeyword:
 |	"RESET"
 |	"DRY"
 |	"RUN"
+|	"QUANTILES"
+|	"SCATTER"
+|	"SUMMARY"
 
 NotKeywordToken:
 	"ADDDATE"
@@ -7022,6 +7276,14 @@ NotKeywordToken:
 |	"NEXT_ROW_ID"
 |	"EXPR_PUSHDOWN_BLACKLIST"
 |	"OPT_RULE_BLACKLIST"
+|	"METRIC_TABLES"
+|	"DIFF"
+|	"COMPARE"
+|	"OPTIMIZER"
+|	"TIMEOUT"
+|	"MATERIALIZED"
+|	"REFRESH"
+|	"RECOMMEND"
 |	"BOUND"
 |	"EXACT" %prec lowerThanStringLitToken
 |	"STALENESS"
@@ -10977,6 +11239,13 @@ AdminStmt:
 			Tables: $4.([]*ast.TableName),
 		}
 	}
+|	"ADMIN" "CHECK" "TABLE" TableNameList "ONLINE"
+	{
+		$$ = &ast.AdminStmt{
+			Tp:     ast.AdminCheckTableOnline,
+			Tables: $4.([]*ast.TableName),
+		}
+	}
 |	"ADMIN" "CHECK" "INDEX" TableName Identifier
 	{
 		$$ = &ast.AdminStmt{
@@ -11024,6 +11293,13 @@ AdminStmt:
 			JobIDs: $5.([]int64),
 		}
 	}
+|	"ADMIN" "CANCEL" "CHECK" "TABLE" "JOBS" NumList
+	{
+		$$ = &ast.AdminStmt{
+			Tp:     ast.AdminCancelCheckTableJobs,
+			JobIDs: $6.([]int64),
+		}
+	}
 |	"ADMIN" "PAUSE" "DDL" "JOBS" NumList
 	{
 		$$ = &ast.AdminStmt{
@@ -11038,6 +11314,20 @@ AdminStmt:
 			JobIDs: $5.([]int64),
 		}
 	}
+|	"ADMIN" "PAUSE" "LOG" "BACKUP" LogBackupTaskOpt
+	{
+		$$ = &ast.AdminStmt{
+			Tp:            ast.AdminPauseLogBackup,
+			LogBackupTask: $5,
+		}
+	}
+|	"ADMIN" "RESUME" "LOG" "BACKUP" LogBackupTaskOpt
+	{
+		$$ = &ast.AdminStmt{
+			Tp:            ast.AdminResumeLogBackup,
+			LogBackupTask: $5,
+		}
+	}
 |	"ADMIN" "SHOW" "DDL" "JOB" "QUERIES" NumList
 	{
 		$$ = &ast.AdminStmt{
@@ -11073,6 +11363,43 @@ AdminStmt:
 			Tp: ast.AdminReloadOptRuleBlacklist,
 		}
 	}
+|	"ADMIN" "RELOAD" "METRIC_TABLES"
+	{
+		$$ = &ast.AdminStmt{
+			Tp: ast.AdminReloadMetricTables,
+		}
+	}
+|	"ADMIN" "DIFF" DatabaseSym "BETWEEN" "TIMESTAMP" stringLit "AND" stringLit AdminDiffSchemaForOpt
+	{
+		opt := $9.(*ast.DiffSchemaOption)
+		opt.FromTS = ast.NewValueExpr($6, "", "")
+		opt.ToTS = ast.NewValueExpr($8, "", "")
+		$$ = &ast.AdminStmt{
+			Tp:         ast.AdminDiffSchema,
+			DiffSchema: opt,
+		}
+	}
+|	"ADMIN" "COMPARE" "OPTIMIZER" "TRACE" stringLit stringLit
+	{
+		$$ = &ast.AdminStmt{
+			Tp:          ast.AdminCompareOptimizerTrace,
+			PlanDigests: []string{$5, $6},
+		}
+	}
+|	"ADMIN" "WAIT" "TIFLASH" "REPLICA" TableNameList TiFlashReplicaWaitTimeoutOpt
+	{
+		$$ = &ast.AdminStmt{
+			Tp:                 ast.AdminWaitTiFlashReplica,
+			Tables:             $5.([]*ast.TableName),
+			TiFlashReplicaWait: &ast.TiFlashReplicaWaitOption{Timeout: $6.(uint64)},
+		}
+	}
+|	"ADMIN" "ROTATE" "ENCRYPTION" "KEY"
+	{
+		$$ = &ast.AdminStmt{
+			Tp: ast.AdminRotateEncryptionKey,
+		}
+	}
 |	"ADMIN" "PLUGINS" "ENABLE" PluginNameList
 	{
 		$$ = &ast.AdminStmt{
@@ -11196,6 +11523,19 @@ AdminShowSlow:
 		}
 	}
 
+AdminDiffSchemaForOpt:
+	{
+		$$ = &ast.DiffSchemaOption{}
+	}
+|	"FOR" Identifier
+	{
+		$$ = &ast.DiffSchemaOption{Schema: model.NewCIStr($2)}
+	}
+|	"FOR" Identifier '.' Identifier
+	{
+		$$ = &ast.DiffSchemaOption{Schema: model.NewCIStr($2), Table: model.NewCIStr($4)}
+	}
+
 HandleRangeList:
 	HandleRange
 	{
@@ -11287,15 +11627,16 @@ ShowStmt:
 			User: $4.(*auth.UserIdentity),
 		}
 	}
-|	"SHOW" "TABLE" TableName PartitionNameListOpt "REGIONS" WhereClauseOptional
+|	"SHOW" "TABLE" TableName PartitionNameListOpt "REGIONS" RegionsSummaryOpt WhereClauseOptional
 	{
 		stmt := &ast.ShowStmt{
-			Tp:    ast.ShowRegions,
-			Table: $3.(*ast.TableName),
+			Tp:             ast.ShowRegions,
+			Table:          $3.(*ast.TableName),
+			RegionsSummary: $6.(bool),
 		}
 		stmt.Table.PartitionNames = $4.([]model.CIStr)
-		if $6 != nil {
-			stmt.Where = $6.(ast.ExprNode)
+		if $7 != nil {
+			stmt.Where = $7.(ast.ExprNode)
 		}
 		$$ = stmt
 	}
@@ -11306,16 +11647,17 @@ ShowStmt:
 			Table: $3.(*ast.TableName),
 		}
 	}
-|	"SHOW" "TABLE" TableName PartitionNameListOpt "INDEX" Identifier "REGIONS" WhereClauseOptional
+|	"SHOW" "TABLE" TableName PartitionNameListOpt "INDEX" Identifier "REGIONS" RegionsSummaryOpt WhereClauseOptional
 	{
 		stmt := &ast.ShowStmt{
-			Tp:        ast.ShowRegions,
-			Table:     $3.(*ast.TableName),
-			IndexName: model.NewCIStr($6),
+			Tp:             ast.ShowRegions,
+			Table:          $3.(*ast.TableName),
+			IndexName:      model.NewCIStr($6),
+			RegionsSummary: $8.(bool),
 		}
 		stmt.Table.PartitionNames = $4.([]model.CIStr)
-		if $8 != nil {
-			stmt.Where = $8.(ast.ExprNode)
+		if $9 != nil {
+			stmt.Where = $9.(ast.ExprNode)
 		}
 		$$ = stmt
 	}
@@ -11416,6 +11758,13 @@ ShowStmt:
 			ImportJobID: &v,
 		}
 	}
+|	"SHOW" "JOB" Int64Num
+	{
+		$$ = &ast.ShowStmt{
+			Tp:        ast.ShowDistJobSubtasks,
+			DistJobID: $3.(int64),
+		}
+	}
 |	"SHOW" "CREATE" "PROCEDURE" TableName
 	{
 		$$ = &ast.ShowStmt{
@@ -11523,6 +11872,16 @@ UsingRoles:
 		$$ = $2.([]*auth.RoleIdentity)
 	}
 
+RegionsSummaryOpt:
+	/* empty */
+	{
+		$$ = false
+	}
+|	"SUMMARY"
+	{
+		$$ = true
+	}
+
 ShowIndexKwd:
 	"INDEX"
 |	"INDEXES"
@@ -11766,6 +12125,10 @@ ShowTargetFilterable:
 	{
 		$$ = &ast.ShowStmt{Tp: ast.ShowImportJobs}
 	}
+|	"JOBS"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowDistJobs}
+	}
 
 ShowLikeOrWhereOpt:
 	{
@@ -12000,11 +12363,16 @@ Statement:
 |	CreateIndexStmt
 |	CreateTableStmt
 |	CreateViewStmt
+|	CreateMaterializedViewStmt
 |	CreateUserStmt
 |	CreateRoleStmt
 |	CreateBindingStmt
 |	CreatePolicyStmt
+|	CreateMaskingPolicyStmt
+|	CreateServerStmt
 |	CreateProcedureStmt
+|	CreateTriggerStmt
+|	CreateEventStmt
 |	CreateResourceGroupStmt
 |	AddQueryWatchStmt
 |	CreateSequenceStmt
@@ -12014,9 +12382,14 @@ Statement:
 |	DropIndexStmt
 |	DropTableStmt
 |	DropProcedureStmt
+|	DropTriggerStmt
+|	DropEventStmt
 |	DropPolicyStmt
+|	DropMaskingPolicyStmt
+|	DropServerStmt
 |	DropSequenceStmt
 |	DropViewStmt
+|	DropMaterializedViewStmt
 |	DropUserStmt
 |	DropResourceGroupStmt
 |	DropQueryWatchStmt
@@ -12047,6 +12420,8 @@ Statement:
 |	RenameUserStmt
 |	ReplaceIntoStmt
 |	RecoverTableStmt
+|	RecommendIndexStmt
+|	RefreshMaterializedViewStmt
 |	ReleaseSavepointStmt
 |	RevokeStmt
 |	RevokeRoleStmt
@@ -12085,6 +12460,7 @@ Statement:
 |	NonTransactionalDMLStmt
 |	OptimizeTableStmt
 |	CancelImportStmt
+|	DistJobActionStmt
 
 TraceableStmt:
 	DeleteFromStmt
@@ -12180,6 +12556,33 @@ CheckConstraintKeyword:
 TableElement:
 	ColumnDef
 |	Constraint
+|	VectorIndexDef
+
+/*
+ * VECTOR INDEX is not a part of ConstraintElem because VECTOR is not a reserved keyword,
+ * it's ambiguous with a column named 'vector' after the optional CONSTRAINT keyword.
+ */
+VectorIndexDef:
+	"VECTOR" KeyOrIndex IfNotExists IndexNameAndTypeOpt '(' IndexPartSpecificationList ')' IndexOptionList
+	{
+		c := &ast.Constraint{
+			IfNotExists:  $3.(bool),
+			Tp:           ast.ConstraintVector,
+			Keys:         $6.([]*ast.IndexPartSpecification),
+			Name:         $4.([]interface{})[0].(*ast.NullString).String,
+			IsEmptyIndex: $4.([]interface{})[0].(*ast.NullString).Empty,
+		}
+		if $8 != nil {
+			c.Option = $8.(*ast.IndexOption)
+		}
+		if indexType := $4.([]interface{})[1]; indexType != nil {
+			if c.Option == nil {
+				c.Option = &ast.IndexOption{}
+			}
+			c.Option.Tp = indexType.(model.IndexType)
+		}
+		$$ = c
+	}
 
 TableElementList:
 	TableElement
@@ -12434,6 +12837,22 @@ TableOption:
 		}
 		$$ = &ast.TableOption{Tp: ast.TableOptionTTLJobInterval, StrValue: $3}
 	}
+|	"LOCATION" EqOpt stringLit
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionLocation, StrValue: $3}
+	}
+|	"FORMAT" EqOpt stringLit
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionFormat, StrValue: $3}
+	}
+|	"INTERVAL_PRECREATE" EqOpt LengthNum
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionIntervalPrecreate, UintValue: $3.(uint64)}
+	}
+|	"INTERVAL_RETENTION" EqOpt LengthNum
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionIntervalRetention, UintValue: $3.(uint64)}
+	}
 
 ForceOpt:
 	/* empty */
@@ -12897,6 +13316,15 @@ StringType:
 		tp.SetCollate(charset.CollationBin)
 		$$ = tp
 	}
+|	"VECTOR" OptFieldLen
+	{
+		tp := types.NewFieldType(mysql.TypeTiDBVectorFloat32)
+		tp.SetFlen($2.(int))
+		tp.SetDecimal(0)
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+		$$ = tp
+	}
 |	"LONG" Varchar OptCharsetWithOptBinary
 	{
 		tp := types.NewFieldType(mysql.TypeMediumBlob)
@@ -13973,7 +14401,7 @@ GrantProxyStmt:
 	}
 
 GrantRoleStmt:
-	"GRANT" RoleOrPrivElemList "TO" UsernameList
+	"GRANT" RoleOrPrivElemList "TO" UsernameList WithAdminOptionOpt
 	{
 		r, err := convertToRole($2.([]*ast.RoleOrPriv))
 		if err != nil {
@@ -13981,11 +14409,21 @@ GrantRoleStmt:
 			return 1
 		}
 		$$ = &ast.GrantRoleStmt{
-			Roles: r,
-			Users: $4.([]*auth.UserIdentity),
+			Roles:           r,
+			Users:           $4.([]*auth.UserIdentity),
+			WithAdminOption: $5.(bool),
 		}
 	}
 
+WithAdminOptionOpt:
+	{
+		$$ = false
+	}
+|	"WITH" "ADMIN" "OPTION"
+	{
+		$$ = true
+	}
+
 WithGrantOptionOpt:
 	{
 		$$ = false
@@ -14958,6 +15396,109 @@ AlterPolicyStmt:
 		}
 	}
 
+/********************************************************************************************
+ *
+ *  Create Masking Policy Statement
+ *
+ *  Example:
+ *  CREATE MASKING POLICY [IF NOT EXISTS] policy_name ON tbl_name (col_name) USING expr TO role [, role] ...
+ ********************************************************************************************/
+CreateMaskingPolicyStmt:
+	"CREATE" "MASKING" "POLICY" IfNotExists PolicyName "ON" TableName '(' ColumnName ')' "USING" Expression "TO" RolenameList
+	{
+		$$ = &ast.CreateMaskingPolicyStmt{
+			IfNotExists: $4.(bool),
+			PolicyName:  model.NewCIStr($5),
+			Table:       $7.(*ast.TableName),
+			Column:      $9.(*ast.ColumnName),
+			Expr:        $12,
+			Roles:       $14.([]*auth.RoleIdentity),
+		}
+	}
+
+DropMaskingPolicyStmt:
+	"DROP" "MASKING" "POLICY" IfExists PolicyName
+	{
+		$$ = &ast.DropMaskingPolicyStmt{
+			IfExists:   $4.(bool),
+			PolicyName: model.NewCIStr($5),
+		}
+	}
+
+/********************************************************************************************
+ *
+ *  Create Server Statement
+ *
+ *  Example:
+ *  CREATE SERVER [IF NOT EXISTS] server_name FOREIGN DATA WRAPPER wrapper_name
+ *      OPTIONS (option [, option] ...)
+ *
+ *  See https://dev.mysql.com/doc/refman/8.0/en/create-server.html
+ ********************************************************************************************/
+CreateServerStmt:
+	"CREATE" "SERVER" IfNotExists Identifier "FOREIGN" "DATA" "WRAPPER" Identifier "OPTIONS" '(' ServerOptionList ')'
+	{
+		$$ = &ast.CreateServerStmt{
+			IfNotExists: $3.(bool),
+			ServerName:  model.NewCIStr($4),
+			WrapperName: strings.ToLower($8),
+			Options:     $11.([]*ast.ServerOption),
+		}
+	}
+
+ServerOptionList:
+	ServerOption
+	{
+		$$ = []*ast.ServerOption{$1.(*ast.ServerOption)}
+	}
+|	ServerOptionList ',' ServerOption
+	{
+		$$ = append($1.([]*ast.ServerOption), $3.(*ast.ServerOption))
+	}
+
+ServerOption:
+	"DATABASE" stringLit
+	{
+		$$ = &ast.ServerOption{Tp: ast.ServerOptionDatabase, StrValue: $2}
+	}
+|	Identifier stringLit
+	{
+		opt := &ast.ServerOption{StrValue: $2}
+		switch strings.ToUpper($1) {
+		case "HOST":
+			opt.Tp = ast.ServerOptionHost
+		case "USER":
+			opt.Tp = ast.ServerOptionUser
+		case "PASSWORD":
+			opt.Tp = ast.ServerOptionPassword
+		case "SOCKET":
+			opt.Tp = ast.ServerOptionSocket
+		case "OWNER":
+			opt.Tp = ast.ServerOptionOwner
+		default:
+			yylex.AppendError(yylex.Errorf("Unknown server option %s", $1))
+			return 1
+		}
+		$$ = opt
+	}
+|	Identifier LengthNum
+	{
+		if strings.ToUpper($1) != "PORT" {
+			yylex.AppendError(yylex.Errorf("Unknown server option %s", $1))
+			return 1
+		}
+		$$ = &ast.ServerOption{Tp: ast.ServerOptionPort, UintValue: $2.(uint64)}
+	}
+
+DropServerStmt:
+	"DROP" "SERVER" IfExists Identifier
+	{
+		$$ = &ast.DropServerStmt{
+			IfExists:   $3.(bool),
+			ServerName: model.NewCIStr($4),
+		}
+	}
+
 /********************************************************************************************
  *
  *  Create Sequence Statement
@@ -15178,6 +15719,50 @@ IndexAdviseStmt:
 		$$ = x
 	}
 
+RecommendIndexStmt:
+	"RECOMMEND" "INDEX" "RUN" RecommendIndexForOpt RecommendIndexWithOpt
+	{
+		$$ = &ast.RecommendIndexStmt{
+			Action:  "run",
+			SQL:     $4,
+			Options: $5.([]ast.RecommendIndexOption),
+		}
+	}
+
+RecommendIndexForOpt:
+	{
+		$$ = ""
+	}
+|	"FOR" stringLit
+	{
+		$$ = $2
+	}
+
+RecommendIndexWithOpt:
+	{
+		$$ = []ast.RecommendIndexOption(nil)
+	}
+|	"WITH" RecommendIndexOptionList
+	{
+		$$ = $2
+	}
+
+RecommendIndexOptionList:
+	RecommendIndexOption
+	{
+		$$ = []ast.RecommendIndexOption{$1.(ast.RecommendIndexOption)}
+	}
+|	RecommendIndexOptionList ',' RecommendIndexOption
+	{
+		$$ = append($1.([]ast.RecommendIndexOption), $3.(ast.RecommendIndexOption))
+	}
+
+RecommendIndexOption:
+	Identifier eq LengthNum
+	{
+		$$ = ast.RecommendIndexOption{Name: strings.ToLower($1), Value: $3.(uint64)}
+	}
+
 MaxMinutesOpt:
 	{
 		$$ = uint64(ast.UnspecifiedSize)
@@ -15511,6 +16096,7 @@ ProcedureStatementStmt:
 |	DeleteFromStmt
 |	AnalyzeTableStmt
 |	TruncateTableStmt
+|	CallStmt
 
 ProcedureCursorSelectStmt:
 	SelectStmt
@@ -15991,22 +16577,23 @@ ProcedureProcStmt:
  *  Valid SQL routine statement
  ********************************************************************************************/
 CreateProcedureStmt:
-	"CREATE" "PROCEDURE" IfNotExists TableName '(' OptSpPdparams ')' ProcedureProcStmt
+	"CREATE" "PROCEDURE" IfNotExists TableName '(' OptSpPdparams ')' ViewSQLSecurity ProcedureProcStmt
 	{
 		x := &ast.ProcedureInfo{
 			IfNotExists:    $3.(bool),
 			ProcedureName:  $4.(*ast.TableName),
 			ProcedureParam: $6.([]*ast.StoreParameter),
-			ProcedureBody:  $8,
+			ProcedureBody:  $9,
+			SQLSecurity:    $8.(model.ViewSecurity),
 		}
 		startOffset := parser.startOffset(&yyS[yypt])
-		originStmt := $8
+		originStmt := $9
 		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
-		startOffset = parser.startOffset(&yyS[yypt-3])
+		startOffset = parser.startOffset(&yyS[yypt-4])
 		if parser.src[startOffset] == '(' {
 			startOffset++
 		}
-		endOffset := parser.startOffset(&yyS[yypt-1])
+		endOffset := parser.startOffset(&yyS[yypt-2])
 		x.ProcedureParamStr = strings.TrimSpace(parser.src[startOffset:endOffset])
 		$$ = x
 	}
@@ -16023,6 +16610,190 @@ DropProcedureStmt:
 		}
 	}
 
+/********************************************************************************************
+ *
+ *  Create Trigger Statement
+ *
+ *  Example:
+ *	CREATE
+ *  TRIGGER [IF NOT EXISTS] trigger_name
+ *  trigger_time trigger_event
+ *  ON tbl_name FOR EACH ROW
+ *  trigger_body
+ *  trigger_time: { BEFORE | AFTER }
+ *  trigger_event: { INSERT | UPDATE | DELETE }
+ ********************************************************************************************/
+CreateTriggerStmt:
+	"CREATE" "TRIGGER" IfNotExists TableName TriggerTime TriggerEvent "ON" TableName "FOR" "EACH" "ROW" ProcedureProcStmt
+	{
+		x := &ast.CreateTriggerStmt{
+			IfNotExists: $3.(bool),
+			Name:        $4.(*ast.TableName),
+			Timing:      $5.(model.TriggerTiming),
+			Event:       $6.(model.TriggerEvent),
+			Table:       $8.(*ast.TableName),
+			Body:        $12,
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		originStmt := $12
+		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = x
+	}
+
+TriggerTime:
+	"BEFORE"
+	{
+		$$ = model.TriggerTimingBefore
+	}
+|	"AFTER"
+	{
+		$$ = model.TriggerTimingAfter
+	}
+
+TriggerEvent:
+	"INSERT"
+	{
+		$$ = model.TriggerEventInsert
+	}
+|	"UPDATE"
+	{
+		$$ = model.TriggerEventUpdate
+	}
+|	"DELETE"
+	{
+		$$ = model.TriggerEventDelete
+	}
+
+/********************************************************************************************
+*  DROP TRIGGER [IF EXISTS] [schema_name.]trigger_name
+********************************************************************************************/
+DropTriggerStmt:
+	"DROP" "TRIGGER" IfExists TableName
+	{
+		$$ = &ast.DropTriggerStmt{
+			IfExists: $3.(bool),
+			Name:     $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+ *
+ *  Create Event Statement
+ *
+ *  Example:
+ *	CREATE EVENT [IF NOT EXISTS] event_name
+ *  ON SCHEDULE schedule
+ *  [ON COMPLETION [NOT] PRESERVE]
+ *  [ENABLE | DISABLE]
+ *  [COMMENT 'string']
+ *  DO event_body
+ *  schedule: { AT timestamp [+ INTERVAL interval] ... | EVERY interval [STARTS timestamp] [ENDS timestamp] }
+ *
+ *  See https://dev.mysql.com/doc/refman/8.0/en/create-event.html
+ ********************************************************************************************/
+CreateEventStmt:
+	"CREATE" "EVENT" IfNotExists TableName "ON" "SCHEDULE" EventSchedule EventOnCompletionOpt EventStatusOpt EventCommentOpt "DO" ProcedureProcStmt
+	{
+		x := &ast.CreateEventStmt{
+			IfNotExists: $3.(bool),
+			Name:        $4.(*ast.TableName),
+			Schedule:    $7.(*ast.EventSchedule),
+			Preserve:    $8.(bool),
+			Disabled:    $9.(bool),
+			Comment:     $10,
+			Body:        $12,
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		originStmt := $12
+		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = x
+	}
+
+EventSchedule:
+	"AT" Expression
+	{
+		$$ = &ast.EventSchedule{At: $2}
+	}
+|	"EVERY" Expression TimeUnit EventStartsOpt EventEndsOpt
+	{
+		x := &ast.EventSchedule{
+			Every: $2,
+			Unit:  $3.(ast.TimeUnitType),
+		}
+		if $4 != nil {
+			x.Starts = $4.(ast.ExprNode)
+		}
+		if $5 != nil {
+			x.Ends = $5.(ast.ExprNode)
+		}
+		$$ = x
+	}
+
+EventStartsOpt:
+	{
+		$$ = nil
+	}
+|	"STARTS" Expression
+	{
+		$$ = $2
+	}
+
+EventEndsOpt:
+	{
+		$$ = nil
+	}
+|	"ENDS" Expression
+	{
+		$$ = $2
+	}
+
+EventOnCompletionOpt:
+	{
+		$$ = false
+	}
+|	"ON" "COMPLETION" "PRESERVE"
+	{
+		$$ = true
+	}
+|	"ON" "COMPLETION" "NOT" "PRESERVE"
+	{
+		$$ = false
+	}
+
+EventStatusOpt:
+	{
+		$$ = false
+	}
+|	"ENABLE"
+	{
+		$$ = false
+	}
+|	"DISABLE"
+	{
+		$$ = true
+	}
+
+EventCommentOpt:
+	{
+		$$ = ""
+	}
+|	"COMMENT" stringLit
+	{
+		$$ = $2
+	}
+
+/********************************************************************************************
+*  DROP EVENT [IF EXISTS] [schema_name.]event_name
+********************************************************************************************/
+DropEventStmt:
+	"DROP" "EVENT" IfExists TableName
+	{
+		$$ = &ast.DropEventStmt{
+			IfExists: $3.(bool),
+			Name:     $4.(*ast.TableName),
+		}
+	}
+
 /********************************************************************
  *
  * Calibrate Resource Statement
@@ -478,11 +478,13 @@ func TestAdminStmt(t *testing.T) {
 		{"admin show ddl job queries limit 22 offset 0", true, "ADMIN SHOW DDL JOB QUERIES LIMIT 0, 22"},
 		{"admin show t1 next_row_id", true, "ADMIN SHOW `t1` NEXT_ROW_ID"},
 		{"admin check table t1, t2;", true, "ADMIN CHECK TABLE `t1`, `t2`"},
+		{"admin check table t1, t2 online;", true, "ADMIN CHECK TABLE `t1`, `t2` ONLINE"},
 		{"admin check index tableName idxName;", true, "ADMIN CHECK INDEX `tableName` idxName"},
 		{"admin check index tableName idxName (1, 2), (4, 5);", true, "ADMIN CHECK INDEX `tableName` idxName (1,2), (4,5)"},
 		{"admin checksum table t1, t2;", true, "ADMIN CHECKSUM TABLE `t1`, `t2`"},
 		{"admin cancel ddl jobs 1", true, "ADMIN CANCEL DDL JOBS 1"},
 		{"admin cancel ddl jobs 1, 2", true, "ADMIN CANCEL DDL JOBS 1, 2"},
+		{"admin cancel check table jobs 1, 2", true, "ADMIN CANCEL CHECK TABLE JOBS 1, 2"},
 		{"admin pause ddl jobs 1, 3", true, "ADMIN PAUSE DDL JOBS 1, 3"},
 		{"admin pause ddl jobs 5", true, "ADMIN PAUSE DDL JOBS 5"},
 		{"admin pause ddl jobs", false, "ADMIN PAUSE DDL JOBS"},
@@ -498,6 +500,22 @@ func TestAdminStmt(t *testing.T) {
 		{"admin show slow top all 9", true, "ADMIN SHOW SLOW TOP ALL 9"},
 		{"admin show slow recent 11", true, "ADMIN SHOW SLOW RECENT 11"},
 		{"admin reload expr_pushdown_blacklist", true, "ADMIN RELOAD EXPR_PUSHDOWN_BLACKLIST"},
+		{"admin reload metric_tables", true, "ADMIN RELOAD METRIC_TABLES"},
+		{"admin diff schema between timestamp '2024-01-01 00:00:00' and '2024-01-02 00:00:00'", true, "ADMIN DIFF SCHEMA BETWEEN TIMESTAMP '2024-01-01 00:00:00' AND '2024-01-02 00:00:00'"},
+		{"admin diff database between timestamp '2024-01-01 00:00:00' and '2024-01-02 00:00:00' for test", true, "ADMIN DIFF SCHEMA BETWEEN TIMESTAMP '2024-01-01 00:00:00' AND '2024-01-02 00:00:00' FOR `test`"},
+		{"admin diff schema between timestamp '2024-01-01 00:00:00' and '2024-01-02 00:00:00' for test.t", true, "ADMIN DIFF SCHEMA BETWEEN TIMESTAMP '2024-01-01 00:00:00' AND '2024-01-02 00:00:00' FOR `test`.`t`"},
+		{"admin diff schema between timestamp '2024-01-01 00:00:00'", false, ""},
+		{"admin diff schema between timestamp now() and '2024-01-02 00:00:00'", false, ""},
+		{"admin compare optimizer trace 'd1' 'd2'", true, "ADMIN COMPARE OPTIMIZER TRACE 'd1' 'd2'"},
+		{"admin compare optimizer trace 'd1'", false, ""},
+		{"admin wait tiflash replica t1, test.t2", true, "ADMIN WAIT TIFLASH REPLICA `t1`, `test`.`t2`"},
+		{"admin wait tiflash replica t timeout 30", true, "ADMIN WAIT TIFLASH REPLICA `t` TIMEOUT 30"},
+		{"admin wait tiflash replica", false, ""},
+		{"admin pause log backup", true, "ADMIN PAUSE LOG BACKUP"},
+		{"admin pause log backup 'pitr'", true, "ADMIN PAUSE LOG BACKUP 'pitr'"},
+		{"admin resume log backup", true, "ADMIN RESUME LOG BACKUP"},
+		{"admin resume log backup \"pitr\"", true, "ADMIN RESUME LOG BACKUP 'pitr'"},
+		{"admin pause log backup pitr", false, ""},
 		{"admin plugins disable audit, whitelist", true, "ADMIN PLUGINS DISABLE audit, whitelist"},
 		{"admin plugins enable audit, whitelist", true, "ADMIN PLUGINS ENABLE audit, whitelist"},
 		{"admin flush bindings", true, "ADMIN FLUSH BINDINGS"},
@@ -517,6 +535,8 @@ func TestAdminStmt(t *testing.T) {
 		{"admin set bdr role secondary", true, "ADMIN SET BDR ROLE SECONDARY"},
 		{"admin unset bdr role", true, "ADMIN UNSET BDR ROLE"},
 		{"admin show bdr role", true, "ADMIN SHOW BDR ROLE"},
+		{"admin rotate encryption key", true, "ADMIN ROTATE ENCRYPTION KEY"},
+		{"admin rotate encryption", false, ""},
 	}
 	RunTest(t, table, false)
 }
@@ -1018,6 +1038,14 @@ AAAAAAAAAAAA5gm5Mg==
 		{"split region for table t1 between (1) and (1000) regions 10", true, "SPLIT REGION FOR TABLE `t1` BETWEEN (1) AND (1000) REGIONS 10"},
 		{"split partition table t1 between (1) and (1000) regions 10", true, "SPLIT PARTITION TABLE `t1` BETWEEN (1) AND (1000) REGIONS 10"},
 		{"split region for partition table t1 between (1) and (1000) regions 10", true, "SPLIT REGION FOR PARTITION TABLE `t1` BETWEEN (1) AND (1000) REGIONS 10"},
+		{"split table t1 by quantiles regions 16", true, "SPLIT TABLE `t1` BY QUANTILES REGIONS 16"},
+		{"split table t1 index idx1 by quantiles regions 16 scatter", true, "SPLIT TABLE `t1` INDEX `idx1` BY QUANTILES REGIONS 16 SCATTER"},
+		{"split partition table t1 partition (p0) by quantiles regions 4", true, "SPLIT PARTITION TABLE `t1` PARTITION(`p0`) BY QUANTILES REGIONS 4"},
+		{"split table t1 by (1),(2) scatter", true, "SPLIT TABLE `t1` BY (1),(2) SCATTER"},
+		{"split table t1 between (1) and (1000) regions 10 scatter", true, "SPLIT TABLE `t1` BETWEEN (1) AND (1000) REGIONS 10 SCATTER"},
+		{"split table t1 by quantiles", false, ""},
+		{"split table t1 by quantiles regions 16 scatter scatter", false, ""},
+		{"create table quantiles (scatter int)", true, "CREATE TABLE `quantiles` (`scatter` INT)"},
 
 		// for show table regions.
 		{"show table t1 regions", true, "SHOW TABLE `t1` REGIONS"},
@@ -1026,6 +1054,12 @@ AAAAAAAAAAAA5gm5Mg==
 		{"show table t1 index idx1 regions", true, "SHOW TABLE `t1` INDEX `idx1` REGIONS"},
 		{"show table t1 index idx1 regions where a=2", true, "SHOW TABLE `t1` INDEX `idx1` REGIONS WHERE `a`=2"},
 		{"show table t1 index idx1", false, ""},
+		{"show table t1 regions summary", true, "SHOW TABLE `t1` REGIONS SUMMARY"},
+		{"show table t1 regions summary where STORE_ID=1", true, "SHOW TABLE `t1` REGIONS SUMMARY WHERE `STORE_ID`=1"},
+		{"show table t1 index idx1 regions summary", true, "SHOW TABLE `t1` INDEX `idx1` REGIONS SUMMARY"},
+		{"show table t1 partition (p0) regions summary", true, "SHOW TABLE `t1` PARTITION(`p0`) REGIONS SUMMARY"},
+		{"show table t1 summary regions", false, ""},
+		{"create table summary (summary int)", true, "CREATE TABLE `summary` (`summary` INT)"},
 
 		// for show table partition regions.
 		{"show table t1 partition (p0,p1) regions", true, "SHOW TABLE `t1` PARTITION(`p0`, `p1`) REGIONS"},
@@ -1095,6 +1129,16 @@ AAAAAAAAAAAA5gm5Mg==
 		{"SHOW PLACEMENT LABELS LIKE '%zone%'", true, "SHOW PLACEMENT LABELS LIKE _UTF8MB4'%zone%'"},
 		{"SHOW PLACEMENT LABELS WHERE label='l123'", true, "SHOW PLACEMENT LABELS WHERE `label`=_UTF8MB4'l123'"},
 
+		// for jobs of the distributed task framework
+		{"show jobs", true, "SHOW JOBS"},
+		{"show jobs where state = 'running'", true, "SHOW JOBS WHERE `state`=_UTF8MB4'running'"},
+		{"show job 1", true, "SHOW JOB 1"},
+		{"show job", false, ""},
+		{"cancel job 1", true, "CANCEL JOB 1"},
+		{"pause job 1", true, "PAUSE JOB 1"},
+		{"resume job 1", true, "RESUME JOB 1"},
+		{"resume job", false, ""},
+
 		// for show/set session_states
 		{"SHOW SESSION_STATES", true, "SHOW SESSION_STATES"},
 		{"SET SESSION_STATES 'x'", true, "SET SESSION_STATES 'x'"},
@@ -2511,6 +2555,41 @@ func TestDDL(t *testing.T) {
 		{"create global temporary table t (a int, b varchar(255)) on commit preserve rows", true, "CREATE GLOBAL TEMPORARY TABLE `t` (`a` INT,`b` VARCHAR(255)) ON COMMIT PRESERVE ROWS"},
 		{"drop global temporary table t", true, "DROP GLOBAL TEMPORARY TABLE `t`"},
 		{"drop temporary table t", true, "DROP TEMPORARY TABLE `t`"},
+		// for create external table
+		{"create external table t (a int, b varchar(255)) location = 's3://bucket/path/*.csv' format = 'csv'", true, "CREATE EXTERNAL TABLE `t` (`a` INT,`b` VARCHAR(255)) LOCATION = 's3://bucket/path/*.csv' FORMAT = 'csv'"},
+		{"create external table if not exists t (a int) location 'gs://bucket/t.parquet', format 'parquet'", true, "CREATE EXTERNAL TABLE IF NOT EXISTS `t` (`a` INT) LOCATION = 'gs://bucket/t.parquet' FORMAT = 'parquet'"},
+		{"create external table t (a int) location = 's3://bucket/path' format = 'csv' partition by hash(a) partitions 2", false, ""},
+		{"create external temporary table t (a int)", false, ""},
+		{"create table external (external int)", true, "CREATE TABLE `external` (`external` INT)"},
+		// for create server
+		{"create server s1 foreign data wrapper mysql options (host '127.0.0.1', port 3306, user 'root', password 'pwd', database 'test')", true, "CREATE SERVER `s1` FOREIGN DATA WRAPPER `mysql` OPTIONS (HOST '127.0.0.1', PORT 3306, USER 'root', PASSWORD 'pwd', DATABASE 'test')"},
+		{"create server if not exists s1 foreign data wrapper MySQL options (socket '/tmp/mysql.sock', owner 'admin')", true, "CREATE SERVER IF NOT EXISTS `s1` FOREIGN DATA WRAPPER `mysql` OPTIONS (SOCKET '/tmp/mysql.sock', OWNER 'admin')"},
+		{"create server s1 foreign data wrapper mysql options (host 'h', unknown 'x')", false, ""},
+		{"create server s1 foreign data wrapper mysql options (host 3306)", false, ""},
+		{"create server s1 foreign data wrapper mysql", false, ""},
+		{"drop server s1", true, "DROP SERVER `s1`"},
+		{"drop server if exists s1", true, "DROP SERVER IF EXISTS `s1`"},
+		{"create table server (server int, wrapper int, options int)", true, "CREATE TABLE `server` (`server` INT,`wrapper` INT,`options` INT)"},
+		{"create table t (a int) engine = federated connection = 's1/t'", true, "CREATE TABLE `t` (`a` INT) ENGINE = federated CONNECTION = 's1/t'"},
+		// for create trigger
+		{"create trigger tr before insert on t for each row set new.a = new.a + 1", true, "CREATE TRIGGER `tr` BEFORE INSERT ON `t` FOR EACH ROW SET @@SESSION.`new.a`=`new`.`a`+1"},
+		{"create trigger if not exists test.tr after insert on test.t for each row insert into log values (new.a, 'x')", true, "CREATE TRIGGER IF NOT EXISTS `test`.`tr` AFTER INSERT ON `test`.`t` FOR EACH ROW INSERT INTO `log` VALUES (`new`.`a`,_UTF8MB4'x')"},
+		{"create trigger tr before update on t for each row set new.b = concat(new.a, 'x')", true, "CREATE TRIGGER `tr` BEFORE UPDATE ON `t` FOR EACH ROW SET @@SESSION.`new.b`=CONCAT(`new`.`a`, _UTF8MB4'x')"},
+		{"create trigger tr after delete on t for each row delete from t2 where id = old.id", true, "CREATE TRIGGER `tr` AFTER DELETE ON `t` FOR EACH ROW DELETE FROM `t2` WHERE `id`=`old`.`id`"},
+		{"create trigger tr before insert on t set new.a = 1", false, ""},
+		{"create trigger tr insert on t for each row set new.a = 1", false, ""},
+		{"drop trigger tr", true, "DROP TRIGGER `tr`"},
+		{"drop trigger if exists test.tr", true, "DROP TRIGGER IF EXISTS `test`.`tr`"},
+		{"create table before (each int)", true, "CREATE TABLE `before` (`each` INT)"},
+		// for create event
+		{"create event ev on schedule every 1 hour do insert into t values (1)", true, "CREATE EVENT `ev` ON SCHEDULE EVERY 1 HOUR DO INSERT INTO `t` VALUES (1)"},
+		{"create event if not exists test.ev on schedule at '2024-01-01 00:00:00' + interval 1 day on completion preserve disable comment 'x' do delete from t", true, "CREATE EVENT IF NOT EXISTS `test`.`ev` ON SCHEDULE AT DATE_ADD(_UTF8MB4'2024-01-01 00:00:00', INTERVAL 1 DAY) ON COMPLETION PRESERVE DISABLE COMMENT 'x' DO DELETE FROM `t`"},
+		{"create event ev on schedule every 10 minute starts now() ends now() + interval 1 day on completion not preserve enable do set @a = 1", true, "CREATE EVENT `ev` ON SCHEDULE EVERY 10 MINUTE STARTS NOW() ENDS DATE_ADD(NOW(), INTERVAL 1 DAY) DO SET @`a`=1"},
+		{"create event ev on schedule every 1 do set @a = 1", false, ""},
+		{"create event ev do set @a = 1", false, ""},
+		{"drop event ev", true, "DROP EVENT `ev`"},
+		{"drop event if exists test.ev", true, "DROP EVENT IF EXISTS `test`.`ev`"},
+		{"create table at (every int, starts int, ends int, completion int)", true, "CREATE TABLE `at` (`every` INT,`starts` INT,`ends` INT,`completion` INT)"},
 		// test use key word as column name
 		{"CREATE TABLE foo (pump varchar(50), b int);", true, "CREATE TABLE `foo` (`pump` VARCHAR(50),`b` INT)"},
 		{"CREATE TABLE foo (drainer varchar(50), b int);", true, "CREATE TABLE `foo` (`drainer` VARCHAR(50),`b` INT)"},
@@ -2788,6 +2867,19 @@ func TestDDL(t *testing.T) {
 		{"drop view xxx, yyy", true, "DROP VIEW `xxx`, `yyy`"},
 		{"drop view if exists xxx", true, "DROP VIEW IF EXISTS `xxx`"},
 		{"drop view if exists xxx, yyy", true, "DROP VIEW IF EXISTS `xxx`, `yyy`"},
+		{"drop materialized view xxx", true, "DROP MATERIALIZED VIEW `xxx`"},
+		{"drop materialized view if exists xxx, test.yyy", true, "DROP MATERIALIZED VIEW IF EXISTS `xxx`, `test`.`yyy`"},
+		{"create materialized view mv as select a, count(*) from t group by a", true, "CREATE MATERIALIZED VIEW `mv` AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`"},
+		{"create materialized view if not exists test.mv as (select * from t) union (select * from t1)", true, "CREATE MATERIALIZED VIEW IF NOT EXISTS `test`.`mv` AS (SELECT * FROM `t`) UNION (SELECT * FROM `t1`)"},
+		{"create materialized view mv (a) as select 1", false, ""},
+		{"create materialized view mv refresh every 2 hour as select * from t", true, "CREATE MATERIALIZED VIEW `mv` REFRESH EVERY 2 HOUR AS SELECT * FROM `t`"},
+		{"create materialized view mv refresh every 1 day_hour as select * from t", true, "CREATE MATERIALIZED VIEW `mv` REFRESH EVERY 1 DAY_HOUR AS SELECT * FROM `t`"},
+		{"create materialized view mv refresh every hour as select * from t", false, ""},
+		{"create materialized view mv refresh as select * from t", false, ""},
+		{"refresh materialized view mv", true, "REFRESH MATERIALIZED VIEW `mv`"},
+		{"refresh materialized view test.mv", true, "REFRESH MATERIALIZED VIEW `test`.`mv`"},
+		{"refresh materialized view", false, ""},
+		{"create table materialized (refresh int)", true, "CREATE TABLE `materialized` (`refresh` INT)"},
 		{"drop stats t", true, "DROP STATS `t`"},
 		{"drop stats t1, t2, t3", true, "DROP STATS `t1`, `t2`, `t3`"},
 		{"drop stats t global", true, "DROP STATS `t` GLOBAL"},
@@ -2962,6 +3054,17 @@ func TestDDL(t *testing.T) {
 		{"create table t (a int default (upper(substring_index(user(),'@',1))))", true, "CREATE TABLE `t` (`a` INT DEFAULT UPPER(SUBSTRING_INDEX(USER(), _UTF8MB4'@', 1)))"},
 		{"create table t (a varchar(32) default (str_to_date('1980-01-01','%Y-%m-%d')))", true, "CREATE TABLE `t` (`a` VARCHAR(32) DEFAULT STR_TO_DATE(_UTF8MB4'1980-01-01', _UTF8MB4'%Y-%m-%d'))"},
 		{"create table t (a varchar(32) default str_to_date('1980-01-01','%Y-%m-%d'))", true, "CREATE TABLE `t` (`a` VARCHAR(32) DEFAULT STR_TO_DATE(_UTF8MB4'1980-01-01', _UTF8MB4'%Y-%m-%d'))"},
+		{"create table t (d date default (current_date + interval 1 day))", true, "CREATE TABLE `t` (`d` DATE DEFAULT (DATE_ADD(CURRENT_DATE(), INTERVAL 1 DAY)))"},
+		{"create table t (a binary(16) default (uuid_to_bin(uuid())))", true, "CREATE TABLE `t` (`a` BINARY(16) DEFAULT UUID_TO_BIN(UUID()))"},
+		{"create table t (a json default (json_array()))", true, "CREATE TABLE `t` (`a` JSON DEFAULT JSON_ARRAY())"},
+		{"create table t (a int default (1 + 1))", true, "CREATE TABLE `t` (`a` INT DEFAULT (1+1))"},
+		{"create table t (a int default ((a + 1) * 2))", true, "CREATE TABLE `t` (`a` INT DEFAULT ((`a`+1)*2))"},
+		{"create table t (a varchar(10) default ('abc'))", true, "CREATE TABLE `t` (`a` VARCHAR(10) DEFAULT _UTF8MB4'abc')"},
+		{"create table t (a int default (-1))", true, "CREATE TABLE `t` (`a` INT DEFAULT -1)"},
+		{"create table t (a timestamp default (current_timestamp(3)))", true, "CREATE TABLE `t` (`a` TIMESTAMP DEFAULT CURRENT_TIMESTAMP(3))"},
+		{"create table t (a timestamp default (now()))", true, "CREATE TABLE `t` (`a` TIMESTAMP DEFAULT CURRENT_TIMESTAMP())"},
+		{"create table t (a timestamp default ((localtimestamp(3))))", true, "CREATE TABLE `t` (`a` TIMESTAMP DEFAULT CURRENT_TIMESTAMP(3))"},
+		{"create table t (a int default 1 + 1)", false, ""},
 
 		// For table option `ENCRYPTION`
 		{"create table t (a int) encryption = 'n';", true, "CREATE TABLE `t` (`a` INT) ENCRYPTION = 'n'"},
@@ -3103,8 +3206,14 @@ func TestDDL(t *testing.T) {
 		{"ALTER TABLE t ALTER COLUMN a SET DEFAULT 1+1", false, ""},
 		{"ALTER TABLE t ALTER COLUMN a SET DEFAULT (CURRENT_TIMESTAMP())", true, "ALTER TABLE `t` ALTER COLUMN `a` SET DEFAULT (CURRENT_TIMESTAMP())"},
 		{"ALTER TABLE t ALTER COLUMN a SET DEFAULT (NOW())", true, "ALTER TABLE `t` ALTER COLUMN `a` SET DEFAULT (NOW())"},
+		{"ALTER TABLE t ALTER COLUMN a SET DEFAULT ((NOW()))", true, "ALTER TABLE `t` ALTER COLUMN `a` SET DEFAULT (NOW())"},
+		{"ALTER TABLE t ALTER COLUMN a SET DEFAULT (CURDATE())", true, "ALTER TABLE `t` ALTER COLUMN `a` SET DEFAULT (CURDATE())"},
+		{"ALTER TABLE t ALTER COLUMN a SET DEFAULT (CURRENT_TIMESTAMP(3))", true, "ALTER TABLE `t` ALTER COLUMN `a` SET DEFAULT (CURRENT_TIMESTAMP(3))"},
 		{"ALTER TABLE t ALTER COLUMN a SET DEFAULT (1+1)", true, "ALTER TABLE `t` ALTER COLUMN `a` SET DEFAULT (1+1)"},
 		{"ALTER TABLE t ALTER COLUMN a SET DEFAULT (1)", true, "ALTER TABLE `t` ALTER COLUMN `a` SET DEFAULT 1"},
+		{"ALTER TABLE t ALTER COLUMN a SET DEFAULT (CURRENT_DATE + INTERVAL 1 DAY)", true, "ALTER TABLE `t` ALTER COLUMN `a` SET DEFAULT (DATE_ADD(CURRENT_DATE(), INTERVAL 1 DAY))"},
+		{"ALTER TABLE t ALTER COLUMN a SET DEFAULT (UUID())", true, "ALTER TABLE `t` ALTER COLUMN `a` SET DEFAULT (UUID())"},
+		{"ALTER TABLE t ALTER COLUMN a SET DEFAULT (NEXT VALUE FOR seq)", true, "ALTER TABLE `t` ALTER COLUMN `a` SET DEFAULT (NEXTVAL(`seq`))"},
 		{"ALTER TABLE t ALTER COLUMN a DROP DEFAULT", true, "ALTER TABLE `t` ALTER COLUMN `a` DROP DEFAULT"},
 		{"ALTER TABLE t ALTER a DROP DEFAULT", true, "ALTER TABLE `t` ALTER COLUMN `a` DROP DEFAULT"},
 		{"ALTER TABLE t ADD COLUMN a SMALLINT UNSIGNED, lock=none", true, "ALTER TABLE `t` ADD COLUMN `a` SMALLINT UNSIGNED, LOCK = NONE"},
@@ -3122,6 +3231,9 @@ func TestDDL(t *testing.T) {
 		{"ALTER TABLE t ADD FULLTEXT KEY `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
 		{"ALTER TABLE t ADD FULLTEXT `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
 		{"ALTER TABLE t ADD FULLTEXT INDEX `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
+		{"ALTER TABLE t ADD VECTOR INDEX idx ((VEC_COSINE_DISTANCE(v))) USING HNSW", true, "ALTER TABLE `t` ADD VECTOR INDEX `idx`((VEC_COSINE_DISTANCE(`v`))) USING HNSW"},
+		{"ALTER TABLE t ADD VECTOR KEY IF NOT EXISTS ((VEC_L2_DISTANCE(v)))", true, "ALTER TABLE `t` ADD VECTOR INDEX IF NOT EXISTS((VEC_L2_DISTANCE(`v`)))"},
+		{"ALTER TABLE t ADD COLUMN vector VECTOR(3)", true, "ALTER TABLE `t` ADD COLUMN `vector` VECTOR(3)"},
 		{"ALTER TABLE t ADD INDEX (a) USING BTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX(`a`) USING BTREE COMMENT 'a'"},
 		{"ALTER TABLE t ADD INDEX IF NOT EXISTS (a) USING BTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX IF NOT EXISTS(`a`) USING BTREE COMMENT 'a'"},
 		{"ALTER TABLE t ADD INDEX (a) USING RTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX(`a`) USING RTREE COMMENT 'a'"},
@@ -3283,6 +3395,7 @@ func TestDDL(t *testing.T) {
 		{"CREATE SPATIAL INDEX idx ON t (a)", true, "CREATE SPATIAL INDEX `idx` ON `t` (`a`)"},
 		{"CREATE SPATIAL INDEX IF NOT EXISTS idx ON t (a)", true, "CREATE SPATIAL INDEX IF NOT EXISTS `idx` ON `t` (`a`)"},
 		{"CREATE FULLTEXT INDEX idx ON t (a)", true, "CREATE FULLTEXT INDEX `idx` ON `t` (`a`)"},
+		{"CREATE VECTOR INDEX idx ON t ((VEC_COSINE_DISTANCE(v))) USING HNSW", true, "CREATE VECTOR INDEX `idx` ON `t` ((VEC_COSINE_DISTANCE(`v`))) USING HNSW"},
 		{"CREATE FULLTEXT INDEX IF NOT EXISTS idx ON t (a)", true, "CREATE FULLTEXT INDEX IF NOT EXISTS `idx` ON `t` (`a`)"},
 		{"CREATE FULLTEXT INDEX idx ON t (a) WITH PARSER ident", true, "CREATE FULLTEXT INDEX `idx` ON `t` (`a`) WITH PARSER `ident`"},
 		{"CREATE FULLTEXT INDEX idx ON t (a) WITH PARSER ident comment 'string'", true, "CREATE FULLTEXT INDEX `idx` ON `t` (`a`) WITH PARSER `ident` COMMENT 'string'"},
@@ -3396,6 +3509,12 @@ func TestDDL(t *testing.T) {
 		{"create table t (a int column_format default)", true, "CREATE TABLE `t` (`a` INT COLUMN_FORMAT DEFAULT)"},
 		{"create table t (a int column_format dynamic)", true, "CREATE TABLE `t` (`a` INT COLUMN_FORMAT DYNAMIC)"},
 		{"alter table t modify column a bigint column_format default", true, "ALTER TABLE `t` MODIFY COLUMN `a` BIGINT COLUMN_FORMAT DEFAULT"},
+		// for invisible column
+		{"create table t (a int, b int invisible, c int visible)", true, "CREATE TABLE `t` (`a` INT,`b` INT INVISIBLE,`c` INT VISIBLE)"},
+		{"create table t (a int not null default 1 invisible comment 'x')", true, "CREATE TABLE `t` (`a` INT NOT NULL DEFAULT 1 INVISIBLE COMMENT 'x')"},
+		{"alter table t add column b int invisible", true, "ALTER TABLE `t` ADD COLUMN `b` INT INVISIBLE"},
+		{"alter table t modify column b int visible", true, "ALTER TABLE `t` MODIFY COLUMN `b` INT VISIBLE"},
+		{"alter table t change column b c bigint invisible after a", true, "ALTER TABLE `t` CHANGE COLUMN `b` `c` BIGINT INVISIBLE AFTER `a`"},
 
 		// for recover table
 		{"recover table by job 11", true, "RECOVER TABLE BY JOB 11"},
@@ -3591,12 +3710,21 @@ func TestDDL(t *testing.T) {
 
 		{"ALTER TABLE t SET TIFLASH REPLICA 2 LOCATION LABELS 'a','b'", true, "ALTER TABLE `t` SET TIFLASH REPLICA 2 LOCATION LABELS 'a', 'b'"},
 		{"ALTER TABLE t SET TIFLASH REPLICA 0", true, "ALTER TABLE `t` SET TIFLASH REPLICA 0"},
+		{"ALTER TABLE t SET TIFLASH REPLICA 2 WAIT", true, "ALTER TABLE `t` SET TIFLASH REPLICA 2 WAIT"},
+		{"ALTER TABLE t SET TIFLASH REPLICA 2 LOCATION LABELS 'a' WAIT TIMEOUT 60", true, "ALTER TABLE `t` SET TIFLASH REPLICA 2 LOCATION LABELS 'a' WAIT TIMEOUT 60"},
+		{"ALTER TABLE t SET TIFLASH REPLICA 2 TIMEOUT 60", false, ""},
 		{"ALTER DATABASE t SET TIFLASH REPLICA 2 LOCATION LABELS 'a','b'", true, "ALTER DATABASE `t` SET TIFLASH REPLICA 2 LOCATION LABELS 'a', 'b'"},
 		{"ALTER DATABASE t SET TIFLASH REPLICA 0", true, "ALTER DATABASE `t` SET TIFLASH REPLICA 0"},
 		{"ALTER DATABASE t SET TIFLASH REPLICA 1 SET TIFLASH REPLICA 2 LOCATION LABELS 'a','b'", true, "ALTER DATABASE `t` SET TIFLASH REPLICA 1 SET TIFLASH REPLICA 2 LOCATION LABELS 'a', 'b'"},
 		{"ALTER DATABASE t SET TIFLASH REPLICA 1 SET TIFLASH REPLICA 2", true, "ALTER DATABASE `t` SET TIFLASH REPLICA 1 SET TIFLASH REPLICA 2"},
 		{"ALTER DATABASE t SET TIFLASH REPLICA 1 LOCATION LABELS 'a','b' SET TIFLASH REPLICA 2", true, "ALTER DATABASE `t` SET TIFLASH REPLICA 1 LOCATION LABELS 'a', 'b' SET TIFLASH REPLICA 2"},
 		{"ALTER DATABASE t SET TIFLASH REPLICA 1 LOCATION LABELS 'a','b' SET TIFLASH REPLICA 2 LOCATION LABELS 'a', 'b'", true, "ALTER DATABASE `t` SET TIFLASH REPLICA 1 LOCATION LABELS 'a', 'b' SET TIFLASH REPLICA 2 LOCATION LABELS 'a', 'b'"},
+		{"ALTER DATABASE t SET READ ONLY = 1", true, "ALTER DATABASE `t` SET READ ONLY = 1"},
+		{"ALTER DATABASE t SET READ ONLY 0", true, "ALTER DATABASE `t` SET READ ONLY = 0"},
+		{"ALTER DATABASE t SET READ ONLY = DEFAULT", true, "ALTER DATABASE `t` SET READ ONLY = 0"},
+		{"ALTER DATABASE t CHARACTER SET = utf8mb4 SET READ ONLY = 1", true, "ALTER DATABASE `t` CHARACTER SET = utf8mb4 SET READ ONLY = 1"},
+		{"ALTER DATABASE t SET READ ONLY = 'a'", false, ""},
+		{"ALTER DATABASE t SET READ ONLY = 2", false, ""},
 
 		// for issue 537
 		{"CREATE TABLE IF NOT EXISTS table_ident (a SQL_TSI_YEAR(4), b SQL_TSI_YEAR);", true, "CREATE TABLE IF NOT EXISTS `table_ident` (`a` YEAR(4),`b` YEAR)"},
@@ -4800,6 +4928,8 @@ func TestType(t *testing.T) {
 
 		// for json type
 		{`create table t (a JSON);`, true, "CREATE TABLE `t` (`a` JSON)"},
+		{`create table t (a VECTOR, b VECTOR(3), vector int);`, true, "CREATE TABLE `t` (`a` VECTOR,`b` VECTOR(3),`vector` INT)"},
+		{`create table t (a VECTOR(3), VECTOR INDEX idx ((VEC_COSINE_DISTANCE(a))) USING HNSW);`, true, "CREATE TABLE `t` (`a` VECTOR(3),VECTOR INDEX `idx`((VEC_COSINE_DISTANCE(`a`))) USING HNSW)"},
 	}
 	RunTest(t, table, false)
 }
@@ -4914,6 +5044,14 @@ func TestPrivilege(t *testing.T) {
 		{"/* rds internal mark */ GRANT SELECT, INSERT, UPDATE, DELETE, CREATE, DROP, REFERENCES, RELOAD, PROCESS, INDEX, ALTER, CREATE TEMPORARY TABLES, LOCK TABLES,      EXECUTE, REPLICATION SLAVE, REPLICATION CLIENT, CREATE VIEW, SHOW VIEW, CREATE ROUTINE, ALTER ROUTINE, CREATE USER, EVENT,      TRIGGER on *.* to 'root2'@'%' identified by password '*sdsadsdsadssadsadsadsadsada' with grant option", true, "GRANT SELECT, INSERT, UPDATE, DELETE, CREATE, DROP, REFERENCES, RELOAD, PROCESS, INDEX, ALTER, CREATE TEMPORARY TABLES, LOCK TABLES, EXECUTE, REPLICATION SLAVE, REPLICATION CLIENT, CREATE VIEW, SHOW VIEW, CREATE ROUTINE, ALTER ROUTINE, CREATE USER, EVENT, TRIGGER ON *.* TO `root2`@`%` IDENTIFIED WITH 'mysql_native_password' AS '*sdsadsdsadssadsadsadsadsada' WITH GRANT OPTION"},
 		{"GRANT 'role1', 'role2' TO 'user1'@'LOCalhost', 'user2'@'LOcalhost';", true, "GRANT `role1`@`%`, `role2`@`%` TO `user1`@`localhost`, `user2`@`localhost`"},
 		{"GRANT 'u1' TO 'u1';", true, "GRANT `u1`@`%` TO `u1`@`%`"},
+		{"GRANT 'r1', 'r2' TO 'u1'@'localhost' WITH ADMIN OPTION;", true, "GRANT `r1`@`%`, `r2`@`%` TO `u1`@`localhost` WITH ADMIN OPTION"},
+
+		// for masking policy
+		{"CREATE MASKING POLICY p1 ON t (email) USING mask_email(email) TO r1", true, "CREATE MASKING POLICY `p1` ON `t` (`email`) USING MASK_EMAIL(`email`) TO `r1`@`%`"},
+		{"CREATE MASKING POLICY IF NOT EXISTS p1 ON test.t (phone) USING mask_partial(phone, 3, 4, '*') TO 'r1'@'localhost', r2", true, "CREATE MASKING POLICY IF NOT EXISTS `p1` ON `test`.`t` (`phone`) USING MASK_PARTIAL(`phone`, 3, 4, _UTF8MB4'*') TO `r1`@`localhost`, `r2`@`%`"},
+		{"CREATE MASKING POLICY p1 ON t (email) USING mask_email(email)", false, ""},
+		{"DROP MASKING POLICY p1", true, "DROP MASKING POLICY `p1`"},
+		{"DROP MASKING POLICY IF EXISTS p1", true, "DROP MASKING POLICY IF EXISTS `p1`"},
 		{"GRANT 'app_read'@'%','app_write'@'%' TO 'rw_user1'@'localhost'", true, "GRANT `app_read`@`%`, `app_write`@`%` TO `rw_user1`@`localhost`"},
 		{"GRANT 'app_developer' TO 'dev1'@'localhost';", true, "GRANT `app_developer`@`%` TO `dev1`@`localhost`"},
 		{"GRANT SHUTDOWN ON *.* TO 'dev1'@'localhost';", true, "GRANT SHUTDOWN ON *.* TO `dev1`@`localhost`"},
@@ -6856,6 +6994,22 @@ func TestIndexAdviseStmt(t *testing.T) {
 	RunTest(t, table, false)
 }
 
+func TestRecommendIndexStmt(t *testing.T) {
+	table := []testCase{
+		{"RECOMMEND INDEX RUN", true, "RECOMMEND INDEX RUN"},
+		{"recommend index run for 'select a from t where b = 1'", true, "RECOMMEND INDEX RUN FOR 'select a from t where b = 1'"},
+		{"RECOMMEND INDEX RUN WITH max_num_index = 3", true, "RECOMMEND INDEX RUN WITH max_num_index = 3"},
+		{"RECOMMEND INDEX RUN FOR 'select * from t' WITH MAX_NUM_INDEX = 3, max_index_columns = 2", true, "RECOMMEND INDEX RUN FOR 'select * from t' WITH max_num_index = 3, max_index_columns = 2"},
+		{"RECOMMEND INDEX RUN WITH max_num_index = -1", false, ""},
+		{"RECOMMEND INDEX RUN WITH max_num_index", false, ""},
+		{"RECOMMEND INDEX", false, ""},
+		{"RECOMMEND INDEX RUN FOR select * from t", false, ""},
+		{"create table recommend (recommend int)", true, "CREATE TABLE `recommend` (`recommend` INT)"},
+	}
+
+	RunTest(t, table, false)
+}
+
 // For BRIE
 func TestBRIE(t *testing.T) {
 	table := []testCase{
@@ -7436,6 +7590,12 @@ func TestIntervalPartition(t *testing.T) {
 		{`ALTER TABLE t REORGANIZE MAXVALUE PARTITION INTO LAST PARTITION LESS THAN (1000)`, false, ""},
 		{`ALTER TABLE t split MAXVALUE PARTITION LESS THAN (1000)`, true, "ALTER TABLE `t` SPLIT MAXVALUE PARTITION LESS THAN (1000)"},
 		{`ALTER TABLE t merge first PARTITION LESS THAN (1000)`, true, "ALTER TABLE `t` MERGE FIRST PARTITION LESS THAN (1000)"},
+		{`ALTER TABLE t split PARTITION p0 INTO (PARTITION p0a VALUES LESS THAN (10), PARTITION p0b VALUES LESS THAN (20))`, true, "ALTER TABLE `t` SPLIT PARTITION `p0` INTO (PARTITION `p0a` VALUES LESS THAN (10), PARTITION `p0b` VALUES LESS THAN (20))"},
+		{`ALTER TABLE t split PARTITION p0 INTO (PARTITION p0a VALUES LESS THAN (10), PARTITION p0b)`, true, "ALTER TABLE `t` SPLIT PARTITION `p0` INTO (PARTITION `p0a` VALUES LESS THAN (10), PARTITION `p0b`)"},
+		{`ALTER TABLE t split PARTITION p0, p1 INTO (PARTITION p0a, PARTITION p0b)`, false, ""},
+		{`ALTER TABLE t merge PARTITIONS p0, p1, p2 INTO PARTITION p012`, true, "ALTER TABLE `t` MERGE PARTITIONS `p0`,`p1`,`p2` INTO PARTITION `p012`"},
+		{`ALTER TABLE t merge PARTITIONS p0 INTO PARTITION p`, true, "ALTER TABLE `t` MERGE PARTITIONS `p0` INTO PARTITION `p`"},
+		{`ALTER TABLE t merge PARTITIONS p0, p1 INTO (PARTITION p)`, false, ""},
 		{`ALTER TABLE t first PARTITION LESS THAN (1000)`, true, "ALTER TABLE `t` FIRST PARTITION LESS THAN (1000)"},
 	}
 
@@ -7480,6 +7640,18 @@ func TestTTLTableOption(t *testing.T) {
 	RunTest(t, table, false)
 }
 
+func TestIntervalPartitionPolicy(t *testing.T) {
+	table := []testCase{
+		{"create table t (a date) interval_precreate = 3 interval_retention 30 partition by range columns (a) interval (1 day) first partition less than ('2024-01-01') last partition less than ('2024-01-10')", true, "CREATE TABLE `t` (`a` DATE) INTERVAL_PRECREATE = 3 INTERVAL_RETENTION = 30 PARTITION BY RANGE COLUMNS (`a`) INTERVAL (1 DAY) FIRST PARTITION LESS THAN (_UTF8MB4'2024-01-01') LAST PARTITION LESS THAN (_UTF8MB4'2024-01-10')"},
+		{"create table t (a date) /*T![interval_partition] INTERVAL_PRECREATE = 3 */ /*T![interval_partition] INTERVAL_RETENTION = 0 */", true, "CREATE TABLE `t` (`a` DATE) INTERVAL_PRECREATE = 3 INTERVAL_RETENTION = 0"},
+		{"alter table t interval_precreate 7, interval_retention = 90", true, "ALTER TABLE `t` INTERVAL_PRECREATE = 7, INTERVAL_RETENTION = 90"},
+		{"alter table t interval_precreate = -1", false, ""},
+		{"alter table t interval_retention = '1d'", false, ""},
+	}
+
+	RunTest(t, table, false)
+}
+
 func TestIssue45898(t *testing.T) {
 	p := parser.New()
 	p.ParseSQL("a.")
@@ -32,16 +32,19 @@ const (
 	FeatureIDTTL = "ttl"
 	// FeatureIDResourceGroup is the `resource group` feature.
 	FeatureIDResourceGroup = "resource_group"
+	// FeatureIDIntervalPartition is the automatic maintenance of INTERVAL partitions.
+	FeatureIDIntervalPartition = "interval_partition"
 )
 
 var featureIDs = map[string]struct{}{
-	FeatureIDAutoRandom:     {},
-	FeatureIDAutoIDCache:    {},
-	FeatureIDAutoRandomBase: {},
-	FeatureIDClusteredIndex: {},
-	FeatureIDForceAutoInc:   {},
-	FeatureIDPlacement:      {},
-	FeatureIDTTL:            {},
+	FeatureIDAutoRandom:        {},
+	FeatureIDAutoIDCache:       {},
+	FeatureIDAutoRandomBase:    {},
+	FeatureIDClusteredIndex:    {},
+	FeatureIDForceAutoInc:      {},
+	FeatureIDPlacement:         {},
+	FeatureIDTTL:               {},
+	FeatureIDIntervalPartition: {},
 }
 
 // CanParseFeature is used to check if a feature can be parsed.
@@ -41,33 +41,34 @@ func IsTypeChar(tp byte) bool {
 }
 
 var type2Str = map[byte]string{
-	mysql.TypeBit:         "bit",
-	mysql.TypeBlob:        "text",
-	mysql.TypeDate:        "date",
-	mysql.TypeDatetime:    "datetime",
-	mysql.TypeUnspecified: "unspecified",
-	mysql.TypeNewDecimal:  "decimal",
-	mysql.TypeDouble:      "double",
-	mysql.TypeEnum:        "enum",
-	mysql.TypeFloat:       "float",
-	mysql.TypeGeometry:    "geometry",
-	mysql.TypeInt24:       "mediumint",
-	mysql.TypeJSON:        "json",
-	mysql.TypeLong:        "int",
-	mysql.TypeLonglong:    "bigint",
-	mysql.TypeLongBlob:    "longtext",
-	mysql.TypeMediumBlob:  "mediumtext",
-	mysql.TypeNull:        "null",
-	mysql.TypeSet:         "set",
-	mysql.TypeShort:       "smallint",
-	mysql.TypeString:      "char",
-	mysql.TypeDuration:    "time",
-	mysql.TypeTimestamp:   "timestamp",
-	mysql.TypeTiny:        "tinyint",
-	mysql.TypeTinyBlob:    "tinytext",
-	mysql.TypeVarchar:     "varchar",
-	mysql.TypeVarString:   "var_string",
-	mysql.TypeYear:        "year",
+	mysql.TypeBit:               "bit",
+	mysql.TypeBlob:              "text",
+	mysql.TypeDate:              "date",
+	mysql.TypeDatetime:          "datetime",
+	mysql.TypeUnspecified:       "unspecified",
+	mysql.TypeNewDecimal:        "decimal",
+	mysql.TypeDouble:            "double",
+	mysql.TypeEnum:              "enum",
+	mysql.TypeFloat:             "float",
+	mysql.TypeGeometry:          "geometry",
+	mysql.TypeInt24:             "mediumint",
+	mysql.TypeJSON:              "json",
+	mysql.TypeLong:              "int",
+	mysql.TypeLonglong:          "bigint",
+	mysql.TypeLongBlob:          "longtext",
+	mysql.TypeMediumBlob:        "mediumtext",
+	mysql.TypeNull:              "null",
+	mysql.TypeSet:               "set",
+	mysql.TypeShort:             "smallint",
+	mysql.TypeString:            "char",
+	mysql.TypeDuration:          "time",
+	mysql.TypeTimestamp:         "timestamp",
+	mysql.TypeTiny:              "tinyint",
+	mysql.TypeTinyBlob:          "tinytext",
+	mysql.TypeVarchar:           "varchar",
+	mysql.TypeVarString:         "var_string",
+	mysql.TypeYear:              "year",
+	mysql.TypeTiDBVectorFloat32: "vector",
 }
 
 var str2Type = map[string]byte{
@@ -98,6 +99,7 @@ var str2Type = map[string]byte{
 	"varchar":     mysql.TypeVarchar,
 	"var_string":  mysql.TypeVarString,
 	"year":        mysql.TypeYear,
+	"vector":      mysql.TypeTiDBVectorFloat32,
 }
 
 // TypeStr converts tp to a string.
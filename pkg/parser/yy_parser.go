@@ -18,12 +18,14 @@ import (
 	"math"
 	"regexp"
 	"strconv"
+	"strings"
 	"unicode"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/parser/types"
@@ -348,6 +350,67 @@ func getInt64FromNUM(num interface{}) (val int64, errMsg string) {
 	}
 }
 
+// newDefaultValueExpr builds the default value of a column from the expression in parentheses.
+// The literals and the function calls which can be written without parentheses are kept the same
+// as the ones without parentheses, and the other expressions are kept in parentheses to be restored as they are.
+// If keepFuncName is false, the synonyms of CURRENT_TIMESTAMP and CURRENT_DATE are renamed as the ones without
+// parentheses, otherwise they are kept as expressions, e.g. `ALTER COLUMN SET DEFAULT (NOW())` is restored as it is.
+func newDefaultValueExpr(expr ast.ExprNode, keepFuncName bool) ast.ExprNode {
+	for {
+		p, ok := expr.(*ast.ParenthesesExpr)
+		if !ok {
+			break
+		}
+		expr = p.Expr
+	}
+	switch x := expr.(type) {
+	case *ast.FuncCallExpr:
+		switch x.FnName.L {
+		case ast.CurrentTimestamp, ast.Now, ast.LocalTime, ast.LocalTimestamp:
+			if !keepFuncName {
+				x.FnName = model.NewCIStr("CURRENT_TIMESTAMP")
+			}
+		case ast.CurrentDate, ast.Curdate:
+			if !keepFuncName {
+				x.FnName = model.NewCIStr("CURRENT_DATE")
+			}
+		}
+		switch {
+		case x.FnName.O == "CURRENT_TIMESTAMP", x.FnName.O == "CURRENT_DATE", x.FnName.L == ast.NextVal:
+		case x.Schema.L != "" || !isIdentifierFuncName(x.FnName.O):
+			return &ast.ParenthesesExpr{Expr: expr}
+		}
+		if len(x.Args) == 0 {
+			x.Args = nil
+		}
+		return x
+	case ast.ValueExpr:
+		return x
+	case *ast.UnaryOperationExpr:
+		if _, ok := x.V.(ast.ValueExpr); ok {
+			return x
+		}
+	}
+	return &ast.ParenthesesExpr{Expr: expr}
+}
+
+// isIdentifierFuncName checks whether the function name is scanned as an identifier,
+// which is the function name of the default value without parentheses.
+func isIdentifierFuncName(name string) bool {
+	upper := strings.ToUpper(name)
+	if upper == "REPLACE" {
+		return true
+	}
+	if _, ok := btFuncTokenMap[upper]; ok {
+		return false
+	}
+	if _, ok := windowFuncTokenMap[upper]; ok {
+		return false
+	}
+	_, ok := tokenMap[upper]
+	return !ok
+}
+
 func isRevokeAllGrant(roleOrPrivList []*ast.RoleOrPriv) bool {
 	if len(roleOrPrivList) != 2 {
 		return false
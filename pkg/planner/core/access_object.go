@@ -300,6 +300,19 @@ func (p *PhysicalMemTable) AccessObject() AccessObject {
 	}
 }
 
+// AccessObject implements dataAccesser interface.
+func (p *PhysicalVectorIndexReader) AccessObject() AccessObject {
+	index := IndexAccess{Name: p.Index.Name.O}
+	for _, idxCol := range p.Index.Columns {
+		index.Cols = append(index.Cols, idxCol.Name.O)
+	}
+	return &ScanAccessObject{
+		Database: p.DBName.O,
+		Table:    p.Table.Name.O,
+		Indexes:  []IndexAccess{index},
+	}
+}
+
 // AccessObject implements dataAccesser interface.
 func (p *PointGetPlan) AccessObject() AccessObject {
 	res := &ScanAccessObject{
@@ -95,3 +95,29 @@ func TestInvisibleIndex(t *testing.T) {
 			`IndexReader_7 10000.00 root  index:IndexFullScan_6`,
 			`└─IndexFullScan_6 10000.00 cop[tikv] table:t1, index:a(a) keep order:false, stats:pseudo`))
 }
+
+func TestHypoIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3), (4, 4), (5, 5), (6, 6), (7, 7), (8, 8), (9, 9), (10, 10)")
+	tk.MustExec("analyze table t")
+	tk.MustExec("create index hypo_a type hypo on t (a)")
+	tk.MustExec("create index if not exists hypo_a type hypo on t (a)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1105 conflict hypo index name hypo_a"))
+	tk.MustGetErrMsg("create index hypo_expr type hypo on t ((a + 1))",
+		"[ddl:8200]hypo index on expressions is not supported")
+
+	// The row count of the hypo index is estimated by the statistics of its columns.
+	tk.MustQuery("explain format = 'brief' select a from t where a = 1").Check(testkit.Rows(
+		"IndexReader 1.00 root  index:IndexRangeScan",
+		"└─IndexRangeScan 1.00 cop[tikv] table:t, index:hypo_a(a) range:[1,1], keep order:false"))
+	// EXPLAIN ANALYZE runs the plan, so the hypo index can't be used.
+	tk.MustQuery("explain analyze format = 'brief' select a from t where a = 1").CheckNotContain("hypo_a")
+
+	// The hypo index is ignored if it doesn't match the table anymore.
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t (b int, a int)")
+	tk.MustQuery("explain format = 'brief' select a from t where a = 1").CheckNotContain("hypo_a")
+}
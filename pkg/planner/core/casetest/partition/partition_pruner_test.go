@@ -212,3 +212,78 @@ func TestListColumnsPartitionPruner(t *testing.T) {
 	}
 	require.True(t, valid)
 }
+
+func TestPartitionPrunerForPartitionExpr(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_partition_prune_mode = 'dynamic'")
+	tk.MustExec("set @@session.tidb_enable_list_partition = ON")
+	tk.MustExec(`create table trange (a int, b int, dt date) partition by range (to_days(dt)) (
+		partition p0 values less than (to_days('2020-01-01')),
+		partition p1 values less than (to_days('2021-01-01')),
+		partition p2 values less than (to_days('2022-01-01')),
+		partition p3 values less than (maxvalue))`)
+	tk.MustExec(`create table trange2 (a int, b int, dt date) partition by range (a + b) (
+		partition p0 values less than (10),
+		partition p1 values less than (20),
+		partition p2 values less than (30),
+		partition p3 values less than (maxvalue))`)
+	tk.MustExec(`create table tlist (a int, b int, dt date) partition by list (year(dt)) (
+		partition p0 values in (2019, 2020),
+		partition p1 values in (2021),
+		partition p2 values in (2022, 2023),
+		partition p3 values in (null))`)
+	tk.MustExec(`create table tlist2 (a int, b int, dt date) partition by list (a + b) (
+		partition p0 values in (null, 1, 2, 3),
+		partition p1 values in (4, 5, 6),
+		partition p2 values in (7, 8, 9),
+		partition p3 values in (10, 11, 12))`)
+	tk.MustExec(`create table tlist3 (a int, b int, dt date) partition by list (a) (
+		partition p0 values in (null, 1, 2, 3),
+		partition p1 values in (4, 5, 6),
+		partition p2 values in (7, 8, 9),
+		partition p3 values in (10, 11, 12))`)
+	tk.MustExec("create table tnormal (a int, b int, dt date)")
+	values := "(1, 2, '2019-03-01'), (2, 2, '2020-05-01'), (3, 3, '2021-02-01'), (4, 4, '2021-12-31'), " +
+		"(5, 3, '2022-06-01'), (6, 5, '2023-01-01'), (7, 5, null), (null, 1, '2020-01-01')"
+	for _, tbl := range []string{"trange", "trange2", "tlist", "tlist2", "tlist3", "tnormal"} {
+		tk.MustExec("insert into " + tbl + " values " + values)
+	}
+
+	cases := []struct {
+		tbl    string
+		cond   string
+		pruner string
+	}{
+		// Conditions on the partition expression of range partitioning.
+		{"trange", "to_days(dt) between to_days('2020-06-01') and to_days('2021-06-01')", "trange: p1,p2"},
+		{"trange", "to_days(dt) in (to_days('2019-01-01'), to_days('2023-01-01'))", "trange: p0,p3"},
+		{"trange", "to_days(dt) < to_days('2020-01-01') or to_days(dt) >= to_days('2022-01-01')", "trange: p0,p3"},
+		{"trange2", "a + b between 5 and 15", "trange2: p0,p1"},
+		{"trange2", "a + b < 10 or a + b > 30", "trange2: p0,p3"},
+		{"trange2", "a + b in (11, 25)", "trange2: p1,p2"},
+		{"trange2", "a + b > 10 and b > 0", "trange2: p1,p2,p3"},
+		// Conditions on the partition expression of list partitioning.
+		{"tlist", "year(dt) in (2019, 2021)", "tlist: p0,p1"},
+		{"tlist", "year(dt) between 2021 and 2022", "tlist: p1,p2"},
+		{"tlist", "year(dt) = 2020 or year(dt) is null", "tlist: p0,p3"},
+		{"tlist", "year(dt) > 2021 and year(dt) < 2023", "tlist: p2"},
+		{"tlist2", "a + b in (2, 8)", "tlist2: p0,p2"},
+		{"tlist2", "a + b >= 8 and a + b < 10", "tlist2: p2"},
+		{"tlist2", "a + b > 10 or a + b < 2", "tlist2: p0,p3"},
+		{"tlist2", "a + b is null", "tlist2: p0"},
+		// Ranges on the column of monotone list partition expression.
+		{"tlist", "dt between '2021-03-01' and '2022-03-01'", "tlist: p1,p2"},
+		{"tlist", "dt < '2020-01-01'", "tlist: p0"},
+		{"tlist3", "a < 3 or a > 10", "tlist3: p0,p3"},
+		{"tlist3", "a between 4 and 7", "tlist3: p1,p2"},
+		{"tlist3", "a >= 5 and a <= 7", "tlist3: p1,p2"},
+	}
+	for _, ca := range cases {
+		sql := fmt.Sprintf("select * from %s where %s", ca.tbl, ca.cond)
+		plan := testdata.ConvertRowsToStrings(tk.MustQuery("explain format = 'brief' " + sql).Rows())
+		checkPrunePartitionInfo(t, sql, ca.pruner, plan)
+		tk.MustQuery(sql).Sort().Check(tk.MustQuery(fmt.Sprintf("select * from tnormal where %s", ca.cond)).Sort().Rows())
+	}
+}
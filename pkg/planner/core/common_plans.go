@@ -87,6 +87,38 @@ type CheckTable struct {
 	CheckIndex         bool
 }
 
+// CheckTableOnline is used for submitting background check table jobs, built from the 'admin check table ... online' statement.
+type CheckTableOnline struct {
+	baseSchemaProducer
+
+	Tables []*ast.TableName
+}
+
+// CancelCheckTableJobs represents a cancel check table jobs plan.
+type CancelCheckTableJobs struct {
+	baseSchemaProducer
+
+	JobIDs []int64
+}
+
+// DiffSchema is used for comparing the schemas at two timestamps, built from the 'admin diff schema' statement.
+type DiffSchema struct {
+	baseSchemaProducer
+
+	FromTS  ast.ExprNode
+	ToTS    ast.ExprNode
+	DBName  model.CIStr
+	TblName model.CIStr
+}
+
+// CompareOptimizerTrace is used for comparing the persisted optimizer traces of two plan digests, built from the
+// 'admin compare optimizer trace' statement.
+type CompareOptimizerTrace struct {
+	baseSchemaProducer
+
+	PlanDigests []string
+}
+
 // RecoverIndex is used for backfilling corrupted index data.
 type RecoverIndex struct {
 	baseSchemaProducer
@@ -151,6 +183,11 @@ type ReloadOptRuleBlacklist struct {
 	baseSchemaProducer
 }
 
+// ReloadMetricTables reloads the user-defined metric tables from metric_table_defs table.
+type ReloadMetricTables struct {
+	baseSchemaProducer
+}
+
 // AdminPluginsAction indicate action will be taken on plugins.
 type AdminPluginsAction int
 
@@ -357,6 +394,9 @@ type Insert struct {
 
 	GenCols InsertGeneratedColumns
 
+	// BeforeTriggers are the assignments to the `NEW` row of the BEFORE INSERT triggers.
+	BeforeTriggers []*expression.Assignment
+
 	SelectPlan PhysicalPlan
 
 	IsReplace bool
@@ -403,6 +443,9 @@ func (p *Insert) MemoryUsage() (sum int64) {
 	for _, as := range p.OnDuplicate {
 		sum += as.MemoryUsage()
 	}
+	for _, as := range p.BeforeTriggers {
+		sum += as.MemoryUsage()
+	}
 	for _, name := range p.names4OnDuplicate {
 		sum += name.MemoryUsage()
 	}
@@ -647,6 +690,11 @@ type SplitRegion struct {
 	Upper          []types.Datum
 	Num            int
 	ValueLists     [][]types.Datum
+	// EncodedValues are the encoded index values or common handles to split at, which are computed from the
+	// statistics by SPLIT ... BY QUANTILES.
+	EncodedValues [][]byte
+	// Scatter means to wait for the new regions to be scattered no matter whether tidb_wait_split_region_finish is set.
+	Scatter bool
 }
 
 // SplitRegionStatus represents a split regions status plan.
@@ -673,6 +721,30 @@ type DDL struct {
 	Statement ast.DDLNode
 }
 
+// CreateTableAsSelect represents a CREATE TABLE ... SELECT plan.
+// The columns of Stmt include the ones derived from the select fields.
+type CreateTableAsSelect struct {
+	baseSchemaProducer
+
+	Stmt       *ast.CreateTableStmt
+	SelectPlan PhysicalPlan
+	// Columns are the columns which the selected rows are imported into.
+	Columns []*ast.ColumnName
+	// MaterializedView is set when the table is created for CREATE MATERIALIZED VIEW.
+	MaterializedView *model.MaterializedViewInfo
+}
+
+// RefreshMaterializedView represents a REFRESH MATERIALIZED VIEW plan.
+type RefreshMaterializedView struct {
+	baseSchemaProducer
+
+	Table *ast.TableName
+	// Delete deletes the data of the view, and Insert inserts the data selected with the
+	// definition of the view.
+	Delete *Delete
+	Insert *Insert
+}
+
 // SelectInto represents a select-into plan.
 type SelectInto struct {
 	baseSchemaProducer
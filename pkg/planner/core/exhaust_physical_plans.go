@@ -1321,6 +1321,7 @@ func (p *LogicalJoin) constructInnerIndexScanTask(
 	// the estimated row count of the IndexScan should be no larger than (total row count / NDV of join key columns).
 	// We can calculate the lower bound of the NDV therefore we can get an upper bound of the row count here.
 	rowCountUpperBound := -1.0
+	ds.SCtx().GetSessionVars().StmtCtx.RecordFixControl(fixcontrol.Fix44855)
 	fixControlOK := fixcontrol.GetBoolWithDefault(ds.SCtx().GetSessionVars().GetOptimizerFixControlMap(), fixcontrol.Fix44855, false)
 	if fixControlOK && ds.tableStats != nil {
 		usedColIDs := make([]int64, 0)
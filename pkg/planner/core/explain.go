@@ -358,6 +358,12 @@ func (p *PhysicalUnionScan) ExplainInfo() string {
 // ExplainInfo implements Plan interface.
 func (p *PhysicalSelection) ExplainInfo() string {
 	exprStr := string(expression.SortedExplainExpressionList(p.SCtx().GetExprCtx(), p.Conditions))
+	if p.hasRFConditions {
+		if len(exprStr) > 0 {
+			exprStr += ", "
+		}
+		exprStr += "with runtime filters"
+	}
 	if p.TiFlashFineGrainedShuffleStreamCount > 0 {
 		exprStr += fmt.Sprintf(", stream_count: %d", p.TiFlashFineGrainedShuffleStreamCount)
 	}
@@ -1117,6 +1123,25 @@ func (p *PhysicalMemTable) ExplainInfo() string {
 	return accessObject + ", " + operatorInfo
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalVectorIndexReader) ExplainInfo() string {
+	return p.AccessObject().String() + ", " + p.OperatorInfo(false)
+}
+
+// ExplainNormalizedInfo implements Plan interface.
+func (p *PhysicalVectorIndexReader) ExplainNormalizedInfo() string {
+	return p.AccessObject().NormalizedString() + ", " + p.OperatorInfo(true)
+}
+
+// OperatorInfo implements dataAccesser interface.
+func (p *PhysicalVectorIndexReader) OperatorInfo(normalized bool) string {
+	query := "?"
+	if !normalized {
+		query = p.Query.String()
+	}
+	return fmt.Sprintf("metric:%s, query:%s, k:%d", p.Index.VectorInfo.DistanceMetric, query, p.K)
+}
+
 // OperatorInfo implements dataAccesser interface.
 func (p *PhysicalMemTable) OperatorInfo(_ bool) string {
 	if p.Extractor != nil {
@@ -1466,6 +1466,10 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		er.binaryOpToExpression(v)
 	case *ast.BetweenExpr:
 		er.betweenToExpression(v)
+	case *ast.MatchAgainst:
+		withPlanCtx(func(planCtx *exprRewriterPlanCtx) {
+			er.matchAgainstToExpression(planCtx, v)
+		})
 	case *ast.CaseExpr:
 		if _, ok := expression.TryFoldFunctions["case"]; ok {
 			er.tryFoldCounter--
@@ -2148,6 +2152,73 @@ func (er *expressionRewriter) wrapExpWithCast() (expr, lexp, rexp expression.Exp
 	return
 }
 
+// matchAgainstToExpression rewrites MATCH (col1, col2, ...) AGAINST (search_str [mode]) to
+// match_against(search_str, mode, parser, col1, col2, ...). The columns must be the same as the
+// columns of a fulltext index, whose parser is used to tokenize the values.
+func (er *expressionRewriter) matchAgainstToExpression(planCtx *exprRewriterPlanCtx, v *ast.MatchAgainst) {
+	if v.Modifier.WithQueryExpansion() {
+		er.err = plannererrors.ErrNotSupportedYet.GenWithStackByArgs("WITH QUERY EXPANSION")
+		return
+	}
+	stkLen := len(er.ctxStack)
+	numCols := len(v.ColumnNames)
+	against := er.ctxStack[stkLen-1]
+	if _, ok := against.(*expression.Constant); !ok {
+		er.err = plannererrors.ErrWrongArguments.GenWithStackByArgs("AGAINST")
+		return
+	}
+	idxInfo := findFullTextIndex(planCtx.builder.is, er.ctxNameStk[stkLen-numCols-1:stkLen-1])
+	if idxInfo == nil {
+		er.err = plannererrors.ErrFtMatchingKeyNotFound
+		return
+	}
+	mode := expression.MatchAgainstNaturalLanguageMode
+	if v.Modifier.IsBooleanMode() {
+		mode = expression.MatchAgainstBooleanMode
+	}
+	args := make([]expression.Expression, 0, numCols+3)
+	args = append(args, against, expression.NewInt64Const(int64(mode)),
+		expression.NewStrConst(string(idxInfo.FullTextInfo.ParserType)))
+	args = append(args, er.ctxStack[stkLen-numCols-1:stkLen-1]...)
+	function, err := er.newFunction(ast.MatchAgainstFunc, types.NewFieldType(mysql.TypeDouble), args...)
+	if err != nil {
+		er.err = err
+		return
+	}
+	er.ctxStackPop(numCols + 1)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
+// findFullTextIndex finds the public fulltext index whose columns are the same as the columns.
+func findFullTextIndex(is infoschema.InfoSchema, names []*types.FieldName) *model.IndexInfo {
+	dbName, tblName := names[0].DBName, names[0].OrigTblName
+	for _, name := range names {
+		if name.OrigTblName.L == "" || name.DBName.L != dbName.L || name.OrigTblName.L != tblName.L {
+			return nil
+		}
+	}
+	tbl, err := is.TableByName(dbName, tblName)
+	if err != nil {
+		return nil
+	}
+	for _, idx := range tbl.Meta().Indices {
+		if !idx.IsFullText() || idx.State != model.StatePublic || len(idx.Columns) != len(names) {
+			continue
+		}
+		matched := true
+		for _, name := range names {
+			if idx.FindColumnByName(name.OrigColName.L) == nil {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return idx
+		}
+	}
+	return nil
+}
+
 func (er *expressionRewriter) betweenToExpression(v *ast.BetweenExpr) {
 	stkLen := len(er.ctxStack)
 	er.err = expression.CheckArgsNotMultiColumnRow(er.ctxStack[stkLen-3:]...)
@@ -2396,6 +2467,7 @@ func (er *expressionRewriter) toColumn(v *ast.ColumnName) {
 			return
 		}
 		er.ctxStackAppend(column, er.names[idx])
+		er.appendColumnVisitInfo(er.names[idx])
 		return
 	}
 
@@ -2411,6 +2483,7 @@ func (er *expressionRewriter) toColumn(v *ast.ColumnName) {
 		return
 	} else if col != nil {
 		er.ctxStackAppend(col, name)
+		er.appendColumnVisitInfo(name)
 		return
 	}
 	for i := len(planCtx.builder.outerSchemas) - 1; i >= 0; i-- {
@@ -2419,6 +2492,7 @@ func (er *expressionRewriter) toColumn(v *ast.ColumnName) {
 		if idx >= 0 {
 			column := outerSchema.Columns[idx]
 			er.ctxStackAppend(&expression.CorrelatedColumn{Column: *column, Data: new(types.Datum)}, outerName[idx])
+			er.appendColumnVisitInfo(outerName[idx])
 			return
 		}
 		if err != nil {
@@ -2436,6 +2510,14 @@ func (er *expressionRewriter) toColumn(v *ast.ColumnName) {
 	er.err = plannererrors.ErrUnknownColumn.GenWithStackByArgs(v.String(), clauseMsg[planCtx.builder.curClause])
 }
 
+// appendColumnVisitInfo records the SELECT privilege required by reading the column.
+func (er *expressionRewriter) appendColumnVisitInfo(name *types.FieldName) {
+	if er.planCtx == nil {
+		return
+	}
+	er.planCtx.builder.appendColumnVisitInfo(mysql.SelectPriv, name.DBName.L, name.OrigTblName.L, name.OrigColName.L)
+}
+
 func findFieldNameFromNaturalUsingJoin(p LogicalPlan, v *ast.ColumnName) (col *expression.Column, name *types.FieldName, err error) {
 	switch x := p.(type) {
 	case *LogicalLimit, *LogicalSelection, *LogicalTopN, *LogicalSort, *LogicalMaxOneRow:
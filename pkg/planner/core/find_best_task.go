@@ -171,7 +171,7 @@ func (p *LogicalShowDDLJobs) findBestTask(prop *property.PhysicalProperty, planC
 	if !prop.IsSortItemEmpty() || planCounter.Empty() {
 		return invalidTask, 0, nil
 	}
-	pShow := PhysicalShowDDLJobs{JobNumber: p.JobNumber}.Init(p.SCtx())
+	pShow := PhysicalShowDDLJobs{JobNumber: p.JobNumber, Extractor: p.Extractor}.Init(p.SCtx())
 	pShow.SetSchema(p.schema)
 	planCounter.Dec(1)
 	return &rootTask{p: pShow}, 1, nil
@@ -753,6 +753,7 @@ func compareCandidates(sctx PlanContext, prop *property.PhysicalProperty, lhs, r
 	if lhs.path.CountAfterAccess > 100 && rhs.path.CountAfterAccess > 100 && // to prevent some extreme cases, e.g. 0.01 : 10
 		len(lhs.path.PartialIndexPaths) == 0 && len(rhs.path.PartialIndexPaths) == 0 && // not IndexMerge since its row count estimation is not accurate enough
 		prop.ExpectedCnt == math.MaxFloat64 { // Limit may affect access row count
+		sctx.GetSessionVars().StmtCtx.RecordFixControl(fixcontrol.Fix45132)
 		threshold := float64(fixcontrol.GetIntWithDefault(sctx.GetSessionVars().OptimizerFixControl, fixcontrol.Fix45132, 1000))
 		if threshold > 0 { // set it to 0 to disable this rule
 			if lhs.path.CountAfterAccess/rhs.path.CountAfterAccess > threshold {
@@ -1265,6 +1266,7 @@ func (ds *DataSource) isPointGetConvertableSchema() bool {
 // See #46177 for more information.
 func (ds *DataSource) exploreEnforcedPlan() bool {
 	// default value is false to keep it compatible with previous versions.
+	ds.SCtx().GetSessionVars().StmtCtx.RecordFixControl(fixcontrol.Fix46177)
 	return fixcontrol.GetBoolWithDefault(ds.SCtx().GetSessionVars().GetOptimizerFixControlMap(), fixcontrol.Fix46177, false)
 }
 
@@ -1441,19 +1443,25 @@ func (ds *DataSource) findBestTask(prop *property.PhysicalProperty, planCounter
 				}
 			}
 		}
-		var hashPartColName *model.CIStr
+		var partColPos int
 		if tblInfo := ds.table.Meta(); canConvertPointGet && tblInfo.GetPartitionInfo() != nil {
-			// partition table with dynamic prune not support batchPointGet
-			if canConvertPointGet && len(path.Ranges) > 1 && ds.SCtx().GetSessionVars().StmtCtx.UseDynamicPartitionPrune() {
+			// The partition selection is not checked by BatchPointGetExec with dynamic prune, the keys span all the
+			// partitions.
+			if canConvertPointGet && len(path.Ranges) > 1 && ds.SCtx().GetSessionVars().StmtCtx.UseDynamicPartitionPrune() &&
+				len(ds.partitionNames) > 0 {
 				canConvertPointGet = false
 			}
 			if canConvertPointGet && len(path.Ranges) > 1 {
-				// We can only build batch point get for hash partitions on a simple column now. This is
-				// decided by the current implementation of `BatchPointGetExec::initialize()`, specifically,
-				// the `getPhysID()` function. Once we optimize that part, we can come back and enable
-				// BatchPointGet plan for more cases.
-				hashPartColName = getHashOrKeyPartitionColumnName(ds.SCtx(), tblInfo)
-				if hashPartColName == nil {
+				// We can only build batch point get for partitions decided by a simple column or global indexes now.
+				// This is decided by the current implementation of `BatchPointGetExec::initialize()`, specifically,
+				// the `GetPhysID()` function.
+				var idx *model.IndexInfo
+				if !path.IsIntHandlePath {
+					idx = path.Index
+				}
+				var err error
+				partColPos, err = batchPointGetPartitionColPos(tblInfo, getPartitionExpr(ds.SCtx(), tblInfo), idx)
+				if err != nil {
 					canConvertPointGet = false
 				}
 			}
@@ -1483,7 +1491,7 @@ func (ds *DataSource) findBestTask(prop *property.PhysicalProperty, planCounter
 				if len(path.Ranges) == 1 {
 					pointGetTask = ds.convertToPointGet(prop, candidate)
 				} else {
-					pointGetTask = ds.convertToBatchPointGet(prop, candidate, hashPartColName)
+					pointGetTask = ds.convertToBatchPointGet(prop, candidate, partColPos)
 				}
 
 				// Batch/PointGet plans may be over-optimized, like `a>=1(?) and a<=1(?)` --> `a=1` --> PointGet(a=1).
@@ -2623,7 +2631,7 @@ func (ds *DataSource) convertToPointGet(prop *property.PhysicalProperty, candida
 	return rTsk
 }
 
-func (ds *DataSource) convertToBatchPointGet(prop *property.PhysicalProperty, candidate *candidatePath, hashPartColName *model.CIStr) (task task) {
+func (ds *DataSource) convertToBatchPointGet(prop *property.PhysicalProperty, candidate *candidatePath, partColPos int) (task task) {
 	if !prop.IsSortItemEmpty() && !candidate.isMatchProp {
 		return invalidTask
 	}
@@ -2666,7 +2674,7 @@ func (ds *DataSource) convertToBatchPointGet(prop *property.PhysicalProperty, ca
 		batchPointGetPlan.IndexInfo = candidate.path.Index
 		batchPointGetPlan.IdxCols = candidate.path.IdxCols
 		batchPointGetPlan.IdxColLens = candidate.path.IdxColLens
-		batchPointGetPlan.PartitionColPos = getColumnPosInIndex(candidate.path.Index, hashPartColName)
+		batchPointGetPlan.PartitionColPos = partColPos
 		for _, ran := range candidate.path.Ranges {
 			batchPointGetPlan.IndexValues = append(batchPointGetPlan.IndexValues, ran.LowVal)
 		}
@@ -331,6 +331,13 @@ func (p PhysicalMemTable) Init(ctx PlanContext, stats *property.StatsInfo, offse
 	return &p
 }
 
+// Init initializes PhysicalVectorIndexReader.
+func (p PhysicalVectorIndexReader) Init(ctx PlanContext, stats *property.StatsInfo, offset int) *PhysicalVectorIndexReader {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeVectorIndexReader, &p, offset)
+	p.SetStats(stats)
+	return &p
+}
+
 // Init initializes PhysicalHashJoin.
 func (p PhysicalHashJoin) Init(ctx PlanContext, stats *property.StatsInfo, offset int, props ...*property.PhysicalProperty) *PhysicalHashJoin {
 	tp := plancodec.TypeHashJoin
@@ -60,6 +60,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/collate"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/generatedexpr"
 	"github.com/pingcap/tidb/pkg/util/hack"
 	h "github.com/pingcap/tidb/pkg/util/hint"
 	"github.com/pingcap/tidb/pkg/util/intset"
@@ -2688,6 +2689,23 @@ func (a *havingWindowAndOrderbyExprResolver) Leave(n ast.Node) (node ast.Node, o
 		if a.inWindowSpec {
 			a.popCurClause()
 		}
+	case *ast.MatchAgainst:
+		// The columns of MATCH are not ColumnNameExpr, append them to select fields so that
+		// they can be resolved by the projection.
+		if a.curClause != orderByClause {
+			break
+		}
+		for _, col := range v.ColumnNames {
+			var index int
+			index, a.err = a.resolveFromPlan(&ast.ColumnNameExpr{Name: col}, a.p)
+			if a.err != nil {
+				return node, false
+			}
+			if index == -1 {
+				a.err = plannererrors.ErrUnknownColumn.GenWithStackByArgs(col.OrigColName(), clauseMsg[a.curClause])
+				return node, false
+			}
+		}
 	case *ast.ColumnNameExpr:
 		resolveFieldsFirst := true
 		if a.inAggFunc || a.inWindowFunc || a.inWindowSpec || (a.curClause == orderByClause && a.inExpr) || a.curClause == fieldList {
@@ -3896,7 +3914,7 @@ func unfoldWildStar(field *ast.SelectField, outputName types.NameSlice, column [
 	tblName := field.WildCard.Table
 	for i, name := range outputName {
 		col := column[i]
-		if col.IsHidden {
+		if col.IsHidden || name.Invisible {
 			continue
 		}
 		if (dbName.L == "" || dbName.L == name.DBName.L) &&
@@ -4617,6 +4635,8 @@ func (b *PlanBuilder) tryBuildCTE(ctx context.Context, tn *ast.TableName, asName
 					LimitEnd:                 limitEnd,
 					pushDownPredicates:       make([]expression.Expression, 0),
 					ColumnMap:                make(map[string]*expression.Column),
+					Digest:                   cte.digest,
+					HasParamMarker:           cte.hasParamMarker,
 				}
 			}
 			var p LogicalPlan
@@ -4764,7 +4784,7 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 	}
 	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, dbName.L, tableInfo.Name.L, "", authErr)
 
-	if tbl.Type().IsVirtualTable() {
+	if tbl.Type().IsVirtualTable() || tableInfo.IsExternal() || tableInfo.IsFederated() {
 		if tn.TableSample != nil {
 			return nil, expression.ErrInvalidTableSample.GenWithStackByArgs("Unsupported TABLESAMPLE in virtual tables")
 		}
@@ -4791,6 +4811,7 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 				globalStatsReady := tblStats.IsInitialized()
 				skipMissingPartition := b.ctx.GetSessionVars().SkipMissingPartitionStats
 				// If we already enabled the tidb_skip_missing_partition_stats, the global stats can be treated as exist.
+				b.ctx.GetSessionVars().StmtCtx.RecordFixControl(fixcontrol.Fix44262)
 				allowDynamicWithoutStats := fixcontrol.GetBoolWithDefault(b.ctx.GetSessionVars().GetOptimizerFixControlMap(), fixcontrol.Fix44262, skipMissingPartition)
 
 				// If dynamic partition prune isn't enabled or global stats is not ready, we won't enable dynamic prune mode in query
@@ -4991,6 +5012,7 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 			OrigColName: col.Name,
 			// For update statement and delete statement, internal version should see the special middle state column, while user doesn't.
 			NotExplicitUsable: col.State != model.StatePublic,
+			Invisible:         col.Invisible,
 		})
 		newCol := &expression.Column{
 			UniqueID: sessionVars.AllocPlanColumnID(),
@@ -5100,9 +5122,75 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 	}
 	sessionVars.StmtCtx.TblInfo2UnionScan[tableInfo] = dirty
 
+	// The masked columns are masked whenever they are read, no matter it's a locking read or a DML statement,
+	// the tables written by UPDATE and DELETE are checked by checkMaskedTableNotWritten.
+	if !sessionVars.InRestrictedSQL {
+		result, err = b.buildMaskingProjection(ctx, result, dbName, tableInfo)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return result, nil
 }
 
+// buildMaskingProjection puts a projection on top of the data source which replaces
+// the columns covered by masking policies of the current user with their masking expressions.
+func (b *PlanBuilder) buildMaskingProjection(ctx context.Context, p LogicalPlan, dbName model.CIStr, tableInfo *model.TableInfo) (LogicalPlan, error) {
+	checker := privilege.GetPrivilegeManager(b.ctx)
+	if checker == nil {
+		return p, nil
+	}
+	policies := checker.GetMaskingPolicies(b.ctx.GetSessionVars().ActiveRoles, dbName.L, tableInfo.Name.L)
+	if len(policies) == 0 {
+		return p, nil
+	}
+	// The masked result depends on the current user, so the plan can't be shared.
+	b.ctx.GetSessionVars().StmtCtx.SetSkipPlanCache(errors.NewNoStackError("query accesses masked columns"))
+	if b.maskedTables == nil {
+		b.maskedTables = make(map[int64]struct{})
+	}
+	b.maskedTables[tableInfo.ID] = struct{}{}
+	schema := p.Schema()
+	names := p.OutputNames()
+	exprs := make([]expression.Expression, 0, schema.Len())
+	newSchema := expression.NewSchema(make([]*expression.Column, 0, schema.Len())...)
+	for i, col := range schema.Columns {
+		maskExpr, ok := policies[names[i].ColName.L]
+		if !ok {
+			exprs = append(exprs, col)
+			newSchema.Append(col)
+			continue
+		}
+		node, err := generatedexpr.ParseExpression(maskExpr)
+		if err != nil {
+			return nil, err
+		}
+		expr, _, err := b.rewrite(ctx, node, p, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+		newSchema.Append(&expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  expr.GetType(),
+		})
+	}
+	proj := LogicalProjection{Exprs: exprs, Proj4Masking: true}.Init(b.ctx, b.getSelectOffset())
+	proj.SetChildren(p)
+	proj.SetSchema(newSchema)
+	proj.SetOutputNames(names)
+	return proj, nil
+}
+
+// checkMaskedTableNotWritten returns an error if the table written by the statement is read through the masking
+// projection, since the masked values would be written back or used to locate the index entries to remove.
+func (b *PlanBuilder) checkMaskedTableNotWritten(tbl table.Table, stmt string) error {
+	if _, ok := b.maskedTables[tbl.Meta().ID]; !ok {
+		return nil
+	}
+	return plannererrors.ErrMaskedTableNotWritable.GenWithStackByArgs(tbl.Meta().Name.O, stmt)
+}
+
 // ExtractFD implements the LogicalPlan interface.
 func (ds *DataSource) ExtractFD() *fd.FDSet {
 	// FD in datasource (leaf node) can be cached and reused.
@@ -5302,6 +5390,12 @@ func (b *PlanBuilder) buildMemTable(_ context.Context, dbName model.CIStr, table
 	p.names = names
 	copy(p.Columns, tableInfo.Columns)
 
+	if tableInfo.IsFederated() {
+		// The predicates are converted to SQL with the values of the parameters.
+		b.ctx.GetSessionVars().StmtCtx.SetSkipPlanCache(errors.NewNoStackError("query accesses federated tables"))
+		p.Extractor = &FederatedTableExtractor{}
+	}
+
 	// Some memory tables can receive some predicates
 	switch dbName.L {
 	case util2.MetricSchemaName.L:
@@ -5344,6 +5438,8 @@ func (b *PlanBuilder) buildMemTable(_ context.Context, dbName model.CIStr, table
 			p.Extractor = &InfoSchemaTablesExtractor{}
 		case infoschema.TableTiKVRegionStatus:
 			p.Extractor = &TiKVRegionStatusExtractor{tablesID: make([]int64, 0)}
+		case infoschema.TableDDLJobs, infoschema.TableTiDBDDLHistory:
+			p.Extractor = &DDLJobsExtractor{}
 		}
 	}
 	return p, nil
@@ -5858,6 +5954,15 @@ func (b *PlanBuilder) buildUpdate(ctx context.Context, update *ast.UpdateStmt) (
 	if err != nil {
 		return nil, err
 	}
+	for _, assign := range updt.OrderedList {
+		for _, info := range updt.TblColPosInfos {
+			if assign.Col.Index >= info.Start && assign.Col.Index < info.End {
+				if err = b.checkMaskedTableNotWritten(tblID2table[info.TblID], "UPDATE"); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
 	updt.PartitionedTable = b.partitionedTable
 	updt.tblID2Table = tblID2table
 	err = updt.buildOnUpdateFKTriggers(b.ctx, b.is, tblID2table)
@@ -5969,7 +6074,7 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 		foundListItem := false
 		for _, tl := range tableList {
 			if (tl.Schema.L == "" || tl.Schema.L == name.DBName.L) && (tl.Name.L == name.TblName.L) {
-				if isCTE(tl) || tl.TableInfo.IsView() || tl.TableInfo.IsSequence() {
+				if isCTE(tl) || tl.TableInfo.IsView() || tl.TableInfo.IsSequence() || tl.TableInfo.IsMaterializedView() || tl.TableInfo.IsExternal() || tl.TableInfo.IsFederated() {
 					return nil, nil, false, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(name.TblName.O, "UPDATE")
 				}
 				foundListItem = true
@@ -6118,6 +6223,7 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 			dbName = b.ctx.GetSessionVars().CurrentDB
 		}
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.UpdatePriv, dbName, name.OrigTblName.L, "", nil)
+		b.appendColumnVisitInfo(mysql.UpdatePriv, dbName, name.OrigTblName.L, name.OrigColName.L)
 	}
 	return newList, p, allAssignmentsAreConstant, nil
 }
@@ -6291,6 +6397,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (Plan
 			if tn.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", tn.Name.O)
 			}
+			if (tn.TableInfo.IsMaterializedView() && !b.isRefreshMaterializedView) || tn.TableInfo.IsExternal() || tn.TableInfo.IsFederated() {
+				return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tn.Name.O, "DELETE")
+			}
 			if sessionVars.User != nil {
 				authErr = plannererrors.ErrTableaccessDenied.FastGenByArgs("DELETE", sessionVars.User.AuthUsername, sessionVars.User.AuthHostname, tb.Name.L)
 			}
@@ -6310,6 +6419,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (Plan
 			if v.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", v.Name.O)
 			}
+			if (v.TableInfo.IsMaterializedView() && !b.isRefreshMaterializedView) || v.TableInfo.IsExternal() || v.TableInfo.IsFederated() {
+				return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(v.Name.O, "DELETE")
+			}
 			dbName := v.Schema.L
 			if dbName == "" {
 				dbName = b.ctx.GetSessionVars().CurrentDB
@@ -6339,6 +6451,11 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (Plan
 	if err != nil {
 		return nil, err
 	}
+	for _, tbl := range tblID2table {
+		if err = b.checkMaskedTableNotWritten(tbl, "DELETE"); err != nil {
+			return nil, err
+		}
+	}
 	err = del.buildOnDeleteFKTriggers(b.ctx, b.is, tblID2table)
 	return del, err
 }
@@ -7301,6 +7418,19 @@ func appendVisitInfo(vi []visitInfo, priv mysql.PrivilegeType, db, tbl, col stri
 	})
 }
 
+// appendColumnVisitInfo records the column scope privilege required by the statement. It only takes effect when
+// the user doesn't have the privilege on the whole table, see checkColumnPrivilege.
+func (b *PlanBuilder) appendColumnVisitInfo(priv mysql.PrivilegeType, db, tbl, col string) {
+	if db == "" || tbl == "" || col == "" {
+		return
+	}
+	var authErr error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		authErr = plannererrors.ErrColumnaccessDenied.FastGenByArgs(strings.ToUpper(mysql.Priv2Str[priv]), user.AuthUsername, user.AuthHostname, col, tbl)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, priv, db, tbl, col, authErr)
+}
+
 func getInnerFromParenthesesAndUnaryPlus(expr ast.ExprNode) ast.ExprNode {
 	if pexpr, ok := expr.(*ast.ParenthesesExpr); ok {
 		return getInnerFromParenthesesAndUnaryPlus(pexpr.Expr)
@@ -7637,6 +7767,7 @@ func (b *PlanBuilder) buildWith(ctx context.Context, w *ast.WithClause) ([]*cteI
 		if b.ctx.GetSessionVars().EnableForceInlineCTE() {
 			b.outerCTEs[len(b.outerCTEs)-1].forceInlineByHintOrVar = true
 		}
+		b.outerCTEs[len(b.outerCTEs)-1].digest, b.outerCTEs[len(b.outerCTEs)-1].hasParamMarker = b.cteDigest(cte, w.IsRecursive)
 		_, err := b.buildCte(ctx, cte, w.IsRecursive)
 		if err != nil {
 			return nil, err
@@ -7651,6 +7782,92 @@ func (b *PlanBuilder) buildWith(ctx context.Context, w *ast.WithClause) ([]*cteI
 	return ctes, nil
 }
 
+// cteDigest computes the digest of the CTE which is being built, it consists of the definition of the CTE,
+// the definitions of the CTEs referenced by it and the current database. An empty digest is returned
+// if the materialized result of the CTE can't be shared.
+func (b *PlanBuilder) cteDigest(cte *ast.CommonTableExpression, isRecursive bool) (digest string, hasParamMarker bool) {
+	currentDB := b.ctx.GetSessionVars().CurrentDB
+	collector := &cteDigestCollector{}
+	cte.Query.Accept(collector)
+	if collector.unshareable || util2.IsMemDB(strings.ToLower(currentDB)) {
+		return "", false
+	}
+	var sb strings.Builder
+	sb.WriteString(currentDB)
+	if isRecursive {
+		sb.WriteString(" recursive ")
+		sb.WriteString(cte.Name.L)
+	}
+	if err := cte.Query.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return "", false
+	}
+	self := b.outerCTEs[len(b.outerCTEs)-1]
+	for _, name := range collector.tableNames {
+		if isRecursive && name == cte.Name.L {
+			continue
+		}
+		// Find the CTE referenced by the name in the same way as tryBuildCTE.
+		for i := len(b.outerCTEs) - 1; i >= 0; i-- {
+			ref := b.outerCTEs[i]
+			if ref == self || ref.def.Name.L != name {
+				continue
+			}
+			if ref.isBuilding {
+				if ref.nonRecursive {
+					continue
+				}
+				// It's the recursive part of an outer CTE, whose result changes in every iteration.
+				return "", false
+			}
+			if ref.digest == "" {
+				return "", false
+			}
+			sb.WriteString(" " + name + ":" + ref.digest)
+			hasParamMarker = hasParamMarker || ref.hasParamMarker
+			break
+		}
+	}
+	return parser.DigestNormalized(sb.String()).String(), hasParamMarker || collector.hasParamMarker
+}
+
+// cteDigestCollector collects the table names and checks whether the materialized result of a CTE can be shared.
+type cteDigestCollector struct {
+	tableNames     []string
+	unshareable    bool
+	hasParamMarker bool
+}
+
+// Enter implements Visitor interface.
+func (c *cteDigestCollector) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.TableName:
+		if x.Schema.L == "" {
+			c.tableNames = append(c.tableNames, x.Name.L)
+		} else if util2.IsMemDB(x.Schema.L) {
+			c.unshareable = true
+		}
+	case *ast.FuncCallExpr:
+		// The functions which are illegal for generated columns are non-deterministic.
+		if _, ok := expression.IllegalFunctions4GeneratedColumns[x.FnName.L]; ok {
+			c.unshareable = true
+		}
+		switch x.FnName.L {
+		case ast.NextVal, ast.LastVal, ast.SetVal:
+			c.unshareable = true
+		}
+	case *ast.VariableExpr:
+		c.unshareable = true
+	case ast.ParamMarkerExpr:
+		c.hasParamMarker = true
+	}
+	return in, c.unshareable
+}
+
+// Leave implements Visitor interface.
+func (*cteDigestCollector) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
 func (b *PlanBuilder) buildProjection4CTEUnion(_ context.Context, seed LogicalPlan, recur LogicalPlan) (LogicalPlan, error) {
 	if seed.Schema().Len() != recur.Schema().Len() {
 		return nil, plannererrors.ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
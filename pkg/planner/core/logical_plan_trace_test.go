@@ -189,6 +189,17 @@ func TestSingleRuleTraceStep(t *testing.T) {
 			sql:            "select * from pt3 where ptn > 3;",
 			flags:          []uint64{flagPartitionProcessor, flagPredicatePushDown, flagBuildKeyInfo, flagPrunColumns},
 			assertRuleName: "partition_processor",
+			assertRuleSteps: []assertTraceStep{
+				{
+					assertReason: "DataSource_1 doesn't have needed partition table after pruning",
+					assertAction: "DataSource_1 becomes TableDual_4",
+				},
+			},
+		},
+		{
+			sql:            "select * from pt3 where ptn > 0;",
+			flags:          []uint64{flagPartitionProcessor, flagPredicatePushDown, flagBuildKeyInfo, flagPrunColumns},
+			assertRuleName: "partition_processor",
 			assertRuleSteps: []assertTraceStep{
 				{
 					assertReason: "DataSource_1 has multiple needed partitions[p1,p2] after pruning",
@@ -801,6 +801,10 @@ type LogicalProjection struct {
 	// Proj4Expand is used for expand to project same column reference, while these
 	// col may be filled with null so we couldn't just eliminate this projection itself.
 	Proj4Expand bool
+
+	// Proj4Masking indicates the projection replaces the masked columns of the data source
+	// below it with their masking expressions, see buildMaskingProjection.
+	Proj4Masking bool
 }
 
 // ExtractFD implements the logical plan interface, extracting the FD from bottom up.
@@ -1647,6 +1651,15 @@ func detachCondAndBuildRangeForPath(
 			path.ConstCols[i] = res.ColumnValues[i] != nil
 		}
 	}
+	if path.Index != nil && path.Index.Tp == model.IndexTypeHypo {
+		// The hypo index has no statistics, estimate the row count by the statistics of its columns.
+		selectivity, _, err := cardinality.Selectivity(sctx, histColl, path.AccessConds, nil)
+		if err != nil {
+			return err
+		}
+		path.CountAfterAccess = selectivity * float64(histColl.RealtimeCount)
+		return nil
+	}
 	path.CountAfterAccess, err = cardinality.GetRowCountByIndexRanges(sctx, histColl, path.Index.ID, path.Ranges)
 	return err
 }
@@ -2257,7 +2270,10 @@ type ShowContents struct {
 	Extended    bool       // Used for `show extended columns from ...`
 	Limit       *ast.Limit // Used for limit Result Set row number.
 
+	RegionsSummary bool // Used for `show table regions summary`.
+
 	ImportJobID *int64 // Used for SHOW LOAD DATA JOB <jobID>
+	DistJobID   int64  // Used for SHOW JOB <jobID>
 }
 
 const emptyShowContentsSize = int64(unsafe.Sizeof(ShowContents{}))
@@ -2286,6 +2302,7 @@ type LogicalShowDDLJobs struct {
 	logicalSchemaProducer
 
 	JobNumber int64
+	Extractor *DDLJobsExtractor
 }
 
 // CTEClass holds the information and plan for a CTE. Most of the fields in this struct are the same as cteInfo.
@@ -2311,6 +2328,11 @@ type CTEClass struct {
 	pushDownPredicates []expression.Expression
 	ColumnMap          map[string]*expression.Column
 	isOuterMostCTE     bool
+	// Digest identifies the definition of this CTE, the CTEs with the same digest (and the same values of the
+	// param markers if HasParamMarker is true) can share the materialized result. It's empty if the result
+	// can't be shared, e.g. the CTE contains non-deterministic functions.
+	Digest         string
+	HasParamMarker bool
 }
 
 const emptyCTEClassSize = int64(unsafe.Sizeof(CTEClass{}))
@@ -1508,12 +1508,79 @@ func TestVisitInfo(t *testing.T) {
 		_, err = builder.Build(context.TODO(), stmt)
 		require.NoError(t, err, comment)
 
-		checkVisitInfo(t, builder.visitInfo, tt.ans, comment)
+		checkVisitInfo(t, filterVisitInfo(builder.visitInfo, false), tt.ans, comment)
 
 		domain.GetDomain(sctx).StatsHandle().Close()
 	}
 }
 
+func TestColumnVisitInfo(t *testing.T) {
+	variable.EnableMDL.Store(false)
+	tests := []struct {
+		sql string
+		ans []visitInfo
+	}{
+		{
+			sql: "select a from t where b > 1",
+			ans: []visitInfo{
+				{mysql.SelectPriv, "test", "t", "a", nil, false, "", false},
+				{mysql.SelectPriv, "test", "t", "b", nil, false, "", false},
+			},
+		},
+		{
+			sql: "select x.c from (select a as c from t) x",
+			ans: []visitInfo{
+				{mysql.SelectPriv, "test", "t", "a", nil, false, "", false},
+			},
+		},
+		{
+			sql: "update t set a = b where c = 1",
+			ans: []visitInfo{
+				{mysql.SelectPriv, "test", "t", "b", nil, false, "", false},
+				{mysql.SelectPriv, "test", "t", "c", nil, false, "", false},
+				{mysql.UpdatePriv, "test", "t", "a", nil, false, "", false},
+			},
+		},
+		{
+			sql: "insert into t (a, b) values (1, 2) on duplicate key update c = 1",
+			ans: []visitInfo{
+				{mysql.InsertPriv, "test", "t", "a", nil, false, "", false},
+				{mysql.InsertPriv, "test", "t", "b", nil, false, "", false},
+				{mysql.UpdatePriv, "test", "t", "c", nil, false, "", false},
+			},
+		},
+	}
+	s := createPlannerSuite()
+	defer s.Close()
+	for _, tt := range tests {
+		comment := fmt.Sprintf("for %s", tt.sql)
+		stmt, err := s.p.ParseOneStmt(tt.sql, "", "")
+		require.NoError(t, err, comment)
+
+		_ = Preprocess(context.Background(), s.sctx, stmt, WithPreprocessorReturn(&PreprocessorReturn{InfoSchema: s.is}))
+		sctx := MockContext()
+		builder, _ := NewPlanBuilder().Init(sctx, s.is, hint.NewQBHintHandler(nil))
+		domain.GetDomain(sctx).MockInfoCacheAndLoadInfoSchema(s.is)
+		_, err = builder.Build(context.TODO(), stmt)
+		require.NoError(t, err, comment)
+
+		checkVisitInfo(t, filterVisitInfo(builder.visitInfo, true), tt.ans, comment)
+
+		domain.GetDomain(sctx).StatsHandle().Close()
+	}
+}
+
+// filterVisitInfo returns the column scope visitInfos if column is true, otherwise the others.
+func filterVisitInfo(vs []visitInfo, column bool) []visitInfo {
+	res := make([]visitInfo, 0, len(vs))
+	for _, v := range vs {
+		if (v.column != "") == column {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
 type visitInfoArray []visitInfo
 
 func (v visitInfoArray) Len() int {
@@ -38,6 +38,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/rowcodec"
 	"github.com/pingcap/tidb/pkg/util/set"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
 	"github.com/pingcap/tipb/go-tipb"
 	"go.uber.org/zap"
@@ -779,10 +780,110 @@ type ClusterLogTableExtractor struct {
 	// e.g:
 	// 1. SELECT * FROM cluster_log WHERE message like '%gc%'
 	// 2. SELECT * FROM cluster_log WHERE message regexp '.*'
-	Patterns  []string
+	Patterns []string
+	// LogLevels is used to filter the log level, the comparisons of the level are
+	// evaluated by the severity instead of the string order, that is
+	// trace < debug < info < warn(warning) < error < critical(fatal).
+	// e.g:
+	// 1. SELECT * FROM cluster_log WHERE level in ('warn', 'error')
+	// 2. SELECT * FROM cluster_log WHERE level >= 'warn'
 	LogLevels set.StringSet
 }
 
+// logLevelSeverities are the log levels ordered from the least to the most severe.
+var logLevelSeverities = []string{"trace", "debug", "info", "warn", "error", "critical"}
+
+// logLevelSeverity returns the index of the level in logLevelSeverities, or -1 if it is unknown.
+func logLevelSeverity(level string) int {
+	switch strings.ToLower(level) {
+	case "trace":
+		return 0
+	case "debug":
+		return 1
+	case "info":
+		return 2
+	case "warn", "warning":
+		return 3
+	case "error":
+		return 4
+	case "critical", "fatal":
+		return 5
+	}
+	return -1
+}
+
+// extractLogLevelRange extracts the comparisons between the level column and a known log level,
+// such as `level >= 'warn'`. It returns the range of the severity, which is [0, len(logLevelSeverities)-1]
+// if there is no such comparison.
+// The extracted comparisons are removed from the remained predicates on purpose: they are evaluated
+// by the severity when the nodes search the logs, while evaluating them again in TiDB compares the
+// strings, e.g. `'CRITICAL' >= 'error'` is false, which would filter out the matched logs.
+// The comparisons with an unknown level are kept and evaluated as strings.
+func (helper extractHelper) extractLogLevelRange(
+	schema *expression.Schema,
+	names []*types.FieldName,
+	predicates []expression.Expression,
+	extractColName string,
+) (
+	remained []expression.Expression,
+	minSeverity int,
+	maxSeverity int,
+) {
+	minSeverity, maxSeverity = 0, len(logLevelSeverities)-1
+	extractCols := helper.findColumn(schema, names, extractColName)
+	if len(extractCols) == 0 {
+		return predicates, minSeverity, maxSeverity
+	}
+	remained = make([]expression.Expression, 0, len(predicates))
+	for _, expr := range predicates {
+		fn, ok := expr.(*expression.ScalarFunction)
+		if !ok {
+			remained = append(remained, expr)
+			continue
+		}
+		var colName string
+		var datums []types.Datum
+		fnName := helper.getStringFunctionName(fn)
+		switch fnName {
+		case ast.GT, ast.GE, ast.LT, ast.LE:
+			colName, datums = helper.extractColBinaryOpConsExpr(extractCols, false, fn)
+		}
+		if colName != extractColName || datums[0].Kind() != types.KindString {
+			remained = append(remained, expr)
+			continue
+		}
+		severity := logLevelSeverity(datums[0].GetString())
+		if severity < 0 {
+			remained = append(remained, expr)
+			continue
+		}
+		// 'warn' < level is the same as level > 'warn'.
+		if _, isCol := fn.GetArgs()[0].(*expression.Column); !isCol {
+			switch fnName {
+			case ast.GT:
+				fnName = ast.LT
+			case ast.GE:
+				fnName = ast.LE
+			case ast.LT:
+				fnName = ast.GT
+			case ast.LE:
+				fnName = ast.GE
+			}
+		}
+		switch fnName {
+		case ast.GT:
+			minSeverity = max(minSeverity, severity+1)
+		case ast.GE:
+			minSeverity = max(minSeverity, severity)
+		case ast.LT:
+			maxSeverity = min(maxSeverity, severity-1)
+		case ast.LE:
+			maxSeverity = min(maxSeverity, severity)
+		}
+	}
+	return remained, minSeverity, maxSeverity
+}
+
 // Extract implements the MemTablePredicateExtractor Extract interface
 func (e *ClusterLogTableExtractor) Extract(ctx PlanContext,
 	schema *expression.Schema,
@@ -793,6 +894,21 @@ func (e *ClusterLogTableExtractor) Extract(ctx PlanContext,
 	remained, typeSkipRequest, nodeTypes := e.extractCol(schema, names, predicates, "type", true)
 	remained, addrSkipRequest, instances := e.extractCol(schema, names, remained, "instance", false)
 	remained, levlSkipRequest, logLevels := e.extractCol(schema, names, remained, "level", true)
+	remained, minSeverity, maxSeverity := e.extractLogLevelRange(schema, names, remained, "level")
+	if minSeverity > 0 || maxSeverity < len(logLevelSeverities)-1 {
+		if len(logLevels) == 0 {
+			for i := minSeverity; i <= maxSeverity; i++ {
+				logLevels.Insert(logLevelSeverities[i])
+			}
+		} else {
+			for level := range logLevels {
+				if severity := logLevelSeverity(level); severity >= 0 && (severity < minSeverity || severity > maxSeverity) {
+					delete(logLevels, level)
+				}
+			}
+		}
+		levlSkipRequest = levlSkipRequest || len(logLevels) == 0
+	}
 	e.SkipRequest = typeSkipRequest || addrSkipRequest || levlSkipRequest
 	e.NodeTypes = nodeTypes
 	e.Instances = instances
@@ -1659,6 +1775,135 @@ func (e *TikvRegionPeersExtractor) explainInfo(_ *PhysicalMemTable) string {
 	return s
 }
 
+// DDLJobsExtractor is used to extract some predicates of information_schema.ddl_jobs, so the jobs which can't
+// match them are skipped before being output, and the history jobs are read by the IDs instead of being iterated.
+// e.g:
+// 1. SELECT * FROM ddl_jobs WHERE job_id IN (100, 102)
+// 2. SELECT * FROM ddl_jobs WHERE db_name = 'test' AND table_name = 't' AND create_time > '2024-01-01 00:00:00'
+//
+// The predicates are only used to skip the jobs, all of them are remained to be evaluated again, because the
+// names are matched case-insensitively and the sub-jobs of a multi-schema change job have their own states.
+type DDLJobsExtractor struct {
+	extractHelper
+
+	// SkipRequest means the where clause always false, we don't need to read any job
+	SkipRequest bool
+
+	// JobIDs are the IDs of the jobs to read, all the jobs are read if it's empty.
+	JobIDs []int64
+	// ColPredicates are the lower-cased values of the db_name, table_name and state columns.
+	ColPredicates map[string]set.StringSet
+	// CreateTimeRange is the range of the create_time column, a zero time means the range is unbounded at that side.
+	CreateTimeRange TimeRange
+}
+
+// Extract implements the MemTablePredicateExtractor Extract interface
+func (e *DDLJobsExtractor) Extract(ctx PlanContext,
+	schema *expression.Schema,
+	names []*types.FieldName,
+	predicates []expression.Expression,
+) []expression.Expression {
+	_, skipRequest, jobIDs := e.extractCol(schema, names, predicates, "job_id", false)
+	if skipRequest {
+		e.SkipRequest = true
+		return nil
+	}
+	for id := range jobIDs {
+		jobID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			// The job ID can't be matched, let the predicates filter the jobs.
+			e.JobIDs = nil
+			break
+		}
+		e.JobIDs = append(e.JobIDs, jobID)
+	}
+	slices.Sort(e.JobIDs)
+
+	e.ColPredicates = make(map[string]set.StringSet)
+	for _, colName := range []string{"db_name", "table_name", "state"} {
+		_, skipRequest, values := e.extractCol(schema, names, predicates, colName, true)
+		if skipRequest {
+			e.SkipRequest = true
+			return nil
+		}
+		if len(values) > 0 {
+			e.ColPredicates[colName] = values
+		}
+	}
+
+	_, startTime, endTime := e.extractTimeRange(ctx, schema, names, predicates, "create_time", ctx.GetSessionVars().StmtCtx.TimeZone())
+	if startTime != 0 {
+		e.CreateTimeRange.StartTime = time.Unix(0, startTime)
+	}
+	if endTime != 0 {
+		e.CreateTimeRange.EndTime = time.Unix(0, endTime)
+	}
+	if startTime != 0 && endTime != 0 && startTime > endTime {
+		e.SkipRequest = true
+		return nil
+	}
+	return predicates
+}
+
+// Filter returns true if the job with the values of the db_name, table_name and state columns and the create time
+// can't match the predicates.
+func (e *DDLJobsExtractor) Filter(dbName, tableName string, states []string, createTime time.Time) bool {
+	if e.SkipRequest {
+		return true
+	}
+	if vals, ok := e.ColPredicates["db_name"]; ok && !vals.Exist(strings.ToLower(dbName)) {
+		return true
+	}
+	if vals, ok := e.ColPredicates["table_name"]; ok && !vals.Exist(strings.ToLower(tableName)) {
+		return true
+	}
+	if vals, ok := e.ColPredicates["state"]; ok {
+		if !slices.ContainsFunc(states, func(state string) bool { return vals.Exist(strings.ToLower(state)) }) {
+			return true
+		}
+	}
+	// The create_time column is shown in seconds, so the range is widened by a second to never skip a matched job.
+	if start := e.CreateTimeRange.StartTime; !start.IsZero() && createTime.Before(start.Add(-time.Second)) {
+		return true
+	}
+	if end := e.CreateTimeRange.EndTime; !end.IsZero() && createTime.After(end.Add(time.Second)) {
+		return true
+	}
+	return false
+}
+
+func (e *DDLJobsExtractor) explainInfo(p *PhysicalMemTable) string {
+	if e.SkipRequest {
+		return "skip_request:true"
+	}
+	r := new(bytes.Buffer)
+	if len(e.JobIDs) > 0 {
+		ids := make([]string, 0, len(e.JobIDs))
+		for _, id := range e.JobIDs {
+			ids = append(ids, strconv.FormatInt(id, 10))
+		}
+		fmt.Fprintf(r, "job_ids:[%s], ", strings.Join(ids, ","))
+	}
+	for _, colName := range []string{"db_name", "table_name", "state"} {
+		if vals, ok := e.ColPredicates[colName]; ok {
+			fmt.Fprintf(r, "%s:[%s], ", colName, extractStringFromStringSet(vals))
+		}
+	}
+	tz := p.SCtx().GetSessionVars().StmtCtx.TimeZone()
+	if start := e.CreateTimeRange.StartTime; !start.IsZero() {
+		fmt.Fprintf(r, "start_time:%v, ", start.In(tz).Format(MetricTableTimeFormat))
+	}
+	if end := e.CreateTimeRange.EndTime; !end.IsZero() {
+		fmt.Fprintf(r, "end_time:%v, ", end.In(tz).Format(MetricTableTimeFormat))
+	}
+	// remove the last ", " in the message info
+	s := r.String()
+	if len(s) > 2 {
+		return s[:len(s)-2]
+	}
+	return s
+}
+
 // ColumnsTableExtractor is used to extract some predicates of columns table.
 type ColumnsTableExtractor struct {
 	extractHelper
@@ -1873,3 +2118,135 @@ func (e *InfoSchemaTablesExtractor) Filter(colName string, val string) bool {
 	// No need to filter records since no predicate for the column exists.
 	return false
 }
+
+// FederatedTableExtractor is used to extract the predicates of a federated table which can be
+// evaluated by the remote server. The predicates are converted to SQL and sent to the remote
+// server in the WHERE clause.
+//
+// Only the comparisons of numbers and temporal values are sent. The strings are compared by the
+// collations of the remote columns, which may differ from the local ones, and the rows filtered
+// out by the remote server can't be brought back by TiDB. All predicates are remained to be
+// evaluated again by TiDB, since the remote server may use different SQL modes.
+type FederatedTableExtractor struct {
+	// Conditions are the predicates evaluated by the remote server.
+	// e.g: SELECT * FROM t WHERE a > 1 AND b IN ('x', 'y'), the conditions are
+	// ["(`a` > 1)", "(`b` IN ('x', 'y'))"].
+	Conditions []string
+}
+
+// Extract implements the MemTablePredicateExtractor Extract interface
+func (e *FederatedTableExtractor) Extract(ctx PlanContext,
+	schema *expression.Schema,
+	names []*types.FieldName,
+	predicates []expression.Expression,
+) []expression.Expression {
+	e.Conditions = e.Conditions[:0]
+	for _, pred := range predicates {
+		if cond, ok := federatedExprToSQL(ctx, schema, names, pred); ok {
+			e.Conditions = append(e.Conditions, cond)
+		}
+	}
+	return predicates
+}
+
+func (e *FederatedTableExtractor) explainInfo(_ *PhysicalMemTable) string {
+	if len(e.Conditions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("remote_conditions:[%s]", strings.Join(e.Conditions, ", "))
+}
+
+// federatedBinaryOps maps the comparison functions to the operators in SQL.
+var federatedBinaryOps = map[string]string{
+	ast.EQ:       "=",
+	ast.NE:       "!=",
+	ast.LT:       "<",
+	ast.LE:       "<=",
+	ast.GT:       ">",
+	ast.GE:       ">=",
+	ast.NullEQ:   "<=>",
+	ast.LogicAnd: "AND",
+	ast.LogicOr:  "OR",
+}
+
+// federatedExprToSQL converts the expression to SQL which can be evaluated by a remote MySQL
+// server. Only the columns, constants, comparisons of non-string values, IN, IS NULL and logic
+// operators are supported, and false is returned for the other expressions.
+func federatedExprToSQL(ctx PlanContext, schema *expression.Schema, names []*types.FieldName, expr expression.Expression) (string, bool) {
+	switch x := expr.(type) {
+	case *expression.Column:
+		idx := schema.ColumnIndex(x)
+		if idx < 0 {
+			return "", false
+		}
+		return sqlescape.MustEscapeSQL("%n", names[idx].ColName.O), true
+	case *expression.Constant:
+		val, err := x.Eval(ctx.GetExprCtx(), chunk.Row{})
+		if err != nil {
+			return "", false
+		}
+		return federatedDatumToSQL(val)
+	case *expression.ScalarFunction:
+		switch x.FuncName.L {
+		case ast.EQ, ast.NE, ast.LT, ast.LE, ast.GT, ast.GE, ast.NullEQ, ast.In:
+			if !federatedComparable(x.GetArgs()) {
+				return "", false
+			}
+		}
+		args := make([]string, 0, len(x.GetArgs()))
+		for _, arg := range x.GetArgs() {
+			s, ok := federatedExprToSQL(ctx, schema, names, arg)
+			if !ok {
+				return "", false
+			}
+			args = append(args, s)
+		}
+		if op, ok := federatedBinaryOps[x.FuncName.L]; ok && len(args) == 2 {
+			return fmt.Sprintf("(%s %s %s)", args[0], op, args[1]), true
+		}
+		switch x.FuncName.L {
+		case ast.UnaryNot:
+			return fmt.Sprintf("(NOT %s)", args[0]), true
+		case ast.IsNull:
+			return fmt.Sprintf("(%s IS NULL)", args[0]), true
+		case ast.In:
+			return fmt.Sprintf("(%s IN (%s))", args[0], strings.Join(args[1:], ", ")), true
+		}
+	}
+	return "", false
+}
+
+// federatedComparable checks whether the remote server compares the first argument with the others
+// in the same way as TiDB, which is false for the strings and JSON values since their collations
+// may differ.
+func federatedComparable(args []expression.Expression) bool {
+	for _, arg := range args[1:] {
+		switch expression.GetAccurateCmpType(args[0], arg) {
+		case types.ETString, types.ETJson:
+			return false
+		}
+	}
+	return true
+}
+
+func federatedDatumToSQL(d types.Datum) (string, bool) {
+	switch d.Kind() {
+	case types.KindNull:
+		return "NULL", true
+	case types.KindInt64:
+		return strconv.FormatInt(d.GetInt64(), 10), true
+	case types.KindUint64:
+		return strconv.FormatUint(d.GetUint64(), 10), true
+	case types.KindFloat32, types.KindFloat64:
+		return strconv.FormatFloat(d.GetFloat64(), 'g', -1, 64), true
+	case types.KindMysqlDecimal:
+		return d.GetMysqlDecimal().String(), true
+	case types.KindString, types.KindBytes:
+		return sqlescape.MustEscapeSQL("%?", d.GetString()), true
+	case types.KindMysqlTime:
+		return sqlescape.MustEscapeSQL("%?", d.GetMysqlTime().String()), true
+	case types.KindMysqlDuration:
+		return sqlescape.MustEscapeSQL("%?", d.GetMysqlDuration().String()), true
+	}
+	return "", false
+}
@@ -514,6 +514,36 @@ func TestClusterLogTableExtractor(t *testing.T) {
 			instances: set.NewStringSet(),
 			level:     set.NewStringSet("debug", "error"),
 		},
+		{
+			sql:       "select * from information_schema.cluster_log where level >= 'WARN'",
+			nodeTypes: set.NewStringSet(),
+			instances: set.NewStringSet(),
+			level:     set.NewStringSet("warn", "error", "critical"),
+		},
+		{
+			sql:       "select * from information_schema.cluster_log where level > 'debug' and level <= 'fatal'",
+			nodeTypes: set.NewStringSet(),
+			instances: set.NewStringSet(),
+			level:     set.NewStringSet("info", "warn", "error", "critical"),
+		},
+		{
+			sql:       "select * from information_schema.cluster_log where 'info' > level and level > 'trace'",
+			nodeTypes: set.NewStringSet(),
+			instances: set.NewStringSet(),
+			level:     set.NewStringSet("debug"),
+		},
+		{
+			sql:       "select * from information_schema.cluster_log where level in ('debug', 'warning', 'error') and level > 'info'",
+			nodeTypes: set.NewStringSet(),
+			instances: set.NewStringSet(),
+			level:     set.NewStringSet("warning", "error"),
+		},
+		{
+			sql:         "select * from information_schema.cluster_log where level > 'error' and level < 'warn'",
+			nodeTypes:   set.NewStringSet(),
+			instances:   set.NewStringSet(),
+			skipRequest: true,
+		},
 	}
 	for _, ca := range cases {
 		logicalMemTable := getLogicalMemTable(t, dom, se, parser, ca.sql)
@@ -1710,6 +1740,91 @@ PARTITION BY RANGE COLUMNS ( id ) (
 	}
 }
 
+func TestDDLJobsExtractor(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+
+	se, err := session.CreateSession4Test(store)
+	require.NoError(t, err)
+
+	var cases = []struct {
+		sql           string
+		jobIDs        []int64
+		colPredicates map[string]set.StringSet
+		startTime     string
+		endTime       string
+		skipRequest   bool
+	}{
+		{
+			sql: "select * from information_schema.ddl_jobs",
+		},
+		{
+			sql:    "select * from information_schema.ddl_jobs where job_id=100",
+			jobIDs: []int64{100},
+		},
+		{
+			sql:    "select * from information_schema.ddl_jobs where job_id in (102, 100, 101)",
+			jobIDs: []int64{100, 101, 102},
+		},
+		{
+			sql:         "select * from information_schema.ddl_jobs where job_id=100 and job_id=101",
+			skipRequest: true,
+		},
+		{
+			sql:    "select * from information_schema.ddl_jobs where job_id in (100, 101) and db_name='Test' and table_name in ('T1', 't2')",
+			jobIDs: []int64{100, 101},
+			colPredicates: map[string]set.StringSet{
+				"db_name":    set.NewStringSet("test"),
+				"table_name": set.NewStringSet("t1", "t2"),
+			},
+		},
+		{
+			sql: "select * from information_schema.ddl_jobs where state in ('synced', 'CANCELLED')",
+			colPredicates: map[string]set.StringSet{
+				"state": set.NewStringSet("synced", "cancelled"),
+			},
+		},
+		{
+			sql:         "select * from information_schema.ddl_jobs where db_name='a' and db_name='b'",
+			skipRequest: true,
+		},
+		{
+			sql:       "select * from information_schema.ddl_jobs where create_time>='2024-01-01 10:00:00' and create_time<='2024-01-08 10:00:00'",
+			startTime: "2024-01-01 10:00:00",
+			endTime:   "2024-01-08 10:00:00",
+		},
+		{
+			sql:         "select * from information_schema.ddl_jobs where create_time>'2024-01-08 10:00:00' and create_time<'2024-01-01 10:00:00'",
+			skipRequest: true,
+		},
+	}
+	parser := parser.New()
+	for _, ca := range cases {
+		logicalMemTable := getLogicalMemTable(t, dom, se, parser, ca.sql)
+		require.NotNil(t, logicalMemTable.Extractor)
+
+		ddlJobsExtractor := logicalMemTable.Extractor.(*plannercore.DDLJobsExtractor)
+		require.Equal(t, ca.skipRequest, ddlJobsExtractor.SkipRequest, "SQL: %v", ca.sql)
+		if ca.skipRequest {
+			continue
+		}
+		require.EqualValues(t, ca.jobIDs, ddlJobsExtractor.JobIDs, "SQL: %v", ca.sql)
+		require.Equal(t, len(ca.colPredicates), len(ddlJobsExtractor.ColPredicates), "SQL: %v", ca.sql)
+		if len(ca.colPredicates) > 0 {
+			require.EqualValues(t, ca.colPredicates, ddlJobsExtractor.ColPredicates, "SQL: %v", ca.sql)
+		}
+		if ca.startTime != "" {
+			require.Equal(t, timestamp(t, ca.startTime), ddlJobsExtractor.CreateTimeRange.StartTime.UnixMilli(), "SQL: %v", ca.sql)
+		} else {
+			require.True(t, ddlJobsExtractor.CreateTimeRange.StartTime.IsZero(), "SQL: %v", ca.sql)
+		}
+		if ca.endTime != "" {
+			require.Equal(t, timestamp(t, ca.endTime), ddlJobsExtractor.CreateTimeRange.EndTime.UnixMilli(), "SQL: %v", ca.sql)
+		} else {
+			require.True(t, ddlJobsExtractor.CreateTimeRange.EndTime.IsZero(), "SQL: %v", ca.sql)
+		}
+	}
+}
+
 func TestExtractorInPreparedStmt(t *testing.T) {
 	store, dom := testkit.CreateMockStoreAndDomain(t)
 	tk := testkit.NewTestKit(t, store)
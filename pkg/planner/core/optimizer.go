@@ -65,6 +65,9 @@ var AllowCartesianProduct = atomic.NewBool(true)
 // IsReadOnly check whether the ast.Node is a read only statement.
 var IsReadOnly func(node ast.Node, vars *variable.SessionVars) bool
 
+// IsDeterministic check whether the ast.Node is a deterministic DML statement which can be replayed safely.
+var IsDeterministic func(node ast.Node, vars *variable.SessionVars) bool
+
 // Note: The order of flags is same as the order of optRule in the list.
 // Do not mess up the order.
 const (
@@ -194,6 +197,10 @@ func BuildLogicalPlanForTest(ctx context.Context, sctx sessionctx.Context, node
 // CheckPrivilege checks the privilege for a user.
 func CheckPrivilege(activeRoles []*auth.RoleIdentity, pm privilege.Manager, vs []visitInfo) error {
 	for _, v := range vs {
+		if v.column != "" {
+			// Column scope privileges are only checked when the table scope check fails, see checkColumnPrivilege.
+			continue
+		}
 		if v.privilege == mysql.ExtendedPriv {
 			if !pm.RequestDynamicVerification(activeRoles, v.dynamicPriv, v.dynamicWithGrant) {
 				if v.err == nil {
@@ -202,6 +209,13 @@ func CheckPrivilege(activeRoles []*auth.RoleIdentity, pm privilege.Manager, vs [
 				return v.err
 			}
 		} else if !pm.RequestVerification(activeRoles, v.db, v.table, v.column, v.privilege) {
+			granted, err := checkColumnPrivilege(activeRoles, pm, v, vs)
+			if err != nil {
+				return err
+			}
+			if granted {
+				continue
+			}
 			if v.err == nil {
 				return plannererrors.ErrPrivilegeCheckFail.GenWithStackByArgs(v.privilege.String())
 			}
@@ -211,6 +225,41 @@ func CheckPrivilege(activeRoles []*auth.RoleIdentity, pm privilege.Manager, vs [
 	return nil
 }
 
+// checkColumnPrivilege checks the column scope privileges of the table when the table scope check of tbl fails.
+// It returns true if the user has the privilege on all the columns referenced by the statement. If the user only
+// has the privilege on part of them, the error of the first denied column is returned. If the user has no privilege
+// on any of them, false is returned and the table scope error should be reported.
+func checkColumnPrivilege(activeRoles []*auth.RoleIdentity, pm privilege.Manager, tbl visitInfo, vs []visitInfo) (bool, error) {
+	if tbl.table == "" || !mysql.AllColumnPrivs.Has(tbl.privilege) {
+		return false, nil
+	}
+	var (
+		granted bool
+		denied  *visitInfo
+	)
+	for i := range vs {
+		v := &vs[i]
+		if v.column == "" || v.privilege != tbl.privilege || v.db != tbl.db || v.table != tbl.table {
+			continue
+		}
+		if pm.RequestVerification(activeRoles, v.db, v.table, v.column, v.privilege) {
+			granted = true
+		} else if denied == nil {
+			denied = v
+		}
+	}
+	if !granted {
+		return false, nil
+	}
+	if denied != nil {
+		if denied.err == nil {
+			return false, plannererrors.ErrPrivilegeCheckFail.GenWithStackByArgs(denied.privilege.String())
+		}
+		return false, denied.err
+	}
+	return true, nil
+}
+
 // VisitInfo4PrivCheck generates privilege check infos because privilege check of local temporary tables is different
 // with normal tables. `CREATE` statement needs `CREATE TEMPORARY TABLE` privilege from the database, and subsequent
 // statements do not need any privileges.
@@ -301,6 +350,50 @@ func CheckTableLock(ctx tablelock.TableLockReadContext, is infoschema.InfoSchema
 	return nil
 }
 
+// schemaWritePrivs are the privileges which modify the data or the schema objects of a database.
+var schemaWritePrivs = map[mysql.PrivilegeType]struct{}{
+	mysql.InsertPriv:        {},
+	mysql.UpdatePriv:        {},
+	mysql.DeletePriv:        {},
+	mysql.CreatePriv:        {},
+	mysql.DropPriv:          {},
+	mysql.AlterPriv:         {},
+	mysql.IndexPriv:         {},
+	mysql.CreateViewPriv:    {},
+	mysql.TriggerPriv:       {},
+	mysql.ReferencesPriv:    {},
+	mysql.CreateRoutinePriv: {},
+	mysql.AlterRoutinePriv:  {},
+}
+
+// CheckSchemaReadOnly checks whether the statement tries to modify a database which is set to read only.
+// Internal SQLs and `ALTER DATABASE ... SET READ ONLY` itself are not restricted.
+func CheckSchemaReadOnly(vars *variable.SessionVars, is infoschema.InfoSchema, node ast.Node, vs []visitInfo) error {
+	if vars.InRestrictedSQL {
+		return nil
+	}
+	if stmt, ok := node.(*ast.AlterDatabaseStmt); ok {
+		for _, opt := range stmt.Options {
+			if opt.Tp == ast.DatabaseSetReadOnly {
+				return nil
+			}
+		}
+	}
+	for i := range vs {
+		if vs[i].db == "" {
+			continue
+		}
+		if _, ok := schemaWritePrivs[vs[i].privilege]; !ok {
+			continue
+		}
+		dbInfo, ok := is.SchemaByName(model.NewCIStr(vs[i].db))
+		if ok && dbInfo.ReadOnly {
+			return plannererrors.ErrSchemaReadOnly.GenWithStackByArgs(dbInfo.Name.O)
+		}
+	}
+	return nil
+}
+
 func checkStableResultMode(sctx PlanContext) bool {
 	s := sctx.GetSessionVars()
 	st := s.StmtCtx
@@ -451,6 +544,7 @@ func postOptimize(ctx context.Context, sctx PlanContext, plan PhysicalPlan) (Phy
 		return nil, err
 	}
 	plan = eliminatePhysicalProjection(plan)
+	plan = rewriteVectorSearch(plan)
 	plan = InjectExtraProjection(plan)
 	mergeContinuousSelections(plan)
 	plan = eliminateUnionScanAndLock(sctx, plan)
@@ -480,6 +574,86 @@ func generateRuntimeFilter(sctx PlanContext, plan PhysicalPlan) {
 		zap.Duration("Cost", time.Since(startRFGenerator)))
 }
 
+// vectorDistanceMetrics maps the distance functions to the distance metrics of vector indexes.
+var vectorDistanceMetrics = map[string]model.DistanceMetric{
+	ast.VecCosineDistance: model.DistanceMetricCosine,
+	ast.VecL2Distance:     model.DistanceMetricL2,
+}
+
+// rewriteVectorSearch rewrites the full table scan under `ORDER BY vec_xxx_distance(col, query) LIMIT k`
+// to read the k nearest rows by the vector index on the column. The TopN is kept to sort the rows.
+func rewriteVectorSearch(plan PhysicalPlan) PhysicalPlan {
+	for i, child := range plan.Children() {
+		plan.SetChild(i, rewriteVectorSearch(child))
+	}
+	if topN, ok := plan.(*PhysicalTopN); ok {
+		if reader := buildVectorIndexReader(topN); reader != nil {
+			topN.SetChild(0, reader)
+		}
+	}
+	return plan
+}
+
+func buildVectorIndexReader(topN *PhysicalTopN) *PhysicalVectorIndexReader {
+	k := topN.Count + topN.Offset
+	if len(topN.ByItems) != 1 || topN.ByItems[0].Desc || k == 0 {
+		return nil
+	}
+	fn, ok := topN.ByItems[0].Expr.(*expression.ScalarFunction)
+	if !ok {
+		return nil
+	}
+	metric, ok := vectorDistanceMetrics[fn.FuncName.L]
+	if !ok {
+		return nil
+	}
+	args := fn.GetArgs()
+	col, ok1 := args[0].(*expression.Column)
+	query, ok2 := args[1].(*expression.Constant)
+	if !ok1 || !ok2 {
+		col, ok1 = args[1].(*expression.Column)
+		query, ok2 = args[0].(*expression.Constant)
+		if !ok1 || !ok2 {
+			return nil
+		}
+	}
+	// Only the full table scan without filters is rewritten, the rows filtered out
+	// can't be replaced by other rows in the vector index.
+	reader, ok := topN.Children()[0].(*PhysicalTableReader)
+	if !ok || reader.StoreType != kv.TiKV || !reader.Schema().Contains(col) {
+		return nil
+	}
+	ts, ok := reader.tablePlan.(*PhysicalTableScan)
+	if !ok || len(ts.filterCondition) > 0 || !ts.isFullScan() || ts.Table.GetPartitionInfo() != nil ||
+		ts.Table.TempTableType != model.TempTableNone || ts.Table.TableCacheStatusType != model.TableCacheStatusDisable {
+		return nil
+	}
+	for _, c := range ts.Columns {
+		if c.IsGenerated() && !c.GeneratedStored {
+			return nil
+		}
+	}
+	for _, idx := range ts.Table.Indices {
+		if !idx.IsVector() || idx.State != model.StatePublic || idx.Invisible || idx.VectorInfo.DistanceMetric != metric {
+			continue
+		}
+		if ts.Table.Columns[idx.Columns[0].Offset].ID != col.ID {
+			continue
+		}
+		p := PhysicalVectorIndexReader{
+			DBName:  ts.DBName,
+			Table:   ts.Table,
+			Index:   idx,
+			Columns: ts.Columns,
+			Query:   query,
+			K:       k,
+		}.Init(reader.SCtx(), reader.StatsInfo(), reader.QueryBlockOffset())
+		p.SetSchema(reader.Schema())
+		return p
+	}
+	return nil
+}
+
 // prunePhysicalColumns currently only work for MPP(HashJoin<-Exchange).
 // Here add projection instead of pruning columns directly for safety considerations.
 // And projection is cheap here for it saves the network cost and work in memory.
@@ -42,7 +42,7 @@ func TestCanBePrune(t *testing.T) {
 
 	tc := prepareTestCtx(t, "create table t (d datetime not null)", "to_days(d)")
 	lessThan := lessThanDataInt{data: []int64{733108, 733132}, maxvalue: false}
-	pruner := &rangePruner{lessThan, tc.col, tc.fn, monotoneModeNonStrict}
+	pruner := &rangePruner{lessThan, tc.col, tc.fn, monotoneModeNonStrict, nil}
 
 	queryExpr := tc.expr("d < '2000-03-08 00:00:00'")
 	result := partitionRangeForCNFExpr(tc.sctx, []expression.Expression{queryExpr}, pruner, fullRange(len(lessThan.data)))
@@ -65,7 +65,7 @@ func TestCanBePrune(t *testing.T) {
 	// );
 	tc = prepareTestCtx(t, "create table t (report_updated timestamp)", "unix_timestamp(report_updated)")
 	lessThan = lessThanDataInt{data: []int64{1199145600, 1207008000, 1262304000, 0}, maxvalue: true}
-	pruner = &rangePruner{lessThan, tc.col, tc.fn, monotoneModeStrict}
+	pruner = &rangePruner{lessThan, tc.col, tc.fn, monotoneModeStrict, nil}
 
 	queryExpr = tc.expr("report_updated > '2008-05-01 00:00:00'")
 	result = partitionRangeForCNFExpr(tc.sctx, []expression.Expression{queryExpr}, pruner, fullRange(len(lessThan.data)))
@@ -241,7 +241,7 @@ func (tc *testCtx) expr(expr string) expression.Expression {
 func TestPartitionRangeForExpr(t *testing.T) {
 	tc := prepareTestCtx(t, "create table t (a int)", "a")
 	lessThan := lessThanDataInt{data: []int64{4, 7, 11, 14, 17, 0}, maxvalue: true}
-	pruner := &rangePruner{lessThan, tc.columns[0], nil, monotoneModeInvalid}
+	pruner := &rangePruner{lessThan, tc.columns[0], nil, monotoneModeInvalid, nil}
 	cases := []struct {
 		input  string
 		result partitionRangeOR
@@ -270,6 +270,50 @@ func TestPartitionRangeForExpr(t *testing.T) {
 	}
 }
 
+func TestPartitionRangeForPartExpr(t *testing.T) {
+	lessThan := lessThanDataInt{data: []int64{4, 7, 11, 14, 17, 0}, maxvalue: true}
+	tc := prepareTestCtx(t, "create table t (a int, b int)", "a + b")
+	require.Nil(t, tc.col)
+	pruner := &rangePruner{lessThan, tc.col, tc.fn, monotoneModeInvalid, tc.fn.Clone()}
+	cases := []struct {
+		input  string
+		result partitionRangeOR
+	}{
+		{"a + b < 3", partitionRangeOR{{0, 1}}},
+		{"a + b >= 11", partitionRangeOR{{3, 6}}},
+		{"12 > a + b", partitionRangeOR{{0, 4}}},
+		{"a + b between 5 and 8", partitionRangeOR{{1, 3}}},
+		{"a + b in (1, 12)", partitionRangeOR{{0, 1}, {3, 4}}},
+		{"a + b > 14 or a + b < 4", partitionRangeOR{{0, 1}, {4, 6}}},
+		{"a + b is null", partitionRangeOR{{0, 1}}},
+		{"a + b > 1.5", partitionRangeOR{{0, 6}}},
+		{"a < 3", partitionRangeOR{{0, 6}}},
+		{"a - b < 3", partitionRangeOR{{0, 6}}},
+	}
+	for _, ca := range cases {
+		result := partitionRangeForExpr(tc.sctx, tc.expr(ca.input), pruner, fullRange(lessThan.length()))
+		require.Truef(t, equalPartitionRangeOR(ca.result, result), "unexpected: %v %v", ca.input, result)
+	}
+
+	tc = prepareTestCtx(t, "create table t (dt date)", "to_days(dt)")
+	lessThan = lessThanDataInt{data: []int64{737790, 737820, 737850, 0}, maxvalue: true}
+	pruner = &rangePruner{lessThan, tc.col, tc.fn, monotoneModeNonStrict, tc.fn.Clone()}
+	cases = []struct {
+		input  string
+		result partitionRangeOR
+	}{
+		{"to_days(dt) between 737800 and 737830", partitionRangeOR{{1, 3}}},
+		{"to_days(dt) < 737790", partitionRangeOR{{0, 1}}},
+		{"to_days(dt) in (737700, 737900)", partitionRangeOR{{0, 1}, {3, 4}}},
+		// Both the conditions on the column and on the partition expression are used.
+		{"dt >= '2020-01-01' and to_days(dt) < 737820", partitionRangeOR{{1, 2}}},
+	}
+	for _, ca := range cases {
+		result := partitionRangeForExpr(tc.sctx, tc.expr(ca.input), pruner, fullRange(lessThan.length()))
+		require.Truef(t, equalPartitionRangeOR(ca.result, result), "unexpected: %v %v", ca.input, result)
+	}
+}
+
 func equalPartitionRangeOR(x, y partitionRangeOR) bool {
 	if len(x) != len(y) {
 		return false
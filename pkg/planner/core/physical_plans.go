@@ -846,6 +846,30 @@ func (p *PhysicalMemTable) MemoryUsage() (sum int64) {
 	return
 }
 
+// PhysicalVectorIndexReader reads the K nearest rows of the query vector by the vector index.
+type PhysicalVectorIndexReader struct {
+	physicalSchemaProducer
+
+	DBName  model.CIStr
+	Table   *model.TableInfo
+	Index   *model.IndexInfo
+	Columns []*model.ColumnInfo
+	// Query is the query vector, which is the constant argument of the distance function.
+	Query *expression.Constant
+	K     uint64
+}
+
+// MemoryUsage return the memory usage of PhysicalVectorIndexReader
+func (p *PhysicalVectorIndexReader) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+
+	sum = p.physicalSchemaProducer.MemoryUsage() + p.DBName.MemoryUsage() + size.SizeOfPointer*3 + size.SizeOfSlice +
+		int64(cap(p.Columns))*size.SizeOfPointer + size.SizeOfUint64
+	return
+}
+
 // PhysicalTableScan represents a table scan plan.
 type PhysicalTableScan struct {
 	physicalSchemaProducer
@@ -940,6 +964,11 @@ func (ts *PhysicalTableScan) Clone() (PhysicalPlan, error) {
 	return clonedScan, nil
 }
 
+// RuntimeFilterList returns the runtime filters assigned to the table scan.
+func (ts *PhysicalTableScan) RuntimeFilterList() []*RuntimeFilter {
+	return ts.runtimeFilterList
+}
+
 // ExtractCorrelatedCols implements PhysicalPlan interface.
 func (ts *PhysicalTableScan) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
 	corCols := make([]*expression.CorrelatedColumn, 0, len(ts.AccessCondition)+len(ts.LateMaterializationFilterCondition))
@@ -1381,6 +1410,11 @@ func (p *PhysicalHashJoin) Clone() (PhysicalPlan, error) {
 	return cloned, nil
 }
 
+// RuntimeFilterList returns the runtime filters generated by the hash join.
+func (p *PhysicalHashJoin) RuntimeFilterList() []*RuntimeFilter {
+	return p.runtimeFilterList
+}
+
 // ExtractCorrelatedCols implements PhysicalPlan interface.
 func (p *PhysicalHashJoin) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
 	corCols := make([]*expression.CorrelatedColumn, 0, len(p.EqualConditions)+len(p.NAEqualConditions)+len(p.LeftConditions)+len(p.RightConditions)+len(p.OtherConditions))
@@ -2170,12 +2204,15 @@ type PhysicalSelection struct {
 	// Please see https://github.com/pingcap/tidb/issues/36243 for more details.
 	fromDataSource bool
 
-	// todo Since the feature of adding filter operators has not yet been implemented,
-	// the following code for this function will not be used for now.
 	// The flag indicates whether this Selection is used for RuntimeFilter
-	// True: Used for RuntimeFilter
+	// True: Used for RuntimeFilter, the conditions of RF are appended when executing
 	// False: Only for normal conditions
-	// hasRFConditions bool
+	hasRFConditions bool
+}
+
+// HasRFConditions returns whether the conditions of runtime filter are appended to this Selection when executing.
+func (p *PhysicalSelection) HasRFConditions() bool {
+	return p.hasRFConditions
 }
 
 // Clone implements PhysicalPlan interface.
@@ -2187,6 +2224,7 @@ func (p *PhysicalSelection) Clone() (PhysicalPlan, error) {
 	}
 	cloned.basePhysicalPlan = *base
 	cloned.Conditions = util.CloneExprs(p.Conditions)
+	cloned.hasRFConditions = p.hasRFConditions
 	return cloned, nil
 }
 
@@ -2483,6 +2521,7 @@ type PhysicalShowDDLJobs struct {
 	physicalSchemaProducer
 
 	JobNumber int64
+	Extractor *DDLJobsExtractor
 }
 
 // MemoryUsage return the memory usage of PhysicalShowDDLJobs
@@ -2490,7 +2529,7 @@ func (p *PhysicalShowDDLJobs) MemoryUsage() (sum int64) {
 	if p == nil {
 		return
 	}
-	return p.physicalSchemaProducer.MemoryUsage() + size.SizeOfInt64
+	return p.physicalSchemaProducer.MemoryUsage() + size.SizeOfInt64 + size.SizeOfPointer
 }
 
 // BuildMergeJoinPlan builds a PhysicalMergeJoin from the given fields. Currently, it is only used for test purpose.
@@ -121,7 +121,15 @@ func planCachePreprocess(ctx context.Context, sctx sessionctx.Context, isNonPrep
 		ret := &PreprocessorReturn{InfoSchema: is}
 		err := Preprocess(ctx, sctx, stmtAst.Stmt, InPrepare, WithPreprocessorReturn(ret))
 		if err != nil {
-			return plannererrors.ErrSchemaChanged.GenWithStack("Schema change caused error: %s", err.Error())
+			// The AST may still hold the information of the old schema, so re-prepare the statement from its text
+			// like MySQL does. It fails only if the statement is invalid in the new schema.
+			if err = rePrepareStmt(ctx, sctx, isNonPrepared, is, stmt); err != nil {
+				return plannererrors.ErrSchemaChanged.GenWithStack("Schema change caused error: %s", err.Error())
+			}
+			stmtAst = stmt.PreparedAst
+			if err = SetParameterValuesIntoSCtx(sctx.GetPlanCtx(), isNonPrepared, stmtAst.Params, params); err != nil {
+				return errors.Trace(err)
+			}
 		}
 		stmtAst.SchemaVersion = is.SchemaMetaVersion()
 	}
@@ -140,6 +148,39 @@ func planCachePreprocess(ctx context.Context, sctx sessionctx.Context, isNonPrep
 	return nil
 }
 
+// rePrepareStmt parses the text of the prepared statement again and replaces the AST and the information derived from
+// it with the ones resolved in the new schema. The statement keeps its ID, database and bindings.
+func rePrepareStmt(ctx context.Context, sctx sessionctx.Context, isNonPrepared bool, is infoschema.InfoSchema, stmt *PlanCacheStmt) error {
+	vars := sctx.GetSessionVars()
+	// the names in the statement are resolved in the database where it's prepared.
+	if vars.CurrentDB != stmt.StmtDB {
+		currentDB := vars.CurrentDB
+		vars.CurrentDB = stmt.StmtDB
+		defer func() {
+			vars.CurrentDB = currentDB
+		}()
+	}
+	stmtNode, err := ParseParameterizedSQL(sctx, stmt.StmtText)
+	if err != nil {
+		return err
+	}
+	newStmt, _, paramCount, err := GeneratePlanCacheStmtWithAST(ctx, sctx, !isNonPrepared, stmt.StmtText, stmtNode, is)
+	if err != nil {
+		return err
+	}
+	if paramCount != len(stmt.PreparedAst.Params) {
+		return errors.Trace(plannererrors.ErrWrongParamCount)
+	}
+	stmt.PreparedAst = newStmt.PreparedAst
+	stmt.VisitInfos = newStmt.VisitInfos
+	stmt.StmtCacheable = newStmt.StmtCacheable
+	stmt.UncacheableReason = newStmt.UncacheableReason
+	stmt.QueryFeatures = newStmt.QueryFeatures
+	stmt.ForUpdateRead = newStmt.ForUpdateRead
+	stmt.SnapshotTSEvaluator = newStmt.SnapshotTSEvaluator
+	return nil
+}
+
 // GetPlanFromSessionPlanCache is the entry point of Plan Cache.
 // It tries to get a valid cached plan from this session's plan cache.
 // If there is no such a plan, it'll call the optimizer to generate a new one.
@@ -194,6 +235,9 @@ func GetPlanFromSessionPlanCache(ctx context.Context, sctx sessionctx.Context,
 		}
 	}
 
+	if stmtCtx.UseCache && stmtAst.CachedPlan != nil && isCachedPointPlanMasked(sctx.GetPlanCtx(), stmtAst.CachedPlan.(Plan)) {
+		stmtAst.CachedPlan = nil
+	}
 	if stmtCtx.UseCache && stmtAst.CachedPlan != nil { // special code path for fast point plan
 		if plan, names, ok, err := getCachedPointPlan(stmtAst, sessVars, stmtCtx); ok {
 			return plan, names, err
@@ -752,8 +796,10 @@ func CheckPreparedPriv(sctx sessionctx.Context, stmt *PlanCacheStmt, is infosche
 			return err
 		}
 	}
-	err := CheckTableLock(sctx, is, stmt.VisitInfos)
-	return err
+	if err := CheckTableLock(sctx, is, stmt.VisitInfos); err != nil {
+		return err
+	}
+	return CheckSchemaReadOnly(sctx.GetSessionVars(), is, stmt.PreparedAst.Stmt, stmt.VisitInfos)
 }
 
 // tryCachePointPlan will try to cache point execution plan, there may be some
@@ -790,6 +836,19 @@ func tryCachePointPlan(_ context.Context, sctx PlanContext,
 	return err
 }
 
+// isCachedPointPlanMasked checks whether the table read by the cached point plan has masked columns for the current
+// user, since the masking policies or the active roles may have changed after the plan is cached.
+func isCachedPointPlanMasked(sctx PlanContext, p Plan) bool {
+	var pointGet *PointGetPlan
+	switch x := p.(type) {
+	case *PointGetPlan:
+		pointGet = x
+	case *Update:
+		pointGet, _ = x.SelectPlan.(*PointGetPlan)
+	}
+	return pointGet != nil && pointGet.TblInfo != nil && hasMaskingPolicies(sctx, pointGet.dbName, pointGet.TblInfo.Name.L)
+}
+
 // IsPointGetPlanShortPathOK check if we can execute using plan cached in prepared structure
 // Be careful with the short path, current precondition is ths cached plan satisfying
 // IsPointGetWithPKOrUniqueKeyByAutoCommit
@@ -807,6 +866,10 @@ func IsPointGetPlanShortPathOK(sctx sessionctx.Context, is infoschema.InfoSchema
 		stmt.ColumnInfos = nil
 		return false, nil
 	}
+	if isCachedPointPlanMasked(sctx.GetPlanCtx(), stmtAst.CachedPlan.(Plan)) {
+		stmtAst.CachedPlan = nil
+		return false, nil
+	}
 	// maybe we'd better check cached plan type here, current
 	// only point select/update will be cached, see "getPhysicalPlan" func
 	var ok bool
@@ -1552,3 +1552,45 @@ func BenchmarkNonPreparedPlanCacheDML(b *testing.B) {
 		tk.MustExec("delete from t where a = 2")
 	}
 }
+
+func TestConsistentStmtTimeZone(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@time_zone='UTC'")
+	tk.MustExec(`create table t (a timestamp, b int, key(a)) partition by range (unix_timestamp(a)) (
+		partition p0 values less than (unix_timestamp('2020-01-01 12:00:00')),
+		partition p1 values less than (maxvalue))`)
+	tk.MustExec("insert into t values ('2020-01-01 10:00:00', 1), ('2020-01-01 14:00:00', 2)")
+
+	queries := []string{
+		"select /*+ SET_VAR(time_zone='+08:00') */ a, b from t where a = '2020-01-01 18:00:00'",
+		"select /*+ SET_VAR(time_zone='+08:00') use_index(t, a) */ a, b from t where a = '2020-01-01 18:00:00'",
+		"select /*+ SET_VAR(time_zone='+08:00') */ a, b from t partition (p0) where a < '2020-01-01 20:00:00'",
+	}
+	tk.MustExec("set @@tidb_consistent_stmt_time_zone = 1")
+	for _, q := range queries {
+		tk.MustQuery(q).Check(testkit.Rows("2020-01-01 18:00:00 1"))
+	}
+	tk.MustQuery("select @@time_zone").Check(testkit.Rows("UTC"))
+
+	// The zones with the same offset do not share the plan when the mode is on.
+	tk.MustExec("create table t2 (a timestamp, b int, key(a))")
+	tk.MustExec("insert into t2 select * from t")
+	tk.MustExec("prepare st from 'select b from t2 where a < ?'")
+	tk.MustExec("set @a = '2020-01-01 20:00:00'")
+	for _, mode := range []string{"0", "1"} {
+		tk.MustExec("set @@tidb_consistent_stmt_time_zone = " + mode)
+		tk.MustExec("set @@time_zone = 'Asia/Shanghai'")
+		tk.MustQuery("execute st using @a").Check(testkit.Rows("1"))
+		tk.MustQuery("execute st using @a").Check(testkit.Rows("1"))
+		tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+		tk.MustExec("set @@time_zone = 'Etc/GMT-8'")
+		tk.MustQuery("execute st using @a").Check(testkit.Rows("1"))
+		if mode == "0" {
+			tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+		} else {
+			tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"math"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 	"unsafe"
 
@@ -34,6 +35,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/planner/util"
 	"github.com/pingcap/tidb/pkg/planner/util/fixcontrol"
+	"github.com/pingcap/tidb/pkg/privilege"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/types"
@@ -212,6 +214,7 @@ type planCacheKey struct {
 	lastUpdatedSchemaVersion int64
 	sqlMode                  mysql.SQLMode
 	timezoneOffset           int
+	timezoneName             string // only set when tidb_consistent_stmt_time_zone is on, zones with the same offset may convert TIMESTAMP differently.
 	isolationReadEngines     map[kv.StoreType]struct{}
 	selectLimit              uint64
 	bindSQL                  string
@@ -220,6 +223,9 @@ type planCacheKey struct {
 	restrictedReadOnly       bool
 	TiDBSuperReadOnly        bool
 	exprBlacklistTS          int64 // expr-pushdown-blacklist can affect query optimization, so we need to consider it in plan cache.
+	// The masking projection depends on the masking policies and the active roles of the user.
+	maskingPolicyVersion int64
+	activeRoles          string
 
 	memoryUsage int64 // Do not include in hash
 	hash        []byte
@@ -238,6 +244,7 @@ func (key *planCacheKey) Hash() []byte {
 		key.hash = codec.EncodeInt(key.hash, key.lastUpdatedSchemaVersion)
 		key.hash = codec.EncodeInt(key.hash, int64(key.sqlMode))
 		key.hash = codec.EncodeInt(key.hash, int64(key.timezoneOffset))
+		key.hash = append(key.hash, hack.Slice(key.timezoneName)...)
 		if _, ok := key.isolationReadEngines[kv.TiDB]; ok {
 			key.hash = append(key.hash, kv.TiDB.Name()...)
 		}
@@ -254,6 +261,8 @@ func (key *planCacheKey) Hash() []byte {
 		key.hash = append(key.hash, hack.Slice(strconv.FormatBool(key.restrictedReadOnly))...)
 		key.hash = append(key.hash, hack.Slice(strconv.FormatBool(key.TiDBSuperReadOnly))...)
 		key.hash = codec.EncodeInt(key.hash, key.exprBlacklistTS)
+		key.hash = codec.EncodeInt(key.hash, key.maskingPolicyVersion)
+		key.hash = append(key.hash, hack.Slice(key.activeRoles)...)
 	}
 	return key.hash
 }
@@ -269,7 +278,7 @@ func (key *planCacheKey) MemoryUsage() (sum int64) {
 	if key.memoryUsage > 0 {
 		return key.memoryUsage
 	}
-	sum = emptyPlanCacheKeySize + int64(len(key.database)+len(key.stmtText)+len(key.bindSQL)+len(key.connCollation)) +
+	sum = emptyPlanCacheKeySize + int64(len(key.database)+len(key.stmtText)+len(key.bindSQL)+len(key.connCollation)+len(key.timezoneName)+len(key.activeRoles)) +
 		int64(len(key.isolationReadEngines))*size.SizeOfUint8 + int64(cap(key.hash))
 	key.memoryUsage = sum
 	return
@@ -309,6 +318,10 @@ func NewPlanCacheKey(sessionVars *variable.SessionVars, stmtText, stmtDB string,
 	if sessionVars.TimeZone != nil {
 		_, timezoneOffset = time.Now().In(sessionVars.TimeZone).Zone()
 	}
+	timezoneName := ""
+	if sessionVars.ConsistentStmtTimeZone {
+		timezoneName = sessionVars.Location().String()
+	}
 	_, connCollation := sessionVars.GetCharsetInfo()
 
 	key := &planCacheKey{
@@ -319,6 +332,7 @@ func NewPlanCacheKey(sessionVars *variable.SessionVars, stmtText, stmtDB string,
 		lastUpdatedSchemaVersion: lastUpdatedSchemaVersion,
 		sqlMode:                  sessionVars.SQLMode,
 		timezoneOffset:           timezoneOffset,
+		timezoneName:             timezoneName,
 		isolationReadEngines:     make(map[kv.StoreType]struct{}),
 		selectLimit:              sessionVars.SelectLimit,
 		bindSQL:                  bindSQL,
@@ -327,6 +341,15 @@ func NewPlanCacheKey(sessionVars *variable.SessionVars, stmtText, stmtDB string,
 		restrictedReadOnly:       variable.RestrictedReadOnly.Load(),
 		TiDBSuperReadOnly:        variable.VarTiDBSuperReadOnly.Load(),
 		exprBlacklistTS:          exprBlacklistTS,
+		maskingPolicyVersion:     privilege.MaskingPolicyVersion.Load(),
+	}
+	if len(sessionVars.ActiveRoles) > 0 {
+		roles := make([]string, 0, len(sessionVars.ActiveRoles))
+		for _, role := range sessionVars.ActiveRoles {
+			roles = append(roles, role.String())
+		}
+		slices.Sort(roles)
+		key.activeRoles = strings.Join(roles, ",")
 	}
 	for k, v := range sessionVars.IsolationReadEngines {
 		key.isolationReadEngines[k] = v
@@ -572,6 +595,7 @@ func isSafePointGetPath4PlanCache(sctx PlanContext, path *util.AccessPath) bool
 
 	// TODO: enable this fix control switch by default after more test cases are added.
 	if sctx != nil && sctx.GetSessionVars() != nil && sctx.GetSessionVars().OptimizerFixControl != nil {
+		sctx.GetSessionVars().StmtCtx.RecordFixControl(fixcontrol.Fix44830)
 		fixControlOK := fixcontrol.GetBoolWithDefault(sctx.GetSessionVars().GetOptimizerFixControlMap(), fixcontrol.Fix44830, false)
 		if fixControlOK && (isSafePointGetPath4PlanCacheScenario2(path) || isSafePointGetPath4PlanCacheScenario3(path)) {
 			return true
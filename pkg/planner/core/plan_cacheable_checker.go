@@ -576,6 +576,8 @@ func isPhysicalPlanCacheable(sctx PlanContext, p PhysicalPlan, paramNum, limitPa
 		return false, "get a Shuffle plan"
 	case *PhysicalMemTable:
 		return false, "PhysicalMemTable plan is un-cacheable"
+	case *PhysicalVectorIndexReader:
+		return false, "PhysicalVectorIndexReader plan is un-cacheable"
 	case *PhysicalIndexMergeReader:
 		if x.AccessMVIndex && !enablePlanCacheForGeneratedCols(sctx) {
 			return false, "the plan with IndexMerge accessing Multi-Valued Index is un-cacheable"
@@ -609,6 +611,7 @@ func getMaxParamLimit(sctx PlanContext) int {
 	if sctx == nil || sctx.GetSessionVars() == nil || sctx.GetSessionVars().OptimizerFixControl == nil {
 		return v
 	}
+	sctx.GetSessionVars().StmtCtx.RecordFixControl(fixcontrol.Fix44823)
 	n := fixcontrol.GetIntWithDefault(sctx.GetSessionVars().GetOptimizerFixControlMap(), fixcontrol.Fix44823, int64(v))
 	if n == 0 {
 		v = math.MaxInt32 // no limitation
@@ -625,6 +628,7 @@ func enablePlanCacheForGeneratedCols(sctx PlanContext) bool {
 	if sctx == nil || sctx.GetSessionVars() == nil || sctx.GetSessionVars().GetOptimizerFixControlMap() == nil {
 		return false
 	}
+	sctx.GetSessionVars().StmtCtx.RecordFixControl(fixcontrol.Fix45798)
 	return fixcontrol.GetBoolWithDefault(sctx.GetSessionVars().GetOptimizerFixControlMap(), fixcontrol.Fix45798, false)
 }
 
@@ -19,6 +19,7 @@ import (
 	"math"
 	"strconv"
 
+	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/expression/aggregation"
 	"github.com/pingcap/tidb/pkg/kv"
@@ -28,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/util"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/util/paging"
+	"github.com/pingcap/tidb/pkg/util/tiflashcompute"
 	"github.com/pingcap/tipb/go-tipb"
 )
 
@@ -967,11 +969,11 @@ func getTaskScanFactorVer2(p PhysicalPlan, storeType kv.StoreType, taskType prop
 		return defaultVer2Factors.TiDBTemp
 	}
 	if storeType == kv.TiFlash {
-		return defaultVer2Factors.TiFlashScan
+		return getTiFlashScanFactorVer2(p)
 	}
 	switch taskType {
 	case property.MppTaskType: // TiFlash
-		return defaultVer2Factors.TiFlashScan
+		return getTiFlashScanFactorVer2(p)
 	default: // TiKV
 		var desc bool
 		if indexScan, ok := p.(*PhysicalIndexScan); ok {
@@ -987,6 +989,25 @@ func getTaskScanFactorVer2(p PhysicalPlan, storeType kv.StoreType, taskType prop
 	}
 }
 
+// getTiFlashScanFactorVer2 returns the scan factor of TiFlash. In disaggregated TiFlash, the data missing in the local cache
+// of tiflash_compute nodes is read from S3, so the factor is scaled by the recent cache hit ratio of the table.
+func getTiFlashScanFactorVer2(p PhysicalPlan) costVer2Factor {
+	factor := defaultVer2Factors.TiFlashScan
+	if !config.GetGlobalConfig().DisaggregatedTiFlash {
+		return factor
+	}
+	tblInfo := getTableInfo(p)
+	if tblInfo == nil {
+		return factor
+	}
+	hitRatio, ok := tiflashcompute.GetTableCacheHitRatio(tblInfo.ID)
+	if !ok {
+		return factor
+	}
+	factor.Value *= hitRatio + (1-hitRatio)*p.SCtx().GetSessionVars().TiFlashS3ReadFactor
+	return factor
+}
+
 func getTaskNetFactorVer2(p PhysicalPlan, _ property.TaskType) costVer2Factor {
 	if isTemporaryTable(getTableInfo(p)) {
 		return defaultVer2Factors.TiDBTemp
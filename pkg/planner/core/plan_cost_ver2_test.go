@@ -23,12 +23,16 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/planner"
 	"github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/planner/property"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/tiflashcompute"
 	"github.com/stretchr/testify/require"
 )
 
@@ -147,6 +151,44 @@ func TestCostModelTraceVer2(t *testing.T) {
 	}
 }
 
+func TestTiFlashScanCostWithCacheResidency(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create table t (a int, b int)`)
+	tk.MustExec(`set @@tidb_cost_model_version=2`)
+	tbl, err := domain.GetDomain(tk.Session()).InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tblInfo := tbl.Meta()
+	tblInfo.TiFlashReplica = &model.TiFlashReplicaInfo{Count: 1, Available: true}
+	defer tiflashcompute.RemoveTableCacheResidency(tblInfo.ID)
+
+	getCost := func() float64 {
+		rs := tk.MustQuery("explain format='verbose' select /*+ read_from_storage(tiflash[t]) */ * from t").Rows()
+		cost, err := strconv.ParseFloat(rs[0][2].(string), 64)
+		require.NoError(t, err)
+		return cost
+	}
+	defer config.RestoreFunc()()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.DisaggregatedTiFlash = true
+	})
+	baseCost := getCost()
+	// The table has been read from S3 entirely.
+	tiflashcompute.UpdateTableCacheResidency(tblInfo.ID, 0, 100)
+	coldCost := getCost()
+	require.Greater(t, coldCost, baseCost)
+	tk.MustExec(`set @@tidb_opt_tiflash_s3_read_factor=1`)
+	require.Equal(t, baseCost, getCost())
+	tk.MustExec(`set @@tidb_opt_tiflash_s3_read_factor=default`)
+
+	// The cache residency is ignored if TiFlash is not disaggregated.
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.DisaggregatedTiFlash = false
+	})
+	require.Less(t, getCost(), coldCost)
+}
+
 func BenchmarkGetPlanCost(b *testing.B) {
 	store := testkit.CreateMockStore(b)
 	tk := testkit.NewTestKit(b, store)
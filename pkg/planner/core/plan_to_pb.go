@@ -259,11 +259,14 @@ func (p *PhysicalTableScan) ToPB(ctx PlanContext, storeType kv.StoreType) (*tipb
 	}
 
 	var err error
-	tsExec.RuntimeFilterList, err = RuntimeFilterListToPB(ctx, p.runtimeFilterList, ctx.GetClient())
-	if err != nil {
-		return nil, errors.Trace(err)
+	// the runtime filters of TiKV scan are evaluated by TiDB and sent as the conditions of selection
+	if storeType == kv.TiFlash {
+		tsExec.RuntimeFilterList, err = RuntimeFilterListToPB(ctx, p.runtimeFilterList, ctx.GetClient())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tsExec.MaxWaitTimeMs = int32(p.maxWaitTimeMs)
 	}
-	tsExec.MaxWaitTimeMs = int32(p.maxWaitTimeMs)
 
 	if p.isPartition {
 		tsExec.TableId = p.physicalTableID
@@ -15,10 +15,12 @@
 package core
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -37,12 +39,14 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/opcode"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/planner/property"
 	"github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/planner/util/fixcontrol"
 	"github.com/pingcap/tidb/pkg/privilege"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
@@ -56,6 +60,7 @@ import (
 	driver "github.com/pingcap/tidb/pkg/types/parser_driver"
 	util2 "github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
 	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
@@ -185,6 +190,10 @@ type cteInfo struct {
 	containAggOrWindow bool
 	// Compute in preprocess phase. Record how many consumers the current CTE has
 	consumerCount int
+	// digest identifies the definition of this CTE, see CTEClass.Digest.
+	digest string
+	// hasParamMarker indicates whether the definition of this CTE contains param markers.
+	hasParamMarker bool
 }
 
 type subQueryCtx = uint64
@@ -254,6 +263,9 @@ type PlanBuilder struct {
 	renamingViewName string
 	// isCreateView indicates whether the query is create view.
 	isCreateView bool
+	// isRefreshMaterializedView indicates whether the plan is built to write the refreshed data
+	// into a materialized view, which can't be written by the other statements.
+	isRefreshMaterializedView bool
 
 	// evalDefaultExpr needs this information to find the corresponding column.
 	// It stores the OutputNames before buildProjection.
@@ -268,7 +280,9 @@ type PlanBuilder struct {
 	// isForUpdateRead should be true in either of the following situations
 	// 1. use `inside insert`, `update`, `delete` or `select for update` statement
 	// 2. isolation level is RC
-	isForUpdateRead             bool
+	isForUpdateRead bool
+	// maskedTables are the IDs of the tables read through the masking projection.
+	maskedTables                map[int64]struct{}
 	allocIDForCTEStorage        int
 	buildingRecursivePartForCTE bool
 	buildingCTE                 bool
@@ -535,6 +549,9 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		if x.SelectIntoOpt != nil {
 			return b.buildSelectInto(ctx, x)
 		}
+		if p, ok, err := b.buildSelectFromMaterializedView(ctx, x); ok {
+			return p, err
+		}
 		return b.buildSelect(ctx, x)
 	case *ast.SetOprStmt:
 		return b.buildSetOpr(ctx, x)
@@ -555,7 +572,9 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.AlterRangeStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
 		*ast.RenameUserStmt, *ast.NonTransactionalDMLStmt, *ast.SetSessionStatesStmt, *ast.SetResourceGroupStmt,
-		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt:
+		*ast.ImportIntoActionStmt, *ast.DistJobActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt,
+		*ast.CreateMaskingPolicyStmt, *ast.DropMaskingPolicyStmt, *ast.CreateServerStmt, *ast.DropServerStmt,
+		*ast.ProcedureInfo, *ast.DropProcedureStmt, *ast.CreateEventStmt, *ast.DropEventStmt, *ast.RecommendIndexStmt:
 		return b.buildSimple(ctx, node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -1034,6 +1053,21 @@ func isForUpdateReadSelectLock(lock *ast.SelectLockInfo) bool {
 
 // getLatestIndexInfo gets the index info of latest schema version from given table id,
 // it returns nil if the schema version is not changed
+// isHypoIndexValid checks whether the columns of the hypo index still match the table,
+// the table may be altered or recreated after the hypo index is created.
+func isHypoIndexValid(tblInfo *model.TableInfo, index *model.IndexInfo) bool {
+	for _, idxCol := range index.Columns {
+		if idxCol.Offset >= len(tblInfo.Columns) {
+			return false
+		}
+		col := tblInfo.Columns[idxCol.Offset]
+		if col.Name.L != idxCol.Name.L || col.State != model.StatePublic || col.Hidden {
+			return false
+		}
+	}
+	return true
+}
+
 func getLatestIndexInfo(ctx PlanContext, id int64, startVer int64) (map[int64]*model.IndexInfo, bool, error) {
 	dom := domain.GetDomain(ctx)
 	if dom == nil {
@@ -1074,8 +1108,9 @@ func getPossibleAccessPaths(ctx PlanContext, tableHints *hint.PlanHints, indexHi
 		publicPaths = append(publicPaths, genTiFlashPath(tblInfo))
 	}
 
-	// consider hypo TiFlash replicas
-	if ctx.GetSessionVars().StmtCtx.InExplainStmt && ctx.GetSessionVars().HypoTiFlashReplicas != nil {
+	// consider hypo TiFlash replicas, they can't be used by EXPLAIN ANALYZE which runs the plan actually.
+	inExplainOnly := ctx.GetSessionVars().StmtCtx.InExplainStmt && !ctx.GetSessionVars().StmtCtx.InExplainAnalyzeStmt
+	if inExplainOnly && ctx.GetSessionVars().HypoTiFlashReplicas != nil {
 		hypoReplicas := ctx.GetSessionVars().HypoTiFlashReplicas
 		originalTableName := tblInfo.Name.L
 		if hypoReplicas[dbName.L] != nil {
@@ -1101,6 +1136,11 @@ func getPossibleAccessPaths(ctx PlanContext, tableHints *hint.PlanHints, indexHi
 			if tblInfo.IsCommonHandle && index.Primary {
 				continue
 			}
+			// The fulltext index is only used by MATCH ... AGAINST, which is evaluated by the executor.
+			// The vector index is only used by the vector search, see rewriteVectorSearch.
+			if index.IsFullText() || index.IsVector() {
+				continue
+			}
 			if check && latestIndexes == nil {
 				latestIndexes, check, err = getLatestIndexInfo(ctx, tblInfo.ID, 0)
 				if err != nil {
@@ -1118,10 +1158,13 @@ func getPossibleAccessPaths(ctx PlanContext, tableHints *hint.PlanHints, indexHi
 
 	// consider hypo-indexes
 	hypoIndexes := ctx.GetSessionVars().HypoIndexes
-	if ctx.GetSessionVars().StmtCtx.InExplainStmt && hypoIndexes != nil {
+	if inExplainOnly && hypoIndexes != nil {
 		originalTableName := tblInfo.Name.L
 		if hypoIndexes[dbName.L] != nil && hypoIndexes[dbName.L][originalTableName] != nil {
 			for _, index := range hypoIndexes[dbName.L][originalTableName] {
+				if !isHypoIndexValid(tblInfo, index) {
+					continue
+				}
 				publicPaths = append(publicPaths, &util.AccessPath{Index: index})
 			}
 		}
@@ -1339,6 +1382,17 @@ func setExtraPhysTblIDColsOnDataSource(p LogicalPlan, tblID2PhysTblIDCol map[int
 		for _, child := range p.Children() {
 			setExtraPhysTblIDColsOnDataSource(child, tblID2PhysTblIDCol)
 		}
+		// The masking projection passes the extra physical table ID column of the data source through.
+		if proj, ok := p.(*LogicalProjection); ok && proj.Proj4Masking {
+			child := proj.Children()[0]
+			for i, col := range child.Schema().Columns {
+				if col.ID == model.ExtraPhysTblID && !proj.Schema().Contains(col) {
+					proj.Exprs = append(proj.Exprs, col)
+					proj.Schema().Append(col)
+					proj.names = append(proj.names, child.OutputNames()[i])
+				}
+			}
+		}
 	}
 }
 
@@ -1372,6 +1426,29 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 		if err != nil {
 			return ret, err
 		}
+	case ast.AdminCheckTableOnline:
+		for _, tbl := range as.Tables {
+			// The online check splits the table into ranges by the integer handle.
+			if tbl.TableInfo.IsCommonHandle {
+				return nil, errors.Errorf("admin check table online is not supported on table %s with clustered index", tbl.Name.O)
+			}
+		}
+		p := &CheckTableOnline{Tables: as.Tables}
+		p.setSchemaAndNames(buildCheckTableOnlineSchema())
+		ret = p
+	case ast.AdminCancelCheckTableJobs:
+		p := &CancelCheckTableJobs{JobIDs: as.JobIDs}
+		p.setSchemaAndNames(buildCommandOnDDLJobsFields())
+		ret = p
+	case ast.AdminDiffSchema:
+		opt := as.DiffSchema
+		p := &DiffSchema{FromTS: opt.FromTS, ToTS: opt.ToTS, DBName: opt.Schema, TblName: opt.Table}
+		p.setSchemaAndNames(buildDiffSchemaSchema())
+		ret = p
+	case ast.AdminCompareOptimizerTrace:
+		p := &CompareOptimizerTrace{PlanDigests: as.PlanDigests}
+		p.setSchemaAndNames(buildCompareOptimizerTraceSchema())
+		ret = p
 	case ast.AdminRecoverIndex:
 		p := &RecoverIndex{Table: as.Tables[0], IndexName: as.Index}
 		p.setSchemaAndNames(buildRecoverIndexFields())
@@ -1442,6 +1519,8 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 		return &ReloadExprPushdownBlacklist{}, nil
 	case ast.AdminReloadOptRuleBlacklist:
 		return &ReloadOptRuleBlacklist{}, nil
+	case ast.AdminReloadMetricTables:
+		ret = &ReloadMetricTables{}
 	case ast.AdminPluginEnable:
 		return &AdminPlugins{Action: Enable, Plugins: as.Plugins}, nil
 	case ast.AdminPluginDisable:
@@ -1463,7 +1542,8 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 		return &Simple{Statement: as}, nil
 	case ast.AdminFlushPlanCache:
 		return &Simple{Statement: as}, nil
-	case ast.AdminSetBDRRole, ast.AdminUnsetBDRRole:
+	case ast.AdminSetBDRRole, ast.AdminUnsetBDRRole, ast.AdminRotateEncryptionKey, ast.AdminWaitTiFlashReplica,
+		ast.AdminPauseLogBackup, ast.AdminResumeLogBackup:
 		ret = &Simple{Statement: as}
 	case ast.AdminShowBDRRole:
 		p := &AdminShowBDRRole{}
@@ -1652,6 +1732,11 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 			// Skip checking clustered index.
 			continue
 		}
+		if idxInfo.IsFullText() || idxInfo.IsVector() {
+			// Skip checking fulltext index, the values of which are the tokens of the row values.
+			// Skip checking vector index, which has no values.
+			continue
+		}
 		if idxInfo.State != model.StatePublic {
 			logutil.Logger(ctx).Info("build physical index lookup reader, the index isn't public",
 				zap.String("index", idxInfo.Name.O),
@@ -2149,6 +2234,10 @@ func getModifiedIndexesInfoForAnalyze(
 		if originIdx.State != model.StatePublic {
 			continue
 		}
+		if originIdx.IsFullText() || originIdx.IsVector() {
+			// The fulltext and vector indexes are not used by the optimizer, so they're not analyzed.
+			continue
+		}
 		if originIdx.MVIndex {
 			independentIdxsInfo = append(independentIdxsInfo, originIdx)
 			continue
@@ -2923,6 +3012,18 @@ func buildTableRegionsSchema() (*expression.Schema, types.NameSlice) {
 	return schema.col2Schema(), schema.names
 }
 
+func buildTableRegionsSummarySchema() (*expression.Schema, types.NameSlice) {
+	schema := newColumnsWithNames(7)
+	schema.Append(buildColumnWithName("", "STORE_ID", mysql.TypeLonglong, 4))
+	schema.Append(buildColumnWithName("", "LEADER_COUNT", mysql.TypeLonglong, 4))
+	schema.Append(buildColumnWithName("", "PEER_COUNT", mysql.TypeLonglong, 4))
+	schema.Append(buildColumnWithName("", "WRITTEN_BYTES", mysql.TypeLonglong, 4))
+	schema.Append(buildColumnWithName("", "READ_BYTES", mysql.TypeLonglong, 4))
+	schema.Append(buildColumnWithName("", "APPROXIMATE_SIZE(MB)", mysql.TypeLonglong, 4))
+	schema.Append(buildColumnWithName("", "APPROXIMATE_KEYS", mysql.TypeLonglong, 4))
+	return schema.col2Schema(), schema.names
+}
+
 func buildSplitRegionsSchema() (*expression.Schema, types.NameSlice) {
 	schema := newColumnsWithNames(2)
 	schema.Append(buildColumnWithName("", "TOTAL_SPLIT_REGION", mysql.TypeLonglong, 4))
@@ -3052,6 +3153,18 @@ func buildCalibrateResourceSchema() (*expression.Schema, types.NameSlice) {
 	return schema.col2Schema(), schema.names
 }
 
+func buildRecommendIndexSchema() (*expression.Schema, types.NameSlice) {
+	schema := newColumnsWithNames(7)
+	schema.Append(buildColumnWithName("", "Database", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "Table", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "Index_name", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "Index_columns", mysql.TypeVarchar, 256))
+	schema.Append(buildColumnWithName("", "Est_benefit", mysql.TypeDouble, 8))
+	schema.Append(buildColumnWithName("", "Top_impacted_query", mysql.TypeVarchar, 4096))
+	schema.Append(buildColumnWithName("", "Create_index_statement", mysql.TypeVarchar, 4096))
+	return schema.col2Schema(), schema.names
+}
+
 func buildAddQueryWatchSchema() (*expression.Schema, types.NameSlice) {
 	longlongSize, _ := mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeLonglong)
 	cols := newColumnsWithNames(1)
@@ -3140,7 +3253,9 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 			GlobalScope:           show.GlobalScope,
 			Extended:              show.Extended,
 			Limit:                 show.Limit,
+			RegionsSummary:        show.RegionsSummary,
 			ImportJobID:           show.ImportJobID,
+			DistJobID:             show.DistJobID,
 		},
 	}.Init(b.ctx)
 	isView := false
@@ -3195,6 +3310,9 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 	case ast.ShowRestores:
 		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESTORE_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "RESTORE_ADMIN", false, err)
+	case ast.ShowDistJobs, ast.ShowDistJobSubtasks:
+		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
 	case ast.ShowTableNextRowId:
 		p := &ShowNextRowID{TableName: show.Table}
 		p.setSchemaAndNames(buildShowNextRowID())
@@ -3328,12 +3446,50 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 	case *ast.DropQueryWatchStmt:
 		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "RESOURCE_GROUP_ADMIN", false, err)
+	case *ast.CreateMaskingPolicyStmt, *ast.DropMaskingPolicyStmt:
+		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or MASKING_POLICY_ADMIN")
+		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "MASKING_POLICY_ADMIN", false, err)
+	case *ast.CreateServerStmt, *ast.DropServerStmt, *ast.DistJobActionStmt:
+		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+	case *ast.RecommendIndexStmt:
+		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+		p.setSchemaAndNames(buildRecommendIndexSchema())
+	case *ast.ProcedureInfo:
+		var err error
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			err = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, raw.ProcedureName.Schema.O)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateRoutinePriv, raw.ProcedureName.Schema.L, "", "", err)
+	case *ast.DropProcedureStmt:
+		var err error
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			err = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, raw.ProcedureName.Schema.O)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterRoutinePriv, raw.ProcedureName.Schema.L, "", "", err)
+	case *ast.CreateEventStmt:
+		var err error
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			err = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, raw.Name.Schema.O)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.EventPriv, raw.Name.Schema.L, "", "", err)
+	case *ast.DropEventStmt:
+		var err error
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			err = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, raw.Name.Schema.O)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.EventPriv, raw.Name.Schema.L, "", "", err)
 	case *ast.GrantRoleStmt:
-		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or ROLE_ADMIN")
-		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "ROLE_ADMIN", false, err)
+		if !hasRoleAdminOption(b.ctx, raw.Roles) {
+			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or ROLE_ADMIN")
+			b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "ROLE_ADMIN", false, err)
+		}
 	case *ast.RevokeRoleStmt:
-		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or ROLE_ADMIN")
-		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "ROLE_ADMIN", false, err)
+		if !hasRoleAdminOption(b.ctx, raw.Roles) {
+			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or ROLE_ADMIN")
+			b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "ROLE_ADMIN", false, err)
+		}
 		// Check if any of the users are RESTRICTED
 		for _, user := range raw.Users {
 			b.visitInfo = appendVisitInfoIsRestrictedUser(b.visitInfo, b.ctx, user, "RESTRICTED_USER_ADMIN")
@@ -3461,6 +3617,22 @@ func collectVisitInfoFromRevokeStmt(sctx PlanContext, vi []visitInfo, stmt *ast.
 
 // appendVisitInfoIsRestrictedUser appends additional visitInfo if the user has a
 // special privilege called "RESTRICTED_USER_ADMIN". It only applies when SEM is enabled.
+// hasRoleAdminOption returns whether all the roles are granted to the current user WITH ADMIN OPTION,
+// in which case the user can grant and revoke these roles without the ROLE_ADMIN privilege.
+func hasRoleAdminOption(sctx PlanContext, roles []*auth.RoleIdentity) bool {
+	checker := privilege.GetPrivilegeManager(sctx)
+	if checker == nil || len(roles) == 0 {
+		return false
+	}
+	activeRoles := sctx.GetSessionVars().ActiveRoles
+	for _, role := range roles {
+		if !checker.HasRoleAdminOption(activeRoles, role) {
+			return false
+		}
+	}
+	return true
+}
+
 func appendVisitInfoIsRestrictedUser(visitInfo []visitInfo, sctx PlanContext, user *auth.UserIdentity, priv string) []visitInfo {
 	if !sem.IsEnabled() {
 		return visitInfo
@@ -3632,6 +3804,12 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		}
 		return nil, err
 	}
+	if (tableInfo.IsMaterializedView() && !b.isRefreshMaterializedView) || tableInfo.IsExternal() || tableInfo.IsFederated() {
+		if insert.IsReplace {
+			return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tableInfo.Name.O, "REPLACE")
+		}
+		return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tableInfo.Name.O, "INSERT")
+	}
 	// Build Schema with DBName otherwise ColumnRef with DBName cannot match any Column in Schema.
 	schema, names, err := expression.TableInfo2SchemaAndNames(b.ctx.GetExprCtx(), tn.Schema, tableInfo)
 	if err != nil {
@@ -3674,6 +3852,17 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 
 	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, tn.DBInfo.Name.L,
 		tableInfo.Name.L, "", authErr)
+	if len(insert.Columns) > 0 {
+		for _, col := range insert.Columns {
+			b.appendColumnVisitInfo(mysql.InsertPriv, tn.DBInfo.Name.L, tableInfo.Name.L, col.Name.L)
+		}
+	} else {
+		for _, col := range tableInfo.Cols() {
+			if !col.Hidden {
+				b.appendColumnVisitInfo(mysql.InsertPriv, tn.DBInfo.Name.L, tableInfo.Name.L, col.Name.L)
+			}
+		}
+	}
 
 	// `REPLACE INTO` requires both INSERT + DELETE privilege
 	// `ON DUPLICATE KEY UPDATE` requires both INSERT + UPDATE privilege
@@ -3690,6 +3879,13 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		}
 		b.visitInfo = appendVisitInfo(b.visitInfo, extraPriv, tn.DBInfo.Name.L, tableInfo.Name.L, "", authErr)
 	}
+	for _, assign := range insert.OnDuplicate {
+		b.appendColumnVisitInfo(mysql.UpdatePriv, tn.DBInfo.Name.L, tableInfo.Name.L, assign.Column.Name.L)
+	}
+	// The assignments of ON DUPLICATE KEY UPDATE read the unmasked values of the duplicated rows.
+	if len(insert.OnDuplicate) > 0 && !b.ctx.GetSessionVars().InRestrictedSQL && hasMaskingPolicies(b.ctx, tn.DBInfo.Name.L, tableInfo.Name.L) {
+		return nil, plannererrors.ErrMaskedTableNotWritable.GenWithStackByArgs(tableInfo.Name.O, "INSERT ON DUPLICATE KEY UPDATE")
+	}
 
 	mockTablePlan := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
 	mockTablePlan.SetSchema(insertPlan.tableSchema)
@@ -3738,6 +3934,10 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 	if err != nil {
 		return nil, err
 	}
+	insertPlan.BeforeTriggers, err = b.buildBeforeInsertTriggers(ctx, tableInfo, mockTablePlan)
+	if err != nil {
+		return nil, err
+	}
 
 	err = insertPlan.ResolveIndices()
 	if err != nil {
@@ -3821,7 +4021,12 @@ func (*PlanBuilder) getAffectCols(insertStmt *ast.InsertStmt, insertPlan *Insert
 		// This branch is for the following scenarios:
 		// 1. `INSERT INTO tbl_name {VALUES | VALUE} (value_list) [, (value_list)] ...`,
 		// 2. `INSERT INTO tbl_name SELECT ...`.
-		affectedValuesCols = insertPlan.Table.VisibleCols()
+		// The invisible columns are only written when they are named explicitly.
+		for _, col := range insertPlan.Table.VisibleCols() {
+			if !col.Invisible {
+				affectedValuesCols = append(affectedValuesCols, col)
+			}
+		}
 	}
 	return affectedValuesCols, nil
 }
@@ -4120,6 +4325,9 @@ func (b *PlanBuilder) buildLoadData(ctx context.Context, ld *ast.LoadDataStmt) (
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DeletePriv, p.Table.Schema.O, p.Table.Name.O, "", deleteErr)
 	}
 	tableInfo := p.Table.TableInfo
+	if tableInfo.IsExternal() || tableInfo.IsFederated() {
+		return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tableInfo.Name.O, "LOAD")
+	}
 	tableInPlan, ok := b.is.TableByID(tableInfo.ID)
 	if !ok {
 		db := b.ctx.GetSessionVars().CurrentDB
@@ -4206,6 +4414,9 @@ func (b *PlanBuilder) buildImportInto(ctx context.Context, ld *ast.ImportIntoStm
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
 	}
 	tableInfo := p.Table.TableInfo
+	if tableInfo.IsExternal() || tableInfo.IsFederated() {
+		return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tableInfo.Name.O, "IMPORT")
+	}
 	// we use the latest IS to support IMPORT INTO dst FROM SELECT * FROM src AS OF TIMESTAMP '2020-01-01 00:00:00'
 	// Note: we need to get p.Table when preprocessing, at that time, IS of session
 	// transaction is used, if the session ctx is already in snapshot read using tidb_snapshot, we might
@@ -4318,12 +4529,153 @@ func (b *PlanBuilder) buildSplitRegion(node *ast.SplitRegionStmt) (Plan, error)
 	if node.SplitSyntaxOpt != nil && node.SplitSyntaxOpt.HasPartition && node.Table.TableInfo.Partition == nil {
 		return nil, plannererrors.ErrPartitionClauseOnNonpartitioned
 	}
+	if node.SplitOpt.ByQuantiles {
+		return b.buildSplitRegionByQuantiles(node)
+	}
 	if len(node.IndexName.L) != 0 {
 		return b.buildSplitIndexRegion(node)
 	}
 	return b.buildSplitTableRegion(node)
 }
 
+// buildSplitRegionByQuantiles builds the plan to split the table or index regions at the quantiles computed from the
+// statistics, so that each region holds roughly the same number of rows.
+func (b *PlanBuilder) buildSplitRegionByQuantiles(node *ast.SplitRegionStmt) (Plan, error) {
+	tblInfo := node.Table.TableInfo
+	p := &SplitRegion{
+		TableInfo:      tblInfo,
+		PartitionNames: node.PartitionNames,
+		Scatter:        node.Scatter,
+	}
+	p.setSchemaAndNames(buildSplitRegionsSchema())
+
+	target := "table"
+	// The statistics of the int handle are kept in the column, and the others are kept in the index.
+	var idxInfo *model.IndexInfo
+	switch {
+	case len(node.IndexName.L) != 0:
+		target = "index"
+		if node.IndexName.L == strings.ToLower(mysql.PrimaryKeyName) &&
+			(tblInfo.IsCommonHandle || tblInfo.PKIsHandle) {
+			return nil, plannererrors.ErrKeyDoesNotExist.FastGen("unable to split clustered index, please split table instead.")
+		}
+		idxInfo = tblInfo.FindIndexByName(node.IndexName.L)
+		if idxInfo == nil {
+			return nil, plannererrors.ErrKeyDoesNotExist.GenWithStackByArgs(node.IndexName, tblInfo.Name)
+		}
+		p.IndexInfo = idxInfo
+	case tblInfo.IsCommonHandle:
+		idxInfo = tables.FindPrimaryIndex(tblInfo)
+	case !tblInfo.PKIsHandle:
+		return nil, errors.Errorf("Split table region by quantiles requires the table to be clustered by the primary key")
+	}
+
+	maxSplitRegionNum := int64(config.GetGlobalConfig().SplitRegionMaxNum)
+	if node.SplitOpt.Num > maxSplitRegionNum {
+		return nil, errors.Errorf("Split %s region num exceeded the limit %v", target, maxSplitRegionNum)
+	} else if node.SplitOpt.Num < 1 {
+		return nil, errors.Errorf("Split %s region num should more than 0", target)
+	}
+
+	// The histograms may not be loaded into the stats cache, so load all of them from the storage.
+	var statsTbl *statistics.Table
+	if statsHandle := domain.GetDomain(b.ctx).StatsHandle(); statsHandle != nil {
+		var err error
+		statsTbl, err = statsHandle.TableStatsFromStorage(tblInfo, tblInfo.ID, true, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if statsTbl == nil || statsTbl.Pseudo {
+		return nil, errors.Errorf("Split %s region by quantiles requires the statistics, please analyze table `%s` first", target, tblInfo.Name.O)
+	}
+	num := int(node.SplitOpt.Num)
+	if idxInfo == nil {
+		var bounds [][]byte
+		if col := statsTbl.Columns[tblInfo.GetPkColInfo().ID]; col != nil {
+			points, err := statsQuantilePoints(&col.Histogram, col.TopN, false)
+			if err != nil {
+				return nil, err
+			}
+			bounds = pickQuantiles(points, num)
+		}
+		for _, bound := range bounds {
+			_, d, err := codec.DecodeOne(bound)
+			if err != nil {
+				return nil, err
+			}
+			p.ValueLists = append(p.ValueLists, []types.Datum{d})
+		}
+	} else if idx := statsTbl.Indices[idxInfo.ID]; idx != nil {
+		points, err := statsQuantilePoints(&idx.Histogram, idx.TopN, true)
+		if err != nil {
+			return nil, err
+		}
+		p.EncodedValues = pickQuantiles(points, num)
+	}
+	if len(p.ValueLists) == 0 && len(p.EncodedValues) == 0 {
+		return nil, errors.Errorf("Split %s region by quantiles found no split point, please analyze table `%s` first", target, tblInfo.Name.O)
+	}
+	return p, nil
+}
+
+// quantilePoint is an encoded value in the statistics and the number of the rows from it to the next point.
+type quantilePoint struct {
+	bound []byte
+	count float64
+}
+
+// statsQuantilePoints returns the points of the histogram buckets and the TopN sorted by the encoded values.
+func statsQuantilePoints(hg *statistics.Histogram, topN *statistics.TopN, isIndex bool) ([]quantilePoint, error) {
+	points := make([]quantilePoint, 0, hg.Len()+topN.Num())
+	for i := 0; i < hg.Len(); i++ {
+		// The bounds of the index histogram are encoded already.
+		bound := hg.GetLower(i).GetBytes()
+		if !isIndex {
+			var err error
+			bound, err = codec.EncodeKey(time.UTC, nil, *hg.GetLower(i))
+			if err != nil {
+				return nil, err
+			}
+		}
+		points = append(points, quantilePoint{bound: bound, count: float64(hg.BucketCount(i))})
+	}
+	if topN != nil {
+		for _, meta := range topN.TopN {
+			points = append(points, quantilePoint{bound: meta.Encoded, count: float64(meta.Count)})
+		}
+	}
+	slices.SortFunc(points, func(a, b quantilePoint) int {
+		return bytes.Compare(a.bound, b.bound)
+	})
+	return points, nil
+}
+
+// pickQuantiles picks at most num-1 bounds from the sorted points, which divide the rows into num parts as evenly as
+// possible. The rows of a picked point belong to the part after it, the same as the region split at it.
+func pickQuantiles(points []quantilePoint, num int) [][]byte {
+	var total float64
+	for _, point := range points {
+		total += point.count
+	}
+	bounds := make([][]byte, 0, num-1)
+	var count float64
+	next := 1
+	for i, point := range points {
+		if next >= num {
+			break
+		}
+		if i > 0 && count >= total*float64(next)/float64(num) {
+			bounds = append(bounds, point.bound)
+			for next < num && count >= total*float64(next)/float64(num) {
+				next++
+			}
+		}
+		count += point.count
+	}
+	return bounds
+}
+
 func (b *PlanBuilder) buildSplitIndexRegion(node *ast.SplitRegionStmt) (Plan, error) {
 	tblInfo := node.Table.TableInfo
 	if node.IndexName.L == strings.ToLower(mysql.PrimaryKeyName) &&
@@ -4347,6 +4699,7 @@ func (b *PlanBuilder) buildSplitIndexRegion(node *ast.SplitRegionStmt) (Plan, er
 		TableInfo:      tblInfo,
 		PartitionNames: node.PartitionNames,
 		IndexInfo:      indexInfo,
+		Scatter:        node.Scatter,
 	}
 	p.names = names
 	p.setSchemaAndNames(buildSplitRegionsSchema())
@@ -4461,6 +4814,7 @@ func (b *PlanBuilder) buildSplitTableRegion(node *ast.SplitRegionStmt) (Plan, er
 	p := &SplitRegion{
 		TableInfo:      tblInfo,
 		PartitionNames: node.PartitionNames,
+		Scatter:        node.Scatter,
 	}
 	p.setSchemaAndNames(buildSplitRegionsSchema())
 	if len(node.SplitOpt.ValueLists) > 0 {
@@ -4694,6 +5048,24 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, v.ReferTable.Schema.L,
 				v.ReferTable.Name.L, "", authErr)
 		}
+		if v.IsExternal {
+			// the external table reads the files on the TiDB server, which needs the FILE privilege as IMPORT INTO.
+			for _, op := range v.Options {
+				if op.Tp != ast.TableOptionLocation {
+					continue
+				}
+				if isLocal, err := storage.IsLocalPath(op.StrValue); err == nil && isLocal {
+					b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
+				}
+			}
+		}
+		if v.Select != nil {
+			return b.buildCreateTableAsSelect(ctx, v)
+		}
+	case *ast.CreateMaterializedViewStmt:
+		return b.buildCreateMaterializedView(ctx, v)
+	case *ast.RefreshMaterializedViewStmt:
+		return b.buildRefreshMaterializedView(ctx, v)
 	case *ast.CreateViewStmt:
 		b.isCreateView = true
 		b.capFlag |= canExpandAST | renameView
@@ -4745,6 +5117,19 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 		}
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.Name.Schema.L,
 			v.Name.Name.L, "", authErr)
+	case *ast.CreateTriggerStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Table.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Table.Schema.L,
+			v.Table.Name.L, "", authErr)
+	case *ast.DropTriggerStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Name.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Name.Schema.L, "", "", authErr)
 	case *ast.DropDatabaseStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
@@ -4878,6 +5263,249 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 	return p, nil
 }
 
+// buildCreateTableAsSelect builds the plan of CREATE TABLE ... SELECT. Columns that are
+// not defined explicitly are derived from the output of the select, the rows are then
+// imported into the new table the same way as IMPORT INTO ... FROM SELECT.
+func (b *PlanBuilder) buildCreateTableAsSelect(ctx context.Context, v *ast.CreateTableStmt) (Plan, error) {
+	if b.ctx.GetSessionVars().InTxn() {
+		return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("CREATE TABLE ... SELECT in an explicit transaction")
+	}
+	if v.TemporaryKeyword != ast.TemporaryNone {
+		return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("CREATE TEMPORARY TABLE ... SELECT")
+	}
+	if v.OnDuplicate != ast.OnDuplicateKeyHandlingError {
+		return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("IGNORE or REPLACE in CREATE TABLE ... SELECT")
+	}
+	for _, col := range v.Cols {
+		for _, opt := range col.Options {
+			if opt.Tp == ast.ColumnOptionGenerated {
+				return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("generated columns in CREATE TABLE ... SELECT")
+			}
+		}
+	}
+	var authErr error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("INSERT", user.AuthUsername,
+			user.AuthHostname, v.Table.Name.L)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, v.Table.Schema.L, v.Table.Name.L, "", authErr)
+
+	// privilege of tables in select will be checked here
+	selectPlan, err := b.Build(ctx, v.Select)
+	if err != nil {
+		return nil, err
+	}
+	stmt := *v
+	stmt.Cols = slices.Clone(v.Cols)
+	names := selectPlan.OutputNames()
+	columns := make([]*ast.ColumnName, 0, len(names))
+	for i, col := range selectPlan.Schema().Columns {
+		name := names[i].ColName
+		if name.L == "" {
+			return nil, dbterror.ErrWrongColumnName.GenWithStackByArgs(name.O)
+		}
+		columns = append(columns, &ast.ColumnName{Name: name})
+		if slices.ContainsFunc(stmt.Cols, func(def *ast.ColumnDef) bool { return def.Name.Name.L == name.L }) {
+			continue
+		}
+		def := &ast.ColumnDef{
+			Name: &ast.ColumnName{Name: name},
+			Tp:   columnTypeOfSelectField(col.RetType),
+		}
+		if mysql.HasNotNullFlag(col.RetType.GetFlag()) {
+			def.Options = append(def.Options, &ast.ColumnOption{Tp: ast.ColumnOptionNotNull})
+		}
+		stmt.Cols = append(stmt.Cols, def)
+	}
+	p := &CreateTableAsSelect{Stmt: &stmt, Columns: columns}
+	p.SelectPlan, _, err = DoOptimize(ctx, b.ctx, b.optFlag, selectPlan.(LogicalPlan))
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// buildCreateMaterializedView builds the plan of CREATE MATERIALIZED VIEW. The data of
+// the view is stored in a table which is created the same way as CREATE TABLE ... SELECT.
+func (b *PlanBuilder) buildCreateMaterializedView(ctx context.Context, v *ast.CreateMaterializedViewStmt) (Plan, error) {
+	user := b.ctx.GetSessionVars().User
+	var authErr error
+	if user != nil {
+		authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("CREATE", user.AuthUsername,
+			user.AuthHostname, v.ViewName.Name.L)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.ViewName.Schema.L, v.ViewName.Name.L, "", authErr)
+
+	// the definition must be restored before building, which rewrites the select.
+	var sb strings.Builder
+	if err := v.Select.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return nil, err
+	}
+	plan, err := b.buildCreateTableAsSelect(ctx, &ast.CreateTableStmt{
+		Table:       v.ViewName,
+		IfNotExists: v.IfNotExists,
+		Select:      v.Select.(ast.ResultSetNode),
+	})
+	if err != nil {
+		return nil, err
+	}
+	p := plan.(*CreateTableAsSelect)
+	charset, collation := b.ctx.GetSessionVars().GetCharsetInfo()
+	p.MaterializedView = &model.MaterializedViewInfo{
+		SelectStmt:          sb.String(),
+		CharacterSetClient:  charset,
+		CollationConnection: collation,
+	}
+	if v.RefreshUnit != ast.TimeUnitInvalid {
+		switch v.RefreshUnit {
+		case ast.TimeUnitYear, ast.TimeUnitQuarter, ast.TimeUnitMonth, ast.TimeUnitWeek,
+			ast.TimeUnitDay, ast.TimeUnitHour, ast.TimeUnitMinute, ast.TimeUnitSecond:
+		default:
+			return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs(
+				fmt.Sprintf("the interval unit %s of materialized view refresh", v.RefreshUnit.String()))
+		}
+		if v.RefreshEvery == 0 {
+			return nil, exeerrors.ErrEventIntervalNotPositiveOrTooBig.GenWithStackByArgs()
+		}
+		p.MaterializedView.RefreshEvery, p.MaterializedView.RefreshUnit = v.RefreshEvery, v.RefreshUnit.String()
+	}
+	if user != nil {
+		p.MaterializedView.Definer = &auth.UserIdentity{Username: user.AuthUsername, Hostname: user.AuthHostname}
+	}
+	return p, nil
+}
+
+// buildRefreshMaterializedView builds the plan of REFRESH MATERIALIZED VIEW, which deletes
+// the data of the view and inserts the data selected with its definition in one transaction.
+// The data is replaced in place, so the ID, statistics, TiFlash replicas and placement of the
+// view are kept.
+func (b *PlanBuilder) buildRefreshMaterializedView(ctx context.Context, v *ast.RefreshMaterializedViewStmt) (Plan, error) {
+	tbl, err := b.is.TableByName(v.ViewName.Schema, v.ViewName.Name)
+	if err != nil {
+		return nil, err
+	}
+	tblInfo := tbl.Meta()
+	if !tblInfo.IsMaterializedView() {
+		return nil, infoschema.ErrWrongObject.GenWithStackByArgs(v.ViewName.Schema.O, v.ViewName.Name.O, "MATERIALIZED VIEW")
+	}
+	if b.ctx.GetSessionVars().InTxn() {
+		return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("REFRESH MATERIALIZED VIEW in an explicit transaction")
+	}
+
+	mv := tblInfo.MaterializedView
+	mvParser := parser.New()
+	mvParser.SetParserConfig(b.ctx.GetSessionVars().BuildParserConfig())
+	selectNode, err := mvParser.ParseOneStmt(mv.SelectStmt, mv.CharacterSetClient, mv.CollationConnection)
+	if err != nil {
+		return nil, err
+	}
+	dbInfo, _ := infoschema.SchemaByTable(b.is, tblInfo)
+	viewTableRefs := func() *ast.TableRefsClause {
+		tn := &ast.TableName{Schema: v.ViewName.Schema, Name: v.ViewName.Name, DBInfo: dbInfo, TableInfo: tblInfo}
+		return &ast.TableRefsClause{TableRefs: &ast.Join{Left: &ast.TableSource{Source: tn}}}
+	}
+
+	b.isRefreshMaterializedView = true
+	defer func() {
+		b.isRefreshMaterializedView = false
+	}()
+	// privilege of tables in select will be checked here
+	insertPlan, err := b.buildInsert(ctx, &ast.InsertStmt{Table: viewTableRefs(), Select: selectNode.(ast.ResultSetNode)})
+	if err != nil {
+		return nil, err
+	}
+	deletePlan, err := b.buildDelete(ctx, &ast.DeleteStmt{TableRefs: viewTableRefs()})
+	if err != nil {
+		return nil, err
+	}
+	return &RefreshMaterializedView{
+		Table:  &ast.TableName{Schema: v.ViewName.Schema, Name: v.ViewName.Name, DBInfo: dbInfo, TableInfo: tblInfo},
+		Delete: deletePlan.(*Delete),
+		Insert: insertPlan.(*Insert),
+	}, nil
+}
+
+// buildSelectFromMaterializedView answers the query by reading a materialized view when
+// tidb_enable_materialized_view_rewrite is on, and the query is the same as the definition
+// of a materialized view in a schema which the query reads.
+func (b *PlanBuilder) buildSelectFromMaterializedView(ctx context.Context, sel *ast.SelectStmt) (Plan, bool, error) {
+	sessVars := b.ctx.GetSessionVars()
+	if !sessVars.EnableMaterializedViewRewrite || !sessVars.StmtCtx.InSelectStmt ||
+		(sel.LockInfo != nil && sel.LockInfo.LockType != ast.SelectLockNone) {
+		return nil, false, nil
+	}
+	var sb strings.Builder
+	if err := sel.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return nil, true, err
+	}
+	definition := sb.String()
+	tables := extractTableList(sel, nil, false)
+	checked := make(map[string]struct{})
+	for _, tn := range tables {
+		if _, ok := checked[tn.Schema.L]; ok || tn.Schema.L == "" {
+			continue
+		}
+		checked[tn.Schema.L] = struct{}{}
+		for _, tbl := range b.is.SchemaTables(tn.Schema) {
+			mv := tbl.Meta().MaterializedView
+			if mv == nil || mv.SelectStmt != definition {
+				continue
+			}
+			sessVars.StmtCtx.SetSkipPlanCache(errors.NewNoStackError("query is answered by a materialized view"))
+			sessVars.StmtCtx.AppendNote(errors.NewNoStackErrorf("query is answered by materialized view %s.%s", tn.Schema.O, tbl.Meta().Name.O))
+			mvSel := &ast.SelectStmt{
+				SelectStmtOpts: &ast.SelectStmtOpts{SQLCache: true},
+				Kind:           ast.SelectStmtKindSelect,
+				Fields:         &ast.FieldList{Fields: []*ast.SelectField{{WildCard: &ast.WildCardField{}}}},
+				From: &ast.TableRefsClause{TableRefs: &ast.Join{Left: &ast.TableSource{
+					Source: &ast.TableName{Schema: tn.Schema, Name: tbl.Meta().Name},
+				}}},
+			}
+			// the tables in the query are not read, but the privileges of them are still required.
+			for _, t := range tables {
+				var authErr error
+				if user := sessVars.User; user != nil {
+					authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("SELECT", user.AuthUsername, user.AuthHostname, t.Name.L)
+				}
+				b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, t.Schema.L, t.Name.L, "", authErr)
+			}
+			p, err := b.buildSelect(ctx, mvSel)
+			return p, true, err
+		}
+	}
+	return nil, false, nil
+}
+
+// columnTypeOfSelectField returns the column type used to store a select field in
+// CREATE TABLE ... SELECT.
+func columnTypeOfSelectField(ft *types.FieldType) *types.FieldType {
+	tp := ft.Clone()
+	tp.SetFlag(tp.GetFlag() & (mysql.UnsignedFlag | mysql.ZerofillFlag | mysql.BinaryFlag))
+	switch tp.GetType() {
+	case mysql.TypeNull:
+		tp = types.NewFieldType(mysql.TypeString)
+		tp.SetFlen(0)
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+		tp.AddFlag(mysql.BinaryFlag)
+	case mysql.TypeVarString, mysql.TypeVarchar:
+		tp.SetType(mysql.TypeVarchar)
+		maxLen := mysql.MaxFieldVarCharLength
+		if cs, err := charset.GetCharsetInfo(tp.GetCharset()); err == nil && cs.Maxlen > 0 {
+			maxLen /= cs.Maxlen
+		}
+		if tp.GetFlen() < 0 || tp.GetFlen() > maxLen {
+			tp.SetType(mysql.TypeLongBlob)
+			tp.SetFlen(types.UnspecifiedLength)
+		}
+	case mysql.TypeFloat, mysql.TypeDouble:
+		if tp.GetDecimal() == types.UnspecifiedLength {
+			tp.SetFlen(types.UnspecifiedLength)
+		}
+	}
+	return tp
+}
+
 const (
 	// TraceFormatRow indicates row tracing format.
 	TraceFormatRow = "row"
@@ -5009,10 +5637,30 @@ func (b *PlanBuilder) buildExplain(ctx context.Context, explain *ast.ExplainStmt
 	if err != nil {
 		return nil, err
 	}
+	appendUsedFixControlNotes(b.ctx.GetSessionVars())
 
 	return b.buildExplainPlan(targetPlan, explain.Format, nil, explain.Analyze, explain.Stmt, nil)
 }
 
+// appendUsedFixControlNotes notes the optimizer fix controls which are set by users and consulted during the
+// optimization, to tell which fixes influenced the plan.
+func appendUsedFixControlNotes(vars *variable.SessionVars) {
+	keys := slices.Clone(vars.StmtCtx.UsedFixControls())
+	slices.Sort(keys)
+	for _, key := range keys {
+		value, exists := fixcontrol.GetStr(vars.GetOptimizerFixControlMap(), key)
+		if !exists {
+			continue
+		}
+		note := errors.NewNoStackErrorf("Using the optimizer fix control %d:%v", key, value)
+		if vars.StmtCtx.InVerboseExplain {
+			vars.StmtCtx.AppendNote(note)
+		} else {
+			vars.StmtCtx.AppendExtraNote(note)
+		}
+	}
+}
+
 func (b *PlanBuilder) buildSelectInto(ctx context.Context, sel *ast.SelectStmt) (Plan, error) {
 	if sem.IsEnabled() {
 		return nil, plannererrors.ErrNotSupportedWithSem.GenWithStackByArgs("SELECT INTO")
@@ -5121,6 +5769,9 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 		}
 		return buildShowWarningsSchema()
 	case ast.ShowRegions:
+		if s.RegionsSummary {
+			return buildTableRegionsSummarySchema()
+		}
 		return buildTableRegionsSchema()
 	case ast.ShowEngines:
 		names = []string{"Engine", "Support", "Comment", "Transactions", "XA", "Savepoints"}
@@ -5284,6 +5935,17 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 	case ast.ShowImportJobs:
 		names = importIntoSchemaNames
 		ftypes = importIntoSchemaFTypes
+	case ast.ShowDistJobs:
+		names = []string{"Job_ID", "Job_Key", "Type", "State", "Step", "Concurrency", "Create_Time", "Start_Time",
+			"Update_Time", "Total_Subtasks", "Finished_Subtasks", "Progress", "Error"}
+		ftypes = []byte{mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar,
+			mysql.TypeLonglong, mysql.TypeDatetime, mysql.TypeDatetime, mysql.TypeDatetime, mysql.TypeLonglong,
+			mysql.TypeLonglong, mysql.TypeDouble, mysql.TypeVarchar}
+	case ast.ShowDistJobSubtasks:
+		names = []string{"Subtask_ID", "Step", "Exec_ID", "State", "Concurrency", "Create_Time", "Start_Time",
+			"Update_Time", "Summary"}
+		ftypes = []byte{mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong,
+			mysql.TypeDatetime, mysql.TypeDatetime, mysql.TypeDatetime, mysql.TypeJSON}
 	}
 	return convert2OutputSchemasAndNames(names, ftypes)
 }
@@ -5376,6 +6038,35 @@ func buildChecksumTableSchema() (*expression.Schema, []*types.FieldName) {
 	return schema.col2Schema(), schema.names
 }
 
+func buildCheckTableOnlineSchema() (*expression.Schema, []*types.FieldName) {
+	schema := newColumnsWithNames(3)
+	schema.Append(buildColumnWithName("", "Db_name", mysql.TypeVarchar, 128))
+	schema.Append(buildColumnWithName("", "Table_name", mysql.TypeVarchar, 128))
+	schema.Append(buildColumnWithName("", "Job_id", mysql.TypeLonglong, 22))
+	return schema.col2Schema(), schema.names
+}
+
+func buildDiffSchemaSchema() (*expression.Schema, []*types.FieldName) {
+	schema := newColumnsWithNames(7)
+	schema.Append(buildColumnWithName("", "Db_name", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "Table_name", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "Object_type", mysql.TypeVarchar, 16))
+	schema.Append(buildColumnWithName("", "Object_name", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "Change", mysql.TypeVarchar, 16))
+	schema.Append(buildColumnWithName("", "Old_definition", mysql.TypeVarchar, 1024))
+	schema.Append(buildColumnWithName("", "New_definition", mysql.TypeVarchar, 1024))
+	return schema.col2Schema(), schema.names
+}
+
+func buildCompareOptimizerTraceSchema() (*expression.Schema, []*types.FieldName) {
+	schema := newColumnsWithNames(4)
+	schema.Append(buildColumnWithName("", "Section", mysql.TypeVarchar, 32))
+	schema.Append(buildColumnWithName("", "Item", mysql.TypeVarchar, 128))
+	schema.Append(buildColumnWithName("", "Trace_1", mysql.TypeVarchar, 1024))
+	schema.Append(buildColumnWithName("", "Trace_2", mysql.TypeVarchar, 1024))
+	return schema.col2Schema(), schema.names
+}
+
 // adjustOverlongViewColname adjusts the overlong outputNames of a view to
 // `new_exp_$off` where `$off` is the offset of the output column, $off starts from 1.
 // There is still some MySQL compatible problems.
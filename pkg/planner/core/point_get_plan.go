@@ -50,7 +50,6 @@ import (
 	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/hint"
 	"github.com/pingcap/tidb/pkg/util/logutil"
-	"github.com/pingcap/tidb/pkg/util/mathutil"
 	"github.com/pingcap/tidb/pkg/util/plancodec"
 	"github.com/pingcap/tidb/pkg/util/size"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
@@ -580,10 +579,11 @@ func TryFastPlan(ctx PlanContext, node ast.Node) (p Plan) {
 		// Try to convert the `SELECT a, b, c FROM t WHERE (a, b, c) in ((1, 2, 4), (1, 3, 5))` to
 		// `PhysicalUnionAll` which children are `PointGet` if exists an unique key (a, b, c) in table `t`
 		if fp := tryWhereIn2BatchPointGet(ctx, x); fp != nil {
-			if checkFastPlanPrivilege(ctx, fp.dbName, fp.TblInfo.Name.L, mysql.SelectPriv) != nil {
+			columns := fastPlanColumnVisitInfo(ctx, fp.dbName, fp.TblInfo, fp.IndexInfo, fp.Schema())
+			if checkFastPlanPrivilege(ctx, fp.dbName, fp.TblInfo.Name.L, columns, mysql.SelectPriv) != nil {
 				return
 			}
-			if tidbutil.IsMemDB(fp.dbName) {
+			if tidbutil.IsMemDB(fp.dbName) || hasMaskingPolicies(ctx, fp.dbName, fp.TblInfo.Name.L) {
 				return nil
 			}
 			fp.Lock, fp.LockWaitTime = getLockWaitTime(ctx, x.LockInfo)
@@ -591,10 +591,11 @@ func TryFastPlan(ctx PlanContext, node ast.Node) (p Plan) {
 			return
 		}
 		if fp := tryPointGetPlan(ctx, x, isForUpdateReadSelectLock(x.LockInfo)); fp != nil {
-			if checkFastPlanPrivilege(ctx, fp.dbName, fp.TblInfo.Name.L, mysql.SelectPriv) != nil {
+			columns := fastPlanColumnVisitInfo(ctx, fp.dbName, fp.TblInfo, fp.IndexInfo, fp.Schema())
+			if checkFastPlanPrivilege(ctx, fp.dbName, fp.TblInfo.Name.L, columns, mysql.SelectPriv) != nil {
 				return nil
 			}
-			if tidbutil.IsMemDB(fp.dbName) {
+			if tidbutil.IsMemDB(fp.dbName) || hasMaskingPolicies(ctx, fp.dbName, fp.TblInfo.Name.L) {
 				return nil
 			}
 			if fp.IsTableDual {
@@ -662,16 +663,13 @@ func newBatchPointGetPlan(
 		if partitionExpr == nil {
 			return nil
 		}
-
-		if partitionExpr.Expr == nil {
-			return nil
-		}
-		if _, ok := partitionExpr.Expr.(*expression.Column); !ok {
-			return nil
-		}
 	}
 
 	if handleCol != nil {
+		// The partition of each handle is located by the handle itself.
+		if _, err := batchPointGetPartitionColPos(tbl, partitionExpr, nil); err != nil {
+			return nil
+		}
 		// condition key of where is primary key
 		var handles = make([]kv.Handle, len(patternInExpr.List))
 		var handleParams = make([]*expression.Constant, len(patternInExpr.List))
@@ -790,7 +788,7 @@ func newBatchPointGetPlan(
 		return nil
 	}
 
-	pos, err := getPartitionColumnPos(matchIdxInfo, partitionExpr, tbl)
+	pos, err := batchPointGetPartitionColPos(tbl, partitionExpr, matchIdxInfo)
 	if err != nil {
 		return nil
 	}
@@ -1268,12 +1266,22 @@ func newPointGetPlan(ctx PlanContext, dbName string, schema *expression.Schema,
 	return p
 }
 
-func checkFastPlanPrivilege(ctx PlanContext, dbName, tableName string, checkTypes ...mysql.PrivilegeType) error {
+// checkFastPlanPrivilege checks the table scope privileges required by the fast plan. The columns are the column scope
+// privileges required by the plan, which are checked when the table scope check fails, see checkColumnPrivilege.
+func checkFastPlanPrivilege(ctx PlanContext, dbName, tableName string, columns []visitInfo, checkTypes ...mysql.PrivilegeType) error {
 	pm := privilege.GetPrivilegeManager(ctx)
+	activeRoles := ctx.GetSessionVars().ActiveRoles
 	visitInfos := make([]visitInfo, 0, len(checkTypes))
 	for _, checkType := range checkTypes {
-		if pm != nil && !pm.RequestVerification(ctx.GetSessionVars().ActiveRoles, dbName, tableName, "", checkType) {
-			return plannererrors.ErrPrivilegeCheckFail.GenWithStackByArgs(checkType.String())
+		if pm != nil && !pm.RequestVerification(activeRoles, dbName, tableName, "", checkType) {
+			tbl := visitInfo{privilege: checkType, db: dbName, table: tableName}
+			granted, err := checkColumnPrivilege(activeRoles, pm, tbl, columns)
+			if err != nil {
+				return err
+			}
+			if !granted {
+				return plannererrors.ErrPrivilegeCheckFail.GenWithStackByArgs(checkType.String())
+			}
 		}
 		// This visitInfo is only for table lock check, so we do not need column field,
 		// just fill it empty string.
@@ -1290,6 +1298,41 @@ func checkFastPlanPrivilege(ctx PlanContext, dbName, tableName string, checkType
 	return CheckTableLock(ctx, infoSchema, visitInfos)
 }
 
+// fastPlanColumnVisitInfo returns the SELECT privileges of the columns read by the point get or the batch point get,
+// which are the output columns and the columns of the handle or the index used to locate the rows.
+func fastPlanColumnVisitInfo(ctx PlanContext, dbName string, tbl *model.TableInfo, idx *model.IndexInfo, schema *expression.Schema) []visitInfo {
+	cols := make([]string, 0, schema.Len()+1)
+	for _, col := range schema.Columns {
+		// tidb_row_checksum() doesn't belong to the table.
+		if col.ID != model.ExtraRowChecksumID {
+			cols = append(cols, col.OrigName)
+		}
+	}
+	if idx != nil {
+		for _, idxCol := range idx.Columns {
+			cols = append(cols, idxCol.Name.L)
+		}
+	} else if pkCol := tbl.GetPkColInfo(); pkCol != nil {
+		cols = append(cols, pkCol.Name.L)
+	}
+	vs := make([]visitInfo, 0, len(cols))
+	for _, col := range cols {
+		var authErr error
+		if user := ctx.GetSessionVars().User; user != nil {
+			authErr = plannererrors.ErrColumnaccessDenied.FastGenByArgs(strings.ToUpper(mysql.Priv2Str[mysql.SelectPriv]), user.AuthUsername, user.AuthHostname, col, tbl.Name.L)
+		}
+		vs = appendVisitInfo(vs, mysql.SelectPriv, dbName, tbl.Name.L, col, authErr)
+	}
+	return vs
+}
+
+// hasMaskingPolicies checks whether the table has masked columns for the current user,
+// the fast plans don't apply the masking projection so they must be skipped.
+func hasMaskingPolicies(ctx PlanContext, dbName, tableName string) bool {
+	pm := privilege.GetPrivilegeManager(ctx)
+	return pm != nil && len(pm.GetMaskingPolicies(ctx.GetSessionVars().ActiveRoles, dbName, tableName)) > 0
+}
+
 func buildSchemaFromFields(
 	dbName model.CIStr,
 	tbl *model.TableInfo,
@@ -1697,7 +1740,10 @@ func tryUpdatePointPlan(ctx PlanContext, updateStmt *ast.UpdateStmt) Plan {
 }
 
 func buildPointUpdatePlan(ctx PlanContext, pointPlan PhysicalPlan, dbName string, tbl *model.TableInfo, updateStmt *ast.UpdateStmt) Plan {
-	if checkFastPlanPrivilege(ctx, dbName, tbl.Name.L, mysql.SelectPriv, mysql.UpdatePriv) != nil {
+	if checkFastPlanPrivilege(ctx, dbName, tbl.Name.L, nil, mysql.SelectPriv, mysql.UpdatePriv) != nil {
+		return nil
+	}
+	if hasMaskingPolicies(ctx, dbName, tbl.Name.L) {
 		return nil
 	}
 	orderedList, allAssignmentsAreConstant := buildOrderedList(ctx, pointPlan, updateStmt.List)
@@ -1821,7 +1867,10 @@ func tryDeletePointPlan(ctx PlanContext, delStmt *ast.DeleteStmt) Plan {
 }
 
 func buildPointDeletePlan(ctx PlanContext, pointPlan PhysicalPlan, dbName string, tbl *model.TableInfo) Plan {
-	if checkFastPlanPrivilege(ctx, dbName, tbl.Name.L, mysql.SelectPriv, mysql.DeletePriv) != nil {
+	if checkFastPlanPrivilege(ctx, dbName, tbl.Name.L, nil, mysql.SelectPriv, mysql.DeletePriv) != nil {
+		return nil
+	}
+	if hasMaskingPolicies(ctx, dbName, tbl.Name.L) {
 		return nil
 	}
 	handleCols := buildHandleCols(ctx, tbl, pointPlan.Schema())
@@ -1919,9 +1968,8 @@ func getPartitionDef(ctx PlanContext, tbl *model.TableInfo, pairs []nameValuePai
 
 		for i, pair := range pairs {
 			if partitionColName.Name.L == pair.colName {
-				val := pair.value.GetInt64()
-				pos := mathutil.Abs(val % int64(pi.Num))
-				return &pi.Definitions[pos], i, int(pos), false
+				pos := tables.HashPartitionIndex(pair.value.GetInt64(), pi.Num, pi.IsLinear)
+				return &pi.Definitions[pos], i, pos, false
 			}
 		}
 	case model.PartitionTypeKey:
@@ -1931,7 +1979,11 @@ func getPartitionDef(ctx PlanContext, tbl *model.TableInfo, pairs []nameValuePai
 			col := &expression.Column{}
 			*col = *partitionExpr.KeyPartCols[0]
 			col.Index = 0
-			pe := &tables.ForKeyPruning{KeyPartCols: []*expression.Column{col}}
+			pe := &tables.ForKeyPruning{
+				KeyPartCols: []*expression.Column{col},
+				IsLinear:    partitionExpr.ForKeyPruning.IsLinear,
+				Algorithm:   partitionExpr.Algorithm,
+			}
 			for i, pair := range pairs {
 				if pi.Columns[0].L == pair.colName {
 					pos, err := pe.LocateKeyPartition(pi.Num, []types.Datum{pair.value})
@@ -1996,15 +2048,45 @@ func findPartitionIdx(idxInfo *model.IndexInfo, pos int, pairs []nameValuePair)
 	return 0
 }
 
+// batchPointGetPartitionColPos gets the position of the column in the unique index, by which BatchPointGetExec locates
+// the partition of each key, see GetPhysID. The keys of a global index don't belong to any partition, the partitions of
+// the rows are read from the index values, so GlobalWithoutColumnPos is returned for it. The handle is used to locate
+// the partition if idx is nil.
+func batchPointGetPartitionColPos(tbl *model.TableInfo, partitionExpr *tables.PartitionExpr, idx *model.IndexInfo) (int, error) {
+	if idx != nil && idx.Global {
+		return GlobalWithoutColumnPos, nil
+	}
+	if idx == nil {
+		// the int primary key must be the partition column, since it includes all the partition columns. The
+		// partition of a row can't be located by the _tidb_rowid.
+		if partitionExpr != nil && !tbl.PKIsHandle {
+			return 0, errors.Errorf("unsupported partition type in BatchGet")
+		}
+		_, err := getPartitionColumnName(partitionExpr, tbl)
+		return 0, err
+	}
+	return getPartitionColumnPos(idx, partitionExpr, tbl)
+}
+
 // getPartitionColumnPos gets the partition column's position in the unique index.
 func getPartitionColumnPos(idx *model.IndexInfo, partitionExpr *tables.PartitionExpr, tbl *model.TableInfo) (int, error) {
+	partitionColName, err := getPartitionColumnName(partitionExpr, tbl)
+	if err != nil || partitionColName == nil {
+		return 0, err
+	}
+	return getColumnPosInIndex(idx, partitionColName), nil
+}
+
+// getPartitionColumnName gets the name of the partition column if the partition is decided by a single column.
+// It returns nil for the regular table.
+func getPartitionColumnName(partitionExpr *tables.PartitionExpr, tbl *model.TableInfo) (*model.CIStr, error) {
 	// regular table
 	if partitionExpr == nil {
-		return 0, nil
+		return nil, nil
 	}
 	pi := tbl.GetPartitionInfo()
 	if pi == nil {
-		return 0, nil
+		return nil, nil
 	}
 
 	var partitionColName model.CIStr
@@ -2012,12 +2094,12 @@ func getPartitionColumnPos(idx *model.IndexInfo, partitionExpr *tables.Partition
 	case model.PartitionTypeHash:
 		col, ok := partitionExpr.OrigExpr.(*ast.ColumnNameExpr)
 		if !ok {
-			return 0, errors.Errorf("unsupported partition type in BatchGet")
+			return nil, errors.Errorf("unsupported partition type in BatchGet")
 		}
 		partitionColName = col.Name.Name
 	case model.PartitionTypeKey:
 		if len(partitionExpr.KeyPartCols) != 1 {
-			return 0, errors.Errorf("unsupported partition type in BatchGet")
+			return nil, errors.Errorf("unsupported partition type in BatchGet")
 		}
 		colInfo := findColNameByColID(tbl.Columns, partitionExpr.KeyPartCols[0])
 		partitionColName = colInfo.Name
@@ -2025,7 +2107,7 @@ func getPartitionColumnPos(idx *model.IndexInfo, partitionExpr *tables.Partition
 		// left range columns partition for future development
 		col, ok := partitionExpr.Expr.(*expression.Column)
 		if !(ok && len(pi.Columns) == 0) {
-			return 0, errors.Errorf("unsupported partition type in BatchGet")
+			return nil, errors.Errorf("unsupported partition type in BatchGet")
 		}
 		colInfo := findColNameByColID(tbl.Columns, col)
 		partitionColName = colInfo.Name
@@ -2033,13 +2115,14 @@ func getPartitionColumnPos(idx *model.IndexInfo, partitionExpr *tables.Partition
 		// left list columns partition for future development
 		locateExpr, ok := partitionExpr.ForListPruning.LocateExpr.(*expression.Column)
 		if !(ok && partitionExpr.ForListPruning.ColPrunes == nil) {
-			return 0, errors.Errorf("unsupported partition type in BatchGet")
+			return nil, errors.Errorf("unsupported partition type in BatchGet")
 		}
 		colInfo := findColNameByColID(tbl.Columns, locateExpr)
 		partitionColName = colInfo.Name
+	default:
+		return nil, errors.Errorf("unsupported partition type in BatchGet")
 	}
-
-	return getColumnPosInIndex(idx, &partitionColName), nil
+	return &partitionColName, nil
 }
 
 // getColumnPosInIndex gets the column's position in the index.
@@ -2075,36 +2158,6 @@ func getPartitionExpr(ctx PlanContext, tbl *model.TableInfo) *tables.PartitionEx
 	return partTable.PartitionExpr()
 }
 
-func getHashOrKeyPartitionColumnName(ctx PlanContext, tbl *model.TableInfo) *model.CIStr {
-	pi := tbl.GetPartitionInfo()
-	if pi == nil {
-		return nil
-	}
-	if pi.Type != model.PartitionTypeHash && pi.Type != model.PartitionTypeKey {
-		return nil
-	}
-	is := ctx.GetInfoSchema().(infoschema.InfoSchema)
-	table, ok := is.TableByID(tbl.ID)
-	if !ok {
-		return nil
-	}
-	// PartitionExpr don't need columns and names for hash partition.
-	partitionExpr := table.(partitionTable).PartitionExpr()
-	if pi.Type == model.PartitionTypeKey {
-		// used to judge whether the key partition contains only one field
-		if len(pi.Columns) != 1 {
-			return nil
-		}
-		return &pi.Columns[0]
-	}
-	expr := partitionExpr.OrigExpr
-	col, ok := expr.(*ast.ColumnNameExpr)
-	if !ok {
-		return nil
-	}
-	return &col.Name.Name
-}
-
 func findColNameByColID(cols []*model.ColumnInfo, col *expression.Column) *model.ColumnInfo {
 	for _, c := range cols {
 		if c.ID == col.ID {
@@ -266,6 +266,13 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.flag |= inCreateOrDropTable
 		p.checkCreateViewGrammar(node)
 		p.checkCreateViewWithSelectGrammar(node)
+	case *ast.CreateMaterializedViewStmt:
+		p.stmtTp = TypeCreate
+		p.flag |= inCreateOrDropTable
+		p.checkCreateMaterializedViewGrammar(node)
+	case *ast.RefreshMaterializedViewStmt:
+		// the view is resolved when building the plan, it's replaced by the refreshed table.
+		p.flag |= inCreateOrDropTable
 	case *ast.DropTableStmt:
 		p.flag |= inCreateOrDropTable
 		p.stmtTp = TypeDrop
@@ -290,6 +297,37 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 	case *ast.DropDatabaseStmt:
 		p.stmtTp = TypeDrop
 		p.checkDropDatabaseGrammar(node)
+	case *ast.ProcedureInfo:
+		p.stmtTp = TypeCreate
+		p.resolveRoutineName(node.ProcedureName)
+		// the routine body is checked when the procedure is called.
+		return in, true
+	case *ast.DropProcedureStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(node.ProcedureName)
+	case *ast.CreateTriggerStmt:
+		p.stmtTp = TypeCreate
+		// The table of the trigger is in the schema of the trigger.
+		if node.Table.Schema.L == "" {
+			node.Table.Schema = node.Name.Schema
+		}
+		p.handleTableName(node.Table)
+		if node.Name.Schema.L == "" {
+			node.Name.Schema = node.Table.Schema
+		}
+		// the trigger body is checked when the trigger is created and activated.
+		return in, true
+	case *ast.DropTriggerStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(node.Name)
+	case *ast.CreateEventStmt:
+		p.stmtTp = TypeCreate
+		p.resolveRoutineName(node.Name)
+		// the schedule is evaluated when the event is created, and the body is checked when it's executed.
+		return in, true
+	case *ast.DropEventStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(node.Name)
 	case *ast.ShowStmt:
 		p.stmtTp = TypeShow
 		p.showTp = node.Tp
@@ -568,7 +606,7 @@ func (p *preprocessor) Leave(in ast.Node) (out ast.Node, ok bool) {
 		p.flag &= ^inCreateOrDropTable
 		p.checkAutoIncrement(x)
 		p.checkContainDotColumn(x)
-	case *ast.CreateViewStmt:
+	case *ast.CreateViewStmt, *ast.CreateMaterializedViewStmt, *ast.RefreshMaterializedViewStmt:
 		p.flag &= ^inCreateOrDropTable
 	case *ast.DropTableStmt, *ast.AlterTableStmt, *ast.RenameTableStmt:
 		p.flag &= ^inCreateOrDropTable
@@ -838,10 +876,10 @@ func (p *preprocessor) checkAdminCheckTableGrammar(stmt *ast.AdminStmt) {
 			return
 		}
 		tempTableType := tableInfo.Meta().TempTableType
-		if (stmt.Tp == ast.AdminCheckTable || stmt.Tp == ast.AdminChecksumTable || stmt.Tp == ast.AdminCheckIndex) && tempTableType != model.TempTableNone {
+		if (stmt.Tp == ast.AdminCheckTable || stmt.Tp == ast.AdminCheckTableOnline || stmt.Tp == ast.AdminChecksumTable || stmt.Tp == ast.AdminCheckIndex) && tempTableType != model.TempTableNone {
 			if stmt.Tp == ast.AdminChecksumTable {
 				p.err = plannererrors.ErrOptOnTemporaryTable.GenWithStackByArgs("admin checksum table")
-			} else if stmt.Tp == ast.AdminCheckTable {
+			} else if stmt.Tp == ast.AdminCheckTable || stmt.Tp == ast.AdminCheckTableOnline {
 				p.err = plannererrors.ErrOptOnTemporaryTable.GenWithStackByArgs("admin check table")
 			} else {
 				p.err = plannererrors.ErrOptOnTemporaryTable.GenWithStackByArgs("admin check index")
@@ -941,11 +979,7 @@ func (p *preprocessor) checkCreateTableGrammar(stmt *ast.CreateTableStmt) {
 	if p.err = checkUnsupportedTableOptions(stmt.Options); p.err != nil {
 		return
 	}
-	if stmt.Select != nil {
-		// FIXME: a temp error noticing 'not implemented' (issue 4754)
-		p.err = errors.New("'CREATE TABLE ... SELECT' is not implemented yet")
-		return
-	} else if len(stmt.Cols) == 0 && stmt.ReferTable == nil {
+	if len(stmt.Cols) == 0 && stmt.ReferTable == nil && stmt.Select == nil {
 		p.err = dbterror.ErrTableMustHaveColumns
 		return
 	}
@@ -1002,7 +1036,20 @@ func (p *preprocessor) checkCreateViewWithSelect(stmt ast.Node) {
 }
 
 func (p *preprocessor) checkCreateViewWithSelectGrammar(stmt *ast.CreateViewStmt) {
-	switch stmt := stmt.Select.(type) {
+	p.checkViewSelectGrammar(stmt.Select)
+}
+
+func (p *preprocessor) checkCreateMaterializedViewGrammar(stmt *ast.CreateMaterializedViewStmt) {
+	vName := stmt.ViewName.Name.String()
+	if util.IsInCorrectIdentifierName(vName) {
+		p.err = dbterror.ErrWrongTableName.GenWithStackByArgs(vName)
+		return
+	}
+	p.checkViewSelectGrammar(stmt.Select)
+}
+
+func (p *preprocessor) checkViewSelectGrammar(sel ast.StmtNode) {
+	switch stmt := sel.(type) {
 	case *ast.SelectStmt:
 		p.checkCreateViewWithSelect(stmt)
 	case *ast.SetOprStmt:
@@ -1518,6 +1565,19 @@ func (p *preprocessor) stmtType() string {
 	}
 }
 
+// resolveRoutineName fills the schema of a stored procedure or trigger name with the current database.
+func (p *preprocessor) resolveRoutineName(tn *ast.TableName) {
+	if tn.Schema.L != "" {
+		return
+	}
+	currentDB := p.sctx.GetSessionVars().CurrentDB
+	if currentDB == "" {
+		p.err = errors.Trace(plannererrors.ErrNoDB)
+		return
+	}
+	tn.Schema = model.NewCIStr(currentDB)
+}
+
 func (p *preprocessor) handleTableName(tn *ast.TableName) {
 	if tn.Schema.L == "" {
 		for _, cte := range p.preprocessWith.cteCanUsed {
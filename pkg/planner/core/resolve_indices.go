@@ -894,6 +894,17 @@ func (p *Insert) ResolveIndices() (err error) {
 			return err
 		}
 	}
+	for _, asgn := range p.BeforeTriggers {
+		newCol, err := asgn.Col.ResolveIndices(p.tableSchema)
+		if err != nil {
+			return err
+		}
+		asgn.Col = newCol.(*expression.Column)
+		asgn.Expr, err = asgn.Expr.ResolveIndices(p.tableSchema)
+		if err != nil {
+			return err
+		}
+	}
 	return
 }
 
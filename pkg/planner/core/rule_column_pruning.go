@@ -433,7 +433,10 @@ func (p *LogicalMemTable) PruneColumns(parentUsedCols []*expression.Column, opt
 		infoschema.ClusterTableDeadlocks,
 		infoschema.TableTables:
 	default:
-		return p, nil
+		// The federated tables only read the used columns from the remote server.
+		if !p.TableInfo.IsFederated() {
+			return p, nil
+		}
 	}
 	prunedColumns := make([]*expression.Column, 0)
 	used := expression.GetUsedList(p.SCtx().GetExprCtx(), parentUsedCols, p.schema)
@@ -524,6 +527,7 @@ func (p *LogicalJoin) PruneColumns(parentUsedCols []*expression.Column, opt *log
 // PruneColumns implements LogicalPlan interface.
 func (la *LogicalApply) PruneColumns(parentUsedCols []*expression.Column, opt *logicalOptimizeOp) (LogicalPlan, error) {
 	leftCols, rightCols := la.extractUsedCols(parentUsedCols)
+	la.SCtx().GetSessionVars().StmtCtx.RecordFixControl(fixcontrol.Fix45822)
 	allowEliminateApply := fixcontrol.GetBoolWithDefault(la.SCtx().GetSessionVars().GetOptimizerFixControlMap(), fixcontrol.Fix45822, true)
 	var err error
 	if allowEliminateApply && rightCols == nil && la.JoinType == LeftOuterJoin {
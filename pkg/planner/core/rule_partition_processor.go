@@ -35,7 +35,6 @@ import (
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/collate"
 	h "github.com/pingcap/tidb/pkg/util/hint"
-	"github.com/pingcap/tidb/pkg/util/mathutil"
 	"github.com/pingcap/tidb/pkg/util/plancodec"
 	"github.com/pingcap/tidb/pkg/util/ranger"
 	"github.com/pingcap/tidb/pkg/util/set"
@@ -206,12 +205,18 @@ func (s *partitionProcessor) getUsedHashPartitions(ctx PlanContext,
 				// if range is less than the number of partitions, there will be unused partitions we can prune out.
 				if rangeScalar < uint64(numPartitions) && !highIsNull && !lowIsNull {
 					var i int64
+					m := make(map[int]struct{})
 					for i = 0; i <= int64(rangeScalar); i++ {
-						idx := mathutil.Abs((posLow + i) % int64(numPartitions))
+						idx := tables.HashPartitionIndex(posLow+i, uint64(numPartitions), pi.IsLinear)
+						if _, ok := m[idx]; ok {
+							// Values of LINEAR HASH may be in a same partition, we should skip.
+							continue
+						}
 						if len(partitionNames) > 0 && !s.findByName(partitionNames, pi.Definitions[idx].Name.L) {
 							continue
 						}
-						used = append(used, int(idx))
+						used = append(used, idx)
+						m[idx] = struct{}{}
 					}
 					continue
 				}
@@ -252,11 +257,11 @@ func (s *partitionProcessor) getUsedHashPartitions(ctx PlanContext,
 		if isNull {
 			pos = 0
 		}
-		idx := mathutil.Abs(pos % int64(pi.Num))
+		idx := tables.HashPartitionIndex(pos, pi.Num, pi.IsLinear)
 		if len(partitionNames) > 0 && !s.findByName(partitionNames, pi.Definitions[idx].Name.L) {
 			continue
 		}
-		used = append(used, int(idx))
+		used = append(used, idx)
 	}
 	return used, detachedResult.RemainedConds, nil
 }
@@ -267,7 +272,7 @@ func (s *partitionProcessor) getUsedKeyPartitions(ctx PlanContext,
 	pi := tbl.Meta().Partition
 	partExpr := tbl.(partitionTable).PartitionExpr()
 	partCols, colLen := partExpr.GetPartColumnsForKeyPartition(columns)
-	pe := &tables.ForKeyPruning{KeyPartCols: partCols}
+	pe := &tables.ForKeyPruning{KeyPartCols: partCols, IsLinear: pi.IsLinear, Algorithm: pi.KeyAlgorithm}
 	detachedResult, err := ranger.DetachCondAndBuildRangeForPartition(ctx, conds, partCols, colLen, ctx.GetSessionVars().RangeMaxSize)
 	if err != nil {
 		return nil, nil, err
@@ -535,6 +540,18 @@ func newListPartitionPruner(ctx PlanContext, tbl table.Table, partitionNames []m
 			}
 		}
 	}
+	// The columns in PruneExpr should also refer to the columns of the data source,
+	// so that the conditions on the partition expression itself can be matched.
+	if pruneList.PruneExpr != nil {
+		for _, c := range expression.ExtractColumns(pruneList.PruneExpr) {
+			for j := range columns {
+				if columns[j].ID == c.ID {
+					c.UniqueID = columns[j].UniqueID
+					break
+				}
+			}
+		}
+	}
 	for i := range pruneList.ColPrunes {
 		for j := range columns {
 			if columns[j].ID == pruneList.ColPrunes[i].ExprCol.ID {
@@ -757,27 +774,281 @@ func (l *listPartitionPruner) findUsedListPartitions(conds []expression.Expressi
 	}
 	used := make(map[int]struct{}, len(ranges))
 	tc := l.ctx.GetSessionVars().StmtCtx.TypeCtx()
+	hasRange := false
 	for _, r := range ranges {
 		if !r.IsPointNullable(tc) {
-			return l.fullRange, nil
+			if r.IsFullRange(false) {
+				used = l.fullRange
+				break
+			}
+			idxs, ok := l.locateRangePartitions(r)
+			if !ok {
+				used = l.fullRange
+				break
+			}
+			hasRange = true
+			for _, idx := range idxs {
+				l.addUsedPartition(used, idx)
+			}
+			continue
 		}
 		if len(r.HighVal) != len(exprCols) {
-			return l.fullRange, nil
+			used = l.fullRange
+			break
 		}
 		value, isNull, err := pruneExpr.EvalInt(l.ctx.GetExprCtx(), chunk.MutRowFromDatums(r.HighVal).ToRow())
 		if err != nil {
 			return nil, err
 		}
-		partitionIdx := l.listPrune.LocatePartition(value, isNull)
-		if partitionIdx == -1 {
+		l.addUsedPartition(used, l.listPrune.LocatePartition(value, isNull))
+	}
+	// Keep it as the full range if the ranges cover all partitions, just like before.
+	if hasRange && len(l.partitionNames) == 0 && len(used) == len(l.pi.Definitions) {
+		used = l.fullRange
+	}
+
+	// The conditions on the partition expression itself, such as 'year(dt) in (2020, 2021)' or 'a + b > 10',
+	// can't be handled by the ranges built on the columns, try to use them to prune more partitions.
+	exprUsed, ok := l.locatePartitionsByPartExprCNF(conds)
+	if !ok {
+		return used, nil
+	}
+	if _, ok := used[FullRange]; ok {
+		return exprUsed, nil
+	}
+	for idx := range used {
+		if _, ok := exprUsed[idx]; !ok {
+			delete(used, idx)
+		}
+	}
+	return used, nil
+}
+
+func (l *listPartitionPruner) addUsedPartition(used map[int]struct{}, partitionIdx int) {
+	if partitionIdx == -1 {
+		return
+	}
+	if len(l.partitionNames) > 0 && !l.findByName(l.partitionNames, l.pi.Definitions[partitionIdx].Name.L) {
+		return
+	}
+	used[partitionIdx] = struct{}{}
+}
+
+// locateRangePartitions locates the partitions for a range which is not a point.
+// It only works when the partition expression is a column or a monotone function of a column,
+// such as 'partition by list (to_days(dt))', the range [low, high] on the column is converted to
+// [fn(low), fn(high)] on the partition expression.
+func (l *listPartitionPruner) locateRangePartitions(r *ranger.Range) ([]int, bool) {
+	pruneExpr := l.listPrune.PruneExpr
+	if len(l.listPrune.PruneExprCols) != 1 || len(r.LowVal) != 1 || len(r.HighVal) != 1 {
+		return nil, false
+	}
+	if sf, ok := pruneExpr.(*expression.ScalarFunction); ok {
+		if getMonotoneMode(sf.FuncName.L) == monotoneModeInvalid {
+			return nil, false
+		}
+		if _, ok := sf.GetArgs()[0].(*expression.Column); !ok {
+			return nil, false
+		}
+	} else if _, ok := pruneExpr.(*expression.Column); !ok {
+		return nil, false
+	}
+	// The excluded bounds can be tightened only if the partition expression is the column itself.
+	_, isCol := pruneExpr.(*expression.Column)
+
+	unsigned := mysql.HasUnsignedFlag(pruneExpr.GetType().GetFlag())
+	low, high := int64(math.MinInt64), int64(math.MaxInt64)
+	if unsigned {
+		low, high = 0, -1 // -1 is math.MaxUint64
+	}
+	var ret []int
+	switch r.LowVal[0].Kind() {
+	case types.KindNull:
+		// The range starts from NULL, the partition for NULL is needed.
+		if idx := l.listPrune.LocatePartition(0, true); idx != -1 {
+			ret = append(ret, idx)
+		}
+	case types.KindMinNotNull:
+	default:
+		value, isNull, err := pruneExpr.EvalInt(l.ctx.GetExprCtx(), chunk.MutRowFromDatums(r.LowVal).ToRow())
+		if err != nil {
+			return nil, false
+		}
+		if !isNull {
+			low = value
+			if isCol && r.LowExclude {
+				if value == high {
+					return ret, true
+				}
+				low++
+			}
+		}
+	}
+	switch r.HighVal[0].Kind() {
+	case types.KindNull:
+		// Only NULL is in the range.
+		return ret, true
+	case types.KindMaxValue:
+	default:
+		value, isNull, err := pruneExpr.EvalInt(l.ctx.GetExprCtx(), chunk.MutRowFromDatums(r.HighVal).ToRow())
+		if err != nil {
+			return nil, false
+		}
+		if !isNull {
+			high = value
+			if isCol && r.HighExclude {
+				if unsigned && value == 0 || !unsigned && value == math.MinInt64 {
+					return ret, true
+				}
+				high--
+			}
+		}
+	}
+	return append(ret, l.listPrune.LocateRange(low, high, unsigned)...), true
+}
+
+// locatePartitionsByPartExprCNF locates the partitions by the conditions on the partition expression itself.
+// The returned bool is false when none of the conditions can be used.
+func (l *listPartitionPruner) locatePartitionsByPartExprCNF(conds []expression.Expression) (map[int]struct{}, bool) {
+	var used map[int]struct{}
+	for _, cond := range conds {
+		condUsed, ok := l.locatePartitionsByPartExpr(cond)
+		if !ok {
 			continue
 		}
-		if len(l.partitionNames) > 0 && !l.findByName(l.partitionNames, l.pi.Definitions[partitionIdx].Name.L) {
+		if used == nil {
+			used = condUsed
 			continue
 		}
-		used[partitionIdx] = struct{}{}
+		for idx := range used {
+			if _, ok := condUsed[idx]; !ok {
+				delete(used, idx)
+			}
+		}
 	}
-	return used, nil
+	return used, used != nil
+}
+
+// locatePartitionsByPartExpr locates the partitions by a condition like 'fn(a, b) op const',
+// 'fn(a, b) in (const, ...)' or 'fn(a, b) is null', where fn(a, b) is the partition expression.
+func (l *listPartitionPruner) locatePartitionsByPartExpr(cond expression.Expression) (map[int]struct{}, bool) {
+	sf, ok := cond.(*expression.ScalarFunction)
+	if !ok {
+		return nil, false
+	}
+	used := make(map[int]struct{})
+	args := sf.GetArgs()
+	switch sf.FuncName.L {
+	case ast.LogicOr:
+		for _, item := range expression.FlattenDNFConditions(sf) {
+			itemUsed, ok := l.locatePartitionsByPartExpr(item)
+			if !ok {
+				return nil, false
+			}
+			for idx := range itemUsed {
+				used[idx] = struct{}{}
+			}
+		}
+		return used, true
+	case ast.LogicAnd:
+		return l.locatePartitionsByPartExprCNF(expression.FlattenCNFConditions(sf))
+	case ast.IsNull:
+		if !l.isPartExpr(args[0]) {
+			return nil, false
+		}
+		l.addUsedPartition(used, l.listPrune.LocatePartition(0, true))
+		return used, true
+	case ast.In:
+		if !l.isPartExpr(args[0]) {
+			return nil, false
+		}
+		for _, arg := range args[1:] {
+			con, ok := arg.(*expression.Constant)
+			if !ok {
+				return nil, false
+			}
+			if con.Value.IsNull() {
+				continue
+			}
+			value, ok := l.extractPartExprConst(con)
+			if !ok {
+				return nil, false
+			}
+			l.addUsedPartition(used, l.listPrune.LocatePartition(value, false))
+		}
+		return used, true
+	case ast.EQ, ast.LT, ast.LE, ast.GT, ast.GE:
+	default:
+		return nil, false
+	}
+
+	op := sf.FuncName.L
+	var con *expression.Constant
+	if l.isPartExpr(args[0]) {
+		con, ok = args[1].(*expression.Constant)
+	} else if l.isPartExpr(args[1]) {
+		con, ok = args[0].(*expression.Constant)
+		op = opposite(op)
+	}
+	if con == nil || !ok {
+		return nil, false
+	}
+	value, ok := l.extractPartExprConst(con)
+	if !ok {
+		return nil, false
+	}
+	if op == ast.EQ {
+		l.addUsedPartition(used, l.listPrune.LocatePartition(value, false))
+		return used, true
+	}
+	unsigned := mysql.HasUnsignedFlag(l.listPrune.PruneExpr.GetType().GetFlag())
+	low, high := int64(math.MinInt64), int64(math.MaxInt64)
+	if unsigned {
+		low, high = 0, -1 // -1 is math.MaxUint64
+	}
+	switch op {
+	case ast.LT:
+		if value == low {
+			return used, true
+		}
+		high = value - 1
+	case ast.LE:
+		high = value
+	case ast.GT:
+		if value == high {
+			return used, true
+		}
+		low = value + 1
+	case ast.GE:
+		low = value
+	}
+	for _, idx := range l.listPrune.LocateRange(low, high, unsigned) {
+		l.addUsedPartition(used, idx)
+	}
+	return used, true
+}
+
+// isPartExpr checks whether the expression is the partition expression itself.
+func (l *listPartitionPruner) isPartExpr(expr expression.Expression) bool {
+	if _, ok := expr.(*expression.ScalarFunction); !ok {
+		// The conditions on the column are handled by the ranges.
+		return false
+	}
+	return l.listPrune.PruneExpr.Equal(l.ctx.GetExprCtx(), expr)
+}
+
+// extractPartExprConst extracts the value of the constant compared with the partition expression,
+// it fails when the value can't be compared with the partition values directly.
+func (l *listPartitionPruner) extractPartExprConst(con *expression.Constant) (int64, bool) {
+	value, unsigned, ok := extractPartExprConst(l.ctx, con)
+	if !ok {
+		return 0, false
+	}
+	if value < 0 && unsigned != mysql.HasUnsignedFlag(l.listPrune.PruneExpr.GetType().GetFlag()) {
+		// The value overflows the type of the partition expression.
+		return 0, false
+	}
+	return value, true
 }
 
 func (s *partitionProcessor) findUsedListPartitions(ctx PlanContext, tbl table.Table, partitionNames []model.CIStr,
@@ -1007,21 +1278,33 @@ func (s *partitionProcessor) pruneRangePartition(ctx PlanContext, pi *model.Part
 		return nil, err
 	}
 	result := fullRange(len(pi.Definitions))
-	if col == nil {
+	if col == nil && fn == nil {
 		return result, nil
 	}
 
 	// Extract the partition column, if the column is not null, it's possible to prune.
+	// If the partition expression is over multiple columns, such as 'a + b', only the
+	// conditions on the whole partition expression can be used for pruning.
+	var unsigned bool
+	if col != nil {
+		unsigned = mysql.HasUnsignedFlag(col.GetType().GetFlag())
+	} else {
+		unsigned = mysql.HasUnsignedFlag(fn.GetType().GetFlag())
+	}
 	pruner := rangePruner{
 		lessThan: lessThanDataInt{
 			data:     partExpr.ForRangePruning.LessThan,
-			unsigned: mysql.HasUnsignedFlag(col.GetType().GetFlag()),
+			unsigned: unsigned,
 			maxvalue: partExpr.ForRangePruning.MaxValue,
 		},
 		col:        col,
 		partFn:     fn,
 		monotonous: mono,
 	}
+	if fn != nil {
+		// partFn is modified in place when replacing the column with constants, keep a copy of it.
+		pruner.partExpr = fn.Clone()
+	}
 	result = partitionRangeForCNFExpr(ctx, conds, &pruner, result)
 
 	return result, nil
@@ -1323,6 +1606,35 @@ type rangePruner struct {
 	partFn   *expression.ScalarFunction
 	// If partFn is not nil, monotonous indicates partFn is monotonous or not.
 	monotonous monotoneMode
+	// partExpr is the untouched partition expression, it's used to match the
+	// conditions on the partition expression itself, such as 'to_days(dt) > 737000'.
+	partExpr expression.Expression
+}
+
+// isPartExpr checks whether the expression is the partition expression itself.
+func (p *rangePruner) isPartExpr(sctx PlanContext, expr expression.Expression) bool {
+	if p.partExpr == nil {
+		return false
+	}
+	if _, ok := expr.(*expression.ScalarFunction); !ok {
+		return false
+	}
+	return p.partExpr.Equal(sctx.GetExprCtx(), expr)
+}
+
+// extractPartExprConst extracts the integer value of the constant compared with the partition expression.
+func extractPartExprConst(sctx PlanContext, con *expression.Constant) (int64, bool, bool) {
+	if con.GetType().EvalType() != types.ETInt {
+		return 0, false, false
+	}
+	if !expression.ConstExprConsiderPlanCache(con, sctx.GetSessionVars().StmtCtx.UseCache) {
+		return 0, false, false
+	}
+	c, isNull, err := con.EvalInt(sctx.GetExprCtx(), chunk.Row{})
+	if err != nil || isNull {
+		return 0, false, false
+	}
+	return c, mysql.HasUnsignedFlag(con.GetType().GetFlag()), true
 }
 
 func (p *rangePruner) partitionRangeForExpr(sctx PlanContext, expr expression.Expression) (start int, end int, ok bool) {
@@ -1393,8 +1705,11 @@ func partitionRangeColumnForInExpr(sctx PlanContext, args []expression.Expressio
 
 func partitionRangeForInExpr(sctx PlanContext, args []expression.Expression,
 	pruner *rangePruner) partitionRangeOR {
+	if pruner.isPartExpr(sctx, args[0]) {
+		return partitionRangeForPartExprInExpr(sctx, args, pruner)
+	}
 	col, ok := args[0].(*expression.Column)
-	if !ok || col.ID != pruner.col.ID {
+	if !ok || pruner.col == nil || col.ID != pruner.col.ID {
 		return pruner.fullRange()
 	}
 
@@ -1431,6 +1746,30 @@ func partitionRangeForInExpr(sctx PlanContext, args []expression.Expression,
 	return result.simplify()
 }
 
+// partitionRangeForPartExprInExpr calculates the partitions for 'fn(col) in (const, ...)',
+// where fn(col) is the partition expression itself.
+func partitionRangeForPartExprInExpr(sctx PlanContext, args []expression.Expression,
+	pruner *rangePruner) partitionRangeOR {
+	var result partitionRangeOR
+	for i := 1; i < len(args); i++ {
+		constExpr, ok := args[i].(*expression.Constant)
+		if !ok {
+			return pruner.fullRange()
+		}
+		if constExpr.Value.Kind() == types.KindNull {
+			// NULL never equals to anything.
+			continue
+		}
+		val, unsigned, ok := extractPartExprConst(sctx, constExpr)
+		if !ok {
+			return pruner.fullRange()
+		}
+		start, end := pruneUseBinarySearch(pruner.lessThan, dataForPrune{op: ast.EQ, c: val, unsigned: unsigned})
+		result = append(result, partitionRange{start, end})
+	}
+	return result.simplify()
+}
+
 type monotoneMode int
 
 const (
@@ -1478,8 +1817,12 @@ func (p *rangePruner) extractDataForPrune(sctx PlanContext, expr expression.Expr
 	case ast.EQ, ast.LT, ast.GT, ast.LE, ast.GE:
 		ret.op = op.FuncName.L
 	case ast.IsNull:
-		// isnull(col)
-		if arg0, ok := op.GetArgs()[0].(*expression.Column); ok && arg0.ID == p.col.ID {
+		// isnull(col) or isnull(fn(col))
+		if arg0, ok := op.GetArgs()[0].(*expression.Column); ok && p.col != nil && arg0.ID == p.col.ID {
+			ret.op = ast.IsNull
+			return ret, true
+		}
+		if p.isPartExpr(sctx, op.GetArgs()[0]) {
 			ret.op = ast.IsNull
 			return ret, true
 		}
@@ -1488,6 +1831,24 @@ func (p *rangePruner) extractDataForPrune(sctx PlanContext, expr expression.Expr
 		return ret, false
 	}
 
+	// The expression is 'fn(col) op const' where fn(col) is the partition expression itself,
+	// the constant can be compared with the partition values directly.
+	if p.isPartExpr(sctx, op.GetArgs()[0]) || p.isPartExpr(sctx, op.GetArgs()[1]) {
+		con, ok := op.GetArgs()[1].(*expression.Constant)
+		if !p.isPartExpr(sctx, op.GetArgs()[0]) {
+			con, ok = op.GetArgs()[0].(*expression.Constant)
+			ret.op = opposite(ret.op)
+		}
+		if !ok {
+			return ret, false
+		}
+		ret.c, ret.unsigned, ok = extractPartExprConst(sctx, con)
+		return ret, ok
+	}
+	if p.col == nil {
+		return ret, false
+	}
+
 	var col *expression.Column
 	var con *expression.Constant
 	if arg0, ok := op.GetArgs()[0].(*expression.Column); ok && arg0.ID == p.col.ID {
@@ -842,6 +842,13 @@ func (p *LogicalMemTable) PredicatePushDown(predicates []expression.Expression,
 	return predicates, p.self
 }
 
+// PredicatePushDown implements LogicalPlan PredicatePushDown interface.
+func (p *LogicalShowDDLJobs) PredicatePushDown(predicates []expression.Expression, _ *logicalOptimizeOp) ([]expression.Expression, LogicalPlan) {
+	p.Extractor = &DDLJobsExtractor{}
+	predicates = p.Extractor.Extract(p.SCtx(), p.schema, p.names, predicates)
+	return predicates, p.self
+}
+
 func (*ppdSolver) name() string {
 	return "predicate_push_down"
 }
@@ -115,6 +115,26 @@ func (rf *RuntimeFilter) assign(targetNode *PhysicalTableScan, targetExpr *expre
 		zap.String("RuntimeFilter", rf.String()))
 }
 
+// ID returns the id of runtime filter.
+func (rf *RuntimeFilter) ID() int {
+	return rf.id
+}
+
+// Type returns the type of runtime filter.
+func (rf *RuntimeFilter) Type() RuntimeFilterType {
+	return rf.rfType
+}
+
+// SrcExprList returns the columns of the build side which the runtime filter is built from.
+func (rf *RuntimeFilter) SrcExprList() []*expression.Column {
+	return rf.srcExprList
+}
+
+// TargetExprList returns the columns of the probe side which the runtime filter is applied to.
+func (rf *RuntimeFilter) TargetExprList() []*expression.Column {
+	return rf.targetExprList
+}
+
 // ExplainInfo explain info of runtime filter
 func (rf *RuntimeFilter) ExplainInfo(isBuildNode bool) string {
 	var builder strings.Builder
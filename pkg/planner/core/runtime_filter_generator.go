@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"go.uber.org/zap"
@@ -78,11 +79,9 @@ func (generator *RuntimeFilterGenerator) GenerateRuntimeFilter(plan PhysicalPlan
 }
 
 func (generator *RuntimeFilterGenerator) generateRuntimeFilterInterval(hashJoinPlan *PhysicalHashJoin) {
-	// precondition: the storage type of hash join must be TiFlash
+	// the hash join executed in TiDB generates RF for the TiKV scans of its probe side
 	if hashJoinPlan.storeTp != kv.TiFlash {
-		logutil.BgLogger().Warn("RF only support TiFlash compute engine while storage type of hash join node is not TiFlash",
-			zap.Int("PhysicalHashJoinId", hashJoinPlan.ID()),
-			zap.String("StoreTP", hashJoinPlan.storeTp.Name()))
+		generator.generateTiKVRuntimeFilter(hashJoinPlan)
 		return
 	}
 	// check hash join pattern
@@ -105,6 +104,114 @@ func (generator *RuntimeFilterGenerator) generateRuntimeFilterInterval(hashJoinP
 	}
 }
 
+// generateTiKVRuntimeFilter constructs RF for the hash join executed in TiDB.
+// The RF is built by TiDB after the build side of the join is fetched, and it is pushed down to the coprocessor
+// requests of the TiKV table reader on the probe side as the conditions of a selection.
+// For example:
+/*
+PhysicalPlanTree:
+        HashJoin (with RF1)
+         /          \
+ TableReader       TableReader
+      |                 |
+ Selection(RF1)     TableScan
+      |
+ TableScan(assign RF1)
+*/
+// Only the table reader reached from the probe side through projections and selections is the target,
+// because the operators like limit or aggregation change the result if their input is filtered.
+func (generator *RuntimeFilterGenerator) generateTiKVRuntimeFilter(hashJoinPlan *PhysicalHashJoin) {
+	if !generator.matchRFJoinType(hashJoinPlan) {
+		return
+	}
+	probeChild := hashJoinPlan.children[1]
+	if hashJoinPlan.RightIsBuildSide() {
+		probeChild = hashJoinPlan.children[0]
+	}
+	tableReader, tableScan := findTiKVRuntimeFilterTarget(probeChild)
+	if tableScan == nil {
+		return
+	}
+	var currentRFList []*RuntimeFilter
+	for _, eqPredicate := range hashJoinPlan.EqualConditions {
+		if !generator.matchEQPredicate(eqPredicate, hashJoinPlan.RightIsBuildSide()) ||
+			!matchTiKVRFType(eqPredicate) {
+			continue
+		}
+		newRFList, targetColumnUniqueID := NewRuntimeFilter(generator.rfIDGenerator, eqPredicate, hashJoinPlan)
+		var targetColumn *expression.Column
+		for _, scanOutputColumn := range tableScan.schema.Columns {
+			if scanOutputColumn.UniqueID == targetColumnUniqueID {
+				targetColumn = scanOutputColumn
+				break
+			}
+		}
+		if targetColumn == nil {
+			continue
+		}
+		for _, runtimeFilter := range newRFList {
+			runtimeFilter.rfMode = variable.RFLocal
+			runtimeFilter.assign(tableScan, targetColumn)
+			currentRFList = append(currentRFList, runtimeFilter)
+		}
+	}
+	if len(currentRFList) == 0 {
+		return
+	}
+	// supply selection to carry the RF conditions if there is no predicates above target scan node
+	sel, ok := tableReader.tablePlan.(*PhysicalSelection)
+	if !ok {
+		// StatsInfo: Just set a placeholder value here, and this value will not be used in subsequent optimizations
+		sel = PhysicalSelection{}.Init(tableScan.SCtx(), tableScan.StatsInfo(), tableScan.QueryBlockOffset())
+		sel.SetChildren(tableScan)
+		tableReader.tablePlan = sel
+		tableReader.TablePlans = flattenPushDownPlan(sel)
+	}
+	sel.hasRFConditions = true
+}
+
+// findTiKVRuntimeFilterTarget finds the TiKV table reader whose table plan is a table scan or a selection on it.
+func findTiKVRuntimeFilterTarget(plan PhysicalPlan) (*PhysicalTableReader, *PhysicalTableScan) {
+	for {
+		switch x := plan.(type) {
+		case *PhysicalSelection, *PhysicalProjection:
+			plan = x.Children()[0]
+		case *PhysicalTableReader:
+			if x.StoreType != kv.TiKV {
+				return nil, nil
+			}
+			tablePlan := x.tablePlan
+			if sel, ok := tablePlan.(*PhysicalSelection); ok {
+				tablePlan = sel.children[0]
+			}
+			tableScan, ok := tablePlan.(*PhysicalTableScan)
+			if !ok {
+				return nil, nil
+			}
+			return x, tableScan
+		default:
+			return nil, nil
+		}
+	}
+}
+
+// matchTiKVRFType checks whether the values of the src column can be used to filter the target column directly.
+// Only the integer columns with the same signedness and the string columns with the same collation are supported.
+func matchTiKVRFType(eqPredicate *expression.ScalarFunction) bool {
+	leftType := eqPredicate.GetArgs()[0].GetType()
+	rightType := eqPredicate.GetArgs()[1].GetType()
+	if leftType.Hybrid() || rightType.Hybrid() || leftType.EvalType() != rightType.EvalType() {
+		return false
+	}
+	switch leftType.EvalType() {
+	case types.ETInt:
+		return mysql.HasUnsignedFlag(leftType.GetFlag()) == mysql.HasUnsignedFlag(rightType.GetFlag())
+	case types.ETString:
+		return leftType.GetCollate() == rightType.GetCollate()
+	}
+	return false
+}
+
 func (generator *RuntimeFilterGenerator) assignRuntimeFilter(physicalTableScan *PhysicalTableScan) {
 	// match rf for current scan node
 	cacheBuildNodeIDToRFMode := map[int]RuntimeFilterMode{}
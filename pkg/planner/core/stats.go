@@ -1013,6 +1013,10 @@ func (p *LogicalCTE) DeriveStats(_ []*property.StatsInfo, selfSchema *expression
 		// Build push-downed predicates.
 		if len(p.cte.pushDownPredicates) > 0 {
 			newCond := expression.ComposeDNFCondition(p.SCtx().GetExprCtx(), p.cte.pushDownPredicates...)
+			if _, ok := newCond.(*expression.Constant); !ok {
+				// The result is filtered by the predicates of the references, it can't be shared with other CTEs.
+				p.cte.Digest = ""
+			}
 			newSel := LogicalSelection{Conditions: []expression.Expression{newCond}}.Init(p.SCtx(), p.cte.seedPartLogicalPlan.QueryBlockOffset())
 			newSel.SetChildren(p.cte.seedPartLogicalPlan)
 			p.cte.seedPartLogicalPlan = newSel
@@ -1302,6 +1302,11 @@ func CheckAggCanPushCop(sctx PlanContext, aggFuncs []*aggregation.AggFuncDesc, g
 			ret = false
 			break
 		}
+		if storeType == kv.TiKV && aggregation.IsConcatAgg(aggFunc.Name) && !sctx.GetSessionVars().EnableTiKVConcatAggPushDown {
+			reason = "AggFunc `" + aggFunc.Name + "` is not enabled to push down to TiKV by tidb_opt_enable_tikv_concat_agg_push_down"
+			ret = false
+			break
+		}
 		if !expression.CanExprsPushDownWithExtraInfo(sctx.GetExprCtx(), aggFunc.Args, client, storeType, aggFunc.Name == ast.AggFuncSum) {
 			reason = "arguments of AggFunc `" + aggFunc.Name + "` contains unsupported exprs"
 			ret = false
@@ -0,0 +1,111 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+)
+
+// TriggerNewRow is the name of the row to be inserted in the trigger body.
+var TriggerNewRow = model.NewCIStr("new")
+
+// ParseTriggerBody parses the body of the trigger and returns the statements executed for each row.
+func ParseTriggerBody(sctx PlanContext, trigger *model.TriggerInfo) ([]ast.StmtNode, error) {
+	p := parser.New()
+	p.SetSQLMode(trigger.SQLMode)
+	p.SetParserConfig(sctx.GetSessionVars().BuildParserConfig())
+	// the names of the trigger and the table don't matter, only the body is used.
+	sql := fmt.Sprintf("CREATE TRIGGER tr %s %s ON t FOR EACH ROW %s", trigger.Timing, trigger.Event, trigger.Body)
+	stmt, err := p.ParseOneStmt(sql, trigger.CharacterSetClient, trigger.CollationConnection)
+	if err != nil {
+		return nil, err
+	}
+	body := stmt.(*ast.CreateTriggerStmt).Body
+	if block, ok := body.(*ast.ProcedureBlock); ok {
+		return block.ProcedureProcStmts, nil
+	}
+	return []ast.StmtNode{body}, nil
+}
+
+// buildBeforeInsertTriggers builds the assignments of the BEFORE INSERT triggers of the table. The assignments
+// are evaluated in order on each row to be inserted, which is referred by `NEW` in the trigger body.
+func (b *PlanBuilder) buildBeforeInsertTriggers(ctx context.Context, tableInfo *model.TableInfo, mockPlan *LogicalTableDual) ([]*expression.Assignment, error) {
+	var (
+		assignments []*expression.Assignment
+		newRowPlan  *LogicalTableDual
+	)
+	for _, trigger := range tableInfo.Triggers {
+		if trigger.Timing != model.TriggerTimingBefore || trigger.Event != model.TriggerEventInsert {
+			continue
+		}
+		if newRowPlan == nil {
+			newRowPlan = LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+			newRowPlan.SetSchema(mockPlan.Schema())
+			newRowPlan.names = make(types.NameSlice, 0, len(mockPlan.names))
+			for _, name := range mockPlan.names {
+				newRowPlan.names = append(newRowPlan.names, &types.FieldName{
+					OrigTblName: name.OrigTblName,
+					OrigColName: name.OrigColName,
+					TblName:     TriggerNewRow,
+					ColName:     name.ColName,
+					Hidden:      name.Hidden,
+				})
+			}
+		}
+		stmts, err := ParseTriggerBody(b.ctx, trigger)
+		if err != nil {
+			return nil, err
+		}
+		for _, stmt := range stmts {
+			set, ok := stmt.(*ast.SetStmt)
+			if !ok {
+				return nil, errors.Errorf("unsupported statement in BEFORE trigger %s", trigger.Name.O)
+			}
+			for _, v := range set.Variables {
+				colName := strings.TrimPrefix(strings.ToLower(v.Name), TriggerNewRow.L+".")
+				idx := -1
+				for i, name := range newRowPlan.names {
+					if name.ColName.L == colName && !name.Hidden {
+						idx = i
+						break
+					}
+				}
+				if idx < 0 {
+					return nil, plannererrors.ErrUnknownColumn.GenWithStackByArgs(colName, "NEW")
+				}
+				expr, _, err := b.rewrite(ctx, v.Value, newRowPlan, nil, true)
+				if err != nil {
+					return nil, err
+				}
+				assignments = append(assignments, &expression.Assignment{
+					Col:     newRowPlan.Schema().Columns[idx],
+					ColName: newRowPlan.names[idx].ColName,
+					Expr:    expr,
+				})
+			}
+		}
+	}
+	return assignments, nil
+}
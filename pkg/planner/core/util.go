@@ -28,7 +28,6 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/core/internal/base"
 	"github.com/pingcap/tidb/pkg/table/tables"
 	"github.com/pingcap/tidb/pkg/types"
-	"github.com/pingcap/tidb/pkg/util/mathutil"
 	"github.com/pingcap/tidb/pkg/util/set"
 	"github.com/pingcap/tidb/pkg/util/size"
 )
@@ -425,8 +424,7 @@ func GetPhysID(tblInfo *model.TableInfo, partitionExpr *tables.PartitionExpr, d
 
 	switch pi.Type {
 	case model.PartitionTypeHash:
-		intVal := d.GetInt64()
-		partIdx := mathutil.Abs(intVal % int64(pi.Num))
+		partIdx := tables.HashPartitionIndex(d.GetInt64(), pi.Num, pi.IsLinear)
 		return pi.Definitions[partIdx].ID, nil
 	case model.PartitionTypeKey:
 		if partitionExpr.ForKeyPruning == nil ||
@@ -437,7 +435,11 @@ func GetPhysID(tblInfo *model.TableInfo, partitionExpr *tables.PartitionExpr, d
 		col := &expression.Column{}
 		*col = *partitionExpr.KeyPartCols[0]
 		col.Index = 0
-		newKeyPartExpr := tables.ForKeyPruning{KeyPartCols: []*expression.Column{col}}
+		newKeyPartExpr := tables.ForKeyPruning{
+			KeyPartCols: []*expression.Column{col},
+			IsLinear:    partitionExpr.ForKeyPruning.IsLinear,
+			Algorithm:   partitionExpr.Algorithm,
+		}
 		partIdx, err := newKeyPartExpr.LocateKeyPartition(pi.Num, []types.Datum{d})
 		if err != nil {
 			return 0, errors.Errorf("unsupported partition type in BatchGet")
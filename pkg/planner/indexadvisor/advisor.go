@@ -0,0 +1,291 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// OptionMaxNumIndex is the max number of the recommended indexes.
+	OptionMaxNumIndex = "max_num_index"
+	// OptionMaxIndexColumns is the max number of columns in a recommended index.
+	OptionMaxIndexColumns = "max_index_columns"
+	// OptionMaxNumQuery is the max number of queries loaded from the statement summary.
+	OptionMaxNumQuery = "max_num_query"
+
+	// minCostReduction is the min cost reduction in proportion for an index to be recommended.
+	minCostReduction = 0.01
+)
+
+// Options are the options of the index advisor.
+type Options struct {
+	MaxNumIndex     int
+	MaxIndexColumns int
+	MaxNumQuery     int
+}
+
+// DefaultOptions returns the default options of the index advisor.
+func DefaultOptions() *Options {
+	return &Options{
+		MaxNumIndex:     5,
+		MaxIndexColumns: 3,
+		MaxNumQuery:     1000,
+	}
+}
+
+// SetOption sets the option by its name.
+func (o *Options) SetOption(name string, value uint64) error {
+	if value == 0 || value > 10000 {
+		return errors.Errorf("invalid value %d for option %s", value, name)
+	}
+	switch strings.ToLower(name) {
+	case OptionMaxNumIndex:
+		o.MaxNumIndex = int(value)
+	case OptionMaxIndexColumns:
+		if value > mysql.MaxKeyParts {
+			return errors.Errorf("invalid value %d for option %s", value, name)
+		}
+		o.MaxIndexColumns = int(value)
+	case OptionMaxNumQuery:
+		o.MaxNumQuery = int(value)
+	default:
+		return errors.Errorf("unknown option %s", name)
+	}
+	return nil
+}
+
+// Recommendation is an index recommended by the index advisor.
+type Recommendation struct {
+	Database     string
+	Table        string
+	IndexName    string
+	IndexColumns []string
+	// CostReduction is the estimated cost reduction of the workload in proportion.
+	CostReduction float64
+	// TopImpactedQuery is the query benefits most from the index.
+	TopImpactedQuery string
+}
+
+// CreateIndexStatement returns the statement to create the recommended index.
+func (r *Recommendation) CreateIndexStatement() string {
+	cols := make([]string, 0, len(r.IndexColumns))
+	for _, col := range r.IndexColumns {
+		cols = append(cols, fmt.Sprintf("`%s`", col))
+	}
+	return fmt.Sprintf("CREATE INDEX `%s` ON `%s`.`%s`(%s)", r.IndexName, r.Database, r.Table, strings.Join(cols, ", "))
+}
+
+// workloadQuery is a query in the workload.
+type workloadQuery struct {
+	schema    string
+	text      string
+	frequency float64
+	// tables are the keys of the tables used by the query.
+	tables map[string]struct{}
+}
+
+// AdviseIndexes recommends indexes for the query, or for the workload in the statement summary if the query is empty.
+// The candidate indexes are evaluated by the cost of the plans as if they exist, which is done by
+// explaining the queries with the hypothetical indexes in se. se should be a session dedicated to the advisor.
+func AdviseIndexes(ctx context.Context, se sessionctx.Context, is infoschema.InfoSchema,
+	defaultDB string, query string, opts *Options) ([]*Recommendation, error) {
+	var queries []*workloadQuery
+	var err error
+	if query != "" {
+		queries = []*workloadQuery{{schema: defaultDB, text: query, frequency: 1}}
+	} else {
+		queries, err = loadWorkload(ctx, se, opts.MaxNumQuery)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p := parser.New()
+	candidates := make(map[string]*indexCandidate)
+	validQueries := queries[:0]
+	for _, q := range queries {
+		stmt, err := p.ParseOneStmt(q.text, "", "")
+		if err != nil {
+			if query != "" {
+				return nil, err
+			}
+			logutil.BgLogger().Debug("skip the query can't be parsed in index advisor", zap.Error(err))
+			continue
+		}
+		if !isSelectStmt(stmt) {
+			if query != "" {
+				return nil, errors.New("only SELECT statements are supported by the index advisor")
+			}
+			continue
+		}
+		q.tables = make(map[string]struct{})
+		for _, cols := range collectQueryColumns(is, q.schema, stmt) {
+			q.tables[tableKey(cols.ref.schema.L, cols.ref.info.Name.L)] = struct{}{}
+			for _, c := range generateCandidates(cols, opts.MaxIndexColumns) {
+				candidates[c.key()] = c
+			}
+		}
+		if len(q.tables) > 0 {
+			validQueries = append(validQueries, q)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	o := newWhatIfOptimizer(se)
+	defer o.close()
+	return searchIndexes(ctx, o, validQueries, sortedCandidates(candidates), opts)
+}
+
+func isSelectStmt(stmt ast.StmtNode) bool {
+	switch stmt.(type) {
+	case *ast.SelectStmt, *ast.SetOprStmt:
+		return true
+	}
+	return false
+}
+
+func sortedCandidates(candidates map[string]*indexCandidate) []*indexCandidate {
+	ret := make([]*indexCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		ret = append(ret, c)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].key() < ret[j].key()
+	})
+	return ret
+}
+
+// searchIndexes selects the indexes greedily, each time it selects the candidate which reduces the cost of the
+// workload most together with the selected ones, until no candidate can reduce the cost remarkably.
+func searchIndexes(ctx context.Context, o *whatIfOptimizer, queries []*workloadQuery,
+	candidates []*indexCandidate, opts *Options) ([]*Recommendation, error) {
+	costs := make([]float64, len(queries))
+	var totalCost float64
+	for i, q := range queries {
+		cost, err := o.queryCost(ctx, q, nil)
+		if err != nil {
+			return nil, err
+		}
+		costs[i] = cost * q.frequency
+		totalCost += costs[i]
+	}
+	if totalCost <= 0 {
+		return nil, nil
+	}
+
+	var (
+		selected        []*indexCandidate
+		recommendations []*Recommendation
+	)
+	used := make([]bool, len(candidates))
+	for len(selected) < opts.MaxNumIndex {
+		bestIdx, bestReduction := -1, 0.0
+		var bestCosts []float64
+		for i, c := range candidates {
+			if used[i] {
+				continue
+			}
+			newCosts := make([]float64, len(queries))
+			var reduction float64
+			for j, q := range queries {
+				newCosts[j] = costs[j]
+				if _, ok := q.tables[c.tableKey()]; !ok {
+					continue
+				}
+				cost, err := o.queryCost(ctx, q, append(selected, c))
+				if err != nil {
+					return nil, err
+				}
+				newCosts[j] = cost * q.frequency
+				reduction += costs[j] - newCosts[j]
+			}
+			if reduction > bestReduction {
+				bestIdx, bestReduction, bestCosts = i, reduction, newCosts
+			}
+		}
+		if bestIdx < 0 || bestReduction/totalCost < minCostReduction {
+			break
+		}
+
+		best := candidates[bestIdx]
+		used[bestIdx] = true
+		selected = append(selected, best)
+		topQuery, topReduction := "", 0.0
+		for j, q := range queries {
+			if r := costs[j] - bestCosts[j]; r > topReduction {
+				topQuery, topReduction = q.text, r
+			}
+		}
+		costs = bestCosts
+		recommendations = append(recommendations, &Recommendation{
+			Database:         best.schema.O,
+			Table:            best.table.Name.O,
+			IndexName:        indexName(best, recommendations),
+			IndexColumns:     columnOriginNames(best.columns),
+			CostReduction:    bestReduction / totalCost,
+			TopImpactedQuery: topQuery,
+		})
+	}
+	return recommendations, nil
+}
+
+func columnOriginNames(cols []*model.ColumnInfo) []string {
+	names := make([]string, 0, len(cols))
+	for _, col := range cols {
+		names = append(names, col.Name.O)
+	}
+	return names
+}
+
+// indexName generates a name for the index which doesn't conflict with the existing indexes.
+func indexName(c *indexCandidate, recommended []*Recommendation) string {
+	base := "idx_" + strings.Join(c.columnNames(), "_")
+	if len(base) > mysql.MaxIndexIdentifierLen-4 {
+		base = base[:mysql.MaxIndexIdentifierLen-4]
+	}
+	conflict := func(name string) bool {
+		if c.table.FindIndexByName(strings.ToLower(name)) != nil {
+			return true
+		}
+		for _, r := range recommended {
+			if strings.EqualFold(r.Database, c.schema.O) && strings.EqualFold(r.Table, c.table.Name.O) &&
+				strings.EqualFold(r.IndexName, name) {
+				return true
+			}
+		}
+		return false
+	}
+	name := base
+	for i := 1; conflict(name); i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	return name
+}
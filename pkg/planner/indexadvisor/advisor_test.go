@@ -0,0 +1,103 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/planner/indexadvisor"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions(t *testing.T) {
+	opts := indexadvisor.DefaultOptions()
+	require.NoError(t, opts.SetOption("MAX_NUM_INDEX", 2))
+	require.NoError(t, opts.SetOption("max_index_columns", 1))
+	require.NoError(t, opts.SetOption("max_num_query", 10))
+	require.Equal(t, &indexadvisor.Options{MaxNumIndex: 2, MaxIndexColumns: 1, MaxNumQuery: 10}, opts)
+	require.ErrorContains(t, opts.SetOption("max_num_index", 0), "invalid value 0 for option max_num_index")
+	require.ErrorContains(t, opts.SetOption("max_index_columns", 100), "invalid value 100 for option max_index_columns")
+	require.ErrorContains(t, opts.SetOption("xxx", 1), "unknown option xxx")
+
+	r := &indexadvisor.Recommendation{Database: "test", Table: "t", IndexName: "idx_a_b", IndexColumns: []string{"a", "b"}}
+	require.Equal(t, "CREATE INDEX `idx_a_b` ON `test`.`t`(`a`, `b`)", r.CreateIndexStatement())
+}
+
+func TestRecommendIndexForQuery(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, c int, d text, key idx_c(c))")
+
+	tk.MustQuery("recommend index run for 'select * from t where a = 1'").CheckAt([]int{0, 1, 2, 3, 5, 6}, testkit.RowsWithSep("|",
+		"test|t|idx_a|a|select * from t where a = 1|CREATE INDEX `idx_a` ON `test`.`t`(`a`)"))
+	// The composite index is led by the equal column and followed by the range column.
+	rows := tk.MustQuery("recommend index run for 'select a, b from t where a = 1 and b > 10'").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "a,b", rows[0][3])
+	require.Equal(t, "CREATE INDEX `idx_a_b` ON `test`.`t`(`a`, `b`)", rows[0][6])
+	tk.MustQuery("recommend index run for 'select a, b from t where a = 1 and b > 10' with max_index_columns = 1").
+		CheckAt([]int{3, 6}, testkit.RowsWithSep("|", "a|CREATE INDEX `idx_a` ON `test`.`t`(`a`)"))
+
+	// The existing indexes and the columns can't be indexed aren't recommended.
+	tk.MustQuery("recommend index run for 'select * from t where c = 1'").Check(testkit.Rows())
+	tk.MustQuery("recommend index run for 'select * from t where d = \"x\"'").Check(testkit.Rows())
+	tk.MustQuery("recommend index run for 'select * from information_schema.tables where table_name = \"t\"'").Check(testkit.Rows())
+
+	// The hypothetical indexes are invisible to the user session.
+	tk.MustQuery("explain format = 'brief' select * from t where a = 1").CheckNotContain("idx_a")
+	require.Nil(t, tk.Session().GetSessionVars().HypoIndexes)
+
+	tk.MustGetErrMsg("recommend index run for 'select * from t' with xxx = 1", "unknown option xxx")
+	require.ErrorContains(t, tk.QueryToErr("recommend index run for 'delete from t where a = 1'"),
+		"only SELECT statements are supported by the index advisor")
+	require.ErrorContains(t, tk.QueryToErr("recommend index run for 'select * frm t'"), "near \"frm t\"")
+}
+
+func TestRecommendIndexForWorkload(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("set global tidb_enable_stmt_summary = 1")
+	tk.MustExec("create table t1 (a int, b int, c int)")
+	tk.MustExec("create table t2 (a int, b int, c int)")
+	tk.MustExec("select * from t1 where a = 1")
+	tk.MustExec("select * from t1 where a = 2")
+	tk.MustExec("select * from t2 where b = 1 order by c")
+	tk.MustExec("select a from t2 where c < 10")
+
+	rows := tk.MustQuery("recommend index run").Sort().Rows()
+	require.GreaterOrEqual(t, len(rows), 2)
+	indexes := make([]string, 0, len(rows))
+	for _, row := range rows {
+		indexes = append(indexes, row[1].(string)+"."+row[3].(string))
+	}
+	require.Contains(t, indexes, "t1.a")
+	require.Len(t, tk.MustQuery("recommend index run with max_num_index = 1").Rows(), 1)
+}
+
+func TestRecommendIndexPrivilege(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create user 'u1'@'%'")
+	tk.MustExec("grant select on test.* to 'u1'@'%'")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustGetErrMsg("recommend index run", "[planner:1227]Access denied; you need (at least one of) the SUPER privilege(s) for this operation")
+}
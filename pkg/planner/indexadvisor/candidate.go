@@ -0,0 +1,341 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util"
+)
+
+// indexCandidate is a candidate index on a table.
+type indexCandidate struct {
+	schema  model.CIStr
+	table   *model.TableInfo
+	columns []*model.ColumnInfo
+}
+
+// key identifies the candidate, it's in the form of 'db.table(col1,col2)'.
+func (c *indexCandidate) key() string {
+	return fmt.Sprintf("%s.%s(%s)", c.schema.L, c.table.Name.L, strings.Join(c.columnNames(), ","))
+}
+
+func (c *indexCandidate) tableKey() string {
+	return tableKey(c.schema.L, c.table.Name.L)
+}
+
+func (c *indexCandidate) columnNames() []string {
+	names := make([]string, 0, len(c.columns))
+	for _, col := range c.columns {
+		names = append(names, col.Name.L)
+	}
+	return names
+}
+
+// coveredByExistingIndex checks whether the columns of the candidate are a prefix of an existing index.
+func (c *indexCandidate) coveredByExistingIndex() bool {
+	if c.table.PKIsHandle {
+		if pk := c.table.GetPkColInfo(); pk != nil && len(c.columns) == 1 && c.columns[0].ID == pk.ID {
+			return true
+		}
+	}
+	for _, idx := range c.table.Indices {
+		if idx.State != model.StatePublic || len(idx.Columns) < len(c.columns) {
+			continue
+		}
+		covered := true
+		for i, col := range c.columns {
+			if idx.Columns[i].Offset != col.Offset || idx.Columns[i].Length != types.UnspecifiedLength {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return true
+		}
+	}
+	return false
+}
+
+func tableKey(schema, table string) string {
+	return schema + "." + table
+}
+
+// tableRef is a table referenced by a query.
+type tableRef struct {
+	schema model.CIStr
+	alias  model.CIStr
+	info   *model.TableInfo
+}
+
+// tableColumns are the columns of a table used by a query.
+type tableColumns struct {
+	ref       *tableRef
+	eqCols    []*model.ColumnInfo
+	rangeCols []*model.ColumnInfo
+	orderCols []*model.ColumnInfo
+}
+
+func appendColumn(cols []*model.ColumnInfo, col *model.ColumnInfo) []*model.ColumnInfo {
+	for _, c := range cols {
+		if c.ID == col.ID {
+			return cols
+		}
+	}
+	return append(cols, col)
+}
+
+// queryColumnCollector collects the indexable columns used by a query.
+type queryColumnCollector struct {
+	is        infoschema.InfoSchema
+	defaultDB string
+	tables    []*tableRef
+	columns   map[*tableRef]*tableColumns
+}
+
+// collectQueryColumns returns the columns used by the predicates, GROUP BY and ORDER BY clauses of a query.
+func collectQueryColumns(is infoschema.InfoSchema, defaultDB string, stmt ast.StmtNode) []*tableColumns {
+	c := &queryColumnCollector{
+		is:        is,
+		defaultDB: defaultDB,
+		columns:   make(map[*tableRef]*tableColumns),
+	}
+	// Collect the tables first to resolve the columns.
+	stmt.Accept(&tableCollector{c: c})
+	if len(c.tables) == 0 {
+		return nil
+	}
+	stmt.Accept(c)
+	result := make([]*tableColumns, 0, len(c.columns))
+	for _, ref := range c.tables {
+		if cols, ok := c.columns[ref]; ok {
+			result = append(result, cols)
+		}
+	}
+	return result
+}
+
+// tableCollector collects the tables referenced by a query.
+type tableCollector struct {
+	c *queryColumnCollector
+}
+
+// Enter implements ast.Visitor interface.
+func (v *tableCollector) Enter(in ast.Node) (ast.Node, bool) {
+	ts, ok := in.(*ast.TableSource)
+	if !ok {
+		return in, false
+	}
+	tn, ok := ts.Source.(*ast.TableName)
+	if !ok {
+		return in, false
+	}
+	schema := tn.Schema
+	if schema.L == "" {
+		schema = model.NewCIStr(v.c.defaultDB)
+	}
+	if schema.L == "" || util.IsMemOrSysDB(schema.L) {
+		return in, true
+	}
+	tbl, err := v.c.is.TableByName(schema, tn.Name)
+	if err != nil {
+		return in, true
+	}
+	alias := ts.AsName
+	if alias.L == "" {
+		alias = tn.Name
+	}
+	v.c.tables = append(v.c.tables, &tableRef{schema: schema, alias: alias, info: tbl.Meta()})
+	return in, true
+}
+
+// Leave implements ast.Visitor interface.
+func (*tableCollector) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// Enter implements ast.Visitor interface.
+func (c *queryColumnCollector) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.BinaryOperationExpr:
+		switch x.Op {
+		case opcode.EQ, opcode.NullEQ:
+			c.addColumn(x.L, columnUsageEQ)
+			c.addColumn(x.R, columnUsageEQ)
+		case opcode.LT, opcode.LE, opcode.GT, opcode.GE:
+			c.addColumn(x.L, columnUsageRange)
+			c.addColumn(x.R, columnUsageRange)
+		}
+	case *ast.PatternInExpr:
+		if !x.Not && x.Sel == nil {
+			c.addColumn(x.Expr, columnUsageEQ)
+		}
+	case *ast.IsNullExpr:
+		if !x.Not {
+			c.addColumn(x.Expr, columnUsageEQ)
+		}
+	case *ast.BetweenExpr:
+		if !x.Not {
+			c.addColumn(x.Expr, columnUsageRange)
+		}
+	case *ast.PatternLikeOrIlikeExpr:
+		if !x.Not && x.IsLike {
+			c.addColumn(x.Expr, columnUsageRange)
+		}
+	case *ast.SelectStmt:
+		if x.GroupBy != nil {
+			for _, item := range x.GroupBy.Items {
+				c.addColumn(item.Expr, columnUsageOrder)
+			}
+		}
+		if x.OrderBy != nil {
+			for _, item := range x.OrderBy.Items {
+				c.addColumn(item.Expr, columnUsageOrder)
+			}
+		}
+	}
+	return in, false
+}
+
+// Leave implements ast.Visitor interface.
+func (*queryColumnCollector) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+type columnUsage int
+
+const (
+	columnUsageEQ columnUsage = iota
+	columnUsageRange
+	columnUsageOrder
+)
+
+func (c *queryColumnCollector) addColumn(expr ast.ExprNode, usage columnUsage) {
+	colExpr, ok := expr.(*ast.ColumnNameExpr)
+	if !ok {
+		return
+	}
+	ref, col := c.resolveColumn(colExpr.Name)
+	if col == nil || !indexableColumn(col) {
+		return
+	}
+	cols, ok := c.columns[ref]
+	if !ok {
+		cols = &tableColumns{ref: ref}
+		c.columns[ref] = cols
+	}
+	switch usage {
+	case columnUsageEQ:
+		cols.eqCols = appendColumn(cols.eqCols, col)
+	case columnUsageRange:
+		cols.rangeCols = appendColumn(cols.rangeCols, col)
+	case columnUsageOrder:
+		cols.orderCols = appendColumn(cols.orderCols, col)
+	}
+}
+
+// resolveColumn finds the column in the referenced tables. The column is ignored if it's ambiguous.
+func (c *queryColumnCollector) resolveColumn(name *ast.ColumnName) (*tableRef, *model.ColumnInfo) {
+	var (
+		resRef *tableRef
+		resCol *model.ColumnInfo
+	)
+	for _, ref := range c.tables {
+		if name.Table.L != "" && name.Table.L != ref.alias.L {
+			continue
+		}
+		if name.Schema.L != "" && name.Schema.L != ref.schema.L {
+			continue
+		}
+		col := model.FindColumnInfo(ref.info.Cols(), name.Name.L)
+		if col == nil {
+			continue
+		}
+		if resCol != nil && (resRef.info.ID != ref.info.ID || resCol.ID != col.ID) {
+			return nil, nil
+		}
+		resRef, resCol = ref, col
+	}
+	return resRef, resCol
+}
+
+// indexableColumn checks whether the column can be indexed without a prefix length.
+func indexableColumn(col *model.ColumnInfo) bool {
+	if col.Hidden {
+		return false
+	}
+	switch col.GetType() {
+	case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeJSON,
+		mysql.TypeGeometry, mysql.TypeTiDBVectorFloat32:
+		return false
+	}
+	return true
+}
+
+// generateCandidates generates the candidate indexes for the columns used by a query.
+// Besides the single column indexes, it generates the composite indexes led by
+// the equal columns and followed by a range column or the ordering columns.
+func generateCandidates(cols *tableColumns, maxIndexColumns int) []*indexCandidate {
+	var candidates []*indexCandidate
+	add := func(columns []*model.ColumnInfo) {
+		if len(columns) == 0 {
+			return
+		}
+		if len(columns) > maxIndexColumns {
+			columns = columns[:maxIndexColumns]
+		}
+		candidate := &indexCandidate{schema: cols.ref.schema, table: cols.ref.info, columns: columns}
+		if candidate.coveredByExistingIndex() {
+			return
+		}
+		for _, c := range candidates {
+			if c.key() == candidate.key() {
+				return
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+	concat := func(prefix []*model.ColumnInfo, suffix ...*model.ColumnInfo) []*model.ColumnInfo {
+		ret := make([]*model.ColumnInfo, 0, len(prefix)+len(suffix))
+		ret = append(ret, prefix...)
+		for _, col := range suffix {
+			ret = appendColumn(ret, col)
+		}
+		return ret
+	}
+
+	for _, group := range [][]*model.ColumnInfo{cols.eqCols, cols.rangeCols, cols.orderCols} {
+		for _, col := range group {
+			add([]*model.ColumnInfo{col})
+		}
+	}
+	eqCols := cols.eqCols
+	if len(eqCols) >= maxIndexColumns {
+		eqCols = eqCols[:maxIndexColumns-1]
+	}
+	add(cols.eqCols)
+	for _, col := range cols.rangeCols {
+		add(concat(eqCols, col))
+	}
+	add(concat(eqCols, cols.orderCols...))
+	return candidates
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// loadWorkloadSQL loads the SELECT statements executed most frequently from the statement summary.
+const loadWorkloadSQL = `SELECT SCHEMA_NAME, QUERY_SAMPLE_TEXT, SUM(EXEC_COUNT) AS CNT
+	FROM information_schema.statements_summary
+	WHERE STMT_TYPE = 'Select' AND SCHEMA_NAME IS NOT NULL AND SCHEMA_NAME != ''
+	GROUP BY SCHEMA_NAME, DIGEST, QUERY_SAMPLE_TEXT ORDER BY CNT DESC LIMIT %?`
+
+func loadWorkload(ctx context.Context, se sessionctx.Context, limit int) ([]*workloadQuery, error) {
+	rows, err := sqlexec.ExecSQL(ctx, se, loadWorkloadSQL, limit)
+	if err != nil {
+		return nil, err
+	}
+	queries := make([]*workloadQuery, 0, len(rows))
+	for _, row := range rows {
+		text := row.GetString(1)
+		if text == "" {
+			continue
+		}
+		freq, err := row.GetMyDecimal(2).ToFloat64()
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, &workloadQuery{schema: row.GetString(0), text: text, frequency: freq})
+	}
+	return queries, nil
+}
+
+// whatIfOptimizer estimates the costs of the queries as if the hypothetical indexes exist.
+type whatIfOptimizer struct {
+	se        sessionctx.Context
+	currentDB string
+	costs     map[string]float64
+}
+
+func newWhatIfOptimizer(se sessionctx.Context) *whatIfOptimizer {
+	return &whatIfOptimizer{
+		se:        se,
+		currentDB: se.GetSessionVars().CurrentDB,
+		costs:     make(map[string]float64),
+	}
+}
+
+// close restores the states of the session.
+func (o *whatIfOptimizer) close() {
+	vars := o.se.GetSessionVars()
+	vars.HypoIndexes = nil
+	vars.CurrentDB = o.currentDB
+}
+
+// queryCost returns the estimated cost of the query plan with the hypothetical indexes.
+func (o *whatIfOptimizer) queryCost(ctx context.Context, q *workloadQuery, indexes []*indexCandidate) (float64, error) {
+	// Only the indexes on the tables used by the query affect its plan.
+	keys := make([]string, 0, len(indexes))
+	relevant := make([]*indexCandidate, 0, len(indexes))
+	for _, idx := range indexes {
+		if _, ok := q.tables[idx.tableKey()]; ok {
+			keys = append(keys, idx.key())
+			relevant = append(relevant, idx)
+		}
+	}
+	sort.Strings(keys)
+	cacheKey := q.schema + "\n" + q.text + "\n" + strings.Join(keys, ";")
+	if cost, ok := o.costs[cacheKey]; ok {
+		return cost, nil
+	}
+
+	vars := o.se.GetSessionVars()
+	vars.CurrentDB = q.schema
+	vars.HypoIndexes = buildHypoIndexes(relevant)
+	rows, err := sqlexec.ExecSQL(ctx, o.se, "EXPLAIN FORMAT = 'verbose' "+q.text)
+	vars.HypoIndexes = nil
+	if err != nil {
+		return 0, err
+	}
+	// The columns are id, estRows, estCost, task, access object and operator info.
+	if len(rows) == 0 || rows[0].Len() < 3 {
+		return 0, errors.Errorf("unexpected explain result of query %s", q.text)
+	}
+	cost, err := strconv.ParseFloat(rows[0].GetString(2), 64)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	o.costs[cacheKey] = cost
+	return cost, nil
+}
+
+// buildHypoIndexes builds the hypothetical indexes in the form of session variable HypoIndexes.
+func buildHypoIndexes(indexes []*indexCandidate) map[string]map[string]map[string]*model.IndexInfo {
+	if len(indexes) == 0 {
+		return nil
+	}
+	hypoIndexes := make(map[string]map[string]map[string]*model.IndexInfo)
+	for i, idx := range indexes {
+		schema, table := idx.schema.L, idx.table.Name.L
+		if hypoIndexes[schema] == nil {
+			hypoIndexes[schema] = make(map[string]map[string]*model.IndexInfo)
+		}
+		if hypoIndexes[schema][table] == nil {
+			hypoIndexes[schema][table] = make(map[string]*model.IndexInfo)
+		}
+		name := fmt.Sprintf("hypo_idx_%d", i)
+		info := &model.IndexInfo{
+			Name:  model.NewCIStr(name),
+			State: model.StatePublic,
+			Tp:    model.IndexTypeHypo,
+		}
+		for _, col := range idx.columns {
+			info.Columns = append(info.Columns, &model.IndexColumn{
+				Name:   col.Name,
+				Offset: col.Offset,
+				Length: types.UnspecifiedLength,
+			})
+		}
+		hypoIndexes[schema][table][name] = info
+	}
+	return hypoIndexes
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"math"
 	"math/rand"
+	"strings"
 	"sync"
 	"time"
 
@@ -58,6 +59,19 @@ func IsReadOnly(node ast.Node, vars *variable.SessionVars) bool {
 	return ast.IsReadOnly(node)
 }
 
+// IsDeterministic check whether the ast.Node is a deterministic DML statement which can be replayed safely.
+func IsDeterministic(node ast.Node, vars *variable.SessionVars) bool {
+	if execStmt, isExecStmt := node.(*ast.ExecuteStmt); isExecStmt {
+		prepareStmt, err := core.GetPreparedStmt(execStmt, vars)
+		if err != nil {
+			logutil.BgLogger().Warn("GetPreparedStmt failed", zap.Error(err))
+			return false
+		}
+		return ast.IsDeterministic(prepareStmt.PreparedAst.Stmt)
+	}
+	return ast.IsDeterministic(node)
+}
+
 // getPlanFromNonPreparedPlanCache tries to get an available cached plan from the NonPrepared Plan Cache for this stmt.
 func getPlanFromNonPreparedPlanCache(ctx context.Context, sctx sessionctx.Context, stmt ast.StmtNode, is infoschema.InfoSchema) (p core.Plan, ns types.NameSlice, ok bool, err error) {
 	stmtCtx := sctx.GetSessionVars().StmtCtx
@@ -195,6 +209,7 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 		}
 		sessVars.StmtCtx.AddSetVarHintRestore(name, oldV)
 	}
+	syncStmtTimeZone(sessVars)
 	if len(sessVars.StmtCtx.StmtHints.SetVars) > 0 {
 		sessVars.StmtCtx.SetSkipPlanCache(errors.NewNoStackError("SET_VAR is used in the SQL"))
 	}
@@ -292,6 +307,7 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 				}
 				sessVars.StmtCtx.AddSetVarHintRestore(name, oldV)
 			}
+			syncStmtTimeZone(sessVars)
 			plan, curNames, cost, err := optimize(ctx, pctx, node, is)
 			if err != nil {
 				binding.Status = bindinfo.Invalid
@@ -387,6 +403,30 @@ func OptimizeForForeignKeyCascade(ctx context.Context, sctx pctx.PlanContext, no
 	return p, nil
 }
 
+// OptimizeForTrigger does optimization and creates a Plan for the statement of a trigger. Compare to
+// OptimizeForForeignKeyCascade, the privileges of the current user are checked, since the statement
+// is written by the definer of the trigger rather than derived from the foreign key.
+func OptimizeForTrigger(ctx context.Context, sctx pctx.PlanContext, node ast.StmtNode, is infoschema.InfoSchema) (core.Plan, error) {
+	builder := planBuilderPool.Get().(*core.PlanBuilder)
+	defer planBuilderPool.Put(builder.ResetForReuse())
+	hintProcessor := hint.NewQBHintHandler(sctx.GetSessionVars().StmtCtx)
+	builder.Init(sctx, is, hintProcessor)
+	p, err := builder.Build(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	if pm := privilege.GetPrivilegeManager(sctx); pm != nil {
+		visitInfo := core.VisitInfo4PrivCheck(is, node, builder.GetVisitInfo())
+		if err := core.CheckPrivilege(sctx.GetSessionVars().ActiveRoles, pm, visitInfo); err != nil {
+			return nil, err
+		}
+	}
+	if err := core.CheckTableLock(sctx, is, builder.GetVisitInfo()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
 func allowInReadOnlyMode(sctx pctx.PlanContext, node ast.Node) (bool, error) {
 	pm := privilege.GetPrivilegeManager(sctx)
 	if pm == nil {
@@ -483,6 +523,10 @@ func optimize(ctx context.Context, sctx pctx.PlanContext, node ast.Node, is info
 		return nil, nil, 0, err
 	}
 
+	if err := core.CheckSchemaReadOnly(sessVars, is, node, builder.GetVisitInfo()); err != nil {
+		return nil, nil, 0, err
+	}
+
 	names := p.OutputNames()
 
 	// Handle the non-logical plan statement.
@@ -573,6 +617,21 @@ func handleInvalidBinding(ctx context.Context, sctx pctx.PlanContext, level stri
 	globalHandle.AddInvalidGlobalBinding(binding)
 }
 
+// syncStmtTimeZone makes the statement context use the time zone set by the SET_VAR hint when
+// tidb_consistent_stmt_time_zone is on. Otherwise, the ranges and the partition pruning of the TIMESTAMP
+// columns are still computed in the time zone of the session while the values are decoded in the hinted one.
+func syncStmtTimeZone(sessVars *variable.SessionVars) {
+	if !sessVars.ConsistentStmtTimeZone {
+		return
+	}
+	for name := range sessVars.StmtCtx.StmtHints.SetVars {
+		if strings.EqualFold(name, variable.TimeZone) {
+			sessVars.StmtCtx.SetTimeZone(sessVars.Location())
+			return
+		}
+	}
+}
+
 // setVarHintChecker checks whether the variable name in set_var hint is valid.
 func setVarHintChecker(varName, hint string) (ok bool, warning error) {
 	sysVar := variable.GetSysVar(varName)
@@ -588,6 +647,7 @@ func setVarHintChecker(varName, hint string) (ok bool, warning error) {
 func init() {
 	core.OptimizeAstNode = Optimize
 	core.IsReadOnly = IsReadOnly
+	core.IsDeterministic = IsDeterministic
 	bindinfo.GetGlobalBindingHandle = func(sctx sessionctx.Context) bindinfo.GlobalBindingHandle {
 		return domain.GetDomain(sctx).BindHandle()
 	}
@@ -15,6 +15,7 @@
 package fixcontrol_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/planner/util/fixcontrol"
@@ -87,3 +88,66 @@ func TestFixControl(t *testing.T) {
 		require.Equal(t, output[i].Variable, rows)
 	}
 }
+
+func TestFixControlInfos(t *testing.T) {
+	infos := fixcontrol.AllInfos()
+	require.NotEmpty(t, infos)
+	for i, info := range infos {
+		if i > 0 {
+			require.Less(t, infos[i-1].ID, info.ID)
+		}
+		require.NotEmpty(t, info.Description)
+		require.Contains(t, []string{fixcontrol.TypeBool, fixcontrol.TypeInt}, info.Type)
+		got, exists := fixcontrol.GetInfo(info.ID)
+		require.True(t, exists)
+		require.Equal(t, info, got)
+	}
+	_, exists := fixcontrol.GetInfo(1)
+	require.False(t, exists)
+}
+
+func TestOptimizerFixControlsTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	query := "select fix_id, value_type, default_value, global_value, session_value, scope from information_schema.optimizer_fix_controls where fix_id in (44855, 45132, 46177)"
+	tk.MustQuery(query).Check(testkit.Rows(
+		"44855 BOOL OFF <nil> <nil> DEFAULT",
+		"45132 INT 1000 <nil> <nil> DEFAULT",
+		"46177 BOOL OFF <nil> <nil> DEFAULT",
+	))
+	tk.MustExec("set global tidb_opt_fix_control = '44855:ON, 45132:10'")
+	tk.MustExec("set tidb_opt_fix_control = '45132:10, 46177:ON'")
+	tk.MustQuery(query).Check(testkit.Rows(
+		"44855 BOOL OFF ON <nil> DEFAULT",
+		"45132 INT 1000 10 10 GLOBAL",
+		"46177 BOOL OFF <nil> ON SESSION",
+	))
+	tk.MustExec("set global tidb_opt_fix_control = ''")
+}
+
+func TestExplainUsedFixControls(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, key(a))")
+	fixControlNotes := func(sql string) []string {
+		tk.MustExec("explain format = 'verbose' " + sql)
+		var notes []string
+		for _, w := range tk.Session().GetSessionVars().StmtCtx.GetWarnings() {
+			if strings.Contains(w.Err.Error(), "fix control") {
+				notes = append(notes, w.Err.Error())
+			}
+		}
+		return notes
+	}
+
+	require.Empty(t, fixControlNotes("select * from t t1 join t t2 on t1.a = t2.a"))
+
+	// only the fix controls set by users and consulted during the optimization are noted
+	tk.MustExec("set tidb_opt_fix_control = '44855:ON, 45822:OFF'")
+	require.Empty(t, fixControlNotes("select * from t where a > 1"))
+	require.Equal(t, []string{"Using the optimizer fix control 44855:ON"},
+		fixControlNotes("select * from t t1 join t t2 on t1.a = t2.a"))
+	require.Equal(t, []string{"Using the optimizer fix control 45822:OFF"},
+		fixControlNotes("select (select count(*) from t t2 where t2.a = t1.a) from t t1"))
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fixcontrol
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Value types of the fix controls.
+const (
+	TypeBool = "BOOL"
+	TypeInt  = "INT"
+)
+
+// Info is the description of a fix control, which is shown by `information_schema.optimizer_fix_controls`.
+type Info struct {
+	ID          uint64
+	Type        string
+	Default     string
+	Description string
+}
+
+var infos = map[uint64]Info{
+	Fix44262: {Type: TypeBool, Default: "ON", Description: "Allow to use dynamic-mode to access partitioning tables without global-stats, the default value follows tidb_skip_missing_partition_stats"},
+	Fix44389: {Type: TypeBool, Default: "OFF", Description: "Consider non-point ranges of some CNF item when building ranges"},
+	Fix44830: {Type: TypeBool, Default: "OFF", Description: "Allow to cache Batch/PointGet from some complex scenarios"},
+	Fix44823: {Type: TypeInt, Default: "200", Description: "The maximum number of parameters for a query that can be cached in the Plan Cache, 0 means no limitation"},
+	Fix44855: {Type: TypeBool, Default: "OFF", Description: "Use a more accurate upper bound when estimating row count of index range scan under inner side of index join"},
+	Fix45132: {Type: TypeInt, Default: "1000", Description: "The threshold of the access range row count ratio to determine access path on the Skyline pruning"},
+	Fix45822: {Type: TypeBool, Default: "ON", Description: "Eliminate the apply operator"},
+	Fix45798: {Type: TypeBool, Default: "OFF", Description: "Allow to cache plans that access generated columns"},
+	Fix46177: {Type: TypeBool, Default: "OFF", Description: "Explore enforced plans for DataSource even if it has already found an unenforced plan"},
+	Fix49736: {Type: TypeBool, Default: "OFF", Description: "Force the optimizer to use plan cache even if there is risky optimization, only used in tests"},
+}
+
+func init() {
+	for id, info := range infos {
+		info.ID = id
+		infos[id] = info
+	}
+}
+
+// GetInfo returns the description of the given fix control.
+func GetInfo(key uint64) (info Info, exists bool) {
+	info, exists = infos[key]
+	return
+}
+
+// AllInfos returns the descriptions of all the fix controls sorted by the ID.
+func AllInfos() []Info {
+	result := make([]Info, 0, len(infos))
+	for _, info := range infos {
+		result = append(result, info)
+	}
+	slices.SortFunc(result, func(a, b Info) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return result
+}
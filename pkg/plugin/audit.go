@@ -104,6 +104,43 @@ const (
 	PostParse
 )
 
+// PrivilegeEvent presents the privilege change events.
+type PrivilegeEvent byte
+
+const (
+	// Grant represents privileges are granted by GRANT statement.
+	Grant PrivilegeEvent = iota
+	// Revoke represents privileges are revoked by REVOKE statement.
+	Revoke
+)
+
+func (e PrivilegeEvent) String() string {
+	switch e {
+	case Grant:
+		return "Grant"
+	case Revoke:
+		return "Revoke"
+	}
+	return ""
+}
+
+// PrivilegeInfo presents the privileges changed by a GRANT or REVOKE statement.
+type PrivilegeInfo struct {
+	// User and Host are the account changing the privileges and the host it connects from.
+	User string
+	Host string
+	// ConnectionID is the ID of the connection changing the privileges.
+	ConnectionID uint64
+	// Privileges are the changed privileges, e.g. "SELECT", "UPDATE (c)" or "BACKUP_ADMIN".
+	Privileges []string
+	// Level is the level of the privileges, e.g. "*.*", "db.*" or "db.t".
+	Level string
+	// Grantees are the accounts whose privileges are changed, in the form of "user@host".
+	Grantees []string
+	// WithGrantOption is true if the privileges are granted with GRANT OPTION.
+	WithGrantOption bool
+}
+
 // AuditManifest presents a sub-manifest that every audit plugin must provide.
 type AuditManifest struct {
 	Manifest
@@ -116,6 +153,8 @@ type AuditManifest struct {
 	OnGlobalVariableEvent func(ctx context.Context, sctx *variable.SessionVars, varName, varValue string)
 	// OnParseEvent will be called around parse logic.
 	OnParseEvent func(ctx context.Context, sctx *variable.SessionVars, event ParseEvent) error
+	// OnPrivilegeEvent will be called after the privileges are changed by GRANT or REVOKE.
+	OnPrivilegeEvent func(ctx context.Context, sctx *variable.SessionVars, event PrivilegeEvent, info *PrivilegeInfo)
 }
 
 type (
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/plugin"
 	"github.com/pingcap/tidb/pkg/server"
@@ -732,7 +733,74 @@ func TestAuditLogNormal(t *testing.T) {
 	}
 }
 
+func TestAuditLogPrivilegeEvent(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("create user u1, u2")
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "127.0.0.1"}, nil, nil, nil))
+
+	type privilegeEvent struct {
+		event plugin.PrivilegeEvent
+		info  plugin.PrivilegeInfo
+	}
+	var events []privilegeEvent
+	loadAuditPlugin(t, func(m *plugin.AuditManifest) {
+		m.OnPrivilegeEvent = func(_ context.Context, _ *variable.SessionVars, event plugin.PrivilegeEvent, info *plugin.PrivilegeInfo) {
+			events = append(events, privilegeEvent{event: event, info: *info})
+		}
+	})
+	defer plugin.Shutdown(context.Background())
+
+	connID := tk.Session().GetSessionVars().ConnectionID
+	tk.MustExec("grant select, update (a, b) on t to u1, u2 with grant option")
+	tk.MustExec("revoke select on test.t from u1")
+	tk.MustExec("grant backup_admin on *.* to u2")
+	// Nothing is audited for the failed statements.
+	require.Error(t, tk.ExecToErr("revoke select on t from u3"))
+	require.Len(t, events, 3)
+	require.Equal(t, plugin.Grant, events[0].event)
+	require.Equal(t, plugin.PrivilegeInfo{
+		User:            "root",
+		Host:            "127.0.0.1",
+		ConnectionID:    connID,
+		Privileges:      []string{"SELECT", "UPDATE (a,b)"},
+		Level:           "test.t",
+		Grantees:        []string{"u1@%", "u2@%"},
+		WithGrantOption: true,
+	}, events[0].info)
+	require.Equal(t, plugin.Revoke, events[1].event)
+	require.Equal(t, []string{"SELECT"}, events[1].info.Privileges)
+	require.Equal(t, "test.t", events[1].info.Level)
+	require.Equal(t, []string{"u1@%"}, events[1].info.Grantees)
+	require.False(t, events[1].info.WithGrantOption)
+	require.Equal(t, plugin.Grant, events[2].event)
+	require.Equal(t, []string{"BACKUP_ADMIN"}, events[2].info.Privileges)
+	require.Equal(t, "*.*", events[2].info.Level)
+	require.Equal(t, []string{"u2@%"}, events[2].info.Grantees)
+
+	// The privileges of the grantees are reloaded after GRANT and REVOKE.
+	tk.MustQuery("show grants for u1").Check(testkit.Rows(
+		"GRANT USAGE ON *.* TO 'u1'@'%'",
+		"GRANT USAGE ON `test`.`t` TO 'u1'@'%' WITH GRANT OPTION",
+		"GRANT UPDATE(a, b) ON `test`.`t` TO 'u1'@'%'",
+	))
+	tk.MustQuery("show grants for u2").Check(testkit.Rows(
+		"GRANT USAGE ON *.* TO 'u2'@'%'",
+		"GRANT SELECT ON `test`.`t` TO 'u2'@'%' WITH GRANT OPTION",
+		"GRANT UPDATE(a, b) ON `test`.`t` TO 'u2'@'%'",
+		"GRANT BACKUP_ADMIN ON *.* TO 'u2'@'%'",
+	))
+}
+
 func loadPlugin(t *testing.T, onGeneralEvent func(context.Context, *variable.SessionVars, plugin.GeneralEvent, string)) {
+	loadAuditPlugin(t, func(m *plugin.AuditManifest) {
+		m.OnGeneralEvent = onGeneralEvent
+	})
+}
+
+func loadAuditPlugin(t *testing.T, setEvents func(*plugin.AuditManifest)) {
 	ctx := context.Background()
 	pluginName := "audit_test"
 	pluginVersion := uint16(1)
@@ -769,9 +837,9 @@ func loadPlugin(t *testing.T, onGeneralEvent func(context.Context, *variable.Ses
 					OnShutdown: onShutdown,
 					Validate:   validate,
 				},
-				OnGeneralEvent:    onGeneralEvent,
 				OnConnectionEvent: onConnectionEvent,
 			}
+			setEvents(m)
 			return plugin.ExportManifest(m)
 		}, nil
 	}
@@ -16,6 +16,7 @@ package privilege
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
@@ -99,6 +100,9 @@ type Manager interface {
 	// UserPrivilegesTable provide data for INFORMATION_SCHEMA.USER_PRIVILEGES table.
 	UserPrivilegesTable(activeRoles []*auth.RoleIdentity, user, host string) [][]types.Datum
 
+	// ColumnPrivilegesTable provide data for INFORMATION_SCHEMA.COLUMN_PRIVILEGES table.
+	ColumnPrivilegesTable(activeRoles []*auth.RoleIdentity, user, host string) [][]types.Datum
+
 	// ActiveRoles active roles for current session.
 	// The first illegal role will be returned.
 	ActiveRoles(ctx sessionctx.Context, roleList []*auth.RoleIdentity) (bool, string)
@@ -112,6 +116,15 @@ type Manager interface {
 	// GetAllRoles return all roles of user.
 	GetAllRoles(user, host string) []*auth.RoleIdentity
 
+	// IsRoleReachable returns whether the role is granted to the grantee directly or through nested roles.
+	IsRoleReachable(grantee, role *auth.RoleIdentity) bool
+
+	// HasRoleAdminOption returns whether the role is granted to the current user WITH ADMIN OPTION.
+	HasRoleAdminOption(activeRoles []*auth.RoleIdentity, role *auth.RoleIdentity) bool
+
+	// GetMaskingPolicies returns the masking expressions of db.table columns that apply to the current user.
+	GetMaskingPolicies(activeRoles []*auth.RoleIdentity, db, table string) map[string]string
+
 	// IsDynamicPrivilege returns if a privilege is in the list of privileges.
 	IsDynamicPrivilege(privNameInUpper string) bool
 
@@ -120,10 +133,18 @@ type Manager interface {
 
 	// GetAuthPlugin gets the authentication plugin for the account identified by the user and host
 	GetAuthPlugin(user, host string) (string, error)
+
+	// GetUserResources gets the MAX_QUERIES_PER_HOUR and MAX_USER_CONNECTIONS limits of the account
+	// identified by the user and host, 0 means no limit.
+	GetUserResources(user, host string) (maxQuestions, maxUserConnections int64)
 }
 
 const key keyType = 0
 
+// MaskingPolicyVersion is increased whenever the loaded masking policies change. It's a part of the plan cache key,
+// so the cached plans built under the former policies are not used anymore.
+var MaskingPolicyVersion atomic.Int64
+
 // BindPrivilegeManager binds Manager to context.
 func BindPrivilegeManager(ctx sessionctx.Context, pc Manager) {
 	ctx.SetValue(key, pc)
@@ -21,8 +21,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"reflect"
 	"slices"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -32,6 +34,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/privilege"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/types"
@@ -54,12 +57,15 @@ var (
 const globalDBVisible = mysql.CreatePriv | mysql.SelectPriv | mysql.InsertPriv | mysql.UpdatePriv | mysql.DeletePriv | mysql.ShowDBPriv | mysql.DropPriv | mysql.AlterPriv | mysql.IndexPriv | mysql.CreateViewPriv | mysql.ShowViewPriv | mysql.GrantPriv | mysql.TriggerPriv | mysql.ReferencesPriv | mysql.ExecutePriv
 
 const (
-	sqlLoadRoleGraph        = "SELECT HIGH_PRIORITY FROM_USER, FROM_HOST, TO_USER, TO_HOST FROM mysql.role_edges"
+	sqlLoadRoleGraph        = "SELECT HIGH_PRIORITY FROM_USER, FROM_HOST, TO_USER, TO_HOST, WITH_ADMIN_OPTION FROM mysql.role_edges"
 	sqlLoadGlobalPrivTable  = "SELECT HIGH_PRIORITY Host,User,Priv FROM mysql.global_priv"
-	sqlLoadDBTable          = "SELECT HIGH_PRIORITY Host,DB,User,Select_priv,Insert_priv,Update_priv,Delete_priv,Create_priv,Drop_priv,Grant_priv,Index_priv,References_priv,Lock_tables_priv,Create_tmp_table_priv,Event_priv,Create_routine_priv,Alter_routine_priv,Alter_priv,Execute_priv,Create_view_priv,Show_view_priv,Trigger_priv FROM mysql.db ORDER BY host, db, user"
+	sqlSelectDBTable        = "SELECT HIGH_PRIORITY Host,DB,User,Select_priv,Insert_priv,Update_priv,Delete_priv,Create_priv,Drop_priv,Grant_priv,Index_priv,References_priv,Lock_tables_priv,Create_tmp_table_priv,Event_priv,Create_routine_priv,Alter_routine_priv,Alter_priv,Execute_priv,Create_view_priv,Show_view_priv,Trigger_priv FROM mysql.db"
+	sqlLoadDBTable          = sqlSelectDBTable + " ORDER BY host, db, user"
 	sqlLoadTablePrivTable   = "SELECT HIGH_PRIORITY Host,DB,User,Table_name,Grantor,Timestamp,Table_priv,Column_priv FROM mysql.tables_priv"
 	sqlLoadColumnsPrivTable = "SELECT HIGH_PRIORITY Host,DB,User,Table_name,Column_name,Timestamp,Column_priv FROM mysql.columns_priv"
 	sqlLoadDefaultRoles     = "SELECT HIGH_PRIORITY HOST, USER, DEFAULT_ROLE_HOST, DEFAULT_ROLE_USER FROM mysql.default_roles"
+	sqlLoadMaskingPolicies  = "SELECT HIGH_PRIORITY policy_name, table_schema, table_name, column_name, masking_expr, role_user, role_host FROM mysql.tidb_masking_policy ORDER BY policy_name"
+	sqlLoadIPAccessList     = "SELECT HIGH_PRIORITY rule_type, cidr, vpce_id FROM mysql.tidb_ip_access_list"
 	// list of privileges from mysql.Priv2UserCol
 	sqlLoadUserTable = `SELECT HIGH_PRIORITY Host,User,authentication_string,
 	Create_priv, Select_priv, Insert_priv, Update_priv, Delete_priv, Show_db_priv, Super_priv,
@@ -67,8 +73,11 @@ const (
 	References_priv,Alter_priv,Execute_priv,Index_priv,Create_view_priv,Show_view_priv,
 	Create_role_priv,Drop_role_priv,Create_tmp_table_priv,Lock_tables_priv,Create_routine_priv,
 	Alter_routine_priv,Event_priv,Shutdown_priv,Reload_priv,File_priv,Config_priv,Repl_client_priv,Repl_slave_priv,
-	Account_locked,Plugin,Token_issuer,User_attributes,password_expired,password_last_changed,password_lifetime FROM mysql.user`
+	Account_locked,Plugin,Token_issuer,User_attributes,password_expired,password_last_changed,password_lifetime,
+	max_questions,max_user_connections FROM mysql.user`
 	sqlLoadGlobalGrantsTable = `SELECT HIGH_PRIORITY Host,User,Priv,With_Grant_Option FROM mysql.global_grants`
+	// sqlWhereUsers is appended to the statements above to load the records of some users.
+	sqlWhereUsers = " WHERE User IN (%?)"
 )
 
 func computePrivMask(privs []mysql.PrivilegeType) mysql.PrivilegeType {
@@ -118,6 +127,9 @@ type UserRecord struct {
 	PasswordLastChanged  time.Time
 	PasswordLifeTime     int64
 	ResourceGroup        string
+	// MaxQuestions and MaxUserConnections are the account resource limits, 0 means no limit.
+	MaxQuestions       int64
+	MaxUserConnections int64
 }
 
 // NewUserRecord return a UserRecord, only use for unit test.
@@ -235,6 +247,23 @@ type columnsPrivRecord struct {
 	ColumnPriv mysql.PrivilegeType
 }
 
+// maskingPolicyRecord is used to cache mysql.tidb_masking_policy.
+type maskingPolicyRecord struct {
+	PolicyName string
+	Column     string
+	Expr       string
+	RoleUser   string
+	RoleHost   string
+}
+
+// ipAccessRecord is used to cache mysql.tidb_ip_access_list.
+// A nil Network or an empty VPCEID matches any client.
+type ipAccessRecord struct {
+	Allow   bool
+	Network *net.IPNet
+	VPCEID  string
+}
+
 // defaultRoleRecord is used to cache mysql.default_roles
 type defaultRoleRecord struct {
 	baseRecord
@@ -246,6 +275,8 @@ type defaultRoleRecord struct {
 // roleGraphEdgesTable is used to cache relationship between and role.
 type roleGraphEdgesTable struct {
 	roleList map[string]*auth.RoleIdentity
+	// adminOption records the roles granted WITH ADMIN OPTION.
+	adminOption map[string]bool
 }
 
 // Find method is used to find role from table
@@ -286,6 +317,12 @@ type MySQLPrivilege struct {
 	ColumnsPriv   []columnsPrivRecord
 	DefaultRoles  []defaultRoleRecord
 	RoleGraph     map[string]roleGraphEdgesTable
+	// MaskingPolicies is keyed by "db.table" in lower case.
+	MaskingPolicies map[string][]maskingPolicyRecord
+	IPAccessList    []ipAccessRecord
+	// roleClosure caches all the roles reachable from a grantee in RoleGraph,
+	// it's resolved once the role graph is loaded.
+	roleClosure map[string][]*auth.RoleIdentity
 }
 
 // FindAllUserEffectiveRoles is used to find all effective roles grant to this user.
@@ -302,29 +339,75 @@ func (p *MySQLPrivilege) FindAllUserEffectiveRoles(user, host string, activeRole
 
 // FindAllRole is used to find all roles grant to this user.
 func (p *MySQLPrivilege) FindAllRole(activeRoles []*auth.RoleIdentity) []*auth.RoleIdentity {
-	queue, head := make([]*auth.RoleIdentity, 0, len(activeRoles)), 0
-	queue = append(queue, activeRoles...)
-	// Using breadth first search to find all roles grant to this user.
-	visited, ret := make(map[string]bool), make([]*auth.RoleIdentity, 0)
-	for head < len(queue) {
-		role := queue[head]
-		if _, ok := visited[role.String()]; !ok {
-			visited[role.String()] = true
+	visited, ret := make(map[string]struct{}, len(activeRoles)), make([]*auth.RoleIdentity, 0, len(activeRoles))
+	// The active roles come first, and then the nested roles of them.
+	for _, role := range activeRoles {
+		key := role.Username + "@" + role.Hostname
+		if _, ok := visited[key]; !ok {
+			visited[key] = struct{}{}
 			ret = append(ret, role)
-			key := role.Username + "@" + role.Hostname
-			if edgeTable, ok := p.RoleGraph[key]; ok {
-				for _, v := range edgeTable.roleList {
-					if _, ok := visited[v.String()]; !ok {
-						queue = append(queue, v)
-					}
-				}
+		}
+	}
+	for _, role := range activeRoles {
+		for _, r := range p.roleClosure[role.Username+"@"+role.Hostname] {
+			rKey := r.Username + "@" + r.Hostname
+			if _, ok := visited[rKey]; !ok {
+				visited[rKey] = struct{}{}
+				ret = append(ret, r)
 			}
 		}
-		head++
 	}
 	return ret
 }
 
+// buildRoleClosure resolves all the roles reachable from each grantee in the role graph,
+// so that the nested roles don't need to be searched again on every privilege check.
+// The role graph may contain cycles which are written before the cycle detection is added.
+func (p *MySQLPrivilege) buildRoleClosure() {
+	closure := make(map[string][]*auth.RoleIdentity, len(p.RoleGraph))
+	for grantee := range p.RoleGraph {
+		// Using breadth first search to find all roles grant to this grantee.
+		visited := map[string]struct{}{grantee: {}}
+		queue := []string{grantee}
+		var roles []*auth.RoleIdentity
+		for head := 0; head < len(queue); head++ {
+			for key, role := range p.RoleGraph[queue[head]].roleList {
+				if _, ok := visited[key]; ok {
+					continue
+				}
+				visited[key] = struct{}{}
+				queue = append(queue, key)
+				roles = append(roles, role)
+			}
+		}
+		closure[grantee] = roles
+	}
+	p.roleClosure = closure
+}
+
+// IsRoleReachable returns whether the role is granted to the grantee directly or through the nested roles.
+func (p *MySQLPrivilege) IsRoleReachable(grantee, role *auth.RoleIdentity) bool {
+	for _, r := range p.roleClosure[grantee.Username+"@"+grantee.Hostname] {
+		if r.Username == role.Username && r.Hostname == role.Hostname {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRoleAdminOption returns whether the role is granted WITH ADMIN OPTION to the user or to one of its effective roles.
+func (p *MySQLPrivilege) HasRoleAdminOption(activeRoles []*auth.RoleIdentity, user, host string, role *auth.RoleIdentity) bool {
+	roleKey := role.Username + "@" + role.Hostname
+	grantees := p.FindAllUserEffectiveRoles(user, host, activeRoles)
+	grantees = append(grantees, &auth.RoleIdentity{Username: user, Hostname: host})
+	for _, grantee := range grantees {
+		if p.RoleGraph[grantee.Username+"@"+grantee.Hostname].adminOption[roleKey] {
+			return true
+		}
+	}
+	return false
+}
+
 // FindRole is used to detect whether there is edges between users and roles.
 func (p *MySQLPrivilege) FindRole(user string, host string, role *auth.RoleIdentity) bool {
 	rec := p.matchUser(user, host)
@@ -398,9 +481,134 @@ func (p *MySQLPrivilege) LoadAll(ctx sessionctx.Context) error {
 		}
 		logutil.BgLogger().Warn("mysql.role_edges missing")
 	}
+
+	err = p.LoadMaskingPolicies(ctx)
+	if err != nil {
+		if !noSuchTable(err) {
+			logutil.BgLogger().Warn("load mysql.tidb_masking_policy", zap.Error(err))
+			return errLoadPrivilege.FastGen("mysql.tidb_masking_policy")
+		}
+		logutil.BgLogger().Warn("mysql.tidb_masking_policy missing")
+	}
+
+	err = p.LoadIPAccessList(ctx)
+	if err != nil {
+		if !noSuchTable(err) {
+			logutil.BgLogger().Warn("load mysql.tidb_ip_access_list", zap.Error(err))
+			return errLoadPrivilege.FastGen("mysql.tidb_ip_access_list")
+		}
+		logutil.BgLogger().Warn("mysql.tidb_ip_access_list missing")
+	}
+	return nil
+}
+
+// loadUsers loads the records of the given users from the tables keyed by
+// users, the other tables like mysql.role_edges are not loaded.
+func (p *MySQLPrivilege) loadUsers(ctx sessionctx.Context, users []string) error {
+	err := p.loadTable(ctx, sqlLoadUserTable+sqlWhereUsers, p.decodeUserTableRow, users)
+	if err != nil {
+		logutil.BgLogger().Warn("load mysql.user fail", zap.Error(err))
+		return errLoadPrivilege.FastGen("mysql.user")
+	}
+	tables := []struct {
+		name   string
+		sql    string
+		decode func(chunk.Row, []*ast.ResultField) error
+	}{
+		{"mysql.global_priv", sqlLoadGlobalPrivTable, p.decodeGlobalPrivTableRow},
+		{"mysql.global_grants", sqlLoadGlobalGrantsTable, p.decodeGlobalGrantsTableRow},
+		{"mysql.db", sqlSelectDBTable, p.decodeDBTableRow},
+		{"mysql.tables_priv", sqlLoadTablePrivTable, p.decodeTablesPrivTableRow},
+		{"mysql.default_roles", sqlLoadDefaultRoles, p.decodeDefaultRoleTableRow},
+		{"mysql.columns_priv", sqlLoadColumnsPrivTable, p.decodeColumnsPrivTableRow},
+	}
+	for _, tbl := range tables {
+		err = p.loadTable(ctx, tbl.sql+sqlWhereUsers, tbl.decode, users)
+		if err != nil {
+			if !noSuchTable(err) {
+				logutil.BgLogger().Warn("load "+tbl.name+" fail", zap.Error(err))
+				return errLoadPrivilege.FastGen(tbl.name)
+			}
+			logutil.BgLogger().Warn(tbl.name + " missing")
+		}
+	}
 	return nil
 }
 
+// merge returns a copy of p in which the records of the given users are
+// replaced by the ones in diff.
+func (p *MySQLPrivilege) merge(diff *MySQLPrivilege, users []string) *MySQLPrivilege {
+	userSet := make(map[string]struct{}, len(users))
+	for _, user := range users {
+		userSet[user] = struct{}{}
+	}
+	changed := func(r *baseRecord) bool {
+		_, ok := userSet[r.User]
+		return ok
+	}
+	ret := &MySQLPrivilege{
+		User:            mergeRecords(p.User, diff.User, func(r *UserRecord) bool { return changed(&r.baseRecord) }),
+		Global:          mergeRecordMap(p.Global, diff.Global, userSet),
+		Dynamic:         mergeRecordMap(p.Dynamic, diff.Dynamic, userSet),
+		DB:              mergeRecords(p.DB, diff.DB, func(r *dbRecord) bool { return changed(&r.baseRecord) }),
+		TablesPriv:      mergeRecords(p.TablesPriv, diff.TablesPriv, func(r *tablesPrivRecord) bool { return changed(&r.baseRecord) }),
+		ColumnsPriv:     mergeRecords(p.ColumnsPriv, diff.ColumnsPriv, func(r *columnsPrivRecord) bool { return changed(&r.baseRecord) }),
+		DefaultRoles:    mergeRecords(p.DefaultRoles, diff.DefaultRoles, func(r *defaultRoleRecord) bool { return changed(&r.baseRecord) }),
+		RoleGraph:       p.RoleGraph,
+		MaskingPolicies: p.MaskingPolicies,
+		IPAccessList:    p.IPAccessList,
+		roleClosure:     p.roleClosure,
+	}
+	ret.SortUserTable()
+	ret.buildUserMap()
+	// Keep the order of "ORDER BY host, db, user" in sqlLoadDBTable.
+	slices.SortStableFunc(ret.DB, func(x, y dbRecord) int {
+		if c := strings.Compare(x.Host, y.Host); c != 0 {
+			return c
+		}
+		if c := strings.Compare(x.DB, y.DB); c != 0 {
+			return c
+		}
+		return strings.Compare(x.User, y.User)
+	})
+	ret.buildDBMap()
+	ret.buildTablesPrivMap()
+	return ret
+}
+
+// mergeRecords returns the records in old which are not changed followed by
+// the changed records in diff.
+func mergeRecords[T any](old, diff []T, changed func(*T) bool) []T {
+	ret := make([]T, 0, len(old)+len(diff))
+	for i := range old {
+		if !changed(&old[i]) {
+			ret = append(ret, old[i])
+		}
+	}
+	for i := range diff {
+		if changed(&diff[i]) {
+			ret = append(ret, diff[i])
+		}
+	}
+	return ret
+}
+
+// mergeRecordMap is like mergeRecords, but for the records keyed by users.
+func mergeRecordMap[T any](old, diff map[string][]T, userSet map[string]struct{}) map[string][]T {
+	ret := make(map[string][]T, len(old))
+	for user, records := range old {
+		if _, ok := userSet[user]; !ok {
+			ret[user] = records
+		}
+	}
+	for user, records := range diff {
+		if _, ok := userSet[user]; ok {
+			ret[user] = records
+		}
+	}
+	return ret
+}
+
 func noSuchTable(err error) bool {
 	e1 := errors.Cause(err)
 	if e2, ok := e1.(*terror.Error); ok {
@@ -418,6 +626,7 @@ func (p *MySQLPrivilege) LoadRoleGraph(ctx sessionctx.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	p.buildRoleClosure()
 	return nil
 }
 
@@ -579,10 +788,22 @@ func (p *MySQLPrivilege) LoadDefaultRoles(ctx sessionctx.Context) error {
 	return p.loadTable(ctx, sqlLoadDefaultRoles, p.decodeDefaultRoleTableRow)
 }
 
+// LoadMaskingPolicies loads the mysql.tidb_masking_policy table from database.
+func (p *MySQLPrivilege) LoadMaskingPolicies(ctx sessionctx.Context) error {
+	p.MaskingPolicies = make(map[string][]maskingPolicyRecord)
+	return p.loadTable(ctx, sqlLoadMaskingPolicies, p.decodeMaskingPolicyTableRow)
+}
+
+// LoadIPAccessList loads the mysql.tidb_ip_access_list table from database.
+func (p *MySQLPrivilege) LoadIPAccessList(ctx sessionctx.Context) error {
+	p.IPAccessList = nil
+	return p.loadTable(ctx, sqlLoadIPAccessList, p.decodeIPAccessListTableRow)
+}
+
 func (p *MySQLPrivilege) loadTable(sctx sessionctx.Context, sql string,
-	decodeTableRow func(chunk.Row, []*ast.ResultField) error) error {
+	decodeTableRow func(chunk.Row, []*ast.ResultField) error, args ...any) error {
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnPrivilege)
-	rs, err := sctx.(sqlexec.SQLExecutor).ExecuteInternal(ctx, sql)
+	rs, err := sctx.(sqlexec.SQLExecutor).ExecuteInternal(ctx, sql, args...)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -723,6 +944,10 @@ func (p *MySQLPrivilege) decodeUserTableRow(row chunk.Row, fs []*ast.ResultField
 				continue
 			}
 			value.PasswordLifeTime = row.GetInt64(i)
+		case f.ColumnAsName.L == "max_questions":
+			value.MaxQuestions = int64(row.GetUint64(i))
+		case f.ColumnAsName.L == "max_user_connections":
+			value.MaxUserConnections = int64(row.GetUint64(i))
 		case f.Column.GetType() == mysql.TypeEnum:
 			if row.GetEnum(i).String() != "Y" {
 				continue
@@ -842,6 +1067,7 @@ func (p *MySQLPrivilege) decodeTablesPrivTableRow(row chunk.Row, fs []*ast.Resul
 
 func (p *MySQLPrivilege) decodeRoleEdgesTable(row chunk.Row, fs []*ast.ResultField) error {
 	var fromUser, fromHost, toHost, toUser string
+	var withAdminOption bool
 	for i, f := range fs {
 		switch f.ColumnAsName.L {
 		case "from_host":
@@ -852,16 +1078,24 @@ func (p *MySQLPrivilege) decodeRoleEdgesTable(row chunk.Row, fs []*ast.ResultFie
 			toHost = row.GetString(i)
 		case "to_user":
 			toUser = row.GetString(i)
+		case "with_admin_option":
+			withAdminOption = row.GetEnum(i).String() == "Y"
 		}
 	}
 	fromKey := fromUser + "@" + fromHost
 	toKey := toUser + "@" + toHost
 	roleGraph, ok := p.RoleGraph[toKey]
 	if !ok {
-		roleGraph = roleGraphEdgesTable{roleList: make(map[string]*auth.RoleIdentity)}
+		roleGraph = roleGraphEdgesTable{
+			roleList:    make(map[string]*auth.RoleIdentity),
+			adminOption: make(map[string]bool),
+		}
 		p.RoleGraph[toKey] = roleGraph
 	}
 	roleGraph.roleList[fromKey] = &auth.RoleIdentity{Username: fromUser, Hostname: fromHost}
+	if withAdminOption {
+		roleGraph.adminOption[fromKey] = true
+	}
 	return nil
 }
 
@@ -907,6 +1141,129 @@ func (p *MySQLPrivilege) decodeColumnsPrivTableRow(row chunk.Row, fs []*ast.Resu
 	return nil
 }
 
+func (p *MySQLPrivilege) decodeMaskingPolicyTableRow(row chunk.Row, fs []*ast.ResultField) error {
+	var value maskingPolicyRecord
+	var db, table string
+	for i, f := range fs {
+		switch f.ColumnAsName.L {
+		case "policy_name":
+			value.PolicyName = row.GetString(i)
+		case "table_schema":
+			db = strings.ToLower(row.GetString(i))
+		case "table_name":
+			table = strings.ToLower(row.GetString(i))
+		case "column_name":
+			value.Column = strings.ToLower(row.GetString(i))
+		case "masking_expr":
+			value.Expr = row.GetString(i)
+		case "role_user":
+			value.RoleUser = row.GetString(i)
+		case "role_host":
+			value.RoleHost = row.GetString(i)
+		}
+	}
+	key := db + "." + table
+	p.MaskingPolicies[key] = append(p.MaskingPolicies[key], value)
+	return nil
+}
+
+func (p *MySQLPrivilege) decodeIPAccessListTableRow(row chunk.Row, fs []*ast.ResultField) error {
+	var value ipAccessRecord
+	var cidr string
+	for i, f := range fs {
+		switch f.ColumnAsName.L {
+		case "rule_type":
+			value.Allow = row.GetEnum(i).String() == "ALLOW"
+		case "cidr":
+			cidr = strings.TrimSpace(row.GetString(i))
+		case "vpce_id":
+			value.VPCEID = row.GetString(i)
+		}
+	}
+	if cidr != "" {
+		network, err := parseIPAccessCIDR(cidr)
+		if err != nil {
+			// Skip the rule rather than refusing all the privileges.
+			logutil.BgLogger().Warn("invalid cidr in mysql.tidb_ip_access_list", zap.String("cidr", cidr), zap.Error(err))
+			return nil
+		}
+		value.Network = network
+	}
+	p.IPAccessList = append(p.IPAccessList, value)
+	return nil
+}
+
+// parseIPAccessCIDR parses a CIDR, a single IP address is treated as a host network.
+func parseIPAccessCIDR(cidr string) (*net.IPNet, error) {
+	if strings.Contains(cidr, "/") {
+		_, network, err := net.ParseCIDR(cidr)
+		return network, err
+	}
+	ip := net.ParseIP(cidr)
+	if ip == nil {
+		return nil, errors.Errorf("invalid IP address %s", cidr)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+func (record *ipAccessRecord) match(ip net.IP, vpceID string) bool {
+	if record.VPCEID != "" && record.VPCEID != vpceID {
+		return false
+	}
+	return record.Network == nil || (ip != nil && record.Network.Contains(ip))
+}
+
+// CheckIPAccess checks the client address, and the AWS VPC endpoint ID it connected
+// through, against mysql.tidb_ip_access_list. The client is rejected if it matches any
+// deny rule, or if there are allow rules but it matches none of them.
+func (p *MySQLPrivilege) CheckIPAccess(host, vpceID string) bool {
+	if len(p.IPAccessList) == 0 {
+		return true
+	}
+	ip := net.ParseIP(host)
+	allowed, hasAllow := false, false
+	for i := range p.IPAccessList {
+		record := &p.IPAccessList[i]
+		if record.Allow {
+			hasAllow = true
+			allowed = allowed || record.match(ip, vpceID)
+		} else if record.match(ip, vpceID) {
+			return false
+		}
+	}
+	return allowed || !hasAllow
+}
+
+// GetMaskingPolicies returns the masking expressions, keyed by lower case column name,
+// that apply to the user or any of its effective roles when reading db.table.
+func (p *MySQLPrivilege) GetMaskingPolicies(activeRoles []*auth.RoleIdentity, user, host, db, table string) map[string]string {
+	records := p.MaskingPolicies[strings.ToLower(db)+"."+strings.ToLower(table)]
+	if len(records) == 0 {
+		return nil
+	}
+	roles := p.FindAllUserEffectiveRoles(user, host, activeRoles)
+	roles = append(roles, &auth.RoleIdentity{Username: user, Hostname: host})
+	var ret map[string]string
+	for _, record := range records {
+		if _, ok := ret[record.Column]; ok {
+			continue
+		}
+		for _, role := range roles {
+			if role.Username == record.RoleUser && role.Hostname == record.RoleHost {
+				if ret == nil {
+					ret = make(map[string]string)
+				}
+				ret[record.Column] = record.Expr
+				break
+			}
+		}
+	}
+	return ret
+}
+
 func decodeSetToPrivilege(s types.Set) mysql.PrivilegeType {
 	var ret mysql.PrivilegeType
 	if s.Name == "" {
@@ -1397,24 +1754,30 @@ func (p *MySQLPrivilege) showGrants(ctx sessionctx.Context, user, host string, r
 	// Show role grants.
 	graphKey := user + "@" + host
 	edgeTable, ok := p.RoleGraph[graphKey]
-	g = ""
 	if ok {
+		// The roles granted WITH ADMIN OPTION are shown in a separate statement.
 		sortedRes := make([]string, 0, 10)
+		sortedAdminRes := make([]string, 0, len(edgeTable.adminOption))
 		for k := range edgeTable.roleList {
 			role := strings.Split(k, "@")
 			roleName, roleHost := role[0], role[1]
 			tmp := fmt.Sprintf("'%s'@'%s'", roleName, roleHost)
-			sortedRes = append(sortedRes, tmp)
-		}
-		slices.Sort(sortedRes)
-		for i, r := range sortedRes {
-			g += r
-			if i != len(sortedRes)-1 {
-				g += ", "
+			if edgeTable.adminOption[k] {
+				sortedAdminRes = append(sortedAdminRes, tmp)
+			} else {
+				sortedRes = append(sortedRes, tmp)
 			}
 		}
-		s := fmt.Sprintf(`GRANT %s TO '%s'@'%s'`, g, user, host)
-		gs = append(gs, s)
+		if len(sortedRes) > 0 {
+			slices.Sort(sortedRes)
+			s := fmt.Sprintf(`GRANT %s TO '%s'@'%s'`, strings.Join(sortedRes, ", "), user, host)
+			gs = append(gs, s)
+		}
+		if len(sortedAdminRes) > 0 {
+			slices.Sort(sortedAdminRes)
+			s := fmt.Sprintf(`GRANT %s TO '%s'@'%s' WITH ADMIN OPTION`, strings.Join(sortedAdminRes, ", "), user, host)
+			gs = append(gs, s)
+		}
 	}
 
 	// If the SHOW GRANTS is for the current user, there might be activeRoles (allRoles)
@@ -1571,6 +1934,39 @@ func (p *MySQLPrivilege) UserPrivilegesTable(activeRoles []*auth.RoleIdentity, u
 	return rows
 }
 
+// ColumnPrivilegesTable provide data for INFORMATION_SCHEMA.COLUMN_PRIVILEGES table.
+func (p *MySQLPrivilege) ColumnPrivilegesTable(activeRoles []*auth.RoleIdentity, user, host string) [][]types.Datum {
+	// Same as USER_PRIVILEGES, seeing all users requires SELECT ON mysql.*
+	showOtherUsers := p.RequestVerification(activeRoles, user, host, mysql.SystemDB, "", "", mysql.SelectPriv)
+	var rows [][]types.Datum
+	for _, record := range p.ColumnsPriv {
+		if showOtherUsers || record.baseRecord.match(user, host) {
+			rows = p.appendColumnPrivilegesTableRow(rows, record)
+		}
+	}
+	return rows
+}
+
+func (p *MySQLPrivilege) appendColumnPrivilegesTableRow(rows [][]types.Datum, record columnsPrivRecord) [][]types.Datum {
+	// The grant option can only be granted on table level, so it is taken from mysql.tables_priv.
+	isGrantable := "NO"
+	for _, tableRecord := range p.TablesPrivMap[record.User] {
+		if tableRecord.Host == record.Host && strings.EqualFold(tableRecord.DB, record.DB) &&
+			strings.EqualFold(tableRecord.TableName, record.TableName) && tableRecord.TablePriv&mysql.GrantPriv > 0 {
+			isGrantable = "YES"
+			break
+		}
+	}
+	grantee := fmt.Sprintf("'%s'@'%s'", record.User, record.Host)
+	for _, priv := range mysql.AllColumnPrivs {
+		if record.ColumnPriv&priv > 0 {
+			privilegeType := strings.ToUpper(mysql.Priv2Str[priv])
+			rows = append(rows, types.MakeDatums(grantee, "def", record.DB, record.TableName, record.ColumnName, privilegeType, isGrantable))
+		}
+	}
+	return rows
+}
+
 func appendDynamicPrivRecord(rows [][]types.Datum, user dynamicPrivRecord) [][]types.Datum {
 	isGrantable := "NO"
 	if user.GrantOption {
@@ -1633,6 +2029,9 @@ func (p *MySQLPrivilege) getAllRoles(user, host string) []*auth.RoleIdentity {
 
 // Handle wraps MySQLPrivilege providing thread safe access.
 type Handle struct {
+	// mu serializes the updates, so that an incremental update is always
+	// merged into the latest privileges.
+	mu   sync.Mutex
 	priv atomic.Value
 }
 
@@ -1648,12 +2047,43 @@ func (h *Handle) Get() *MySQLPrivilege {
 
 // Update loads all the privilege info from kv storage.
 func (h *Handle) Update(ctx sessionctx.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	var priv MySQLPrivilege
 	err := priv.LoadAll(ctx)
 	if err != nil {
 		return err
 	}
 
+	if old, ok := h.priv.Load().(*MySQLPrivilege); !ok || !reflect.DeepEqual(old.MaskingPolicies, priv.MaskingPolicies) {
+		privilege.MaskingPolicyVersion.Add(1)
+	}
 	h.priv.Store(&priv)
 	return nil
 }
+
+// UpdateUsers loads the privilege info of the given users from kv storage,
+// the privileges of the other users are kept as is. It's much cheaper than
+// Update when there are lots of users in the mysql.user table.
+func (h *Handle) UpdateUsers(ctx sessionctx.Context, users []string) error {
+	if len(users) == 0 {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var priv MySQLPrivilege
+	old, ok := h.priv.Load().(*MySQLPrivilege)
+	if !ok {
+		// Nothing is loaded yet, fall back to load all.
+		if err := priv.LoadAll(ctx); err != nil {
+			return err
+		}
+		h.priv.Store(&priv)
+		return nil
+	}
+	if err := priv.loadUsers(ctx, users); err != nil {
+		return err
+	}
+	h.priv.Store(old.merge(&priv, users))
+	return nil
+}
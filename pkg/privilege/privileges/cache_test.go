@@ -19,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/privilege/privileges"
@@ -307,7 +308,9 @@ func TestRoleGraphBFS(t *testing.T) {
 	tk.MustExec(`GRANT r_2 TO r_1;`)
 	tk.MustExec(`GRANT r_3 TO r_2;`)
 	tk.MustExec(`GRANT r_4 TO r_3;`)
-	tk.MustExec(`GRANT r_1 TO r_4;`)
+	tk.MustGetErrCode(`GRANT r_1 TO r_4;`, errno.ErrRoleGrantedToItself)
+	// The role graph may still contain loops which are written directly.
+	tk.MustExec(`INSERT INTO mysql.role_edges (FROM_HOST, FROM_USER, TO_HOST, TO_USER) VALUES ("%", "r_1", "%", "r_4")`)
 	tk.MustExec(`GRANT r_5 TO r_3, r_6;`)
 
 	var p privileges.MySQLPrivilege
@@ -332,6 +335,33 @@ func TestRoleGraphBFS(t *testing.T) {
 	require.Len(t, ret, 6)
 }
 
+func TestCheckIPAccess(t *testing.T) {
+	store := createStoreAndPrepareDB(t)
+
+	tk := testkit.NewTestKit(t, store)
+	var p privileges.MySQLPrivilege
+	require.NoError(t, p.LoadIPAccessList(tk.Session()))
+	require.True(t, p.CheckIPAccess("10.0.0.1", ""))
+
+	tk.MustExec(`INSERT INTO mysql.tidb_ip_access_list (rule_type, cidr) VALUES ("DENY", "10.1.0.0/16"), ("DENY", "192.168.1.2"), ("DENY", "not-a-cidr")`)
+	require.NoError(t, p.LoadIPAccessList(tk.Session()))
+	require.Len(t, p.IPAccessList, 2)
+	require.False(t, p.CheckIPAccess("10.1.2.3", ""))
+	require.False(t, p.CheckIPAccess("192.168.1.2", "vpce-1"))
+	require.True(t, p.CheckIPAccess("192.168.1.3", ""))
+	require.True(t, p.CheckIPAccess("::1", ""))
+
+	// Once there are allow rules, the other clients are rejected.
+	tk.MustExec(`INSERT INTO mysql.tidb_ip_access_list (rule_type, cidr, vpce_id) VALUES ("ALLOW", "10.0.0.0/8", ""), ("ALLOW", "", "vpce-1")`)
+	require.NoError(t, p.LoadIPAccessList(tk.Session()))
+	require.True(t, p.CheckIPAccess("10.0.0.1", ""))
+	require.False(t, p.CheckIPAccess("10.1.2.3", ""))
+	require.True(t, p.CheckIPAccess("172.16.0.1", "vpce-1"))
+	require.False(t, p.CheckIPAccess("172.16.0.1", "vpce-2"))
+	require.False(t, p.CheckIPAccess("172.16.0.1", ""))
+	require.False(t, p.CheckIPAccess("localhost", ""))
+}
+
 func TestFindAllUserEffectiveRoles(t *testing.T) {
 	store := createStoreAndPrepareDB(t)
 
@@ -572,3 +602,51 @@ func TestDBIsVisible(t *testing.T) {
 	require.True(t, isVisible)
 	tk.MustExec("TRUNCATE TABLE mysql.user")
 }
+
+func TestHandleUpdateUsers(t *testing.T) {
+	store := createStoreAndPrepareDB(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`INSERT INTO mysql.user (Host, User) VALUES ("%", "u1"), ("%", "u2")`)
+	tk.MustExec(`INSERT INTO mysql.db (Host, DB, User, Select_priv) VALUES ("%", "db1", "u1", "Y"), ("%", "db1", "u2", "Y")`)
+	h := privileges.NewHandle()
+	require.NoError(t, h.Update(tk.Session()))
+	p := h.Get()
+	require.True(t, p.RequestVerification(nil, "u1", "%", "db1", "", "", mysql.SelectPriv))
+	require.True(t, p.RequestVerification(nil, "u2", "%", "db1", "", "", mysql.SelectPriv))
+
+	// Only the privileges of u1 and the new user u3 are reloaded.
+	tk.MustExec(`UPDATE mysql.db SET Select_priv = "N", Insert_priv = "Y" WHERE User in ("u1", "u2")`)
+	tk.MustExec(`INSERT INTO mysql.user (Host, User, Update_priv) VALUES ("%", "u3", "Y")`)
+	tk.MustExec(`INSERT INTO mysql.tables_priv (Host, DB, User, Table_name, Table_priv) VALUES ("%", "db1", "u1", "t", "Delete")`)
+	require.NoError(t, h.UpdateUsers(tk.Session(), []string{"u1", "u3"}))
+	p = h.Get()
+	require.False(t, p.RequestVerification(nil, "u1", "%", "db1", "", "", mysql.SelectPriv))
+	require.True(t, p.RequestVerification(nil, "u1", "%", "db1", "", "", mysql.InsertPriv))
+	require.True(t, p.RequestVerification(nil, "u1", "%", "db1", "t", "", mysql.DeletePriv))
+	require.True(t, p.RequestVerification(nil, "u2", "%", "db1", "", "", mysql.SelectPriv))
+	require.False(t, p.RequestVerification(nil, "u2", "%", "db1", "", "", mysql.InsertPriv))
+	require.True(t, p.RequestVerification(nil, "u3", "%", "db1", "", "", mysql.UpdatePriv))
+	require.NotNil(t, p.UserMap["u3"])
+	require.Len(t, p.DBMap["u1"], 1)
+	require.Len(t, p.DBMap["u2"], 1)
+
+	// The removed users are removed from the privileges too.
+	tk.MustExec(`DELETE FROM mysql.user WHERE User = "u1"`)
+	tk.MustExec(`DELETE FROM mysql.db WHERE User = "u1"`)
+	tk.MustExec(`DELETE FROM mysql.tables_priv WHERE User = "u1"`)
+	require.NoError(t, h.UpdateUsers(tk.Session(), []string{"u1"}))
+	p = h.Get()
+	require.Nil(t, p.UserMap["u1"])
+	require.Nil(t, p.DBMap["u1"])
+	require.Nil(t, p.TablesPrivMap["u1"])
+	require.False(t, p.RequestVerification(nil, "u1", "%", "db1", "", "", mysql.InsertPriv))
+	require.True(t, p.RequestVerification(nil, "u2", "%", "db1", "", "", mysql.SelectPriv))
+	require.True(t, p.RequestVerification(nil, "u3", "%", "db1", "", "", mysql.UpdatePriv))
+
+	// Update reloads all the users.
+	require.NoError(t, h.Update(tk.Session()))
+	p = h.Get()
+	require.False(t, p.RequestVerification(nil, "u2", "%", "db1", "", "", mysql.SelectPriv))
+	require.True(t, p.RequestVerification(nil, "u2", "%", "db1", "", "", mysql.InsertPriv))
+}
@@ -66,6 +66,7 @@ var dynamicPrivs = []string{
 	"RESTRICTED_CONNECTION_ADMIN",     // Can not be killed by PROCESS/CONNECTION_ADMIN privilege
 	"RESTRICTED_REPLICA_WRITER_ADMIN", // Can write to the sever even when tidb_restriced_read_only is turned on.
 	"RESOURCE_GROUP_ADMIN",            // Create/Drop/Alter RESOURCE GROUP
+	"MASKING_POLICY_ADMIN",            // Create/Drop MASKING POLICY
 }
 var dynamicPrivLock sync.Mutex
 var defaultTokenLife = 15 * time.Minute
@@ -305,6 +306,19 @@ func (p *UserPrivileges) GetAuthPlugin(user, host string) (string, error) {
 	return record.AuthPlugin, nil
 }
 
+// GetUserResources implements the Manager interface.
+func (p *UserPrivileges) GetUserResources(user, host string) (maxQuestions, maxUserConnections int64) {
+	if SkipWithGrant {
+		return 0, 0
+	}
+	mysqlPriv := p.Handle.Get()
+	record := mysqlPriv.connectionVerification(user, host)
+	if record == nil {
+		return 0, 0
+	}
+	return record.MaxQuestions, record.MaxUserConnections
+}
+
 // MatchIdentity implements the Manager interface.
 func (p *UserPrivileges) MatchIdentity(user, host string, skipNameResolve bool) (u string, h string, success bool) {
 	if SkipWithGrant {
@@ -830,6 +844,12 @@ func (p *UserPrivileges) UserPrivilegesTable(activeRoles []*auth.RoleIdentity, u
 	return mysqlPriv.UserPrivilegesTable(activeRoles, user, host)
 }
 
+// ColumnPrivilegesTable implements the Manager interface.
+func (p *UserPrivileges) ColumnPrivilegesTable(activeRoles []*auth.RoleIdentity, user, host string) [][]types.Datum {
+	mysqlPriv := p.Handle.Get()
+	return mysqlPriv.ColumnPrivilegesTable(activeRoles, user, host)
+}
+
 // ShowGrants implements privilege.Manager ShowGrants interface.
 func (p *UserPrivileges) ShowGrants(ctx sessionctx.Context, user *auth.UserIdentity, roles []*auth.RoleIdentity) (grants []string, err error) {
 	if SkipWithGrant {
@@ -903,6 +923,39 @@ func (p *UserPrivileges) GetAllRoles(user, host string) []*auth.RoleIdentity {
 	return mysqlPrivilege.getAllRoles(user, host)
 }
 
+// IsRoleReachable implements the Manager interface.
+func (p *UserPrivileges) IsRoleReachable(grantee, role *auth.RoleIdentity) bool {
+	if SkipWithGrant {
+		return false
+	}
+	mysqlPrivilege := p.Handle.Get()
+	return mysqlPrivilege.IsRoleReachable(grantee, role)
+}
+
+// HasRoleAdminOption implements the Manager interface.
+func (p *UserPrivileges) HasRoleAdminOption(activeRoles []*auth.RoleIdentity, role *auth.RoleIdentity) bool {
+	if SkipWithGrant {
+		return true
+	}
+	if p.user == "" && p.host == "" {
+		return true
+	}
+	mysqlPrivilege := p.Handle.Get()
+	return mysqlPrivilege.HasRoleAdminOption(activeRoles, p.user, p.host, role)
+}
+
+// GetMaskingPolicies implements the Manager interface.
+func (p *UserPrivileges) GetMaskingPolicies(activeRoles []*auth.RoleIdentity, db, table string) map[string]string {
+	if SkipWithGrant {
+		return nil
+	}
+	if p.user == "" && p.host == "" {
+		return nil
+	}
+	mysqlPrivilege := p.Handle.Get()
+	return mysqlPrivilege.GetMaskingPolicies(activeRoles, p.user, p.host, db, table)
+}
+
 // IsDynamicPrivilege returns true if the DYNAMIC privilege is built-in or has been registered by a plugin
 func (p *UserPrivileges) IsDynamicPrivilege(privName string) bool {
 	privNameInUpper := strings.ToUpper(privName)
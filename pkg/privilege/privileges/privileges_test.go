@@ -32,9 +32,11 @@ import (
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/privilege"
 	"github.com/pingcap/tidb/pkg/privilege/privileges"
 	"github.com/pingcap/tidb/pkg/session"
@@ -1057,6 +1059,184 @@ func TestDefaultRoles(t *testing.T) {
 	require.Len(t, ret, 0)
 }
 
+func TestRoleAdminOption(t *testing.T) {
+	store := createStoreAndPrepareDB(t)
+
+	rootTk := testkit.NewTestKit(t, store)
+	require.NoError(t, rootTk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	rootTk.MustExec(`CREATE USER u1, u2`)
+	rootTk.MustExec(`CREATE ROLE r_admin, r_nested`)
+	rootTk.MustExec(`GRANT BACKUP_ADMIN ON *.* TO r_nested`)
+	rootTk.MustExec(`GRANT r_nested TO r_admin`)
+	rootTk.MustExec(`GRANT r_admin TO u1 WITH ADMIN OPTION`)
+	rootTk.MustExec(`SET DEFAULT ROLE ALL TO u1`)
+	rootTk.MustQuery(`SHOW GRANTS FOR u1`).Check(testkit.Rows(
+		"GRANT USAGE ON *.* TO 'u1'@'%'",
+		"GRANT 'r_admin'@'%' TO 'u1'@'%' WITH ADMIN OPTION",
+	))
+	// The loop in the role graph is rejected.
+	rootTk.MustGetErrCode(`GRANT r_admin TO r_nested`, errno.ErrRoleGrantedToItself)
+	rootTk.MustGetErrCode(`GRANT r_admin TO r_admin`, errno.ErrRoleGrantedToItself)
+
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	// The dynamic privilege is inherited from the nested role.
+	pc := privilege.GetPrivilegeManager(tk.Session())
+	require.True(t, pc.RequestDynamicVerification(tk.Session().GetSessionVars().ActiveRoles, "BACKUP_ADMIN", false))
+	tk.MustExec(`SET ROLE NONE`)
+	require.False(t, pc.RequestDynamicVerification(tk.Session().GetSessionVars().ActiveRoles, "BACKUP_ADMIN", false))
+
+	// The role granted WITH ADMIN OPTION can be granted and revoked without ROLE_ADMIN.
+	tk.MustExec(`GRANT r_admin TO u2`)
+	rootTk.MustQuery(`SHOW GRANTS FOR u2`).Check(testkit.Rows(
+		"GRANT USAGE ON *.* TO 'u2'@'%'",
+		"GRANT 'r_admin'@'%' TO 'u2'@'%'",
+	))
+	tk.MustExec(`REVOKE r_admin FROM u2`)
+	tk.MustGetErrCode(`GRANT r_nested TO u2`, errno.ErrSpecificAccessDenied)
+	tk.MustGetErrCode(`GRANT r_admin, r_nested TO u2`, errno.ErrSpecificAccessDenied)
+}
+
+func TestMaskingPolicy(t *testing.T) {
+	store := createStoreAndPrepareDB(t)
+
+	rootTk := testkit.NewTestKit(t, store)
+	require.NoError(t, rootTk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	rootTk.MustExec(`USE test`)
+	rootTk.MustExec(`CREATE TABLE customers (id INT PRIMARY KEY, phone VARCHAR(20), email VARCHAR(64))`)
+	rootTk.MustExec(`INSERT INTO customers VALUES (1, '13812345678', 'john.doe@example.com')`)
+	rootTk.MustExec(`CREATE USER analyst, policy_admin`)
+	rootTk.MustExec(`CREATE ROLE r_masked`)
+	rootTk.MustExec(`GRANT SELECT ON test.* TO r_masked`)
+	rootTk.MustExec(`GRANT r_masked TO analyst`)
+	rootTk.MustExec(`SET DEFAULT ROLE ALL TO analyst`)
+
+	adminTk := testkit.NewTestKit(t, store)
+	require.NoError(t, adminTk.Session().Auth(&auth.UserIdentity{Username: "policy_admin", Hostname: "%"}, nil, nil, nil))
+	adminTk.MustGetErrCode(`CREATE MASKING POLICY p_phone ON test.customers (phone) USING mask_partial(phone, 3, 4) TO r_masked`, errno.ErrSpecificAccessDenied)
+	rootTk.MustExec(`GRANT MASKING_POLICY_ADMIN ON *.* TO policy_admin`)
+	adminTk.MustExec(`CREATE MASKING POLICY p_phone ON test.customers (phone) USING mask_partial(phone, 3, 4) TO r_masked`)
+	adminTk.MustExec(`CREATE MASKING POLICY p_email ON test.customers (email) USING mask_email(email) TO r_masked`)
+	adminTk.MustGetErrCode(`CREATE MASKING POLICY p_phone ON test.customers (phone) USING mask_partial(phone, 3, 4) TO r_masked`, errno.ErrMaskingPolicyExists)
+	adminTk.MustExec(`CREATE MASKING POLICY IF NOT EXISTS p_phone ON test.customers (phone) USING mask_partial(phone, 3, 4) TO r_masked`)
+	adminTk.MustGetErrCode(`CREATE MASKING POLICY p_bad ON test.customers (no_such_col) USING mask_email(email) TO r_masked`, errno.ErrBadField)
+
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "analyst", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec(`USE test`)
+	tk.MustQuery(`SELECT * FROM customers`).Check(testkit.Rows("1 138XXXX5678 jXXXXXXX@example.com"))
+	tk.MustQuery(`SELECT c.phone FROM customers c WHERE c.id = 1`).Check(testkit.Rows("138XXXX5678"))
+	// The policies only apply to the specified roles.
+	rootTk.MustQuery(`SELECT phone, email FROM customers`).Check(testkit.Rows("13812345678 john.doe@example.com"))
+	tk.MustExec(`SET ROLE NONE`)
+	rootTk.MustExec(`GRANT SELECT ON test.* TO analyst`)
+	tk.MustQuery(`SELECT phone, email FROM customers`).Check(testkit.Rows("13812345678 john.doe@example.com"))
+	tk.MustExec(`SET ROLE ALL`)
+
+	adminTk.MustExec(`DROP MASKING POLICY p_phone`)
+	adminTk.MustGetErrCode(`DROP MASKING POLICY p_phone`, errno.ErrMaskingPolicyNotExists)
+	adminTk.MustExec(`DROP MASKING POLICY IF EXISTS p_phone`)
+	tk.MustQuery(`SELECT phone, email FROM customers`).Check(testkit.Rows("13812345678 jXXXXXXX@example.com"))
+}
+
+func TestMaskingPolicyLockingReadAndDML(t *testing.T) {
+	store := createStoreAndPrepareDB(t)
+
+	rootTk := testkit.NewTestKit(t, store)
+	require.NoError(t, rootTk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	rootTk.MustExec(`USE test`)
+	rootTk.MustExec(`CREATE TABLE customers (id INT PRIMARY KEY, phone VARCHAR(20), email VARCHAR(64))`)
+	rootTk.MustExec(`INSERT INTO customers VALUES (1, '13812345678', 'john.doe@example.com')`)
+	rootTk.MustExec(`CREATE TABLE pcustomers (id INT PRIMARY KEY, phone VARCHAR(20)) PARTITION BY HASH(id) PARTITIONS 2`)
+	rootTk.MustExec(`INSERT INTO pcustomers VALUES (1, '13812345678'), (2, '13987654321')`)
+	rootTk.MustExec(`CREATE TABLE t2 (id INT PRIMARY KEY, x VARCHAR(20))`)
+	rootTk.MustExec(`INSERT INTO t2 VALUES (1, '')`)
+	rootTk.MustExec(`CREATE USER analyst`)
+	rootTk.MustExec(`CREATE ROLE r_masked`)
+	rootTk.MustExec(`GRANT SELECT, INSERT, UPDATE, DELETE, CREATE ON test.* TO r_masked`)
+	rootTk.MustExec(`GRANT r_masked TO analyst`)
+	rootTk.MustExec(`SET DEFAULT ROLE ALL TO analyst`)
+	rootTk.MustExec(`CREATE MASKING POLICY p_phone ON test.customers (phone) USING mask_partial(phone, 3, 4) TO r_masked`)
+	rootTk.MustExec(`CREATE MASKING POLICY p_pphone ON test.pcustomers (phone) USING mask_partial(phone, 3, 4) TO r_masked`)
+
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "analyst", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec(`USE test`)
+
+	// Locking reads.
+	tk.MustExec(`BEGIN`)
+	tk.MustQuery(`SELECT phone FROM customers WHERE id = 1 FOR UPDATE`).Check(testkit.Rows("138XXXX5678"))
+	tk.MustQuery(`SELECT phone FROM customers FOR UPDATE`).Check(testkit.Rows("138XXXX5678"))
+	tk.MustQuery(`SELECT phone FROM pcustomers ORDER BY id FOR UPDATE`).Check(testkit.Rows("138XXXX5678", "139XXXX4321"))
+	tk.MustExec(`COMMIT`)
+	tk.MustExec(`SET tx_isolation = 'READ-COMMITTED'`)
+	tk.MustExec(`BEGIN PESSIMISTIC`)
+	tk.MustQuery(`SELECT phone FROM customers`).Check(testkit.Rows("138XXXX5678"))
+	tk.MustExec(`COMMIT`)
+	tk.MustExec(`SET tx_isolation = 'REPEATABLE-READ'`)
+
+	// The masked values are copied by the DML statements reading the masked columns.
+	tk.MustExec(`INSERT INTO t2 SELECT 2, phone FROM customers`)
+	tk.MustExec(`UPDATE t2 JOIN customers ON t2.id = customers.id SET t2.x = customers.phone`)
+	rootTk.MustQuery(`SELECT * FROM t2 ORDER BY id`).Check(testkit.Rows("1 138XXXX5678", "2 138XXXX5678"))
+	tk.MustExec(`CREATE TABLE t3 SELECT id, phone FROM customers`)
+	rootTk.MustQuery(`SELECT * FROM t3`).Check(testkit.Rows("1 138XXXX5678"))
+
+	// The masked tables can't be modified.
+	tk.MustGetErrCode(`UPDATE customers SET email = 'a@example.com' WHERE id = 1`, errno.ErrMaskedTableNotWritable)
+	tk.MustGetErrCode(`UPDATE customers SET email = 'a@example.com'`, errno.ErrMaskedTableNotWritable)
+	tk.MustGetErrCode(`UPDATE customers JOIN t2 ON t2.id = customers.id SET customers.email = t2.x`, errno.ErrMaskedTableNotWritable)
+	tk.MustGetErrCode(`DELETE FROM customers WHERE id = 1`, errno.ErrMaskedTableNotWritable)
+	tk.MustGetErrCode(`DELETE FROM customers`, errno.ErrMaskedTableNotWritable)
+	tk.MustGetErrCode(`INSERT INTO customers VALUES (1, '', '') ON DUPLICATE KEY UPDATE phone = phone`, errno.ErrMaskedTableNotWritable)
+	tk.MustExec(`DELETE t2 FROM t2 JOIN customers ON t2.id = customers.id`)
+	rootTk.MustQuery(`SELECT * FROM customers`).Check(testkit.Rows("1 13812345678 john.doe@example.com"))
+	rootTk.MustQuery(`SELECT * FROM t2`).Check(testkit.Rows("2 138XXXX5678"))
+}
+
+func TestMaskingPolicyPreparedPlanCache(t *testing.T) {
+	store := createStoreAndPrepareDB(t)
+
+	rootTk := testkit.NewTestKit(t, store)
+	require.NoError(t, rootTk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	rootTk.MustExec(`USE test`)
+	rootTk.MustExec(`CREATE TABLE customers (id INT PRIMARY KEY, phone VARCHAR(20))`)
+	rootTk.MustExec(`INSERT INTO customers VALUES (1, '13812345678')`)
+	rootTk.MustExec(`CREATE USER analyst`)
+	rootTk.MustExec(`CREATE ROLE r_masked`)
+	rootTk.MustExec(`GRANT SELECT ON test.* TO analyst`)
+	rootTk.MustExec(`GRANT r_masked TO analyst`)
+	rootTk.MustExec(`SET DEFAULT ROLE ALL TO analyst`)
+
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "analyst", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec(`USE test`)
+	tk.MustExec(`SET tidb_enable_prepared_plan_cache = ON`)
+	tk.MustExec(`PREPARE st_range FROM 'SELECT phone FROM customers WHERE id > ?'`)
+	tk.MustExec(`PREPARE st_point FROM 'SELECT phone FROM customers WHERE id = ?'`)
+	tk.MustExec(`SET @a = 0, @b = 1`)
+	check := func(phone string) {
+		tk.MustQuery(`EXECUTE st_range USING @a`).Check(testkit.Rows(phone))
+		tk.MustQuery(`EXECUTE st_point USING @b`).Check(testkit.Rows(phone))
+	}
+	check("13812345678")
+	check("13812345678")
+	tk.MustQuery(`EXECUTE st_range USING @a`).Check(testkit.Rows("13812345678"))
+	tk.MustQuery(`SELECT @@last_plan_from_cache`).Check(testkit.Rows("1"))
+
+	// The plans cached before the policy is created don't mask the column.
+	rootTk.MustExec(`CREATE MASKING POLICY p_phone ON test.customers (phone) USING mask_partial(phone, 3, 4) TO r_masked`)
+	check("138XXXX5678")
+	check("138XXXX5678")
+	tk.MustExec(`SET ROLE NONE`)
+	check("13812345678")
+	check("13812345678")
+	tk.MustExec(`SET ROLE ALL`)
+	check("138XXXX5678")
+	rootTk.MustExec(`DROP MASKING POLICY p_phone`)
+	check("13812345678")
+}
+
 func TestUserTableConsistency(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 
@@ -1506,6 +1686,114 @@ func TestInfoSchemaUserPrivileges(t *testing.T) {
 	tk.MustQuery(`SELECT * FROM information_schema.user_privileges WHERE grantee = "'isselectonmysqluser'@'%'"`).Check(testkit.Rows("'isselectonmysqluser'@'%' def USAGE NO"))
 }
 
+func TestColumnPrivilege(t *testing.T) {
+	store := createStoreAndPrepareDB(t)
+
+	rootTk := testkit.NewTestKit(t, store)
+	rootTk.MustExec("CREATE TABLE test.colpriv (a INT PRIMARY KEY, b INT, c INT)")
+	rootTk.MustExec("CREATE TABLE test.colpriv_other (a INT)")
+	rootTk.MustExec("INSERT INTO test.colpriv VALUES (1, 1, 1)")
+	rootTk.MustExec("CREATE USER colpriv_user")
+	rootTk.MustExec("GRANT SELECT(a, b), INSERT(a, b), UPDATE(b) ON test.colpriv TO colpriv_user")
+
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "colpriv_user", Hostname: "localhost"}, nil, nil, nil))
+	tk.MustQuery("SELECT a, b FROM test.colpriv WHERE b = 1").Check(testkit.Rows("1 1"))
+	tk.MustQuery("SELECT t.b FROM (SELECT a AS x, b FROM test.colpriv) t WHERE t.x = 1").Check(testkit.Rows("1"))
+	tk.MustGetErrCode("SELECT * FROM test.colpriv", errno.ErrColumnaccessDenied)
+	tk.MustGetErrCode("SELECT a FROM test.colpriv WHERE c = 1", errno.ErrColumnaccessDenied)
+	tk.MustGetErrCode("SELECT a FROM test.colpriv_other", errno.ErrTableaccessDenied)
+
+	tk.MustExec("INSERT INTO test.colpriv (a, b) VALUES (2, 2)")
+	tk.MustGetErrCode("INSERT INTO test.colpriv VALUES (3, 3, 3)", errno.ErrColumnaccessDenied)
+	tk.MustGetErrCode("INSERT INTO test.colpriv (a, c) VALUES (3, 3)", errno.ErrColumnaccessDenied)
+
+	tk.MustExec("UPDATE test.colpriv SET b = b + 1 WHERE a = 2")
+	tk.MustGetErrCode("UPDATE test.colpriv SET b = 1, c = 1 WHERE a = 2", errno.ErrColumnaccessDenied)
+	tk.MustGetErrCode("UPDATE test.colpriv SET b = c WHERE a = 2", errno.ErrColumnaccessDenied)
+	tk.MustGetErrCode("DELETE FROM test.colpriv WHERE a = 2", errno.ErrTableaccessDenied)
+	tk.MustQuery("SELECT a, b FROM test.colpriv").Sort().Check(testkit.Rows("1 1", "2 3"))
+
+	rootTk.MustExec("REVOKE SELECT(b) ON test.colpriv FROM colpriv_user")
+	tk.MustQuery("SELECT a FROM test.colpriv").Sort().Check(testkit.Rows("1", "2"))
+	tk.MustGetErrCode("SELECT a, b FROM test.colpriv", errno.ErrColumnaccessDenied)
+
+	// Table level privileges still cover all the columns.
+	rootTk.MustExec("GRANT SELECT ON test.colpriv TO colpriv_user")
+	tk.MustQuery("SELECT * FROM test.colpriv").Sort().Check(testkit.Rows("1 1 1", "2 3 <nil>"))
+}
+
+func TestColumnPrivilegePointGet(t *testing.T) {
+	store := createStoreAndPrepareDB(t)
+
+	rootTk := testkit.NewTestKit(t, store)
+	rootTk.MustExec("CREATE TABLE test.colpriv (a INT PRIMARY KEY, b INT, c INT, UNIQUE KEY(b), UNIQUE KEY(c))")
+	rootTk.MustExec("INSERT INTO test.colpriv VALUES (1, 1, 1), (2, 2, 2)")
+	rootTk.MustExec("CREATE USER colpriv_pg")
+	rootTk.MustExec("GRANT SELECT(a, b) ON test.colpriv TO colpriv_pg")
+
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "colpriv_pg", Hostname: "localhost"}, nil, nil, nil))
+	tryFastPlan := func(sql string) plannercore.Plan {
+		stmt, err := parser.New().ParseOneStmt(sql, "", "")
+		require.NoError(t, err)
+		require.NoError(t, plannercore.Preprocess(context.Background(), tk.Session(), stmt))
+		return plannercore.TryFastPlan(tk.Session().GetPlanCtx(), stmt)
+	}
+
+	// The point get and the batch point get only read the granted columns.
+	for _, sql := range []string{
+		"SELECT a, b FROM test.colpriv WHERE a = 1",
+		"SELECT a FROM test.colpriv WHERE b = 1",
+		"SELECT a, b FROM test.colpriv WHERE a IN (1, 2)",
+		"SELECT b FROM test.colpriv WHERE b IN (1, 2)",
+	} {
+		require.NotNil(t, tryFastPlan(sql), sql)
+	}
+	tk.MustPointGet("SELECT a, b FROM test.colpriv WHERE a = 1").Check(testkit.Rows("1 1"))
+	tk.MustPointGet("SELECT a FROM test.colpriv WHERE b = 2").Check(testkit.Rows("2"))
+	tk.MustQuery("SELECT a, b FROM test.colpriv WHERE a IN (1, 2)").Sort().Check(testkit.Rows("1 1", "2 2"))
+
+	// The columns in the output or used to locate the rows must be granted.
+	for _, sql := range []string{
+		"SELECT * FROM test.colpriv WHERE a = 1",
+		"SELECT a FROM test.colpriv WHERE c = 1",
+		"SELECT c FROM test.colpriv WHERE a IN (1, 2)",
+		"SELECT a FROM test.colpriv WHERE c IN (1, 2)",
+	} {
+		require.Nil(t, tryFastPlan(sql), sql)
+		tk.MustGetErrCode(sql, errno.ErrColumnaccessDenied)
+	}
+}
+
+func TestInfoSchemaColumnPrivileges(t *testing.T) {
+	store := createStoreAndPrepareDB(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("CREATE TABLE test.colpriv (a INT, b INT)")
+	tk.MustExec("CREATE USER iscolnobody, iscoluser, iscolselectonmysql")
+	tk.MustExec("GRANT SELECT(a, b), UPDATE(b) ON test.colpriv TO iscoluser")
+	tk.MustExec("GRANT SELECT ON mysql.* TO iscolselectonmysql")
+
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "iscoluser", Hostname: "localhost"}, nil, nil, nil))
+	tk.MustQuery("SELECT * FROM information_schema.column_privileges").Sort().Check(testkit.Rows(
+		"'iscoluser'@'%' def test colpriv a SELECT NO",
+		"'iscoluser'@'%' def test colpriv b SELECT NO",
+		"'iscoluser'@'%' def test colpriv b UPDATE NO",
+	))
+
+	// Other users can not be seen without SELECT on mysql.*
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "iscolnobody", Hostname: "localhost"}, nil, nil, nil))
+	tk.MustQuery("SELECT * FROM information_schema.column_privileges").Check(testkit.Rows())
+
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "iscolselectonmysql", Hostname: "localhost"}, nil, nil, nil))
+	tk.MustQuery("SELECT column_name, privilege_type FROM information_schema.column_privileges WHERE grantee = \"'iscoluser'@'%'\"").Sort().Check(testkit.Rows(
+		"a SELECT",
+		"b SELECT",
+		"b UPDATE",
+	))
+}
+
 // Issues https://github.com/pingcap/tidb/issues/25972 and https://github.com/pingcap/tidb/issues/26451
 func TestGrantOptionAndRevoke(t *testing.T) {
 	store := createStoreAndPrepareDB(t)
@@ -45,6 +45,7 @@ import (
 	"io"
 	"net"
 	"os/user"
+	"reflect"
 	"runtime"
 	"runtime/pprof"
 	"runtime/trace"
@@ -85,6 +86,7 @@ import (
 	"github.com/pingcap/tidb/pkg/server/internal/dump"
 	"github.com/pingcap/tidb/pkg/server/internal/handshake"
 	"github.com/pingcap/tidb/pkg/server/internal/parse"
+	"github.com/pingcap/tidb/pkg/server/internal/proxytlv"
 	"github.com/pingcap/tidb/pkg/server/internal/resultset"
 	util2 "github.com/pingcap/tidb/pkg/server/internal/util"
 	server_metrics "github.com/pingcap/tidb/pkg/server/metrics"
@@ -201,6 +203,13 @@ type clientConn struct {
 
 	// Proxy Protocol Enabled
 	ppEnabled bool
+	// proxyTLVs are the TLVs of the PROXY protocol v2 header, if any.
+	proxyTLVs []proxytlv.TLV
+	// userResource counts the connections and statements of the authenticated account.
+	userResource atomic.Pointer[userResourceUsage]
+	// lazyCursorStmt is the statement whose cursor reads the rows from the executor on demand. At most one lazy
+	// cursor is open at any time, because executing another statement materializes it.
+	lazyCursorStmt PreparedStatement
 }
 
 func (cc *clientConn) getCtx() *TiDBContext {
@@ -350,29 +359,28 @@ func (cc *clientConn) handshake(ctx context.Context) error {
 	// With mysql --compression-algorithms=zlib,zstd both flags are set, the result is Zlib
 	if cc.capability&mysql.ClientCompress > 0 {
 		cc.pkt.SetCompressionAlgorithm(mysql.CompressionZlib)
-		cc.ctx.SetCompressionAlgorithm(mysql.CompressionZlib)
 	} else if cc.capability&mysql.ClientZstdCompressionAlgorithm > 0 {
 		cc.pkt.SetCompressionAlgorithm(mysql.CompressionZstd)
-		cc.ctx.SetCompressionAlgorithm(mysql.CompressionZstd)
 	}
+	cc.syncCompression()
 
 	return err
 }
 
-func (cc *clientConn) Close() error {
-	cc.server.rwlock.Lock()
-	delete(cc.server.clients, cc.connectionID)
-	resourceGroupName, count := "", 0
-	if ctx := cc.getCtx(); ctx != nil {
-		resourceGroupName = ctx.GetSessionVars().ResourceGroupName
-		count = cc.server.ConnNumByResourceGroup[resourceGroupName]
-		if count <= 1 {
-			delete(cc.server.ConnNumByResourceGroup, resourceGroupName)
-		} else {
-			cc.server.ConnNumByResourceGroup[resourceGroupName]--
-		}
+// syncCompression records the protocol compression of the connection in the session. It's also called when the
+// session is renewed by COM_CHANGE_USER and COM_RESET_CONNECTION.
+func (cc *clientConn) syncCompression() {
+	ca := cc.pkt.CompressionAlgorithm()
+	if ca == mysql.CompressionNone {
+		return
 	}
-	cc.server.rwlock.Unlock()
+	cc.ctx.SetCompressionAlgorithm(ca)
+	cc.ctx.GetSessionVars().CompressionStats = cc.pkt.CompressionStats()
+}
+
+func (cc *clientConn) Close() error {
+	resourceGroupName, count := cc.server.unregisterConn(cc)
+	cc.server.releaseUserConnection(cc)
 	return closeConn(cc, resourceGroupName, count)
 }
 
@@ -562,6 +570,12 @@ func (cc *clientConn) readOptionalSSLRequestAndHandshakeResponse(ctx context.Con
 		if err != nil {
 			return err
 		}
+		cc.proxyTLVs = getProxyTLVs(cc.bufReadConn.Conn)
+	}
+	if !cc.isUnixSocket {
+		if err = cc.server.checkIPAccess(cc.peerHost, proxytlv.AWSVPCEndpointID(cc.proxyTLVs)); err != nil {
+			return err
+		}
 	}
 
 	if resp.Capability&mysql.ClientSSL > 0 {
@@ -765,6 +779,7 @@ func (cc *clientConn) openSession() error {
 		return err
 	}
 	cc.SetCtx(ctx)
+	cc.syncCompression()
 
 	err = cc.server.checkConnectionCount()
 	if err != nil {
@@ -797,7 +812,7 @@ func (cc *clientConn) openSessionAndDoAuth(authData []byte, authPlugin string, z
 	}
 
 	userIdentity := &auth.UserIdentity{Username: cc.user, Hostname: host, AuthPlugin: authPlugin}
-	if err = cc.ctx.Auth(userIdentity, authData, cc.salt, cc); err != nil {
+	if err = cc.authUser(userIdentity, authData, cc.salt, cc); err != nil {
 		return err
 	}
 	cc.ctx.SetPort(port)
@@ -904,6 +919,23 @@ func (cc *clientConn) checkAuthPlugin(ctx context.Context, resp *handshake.Respo
 	return nil, nil
 }
 
+// getProxyTLVs returns the TLVs captured under the PROXY protocol connection.
+func getProxyTLVs(conn net.Conn) []proxytlv.TLV {
+	// Using reflect to get Raw Conn object from proxy protocol wrapper connection object
+	ppv := reflect.ValueOf(conn)
+	if ppv.Kind() != reflect.Pointer || ppv.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	vconn := ppv.Elem().FieldByName("Conn")
+	if !vconn.IsValid() || !vconn.CanInterface() {
+		return nil
+	}
+	if tc, ok := vconn.Interface().(*proxytlv.Conn); ok {
+		return tc.TLVs()
+	}
+	return nil
+}
+
 func (cc *clientConn) PeerHost(hasPassword string, update bool) (host, port string, err error) {
 	// already get peer host
 	if len(cc.peerHost) > 0 {
@@ -1318,6 +1350,13 @@ func (cc *clientConn) dispatch(ctx context.Context, data []byte) error {
 		cc.ctx.SetCommandValue(cmd)
 	}
 
+	if isUserQuestion(cmd) {
+		if err := cc.checkUserQuestions(); err != nil {
+			return err
+		}
+	}
+	cc.materializeLazyCursor(ctx, cmd, data)
+
 	dataStr := string(hack.String(data))
 	switch cmd {
 	case mysql.ComPing, mysql.ComStmtClose, mysql.ComStmtSendLongData, mysql.ComStmtReset,
@@ -1781,9 +1820,20 @@ func (cc *clientConn) handleQuery(ctx context.Context, sql string) (err error) {
 			}
 
 			if retryable && action == sessiontxn.StmtActionRetryReady {
-				cc.ctx.GetSessionVars().RetryInfo.Retrying = true
-				_, err = cc.handleStmt(ctx, stmt, parserWarns, i == len(stmts)-1)
-				cc.ctx.GetSessionVars().RetryInfo.Retrying = false
+				// The statement may be replayed several times, see tidb_rc_write_conflict_replay_limit.
+				for retryable && action == sessiontxn.StmtActionRetryReady {
+					cc.ctx.GetSessionVars().RetryInfo.Retrying = true
+					retryable, err = cc.handleStmt(ctx, stmt, parserWarns, i == len(stmts)-1)
+					cc.ctx.GetSessionVars().RetryInfo.Retrying = false
+					if err == nil {
+						break
+					}
+					action, txnErr = sessiontxn.GetTxnManager(&cc.ctx).OnStmtErrorForNextAction(ctx, sessiontxn.StmtErrAfterQuery, err)
+					if txnErr != nil {
+						err = txnErr
+						break
+					}
+				}
 				if err != nil {
 					break
 				}
@@ -2547,6 +2597,7 @@ func (cc *clientConn) handleResetConnection(ctx context.Context) error {
 		return err
 	}
 	cc.SetCtx(tidbCtx)
+	cc.syncCompression()
 	if !cc.ctx.AuthWithoutVerification(user) {
 		return errors.New("Could not reset connection")
 	}
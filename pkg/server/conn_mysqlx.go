@@ -0,0 +1,585 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"net"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	servererr "github.com/pingcap/tidb/pkg/server/err"
+	"github.com/pingcap/tidb/pkg/server/internal/column"
+	"github.com/pingcap/tidb/pkg/server/internal/mysqlx"
+	"github.com/pingcap/tidb/pkg/server/internal/resultset"
+	util2 "github.com/pingcap/tidb/pkg/server/internal/util"
+	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
+	"github.com/pingcap/tidb/pkg/util/fastrand"
+	"github.com/pingcap/tidb/pkg/util/hack"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	tlsutil "github.com/pingcap/tidb/pkg/util/tls"
+	"github.com/tikv/client-go/v2/util"
+	"go.uber.org/zap"
+)
+
+const (
+	// mysqlxMaxMessageSize is the largest client message accepted, the same as
+	// the default mysqlx_max_allowed_packet of MySQL.
+	mysqlxMaxMessageSize = 64 << 20
+	// mysqlxCapability is the client capability of the sessions opened by X Protocol
+	// connections. It only affects the session behaviors depending on the capability.
+	mysqlxCapability = mysql.ClientProtocol41 | mysql.ClientTransactions | mysql.ClientSecureConnection
+	// mysqlxAuthMysql41 is the only supported authentication mechanism. It is the
+	// mysql_native_password challenge-response used over unencrypted connections.
+	mysqlxAuthMysql41 = "MYSQL41"
+)
+
+// mysqlxClientCapabilities are the capabilities a client is allowed to set. They
+// are accepted but have no effect.
+var mysqlxClientCapabilities = map[string]struct{}{
+	"client.interactive":    {},
+	"client.pwd_expire_ok":  {},
+	"session_connect_attrs": {},
+}
+
+// mysqlxConn is a client connection speaking the X Protocol. Only the subset
+// required by the connectors to run SQL is supported: capability negotiation,
+// MYSQL41 authentication, SQL execution and result streaming. TLS, compression,
+// CRUD messages and prepared statements are not supported.
+//
+// The session is held by a clientConn which is registered in Server.clients once
+// the client is authenticated, so the connection is shown in the processlist,
+// can be killed and is counted by max_connections like a MySQL protocol one.
+type mysqlxConn struct {
+	cc        *clientConn
+	bufWriter *bufio.Writer
+	salt      []byte
+}
+
+func newMySQLXConn(s *Server, conn net.Conn) *mysqlxConn {
+	cc := newClientConn(s)
+	cc.setConn(conn)
+	return &mysqlxConn{
+		cc:        cc,
+		bufWriter: bufio.NewWriterSize(cc.bufReadConn, util2.DefaultReaderSize),
+		salt:      fastrand.Buf(20),
+	}
+}
+
+func (s *Server) onMySQLXConn(conn net.Conn) {
+	xc := newMySQLXConn(s, conn)
+	cc := xc.cc
+	defer func() {
+		// The connection is already closed by the server if the status is shutdown.
+		if cc.getStatus() != connStatusShutdown {
+			terror.Log(xc.Close())
+		}
+		close(cc.quit)
+	}()
+	ctx := logutil.WithConnID(context.Background(), cc.connectionID)
+	host, port, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		logutil.Logger(ctx).Error("get peer host failed", zap.Error(err))
+		return
+	}
+	cc.peerHost, cc.peerPort = host, port
+	if err := xc.Run(ctx); err != nil {
+		logutil.Logger(ctx).Info("X Protocol connection closed with error", zap.Error(err))
+	}
+}
+
+// Run reads and dispatches messages until the client closes the connection.
+func (xc *mysqlxConn) Run(ctx context.Context) error {
+	cc := xc.cc
+	for {
+		// See clientConn.Run, the status is changed by the server to kill the connection.
+		if !cc.CompareAndSwapStatus(connStatusDispatching, connStatusReading) {
+			return nil
+		}
+		// Clear the deadline set by killing a query.
+		if err := cc.bufReadConn.SetReadDeadline(time.Time{}); err != nil {
+			return errors.Trace(err)
+		}
+		tp, payload, err := mysqlx.ReadMessage(cc.bufReadConn, mysqlxMaxMessageSize)
+		if err != nil {
+			if errors.Cause(err) == io.EOF {
+				return nil
+			}
+			if err == mysqlx.ErrMessageTooLarge {
+				return xc.writeError(servererr.ErrNetPacketTooLarge, true)
+			}
+			if netErr, ok := errors.Cause(err).(net.Error); ok && netErr.Timeout() && cc.getStatus() == connStatusWaitShutdown {
+				logutil.Logger(ctx).Info("read X Protocol message timeout because of killed connection")
+				return nil
+			}
+			return err
+		}
+		if !cc.CompareAndSwapStatus(connStatusReading, connStatusDispatching) {
+			return nil
+		}
+		quit, err := xc.dispatch(ctx, tp, payload)
+		if err != nil {
+			return err
+		}
+		if err = xc.bufWriter.Flush(); err != nil || quit {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// dispatch handles a client message. The returned error is only set when the
+// connection can't be used anymore, other errors are reported to the client.
+func (xc *mysqlxConn) dispatch(ctx context.Context, tp byte, payload []byte) (quit bool, err error) {
+	switch tp {
+	case mysqlx.ClientConCapabilitiesGet:
+		return false, xc.writeMessage(mysqlx.ServerConCapabilities, mysqlx.EncodeCapabilities(xc.capabilities()))
+	case mysqlx.ClientConCapabilitiesSet:
+		if err := xc.handleCapabilitiesSet(payload); err != nil {
+			return false, xc.writeError(err, false)
+		}
+		return false, xc.writeMessage(mysqlx.ServerOk, nil)
+	case mysqlx.ClientConClose:
+		return true, xc.writeMessage(mysqlx.ServerOk, mysqlx.EncodeOk("bye!"))
+	}
+
+	if xc.cc.getCtx() == nil {
+		if tp != mysqlx.ClientSessAuthenticateStart {
+			return true, xc.writeError(servererr.ErrUnknownCom, true)
+		}
+		if err := xc.handleAuthenticate(ctx, payload); err != nil {
+			logutil.Logger(ctx).Info("X Protocol authentication failed", zap.Error(err))
+			return false, xc.writeError(err, false)
+		}
+		// The connection is closed if the server is shutting down.
+		return !xc.cc.server.registerConn(xc.cc), nil
+	}
+
+	switch tp {
+	case mysqlx.ClientSQLStmtExecute:
+		if err := xc.handleStmtExecute(ctx, payload); err != nil {
+			return false, xc.writeError(err, false)
+		}
+		return false, nil
+	case mysqlx.ClientSessReset, mysqlx.ClientSessClose:
+		// The client has to authenticate again before running statements.
+		xc.closeSession()
+		return false, xc.writeMessage(mysqlx.ServerOk, nil)
+	case mysqlx.ClientExpectOpen, mysqlx.ClientExpectClose:
+		// Expectation blocks are accepted but not enforced.
+		return false, xc.writeMessage(mysqlx.ServerOk, nil)
+	}
+	return false, xc.writeError(servererr.ErrUnknownCom, false)
+}
+
+func (*mysqlxConn) capabilities() []mysqlx.Capability {
+	return []mysqlx.Capability{
+		{Name: "authentication.mechanisms", Value: []any{mysqlxAuthMysql41}},
+		{Name: "doc.formats", Value: "text"},
+		{Name: "node_type", Value: "mysql"},
+		{Name: "client.interactive", Value: false},
+		{Name: "client.pwd_expire_ok", Value: false},
+	}
+}
+
+func (*mysqlxConn) handleCapabilitiesSet(payload []byte) error {
+	caps, err := mysqlx.DecodeCapabilitiesSet(payload)
+	if err != nil {
+		return err
+	}
+	for _, c := range caps {
+		if _, ok := mysqlxClientCapabilities[c.Name]; !ok {
+			return servererr.ErrNotSupportedYet.GenWithStackByArgs("X Protocol capability " + c.Name)
+		}
+	}
+	return nil
+}
+
+// handleAuthenticate runs the MYSQL41 challenge-response and opens the session.
+func (xc *mysqlxConn) handleAuthenticate(ctx context.Context, payload []byte) error {
+	start, err := mysqlx.DecodeAuthenticateStart(payload)
+	if err != nil {
+		return err
+	}
+	if start.MechName != mysqlxAuthMysql41 {
+		return servererr.ErrNotSupportedAuthMode.GenWithStackByArgs()
+	}
+	// TLS isn't supported, so no connection is secure.
+	if tlsutil.RequireSecureTransport.Load() {
+		return servererr.ErrSecureTransportRequired.FastGenByArgs()
+	}
+	if err = xc.writeMessage(mysqlx.ServerSessAuthenticateContinue, mysqlx.EncodeAuthenticateContinue(xc.salt)); err != nil {
+		return err
+	}
+	if err = xc.bufWriter.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+	tp, payload, err := mysqlx.ReadMessage(xc.cc.bufReadConn, mysqlxMaxMessageSize)
+	if err != nil {
+		return err
+	}
+	if tp != mysqlx.ClientSessAuthenticateContinue {
+		return servererr.ErrInvalidSequence.GenWithStack("unexpected X Protocol message %d during authentication", tp)
+	}
+	authData, err := mysqlx.DecodeAuthenticateContinue(payload)
+	if err != nil {
+		return err
+	}
+	// The auth data is "schema\0user\0*<hex scramble>", the scramble is empty for an empty password.
+	parts := bytes.SplitN(authData, []byte{0}, 3)
+	if len(parts) != 3 {
+		return mysql.ErrMalformPacket
+	}
+	dbname, user := string(parts[0]), string(parts[1])
+	var scramble []byte
+	if len(parts[2]) > 0 {
+		if parts[2][0] != '*' {
+			return mysql.ErrMalformPacket
+		}
+		if scramble, err = hex.DecodeString(string(parts[2][1:])); err != nil {
+			return mysql.ErrMalformPacket
+		}
+	}
+
+	cc := xc.cc
+	tc, err := cc.server.driver.OpenCtx(cc.connectionID, mysqlxCapability, mysql.DefaultCollationID, dbname, nil, nil)
+	if err != nil {
+		return err
+	}
+	cc.SetCtx(tc)
+	if err = xc.authenticate(ctx, tc, user, dbname, scramble); err != nil {
+		cc.server.releaseUserConnection(cc)
+		cc.SetCtx(nil)
+		terror.Log(tc.Close())
+		return err
+	}
+	cc.user, cc.dbname = user, dbname
+	notice := mysqlx.EncodeSessionStateChanged(mysqlx.StateClientIDAssigned, cc.connectionID)
+	if err = xc.writeMessage(mysqlx.ServerNotice, notice); err != nil {
+		return err
+	}
+	return xc.writeMessage(mysqlx.ServerSessAuthenticateOk, nil)
+}
+
+func (xc *mysqlxConn) authenticate(ctx context.Context, tc *TiDBContext, user, dbname string, scramble []byte) error {
+	cc := xc.cc
+	if err := cc.server.checkConnectionCount(); err != nil {
+		return err
+	}
+	// The X Protocol listener doesn't support the PROXY protocol, so there is no VPC endpoint ID.
+	if err := cc.server.checkIPAccess(cc.peerHost, ""); err != nil {
+		return err
+	}
+	hasPassword := "YES"
+	if len(scramble) == 0 {
+		hasPassword = "NO"
+	}
+	// MYSQL41 can only verify mysql_native_password accounts.
+	identity, err := tc.MatchIdentity(user, cc.peerHost)
+	if err != nil {
+		return servererr.ErrAccessDenied.FastGenByArgs(user, cc.peerHost, hasPassword)
+	}
+	if plugin, err := tc.AuthPluginForUser(identity); err == nil && plugin != "" && plugin != mysql.AuthNativePassword {
+		return servererr.ErrAccessDenied.FastGenByArgs(user, cc.peerHost, hasPassword)
+	}
+	userIdentity := &auth.UserIdentity{Username: user, Hostname: cc.peerHost, AuthPlugin: mysql.AuthNativePassword}
+	if err = cc.authUser(userIdentity, scramble, xc.salt, nil); err != nil {
+		return err
+	}
+	tc.SetPort(cc.peerPort)
+	if dbname != "" {
+		stmts, err := tc.Parse(ctx, "use `"+dbname+"`")
+		if err != nil {
+			return err
+		}
+		if _, err = tc.ExecuteStmt(ctx, stmts[0]); err != nil {
+			return err
+		}
+	}
+	tc.SetSessionManager(cc.server)
+	return nil
+}
+
+func (xc *mysqlxConn) handleStmtExecute(ctx context.Context, payload []byte) error {
+	msg, err := mysqlx.DecodeStmtExecute(payload)
+	if err != nil {
+		return err
+	}
+	switch {
+	case msg.Namespace == "mysqlx" && msg.Stmt == "ping":
+		return xc.writeMessage(mysqlx.ServerSQLStmtExecuteOk, nil)
+	case msg.Namespace != "sql":
+		return servererr.ErrNotSupportedYet.GenWithStackByArgs("X Protocol namespace " + msg.Namespace)
+	case len(msg.Args) > 0:
+		return servererr.ErrNotSupportedYet.GenWithStackByArgs("X Protocol statement arguments")
+	}
+	if err = xc.cc.checkUserQuestions(); err != nil {
+		return err
+	}
+
+	tc := xc.cc.getCtx()
+	stmts, err := tc.Parse(ctx, msg.Stmt)
+	if err != nil {
+		return err
+	}
+	if len(stmts) > 1 {
+		return servererr.ErrMultiStatementDisabled
+	}
+	if len(stmts) == 1 {
+		ctx = context.WithValue(ctx, execdetails.StmtExecDetailKey, &execdetails.StmtExecDetails{})
+		ctx = context.WithValue(ctx, util.ExecDetailsKey, &util.ExecDetails{})
+		ctx = context.WithValue(ctx, util.RUDetailsCtxKey, util.NewRUDetails())
+		// The statement is canceled when the query is killed, see killQuery.
+		var cancelFunc context.CancelFunc
+		ctx, cancelFunc = context.WithCancel(ctx)
+		xc.cc.mu.Lock()
+		xc.cc.mu.cancelFunc = cancelFunc
+		xc.cc.mu.Unlock()
+		defer cancelFunc()
+		rs, err := tc.ExecuteStmt(ctx, stmts[0])
+		if rs != nil {
+			defer rs.Close()
+		}
+		if err != nil {
+			if sv := tc.GetSessionVars(); sv != nil && sv.StmtCtx != nil {
+				sv.StmtCtx.DetachMemDiskTracker()
+			}
+			return err
+		}
+		if rs != nil {
+			if err = xc.writeResultSet(ctx, rs); err != nil {
+				return err
+			}
+		}
+	}
+	return xc.writeStmtExecuteOk()
+}
+
+func (xc *mysqlxConn) writeResultSet(ctx context.Context, rs resultset.ResultSet) error {
+	req := rs.NewChunk(nil)
+	gotColumnInfo := false
+	var data []byte
+	for {
+		if err := rs.Next(ctx, req); err != nil {
+			return err
+		}
+		if !gotColumnInfo {
+			// Columns are only correct after the first Next.
+			for _, col := range rs.Columns() {
+				if err := xc.writeMessage(mysqlx.ServerResultsetColumnMetaData, mysqlxColumnMetaData(col).Encode()); err != nil {
+					return err
+				}
+			}
+			gotColumnInfo = true
+		}
+		rowCount := req.NumRows()
+		if rowCount == 0 {
+			break
+		}
+		for i := 0; i < rowCount; i++ {
+			var err error
+			if data, err = dumpMySQLXRow(data[:0], rs.Columns(), req.GetRow(i)); err != nil {
+				return err
+			}
+			if err = xc.writeMessage(mysqlx.ServerResultsetRow, data); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rs.Finish(); err != nil {
+		return err
+	}
+	return xc.writeMessage(mysqlx.ServerResultsetFetchDone, nil)
+}
+
+// writeStmtExecuteOk reports the warnings and the session state of the last statement,
+// then ends the statement.
+func (xc *mysqlxConn) writeStmtExecuteOk() error {
+	tc := xc.cc.getCtx()
+	for _, w := range tc.GetWarnings() {
+		level := mysqlx.WarningLevelWarning
+		switch w.Level {
+		case stmtctx.WarnLevelNote:
+			level = mysqlx.WarningLevelNote
+		case stmtctx.WarnLevelError:
+			level = mysqlx.WarningLevelError
+		}
+		m := toSQLError(w.Err)
+		if err := xc.writeMessage(mysqlx.ServerNotice, mysqlx.EncodeWarning(level, m.Code, m.Message)); err != nil {
+			return err
+		}
+	}
+	notices := [][]byte{mysqlx.EncodeSessionStateChanged(mysqlx.StateRowsAffected, tc.AffectedRows())}
+	if id := tc.LastInsertID(); id > 0 {
+		notices = append(notices, mysqlx.EncodeSessionStateChanged(mysqlx.StateGeneratedInsertID, id))
+	}
+	if msg := tc.LastMessage(); msg != "" {
+		notices = append(notices, mysqlx.EncodeSessionStateChanged(mysqlx.StateProducedMessage, msg))
+	}
+	for _, notice := range notices {
+		if err := xc.writeMessage(mysqlx.ServerNotice, notice); err != nil {
+			return err
+		}
+	}
+	return xc.writeMessage(mysqlx.ServerSQLStmtExecuteOk, nil)
+}
+
+func (xc *mysqlxConn) writeMessage(tp byte, payload []byte) error {
+	return mysqlx.WriteMessage(xc.bufWriter, tp, payload)
+}
+
+func (xc *mysqlxConn) writeError(e error, fatal bool) error {
+	m := toSQLError(e)
+	errno.IncrementError(m.Code, "", xc.cc.peerHost)
+	return xc.writeMessage(mysqlx.ServerError, mysqlx.EncodeError(m.Code, m.State, m.Message, fatal))
+}
+
+// closeSession closes the session and unregisters the connection until the client
+// authenticates again.
+func (xc *mysqlxConn) closeSession() {
+	cc := xc.cc
+	tc := cc.getCtx()
+	if tc == nil {
+		return
+	}
+	resourceGroupName, count := cc.server.unregisterConn(cc)
+	metrics.ConnGauge.WithLabelValues(resourceGroupName).Set(float64(count))
+	cc.server.releaseUserConnection(cc)
+	cc.SetCtx(nil)
+	terror.Log(tc.Close())
+}
+
+// Close closes the session and the network connection.
+func (xc *mysqlxConn) Close() error {
+	return xc.cc.Close()
+}
+
+func toSQLError(e error) *mysql.SQLError {
+	if te, ok := errors.Cause(e).(*terror.Error); ok {
+		return terror.ToSQLError(te)
+	}
+	return mysql.NewErrf(mysql.ErrUnknown, "%s", nil, e.Error())
+}
+
+// mysqlxColumnMetaData converts the column info to the X Protocol column metadata.
+func mysqlxColumnMetaData(col *column.Info) *mysqlx.ColumnMetaData {
+	md := &mysqlx.ColumnMetaData{
+		Type:          mysqlxColumnType(col),
+		Name:          col.Name,
+		OriginalName:  col.OrgName,
+		Table:         col.Table,
+		OriginalTable: col.OrgTable,
+		Schema:        col.Schema,
+		Length:        col.ColumnLength,
+	}
+	switch md.Type {
+	case mysqlx.ColumnTypeBytes:
+		md.Collation = uint64(col.Charset)
+	case mysqlx.ColumnTypeSint, mysqlx.ColumnTypeUint:
+		if mysql.HasZerofillFlag(uint(col.Flag)) {
+			md.Flags |= 0x0001
+		}
+	}
+	if col.Type == mysql.TypeNewDecimal || col.Type == mysql.TypeFloat || col.Type == mysql.TypeDouble {
+		md.FractionalDigits = uint32(col.Decimal)
+	}
+	flag := uint(col.Flag)
+	if mysql.HasNotNullFlag(flag) {
+		md.Flags |= 0x0010
+	}
+	if mysql.HasPriKeyFlag(flag) {
+		md.Flags |= 0x0020
+	}
+	if mysql.HasUniKeyFlag(flag) {
+		md.Flags |= 0x0040
+	}
+	if mysql.HasMultipleKeyFlag(flag) {
+		md.Flags |= 0x0080
+	}
+	if mysql.HasAutoIncrementFlag(flag) {
+		md.Flags |= 0x0100
+	}
+	return md
+}
+
+// mysqlxColumnType returns the X Protocol type of the column. Values that have no
+// native representation in the supported subset are sent as their text form.
+func mysqlxColumnType(col *column.Info) uint64 {
+	switch col.Type {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeYear:
+		if mysql.HasUnsignedFlag(uint(col.Flag)) {
+			return mysqlx.ColumnTypeUint
+		}
+		return mysqlx.ColumnTypeSint
+	case mysql.TypeFloat:
+		return mysqlx.ColumnTypeFloat
+	case mysql.TypeDouble:
+		return mysqlx.ColumnTypeDouble
+	}
+	return mysqlx.ColumnTypeBytes
+}
+
+// dumpMySQLXRow appends the Mysqlx.Resultset.Row message of the row to buffer.
+func dumpMySQLXRow(buffer []byte, columns []*column.Info, row chunk.Row) ([]byte, error) {
+	for i, col := range columns {
+		if row.IsNull(i) {
+			buffer = mysqlx.AppendRowField(buffer, nil)
+			continue
+		}
+		var value []byte
+		switch mysqlxColumnType(col) {
+		case mysqlx.ColumnTypeSint:
+			value = mysqlx.EncodeSint(row.GetInt64(i))
+		case mysqlx.ColumnTypeUint:
+			value = mysqlx.EncodeUint(row.GetUint64(i))
+		case mysqlx.ColumnTypeFloat:
+			value = mysqlx.EncodeFloat(row.GetFloat32(i))
+		case mysqlx.ColumnTypeDouble:
+			value = mysqlx.EncodeDouble(row.GetFloat64(i))
+		default:
+			switch col.Type {
+			case mysql.TypeNewDecimal:
+				value = mysqlx.EncodeBytes(hack.Slice(row.GetMyDecimal(i).String()))
+			case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBit,
+				mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeTiDBVectorFloat32:
+				value = mysqlx.EncodeBytes(row.GetBytes(i))
+			case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+				value = mysqlx.EncodeBytes(hack.Slice(row.GetTime(i).String()))
+			case mysql.TypeDuration:
+				value = mysqlx.EncodeBytes(hack.Slice(row.GetDuration(i, int(col.Decimal)).String()))
+			case mysql.TypeEnum:
+				value = mysqlx.EncodeBytes(hack.Slice(row.GetEnum(i).String()))
+			case mysql.TypeSet:
+				value = mysqlx.EncodeBytes(hack.Slice(row.GetSet(i).String()))
+			case mysql.TypeJSON:
+				value = mysqlx.EncodeBytes(hack.Slice(row.GetJSON(i).String()))
+			default:
+				return nil, servererr.ErrInvalidType.GenWithStack("invalid type %v", col.Type)
+			}
+		}
+		buffer = mysqlx.AppendRowField(buffer, value)
+	}
+	return buffer, nil
+}
@@ -57,6 +57,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
 	storeerr "github.com/pingcap/tidb/pkg/store/driver/error"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/logutil"
@@ -177,6 +178,13 @@ func (cc *clientConn) handleStmtExecute(ctx context.Context, data []byte) (err e
 		paramTypes  []byte
 		paramValues []byte
 	)
+	if err = stmt.GetLongDataError(); err != nil {
+		// the arguments sent through `SEND_LONG_DATA` are dropped, the same as MySQL.
+		if errReset := stmt.Reset(); errReset != nil {
+			logutil.Logger(ctx).Warn("fail to reset statement in EXECUTE command", zap.Error(errReset))
+		}
+		return errors.Annotate(err, cc.preparedStmt2String(stmtID))
+	}
 	cc.initInputEncoder(ctx)
 	numParams := stmt.NumParams()
 	args := make([]param.BinaryParam, numParams)
@@ -238,9 +246,19 @@ func (cc *clientConn) executePlanCacheStmt(ctx context.Context, stmt any, args [
 		}
 
 		if retryable && action == sessiontxn.StmtActionRetryReady {
-			cc.ctx.GetSessionVars().RetryInfo.Retrying = true
-			_, err = cc.executePreparedStmtAndWriteResult(ctx, stmt.(PreparedStatement), args, useCursor)
-			cc.ctx.GetSessionVars().RetryInfo.Retrying = false
+			// The statement may be replayed several times, see tidb_rc_write_conflict_replay_limit.
+			for retryable && action == sessiontxn.StmtActionRetryReady {
+				cc.ctx.GetSessionVars().RetryInfo.Retrying = true
+				retryable, err = cc.executePreparedStmtAndWriteResult(ctx, stmt.(PreparedStatement), args, useCursor)
+				cc.ctx.GetSessionVars().RetryInfo.Retrying = false
+				if err == nil {
+					return nil
+				}
+				action, txnErr = sessiontxn.GetTxnManager(&cc.ctx).OnStmtErrorForNextAction(ctx, sessiontxn.StmtErrAfterQuery, err)
+				if txnErr != nil {
+					return txnErr
+				}
+			}
 			return err
 		}
 	}
@@ -303,8 +321,14 @@ func (cc *clientConn) executePreparedStmtAndWriteResult(ctx context.Context, stm
 	}
 	execStmt.SetText(charset.EncodingUTF8Impl, sql)
 	rs, err := (&cc.ctx).ExecuteStmt(ctx, execStmt)
+	// the result set of a lazy cursor is closed by its reader.
+	keepResultSet := false
 	if rs != nil {
-		defer rs.Close()
+		defer func() {
+			if !keepResultSet {
+				rs.Close()
+			}
+		}()
 	}
 	if err != nil {
 		// If error is returned during the planner phase or the executor.Open
@@ -325,6 +349,12 @@ func (cc *clientConn) executePreparedStmtAndWriteResult(ctx context.Context, stm
 	if planCacheStmt, ok := prepStmt.(*plannercore.PlanCacheStmt); ok {
 		rs.SetPreparedStmt(planCacheStmt)
 	}
+	// The result columns may change after the statement is re-prepared on a schema change, notify the client to
+	// refresh the metadata it cached like MySQL does.
+	serverStatus := cc.ctx.Status()
+	if stmt.SwapColumns(rs.Columns()) {
+		serverStatus |= mysql.ServerStatusMetadataChanged
+	}
 
 	// if the client wants to use cursor
 	// we should hold the ResultSet in PreparedStatement for next stmt_fetch, and only send back ColumnInfo.
@@ -334,58 +364,53 @@ func (cc *clientConn) executePreparedStmtAndWriteResult(ctx context.Context, stm
 
 		cc.initResultEncoder(ctx)
 		defer cc.rsEncoder.Clean()
-		// fetch all results of the resultSet, and stored them locally, so that the future `FETCH` command can read
-		// the rows directly to avoid running executor and accessing shared params/variables in the session
-		// NOTE: chunk should not be allocated from the connection allocator, which will reset after executing this command
-		// but the rows are still needed in the following FETCH command.
-
-		// create the row container to manage spill
-		// this `rowContainer` will be released when the statement (or the connection) is closed.
-		rowContainer := chunk.NewRowContainer(crs.FieldTypes(), vars.MaxChunkSize)
-		rowContainer.GetMemTracker().AttachTo(vars.MemTracker)
-		rowContainer.GetMemTracker().SetLabel(memory.LabelForCursorFetch)
-		rowContainer.GetDiskTracker().AttachTo(vars.DiskTracker)
-		rowContainer.GetDiskTracker().SetLabel(memory.LabelForCursorFetch)
-		if variable.EnableTmpStorageOnOOM.Load() {
-			failpoint.Inject("testCursorFetchSpill", func(val failpoint.Value) {
-				if val, ok := val.(bool); val && ok {
-					actionSpill := rowContainer.ActionSpillForTest()
-					defer actionSpill.WaitForTest()
-				}
-			})
-			action := memory.NewActionWithPriority(rowContainer.ActionSpill(), memory.DefCursorFetchSpillPriority)
-			vars.MemTracker.FallbackOldAndSetNewAction(action)
-		}
-		defer func() {
+		var (
+			reader       chunk.RowContainerReader
+			rowContainer *chunk.RowContainer
+		)
+		if vars.EnableLazyCursorFetch {
+			// read the rows from the executor on demand in the following `FETCH` commands. If the session executes
+			// another statement before the cursor is exhausted, the rows left are read into a row container by
+			// `materializeLazyCursor`.
+			var lazyReader *resultset.LazyRowReader
+			lazyReader, err = resultset.NewLazyRowReader(ctx, crs)
 			if err != nil {
-				rowContainer.GetMemTracker().Detach()
-				rowContainer.GetDiskTracker().Detach()
-				errCloseRowContainer := rowContainer.Close()
-				if errCloseRowContainer != nil {
-					logutil.Logger(ctx).Error("Fail to close rowContainer in error handler. May cause resource leak",
-						zap.NamedError("original-error", err), zap.NamedError("close-error", errCloseRowContainer))
-				}
+				return false, err
 			}
-		}()
+			keepResultSet = true
+			reader = lazyReader
+			cc.lazyCursorStmt = stmt
+		} else {
+			// fetch all results of the resultSet, and stored them locally, so that the future `FETCH` command can read
+			// the rows directly to avoid running executor and accessing shared params/variables in the session
+			// NOTE: chunk should not be allocated from the connection allocator, which will reset after executing this command
+			// but the rows are still needed in the following FETCH command.
+			rowContainer = cc.newCursorRowContainer(crs.FieldTypes())
+			defer func() {
+				if err != nil {
+					closeCursorRowContainer(ctx, rowContainer, err)
+				}
+			}()
 
-		for {
-			chk := crs.NewChunk(nil)
+			for {
+				chk := crs.NewChunk(nil)
 
-			if err = crs.Next(ctx, chk); err != nil {
-				return false, err
-			}
-			rowCount := chk.NumRows()
-			if rowCount == 0 {
-				break
-			}
+				if err = crs.Next(ctx, chk); err != nil {
+					return false, err
+				}
+				rowCount := chk.NumRows()
+				if rowCount == 0 {
+					break
+				}
 
-			err = rowContainer.Add(chk)
-			if err != nil {
-				return false, err
+				err = rowContainer.Add(chk)
+				if err != nil {
+					return false, err
+				}
 			}
+			reader = chunk.NewRowContainerReader(rowContainer)
 		}
 
-		reader := chunk.NewRowContainerReader(rowContainer)
 		crs.StoreRowContainerReader(reader)
 		stmt.StoreResultSet(crs)
 		stmt.StoreRowContainer(rowContainer)
@@ -395,12 +420,16 @@ func (cc *clientConn) executePreparedStmtAndWriteResult(ctx context.Context, stm
 		stmt.SetCursorActive(true)
 		defer func() {
 			if err != nil {
+				// for the lazy cursor, closing the reader also closes the resultSet.
 				reader.Close()
 
-				// the resultSet and rowContainer have been closed in former "defer" statement.
+				// the rowContainer has been closed in former "defer" statement.
 				stmt.StoreResultSet(nil)
 				stmt.StoreRowContainer(nil)
 				stmt.SetCursorActive(false)
+				if cc.lazyCursorStmt == stmt {
+					cc.lazyCursorStmt = nil
+				}
 			}
 		}()
 
@@ -409,20 +438,100 @@ func (cc *clientConn) executePreparedStmtAndWriteResult(ctx context.Context, stm
 		}
 
 		// explicitly flush columnInfo to client.
-		err = cc.writeEOF(ctx, cc.ctx.Status())
+		err = cc.writeEOF(ctx, serverStatus)
 		if err != nil {
 			return false, err
 		}
 
 		return false, cc.flush(ctx)
 	}
-	retryable, err := cc.writeResultSet(ctx, rs, true, cc.ctx.Status(), 0)
+	retryable, err := cc.writeResultSet(ctx, rs, true, serverStatus, 0)
 	if err != nil {
 		return retryable, errors.Annotate(err, cc.preparedStmt2String(uint32(stmt.ID())))
 	}
 	return false, nil
 }
 
+// newCursorRowContainer creates the row container to manage spill for the server-side cursor.
+// this `rowContainer` will be released when the statement (or the connection) is closed.
+func (cc *clientConn) newCursorRowContainer(fieldTypes []*types.FieldType) *chunk.RowContainer {
+	vars := cc.ctx.GetSessionVars()
+	rowContainer := chunk.NewRowContainer(fieldTypes, vars.MaxChunkSize)
+	rowContainer.GetMemTracker().AttachTo(vars.MemTracker)
+	rowContainer.GetMemTracker().SetLabel(memory.LabelForCursorFetch)
+	rowContainer.GetDiskTracker().AttachTo(vars.DiskTracker)
+	rowContainer.GetDiskTracker().SetLabel(memory.LabelForCursorFetch)
+	if variable.EnableTmpStorageOnOOM.Load() {
+		failpoint.Inject("testCursorFetchSpill", func(val failpoint.Value) {
+			if val, ok := val.(bool); val && ok {
+				actionSpill := rowContainer.ActionSpillForTest()
+				defer actionSpill.WaitForTest()
+			}
+		})
+		action := memory.NewActionWithPriority(rowContainer.ActionSpill(), memory.DefCursorFetchSpillPriority)
+		vars.MemTracker.FallbackOldAndSetNewAction(action)
+	}
+	return rowContainer
+}
+
+func closeCursorRowContainer(ctx context.Context, rowContainer *chunk.RowContainer, err error) {
+	rowContainer.GetMemTracker().Detach()
+	rowContainer.GetDiskTracker().Detach()
+	errCloseRowContainer := rowContainer.Close()
+	if errCloseRowContainer != nil {
+		logutil.Logger(ctx).Error("Fail to close rowContainer in error handler. May cause resource leak",
+			zap.NamedError("original-error", err), zap.NamedError("close-error", errCloseRowContainer))
+	}
+}
+
+// lazyCursorStmtNeedsSession returns whether the command may use the session, which is still used by the executor
+// of the lazy cursor.
+func lazyCursorStmtNeedsSession(cmd byte) bool {
+	switch cmd {
+	case mysql.ComStmtFetch, mysql.ComStmtClose, mysql.ComStmtReset, mysql.ComStmtSendLongData, mysql.ComPing, mysql.ComQuit:
+		return false
+	}
+	return true
+}
+
+// materializeLazyCursor reads the rows left of the lazy cursor into a row container before the session executes
+// another statement, so the following `FETCH` commands don't need to run the executor any more. If the command
+// executes the statement of the cursor again, the cursor is closed directly.
+func (cc *clientConn) materializeLazyCursor(ctx context.Context, cmd byte, data []byte) {
+	stmt := cc.lazyCursorStmt
+	if stmt == nil || !lazyCursorStmtNeedsSession(cmd) {
+		return
+	}
+	cc.lazyCursorStmt = nil
+	crs := stmt.GetResultSet()
+	if !stmt.GetCursorActive() || crs == nil {
+		return
+	}
+	reader, ok := crs.GetRowContainerReader().(*resultset.LazyRowReader)
+	if !ok || reader.IsClosed() {
+		return
+	}
+	if cmd == mysql.ComStmtExecute && len(data) >= 4 && int(binary.LittleEndian.Uint32(data[0:4])) == stmt.ID() {
+		// executing a statement closes its cursor, don't keep the arguments sent through `SEND_LONG_DATA`.
+		reader.Close()
+		stmt.StoreResultSet(nil)
+		stmt.SetCursorActive(false)
+		return
+	}
+
+	rowContainer := cc.newCursorRowContainer(crs.FieldTypes())
+	if err := reader.Materialize(ctx, rowContainer); err != nil {
+		closeCursorRowContainer(ctx, rowContainer, err)
+		// the following `FETCH` command will report the cursor is not open.
+		stmt.StoreResultSet(nil)
+		stmt.SetCursorActive(false)
+		logutil.Logger(ctx).Warn("fail to read the rows left of the lazy cursor", zap.Error(err))
+		return
+	}
+	crs.StoreRowContainerReader(chunk.NewRowContainerReader(rowContainer))
+	stmt.StoreRowContainer(rowContainer)
+}
+
 func (cc *clientConn) handleStmtFetch(ctx context.Context, data []byte) (err error) {
 	cc.ctx.GetSessionVars().StartTime = time.Now()
 	cc.ctx.GetSessionVars().ClearAlloc(nil, false)
@@ -472,6 +581,9 @@ func (cc *clientConn) handleStmtFetch(ctx context.Context, data []byte) (err err
 	}
 	cc.ctx.SetProcessInfo(sql, time.Now(), mysql.ComStmtExecute, 0)
 	rs := stmt.GetResultSet()
+	if reader, ok := rs.GetRowContainerReader().(*resultset.LazyRowReader); ok {
+		reader.SetContext(ctx)
+	}
 
 	_, err = cc.writeResultSet(ctx, rs, true, cc.ctx.Status(), int(fetchSize))
 	// if the iterator reached the end before writing result, we could say the `FETCH` command will send EOF
@@ -513,14 +625,20 @@ func (cc *clientConn) handleStmtSendLongData(data []byte) (err error) {
 
 	stmtID := int(binary.LittleEndian.Uint32(data[0:4]))
 
+	// `SEND_LONG_DATA` doesn't have a response, writing an error here would be read as the response of the next
+	// command. Like MySQL, the data of an unknown statement is ignored, and the other errors are reported by the next
+	// `EXECUTE` command.
 	stmt := cc.ctx.GetStatement(stmtID)
 	if stmt == nil {
-		return mysql.NewErr(mysql.ErrUnknownStmtHandler,
-			strconv.Itoa(stmtID), "stmt_send_longdata")
+		return nil
 	}
 
 	paramID := int(binary.LittleEndian.Uint16(data[4:6]))
-	return stmt.AppendParam(paramID, data[6:])
+	if err := stmt.AppendParam(paramID, data[6:]); err != nil {
+		logutil.BgLogger().Debug("fail to append the long data of the parameter", zap.Int("stmtID", stmtID),
+			zap.Int("paramID", paramID), zap.Error(err))
+	}
+	return nil
 }
 
 func (cc *clientConn) handleStmtReset(ctx context.Context, data []byte) (err error) {
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/server/internal"
 	"github.com/pingcap/tidb/pkg/server/internal/column"
+	"github.com/pingcap/tidb/pkg/server/internal/resultset"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/arena"
@@ -101,6 +102,53 @@ func TestCursorExistsFlag(t *testing.T) {
 	require.Error(t, c.Dispatch(ctx, appendUint32(appendUint32([]byte{mysql.ComStmtFetch}, uint32(stmt.ID())), 5)))
 }
 
+func TestMetadataChangedFlag(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	srv := CreateMockServer(t, store)
+	srv.SetDomain(dom)
+	defer srv.Close()
+
+	appendUint32 := binary.LittleEndian.AppendUint32
+	ctx := context.Background()
+	c := CreateMockConn(t, srv).(*mockConn)
+	out := new(bytes.Buffer)
+	c.pkt.ResetBufWriter(out)
+	c.capability |= mysql.ClientDeprecateEOF | mysql.ClientProtocol41
+	tk := testkit.NewTestKitWithSession(t, store, c.Context().Session)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int primary key)")
+	tk.MustExec("insert into t values (1), (2)")
+
+	getLastStatus := func() uint16 {
+		raw := out.Bytes()
+		return binary.LittleEndian.Uint16(raw[len(raw)-4 : len(raw)-2])
+	}
+	stmt, _, _, err := c.Context().Prepare("select * from t")
+	require.NoError(t, err)
+	execute := func(cursorType byte) uint16 {
+		out.Reset()
+		require.NoError(t, c.Dispatch(ctx, append(
+			appendUint32([]byte{mysql.ComStmtExecute}, uint32(stmt.ID())),
+			cursorType, 0x1, 0x0, 0x0, 0x0,
+		)))
+		return getLastStatus()
+	}
+
+	// the result columns are the same as the ones returned by PREPARE.
+	require.Zero(t, execute(0x0)&mysql.ServerStatusMetadataChanged)
+
+	// the statement returns a new column after the schema changes.
+	tk.MustExec("alter table t add column b int")
+	require.NotZero(t, execute(0x0)&mysql.ServerStatusMetadataChanged)
+	require.Zero(t, execute(0x0)&mysql.ServerStatusMetadataChanged)
+
+	// the type of a column changes.
+	tk.MustExec("alter table t modify column b bigint")
+	require.NotZero(t, execute(mysql.CursorTypeReadOnly)&mysql.ServerStatusMetadataChanged)
+	require.NoError(t, c.Dispatch(ctx, appendUint32([]byte{mysql.ComStmtReset}, uint32(stmt.ID()))))
+	require.Zero(t, execute(mysql.CursorTypeReadOnly)&mysql.ServerStatusMetadataChanged)
+}
+
 func TestCursorWithParams(t *testing.T) {
 	store, dom := testkit.CreateMockStoreAndDomain(t)
 	srv := CreateMockServer(t, store)
@@ -471,3 +519,109 @@ func TestCursorFetchSendLongDataReset(t *testing.T) {
 	require.NoError(t, c.flush(context.Background()))
 	require.Equal(t, expected, out.Bytes())
 }
+
+func TestLazyCursorFetch(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	srv := CreateMockServer(t, store)
+	srv.SetDomain(dom)
+	defer srv.Close()
+
+	appendUint32 := binary.LittleEndian.AppendUint32
+	ctx := context.Background()
+	c := CreateMockConn(t, srv).(*mockConn)
+	c.capability = mysql.ClientProtocol41
+
+	tk := testkit.NewTestKitWithSession(t, store, c.Context().Session)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id BIGINT primary key)")
+	tk.MustExec("insert into t values (1), (2), (3), (4), (5)")
+	tk.MustExec("set @@tidb_enable_lazy_cursor_fetch = 1")
+
+	stmt, _, _, err := c.Context().Prepare("select id from t order by id")
+	require.NoError(t, err)
+	execute := func() {
+		require.NoError(t, c.Dispatch(ctx, append(
+			appendUint32([]byte{mysql.ComStmtExecute}, uint32(stmt.ID())),
+			mysql.CursorTypeReadOnly, 0x1, 0x0, 0x0, 0x0,
+		)))
+	}
+	fetchOne := func(i int64) {
+		out := c.GetOutput()
+		expected := expectedLonglongFetchResult(t, c, i)
+		require.NoError(t, c.Dispatch(ctx, appendUint32(appendUint32([]byte{mysql.ComStmtFetch}, uint32(stmt.ID())), 1)))
+		require.NoError(t, c.flush(context.Background()))
+		require.Equal(t, expected, out.Bytes())
+	}
+
+	// the rows are read on demand
+	execute()
+	require.IsType(t, &resultset.LazyRowReader{}, stmt.GetResultSet().GetRowContainerReader())
+	require.Nil(t, stmt.GetRowContainer())
+	fetchOne(1)
+	fetchOne(2)
+
+	// executing another statement reads the rows left into the row container
+	require.NoError(t, c.Dispatch(ctx, append([]byte{mysql.ComQuery}, "select 1"...)))
+	require.NotNil(t, stmt.GetRowContainer())
+	fetchOne(3)
+	fetchOne(4)
+
+	// re-executing the statement closes the lazy cursor
+	execute()
+	reader := stmt.GetResultSet().GetRowContainerReader().(*resultset.LazyRowReader)
+	fetchOne(1)
+	execute()
+	require.True(t, reader.IsClosed())
+	fetchOne(1)
+
+	// closing the statement closes the lazy cursor
+	reader = stmt.GetResultSet().GetRowContainerReader().(*resultset.LazyRowReader)
+	require.NoError(t, c.Dispatch(ctx, appendUint32([]byte{mysql.ComStmtClose}, uint32(stmt.ID()))))
+	require.True(t, reader.IsClosed())
+	require.NoError(t, c.Dispatch(ctx, append([]byte{mysql.ComQuery}, "select 1"...)))
+}
+
+func TestSendLongDataError(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	srv := CreateMockServer(t, store)
+	srv.SetDomain(dom)
+	defer srv.Close()
+	testkit.NewTestKit(t, store).MustExec("set global max_allowed_packet = 1024")
+
+	appendUint32 := binary.LittleEndian.AppendUint32
+	ctx := context.Background()
+	c := CreateMockConn(t, srv).(*mockConn)
+	c.capability = mysql.ClientProtocol41
+
+	stmt, _, _, err := c.Context().Prepare("select length(?)")
+	require.NoError(t, err)
+	execute := func() error {
+		return c.Dispatch(ctx, append(
+			appendUint32([]byte{mysql.ComStmtExecute}, uint32(stmt.ID())),
+			0x0, 0x1, 0x0, 0x0, 0x0,
+			0x0, 0x1, 0xfc, 0x0,
+		))
+	}
+
+	// `SEND_LONG_DATA` never writes a response
+	out := c.GetOutput()
+	require.NoError(t, dispatchSendLongData(c, stmt.ID()+100, 0, []byte("a")))
+	require.NoError(t, dispatchSendLongData(c, stmt.ID(), 1, []byte("a")))
+	require.NoError(t, c.flush(context.Background()))
+	require.Empty(t, out.Bytes())
+
+	// the error is reported by the next execution
+	err = execute()
+	require.ErrorContains(t, err, "Incorrect arguments to stmt_send_longdata")
+	require.NoError(t, stmt.GetLongDataError())
+
+	// the parameter longer than max_allowed_packet
+	require.NoError(t, dispatchSendLongData(c, stmt.ID(), 0, bytes.Repeat([]byte("a"), 1000)))
+	require.NoError(t, dispatchSendLongData(c, stmt.ID(), 0, bytes.Repeat([]byte("a"), 1000)))
+	require.Nil(t, stmt.BoundParams()[0])
+	err = execute()
+	require.ErrorContains(t, err, "longer than 'max_allowed_packet' bytes")
+
+	require.NoError(t, dispatchSendLongData(c, stmt.ID(), 0, bytes.Repeat([]byte("a"), 1000)))
+	require.NoError(t, execute())
+}
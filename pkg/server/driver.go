@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/extension"
+	"github.com/pingcap/tidb/pkg/server/internal/column"
 	"github.com/pingcap/tidb/pkg/server/internal/resultset"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 )
@@ -41,6 +42,9 @@ type PreparedStatement interface {
 	// AppendParam appends parameter to the statement.
 	AppendParam(paramID int, data []byte) error
 
+	// GetLongDataError returns the error of appending parameters, which should be reported by the next execution.
+	GetLongDataError() error
+
 	// NumParams returns number of parameters.
 	NumParams() int
 
@@ -53,6 +57,10 @@ type PreparedStatement interface {
 	// GetParamsType returns the type for parameters.
 	GetParamsType() []byte
 
+	// SwapColumns stores the result columns of an execution, and returns whether they differ from the ones described
+	// to the client last time, e.g. after the statement is re-prepared on a schema change.
+	SwapColumns(columns []*column.Info) bool
+
 	// StoreResultSet stores ResultSet for subsequent stmt fetching
 	StoreResultSet(rs resultset.CursorResultSet)
 
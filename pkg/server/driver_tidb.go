@@ -72,10 +72,16 @@ type TiDBStatement struct {
 	// not moved out to reuse the logic inside functions `writeResultSet...`
 	// TODO: move the `fetchedRows` into the statement, and remove the `ResultSet` from statement.
 	rs resultset.CursorResultSet
-	// the `rowContainer` should contain all pre-fetched results of the statement in `EXECUTE` command.
+	// the `rowContainer` should contain all pre-fetched results of the statement in `EXECUTE` command. It's nil for
+	// a lazy cursor until the rows left are read into it.
 	// it's stored here to be closed in RESET and CLOSE command
 	rowContainer *chunk.RowContainer
 	sql          string
+	// longDataErr is the first error of the `SEND_LONG_DATA` commands, it's reported by the next `EXECUTE` command
+	// because `SEND_LONG_DATA` doesn't have a response.
+	longDataErr error
+	// columns are the result columns described to the client by the last PREPARE or EXECUTE.
+	columns []*column.Info
 
 	hasActiveCursor bool
 }
@@ -100,8 +106,19 @@ func (ts *TiDBStatement) Execute(ctx context.Context, args []expression.Expressi
 
 // AppendParam implements PreparedStatement AppendParam method.
 func (ts *TiDBStatement) AppendParam(paramID int, data []byte) error {
+	if ts.longDataErr != nil {
+		return ts.longDataErr
+	}
 	if paramID >= len(ts.boundParams) {
-		return mysql.NewErr(mysql.ErrWrongArguments, "stmt_send_longdata")
+		ts.longDataErr = mysql.NewErr(mysql.ErrWrongArguments, "stmt_send_longdata")
+		return ts.longDataErr
+	}
+	if maxAllowedPacket := ts.ctx.GetSessionVars().MaxAllowedPacket; maxAllowedPacket > 0 &&
+		uint64(len(ts.boundParams[paramID])+len(data)) > maxAllowedPacket {
+		// release the data received, the statement can't be executed with it anyway.
+		ts.boundParams[paramID] = nil
+		ts.longDataErr = mysql.NewErrf(mysql.ErrUnknown, "Parameter of prepared statement which is set through mysql_send_long_data() is longer than 'max_allowed_packet' bytes", nil)
+		return ts.longDataErr
 	}
 	// If len(data) is 0, append an empty byte slice to the end to distinguish no data and no parameter.
 	if len(data) == 0 {
@@ -112,6 +129,11 @@ func (ts *TiDBStatement) AppendParam(paramID int, data []byte) error {
 	return nil
 }
 
+// GetLongDataError implements PreparedStatement GetLongDataError method.
+func (ts *TiDBStatement) GetLongDataError() error {
+	return ts.longDataErr
+}
+
 // NumParams implements PreparedStatement NumParams method.
 func (ts *TiDBStatement) NumParams() int {
 	return ts.numParams
@@ -132,6 +154,18 @@ func (ts *TiDBStatement) GetParamsType() []byte {
 	return ts.paramsType
 }
 
+// SwapColumns implements PreparedStatement SwapColumns method.
+func (ts *TiDBStatement) SwapColumns(columns []*column.Info) bool {
+	changed := len(columns) != len(ts.columns)
+	for i := 0; !changed && i < len(columns); i++ {
+		old, cur := ts.columns[i], columns[i]
+		changed = old.Name != cur.Name || old.Type != cur.Type || old.Flag != cur.Flag ||
+			old.ColumnLength != cur.ColumnLength || old.Decimal != cur.Decimal || old.Charset != cur.Charset
+	}
+	ts.columns = columns
+	return changed
+}
+
 // StoreResultSet stores ResultSet for stmt fetching
 func (ts *TiDBStatement) StoreResultSet(rs resultset.CursorResultSet) {
 	// the original reset set should have been closed, and it's only used to store the iterator through the rowContainer
@@ -149,6 +183,7 @@ func (ts *TiDBStatement) Reset() error {
 	for i := range ts.boundParams {
 		ts.boundParams[i] = nil
 	}
+	ts.longDataErr = nil
 	ts.hasActiveCursor = false
 
 	if ts.rs != nil && ts.rs.GetRowContainerReader() != nil {
@@ -288,9 +323,12 @@ func (tc *TiDBContext) ExecuteStmt(ctx context.Context, stmt ast.StmtNode) (resu
 	if err = tc.checkSandBoxMode(stmt); err != nil {
 		return nil, err
 	}
-	if s, ok := stmt.(*ast.NonTransactionalDMLStmt); ok {
+	switch s := stmt.(type) {
+	case *ast.NonTransactionalDMLStmt:
 		rs, err = session.HandleNonTransactionalDML(ctx, s, tc.Session)
-	} else {
+	case *ast.CallStmt:
+		rs, err = session.HandleCallProcedure(ctx, s, tc.Session)
+	default:
 		rs, err = tc.Session.ExecuteStmt(ctx, stmt)
 	}
 	if err != nil {
@@ -354,6 +392,7 @@ func (tc *TiDBContext) Prepare(sql string) (statement PreparedStatement, columns
 	for i := range fields {
 		columns[i] = column.ConvertColumnInfo(fields[i])
 	}
+	stmt.columns = columns
 	params = make([]*column.Info, paramCount)
 	for i := range params {
 		params[i] = &column.Info{
@@ -44,4 +44,14 @@ var (
 	ErrNetPacketTooLarge = dbterror.ClassServer.NewStd(errno.ErrNetPacketTooLarge)
 	// ErrMustChangePassword is returned when the user must change the password.
 	ErrMustChangePassword = dbterror.ClassServer.NewStd(errno.ErrMustChangePassword)
+	// ErrUnknownCom is returned when the client sends a command or message the server does not handle.
+	ErrUnknownCom = dbterror.ClassServer.NewStd(errno.ErrUnknownCom)
+	// ErrNotSupportedYet is returned when the client uses a protocol feature that is not supported.
+	ErrNotSupportedYet = dbterror.ClassServer.NewStd(errno.ErrNotSupportedYet)
+	// ErrTooManyUserConnections is returned when the account has reached MAX_USER_CONNECTIONS.
+	ErrTooManyUserConnections = dbterror.ClassServer.NewStd(errno.ErrTooManyUserConnections)
+	// ErrUserLimitReached is returned when the account has exceeded an hourly resource limit.
+	ErrUserLimitReached = dbterror.ClassServer.NewStd(errno.ErrUserLimitReached)
+	// ErrHostNotPrivileged is returned when the client address is rejected by the IP access list.
+	ErrHostNotPrivileged = dbterror.ClassServer.NewStd(errno.ErrHostNotPrivileged)
 )
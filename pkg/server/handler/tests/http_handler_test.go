@@ -50,6 +50,7 @@ import (
 	"github.com/pingcap/tidb/pkg/server/handler"
 	"github.com/pingcap/tidb/pkg/server/handler/optimizor"
 	"github.com/pingcap/tidb/pkg/server/handler/tikvhandler"
+	"github.com/pingcap/tidb/pkg/server/handler/topsqlhandler"
 	"github.com/pingcap/tidb/pkg/server/internal/testserverclient"
 	"github.com/pingcap/tidb/pkg/server/internal/testutil"
 	"github.com/pingcap/tidb/pkg/server/internal/util"
@@ -840,6 +841,27 @@ func TestGetSettings(t *testing.T) {
 	require.Equal(t, configBytes, settingBytes)
 }
 
+func TestTableIndexProcessTime(t *testing.T) {
+	ts := createBasicHTTPHandlerTestSuite()
+	ts.startServer(t)
+	defer ts.stopServer(t)
+
+	resp, err := ts.FetchStatus("/topsql/table-index-process-time?window=1h&limit=5")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var records []topsqlhandler.TableIndexProcessTime
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&records))
+	require.NoError(t, resp.Body.Close())
+	require.LessOrEqual(t, len(records), 5)
+
+	for _, query := range []string{"window=abc", "window=-1m", "limit=abc", "limit=0"} {
+		resp, err = ts.FetchStatus("/topsql/table-index-process-time?" + query)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+		require.NoError(t, resp.Body.Close())
+	}
+}
+
 func TestGetSchema(t *testing.T) {
 	ts := createBasicHTTPHandlerTestSuite()
 	ts.startServer(t)
@@ -967,6 +989,137 @@ func TestGetSchema(t *testing.T) {
 	require.Equal(t, ti, ti)
 }
 
+func TestGetSchemaTablesWithOptions(t *testing.T) {
+	ts := createBasicHTTPHandlerTestSuite()
+	ts.startServer(t)
+	defer ts.stopServer(t)
+
+	db, err := sql.Open("mysql", ts.GetDSN())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	dbt := testkit.NewDBTestKit(t, db)
+	dbt.MustExec("create database test_options")
+	for i := 0; i < 5; i++ {
+		dbt.MustExec(fmt.Sprintf("create table test_options.t%d (a int)", i))
+	}
+	dbt.MustExec("create table test_options.x (a int)")
+
+	fetchTables := func(url string) []map[string]json.RawMessage {
+		resp, err := ts.FetchStatus(url)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, resp.Body.Close())
+		}()
+		require.Equal(t, http.StatusOK, resp.StatusCode, url)
+		var tbls []map[string]json.RawMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&tbls))
+		return tbls
+	}
+	names := func(tbls []map[string]json.RawMessage) []string {
+		res := make([]string, 0, len(tbls))
+		for _, tbl := range tbls {
+			var name model.CIStr
+			require.NoError(t, json.Unmarshal(tbl["name"], &name))
+			res = append(res, name.L)
+		}
+		return res
+	}
+
+	tbls := fetchTables("/schema/test_options")
+	require.Len(t, tbls, 6)
+	require.Contains(t, tbls[0], "cols")
+
+	tbls = fetchTables("/schema/test_options?table_name_pattern=T%25&offset=1&limit=3&fields=id,name")
+	require.Equal(t, []string{"t1", "t2", "t3"}, names(tbls))
+	for _, tbl := range tbls {
+		require.Len(t, tbl, 2)
+	}
+	require.Empty(t, fetchTables("/schema/test_options?offset=10"))
+
+	var ti *model.TableInfo
+	resp, err := ts.FetchStatus("/schema/test_options/t4")
+	require.NoError(t, err)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&ti))
+	require.NoError(t, resp.Body.Close())
+	dbt.MustExec("alter table test_options.t1 add column b int")
+	tbls = fetchTables(fmt.Sprintf("/schema/test_options?modified_since=%d", ti.UpdateTS))
+	require.ElementsMatch(t, []string{"t1", "x"}, names(tbls))
+
+	for _, url := range []string{
+		"/schema/test_options?offset=-1",
+		"/schema/test_options?limit=abc",
+		"/schema/test_options?modified_since=abc",
+	} {
+		resp, err := ts.FetchStatus(url)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, url)
+		require.NoError(t, resp.Body.Close())
+	}
+}
+
+func TestGetHistorySchemaByTableID(t *testing.T) {
+	ts := createBasicHTTPHandlerTestSuite()
+	ts.startServer(t)
+	defer ts.stopServer(t)
+
+	db, err := sql.Open("mysql", ts.GetDSN())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	dbt := testkit.NewDBTestKit(t, db)
+	dbt.MustExec("create database if not exists test")
+	dbt.MustExec("create table test.t_history (a int)")
+
+	fetchDBTable := func(url string) *tikvhandler.DBTableInfo {
+		resp, err := ts.FetchStatus(url)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, resp.Body.Close())
+		}()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var dbtbl *tikvhandler.DBTableInfo
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&dbtbl))
+		return dbtbl
+	}
+
+	resp, err := ts.FetchStatus("/schema/test/t_history")
+	require.NoError(t, err)
+	var ti *model.TableInfo
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&ti))
+	require.NoError(t, resp.Body.Close())
+	before := fetchDBTable(fmt.Sprintf("/db-table/%d", ti.ID))
+	require.Len(t, before.TableInfo.Columns, 1)
+	ver, err := ts.store.CurrentVersion(kv.GlobalTxnScope)
+	require.NoError(t, err)
+
+	dbt.MustExec("alter table test.t_history add column b varchar(10) charset latin1")
+	latest := fetchDBTable(fmt.Sprintf("/db-table/%d", ti.ID))
+	require.Len(t, latest.TableInfo.Columns, 2)
+	require.Equal(t, "latin1", latest.TableInfo.Columns[1].GetCharset())
+	require.Greater(t, latest.SchemaVersion, before.SchemaVersion)
+
+	byVersion := fetchDBTable(fmt.Sprintf("/db-table/%d?schema_version=%d", ti.ID, before.SchemaVersion))
+	require.Equal(t, before.SchemaVersion, byVersion.SchemaVersion)
+	require.Len(t, byVersion.TableInfo.Columns, 1)
+	byTS := fetchDBTable(fmt.Sprintf("/db-table/%d?snapshot_ts=%d", ti.ID, ver.Ver))
+	require.Len(t, byTS.TableInfo.Columns, 1)
+	require.Equal(t, "test", byTS.DBInfo.Name.L)
+
+	for _, url := range []string{
+		fmt.Sprintf("/db-table/%d?schema_version=%d&snapshot_ts=%d", ti.ID, before.SchemaVersion, ver.Ver),
+		fmt.Sprintf("/db-table/%d?schema_version=abc", ti.ID),
+		fmt.Sprintf("/db-table/%d?schema_version=%d", ti.ID, latest.SchemaVersion+1000),
+	} {
+		resp, err := ts.FetchStatus(url)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, url)
+		require.NoError(t, resp.Body.Close())
+	}
+}
+
 func TestAllHistory(t *testing.T) {
 	ts := createBasicHTTPHandlerTestSuite()
 	ts.startServer(t)
@@ -16,6 +16,7 @@ package tikvhandler
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/base64"
 	"encoding/hex"
@@ -25,6 +26,7 @@ import (
 	"net/http"
 	"net/url"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -62,6 +64,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/hack"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/pingcap/tidb/pkg/util/stringutil"
 	"github.com/tikv/client-go/v2/tikv"
 	pd "github.com/tikv/pd/client/http"
 	"go.uber.org/zap"
@@ -923,6 +926,33 @@ func (h SchemaStorageHandler) ServeHTTP(w http.ResponseWriter, req *http.Request
 // Then we add some bytes to make it become [{tb1}, {tb2}, {tb3}], so we can unmarshal it to []*model.TableInfo.
 // Note: It would return StatusOK even if errors occur. But if errors occur, there must be some bugs.
 func WriteDBTablesData(w http.ResponseWriter, tbs []table.Table) {
+	writeDBTablesData(w, tbs, nil)
+}
+
+// projectTableInfo marshals the table info with only the specified JSON fields,
+// all the fields are marshaled if fields is empty.
+func projectTableInfo(tblInfo *model.TableInfo, fields []string) ([]byte, error) {
+	if len(fields) == 0 {
+		return json.MarshalIndent(tblInfo, "", " ")
+	}
+	js, err := json.Marshal(tblInfo)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err = json.Unmarshal(js, &all); err != nil {
+		return nil, err
+	}
+	projected := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if v, ok := all[field]; ok {
+			projected[field] = v
+		}
+	}
+	return json.MarshalIndent(projected, "", " ")
+}
+
+func writeDBTablesData(w http.ResponseWriter, tbs []table.Table, fields []string) {
 	if len(tbs) == 0 {
 		handler.WriteData(w, []*model.TableInfo{})
 		return
@@ -946,7 +976,7 @@ func WriteDBTablesData(w http.ResponseWriter, tbs []table.Table) {
 		} else {
 			init = true
 		}
-		js, err := json.MarshalIndent(tb.Meta(), "", " ")
+		js, err := projectTableInfo(tb.Meta(), fields)
 		if err != nil {
 			terror.Log(errors.Trace(err))
 			return
@@ -961,6 +991,89 @@ func WriteDBTablesData(w http.ResponseWriter, tbs []table.Table) {
 	terror.Log(errors.Trace(err))
 }
 
+// schemaTablesOptions is the options to list the table schemas in a database,
+// dumping all the table schemas of a large database may be too expensive.
+type schemaTablesOptions struct {
+	// namePatWeights and namePatTypes are the compiled LIKE pattern of the table names.
+	namePatWeights []rune
+	namePatTypes   []byte
+	// modifiedSince filters the tables whose update ts is greater than it.
+	modifiedSince uint64
+	// offset and limit paginate the tables ordered by the table IDs.
+	offset   int
+	limit    int
+	paginate bool
+	// fields is the JSON fields of the table info to output.
+	fields []string
+}
+
+func parseSchemaTablesOptions(req *http.Request) (*schemaTablesOptions, error) {
+	opts := &schemaTablesOptions{}
+	if pattern := req.FormValue(handler.TableNamePatternQuery); len(pattern) > 0 {
+		opts.namePatWeights, opts.namePatTypes = stringutil.CompilePattern(strings.ToLower(pattern), '\\')
+	}
+	if since := req.FormValue(handler.ModifiedSinceQuery); len(since) > 0 {
+		ts, err := strconv.ParseUint(since, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("Wrong %s: %v", handler.ModifiedSinceQuery, since)
+		}
+		opts.modifiedSince = ts
+	}
+	if offset := req.FormValue(handler.Offset); len(offset) > 0 {
+		v, err := strconv.Atoi(offset)
+		if err != nil || v < 0 {
+			return nil, errors.Errorf("Wrong %s: %v", handler.Offset, offset)
+		}
+		opts.offset = v
+		opts.paginate = true
+	}
+	opts.limit = -1
+	if limit := req.FormValue(handler.Limit); len(limit) > 0 {
+		v, err := strconv.Atoi(limit)
+		if err != nil || v < 0 {
+			return nil, errors.Errorf("Wrong %s: %v", handler.Limit, limit)
+		}
+		opts.limit = v
+		opts.paginate = true
+	}
+	if fields := req.FormValue(handler.FieldsQuery); len(fields) > 0 {
+		for _, field := range strings.Split(fields, ",") {
+			if field = strings.TrimSpace(field); len(field) > 0 {
+				opts.fields = append(opts.fields, field)
+			}
+		}
+	}
+	return opts, nil
+}
+
+func (opts *schemaTablesOptions) filter(tbs []table.Table) []table.Table {
+	filtered := make([]table.Table, 0, len(tbs))
+	for _, tb := range tbs {
+		tblInfo := tb.Meta()
+		if opts.namePatTypes != nil && !stringutil.DoMatch(tblInfo.Name.L, opts.namePatWeights, opts.namePatTypes) {
+			continue
+		}
+		if opts.modifiedSince > 0 && tblInfo.UpdateTS <= opts.modifiedSince {
+			continue
+		}
+		filtered = append(filtered, tb)
+	}
+	if !opts.paginate {
+		return filtered
+	}
+	slices.SortFunc(filtered, func(a, b table.Table) int {
+		return cmp.Compare(a.Meta().ID, b.Meta().ID)
+	})
+	if opts.offset >= len(filtered) {
+		return nil
+	}
+	filtered = filtered[opts.offset:]
+	if opts.limit >= 0 && opts.limit < len(filtered) {
+		filtered = filtered[:opts.limit]
+	}
+	return filtered
+}
+
 // ServeHTTP handles request of list a database or table's schemas.
 func (h SchemaHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	schema, err := h.Schema()
@@ -987,8 +1100,13 @@ func (h SchemaHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		}
 		// all table schemas in a specified database
 		if schema.SchemaExists(cDBName) {
-			tbs := schema.SchemaTables(cDBName)
-			WriteDBTablesData(w, tbs)
+			opts, err := parseSchemaTablesOptions(req)
+			if err != nil {
+				handler.WriteError(w, err)
+				return
+			}
+			tbs := opts.filter(schema.SchemaTables(cDBName))
+			writeDBTablesData(w, tbs, opts.fields)
 			return
 		}
 		handler.WriteError(w, infoschema.ErrDatabaseNotExists.GenWithStackByArgs(dbName))
@@ -1782,7 +1900,38 @@ type DBTableInfo struct {
 	SchemaVersion int64            `json:"schema_version"`
 }
 
+// snapshotSchema returns the infoschema at the schema version or the snapshot ts specified
+// by the query string, the latest infoschema is returned if neither of them is specified.
+func (h DBTableHandler) snapshotSchema(req *http.Request) (infoschema.InfoSchema, error) {
+	schemaVersion := req.FormValue(handler.SchemaVersionQuery)
+	snapshotTS := req.FormValue(handler.SnapshotTSQuery)
+	if len(schemaVersion) == 0 && len(snapshotTS) == 0 {
+		return h.Schema()
+	}
+	if len(schemaVersion) > 0 && len(snapshotTS) > 0 {
+		return nil, errors.Errorf("only one of %s and %s can be specified", handler.SchemaVersionQuery, handler.SnapshotTSQuery)
+	}
+	dom, err := session.GetDomain(h.Store)
+	if err != nil {
+		return nil, err
+	}
+	if len(schemaVersion) > 0 {
+		version, err := strconv.ParseInt(schemaVersion, 10, 64)
+		if err != nil || version <= 0 {
+			return nil, errors.Errorf("Wrong schema version: %v", schemaVersion)
+		}
+		return dom.GetInfoSchemaByVersion(version)
+	}
+	ts, err := strconv.ParseUint(snapshotTS, 10, 64)
+	if err != nil {
+		return nil, errors.Errorf("Wrong snapshot ts: %v", snapshotTS)
+	}
+	return dom.GetSnapshotInfoSchema(ts)
+}
+
 // ServeHTTP handles request of database information and table information by tableID.
+// The schema version or the snapshot ts can be specified to get the history schema,
+// so the consumers of the change logs can decode the old events.
 func (h DBTableHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	params := mux.Vars(req)
 	tableID := params[handler.TableID]
@@ -1792,7 +1941,7 @@ func (h DBTableHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	schema, err := h.Schema()
+	schema, err := h.snapshotSchema(req)
 	if err != nil {
 		handler.WriteError(w, err)
 		return
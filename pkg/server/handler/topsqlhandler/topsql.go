@@ -0,0 +1,121 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topsqlhandler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/server/handler"
+	"github.com/pingcap/tidb/pkg/util/topsql"
+)
+
+const (
+	// windowParam is the query parameter of the time window, such as "10m".
+	windowParam   = "window"
+	defaultWindow = 10 * time.Minute
+	defaultLimit  = 20
+)
+
+// TableIndexProcessTime is the KV process time of a table or index with resolved names.
+type TableIndexProcessTime struct {
+	topsql.TableIndexProcessTime
+	DBName        string `json:"db_name"`
+	TableName     string `json:"table_name"`
+	PartitionName string `json:"partition_name,omitempty"`
+	IndexName     string `json:"index_name,omitempty"`
+}
+
+// TableIndexProcessTimeHandler is the handler for pulling the hottest tables and indexes by KV process time.
+type TableIndexProcessTimeHandler struct {
+	*handler.TikvHandlerTool
+}
+
+// NewTableIndexProcessTimeHandler creates a new TableIndexProcessTimeHandler.
+func NewTableIndexProcessTimeHandler(tool *handler.TikvHandlerTool) *TableIndexProcessTimeHandler {
+	return &TableIndexProcessTimeHandler{tool}
+}
+
+// ServeHTTP handles request of getting the KV process time by table and index.
+func (h TableIndexProcessTimeHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	window := defaultWindow
+	if value := req.FormValue(windowParam); len(value) > 0 {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			handler.WriteError(w, err)
+			return
+		}
+		if d <= 0 {
+			handler.WriteError(w, errors.New("window must be greater than 0"))
+			return
+		}
+		window = d
+	}
+	limit := defaultLimit
+	if value := req.FormValue(handler.Limit); len(value) > 0 {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			handler.WriteError(w, err)
+			return
+		}
+		if n < 1 {
+			handler.WriteError(w, errors.New("limit must be greater than 0"))
+			return
+		}
+		limit = n
+	}
+
+	schema, err := h.Schema()
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	records := topsql.TopTableIndexProcessTime(window, limit)
+	result := make([]TableIndexProcessTime, 0, len(records))
+	for _, r := range records {
+		result = append(result, resolveTableIndexProcessTime(schema, r))
+	}
+	handler.WriteData(w, result)
+}
+
+// resolveTableIndexProcessTime fills the names of the physical table and index. The
+// names are left empty if the object has been dropped.
+func resolveTableIndexProcessTime(is infoschema.InfoSchema, r topsql.TableIndexProcessTime) TableIndexProcessTime {
+	result := TableIndexProcessTime{TableIndexProcessTime: r}
+	tbl, partDef := infoschema.FindTableByTblOrPartID(is, r.TableID)
+	if tbl == nil {
+		return result
+	}
+	tblInfo := tbl.Meta()
+	result.TableName = tblInfo.Name.O
+	if partDef != nil {
+		result.PartitionName = partDef.Name.O
+	}
+	if dbInfo, ok := infoschema.SchemaByTable(is, tblInfo); ok {
+		result.DBName = dbInfo.Name.O
+	}
+	if r.IndexID != 0 {
+		for _, idx := range tblInfo.Indices {
+			if idx.ID == r.IndexID {
+				result.IndexName = idx.Name.O
+				break
+			}
+		}
+	}
+	return result
+}
@@ -57,11 +57,17 @@ const (
 
 // For query string
 const (
-	TableIDQuery = "table_id"
-	Limit        = "limit"
-	JobID        = "start_job_id"
-	Operation    = "op"
-	Seconds      = "seconds"
+	TableIDQuery          = "table_id"
+	Limit                 = "limit"
+	JobID                 = "start_job_id"
+	Operation             = "op"
+	Seconds               = "seconds"
+	SchemaVersionQuery    = "schema_version"
+	SnapshotTSQuery       = "snapshot_ts"
+	Offset                = "offset"
+	FieldsQuery           = "fields"
+	ModifiedSinceQuery    = "modified_since"
+	TableNamePatternQuery = "table_name_pattern"
 )
 
 const (
@@ -47,6 +47,7 @@ import (
 	"github.com/pingcap/tidb/pkg/server/handler"
 	"github.com/pingcap/tidb/pkg/server/handler/optimizor"
 	"github.com/pingcap/tidb/pkg/server/handler/tikvhandler"
+	"github.com/pingcap/tidb/pkg/server/handler/topsqlhandler"
 	"github.com/pingcap/tidb/pkg/server/handler/ttlhandler"
 	util2 "github.com/pingcap/tidb/pkg/server/internal/util"
 	"github.com/pingcap/tidb/pkg/session"
@@ -252,6 +253,9 @@ func (s *Server) startHTTPServer() {
 	// HTTP path for get table tiflash replica info.
 	router.Handle("/tiflash/replica-deprecated", tikvhandler.NewFlashReplicaHandler(tikvHandlerTool))
 
+	// HTTP path for get the hottest tables and indexes by KV process time.
+	router.Handle("/topsql/table-index-process-time", topsqlhandler.NewTableIndexProcessTimeHandler(tikvHandlerTool)).Name("TopSQL_TableIndexProcessTime")
+
 	// HTTP path for upgrade operations.
 	router.Handle("/upgrade/{op}", handler.NewClusterUpgradeHandler(tikvHandlerTool.Store.(kv.Storage))).Name("upgrade operations")
 
@@ -111,6 +111,9 @@ func dumpType(tp byte) byte {
 	switch tp {
 	case mysql.TypeSet, mysql.TypeEnum:
 		return mysql.TypeString
+	case mysql.TypeTiDBVectorFloat32:
+		// The clients don't know the VECTOR type, the values are sent in the text format.
+		return mysql.TypeVarString
 	default:
 		return tp
 	}
@@ -164,7 +167,7 @@ func DumpTextRow(buffer []byte, columns []*Info, row chunk.Row, d *ResultEncoder
 		case mysql.TypeNewDecimal:
 			buffer = dump.LengthEncodedString(buffer, hack.Slice(row.GetMyDecimal(i).String()))
 		case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBit,
-			mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+			mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeTiDBVectorFloat32:
 			d.UpdateDataEncoding(col.Charset)
 			buffer = dump.LengthEncodedString(buffer, d.EncodeData(row.GetBytes(i)))
 		case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
@@ -224,7 +227,7 @@ func DumpBinaryRow(buffer []byte, columns []*Info, row chunk.Row, d *ResultEncod
 		case mysql.TypeNewDecimal:
 			buffer = dump.LengthEncodedString(buffer, hack.Slice(row.GetMyDecimal(i).String()))
 		case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBit,
-			mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+			mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeTiDBVectorFloat32:
 			d.UpdateDataEncoding(columns[i].Charset)
 			buffer = dump.LengthEncodedString(buffer, d.EncodeData(row.GetBytes(i)))
 		case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
@@ -0,0 +1,578 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mysqlx implements the wire format of the subset of the MySQL X Protocol
+// supported by TiDB: capability negotiation, authentication, SQL execution and
+// result streaming.
+//
+// Every message is framed as a 4-byte little-endian length, a 1-byte message type
+// and a protobuf encoded payload. The payloads used here are small enough that
+// they are encoded by hand instead of depending on the generated protobuf code.
+package mysqlx
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/pingcap/errors"
+)
+
+// Client message types.
+const (
+	ClientConCapabilitiesGet       byte = 1
+	ClientConCapabilitiesSet       byte = 2
+	ClientConClose                 byte = 3
+	ClientSessAuthenticateStart    byte = 4
+	ClientSessAuthenticateContinue byte = 5
+	ClientSessReset                byte = 6
+	ClientSessClose                byte = 7
+	ClientSQLStmtExecute           byte = 12
+	ClientExpectOpen               byte = 24
+	ClientExpectClose              byte = 25
+)
+
+// Server message types.
+const (
+	ServerOk                       byte = 0
+	ServerError                    byte = 1
+	ServerConCapabilities          byte = 2
+	ServerSessAuthenticateContinue byte = 3
+	ServerSessAuthenticateOk       byte = 4
+	ServerNotice                   byte = 11
+	ServerResultsetColumnMetaData  byte = 12
+	ServerResultsetRow             byte = 13
+	ServerResultsetFetchDone       byte = 14
+	ServerSQLStmtExecuteOk         byte = 17
+)
+
+// Column types used in ColumnMetaData.
+const (
+	ColumnTypeSint   uint64 = 1
+	ColumnTypeUint   uint64 = 2
+	ColumnTypeDouble uint64 = 5
+	ColumnTypeFloat  uint64 = 6
+	ColumnTypeBytes  uint64 = 7
+)
+
+// Notice types and the parameters of the SessionStateChanged notice.
+const (
+	NoticeWarning             uint64 = 1
+	NoticeSessionStateChanged uint64 = 3
+
+	StateCurrentSchema     uint64 = 1
+	StateGeneratedInsertID uint64 = 3
+	StateRowsAffected      uint64 = 4
+	StateProducedMessage   uint64 = 10
+	StateClientIDAssigned  uint64 = 11
+)
+
+// Warning levels used in the Warning notice.
+const (
+	WarningLevelNote    uint64 = 1
+	WarningLevelWarning uint64 = 2
+	WarningLevelError   uint64 = 3
+)
+
+const (
+	noticeScopeLocal uint64 = 2
+
+	severityError uint64 = 0
+	severityFatal uint64 = 1
+
+	anyTypeScalar uint64 = 1
+	anyTypeArray  uint64 = 3
+
+	scalarTypeSint   uint64 = 1
+	scalarTypeUint   uint64 = 2
+	scalarTypeNull   uint64 = 3
+	scalarTypeOctets uint64 = 4
+	scalarTypeDouble uint64 = 5
+	scalarTypeFloat  uint64 = 6
+	scalarTypeBool   uint64 = 7
+	scalarTypeString uint64 = 8
+
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// ErrMessageTooLarge is returned when a client message exceeds the size limit.
+var ErrMessageTooLarge = errors.New("X Protocol message is too large")
+
+// ReadMessage reads a message and returns its type and payload.
+func ReadMessage(r io.Reader, maxSize uint32) (byte, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:4]); err != nil {
+		return 0, nil, err
+	}
+	size := binary.LittleEndian.Uint32(header[:4])
+	if size == 0 {
+		return 0, nil, errors.New("invalid X Protocol message size")
+	}
+	if size > maxSize {
+		return 0, nil, ErrMessageTooLarge
+	}
+	if _, err := io.ReadFull(r, header[4:]); err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	payload := make([]byte, size-1)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	return header[4], payload, nil
+}
+
+// WriteMessage writes a message with the given type and payload.
+func WriteMessage(w io.Writer, tp byte, payload []byte) error {
+	data := make([]byte, 5, 5+len(payload))
+	binary.LittleEndian.PutUint32(data, uint32(len(payload)+1))
+	data[4] = tp
+	data = append(data, payload...)
+	_, err := w.Write(data)
+	return errors.Trace(err)
+}
+
+// Capability is a capability reported to or requested by the client. Value is
+// one of nil, bool, int64, uint64, float64, string, []byte or []any.
+type Capability struct {
+	Name  string
+	Value any
+}
+
+// EncodeCapabilities encodes a Mysqlx.Connection.Capabilities message.
+func EncodeCapabilities(caps []Capability) []byte {
+	var b []byte
+	for _, c := range caps {
+		var capability []byte
+		capability = appendStringField(capability, 1, c.Name)
+		capability = appendBytesField(capability, 2, appendAny(nil, c.Value))
+		b = appendBytesField(b, 1, capability)
+	}
+	return b
+}
+
+// DecodeCapabilitiesSet decodes a Mysqlx.Connection.CapabilitiesSet message.
+func DecodeCapabilitiesSet(payload []byte) ([]Capability, error) {
+	var caps []Capability
+	err := walkFields(payload, func(num int, _ uint64, capabilities []byte) error {
+		if num != 1 {
+			return nil
+		}
+		return walkFields(capabilities, func(num int, _ uint64, capability []byte) error {
+			if num != 1 {
+				return nil
+			}
+			var c Capability
+			err := walkFields(capability, func(num int, _ uint64, data []byte) (err error) {
+				switch num {
+				case 1:
+					c.Name = string(data)
+				case 2:
+					c.Value, err = decodeAny(data)
+				}
+				return err
+			})
+			caps = append(caps, c)
+			return err
+		})
+	})
+	return caps, err
+}
+
+// AuthenticateStart is the Mysqlx.Session.AuthenticateStart message.
+type AuthenticateStart struct {
+	MechName        string
+	AuthData        []byte
+	InitialResponse []byte
+}
+
+// DecodeAuthenticateStart decodes a Mysqlx.Session.AuthenticateStart message.
+func DecodeAuthenticateStart(payload []byte) (*AuthenticateStart, error) {
+	msg := &AuthenticateStart{}
+	err := walkFields(payload, func(num int, _ uint64, data []byte) error {
+		switch num {
+		case 1:
+			msg.MechName = string(data)
+		case 2:
+			msg.AuthData = data
+		case 3:
+			msg.InitialResponse = data
+		}
+		return nil
+	})
+	return msg, err
+}
+
+// DecodeAuthenticateContinue decodes a Mysqlx.Session.AuthenticateContinue message
+// and returns its auth data.
+func DecodeAuthenticateContinue(payload []byte) ([]byte, error) {
+	var authData []byte
+	err := walkFields(payload, func(num int, _ uint64, data []byte) error {
+		if num == 1 {
+			authData = data
+		}
+		return nil
+	})
+	return authData, err
+}
+
+// EncodeAuthenticateContinue encodes a Mysqlx.Session.AuthenticateContinue message.
+func EncodeAuthenticateContinue(authData []byte) []byte {
+	return appendBytesField(nil, 1, authData)
+}
+
+// StmtExecute is the Mysqlx.Sql.StmtExecute message.
+type StmtExecute struct {
+	Namespace string
+	Stmt      string
+	Args      []any
+}
+
+// DecodeStmtExecute decodes a Mysqlx.Sql.StmtExecute message.
+func DecodeStmtExecute(payload []byte) (*StmtExecute, error) {
+	msg := &StmtExecute{Namespace: "sql"}
+	err := walkFields(payload, func(num int, _ uint64, data []byte) error {
+		switch num {
+		case 1:
+			msg.Stmt = string(data)
+		case 2:
+			arg, err := decodeAny(data)
+			if err != nil {
+				return err
+			}
+			msg.Args = append(msg.Args, arg)
+		case 3:
+			msg.Namespace = string(data)
+		}
+		return nil
+	})
+	return msg, err
+}
+
+// EncodeOk encodes a Mysqlx.Ok message.
+func EncodeOk(msg string) []byte {
+	if msg == "" {
+		return nil
+	}
+	return appendStringField(nil, 1, msg)
+}
+
+// EncodeError encodes a Mysqlx.Error message.
+func EncodeError(code uint16, sqlState, msg string, fatal bool) []byte {
+	var b []byte
+	if fatal {
+		b = appendVarintField(b, 1, severityFatal)
+	} else {
+		b = appendVarintField(b, 1, severityError)
+	}
+	b = appendVarintField(b, 2, uint64(code))
+	b = appendStringField(b, 3, msg)
+	b = appendStringField(b, 4, sqlState)
+	return b
+}
+
+// ColumnMetaData is the Mysqlx.Resultset.ColumnMetaData message.
+type ColumnMetaData struct {
+	Type             uint64
+	Name             string
+	OriginalName     string
+	Table            string
+	OriginalTable    string
+	Schema           string
+	Collation        uint64
+	FractionalDigits uint32
+	Length           uint32
+	Flags            uint32
+}
+
+// Encode encodes the message.
+func (c *ColumnMetaData) Encode() []byte {
+	var b []byte
+	b = appendVarintField(b, 1, c.Type)
+	b = appendStringField(b, 2, c.Name)
+	b = appendStringField(b, 3, c.OriginalName)
+	b = appendStringField(b, 4, c.Table)
+	b = appendStringField(b, 5, c.OriginalTable)
+	b = appendStringField(b, 6, c.Schema)
+	b = appendStringField(b, 7, "def")
+	if c.Collation != 0 {
+		b = appendVarintField(b, 8, c.Collation)
+	}
+	b = appendVarintField(b, 9, uint64(c.FractionalDigits))
+	b = appendVarintField(b, 10, uint64(c.Length))
+	b = appendVarintField(b, 11, uint64(c.Flags))
+	return b
+}
+
+// AppendRowField appends a field of a Mysqlx.Resultset.Row message to b. A nil
+// value stands for NULL, other values must be encoded by the Encode* functions.
+func AppendRowField(b []byte, value []byte) []byte {
+	return appendBytesField(b, 1, value)
+}
+
+// EncodeSint encodes a value of a ColumnTypeSint column.
+func EncodeSint(v int64) []byte {
+	return binary.AppendUvarint(nil, zigzag(v))
+}
+
+// EncodeUint encodes a value of a ColumnTypeUint column.
+func EncodeUint(v uint64) []byte {
+	return binary.AppendUvarint(nil, v)
+}
+
+// EncodeDouble encodes a value of a ColumnTypeDouble column.
+func EncodeDouble(v float64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, math.Float64bits(v))
+}
+
+// EncodeFloat encodes a value of a ColumnTypeFloat column.
+func EncodeFloat(v float32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, math.Float32bits(v))
+}
+
+// EncodeBytes encodes a value of a ColumnTypeBytes column. The trailing zero
+// distinguishes an empty value from NULL.
+func EncodeBytes(v []byte) []byte {
+	b := make([]byte, 0, len(v)+1)
+	b = append(b, v...)
+	return append(b, 0)
+}
+
+// EncodeSessionStateChanged encodes a Mysqlx.Notice.Frame carrying a SessionStateChanged notice.
+func EncodeSessionStateChanged(param uint64, value any) []byte {
+	var changed []byte
+	changed = appendVarintField(changed, 1, param)
+	changed = appendBytesField(changed, 2, appendScalar(nil, value))
+	return encodeNoticeFrame(NoticeSessionStateChanged, changed)
+}
+
+// EncodeWarning encodes a Mysqlx.Notice.Frame carrying a Warning notice.
+func EncodeWarning(level uint64, code uint16, msg string) []byte {
+	var warning []byte
+	warning = appendVarintField(warning, 1, level)
+	warning = appendVarintField(warning, 2, uint64(code))
+	warning = appendStringField(warning, 3, msg)
+	return encodeNoticeFrame(NoticeWarning, warning)
+}
+
+func encodeNoticeFrame(tp uint64, payload []byte) []byte {
+	var b []byte
+	b = appendVarintField(b, 1, tp)
+	b = appendVarintField(b, 2, noticeScopeLocal)
+	return appendBytesField(b, 3, payload)
+}
+
+func appendAny(b []byte, v any) []byte {
+	if arr, ok := v.([]any); ok {
+		var array []byte
+		for _, elem := range arr {
+			array = appendBytesField(array, 1, appendAny(nil, elem))
+		}
+		b = appendVarintField(b, 1, anyTypeArray)
+		return appendBytesField(b, 4, array)
+	}
+	b = appendVarintField(b, 1, anyTypeScalar)
+	return appendBytesField(b, 2, appendScalar(nil, v))
+}
+
+func appendScalar(b []byte, v any) []byte {
+	switch x := v.(type) {
+	case int64:
+		b = appendVarintField(b, 1, scalarTypeSint)
+		b = appendVarintField(b, 2, zigzag(x))
+	case uint64:
+		b = appendVarintField(b, 1, scalarTypeUint)
+		b = appendVarintField(b, 3, x)
+	case []byte:
+		b = appendVarintField(b, 1, scalarTypeOctets)
+		b = appendBytesField(b, 5, appendBytesField(nil, 1, x))
+	case float64:
+		b = appendVarintField(b, 1, scalarTypeDouble)
+		b = appendTag(b, 6, wireFixed64)
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(x))
+	case bool:
+		b = appendVarintField(b, 1, scalarTypeBool)
+		if x {
+			b = appendVarintField(b, 8, 1)
+		} else {
+			b = appendVarintField(b, 8, 0)
+		}
+	case string:
+		b = appendVarintField(b, 1, scalarTypeString)
+		b = appendBytesField(b, 9, appendStringField(nil, 1, x))
+	default:
+		b = appendVarintField(b, 1, scalarTypeNull)
+	}
+	return b
+}
+
+func decodeAny(data []byte) (any, error) {
+	var (
+		tp     uint64
+		scalar any
+		array  []any
+	)
+	err := walkFields(data, func(num int, v uint64, data []byte) (err error) {
+		switch num {
+		case 1:
+			tp = v
+		case 2:
+			scalar, err = decodeScalar(data)
+		case 4:
+			err = walkFields(data, func(num int, _ uint64, data []byte) error {
+				if num != 1 {
+					return nil
+				}
+				elem, err := decodeAny(data)
+				array = append(array, elem)
+				return err
+			})
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch tp {
+	case anyTypeScalar:
+		return scalar, nil
+	case anyTypeArray:
+		return array, nil
+	}
+	return nil, errors.Errorf("unsupported X Protocol value type %d", tp)
+}
+
+func decodeScalar(data []byte) (any, error) {
+	var (
+		tp    uint64
+		value any
+	)
+	err := walkFields(data, func(num int, v uint64, data []byte) (err error) {
+		switch num {
+		case 1:
+			tp = v
+		case 2:
+			value = int64(v>>1) ^ -int64(v&1)
+		case 3:
+			value = v
+		case 5, 9:
+			var content []byte
+			err = walkFields(data, func(num int, _ uint64, data []byte) error {
+				if num == 1 {
+					content = data
+				}
+				return nil
+			})
+			if num == 9 {
+				value = string(content)
+			} else {
+				value = content
+			}
+		case 6:
+			value = math.Float64frombits(v)
+		case 7:
+			value = float64(math.Float32frombits(uint32(v)))
+		case 8:
+			value = v != 0
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch tp {
+	case scalarTypeNull:
+		return nil, nil
+	case scalarTypeSint, scalarTypeUint, scalarTypeOctets, scalarTypeDouble,
+		scalarTypeFloat, scalarTypeBool, scalarTypeString:
+		return value, nil
+	}
+	return nil, errors.Errorf("unsupported X Protocol scalar type %d", tp)
+}
+
+// walkFields calls fn for every field of a protobuf message. Varint and fixed
+// width fields are passed in v, length delimited fields in data.
+func walkFields(b []byte, fn func(num int, v uint64, data []byte) error) error {
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errors.New("malformed X Protocol message")
+		}
+		b = b[n:]
+		var (
+			v    uint64
+			data []byte
+		)
+		switch tag & 7 {
+		case wireVarint:
+			v, n = binary.Uvarint(b)
+			if n <= 0 {
+				return errors.New("malformed X Protocol message")
+			}
+			b = b[n:]
+		case wireFixed64:
+			if len(b) < 8 {
+				return errors.New("malformed X Protocol message")
+			}
+			v = binary.LittleEndian.Uint64(b)
+			b = b[8:]
+		case wireBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < l {
+				return errors.New("malformed X Protocol message")
+			}
+			data = b[n : n+int(l)]
+			b = b[n+int(l):]
+		case wireFixed32:
+			if len(b) < 4 {
+				return errors.New("malformed X Protocol message")
+			}
+			v = uint64(binary.LittleEndian.Uint32(b))
+			b = b[4:]
+		default:
+			return errors.Errorf("unsupported protobuf wire type %d", tag&7)
+		}
+		if err := fn(int(tag>>3), v, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+func appendTag(b []byte, num int, wireType int) []byte {
+	return binary.AppendUvarint(b, uint64(num)<<3|uint64(wireType))
+}
+
+func appendVarintField(b []byte, num int, v uint64) []byte {
+	b = appendTag(b, num, wireVarint)
+	return binary.AppendUvarint(b, v)
+}
+
+func appendBytesField(b []byte, num int, v []byte) []byte {
+	b = appendTag(b, num, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendStringField(b []byte, num int, v string) []byte {
+	b = appendTag(b, num, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
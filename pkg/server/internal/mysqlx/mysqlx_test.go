@@ -0,0 +1,141 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlx
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadWriteMessage(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteMessage(&buf, ServerOk, EncodeOk("bye!")))
+	require.NoError(t, WriteMessage(&buf, ServerResultsetFetchDone, nil))
+	require.Equal(t, []byte{7, 0, 0, 0, ServerOk, 0x0a, 4, 'b', 'y', 'e', '!', 1, 0, 0, 0, ServerResultsetFetchDone}, buf.Bytes())
+
+	tp, payload, err := ReadMessage(&buf, 1024)
+	require.NoError(t, err)
+	require.Equal(t, ServerOk, tp)
+	require.Equal(t, EncodeOk("bye!"), payload)
+	tp, payload, err = ReadMessage(&buf, 1024)
+	require.NoError(t, err)
+	require.Equal(t, ServerResultsetFetchDone, tp)
+	require.Empty(t, payload)
+	_, _, err = ReadMessage(&buf, 1024)
+	require.Equal(t, io.EOF, err)
+
+	require.NoError(t, WriteMessage(&buf, ServerOk, make([]byte, 10)))
+	_, _, err = ReadMessage(&buf, 10)
+	require.Equal(t, ErrMessageTooLarge, err)
+	_, _, err = ReadMessage(bytes.NewReader([]byte{0, 0, 0, 0}), 10)
+	require.Error(t, err)
+}
+
+func TestDecodeClientMessages(t *testing.T) {
+	// AuthenticateStart{mech_name: "MYSQL41"}
+	start, err := DecodeAuthenticateStart(appendStringField(nil, 1, "MYSQL41"))
+	require.NoError(t, err)
+	require.Equal(t, "MYSQL41", start.MechName)
+	require.Empty(t, start.AuthData)
+
+	authData, err := DecodeAuthenticateContinue(EncodeAuthenticateContinue([]byte("test\x00root\x00")))
+	require.NoError(t, err)
+	require.Equal(t, []byte("test\x00root\x00"), authData)
+
+	// StmtExecute{stmt: "select ?", args: [-5, "a", null, [1.5, true]]}
+	var b []byte
+	b = appendBytesField(b, 1, []byte("select ?"))
+	b = appendBytesField(b, 2, appendAny(nil, int64(-5)))
+	b = appendBytesField(b, 2, appendAny(nil, "a"))
+	b = appendBytesField(b, 2, appendAny(nil, nil))
+	b = appendBytesField(b, 2, appendAny(nil, []any{1.5, true}))
+	stmt, err := DecodeStmtExecute(b)
+	require.NoError(t, err)
+	require.Equal(t, "sql", stmt.Namespace)
+	require.Equal(t, "select ?", stmt.Stmt)
+	require.Equal(t, []any{int64(-5), "a", nil, []any{1.5, true}}, stmt.Args)
+
+	b = appendStringField(b, 3, "mysqlx")
+	stmt, err = DecodeStmtExecute(b)
+	require.NoError(t, err)
+	require.Equal(t, "mysqlx", stmt.Namespace)
+
+	caps := []Capability{{Name: "tls", Value: true}, {Name: "session_connect_attrs", Value: uint64(1)}}
+	decoded, err := DecodeCapabilitiesSet(appendBytesField(nil, 1, EncodeCapabilities(caps)))
+	require.NoError(t, err)
+	require.Equal(t, caps, decoded)
+
+	_, err = DecodeStmtExecute([]byte{0x0a, 10, 'a'})
+	require.Error(t, err)
+	_, err = DecodeStmtExecute([]byte{0x0b})
+	require.Error(t, err)
+}
+
+func TestEncodeValues(t *testing.T) {
+	require.Equal(t, []byte{0}, EncodeSint(0))
+	require.Equal(t, []byte{1}, EncodeSint(-1))
+	require.Equal(t, []byte{2}, EncodeSint(1))
+	require.Equal(t, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 1}, EncodeSint(math.MinInt64))
+	require.Equal(t, []byte{0xac, 0x02}, EncodeUint(300))
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0xf8, 0x3f}, EncodeDouble(1.5))
+	require.Equal(t, []byte{0, 0, 0xc0, 0x3f}, EncodeFloat(1.5))
+	require.Equal(t, []byte{0}, EncodeBytes(nil))
+	require.Equal(t, []byte{'a', 0}, EncodeBytes([]byte("a")))
+
+	// NULL is an empty field, the empty string has the trailing zero.
+	row := AppendRowField(nil, nil)
+	row = AppendRowField(row, EncodeBytes(nil))
+	require.Equal(t, []byte{0x0a, 0, 0x0a, 1, 0}, row)
+}
+
+func TestEncodeServerMessages(t *testing.T) {
+	fields := func(b []byte) map[int]any {
+		m := make(map[int]any)
+		require.NoError(t, walkFields(b, func(num int, v uint64, data []byte) error {
+			if data != nil {
+				m[num] = string(data)
+			} else {
+				m[num] = v
+			}
+			return nil
+		}))
+		return m
+	}
+
+	require.Equal(t, map[int]any{1: uint64(severityFatal), 2: uint64(1045), 3: "denied", 4: "28000"},
+		fields(EncodeError(1045, "28000", "denied", true)))
+
+	md := &ColumnMetaData{Type: ColumnTypeBytes, Name: "a", OriginalName: "b", Table: "t", OriginalTable: "t1",
+		Schema: "test", Collation: 46, Length: 20, Flags: 0x10}
+	require.Equal(t, map[int]any{1: ColumnTypeBytes, 2: "a", 3: "b", 4: "t", 5: "t1", 6: "test", 7: "def",
+		8: uint64(46), 9: uint64(0), 10: uint64(20), 11: uint64(0x10)}, fields(md.Encode()))
+
+	frame := fields(EncodeSessionStateChanged(StateRowsAffected, uint64(3)))
+	require.Equal(t, NoticeSessionStateChanged, frame[1])
+	require.Equal(t, noticeScopeLocal, frame[2])
+	changed := fields([]byte(frame[3].(string)))
+	require.Equal(t, StateRowsAffected, changed[1])
+	value, err := decodeScalar([]byte(changed[2].(string)))
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), value)
+
+	frame = fields(EncodeWarning(WarningLevelNote, 1292, "truncated"))
+	require.Equal(t, NoticeWarning, frame[1])
+	require.Equal(t, map[int]any{1: WarningLevelNote, 2: uint64(1292), 3: "truncated"}, fields([]byte(frame[3].(string))))
+}
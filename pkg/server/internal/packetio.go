@@ -50,6 +50,7 @@ import (
 	"github.com/pingcap/tidb/pkg/server/internal/util"
 	server_metrics "github.com/pingcap/tidb/pkg/server/metrics"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	tidbutil "github.com/pingcap/tidb/pkg/util"
 )
 
 const defaultWriterSize = 16 * 1024
@@ -67,6 +68,7 @@ type PacketIO struct {
 	// accumulatedLength count the length of totally received 'payload' in ReadPacket.
 	accumulatedLength    uint64
 	compressionAlgorithm int
+	compressionStats     *tidbutil.CompressionStats
 	zstdLevel            zstd.EncoderLevel
 	sequence             uint8
 	compressedSequence   uint8
@@ -120,13 +122,33 @@ func (p *PacketIO) ResetBufWriter(w io.Writer) {
 // SetCompressionAlgorithm sets the compression algorithm of PacketIO.
 func (p *PacketIO) SetCompressionAlgorithm(ca int) {
 	p.compressionAlgorithm = ca
+	p.compressionStats = &tidbutil.CompressionStats{}
+	switch ca {
+	case mysql.CompressionZlib:
+		p.compressionStats.Algorithm = "zlib"
+	case mysql.CompressionZstd:
+		p.compressionStats.Algorithm = "zstd"
+	}
 	p.compressedWriter = newCompressedWriter(p.bufReadConn, ca, &p.compressedSequence)
 	p.compressedWriter.zstdLevel = p.zstdLevel
+	p.compressedWriter.stats = p.compressionStats
 	p.compressedReader = newCompressedReader(p.bufReadConn, ca, &p.compressedSequence)
 	p.compressedReader.zstdLevel = p.zstdLevel
+	p.compressedReader.stats = p.compressionStats
 	p.bufWriter.Flush()
 }
 
+// CompressionAlgorithm returns the compression algorithm of PacketIO.
+func (p *PacketIO) CompressionAlgorithm() int {
+	return p.compressionAlgorithm
+}
+
+// CompressionStats returns the bytes of the packets before and after compression, it's nil if the compression
+// is not enabled.
+func (p *PacketIO) CompressionStats() *tidbutil.CompressionStats {
+	return p.compressionStats
+}
+
 // SetBufferedReadConn sets the BufferedReadConn of PacketIO.
 func (p *PacketIO) SetBufferedReadConn(bufReadConn *util.BufferedReadConn) {
 	p.bufReadConn = bufReadConn
@@ -320,6 +342,7 @@ func newCompressedWriter(w io.Writer, ca int, seq *uint8) *compressedWriter {
 		seq,
 		ca,
 		3,
+		nil,
 	}
 }
 
@@ -329,6 +352,7 @@ type compressedWriter struct {
 	compressedSequence   *uint8
 	compressionAlgorithm int
 	zstdLevel            zstd.EncoderLevel
+	stats                *tidbutil.CompressionStats
 }
 
 func (cw *compressedWriter) Write(data []byte) (n int, err error) {
@@ -429,6 +453,10 @@ func (cw *compressedWriter) Flush() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if cw.stats != nil {
+		cw.stats.BytesSent.Add(uint64(len(data)))
+		cw.stats.CompressedBytesSent.Add(uint64(compressedPacket.Len()))
+	}
 	return nil
 }
 
@@ -440,6 +468,7 @@ func newCompressedReader(r io.Reader, ca int, seq *uint8) *compressedReader {
 		ca,
 		3,
 		0,
+		nil,
 	}
 }
 
@@ -450,6 +479,7 @@ type compressedReader struct {
 	compressionAlgorithm int
 	zstdLevel            zstd.EncoderLevel
 	pos                  uint64
+	stats                *tidbutil.CompressionStats
 }
 
 func (cr *compressedReader) Read(data []byte) (n int, err error) {
@@ -500,6 +530,10 @@ func (cr *compressedReader) Read(data []byte) (n int, err error) {
 			}
 			n = copy(data, cr.data)
 		}
+		if cr.stats != nil {
+			cr.stats.BytesReceived.Add(uint64(len(cr.data)))
+			cr.stats.CompressedBytesReceived.Add(uint64(len(compressedHeader) + compressedLength))
+		}
 	} else {
 		if cr.pos > uint64(len(cr.data)) {
 			return n, io.EOF
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/server/internal/testutil"
 	"github.com/pingcap/tidb/pkg/server/internal/util"
+	tidbutil "github.com/pingcap/tidb/pkg/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -455,3 +456,29 @@ func TestSubHeaderWithWrongSequenceNumber(t *testing.T) {
 	require.Equal(t, []byte{0x03, 0x73, 0x65, 0x6c, 0x65, 0x63,
 		0x74, 0x20, 0x31, 0x3b}, readBytes)
 }
+
+func TestCompressionStats(t *testing.T) {
+	var testdata bytes.Buffer
+	payload := bytes.Repeat([]byte("compression_stats"), 100)
+	stats := &tidbutil.CompressionStats{Algorithm: "zstd"}
+
+	seq := uint8(0)
+	cw := newCompressedWriter(&testdata, mysql.CompressionZstd, &seq)
+	cw.stats = stats
+	_, err := cw.Write(payload)
+	require.NoError(t, err)
+	require.NoError(t, cw.Flush())
+	require.Equal(t, uint64(len(payload)), stats.BytesSent.Load())
+	require.Equal(t, uint64(testdata.Len()), stats.CompressedBytesSent.Load())
+	require.Less(t, stats.CompressedBytesSent.Load(), stats.BytesSent.Load())
+
+	seq = 0
+	cr := newCompressedReader(bytes.NewReader(testdata.Bytes()), mysql.CompressionZstd, &seq)
+	cr.stats = stats
+	data := make([]byte, len(payload))
+	_, err = io.ReadFull(cr, data)
+	require.NoError(t, err)
+	require.Equal(t, payload, data)
+	require.Equal(t, stats.BytesSent.Load(), stats.BytesReceived.Load())
+	require.Equal(t, stats.CompressedBytesSent.Load(), stats.CompressedBytesReceived.Load())
+}
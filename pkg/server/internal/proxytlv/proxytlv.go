@@ -0,0 +1,171 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package proxytlv extracts the TLV (type-length-value) vectors of PROXY protocol v2
+// headers. The PROXY protocol listener only extracts the client address and skips
+// the TLVs, so the accepted connections are wrapped to observe the header bytes it reads.
+package proxytlv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+
+	"github.com/pingcap/errors"
+)
+
+// TLV types defined by the PROXY protocol specification.
+const (
+	TypeALPN      byte = 0x01
+	TypeAuthority byte = 0x02
+	TypeCRC32C    byte = 0x03
+	TypeNoop      byte = 0x04
+	TypeUniqueID  byte = 0x05
+	TypeSSL       byte = 0x20
+	TypeNetNS     byte = 0x30
+	// TypeAWS is set by AWS Network Load Balancers, the first byte of the value is the subtype.
+	TypeAWS byte = 0xEA
+)
+
+const (
+	awsSubtypeVPCEndpointID byte = 0x01
+
+	headerLen = 16
+)
+
+var v2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+// ErrInvalidHeader is returned when the PROXY protocol v2 header is malformed.
+var ErrInvalidHeader = errors.New("invalid PROXY protocol v2 header")
+
+// TLV is a type-length-value vector of the PROXY protocol v2 header.
+type TLV struct {
+	Type  byte
+	Value []byte
+}
+
+// ParseTLVs parses a complete PROXY protocol v2 header and returns its TLVs.
+func ParseTLVs(header []byte) ([]TLV, error) {
+	if len(header) < headerLen || !bytes.Equal(header[:len(v2Signature)], v2Signature) {
+		return nil, ErrInvalidHeader
+	}
+	end := headerLen + int(binary.BigEndian.Uint16(header[14:headerLen]))
+	if len(header) < end {
+		return nil, ErrInvalidHeader
+	}
+	// The address block depends on the address family in the high nibble.
+	var addrLen int
+	switch header[13] >> 4 {
+	case 0x1: // AF_INET
+		addrLen = 12
+	case 0x2: // AF_INET6
+		addrLen = 36
+	case 0x3: // AF_UNIX
+		addrLen = 216
+	}
+	pos := headerLen + addrLen
+	if pos > end {
+		return nil, ErrInvalidHeader
+	}
+	var tlvs []TLV
+	for pos < end {
+		if end-pos < 3 {
+			return nil, ErrInvalidHeader
+		}
+		tp := header[pos]
+		l := int(binary.BigEndian.Uint16(header[pos+1 : pos+3]))
+		pos += 3
+		if end-pos < l {
+			return nil, ErrInvalidHeader
+		}
+		tlvs = append(tlvs, TLV{Type: tp, Value: header[pos : pos+l]})
+		pos += l
+	}
+	return tlvs, nil
+}
+
+// AWSVPCEndpointID returns the ID of the AWS VPC endpoint the client connected
+// through, or an empty string if the TLVs don't carry it.
+func AWSVPCEndpointID(tlvs []TLV) string {
+	for _, tlv := range tlvs {
+		if tlv.Type == TypeAWS && len(tlv.Value) > 0 && tlv.Value[0] == awsSubtypeVPCEndpointID {
+			return string(tlv.Value[1:])
+		}
+	}
+	return ""
+}
+
+// Listener wraps the accepted connections with Conn. It must be the listener
+// under the PROXY protocol listener.
+type Listener struct {
+	net.Listener
+}
+
+// NewListener creates a Listener.
+func NewListener(l net.Listener) *Listener {
+	return &Listener{Listener: l}
+}
+
+// Accept implements net.Listener.
+func (l *Listener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{Conn: conn}, nil
+}
+
+// Conn records the bytes read from the connection until a PROXY protocol v2
+// header is complete, or the data is known not to start with one.
+// It is not safe for concurrent use, like the reads it observes.
+type Conn struct {
+	net.Conn
+	buf  []byte
+	done bool
+	tlvs []TLV
+}
+
+// Read implements net.Conn.
+func (c *Conn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 && !c.done {
+		c.record(b[:n])
+	}
+	return n, err
+}
+
+func (c *Conn) record(data []byte) {
+	c.buf = append(c.buf, data...)
+	l := min(len(c.buf), len(v2Signature))
+	if !bytes.Equal(c.buf[:l], v2Signature[:l]) {
+		c.done, c.buf = true, nil
+		return
+	}
+	if len(c.buf) < headerLen {
+		return
+	}
+	end := headerLen + int(binary.BigEndian.Uint16(c.buf[14:headerLen]))
+	if len(c.buf) < end {
+		return
+	}
+	// A malformed header is rejected by the PROXY protocol listener, just ignore its TLVs here.
+	c.tlvs, _ = ParseTLVs(c.buf[:end])
+	c.done, c.buf = true, nil
+}
+
+// TLVs returns the TLVs of the PROXY protocol v2 header. It returns nil before
+// the header is read or if the connection doesn't start with a v2 header.
+func (c *Conn) TLVs() []TLV {
+	return c.tlvs
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxytlv
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+
+	proxyprotocol "github.com/blacktear23/go-proxyprotocol"
+	"github.com/stretchr/testify/require"
+)
+
+func buildV2Header(family byte, addrs []byte, tlvs ...TLV) []byte {
+	var body []byte
+	body = append(body, addrs...)
+	for _, tlv := range tlvs {
+		body = append(body, tlv.Type)
+		body = binary.BigEndian.AppendUint16(body, uint16(len(tlv.Value)))
+		body = append(body, tlv.Value...)
+	}
+	header := append([]byte{}, v2Signature...)
+	header = append(header, 0x21, family)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(body)))
+	return append(header, body...)
+}
+
+func tcp4Addrs() []byte {
+	addrs := []byte{192, 168, 1, 2, 10, 0, 0, 1}
+	addrs = binary.BigEndian.AppendUint16(addrs, 60055)
+	return binary.BigEndian.AppendUint16(addrs, 4000)
+}
+
+func TestParseTLVs(t *testing.T) {
+	vpce := TLV{Type: TypeAWS, Value: append([]byte{awsSubtypeVPCEndpointID}, "vpce-08d2bf15fac5001c9"...)}
+	authority := TLV{Type: TypeAuthority, Value: []byte("tidb.example.com")}
+	tlvs, err := ParseTLVs(buildV2Header(0x11, tcp4Addrs(), authority, vpce))
+	require.NoError(t, err)
+	require.Equal(t, []TLV{authority, vpce}, tlvs)
+	require.Equal(t, "vpce-08d2bf15fac5001c9", AWSVPCEndpointID(tlvs))
+
+	tlvs, err = ParseTLVs(buildV2Header(0x21, make([]byte, 36), TLV{Type: TypeNoop}))
+	require.NoError(t, err)
+	require.Equal(t, []TLV{{Type: TypeNoop, Value: []byte{}}}, tlvs)
+	require.Empty(t, AWSVPCEndpointID(tlvs))
+
+	tlvs, err = ParseTLVs(buildV2Header(0x11, tcp4Addrs()))
+	require.NoError(t, err)
+	require.Empty(t, tlvs)
+
+	header := buildV2Header(0x11, tcp4Addrs(), vpce)
+	_, err = ParseTLVs(header[:len(header)-1])
+	require.ErrorIs(t, err, ErrInvalidHeader)
+	_, err = ParseTLVs(append(header, 0x01))
+	require.NoError(t, err)
+	// The TLV length exceeds the header.
+	header[len(header)-len(vpce.Value)-1]++
+	_, err = ParseTLVs(header)
+	require.ErrorIs(t, err, ErrInvalidHeader)
+	_, err = ParseTLVs([]byte("PROXY TCP4 192.168.1.2 10.0.0.1 60055 4000\r\n"))
+	require.ErrorIs(t, err, ErrInvalidHeader)
+}
+
+func TestListener(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ppl, err := proxyprotocol.NewLazyListener(NewListener(l), "*", 5, true)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, ppl.Close())
+	}()
+
+	vpce := TLV{Type: TypeAWS, Value: append([]byte{awsSubtypeVPCEndpointID}, "vpce-08d2bf15fac5001c9"...)}
+	// Make the header longer than the first read of the PROXY protocol listener.
+	padding := TLV{Type: TypeNoop, Value: make([]byte, 200)}
+	cases := []struct {
+		header []byte
+		tlvs   []TLV
+	}{
+		{buildV2Header(0x11, tcp4Addrs(), vpce, padding), []TLV{vpce, padding}},
+		{buildV2Header(0x11, tcp4Addrs()), nil},
+		{[]byte("PROXY TCP4 192.168.1.2 10.0.0.1 60055 4000\r\n"), nil},
+		{nil, nil},
+	}
+	for _, c := range cases {
+		client, err := net.Dial("tcp", l.Addr().String())
+		require.NoError(t, err)
+		_, err = client.Write(append(c.header, "hello"...))
+		require.NoError(t, err)
+
+		conn, err := ppl.Accept()
+		require.NoError(t, err)
+		data := make([]byte, 5)
+		_, err = io.ReadFull(conn, data)
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(data))
+		if c.header != nil {
+			require.Equal(t, "192.168.1.2:60055", conn.RemoteAddr().String())
+		}
+
+		// The PROXY protocol connection embeds the accepted connection.
+		inner := reflect.ValueOf(conn).Elem().FieldByName("Conn").Interface().(*Conn)
+		require.Equal(t, c.tlvs, inner.TLVs())
+		require.NoError(t, conn.Close())
+		require.NoError(t, client.Close())
+	}
+}
@@ -14,7 +14,11 @@
 
 package resultset
 
-import "github.com/pingcap/tidb/pkg/util/chunk"
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
 
 // CursorResultSet extends the `ResultSet` to provide the ability to store an iterator
 type CursorResultSet interface {
@@ -53,3 +57,121 @@ type FetchNotifier interface {
 	// it will be used in server-side cursor.
 	OnFetchReturned()
 }
+
+var _ chunk.RowContainerReader = &LazyRowReader{}
+
+// LazyRowReader is a `chunk.RowContainerReader` which reads the rows from the `ResultSet` on demand, so the cursor
+// doesn't need to hold all the rows. It closes the `ResultSet` when it's closed.
+type LazyRowReader struct {
+	ctx context.Context
+	rs  ResultSet
+
+	chk        *chunk.Chunk
+	idx        int
+	currentRow chunk.Row
+
+	err    error
+	closed bool
+}
+
+// NewLazyRowReader creates a `LazyRowReader` and reads the first chunk of the `ResultSet`.
+func NewLazyRowReader(ctx context.Context, rs ResultSet) (*LazyRowReader, error) {
+	reader := &LazyRowReader{
+		ctx: ctx,
+		rs:  rs,
+		chk: rs.NewChunk(nil),
+		idx: -1,
+	}
+	reader.Next()
+	return reader, reader.err
+}
+
+// SetContext sets the context used to read the following chunks. It should be called at the beginning of each
+// `FETCH` command, because the context of the former command may have been canceled.
+func (reader *LazyRowReader) SetContext(ctx context.Context) {
+	reader.ctx = ctx
+}
+
+// Next implements RowContainerReader
+func (reader *LazyRowReader) Next() chunk.Row {
+	if reader.closed || reader.err != nil {
+		reader.currentRow = reader.End()
+		return reader.currentRow
+	}
+	reader.idx++
+	if reader.idx >= reader.chk.NumRows() {
+		reader.idx = 0
+		if err := reader.rs.Next(reader.ctx, reader.chk); err != nil {
+			reader.err = err
+		}
+		if reader.err != nil || reader.chk.NumRows() == 0 {
+			reader.currentRow = reader.End()
+			return reader.currentRow
+		}
+	}
+	reader.currentRow = reader.chk.GetRow(reader.idx)
+	return reader.currentRow
+}
+
+// Current implements RowContainerReader
+func (reader *LazyRowReader) Current() chunk.Row {
+	return reader.currentRow
+}
+
+// End implements RowContainerReader
+func (*LazyRowReader) End() chunk.Row {
+	return chunk.Row{}
+}
+
+// Error implements RowContainerReader
+func (reader *LazyRowReader) Error() error {
+	return reader.err
+}
+
+// Close implements RowContainerReader
+func (reader *LazyRowReader) Close() {
+	if reader.closed {
+		return
+	}
+	reader.closed = true
+	reader.currentRow = reader.End()
+	reader.rs.Close()
+}
+
+// IsClosed returns whether the reader has been closed.
+func (reader *LazyRowReader) IsClosed() bool {
+	return reader.closed
+}
+
+// Materialize reads the rows left into the row container and closes the reader. The caller should read the rows
+// through a new `chunk.RowContainerReader` of the row container afterwards.
+func (reader *LazyRowReader) Materialize(ctx context.Context, rc *chunk.RowContainer) error {
+	defer reader.Close()
+	if reader.err != nil {
+		return reader.err
+	}
+	if reader.Current() == reader.End() {
+		return nil
+	}
+	reader.SetContext(ctx)
+
+	// the rows left in the current chunk
+	left := chunk.NewChunkWithCapacity(reader.rs.FieldTypes(), reader.chk.NumRows()-reader.idx)
+	left.Append(reader.chk, reader.idx, reader.chk.NumRows())
+	if err := rc.Add(left); err != nil {
+		return err
+	}
+	for {
+		chk := reader.rs.NewChunk(nil)
+		if err := reader.rs.Next(ctx, chk); err != nil {
+			reader.err = err
+			return err
+		}
+		if chk.NumRows() == 0 {
+			return nil
+		}
+		if err := rc.Add(chk); err != nil {
+			return err
+		}
+	}
+}
@@ -64,6 +64,7 @@ import (
 	"github.com/pingcap/tidb/pkg/plugin"
 	"github.com/pingcap/tidb/pkg/privilege/privileges"
 	servererr "github.com/pingcap/tidb/pkg/server/err"
+	"github.com/pingcap/tidb/pkg/server/internal/proxytlv"
 	"github.com/pingcap/tidb/pkg/session"
 	"github.com/pingcap/tidb/pkg/session/txninfo"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
@@ -118,12 +119,18 @@ type Server struct {
 	driver            IDriver
 	listener          net.Listener
 	socket            net.Listener
+	mysqlxListener    net.Listener
 	concurrentLimiter *TokenLimiter
 
 	rwlock                 sync.RWMutex
 	clients                map[uint64]*clientConn
 	ConnNumByResourceGroup map[string]int
 
+	userResourceMu sync.Mutex
+	userResources  map[string]*userResourceUsage
+	// userResourceEvicted is the last time the expired userResources were evicted.
+	userResourceEvicted time.Time
+
 	capability uint32
 	dom        *domain.Domain
 
@@ -164,6 +171,11 @@ func (s *Server) ListenAddr() net.Addr {
 	return s.listener.Addr()
 }
 
+// MySQLXListenAddr returns the network address of the X Protocol listener.
+func (s *Server) MySQLXListenAddr() net.Addr {
+	return s.mysqlxListener.Addr()
+}
+
 // StatusListenerAddr returns the server's status listener's network address.
 func (s *Server) StatusListenerAddr() net.Addr {
 	return s.statusListener.Addr()
@@ -329,8 +341,11 @@ func (s *Server) initTiDBListener() (err error) {
 	}
 
 	if s.cfg.ProxyProtocol.Networks != "" {
-		proxyTarget := s.listener
-		if proxyTarget == nil {
+		var proxyTarget net.Listener
+		if s.listener != nil {
+			// Capture the TLVs of the PROXY protocol v2 headers, which the PROXY protocol listener skips.
+			proxyTarget = proxytlv.NewListener(s.listener)
+		} else {
 			proxyTarget = s.socket
 		}
 		ppListener, err := proxyprotocol.NewLazyListener(proxyTarget, s.cfg.ProxyProtocol.Networks,
@@ -350,6 +365,25 @@ func (s *Server) initTiDBListener() (err error) {
 	return nil
 }
 
+func (s *Server) initMySQLXListener() (err error) {
+	if !s.cfg.MySQLX.Enable || s.cfg.Host == "" {
+		return nil
+	}
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(int(s.cfg.MySQLX.Port)))
+	tcpProto := "tcp"
+	if s.cfg.EnableTCP4Only {
+		tcpProto = "tcp4"
+	}
+	if s.mysqlxListener, err = net.Listen(tcpProto, addr); err != nil {
+		return errors.Trace(err)
+	}
+	logutil.BgLogger().Info("server is running X Protocol", zap.String("addr", addr))
+	if RunInGoTest && s.cfg.MySQLX.Port == 0 {
+		s.cfg.MySQLX.Port = uint(s.mysqlxListener.Addr().(*net.TCPAddr).Port)
+	}
+	return nil
+}
+
 func (s *Server) initHTTPListener() (err error) {
 	if s.cfg.Status.ReportStatus {
 		if err = s.listenStatusHTTPServer(); err != nil {
@@ -444,26 +478,32 @@ func (s *Server) Run(dom *domain.Domain) error {
 	}
 	// If error should be reported and exit the server it can be sent on this
 	// channel. Otherwise, end with sending a nil error to signal "done"
-	errChan := make(chan error, 2)
+	errChan := make(chan error, 3)
 	err := s.initTiDBListener()
 	if err != nil {
 		log.Error("failed to create the server", zap.Error(err), zap.Stack("stack"))
 		return err
 	}
+	if err = s.initMySQLXListener(); err != nil {
+		log.Error("failed to create the server", zap.Error(err), zap.Stack("stack"))
+		return err
+	}
 	// Register error API is not thread-safe, the caller MUST NOT register errors after initialization.
 	// To prevent misuse, set a flag to indicate that register new error will panic immediately.
 	// For regression of issue like https://github.com/pingcap/tidb/issues/28190
 	terror.RegisterFinish()
 	go s.startNetworkListener(s.listener, false, errChan)
 	go s.startNetworkListener(s.socket, true, errChan)
+	go s.startMySQLXListener(errChan)
 	if RunInGoTest && !isClosed(RunInGoTestChan) {
 		close(RunInGoTestChan)
 	}
-	err = <-errChan
-	if err != nil {
-		return err
+	for i := 0; i < cap(errChan); i++ {
+		if err = <-errChan; err != nil {
+			return err
+		}
 	}
-	return <-errChan
+	return nil
 }
 
 // isClosed is to check if the channel is closed
@@ -557,6 +597,36 @@ func (s *Server) startNetworkListener(listener net.Listener, isUnixSocket bool,
 	}
 }
 
+func (s *Server) startMySQLXListener(errChan chan error) {
+	listener := s.mysqlxListener
+	if listener == nil {
+		errChan <- nil
+		return
+	}
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if opErr, ok := err.(*net.OpError); ok && opErr.Err.Error() == "use of closed network connection" {
+				if s.inShutdownMode.Load() {
+					errChan <- nil
+				} else {
+					errChan <- err
+				}
+				return
+			}
+			logutil.BgLogger().Error("accept failed", zap.Error(err))
+			errChan <- err
+			return
+		}
+		if s.dom != nil && s.dom.IsLostConnectionToPD() {
+			logutil.BgLogger().Warn("reject connection due to lost connection to PD")
+			terror.Log(conn.Close())
+			continue
+		}
+		go s.onMySQLXConn(conn)
+	}
+}
+
 func (*Server) checkAuditPlugin(clientConn *clientConn) error {
 	return plugin.ForeachPlugin(plugin.Audit, func(p *plugin.Plugin) error {
 		authPlugin := plugin.DeclareAuditManifest(p.Manifest)
@@ -602,6 +672,11 @@ func (s *Server) closeListener() {
 		terror.Log(errors.Trace(err))
 		s.socket = nil
 	}
+	if s.mysqlxListener != nil {
+		err := s.mysqlxListener.Close()
+		terror.Log(errors.Trace(err))
+		s.mysqlxListener = nil
+	}
 	if s.statusServer != nil {
 		err := s.statusServer.Close()
 		terror.Log(errors.Trace(err))
@@ -804,6 +879,24 @@ func (cc *clientConn) connectInfo() *variable.ConnectionInfo {
 	return connInfo
 }
 
+// unregisterConn removes the connection from the clients, it returns the resource group
+// of the connection and the connection count of the group before the removal.
+func (s *Server) unregisterConn(conn *clientConn) (resourceGroupName string, count int) {
+	s.rwlock.Lock()
+	defer s.rwlock.Unlock()
+	delete(s.clients, conn.connectionID)
+	if ctx := conn.getCtx(); ctx != nil {
+		resourceGroupName = ctx.GetSessionVars().ResourceGroupName
+		count = s.ConnNumByResourceGroup[resourceGroupName]
+		if count <= 1 {
+			delete(s.ConnNumByResourceGroup, resourceGroupName)
+		} else {
+			s.ConnNumByResourceGroup[resourceGroupName]--
+		}
+	}
+	return resourceGroupName, count
+}
+
 func (s *Server) checkConnectionCount() error {
 	// When the value of Instance.MaxConnections is 0, the number of connections is unlimited.
 	if int(s.cfg.Instance.MaxConnections) == 0 {
@@ -822,6 +915,20 @@ func (s *Server) checkConnectionCount() error {
 	return nil
 }
 
+// checkIPAccess checks the client address, and the AWS VPC endpoint ID from the
+// PROXY protocol header, against the IP access list in mysql.tidb_ip_access_list.
+func (s *Server) checkIPAccess(host, vpceID string) error {
+	if s.dom == nil || s.dom.PrivilegeHandle() == nil {
+		return nil
+	}
+	if priv := s.dom.PrivilegeHandle().Get(); priv != nil && !priv.CheckIPAccess(host, vpceID) {
+		logutil.BgLogger().Warn("reject connection by the IP access list",
+			zap.String("host", host), zap.String("vpceID", vpceID))
+		return servererr.ErrHostNotPrivileged.FastGenByArgs(host)
+	}
+	return nil
+}
+
 // ShowProcessList implements the SessionManager interface.
 func (s *Server) ShowProcessList() map[uint64]*util.ProcessInfo {
 	rs := make(map[uint64]*util.ProcessInfo)
@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/parser/auth"
@@ -193,3 +194,23 @@ func TestGetConAttrs(t *testing.T) {
 	_, hasClientName = attrs[1]
 	require.False(t, hasClientName)
 }
+
+func TestEvictExpiredUserResources(t *testing.T) {
+	now := time.Now()
+	s := &Server{userResources: map[string]*userResourceUsage{
+		"connected@%": {user: "connected", host: "%", connections: 1, questions: 3, hourStart: now.Add(-2 * time.Hour)},
+		"expired@%":   {user: "expired", host: "%", questions: 3, hourStart: now.Add(-2 * time.Hour)},
+		"current@%":   {user: "current", host: "%", questions: 3, hourStart: now.Add(-time.Minute)},
+	}}
+	s.evictExpiredUserResources(now)
+	require.Len(t, s.userResources, 2)
+	require.NotContains(t, s.userResources, "expired@%")
+
+	// The eviction runs at most once per hour.
+	s.userResources["current@%"].hourStart = now.Add(-2 * time.Hour)
+	s.evictExpiredUserResources(now.Add(time.Minute))
+	require.Len(t, s.userResources, 2)
+	s.evictExpiredUserResources(now.Add(time.Hour))
+	require.Len(t, s.userResources, 1)
+	require.Contains(t, s.userResources, "connected@%")
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net"
@@ -34,6 +35,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/config"
 	ddlutil "github.com/pingcap/tidb/pkg/ddl/util"
+	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/extension"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
@@ -41,6 +43,7 @@ import (
 	tmysql "github.com/pingcap/tidb/pkg/parser/mysql"
 	server2 "github.com/pingcap/tidb/pkg/server"
 	"github.com/pingcap/tidb/pkg/server/internal/column"
+	"github.com/pingcap/tidb/pkg/server/internal/mysqlx"
 	"github.com/pingcap/tidb/pkg/server/internal/resultset"
 	"github.com/pingcap/tidb/pkg/server/internal/testserverclient"
 	"github.com/pingcap/tidb/pkg/server/internal/testutil"
@@ -51,8 +54,10 @@ import (
 	"github.com/pingcap/tidb/pkg/store/mockstore/unistore"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/plancodec"
 	"github.com/pingcap/tidb/pkg/util/resourcegrouptag"
+	tlsutil "github.com/pingcap/tidb/pkg/util/tls"
 	"github.com/pingcap/tidb/pkg/util/topsql"
 	"github.com/pingcap/tidb/pkg/util/topsql/collector"
 	mockTopSQLTraceCPU "github.com/pingcap/tidb/pkg/util/topsql/collector/mock"
@@ -2767,8 +2772,10 @@ type mockProxyProtocolProxy struct {
 	backend       string
 	clientAddr    string
 	backendIsSock bool
-	ln            net.Listener
-	run           atomic.Bool
+	// vpceID is sent in the AWS TLV of the PROXY protocol v2 header if not empty.
+	vpceID string
+	ln     net.Listener
+	run    atomic.Bool
 }
 
 func newMockProxyProtocolProxy(frontend, backend, clientAddr string, backendIsSock bool) *mockProxyProtocolProxy {
@@ -2866,7 +2873,16 @@ func (p *mockProxyProtocolProxy) generateProxyProtocolHeaderV2(network, srcAddr,
 		copy(buffer[20:20+4], []byte(daddr.IP.To4()))
 		binary.BigEndian.PutUint16(buffer[24:24+2], uint16(saddr.Port))
 		binary.BigEndian.PutUint16(buffer[26:26+2], uint16(saddr.Port))
-		return buffer[0:28]
+		if p.vpceID == "" {
+			return buffer[0:28]
+		}
+		// AWS TLV with the VPC endpoint ID subtype.
+		buffer[28] = 0xEA
+		binary.BigEndian.PutUint16(buffer[29:29+2], uint16(1+len(p.vpceID)))
+		buffer[31] = 0x01
+		n := 32 + copy(buffer[32:], p.vpceID)
+		binary.BigEndian.PutUint16(buffer[14:14+2], uint16(n-16))
+		return buffer[0:n]
 	} else if network == "tcp6" {
 		buffer[v2FamlyPos] = 0x21
 		binary.BigEndian.PutUint16(buffer[14:14+2], 36)
@@ -2983,6 +2999,182 @@ func TestProxyProtocolWithIpNoFallbackable(t *testing.T) {
 	db.Close()
 }
 
+func TestProxyProtocolIPAccessList(t *testing.T) {
+	cfg := util2.NewTestConfig()
+	cfg.Port = 0
+	cfg.Status.ReportStatus = false
+	// Setup proxy protocol config
+	cfg.ProxyProtocol.Networks = "*"
+	cfg.ProxyProtocol.Fallbackable = true
+
+	ts := servertestkit.CreateTidbTestSuite(t)
+
+	// Prepare Server
+	server, err := server2.NewServer(cfg, ts.Tidbdrv)
+	require.NoError(t, err)
+	server.SetDomain(ts.Domain)
+	go func() {
+		err := server.Run(nil)
+		require.NoError(t, err)
+	}()
+	time.Sleep(time.Millisecond * 100)
+	defer func() {
+		server.Close()
+	}()
+
+	// Prepare Proxy
+	ppProxy := newMockProxyProtocolProxy("127.0.0.1:0", server.ListenAddr().String(), "192.168.1.2:60055", false)
+	ppProxy.vpceID = "vpce-08d2bf15fac5001c9"
+	go func() {
+		ppProxy.Run()
+	}()
+	time.Sleep(time.Millisecond * 100)
+	defer func() {
+		ppProxy.Close()
+	}()
+
+	ping := func(port uint) error {
+		cli := testserverclient.NewTestServerClient()
+		cli.Port = port
+		dsn := cli.GetDSN(func(config *mysql.Config) {
+			config.User = "root"
+		})
+		db, err := sql.Open("mysql", dsn)
+		require.NoError(t, err)
+		defer db.Close()
+		return db.Ping()
+	}
+	proxyPort := testutil.GetPortFromTCPAddr(ppProxy.ListenAddr())
+	directPort := testutil.GetPortFromTCPAddr(server.ListenAddr())
+	require.NoError(t, ping(proxyPort))
+	require.NoError(t, ping(directPort))
+
+	tk := testkit.NewTestKit(t, ts.Store)
+	tk.MustExec(`INSERT INTO mysql.tidb_ip_access_list (rule_type, cidr) VALUES ("DENY", "192.168.1.0/24")`)
+	tk.MustExec("FLUSH PRIVILEGES")
+	err = ping(proxyPort)
+	require.ErrorContains(t, err, "Host '192.168.1.2' is not allowed to connect to this MySQL server")
+	require.NoError(t, ping(directPort))
+
+	// Only the clients connecting through the VPC endpoint are allowed.
+	tk.MustExec("DELETE FROM mysql.tidb_ip_access_list")
+	tk.MustExec(`INSERT INTO mysql.tidb_ip_access_list (rule_type, vpce_id) VALUES ("ALLOW", "vpce-08d2bf15fac5001c9")`)
+	tk.MustExec("FLUSH PRIVILEGES")
+	require.NoError(t, ping(proxyPort))
+	err = ping(directPort)
+	require.ErrorContains(t, err, "Host '127.0.0.1' is not allowed to connect to this MySQL server")
+
+	tk.MustExec("DELETE FROM mysql.tidb_ip_access_list")
+	tk.MustExec("FLUSH PRIVILEGES")
+	require.NoError(t, ping(directPort))
+}
+
+func TestUserResourceLimits(t *testing.T) {
+	ts := servertestkit.CreateTidbTestSuite(t)
+
+	root, err := sql.Open("mysql", ts.GetDSN())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, root.Close())
+	}()
+	dbt := testkit.NewDBTestKit(t, root)
+	dbt.MustExec("CREATE USER 'limited'@'%' WITH MAX_USER_CONNECTIONS 1 MAX_QUERIES_PER_HOUR 3")
+
+	db, err := sql.Open("mysql", ts.GetDSN(func(config *mysql.Config) {
+		config.User = "limited"
+		config.DBName = ""
+	}))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	ctx := context.Background()
+	conn1, err := db.Conn(ctx)
+	require.NoError(t, err)
+	_, err = db.Conn(ctx)
+	require.ErrorContains(t, err, "Error 1203")
+
+	for i := 0; i < 3; i++ {
+		_, err = conn1.ExecContext(ctx, "SELECT 1")
+		require.NoError(t, err)
+	}
+	_, err = conn1.ExecContext(ctx, "SELECT 1")
+	require.ErrorContains(t, err, "Error 1226")
+	require.ErrorContains(t, err, "User 'limited' has exceeded the 'max_questions' resource (current value: 3)")
+
+	rows := dbt.MustQuery("SELECT user, host, current_connections, max_user_connections, questions, max_questions, hour_start IS NOT NULL FROM information_schema.tidb_user_resource_usage WHERE user = 'limited'")
+	ts.CheckRows(t, rows, "limited % 1 1 3 3 1")
+
+	// The limits are reloaded with the privileges.
+	dbt.MustExec("ALTER USER 'limited'@'%' WITH MAX_USER_CONNECTIONS 0 MAX_QUERIES_PER_HOUR 0")
+	_, err = conn1.ExecContext(ctx, "SELECT 1")
+	require.NoError(t, err)
+	conn2, err := db.Conn(ctx)
+	require.NoError(t, err)
+	rows = dbt.MustQuery("SELECT current_connections, max_user_connections, questions, max_questions FROM information_schema.tidb_user_resource_usage WHERE user = 'limited'")
+	ts.CheckRows(t, rows, "2 0 4 0")
+
+	// The global max_user_connections limits the accounts without MAX_USER_CONNECTIONS.
+	dbt.MustExec("SET GLOBAL max_user_connections = 2")
+	_, err = db.Conn(ctx)
+	require.ErrorContains(t, err, "Error 1203")
+	dbt.MustExec("SET GLOBAL max_user_connections = 0")
+
+	require.NoError(t, conn1.Close())
+	require.NoError(t, conn2.Close())
+}
+
+func TestFederatedTable(t *testing.T) {
+	ts := servertestkit.CreateTidbTestSuite(t)
+
+	tk := testkit.NewTestKit(t, ts.Store)
+	tk.MustExec("use test")
+	tk.MustExec("create table remote (id int primary key, name varchar(20), score double)")
+	tk.MustExec("insert into remote values (1, 'a', 1.5), (2, 'b', NULL), (3, 'c', 3.5)")
+	tk.MustExec(fmt.Sprintf("create server s1 foreign data wrapper mysql options (host '127.0.0.1', port %d, user 'root', database 'test')", ts.Port))
+	tk.MustGetErrCode("create server s1 foreign data wrapper mysql options (host '127.0.0.1')", errno.ErrForeignServerExists)
+	tk.MustExec("create server if not exists s1 foreign data wrapper mysql options (host '127.0.0.1')")
+	tk.MustQuery("select Server_name, Host, Db, Username, Wrapper from mysql.servers").Check(testkit.Rows("s1 127.0.0.1 test root mysql"))
+
+	tk.MustExec("create table f (id int, name varchar(20), score double) engine = federated connection = 's1/remote'")
+	tk.MustQuery("show create table f").Check(testkit.Rows("f CREATE TABLE `f` (\n" +
+		"  `id` int(11) DEFAULT NULL,\n" +
+		"  `name` varchar(20) DEFAULT NULL,\n" +
+		"  `score` double DEFAULT NULL\n" +
+		") ENGINE=FEDERATED DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin CONNECTION='s1/remote'"))
+	tk.MustQuery("select * from f order by id").Check(testkit.Rows("1 a 1.5", "2 b <nil>", "3 c 3.5"))
+	tk.MustQuery("select name from f where id >= 2 and score is null").Check(testkit.Rows("b"))
+	tk.MustQuery("explain format = 'brief' select name from f where id in (1, 3) and name != 'c'").CheckContain(
+		"remote_conditions:[(`id` IN (1, 3))]")
+	tk.MustQuery("select name from f where id in (1, 3) and name != 'c'").Check(testkit.Rows("a"))
+	// The strings are compared by the local collation, which differs from the remote one.
+	tk.MustExec("create table f3 (id int, name varchar(20) collate utf8mb4_general_ci) engine = federated connection = 's1/remote'")
+	tk.MustQuery("select id from f3 where name = 'A'").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from f3 where name in ('B', 'C') order by id").Check(testkit.Rows("2", "3"))
+	tk.MustQuery("explain format = 'brief' select id from f3 where name = 'A' and (id > 0 or name is null)").CheckContain(
+		"remote_conditions:[((`id` > 0) OR (`name` IS NULL))]")
+	tk.MustExec("create table l (id int, v int)")
+	tk.MustExec("insert into l values (1, 10), (3, 30)")
+	tk.MustQuery("select l.v, f.name from l join f on l.id = f.id order by l.id").Check(testkit.Rows("10 a", "30 c"))
+
+	// federated tables are read-only.
+	tk.MustGetErrCode("insert into f values (4, 'd', 4)", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("delete from f", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("alter table f add column c int", errno.ErrOptOnExternalTable)
+	tk.MustGetErrCode("create table f2 (id int) engine = federated connection = 'mysql://root@127.0.0.1/test/remote'", errno.ErrForeignDataStringInvalidCantCreate)
+	tk.MustGetErrCode("create table f2 (id int primary key) engine = federated connection = 's1'", errno.ErrOptOnExternalTable)
+
+	tk.MustExec("create table f2 (id int) engine = federated connection = 's1/not_exists'")
+	err := tk.QueryToErr("select * from f2")
+	require.True(t, exeerrors.ErrQueryOnForeignDataSource.Equal(err), err)
+
+	tk.MustExec("drop server s1")
+	err = tk.QueryToErr("select * from f")
+	require.True(t, exeerrors.ErrForeignServerDoesntExist.Equal(err), err)
+	tk.MustGetErrCode("drop server s1", errno.ErrForeignServerDoesntExist)
+	tk.MustExec("drop server if exists s1")
+}
+
 func TestConnectionWillNotLeak(t *testing.T) {
 	cfg := util2.NewTestConfig()
 	cfg.Port = 0
@@ -3067,3 +3259,305 @@ func TestPrepareCount(t *testing.T) {
 	require.Equal(t, prepareCnt, atomic.LoadInt64(&variable.PreparedStmtCount))
 	require.NoError(t, qctx.Close())
 }
+
+type mysqlxTestClient struct {
+	t    *testing.T
+	conn net.Conn
+}
+
+func (c *mysqlxTestClient) send(tp byte, payload []byte) {
+	require.NoError(c.t, mysqlx.WriteMessage(c.conn, tp, payload))
+}
+
+func (c *mysqlxTestClient) recv() (byte, map[int][][]byte) {
+	tp, payload, err := mysqlx.ReadMessage(c.conn, 1<<20)
+	require.NoError(c.t, err)
+	return tp, mysqlxFields(c.t, payload)
+}
+
+// recvUntil reads messages until one of type tp, and returns the skipped messages of type skip.
+func (c *mysqlxTestClient) recvUntil(tp, skip byte) (map[int][][]byte, []map[int][][]byte) {
+	var skipped []map[int][][]byte
+	for {
+		got, fields := c.recv()
+		if got == tp {
+			return fields, skipped
+		}
+		if got == skip {
+			skipped = append(skipped, fields)
+		}
+	}
+}
+
+func (c *mysqlxTestClient) execute(sql string) {
+	c.send(mysqlx.ClientSQLStmtExecute, mysqlxField(nil, 1, []byte(sql)))
+}
+
+func (c *mysqlxTestClient) authenticate(user, password string) (byte, map[int][][]byte) {
+	c.send(mysqlx.ClientSessAuthenticateStart, mysqlxField(nil, 1, []byte("MYSQL41")))
+	tp, fields := c.recv()
+	require.Equal(c.t, mysqlx.ServerSessAuthenticateContinue, tp)
+	salt := fields[1][0]
+	require.Len(c.t, salt, 20)
+	authData := "test\x00" + user + "\x00"
+	if password != "" {
+		// SHA1(password) XOR SHA1(salt + SHA1(SHA1(password)))
+		stage1 := auth.Sha1Hash([]byte(password))
+		scramble := auth.Sha1Hash(append(append([]byte{}, salt...), auth.Sha1Hash(stage1)...))
+		for i := range scramble {
+			scramble[i] ^= stage1[i]
+		}
+		authData += "*" + strings.ToUpper(hex.EncodeToString(scramble))
+	}
+	c.send(mysqlx.ClientSessAuthenticateContinue, mysqlxField(nil, 1, []byte(authData)))
+	tp, fields = c.recv()
+	if tp == mysqlx.ServerNotice {
+		tp, fields = c.recv()
+	}
+	return tp, fields
+}
+
+func mysqlxField(b []byte, num int, data []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(num)<<3|2)
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+// mysqlxFields decodes a protobuf message, varint fields are returned as their encoding.
+func mysqlxFields(t *testing.T, b []byte) map[int][][]byte {
+	fields := make(map[int][][]byte)
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		require.Greater(t, n, 0)
+		b = b[n:]
+		var data []byte
+		switch tag & 7 {
+		case 0:
+			_, n = binary.Uvarint(b)
+			require.Greater(t, n, 0)
+			data, b = b[:n], b[n:]
+		case 2:
+			l, n := binary.Uvarint(b)
+			require.Greater(t, n, 0)
+			data, b = b[n:n+int(l)], b[n+int(l):]
+		default:
+			require.FailNow(t, "unexpected wire type")
+		}
+		fields[int(tag>>3)] = append(fields[int(tag>>3)], data)
+	}
+	return fields
+}
+
+func mysqlxVarint(t *testing.T, b []byte) uint64 {
+	v, n := binary.Uvarint(b)
+	require.Equal(t, len(b), n)
+	return v
+}
+
+func TestMySQLXProtocol(t *testing.T) {
+	cfg := util2.NewTestConfig()
+	cfg.Port = 0
+	cfg.Status.StatusPort = 0
+	cfg.MySQLX.Enable = true
+	cfg.MySQLX.Port = 0
+	ts := servertestkit.CreateTidbTestSuiteWithCfg(t, cfg)
+	ts.RunTests(t, nil, func(dbt *testkit.DBTestKit) {
+		dbt.MustExec("CREATE USER 'xuser'@'%' IDENTIFIED WITH mysql_native_password BY 'xpass'")
+		dbt.MustExec("CREATE USER 'xsha'@'%' IDENTIFIED WITH caching_sha2_password BY 'xpass'")
+		dbt.MustExec("GRANT ALL ON test.* TO 'xuser'@'%'")
+	})
+
+	conn, err := net.Dial("tcp", ts.Server.MySQLXListenAddr().String())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+	c := &mysqlxTestClient{t: t, conn: conn}
+
+	// Capability negotiation. TLS is not supported.
+	c.send(mysqlx.ClientConCapabilitiesGet, nil)
+	tp, fields := c.recv()
+	require.Equal(t, mysqlx.ServerConCapabilities, tp)
+	require.Len(t, fields[1], 5)
+	tls := mysqlxField(nil, 1, []byte("tls"))
+	tls = mysqlxField(tls, 2, []byte{0x08, 0x01, 0x12, 0x04, 0x08, 0x07, 0x40, 0x01})
+	c.send(mysqlx.ClientConCapabilitiesSet, mysqlxField(nil, 1, mysqlxField(nil, 1, tls)))
+	tp, fields = c.recv()
+	require.Equal(t, mysqlx.ServerError, tp)
+	require.Equal(t, uint64(tmysql.ErrNotSupportedYet), mysqlxVarint(t, fields[2][0]))
+
+	// Only mysql_native_password accounts can use MYSQL41.
+	tp, fields = c.authenticate("xuser", "wrong")
+	require.Equal(t, mysqlx.ServerError, tp)
+	require.Equal(t, uint64(tmysql.ErrAccessDenied), mysqlxVarint(t, fields[2][0]))
+	tp, fields = c.authenticate("xsha", "xpass")
+	require.Equal(t, mysqlx.ServerError, tp)
+	require.Equal(t, uint64(tmysql.ErrAccessDenied), mysqlxVarint(t, fields[2][0]))
+	tp, _ = c.authenticate("xuser", "xpass")
+	require.Equal(t, mysqlx.ServerSessAuthenticateOk, tp)
+
+	c.execute("create table t (a int, b varchar(10), c double)")
+	c.recvUntil(mysqlx.ServerSQLStmtExecuteOk, mysqlx.ServerNotice)
+	c.execute("insert into t values (-1, 'x', 1.5), (2, '', null)")
+	_, notices := c.recvUntil(mysqlx.ServerSQLStmtExecuteOk, mysqlx.ServerNotice)
+	require.Len(t, notices, 2)
+	changed := mysqlxFields(t, notices[0][3][0])
+	require.Equal(t, mysqlx.StateRowsAffected, mysqlxVarint(t, changed[1][0]))
+	require.Equal(t, uint64(2), mysqlxVarint(t, mysqlxFields(t, changed[2][0])[3][0]))
+	changed = mysqlxFields(t, notices[1][3][0])
+	require.Equal(t, mysqlx.StateProducedMessage, mysqlxVarint(t, changed[1][0]))
+	require.Contains(t, string(changed[2][0]), "Records: 2  Duplicates: 0  Warnings: 0")
+
+	// Result streaming.
+	c.execute("select a, b, c from t order by a")
+	var columns []map[int][][]byte
+	for i := 0; i < 3; i++ {
+		tp, fields = c.recv()
+		require.Equal(t, mysqlx.ServerResultsetColumnMetaData, tp)
+		columns = append(columns, fields)
+	}
+	require.Equal(t, mysqlx.ColumnTypeSint, mysqlxVarint(t, columns[0][1][0]))
+	require.Equal(t, "a", string(columns[0][2][0]))
+	require.Equal(t, "t", string(columns[0][5][0]))
+	require.Equal(t, "test", string(columns[0][6][0]))
+	require.Equal(t, mysqlx.ColumnTypeBytes, mysqlxVarint(t, columns[1][1][0]))
+	require.Equal(t, mysqlx.ColumnTypeDouble, mysqlxVarint(t, columns[2][1][0]))
+	tp, fields = c.recv()
+	require.Equal(t, mysqlx.ServerResultsetRow, tp)
+	require.Equal(t, [][]byte{mysqlx.EncodeSint(-1), mysqlx.EncodeBytes([]byte("x")), mysqlx.EncodeDouble(1.5)}, fields[1])
+	tp, fields = c.recv()
+	require.Equal(t, mysqlx.ServerResultsetRow, tp)
+	require.Equal(t, [][]byte{mysqlx.EncodeSint(2), mysqlx.EncodeBytes(nil), {}}, fields[1])
+	tp, _ = c.recv()
+	require.Equal(t, mysqlx.ServerResultsetFetchDone, tp)
+	c.recvUntil(mysqlx.ServerSQLStmtExecuteOk, mysqlx.ServerNotice)
+
+	// Warnings are sent as notices, errors don't break the session.
+	c.execute("select 1/0")
+	_, notices = c.recvUntil(mysqlx.ServerSQLStmtExecuteOk, mysqlx.ServerNotice)
+	require.Equal(t, mysqlx.NoticeWarning, mysqlxVarint(t, notices[0][1][0]))
+	require.Equal(t, uint64(tmysql.ErrDivisionByZero), mysqlxVarint(t, mysqlxFields(t, notices[0][3][0])[2][0]))
+	c.execute("select * from not_exists")
+	tp, fields = c.recv()
+	require.Equal(t, mysqlx.ServerError, tp)
+	require.Equal(t, uint64(tmysql.ErrNoSuchTable), mysqlxVarint(t, fields[2][0]))
+	c.execute("select 1; select 2")
+	tp, _ = c.recv()
+	require.Equal(t, mysqlx.ServerError, tp)
+	c.send(mysqlx.ClientSQLStmtExecute, mysqlxField(mysqlxField(nil, 1, []byte("ping")), 3, []byte("mysqlx")))
+	tp, _ = c.recv()
+	require.Equal(t, mysqlx.ServerSQLStmtExecuteOk, tp)
+
+	// A closed session must authenticate again.
+	c.send(mysqlx.ClientSessClose, nil)
+	tp, _ = c.recv()
+	require.Equal(t, mysqlx.ServerOk, tp)
+	tp, _ = c.authenticate("root", "")
+	require.Equal(t, mysqlx.ServerSessAuthenticateOk, tp)
+	c.send(mysqlx.ClientConClose, nil)
+	tp, fields = c.recv()
+	require.Equal(t, mysqlx.ServerOk, tp)
+	require.Equal(t, "bye!", string(fields[1][0]))
+	_, _, err = mysqlx.ReadMessage(conn, 1<<20)
+	require.ErrorIs(t, err, io.EOF)
+
+	// The authenticated connections are managed like the MySQL protocol ones.
+	require.Eventually(t, func() bool {
+		return ts.Server.ConnectionCount() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	dial := func() *mysqlxTestClient {
+		conn, err := net.Dial("tcp", ts.Server.MySQLXListenAddr().String())
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, conn.Close())
+		})
+		return &mysqlxTestClient{t: t, conn: conn}
+	}
+	xuserConnID := func() (uint64, bool) {
+		for id, pi := range ts.Server.ShowProcessList() {
+			if pi.User == "xuser" {
+				return id, true
+			}
+		}
+		return 0, false
+	}
+
+	// TLS isn't supported, so the connections are rejected if secure transport is required.
+	c = dial()
+	tlsutil.RequireSecureTransport.Store(true)
+	c.send(mysqlx.ClientSessAuthenticateStart, mysqlxField(nil, 1, []byte("MYSQL41")))
+	tp, fields = c.recv()
+	tlsutil.RequireSecureTransport.Store(false)
+	require.Equal(t, mysqlx.ServerError, tp)
+	require.Equal(t, uint64(errno.ErrSecureTransportRequired), mysqlxVarint(t, fields[2][0]))
+
+	// The authenticated connections are shown in the processlist and counted by max_connections.
+	tp, _ = c.authenticate("xuser", "xpass")
+	require.Equal(t, mysqlx.ServerSessAuthenticateOk, tp)
+	require.Equal(t, 1, ts.Server.ConnectionCount())
+	_, ok := xuserConnID()
+	require.True(t, ok)
+	cfg.Instance.MaxConnections = 1
+	tp, fields = dial().authenticate("xuser", "xpass")
+	cfg.Instance.MaxConnections = 0
+	require.Equal(t, mysqlx.ServerError, tp)
+	require.Equal(t, uint64(tmysql.ErrConCount), mysqlxVarint(t, fields[2][0]))
+
+	// A closed session is removed from the processlist until the client authenticates again.
+	c.send(mysqlx.ClientSessClose, nil)
+	tp, _ = c.recv()
+	require.Equal(t, mysqlx.ServerOk, tp)
+	require.Equal(t, 0, ts.Server.ConnectionCount())
+	_, ok = xuserConnID()
+	require.False(t, ok)
+	tp, _ = c.authenticate("xuser", "xpass")
+	require.Equal(t, mysqlx.ServerSessAuthenticateOk, tp)
+
+	// The connection can be killed.
+	id, ok := xuserConnID()
+	require.True(t, ok)
+	ts.RunTests(t, nil, func(dbt *testkit.DBTestKit) {
+		dbt.MustExec(fmt.Sprintf("KILL %d", id))
+	})
+	_, _, err = mysqlx.ReadMessage(c.conn, 1<<20)
+	require.ErrorIs(t, err, io.EOF)
+	_, ok = xuserConnID()
+	require.False(t, ok)
+
+	// The account resource limits are shared by the MySQL protocol and X Protocol connections.
+	ts.RunTests(t, nil, func(dbt *testkit.DBTestKit) {
+		dbt.MustExec("CREATE USER 'xlimited'@'%' IDENTIFIED WITH mysql_native_password BY 'xpass' WITH MAX_USER_CONNECTIONS 1 MAX_QUERIES_PER_HOUR 2")
+		dbt.MustExec("GRANT ALL ON test.* TO 'xlimited'@'%'")
+	})
+	db, err := sql.Open("mysql", ts.GetDSN(func(config *mysql.Config) {
+		config.User = "xlimited"
+		config.Passwd = "xpass"
+	}))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	db.SetMaxIdleConns(0)
+	mysqlConn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	c = dial()
+	tp, fields = c.authenticate("xlimited", "xpass")
+	require.Equal(t, mysqlx.ServerError, tp)
+	require.Equal(t, uint64(tmysql.ErrTooManyUserConnections), mysqlxVarint(t, fields[2][0]))
+	require.NoError(t, mysqlConn.Close())
+	require.Eventually(t, func() bool {
+		tp, _ = c.authenticate("xlimited", "xpass")
+		return tp == mysqlx.ServerSessAuthenticateOk
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = db.Conn(context.Background())
+	require.ErrorContains(t, err, "Error 1203")
+	for i := 0; i < 2; i++ {
+		c.execute("select 1")
+		c.recvUntil(mysqlx.ServerSQLStmtExecuteOk, mysqlx.ServerNotice)
+	}
+	c.execute("select 1")
+	tp, fields = c.recv()
+	require.Equal(t, mysqlx.ServerError, tp)
+	require.Equal(t, uint64(tmysql.ErrUserLimitReached), mysqlxVarint(t, fields[2][0]))
+}
@@ -157,3 +157,57 @@ func TestCursorFetchExecuteCheck(t *testing.T) {
 		tmysql.CursorTypeReadOnly|tmysql.CursorTypeScrollable, 0x1, 0x0, 0x0, 0x0,
 	)))
 }
+
+func TestLazyCursorFetch(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	srv := server2.CreateMockServer(t, store)
+	srv.SetDomain(dom)
+	defer srv.Close()
+
+	appendUint32 := binary.LittleEndian.AppendUint32
+	ctx := context.Background()
+	c := server2.CreateMockConn(t, srv)
+
+	tk := testkit.NewTestKitWithSession(t, store, c.Context().Session)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int primary key, payload BLOB)")
+	payload := make([]byte, 512)
+	for i := 0; i < 2048; i++ {
+		rand.Read(payload)
+		tk.MustExec("insert into t values (?, ?)", i, payload)
+	}
+	tk.MustExec("set tidb_enable_lazy_cursor_fetch = ON")
+
+	stmt, _, _, err := c.Context().Prepare("select * from t")
+	require.NoError(t, err)
+	execute := func() {
+		require.NoError(t, c.Dispatch(ctx, append(
+			appendUint32([]byte{tmysql.ComStmtExecute}, uint32(stmt.ID())),
+			tmysql.CursorTypeReadOnly, 0x1, 0x0, 0x0, 0x0,
+		)))
+	}
+	fetch := func(n uint32) {
+		require.NoError(t, c.Dispatch(ctx, appendUint32(appendUint32([]byte{tmysql.ComStmtFetch}, uint32(stmt.ID())), n)))
+	}
+
+	// the rows are not read into a row container
+	execute()
+	require.Nil(t, stmt.GetRowContainer())
+	fetch(1000)
+	fetch(1000)
+	require.True(t, stmt.GetCursorActive())
+	fetch(1000)
+	require.False(t, stmt.GetCursorActive())
+	require.Len(t, tk.Session().GetSessionVars().MemTracker.GetChildrenForTest(), 0)
+
+	// the rows left are read into a row container before executing another statement
+	execute()
+	fetch(1000)
+	require.NoError(t, c.Dispatch(ctx, append([]byte{tmysql.ComQuery}, "select count(*) from t"...)))
+	require.NotNil(t, stmt.GetRowContainer())
+	fetch(1000)
+	require.True(t, stmt.GetCursorActive())
+	fetch(1000)
+	require.False(t, stmt.GetCursorActive())
+	require.Len(t, tk.Session().GetSessionVars().MemTracker.GetChildrenForTest(), 0)
+}
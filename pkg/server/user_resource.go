@@ -0,0 +1,193 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/privilege/conn"
+	servererr "github.com/pingcap/tidb/pkg/server/err"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util"
+)
+
+// userResourceUsage counts the connections and the statements of an account to
+// enforce the MAX_USER_CONNECTIONS and MAX_QUERIES_PER_HOUR resource options.
+// Like MySQL, the counters are kept per account and per instance.
+type userResourceUsage struct {
+	user string
+	host string
+
+	mu          sync.Mutex
+	connections int64
+	questions   int64
+	// hourStart is the time of the first statement of the current hour window.
+	hourStart time.Time
+}
+
+func userResourceKey(user, host string) string {
+	return user + "@" + host
+}
+
+// addQuestion counts a statement, it returns false if the account has exceeded maxQuestions.
+func (u *userResourceUsage) addQuestion(now time.Time, maxQuestions int64) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if now.Sub(u.hourStart) >= time.Hour {
+		u.hourStart = now
+		u.questions = 0
+	}
+	if maxQuestions > 0 && u.questions >= maxQuestions {
+		return false
+	}
+	u.questions++
+	return true
+}
+
+// authUser authenticates the session of the connection, and counts the connection of the
+// account. It's shared by the MySQL protocol and the X Protocol connections, so the resource
+// options are enforced for both of them.
+func (cc *clientConn) authUser(user *auth.UserIdentity, authData, salt []byte, authConn conn.AuthConn) error {
+	if err := cc.getCtx().Auth(user, authData, salt, authConn); err != nil {
+		return err
+	}
+	return cc.server.acquireUserConnection(cc)
+}
+
+// acquireUserConnection counts the connection of the account it has authenticated as,
+// it returns an error if the account has reached MAX_USER_CONNECTIONS. If the account
+// has no MAX_USER_CONNECTIONS, the global max_user_connections is the limit.
+func (s *Server) acquireUserConnection(cc *clientConn) error {
+	// The connection may authenticate again by COM_CHANGE_USER.
+	s.releaseUserConnection(cc)
+
+	vars := cc.ctx.GetSessionVars()
+	if vars.User == nil {
+		return nil
+	}
+	user, host := vars.User.AuthUsername, vars.User.AuthHostname
+	var maxUserConnections int64
+	if pm := privilege.GetPrivilegeManager(cc.ctx.Session); pm != nil {
+		_, maxUserConnections = pm.GetUserResources(user, host)
+	}
+	if maxUserConnections == 0 {
+		maxUserConnections = int64(variable.MaxUserConnectionsValue.Load())
+	}
+
+	s.userResourceMu.Lock()
+	defer s.userResourceMu.Unlock()
+	if s.userResources == nil {
+		s.userResources = make(map[string]*userResourceUsage)
+	}
+	s.evictExpiredUserResources(time.Now())
+	key := userResourceKey(user, host)
+	usage, ok := s.userResources[key]
+	if !ok {
+		usage = &userResourceUsage{user: user, host: host}
+		s.userResources[key] = usage
+	}
+	usage.mu.Lock()
+	defer usage.mu.Unlock()
+	if maxUserConnections > 0 && usage.connections >= maxUserConnections {
+		return servererr.ErrTooManyUserConnections.FastGenByArgs(user)
+	}
+	usage.connections++
+	cc.userResource.Store(usage)
+	return nil
+}
+
+// releaseUserConnection is idempotent, it's called when the connection is closed.
+func (s *Server) releaseUserConnection(cc *clientConn) {
+	s.userResourceMu.Lock()
+	defer s.userResourceMu.Unlock()
+	usage := cc.userResource.Swap(nil)
+	if usage == nil {
+		return
+	}
+	usage.mu.Lock()
+	defer usage.mu.Unlock()
+	usage.connections--
+	// Keep the statements of the current hour, it's still limited after reconnection.
+	if usage.connections <= 0 && (usage.questions == 0 || time.Since(usage.hourStart) >= time.Hour) {
+		delete(s.userResources, userResourceKey(usage.user, usage.host))
+	}
+}
+
+// evictExpiredUserResources removes the accounts without connections whose hour window
+// has expired. It runs at most once per hour, userResourceMu must be held.
+func (s *Server) evictExpiredUserResources(now time.Time) {
+	if now.Sub(s.userResourceEvicted) < time.Hour {
+		return
+	}
+	s.userResourceEvicted = now
+	for key, usage := range s.userResources {
+		usage.mu.Lock()
+		if usage.connections <= 0 && now.Sub(usage.hourStart) >= time.Hour {
+			delete(s.userResources, key)
+		}
+		usage.mu.Unlock()
+	}
+}
+
+// checkUserQuestions counts a statement of the connection, it returns an error
+// if the account has reached MAX_QUERIES_PER_HOUR. It's called before running the
+// statements of both the MySQL protocol and the X Protocol connections.
+func (cc *clientConn) checkUserQuestions() error {
+	usage := cc.userResource.Load()
+	if usage == nil {
+		return nil
+	}
+	var maxQuestions int64
+	if pm := privilege.GetPrivilegeManager(cc.ctx.Session); pm != nil {
+		maxQuestions, _ = pm.GetUserResources(usage.user, usage.host)
+	}
+	if !usage.addQuestion(time.Now(), maxQuestions) {
+		return servererr.ErrUserLimitReached.FastGenByArgs(usage.user, "max_questions", maxQuestions)
+	}
+	return nil
+}
+
+// GetUserResourceUsage implements the SessionManager interface.
+func (s *Server) GetUserResourceUsage() []*util.UserResourceUsage {
+	s.userResourceMu.Lock()
+	defer s.userResourceMu.Unlock()
+	rs := make([]*util.UserResourceUsage, 0, len(s.userResources))
+	now := time.Now()
+	for _, usage := range s.userResources {
+		usage.mu.Lock()
+		r := &util.UserResourceUsage{
+			User:        usage.user,
+			Host:        usage.host,
+			Connections: usage.connections,
+			Questions:   usage.questions,
+			HourStart:   usage.hourStart,
+		}
+		usage.mu.Unlock()
+		if now.Sub(r.HourStart) >= time.Hour {
+			r.Questions, r.HourStart = 0, time.Time{}
+		}
+		rs = append(rs, r)
+	}
+	return rs
+}
+
+// isUserQuestion returns whether the command is counted by MAX_QUERIES_PER_HOUR.
+func isUserQuestion(cmd byte) bool {
+	return cmd == mysql.ComQuery || cmd == mysql.ComStmtExecute
+}
@@ -112,6 +112,8 @@ const (
 		Password_expired		ENUM('N','Y') NOT NULL DEFAULT 'N',
 		Password_last_changed	TIMESTAMP DEFAULT CURRENT_TIMESTAMP(),
 		Password_lifetime		SMALLINT UNSIGNED DEFAULT NULL,
+		max_questions			INT UNSIGNED NOT NULL DEFAULT 0,
+		max_user_connections	INT UNSIGNED NOT NULL DEFAULT 0,
 		PRIMARY KEY (Host, User));`
 	// CreateGlobalPrivTable is the SQL statement creates Global scope privilege table in system db.
 	CreateGlobalPrivTable = "CREATE TABLE IF NOT EXISTS mysql.global_priv (" +
@@ -687,6 +689,151 @@ const (
 		KEY (created_by),
 		KEY (status));`
 
+	// CreateMetricTableDefs stores the user-defined tables in METRICS_SCHEMA.
+	CreateMetricTableDefs = `CREATE TABLE IF NOT EXISTS mysql.metric_table_defs (
+		table_name VARCHAR(64) NOT NULL,
+		promql TEXT NOT NULL,
+		labels VARCHAR(512) NOT NULL DEFAULT '',
+		quantile DOUBLE NOT NULL DEFAULT 0,
+		comment VARCHAR(256) NOT NULL DEFAULT '',
+		PRIMARY KEY (table_name)
+	);`
+
+	// CreateCheckTableJobs stores the jobs of `ADMIN CHECK TABLE ... ONLINE`. The active_table_id is
+	// the table ID of a running or cancelled job and NULL otherwise, so a table has at most one unfinished job.
+	CreateCheckTableJobs = `CREATE TABLE IF NOT EXISTS mysql.tidb_check_table_jobs (
+		id bigint(64) NOT NULL AUTO_INCREMENT,
+		create_time TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		update_time TIMESTAMP(6) NULL DEFAULT NULL,
+		end_time TIMESTAMP(6) NULL DEFAULT NULL,
+		table_schema VARCHAR(64) NOT NULL,
+		table_name VARCHAR(64) NOT NULL,
+		table_id bigint(64) NOT NULL,
+		active_table_id bigint(64) DEFAULT NULL,
+		created_by VARCHAR(300) NOT NULL,
+		instance VARCHAR(512) NOT NULL DEFAULT '',
+		status VARCHAR(64) NOT NULL,
+		start_handle bigint(64) NOT NULL DEFAULT 0,
+		end_handle bigint(64) NOT NULL DEFAULT 0,
+		checkpoint bigint(64) NOT NULL DEFAULT 0,
+		checked_rows bigint(64) NOT NULL DEFAULT 0,
+		error_message TEXT DEFAULT NULL,
+		PRIMARY KEY (id),
+		KEY (table_id),
+		UNIQUE KEY (active_table_id),
+		KEY (status));`
+
+	// CreateMaskingPolicyTable stores the masking policies, one row for each role of a policy.
+	CreateMaskingPolicyTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_masking_policy (
+		policy_name VARCHAR(64) NOT NULL,
+		table_schema VARCHAR(64) NOT NULL,
+		table_name VARCHAR(64) NOT NULL,
+		column_name VARCHAR(64) NOT NULL,
+		masking_expr TEXT NOT NULL,
+		role_user CHAR(32) COLLATE utf8_bin NOT NULL DEFAULT '',
+		role_host CHAR(255) COLLATE utf8_bin NOT NULL DEFAULT '',
+		created_by VARCHAR(300) NOT NULL DEFAULT '',
+		create_time TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		PRIMARY KEY (policy_name, role_user, role_host),
+		KEY (table_schema, table_name));`
+
+	// CreateIPAccessListTable stores the client IP allow list and deny list checked at handshake.
+	// An empty cidr or vpce_id matches any client.
+	CreateIPAccessListTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_ip_access_list (
+		rule_type ENUM('ALLOW','DENY') NOT NULL,
+		cidr VARCHAR(64) NOT NULL DEFAULT '',
+		vpce_id VARCHAR(64) NOT NULL DEFAULT '',
+		comment VARCHAR(256) NOT NULL DEFAULT '',
+		PRIMARY KEY (rule_type, cidr, vpce_id));`
+
+	// CreateServersTable stores the servers created by CREATE SERVER, which are used by the FEDERATED tables.
+	CreateServersTable = `CREATE TABLE IF NOT EXISTS mysql.servers (
+		Server_name CHAR(64) NOT NULL DEFAULT '',
+		Host CHAR(255) NOT NULL DEFAULT '',
+		Db CHAR(64) NOT NULL DEFAULT '',
+		Username CHAR(64) NOT NULL DEFAULT '',
+		Password CHAR(64) NOT NULL DEFAULT '',
+		Port INT NOT NULL DEFAULT 0,
+		Socket CHAR(64) NOT NULL DEFAULT '',
+		Wrapper CHAR(64) NOT NULL DEFAULT '',
+		Owner CHAR(64) NOT NULL DEFAULT '',
+		PRIMARY KEY (Server_name));`
+
+	// CreateRoutinesTable stores the stored procedures created by CREATE PROCEDURE.
+	CreateRoutinesTable = `CREATE TABLE IF NOT EXISTS mysql.routines (
+		route_schema VARCHAR(64) NOT NULL,
+		name VARCHAR(64) NOT NULL,
+		type ENUM('FUNCTION','PROCEDURE') NOT NULL,
+		definition LONGTEXT NOT NULL,
+		parameter_str BLOB NOT NULL,
+		is_deterministic ENUM('YES','NO') NOT NULL DEFAULT 'NO',
+		security_type ENUM('INVOKER','DEFINER') NOT NULL DEFAULT 'DEFINER',
+		definer VARCHAR(288) NOT NULL DEFAULT '',
+		sql_mode VARCHAR(1024) NOT NULL DEFAULT '',
+		character_set_client VARCHAR(32) NOT NULL DEFAULT '',
+		connection_collation VARCHAR(32) NOT NULL DEFAULT '',
+		schema_collation VARCHAR(32) NOT NULL DEFAULT '',
+		created TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		last_altered TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		comment TEXT,
+		PRIMARY KEY (route_schema, name, type));`
+
+	// CreateEventsTable stores the events created by CREATE EVENT and the status of their last execution.
+	CreateEventsTable = `CREATE TABLE IF NOT EXISTS mysql.events (
+		event_schema VARCHAR(64) NOT NULL,
+		event_name VARCHAR(64) NOT NULL,
+		definer VARCHAR(288) NOT NULL DEFAULT '',
+		time_zone VARCHAR(64) NOT NULL DEFAULT 'SYSTEM',
+		body LONGTEXT NOT NULL,
+		execute_at TIMESTAMP NULL DEFAULT NULL,
+		interval_value BIGINT NULL DEFAULT NULL,
+		interval_field ENUM('YEAR','QUARTER','MONTH','WEEK','DAY','HOUR','MINUTE','SECOND') NULL DEFAULT NULL,
+		starts TIMESTAMP NULL DEFAULT NULL,
+		ends TIMESTAMP NULL DEFAULT NULL,
+		status ENUM('ENABLED','DISABLED') NOT NULL DEFAULT 'ENABLED',
+		on_completion ENUM('DROP','PRESERVE') NOT NULL DEFAULT 'DROP',
+		sql_mode VARCHAR(1024) NOT NULL DEFAULT '',
+		comment VARCHAR(2048) NOT NULL DEFAULT '',
+		character_set_client VARCHAR(32) NOT NULL DEFAULT '',
+		collation_connection VARCHAR(32) NOT NULL DEFAULT '',
+		db_collation VARCHAR(32) NOT NULL DEFAULT '',
+		created TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		last_altered TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		last_executed TIMESTAMP NULL DEFAULT NULL,
+		last_status ENUM('SUCCESS','FAILED') NULL DEFAULT NULL,
+		last_error TEXT,
+		PRIMARY KEY (event_schema, event_name));`
+
+	// CreateOptimizerTraceTable stores the optimizer traces generated by TRACE PLAN, keyed by the sql digest and plan digest.
+	CreateOptimizerTraceTable = `CREATE TABLE IF NOT EXISTS mysql.optimizer_trace (
+		sql_digest VARCHAR(128) NOT NULL,
+		plan_digest VARCHAR(128) NOT NULL,
+		origin_sql LONGTEXT NOT NULL,
+		trace LONGTEXT NOT NULL,
+		create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (sql_digest, plan_digest),
+		KEY (plan_digest));`
+
+	// CreateHotspotMitigationHistoryTable stores the write hotspots detected by the hotspot mitigation worker and the
+	// mitigations applied to them.
+	CreateHotspotMitigationHistoryTable = `CREATE TABLE IF NOT EXISTS mysql.hotspot_mitigation_history (
+		id BIGINT(20) NOT NULL AUTO_INCREMENT,
+		detect_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		table_schema VARCHAR(64) NOT NULL,
+		table_name VARCHAR(64) NOT NULL,
+		partition_name VARCHAR(64) NOT NULL DEFAULT '',
+		index_name VARCHAR(64) NOT NULL DEFAULT '',
+		region_id BIGINT(20) UNSIGNED NOT NULL,
+		flow_bytes BIGINT(20) UNSIGNED NOT NULL DEFAULT 0,
+		hotspot_type ENUM('AUTO_INCREMENT','TIMESTAMP') NOT NULL,
+		action ENUM('LOG','PRE_SPLIT','ENLARGE_AUTO_ID_CACHE') NOT NULL,
+		statement TEXT,
+		status ENUM('SUCCESS','FAILED') NOT NULL DEFAULT 'SUCCESS',
+		error TEXT,
+		PRIMARY KEY (id),
+		KEY (detect_time),
+		KEY (table_schema, table_name));`
+
 	// DropMySQLIndexUsageTable removes the table `mysql.schema_index_usage`
 	DropMySQLIndexUsageTable = "DROP TABLE IF EXISTS mysql.schema_index_usage"
 
@@ -1074,11 +1221,51 @@ const (
 	//   create `sys` schema
 	//   create `sys.schema_unused_indexes` table
 	version185 = 185
+
+	// version 186
+	//   create `mysql.metric_table_defs` table
+	version186 = 186
+
+	// version 187
+	//   create `mysql.tidb_check_table_jobs` table
+	version187 = 187
+
+	// version 188
+	//   create `mysql.tidb_masking_policy` table
+	version188 = 188
+
+	// version 189
+	//   create `mysql.tidb_ip_access_list` table
+	version189 = 189
+
+	// version 190
+	//   add `max_questions` and `max_user_connections` columns to `mysql.user`
+	version190 = 190
+
+	// version 191
+	//   create `mysql.servers` table
+	version191 = 191
+
+	// version 192
+	//   create `mysql.routines` table
+	version192 = 192
+
+	// version 193
+	//   create `mysql.events` table
+	version193 = 193
+
+	// version 194
+	//   create `mysql.optimizer_trace` table
+	version194 = 194
+
+	// version 195
+	//   create `mysql.hotspot_mitigation_history` table
+	version195 = 195
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version185
+var currentBootstrapVersion int64 = version195
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -1239,6 +1426,16 @@ var (
 		upgradeToVer183,
 		upgradeToVer184,
 		upgradeToVer185,
+		upgradeToVer186,
+		upgradeToVer187,
+		upgradeToVer188,
+		upgradeToVer189,
+		upgradeToVer190,
+		upgradeToVer191,
+		upgradeToVer192,
+		upgradeToVer193,
+		upgradeToVer194,
+		upgradeToVer195,
 	}
 )
 
@@ -3012,6 +3209,77 @@ func upgradeToVer185(s sessiontypes.Session, ver int64) {
 	doReentrantDDL(s, DropMySQLIndexUsageTable)
 }
 
+func upgradeToVer186(s sessiontypes.Session, ver int64) {
+	if ver >= version186 {
+		return
+	}
+	doReentrantDDL(s, CreateMetricTableDefs)
+}
+
+func upgradeToVer187(s sessiontypes.Session, ver int64) {
+	if ver >= version187 {
+		return
+	}
+	doReentrantDDL(s, CreateCheckTableJobs)
+}
+
+func upgradeToVer188(s sessiontypes.Session, ver int64) {
+	if ver >= version188 {
+		return
+	}
+	doReentrantDDL(s, CreateMaskingPolicyTable)
+}
+
+func upgradeToVer189(s sessiontypes.Session, ver int64) {
+	if ver >= version189 {
+		return
+	}
+	doReentrantDDL(s, CreateIPAccessListTable)
+}
+
+func upgradeToVer190(s sessiontypes.Session, ver int64) {
+	if ver >= version190 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.user ADD COLUMN IF NOT EXISTS `max_questions` INT UNSIGNED NOT NULL DEFAULT 0")
+	doReentrantDDL(s, "ALTER TABLE mysql.user ADD COLUMN IF NOT EXISTS `max_user_connections` INT UNSIGNED NOT NULL DEFAULT 0")
+}
+
+func upgradeToVer191(s sessiontypes.Session, ver int64) {
+	if ver >= version191 {
+		return
+	}
+	doReentrantDDL(s, CreateServersTable)
+}
+
+func upgradeToVer192(s sessiontypes.Session, ver int64) {
+	if ver >= version192 {
+		return
+	}
+	doReentrantDDL(s, CreateRoutinesTable)
+}
+
+func upgradeToVer193(s sessiontypes.Session, ver int64) {
+	if ver >= version193 {
+		return
+	}
+	doReentrantDDL(s, CreateEventsTable)
+}
+
+func upgradeToVer194(s sessiontypes.Session, ver int64) {
+	if ver >= version194 {
+		return
+	}
+	doReentrantDDL(s, CreateOptimizerTraceTable)
+}
+
+func upgradeToVer195(s sessiontypes.Session, ver int64) {
+	if ver >= version195 {
+		return
+	}
+	doReentrantDDL(s, CreateHotspotMitigationHistoryTable)
+}
+
 func writeOOMAction(s sessiontypes.Session) {
 	comment := "oom-action is `log` by default in v3.0.x, `cancel` by default in v4.0.11+"
 	mustExecute(s, `INSERT HIGH_PRIORITY INTO %n.%n VALUES (%?, %?, %?) ON DUPLICATE KEY UPDATE VARIABLE_VALUE= %?`,
@@ -3139,6 +3407,24 @@ func doDDLWorks(s sessiontypes.Session) {
 	mustExecute(s, CreateDistFrameworkMeta)
 	// create request_unit_by_group
 	mustExecute(s, CreateRequestUnitByGroupTable)
+	// create metric_table_defs
+	mustExecute(s, CreateMetricTableDefs)
+	// create tidb_check_table_jobs
+	mustExecute(s, CreateCheckTableJobs)
+	// create tidb_masking_policy
+	mustExecute(s, CreateMaskingPolicyTable)
+	// create tidb_ip_access_list
+	mustExecute(s, CreateIPAccessListTable)
+	// create servers
+	mustExecute(s, CreateServersTable)
+	// create routines
+	mustExecute(s, CreateRoutinesTable)
+	// create events
+	mustExecute(s, CreateEventsTable)
+	// create optimizer_trace
+	mustExecute(s, CreateOptimizerTraceTable)
+	// create hotspot_mitigation_history
+	mustExecute(s, CreateHotspotMitigationHistoryTable)
 	// create `sys` schema
 	mustExecute(s, CreateSysSchema)
 	// create `sys.schema_unused_indexes` view
@@ -0,0 +1,66 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+// runEvent executes the body of an event in a new session. Like MySQL, the body is executed in the schema
+// of the event, with the SQL mode and time zone when the event was created and the privileges of the definer.
+func runEvent(ctx context.Context, store kv.Storage, event *domain.EventInfo) error {
+	// the session is created with a privilege manager, so the body is checked with the privileges of the definer.
+	se, err := CreateSession(store)
+	if err != nil {
+		return err
+	}
+	defer se.Close()
+	sessVars := se.GetSessionVars()
+	if err := sessVars.SetSystemVar(variable.SQLModeVar, event.SQLMode); err != nil {
+		return err
+	}
+	if err := sessVars.SetSystemVar(variable.TimeZone, event.TimeZone); err != nil {
+		return err
+	}
+	if pm := privilege.GetPrivilegeManager(se); pm != nil && event.Definer != "" {
+		user := &auth.UserIdentity{Username: event.Definer}
+		if idx := strings.LastIndex(event.Definer, "@"); idx >= 0 {
+			user.Username, user.Hostname = event.Definer[:idx], event.Definer[idx+1:]
+		}
+		if !se.AuthWithoutVerification(user) {
+			return exeerrors.ErrNoSuchUser.GenWithStackByArgs(user.Username, user.Hostname)
+		}
+	}
+	sessVars.CurrentDB = event.Schema
+
+	// the event body is parsed as the body of a procedure, so the compound statements can be executed by
+	// the procedure executor.
+	stmts, err := se.Parse(ctx, fmt.Sprintf("CREATE PROCEDURE p() %s", event.Body))
+	if err != nil {
+		return err
+	}
+	e := &procedureExecutor{se: se}
+	return e.execStmt(ctx, &procedureFrame{}, stmts[0].(*ast.ProcedureInfo).ProcedureBody)
+}
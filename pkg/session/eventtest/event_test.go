@@ -0,0 +1,181 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDropEvent(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("set time_zone = '+00:00'")
+
+	tk.MustExec("create event ev1 on schedule every 10 minute starts '2030-01-01 00:00:00' ends '2031-01-01 00:00:00' comment 'c' do insert into t values (1)")
+	tk.MustExec("create event test.ev2 on schedule at '2030-01-01 00:00:00' on completion preserve disable do begin delete from t; end")
+	tk.MustQuery("select event_schema, event_name, definer, time_zone, event_definition, event_type, execute_at, interval_value, interval_field, " +
+		"starts, ends, status, on_completion, event_comment from information_schema.events order by event_name").Check(testkit.Rows(
+		"test ev1 root@% +00:00 insert into t values (1) RECURRING <nil> 10 MINUTE 2030-01-01 00:00:00 2031-01-01 00:00:00 ENABLED NOT PRESERVE c",
+		"test ev2 root@% +00:00 begin delete from t; end ONE TIME 2030-01-01 00:00:00 <nil> <nil> <nil> <nil> DISABLED PRESERVE ",
+	))
+	// the times are shown in the session time zone.
+	tk.MustExec("set time_zone = '+08:00'")
+	tk.MustQuery("select execute_at from information_schema.events where event_name = 'ev2'").Check(testkit.Rows("2030-01-01 08:00:00"))
+
+	tk.MustGetErrCode("create event ev1 on schedule every 1 day do select 1", errno.ErrEventAlreadyExists)
+	tk.MustExec("create event if not exists ev1 on schedule every 1 day do select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1537 Event 'ev1' already exists"))
+	tk.MustGetErrCode("create event ev3 on schedule every 0 day do select 1", errno.ErrEventIntervalNotPositiveOrTooBig)
+	tk.MustGetErrCode("create event ev3 on schedule every 1 day starts '2030-01-02' ends '2030-01-01' do select 1", errno.ErrEventEndsBeforeStarts)
+	tk.MustGetErrCode("create event ev3 on schedule every 1 day_hour do select 1", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create event ev3 on schedule every 1 day do drop event ev1", errno.ErrParse)
+	tk.MustGetErrCode("create event not_exist_db.ev3 on schedule every 1 day do select 1", errno.ErrBadDB)
+
+	// the events whose schedule is in the past are dropped immediately, or disabled if they are preserved.
+	tk.MustExec("create event ev3 on schedule at '2000-01-01 00:00:00' do select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1588 Event execution time is in the past and ON COMPLETION NOT PRESERVE is set. The event was dropped immediately after creation."))
+	tk.MustExec("create event ev3 on schedule at '2000-01-01 00:00:00' on completion preserve do select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1544 Event execution time is in the past. Event has been disabled"))
+	tk.MustQuery("select status from information_schema.events where event_name = 'ev3'").Check(testkit.Rows("DISABLED"))
+
+	tk.MustExec("drop event ev1")
+	tk.MustExec("drop event test.ev2")
+	tk.MustExec("drop event ev3")
+	tk.MustQuery("select count(*) from information_schema.events").Check(testkit.Rows("0"))
+	tk.MustGetErrCode("drop event ev1", errno.ErrEventDoesNotExist)
+	tk.MustExec("drop event if exists ev1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1539 Unknown event 'ev1'"))
+}
+
+func TestRunDueEvents(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key auto_increment, v varchar(10))")
+	dom := domain.GetDomain(tk.Session())
+
+	tk.MustExec(`create event ev1 on schedule every 1 minute do
+begin
+	declare n int default 0;
+	set n = (select count(*) from t);
+	insert into t (v) values (concat('r', n));
+end`)
+	now := time.Now()
+	require.NoError(t, dom.RunDueEvents(now))
+	tk.MustQuery("select v from t").Check(testkit.Rows("r0"))
+	tk.MustQuery("select tidb_last_status, tidb_last_error, last_executed is not null from information_schema.events").Check(testkit.Rows("SUCCESS <nil> 1"))
+	// the event is not due until the next minute.
+	require.NoError(t, dom.RunDueEvents(now))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("1"))
+	require.NoError(t, dom.RunDueEvents(now.Add(time.Minute)))
+	tk.MustQuery("select v from t order by id").Check(testkit.Rows("r0", "r1"))
+	tk.MustExec("drop event ev1")
+
+	// the one-time event is dropped after it's executed, or disabled if it's preserved.
+	tk.MustExec("create event ev2 on schedule at now() + interval 1 hour do insert into t (v) values ('ev2')")
+	tk.MustExec("create event ev3 on schedule at now() + interval 1 hour on completion preserve do insert into t (v) values ('ev3')")
+	require.NoError(t, dom.RunDueEvents(now))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("2"))
+	require.NoError(t, dom.RunDueEvents(now.Add(2*time.Hour)))
+	tk.MustQuery("select v from t where v like 'ev%' order by v").Check(testkit.Rows("ev2", "ev3"))
+	tk.MustQuery("select event_name, status, tidb_last_status from information_schema.events").Check(testkit.Rows("ev3 DISABLED SUCCESS"))
+	require.NoError(t, dom.RunDueEvents(now.Add(3*time.Hour)))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("4"))
+	tk.MustExec("drop event ev3")
+
+	// the failures are recorded, and the event is still executed on schedule.
+	tk.MustExec("create event ev4 on schedule every 1 minute do insert into not_exist values (1)")
+	require.NoError(t, dom.RunDueEvents(time.Now()))
+	tk.MustQuery("select event_name, status, tidb_last_status, tidb_last_error from information_schema.events").Check(testkit.Rows(
+		"ev4 ENABLED FAILED [schema:1146]Table 'test.not_exist' doesn't exist"))
+}
+
+func TestEventDefinerPrivileges(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key auto_increment, v varchar(10))")
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("create user u")
+	tk.MustExec("grant event on test.* to u")
+	tk.MustExec("grant select on test.t to u")
+	dom := domain.GetDomain(tk.Session())
+
+	// the body is checked with the privileges of the definer rather than the ones of the scheduler.
+	tku := testkit.NewTestKit(t, store)
+	require.NoError(t, tku.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "%"}, nil, nil, nil))
+	tku.MustExec("create event test.ev on schedule every 1 minute do insert into t (v) values ('ev')")
+	now := time.Now()
+	require.NoError(t, dom.RunDueEvents(now))
+	tk.MustQuery("select definer, tidb_last_status, tidb_last_error from information_schema.events").Check(testkit.Rows(
+		"u@% FAILED [planner:1142]INSERT command denied to user 'u'@'%' for table 't'"))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("0"))
+
+	tk.MustExec("grant insert on test.t to u")
+	require.NoError(t, dom.RunDueEvents(now.Add(time.Minute)))
+	tk.MustQuery("select tidb_last_status, tidb_last_error from information_schema.events").Check(testkit.Rows("SUCCESS <nil>"))
+	tk.MustQuery("select v from t").Check(testkit.Rows("ev"))
+
+	// the statements in a compound body are checked one by one.
+	tku.MustExec("drop event test.ev")
+	tku.MustExec("create event test.ev on schedule every 1 minute do begin insert into t (v) values ('ev2'); delete from t2; end")
+	require.NoError(t, dom.RunDueEvents(now))
+	tk.MustQuery("select tidb_last_status, tidb_last_error from information_schema.events").Check(testkit.Rows(
+		"FAILED [planner:1142]DELETE command denied to user 'u'@'%' for table 't2'"))
+	tk.MustQuery("select v from t order by id").Check(testkit.Rows("ev", "ev2"))
+
+	// the privileges revoked after the creation are effective.
+	tk.MustExec("revoke insert on test.t from u")
+	require.NoError(t, dom.RunDueEvents(now.Add(time.Minute)))
+	tk.MustQuery("select tidb_last_status, tidb_last_error from information_schema.events").Check(testkit.Rows(
+		"FAILED [planner:1142]INSERT command denied to user 'u'@'%' for table 't'"))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("2"))
+
+	// the event fails if the definer is dropped.
+	tk.MustExec("drop user u")
+	require.NoError(t, dom.RunDueEvents(now.Add(2*time.Minute)))
+	tk.MustQuery("select tidb_last_status, tidb_last_error from information_schema.events").Check(testkit.Rows(
+		"FAILED [executor:1449]The user specified as a definer ('u'@'%') does not exist"))
+}
+
+func TestEventScheduler(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+
+	tk.MustExec("create event ev on schedule every 1 second do insert into t values (1)")
+	// the events are not executed when the event scheduler is OFF.
+	time.Sleep(2 * time.Second)
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("0"))
+
+	tk.MustExec("set global event_scheduler = on")
+	defer tk.MustExec("set global event_scheduler = off")
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from t").Rows()) > 0
+	}, 10*time.Second, 100*time.Millisecond)
+	tk.MustQuery("select tidb_last_status from information_schema.events").Check(testkit.Rows("SUCCESS"))
+}
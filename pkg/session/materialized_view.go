@@ -0,0 +1,50 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+)
+
+// refreshMaterializedView executes REFRESH MATERIALIZED VIEW in a new session with the privileges of
+// the definer of the view, so the data is selected the same way as it's selected by the definer.
+func refreshMaterializedView(ctx context.Context, store kv.Storage, schema model.CIStr, tblInfo *model.TableInfo) error {
+	se, err := CreateSession(store)
+	if err != nil {
+		return err
+	}
+	defer se.Close()
+	if definer := tblInfo.MaterializedView.Definer; privilege.GetPrivilegeManager(se) != nil && definer != nil {
+		if !se.AuthWithoutVerification(definer) {
+			return exeerrors.ErrNoSuchUser.GenWithStackByArgs(definer.Username, definer.Hostname)
+		}
+	}
+	stmts, err := se.Parse(ctx, sqlescape.MustEscapeSQL("REFRESH MATERIALIZED VIEW %n.%n", schema.O, tblInfo.Name.O))
+	if err != nil {
+		return err
+	}
+	rs, err := se.ExecuteStmt(ctx, stmts[0])
+	if rs != nil {
+		terror.Call(rs.Close)
+	}
+	return err
+}
@@ -0,0 +1,539 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/extension"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/privilege/privileges"
+	sessiontypes "github.com/pingcap/tidb/pkg/session/types"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// HandleCallProcedure executes a CALL statement. The statements in the procedure body are executed one by one
+// through the session, with the local variables and parameters replaced by their current values.
+// Only the result set of the last statement which returns rows is returned to the client.
+func HandleCallProcedure(ctx context.Context, stmt *ast.CallStmt, se sessiontypes.Session) (sqlexec.RecordSet, error) {
+	e := &procedureExecutor{se: se}
+	if err := e.call(ctx, stmt, nil); err != nil {
+		return nil, err
+	}
+	return e.result, nil
+}
+
+// procedureVar is a parameter or a local variable of a stored procedure.
+type procedureVar struct {
+	tp  *types.FieldType
+	val types.Datum
+}
+
+// procedureFrame holds the variables of a running stored procedure, each BEGIN ... END block opens a new scope.
+type procedureFrame struct {
+	scopes []map[string]*procedureVar
+}
+
+func (f *procedureFrame) lookup(name string) *procedureVar {
+	if f == nil {
+		return nil
+	}
+	name = strings.ToLower(name)
+	for i := len(f.scopes) - 1; i >= 0; i-- {
+		if v, ok := f.scopes[i][name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func (f *procedureFrame) pushScope() map[string]*procedureVar {
+	scope := make(map[string]*procedureVar)
+	f.scopes = append(f.scopes, scope)
+	return scope
+}
+
+func (f *procedureFrame) popScope() {
+	f.scopes = f.scopes[:len(f.scopes)-1]
+}
+
+type procedureExecutor struct {
+	se sessiontypes.Session
+	// callStack is the names of the procedures being called, it's used to limit the recursion.
+	callStack []string
+	result    sqlexec.RecordSet
+}
+
+// procedureOutArg is an OUT or INOUT argument which is assigned when the procedure returns.
+type procedureOutArg struct {
+	param   string
+	userVar string
+	local   *procedureVar
+}
+
+func (e *procedureExecutor) call(ctx context.Context, stmt *ast.CallStmt, caller *procedureFrame) error {
+	sessVars := e.se.GetSessionVars()
+	schema, name := stmt.Procedure.Schema, stmt.Procedure.FnName
+	if schema.L == "" {
+		if sessVars.CurrentDB == "" {
+			return plannererrors.ErrNoDB
+		}
+		schema = model.NewCIStr(sessVars.CurrentDB)
+	}
+	fullName := schema.O + "." + name.O
+	if checker := privilege.GetPrivilegeManager(e.se); checker != nil && sessVars.User != nil &&
+		!checker.RequestVerification(sessVars.ActiveRoles, schema.L, name.L, "", mysql.ExecutePriv) {
+		return exeerrors.ErrProcaccessDenied.GenWithStackByArgs("execute", sessVars.User.AuthUsername, sessVars.User.AuthHostname, fullName)
+	}
+	proc, err := e.loadProcedure(ctx, schema.L, name.L, fullName)
+	if err != nil {
+		return err
+	}
+	info := proc.info
+	if len(stmt.Procedure.Args) != len(info.ProcedureParam) {
+		return exeerrors.ErrSpWrongNoOfArgs.GenWithStackByArgs("PROCEDURE", fullName, len(info.ProcedureParam), len(stmt.Procedure.Args))
+	}
+
+	depth := 0
+	for _, n := range e.callStack {
+		if n == strings.ToLower(fullName) {
+			depth++
+		}
+	}
+	if depth > 0 {
+		limitStr, err := sessVars.GetSessionOrGlobalSystemVar(ctx, variable.MaxSpRecursionDepth)
+		if err != nil {
+			return err
+		}
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if depth > limit {
+			return exeerrors.ErrSpRecursionLimit.GenWithStackByArgs(limit, name.O)
+		}
+	}
+
+	frame := &procedureFrame{}
+	params := frame.pushScope()
+	outArgs := make([]procedureOutArg, 0)
+	for i, param := range info.ProcedureParam {
+		v := &procedureVar{tp: e.varType(param.ParamType)}
+		arg := stmt.Procedure.Args[i]
+		if param.Paramstatus != ast.MODE_IN {
+			out := procedureOutArg{param: strings.ToLower(param.ParamName)}
+			switch x := arg.(type) {
+			case *ast.VariableExpr:
+				if !x.IsSystem {
+					out.userVar = strings.ToLower(x.Name)
+				}
+			case *ast.ColumnNameExpr:
+				if x.Name.Table.L == "" {
+					out.local = caller.lookup(x.Name.Name.L)
+				}
+			}
+			if out.userVar == "" && out.local == nil {
+				return exeerrors.ErrSpNotVarArg.GenWithStackByArgs(i+1, fullName)
+			}
+			outArgs = append(outArgs, out)
+		}
+		if param.Paramstatus != ast.MODE_OUT {
+			d, err := e.evalExpr(ctx, caller, arg)
+			if err != nil {
+				return err
+			}
+			if err := e.assign(v, d); err != nil {
+				return err
+			}
+		}
+		params[strings.ToLower(param.ParamName)] = v
+	}
+
+	restore, err := e.switchContext(proc, schema.O)
+	if err != nil {
+		return err
+	}
+	e.callStack = append(e.callStack, strings.ToLower(fullName))
+	err = e.execStmt(ctx, frame, info.ProcedureBody)
+	e.callStack = e.callStack[:len(e.callStack)-1]
+	if restoreErr := restore(); err == nil {
+		err = restoreErr
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, out := range outArgs {
+		v := params[out.param]
+		if out.local != nil {
+			if err := e.assign(out.local, v.val); err != nil {
+				return err
+			}
+			continue
+		}
+		if v.val.IsNull() {
+			sessVars.UnsetUserVar(out.userVar)
+			continue
+		}
+		sessVars.SetUserVarVal(out.userVar, *v.val.Clone())
+		sessVars.SetUserVarType(out.userVar, v.tp.Clone())
+	}
+	return nil
+}
+
+// storedProcedure is a procedure read from `mysql.routines`.
+type storedProcedure struct {
+	info    *ast.ProcedureInfo
+	sqlMode string
+	definer string
+}
+
+// loadProcedure reads the definition of a procedure from `mysql.routines` and parses it.
+func (e *procedureExecutor) loadProcedure(ctx context.Context, schema, name, fullName string) (*storedProcedure, error) {
+	exec := e.se.(sqlexec.RestrictedSQLExecutor)
+	internalCtx := kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := exec.ExecRestrictedSQL(internalCtx, nil,
+		`SELECT definition, parameter_str, sql_mode, security_type, definer FROM mysql.routines WHERE route_schema=%? AND name=%? AND type='PROCEDURE'`, schema, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, exeerrors.ErrSpDoesNotExist.GenWithStackByArgs("PROCEDURE", fullName)
+	}
+	proc := &storedProcedure{
+		sqlMode: rows[0].GetString(2),
+		definer: rows[0].GetString(4),
+	}
+	sqlMode, err := mysql.GetSQLMode(proc.sqlMode)
+	if err != nil {
+		return nil, err
+	}
+	p := parser.New()
+	p.SetSQLMode(sqlMode)
+	p.SetParserConfig(e.se.GetSessionVars().BuildParserConfig())
+	// the name doesn't matter, only the parameters, the security type and the body are used.
+	sql := fmt.Sprintf("CREATE PROCEDURE p(%s) SQL SECURITY %s %s", rows[0].GetString(1), rows[0].GetEnum(3).String(), rows[0].GetString(0))
+	stmt, err := p.ParseOneStmt(sql, "", "")
+	if err != nil {
+		return nil, err
+	}
+	proc.info = stmt.(*ast.ProcedureInfo)
+	return proc, nil
+}
+
+// switchContext switches the session to the context the body of a procedure is executed in. Like MySQL, the body
+// is executed in the schema of the procedure, with the SQL mode when the procedure was created and, for the
+// SQL SECURITY DEFINER procedures, the privileges of the definer. The returned function switches it back.
+func (e *procedureExecutor) switchContext(proc *storedProcedure, schema string) (func() error, error) {
+	sessVars := e.se.GetSessionVars()
+	oldSQLMode, err := sessVars.SetSystemVarWithOldValAsRet(variable.SQLModeVar, proc.sqlMode)
+	if err != nil {
+		return nil, err
+	}
+	currentDB := sessVars.CurrentDB
+	sessVars.CurrentDB = schema
+	restore := func() error {
+		sessVars.CurrentDB = currentDB
+		return sessVars.SetSystemVar(variable.SQLModeVar, oldSQLMode)
+	}
+
+	pm := privilege.GetPrivilegeManager(e.se)
+	if pm == nil || proc.info.SQLSecurity != model.SecurityDefiner || proc.definer == "" {
+		return restore, nil
+	}
+	extensions, err := extension.GetExtensions()
+	if err != nil {
+		terror.Log(restore())
+		return nil, err
+	}
+	// the privilege manager keeps the authenticated user, so a new one is bound for the definer.
+	user, roles := sessVars.User, sessVars.ActiveRoles
+	privilege.BindPrivilegeManager(e.se, privileges.NewUserPrivileges(domain.GetDomain(e.se).PrivilegeHandle(), extensions))
+	restoreUser := func() error {
+		privilege.BindPrivilegeManager(e.se, pm)
+		sessVars.User, sessVars.ActiveRoles = user, roles
+		return restore()
+	}
+	definer := &auth.UserIdentity{Username: proc.definer}
+	if idx := strings.LastIndex(proc.definer, "@"); idx >= 0 {
+		definer.Username, definer.Hostname = proc.definer[:idx], proc.definer[idx+1:]
+	}
+	if !e.se.AuthWithoutVerification(definer) {
+		terror.Log(restoreUser())
+		return nil, exeerrors.ErrNoSuchUser.GenWithStackByArgs(definer.Username, definer.Hostname)
+	}
+	return restoreUser, nil
+}
+
+func (e *procedureExecutor) execStmts(ctx context.Context, frame *procedureFrame, stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := e.execStmt(ctx, frame, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *procedureExecutor) execStmt(ctx context.Context, frame *procedureFrame, stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		scope := frame.pushScope()
+		defer frame.popScope()
+		for _, v := range x.ProcedureVars {
+			decl, ok := v.(*ast.ProcedureDecl)
+			if !ok {
+				return dbterror.ErrNotSupportedYet.GenWithStackByArgs("cursors and handlers in stored procedures")
+			}
+			var d types.Datum
+			if decl.DeclDefault != nil {
+				var err error
+				if d, err = e.evalExpr(ctx, frame, decl.DeclDefault); err != nil {
+					return err
+				}
+			}
+			for _, name := range decl.DeclNames {
+				pv := &procedureVar{tp: e.varType(decl.DeclType)}
+				if err := e.assign(pv, d); err != nil {
+					return err
+				}
+				scope[strings.ToLower(name)] = pv
+			}
+		}
+		return e.execStmts(ctx, frame, x.ProcedureProcStmts)
+	case *ast.ProcedureIfInfo:
+		return e.execStmt(ctx, frame, x.IfBody)
+	case *ast.ProcedureIfBlock:
+		cond, err := e.evalCondition(ctx, frame, x.IfExpr)
+		if err != nil {
+			return err
+		}
+		if cond {
+			return e.execStmts(ctx, frame, x.ProcedureIfStmts)
+		}
+		if x.ProcedureElseStmt != nil {
+			return e.execStmt(ctx, frame, x.ProcedureElseStmt)
+		}
+		return nil
+	case *ast.ProcedureElseIfBlock:
+		return e.execStmt(ctx, frame, x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return e.execStmts(ctx, frame, x.ProcedureIfStmts)
+	case *ast.ProcedureWhileStmt:
+		for {
+			cond, err := e.evalCondition(ctx, frame, x.Condition)
+			if err != nil || !cond {
+				return err
+			}
+			if err := e.execStmts(ctx, frame, x.Body); err != nil {
+				return err
+			}
+		}
+	case *ast.CallStmt:
+		return e.call(ctx, x, frame)
+	case *ast.SetStmt:
+		return e.execSet(ctx, frame, x)
+	case *ast.ProcedureOpenCur, *ast.ProcedureCloseCur, *ast.ProcedureFetchInto,
+		*ast.SimpleCaseStmt, *ast.SearchCaseStmt, *ast.ProcedureRepeatStmt,
+		*ast.ProcedureLabelBlock, *ast.ProcedureLabelLoop, *ast.ProcedureJump:
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs("cursors, CASE, REPEAT and labels in stored procedures")
+	}
+	return e.execSQL(ctx, frame, stmt)
+}
+
+// execSet assigns the local variables in a SET statement, the other variables are set through the session.
+func (e *procedureExecutor) execSet(ctx context.Context, frame *procedureFrame, stmt *ast.SetStmt) error {
+	for _, va := range stmt.Variables {
+		if va.IsSystem && !va.IsGlobal {
+			if v := frame.lookup(va.Name); v != nil {
+				d, err := e.evalExpr(ctx, frame, va.Value)
+				if err != nil {
+					return err
+				}
+				if err := e.assign(v, d); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if err := e.execSQL(ctx, frame, &ast.SetStmt{Variables: []*ast.VariableAssignment{va}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execSQL executes a statement through the session. The statement is restored and parsed again, so the
+// variables can be replaced without modifying the procedure body which may be executed many times.
+// The rows of the result set are buffered because the next statement is executed before they are read.
+func (e *procedureExecutor) execSQL(ctx context.Context, frame *procedureFrame, stmt ast.StmtNode) error {
+	var sb strings.Builder
+	if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return err
+	}
+	rs, err := e.execReplaced(ctx, frame, sb.String())
+	if err != nil || rs == nil {
+		return err
+	}
+	defer terror.Call(rs.Close)
+	fields := rs.Fields()
+	fieldTypes := make([]*types.FieldType, 0, len(fields))
+	for _, field := range fields {
+		fieldTypes = append(fieldTypes, &field.Column.FieldType)
+	}
+	maxChunkSize := e.se.GetSessionVars().BatchSize.MaxChunkSize
+	rows, err := sqlexec.DrainRecordSet(ctx, rs, maxChunkSize)
+	if err != nil {
+		return err
+	}
+	result := &sqlexec.SimpleRecordSet{
+		ResultFields: fields,
+		Rows:         make([][]any, 0, len(rows)),
+		MaxChunkSize: maxChunkSize,
+	}
+	for _, row := range rows {
+		datums := row.GetDatumRow(fieldTypes)
+		values := make([]any, 0, len(datums))
+		for _, d := range datums {
+			values = append(values, d.GetValue())
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	e.result = result
+	return nil
+}
+
+func (e *procedureExecutor) execReplaced(ctx context.Context, frame *procedureFrame, sql string) (sqlexec.RecordSet, error) {
+	stmts, err := e.se.Parse(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	if len(stmts) != 1 {
+		return nil, errors.Errorf("unexpected statement count %d in stored procedure", len(stmts))
+	}
+	newStmt, _ := stmts[0].Accept(&procedureVarReplacer{frame: frame})
+	return e.se.ExecuteStmt(ctx, newStmt.(ast.StmtNode))
+}
+
+// evalExpr evaluates an expression by executing `SELECT expr` through the session.
+func (e *procedureExecutor) evalExpr(ctx context.Context, frame *procedureFrame, expr ast.ExprNode) (types.Datum, error) {
+	var d types.Datum
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	if err := expr.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return d, err
+	}
+	rs, err := e.execReplaced(ctx, frame, sb.String())
+	if err != nil || rs == nil {
+		return d, err
+	}
+	defer terror.Call(rs.Close)
+	rows, err := sqlexec.DrainRecordSet(ctx, rs, 1)
+	if err != nil || len(rows) == 0 {
+		return d, err
+	}
+	d = rows[0].GetDatum(0, &rs.Fields()[0].Column.FieldType)
+	return *d.Clone(), nil
+}
+
+func (e *procedureExecutor) evalCondition(ctx context.Context, frame *procedureFrame, expr ast.ExprNode) (bool, error) {
+	d, err := e.evalExpr(ctx, frame, expr)
+	if err != nil || d.IsNull() {
+		return false, err
+	}
+	b, err := d.ToBool(e.se.GetSessionVars().StmtCtx.TypeCtx())
+	return b != 0, err
+}
+
+// assign converts the value to the type of the variable and assigns it.
+func (e *procedureExecutor) assign(v *procedureVar, d types.Datum) error {
+	if d.IsNull() {
+		v.val.SetNull()
+		return nil
+	}
+	converted, err := d.ConvertTo(e.se.GetSessionVars().StmtCtx.TypeCtx(), v.tp)
+	if err != nil {
+		return err
+	}
+	v.val = converted
+	return nil
+}
+
+// varType fills the charset, collation, length and decimal left unspecified in the type of a variable.
+func (e *procedureExecutor) varType(tp *types.FieldType) *types.FieldType {
+	ft := tp.Clone()
+	if ft.GetCharset() == "" {
+		if ft.EvalType() == types.ETString && !mysql.HasBinaryFlag(ft.GetFlag()) {
+			chs, coll := e.se.GetSessionVars().GetCharsetInfo()
+			ft.SetCharset(chs)
+			ft.SetCollate(coll)
+		} else {
+			ft.SetCharset(charset.CharsetBin)
+			ft.SetCollate(charset.CollationBin)
+		}
+	}
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
+	if ft.GetFlen() == types.UnspecifiedLength {
+		ft.SetFlen(defaultFlen)
+	}
+	if ft.GetDecimal() == types.UnspecifiedLength {
+		ft.SetDecimal(defaultDecimal)
+	}
+	return ft
+}
+
+// procedureVarReplacer replaces the references of the procedure variables with their values.
+type procedureVarReplacer struct {
+	frame *procedureFrame
+}
+
+// Enter implements ast.Visitor interface.
+func (r *procedureVarReplacer) Enter(in ast.Node) (ast.Node, bool) {
+	// keep the name of the output column as the name of the variable.
+	if field, ok := in.(*ast.SelectField); ok && field.AsName.L == "" {
+		if col, ok := field.Expr.(*ast.ColumnNameExpr); ok && col.Name.Table.L == "" && r.frame.lookup(col.Name.Name.L) != nil {
+			field.AsName = col.Name.Name
+		}
+	}
+	return in, false
+}
+
+// Leave implements ast.Visitor interface.
+func (r *procedureVarReplacer) Leave(in ast.Node) (ast.Node, bool) {
+	if col, ok := in.(*ast.ColumnNameExpr); ok && col.Name.Table.L == "" {
+		if v := r.frame.lookup(col.Name.Name.L); v != nil {
+			return ast.NewValueExpr(v.val.GetValue(), v.tp.GetCharset(), v.tp.GetCollate()), true
+		}
+	}
+	return in, true
+}
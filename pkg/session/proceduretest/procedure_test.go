@@ -0,0 +1,219 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proceduretest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDropProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+
+	tk.MustExec("create procedure p1(in a int, out b varchar(10)) begin set b = concat('v', a); end")
+	tk.MustQuery("select routine_schema, routine_name, routine_type, routine_definition, security_type, definer from information_schema.routines").
+		Check(testkit.Rows("test p1 PROCEDURE begin set b = concat('v', a); end DEFINER root@%"))
+	tk.MustQuery("select route_schema, name, parameter_str from mysql.routines").Check(testkit.Rows("test p1 in a int, out b varchar(10)"))
+
+	tk.MustExec("create procedure p2(in a int) sql security invoker select a")
+	tk.MustQuery("select parameter_str, security_type, definition from mysql.routines where name = 'p2'").Check(testkit.Rows("in a int INVOKER select a"))
+	tk.MustExec("drop procedure p2")
+
+	tk.MustGetErrCode("create procedure p1() select 1", errno.ErrSpAlreadyExists)
+	tk.MustExec("create procedure if not exists p1() select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1304 PROCEDURE p1 already exists"))
+	tk.MustGetErrCode("create procedure p2(a int, a int) select 1", errno.ErrSpDupParam)
+	tk.MustGetErrCode("create procedure p2() begin declare a int; declare a int; select 1; end", errno.ErrSpDupVar)
+	tk.MustGetErrCode("create procedure p2() begin declare c cursor for select 1; open c; end", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create procedure not_exist_db.p2() select 1", errno.ErrBadDB)
+
+	tk.MustExec("drop procedure p1")
+	tk.MustQuery("select count(*) from information_schema.routines").Check(testkit.Rows("0"))
+	tk.MustGetErrCode("drop procedure p1", errno.ErrSpDoesNotExist)
+	tk.MustExec("drop procedure if exists p1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1305 PROCEDURE test.p1 does not exist"))
+}
+
+func TestCallProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v varchar(20))")
+
+	tk.MustExec(`create procedure fill(in n int, out total int)
+begin
+	declare i int default 0;
+	declare s varchar(20);
+	while i < n do
+		set i = i + 1;
+		if i % 3 = 0 then
+			set s = 'fizz';
+		elseif i % 2 = 0 then
+			set s = 'even';
+		else
+			set s = concat('odd', i);
+		end if;
+		insert into t values (i, s);
+	end while;
+	set @cnt = (select count(*) from t);
+	set total = i;
+	select id, v from t order by id;
+end`)
+	tk.MustQuery("call fill(5, @total)").Check(testkit.Rows("1 odd1", "2 even", "3 fizz", "4 even", "5 odd5"))
+	tk.MustQuery("select @total, @cnt").Check(testkit.Rows("5 5"))
+
+	// INOUT parameters and local variables in the result set.
+	tk.MustExec(`create procedure twice(inout x int)
+begin
+	declare old int default x;
+	set x = x * 2;
+	select old, x;
+end`)
+	tk.MustExec("set @x = 21")
+	tk.MustQuery("call twice(@x)").Check(testkit.Rows("21 42"))
+	tk.MustQuery("call test.twice(@x)").Check(testkit.Rows("42 84"))
+	tk.MustQuery("select @x").Check(testkit.Rows("84"))
+
+	// the procedures are found in the current database by default.
+	tk.MustExec("create database db2")
+	tk.MustExec("use db2")
+	tk.MustGetErrCode("call fill(1, @total)", errno.ErrSpDoesNotExist)
+	tk.MustExec("call test.fill(0, @total)")
+	tk.MustQuery("select @total").Check(testkit.Rows("0"))
+}
+
+func TestCallNestedProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create procedure add_one(in a int, out b int) set b = a + 1")
+	tk.MustExec(`create procedure add_two(in a int, out b int)
+begin
+	declare tmp int;
+	call add_one(a, tmp);
+	call add_one(tmp, b);
+end`)
+	tk.MustExec("call add_two(1, @r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("3"))
+
+	// the recursion is limited by max_sp_recursion_depth.
+	tk.MustExec(`create procedure countdown(in n int, inout acc int)
+begin
+	if n > 0 then
+		set acc = acc + n;
+		call countdown(n - 1, acc);
+	end if;
+end`)
+	tk.MustExec("set @acc = 0")
+	tk.MustGetErrCode("call countdown(3, @acc)", errno.ErrSpRecursionLimit)
+	tk.MustExec("set @@max_sp_recursion_depth = 10")
+	tk.MustExec("call countdown(3, @acc)")
+	tk.MustQuery("select @acc").Check(testkit.Rows("6"))
+}
+
+func TestCallProcedureErrors(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create procedure p(in a int, out b int) set b = a")
+	tk.MustGetErrCode("call p(1)", errno.ErrSpWrongNoOfArgs)
+	tk.MustGetErrCode("call p(1, 2)", errno.ErrSpNotVarArg)
+	tk.MustGetErrCode("call not_exist()", errno.ErrSpDoesNotExist)
+	tk.MustGetErrCode("call p((select 1 from not_exist_table), @b)", errno.ErrNoSuchTable)
+
+	tk.MustExec("create user 'u1'@'%'")
+	tk.MustExec("grant select on test.* to 'u1'@'%'")
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustExec("use test")
+	tk1.MustGetErrCode("call p(1, @b)", errno.ErrProcaccessDenied)
+	tk1.MustGetErrCode("create procedure p2() select 1", errno.ErrDBaccessDenied)
+	tk1.MustGetErrCode("drop procedure p", errno.ErrDBaccessDenied)
+	tk.MustExec("grant execute on test.* to 'u1'@'%'")
+	tk1.MustExec("call p(1, @b)")
+	tk1.MustQuery("select @b").Check(testkit.Rows("1"))
+	tk.MustExec("grant create routine, alter routine on test.* to 'u1'@'%'")
+	tk1.MustExec("create procedure p2() select 1")
+	tk1.MustExec("drop procedure p2")
+}
+
+func TestCallProcedureContext(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v varchar(3))")
+
+	// the body is executed with the SQL mode when the procedure was created.
+	tk.MustExec("set sql_mode = ''")
+	tk.MustExec("create procedure loose(in i int) begin insert into t values (i, 'abcdef'); select @@sql_mode; end")
+	tk.MustExec("set sql_mode = 'STRICT_TRANS_TABLES'")
+	tk.MustExec("create procedure strict_insert(in i int) insert into t values (i, 'abcdef')")
+	tk.MustQuery("call loose(1)").Check(testkit.Rows(""))
+	tk.MustQuery("select @@sql_mode").Check(testkit.Rows("STRICT_TRANS_TABLES"))
+	tk.MustExec("set sql_mode = ''")
+	tk.MustGetErrCode("call strict_insert(2)", errno.ErrDataTooLong)
+	tk.MustQuery("select @@sql_mode").Check(testkit.Rows(""))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 abc"))
+
+	// the body is executed in the schema of the procedure.
+	tk.MustExec("create database db2")
+	tk.MustExec("create table db2.t (id int primary key)")
+	tk.MustExec("create procedure db2.p() begin insert into t values (10); select database(); end")
+	tk.MustQuery("call db2.p()").Check(testkit.Rows("db2"))
+	tk.MustQuery("select database()").Check(testkit.Rows("test"))
+	tk.MustQuery("select * from db2.t").Check(testkit.Rows("10"))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("1"))
+}
+
+func TestCallProcedureSQLSecurity(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table secret (id int primary key)")
+	tk.MustExec("insert into secret values (1), (2)")
+	tk.MustExec("create user 'definer'@'%', 'u1'@'%'")
+	tk.MustExec("grant select, create routine on test.* to 'definer'@'%'")
+	tk.MustExec("grant execute on test.* to 'u1'@'%'")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "definer", Hostname: "%"}, nil, nil, nil))
+	tk1.MustExec("use test")
+	tk1.MustExec("create procedure by_definer() select count(*), current_user() from secret")
+	tk1.MustExec("create procedure by_invoker() sql security invoker select count(*), current_user() from secret")
+
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk2.MustExec("use test")
+	tk2.MustGetErrCode("select count(*) from secret", errno.ErrTableaccessDenied)
+	tk2.MustQuery("call by_definer()").Check(testkit.Rows("2 definer@%"))
+	tk2.MustGetErrCode("call by_invoker()", errno.ErrTableaccessDenied)
+	// the privileges of the caller are restored after the call.
+	tk2.MustQuery("select current_user()").Check(testkit.Rows("u1@%"))
+	tk2.MustGetErrCode("select count(*) from secret", errno.ErrTableaccessDenied)
+
+	// the procedure can't be executed if the definer doesn't exist.
+	tk.MustExec("drop user 'definer'@'%'")
+	tk2.MustGetErrCode("call by_definer()", errno.ErrNoSuchUser)
+	tk2.MustQuery("select current_user()").Check(testkit.Rows("u1@%"))
+}
@@ -44,6 +44,8 @@ import (
 	"github.com/pingcap/tidb/pkg/ddl"
 	"github.com/pingcap/tidb/pkg/ddl/placement"
 	distsqlctx "github.com/pingcap/tidb/pkg/distsql/context"
+	"github.com/pingcap/tidb/pkg/disttask/analyze"
+	"github.com/pingcap/tidb/pkg/disttask/checksum"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
 	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
@@ -103,11 +105,13 @@ import (
 	"github.com/pingcap/tidb/pkg/util/dbterror"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 	"github.com/pingcap/tidb/pkg/util/execdetails"
+	"github.com/pingcap/tidb/pkg/util/generallog"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/kvcache"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/logutil/consistency"
 	"github.com/pingcap/tidb/pkg/util/memory"
+	"github.com/pingcap/tidb/pkg/util/perfevents"
 	"github.com/pingcap/tidb/pkg/util/sem"
 	"github.com/pingcap/tidb/pkg/util/sli"
 	"github.com/pingcap/tidb/pkg/util/sqlescape"
@@ -870,6 +874,7 @@ func (s *session) doCommitWithRetry(ctx context.Context) error {
 	var err error
 	txnSize := s.txn.Size()
 	isPessimistic := s.txn.IsPessimistic()
+	isPipelined := s.txn.IsPipelined()
 	r, ctx := tracing.StartRegionEx(ctx, "session.doCommitWithRetry")
 	defer r.End()
 
@@ -888,7 +893,9 @@ func (s *session) doCommitWithRetry(ctx context.Context) error {
 		// Don't retry in BatchInsert mode. As a counter-example, insert into t1 select * from t2,
 		// BatchInsert already commit the first batch 1000 rows, then it commit 1000-2000 and retry the statement,
 		// Finally t1 will have more data than t2, with no errors return to user!
-		if s.isTxnRetryableError(err) && !s.sessionVars.BatchInsert && commitRetryLimit > 0 && !isPessimistic {
+		// Don't retry the pipelined transaction either, its mutations have been flushed to the storage.
+		if s.isTxnRetryableError(err) && !s.sessionVars.BatchInsert && commitRetryLimit > 0 && !isPessimistic &&
+			!isPipelined {
 			logutil.Logger(ctx).Warn("sql",
 				zap.String("label", s.GetSQLLabel()),
 				zap.Error(err),
@@ -904,6 +911,7 @@ func (s *session) doCommitWithRetry(ctx context.Context) error {
 				zap.Error(err),
 				zap.Bool("IsBatchInsert", s.sessionVars.BatchInsert),
 				zap.Bool("IsPessimistic", isPessimistic),
+				zap.Bool("IsPipelined", isPipelined),
 				zap.Bool("InRestrictedSQL", s.sessionVars.InRestrictedSQL),
 				zap.Int64("tidb_retry_limit", s.sessionVars.RetryLimit),
 				zap.Bool("tidb_disable_txn_auto_retry", s.sessionVars.DisableTxnAutoRetry))
@@ -912,6 +920,8 @@ func (s *session) doCommitWithRetry(ctx context.Context) error {
 	counter := s.sessionVars.TxnCtx.StatementCount
 	duration := time.Since(s.GetSessionVars().TxnCtx.CreateTime).Seconds()
 	s.recordOnTransactionExecution(err, counter, duration, isInternalTxn)
+	// the commit may succeed even if an error is returned, so the cached results are always invalidated.
+	s.invalidateResultCache()
 
 	if err != nil {
 		if !errIsNoisy(err) {
@@ -994,6 +1004,23 @@ func (s *session) updateStatsDeltaToCollector() {
 	}
 }
 
+// invalidateResultCache removes the cached results which read the tables written by the transaction.
+func (s *session) invalidateResultCache() {
+	mapper := s.GetSessionVars().TxnCtx.TableDeltaMap
+	if len(mapper) == 0 {
+		return
+	}
+	dom := domain.GetDomain(s)
+	if dom == nil {
+		return
+	}
+	ids := make([]int64, 0, len(mapper))
+	for id := range mapper {
+		ids = append(ids, id)
+	}
+	dom.ResultCache().InvalidateTables(ids...)
+}
+
 func (s *session) CommitTxn(ctx context.Context) error {
 	r, ctx := tracing.StartRegionEx(ctx, "session.CommitTxn")
 	defer r.End()
@@ -1526,9 +1553,14 @@ func (s *session) SetProcessInfo(sql string, t time.Time, command byte, maxExecu
 		IndexNames:            s.sessionVars.StmtCtx.IndexNames,
 		MaxExecutionTime:      maxExecutionTime,
 		RedactSQL:             s.sessionVars.EnableRedactLog,
+		GCProtected:           s.sessionVars.EnableQueryGCProtection,
 		ResourceGroupName:     s.sessionVars.StmtCtx.ResourceGroupName,
 		SessionAlias:          s.sessionVars.SessionAlias,
 	}
+	if s.sessionVars.ConnectionInfo != nil {
+		pi.ConnectAttrs = s.sessionVars.ConnectionInfo.Attributes
+	}
+	pi.CompressionStats = s.sessionVars.CompressionStats
 	oldPi := s.ShowProcess()
 	if p == nil {
 		// Store the last valid plan when the current plan is nil.
@@ -2114,6 +2146,7 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 		if binParam, ok := execStmt.BinaryArgs.([]param.BinaryParam); ok {
 			args, err := param.ExecArgs(s.GetSessionVars().StmtCtx.TypeCtx(), binParam)
 			if err != nil {
+				executor.LogFailedStmtAudit(s, stmtNode, err)
 				return nil, err
 			}
 			execStmt.BinaryArgs = args
@@ -2146,10 +2179,12 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	}
 
 	if err := s.validateStatementInTxn(stmtNode); err != nil {
+		executor.LogFailedStmtAudit(s, stmtNode, err)
 		return nil, err
 	}
 
 	if err := s.validateStatementReadOnlyInStaleness(stmtNode); err != nil {
+		executor.LogFailedStmtAudit(s, stmtNode, err)
 		return nil, err
 	}
 
@@ -2161,6 +2196,7 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	defer s.txn.onStmtEnd()
 
 	if err := s.onTxnManagerStmtStartOrRetry(ctx, stmtNode); err != nil {
+		executor.LogFailedStmtAudit(s, stmtNode, err)
 		return nil, err
 	}
 
@@ -2213,6 +2249,8 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 					zap.String("SQL", sql))
 			}
 		}
+		// the statements rejected by the privilege check fail here, so they are audited too.
+		executor.LogFailedStmtAudit(s, stmtNode, err)
 		return nil, err
 	}
 
@@ -2223,6 +2261,11 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	} else {
 		session_metrics.SessionExecuteCompileDurationGeneral.Observe(durCompile.Seconds())
 	}
+	if sessVars.BulkDMLEnabled && sessVars.InTxn() && s.txn.Valid() {
+		// The statement runs in the transaction activated by the former statements, only warn that it can't be
+		// pipelined.
+		usePipelinedDMLOrWarn(s)
+	}
 	s.currentPlan = stmt.Plan
 	if execStmt, ok := stmtNode.(*ast.ExecuteStmt); ok {
 		if execStmt.Name == "" {
@@ -2591,6 +2634,7 @@ func (s *session) Close() {
 	s.RollbackTxn(ctx)
 	if s.sessionVars != nil {
 		s.sessionVars.WithdrawAllPreparedStmt()
+		perfevents.RemoveThread(s.sessionVars.ConnectionID)
 	}
 	if s.stmtStats != nil {
 		s.stmtStats.SetFinished()
@@ -3053,16 +3097,23 @@ func CreateSession(store kv.Storage) (types.Session, error) {
 // CreateSessionWithOpt creates a new session environment with option.
 // Use default option if opt is nil.
 func CreateSessionWithOpt(store kv.Storage, opt *Opt) (types.Session, error) {
-	s, err := createSessionWithOpt(store, opt)
+	do, err := domap.Get(store)
 	if err != nil {
 		return nil, err
 	}
+	return createSessionOnDomain(store, do, opt)
+}
+
+// CreateSessionOnDomain4Test creates a new session environment bound to the domain, rather than the one registered
+// for the store. It's used to simulate the sessions of the different TiDB nodes sharing a store in the tests.
+func CreateSessionOnDomain4Test(store kv.Storage, do *domain.Domain) (types.Session, error) {
+	return createSessionOnDomain(store, do, nil)
+}
+
+func createSessionOnDomain(store kv.Storage, do *domain.Domain, opt *Opt) (types.Session, error) {
+	s := newSession(store, do, opt)
 
 	// Add auth here.
-	do, err := domap.Get(store)
-	if err != nil {
-		return nil, err
-	}
 	extensions, err := extension.GetExtensions()
 	if err != nil {
 		return nil, err
@@ -3337,6 +3388,30 @@ func bootstrapSessionImpl(store kv.Storage, createSessionsImpl func(store kv.Sto
 			return importinto.NewImportExecutor(ctx, id, task, table, store)
 		},
 	)
+	scheduler.RegisterSchedulerFactory(
+		proto.ChecksumTable,
+		func(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
+			return checksum.NewScheduler(ctx, task, param, store)
+		},
+	)
+	taskexecutor.RegisterTaskType(
+		proto.ChecksumTable,
+		func(ctx context.Context, id string, task *proto.Task, table taskexecutor.TaskTable) taskexecutor.TaskExecutor {
+			return checksum.NewTaskExecutor(ctx, id, task, table, store)
+		},
+	)
+	scheduler.RegisterSchedulerFactory(
+		proto.Analyze,
+		func(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
+			return analyze.NewScheduler(ctx, task, param)
+		},
+	)
+	taskexecutor.RegisterTaskType(
+		proto.Analyze,
+		func(ctx context.Context, id string, task *proto.Task, table taskexecutor.TaskTable) taskexecutor.TaskExecutor {
+			return analyze.NewTaskExecutor(ctx, id, task, table)
+		},
+	)
 
 	analyzeConcurrencyQuota := int(config.GetGlobalConfig().Performance.AnalyzePartitionConcurrencyQuota)
 	concurrency := int(config.GetGlobalConfig().Performance.StatsLoadConcurrency)
@@ -3412,6 +3487,10 @@ func bootstrapSessionImpl(store kv.Storage, createSessionsImpl func(store kv.Sto
 	if err != nil {
 		return nil, err
 	}
+	err = dom.LoadMetricTablesLoop(ses[5], executor.LoadUserMetricTables)
+	if err != nil {
+		return nil, err
+	}
 
 	planReplayerWorkerCnt := config.GetGlobalConfig().Performance.PlanReplayerDumpWorkerConcurrency
 	planReplayerWorkersSctx := make([]sessionctx.Context, planReplayerWorkerCnt)
@@ -3489,6 +3568,19 @@ func bootstrapSessionImpl(store kv.Storage, createSessionsImpl func(store kv.Sto
 	}
 	dom.StartTTLJobManager()
 
+	dom.StartEventScheduler(func(ctx context.Context, event *domain.EventInfo) error {
+		return runEvent(ctx, store, event)
+	})
+	dom.StartMaterializedViewRefresher(func(ctx context.Context, schema model.CIStr, tblInfo *model.TableInfo) error {
+		return refreshMaterializedView(ctx, store, schema, tblInfo)
+	})
+
+	dom.StartCheckTableJobResumer(func(ctx context.Context) error {
+		return executor.ResumeCheckTableJobs(ctx, dom)
+	})
+
+	dom.StartHotspotMitigation()
+
 	analyzeCtxs, err := createSessions(store, analyzeConcurrencyQuota)
 	if err != nil {
 		return nil, err
@@ -3592,6 +3684,10 @@ func createSessionWithOpt(store kv.Storage, opt *Opt) (*session, error) {
 	if err != nil {
 		return nil, err
 	}
+	return newSession(store, dom, opt), nil
+}
+
+func newSession(store kv.Storage, dom *domain.Domain, opt *Opt) *session {
 	s := &session{
 		store:                 store,
 		ddlOwnerManager:       dom.DDL().OwnerManager(),
@@ -3621,7 +3717,7 @@ func createSessionWithOpt(store kv.Storage, opt *Opt) (*session, error) {
 	sessionBindHandle := bindinfo.NewSessionBindingHandle()
 	s.SetValue(bindinfo.SessionBindInfoKeyType, sessionBindHandle)
 	s.SetSessionStatesHandler(sessionstates.StateBinding, sessionBindHandle)
-	return s, nil
+	return s
 }
 
 // attachStatsCollector attaches the stats collector in the dom for the session
@@ -3783,7 +3879,10 @@ func (s *session) PrepareTxnCtx(ctx context.Context) error {
 	}
 
 	txnMode := ast.Optimistic
-	if !s.sessionVars.IsAutocommit() || config.GetGlobalConfig().PessimisticTxn.PessimisticAutoCommit.Load() {
+	// The auto-commit statements are executed in optimistic transactions in the bulk DML mode, because a pipelined
+	// transaction can't acquire pessimistic locks.
+	if !s.sessionVars.IsAutocommit() || (config.GetGlobalConfig().PessimisticTxn.PessimisticAutoCommit.Load() &&
+		!s.sessionVars.BulkDMLEnabled) {
 		if s.sessionVars.TxnMode == ast.Pessimistic {
 			txnMode = ast.Pessimistic
 		}
@@ -3931,6 +4030,13 @@ func logStmt(execStmt *executor.ExecStmt, s *session) {
 func logGeneralQuery(execStmt *executor.ExecStmt, s *session, isPrepared bool) {
 	vars := s.GetSessionVars()
 	if variable.ProcessGeneralLog.Load() && !vars.InRestrictedSQL {
+		var userName, hostName string
+		if vars.User != nil {
+			userName, hostName = vars.User.Username, vars.User.Hostname
+		}
+		if !generallog.ShouldLog(userName) {
+			return
+		}
 		var query string
 		if isPrepared {
 			query = execStmt.OriginText()
@@ -3942,6 +4048,19 @@ func logGeneralQuery(execStmt *executor.ExecStmt, s *session, isPrepared bool) {
 		if !vars.EnableRedactLog {
 			query += vars.PlanCacheParams.String()
 		}
+		if generallog.OutputToTable() {
+			generallog.GlobalHistory.Push(&generallog.Record{
+				Time:   time.Now(),
+				ConnID: vars.ConnectionID,
+				User:   userName,
+				Host:   hostName,
+				DB:     vars.CurrentDB,
+				Query:  query,
+			})
+		}
+		if !generallog.OutputToFile() {
+			return
+		}
 		logutil.BgLogger().Info("GENERAL_LOG",
 			zap.Uint64("conn", vars.ConnectionID),
 			zap.String("session_alias", vars.SessionAlias),
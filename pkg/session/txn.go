@@ -26,9 +26,11 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/errctx"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/terror"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/session/txninfo"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/binloginfo"
@@ -280,7 +282,7 @@ func (txn *LazyTxn) changePendingToValid(ctx context.Context, sctx sessionctx.Co
 	txn.txnFuture = nil
 
 	defer trace.StartRegion(ctx, "WaitTsoFuture").End()
-	t, err := future.wait()
+	t, err := future.wait(usePipelinedDMLOrWarn(sctx))
 	if err != nil {
 		txn.Transaction = nil
 		return err
@@ -688,18 +690,88 @@ type txnFuture struct {
 	txnScope string
 }
 
-func (tf *txnFuture) wait() (kv.Transaction, error) {
+func (tf *txnFuture) wait(pipelined bool) (kv.Transaction, error) {
+	options := []tikv.TxnOption{tikv.WithTxnScope(tf.txnScope)}
+	if pipelined {
+		options = append(options, tikv.WithPipelinedMemDB())
+	}
 	startTS, err := tf.future.Wait()
 	failpoint.Inject("txnFutureWait", func() {})
 	if err == nil {
-		return tf.store.Begin(tikv.WithTxnScope(tf.txnScope), tikv.WithStartTS(startTS))
+		return tf.store.Begin(append(options, tikv.WithStartTS(startTS))...)
 	} else if config.GetGlobalConfig().Store == "unistore" {
 		return nil, err
 	}
 
 	logutil.BgLogger().Warn("wait tso failed", zap.Error(err))
 	// It would retry get timestamp.
-	return tf.store.Begin(tikv.WithTxnScope(tf.txnScope))
+	return tf.store.Begin(options...)
+}
+
+// usePipelinedDMLOrWarn returns whether the transaction of the current statement should be a pipelined transaction,
+// see variable.TiDBDMLType. If the statement requires the bulk DML mode but can't be executed in it, a warning is
+// appended and the statement falls back to the standard mode.
+func usePipelinedDMLOrWarn(sctx sessionctx.Context) bool {
+	vars := sctx.GetSessionVars()
+	if !vars.BulkDMLEnabled || vars.InRestrictedSQL {
+		return false
+	}
+	sc := vars.StmtCtx
+	if sc.IsReadOnly {
+		return false
+	}
+	fallback := func(reason string) bool {
+		sc.AppendWarning(errors.NewNoStackErrorf("Pipelined DML can not be used %s. Fallback to standard mode", reason))
+		return false
+	}
+	plan := sc.GetPlan()
+	if execute, ok := plan.(*plannercore.Execute); ok {
+		plan = execute.Plan
+	}
+	switch p := plan.(type) {
+	case *plannercore.Insert:
+		if len(p.OnDuplicate) > 0 {
+			return fallback("for INSERT ... ON DUPLICATE KEY UPDATE")
+		}
+	case *plannercore.Update, *plannercore.Delete:
+	case nil:
+		return fallback("when the transaction is activated before the statement is planned")
+	default:
+		return false
+	}
+	if !vars.IsAutocommit() || vars.InTxn() {
+		return fallback("in an explicit transaction")
+	}
+	if vars.TxnCtx.IsPessimistic {
+		return fallback("in a pessimistic transaction")
+	}
+	if vars.BinlogClient != nil {
+		return fallback("when binlog is enabled")
+	}
+	if sc.ErrGroupLevel(errctx.ErrGroupDupKey) != errctx.LevelError {
+		return fallback("for the statement with IGNORE")
+	}
+	is := sessiontxn.GetTxnManager(sctx).GetTxnInfoSchema()
+	for _, t := range sc.Tables {
+		tbl, err := is.TableByName(model.NewCIStr(t.DB), model.NewCIStr(t.Table))
+		if err != nil {
+			// The statement may refer to a CTE or a view, they are not written.
+			continue
+		}
+		tblInfo := tbl.Meta()
+		switch {
+		case tblInfo.TempTableType != model.TempTableNone:
+			return fallback("on temporary tables")
+		case tblInfo.TableCacheStatusType != model.TableCacheStatusDisable:
+			return fallback("on cached tables")
+		case len(tblInfo.Triggers) > 0:
+			return fallback("on tables with triggers")
+		case vars.ForeignKeyChecks && (len(tblInfo.ForeignKeys) > 0 ||
+			len(is.GetTableReferredForeignKeys(t.DB, t.Table)) > 0):
+			return fallback("on tables with foreign keys")
+		}
+	}
+	return true
 }
 
 // HasDirtyContent checks whether there's dirty update on the given table.
@@ -708,6 +780,10 @@ func (s *session) HasDirtyContent(tid int64) bool {
 	if s.txn.Transaction == nil {
 		return false
 	}
+	if s.txn.IsPipelined() {
+		// The mutations of a pipelined transaction can't be iterated, and its statements never read them.
+		return false
+	}
 	seekKey := tablecodec.EncodeTablePrefix(tid)
 	it, err := s.txn.GetMemBuffer().Iter(seekKey, nil)
 	terror.Log(err)
@@ -275,6 +275,9 @@ type StatementContext struct {
 	// BindSQL used to construct the key for plan cache. It records the binding used by the stmt.
 	// If the binding is not used by the stmt, the value is empty
 	BindSQL string
+	// usedFixControls records the optimizer fix controls consulted during optimization, EXPLAIN shows the ones set by
+	// users among them to tell which fixes influenced the plan.
+	usedFixControls []uint64
 
 	// The several fields below are mainly for some diagnostic features, like stmt summary and slow query.
 	// We cache the values here to avoid calculating them multiple times.
@@ -322,6 +325,10 @@ type StatementContext struct {
 	// If the statement read from table cache, this flag is set.
 	ReadFromTableCache bool
 
+	// ResultCacheEpoch is the epoch of the result cache when the statement starts, the result of the
+	// statement is not cached if any table is changed after it.
+	ResultCacheEpoch uint64
+
 	// cache is used to reduce object allocation.
 	cache struct {
 		execdetails.RuntimeStatsColl
@@ -752,6 +759,18 @@ func (sc *StatementContext) SetSkipPlanCache(reason error) {
 	sc.setSkipPlanCache(reason)
 }
 
+// RecordFixControl records the optimizer fix control consulted during optimization.
+func (sc *StatementContext) RecordFixControl(key uint64) {
+	if !slices.Contains(sc.usedFixControls, key) {
+		sc.usedFixControls = append(sc.usedFixControls, key)
+	}
+}
+
+// UsedFixControls returns the optimizer fix controls consulted during optimization.
+func (sc *StatementContext) UsedFixControls() []uint64 {
+	return sc.usedFixControls
+}
+
 // SetHintWarning sets the hint warning and records the reason.
 func (sc *StatementContext) SetHintWarning(reason string) {
 	sc.AppendWarning(plannererrors.ErrInternal.FastGen(reason))
@@ -48,6 +48,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
 	pumpcli "github.com/pingcap/tidb/pkg/tidb-binlog/pump_client"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 	"github.com/pingcap/tidb/pkg/util/disk"
@@ -123,6 +124,12 @@ func (r *RetryInfo) ResetOffset() {
 	r.autoRandomIDs.resetOffset()
 }
 
+// SkipRecordedAutoIDs makes the retried statement allocate new auto IDs instead of reusing the recorded ones.
+func (r *RetryInfo) SkipRecordedAutoIDs() {
+	r.autoIncrementIDs.skipRecorded()
+	r.autoRandomIDs.skipRecorded()
+}
+
 // AddAutoIncrementID adds id to autoIncrementIDs.
 func (r *RetryInfo) AddAutoIncrementID(id int64) {
 	r.autoIncrementIDs.autoIDs = append(r.autoIncrementIDs.autoIDs, id)
@@ -152,6 +159,10 @@ func (r *retryInfoAutoIDs) resetOffset() {
 	r.currentOffset = 0
 }
 
+func (r *retryInfoAutoIDs) skipRecorded() {
+	r.currentOffset = len(r.autoIDs)
+}
+
 func (r *retryInfoAutoIDs) clean() {
 	r.currentOffset = 0
 	if len(r.autoIDs) > 0 {
@@ -174,6 +185,12 @@ type TransactionContext struct {
 	TxnCtxNeedToRestore
 }
 
+// TxnCTECache is the cache of the materialized CTE results of a transaction.
+type TxnCTECache interface {
+	// Release releases the cached results.
+	Release()
+}
+
 // TxnCtxNeedToRestore stores transaction variables which need to be restored when rolling back to a savepoint.
 type TxnCtxNeedToRestore struct {
 	// TableDeltaMap is used in the schema validator for DDL changes in one table not to block others.
@@ -220,9 +237,11 @@ type TxnCtxNoNeedToRestore struct {
 	// IsExplicit indicates whether the txn is an interactive txn, which is typically started with a BEGIN
 	// or START TRANSACTION statement, or by setting autocommit to 0.
 	IsExplicit bool
-	Isolation  string
-	LockExpire uint32
-	ForUpdate  uint32
+	// IsPipelined indicates whether the txn flushes its mutations to the storage during execution, see TiDBDMLType.
+	IsPipelined bool
+	Isolation   string
+	LockExpire  uint32
+	ForUpdate   uint32
 	// TxnScope indicates the value of txn_scope
 	TxnScope string
 
@@ -249,6 +268,10 @@ type TxnCtxNoNeedToRestore struct {
 	// has occurred during execution of any statement).
 	FairLockingEffective bool
 
+	// CTECache caches the materialized results of the CTEs for the following statements of the transaction,
+	// see TiDBEnableTxnCTECache. It's released when the transaction ends.
+	CTECache TxnCTECache
+
 	// CurrentStmtPessimisticLockCache is the cache for pessimistic locked keys in the current statement.
 	// It is merged into `pessimisticLockCache` after a statement finishes.
 	// Read results cannot be directly written into pessimisticLockCache because failed statement need to rollback
@@ -367,6 +390,10 @@ func (tc *TransactionContext) Cleanup() {
 	tc.IsStaleness = false
 	tc.Savepoints = nil
 	tc.EnableMDL = false
+	if tc.CTECache != nil {
+		tc.CTECache.Release()
+		tc.CTECache = nil
+	}
 }
 
 // ClearDelta clears the delta map.
@@ -940,6 +967,9 @@ type SessionVars struct {
 	// CopTiFlashConcurrencyFactor is the concurrency number of computation in tiflash coprocessor.
 	CopTiFlashConcurrencyFactor float64
 
+	// TiFlashS3ReadFactor is how many times reading data from S3 is slower than reading the local cache in disaggregated TiFlash.
+	TiFlashS3ReadFactor float64
+
 	// CurrInsertValues is used to record current ValuesExpr's values.
 	// See http://dev.mysql.com/doc/refman/5.7/en/miscellaneous-functions.html#function_values
 	CurrInsertValues chunk.Row
@@ -1283,6 +1313,9 @@ type SessionVars struct {
 	// MPPStoreFailTTL indicates the duration that protect TiDB from sending task to a new recovered TiFlash.
 	MPPStoreFailTTL string
 
+	// EnableMPPNodeFailureRecovery indicates whether to reschedule the MPP tasks onto the other TiFlash nodes when a TiFlash node fails.
+	EnableMPPNodeFailureRecovery bool
+
 	// ReadStaleness indicates the staleness duration for the following query
 	ReadStaleness time.Duration
 
@@ -1367,6 +1400,12 @@ type SessionVars struct {
 	// PrimaryKeyRequired indicates if sql_require_primary_key sysvar is set
 	PrimaryKeyRequired bool
 
+	// GenerateInvisiblePrimaryKey indicates if sql_generated_invisible_primary_key sysvar is set
+	GenerateInvisiblePrimaryKey bool
+
+	// ShowGIPK indicates if show_gipk_in_create_table_and_information_schema sysvar is set
+	ShowGIPK bool
+
 	// EnablePreparedPlanCache indicates whether to enable prepared plan cache.
 	EnablePreparedPlanCache bool
 
@@ -1470,6 +1509,10 @@ type SessionVars struct {
 	// EnablePlanReplayedContinuesCapture indicates whether enabled plan replayer continues capture
 	EnablePlanReplayedContinuesCapture bool
 
+	// PlanReplayerContinuousCaptureThreshold indicates the min latency of the statements captured by plan replayer
+	// continues capture.
+	PlanReplayerContinuousCaptureThreshold time.Duration
+
 	// PlanReplayerFinishedTaskKey used to record the finished plan replayer task key in order not to record the
 	// duplicate task in plan replayer continues capture
 	PlanReplayerFinishedTaskKey map[replayer.PlanReplayerTaskKey]struct{}
@@ -1570,10 +1613,76 @@ type SessionVars struct {
 
 	CompressionAlgorithm int
 	CompressionLevel     int
+	// CompressionStats counts the bytes of the compressed protocol packets, it's nil if the connection doesn't
+	// use the protocol compression.
+	CompressionStats *util.CompressionStats
 
 	// EnableParallelSort indicates whether use parallel sort. Default is false.
 	EnableParallelSort bool
 
+	// ClusterLogMaxRowsPerNode limits the number of log rows read from each node by CLUSTER_LOG. 0 means no limit.
+	ClusterLogMaxRowsPerNode int64
+
+	// EnableMaterializedViewRewrite indicates whether a query is answered by reading a materialized view
+	// whose definition is the same as the query. The result may be stale as the view is refreshed on demand.
+	EnableMaterializedViewRewrite bool
+
+	// EnableLazyCursorFetch indicates whether the server-side cursor reads the rows from the executor on demand.
+	// The rows left are read into the row container once the session executes another statement.
+	EnableLazyCursorFetch bool
+
+	// EnableResultCache indicates whether the results of the read-only queries are cached in the instance.
+	EnableResultCache bool
+
+	// ResultCacheTSBucket is the size in seconds of the time buckets which the cached results belong to.
+	ResultCacheTSBucket int64
+
+	// FoundInResultCache indicates whether the result of this statement was read from the result cache.
+	FoundInResultCache bool
+	// PrevFoundInResultCache indicates whether the result of the last statement was read from the result cache.
+	PrevFoundInResultCache bool
+
+	// ShowCreateTableMySQLCompatible indicates whether SHOW CREATE TABLE omits the TiDB specific clauses.
+	ShowCreateTableMySQLCompatible bool
+
+	// EnableQueryGCProtection indicates whether the start ts of the statements is protected from GC longer than
+	// tidb_gc_max_wait_time, see TiDBQueryGCProtectionMaxTime.
+	EnableQueryGCProtection bool
+
+	// ConsistentStmtTimeZone indicates whether the statement uses the time zone set by the SET_VAR hint everywhere,
+	// including the ranges and the partition pruning of TIMESTAMP columns, see TiDBConsistentStmtTimeZone.
+	ConsistentStmtTimeZone bool
+
+	// RCWriteConflictReplayLimit is the max number of times a deterministic statement is replayed when it meets
+	// a write conflict in a pessimistic read-committed transaction, see TiDBRCWriteConflictReplayLimit.
+	RCWriteConflictReplayLimit int
+
+	// ShowTableRegionsMaxRows is the max number of regions returned by `SHOW TABLE ... REGIONS`, see
+	// TiDBShowTableRegionsMaxRows.
+	ShowTableRegionsMaxRows int
+
+	// EnableAdaptiveHashJoin indicates whether the hash join adapts to the data it reads at runtime, see
+	// TiDBEnableAdaptiveHashJoin.
+	EnableAdaptiveHashJoin bool
+
+	// EnableTiKVConcatAggPushDown indicates whether GROUP_CONCAT, JSON_ARRAYAGG and JSON_OBJECTAGG can be pushed
+	// down to TiKV, see TiDBOptEnableTiKVConcatAggPushDown.
+	EnableTiKVConcatAggPushDown bool
+
+	// EnableTxnCTECache indicates whether the materialized results of the CTEs can be reused by the following
+	// statements of a read-only transaction, see TiDBEnableTxnCTECache.
+	EnableTxnCTECache bool
+
+	// CTEMaxRecursionRows is the max number of rows a recursive CTE can produce, see TiDBCTEMaxRecursionRows.
+	CTEMaxRecursionRows int64
+
+	// CTERecursionPartialResult indicates whether a recursive CTE returns partial results when it exceeds the
+	// recursion limits, see TiDBCTERecursionPartialResult.
+	CTERecursionPartialResult bool
+
+	// BulkDMLEnabled indicates whether the auto-commit DML statements are executed as pipelined DML, see TiDBDMLType.
+	BulkDMLEnabled bool
+
 	// TxnEntrySizeLimit indicates indicates the max size of a entry in membuf. The default limit (from config) will be
 	// overwritten if this value is not 0.
 	TxnEntrySizeLimit uint64
@@ -1795,6 +1904,24 @@ const (
 	PlacementModeIgnore string = "IGNORE"
 )
 
+const (
+	// HotspotMitigationOff disables the detection of the write hotspots.
+	HotspotMitigationOff string = "OFF"
+	// HotspotMitigationLog only logs the detected write hotspots.
+	HotspotMitigationLog string = "LOG"
+	// HotspotMitigationPreSplit pre-splits the regions ahead of the hot key of the detected write hotspots.
+	HotspotMitigationPreSplit string = "PRE_SPLIT"
+	// HotspotMitigationAuto pre-splits the regions and enlarges the auto id cache of the hot tables.
+	HotspotMitigationAuto string = "AUTO"
+)
+
+const (
+	// DMLTypeStandard buffers all the mutations of a transaction in memory until it commits.
+	DMLTypeStandard string = "STANDARD"
+	// DMLTypeBulk flushes the mutations of the auto-commit DML statements to the storage during execution.
+	DMLTypeBulk string = "BULK"
+)
+
 // PartitionPruneMode presents the prune mode used.
 type PartitionPruneMode string
 
@@ -1966,6 +2093,7 @@ func NewSessionVars(hctx HookContext) *SessionVars {
 		cpuFactor:                     DefOptCPUFactor,
 		copCPUFactor:                  DefOptCopCPUFactor,
 		CopTiFlashConcurrencyFactor:   DefOptTiFlashConcurrencyFactor,
+		TiFlashS3ReadFactor:           DefOptTiFlashS3ReadFactor,
 		networkFactor:                 DefOptNetworkFactor,
 		scanFactor:                    DefOptScanFactor,
 		descScanFactor:                DefOptDescScanFactor,
@@ -1980,6 +2108,7 @@ func NewSessionVars(hctx HookContext) *SessionVars {
 		SlowQueryFile:                 config.GetGlobalConfig().Log.SlowQueryFile,
 		WaitSplitRegionFinish:         DefTiDBWaitSplitRegionFinish,
 		WaitSplitRegionTimeout:        DefWaitSplitRegionTimeout,
+		ShowTableRegionsMaxRows:       DefTiDBShowTableRegionsMaxRows,
 		enableIndexMerge:              DefTiDBEnableIndexMerge,
 		NoopFuncsMode:                 TiDBOptOnOffWarn(DefTiDBEnableNoopFuncs),
 		replicaRead:                   kv.ReplicaReadLeader,
@@ -1995,6 +2124,7 @@ func NewSessionVars(hctx HookContext) *SessionVars {
 		WindowingUseHighPrecision:     true,
 		PrevFoundInPlanCache:          DefTiDBFoundInPlanCache,
 		FoundInPlanCache:              DefTiDBFoundInPlanCache,
+		ResultCacheTSBucket:           DefTiDBResultCacheTSBucket,
 		PrevFoundInBinding:            DefTiDBFoundInBinding,
 		FoundInBinding:                DefTiDBFoundInBinding,
 		SelectLimit:                   math.MaxUint64,
@@ -2022,6 +2152,7 @@ func NewSessionVars(hctx HookContext) *SessionVars {
 		Enable3StageDistinctAgg:       DefTiDB3StageDistinctAgg,
 		MaxAllowedPacket:              DefMaxAllowedPacket,
 		TiFlashFastScan:               DefTiFlashFastScan,
+		ShowGIPK:                      true,
 		EnableTiFlashReadForWriteStmt: true,
 		ForeignKeyChecks:              DefTiDBForeignKeyChecks,
 		HookContext:                   hctx,
@@ -3109,6 +3240,14 @@ const (
 	SlowLogPDTotal = "PD_total"
 	// SlowLogBackoffTotal is the total time doing backoff.
 	SlowLogBackoffTotal = "Backoff_total"
+	// SlowLogBackoffStrategy is the backoff strategy of the kv requests.
+	SlowLogBackoffStrategy = "Backoff_strategy"
+	// SlowLogBackoffBudget is the total backoff budget of the kv requests which have backed off.
+	SlowLogBackoffBudget = "Backoff_budget"
+	// SlowLogBackoffBudgetUsed is the backoff time of the kv requests which have backed off.
+	SlowLogBackoffBudgetUsed = "Backoff_budget_used"
+	// SlowLogBackoffBudgetExhausted is the number of the kv requests which run out of the backoff budget.
+	SlowLogBackoffBudgetExhausted = "Backoff_budget_exhausted"
 	// SlowLogWriteSQLRespTotal is the total time used to write response to client.
 	SlowLogWriteSQLRespTotal = "Write_sql_response_total"
 	// SlowLogExecRetryCount is the execution retry count.
@@ -3182,6 +3321,10 @@ type SlowQueryLogItems struct {
 	KVTotal           time.Duration
 	PDTotal           time.Duration
 	BackoffTotal      time.Duration
+	BackoffStrategy   string
+	BackoffBudget     time.Duration
+	BackoffBudgetUsed time.Duration
+	BackoffExhausted  int64
 	WriteSQLRespTotal time.Duration
 	ExecRetryCount    uint
 	ExecRetryTime     time.Duration
@@ -3221,71 +3364,87 @@ type SlowQueryLogItems struct {
 // # Prev_stmt: begin;
 // select * from t_slim;
 func (s *SessionVars) SlowLogFormat(logItems *SlowQueryLogItems) string {
-	var buf bytes.Buffer
+	return s.formatSlowLog(logItems, false)
+}
+
+// SlowLogFormatJSON formats the slow log as a single line JSON object. The field names
+// are the same as the column names of INFORMATION_SCHEMA.SLOW_QUERY, and the values are
+// strings in the same format as SlowLogFormat. The "Time" field is not included, it is
+// added by the slow query logger.
+func (s *SessionVars) SlowLogFormatJSON(logItems *SlowQueryLogItems) string {
+	return s.formatSlowLog(logItems, true)
+}
+
+func (s *SessionVars) formatSlowLog(logItems *SlowQueryLogItems, isJSON bool) string {
+	b := &slowLogBuilder{json: isJSON}
+	if isJSON {
+		b.buf.WriteByte('{')
+	}
 
-	writeSlowLogItem(&buf, SlowLogTxnStartTSStr, strconv.FormatUint(logItems.TxnTS, 10))
+	b.writeItems(SlowLogTxnStartTSStr, strconv.FormatUint(logItems.TxnTS, 10))
 	if logItems.KeyspaceName != "" {
-		writeSlowLogItem(&buf, SlowLogKeyspaceName, logItems.KeyspaceName)
-		writeSlowLogItem(&buf, SlowLogKeyspaceID, fmt.Sprintf("%d", logItems.KeyspaceID))
+		b.writeItems(SlowLogKeyspaceName, logItems.KeyspaceName)
+		b.writeItems(SlowLogKeyspaceID, fmt.Sprintf("%d", logItems.KeyspaceID))
 	}
 
 	if s.User != nil {
-		hostAddress := s.User.Hostname
-		if s.ConnectionInfo != nil {
-			hostAddress = s.ConnectionInfo.ClientIP
+		if isJSON {
+			b.writeItems(SlowLogUserStr, s.User.Username, SlowLogHostStr, s.User.Hostname)
+		} else {
+			hostAddress := s.User.Hostname
+			if s.ConnectionInfo != nil {
+				hostAddress = s.ConnectionInfo.ClientIP
+			}
+			b.writeItems(SlowLogUserAndHostStr, fmt.Sprintf("%s[%s] @ %s [%s]", s.User.Username, s.User.Username, s.User.Hostname, hostAddress))
 		}
-		writeSlowLogItem(&buf, SlowLogUserAndHostStr, fmt.Sprintf("%s[%s] @ %s [%s]", s.User.Username, s.User.Username, s.User.Hostname, hostAddress))
 	}
 	if s.ConnectionID != 0 {
-		writeSlowLogItem(&buf, SlowLogConnIDStr, strconv.FormatUint(s.ConnectionID, 10))
+		b.writeItems(SlowLogConnIDStr, strconv.FormatUint(s.ConnectionID, 10))
 	}
 	if s.SessionAlias != "" {
-		writeSlowLogItem(&buf, SlowLogSessAliasStr, s.SessionAlias)
+		b.writeItems(SlowLogSessAliasStr, s.SessionAlias)
 	}
 	if logItems.ExecRetryCount > 0 {
-		buf.WriteString(SlowLogRowPrefixStr)
-		buf.WriteString(SlowLogExecRetryTime)
-		buf.WriteString(SlowLogSpaceMarkStr)
-		buf.WriteString(strconv.FormatFloat(logItems.ExecRetryTime.Seconds(), 'f', -1, 64))
-		buf.WriteString(" ")
-		buf.WriteString(SlowLogExecRetryCount)
-		buf.WriteString(SlowLogSpaceMarkStr)
-		buf.WriteString(strconv.Itoa(int(logItems.ExecRetryCount)))
-		buf.WriteString("\n")
-	}
-	writeSlowLogItem(&buf, SlowLogQueryTimeStr, strconv.FormatFloat(logItems.TimeTotal.Seconds(), 'f', -1, 64))
-	writeSlowLogItem(&buf, SlowLogParseTimeStr, strconv.FormatFloat(logItems.TimeParse.Seconds(), 'f', -1, 64))
-	writeSlowLogItem(&buf, SlowLogCompileTimeStr, strconv.FormatFloat(logItems.TimeCompile.Seconds(), 'f', -1, 64))
-
-	buf.WriteString(SlowLogRowPrefixStr + fmt.Sprintf("%v%v%v", SlowLogRewriteTimeStr,
-		SlowLogSpaceMarkStr, strconv.FormatFloat(logItems.RewriteInfo.DurationRewrite.Seconds(), 'f', -1, 64)))
+		b.writeItems(SlowLogExecRetryTime, strconv.FormatFloat(logItems.ExecRetryTime.Seconds(), 'f', -1, 64),
+			SlowLogExecRetryCount, strconv.Itoa(int(logItems.ExecRetryCount)))
+	}
+	b.writeItems(SlowLogQueryTimeStr, strconv.FormatFloat(logItems.TimeTotal.Seconds(), 'f', -1, 64))
+	b.writeItems(SlowLogParseTimeStr, strconv.FormatFloat(logItems.TimeParse.Seconds(), 'f', -1, 64))
+	b.writeItems(SlowLogCompileTimeStr, strconv.FormatFloat(logItems.TimeCompile.Seconds(), 'f', -1, 64))
+
+	rewriteItems := []string{SlowLogRewriteTimeStr, strconv.FormatFloat(logItems.RewriteInfo.DurationRewrite.Seconds(), 'f', -1, 64)}
 	if logItems.RewriteInfo.PreprocessSubQueries > 0 {
-		buf.WriteString(fmt.Sprintf(" %v%v%v %v%v%v", SlowLogPreprocSubQueriesStr, SlowLogSpaceMarkStr, logItems.RewriteInfo.PreprocessSubQueries,
-			SlowLogPreProcSubQueryTimeStr, SlowLogSpaceMarkStr, strconv.FormatFloat(logItems.RewriteInfo.DurationPreprocessSubQuery.Seconds(), 'f', -1, 64)))
+		rewriteItems = append(rewriteItems,
+			SlowLogPreprocSubQueriesStr, fmt.Sprintf("%v", logItems.RewriteInfo.PreprocessSubQueries),
+			SlowLogPreProcSubQueryTimeStr, strconv.FormatFloat(logItems.RewriteInfo.DurationPreprocessSubQuery.Seconds(), 'f', -1, 64))
 	}
-	buf.WriteString("\n")
+	b.writeItems(rewriteItems...)
 
-	writeSlowLogItem(&buf, SlowLogOptimizeTimeStr, strconv.FormatFloat(logItems.TimeOptimize.Seconds(), 'f', -1, 64))
-	writeSlowLogItem(&buf, SlowLogWaitTSTimeStr, strconv.FormatFloat(logItems.TimeWaitTS.Seconds(), 'f', -1, 64))
+	b.writeItems(SlowLogOptimizeTimeStr, strconv.FormatFloat(logItems.TimeOptimize.Seconds(), 'f', -1, 64))
+	b.writeItems(SlowLogWaitTSTimeStr, strconv.FormatFloat(logItems.TimeWaitTS.Seconds(), 'f', -1, 64))
 
-	if execDetailStr := logItems.ExecDetail.String(); len(execDetailStr) > 0 {
-		buf.WriteString(SlowLogRowPrefixStr + execDetailStr + "\n")
+	if fields, values := logItems.ExecDetail.Fields(); len(fields) > 0 {
+		execDetailItems := make([]string, 0, 2*len(fields))
+		for i := range fields {
+			execDetailItems = append(execDetailItems, fields[i], values[i])
+		}
+		b.writeItems(execDetailItems...)
 	}
 
 	if len(s.CurrentDB) > 0 {
-		writeSlowLogItem(&buf, SlowLogDBStr, strings.ToLower(s.CurrentDB))
+		b.writeItems(SlowLogDBStr, strings.ToLower(s.CurrentDB))
 	}
 	if len(logItems.IndexNames) > 0 {
-		writeSlowLogItem(&buf, SlowLogIndexNamesStr, logItems.IndexNames)
+		b.writeItems(SlowLogIndexNamesStr, logItems.IndexNames)
 	}
 
-	writeSlowLogItem(&buf, SlowLogIsInternalStr, strconv.FormatBool(s.InRestrictedSQL))
+	b.writeItems(SlowLogIsInternalStr, strconv.FormatBool(s.InRestrictedSQL))
 	if len(logItems.Digest) > 0 {
-		writeSlowLogItem(&buf, SlowLogDigestStr, logItems.Digest)
+		b.writeItems(SlowLogDigestStr, logItems.Digest)
 	}
 	keys := logItems.UsedStats.Keys()
 	if len(keys) > 0 {
-		buf.WriteString(SlowLogRowPrefixStr + SlowLogStatsInfoStr + SlowLogSpaceMarkStr)
+		var statsBuf bytes.Buffer
 		firstComma := false
 		slices.Sort(keys)
 		for _, id := range keys {
@@ -3294,16 +3453,15 @@ func (s *SessionVars) SlowLogFormat(logItems *SlowQueryLogItems) string {
 				continue
 			}
 			if firstComma {
-				buf.WriteString(",")
+				statsBuf.WriteString(",")
 			}
-			usedStatsForTbl.WriteToSlowLog(&buf)
+			usedStatsForTbl.WriteToSlowLog(&statsBuf)
 			firstComma = true
 		}
-
-		buf.WriteString("\n")
+		b.writeItems(SlowLogStatsInfoStr, statsBuf.String())
 	}
 	if logItems.CopTasks != nil {
-		writeSlowLogItem(&buf, SlowLogNumCopTasksStr, strconv.FormatInt(int64(logItems.CopTasks.NumCopTasks), 10))
+		b.writeItems(SlowLogNumCopTasksStr, strconv.FormatInt(int64(logItems.CopTasks.NumCopTasks), 10))
 		if logItems.CopTasks.NumCopTasks > 0 {
 			// make the result stable
 			backoffs := make([]string, 0, 3)
@@ -3313,119 +3471,187 @@ func (s *SessionVars) SlowLogFormat(logItems *SlowQueryLogItems) string {
 			slices.Sort(backoffs)
 
 			if logItems.CopTasks.NumCopTasks == 1 {
-				buf.WriteString(SlowLogRowPrefixStr + fmt.Sprintf("%v%v%v %v%v%v",
-					SlowLogCopProcAvg, SlowLogSpaceMarkStr, logItems.CopTasks.AvgProcessTime.Seconds(),
-					SlowLogCopProcAddr, SlowLogSpaceMarkStr, logItems.CopTasks.MaxProcessAddress) + "\n")
-				buf.WriteString(SlowLogRowPrefixStr + fmt.Sprintf("%v%v%v %v%v%v",
-					SlowLogCopWaitAvg, SlowLogSpaceMarkStr, logItems.CopTasks.AvgWaitTime.Seconds(),
-					SlowLogCopWaitAddr, SlowLogSpaceMarkStr, logItems.CopTasks.MaxWaitAddress) + "\n")
+				b.writeItems(SlowLogCopProcAvg, fmt.Sprintf("%v", logItems.CopTasks.AvgProcessTime.Seconds()),
+					SlowLogCopProcAddr, logItems.CopTasks.MaxProcessAddress)
+				b.writeItems(SlowLogCopWaitAvg, fmt.Sprintf("%v", logItems.CopTasks.AvgWaitTime.Seconds()),
+					SlowLogCopWaitAddr, logItems.CopTasks.MaxWaitAddress)
 				for _, backoff := range backoffs {
 					backoffPrefix := SlowLogCopBackoffPrefix + backoff + "_"
-					buf.WriteString(SlowLogRowPrefixStr + fmt.Sprintf("%v%v%v %v%v%v\n",
-						backoffPrefix+"total_times", SlowLogSpaceMarkStr, logItems.CopTasks.TotBackoffTimes[backoff],
-						backoffPrefix+"total_time", SlowLogSpaceMarkStr, logItems.CopTasks.TotBackoffTime[backoff].Seconds(),
-					))
+					b.writeBackoffItems(
+						backoffPrefix+"total_times", fmt.Sprintf("%v", logItems.CopTasks.TotBackoffTimes[backoff]),
+						backoffPrefix+"total_time", fmt.Sprintf("%v", logItems.CopTasks.TotBackoffTime[backoff].Seconds()),
+					)
 				}
 			} else {
-				buf.WriteString(SlowLogRowPrefixStr + fmt.Sprintf("%v%v%v %v%v%v %v%v%v %v%v%v",
-					SlowLogCopProcAvg, SlowLogSpaceMarkStr, logItems.CopTasks.AvgProcessTime.Seconds(),
-					SlowLogCopProcP90, SlowLogSpaceMarkStr, logItems.CopTasks.P90ProcessTime.Seconds(),
-					SlowLogCopProcMax, SlowLogSpaceMarkStr, logItems.CopTasks.MaxProcessTime.Seconds(),
-					SlowLogCopProcAddr, SlowLogSpaceMarkStr, logItems.CopTasks.MaxProcessAddress) + "\n")
-				buf.WriteString(SlowLogRowPrefixStr + fmt.Sprintf("%v%v%v %v%v%v %v%v%v %v%v%v",
-					SlowLogCopWaitAvg, SlowLogSpaceMarkStr, logItems.CopTasks.AvgWaitTime.Seconds(),
-					SlowLogCopWaitP90, SlowLogSpaceMarkStr, logItems.CopTasks.P90WaitTime.Seconds(),
-					SlowLogCopWaitMax, SlowLogSpaceMarkStr, logItems.CopTasks.MaxWaitTime.Seconds(),
-					SlowLogCopWaitAddr, SlowLogSpaceMarkStr, logItems.CopTasks.MaxWaitAddress) + "\n")
+				b.writeItems(SlowLogCopProcAvg, fmt.Sprintf("%v", logItems.CopTasks.AvgProcessTime.Seconds()),
+					SlowLogCopProcP90, fmt.Sprintf("%v", logItems.CopTasks.P90ProcessTime.Seconds()),
+					SlowLogCopProcMax, fmt.Sprintf("%v", logItems.CopTasks.MaxProcessTime.Seconds()),
+					SlowLogCopProcAddr, logItems.CopTasks.MaxProcessAddress)
+				b.writeItems(SlowLogCopWaitAvg, fmt.Sprintf("%v", logItems.CopTasks.AvgWaitTime.Seconds()),
+					SlowLogCopWaitP90, fmt.Sprintf("%v", logItems.CopTasks.P90WaitTime.Seconds()),
+					SlowLogCopWaitMax, fmt.Sprintf("%v", logItems.CopTasks.MaxWaitTime.Seconds()),
+					SlowLogCopWaitAddr, logItems.CopTasks.MaxWaitAddress)
 				for _, backoff := range backoffs {
 					backoffPrefix := SlowLogCopBackoffPrefix + backoff + "_"
-					buf.WriteString(SlowLogRowPrefixStr + fmt.Sprintf("%v%v%v %v%v%v %v%v%v %v%v%v %v%v%v %v%v%v\n",
-						backoffPrefix+"total_times", SlowLogSpaceMarkStr, logItems.CopTasks.TotBackoffTimes[backoff],
-						backoffPrefix+"total_time", SlowLogSpaceMarkStr, logItems.CopTasks.TotBackoffTime[backoff].Seconds(),
-						backoffPrefix+"max_time", SlowLogSpaceMarkStr, logItems.CopTasks.MaxBackoffTime[backoff].Seconds(),
-						backoffPrefix+"max_addr", SlowLogSpaceMarkStr, logItems.CopTasks.MaxBackoffAddress[backoff],
-						backoffPrefix+"avg_time", SlowLogSpaceMarkStr, logItems.CopTasks.AvgBackoffTime[backoff].Seconds(),
-						backoffPrefix+"p90_time", SlowLogSpaceMarkStr, logItems.CopTasks.P90BackoffTime[backoff].Seconds(),
-					))
+					b.writeBackoffItems(
+						backoffPrefix+"total_times", fmt.Sprintf("%v", logItems.CopTasks.TotBackoffTimes[backoff]),
+						backoffPrefix+"total_time", fmt.Sprintf("%v", logItems.CopTasks.TotBackoffTime[backoff].Seconds()),
+						backoffPrefix+"max_time", fmt.Sprintf("%v", logItems.CopTasks.MaxBackoffTime[backoff].Seconds()),
+						backoffPrefix+"max_addr", logItems.CopTasks.MaxBackoffAddress[backoff],
+						backoffPrefix+"avg_time", fmt.Sprintf("%v", logItems.CopTasks.AvgBackoffTime[backoff].Seconds()),
+						backoffPrefix+"p90_time", fmt.Sprintf("%v", logItems.CopTasks.P90BackoffTime[backoff].Seconds()),
+					)
 				}
 			}
 		}
 	}
 	if logItems.MemMax > 0 {
-		writeSlowLogItem(&buf, SlowLogMemMax, strconv.FormatInt(logItems.MemMax, 10))
+		b.writeItems(SlowLogMemMax, strconv.FormatInt(logItems.MemMax, 10))
 	}
 	if logItems.DiskMax > 0 {
-		writeSlowLogItem(&buf, SlowLogDiskMax, strconv.FormatInt(logItems.DiskMax, 10))
-	}
-
-	writeSlowLogItem(&buf, SlowLogPrepared, strconv.FormatBool(logItems.Prepared))
-	writeSlowLogItem(&buf, SlowLogPlanFromCache, strconv.FormatBool(logItems.PlanFromCache))
-	writeSlowLogItem(&buf, SlowLogPlanFromBinding, strconv.FormatBool(logItems.PlanFromBinding))
-	writeSlowLogItem(&buf, SlowLogHasMoreResults, strconv.FormatBool(logItems.HasMoreResults))
-	writeSlowLogItem(&buf, SlowLogKVTotal, strconv.FormatFloat(logItems.KVTotal.Seconds(), 'f', -1, 64))
-	writeSlowLogItem(&buf, SlowLogPDTotal, strconv.FormatFloat(logItems.PDTotal.Seconds(), 'f', -1, 64))
-	writeSlowLogItem(&buf, SlowLogBackoffTotal, strconv.FormatFloat(logItems.BackoffTotal.Seconds(), 'f', -1, 64))
-	writeSlowLogItem(&buf, SlowLogWriteSQLRespTotal, strconv.FormatFloat(logItems.WriteSQLRespTotal.Seconds(), 'f', -1, 64))
-	writeSlowLogItem(&buf, SlowLogResultRows, strconv.FormatInt(logItems.ResultRows, 10))
+		b.writeItems(SlowLogDiskMax, strconv.FormatInt(logItems.DiskMax, 10))
+	}
+
+	b.writeItems(SlowLogPrepared, strconv.FormatBool(logItems.Prepared))
+	b.writeItems(SlowLogPlanFromCache, strconv.FormatBool(logItems.PlanFromCache))
+	b.writeItems(SlowLogPlanFromBinding, strconv.FormatBool(logItems.PlanFromBinding))
+	b.writeItems(SlowLogHasMoreResults, strconv.FormatBool(logItems.HasMoreResults))
+	b.writeItems(SlowLogKVTotal, strconv.FormatFloat(logItems.KVTotal.Seconds(), 'f', -1, 64))
+	b.writeItems(SlowLogPDTotal, strconv.FormatFloat(logItems.PDTotal.Seconds(), 'f', -1, 64))
+	b.writeItems(SlowLogBackoffTotal, strconv.FormatFloat(logItems.BackoffTotal.Seconds(), 'f', -1, 64))
+	if logItems.BackoffBudget > 0 {
+		b.writeItems(SlowLogBackoffStrategy, logItems.BackoffStrategy)
+		b.writeItems(SlowLogBackoffBudget, strconv.FormatFloat(logItems.BackoffBudget.Seconds(), 'f', -1, 64))
+		b.writeItems(SlowLogBackoffBudgetUsed, strconv.FormatFloat(logItems.BackoffBudgetUsed.Seconds(), 'f', -1, 64))
+		b.writeItems(SlowLogBackoffBudgetExhausted, strconv.FormatInt(logItems.BackoffExhausted, 10))
+	}
+	b.writeItems(SlowLogWriteSQLRespTotal, strconv.FormatFloat(logItems.WriteSQLRespTotal.Seconds(), 'f', -1, 64))
+	b.writeItems(SlowLogResultRows, strconv.FormatInt(logItems.ResultRows, 10))
 	if len(logItems.Warnings) > 0 {
-		buf.WriteString(SlowLogRowPrefixStr + SlowLogWarnings + SlowLogSpaceMarkStr)
-		jsonEncoder := json.NewEncoder(&buf)
+		var warningsBuf bytes.Buffer
+		jsonEncoder := json.NewEncoder(&warningsBuf)
 		jsonEncoder.SetEscapeHTML(false)
-		// Note that the Encode() will append a '\n' so we don't need to add another.
-		err := jsonEncoder.Encode(logItems.Warnings)
-		if err != nil {
-			buf.WriteString(err.Error())
+		if err := jsonEncoder.Encode(logItems.Warnings); err != nil {
+			warningsBuf.WriteString(err.Error())
 		}
+		// Note that the Encode() appends a '\n'.
+		b.writeItems(SlowLogWarnings, strings.TrimSuffix(warningsBuf.String(), "\n"))
 	}
-	writeSlowLogItem(&buf, SlowLogSucc, strconv.FormatBool(logItems.Succ))
-	writeSlowLogItem(&buf, SlowLogIsExplicitTxn, strconv.FormatBool(logItems.IsExplicitTxn))
-	writeSlowLogItem(&buf, SlowLogIsSyncStatsFailed, strconv.FormatBool(logItems.IsSyncStatsFailed))
+	b.writeItems(SlowLogSucc, strconv.FormatBool(logItems.Succ))
+	b.writeItems(SlowLogIsExplicitTxn, strconv.FormatBool(logItems.IsExplicitTxn))
+	b.writeItems(SlowLogIsSyncStatsFailed, strconv.FormatBool(logItems.IsSyncStatsFailed))
 	if s.StmtCtx.WaitLockLeaseTime > 0 {
-		writeSlowLogItem(&buf, SlowLogIsWriteCacheTable, strconv.FormatBool(logItems.IsWriteCacheTable))
+		b.writeItems(SlowLogIsWriteCacheTable, strconv.FormatBool(logItems.IsWriteCacheTable))
 	}
 	if len(logItems.Plan) != 0 {
-		writeSlowLogItem(&buf, SlowLogPlan, logItems.Plan)
+		b.writeItems(SlowLogPlan, logItems.Plan)
 	}
 	if len(logItems.PlanDigest) != 0 {
-		writeSlowLogItem(&buf, SlowLogPlanDigest, logItems.PlanDigest)
+		b.writeItems(SlowLogPlanDigest, logItems.PlanDigest)
 	}
 	if len(logItems.BinaryPlan) != 0 {
-		writeSlowLogItem(&buf, SlowLogBinaryPlan, logItems.BinaryPlan)
+		b.writeItems(SlowLogBinaryPlan, logItems.BinaryPlan)
 	}
 
 	if logItems.ResourceGroupName != "" {
-		writeSlowLogItem(&buf, SlowLogResourceGroup, logItems.ResourceGroupName)
+		b.writeItems(SlowLogResourceGroup, logItems.ResourceGroupName)
 	}
 	if logItems.RRU > 0.0 {
-		writeSlowLogItem(&buf, SlowLogRRU, strconv.FormatFloat(logItems.RRU, 'f', -1, 64))
+		b.writeItems(SlowLogRRU, strconv.FormatFloat(logItems.RRU, 'f', -1, 64))
 	}
 	if logItems.WRU > 0.0 {
-		writeSlowLogItem(&buf, SlowLogWRU, strconv.FormatFloat(logItems.WRU, 'f', -1, 64))
+		b.writeItems(SlowLogWRU, strconv.FormatFloat(logItems.WRU, 'f', -1, 64))
 	}
 	if logItems.WaitRUDuration > time.Duration(0) {
-		writeSlowLogItem(&buf, SlowLogWaitRUDuration, strconv.FormatFloat(logItems.WaitRUDuration.Seconds(), 'f', -1, 64))
+		b.writeItems(SlowLogWaitRUDuration, strconv.FormatFloat(logItems.WaitRUDuration.Seconds(), 'f', -1, 64))
 	}
 
 	if logItems.PrevStmt != "" {
-		writeSlowLogItem(&buf, SlowLogPrevStmt, logItems.PrevStmt)
+		b.writeItems(SlowLogPrevStmt, logItems.PrevStmt)
 	}
 
 	if s.CurrentDBChanged {
-		buf.WriteString(fmt.Sprintf("use %s;\n", strings.ToLower(s.CurrentDB)))
+		// The JSON slow log has the DB field, so the use statement is only written in the text slow log.
+		if !isJSON {
+			b.buf.WriteString(fmt.Sprintf("use %s;\n", strings.ToLower(s.CurrentDB)))
+		}
 		s.CurrentDBChanged = false
 	}
 
-	buf.WriteString(logItems.SQL)
-	if len(logItems.SQL) == 0 || logItems.SQL[len(logItems.SQL)-1] != ';' {
-		buf.WriteString(";")
+	sql := logItems.SQL
+	if len(sql) == 0 || sql[len(sql)-1] != ';' {
+		sql += ";"
+	}
+	if isJSON {
+		if len(b.backoffDetail) > 0 {
+			b.writeJSONField(SlowLogBackoffDetail, strings.Join(b.backoffDetail, " "))
+		}
+		b.writeJSONField(SlowLogQuerySQLStr, sql)
+		b.buf.WriteByte('}')
+	} else {
+		b.buf.WriteString(sql)
+	}
+	return b.buf.String()
+}
+
+// slowLogBuilder builds a slow log in the text format or the JSON format.
+type slowLogBuilder struct {
+	buf  bytes.Buffer
+	json bool
+	// notEmpty indicates whether any field has been written to the JSON slow log.
+	notEmpty bool
+	// backoffDetail is the backoff items of the JSON slow log, which are written as the
+	// Backoff_Detail field like the one parsed from the text slow log.
+	backoffDetail []string
+}
+
+// writeItems writes the pairs of field and value in a line of the text slow log in the
+// form of: "# ${field1}: ${value1} ${field2}: ${value2}", or as the fields of the JSON slow log.
+func (b *slowLogBuilder) writeItems(items ...string) {
+	if b.json {
+		for i := 0; i+1 < len(items); i += 2 {
+			b.writeJSONField(items[i], items[i+1])
+		}
+		return
+	}
+	b.buf.WriteString(SlowLogRowPrefixStr)
+	b.buf.WriteString(joinSlowLogItems(items))
+	b.buf.WriteString("\n")
+}
+
+// writeBackoffItems writes the backoff items of a cop task type.
+func (b *slowLogBuilder) writeBackoffItems(items ...string) {
+	if b.json {
+		b.backoffDetail = append(b.backoffDetail, joinSlowLogItems(items))
+		return
 	}
+	b.writeItems(items...)
+}
 
-	return buf.String()
+func (b *slowLogBuilder) writeJSONField(field, value string) {
+	if b.notEmpty {
+		b.buf.WriteByte(',')
+	}
+	b.notEmpty = true
+	// Marshal a string never fails.
+	k, _ := json.Marshal(field)
+	v, _ := json.Marshal(value)
+	b.buf.Write(k)
+	b.buf.WriteByte(':')
+	b.buf.Write(v)
 }
 
-// writeSlowLogItem writes a slow log item in the form of: "# ${key}:${value}"
-func writeSlowLogItem(buf *bytes.Buffer, key, value string) {
-	buf.WriteString(SlowLogRowPrefixStr + key + SlowLogSpaceMarkStr + value + "\n")
+func joinSlowLogItems(items []string) string {
+	var sb strings.Builder
+	for i := 0; i+1 < len(items); i += 2 {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(items[i])
+		sb.WriteString(SlowLogSpaceMarkStr)
+		sb.WriteString(items[i+1])
+	}
+	return sb.String()
 }
 
 // TxnReadTS indicates the value and used situation for tx_read_ts
@@ -16,6 +16,7 @@ package variable_test
 
 import (
 	"context"
+	"encoding/json"
 	"strconv"
 	"sync"
 	"testing"
@@ -315,6 +316,30 @@ func TestSlowLogFormat(t *testing.T) {
 	logString = seVar.SlowLogFormat(logItems)
 	require.Equal(t, resultFields+"\n"+"use test;\n"+sql, logString)
 	require.False(t, seVar.CurrentDBChanged)
+
+	seVar.CurrentDBChanged = true
+	logString = seVar.SlowLogFormatJSON(logItems)
+	require.False(t, seVar.CurrentDBChanged)
+	require.NotContains(t, logString, "\n")
+	jsonFields := make(map[string]string)
+	require.NoError(t, json.Unmarshal([]byte(logString), &jsonFields))
+	require.Equal(t, "406649736972468225", jsonFields[variable.SlowLogTxnStartTSStr])
+	require.Equal(t, "root", jsonFields[variable.SlowLogUserStr])
+	require.Equal(t, "192.168.0.1", jsonFields[variable.SlowLogHostStr])
+	require.Equal(t, "test", jsonFields[variable.SlowLogDBStr])
+	require.Equal(t, "2", jsonFields[execdetails.ProcessTimeStr])
+	require.Equal(t, "10000", jsonFields[execdetails.TotalKeysStr])
+	require.Equal(t, "t1:123[1000;0][ID 1:allLoaded,ID 2:allLoaded][ID 2:allEvicted,ID 3:onlyCmsEvicted],t2:pseudo[10000;0]",
+		jsonFields[variable.SlowLogStatsInfoStr])
+	require.Equal(t, "Cop_backoff_regionMiss_total_times: 200 Cop_backoff_regionMiss_total_time: 0.2 Cop_backoff_regionMiss_max_time: 0.2 "+
+		"Cop_backoff_regionMiss_max_addr: 127.0.0.1 Cop_backoff_regionMiss_avg_time: 0.2 Cop_backoff_regionMiss_p90_time: 0.2 "+
+		"Cop_backoff_rpcPD_total_times: 200 Cop_backoff_rpcPD_total_time: 0.2 Cop_backoff_rpcPD_max_time: 0.2 "+
+		"Cop_backoff_rpcPD_max_addr: 127.0.0.1 Cop_backoff_rpcPD_avg_time: 0.2 Cop_backoff_rpcPD_p90_time: 0.2 "+
+		"Cop_backoff_rpcTiKV_total_times: 200 Cop_backoff_rpcTiKV_total_time: 0.2 Cop_backoff_rpcTiKV_max_time: 0.2 "+
+		"Cop_backoff_rpcTiKV_max_addr: 127.0.0.1 Cop_backoff_rpcTiKV_avg_time: 0.2 Cop_backoff_rpcTiKV_p90_time: 0.2",
+		jsonFields[variable.SlowLogBackoffDetail])
+	require.Equal(t, "true", jsonFields[variable.SlowLogIsWriteCacheTable])
+	require.Equal(t, sql, jsonFields[variable.SlowLogQuerySQLStr])
 }
 
 func TestIsolationRead(t *testing.T) {
@@ -34,6 +34,11 @@ var isHintUpdatableVerified = map[string]struct{}{
 	// This var is used during the planner's preprocess phase. Need to fix its behavior.
 	// "tidb_read_staleness":                              {},
 	"tidb_enable_paging":                              {},
+	"tidb_enable_adaptive_hash_join":                  {},
+	"tidb_opt_enable_tikv_concat_agg_push_down":       {},
+	"tidb_enable_txn_cte_cache":                       {},
+	"tidb_cte_max_recursion_rows":                     {},
+	"tidb_cte_recursion_partial_result":               {},
 	"tidb_read_consistency":                           {},
 	"tidb_distsql_scan_concurrency":                   {},
 	"tidb_opt_insubq_to_join_and_agg":                 {},
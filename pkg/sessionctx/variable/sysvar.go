@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"os"
 	"runtime"
 	"strconv"
 	"strings"
@@ -40,13 +41,16 @@ import (
 	"github.com/pingcap/tidb/pkg/types"
 	_ "github.com/pingcap/tidb/pkg/types/parser_driver" // for parser driver
 	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/audit"
 	"github.com/pingcap/tidb/pkg/util/collate"
 	distroleutil "github.com/pingcap/tidb/pkg/util/distrole"
 	"github.com/pingcap/tidb/pkg/util/gctuner"
+	"github.com/pingcap/tidb/pkg/util/generallog"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/mathutil"
 	"github.com/pingcap/tidb/pkg/util/memory"
+	"github.com/pingcap/tidb/pkg/util/perfevents"
 	stmtsummaryv2 "github.com/pingcap/tidb/pkg/util/stmtsummary/v2"
 	"github.com/pingcap/tidb/pkg/util/tiflash"
 	"github.com/pingcap/tidb/pkg/util/tiflashcompute"
@@ -378,6 +382,9 @@ var defaultSysVars = []*SysVar{
 	{Scope: ScopeSession, Name: TiDBFoundInBinding, Value: BoolToOnOff(DefTiDBFoundInBinding), Type: TypeBool, ReadOnly: true, GetSession: func(s *SessionVars) (string, error) {
 		return BoolToOnOff(s.PrevFoundInBinding), nil
 	}},
+	{Scope: ScopeSession, Name: TiDBFoundInResultCache, Value: Off, Type: TypeBool, ReadOnly: true, GetSession: func(s *SessionVars) (string, error) {
+		return BoolToOnOff(s.PrevFoundInResultCache), nil
+	}},
 	{Scope: ScopeSession, Name: RandSeed1, Type: TypeInt, Value: "0", skipInit: true, MaxValue: math.MaxInt32, SetSession: func(s *SessionVars, val string) error {
 		s.Rng.SetSeed1(uint32(tidbOptPositiveInt32(val, 0)))
 		return nil
@@ -451,6 +458,34 @@ var defaultSysVars = []*SysVar{
 	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
 		return BoolToOnOff(ProcessGeneralLog.Load()), nil
 	}},
+	{Scope: ScopeInstance, Name: TiDBGeneralLogSampleRate, Value: strconv.FormatFloat(DefTiDBGeneralLogSampleRate, 'f', -1, 64), Type: TypeFloat, MinValue: 0, MaxValue: 1, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		generallog.SetSampleRate(tidbOptFloat64(val, DefTiDBGeneralLogSampleRate))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return strconv.FormatFloat(generallog.SampleRate(), 'f', -1, 64), nil
+	}},
+	{Scope: ScopeInstance, Name: TiDBGeneralLogUsers, Value: "", Type: TypeStr, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		generallog.SetUsers(val)
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return generallog.Users(), nil
+	}},
+	{Scope: ScopeInstance, Name: TiDBGeneralLogOutput, Value: DefTiDBGeneralLogOutput, Type: TypeStr,
+		Validation: func(_ *SessionVars, normalizedValue string, _ string, _ ScopeFlag) (string, error) {
+			_, _, normalized, err := generallog.ParseOutput(normalizedValue)
+			return normalized, err
+		},
+		SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+			return generallog.SetOutput(val)
+		}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+			return generallog.Output(), nil
+		}},
+	{Scope: ScopeInstance, Name: TiDBGeneralLogTableSize, Value: strconv.Itoa(DefTiDBGeneralLogTableSize), Type: TypeUnsigned, MinValue: 0, MaxValue: 100000, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		generallog.GlobalHistory.Resize(uint(TidbOptInt64(val, DefTiDBGeneralLogTableSize)))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return strconv.Itoa(generallog.GlobalHistory.Capacity()), nil
+	}},
 	{Scope: ScopeSession, Name: TiDBSlowTxnLogThreshold, Value: strconv.Itoa(logutil.DefaultSlowTxnThreshold),
 		Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt64, SetSession: func(s *SessionVars, val string) error {
 			s.SlowTxnThreshold = TidbOptUint64(val, logutil.DefaultSlowTxnThreshold)
@@ -577,6 +612,21 @@ var defaultSysVars = []*SysVar{
 	{Scope: ScopeInstance, Name: TiDBStmtSummaryFileMaxBackups, ReadOnly: true, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
 		return strconv.Itoa(config.GetGlobalConfig().Instance.StmtSummaryFileMaxBackups), nil
 	}},
+	{Scope: ScopeInstance, Name: TiDBAuditLogFilename, ReadOnly: true, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+		return config.GetGlobalConfig().Instance.AuditLogFilename, nil
+	}},
+	{Scope: ScopeInstance, Name: TiDBAuditLogFileMaxDays, ReadOnly: true, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+		return strconv.Itoa(config.GetGlobalConfig().Instance.AuditLogFileMaxDays), nil
+	}},
+	{Scope: ScopeInstance, Name: TiDBAuditLogFileMaxSize, ReadOnly: true, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+		return strconv.Itoa(config.GetGlobalConfig().Instance.AuditLogFileMaxSize), nil
+	}},
+	{Scope: ScopeInstance, Name: TiDBAuditLogFileMaxBackups, ReadOnly: true, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+		return strconv.Itoa(config.GetGlobalConfig().Instance.AuditLogFileMaxBackups), nil
+	}},
+	{Scope: ScopeInstance, Name: TiDBAuditLogQueueSize, ReadOnly: true, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+		return strconv.Itoa(config.GetGlobalConfig().Instance.AuditLogQueueSize), nil
+	}},
 
 	/* The system variables below have GLOBAL scope  */
 	{Scope: ScopeGlobal, Name: MaxPreparedStmtCount, Value: strconv.FormatInt(DefMaxPreparedStmtCount, 10), Type: TypeInt, MinValue: -1, MaxValue: 1048576,
@@ -588,6 +638,17 @@ var defaultSysVars = []*SysVar{
 			MaxPreparedStmtCountValue.Store(num)
 			return nil
 		}},
+	{Scope: ScopeGlobal, Name: MaxUserConnections, Value: strconv.FormatUint(DefMaxUserConnections, 10), Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxUint32,
+		SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+			MaxUserConnectionsValue.Store(uint32(TidbOptInt64(val, DefMaxUserConnections)))
+			return nil
+		}},
+	{Scope: ScopeGlobal, Name: EventScheduler, Value: Off, Type: TypeBool, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		EnableEventScheduler.Store(TiDBOptOn(val))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return BoolToOnOff(EnableEventScheduler.Load()), nil
+	}},
 	{Scope: ScopeGlobal, Name: InitConnect, Value: "", Validation: func(vars *SessionVars, normalizedValue string, originalValue string, scope ScopeFlag) (string, error) {
 		p := parser.New()
 		p.SetSQLMode(vars.SQLMode)
@@ -779,6 +840,27 @@ var defaultSysVars = []*SysVar{
 		SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
 			return stmtsummaryv2.SetMaxSQLLength(TidbOptInt(val, DefTiDBStmtSummaryMaxSQLLength))
 		}},
+	{Scope: ScopeGlobal, Name: TiDBAuditLogEnabled, Value: BoolToOnOff(DefTiDBAuditLogEnabled), Type: TypeBool,
+		SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+			return audit.SetEnabled(TiDBOptOn(val))
+		}},
+	{Scope: ScopeGlobal, Name: TiDBAuditLogRedact, Value: BoolToOnOff(DefTiDBAuditLogRedact), Type: TypeBool,
+		SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+			return audit.SetRedact(TiDBOptOn(val))
+		}},
+	{Scope: ScopeGlobal, Name: TiDBAuditLogRules, Value: "", Type: TypeStr,
+		Validation: func(_ *SessionVars, normalizedValue string, _ string, _ ScopeFlag) (string, error) {
+			_, err := audit.ParseRules(normalizedValue)
+			return normalizedValue, err
+		},
+		SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+			return audit.SetRules(val)
+		}},
+	{Scope: ScopeGlobal, Name: TiDBEnablePerfSchemaEvents, Value: BoolToOnOff(DefTiDBEnablePerfSchemaEvents), Type: TypeBool,
+		SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+			perfevents.SetEnabled(TiDBOptOn(val))
+			return nil
+		}},
 	{Scope: ScopeGlobal, Name: TiDBCapturePlanBaseline, Value: DefTiDBCapturePlanBaseline, Type: TypeBool, AllowEmptyAll: true},
 	{Scope: ScopeGlobal, Name: TiDBEvolvePlanTaskMaxTime, Value: strconv.Itoa(DefTiDBEvolvePlanTaskMaxTime), Type: TypeInt, MinValue: -1, MaxValue: math.MaxInt64},
 	{Scope: ScopeGlobal, Name: TiDBEvolvePlanTaskStartTime, Value: DefTiDBEvolvePlanTaskStartTime, Type: TypeTime},
@@ -1374,6 +1456,15 @@ var defaultSysVars = []*SysVar{
 			return BoolToOnOff(EnableHistoricalStatsForCapture.Load()), nil
 		},
 	},
+	{Scope: ScopeGlobal, Name: TiDBPlanReplayerCaptureStorageQuota, Value: strconv.Itoa(DefTiDBPlanReplayerCaptureStorageQuota), Type: TypeInt, MinValue: 0, MaxValue: math.MaxInt64,
+		SetGlobal: func(_ context.Context, _ *SessionVars, val string) error {
+			PlanReplayerCaptureStorageQuota.Store(TidbOptInt64(val, DefTiDBPlanReplayerCaptureStorageQuota))
+			return nil
+		},
+		GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return strconv.FormatInt(PlanReplayerCaptureStorageQuota.Load(), 10), nil
+		},
+	},
 	{Scope: ScopeGlobal, Name: TiDBHistoricalStatsDuration, Value: DefTiDBHistoricalStatsDuration.String(), Type: TypeDuration, MinValue: int64(time.Second), MaxValue: uint64(time.Hour * 24 * 365),
 		GetGlobal: func(ctx context.Context, vars *SessionVars) (string, error) {
 			return HistoricalStatsDuration.Load().String(), nil
@@ -1423,6 +1514,12 @@ var defaultSysVars = []*SysVar{
 			return s, nil
 		},
 	},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBPlanReplayerContinuousCaptureThreshold, Value: strconv.Itoa(DefTiDBPlanReplayerCaptureThreshold), Type: TypeInt, MinValue: 0, MaxValue: math.MaxInt32,
+		SetSession: func(s *SessionVars, val string) error {
+			s.PlanReplayerContinuousCaptureThreshold = time.Duration(TidbOptInt64(val, DefTiDBPlanReplayerCaptureThreshold)) * time.Millisecond
+			return nil
+		},
+	},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnablePlanReplayerCapture, Value: BoolToOnOff(DefTiDBEnablePlanReplayerCapture), Type: TypeBool,
 		SetSession: func(s *SessionVars, val string) error {
 			s.EnablePlanReplayerCapture = TiDBOptOn(val)
@@ -1690,6 +1787,10 @@ var defaultSysVars = []*SysVar{
 		s.MPPStoreFailTTL = val
 		return nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableMPPNodeFailureRecovery, Type: TypeBool, Value: BoolToOnOff(DefTiDBEnableMPPNodeFailureRecovery), SetSession: func(s *SessionVars, val string) error {
+		s.EnableMPPNodeFailureRecovery = TiDBOptOn(val)
+		return nil
+	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBHashExchangeWithNewCollation, Type: TypeBool, Value: BoolToOnOff(DefTiDBHashExchangeWithNewCollation), SetSession: func(s *SessionVars, val string) error {
 		s.HashExchangeWithNewCollation = TiDBOptOn(val)
 		return nil
@@ -1760,6 +1861,10 @@ var defaultSysVars = []*SysVar{
 		s.CopTiFlashConcurrencyFactor = tidbOptFloat64(val, DefOptTiFlashConcurrencyFactor)
 		return nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBOptTiFlashS3ReadFactor, Value: strconv.FormatFloat(DefOptTiFlashS3ReadFactor, 'f', -1, 64), Type: TypeFloat, MinValue: 1, MaxValue: math.MaxUint64, SetSession: func(s *SessionVars, val string) error {
+		s.TiFlashS3ReadFactor = tidbOptFloat64(val, DefOptTiFlashS3ReadFactor)
+		return nil
+	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBOptCopCPUFactor, Value: strconv.FormatFloat(DefOptCopCPUFactor, 'f', -1, 64), Type: TypeFloat, MinValue: 0, MaxValue: math.MaxUint64, SetSession: func(s *SessionVars, val string) error {
 		s.copCPUFactor = tidbOptFloat64(val, DefOptCopCPUFactor)
 		return nil
@@ -2366,6 +2471,14 @@ var defaultSysVars = []*SysVar{
 		s.PrimaryKeyRequired = TiDBOptOn(val)
 		return nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: SQLGeneratedInvisiblePrimaryKey, Value: Off, Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.GenerateInvisiblePrimaryKey = TiDBOptOn(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: ShowGIPKInCreateTableAndInformationSchema, Value: On, Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.ShowGIPK = TiDBOptOn(val)
+		return nil
+	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableAnalyzeSnapshot, Value: BoolToOnOff(DefTiDBEnableAnalyzeSnapshot), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
 		s.EnableAnalyzeSnapshot = TiDBOptOn(val)
 		return nil
@@ -2540,6 +2653,39 @@ var defaultSysVars = []*SysVar{
 			return nil
 		},
 	},
+	{Scope: ScopeGlobal, Name: TiDBKVBackoffStrategy, Value: DefTiDBKVBackoffStrategy, Type: TypeStr,
+		Validation: func(_ *SessionVars, normalizedValue string, _ string, _ ScopeFlag) (string, error) {
+			return strings.ToLower(normalizedValue), nil
+		},
+		GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return KVBackoffStrategy.Load(), nil
+		}, SetGlobal: func(_ context.Context, _ *SessionVars, val string) error {
+			if SetKVBackoffStrategy != nil {
+				if err := SetKVBackoffStrategy(val); err != nil {
+					return ErrWrongValueForVar.GenWithStackByArgs(TiDBKVBackoffStrategy, val)
+				}
+			}
+			KVBackoffStrategy.Store(val)
+			return nil
+		}},
+	{Scope: ScopeGlobal, Name: TiDBCopBatchWaitTime, Value: time.Duration(DefTiDBCopBatchWaitTime).String(), Type: TypeDuration, MinValue: 0, MaxValue: uint64(time.Second),
+		GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return CopBatchWaitTime.Load().String(), nil
+		}, SetGlobal: func(_ context.Context, _ *SessionVars, val string) error {
+			d, err := time.ParseDuration(val)
+			if err != nil {
+				return err
+			}
+			CopBatchWaitTime.Store(d)
+			return nil
+		}},
+	{Scope: ScopeGlobal, Name: TiDBCopBatchWaitSize, Value: strconv.Itoa(DefTiDBCopBatchWaitSize), Type: TypeInt, MinValue: 2, MaxValue: 1024,
+		GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return strconv.FormatInt(CopBatchWaitSize.Load(), 10), nil
+		}, SetGlobal: func(_ context.Context, _ *SessionVars, val string) error {
+			CopBatchWaitSize.Store(TidbOptInt64(val, DefTiDBCopBatchWaitSize))
+			return nil
+		}},
 	{Scope: ScopeGlobal | ScopeSession, Name: MppExchangeCompressionMode, Type: TypeStr, Value: DefaultExchangeCompressionMode.Name(),
 		Validation: func(_ *SessionVars, normalizedValue string, originalValue string, _ ScopeFlag) (string, error) {
 			_, ok := kv.ToExchangeCompressionMode(normalizedValue)
@@ -3065,6 +3211,159 @@ var defaultSysVars = []*SysVar{
 			vars.EnableParallelSort = TiDBOptOn(s)
 			return nil
 		}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBClusterLogMaxRowsPerNode, Value: strconv.Itoa(DefTiDBClusterLogMaxRowsPerNode), Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt64,
+		SetSession: func(s *SessionVars, val string) error {
+			s.ClusterLogMaxRowsPerNode = TidbOptInt64(val, DefTiDBClusterLogMaxRowsPerNode)
+			return nil
+		}},
+	{Scope: ScopeGlobal, Name: TiDBCheckTableOnlineBatchSize, Value: strconv.Itoa(DefTiDBCheckTableOnlineBatchSize), Type: TypeInt, MinValue: 1, MaxValue: 10000000, SetGlobal: func(_ context.Context, _ *SessionVars, s string) error {
+		CheckTableOnlineBatchSize.Store(TidbOptInt64(s, DefTiDBCheckTableOnlineBatchSize))
+		return nil
+	}, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+		return strconv.FormatInt(CheckTableOnlineBatchSize.Load(), 10), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBCheckTableOnlineRateLimit, Value: strconv.Itoa(DefTiDBCheckTableOnlineRateLimit), Type: TypeInt, MinValue: 0, MaxValue: math.MaxInt64, SetGlobal: func(_ context.Context, _ *SessionVars, s string) error {
+		CheckTableOnlineRateLimit.Store(TidbOptInt64(s, DefTiDBCheckTableOnlineRateLimit))
+		return nil
+	}, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+		return strconv.FormatInt(CheckTableOnlineRateLimit.Load(), 10), nil
+	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableMaterializedViewRewrite, Value: BoolToOnOff(DefTiDBEnableMaterializedViewRewrite), Type: TypeBool,
+		SetSession: func(s *SessionVars, val string) error {
+			s.EnableMaterializedViewRewrite = TiDBOptOn(val)
+			return nil
+		}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableLazyCursorFetch, Value: BoolToOnOff(DefTiDBEnableLazyCursorFetch), Type: TypeBool,
+		SetSession: func(s *SessionVars, val string) error {
+			s.EnableLazyCursorFetch = TiDBOptOn(val)
+			return nil
+		}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableResultCache, Value: BoolToOnOff(DefTiDBEnableResultCache), Type: TypeBool,
+		SetSession: func(s *SessionVars, val string) error {
+			s.EnableResultCache = TiDBOptOn(val)
+			return nil
+		}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBShowCreateTableMySQLCompatible, Value: BoolToOnOff(DefTiDBShowCreateTableMySQLCompatible), Type: TypeBool,
+		SetSession: func(s *SessionVars, val string) error {
+			s.ShowCreateTableMySQLCompatible = TiDBOptOn(val)
+			return nil
+		}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBResultCacheTSBucket, Value: strconv.Itoa(DefTiDBResultCacheTSBucket), Type: TypeUnsigned, MinValue: 1, MaxValue: 86400,
+		SetSession: func(s *SessionVars, val string) error {
+			s.ResultCacheTSBucket = TidbOptInt64(val, DefTiDBResultCacheTSBucket)
+			return nil
+		}},
+	{Scope: ScopeGlobal, Name: TiDBResultCacheMaxSize, Value: strconv.Itoa(DefTiDBResultCacheMaxSize), Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt64, SetGlobal: func(_ context.Context, _ *SessionVars, s string) error {
+		v := TidbOptInt64(s, DefTiDBResultCacheMaxSize)
+		if v != ResultCacheMaxSize.Load() {
+			ResultCacheMaxSize.Store(v)
+			if setCapacity := SetResultCacheCapacity.Load(); setCapacity != nil {
+				(*setCapacity)(v)
+			}
+		}
+		return nil
+	}, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+		return strconv.FormatInt(ResultCacheMaxSize.Load(), 10), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBResultCacheMaxEntrySize, Value: strconv.Itoa(DefTiDBResultCacheMaxEntrySize), Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt64, SetGlobal: func(_ context.Context, _ *SessionVars, s string) error {
+		ResultCacheMaxEntrySize.Store(TidbOptInt64(s, DefTiDBResultCacheMaxEntrySize))
+		return nil
+	}, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+		return strconv.FormatInt(ResultCacheMaxEntrySize.Load(), 10), nil
+	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableQueryGCProtection, Value: BoolToOnOff(DefTiDBEnableQueryGCProtection), Type: TypeBool,
+		SetSession: func(s *SessionVars, val string) error {
+			s.EnableQueryGCProtection = TiDBOptOn(val)
+			return nil
+		},
+		// The protected sessions delay the GC safe point of the whole cluster, so it can't be enabled by any user.
+		RequireDynamicPrivileges: func(_ bool, _ bool) []string {
+			return []string{"SYSTEM_VARIABLES_ADMIN"}
+		}},
+	{Scope: ScopeGlobal, Name: TiDBQueryGCProtectionMaxTime, Value: strconv.Itoa(DefTiDBQueryGCProtectionMaxTime), Type: TypeInt, MinValue: 600, MaxValue: 31536000, SetGlobal: func(_ context.Context, _ *SessionVars, s string) error {
+		QueryGCProtectionMaxTime.Store(TidbOptInt64(s, DefTiDBQueryGCProtectionMaxTime))
+		return nil
+	}, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+		return strconv.FormatInt(QueryGCProtectionMaxTime.Load(), 10), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBTZDataPath, Value: "", Type: TypeStr, Validation: func(_ *SessionVars, normalizedValue string, originalValue string, _ ScopeFlag) (string, error) {
+		if normalizedValue == "" {
+			return normalizedValue, nil
+		}
+		if info, err := os.Stat(normalizedValue); err != nil || !info.IsDir() {
+			return normalizedValue, ErrWrongValueForVar.GenWithStackByArgs(TiDBTZDataPath, originalValue)
+		}
+		return normalizedValue, nil
+	}, SetGlobal: func(_ context.Context, _ *SessionVars, s string) error {
+		return timeutil.SetTZDataDir(s)
+	}, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+		return timeutil.GetTZDataDir(), nil
+	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBConsistentStmtTimeZone, Value: BoolToOnOff(DefTiDBConsistentStmtTimeZone), Type: TypeBool,
+		SetSession: func(s *SessionVars, val string) error {
+			s.ConsistentStmtTimeZone = TiDBOptOn(val)
+			return nil
+		}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBRCWriteConflictReplayLimit, Value: strconv.Itoa(DefTiDBRCWriteConflictReplayLimit), Type: TypeInt, MinValue: 0, MaxValue: 100,
+		SetSession: func(s *SessionVars, val string) error {
+			s.RCWriteConflictReplayLimit = TidbOptInt(val, DefTiDBRCWriteConflictReplayLimit)
+			return nil
+		}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBShowTableRegionsMaxRows, Value: strconv.Itoa(DefTiDBShowTableRegionsMaxRows), Type: TypeInt, MinValue: 0, MaxValue: math.MaxInt32,
+		SetSession: func(s *SessionVars, val string) error {
+			s.ShowTableRegionsMaxRows = TidbOptInt(val, DefTiDBShowTableRegionsMaxRows)
+			return nil
+		}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableAdaptiveHashJoin, Value: BoolToOnOff(DefTiDBEnableAdaptiveHashJoin), Type: TypeBool,
+		SetSession: func(s *SessionVars, val string) error {
+			s.EnableAdaptiveHashJoin = TiDBOptOn(val)
+			return nil
+		}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBOptEnableTiKVConcatAggPushDown, Value: BoolToOnOff(DefTiDBOptEnableTiKVConcatAggPushDown), Type: TypeBool,
+		SetSession: func(s *SessionVars, val string) error {
+			s.EnableTiKVConcatAggPushDown = TiDBOptOn(val)
+			return nil
+		}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableTxnCTECache, Value: BoolToOnOff(DefTiDBEnableTxnCTECache), Type: TypeBool,
+		SetSession: func(s *SessionVars, val string) error {
+			s.EnableTxnCTECache = TiDBOptOn(val)
+			return nil
+		}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBCTEMaxRecursionRows, Value: strconv.Itoa(DefTiDBCTEMaxRecursionRows), Type: TypeInt, MinValue: 0, MaxValue: math.MaxInt64,
+		SetSession: func(s *SessionVars, val string) error {
+			s.CTEMaxRecursionRows = TidbOptInt64(val, DefTiDBCTEMaxRecursionRows)
+			return nil
+		}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBCTERecursionPartialResult, Value: BoolToOnOff(DefTiDBCTERecursionPartialResult), Type: TypeBool,
+		SetSession: func(s *SessionVars, val string) error {
+			s.CTERecursionPartialResult = TiDBOptOn(val)
+			return nil
+		}},
+	{Scope: ScopeSession, Name: TiDBDMLType, Value: DefTiDBDMLType, Type: TypeEnum,
+		PossibleValues: []string{DMLTypeStandard, DMLTypeBulk},
+		SetSession: func(s *SessionVars, val string) error {
+			s.BulkDMLEnabled = strings.EqualFold(val, DMLTypeBulk)
+			return nil
+		}},
+	{Scope: ScopeGlobal, Name: TiDBHotspotMitigationPolicy, Value: DefTiDBHotspotMitigationPolicy, Type: TypeEnum,
+		PossibleValues: []string{HotspotMitigationOff, HotspotMitigationLog, HotspotMitigationPreSplit, HotspotMitigationAuto},
+		SetGlobal: func(_ context.Context, _ *SessionVars, s string) error {
+			HotspotMitigationPolicy.Store(s)
+			return nil
+		}, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return HotspotMitigationPolicy.Load(), nil
+		}},
+	{Scope: ScopeGlobal, Name: TiDBDDLHistoryRetention, Value: DefTiDBDDLHistoryRetention.String(), Type: TypeDuration, MinValue: 0, MaxValue: uint64(time.Hour * 24 * 365 * 10),
+		SetGlobal: func(_ context.Context, _ *SessionVars, s string) error {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return err
+			}
+			DDLHistoryRetention.Store(d)
+			return nil
+		}, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return DDLHistoryRetention.Load().String(), nil
+		}},
 }
 
 // GlobalSystemVariableInitialValue gets the default value for a system variable including ones that are dynamically set (e.g. based on the store)
@@ -3163,6 +3462,8 @@ const (
 	GroupConcatMaxLen = "group_concat_max_len"
 	// DelayKeyWrite is the name for 'delay_key_write' system variable.
 	DelayKeyWrite = "delay_key_write"
+	// EventScheduler is the name for 'event_scheduler' system variable.
+	EventScheduler = "event_scheduler"
 	// EndMarkersInJSON is the name for 'end_markers_in_json' system variable.
 	EndMarkersInJSON = "end_markers_in_json"
 	// Hostname is the name for 'hostname' system variable.
@@ -3423,6 +3724,10 @@ const (
 	RandSeed2 = "rand_seed2"
 	// SQLRequirePrimaryKey is the name of `sql_require_primary_key` system variable.
 	SQLRequirePrimaryKey = "sql_require_primary_key"
+	// SQLGeneratedInvisiblePrimaryKey is the name of `sql_generated_invisible_primary_key` system variable.
+	SQLGeneratedInvisiblePrimaryKey = "sql_generated_invisible_primary_key"
+	// ShowGIPKInCreateTableAndInformationSchema is the name of `show_gipk_in_create_table_and_information_schema` system variable.
+	ShowGIPKInCreateTableAndInformationSchema = "show_gipk_in_create_table_and_information_schema"
 	// ValidatePasswordEnable turns on/off the validation of password.
 	ValidatePasswordEnable = "validate_password.enable"
 	// ValidatePasswordPolicy specifies the password policy enforced by validate_password.
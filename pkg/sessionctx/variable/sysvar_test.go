@@ -21,6 +21,7 @@ import (
 	"math"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -1550,3 +1551,56 @@ func TestTiDBLowResTSOUpdateInterval(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "1000", val)
 }
+
+func TestTiDBKVBackoffStrategy(t *testing.T) {
+	sv := GetSysVar(TiDBKVBackoffStrategy)
+	vars := NewSessionVars(nil)
+	defer func(fn func(string) error) {
+		SetKVBackoffStrategy = fn
+		KVBackoffStrategy.Store(DefTiDBKVBackoffStrategy)
+	}(SetKVBackoffStrategy)
+	SetKVBackoffStrategy = func(name string) error {
+		if name != "adaptive" {
+			return fmt.Errorf("unknown backoff strategy %s", name)
+		}
+		return nil
+	}
+
+	val, err := sv.Validate(vars, "Adaptive", ScopeGlobal)
+	require.NoError(t, err)
+	require.Equal(t, "adaptive", val)
+	require.NoError(t, sv.SetGlobalFromHook(context.Background(), vars, val, false))
+	val, err = sv.GetGlobalFromHook(context.Background(), vars)
+	require.NoError(t, err)
+	require.Equal(t, "adaptive", val)
+
+	err = sv.SetGlobalFromHook(context.Background(), vars, "unknown", false)
+	require.Equal(t, "[variable:1231]Variable 'tidb_kv_backoff_strategy' can't be set to the value of 'unknown'", err.Error())
+	require.Equal(t, "adaptive", KVBackoffStrategy.Load())
+}
+
+func TestTiDBTZDataPath(t *testing.T) {
+	sv := GetSysVar(TiDBTZDataPath)
+	vars := NewSessionVars(nil)
+	defer func() {
+		require.NoError(t, timeutil.SetTZDataDir(""))
+	}()
+	require.Equal(t, "", sv.Value)
+
+	dir := t.TempDir()
+	val, err := sv.Validate(vars, dir, ScopeGlobal)
+	require.NoError(t, err)
+	require.NoError(t, sv.SetGlobalFromHook(context.Background(), vars, val, false))
+	val, err = sv.GetGlobalFromHook(context.Background(), vars)
+	require.NoError(t, err)
+	require.Equal(t, dir, val)
+	require.Equal(t, dir, timeutil.GetTZDataDir())
+
+	_, err = sv.Validate(vars, filepath.Join(dir, "not-exist"), ScopeGlobal)
+	require.True(t, ErrWrongValueForVar.Equal(err))
+
+	val, err = sv.Validate(vars, "", ScopeGlobal)
+	require.NoError(t, err)
+	require.NoError(t, sv.SetGlobalFromHook(context.Background(), vars, val, false))
+	require.Equal(t, "", timeutil.GetTZDataDir())
+}
@@ -146,6 +146,18 @@ const (
 	// TiDBGeneralLog is used to log every query in the server in info level.
 	TiDBGeneralLog = "tidb_general_log"
 
+	// TiDBGeneralLogSampleRate is the fraction of statements written to the general log.
+	TiDBGeneralLogSampleRate = "tidb_general_log_sample_rate"
+
+	// TiDBGeneralLogUsers is the comma separated user names whose statements are written to the general log.
+	TiDBGeneralLogUsers = "tidb_general_log_users"
+
+	// TiDBGeneralLogOutput is the destinations of the general log, FILE and/or TABLE.
+	TiDBGeneralLogOutput = "tidb_general_log_output"
+
+	// TiDBGeneralLogTableSize is the max number of statements kept in INFORMATION_SCHEMA.TIDB_GENERAL_LOG.
+	TiDBGeneralLogTableSize = "tidb_general_log_table_size"
+
 	// TiDBLogFileMaxDays is used to log every query in the server in info level.
 	TiDBLogFileMaxDays = "tidb_log_file_max_days"
 
@@ -326,6 +338,8 @@ const (
 	TiDBOptCopCPUFactor = "tidb_opt_copcpu_factor"
 	// TiDBOptTiFlashConcurrencyFactor is concurrency number of tiflash computation.
 	TiDBOptTiFlashConcurrencyFactor = "tidb_opt_tiflash_concurrency_factor"
+	// TiDBOptTiFlashS3ReadFactor is how many times reading data from S3 is slower than reading the local cache in disaggregated TiFlash.
+	TiDBOptTiFlashS3ReadFactor = "tidb_opt_tiflash_s3_read_factor"
 	// TiDBOptNetworkFactor is the network cost of transferring 1 byte data.
 	TiDBOptNetworkFactor = "tidb_opt_network_factor"
 	// TiDBOptScanFactor is the IO cost of scanning 1 byte data on TiKV.
@@ -427,6 +441,10 @@ const (
 	// TiFlash even though the failed TiFlash node has been recovered.
 	TiDBMPPStoreFailTTL = "tidb_mpp_store_fail_ttl"
 
+	// TiDBEnableMPPNodeFailureRecovery indicates whether to reschedule the MPP tasks onto the other TiFlash nodes
+	// when a TiFlash node fails before any result of the query is returned to the client.
+	TiDBEnableMPPNodeFailureRecovery = "tidb_enable_mpp_node_failure_recovery"
+
 	// TiDBInitChunkSize is used to control the init chunk size during query execution.
 	TiDBInitChunkSize = "tidb_init_chunk_size"
 
@@ -872,11 +890,23 @@ const (
 
 	// TiDBEnablePlanReplayerContinuousCapture indicates whether to enable continuous capture
 	TiDBEnablePlanReplayerContinuousCapture = "tidb_enable_plan_replayer_continuous_capture"
+
+	// TiDBPlanReplayerContinuousCaptureThreshold indicates the latency threshold in milliseconds of the statements
+	// captured by continuous capture, the statements executed faster than the threshold are not captured.
+	TiDBPlanReplayerContinuousCaptureThreshold = "tidb_plan_replayer_continuous_capture_threshold"
 	// TiDBEnableReusechunk indicates whether to enable chunk alloc
 	TiDBEnableReusechunk = "tidb_enable_reuse_chunk"
 
 	// TiDBStoreBatchSize indicates the batch size of coprocessor in the same store.
 	TiDBStoreBatchSize = "tidb_store_batch_size"
+	// TiDBCopBatchWaitTime indicates the max time a small coprocessor task to a TiKV store waits for the tasks
+	// of the other requests with the same DAG and start ts, so they're merged into one RPC. It's disabled if it's 0.
+	TiDBCopBatchWaitTime = "tidb_cop_batch_wait_time"
+	// TiDBCopBatchWaitSize indicates the max number of the coprocessor tasks merged into one RPC by
+	// tidb_cop_batch_wait_time, the RPC is sent without waiting any longer once it's reached.
+	TiDBCopBatchWaitSize = "tidb_cop_batch_wait_size"
+	// TiDBKVBackoffStrategy indicates the strategy the kv requests use to back off when they meet retryable errors.
+	TiDBKVBackoffStrategy = "tidb_kv_backoff_strategy"
 
 	// MppExchangeCompressionMode indicates the data compression method in mpp exchange operator
 	MppExchangeCompressionMode = "mpp_exchange_compression_mode"
@@ -948,6 +978,73 @@ const (
 
 	// TiDBSchemaCacheSize indicates the size of infoschema meta data which are cached in V2 implementation.
 	TiDBSchemaCacheSize = "tidb_schema_cache_size"
+
+	// TiDBEnableMaterializedViewRewrite indicates whether a query is answered by reading a materialized view
+	// whose definition is the same as the query.
+	TiDBEnableMaterializedViewRewrite = "tidb_enable_materialized_view_rewrite"
+
+	// TiDBEnableLazyCursorFetch indicates whether the server-side cursor reads the rows from the executor on demand
+	// instead of reading all of them into the row container when the statement is executed.
+	TiDBEnableLazyCursorFetch = "tidb_enable_lazy_cursor_fetch"
+
+	// TiDBEnableResultCache indicates whether the results of the read-only queries are cached in the instance, so
+	// the same query is answered without being executed again.
+	TiDBEnableResultCache = "tidb_enable_result_cache"
+
+	// TiDBResultCacheTSBucket is the size in seconds of the time buckets which the cached results belong to.
+	// A result is only reused by the queries in the same bucket, which bounds the staleness of the result.
+	TiDBResultCacheTSBucket = "tidb_result_cache_ts_bucket"
+
+	// TiDBFoundInResultCache indicates whether the result of the last statement was read from the result cache.
+	TiDBFoundInResultCache = "last_result_from_cache"
+
+	// TiDBShowCreateTableMySQLCompatible indicates whether SHOW CREATE TABLE omits the TiDB specific clauses, such as
+	// AUTO_RANDOM, the clustered index and the placement policy, so the output can be replayed into MySQL.
+	TiDBShowCreateTableMySQLCompatible = "tidb_show_create_table_mysql_compatible"
+
+	// TiDBEnableQueryGCProtection indicates whether the start ts of the statements of the session is protected from GC
+	// until tidb_query_gc_protection_max_time, even if the statements run longer than tidb_gc_max_wait_time.
+	TiDBEnableQueryGCProtection = "tidb_enable_query_gc_protection"
+
+	// TiDBConsistentStmtTimeZone indicates whether the time zone set by the SET_VAR hint is also used to compute the
+	// ranges and prune the partitions of TIMESTAMP columns, and whether the plan cache is keyed by the time zone name
+	// instead of its current offset.
+	TiDBConsistentStmtTimeZone = "tidb_consistent_stmt_time_zone"
+
+	// TiDBRCWriteConflictReplayLimit is the max number of times a deterministic statement is replayed with a fresh
+	// for-update ts when it meets a write conflict in a pessimistic read-committed transaction. 0 disables the replay.
+	TiDBRCWriteConflictReplayLimit = "tidb_rc_write_conflict_replay_limit"
+
+	// TiDBShowTableRegionsMaxRows is the max number of regions returned by `SHOW TABLE ... REGIONS`, the remaining
+	// regions are omitted with a warning. 0 means no limitation. `SHOW TABLE ... REGIONS SUMMARY` is not limited.
+	TiDBShowTableRegionsMaxRows = "tidb_show_table_regions_max_rows"
+
+	// TiDBEnableAdaptiveHashJoin indicates whether the hash join adapts to the data it reads at runtime: an inner join
+	// swaps its build and probe sides if the probe side turns out to be much smaller than the build side, and the
+	// probe workers cache the matched rows of the skewed join keys.
+	TiDBEnableAdaptiveHashJoin = "tidb_enable_adaptive_hash_join"
+
+	// TiDBOptEnableTiKVConcatAggPushDown indicates whether GROUP_CONCAT, JSON_ARRAYAGG and JSON_OBJECTAGG can be
+	// pushed down to TiKV as partial aggregations. Only the coprocessor of unistore supports them for now, so it's
+	// used for test and takes no effect on the other stores.
+	TiDBOptEnableTiKVConcatAggPushDown = "tidb_opt_enable_tikv_concat_agg_push_down"
+
+	// TiDBEnableTxnCTECache indicates whether the materialized results of the CTEs can be reused by the following
+	// statements of a read-only transaction.
+	TiDBEnableTxnCTECache = "tidb_enable_txn_cte_cache"
+
+	// TiDBCTEMaxRecursionRows is the max number of rows a recursive CTE can produce, including the rows of the seed
+	// part. 0 means no limitation.
+	TiDBCTEMaxRecursionRows = "tidb_cte_max_recursion_rows"
+
+	// TiDBCTERecursionPartialResult indicates whether a recursive CTE returns the rows produced so far with a warning
+	// instead of an error when it exceeds cte_max_recursion_depth or tidb_cte_max_recursion_rows.
+	TiDBCTERecursionPartialResult = "tidb_cte_recursion_partial_result"
+
+	// TiDBDMLType indicates how the auto-commit DML statements are executed. STANDARD buffers all the mutations of a
+	// statement in memory until commit. BULK flushes the mutations of large INSERT, REPLACE, UPDATE and DELETE
+	// statements to the storage during execution (pipelined DML), so the memory usage is bounded.
+	TiDBDMLType = "tidb_dml_type"
 )
 
 // TiDB vars that have only global scope
@@ -1066,6 +1163,9 @@ const (
 	TiDBHistoricalStatsDuration = "tidb_historical_stats_duration"
 	// TiDBEnableHistoricalStatsForCapture indicates whether use historical stats in plan replayer capture
 	TiDBEnableHistoricalStatsForCapture = "tidb_enable_historical_stats_for_capture"
+	// TiDBPlanReplayerCaptureStorageQuota indicates the max total size in bytes of the plan replayer capture files
+	// kept on each TiDB instance, the oldest files are removed when the quota is exceeded. 0 means no limit.
+	TiDBPlanReplayerCaptureStorageQuota = "tidb_plan_replayer_capture_storage_quota"
 	// TiDBEnableResourceControl indicates whether resource control feature is enabled
 	TiDBEnableResourceControl = "tidb_enable_resource_control"
 	// TiDBStmtSummaryEnablePersistent indicates whether to enable file persistence for stmtsummary.
@@ -1078,6 +1178,51 @@ const (
 	TiDBStmtSummaryFileMaxSize = "tidb_stmt_summary_file_max_size"
 	// TiDBStmtSummaryFileMaxBackups indicates the maximum number of files written by stmtsummary.
 	TiDBStmtSummaryFileMaxBackups = "tidb_stmt_summary_file_max_backups"
+	// TiDBAuditLogFilename indicates the file name of the built-in audit log.
+	TiDBAuditLogFilename = "tidb_audit_log_filename"
+	// TiDBAuditLogFileMaxDays indicates how many days the audit log files will be kept.
+	TiDBAuditLogFileMaxDays = "tidb_audit_log_file_max_days"
+	// TiDBAuditLogFileMaxSize indicates the maximum size (in mb) of a single audit log file.
+	TiDBAuditLogFileMaxSize = "tidb_audit_log_file_max_size"
+	// TiDBAuditLogFileMaxBackups indicates the maximum number of audit log files.
+	TiDBAuditLogFileMaxBackups = "tidb_audit_log_file_max_backups"
+	// TiDBAuditLogQueueSize indicates the max number of audit log entries waiting to be written.
+	TiDBAuditLogQueueSize = "tidb_audit_log_queue_size"
+	// TiDBAuditLogEnabled indicates whether the built-in audit log is enabled.
+	TiDBAuditLogEnabled = "tidb_audit_log_enabled"
+	// TiDBAuditLogRules is a JSON array of rules filtering the statements written to the audit log.
+	TiDBAuditLogRules = "tidb_audit_log_rules"
+	// TiDBAuditLogRedact indicates whether the literals in the audit log are redacted.
+	TiDBAuditLogRedact = "tidb_audit_log_redact"
+	// TiDBEnablePerfSchemaEvents indicates whether the statement and wait events are recorded
+	// in the events_statements_* and events_waits_* tables of PERFORMANCE_SCHEMA.
+	TiDBEnablePerfSchemaEvents = "tidb_enable_perfschema_events"
+	// TiDBClusterLogMaxRowsPerNode limits the number of log rows read from each node when querying
+	// INFORMATION_SCHEMA.CLUSTER_LOG. 0 means no limit.
+	TiDBClusterLogMaxRowsPerNode = "tidb_cluster_log_max_rows_per_node"
+	// TiDBCheckTableOnlineBatchSize is the number of rows verified in one range by `ADMIN CHECK TABLE ... ONLINE`.
+	TiDBCheckTableOnlineBatchSize = "tidb_check_table_online_batch_size"
+	// TiDBCheckTableOnlineRateLimit limits the rows verified per second by each `ADMIN CHECK TABLE ... ONLINE` job.
+	// 0 means no limit.
+	TiDBCheckTableOnlineRateLimit = "tidb_check_table_online_rate_limit"
+	// TiDBResultCacheMaxSize is the memory limit in bytes of the result cache of each TiDB instance.
+	TiDBResultCacheMaxSize = "tidb_result_cache_max_size"
+	// TiDBResultCacheMaxEntrySize is the max size in bytes of a result which can be cached.
+	TiDBResultCacheMaxEntrySize = "tidb_result_cache_max_entry_size"
+	// TiDBQueryGCProtectionMaxTime is the max time in seconds that the start ts of the sessions enabling
+	// tidb_enable_query_gc_protection delays the GC safe point.
+	TiDBQueryGCProtectionMaxTime = "tidb_query_gc_protection_max_time"
+	// TiDBTZDataPath is the zoneinfo directory to load the named time zones from. The system zoneinfo database,
+	// or the embedded tzdata if the system one is missing, is used if it's empty or a zone is not found in it.
+	TiDBTZDataPath = "tidb_tz_data_path"
+	// TiDBHotspotMitigationPolicy controls how the single-region write hotspots on auto-increment or timestamp
+	// prefixed keys are handled. OFF disables the detection, LOG only logs the hotspots into
+	// mysql.hotspot_mitigation_history, PRE_SPLIT pre-splits the regions ahead of the hot key, and AUTO also
+	// enlarges the auto id cache of the table.
+	TiDBHotspotMitigationPolicy = "tidb_hotspot_mitigation_policy"
+	// TiDBDDLHistoryRetention is how long the finished DDL jobs are kept in the DDL history. The DDL owner deletes
+	// the older jobs periodically. 0 means the jobs are kept forever.
+	TiDBDDLHistoryRetention = "tidb_ddl_history_retention"
 	// TiDBTTLRunningTasks limits the count of running ttl tasks. Default to 0, means 3 times the count of TiKV (or no
 	// limitation, if the storage is not TiKV).
 	TiDBTTLRunningTasks = "tidb_ttl_running_tasks"
@@ -1197,6 +1342,7 @@ const (
 	DefOptCPUFactor                                = 3.0
 	DefOptCopCPUFactor                             = 3.0
 	DefOptTiFlashConcurrencyFactor                 = 24.0
+	DefOptTiFlashS3ReadFactor                      = 4.0
 	DefOptNetworkFactor                            = 1.0
 	DefOptScanFactor                               = 1.5
 	DefOptDescScanFactor                           = 3.0
@@ -1217,10 +1363,14 @@ const (
 	DefMaxChunkSize                                = 1024
 	DefDMLBatchSize                                = 0
 	DefMaxPreparedStmtCount                        = -1
+	DefMaxUserConnections                          = 0
 	DefWaitTimeout                                 = 28800
 	DefTiDBMemQuotaApplyCache                      = 32 << 20 // 32MB.
 	DefTiDBMemQuotaBindingCache                    = 64 << 20 // 64MB.
 	DefTiDBGeneralLog                              = false
+	DefTiDBGeneralLogSampleRate                    = 1.0
+	DefTiDBGeneralLogOutput                        = "FILE"
+	DefTiDBGeneralLogTableSize                     = 1000
 	DefTiDBPProfSQLCPU                             = 0
 	DefTiDBRetryLimit                              = 10
 	DefTiDBDisableTxnAutoRetry                     = true
@@ -1247,6 +1397,7 @@ const (
 	DefTiFlashQuerySpillRatio                      = 0.7
 	DefTiDBEnableTiFlashPipelineMode               = true
 	DefTiDBMPPStoreFailTTL                         = "60s"
+	DefTiDBEnableMPPNodeFailureRecovery            = false
 	DefTiDBTxnMode                                 = PessimisticTxnMode
 	DefTiDBRowFormatV1                             = 1
 	DefTiDBRowFormatV2                             = 2
@@ -1434,8 +1585,13 @@ const (
 	DefPasswordReuseHistory                           = 0
 	DefPasswordReuseTime                              = 0
 	DefTiDBStoreBatchSize                             = 4
+	DefTiDBCopBatchWaitTime                           = 0
+	DefTiDBCopBatchWaitSize                           = 16
+	DefTiDBKVBackoffStrategy                          = "default"
 	DefTiDBHistoricalStatsDuration                    = 7 * 24 * time.Hour
 	DefTiDBEnableHistoricalStatsForCapture            = false
+	DefTiDBPlanReplayerCaptureThreshold               = 0
+	DefTiDBPlanReplayerCaptureStorageQuota            = 0
 	DefTiDBTTLJobScheduleWindowStartTime              = "00:00 +0000"
 	DefTiDBTTLJobScheduleWindowEndTime                = "23:59 +0000"
 	DefTiDBTTLScanWorkerCount                         = 4
@@ -1480,6 +1636,32 @@ const (
 	DefTiDBTxnEntrySizeLimit                          = 0
 	DefTiDBSchemaCacheSize                            = 0
 	DefTiDBLowResolutionTSOUpdateInterval             = 2000
+	DefTiDBAuditLogEnabled                            = false
+	DefTiDBAuditLogRedact                             = true
+	DefTiDBEnablePerfSchemaEvents                     = false
+	DefTiDBClusterLogMaxRowsPerNode                   = 100000
+	DefTiDBCheckTableOnlineBatchSize                  = 10000
+	DefTiDBCheckTableOnlineRateLimit                  = 0
+	DefTiDBEnableMaterializedViewRewrite              = false
+	DefTiDBEnableLazyCursorFetch                      = false
+	DefTiDBEnableResultCache                          = false
+	DefTiDBResultCacheTSBucket                        = 5
+	DefTiDBResultCacheMaxSize                         = 64 << 20 // 64MB.
+	DefTiDBResultCacheMaxEntrySize                    = 1 << 20  // 1MB.
+	DefTiDBShowCreateTableMySQLCompatible             = false
+	DefTiDBEnableQueryGCProtection                    = false
+	DefTiDBQueryGCProtectionMaxTime                   = 2 * 24 * 60 * 60
+	DefTiDBConsistentStmtTimeZone                     = false
+	DefTiDBRCWriteConflictReplayLimit                 = 0
+	DefTiDBShowTableRegionsMaxRows                    = 10000
+	DefTiDBEnableAdaptiveHashJoin                     = false
+	DefTiDBOptEnableTiKVConcatAggPushDown             = false
+	DefTiDBEnableTxnCTECache                          = false
+	DefTiDBCTEMaxRecursionRows                        = 0
+	DefTiDBCTERecursionPartialResult                  = false
+	DefTiDBDMLType                                    = DMLTypeStandard
+	DefTiDBHotspotMitigationPolicy                    = HotspotMitigationOff
+	DefTiDBDDLHistoryRetention                        = time.Duration(0)
 )
 
 // Process global variables.
@@ -1571,8 +1753,14 @@ var (
 	PasswordReuseInterval           = atomic.NewInt64(DefPasswordReuseTime)
 	IsSandBoxModeEnabled            = atomic.NewBool(false)
 	MaxPreparedStmtCountValue       = atomic.NewInt64(DefMaxPreparedStmtCount)
+	MaxUserConnectionsValue         = atomic.NewUint32(DefMaxUserConnections)
+	EnableEventScheduler            = atomic.NewBool(false)
 	HistoricalStatsDuration         = atomic.NewDuration(DefTiDBHistoricalStatsDuration)
+	CopBatchWaitTime                = atomic.NewDuration(DefTiDBCopBatchWaitTime)
+	CopBatchWaitSize                = atomic.NewInt64(DefTiDBCopBatchWaitSize)
+	KVBackoffStrategy               = atomic.NewString(DefTiDBKVBackoffStrategy)
 	EnableHistoricalStatsForCapture = atomic.NewBool(DefTiDBEnableHistoricalStatsForCapture)
+	PlanReplayerCaptureStorageQuota = atomic.NewInt64(DefTiDBPlanReplayerCaptureStorageQuota)
 	TTLRunningTasks                 = atomic.NewInt32(DefTiDBTTLRunningTasks)
 	// always set the default value to false because the resource control in kv-client is not inited
 	// It will be initialized to the right value after the first call of `rebuildSysVarCache`
@@ -1587,6 +1775,18 @@ var (
 	TxnEntrySizeLimit         = atomic.NewUint64(DefTiDBTxnEntrySizeLimit)
 
 	SchemaCacheSize = atomic.NewInt64(DefTiDBSchemaCacheSize)
+
+	CheckTableOnlineBatchSize = atomic.NewInt64(DefTiDBCheckTableOnlineBatchSize)
+	CheckTableOnlineRateLimit = atomic.NewInt64(DefTiDBCheckTableOnlineRateLimit)
+
+	ResultCacheMaxSize      = atomic.NewInt64(DefTiDBResultCacheMaxSize)
+	ResultCacheMaxEntrySize = atomic.NewInt64(DefTiDBResultCacheMaxEntrySize)
+
+	QueryGCProtectionMaxTime = atomic.NewInt64(DefTiDBQueryGCProtectionMaxTime)
+
+	HotspotMitigationPolicy = atomic.NewString(DefTiDBHotspotMitigationPolicy)
+
+	DDLHistoryRetention = atomic.NewDuration(DefTiDBDDLHistoryRetention)
 )
 
 var (
@@ -1596,6 +1796,8 @@ var (
 	GetMemQuotaAnalyze func() int64 = nil
 	// SetStatsCacheCapacity is the func registered by domain to set statsCache memory quota.
 	SetStatsCacheCapacity atomic.Pointer[func(int64)]
+	// SetResultCacheCapacity is the func registered by domain to set the memory limit of the result cache.
+	SetResultCacheCapacity atomic.Pointer[func(int64)]
 	// SetPDClientDynamicOption is the func registered by domain
 	SetPDClientDynamicOption atomic.Pointer[func(string, string) error]
 	// SwitchMDL is the func registered by DDL to switch MDL.
@@ -1616,6 +1818,8 @@ var (
 	ValidateCloudStorageURI func(ctx context.Context, uri string) error
 	// SetLowResolutionTSOUpdateInterval is the func registered by domain to set slow resolution tso update interval.
 	SetLowResolutionTSOUpdateInterval func(interval time.Duration) error = nil
+	// SetKVBackoffStrategy is the func registered by copr to switch the backoff strategy of the kv requests.
+	SetKVBackoffStrategy func(name string) error = nil
 )
 
 // Hooks functions for Cluster Resource Control.
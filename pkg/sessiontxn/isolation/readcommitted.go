@@ -56,6 +56,10 @@ type PessimisticRCTxnContextProvider struct {
 	latestOracleTSValid bool
 	// checkTSInWriteStmt is used to set RCCheckTS isolation for getting value when doing point-write
 	checkTSInWriteStmt bool
+	// stmtNode is the current statement, it is used to check whether the statement can be replayed.
+	stmtNode ast.StmtNode
+	// stmtReplayCount is the number of times the current statement has been replayed for write conflicts.
+	stmtReplayCount int
 }
 
 // NewPessimisticRCTxnContextProvider returns a new PessimisticRCTxnContextProvider
@@ -98,6 +102,8 @@ func (p *PessimisticRCTxnContextProvider) OnStmtStart(ctx context.Context, node
 		p.sctx.GetSessionVars().StmtCtx.RCCheckTS = true
 	}
 	p.checkTSInWriteStmt = false
+	p.stmtNode = node
+	p.stmtReplayCount = 0
 
 	return p.prepareStmt(!p.isTxnPrepared)
 }
@@ -200,9 +206,12 @@ func (p *PessimisticRCTxnContextProvider) getStmtTS() (ts uint64, err error) {
 // At this point the query will be retried from the beginning.
 func (p *PessimisticRCTxnContextProvider) handleAfterQueryError(queryErr error) (sessiontxn.StmtErrorAction, error) {
 	sessVars := p.sctx.GetSessionVars()
-	if !errors.ErrorEqual(queryErr, kv.ErrWriteConflict) || !sessVars.StmtCtx.RCCheckTS {
+	if !errors.ErrorEqual(queryErr, kv.ErrWriteConflict) {
 		return sessiontxn.NoIdea()
 	}
+	if !sessVars.StmtCtx.RCCheckTS {
+		return p.handleWriteConflictReplay(queryErr)
+	}
 
 	isolation_metrics.RcReadCheckTSWriteConfilictCounter.Inc()
 
@@ -211,6 +220,25 @@ func (p *PessimisticRCTxnContextProvider) handleAfterQueryError(queryErr error)
 	return sessiontxn.RetryReady()
 }
 
+// handleWriteConflictReplay decides whether to replay the statement with a fresh for-update ts when it meets a write
+// conflict. The statement is replayed at most tidb_rc_write_conflict_replay_limit times, and only if it is deterministic.
+func (p *PessimisticRCTxnContextProvider) handleWriteConflictReplay(queryErr error) (sessiontxn.StmtErrorAction, error) {
+	sessVars := p.sctx.GetSessionVars()
+	if p.stmtReplayCount >= sessVars.RCWriteConflictReplayLimit || p.stmtNode == nil ||
+		!plannercore.IsDeterministic(p.stmtNode, sessVars) {
+		return sessiontxn.NoIdea()
+	}
+	p.stmtReplayCount++
+	// The recorded auto IDs may belong to the previous statements of the transaction.
+	sessVars.RetryInfo.SkipRecordedAutoIDs()
+
+	logutil.Logger(p.ctx).Info("RC statement meets write conflict, replay the statement",
+		zap.Uint64("txn", sessVars.TxnCtx.StartTS),
+		zap.Int("replayCount", p.stmtReplayCount),
+		zap.String("sql", sessVars.StmtCtx.OriginalSQL), zap.Error(queryErr))
+	return sessiontxn.RetryReady()
+}
+
 func (p *PessimisticRCTxnContextProvider) handleAfterPessimisticLockError(ctx context.Context, lockErr error) (sessiontxn.StmtErrorAction, error) {
 	txnCtx := p.sctx.GetSessionVars().TxnCtx
 	retryable := false
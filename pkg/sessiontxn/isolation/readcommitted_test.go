@@ -239,6 +239,68 @@ func TestPessimisticRCTxnContextProviderLockError(t *testing.T) {
 	}
 }
 
+func TestPessimisticRCTxnContextProviderWriteConflictReplay(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+
+	tk := testkit.NewTestKit(t, store)
+	defer tk.MustExec("rollback")
+
+	se := tk.Session()
+	provider := initializePessimisticRCProvider(t, tk)
+
+	parse := func(sql string) ast.StmtNode {
+		stmt, err := parser.New().ParseOneStmt(sql, "", "")
+		require.NoError(t, err)
+		return stmt
+	}
+	updateStmt := parse("update t set v = v + 1 where id = 1")
+	randStmt := parse("update t set v = rand() where id = 1")
+
+	// the replay is disabled by default
+	require.NoError(t, executor.ResetContextOfStmt(se, updateStmt))
+	require.NoError(t, provider.OnStmtStart(context.TODO(), updateStmt))
+	nextAction, err := provider.OnStmtErrorForNextAction(context.Background(), sessiontxn.StmtErrAfterQuery, kv.ErrWriteConflict)
+	require.NoError(t, err)
+	require.Equal(t, sessiontxn.StmtActionNoIdea, nextAction)
+
+	// the deterministic statement is replayed up to the limit
+	se.GetSessionVars().RCWriteConflictReplayLimit = 2
+	require.NoError(t, executor.ResetContextOfStmt(se, updateStmt))
+	require.NoError(t, provider.OnStmtStart(context.TODO(), updateStmt))
+	for i := 0; i < 2; i++ {
+		nextAction, err = provider.OnStmtErrorForNextAction(context.Background(), sessiontxn.StmtErrAfterQuery, kv.ErrWriteConflict)
+		require.NoError(t, err)
+		require.Equal(t, sessiontxn.StmtActionRetryReady, nextAction)
+		compareTS := getOracleTS(t, se)
+		require.NoError(t, provider.OnStmtRetry(context.TODO()))
+		ts, err := provider.GetStmtForUpdateTS()
+		require.NoError(t, err)
+		require.Greater(t, ts, compareTS)
+	}
+	nextAction, err = provider.OnStmtErrorForNextAction(context.Background(), sessiontxn.StmtErrAfterQuery, kv.ErrWriteConflict)
+	require.NoError(t, err)
+	require.Equal(t, sessiontxn.StmtActionNoIdea, nextAction)
+
+	// the count is reset by the next statement
+	require.NoError(t, executor.ResetContextOfStmt(se, updateStmt))
+	require.NoError(t, provider.OnStmtStart(context.TODO(), updateStmt))
+	nextAction, err = provider.OnStmtErrorForNextAction(context.Background(), sessiontxn.StmtErrAfterQuery, kv.ErrWriteConflict)
+	require.NoError(t, err)
+	require.Equal(t, sessiontxn.StmtActionRetryReady, nextAction)
+
+	// other errors and the non-deterministic statements are not replayed
+	require.NoError(t, executor.ResetContextOfStmt(se, updateStmt))
+	require.NoError(t, provider.OnStmtStart(context.TODO(), updateStmt))
+	nextAction, err = provider.OnStmtErrorForNextAction(context.Background(), sessiontxn.StmtErrAfterQuery, errors.New("err"))
+	require.NoError(t, err)
+	require.Equal(t, sessiontxn.StmtActionNoIdea, nextAction)
+	require.NoError(t, executor.ResetContextOfStmt(se, randStmt))
+	require.NoError(t, provider.OnStmtStart(context.TODO(), randStmt))
+	nextAction, err = provider.OnStmtErrorForNextAction(context.Background(), sessiontxn.StmtErrAfterQuery, kv.ErrWriteConflict)
+	require.NoError(t, err)
+	require.Equal(t, sessiontxn.StmtActionNoIdea, nextAction)
+}
+
 func TestPessimisticRCTxnContextProviderTS(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 
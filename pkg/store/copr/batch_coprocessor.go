@@ -40,6 +40,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/store/driver/backoff"
 	derr "github.com/pingcap/tidb/pkg/store/driver/error"
+	"github.com/pingcap/tidb/pkg/store/regioncache"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/tiflash"
@@ -1358,7 +1359,7 @@ func (b *batchCopIterator) handleBatchCopResponse(bo *Backoffer, response *copro
 		for idx, retry := range response.RetryRegions {
 			id := tikv.NewRegionVerID(retry.Id, retry.RegionEpoch.ConfVer, retry.RegionEpoch.Version)
 			logutil.BgLogger().Info("invalid region because tiflash detected stale region", zap.String("region id", id.String()))
-			b.store.GetRegionCache().InvalidateCachedRegionWithReason(id, tikv.EpochNotMatch)
+			regioncache.InvalidateStaleRegion(b.store.GetRegionCache().RegionCache, id)
 			if idx >= 10 {
 				logutil.BgLogger().Info("stale regions are too many, so we omit the rest ones")
 				break
@@ -0,0 +1,229 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package copr
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/coprocessor"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/tikv/client-go/v2/tikv"
+)
+
+// copBatchWaiter merges the small coprocessor tasks to the same TiKV store from the concurrent requests into one
+// RPC like a group commit, to cut the per-RPC overhead of the workloads issuing many small point-range requests.
+//
+// A coprocessor RPC carries the tasks of the other regions as the store batched tasks, which share the DAG, the
+// start ts and the context of the RPC, so only the tasks with the same ones are merged, e.g. the table side tasks
+// of an IndexLookUp or the inner side tasks of an IndexJoin. The first task opens a batch and becomes its leader,
+// the tasks arriving later join the batch as the followers. The leader waits until tidb_cop_batch_wait_time passes
+// or tidb_cop_batch_wait_size tasks are in the batch, then it sends all of them in one RPC and hands the responses
+// over to the followers. The followers whose tasks are not answered by the RPC send their tasks by themselves.
+type copBatchWaiter struct {
+	mu      sync.Mutex
+	batches map[copBatchKey]*copBatch
+}
+
+// copBatchKey identifies the tasks which can be sent in one RPC.
+type copBatchKey struct {
+	storeID       uint64
+	startTS       uint64
+	tp            int64
+	schemaVer     int64
+	data          string
+	isolation     kv.IsoLevel
+	priority      int
+	notFillCache  bool
+	resourceGroup string
+	requestSource string
+	taskID        uint64
+	connID        uint64
+}
+
+type copBatch struct {
+	key       copBatchKey
+	maxSize   int
+	followers []*copBatchFollower
+	// full is closed when the batch has maxSize tasks, so the leader sends them without waiting any longer.
+	full chan struct{}
+}
+
+type copBatchFollower struct {
+	task *batchedCopTask
+	// result receives the response of the task from the leader, a nil response means the task isn't answered and
+	// should be sent by the follower itself.
+	result chan copBatchResult
+}
+
+type copBatchResult struct {
+	resp      *coprocessor.StoreBatchTaskResponse
+	storeAddr string
+}
+
+var globalCopBatchWaiter = &copBatchWaiter{batches: make(map[copBatchKey]*copBatch)}
+
+// join adds the task to the batch of the key. It returns the batch if the task opens it and becomes the leader,
+// otherwise it returns the follower of the task.
+func (w *copBatchWaiter) join(key copBatchKey, task *batchedCopTask, maxSize int) (*copBatch, *copBatchFollower) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	b, ok := w.batches[key]
+	if !ok {
+		b = &copBatch{key: key, maxSize: maxSize, full: make(chan struct{})}
+		w.batches[key] = b
+		return b, nil
+	}
+	f := &copBatchFollower{task: task, result: make(chan copBatchResult, 1)}
+	b.followers = append(b.followers, f)
+	if len(b.followers)+1 >= b.maxSize {
+		w.sealLocked(b)
+		close(b.full)
+	}
+	return nil, f
+}
+
+// wait waits until the wait time passes, the batch is full or the context is done. Then it seals the batch so no
+// task joins it anymore, and returns the followers of the batch.
+func (w *copBatchWaiter) wait(ctx context.Context, b *copBatch, waitTime time.Duration) []*copBatchFollower {
+	timer := time.NewTimer(waitTime)
+	select {
+	case <-b.full:
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	timer.Stop()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.sealLocked(b)
+	return b.followers
+}
+
+// sealLocked removes the batch from the waiter if it's still open, the caller must hold the lock.
+func (w *copBatchWaiter) sealLocked(b *copBatch) {
+	if w.batches[b.key] == b {
+		delete(w.batches, b.key)
+	}
+}
+
+// waitResult waits for the response of the task from the leader.
+func (f *copBatchFollower) waitResult(ctx context.Context) (copBatchResult, error) {
+	select {
+	case result := <-f.result:
+		return result, nil
+	case <-ctx.Done():
+		return copBatchResult{}, errors.Trace(ctx.Err())
+	}
+}
+
+// joinCopBatch makes the task join a batch if it can be merged with the tasks of the other requests. It returns
+// the batch if the task is the leader of it, or the follower of the task, or both nil if the task isn't batched.
+func (worker *copIteratorWorker) joinCopBatch(bo *Backoffer, task *copTask) (*copBatch, *copBatchFollower) {
+	waitSize := int(variable.CopBatchWaitSize.Load())
+	if variable.CopBatchWaitTime.Load() <= 0 || waitSize < 2 {
+		return nil, nil
+	}
+	// The tasks which are already batched, paged, retried or sent to a specified replica are sent as is.
+	if task.storeType != kv.TiKV || len(task.batchTaskList) > 0 || task.paging || !isSmallTask(task) ||
+		task.redirect2Replica != nil || task.meetLockFallback || task.firstReadType != "" {
+		return nil, nil
+	}
+	if worker.req.ReplicaRead != kv.ReplicaReadLeader || worker.req.IsStaleness || worker.req.RunawayChecker != nil {
+		return nil, nil
+	}
+	batchedTask, err := worker.store.GetRegionCache().BuildBatchTask(bo, worker.req, task, kv.ReplicaReadLeader)
+	if err != nil || batchedTask == nil {
+		return nil, nil
+	}
+	key := copBatchKey{
+		storeID:       batchedTask.storeID,
+		startTS:       worker.req.StartTs,
+		tp:            worker.req.Tp,
+		schemaVer:     worker.req.SchemaVar,
+		data:          string(worker.req.Data),
+		isolation:     worker.req.IsolationLevel,
+		priority:      worker.req.Priority,
+		notFillCache:  worker.req.NotFillCache,
+		resourceGroup: worker.req.ResourceGroupName,
+		requestSource: task.requestSource.GetRequestSource(),
+		taskID:        worker.req.TaskID,
+		connID:        worker.req.ConnID,
+	}
+	return globalCopBatchWaiter.join(key, batchedTask, waitSize)
+}
+
+// waitCopBatchFollowers waits for the followers of the batch led by the task, and appends their tasks to the request.
+func waitCopBatchFollowers(bo *Backoffer, batch *copBatch, copReq *coprocessor.Request) []*copBatchFollower {
+	followers := globalCopBatchWaiter.wait(bo.GetCtx(), batch, variable.CopBatchWaitTime.Load())
+	for i, f := range followers {
+		copReq.Tasks = append(copReq.Tasks, &coprocessor.StoreBatchTask{
+			RegionId:    f.task.region.GetRegionId(),
+			RegionEpoch: f.task.region.GetRegionEpoch(),
+			Peer:        f.task.peer,
+			Ranges:      f.task.region.GetRanges(),
+			// The leader task isn't batched, so the task IDs of the followers don't conflict with the others.
+			TaskId: uint64(i + 1),
+		})
+	}
+	metrics.DistSQLCoprBatchWaitSize.Observe(float64(len(followers) + 1))
+	return followers
+}
+
+// deliverCopBatchResponses hands the responses of the followers' tasks over to them, and removes the responses
+// from the response of the leader. The followers which are not answered are released by releaseCopBatchFollowers.
+func deliverCopBatchResponses(resp *coprocessor.Response, followers []*copBatchFollower, storeAddr string) {
+	if resp == nil || len(followers) == 0 {
+		return
+	}
+	for _, batchResp := range resp.BatchResponses {
+		idx := int(batchResp.GetTaskId()) - 1
+		if idx < 0 || idx >= len(followers) || followers[idx] == nil {
+			continue
+		}
+		followers[idx].result <- copBatchResult{resp: batchResp, storeAddr: storeAddr}
+		followers[idx] = nil
+	}
+	resp.BatchResponses = nil
+}
+
+// releaseCopBatchFollowers makes the followers which are not answered send their tasks by themselves.
+func releaseCopBatchFollowers(followers []*copBatchFollower) {
+	for _, f := range followers {
+		if f != nil {
+			f.result <- copBatchResult{}
+		}
+	}
+}
+
+// handleCopBatchResult handles the response of the task which is sent by the leader of its batch.
+func (worker *copIteratorWorker) handleCopBatchResult(bo *Backoffer, task *copTask, result copBatchResult, ch chan<- *copResponse, costTime time.Duration) ([]*copTask, error) {
+	task.storeAddr = result.storeAddr
+	resp := &copResponse{
+		pbResp: &coprocessor.Response{
+			Data:          result.resp.Data,
+			RegionError:   result.resp.RegionError,
+			Locked:        result.resp.Locked,
+			OtherError:    result.resp.OtherError,
+			ExecDetailsV2: result.resp.ExecDetailsV2,
+		},
+	}
+	// need Addr for recording details.
+	rpcCtx := &tikv.RPCContext{Addr: result.storeAddr}
+	return worker.handleCopResponse(bo, rpcCtx, resp, nil, nil, task, ch, nil, costTime)
+}
@@ -191,6 +191,11 @@ func (c *CopClient) BuildCopIterator(ctx context.Context, req *kv.Request, vars
 		runawayChecker:   req.RunawayChecker,
 	}
 	it.tasks = tasks
+	if req.IsPipelinedTxn {
+		// Skip the locks flushed by the pipelined transaction itself instead of trying to resolve them, otherwise the
+		// request waits for the primary lock of its own transaction to expire.
+		it.resolvedLocks.Put(req.StartTs)
+	}
 	if it.concurrency > len(tasks) {
 		it.concurrency = len(tasks)
 	}
@@ -723,6 +728,13 @@ type copIteratorWorker struct {
 
 	storeBatchedNum         *atomic.Uint64
 	storeBatchedFallbackNum *atomic.Uint64
+
+	// taskRUDetails collects the RU consumed by the current task when the execution info is collected,
+	// and reportedRUDetails is the part of it which has been reported in the responses.
+	taskRUDetails     *util.RUDetails
+	reportedRUDetails *util.RUDetails
+	// resolveLockTime is the time spent on resolving the locks met since the last response.
+	resolveLockTime time.Duration
 }
 
 // copIteratorTaskSender sends tasks to taskCh then wait for the workers to exit.
@@ -795,6 +807,7 @@ var finCopResp *copResponse
 
 func init() {
 	finCopResp = &copResponse{}
+	variable.SetKVBackoffStrategy = backoff.SetStrategy
 }
 
 // run is a worker function that get a copTask from channel, handle it and
@@ -1127,6 +1140,18 @@ func (worker *copIteratorWorker) handleTask(ctx context.Context, task *copTask,
 			worker.sendToRespCh(resp, respCh, false)
 		}
 	}()
+	if worker.enableCollectExecutionInfo {
+		// The RU consumed by the task is collected separately so it can be reported in the runtime stats of
+		// the operator, and it's merged into the RU of the statement when the task is finished.
+		if stmtRUDetails, ok := ctx.Value(util.RUDetailsCtxKey).(*util.RUDetails); ok && stmtRUDetails != nil {
+			worker.taskRUDetails, worker.reportedRUDetails = util.NewRUDetails(), util.NewRUDetails()
+			ctx = context.WithValue(ctx, util.RUDetailsCtxKey, worker.taskRUDetails)
+			defer func() {
+				stmtRUDetails.Merge(worker.taskRUDetails)
+				worker.taskRUDetails, worker.reportedRUDetails = nil, nil
+			}()
+		}
+	}
 	remainTasks := []*copTask{task}
 	backoffermap := make(map[uint64]*Backoffer)
 	for len(remainTasks) > 0 {
@@ -1242,6 +1267,23 @@ func (worker *copIteratorWorker) handleTaskOnce(bo *Backoffer, task *copTask, ch
 		req.ReplicaReadType = options.GetTiKVReplicaReadType(kv.ReplicaReadFollower)
 		ops = append(ops, tikv.WithMatchStores([]uint64{*task.redirect2Replica}))
 	}
+	var batchFollowers []*copBatchFollower
+	batch, follower := worker.joinCopBatch(bo, task)
+	if follower != nil {
+		result, err := follower.waitResult(bo.GetCtx())
+		if err != nil {
+			return nil, err
+		}
+		if result.resp != nil {
+			return worker.handleCopBatchResult(bo, task, result, ch, time.Since(startTime))
+		}
+		// The task isn't answered by the leader, send it by itself.
+	} else if batch != nil {
+		batchFollowers = waitCopBatchFollowers(bo, batch, &copReq)
+		defer func() {
+			releaseCopBatchFollowers(batchFollowers)
+		}()
+	}
 	resp, rpcCtx, storeAddr, err := worker.kvclient.SendReqCtx(bo.TiKVBackoffer(), req, task.region,
 		timeout, getEndPointType(task.storeType), task.storeAddr, ops...)
 	err = derr.ToTiDBErr(err)
@@ -1269,6 +1311,7 @@ func (worker *copIteratorWorker) handleTaskOnce(bo *Backoffer, task *copTask, ch
 
 	costTime := time.Since(startTime)
 	copResp := resp.Resp.(*coprocessor.Response)
+	deliverCopBatchResponses(copResp, batchFollowers, storeAddr)
 
 	if costTime > minLogCopTaskTime {
 		worker.logTimeCopTask(costTime, task, bo, copResp)
@@ -1632,6 +1675,9 @@ func (worker *copIteratorWorker) handleLockErr(bo *Backoffer, lockErr *kvrpcpb.L
 		Detail:        resolveLockDetail,
 	}
 	resolveLocksRes, err1 := worker.kvclient.ResolveLocksWithOpts(bo.TiKVBackoffer(), resolveLocksOpts)
+	if resolveLockDetail != nil {
+		worker.resolveLockTime += time.Duration(resolveLockDetail.ResolveLockTime)
+	}
 	err1 = derr.ToTiDBErr(err1)
 	if err1 != nil {
 		return errors.Trace(err1)
@@ -1770,6 +1816,17 @@ func (worker *copIteratorWorker) handleCollectExecutionInfo(bo *Backoffer, rpcCt
 	if rpcCtx != nil {
 		resp.detail.CalleeAddress = rpcCtx.Addr
 	}
+	resp.detail.ResolveLockTime = worker.resolveLockTime
+	worker.resolveLockTime = 0
+	if worker.taskRUDetails != nil {
+		// only the RU consumed since the last response is reported, to avoid counting it repeatedly.
+		ruDetails := util.NewRUDetailsWith(
+			worker.taskRUDetails.RRU()-worker.reportedRUDetails.RRU(),
+			worker.taskRUDetails.WRU()-worker.reportedRUDetails.WRU(),
+			worker.taskRUDetails.RUWaitDuration()-worker.reportedRUDetails.RUWaitDuration())
+		worker.reportedRUDetails.Merge(ruDetails)
+		resp.detail.RUDetails = ruDetails
+	}
 	sd := &util.ScanDetail{}
 	td := util.TimeDetail{}
 	if pbDetails := resp.pbResp.ExecDetailsV2; pbDetails != nil {
@@ -1801,6 +1858,10 @@ type CopRuntimeStats struct {
 	tikv.RegionRequestRuntimeStats
 
 	CoprCacheHit bool
+	// RUDetails is the RU consumed by the requests of the response, it's nil if the RU is not collected.
+	RUDetails *util.RUDetails
+	// ResolveLockTime is the time spent on resolving the locks met before the response.
+	ResolveLockTime time.Duration
 }
 
 func (worker *copIteratorWorker) handleTiDBSendReqErr(err error, task *copTask, ch chan<- *copResponse) error {
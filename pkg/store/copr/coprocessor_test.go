@@ -17,6 +17,7 @@ package copr
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/pingcap/kvproto/pkg/coprocessor"
 	"github.com/pingcap/tidb/pkg/kv"
@@ -875,3 +876,53 @@ func TestSmallTaskConcurrencyLimit(t *testing.T) {
 	require.Equal(t, smallConcPerCore, conc)
 	require.Equal(t, smallTaskCount, count)
 }
+
+func TestCopBatchWaiter(t *testing.T) {
+	w := &copBatchWaiter{batches: make(map[copBatchKey]*copBatch)}
+	key := copBatchKey{storeID: 1, startTS: 100}
+
+	// The first task leads the batch, the batch is sent when it's full.
+	leader, f := w.join(key, &batchedCopTask{}, 3)
+	require.NotNil(t, leader)
+	require.Nil(t, f)
+	_, f1 := w.join(key, &batchedCopTask{}, 3)
+	require.NotNil(t, f1)
+	// The task of another store opens its own batch.
+	other, _ := w.join(copBatchKey{storeID: 2, startTS: 100}, &batchedCopTask{}, 3)
+	require.NotNil(t, other)
+	_, f2 := w.join(key, &batchedCopTask{}, 3)
+	require.NotNil(t, f2)
+	start := time.Now()
+	followers := w.wait(context.Background(), leader, time.Minute)
+	require.Less(t, time.Since(start), time.Minute)
+	require.Equal(t, []*copBatchFollower{f1, f2}, followers)
+
+	// The batch is sealed, so the next task leads a new batch, which is sent when the wait time passes.
+	leader, _ = w.join(key, &batchedCopTask{}, 3)
+	require.NotNil(t, leader)
+	_, f3 := w.join(key, &batchedCopTask{}, 3)
+	followers = w.wait(context.Background(), leader, 10*time.Millisecond)
+	require.Equal(t, []*copBatchFollower{f3}, followers)
+	leader, _ = w.join(key, &batchedCopTask{}, 3)
+	require.NotNil(t, leader)
+
+	// The responses are delivered by the task IDs, the followers not answered send their tasks by themselves.
+	resp := &coprocessor.Response{BatchResponses: []*coprocessor.StoreBatchTaskResponse{{TaskId: 2, Data: []byte("2")}}}
+	followers = []*copBatchFollower{f1, f2}
+	deliverCopBatchResponses(resp, followers, "store")
+	require.Nil(t, resp.BatchResponses)
+	releaseCopBatchFollowers(followers)
+	result, err := f1.waitResult(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, result.resp)
+	result, err = f2.waitResult(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "2", string(result.resp.Data))
+	require.Equal(t, "store", result.storeAddr)
+
+	// The follower stops waiting when its context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = f3.waitResult(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
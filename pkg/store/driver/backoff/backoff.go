@@ -16,21 +16,29 @@ package backoff
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/pingcap/tidb/pkg/kv"
 	derr "github.com/pingcap/tidb/pkg/store/driver/error"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/tikv/client-go/v2/tikv"
 )
 
 // Backoffer wraps tikv.Backoffer and converts the error which returns by the functions of tikv.Backoffer to tidb error.
 type Backoffer struct {
 	b *tikv.Backoffer
+	// maxSleep is the backoff budget in milliseconds, it's 0 if unknown.
+	maxSleep int
+	// budgetRecorded indicates whether the budget has been recorded to the statement.
+	budgetRecorded bool
 }
 
 // NewBackofferWithVars creates a Backoffer with maximum sleep time(in ms) and kv.Variables.
+// The maximum sleep time is adjusted by the current backoff strategy.
 func NewBackofferWithVars(ctx context.Context, maxSleep int, vars *kv.Variables) *Backoffer {
+	maxSleep = GetStrategy().MaxSleep(maxSleep)
 	b := tikv.NewBackofferWithVars(ctx, maxSleep, vars)
-	return &Backoffer{b: b}
+	return &Backoffer{b: b, maxSleep: maxSleep}
 }
 
 // NewBackofferWithTikvBo creates a Backoffer with tikv.Backoffer
@@ -39,9 +47,11 @@ func NewBackofferWithTikvBo(bo *tikv.Backoffer) *Backoffer {
 }
 
 // NewBackoffer creates a Backoffer with maximum sleep time(in ms).
+// The maximum sleep time is adjusted by the current backoff strategy.
 func NewBackoffer(ctx context.Context, maxSleep int) *Backoffer {
+	maxSleep = GetStrategy().MaxSleep(maxSleep)
 	b := tikv.NewBackoffer(ctx, maxSleep)
-	return &Backoffer{b: b}
+	return &Backoffer{b: b, maxSleep: maxSleep}
 }
 
 // TiKVBackoffer returns tikv.Backoffer.
@@ -51,8 +61,15 @@ func (b *Backoffer) TiKVBackoffer() *tikv.Backoffer {
 
 // Backoff sleeps a while base on the BackoffConfig and records the error message.
 // It returns a retryable error if total sleep time exceeds maxSleep.
+// The BackoffConfig is adjusted by the current backoff strategy.
 func (b *Backoffer) Backoff(cfg *tikv.BackoffConfig, err error) error {
-	e := b.b.Backoff(cfg, err)
+	strategy := GetStrategy()
+	sleptBefore := b.b.GetTotalSleep()
+	e := b.b.Backoff(strategy.Config(cfg), err)
+	if e == nil {
+		strategy.OnBackoff(cfg, b.b.GetBackoffTimes()[cfg.String()])
+	}
+	b.recordBudget(sleptBefore, e)
 	return derr.ToTiDBErr(e)
 }
 
@@ -87,3 +104,19 @@ func (b *Backoffer) GetBackoffSleepMS() map[string]int {
 func (b *Backoffer) GetTotalSleep() int {
 	return b.b.GetTotalSleep()
 }
+
+// recordBudget records how much of the backoff budget is used to the statement details in the context.
+func (b *Backoffer) recordBudget(sleptBefore int, err error) {
+	details, ok := b.GetCtx().Value(execdetails.StmtExecDetailKey).(*execdetails.StmtExecDetails)
+	if !ok {
+		return
+	}
+	if !b.budgetRecorded {
+		b.budgetRecorded = true
+		atomic.AddInt64(&details.BackoffBudgetMs, int64(b.maxSleep))
+	}
+	atomic.AddInt64(&details.BackoffBudgetUsedMs, int64(b.b.GetTotalSleep()-sleptBefore))
+	if err != nil && b.GetCtx().Err() == nil {
+		atomic.AddInt64(&details.BackoffBudgetExhausted, 1)
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backoff
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/tikv/client-go/v2/config/retry"
+	"github.com/tikv/client-go/v2/tikv"
+)
+
+const (
+	// DefaultStrategyName is the name of the strategy which uses the fixed exponential backoff of client-go.
+	DefaultStrategyName = "default"
+	// AdaptiveStrategyName is the name of the strategy which scales the backoff by the recent retry results.
+	AdaptiveStrategyName = "adaptive"
+)
+
+// Strategy decides how the kv requests back off when they meet retryable errors.
+type Strategy interface {
+	// Name returns the name of the strategy.
+	Name() string
+	// Config returns the config used to back off for the errors of the cfg type.
+	// Note that a backoffer builds the backoff function of a type at the first time it backs off for the type,
+	// so the returned config only takes effect on the backoffers which haven't backed off for the type.
+	Config(cfg *tikv.BackoffConfig) *tikv.BackoffConfig
+	// MaxSleep returns the backoff budget in milliseconds of a new backoffer whose default budget is maxSleep.
+	MaxSleep(maxSleep int) int
+	// OnBackoff is called after a backoffer backs off for the cfg type, attempts is the number of times the
+	// backoffer has backed off for the type, so a value larger than 1 means the last retry failed again.
+	OnBackoff(cfg *tikv.BackoffConfig, attempts int)
+}
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]Strategy{}
+
+	currentStrategy atomic.Pointer[Strategy]
+)
+
+func init() {
+	MustRegisterStrategy(defaultStrategy{})
+	MustRegisterStrategy(NewAdaptiveStrategy())
+	var s Strategy = defaultStrategy{}
+	currentStrategy.Store(&s)
+}
+
+// RegisterStrategy registers a backoff strategy, which can be used by SetStrategy then.
+func RegisterStrategy(s Strategy) error {
+	name := strings.ToLower(s.Name())
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	if _, ok := strategies[name]; ok {
+		return errors.Errorf("backoff strategy %s has been registered", name)
+	}
+	strategies[name] = s
+	return nil
+}
+
+// MustRegisterStrategy is like RegisterStrategy but panics if the strategy has been registered.
+func MustRegisterStrategy(s Strategy) {
+	if err := RegisterStrategy(s); err != nil {
+		panic(err)
+	}
+}
+
+// SetStrategy switches the backoff strategy of the kv requests to the registered one named name.
+func SetStrategy(name string) error {
+	strategiesMu.RLock()
+	s, ok := strategies[strings.ToLower(name)]
+	strategiesMu.RUnlock()
+	if !ok {
+		return errors.Errorf("unknown backoff strategy %s", name)
+	}
+	currentStrategy.Store(&s)
+	return nil
+}
+
+// GetStrategy returns the current backoff strategy of the kv requests.
+func GetStrategy() Strategy {
+	return *currentStrategy.Load()
+}
+
+// defaultStrategy keeps the backoff configs and budgets as they are.
+type defaultStrategy struct{}
+
+func (defaultStrategy) Name() string { return DefaultStrategyName }
+
+func (defaultStrategy) Config(cfg *tikv.BackoffConfig) *tikv.BackoffConfig { return cfg }
+
+func (defaultStrategy) MaxSleep(maxSleep int) int { return maxSleep }
+
+func (defaultStrategy) OnBackoff(*tikv.BackoffConfig, int) {}
+
+// backoffFnParams is the parameters of the exponential backoff function of a backoff type.
+type backoffFnParams struct {
+	base   int
+	cap    int
+	jitter int
+}
+
+// defaultBackoffFnParams are the parameters client-go uses for the backoff types. client-go doesn't expose them,
+// so they are kept here, the types not in the map are never adjusted by the adaptive strategy.
+var defaultBackoffFnParams = map[string]backoffFnParams{
+	"tikvRPC":                  {100, 2000, retry.EqualJitter},
+	"tiflashRPC":               {100, 2000, retry.EqualJitter},
+	"txnLock":                  {100, 3000, retry.EqualJitter},
+	"pdRPC":                    {500, 3000, retry.EqualJitter},
+	"regionMiss":               {2, 500, retry.NoJitter},
+	"regionScheduling":         {2, 500, retry.NoJitter},
+	"tikvServerBusy":           {2000, 10000, retry.EqualJitter},
+	"tikvDiskFull":             {500, 5000, retry.NoJitter},
+	"regionRecoveryInProgress": {100, 10000, retry.EqualJitter},
+	"tiflashServerBusy":        {2000, 10000, retry.EqualJitter},
+	"txnNotFound":              {2, 500, retry.NoJitter},
+	"staleCommand":             {2, 1000, retry.NoJitter},
+	"maxTsNotSynced":           {2, 500, retry.NoJitter},
+	"dataNotReady":             {2, 2000, retry.NoJitter},
+	"regionNotInitialized":     {2, 1000, retry.NoJitter},
+	"isWitness":                {1000, 10000, retry.EqualJitter},
+}
+
+const (
+	// adaptiveMinSamples is the number of backoff rounds of a type needed before the adaptive strategy adjusts it.
+	adaptiveMinSamples = 16
+	// adaptiveWindow is the number of backoff rounds after which the statistics of a type decay by half,
+	// so the strategy follows the recent retry results.
+	adaptiveWindow = 1024
+	// adaptiveMinFactor and adaptiveMaxFactor limit how much the backoff time can be scaled.
+	adaptiveMinFactor = 0.5
+	adaptiveMaxFactor = 4.0
+)
+
+// adaptiveStats is the retry statistics of a backoff type.
+type adaptiveStats struct {
+	// rounds is the number of times the backoffers start to back off for the type.
+	rounds float64
+	// failedRetries is the number of retries which meet the errors of the type again.
+	failedRetries float64
+}
+
+// AdaptiveStrategy scales the backoff time of each type by the recent retry results of the type.
+// When most retries succeed after the first backoff, e.g. the region is just split, it backs off less than
+// the default to retry faster. When the retries keep failing, e.g. some stores are down during a partial outage,
+// it backs off more and with jitters, so the requests don't hammer the unhealthy stores and the network.
+type AdaptiveStrategy struct {
+	mu    sync.Mutex
+	stats map[string]*adaptiveStats
+}
+
+// NewAdaptiveStrategy creates an AdaptiveStrategy.
+func NewAdaptiveStrategy() *AdaptiveStrategy {
+	return &AdaptiveStrategy{stats: make(map[string]*adaptiveStats)}
+}
+
+// Name implements the Strategy interface.
+func (*AdaptiveStrategy) Name() string { return AdaptiveStrategyName }
+
+// MaxSleep implements the Strategy interface.
+func (*AdaptiveStrategy) MaxSleep(maxSleep int) int { return maxSleep }
+
+// Config implements the Strategy interface.
+func (s *AdaptiveStrategy) Config(cfg *tikv.BackoffConfig) *tikv.BackoffConfig {
+	params, ok := defaultBackoffFnParams[cfg.String()]
+	if !ok {
+		return cfg
+	}
+	factor := s.factor(cfg.String())
+	if factor == 1 {
+		return cfg
+	}
+	base := int(math.Round(float64(params.base) * factor))
+	capacity := int(math.Round(float64(params.cap) * factor))
+	jitter := params.jitter
+	if factor > 1 && jitter == retry.NoJitter {
+		// Spread the retries, the requests meet the errors at the same time during an outage.
+		jitter = retry.EqualJitter
+	}
+	adjusted := *cfg
+	adjusted.SetBackoffFnCfg(retry.NewBackoffFnCfg(base, capacity, jitter))
+	return &adjusted
+}
+
+// OnBackoff implements the Strategy interface.
+func (s *AdaptiveStrategy) OnBackoff(cfg *tikv.BackoffConfig, attempts int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.stats[cfg.String()]
+	if !ok {
+		st = &adaptiveStats{}
+		s.stats[cfg.String()] = st
+	}
+	if attempts <= 1 {
+		st.rounds++
+	} else {
+		st.failedRetries++
+	}
+	if st.rounds >= adaptiveWindow {
+		st.rounds /= 2
+		st.failedRetries /= 2
+	}
+}
+
+// factor returns how much the backoff time of the type is scaled. It's the average number of the failed retries
+// per backoff round, which is close to 1 when the default backoff fits well.
+func (s *AdaptiveStrategy) factor(name string) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.stats[name]
+	if !ok || st.rounds < adaptiveMinSamples {
+		return 1
+	}
+	return math.Max(adaptiveMinFactor, math.Min(adaptiveMaxFactor, st.failedRetries/st.rounds))
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backoff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/testkit/testsetup"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/config/retry"
+	"github.com/tikv/client-go/v2/tikv"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	testsetup.SetupForCommonTest()
+	opts := []goleak.Option{
+		goleak.IgnoreTopFunction("github.com/golang/glog.(*fileSink).flushDaemon"),
+		goleak.IgnoreTopFunction("github.com/bazelbuild/rules_go/go/tools/bzltestutil.RegisterTimeoutHandler.func1"),
+		goleak.IgnoreTopFunction("github.com/lestrrat-go/httprc.runFetchWorker"),
+		goleak.IgnoreTopFunction("go.etcd.io/etcd/client/pkg/v3/logutil.(*MergeLogger).outputLoop"),
+		goleak.IgnoreTopFunction("go.opencensus.io/stats/view.(*worker).start"),
+	}
+	goleak.VerifyTestMain(m, opts...)
+}
+
+func TestSetStrategy(t *testing.T) {
+	defer func() {
+		require.NoError(t, SetStrategy(DefaultStrategyName))
+	}()
+	require.Equal(t, DefaultStrategyName, GetStrategy().Name())
+
+	require.NoError(t, SetStrategy("ADAPTIVE"))
+	require.Equal(t, AdaptiveStrategyName, GetStrategy().Name())
+	require.ErrorContains(t, SetStrategy("unknown"), "unknown backoff strategy unknown")
+	require.Equal(t, AdaptiveStrategyName, GetStrategy().Name())
+
+	require.ErrorContains(t, RegisterStrategy(NewAdaptiveStrategy()), "backoff strategy adaptive has been registered")
+}
+
+func TestAdaptiveStrategy(t *testing.T) {
+	s := NewAdaptiveStrategy()
+	cfg := tikv.BoRegionMiss()
+	// Not enough samples, the default config is used.
+	s.OnBackoff(cfg, 1)
+	require.Equal(t, 1.0, s.factor(cfg.String()))
+	require.Same(t, cfg, s.Config(cfg))
+
+	// The retries succeed after the first backoff, back off less.
+	for i := 0; i < adaptiveMinSamples; i++ {
+		s.OnBackoff(cfg, 1)
+	}
+	require.Equal(t, adaptiveMinFactor, s.factor(cfg.String()))
+	adjusted := s.Config(cfg)
+	require.NotSame(t, cfg, adjusted)
+	require.Equal(t, cfg.String(), adjusted.String())
+
+	// The retries keep failing, back off more.
+	for i := 0; i < 10*adaptiveMinSamples; i++ {
+		s.OnBackoff(cfg, 2)
+	}
+	require.Equal(t, adaptiveMaxFactor, s.factor(cfg.String()))
+	// The other types are not affected.
+	require.Equal(t, 1.0, s.factor(tikv.BoTiKVRPC().String()))
+
+	// The statistics decay so the recent results matter more.
+	for i := 0; i < 2*adaptiveWindow; i++ {
+		s.OnBackoff(cfg, 1)
+	}
+	require.Equal(t, adaptiveMinFactor, s.factor(cfg.String()))
+
+	// The unknown types are never adjusted.
+	txnLockFast := retry.BoTxnLockFast
+	for i := 0; i < adaptiveMinSamples; i++ {
+		s.OnBackoff(txnLockFast, 1)
+	}
+	require.Same(t, txnLockFast, s.Config(txnLockFast))
+}
+
+func TestBackoffBudget(t *testing.T) {
+	details := &execdetails.StmtExecDetails{}
+	ctx := context.WithValue(context.Background(), execdetails.StmtExecDetailKey, details)
+	bo := NewBackoffer(ctx, 20)
+	var err error
+	for err == nil {
+		err = bo.Backoff(tikv.BoRegionMiss(), errors.New("region miss"))
+	}
+	require.Equal(t, int64(20), details.BackoffBudgetMs)
+	require.Equal(t, int64(bo.GetTotalSleep()), details.BackoffBudgetUsedMs)
+	require.Equal(t, int64(1), details.BackoffBudgetExhausted)
+
+	// The budget of a backoffer is only counted once.
+	bo = NewBackoffer(ctx, 20)
+	require.NoError(t, bo.Backoff(tikv.BoRegionMiss(), errors.New("region miss")))
+	require.NoError(t, bo.Backoff(tikv.BoRegionMiss(), errors.New("region miss")))
+	require.Equal(t, int64(40), details.BackoffBudgetMs)
+	require.Equal(t, int64(1), details.BackoffBudgetExhausted)
+}
@@ -26,13 +26,16 @@ import (
 // memBuffer wraps tikv.MemDB as kv.MemBuffer.
 type memBuffer struct {
 	tikv.MemBuffer
+	// isPipelined indicates the buffer is a pipelined memdb, which flushes the mutations during execution and
+	// doesn't support staging buffers. The statements of a pipelined transaction write to the buffer directly.
+	isPipelined bool
 }
 
-func newMemBuffer(m tikv.MemBuffer) kv.MemBuffer {
+func newMemBuffer(m tikv.MemBuffer, isPipelined bool) kv.MemBuffer {
 	if m == nil {
 		return nil
 	}
-	return &memBuffer{MemBuffer: m}
+	return &memBuffer{MemBuffer: m, isPipelined: isPipelined}
 }
 
 func (m *memBuffer) Size() int {
@@ -67,17 +70,35 @@ func (m *memBuffer) GetFlags(key kv.Key) (kv.KeyFlags, error) {
 }
 
 func (m *memBuffer) Staging() kv.StagingHandle {
+	if m.isPipelined {
+		return kv.InvalidStagingHandle
+	}
 	return kv.StagingHandle(m.MemBuffer.Staging())
 }
 
 func (m *memBuffer) Cleanup(h kv.StagingHandle) {
+	if m.isPipelined {
+		return
+	}
 	m.MemBuffer.Cleanup(int(h))
 }
 
 func (m *memBuffer) Release(h kv.StagingHandle) {
+	if m.isPipelined {
+		return
+	}
 	m.MemBuffer.Release(int(h))
 }
 
+func (m *memBuffer) Flush(force bool) (bool, error) {
+	flushed, err := m.MemBuffer.Flush(force)
+	return flushed, extractKeyErr(err)
+}
+
+func (m *memBuffer) FlushWait() error {
+	return extractKeyErr(m.MemBuffer.FlushWait())
+}
+
 func (m *memBuffer) InspectStage(handle kv.StagingHandle, f func(kv.Key, kv.KeyFlags, []byte)) {
 	tf := func(key []byte, flag tikvstore.KeyFlags, value []byte) {
 		f(kv.Key(key), getTiDBKeyFlags(flag), value)
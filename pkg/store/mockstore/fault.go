@@ -0,0 +1,200 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockstore
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/errorpb"
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/tikv/client-go/v2/tikv"
+	"github.com/tikv/client-go/v2/tikvrpc"
+)
+
+// KeyRangeFault is a fault injected into the requests sent to the mock store which touch the keys in
+// [StartKey, EndKey). An empty EndKey means the range is unbounded.
+type KeyRangeFault struct {
+	StartKey kv.Key
+	EndKey   kv.Key
+	// CmdTypes are the types of the requests the fault is injected into, it's injected into all the requests
+	// touching the range if it's empty.
+	CmdTypes []tikvrpc.CmdType
+	// Latency delays the requests, it's used to simulate the hot regions and the slow stores.
+	Latency time.Duration
+	// RegionError is returned as the region error of the response, which makes the client backoff and retry.
+	RegionError *errorpb.Error
+	// Err is returned as the error of sending the request, it's used to simulate the unreachable stores.
+	Err error
+	// Count limits the times the fault is injected, it's always injected if Count is 0.
+	Count int
+}
+
+type injectedFault struct {
+	KeyRangeFault
+	hits int
+}
+
+// KeyRangeFaultInjector injects the faults into the requests sent to the mock store by the key ranges they touch.
+// The faults can be injected and cleared while the store is running. If more than one fault matches a request,
+// the one injected earliest takes effect, so the injection is deterministic.
+type KeyRangeFaultInjector struct {
+	mu     sync.Mutex
+	faults []*injectedFault
+	hits   int
+}
+
+// NewKeyRangeFaultInjector creates a KeyRangeFaultInjector without any fault.
+func NewKeyRangeFaultInjector() *KeyRangeFaultInjector {
+	return &KeyRangeFaultInjector{}
+}
+
+// Inject adds the fault to the injector.
+func (i *KeyRangeFaultInjector) Inject(fault KeyRangeFault) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.faults = append(i.faults, &injectedFault{KeyRangeFault: fault})
+}
+
+// Clear removes all the faults from the injector.
+func (i *KeyRangeFaultInjector) Clear() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.faults = nil
+}
+
+// Hits returns the times the faults have been injected into the requests.
+func (i *KeyRangeFaultInjector) Hits() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.hits
+}
+
+// match returns the fault to inject into the request, it returns nil if there is none.
+func (i *KeyRangeFaultInjector) match(req *tikvrpc.Request) *KeyRangeFault {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if len(i.faults) == 0 {
+		return nil
+	}
+	ranges := requestKeyRanges(req)
+	for _, f := range i.faults {
+		if f.Count > 0 && f.hits >= f.Count {
+			continue
+		}
+		if len(f.CmdTypes) > 0 && !slices.Contains(f.CmdTypes, req.Type) {
+			continue
+		}
+		for _, r := range ranges {
+			if keyRangesOverlap(r, kv.KeyRange{StartKey: f.StartKey, EndKey: f.EndKey}) {
+				f.hits++
+				i.hits++
+				fault := f.KeyRangeFault
+				return &fault
+			}
+		}
+	}
+	return nil
+}
+
+// requestKeyRanges returns the key ranges touched by the request. The requests which don't touch the keys of
+// the user data, e.g. the requests of GC and the MPP tasks, are never matched.
+func requestKeyRanges(req *tikvrpc.Request) []kv.KeyRange {
+	pointRanges := func(keys ...[]byte) []kv.KeyRange {
+		ranges := make([]kv.KeyRange, 0, len(keys))
+		for _, k := range keys {
+			ranges = append(ranges, kv.KeyRange{StartKey: k, EndKey: kv.Key(k).Next()})
+		}
+		return ranges
+	}
+	mutationKeys := func(mutations []*kvrpcpb.Mutation) [][]byte {
+		keys := make([][]byte, 0, len(mutations))
+		for _, m := range mutations {
+			keys = append(keys, m.Key)
+		}
+		return keys
+	}
+	switch req.Type {
+	case tikvrpc.CmdGet:
+		return pointRanges(req.Get().Key)
+	case tikvrpc.CmdBatchGet:
+		return pointRanges(req.BatchGet().Keys...)
+	case tikvrpc.CmdScan:
+		scan := req.Scan()
+		if scan.Reverse {
+			// The start key is the upper bound of the reverse scan.
+			return []kv.KeyRange{{StartKey: scan.EndKey, EndKey: scan.StartKey}}
+		}
+		return []kv.KeyRange{{StartKey: scan.StartKey, EndKey: scan.EndKey}}
+	case tikvrpc.CmdPrewrite:
+		return pointRanges(mutationKeys(req.Prewrite().Mutations)...)
+	case tikvrpc.CmdPessimisticLock:
+		return pointRanges(mutationKeys(req.PessimisticLock().Mutations)...)
+	case tikvrpc.CmdCommit:
+		return pointRanges(req.Commit().Keys...)
+	case tikvrpc.CmdBatchRollback:
+		return pointRanges(req.BatchRollback().Keys...)
+	case tikvrpc.CmdCleanup:
+		return pointRanges(req.Cleanup().Key)
+	case tikvrpc.CmdCheckTxnStatus:
+		return pointRanges(req.CheckTxnStatus().PrimaryKey)
+	case tikvrpc.CmdCop:
+		copRanges := req.Cop().Ranges
+		ranges := make([]kv.KeyRange, 0, len(copRanges))
+		for _, r := range copRanges {
+			ranges = append(ranges, kv.KeyRange{StartKey: r.Start, EndKey: r.End})
+		}
+		return ranges
+	}
+	return nil
+}
+
+// keyRangesOverlap checks whether the two ranges overlap, an empty end key means the range is unbounded.
+func keyRangesOverlap(a, b kv.KeyRange) bool {
+	return (len(b.EndKey) == 0 || bytes.Compare(a.StartKey, b.EndKey) < 0) &&
+		(len(a.EndKey) == 0 || bytes.Compare(b.StartKey, a.EndKey) < 0)
+}
+
+// faultInjectionClient injects the faults of the KeyRangeFaultInjector into the requests sent by the client.
+type faultInjectionClient struct {
+	tikv.Client
+	injector *KeyRangeFaultInjector
+}
+
+func (c *faultInjectionClient) SendRequest(ctx context.Context, addr string, req *tikvrpc.Request, timeout time.Duration) (*tikvrpc.Response, error) {
+	fault := c.injector.match(req)
+	if fault == nil {
+		return c.Client.SendRequest(ctx, addr, req, timeout)
+	}
+	if fault.Latency > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, errors.Trace(ctx.Err())
+		case <-time.After(fault.Latency):
+		}
+	}
+	if fault.Err != nil {
+		return nil, fault.Err
+	}
+	if fault.RegionError != nil {
+		return tikvrpc.GenRegionErrorResp(req, fault.RegionError)
+	}
+	return c.Client.SendRequest(ctx, addr, req, timeout)
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/errorpb"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/tikvrpc"
+)
+
+func TestKeyRangeFaultInjector(t *testing.T) {
+	injector := NewKeyRangeFaultInjector()
+	store, err := NewMockStore(WithKeyRangeFaultInjector(injector))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	err = kv.RunInNewTxn(ctx, store, false, func(_ context.Context, txn kv.Transaction) error {
+		for _, k := range []string{"a", "b", "c"} {
+			if err := txn.Set(kv.Key(k), []byte(k)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, injector.Hits())
+
+	get := func(key string) time.Duration {
+		start := time.Now()
+		txn, err := store.Begin()
+		require.NoError(t, err)
+		val, err := txn.Get(ctx, kv.Key(key))
+		require.NoError(t, err)
+		require.Equal(t, key, string(val))
+		require.NoError(t, txn.Rollback())
+		return time.Since(start)
+	}
+
+	// The region errors make the client backoff and retry, the request succeeds after the fault is exhausted.
+	injector.Inject(KeyRangeFault{
+		StartKey:    kv.Key("b"),
+		EndKey:      kv.Key("c"),
+		CmdTypes:    []tikvrpc.CmdType{tikvrpc.CmdGet},
+		RegionError: &errorpb.Error{ServerIsBusy: &errorpb.ServerIsBusy{Reason: "mock server is busy"}},
+		Count:       2,
+	})
+	get("a")
+	require.Equal(t, 0, injector.Hits())
+	get("b")
+	require.Equal(t, 2, injector.Hits())
+	get("b")
+	require.Equal(t, 2, injector.Hits())
+
+	// The latency is only injected into the requests touching the range.
+	injector.Inject(KeyRangeFault{StartKey: kv.Key("c"), Latency: 200 * time.Millisecond})
+	require.GreaterOrEqual(t, get("c"), 200*time.Millisecond)
+	require.Equal(t, 3, injector.Hits())
+	get("a")
+	require.Equal(t, 3, injector.Hits())
+
+	injector.Clear()
+	get("c")
+	require.Equal(t, 3, injector.Hits())
+}
+
+func TestKeyRangesOverlap(t *testing.T) {
+	keyRange := func(start, end string) kv.KeyRange {
+		return kv.KeyRange{StartKey: kv.Key(start), EndKey: kv.Key(end)}
+	}
+	cases := []struct {
+		a, b    kv.KeyRange
+		overlap bool
+	}{
+		{keyRange("a", "c"), keyRange("b", "d"), true},
+		{keyRange("a", "b"), keyRange("b", "c"), false},
+		{keyRange("c", "d"), keyRange("a", "c"), false},
+		{keyRange("a", ""), keyRange("x", "y"), true},
+		{keyRange("x", "y"), keyRange("", ""), true},
+		{keyRange("x", "y"), keyRange("a", "b"), false},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.overlap, keyRangesOverlap(c.a, c.b), "%v %v", c.a, c.b)
+		require.Equal(t, c.overlap, keyRangesOverlap(c.b, c.a), "%v %v", c.b, c.a)
+	}
+}
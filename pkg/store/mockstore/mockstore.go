@@ -97,6 +97,7 @@ type mockOptions struct {
 	ddlCheckerHijack bool
 	tikvOptions      []tikv.Option
 	pdAddrs          []string
+	faultInjector    *KeyRangeFaultInjector
 }
 
 // MockTiKVStoreOption is used to control some behavior of mock tikv.
@@ -133,6 +134,14 @@ func WithClientHijacker(hijacker func(tikv.Client) tikv.Client) MockTiKVStoreOpt
 	}
 }
 
+// WithKeyRangeFaultInjector injects the faults of the injector into the requests sent to the mock store, makes it
+// easy to simulate the hot regions, the slow stores and the region errors of some key ranges deterministically.
+func WithKeyRangeFaultInjector(injector *KeyRangeFaultInjector) MockTiKVStoreOption {
+	return func(c *mockOptions) {
+		c.faultInjector = injector
+	}
+}
+
 // WithPDClientHijacker hijacks PD client's behavior, makes it easy to simulate the network
 // problem between TiDB and PD.
 func WithPDClientHijacker(hijacker func(pd.Client) pd.Client) MockTiKVStoreOption {
@@ -193,6 +202,15 @@ func NewMockStore(options ...MockTiKVStoreOption) (kv.Storage, error) {
 	for _, f := range options {
 		f(&opt)
 	}
+	if injector := opt.faultInjector; injector != nil {
+		hijacker := opt.clientHijacker
+		opt.clientHijacker = func(c tikv.Client) tikv.Client {
+			if hijacker != nil {
+				c = hijacker(c)
+			}
+			return &faultInjectionClient{Client: c, injector: injector}
+		}
+	}
 
 	var (
 		store kv.Storage
@@ -169,6 +169,10 @@ func (c *RPCClient) SendRequest(ctx context.Context, addr string, req *tikvrpc.R
 				failpoint.Return(nil, undeterminedErr)
 			}
 		})
+	case tikvrpc.CmdFlush:
+		r := req.Flush()
+		c.cluster.handleDelay(r.StartTs, r.Context.RegionId)
+		resp.Resp, err = c.usSvr.KvFlush(ctx, r)
 	case tikvrpc.CmdPessimisticLock:
 		r := req.PessimisticLock()
 		c.cluster.handleDelay(r.StartVersion, r.Context.RegionId)
@@ -227,6 +231,8 @@ func (c *RPCClient) SendRequest(ctx context.Context, addr string, req *tikvrpc.R
 		})
 
 		resp.Resp, err = c.usSvr.KvBatchGet(ctx, batchGetReq)
+	case tikvrpc.CmdBufferBatchGet:
+		resp.Resp, err = c.usSvr.KvBufferBatchGet(ctx, req.BufferBatchGet())
 	case tikvrpc.CmdBatchRollback:
 		resp.Resp, err = c.usSvr.KvBatchRollback(ctx, req.BatchRollback())
 	case tikvrpc.CmdScanLock:
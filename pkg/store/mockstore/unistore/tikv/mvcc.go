@@ -824,6 +824,87 @@ func (store *MVCCStore) prewriteOptimistic(reqCtx *requestCtx, mutations []*kvrp
 	return store.prewriteMutations(reqCtx, mutations, req, items)
 }
 
+// Flush implements the MVCCStore interface. It writes the mutations of a generation of a pipelined DML transaction as
+// locks. Unlike prewrite, a key may be flushed by several generations of the same transaction, the later generation
+// overwrites the lock of the earlier one.
+func (store *MVCCStore) Flush(reqCtx *requestCtx, req *kvrpcpb.FlushRequest) error {
+	mutations := sortMutations(req.Mutations)
+	regCtx := reqCtx.regCtx
+	hashVals := mutationsToHashVals(mutations)
+
+	regCtx.AcquireLatches(hashVals)
+	defer regCtx.ReleaseLatches(hashVals)
+
+	startTS := req.StartTs
+	locks := make([]*mvcc.Lock, len(mutations))
+	for i, m := range mutations {
+		lock, err := store.checkConflictInLockStore(reqCtx, m, startTS)
+		if err != nil {
+			return err
+		}
+		if lock != nil {
+			// The assertions and the existence checks are made against the writes of the transaction itself.
+			m.Assertion = kvrpcpb.Assertion_None
+			switch {
+			case lock.Op == uint8(kvrpcpb.Op_Put) && (m.Op == kvrpcpb.Op_Insert || m.Op == kvrpcpb.Op_CheckNotExists):
+				return &kverrors.ErrKeyAlreadyExists{Key: m.Key}
+			case lock.Op == uint8(kvrpcpb.Op_Del) && m.Op == kvrpcpb.Op_Insert:
+				m.Op = kvrpcpb.Op_Put
+			}
+		}
+		locks[i] = lock
+	}
+	items, err := store.getDBItems(reqCtx, mutations)
+	if err != nil {
+		return err
+	}
+	prewriteReq := &kvrpcpb.PrewriteRequest{
+		StartVersion:   startTS,
+		PrimaryLock:    req.PrimaryKey,
+		LockTtl:        req.LockTtl,
+		MinCommitTs:    req.MinCommitTs,
+		AssertionLevel: req.AssertionLevel,
+	}
+	batch := store.dbWriter.NewWriteBatch(startTS, 0, reqCtx.rpcCtx)
+	for i, m := range mutations {
+		item := items[i]
+		if item != nil {
+			userMeta := mvcc.DBUserMeta(item.UserMeta())
+			if userMeta.CommitTS() > startTS {
+				return &kverrors.ErrConflict{
+					StartTS:          startTS,
+					ConflictTS:       userMeta.StartTS(),
+					ConflictCommitTS: userMeta.CommitTS(),
+					Key:              item.KeyCopy(nil),
+					Reason:           kvrpcpb.WriteConflict_Optimistic,
+				}
+			}
+		}
+		if m.Op == kvrpcpb.Op_CheckNotExists {
+			if locks[i] == nil && item != nil {
+				val, err := item.Value()
+				if err != nil {
+					return err
+				}
+				if len(val) > 0 {
+					return &kverrors.ErrKeyAlreadyExists{Key: m.Key}
+				}
+			}
+			continue
+		}
+		if m.Op == kvrpcpb.Op_Lock && locks[i] != nil {
+			// Keep the value written by the earlier generation.
+			continue
+		}
+		lock, err1 := store.buildPrewriteLock(reqCtx, m, item, prewriteReq)
+		if err1 != nil {
+			return err1
+		}
+		batch.Prewrite(m.Key, lock)
+	}
+	return store.dbWriter.Write(batch)
+}
+
 func (store *MVCCStore) prewritePessimistic(reqCtx *requestCtx, mutations []*kvrpcpb.Mutation, req *kvrpcpb.PrewriteRequest) error {
 	startTS := req.StartVersion
 
@@ -1730,6 +1811,25 @@ func (store *MVCCStore) BatchGet(reqCtx *requestCtx, keys [][]byte, version uint
 	return pairs
 }
 
+// BufferBatchGet implements the MVCCStore interface. It reads the values flushed by the pipelined DML transaction
+// whose start ts is version, the keys not written by the transaction are omitted.
+func (store *MVCCStore) BufferBatchGet(reqCtx *requestCtx, keys [][]byte, version uint64) []*kvrpcpb.KvPair {
+	pairs := make([]*kvrpcpb.KvPair, 0, len(keys))
+	for _, key := range keys {
+		lock := store.getLock(reqCtx, key)
+		if lock == nil || lock.StartTS != version {
+			continue
+		}
+		switch kvrpcpb.Op(lock.Op) {
+		case kvrpcpb.Op_Put:
+			pairs = append(pairs, &kvrpcpb.KvPair{Key: safeCopy(key), Value: safeCopy(lock.Value)})
+		case kvrpcpb.Op_Del:
+			pairs = append(pairs, &kvrpcpb.KvPair{Key: safeCopy(key)})
+		}
+	}
+	return pairs
+}
+
 func (store *MVCCStore) collectRangeLock(startTS uint64, startKey, endKey []byte, resolved, committed []uint64,
 	isolationLEvel kvrpcpb.IsolationLevel) []*kvrpcpb.KvPair {
 	var pairs []*kvrpcpb.KvPair
@@ -1881,3 +1881,59 @@ func TestRcReadCheckTS(t *testing.T) {
 	conflictErr = getConflictErr(scanRes)
 	require.NotNil(t, conflictErr)
 }
+
+func TestPipelinedFlush(t *testing.T) {
+	store := NewTestStore("pipelined_flush_db", "pipelined_flush_log", t)
+	pk, k1, k2, k3, k4 := []byte("tpk"), []byte("tk1"), []byte("tk2"), []byte("tk3"), []byte("tk4")
+	MustPrewritePut(k3, k3, []byte("v3"), 1, store)
+	MustCommit(k3, 1, 2, store)
+	MustPrewritePut(k4, k4, []byte("v4"), 20, store)
+	MustCommit(k4, 20, 21, store)
+
+	flush := func(startTS uint64, mutations ...*kvrpcpb.Mutation) error {
+		return store.MvccStore.Flush(store.newReqCtx(), &kvrpcpb.FlushRequest{
+			Mutations:   mutations,
+			PrimaryKey:  pk,
+			StartTs:     startTS,
+			MinCommitTs: startTS + 1,
+			LockTtl:     lockTTL,
+		})
+	}
+	require.NoError(t, flush(10,
+		newMutation(kvrpcpb.Op_Put, pk, []byte("v0")),
+		newMutation(kvrpcpb.Op_Put, k1, []byte("v1")),
+		newMutation(kvrpcpb.Op_Insert, k2, []byte("v2"))))
+	// The later generation overwrites the locks of the earlier one.
+	require.NoError(t, flush(10,
+		newMutation(kvrpcpb.Op_Put, k1, []byte("v1'")),
+		newMutation(kvrpcpb.Op_Del, k3, nil),
+		newMutation(kvrpcpb.Op_Lock, k2, nil)))
+	// The existence is checked against the writes of the transaction itself.
+	err := flush(10, newMutation(kvrpcpb.Op_Insert, k1, []byte("v")))
+	require.IsType(t, &kverrors.ErrKeyAlreadyExists{}, err)
+	require.NoError(t, flush(10, newMutation(kvrpcpb.Op_Insert, k3, []byte("v3'"))))
+	// The other transactions meet the locks.
+	require.IsType(t, &kverrors.ErrLocked{}, flush(15, newMutation(kvrpcpb.Op_Put, k1, nil)))
+	require.IsType(t, &kverrors.ErrConflict{}, flush(10, newMutation(kvrpcpb.Op_Put, k4, nil)))
+
+	pairs := store.MvccStore.BufferBatchGet(store.newReqCtx(), [][]byte{pk, k1, k2, k3, k4}, 10)
+	require.Equal(t, []*kvrpcpb.KvPair{
+		{Key: pk, Value: []byte("v0")},
+		{Key: k1, Value: []byte("v1'")},
+		{Key: k2, Value: []byte("v2")},
+		{Key: k3, Value: []byte("v3'")},
+	}, pairs)
+	require.Empty(t, store.MvccStore.BufferBatchGet(store.newReqCtx(), [][]byte{pk, k1}, 11))
+
+	// The transaction itself skips its locks when reading.
+	val, err := kvGet(k3, 12, []uint64{10}, nil, store)
+	require.NoError(t, err)
+	require.Equal(t, []byte("v3"), val)
+	MustGetErr(k3, 12, store)
+
+	require.NoError(t, store.MvccStore.ResolveLock(store.newReqCtx(), nil, 10, 11))
+	MustGetVal(pk, []byte("v0"), 12, store)
+	MustGetVal(k1, []byte("v1'"), 12, store)
+	MustGetVal(k2, []byte("v2"), 12, store)
+	MustGetVal(k3, []byte("v3'"), 12, store)
+}
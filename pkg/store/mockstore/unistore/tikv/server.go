@@ -364,6 +364,22 @@ func (svr *Server) KvPrewrite(ctx context.Context, req *kvrpcpb.PrewriteRequest)
 	return resp, nil
 }
 
+// KvFlush implements the tikvpb.TikvServer interface.
+func (svr *Server) KvFlush(ctx context.Context, req *kvrpcpb.FlushRequest) (*kvrpcpb.FlushResponse, error) {
+	reqCtx, err := newRequestCtx(svr, req.Context, "KvFlush")
+	if err != nil {
+		return &kvrpcpb.FlushResponse{Errors: []*kvrpcpb.KeyError{convertToKeyError(err)}}, nil
+	}
+	defer reqCtx.finish()
+	if reqCtx.regErr != nil {
+		return &kvrpcpb.FlushResponse{RegionError: reqCtx.regErr}, nil
+	}
+	err = svr.mvccStore.Flush(reqCtx, req)
+	resp := &kvrpcpb.FlushResponse{}
+	resp.Errors, resp.RegionError = convertToPBErrors(err)
+	return resp, nil
+}
+
 // KvCommit implements the tikvpb.TikvServer interface.
 func (svr *Server) KvCommit(ctx context.Context, req *kvrpcpb.CommitRequest) (*kvrpcpb.CommitResponse, error) {
 	reqCtx, err := newRequestCtx(svr, req.Context, "KvCommit")
@@ -431,6 +447,21 @@ func (svr *Server) KvBatchGet(ctx context.Context, req *kvrpcpb.BatchGetRequest)
 	}, nil
 }
 
+// KvBufferBatchGet implements the tikvpb.TikvServer interface.
+func (svr *Server) KvBufferBatchGet(ctx context.Context, req *kvrpcpb.BufferBatchGetRequest) (*kvrpcpb.BufferBatchGetResponse, error) {
+	reqCtx, err := newRequestCtx(svr, req.Context, "KvBufferBatchGet")
+	if err != nil {
+		return &kvrpcpb.BufferBatchGetResponse{Error: convertToKeyError(err)}, nil
+	}
+	defer reqCtx.finish()
+	if reqCtx.regErr != nil {
+		return &kvrpcpb.BufferBatchGetResponse{RegionError: reqCtx.regErr}, nil
+	}
+	return &kvrpcpb.BufferBatchGetResponse{
+		Pairs: svr.mvccStore.BufferBatchGet(reqCtx, req.Keys, req.GetVersion()),
+	}, nil
+}
+
 // KvBatchRollback implements the tikvpb.TikvServer interface.
 func (svr *Server) KvBatchRollback(ctx context.Context, req *kvrpcpb.BatchRollbackRequest) (*kvrpcpb.BatchRollbackResponse, error) {
 	reqCtx, err := newRequestCtx(svr, req.Context, "KvBatchRollback")
@@ -0,0 +1,227 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regioncache
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/tikv/client-go/v2/metrics"
+	"github.com/tikv/client-go/v2/tikv"
+)
+
+// Categories of the built-in statistics.
+const (
+	CategorySize       = "size"
+	CategoryOperation  = "operation"
+	CategoryReload     = "reload"
+	CategoryInvalidate = "invalidate"
+	CategoryRepair     = "repair"
+)
+
+// Item is an entry of the region cache statistics.
+type Item struct {
+	Category string
+	Name     string
+	Value    int64
+}
+
+// StatsSource provides a category of the region cache statistics.
+type StatsSource func(cache *tikv.RegionCache) []Item
+
+var sources struct {
+	sync.RWMutex
+	m map[string]StatsSource
+}
+
+// RegisterStatsSource registers a source of the region cache statistics, the source registered later replaces the
+// former one of the same category.
+func RegisterStatsSource(category string, source StatsSource) {
+	sources.Lock()
+	defer sources.Unlock()
+	sources.m[category] = source
+}
+
+func init() {
+	sources.m = map[string]StatsSource{
+		CategorySize:       sizeStats,
+		CategoryOperation:  operationStats,
+		CategoryReload:     reloadStats,
+		CategoryInvalidate: invalidateStats,
+		CategoryRepair:     repairStats,
+	}
+}
+
+// Collect collects the statistics of the region cache from all the sources, sorted by category and name.
+func Collect(cache *tikv.RegionCache) []Item {
+	sources.RLock()
+	items := make([]Item, 0, 32)
+	for category, source := range sources.m {
+		for _, item := range source(cache) {
+			item.Category = category
+			items = append(items, item)
+		}
+	}
+	sources.RUnlock()
+	slices.SortFunc(items, func(a, b Item) int {
+		if c := cmp.Compare(a.Category, b.Category); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return items
+}
+
+func sizeStats(cache *tikv.RegionCache) []Item {
+	if cache == nil {
+		return nil
+	}
+	return []Item{{Name: "stores", Value: int64(len(cache.GetAllStores()))}}
+}
+
+func operationStats(*tikv.RegionCache) []Item {
+	return collectCounterVec(metrics.TiKVRegionCacheCounter, metrics.LblType, metrics.LblResult)
+}
+
+func reloadStats(*tikv.RegionCache) []Item {
+	return collectCounterVec(metrics.TiKVLoadRegionCounter, metrics.LblType, metrics.LblReason)
+}
+
+// collectCounterVec reads the counters of a client-go metric, the counters are named by joining the given labels.
+func collectCounterVec(vec *prometheus.CounterVec, labels ...string) []Item {
+	if vec == nil {
+		return nil
+	}
+	ch := make(chan prometheus.Metric, 64)
+	go func() {
+		vec.Collect(ch)
+		close(ch)
+	}()
+	var items []Item
+	for m := range ch {
+		var pb dto.Metric
+		if err := m.Write(&pb); err != nil || pb.Counter == nil {
+			continue
+		}
+		values := make([]string, 0, len(labels))
+		for _, label := range labels {
+			for _, pair := range pb.Label {
+				if pair.GetName() == label {
+					values = append(values, pair.GetValue())
+				}
+			}
+		}
+		items = append(items, Item{Name: strings.Join(values, "/"), Value: int64(pb.Counter.GetValue())})
+	}
+	return items
+}
+
+var invalidateCounters sync.Map // reason -> *atomic.Int64
+
+// InvalidateStaleRegion invalidates the cached region whose epoch is found stale, and records the invalidation.
+func InvalidateStaleRegion(cache *tikv.RegionCache, id tikv.RegionVerID) {
+	cache.InvalidateCachedRegionWithReason(id, tikv.EpochNotMatch)
+	RecordInvalidation(tikv.EpochNotMatch.String())
+}
+
+// RecordInvalidation records an invalidation of the cached regions by the reason.
+func RecordInvalidation(reason string) {
+	counter, _ := invalidateCounters.LoadOrStore(reason, new(atomic.Int64))
+	counter.(*atomic.Int64).Add(1)
+}
+
+func invalidateStats(*tikv.RegionCache) []Item {
+	var items []Item
+	invalidateCounters.Range(func(key, value any) bool {
+		items = append(items, Item{Name: key.(string), Value: value.(*atomic.Int64).Load()})
+		return true
+	})
+	return items
+}
+
+var repairCounters struct {
+	calls         atomic.Int64
+	loadedRegions atomic.Int64
+	staleRegions  atomic.Int64
+}
+
+func repairStats(*tikv.RegionCache) []Item {
+	return []Item{
+		{Name: "calls", Value: repairCounters.calls.Load()},
+		{Name: "loaded_regions", Value: repairCounters.loadedRegions.Load()},
+		{Name: "stale_regions", Value: repairCounters.staleRegions.Load()},
+	}
+}
+
+// RepairRegionsInKeyRange reloads all the regions in [startKey, endKey) from PD in batches, and replaces the stale
+// ones in the region cache. It returns the loaded regions and the number of the cached regions found stale.
+// It's used by the queries reading the region meta anyway, so the region cache gets repaired as a side effect of
+// debugging an unavailable region.
+func RepairRegionsInKeyRange(bo *tikv.Backoffer, cache *tikv.RegionCache, startKey, endKey []byte) ([]*tikv.Region, int, error) {
+	cached := cachedRegionsInKeyRange(cache, startKey, endKey)
+	regions, err := cache.LoadRegionsInKeyRange(bo, startKey, endKey)
+	if err != nil {
+		return nil, 0, err
+	}
+	loaded := make(map[tikv.RegionVerID]struct{}, len(regions))
+	for _, r := range regions {
+		loaded[r.VerID()] = struct{}{}
+	}
+	stale := 0
+	for _, id := range cached {
+		if _, ok := loaded[id]; !ok {
+			stale++
+		}
+	}
+	repairCounters.calls.Add(1)
+	repairCounters.loadedRegions.Add(int64(len(regions)))
+	repairCounters.staleRegions.Add(int64(stale))
+	return regions, stale, nil
+}
+
+// cachedRegionsInKeyRange returns the valid regions in the region cache that cover [startKey, endKey), it stops at
+// the first key not covered by the cache.
+func cachedRegionsInKeyRange(cache *tikv.RegionCache, startKey, endKey []byte) []tikv.RegionVerID {
+	var ids []tikv.RegionVerID
+	key := startKey
+	for {
+		loc := cache.TryLocateKey(key)
+		if loc == nil {
+			return ids
+		}
+		ids = append(ids, loc.Region)
+		if len(loc.EndKey) == 0 || (len(endKey) > 0 && bytes.Compare(loc.EndKey, endKey) >= 0) {
+			return ids
+		}
+		key = loc.EndKey
+	}
+}
+
+// ResetStats resets the statistics recorded by this package, only used in tests.
+func ResetStats() {
+	invalidateCounters.Range(func(key, _ any) bool {
+		invalidateCounters.Delete(key)
+		return true
+	})
+	repairCounters.calls.Store(0)
+	repairCounters.loadedRegions.Store(0)
+	repairCounters.staleRegions.Store(0)
+}
@@ -0,0 +1,108 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regioncache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/testutils"
+	"github.com/tikv/client-go/v2/tikv"
+)
+
+func findItem(items []Item, category, name string) (int64, bool) {
+	for _, item := range items {
+		if item.Category == category && item.Name == name {
+			return item.Value, true
+		}
+	}
+	return 0, false
+}
+
+func TestRepairRegionsInKeyRange(t *testing.T) {
+	ResetStats()
+	mockClient, cluster, pdClient, err := testutils.NewMockTiKV("", nil)
+	require.NoError(t, err)
+	defer func() {
+		pdClient.Close()
+		require.NoError(t, mockClient.Close())
+	}()
+	_, regionIDs, _ := testutils.BootstrapWithMultiRegions(cluster, []byte("g"), []byte("n"))
+	pdCli := tikv.NewCodecPDClient(tikv.ModeTxn, pdClient)
+	defer pdCli.Close()
+	cache := tikv.NewRegionCache(pdCli)
+	defer cache.Close()
+	bo := tikv.NewBackofferWithVars(context.Background(), 3000, nil)
+
+	// Nothing is cached, so nothing is stale.
+	regions, stale, err := RepairRegionsInKeyRange(bo, cache, []byte("a"), []byte("z"))
+	require.NoError(t, err)
+	require.Len(t, regions, 3)
+	require.Equal(t, 0, stale)
+
+	// Split the last region, the cached one becomes stale and is replaced.
+	newRegionID := cluster.AllocID()
+	newPeerID := cluster.AllocID()
+	cluster.Split(regionIDs[2], newRegionID, []byte("t"), []uint64{newPeerID}, newPeerID)
+	regions, stale, err = RepairRegionsInKeyRange(bo, cache, []byte("a"), []byte("z"))
+	require.NoError(t, err)
+	require.Len(t, regions, 4)
+	require.Equal(t, 1, stale)
+	loc := cache.TryLocateKey([]byte("u"))
+	require.NotNil(t, loc)
+	require.Equal(t, newRegionID, loc.Region.GetID())
+
+	// Invalidate a region, it's not cached anymore.
+	InvalidateStaleRegion(cache, regions[0].VerID())
+	require.Nil(t, cache.TryLocateKey([]byte("a")))
+	_, stale, err = RepairRegionsInKeyRange(bo, cache, []byte("a"), []byte("z"))
+	require.NoError(t, err)
+	require.Equal(t, 0, stale)
+
+	items := Collect(cache)
+	for name, expected := range map[string]int64{"calls": 3, "loaded_regions": 11, "stale_regions": 1} {
+		v, ok := findItem(items, CategoryRepair, name)
+		require.True(t, ok)
+		require.Equal(t, expected, v, name)
+	}
+	v, ok := findItem(items, CategoryInvalidate, tikv.EpochNotMatch.String())
+	require.True(t, ok)
+	require.Equal(t, int64(1), v)
+	v, ok = findItem(items, CategorySize, "stores")
+	require.True(t, ok)
+	require.Equal(t, int64(1), v)
+	_, ok = findItem(items, CategoryOperation, "scan_regions/ok")
+	require.True(t, ok)
+}
+
+func TestRegisterStatsSource(t *testing.T) {
+	RegisterStatsSource("custom", func(cache *tikv.RegionCache) []Item {
+		require.Nil(t, cache)
+		return []Item{{Name: "b", Value: 2}, {Name: "a", Value: 1}}
+	})
+	defer func() {
+		sources.Lock()
+		delete(sources.m, "custom")
+		sources.Unlock()
+	}()
+	var custom []Item
+	for _, item := range Collect(nil) {
+		if item.Category == "custom" {
+			custom = append(custom, item)
+		}
+	}
+	require.Equal(t, []Item{{Category: "custom", Name: "a", Value: 1}, {Category: "custom", Name: "b", Value: 2}}, custom)
+}
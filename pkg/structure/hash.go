@@ -206,6 +206,35 @@ func (t *TxStructure) HGetLastN(key []byte, num int) ([]HashPair, error) {
 	return res, errors.Trace(err)
 }
 
+// HGetFirstN gets the first N fields and values in hash in the ascending order of the fields.
+func (t *TxStructure) HGetFirstN(key []byte, num int) ([]HashPair, error) {
+	res := make([]HashPair, 0, num)
+	dataPrefix := t.hashDataKeyPrefix(key)
+	it, err := t.reader.Iter(dataPrefix, dataPrefix.PrefixNext())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer it.Close()
+
+	for it.Valid() && len(res) < num {
+		if !it.Key().HasPrefix(dataPrefix) {
+			break
+		}
+		_, field, err := t.decodeHashDataKey(it.Key())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		res = append(res, HashPair{
+			Field: append([]byte{}, field...),
+			Value: append([]byte{}, it.Value()...),
+		})
+		if err = it.Next(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
+
 // HClear removes the hash value of the key.
 func (t *TxStructure) HClear(key []byte) error {
 	err := t.iterateHash(key, func(field []byte, _ []byte) error {
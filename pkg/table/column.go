@@ -55,6 +55,16 @@ type Column struct {
 	DefaultExpr ast.ExprNode
 }
 
+// GIPKColumnName is the name of the generated invisible primary key column.
+const GIPKColumnName = "my_row_id"
+
+// IsGIPKColumn checks whether the column is a generated invisible primary key,
+// which is generated for the table created without a primary key when sql_generated_invisible_primary_key is on.
+func IsGIPKColumn(col *model.ColumnInfo) bool {
+	return col.Name.L == GIPKColumnName && col.Invisible &&
+		mysql.HasPriKeyFlag(col.GetFlag()) && mysql.HasAutoIncrementFlag(col.GetFlag())
+}
+
 // ClonableExprNode is a wrapper for ast.ExprNode.
 type ClonableExprNode struct {
 	ctor     func() ast.ExprNode
@@ -440,6 +450,13 @@ func NewColDesc(col *Column) *ColDesc {
 	} else if col.DefaultIsExpr {
 		extra = "DEFAULT_GENERATED"
 	}
+	if col.Invisible {
+		// Same as MySQL, the INVISIBLE attribute is appended to the other attributes.
+		if extra != "" {
+			extra += " "
+		}
+		extra += "INVISIBLE"
+	}
 
 	desc := &ColDesc{
 		Field:        name.O,
@@ -712,7 +729,7 @@ func GetZeroValue(col *model.ColumnInfo) types.Datum {
 		} else {
 			d.SetString("", col.GetCollate())
 		}
-	case mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeTiDBVectorFloat32:
 		d.SetString("", col.GetCollate())
 	case mysql.TypeDuration:
 		d.SetMysqlDuration(types.ZeroDuration)
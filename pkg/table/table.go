@@ -290,4 +290,18 @@ type CachedTable interface {
 	// 'exit' is a channel to tell the keep alive goroutine to exit.
 	// The result is sent to the 'wg' channel.
 	WriteLockAndKeepAlive(ctx context.Context, exit chan struct{}, leasePtr *uint64, wg chan error)
+
+	// CacheStatus returns the status of the cache on the current TiDB instance.
+	CacheStatus() CachedTableStatus
+}
+
+// CachedTableStatus is the status of a cached table on the current TiDB instance.
+type CachedTableStatus struct {
+	// Size is the size in bytes of the data loaded into the cache.
+	Size int64
+	// WriteThrough means a write burst is detected, the cache is bypassed so the writes
+	// don't wait for the read lease.
+	WriteThrough bool
+	// TooLarge means the table exceeds the size limit and should be demoted to a normal table.
+	TooLarge bool
 }
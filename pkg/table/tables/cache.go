@@ -16,6 +16,7 @@ package tables
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -44,6 +45,56 @@ type cachedTable struct {
 	totalSize int64
 	// StateRemote is not thread-safe, this tokenLimit is used to keep only one visitor.
 	tokenLimit
+	writeBurst writeBurstDetector
+}
+
+const (
+	// cachedTableWriteBurstWindow is the time window to count the writes blocked by the read lease.
+	cachedTableWriteBurstWindow = 30 * time.Second
+	// cachedTableWriteBurstThreshold is the number of blocked writes in cachedTableWriteBurstWindow
+	// which switches a cached table to the write-through mode.
+	cachedTableWriteBurstThreshold = 3
+	// cachedTableWriteThroughDuration is how long a cached table stays in the write-through mode
+	// after a write burst is detected.
+	cachedTableWriteThroughDuration = time.Minute
+)
+
+// writeBurstDetector counts the writes on a cached table which are blocked by the read lease.
+// When it happens too often, the table is switched to the write-through mode: the read lease is
+// neither acquired nor renewed, so the writes do not need to wait for the read lease to expire.
+type writeBurstDetector struct {
+	mu                sync.Mutex
+	windowStart       time.Time
+	count             int
+	writeThroughUntil time.Time
+}
+
+// recordBlockedWrite records a blocked write at `now` and reports whether the write-through mode is entered.
+func (d *writeBurstDetector) recordBlockedWrite(now time.Time) bool {
+	threshold := cachedTableWriteBurstThreshold
+	failpoint.Inject("mockCachedTableWriteBurstThreshold", func(val failpoint.Value) {
+		threshold = val.(int)
+	})
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if now.Sub(d.windowStart) > cachedTableWriteBurstWindow {
+		d.windowStart = now
+		d.count = 0
+	}
+	d.count++
+	if d.count < threshold {
+		return false
+	}
+	entered := !now.Before(d.writeThroughUntil)
+	d.writeThroughUntil = now.Add(cachedTableWriteThroughDuration)
+	return entered
+}
+
+func (d *writeBurstDetector) inWriteThrough(now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return now.Before(d.writeThroughUntil)
 }
 
 type tokenLimit chan StateRemote
@@ -90,6 +141,9 @@ func newMemBuffer(store kv.Storage) (kv.MemBuffer, error) {
 }
 
 func (c *cachedTable) TryReadFromCache(ts uint64, leaseDuration time.Duration) (kv.MemBuffer, bool /*loading*/) {
+	if c.writeBurst.inWriteThrough(time.Now()) {
+		return nil, false
+	}
 	tmp := c.cacheData.Load()
 	if tmp == nil {
 		return nil, false
@@ -182,6 +236,10 @@ func (c *cachedTable) loadDataFromOriginalTable(store kv.Storage) (kv.MemBuffer,
 }
 
 func (c *cachedTable) UpdateLockForRead(ctx context.Context, store kv.Storage, ts uint64, leaseDuration time.Duration) {
+	// Don't take the read lease in the write-through mode, otherwise the writes have to wait for it.
+	if c.writeBurst.inWriteThrough(time.Now()) {
+		return
+	}
 	if h := c.TakeStateRemoteHandleNoWait(); h != nil {
 		go c.updateLockForRead(ctx, h, store, ts, leaseDuration)
 	}
@@ -239,11 +297,25 @@ func (c *cachedTable) updateLockForRead(ctx context.Context, handle StateRemote,
 
 const cachedTableSizeLimit = 64 * (1 << 20)
 
+func (c *cachedTable) exceedSizeLimit() bool {
+	limit := int64(cachedTableSizeLimit)
+	failpoint.Inject("mockCachedTableSizeLimit", func(val failpoint.Value) {
+		limit = int64(val.(int))
+	})
+	return atomic.LoadInt64(&c.totalSize) > limit
+}
+
+// CacheStatus implements the table.CachedTable interface.
+func (c *cachedTable) CacheStatus() table.CachedTableStatus {
+	return table.CachedTableStatus{
+		Size:         atomic.LoadInt64(&c.totalSize),
+		WriteThrough: c.writeBurst.inWriteThrough(time.Now()),
+		TooLarge:     c.exceedSizeLimit(),
+	}
+}
+
 // AddRecord implements the AddRecord method for the table.Table interface.
 func (c *cachedTable) AddRecord(sctx table.MutateContext, r []types.Datum, opts ...table.AddRecordOption) (recordID kv.Handle, err error) {
-	if atomic.LoadInt64(&c.totalSize) > cachedTableSizeLimit {
-		return nil, table.ErrOptOnCacheTable.GenWithStackByArgs("table too large")
-	}
 	txnCtxAddCachedTable(sctx, c.Meta().ID, c)
 	return c.TableCommon.AddRecord(sctx, r, opts...)
 }
@@ -255,15 +327,16 @@ func txnCtxAddCachedTable(sctx table.MutateContext, tid int64, handle *cachedTab
 	}
 	if _, ok := txnCtx.CachedTables[tid]; !ok {
 		txnCtx.CachedTables[tid] = handle
+		// The table is demoted to a normal table in the background, see CacheStatus.
+		if handle.exceedSizeLimit() {
+			sctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf(
+				"cached table %s exceeds the size limit, it will be demoted to a normal table", handle.Meta().Name.O))
+		}
 	}
 }
 
 // UpdateRecord implements table.Table
 func (c *cachedTable) UpdateRecord(ctx context.Context, sctx table.MutateContext, h kv.Handle, oldData, newData []types.Datum, touched []bool) error {
-	// Prevent furthur writing when the table is already too large.
-	if atomic.LoadInt64(&c.totalSize) > cachedTableSizeLimit {
-		return table.ErrOptOnCacheTable.GenWithStackByArgs("table too large")
-	}
 	txnCtxAddCachedTable(sctx, c.Meta().ID, c)
 	return c.TableCommon.UpdateRecord(ctx, sctx, h, oldData, newData, touched)
 }
@@ -310,7 +383,17 @@ func (c *cachedTable) renewLease(handle StateRemote, ts uint64, data *cacheData,
 
 const cacheTableWriteLease = 5 * time.Second
 
+// holdReadLease reports whether the current TiDB instance holds a read lease which is not expired.
+func (c *cachedTable) holdReadLease(now time.Time) bool {
+	tmp := c.cacheData.Load()
+	if tmp == nil {
+		return false
+	}
+	return oracle.GoTimeToTS(now) < tmp.(*cacheData).Lease
+}
+
 func (c *cachedTable) WriteLockAndKeepAlive(ctx context.Context, exit chan struct{}, leasePtr *uint64, wg chan error) {
+	blocked := c.holdReadLease(time.Now())
 	writeLockLease, err := c.lockForWrite(ctx)
 	atomic.StoreUint64(leasePtr, writeLockLease)
 	wg <- err
@@ -318,6 +401,10 @@ func (c *cachedTable) WriteLockAndKeepAlive(ctx context.Context, exit chan struc
 		logutil.Logger(ctx).Warn("lock for write lock fail", zap.String("category", "cached table"), zap.Error(err))
 		return
 	}
+	if blocked && c.writeBurst.recordBlockedWrite(time.Now()) {
+		logutil.Logger(ctx).Info("write burst detected, switch to write-through mode",
+			zap.String("category", "cached table"), zap.Int64("tableID", c.Meta().ID))
+	}
 
 	t := time.NewTicker(cacheTableWriteLease / 2)
 	defer t.Stop()
@@ -558,3 +558,82 @@ func TestRenewLeaseABAFailPoint(t *testing.T) {
 	tk.MustQuery("select * from t_lease").Check(testkit.Rows("1 2"))
 	require.False(t, lastReadFromCache(tk))
 }
+
+func TestCacheTableWriteThrough(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_burst")
+	tk.MustExec("create table t_burst(id int)")
+	tk.MustExec("alter table t_burst cache")
+
+	// Write without reading doesn't switch the table to write-through mode.
+	for i := 0; i < 3; i++ {
+		tk.MustExec("insert into t_burst values (?)", i)
+	}
+	var cacheUsed bool
+	for i := 0; i < 100; i++ {
+		tk.MustQuery("select count(*) from t_burst").Check(testkit.Rows("3"))
+		if lastReadFromCache(tk) {
+			cacheUsed = true
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.True(t, cacheUsed)
+	tk.MustQuery("select tidb_table_cache_status, tidb_table_cache_mode from information_schema.tables where table_schema = 'test' and table_name = 't_burst'").
+		Check(testkit.Rows("ENABLE READ"))
+
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/table/tables/mockCachedTableWriteBurstThreshold", `return(1)`))
+	defer func() {
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/table/tables/mockCachedTableWriteBurstThreshold"))
+	}()
+	// This write is blocked by the read lease.
+	tk.MustExec("insert into t_burst values (3)")
+	tk.MustQuery("select tidb_table_cache_mode from information_schema.tables where table_schema = 'test' and table_name = 't_burst'").
+		Check(testkit.Rows("WRITE_THROUGH"))
+
+	// The cache is bypassed and no read lease is taken, so the writes don't wait for the lease.
+	for i := 0; i < 3; i++ {
+		tk.MustQuery("select count(*) from t_burst").Check(testkit.Rows(fmt.Sprintf("%d", 4+i)))
+		require.False(t, lastReadFromCache(tk))
+		tk.MustExec("insert into t_burst values (?)", 4+i)
+		require.Less(t, tk.Session().GetSessionVars().StmtCtx.WaitLockLeaseTime, time.Second)
+	}
+}
+
+func TestCacheTableSizeDemotion(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_large")
+	tk.MustExec("create table t_large(id int, v varchar(32))")
+	tk.MustExec("insert into t_large values (1, 'a'), (2, 'b')")
+	tk.MustExec("alter table t_large cache")
+
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/table/tables/mockCachedTableSizeLimit", `return(1)`))
+	defer func() {
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/table/tables/mockCachedTableSizeLimit"))
+	}()
+	var cacheUsed bool
+	for i := 0; i < 10; i++ {
+		tk.MustQuery("select count(*) from t_large").Check(testkit.Rows("2"))
+		if lastReadFromCache(tk) {
+			cacheUsed = true
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	require.True(t, cacheUsed)
+	tk.MustQuery("select tidb_table_cache_mode from information_schema.tables where table_schema = 'test' and table_name = 't_large'").
+		Check(testkit.Rows("DEMOTING"))
+
+	// The write is not rejected, a warning is reported instead.
+	tk.MustExec("insert into t_large values (3, 'c')")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 cached table t_large exceeds the size limit, it will be demoted to a normal table"))
+
+	dom.DemoteOversizeCachedTables()
+	tk.MustQuery("select create_options, tidb_table_cache_status, tidb_table_cache_mode, tidb_table_cache_size from information_schema.tables where table_schema = 'test' and table_name = 't_large'").
+		Check(testkit.Rows(" DISABLE <nil> <nil>"))
+	tk.MustQuery("select count(*) from t_large").Check(testkit.Rows("3"))
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/pkg/tablecodec"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
 	"github.com/pingcap/tidb/pkg/util/rowcodec"
 	"github.com/pingcap/tidb/pkg/util/tracing"
 )
@@ -103,7 +104,15 @@ func (c *index) GenIndexValue(ec errctx.Context, loc *time.Location, distinct bo
 // 2. (i1, [m1,m2], i2, ...) ==> [(i1, m1, i2, ...), (i1, m2, i2, ...)]
 // 3. (i1, null, i2, ...) ==> [(i1, null, i2, ...)]
 // 4. (i1, [], i2, ...) ==> nothing.
+// 5. If fulltext index, ('a b', 'b c') ==> [('a', null), ('b', null), ('c', null)].
+// 6. If vector index ==> nothing, the graph of the vector index is built in memory.
 func (c *index) getIndexedValue(indexedValues []types.Datum) [][]types.Datum {
+	if c.idxInfo.IsFullText() {
+		return c.getFullTextIndexedValue(indexedValues)
+	}
+	if c.idxInfo.IsVector() {
+		return nil
+	}
 	if !c.idxInfo.MVIndex {
 		return [][]types.Datum{indexedValues}
 	}
@@ -150,6 +159,26 @@ out:
 	return vals
 }
 
+// getFullTextIndexedValue splits the values into the distinct tokens, and every token is an
+// entry of the index. The token is stored in the first column, and the other columns are null.
+func (c *index) getFullTextIndexedValue(indexedValues []types.Datum) [][]types.Datum {
+	texts := make([]string, 0, len(indexedValues))
+	for _, v := range indexedValues {
+		if !v.IsNull() {
+			texts = append(texts, v.GetString())
+		}
+	}
+	collation := c.tblInfo.Columns[c.idxInfo.Columns[0].Offset].GetCollate()
+	tokens := fulltext.DistinctTokens(c.idxInfo.FullTextInfo.ParserType, texts...)
+	vals := make([][]types.Datum, 0, len(tokens))
+	for _, token := range tokens {
+		val := make([]types.Datum, len(indexedValues))
+		val[0].SetString(token, collation)
+		vals = append(vals, val)
+	}
+	return vals
+}
+
 // Create creates a new entry in the kvIndex data.
 // If the index is unique and there is an existing entry with the same key,
 // Create will return the existing entry's handle as the first return value, ErrKeyExists as the second return value.
@@ -471,7 +500,7 @@ func (c *index) Delete(ctx table.MutateContext, txn kv.Transaction, indexedValue
 func (c *index) GenIndexKVIter(ec errctx.Context, loc *time.Location, indexedValue []types.Datum,
 	h kv.Handle, handleRestoreData []types.Datum) table.IndexKVGenerator {
 	var mvIndexValues [][]types.Datum
-	if c.Meta().MVIndex {
+	if c.Meta().MVIndex || c.Meta().IsFullText() || c.Meta().IsVector() {
 		mvIndexValues = c.getIndexedValue(indexedValue)
 		return table.NewMultiValueIndexKVGenerator(c, ec, loc, h, handleRestoreData, mvIndexValues)
 	}
@@ -215,6 +215,11 @@ func checkIndexKeys(
 		if !ok {
 			return errors.New("index not found")
 		}
+		if indexInfo.IsFullText() || indexInfo.IsVector() {
+			// The values of the fulltext index are the tokens of the row values,
+			// and the vector index has no values.
+			continue
+		}
 		rowColInfos, ok := indexIDToRowColInfos[idxID]
 		if !ok {
 			return errors.New("index not found")
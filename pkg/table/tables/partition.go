@@ -112,7 +112,7 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Part
 		return nil, table.ErrUnknownPartition
 	}
 	ret := &partitionedTable{TableCommon: *tbl}
-	partitionExpr, err := newPartitionExpr(tblInfo, pi.Type, pi.Expr, pi.Columns, pi.Definitions)
+	partitionExpr, err := newPartitionExpr(tblInfo, pi.Type, pi.Expr, pi.Columns, pi.IsLinear, pi.KeyAlgorithm, pi.Definitions)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -149,9 +149,9 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Part
 		defer unsetIndexesState(ret, origIdx)
 		// TODO: Explicitly explain the different DDL/New fields!
 		if pi.NewTableID != 0 {
-			ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.DDLType, pi.DDLExpr, pi.DDLColumns, pi.DroppingDefinitions)
+			ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.DDLType, pi.DDLExpr, pi.DDLColumns, pi.DDLIsLinear, pi.DDLKeyAlgorithm, pi.DroppingDefinitions)
 		} else {
-			ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.Type, pi.Expr, pi.Columns, pi.DroppingDefinitions)
+			ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.Type, pi.Expr, pi.Columns, pi.IsLinear, pi.KeyAlgorithm, pi.DroppingDefinitions)
 		}
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -175,10 +175,10 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Part
 			defer unsetIndexesState(ret, origIdx)
 			if pi.NewTableID != 0 {
 				// REMOVE PARTITIONING or PARTITION BY
-				ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.DDLType, pi.DDLExpr, pi.DDLColumns, pi.AddingDefinitions)
+				ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.DDLType, pi.DDLExpr, pi.DDLColumns, pi.DDLIsLinear, pi.DDLKeyAlgorithm, pi.AddingDefinitions)
 			} else {
 				// REORGANIZE PARTITION
-				ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.Type, pi.Expr, pi.Columns, pi.AddingDefinitions)
+				ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.Type, pi.Expr, pi.Columns, pi.IsLinear, pi.KeyAlgorithm, pi.AddingDefinitions)
 			}
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -238,7 +238,7 @@ func initPartition(t *partitionedTable, def model.PartitionDefinition) (*partiti
 	return &newPart, nil
 }
 
-func newPartitionExpr(tblInfo *model.TableInfo, tp model.PartitionType, expr string, partCols []model.CIStr, defs []model.PartitionDefinition) (*PartitionExpr, error) {
+func newPartitionExpr(tblInfo *model.TableInfo, tp model.PartitionType, expr string, partCols []model.CIStr, isLinear bool, keyAlgorithm uint64, defs []model.PartitionDefinition) (*PartitionExpr, error) {
 	// a partitioned table cannot rely on session context/sql modes, so use a default one!
 	ctx := mock.NewContext()
 	dbName := model.NewCIStr(ctx.GetSessionVars().CurrentDB)
@@ -253,9 +253,21 @@ func newPartitionExpr(tblInfo *model.TableInfo, tp model.PartitionType, expr str
 	case model.PartitionTypeRange:
 		return generateRangePartitionExpr(ctx, expr, partCols, defs, columns, names)
 	case model.PartitionTypeHash:
-		return generateHashPartitionExpr(ctx, expr, columns, names)
+		ret, err := generateHashPartitionExpr(ctx, expr, columns, names)
+		if err != nil {
+			return nil, err
+		}
+		ret.IsLinear = isLinear
+		return ret, nil
 	case model.PartitionTypeKey:
-		return generateKeyPartitionExpr(ctx, expr, partCols, columns, names)
+		ret, err := generateKeyPartitionExpr(ctx, expr, partCols, columns, names)
+		if err != nil {
+			return nil, err
+		}
+		ret.IsLinear = isLinear
+		ret.ForKeyPruning.IsLinear = isLinear
+		ret.ForKeyPruning.Algorithm = keyAlgorithm
+		return ret, nil
 	case model.PartitionTypeList:
 		return generateListPartitionExpr(ctx, tblInfo, expr, partCols, defs, columns, names)
 	}
@@ -270,6 +282,8 @@ type PartitionExpr struct {
 	OrigExpr ast.ExprNode
 	// Expr is the hash partition expression.
 	Expr expression.Expression
+	// IsLinear is true for LINEAR HASH and LINEAR KEY partitioning.
+	IsLinear bool
 	// Used in the key partition
 	*ForKeyPruning
 	// Used in the range pruning process.
@@ -296,6 +310,22 @@ func (pe *PartitionExpr) GetPartColumnsForKeyPartition(columns []*expression.Col
 
 // LocateKeyPartition is the common interface used to locate the destination partition
 func (kp *ForKeyPruning) LocateKeyPartition(numParts uint64, r []types.Datum) (int, error) {
+	if kp.Algorithm != 0 {
+		fts := make([]*types.FieldType, 0, len(kp.KeyPartCols))
+		vals := make([]types.Datum, 0, len(kp.KeyPartCols))
+		for _, col := range kp.KeyPartCols {
+			fts = append(fts, col.RetType)
+			vals = append(vals, r[col.Index])
+		}
+		hash, err := mysqlKeyHash(types.DefaultStmtNoWarningContext, fts, vals)
+		if err != nil {
+			return 0, err
+		}
+		if kp.IsLinear {
+			return linearHashPartitionIndex(uint64(hash), numParts), nil
+		}
+		return int(hash % uint32(numParts)), nil
+	}
 	h := crc32.NewIEEE()
 	for _, col := range kp.KeyPartCols {
 		val := r[col.Index]
@@ -309,6 +339,9 @@ func (kp *ForKeyPruning) LocateKeyPartition(numParts uint64, r []types.Datum) (i
 			h.Write(data)
 		}
 	}
+	if kp.IsLinear {
+		return linearHashPartitionIndex(uint64(h.Sum32()), numParts), nil
+	}
 	return int(h.Sum32() % uint32(numParts)), nil
 }
 
@@ -388,6 +421,10 @@ func parseSimpleExprWithNames(p *parser.Parser, ctx expression.BuildContext, exp
 // ForKeyPruning is used for key partition pruning.
 type ForKeyPruning struct {
 	KeyPartCols []*expression.Column
+	// IsLinear is true for LINEAR KEY partitioning.
+	IsLinear bool
+	// Algorithm is the ALGORITHM of KEY partitioning, 0 means the TiDB hash function is used.
+	Algorithm uint64
 }
 
 // ForListPruning is used for list partition pruning.
@@ -976,6 +1013,34 @@ func (lp *ForListPruning) LocatePartition(value int64, isNull bool) int {
 	return partitionIdx
 }
 
+// LocateRange locates partitions by the not null values in range [low, high].
+// The default partition is always included since it may contain any value in the range.
+func (lp *ForListPruning) LocateRange(low, high int64, unsigned bool) []int {
+	if unsigned && uint64(low) > uint64(high) || !unsigned && low > high {
+		return nil
+	}
+	used := make(map[int]struct{})
+	for v, partitionIdx := range lp.valueMap {
+		if unsigned {
+			if uint64(v) < uint64(low) || uint64(v) > uint64(high) {
+				continue
+			}
+		} else if v < low || v > high {
+			continue
+		}
+		used[partitionIdx] = struct{}{}
+	}
+	if lp.defaultPartitionIdx >= 0 {
+		used[lp.defaultPartitionIdx] = struct{}{}
+	}
+	ret := make([]int, 0, len(used))
+	for idx := range used {
+		ret = append(ret, idx)
+	}
+	sort.Ints(ret)
+	return ret
+}
+
 func (lp *ForListPruning) locateListPartitionByRow(ctx expression.EvalContext, r []types.Datum) (int, error) {
 	value, isNull, err := lp.LocateExpr.EvalInt(ctx, chunk.MutRowFromDatums(r).ToRow())
 	if err != nil {
@@ -1320,7 +1385,7 @@ func (t *partitionedTable) locatePartition(ctx expression.BuildContext, r []type
 func (t *partitionedTable) locateReorgPartition(ctx expression.BuildContext, r []types.Datum) (int64, error) {
 	pi := t.Meta().GetPartitionInfo()
 	columnsSet := len(pi.DDLColumns) > 0
-	// Note that for KEY/HASH partitioning, since we do not support LINEAR,
+	// Note that for KEY/HASH partitioning, even for LINEAR,
 	// all partitions will be reorganized,
 	// so we can use the number in Dropping or AddingDefinitions,
 	// depending on current state.
@@ -1448,7 +1513,6 @@ func (t *partitionedTable) locateRangePartition(ctx expression.BuildContext, par
 	return pos, nil
 }
 
-// TODO: supports linear hashing
 func (t *partitionedTable) locateHashPartition(ctx expression.EvalContext, partExpr *PartitionExpr, numParts uint64, r []types.Datum) (int, error) {
 	if col, ok := partExpr.Expr.(*expression.Column); ok {
 		var data types.Datum
@@ -1462,12 +1526,7 @@ func (t *partitionedTable) locateHashPartition(ctx expression.EvalContext, partE
 				return 0, err
 			}
 		}
-		ret := data.GetInt64()
-		ret = ret % int64(numParts)
-		if ret < 0 {
-			ret = -ret
-		}
-		return int(ret), nil
+		return HashPartitionIndex(data.GetInt64(), numParts, partExpr.IsLinear), nil
 	}
 	evalBuffer := t.evalBufferPool.Get().(*chunk.MutRow)
 	defer t.evalBufferPool.Put(evalBuffer)
@@ -1479,11 +1538,7 @@ func (t *partitionedTable) locateHashPartition(ctx expression.EvalContext, partE
 	if isNull {
 		return 0, nil
 	}
-	ret = ret % int64(numParts)
-	if ret < 0 {
-		ret = -ret
-	}
-	return int(ret), nil
+	return HashPartitionIndex(ret, numParts, partExpr.IsLinear), nil
 }
 
 // GetPartition returns a Table, which is actually a partition.
@@ -1519,6 +1574,8 @@ func GetReorganizedPartitionedTable(t table.Table) (table.PartitionedTable, erro
 	pi.Type = pi.DDLType
 	pi.Expr = pi.DDLExpr
 	pi.Columns = pi.DDLColumns
+	pi.IsLinear = pi.DDLIsLinear
+	pi.KeyAlgorithm = pi.DDLKeyAlgorithm
 	tblInfo.ID = pi.NewTableID
 
 	constraints, err := table.LoadCheckConstraint(tblInfo)
@@ -0,0 +1,149 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"encoding/binary"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// HashPartitionIndex returns the index of the partition for the value of the
+// HASH partitioning expression, the same as MySQL does for [LINEAR] HASH.
+func HashPartitionIndex(val int64, numParts uint64, isLinear bool) int {
+	if isLinear {
+		return linearHashPartitionIndex(uint64(val), numParts)
+	}
+	idx := val % int64(numParts)
+	if idx < 0 {
+		idx = -idx
+	}
+	return int(idx)
+}
+
+// linearHashPartitionIndex is the powers-of-two algorithm of MySQL LINEAR HASH/KEY partitioning.
+func linearHashPartitionIndex(val uint64, numParts uint64) int {
+	mask := uint64(1)
+	for mask < numParts {
+		mask <<= 1
+	}
+	mask--
+	idx := val & mask
+	if idx >= numParts {
+		idx = val & (((mask + 1) >> 1) - 1)
+	}
+	return int(idx)
+}
+
+// IsMySQLKeyHashSupported returns whether the values of the column type can be hashed
+// the same as MySQL does for KEY partitioning, see mysqlKeyHash.
+func IsMySQLKeyHashSupported(ft *types.FieldType) bool {
+	switch ft.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong,
+		mysql.TypeYear, mysql.TypeDate, mysql.TypeDatetime:
+		return true
+	case mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString:
+		switch ft.GetCollate() {
+		case charset.CollationBin, charset.CollationUTF8MB4, charset.CollationUTF8, charset.CollationASCII, "utf8mb4_0900_bin":
+			return true
+		}
+	}
+	return false
+}
+
+// mysqlKeyHash is the same hash function as calculate_key_hash_value() of MySQL, which
+// hashes the values in their MySQL storage format by the hash_sort() of the collation.
+func mysqlKeyHash(ctx types.Context, fts []*types.FieldType, vals []types.Datum) (uint32, error) {
+	nr1, nr2 := uint64(1), uint64(4)
+	for i, val := range vals {
+		if val.IsNull() {
+			nr1 ^= (nr1 << 1) | 1
+			continue
+		}
+		ft := fts[i]
+		// The row values are already of the column type, but the values used for pruning may not be.
+		var needConvert bool
+		switch ft.GetType() {
+		case mysql.TypeDate, mysql.TypeDatetime:
+			needConvert = val.Kind() != types.KindMysqlTime
+		case mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString:
+			needConvert = val.Kind() != types.KindString && val.Kind() != types.KindBytes
+		default:
+			needConvert = val.Kind() != types.KindInt64 && val.Kind() != types.KindUint64
+		}
+		if needConvert {
+			var err error
+			if val, err = val.ConvertTo(ctx, ft); err != nil {
+				return 0, errors.Trace(err)
+			}
+		}
+		var buf []byte
+		switch ft.GetType() {
+		case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
+			buf = binary.LittleEndian.AppendUint64(nil, val.GetUint64())[:mysql.DefaultLengthOfMysqlTypes[ft.GetType()]]
+		case mysql.TypeYear:
+			year := val.GetInt64()
+			if year > 0 {
+				year -= 1900
+			}
+			buf = []byte{byte(year)}
+		case mysql.TypeDate:
+			t := val.GetMysqlTime()
+			v := uint32(t.Day()) + uint32(t.Month())*32 + uint32(t.Year())*16*32
+			buf = []byte{byte(v), byte(v >> 8), byte(v >> 16)}
+		case mysql.TypeDatetime:
+			buf = mysqlDatetimeBinary(val.GetMysqlTime(), ft.GetDecimal())
+		default:
+			buf = val.GetBytes()
+			if ft.GetCollate() != charset.CollationBin && ft.GetCollate() != "utf8mb4_0900_bin" {
+				// PAD SPACE collations ignore the trailing spaces.
+				for len(buf) > 0 && buf[len(buf)-1] == ' ' {
+					buf = buf[:len(buf)-1]
+				}
+			}
+		}
+		for _, b := range buf {
+			nr1 ^= ((nr1&63)+nr2)*uint64(b) + (nr1 << 8)
+			nr2 += 3
+		}
+	}
+	return uint32(nr1), nil
+}
+
+// mysqlDatetimeBinary encodes the datetime like my_datetime_packed_to_binary() of MySQL.
+func mysqlDatetimeBinary(t types.Time, fsp int) []byte {
+	ymd := uint64((t.Year()*13+t.Month())<<5 | t.Day())
+	hms := uint64(t.Hour()<<12 | t.Minute()<<6 | t.Second())
+	intPart := (ymd<<17 | hms) + 0x8000000000
+	buf := make([]byte, 5, 8)
+	for i := 4; i >= 0; i-- {
+		buf[i] = byte(intPart)
+		intPart >>= 8
+	}
+	frac := uint32(t.Microsecond())
+	switch fsp {
+	case 1, 2:
+		buf = append(buf, byte(frac/10000))
+	case 3, 4:
+		frac /= 100
+		buf = append(buf, byte(frac>>8), byte(frac))
+	case 5, 6:
+		buf = append(buf, byte(frac>>16), byte(frac>>8), byte(frac))
+	}
+	return buf
+}
@@ -39,6 +39,8 @@ type MockSessionManager struct {
 	Conn     map[uint64]sessiontypes.Session
 	mu       sync.Mutex
 	ConAttrs map[uint64]map[string]string
+	// UserResources is returned by GetUserResourceUsage.
+	UserResources []*util.UserResourceUsage
 
 	internalSessions map[any]struct{}
 }
@@ -111,6 +113,11 @@ func (msm *MockSessionManager) GetConAttrs(user *auth.UserIdentity) map[uint64]m
 	return msm.ConAttrs
 }
 
+// GetUserResourceUsage implements the SessionManager.GetUserResourceUsage interface.
+func (msm *MockSessionManager) GetUserResourceUsage() []*util.UserResourceUsage {
+	return msm.UserResources
+}
+
 // Kill implements the SessionManager.Kill interface.
 func (*MockSessionManager) Kill(uint64, bool, bool) {
 }
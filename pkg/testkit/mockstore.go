@@ -165,9 +165,14 @@ func (d *DistExecutionContext) SetOwner(idx int) {
 
 // AddDomain add 1 domain which is not ddl owner.
 func (d *DistExecutionContext) AddDomain() {
+	d.AddDomainWithSchemaLease(500 * time.Millisecond)
+}
+
+// AddDomainWithSchemaLease add 1 domain which is not ddl owner, and loads the schema with its own lease.
+func (d *DistExecutionContext) AddDomainWithSchemaLease(lease time.Duration) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	dom := bootstrap4DistExecution(d.t, d.Store, 500*time.Millisecond)
+	dom := bootstrap4DistExecution(d.t, d.Store, lease)
 	dom.InfoSyncer().SetSessionManager(d.domains[0].InfoSyncer().GetSessionManager())
 	dom.DDL().OwnerManager().RetireOwner()
 	d.domains = append(d.domains, dom)
@@ -230,8 +235,51 @@ func (d *DistExecutionContext) GetDomainCnt() int {
 	return len(d.domains)
 }
 
+// NewTestKit creates a TestKit whose session is bound to the domain by idx, so the statements are executed
+// with the infoschema loaded by that domain, like they're sent to the corresponding TiDB node.
+func (d *DistExecutionContext) NewTestKit(idx int) *TestKit {
+	dom := d.GetDomain(idx)
+	se, err := session.CreateSessionOnDomain4Test(d.Store, dom)
+	require.NoError(d.t, err)
+	se.SetSessionManager(dom.InfoSyncer().GetSessionManager())
+	tk := NewTestKitWithSession(d.t, d.Store, se)
+	// enforce sysvar cache loading, ref loadCommonGlobalVariableIfNeeded
+	tk.MustExec("select 3")
+	return tk
+}
+
+// PauseSchemaLoad makes the domain by idx stop loading the newer schema, to simulate a TiDB node lagging behind
+// the latest schema version. Each domain has its own schema syncer, so the DDL owner won't wait for the paused
+// domain, just like it's regarded as lost by the cluster.
+func (d *DistExecutionContext) PauseSchemaLoad(idx int) {
+	d.GetDomain(idx).PauseSchemaLoad4Test()
+}
+
+// ResumeSchemaLoad resumes the schema loading of the domain by idx, and reloads the schema immediately.
+func (d *DistExecutionContext) ResumeSchemaLoad(idx int) {
+	dom := d.GetDomain(idx)
+	dom.ResumeSchemaLoad4Test()
+	require.NoError(d.t, dom.Reload())
+}
+
+// SchemaVersion returns the schema version of the infoschema loaded by the domain by idx.
+func (d *DistExecutionContext) SchemaVersion(idx int) int64 {
+	return d.GetDomain(idx).InfoSchema().SchemaMetaVersion()
+}
+
 // NewDistExecutionContext create DistExecutionContext for testing.
 func NewDistExecutionContext(t testing.TB, serverNum int) *DistExecutionContext {
+	leases := make([]time.Duration, serverNum)
+	for i := range leases {
+		leases[i] = 500 * time.Millisecond
+	}
+	return NewDistExecutionContextWithSchemaLeases(t, leases)
+}
+
+// NewDistExecutionContextWithSchemaLeases create DistExecutionContext for testing, there is one domain for each
+// lease, and the domain loads the schema with its own lease.
+func NewDistExecutionContextWithSchemaLeases(t testing.TB, leases []time.Duration) *DistExecutionContext {
+	serverNum := len(leases)
 	store, err := mockstore.NewMockStore()
 	require.NoError(t, err)
 	gctuner.GlobalMemoryLimitTuner.Stop()
@@ -240,7 +288,7 @@ func NewDistExecutionContext(t testing.TB, serverNum int) *DistExecutionContext
 
 	var domInfo []string
 	for i := 0; i < serverNum; i++ {
-		dom := bootstrap4DistExecution(t, store, 500*time.Millisecond)
+		dom := bootstrap4DistExecution(t, store, leases[i])
 		if i != serverNum-1 {
 			dom.SetOnClose(func() { /* don't delete the store in domain map */ })
 		}
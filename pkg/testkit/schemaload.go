@@ -0,0 +1,93 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testkit
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/stretchr/testify/require"
+)
+
+// The failpoints used to inject the faults into the loading of the infoschema.
+const (
+	fpListTablesError = "github.com/pingcap/tidb/pkg/domain/mockListTablesError"
+	fpStaleSchemaDiff = "github.com/pingcap/tidb/pkg/domain/mockStaleSchemaDiff"
+	fpApplyDiffError  = "github.com/pingcap/tidb/pkg/infoschema/mockApplyDiffError"
+	fpPolicyMissing   = "github.com/pingcap/tidb/pkg/infoschema/mockPolicyMissing"
+)
+
+// SchemaLoadFault is a fault injected into the loading of the infoschema. It's removed when the test finishes,
+// or it can be removed by Remove to check the loading recovers from it.
+type SchemaLoadFault struct {
+	t       testing.TB
+	fpName  string
+	removed bool
+}
+
+func injectSchemaLoadFault(t testing.TB, fpName, expr string) *SchemaLoadFault {
+	require.NoError(t, failpoint.Enable(fpName, expr))
+	f := &SchemaLoadFault{t: t, fpName: fpName}
+	t.Cleanup(f.Remove)
+	return f
+}
+
+// Remove removes the fault, it's safe to call it more than once.
+func (f *SchemaLoadFault) Remove() {
+	if f.removed {
+		return
+	}
+	f.removed = true
+	require.NoError(f.t, failpoint.Disable(f.fpName))
+}
+
+// InjectListTablesError makes listing the tables of the schema fail when the infoschema is fully loaded.
+func InjectListTablesError(t testing.TB, dbName string) *SchemaLoadFault {
+	return injectSchemaLoadFault(t, fpListTablesError, fmt.Sprintf(`return("%s")`, strings.ToLower(dbName)))
+}
+
+// InjectStaleSchemaDiff makes the schema diff of the version be read as a stale one, so the infoschema can't be
+// loaded by applying the schema diffs, and it's fully loaded instead.
+func InjectStaleSchemaDiff(t testing.TB, version int64) *SchemaLoadFault {
+	return injectSchemaLoadFault(t, fpStaleSchemaDiff, fmt.Sprintf("return(%d)", version))
+}
+
+// InjectApplyDiffError makes applying the schema diffs of the action type fail.
+func InjectApplyDiffError(t testing.TB, tp model.ActionType) *SchemaLoadFault {
+	return injectSchemaLoadFault(t, fpApplyDiffError, fmt.Sprintf(`return("%s")`, tp.String()))
+}
+
+// InjectPolicyMissing makes the placement policy missing in the meta when the schema diff creating or altering
+// it is applied.
+func InjectPolicyMissing(t testing.TB, policyID int64) *SchemaLoadFault {
+	return injectSchemaLoadFault(t, fpPolicyMissing, fmt.Sprintf("return(%d)", policyID))
+}
+
+// RequireSchemaLoadRecovered reloads the infoschema of the domain, and checks the loaded infoschema catches up
+// with the latest schema version in the meta.
+func RequireSchemaLoadRecovered(t testing.TB, dom *domain.Domain) {
+	require.NoError(t, dom.Reload())
+	ver, err := dom.Store().CurrentVersion(kv.GlobalTxnScope)
+	require.NoError(t, err)
+	latest, err := meta.NewSnapshotMeta(dom.Store().GetSnapshot(ver)).GetSchemaVersionWithNonEmptyDiff()
+	require.NoError(t, err)
+	require.Equal(t, latest, dom.InfoSchema().SchemaMetaVersion())
+}
@@ -359,9 +359,12 @@ func (tk *TestKit) ExecWithContext(ctx context.Context, sql string, args ...any)
 		for i, stmt := range stmts {
 			var rs sqlexec.RecordSet
 			var err error
-			if s, ok := stmt.(*ast.NonTransactionalDMLStmt); ok {
+			switch s := stmt.(type) {
+			case *ast.NonTransactionalDMLStmt:
 				rs, err = session.HandleNonTransactionalDML(ctx, s, tk.Session())
-			} else {
+			case *ast.CallStmt:
+				rs, err = session.HandleCallProcedure(ctx, s, tk.Session())
+			default:
 				rs, err = tk.Session().ExecuteStmt(ctx, stmt)
 			}
 			if i == 0 {
@@ -49,19 +49,19 @@ func (isc *InfoSchemaCache) Update(se session.Session) error {
 	}
 
 	newTables := make(map[int64]*PhysicalTable, len(isc.Tables))
-	for _, db := range is.AllSchemas() {
-		for _, tbl := range is.SchemaTables(db.Name) {
+	for _, db := range is.ListTablesWithSpecialAttribute(infoschema.TTLAttribute) {
+		for _, tbl := range db.Tables {
 			tblInfo := tbl.Meta()
 			if tblInfo.TTLInfo == nil || !tblInfo.TTLInfo.Enable || tblInfo.State != model.StatePublic {
 				continue
 			}
 
 			logger := logutil.BgLogger().
-				With(zap.String("schema", db.Name.L),
+				With(zap.String("schema", db.DBName.L),
 					zap.Int64("tableID", tblInfo.ID), zap.String("tableName", tblInfo.Name.L))
 
 			if tblInfo.Partition == nil {
-				ttlTable, err := isc.newTable(db.Name, tblInfo, nil)
+				ttlTable, err := isc.newTable(db.DBName, tblInfo, nil)
 				if err != nil {
 					logger.Warn("fail to build info schema cache", zap.Error(err))
 					continue
@@ -72,7 +72,7 @@ func (isc *InfoSchemaCache) Update(se session.Session) error {
 
 			for _, par := range tblInfo.Partition.Definitions {
 				par := par
-				ttlTable, err := isc.newTable(db.Name, tblInfo, &par)
+				ttlTable, err := isc.newTable(db.DBName, tblInfo, &par)
 				if err != nil {
 					logger.Warn("fail to build info schema cache",
 						zap.Int64("partitionID", par.ID),
@@ -99,7 +99,7 @@ func (isc *InfoSchemaCache) newTable(schema model.CIStr, tblInfo *model.TableInf
 
 	if isc.Tables != nil {
 		ttlTable, ok := isc.Tables[id]
-		if ok && ttlTable.TableInfo == tblInfo {
+		if ok && ttlTable.TableInfo == tblInfo && ttlTable.Schema.L == schema.L {
 			return ttlTable, nil
 		}
 	}
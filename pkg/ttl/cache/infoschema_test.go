@@ -70,4 +70,13 @@ func TestInfoSchemaCache(t *testing.T) {
 		partitions = append(partitions, table.PartitionDef.Name.L)
 	}
 	assert.ElementsMatch(t, []string{"p0", "p1"}, partitions)
+
+	// test tables renamed across the schemas are synced
+	tk.MustExec("create database test2")
+	tk.MustExec("rename table test.t to test2.t")
+	assert.NoError(t, isc.Update(se))
+	assert.Equal(t, 2, len(isc.Tables))
+	for _, table := range isc.Tables {
+		assert.Equal(t, "test2", table.Schema.L)
+	}
 }
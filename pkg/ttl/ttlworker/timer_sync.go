@@ -184,13 +184,13 @@ func (g *TTLTimersSyncer) SyncTimers(ctx context.Context, is infoschema.InfoSche
 	defer se.Close()
 
 	currentTimerKeys := make(map[string]struct{})
-	for _, db := range is.AllSchemas() {
-		for _, tbl := range is.SchemaTables(db.Name) {
+	for _, db := range is.ListTablesWithSpecialAttribute(infoschema.TTLAttribute) {
+		for _, tbl := range db.Tables {
 			tblInfo := tbl.Meta()
 			if tblInfo.State != model.StatePublic || tblInfo.TTLInfo == nil {
 				continue
 			}
-			for _, key := range g.syncTimersForTable(ctx, se, db.Name, tblInfo) {
+			for _, key := range g.syncTimersForTable(ctx, se, db.DBName, tblInfo) {
 				currentTimerKeys[key] = struct{}{}
 			}
 		}
@@ -937,6 +937,8 @@ func (d *Datum) ConvertTo(ctx Context, target *FieldType) (Datum, error) {
 		return d.convertToMysqlSet(ctx, target)
 	case mysql.TypeJSON:
 		return d.convertToMysqlJSON(target)
+	case mysql.TypeTiDBVectorFloat32:
+		return d.convertToVectorFloat32(target)
 	case mysql.TypeNull:
 		return Datum{}, nil
 	default:
@@ -1090,6 +1092,17 @@ func (d *Datum) convertToString(ctx Context, target *FieldType) (Datum, error) {
 // whether we should pad `\0` for `binary(flen)` type.
 func ProduceStrWithSpecifiedTp(s string, tp *FieldType, ctx Context, padZero bool) (_ string, err error) {
 	flen, chs := tp.GetFlen(), tp.GetCharset()
+	if tp.GetType() == mysql.TypeTiDBVectorFloat32 {
+		// The flen of VECTOR is the dimension rather than the length of the text.
+		v, err := ParseVectorFloat32(s)
+		if err != nil {
+			return "", err
+		}
+		if err = v.CheckDimsFitColumn(flen); err != nil {
+			return "", err
+		}
+		return v.String(), nil
+	}
 	if flen >= 0 {
 		// overflowed stores the part of the string that is out of the length constraint, it is later checked to see if the
 		// overflowed part is all whitespaces
@@ -1739,6 +1752,32 @@ func (d *Datum) convertToMysqlJSON(_ *FieldType) (ret Datum, err error) {
 	return ret, errors.Trace(err)
 }
 
+// convertToVectorFloat32 converts the datum to the text format of a vector, which is how
+// the values of VECTOR columns are stored.
+func (d *Datum) convertToVectorFloat32(target *FieldType) (ret Datum, err error) {
+	var s string
+	switch d.k {
+	case KindString, KindBytes:
+		s = d.GetString()
+	case KindMysqlJSON:
+		s = d.GetMysqlJSON().String()
+	default:
+		if s, err = d.ToString(); err != nil {
+			return ret, errors.Trace(err)
+		}
+		return ret, ErrInvalidVectorValue.GenWithStackByArgs(s)
+	}
+	v, err := ParseVectorFloat32(s)
+	if err != nil {
+		return ret, errors.Trace(err)
+	}
+	if err = v.CheckDimsFitColumn(target.GetFlen()); err != nil {
+		return ret, errors.Trace(err)
+	}
+	ret.SetString(v.String(), charset.CollationBin)
+	return ret, nil
+}
+
 // ToBool converts to a bool.
 // We will use 1 for true, and 0 for false.
 func (d *Datum) ToBool(ctx Context) (int64, error) {
@@ -2374,7 +2413,7 @@ func GetMaxValue(ft *FieldType) (max Datum) {
 		max.SetFloat32(float32(GetMaxFloat(ft.GetFlen(), ft.GetDecimal())))
 	case mysql.TypeDouble:
 		max.SetFloat64(GetMaxFloat(ft.GetFlen(), ft.GetDecimal()))
-	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeTiDBVectorFloat32:
 		// codec.Encode KindMaxValue, to avoid import circle
 		bytes := []byte{250}
 		max.SetString(string(bytes), ft.GetCollate())
@@ -2405,7 +2444,7 @@ func GetMinValue(ft *FieldType) (min Datum) {
 		min.SetFloat32(float32(-GetMaxFloat(ft.GetFlen(), ft.GetDecimal())))
 	case mysql.TypeDouble:
 		min.SetFloat64(-GetMaxFloat(ft.GetFlen(), ft.GetDecimal()))
-	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeTiDBVectorFloat32:
 		// codec.Encode KindMinNotNull, to avoid import circle
 		bytes := []byte{1}
 		min.SetString(string(bytes), ft.GetCollate())
@@ -94,4 +94,8 @@ var (
 	ErrPartitionColumnStatsMissing = dbterror.ClassTypes.NewStd(mysql.ErrPartitionColumnStatsMissing)
 	// ErrIncorrectDatetimeValue is returned when the input value is in wrong format for datetime.
 	ErrIncorrectDatetimeValue = dbterror.ClassTypes.NewStd(mysql.ErrIncorrectDatetimeValue)
+	// ErrVectorDimensionMismatch is returned when the dimensions of the vectors don't match.
+	ErrVectorDimensionMismatch = dbterror.ClassTypes.NewStd(mysql.ErrVectorDimensionMismatch)
+	// ErrInvalidVectorValue is returned when the value can't be converted to a vector.
+	ErrInvalidVectorValue = dbterror.ClassTypes.NewStd(mysql.ErrInvalidVectorValue)
 )
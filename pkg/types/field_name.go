@@ -31,6 +31,8 @@ type FieldName struct {
 	ColName     model.CIStr
 
 	Hidden bool
+	// Invisible is used for mark whether a column is excluded from the expansion of `*`.
+	Invisible bool
 
 	// NotExplicitUsable is used for mark whether a column can be explicit used in SQL.
 	// update stmt can write `writeable` column implicitly but cannot use non-public columns explicit.
@@ -65,7 +67,7 @@ func (name *FieldName) MemoryUsage() (sum int64) {
 	}
 
 	sum = name.OrigTblName.MemoryUsage() + name.OrigColName.MemoryUsage() + name.DBName.MemoryUsage() +
-		name.TblName.MemoryUsage() + name.ColName.MemoryUsage() + size.SizeOfBool*3
+		name.TblName.MemoryUsage() + name.ColName.MemoryUsage() + size.SizeOfBool*4
 	return
 }
 
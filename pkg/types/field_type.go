@@ -373,6 +373,13 @@ func DefaultCharsetForType(tp byte) (defaultCharset string, defaultCollation str
 // The result field type of the case expression is the merged type of the two when clause.
 // See https://github.com/mysql/mysql-server/blob/8.0/sql/field.cc#L1042
 func MergeFieldType(a byte, b byte) byte {
+	if a == mysql.TypeTiDBVectorFloat32 || b == mysql.TypeTiDBVectorFloat32 {
+		// The vectors are merged as strings with the other types.
+		if a == b {
+			return a
+		}
+		return mysql.TypeLongBlob
+	}
 	ia := getFieldTypeIndex(a)
 	ib := getFieldTypeIndex(b)
 	return fieldTypeMergeRules[ia][ib]
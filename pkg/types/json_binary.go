@@ -284,6 +284,16 @@ func (bj BinaryJSON) ArrayGetElem(idx int) BinaryJSON {
 	return bj.valEntryGet(headerSize + idx*valEntrySize)
 }
 
+// ObjectGetKey gets the key of the element of the index `i` in an object.
+func (bj BinaryJSON) ObjectGetKey(i int) []byte {
+	return bj.objectGetKey(i)
+}
+
+// ObjectGetVal gets the value of the element of the index `i` in an object.
+func (bj BinaryJSON) ObjectGetVal(i int) BinaryJSON {
+	return bj.objectGetVal(i)
+}
+
 func (bj BinaryJSON) objectGetKey(i int) []byte {
 	keyOff := int(jsonEndian.Uint32(bj.Value[headerSize+i*keyEntrySize:]))
 	keyLen := int(jsonEndian.Uint16(bj.Value[headerSize+i*keyEntrySize+keyLenOff:]))
@@ -817,6 +817,13 @@ func (d *MyDecimal) doMiniRightShift(shift, beg, end int) {
 //
 //	nil/ErrTruncated/ErrOverflow
 func (d *MyDecimal) Round(to *MyDecimal, frac int, roundMode RoundMode) (err error) {
+	if d.roundFast(to, frac, roundMode) {
+		return nil
+	}
+	return d.doRound(to, frac, roundMode)
+}
+
+func (d *MyDecimal) doRound(to *MyDecimal, frac int, roundMode RoundMode) (err error) {
 	// wordsFracTo is the number of fraction words in buffer.
 	wordsFracTo := (frac + 1) / digitsPerWord
 	if frac > 0 {
@@ -1963,6 +1970,13 @@ DecimalMul multiplies two decimals.
 */
 func DecimalMul(from1, from2, to *MyDecimal) error {
 	from1, from2, to = validateArgs(from1, from2, to)
+	if decimalMulFast(from1, from2, to) {
+		return nil
+	}
+	return doMul(from1, from2, to)
+}
+
+func doMul(from1, from2, to *MyDecimal) error {
 	var (
 		err         error
 		wordsInt1   = digitsToWords(int(from1.digitsInt))
@@ -2065,23 +2079,28 @@ func DecimalMul(from1, from2, to *MyDecimal) error {
 		}
 	}
 
-	idxTo = 0
-	dToMove := wordsIntTo + digitsToWords(int(to.digitsFrac))
-	for to.wordBuf[idxTo] == 0 && to.digitsInt > digitsPerWord {
-		idxTo++
-		to.digitsInt -= digitsPerWord
+	to.removeLeadingZeroWords(wordsIntTo)
+	return err
+}
+
+// removeLeadingZeroWords removes the leading zero words of the product of DecimalMul, which has wordsInt integer words.
+func (d *MyDecimal) removeLeadingZeroWords(wordsInt int) {
+	idx := 0
+	dToMove := wordsInt + digitsToWords(int(d.digitsFrac))
+	for d.wordBuf[idx] == 0 && d.digitsInt > digitsPerWord {
+		idx++
+		d.digitsInt -= digitsPerWord
 		dToMove--
 	}
-	if idxTo > 0 {
+	if idx > 0 {
 		curIdx := 0
 		for dToMove > 0 {
-			to.wordBuf[curIdx] = to.wordBuf[idxTo]
+			d.wordBuf[curIdx] = d.wordBuf[idx]
 			curIdx++
-			idxTo++
+			idx++
 			dToMove--
 		}
 	}
-	return err
 }
 
 // DecimalDiv does division of two decimals.
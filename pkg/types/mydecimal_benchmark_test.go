@@ -174,3 +174,49 @@ func BenchmarkMyDecimalToHashKey(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkMyDecimalMul(b *testing.B) {
+	cases := []string{"12345.67", "-9876.54", "1.5", "99999999.99", "0.000001", "-123456789.12"}
+	decs := make([]MyDecimal, 0, len(cases))
+	for _, ca := range cases {
+		var dec MyDecimal
+		if err := dec.FromString([]byte(ca)); err != nil {
+			b.Fatal(err)
+		}
+		decs = append(decs, dec)
+	}
+	for _, c := range []struct {
+		name string
+		mul  func(from1, from2, to *MyDecimal) error
+	}{
+		{"FastPath", DecimalMul},
+		{"WordByWord", doMul},
+	} {
+		b.Run(c.name, func(b *testing.B) {
+			var to MyDecimal
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := range decs {
+					for k := range decs {
+						to = MyDecimal{}
+						if err := c.mul(&decs[j], &decs[k], &to); err != nil {
+							b.Fatal(err)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFromInts(b *testing.B) {
+	ints := make([]int64, 1024)
+	for i := range ints {
+		ints[i] = rand.Int63n(1 << 40)
+	}
+	decs := make([]MyDecimal, len(ints))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FromInts(ints, false, decs)
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math/bits"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+// The fast paths of MyDecimal handle the decimals with at most fastPathMaxWords words, i.e. DECIMAL(36) and less,
+// which covers the most common precisions. The coefficients of such decimals always fit in 128 bits since
+// 10^36 < 2^128, so DecimalMul can multiply them at once instead of word by word, and Round can do the rounding with
+// a single division if the coefficient fits in uint64.
+//
+// The fast paths must produce exactly the same MyDecimal as the word-by-word implementation, including the layout of
+// the words, digitsInt and digitsFrac, so they mirror how the layout is decided there and only replace the
+// arithmetic. TestDecimalFastPathCompatibility checks it exhaustively.
+//
+// DecimalAdd, DecimalSub and Compare don't have fast paths, they only touch each word once and are as fast as the
+// fixed-size representation for such small decimals.
+const fastPathMaxWords = 4
+
+// uint128 is an unsigned 128-bit integer.
+type uint128 struct {
+	hi, lo uint64
+}
+
+// wordBasePowers[i] is wordBase^i, i.e. the upper bound of the coefficient of i words.
+var wordBasePowers = [fastPathMaxWords + 1]uint128{
+	{0, 1},
+	{0, 1e9},
+	{0, 1e18},
+	{0x33b2e3c, 0x9fd0803ce8000000},        // 1e27
+	{0xc097ce7bc90715, 0xb34b9f1000000000}, // 1e36
+}
+
+// pow10Uint64[i] is 10^i.
+var pow10Uint64 = [19]uint64{
+	1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18,
+}
+
+// mulAdd returns u*m+a, the caller makes sure that it doesn't overflow.
+func (u uint128) mulAdd(m, a uint64) uint128 {
+	hi, lo := bits.Mul64(u.lo, m)
+	var carry uint64
+	lo, carry = bits.Add64(lo, a, 0)
+	return uint128{hi: u.hi*m + hi + carry, lo: lo}
+}
+
+func (u uint128) cmp(v uint128) int {
+	switch {
+	case u.hi < v.hi:
+		return -1
+	case u.hi > v.hi:
+		return 1
+	case u.lo < v.lo:
+		return -1
+	case u.lo > v.lo:
+		return 1
+	}
+	return 0
+}
+
+func (u uint128) isZero() bool {
+	return u.hi == 0 && u.lo == 0
+}
+
+// mul returns u*v, the caller makes sure that it doesn't overflow.
+func (u uint128) mul(v uint128) uint128 {
+	hi, lo := bits.Mul64(u.lo, v.lo)
+	hi += u.hi*v.lo + u.lo*v.hi
+	return uint128{hi: hi, lo: lo}
+}
+
+// coefficient returns the magnitude of the words [0, n) as an integer.
+func (d *MyDecimal) coefficient(n int) uint128 {
+	// The first 2 words always fit in uint64.
+	var lo uint64
+	i := 0
+	for ; i < n && i < 2; i++ {
+		lo = lo*wordBase + uint64(d.wordBuf[i])
+	}
+	c := uint128{lo: lo}
+	for ; i < n; i++ {
+		c = c.mulAdd(wordBase, uint64(d.wordBuf[i]))
+	}
+	return c
+}
+
+// setWords sets the words [0, n) from the coefficient, the caller makes sure that c < wordBase^n.
+func (d *MyDecimal) setWords(c uint128, n int) {
+	lo, start := c.lo, 0
+	if c.hi != 0 {
+		// Split the coefficient by wordBase^2 to avoid dividing the 128-bit integer word by word, the quotient always
+		// fits in uint64 since c < wordBase^4.
+		var hi uint64
+		hi, lo = bits.Div64(c.hi, c.lo, wordBase*wordBase)
+		start = n - 2
+		for i := start - 1; i >= 0; i-- {
+			q := hi / wordBase
+			d.wordBuf[i] = int32(hi - q*wordBase)
+			hi = q
+		}
+	}
+	for i := n - 1; i >= start; i-- {
+		q := lo / wordBase
+		d.wordBuf[i] = int32(lo - q*wordBase)
+		lo = q
+	}
+}
+
+// decimalMulFast is the fast path of DecimalMul, it returns false if the decimals are not supported by the fast path.
+func decimalMulFast(from1, from2, to *MyDecimal) bool {
+	wordsInt1, wordsFrac1 := digitsToWords(int(from1.digitsInt)), digitsToWords(int(from1.digitsFrac))
+	wordsInt2, wordsFrac2 := digitsToWords(int(from2.digitsInt)), digitsToWords(int(from2.digitsFrac))
+	if wordsInt1+wordsFrac1+wordsInt2+wordsFrac2 > fastPathMaxWords || from1.digitsFrac+from2.digitsFrac > notFixedDec {
+		return false
+	}
+	wordsIntTo := digitsToWords(int(from1.digitsInt) + int(from2.digitsInt))
+	wordsFracTo := wordsFrac1 + wordsFrac2
+	product := from1.coefficient(wordsInt1 + wordsFrac1).mul(from2.coefficient(wordsInt2 + wordsFrac2))
+	if product.cmp(wordBasePowers[wordsIntTo+wordsFracTo]) >= 0 {
+		// The digitsInt doesn't match the words, let DecimalMul report the error.
+		return false
+	}
+	to.resultFrac = min(from1.resultFrac+from2.resultFrac, mysql.MaxDecimalScale)
+	to.negative = from1.negative != from2.negative
+	to.digitsFrac = from1.digitsFrac + from2.digitsFrac
+	to.digitsInt = int8(wordsIntTo * digitsPerWord)
+	if product.isZero() && to.negative {
+		*to = zeroMyDecimalWithFrac(to.resultFrac)
+		return true
+	}
+	to.setWords(product, wordsIntTo+wordsFracTo)
+	to.removeLeadingZeroWords(wordsIntTo)
+	return true
+}
+
+// roundFast is the fast path of Round for the decimals with at most 2 words, whose coefficients fit in uint64, it
+// returns false if the arguments are not supported by the fast path.
+func (d *MyDecimal) roundFast(to *MyDecimal, frac int, roundMode RoundMode) bool {
+	if roundMode == ModeCeiling || frac < 0 || frac >= int(d.digitsFrac) {
+		return false
+	}
+	wordsInt, wordsFrac := digitsToWords(int(d.digitsInt)), digitsToWords(int(d.digitsFrac))
+	if wordsInt == 0 || wordsInt+wordsFrac > 2 {
+		return false
+	}
+	wordsFracTo := digitsToWords(frac)
+	c := uint64(d.wordBuf[0])
+	if wordsInt+wordsFrac == 2 {
+		c = c*wordBase + uint64(d.wordBuf[1])
+	}
+	unit := pow10Uint64[wordsFrac*digitsPerWord-frac]
+	q, r := c/unit, c%unit
+	inc := roundMode == ModeHalfUp && r >= unit/2
+	if inc {
+		q++
+	}
+	rounded := q * pow10Uint64[wordsFracTo*digitsPerWord-frac]
+	n := wordsInt + wordsFracTo
+
+	if to != d {
+		to.wordBuf = d.wordBuf
+		to.negative = d.negative
+		to.digitsInt = int8(wordsInt * digitsPerWord)
+	}
+	if wordsFracTo < wordsFrac {
+		for i := n; i < wordBufLen; i++ {
+			to.wordBuf[i] = 0
+		}
+	}
+	to.digitsFrac = int8(frac)
+	to.resultFrac = to.digitsFrac
+	if rounded >= pow10Uint64[n*digitsPerWord] {
+		// The carry goes beyond the first word, e.g. 999.9 -> 1000.
+		for i := n; i > 0; i-- {
+			to.wordBuf[i] = 0
+		}
+		to.wordBuf[0] = 1
+		to.digitsInt++
+		return true
+	}
+	for i := n - 1; i >= 0; i-- {
+		to.wordBuf[i] = int32(rounded % wordBase)
+		rounded /= wordBase
+	}
+	// The same as Round, find the last word which is not zero, it's where the carry stops if there is a carry.
+	toIdx := n - 1
+	for to.wordBuf[toIdx] == 0 {
+		if toIdx == 0 {
+			// making 'zero' with the proper scale
+			to.digitsInt = 1
+			to.negative = false
+			return true
+		}
+		toIdx--
+	}
+	if firstDig := mod9[to.digitsInt]; firstDig > 0 && to.wordBuf[toIdx] >= powers10[firstDig] {
+		to.digitsInt++
+	}
+	return true
+}
+
+// FromInts converts the integers to decimals in batch, it's the vectorized version of FromInt and FromUint.
+func FromInts(src []int64, unsigned bool, dst []MyDecimal) {
+	for i, v := range src {
+		d := &dst[i]
+		*d = MyDecimal{}
+		u := uint64(v)
+		if !unsigned && v < 0 {
+			d.negative = true
+			u = -u
+		}
+		// Most integers are less than wordBase, which only take one word.
+		if u < wordBase {
+			d.digitsInt = digitsPerWord
+			d.wordBuf[0] = int32(u)
+			continue
+		}
+		d.FromUint(u)
+	}
+}
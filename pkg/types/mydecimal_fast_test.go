@@ -0,0 +1,190 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUint128(t *testing.T) {
+	for i := 0; i <= fastPathMaxWords; i++ {
+		c := uint128{lo: 1}
+		for j := 0; j < i; j++ {
+			c = c.mulAdd(wordBase, 0)
+		}
+		require.Equal(t, wordBasePowers[i], c)
+	}
+	// 999999999999999999999999999999999999
+	max36 := uint128{}
+	for i := 0; i < fastPathMaxWords; i++ {
+		max36 = max36.mulAdd(wordBase, wordMax)
+	}
+	require.Equal(t, -1, max36.cmp(wordBasePowers[fastPathMaxWords]))
+	nines := uint128{}
+	for n := 1; n <= fastPathMaxWords; n++ {
+		nines = nines.mulAdd(wordBase, wordMax)
+		var d MyDecimal
+		d.setWords(nines, n)
+		for i := 0; i < n; i++ {
+			require.Equal(t, int32(wordMax), d.wordBuf[i])
+		}
+		require.Equal(t, nines, d.coefficient(n))
+	}
+	c := uint128{lo: 999999999999999999}.mulAdd(wordBase, wordMax)
+	require.Equal(t, max36, uint128{lo: 999999999999999999}.mul(wordBasePowers[2]).mulAdd(1, 999999999999999999))
+	require.Equal(t, 1, max36.cmp(c))
+	require.Equal(t, -1, c.cmp(max36))
+	require.Equal(t, 0, c.cmp(c))
+}
+
+// genDecimalsForFastPath generates the decimals to check the fast paths of MyDecimal, including the edge cases like
+// the words full of 9 and the decimals which have leading zero words.
+func genDecimalsForFastPath(rnd *rand.Rand, n int) []MyDecimal {
+	edges := []string{
+		"0", "-0", "0.0", "0.000000000", "1", "-1", "0.5", "-0.5", "0.000000001", "999999999", "-999999999",
+		"1000000000", "999999999.999999999", "-999999999.999999999", "0.999999999", "999999999999999999",
+		"1000000000000000000", "999999999999999999999999999", "999999999999999999999999999999999999",
+		"0.999999999999999999999999999999999999", "12345678.9", "99.9999", "-99.99995", "1.5", "2.5", "-2.5",
+		"123456789012345678.123456789012345678", "0.000000000000000000000000000001", "4.99999999", "5.000000001",
+	}
+	decs := make([]MyDecimal, 0, n+len(edges)*2)
+	for _, s := range edges {
+		var d MyDecimal
+		if err := d.FromString([]byte(s)); err != nil {
+			panic(err)
+		}
+		decs = append(decs, d)
+	}
+	for len(decs) < n {
+		intDigits := rnd.Intn(38)
+		fracDigits := rnd.Intn(39 - max(intDigits, 1))
+		var sb strings.Builder
+		if rnd.Intn(2) == 0 {
+			sb.WriteByte('-')
+		}
+		genDigits := func(cnt int) {
+			for i := 0; i < cnt; i++ {
+				switch rnd.Intn(4) {
+				case 0:
+					sb.WriteByte('9')
+				case 1:
+					sb.WriteByte('0')
+				default:
+					sb.WriteByte(byte('0' + rnd.Intn(10)))
+				}
+			}
+		}
+		if intDigits == 0 {
+			sb.WriteByte('0')
+		}
+		genDigits(intDigits)
+		if fracDigits > 0 {
+			sb.WriteByte('.')
+			genDigits(fracDigits)
+		}
+		var d MyDecimal
+		if err := d.FromString([]byte(sb.String())); err != nil {
+			panic(err)
+		}
+		decs = append(decs, d)
+	}
+	// The results of subtraction may have leading zero words, e.g. 1000000000 - 999999999.
+	for i := 0; i+1 < len(edges); i++ {
+		var d MyDecimal
+		if err := DecimalSub(&decs[i], &decs[i+1], &d); err == nil {
+			decs = append(decs, d)
+		}
+	}
+	return decs
+}
+
+func TestDecimalFastPathCompatibility(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("seed: %d", seed)
+	rnd := rand.New(rand.NewSource(seed))
+	decs := genDecimalsForFastPath(rnd, 400)
+
+	var mulHits, roundHits int
+	for i := range decs {
+		for j := range decs {
+			a, b := &decs[i], &decs[j]
+			msg := a.String() + ", " + b.String()
+			var expected, actual MyDecimal
+			expectedErr := doMul(a, b, &expected)
+			err := DecimalMul(a, b, &actual)
+			require.Equal(t, expectedErr, err, msg)
+			require.Equal(t, expected, actual, msg)
+			var tmp MyDecimal
+			if decimalMulFast(a, b, &tmp) {
+				mulHits++
+			}
+		}
+
+		// Round
+		d := &decs[i]
+		for frac := -2; frac <= int(d.digitsFrac)+1; frac++ {
+			for _, mode := range []RoundMode{ModeHalfUp, ModeTruncate} {
+				msg := fmt.Sprintf("%s, %d, %d", d.String(), frac, mode)
+				var expected, actual MyDecimal
+				expectedErr := d.doRound(&expected, frac, mode)
+				err := d.Round(&actual, frac, mode)
+				require.Equal(t, expectedErr, err, msg)
+				require.Equal(t, expected, actual, msg)
+
+				// Round in place.
+				expected, actual = *d, *d
+				expectedErr = expected.doRound(&expected, frac, mode)
+				err = actual.Round(&actual, frac, mode)
+				require.Equal(t, expectedErr, err, msg)
+				require.Equal(t, expected, actual, msg)
+
+				var tmp MyDecimal
+				if d.roundFast(&tmp, frac, mode) {
+					roundHits++
+				}
+			}
+		}
+	}
+	require.Greater(t, mulHits, 0)
+	require.Greater(t, roundHits, 0)
+}
+
+func TestFromInts(t *testing.T) {
+	ints := []int64{0, 1, -1, 999999999, -999999999, 1000000000, -1000000000, math.MaxInt64, math.MinInt64, 123456789012}
+	decs := make([]MyDecimal, len(ints))
+	for _, unsigned := range []bool{false, true} {
+		// Make sure the dirty decimals are reset.
+		for i := range decs {
+			decs[i] = *NewDecFromStringForTest("-123.456")
+		}
+		FromInts(ints, unsigned, decs)
+		for i, v := range ints {
+			var expected MyDecimal
+			if unsigned {
+				expected.FromUint(uint64(v))
+			} else {
+				expected.FromInt(v)
+			}
+			require.Equal(t, expected, decs[i])
+		}
+	}
+}
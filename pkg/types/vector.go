@@ -0,0 +1,129 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MaxVectorDimension is the max dimension of a VECTOR column.
+const MaxVectorDimension = 16383
+
+// VectorFloat32 is a vector of float32 values.
+// The values of VECTOR columns are stored and evaluated in the text format like '[1,2.5,3]',
+// VectorFloat32 is parsed from the text when the values are computed.
+type VectorFloat32 []float32
+
+// ParseVectorFloat32 parses a vector from the text format like '[1,2.5,3]'.
+func ParseVectorFloat32(s string) (VectorFloat32, error) {
+	text := strings.TrimSpace(s)
+	if len(text) < 2 || text[0] != '[' || text[len(text)-1] != ']' {
+		return nil, ErrInvalidVectorValue.GenWithStackByArgs(s)
+	}
+	text = strings.TrimSpace(text[1 : len(text)-1])
+	if len(text) == 0 {
+		return VectorFloat32{}, nil
+	}
+	elems := strings.Split(text, ",")
+	v := make(VectorFloat32, 0, len(elems))
+	for _, elem := range elems {
+		f, err := strconv.ParseFloat(strings.TrimSpace(elem), 32)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, ErrInvalidVectorValue.GenWithStackByArgs(s)
+		}
+		v = append(v, float32(f))
+	}
+	return v, nil
+}
+
+// String returns the text format of the vector.
+func (v VectorFloat32) String() string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, f := range v {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatFloat(float64(f), 'g', -1, 32))
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// Len returns the dimension of the vector.
+func (v VectorFloat32) Len() int {
+	return len(v)
+}
+
+// CheckDimsFitColumn checks whether the vector fits the VECTOR(dims) column,
+// the dimension of a VECTOR column without the length is not restricted.
+func (v VectorFloat32) CheckDimsFitColumn(dims int) error {
+	if dims != UnspecifiedLength && len(v) != dims {
+		return ErrVectorDimensionMismatch.GenWithStackByArgs(dims, len(v))
+	}
+	return nil
+}
+
+func (v VectorFloat32) checkDimsFit(b VectorFloat32) error {
+	if len(v) != len(b) {
+		return ErrVectorDimensionMismatch.GenWithStackByArgs(len(v), len(b))
+	}
+	return nil
+}
+
+// L2Norm returns the euclidean norm of the vector.
+func (v VectorFloat32) L2Norm() float64 {
+	var sum float64
+	for _, f := range v {
+		sum += float64(f) * float64(f)
+	}
+	return math.Sqrt(sum)
+}
+
+// L2Distance returns the euclidean distance between two vectors.
+func (v VectorFloat32) L2Distance(b VectorFloat32) (float64, error) {
+	if err := v.checkDimsFit(b); err != nil {
+		return 0, err
+	}
+	var sum float64
+	for i := range v {
+		diff := float64(v[i]) - float64(b[i])
+		sum += diff * diff
+	}
+	return math.Sqrt(sum), nil
+}
+
+// CosineDistance returns the cosine distance between two vectors, which is 1 - cosine similarity.
+// It returns NaN if any of the vectors is a zero vector.
+func (v VectorFloat32) CosineDistance(b VectorFloat32) (float64, error) {
+	if err := v.checkDimsFit(b); err != nil {
+		return 0, err
+	}
+	var dot, normA, normB float64
+	for i := range v {
+		dot += float64(v[i]) * float64(b[i])
+		normA += float64(v[i]) * float64(v[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return math.NaN(), nil
+	}
+	similarity := dot / math.Sqrt(normA*normB)
+	// Clamp the similarity to avoid the precision issues.
+	similarity = math.Max(-1, math.Min(1, similarity))
+	return 1 - similarity, nil
+}
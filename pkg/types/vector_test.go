@@ -0,0 +1,91 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVectorFloat32(t *testing.T) {
+	tests := []struct {
+		text   string
+		result string
+	}{
+		{"[]", "[]"},
+		{" [ 1, 2.5 ,-3e2 ] ", "[1,2.5,-300]"},
+		{"[0.1]", "[0.1]"},
+	}
+	for _, tt := range tests {
+		v, err := ParseVectorFloat32(tt.text)
+		require.NoError(t, err, tt.text)
+		require.Equal(t, tt.result, v.String())
+	}
+	for _, text := range []string{"", "1,2", "[1,2", "[1,,2]", "[a]", "[NaN]", "[1e100]"} {
+		_, err := ParseVectorFloat32(text)
+		require.True(t, ErrInvalidVectorValue.Equal(err), text)
+	}
+}
+
+func TestVectorDistance(t *testing.T) {
+	a, err := ParseVectorFloat32("[1,2,3]")
+	require.NoError(t, err)
+	b, err := ParseVectorFloat32("[4,6,3]")
+	require.NoError(t, err)
+
+	d, err := a.L2Distance(b)
+	require.NoError(t, err)
+	require.Equal(t, float64(5), d)
+	d, err = a.CosineDistance(a)
+	require.NoError(t, err)
+	require.InDelta(t, 0, d, 1e-9)
+	d, err = a.CosineDistance(VectorFloat32{-1, -2, -3})
+	require.NoError(t, err)
+	require.InDelta(t, 2, d, 1e-9)
+	d, err = a.CosineDistance(VectorFloat32{0, 0, 0})
+	require.NoError(t, err)
+	require.True(t, math.IsNaN(d))
+	require.InDelta(t, math.Sqrt(14), a.L2Norm(), 1e-9)
+
+	_, err = a.L2Distance(VectorFloat32{1})
+	require.True(t, ErrVectorDimensionMismatch.Equal(err))
+	_, err = a.CosineDistance(VectorFloat32{1})
+	require.True(t, ErrVectorDimensionMismatch.Equal(err))
+	require.NoError(t, a.CheckDimsFitColumn(3))
+	require.NoError(t, a.CheckDimsFitColumn(UnspecifiedLength))
+	require.True(t, ErrVectorDimensionMismatch.Equal(a.CheckDimsFitColumn(2)))
+}
+
+func TestConvertToVectorFloat32(t *testing.T) {
+	ft := NewFieldType(mysql.TypeTiDBVectorFloat32)
+	ft.SetFlen(2)
+	d := NewStringDatum(" [1.0, 2] ")
+	d, err := d.ConvertTo(DefaultStmtNoWarningContext, ft)
+	require.NoError(t, err)
+	require.Equal(t, "[1,2]", d.GetString())
+
+	d = NewStringDatum("[1,2,3]")
+	_, err = d.ConvertTo(DefaultStmtNoWarningContext, ft)
+	require.True(t, ErrVectorDimensionMismatch.Equal(err))
+	d = NewIntDatum(1)
+	_, err = d.ConvertTo(DefaultStmtNoWarningContext, ft)
+	require.True(t, ErrInvalidVectorValue.Equal(err))
+
+	require.Equal(t, mysql.TypeTiDBVectorFloat32, MergeFieldType(mysql.TypeTiDBVectorFloat32, mysql.TypeTiDBVectorFloat32))
+	require.Equal(t, mysql.TypeLongBlob, MergeFieldType(mysql.TypeTiDBVectorFloat32, mysql.TypeLong))
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit implements the built-in audit log. Statements matching the
+// audit rules are written to a dedicated file asynchronously.
+package audit
+
+import (
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser"
+	"go.uber.org/atomic"
+)
+
+const defaultQueueSize = 10240
+
+// Config is the static configuration of the audit log. It cannot be
+// modified at runtime.
+type Config struct {
+	Filename       string
+	FileMaxSize    int
+	FileMaxDays    int
+	FileMaxBackups int
+	// QueueSize is the max number of entries waiting to be written.
+	QueueSize int
+}
+
+var (
+	enabled = atomic.NewBool(false)
+	redact  = atomic.NewBool(true)
+	rules   atomic.Pointer[[]Rule]
+
+	// loggerMu protects logger from being closed while logging.
+	loggerMu sync.RWMutex
+	logger   *asyncLogger
+)
+
+// Setup initializes the audit logger. The audit log is not written until
+// it is enabled by SetEnabled.
+func Setup(cfg *Config) error {
+	if cfg.Filename == "" {
+		return errors.New("audit: empty filename")
+	}
+	l := newFileLogger(cfg)
+	loggerMu.Lock()
+	old := logger
+	logger = l
+	loggerMu.Unlock()
+	if old != nil {
+		old.close()
+	}
+	return nil
+}
+
+// Close flushes and closes the audit logger.
+func Close() {
+	loggerMu.Lock()
+	l := logger
+	logger = nil
+	loggerMu.Unlock()
+	if l != nil {
+		l.close()
+	}
+}
+
+// SetEnabled enables or disables the audit log.
+func SetEnabled(v bool) error {
+	enabled.Store(v)
+	return nil
+}
+
+// Enabled returns whether the audit log is enabled.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// SetRedact sets whether the literals in the statements are redacted.
+func SetRedact(v bool) error {
+	redact.Store(v)
+	return nil
+}
+
+// SetRules parses and replaces the audit rules.
+func SetRules(s string) error {
+	r, err := ParseRules(s)
+	if err != nil {
+		return err
+	}
+	rules.Store(&r)
+	return nil
+}
+
+// GetRules returns the current audit rules.
+func GetRules() []Rule {
+	if r := rules.Load(); r != nil {
+		return *r
+	}
+	return nil
+}
+
+// ShouldLog returns whether a statement should be written to the audit log.
+// All statements are audited if there are no rules.
+func ShouldLog(user string, dbs []string, stmtClass string) bool {
+	if !enabled.Load() {
+		return false
+	}
+	r := GetRules()
+	if len(r) == 0 {
+		return true
+	}
+	for i := range r {
+		if r[i].Match(user, dbs, stmtClass) {
+			return true
+		}
+	}
+	return false
+}
+
+// Log writes the entry to the audit log asynchronously. The query is redacted
+// if redaction is enabled. It returns false if the entry is dropped.
+func Log(e *Entry) bool {
+	if redact.Load() {
+		e.Query = parser.Normalize(e.Query)
+	}
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+	if logger == nil {
+		return false
+	}
+	return logger.log(e)
+}
+
+// DroppedCount returns the number of entries dropped because the queue is full.
+func DroppedCount() uint64 {
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+	if logger == nil {
+		return 0
+	}
+	return logger.dropped.Load()
+}
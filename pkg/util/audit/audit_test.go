@@ -0,0 +1,152 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser"
+	_ "github.com/pingcap/tidb/pkg/types/parser_driver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRules(t *testing.T) {
+	r, err := ParseRules("")
+	require.NoError(t, err)
+	require.Empty(t, r)
+
+	r, err = ParseRules(`[{"user":"root","db":"Test","class":"ddl"},{"class":"dml"},{}]`)
+	require.NoError(t, err)
+	require.Equal(t, []Rule{
+		{User: "root", DB: "test", StmtClass: StmtClassDDL},
+		{User: "*", DB: "*", StmtClass: StmtClassDML},
+		{User: "*", DB: "*", StmtClass: "*"},
+	}, r)
+
+	_, err = ParseRules(`[{"class":"unknown"}]`)
+	require.ErrorContains(t, err, "invalid statement class 'UNKNOWN'")
+	_, err = ParseRules(`{"class":"ddl"}`)
+	require.ErrorContains(t, err, "invalid audit log rules")
+}
+
+func TestRuleMatch(t *testing.T) {
+	r := Rule{User: "root", DB: "test", StmtClass: StmtClassDDL}
+	require.True(t, r.Match("root", []string{"mysql", "TEST"}, StmtClassDDL))
+	require.False(t, r.Match("u1", []string{"test"}, StmtClassDDL))
+	require.False(t, r.Match("root", []string{"mysql"}, StmtClassDDL))
+	require.False(t, r.Match("root", []string{"test"}, StmtClassDML))
+	r = Rule{User: "*", DB: "*", StmtClass: "*"}
+	require.True(t, r.Match("u1", nil, StmtClassOther))
+}
+
+func TestClassifyStmt(t *testing.T) {
+	p := parser.New()
+	cases := map[string]string{
+		"select * from t":                StmtClassQuery,
+		"show tables":                    StmtClassQuery,
+		"insert into t values (1)":       StmtClassDML,
+		"delete from t":                  StmtClassDML,
+		"create table t (a int)":         StmtClassDDL,
+		"grant select on *.* to u1":      StmtClassDCL,
+		"create user u1":                 StmtClassDCL,
+		"set password for u1 = 'secret'": StmtClassDCL,
+		"set @a = 1":                     StmtClassOther,
+	}
+	for sql, class := range cases {
+		stmt, err := p.ParseOneStmt(sql, "", "")
+		require.NoError(t, err)
+		require.Equal(t, class, ClassifyStmt(stmt), sql)
+	}
+}
+
+func TestShouldLog(t *testing.T) {
+	defer func() {
+		require.NoError(t, SetEnabled(false))
+		require.NoError(t, SetRules(""))
+	}()
+	require.False(t, ShouldLog("root", nil, StmtClassDDL))
+	require.NoError(t, SetEnabled(true))
+	require.True(t, ShouldLog("root", nil, StmtClassDDL))
+	require.NoError(t, SetRules(`[{"user":"root","class":"DDL"},{"db":"audit"}]`))
+	require.Len(t, GetRules(), 2)
+	require.True(t, ShouldLog("root", nil, StmtClassDDL))
+	require.False(t, ShouldLog("root", nil, StmtClassDML))
+	require.True(t, ShouldLog("u1", []string{"audit"}, StmtClassDML))
+	require.Error(t, SetRules(`[{"class":"unknown"}]`))
+	require.Len(t, GetRules(), 2)
+}
+
+func TestLogToFile(t *testing.T) {
+	defer func() {
+		require.NoError(t, SetRedact(true))
+	}()
+	filename := filepath.Join(t.TempDir(), "tidb-audit.log")
+	require.NoError(t, Setup(&Config{Filename: filename}))
+	require.True(t, Log(&Entry{User: "root", StmtClass: StmtClassDML, Query: "insert into t values (1, 'secret')", Succ: true}))
+	require.NoError(t, SetRedact(false))
+	require.True(t, Log(&Entry{User: "root", StmtClass: StmtClassQuery, Query: "select 1"}))
+	Close()
+	require.False(t, Log(&Entry{Query: "select 1"}))
+
+	content, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+	var e Entry
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &e))
+	require.Equal(t, "root", e.User)
+	require.Equal(t, StmtClassDML, e.StmtClass)
+	require.True(t, e.Succ)
+	require.Equal(t, "insert into `t` values ( ... )", e.Query)
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &e))
+	require.Equal(t, "select 1", e.Query)
+}
+
+type blockingWriter struct {
+	bytes.Buffer
+	wg sync.WaitGroup
+}
+
+func (w *blockingWriter) Write(b []byte) (int, error) {
+	w.wg.Wait()
+	return w.Buffer.Write(b)
+}
+
+func (*blockingWriter) Close() error { return nil }
+
+func TestAsyncLoggerBoundedQueue(t *testing.T) {
+	w := &blockingWriter{}
+	w.wg.Add(1)
+	l := newAsyncLogger(w, 1)
+	// The first entry may be taken by the writer goroutine, so at most two
+	// entries are accepted before the queue is full.
+	accepted := 0
+	for i := 0; i < 5; i++ {
+		if l.log(&Entry{Query: "select 1"}) {
+			accepted++
+		}
+	}
+	require.LessOrEqual(t, accepted, 2)
+	require.Equal(t, uint64(5-accepted), l.dropped.Load())
+	w.wg.Done()
+	l.close()
+	require.Equal(t, accepted, strings.Count(w.String(), "\n"))
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Entry is a record in the audit log.
+type Entry struct {
+	Time      time.Time `json:"time"`
+	ConnID    uint64    `json:"conn_id"`
+	User      string    `json:"user"`
+	Host      string    `json:"host"`
+	DB        string    `json:"db"`
+	Tables    []string  `json:"tables,omitempty"`
+	StmtClass string    `json:"class"`
+	Query     string    `json:"query"`
+	Succ      bool      `json:"succ"`
+	Error     string    `json:"error,omitempty"`
+	CostTime  float64   `json:"cost_time"`
+	// Event, Privileges, Level and Grantees describe the privileges changed by GRANT or REVOKE.
+	Event      string   `json:"event,omitempty"`
+	Privileges []string `json:"privileges,omitempty"`
+	Level      string   `json:"level,omitempty"`
+	Grantees   []string `json:"grantees,omitempty"`
+}
+
+// asyncLogger writes the entries to w in a background goroutine. The entries
+// are dropped if the queue is full, so the auditing never blocks the statements.
+type asyncLogger struct {
+	w       io.WriteCloser
+	queue   chan *Entry
+	dropped atomic.Uint64
+	wg      sync.WaitGroup
+}
+
+func newAsyncLogger(w io.WriteCloser, queueSize int) *asyncLogger {
+	l := &asyncLogger{
+		w:     w,
+		queue: make(chan *Entry, queueSize),
+	}
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		l.run()
+	}()
+	return l
+}
+
+func newFileLogger(cfg *Config) *asyncLogger {
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	return newAsyncLogger(&lumberjack.Logger{
+		Filename:   cfg.Filename,
+		MaxSize:    cfg.FileMaxSize,
+		MaxAge:     cfg.FileMaxDays,
+		MaxBackups: cfg.FileMaxBackups,
+		LocalTime:  true,
+	}, queueSize)
+}
+
+// log enqueues the entry. It returns false if the entry is dropped.
+func (l *asyncLogger) log(e *Entry) bool {
+	select {
+	case l.queue <- e:
+		return true
+	default:
+		l.dropped.Inc()
+		metrics.AuditLogDroppedCounter.Inc()
+		return false
+	}
+}
+
+func (l *asyncLogger) run() {
+	buf := make([]byte, 0, 1024)
+	for e := range l.queue {
+		b, err := json.Marshal(e)
+		if err != nil {
+			logutil.BgLogger().Warn("failed to marshal audit log", zap.Error(err))
+			continue
+		}
+		buf = append(append(buf[:0], b...), '\n')
+		if _, err = l.w.Write(buf); err != nil {
+			logutil.BgLogger().Warn("failed to write audit log", zap.Error(err))
+		}
+	}
+}
+
+// close flushes the pending entries and closes the underlying writer.
+func (l *asyncLogger) close() {
+	close(l.queue)
+	l.wg.Wait()
+	if err := l.w.Close(); err != nil {
+		logutil.BgLogger().Warn("failed to close audit log", zap.Error(err))
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+)
+
+// Statement classes used by audit rules.
+const (
+	// StmtClassAll matches all the statement classes.
+	StmtClassAll = "*"
+	// StmtClassQuery is the class of read only statements, such as SELECT and SHOW.
+	StmtClassQuery = "QUERY"
+	// StmtClassDML is the class of statements modifying data.
+	StmtClassDML = "DML"
+	// StmtClassDDL is the class of statements modifying schema.
+	StmtClassDDL = "DDL"
+	// StmtClassDCL is the class of statements managing users and privileges.
+	StmtClassDCL = "DCL"
+	// StmtClassOther is the class of all other statements.
+	StmtClassOther = "OTHER"
+)
+
+var validStmtClasses = map[string]struct{}{
+	StmtClassAll:   {},
+	StmtClassQuery: {},
+	StmtClassDML:   {},
+	StmtClassDDL:   {},
+	StmtClassDCL:   {},
+	StmtClassOther: {},
+}
+
+// Rule decides which statements are written to the audit log. An empty
+// field or "*" matches everything.
+type Rule struct {
+	User      string `json:"user"`
+	DB        string `json:"db"`
+	StmtClass string `json:"class"`
+}
+
+// ParseRules parses the rules from a JSON array, such as
+// `[{"user":"root","db":"*","class":"DDL"}]`. An empty string means no rules.
+func ParseRules(s string) ([]Rule, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var rules []Rule
+	if err := json.Unmarshal([]byte(s), &rules); err != nil {
+		return nil, errors.Annotate(err, "invalid audit log rules")
+	}
+	for i := range rules {
+		r := &rules[i]
+		r.User = normalizeWildcard(r.User)
+		r.DB = strings.ToLower(normalizeWildcard(r.DB))
+		r.StmtClass = strings.ToUpper(normalizeWildcard(r.StmtClass))
+		if _, ok := validStmtClasses[r.StmtClass]; !ok {
+			return nil, errors.Errorf("invalid statement class '%s' in audit log rules", r.StmtClass)
+		}
+	}
+	return rules, nil
+}
+
+func normalizeWildcard(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return StmtClassAll
+	}
+	return s
+}
+
+// Match returns whether the rule matches the statement.
+func (r *Rule) Match(user string, dbs []string, stmtClass string) bool {
+	if r.User != StmtClassAll && r.User != user {
+		return false
+	}
+	if r.StmtClass != StmtClassAll && r.StmtClass != stmtClass {
+		return false
+	}
+	if r.DB == StmtClassAll {
+		return true
+	}
+	for _, db := range dbs {
+		if strings.ToLower(db) == r.DB {
+			return true
+		}
+	}
+	return false
+}
+
+// ClassifyStmt returns the statement class of the statement.
+func ClassifyStmt(stmt ast.StmtNode) string {
+	switch stmt.(type) {
+	case *ast.SelectStmt, *ast.SetOprStmt, *ast.ShowStmt, *ast.ExplainStmt:
+		return StmtClassQuery
+	case *ast.GrantStmt, *ast.GrantRoleStmt, *ast.RevokeStmt, *ast.RevokeRoleStmt,
+		*ast.CreateUserStmt, *ast.AlterUserStmt, *ast.DropUserStmt, *ast.RenameUserStmt, *ast.SetPwdStmt,
+		*ast.SetRoleStmt, *ast.SetDefaultRoleStmt:
+		return StmtClassDCL
+	case ast.DDLNode:
+		return StmtClassDDL
+	case ast.DMLNode:
+		return StmtClassDML
+	}
+	return StmtClassOther
+}
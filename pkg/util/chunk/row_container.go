@@ -207,6 +207,13 @@ func (c *RowContainer) alreadySpilled() bool {
 	return c.m.records.inDisk != nil
 }
 
+// AlreadySpilled indicates that records have spilled out into disk. It's thread-safe.
+func (c *RowContainer) AlreadySpilled() bool {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	return c.alreadySpilled()
+}
+
 // AlreadySpilledSafeForTest indicates that records have spilled out into disk. It's thread-safe.
 // The function is only used for test.
 func (c *RowContainer) AlreadySpilledSafeForTest() bool {
@@ -331,7 +331,7 @@ func IsDefaultCollationForUTF8MB4(collate string) bool {
 func IsCICollation(collate string) bool {
 	return collate == "utf8_general_ci" || collate == "utf8mb4_general_ci" ||
 		collate == "utf8_unicode_ci" || collate == "utf8mb4_unicode_ci" || collate == "gbk_chinese_ci" ||
-		collate == "utf8mb4_0900_ai_ci"
+		collate == "gb18030_chinese_ci" || collate == "utf8mb4_0900_ai_ci"
 }
 
 // ConvertAndGetBinCollation converts collator to binary collator
@@ -349,6 +349,8 @@ func ConvertAndGetBinCollation(collate string) Collator {
 		return GetCollator("utf8mb4_bin")
 	case "gbk_chinese_ci":
 		return GetCollator("gbk_bin")
+	case "gb18030_chinese_ci":
+		return GetCollator("gb18030_bin")
 	}
 	return GetCollator(collate)
 }
@@ -428,4 +430,8 @@ func init() {
 	newCollatorIDMap[CollationName2ID(charset.CollationGBKBin)] = &gbkBinCollator{charset.NewCustomGBKEncoder()}
 	newCollatorMap[charset.CollationGBKChineseCI] = &gbkChineseCICollator{}
 	newCollatorIDMap[CollationName2ID(charset.CollationGBKChineseCI)] = &gbkChineseCICollator{}
+	newCollatorMap[charset.CollationGB18030Bin] = newGB18030BinCollator()
+	newCollatorIDMap[CollationName2ID(charset.CollationGB18030Bin)] = newGB18030BinCollator()
+	newCollatorMap[charset.CollationGB18030ChineseCI] = newGB18030ChineseCICollator()
+	newCollatorIDMap[CollationName2ID(charset.CollationGB18030ChineseCI)] = newGB18030ChineseCICollator()
 }
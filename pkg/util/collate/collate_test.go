@@ -217,3 +217,37 @@ func TestGetCollator(t *testing.T) {
 	require.IsType(t, &gbkBinCollator{}, GetCollator("gbk_bin"))
 	require.IsType(t, &gbkBinCollator{}, GetCollatorByID(87))
 }
+
+func TestGB18030Collator(t *testing.T) {
+	SetNewCollationEnabledForTest(true)
+	defer SetNewCollationEnabledForTest(false)
+	require.IsType(t, &gb18030BinCollator{}, GetCollator("gb18030_bin"))
+	require.IsType(t, &gb18030BinCollator{}, GetCollatorByID(249))
+	require.IsType(t, &gb18030ChineseCICollator{}, GetCollator("gb18030_chinese_ci"))
+	require.IsType(t, &gb18030ChineseCICollator{}, GetCollatorByID(248))
+
+	collations := []string{"gb18030_bin", "gb18030_chinese_ci"}
+	compareTests := []compareTable{
+		{"a", "A", []int{1, 0}},
+		{"a", "a ", []int{0, 0}},
+		{"啊", "吧", []int{-1, -1}},
+		{"中文", "汉字", []int{1, 1}},
+		{"À", "A", []int{1, 1}},
+		{"€", "啊", []int{-1, -1}},
+		{"😂", "😃", []int{-1, -1}},
+		{"😂", "啊", []int{-1, 1}},
+		{"😂", "😂", []int{0, 0}},
+	}
+	testCompareTable(t, collations, compareTests)
+
+	keyTests := []keyTable{
+		{"a", [][]byte{{0x61}, {0x41}}},
+		{"中文", [][]byte{{0xD6, 0xD0, 0xCE, 0xC4}, {0xD3, 0x21, 0xC1, 0xAD}}},
+		{"À", [][]byte{{0x81, 0x30, 0x86, 0x38}, {0xFF, 0x81, 0x30, 0x86, 0x38}}},
+		{"a😂 ", [][]byte{{0x61, 0x94, 0x39, 0xFC, 0x38}, {0x41, 0xFF, 0x94, 0x39, 0xFC, 0x38}}},
+	}
+	testKeyTable(t, collations, keyTests)
+
+	require.Same(t, GetCollator("gb18030_bin"), ConvertAndGetBinCollation("gb18030_chinese_ci"))
+	require.True(t, IsCICollation("gb18030_chinese_ci"))
+}
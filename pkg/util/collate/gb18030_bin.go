@@ -0,0 +1,29 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collate
+
+import (
+	"github.com/pingcap/tidb/pkg/parser/charset"
+)
+
+// gb18030BinCollator is collator for gb18030_bin.
+// It compares the gb18030 encoded bytes of the strings, which is the same as gbkBinCollator except the encoder.
+type gb18030BinCollator struct {
+	gbkBinCollator
+}
+
+func newGB18030BinCollator() *gb18030BinCollator {
+	return &gb18030BinCollator{gbkBinCollator{charset.NewGB18030Encoder()}}
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collate
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/util/stringutil"
+	"golang.org/x/text/encoding"
+)
+
+// gb18030ChineseCICollator is collator for gb18030_chinese_ci.
+// The characters in gbk share the weights of gbk_chinese_ci, the other characters are
+// weighted by their 4-bytes gb18030 encoding, and they are sorted after all gbk characters.
+type gb18030ChineseCICollator struct {
+	e *encoding.Encoder
+}
+
+func newGB18030ChineseCICollator() *gb18030ChineseCICollator {
+	return &gb18030ChineseCICollator{charset.NewGB18030Encoder()}
+}
+
+// Compare implements Collator interface.
+func (g *gb18030ChineseCICollator) Compare(a, b string) int {
+	a = truncateTailingSpace(a)
+	b = truncateTailingSpace(b)
+
+	r1, r2 := rune(0), rune(0)
+	ai, bi := 0, 0
+	var k1, k2 [5]byte
+	for ai < len(a) && bi < len(b) {
+		r1, ai = decodeRune(a, ai)
+		r2, bi = decodeRune(b, bi)
+		if r1 == r2 {
+			continue
+		}
+
+		cmp := bytes.Compare(g.appendSortKey(k1[:0], r1), g.appendSortKey(k2[:0], r2))
+		if cmp != 0 {
+			return cmp
+		}
+	}
+	return sign((len(a) - ai) - (len(b) - bi))
+}
+
+// Key implements Collator interface.
+func (g *gb18030ChineseCICollator) Key(str string) []byte {
+	return g.KeyWithoutTrimRightSpace(truncateTailingSpace(str))
+}
+
+// KeyWithoutTrimRightSpace implement Collator interface.
+func (g *gb18030ChineseCICollator) KeyWithoutTrimRightSpace(str string) []byte {
+	buf := make([]byte, 0, len(str)*2)
+	i := 0
+	r := rune(0)
+	for i < len(str) {
+		r, i = decodeRune(str, i)
+		buf = g.appendSortKey(buf, r)
+	}
+	return buf
+}
+
+// Pattern implements Collator interface.
+func (g *gb18030ChineseCICollator) Pattern() WildcardPattern {
+	return &gb18030ChineseCIPattern{c: g}
+}
+
+// appendSortKey appends the weight of the rune to buf. The weight of a gbk character is
+// its gbk_chinese_ci sort key, which has 1 or 2 bytes and never starts with 0xFF, otherwise
+// it's 0xFF followed by the 4-bytes gb18030 code. So a weight is never the prefix of another
+// one, and comparing the weights one by one keeps the order of Key.
+func (g *gb18030ChineseCICollator) appendSortKey(buf []byte, r rune) []byte {
+	u16 := gbkChineseCISortKey(r)
+	if u16 == 0x3F && r != '?' {
+		var runeBytes [utf8.UTFMax]byte
+		n := utf8.EncodeRune(runeBytes[:], r)
+		code, err := g.e.Bytes(runeBytes[:n])
+		// if convert error happened, we use '?'(0x3F) replace it.
+		if err == nil && len(code) == 4 {
+			buf = append(buf, gb18030FourBytesWeightPrefix)
+			return append(buf, code...)
+		}
+	}
+	if u16 > 0xFF {
+		buf = append(buf, byte(u16>>8))
+	}
+	return append(buf, byte(u16))
+}
+
+// gb18030FourBytesWeightPrefix is larger than the leading byte of all gbk_chinese_ci sort keys.
+const gb18030FourBytesWeightPrefix = 0xFF
+
+type gb18030ChineseCIPattern struct {
+	c        *gb18030ChineseCICollator
+	patChars []rune
+	patTypes []byte
+}
+
+// Compile implements WildcardPattern interface.
+func (p *gb18030ChineseCIPattern) Compile(patternStr string, escape byte) {
+	p.patChars, p.patTypes = stringutil.CompilePatternInner(patternStr, escape)
+}
+
+// DoMatch implements WildcardPattern interface.
+func (p *gb18030ChineseCIPattern) DoMatch(str string) bool {
+	var k1, k2 [5]byte
+	return stringutil.DoMatchCustomized(str, p.patChars, p.patTypes, func(a, b rune) bool {
+		return a == b || bytes.Equal(p.c.appendSortKey(k1[:0], a), p.c.appendSortKey(k2[:0], b))
+	})
+}
@@ -78,6 +78,8 @@ var (
 	ErrUnsupportedAlterTableOption = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message("This type of ALTER TABLE is currently unsupported", nil))
 	// ErrUnsupportedAlterCacheForSysTable means we don't support the alter cache for system table.
 	ErrUnsupportedAlterCacheForSysTable = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message("ALTER table cache for tables in system database is currently unsupported", nil))
+	// ErrUnsupportedReadOnlyForSysDB means we don't support setting the system database read only.
+	ErrUnsupportedReadOnlyForSysDB = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message("SET READ ONLY for system database is currently unsupported", nil))
 	// ErrBlobKeyWithoutLength is used when BLOB is used as key but without a length.
 	ErrBlobKeyWithoutLength = ClassDDL.NewStd(mysql.ErrBlobKeyWithoutLength)
 	// ErrKeyPart0 is used when key parts length is 0.
@@ -136,6 +138,10 @@ var (
 	ErrInvalidDefaultValue = ClassDDL.NewStd(mysql.ErrInvalidDefault)
 	// ErrDefValGeneratedNamedFunctionIsNotAllowed returns for disallowed function as default value expression of column.
 	ErrDefValGeneratedNamedFunctionIsNotAllowed = ClassDDL.NewStd(mysql.ErrDefValGeneratedNamedFunctionIsNotAllowed)
+	// ErrDefValGeneratedFunctionIsNotAllowed returns for disallowed expression as default value expression of column.
+	ErrDefValGeneratedFunctionIsNotAllowed = ClassDDL.NewStd(mysql.ErrDefValGeneratedFunctionIsNotAllowed)
+	// ErrDefValGeneratedVariables returns for variables referred by default value expression of column.
+	ErrDefValGeneratedVariables = ClassDDL.NewStd(mysql.ErrDefValGeneratedVariables)
 	// ErrGeneratedColumnRefAutoInc forbids to refer generated columns to auto-increment columns .
 	ErrGeneratedColumnRefAutoInc = ClassDDL.NewStd(mysql.ErrGeneratedColumnRefAutoInc)
 	// ErrExpressionIndexCanNotRefer forbids to refer expression index to auto-increment column.
@@ -213,6 +219,12 @@ var (
 	ErrInvalidGroupFuncUse = ClassDDL.NewStd(mysql.ErrInvalidGroupFuncUse)
 	// ErrTableMustHaveColumns returns for missing column when creating a table.
 	ErrTableMustHaveColumns = ClassDDL.NewStd(mysql.ErrTableMustHaveColumns)
+	// ErrTableMustHaveAVisibleColumn returns when all the columns of a table are invisible.
+	ErrTableMustHaveAVisibleColumn = ClassDDL.NewStd(mysql.ErrTableMustHaveAVisibleColumn)
+	// ErrGIPKColumnExists returns when the generated invisible primary key conflicts with an existing column.
+	ErrGIPKColumnExists = ClassDDL.NewStd(mysql.ErrGIPKColumnExists)
+	// ErrGIPKFailedAutoIncColumnExists returns when the invisible primary key can't be generated for an auto-increment column exists.
+	ErrGIPKFailedAutoIncColumnExists = ClassDDL.NewStd(mysql.ErrGIPKFailedAutoIncColumnExists)
 	// ErrWrongNameForIndex returns for wrong index name.
 	ErrWrongNameForIndex = ClassDDL.NewStd(mysql.ErrWrongNameForIndex)
 	// ErrUnknownCharacterSet returns unknown character set.
@@ -272,6 +284,10 @@ var (
 	ErrWrongObject = ClassDDL.NewStd(mysql.ErrWrongObject)
 	// ErrTableCantHandleFt returns FULLTEXT keys are not supported by table type
 	ErrTableCantHandleFt = ClassDDL.NewStd(mysql.ErrTableCantHandleFt)
+	// ErrBadFtColumn returns when the column can't be part of a FULLTEXT index.
+	ErrBadFtColumn = ClassDDL.NewStd(mysql.ErrBadFtColumn)
+	// ErrPluginIsNotLoaded returns when the parser of a FULLTEXT index is not supported.
+	ErrPluginIsNotLoaded = ClassDDL.NewStd(mysql.ErrPluginIsNotLoaded)
 	// ErrFieldNotFoundPart returns an error when 'partition by columns' are not found in table columns.
 	ErrFieldNotFoundPart = ClassDDL.NewStd(mysql.ErrFieldNotFoundPart)
 	// ErrWrongTypeColumnValue returns 'Partition column values of incorrect type'
@@ -363,6 +379,24 @@ var (
 	ErrOptOnTemporaryTable = ClassDDL.NewStd(mysql.ErrOptOnTemporaryTable)
 	// ErrOptOnCacheTable returns when exec unsupported opt at cache mode
 	ErrOptOnCacheTable = ClassDDL.NewStd(mysql.ErrOptOnCacheTable)
+	// ErrOptOnExternalTable returns when exec unsupported opt on external tables
+	ErrOptOnExternalTable = ClassDDL.NewStd(mysql.ErrOptOnExternalTable)
+	// ErrInvalidExternalTableDef returns when the options of an external table are invalid
+	ErrInvalidExternalTableDef = ClassDDL.NewStd(mysql.ErrInvalidExternalTableDef)
+	// ErrForeignDataStringInvalidCantCreate returns when the connection string of a federated table is invalid
+	ErrForeignDataStringInvalidCantCreate = ClassDDL.NewStd(mysql.ErrForeignDataStringInvalidCantCreate)
+	// ErrTrgAlreadyExists returns when the trigger to be created already exists
+	ErrTrgAlreadyExists = ClassDDL.NewStd(mysql.ErrTrgAlreadyExists)
+	// ErrTrgDoesNotExist returns when the trigger to be dropped does not exist
+	ErrTrgDoesNotExist = ClassDDL.NewStd(mysql.ErrTrgDoesNotExist)
+	// ErrTrgOnViewOrTempTable returns when creating a trigger on a view or temporary table
+	ErrTrgOnViewOrTempTable = ClassDDL.NewStd(mysql.ErrTrgOnViewOrTempTable)
+	// ErrTrgCantChangeRow returns when the trigger body assigns to a row which can't be changed
+	ErrTrgCantChangeRow = ClassDDL.NewStd(mysql.ErrTrgCantChangeRow)
+	// ErrTrgNoSuchRowInTrg returns when the trigger body refers to a row which doesn't exist for the event
+	ErrTrgNoSuchRowInTrg = ClassDDL.NewStd(mysql.ErrTrgNoSuchRowInTrg)
+	// ErrTrgInWrongSchema returns when the trigger and its table are in different schemas
+	ErrTrgInWrongSchema = ClassDDL.NewStd(mysql.ErrTrgInWrongSchema)
 	// ErrUnsupportedOnCommitPreserve returns when exec unsupported opt on commit preserve
 	ErrUnsupportedOnCommitPreserve = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message("TiDB doesn't support ON COMMIT PRESERVE ROWS for now", nil))
 	// ErrUnsupportedClusteredSecondaryKey returns when exec unsupported clustered secondary key
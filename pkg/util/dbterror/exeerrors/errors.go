@@ -48,6 +48,7 @@ var (
 	ErrWrongObject                          = dbterror.ClassExecutor.NewStd(mysql.ErrWrongObject)
 	ErrWrongUsage                           = dbterror.ClassExecutor.NewStd(mysql.ErrWrongUsage)
 	ErrRoleNotGranted                       = dbterror.ClassPrivilege.NewStd(mysql.ErrRoleNotGranted)
+	ErrRoleGrantedToItself                  = dbterror.ClassPrivilege.NewStd(mysql.ErrRoleGrantedToItself)
 	ErrDeadlock                             = dbterror.ClassExecutor.NewStd(mysql.ErrLockDeadlock)
 	ErrQueryInterrupted                     = dbterror.ClassExecutor.NewStd(mysql.ErrQueryInterrupted)
 	ErrMaxExecTimeExceeded                  = dbterror.ClassExecutor.NewStd(mysql.ErrMaxExecTimeExceeded)
@@ -69,6 +70,7 @@ var (
 	ErrBRIEExportFailed               = dbterror.ClassExecutor.NewStd(mysql.ErrBRIEExportFailed)
 	ErrBRJobNotFound                  = dbterror.ClassExecutor.NewStd(mysql.ErrBRJobNotFound)
 	ErrCTEMaxRecursionDepth           = dbterror.ClassExecutor.NewStd(mysql.ErrCTEMaxRecursionDepth)
+	ErrCTEMaxRecursionRows            = dbterror.ClassExecutor.NewStd(mysql.ErrCTEMaxRecursionRows)
 	ErrPluginIsNotLoaded              = dbterror.ClassExecutor.NewStd(mysql.ErrPluginIsNotLoaded)
 	ErrSetPasswordAuthPlugin          = dbterror.ClassExecutor.NewStd(mysql.ErrSetPasswordAuthPlugin)
 	ErrFuncNotEnabled                 = dbterror.ClassExecutor.NewStdErr(mysql.ErrNotSupportedYet, parser_mysql.Message("%-.32s is not supported. To enable this experimental feature, set '%-.32s' in the configuration file.", nil))
@@ -100,4 +102,32 @@ var (
 	ErrLoadDataInvalidOperation       = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataInvalidOperation)
 	ErrLoadDataLocalUnsupportedOption = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataLocalUnsupportedOption)
 	ErrLoadDataPreCheckFailed         = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataPreCheckFailed)
+
+	ErrMaskingPolicyExists    = dbterror.ClassExecutor.NewStd(mysql.ErrMaskingPolicyExists)
+	ErrMaskingPolicyNotExists = dbterror.ClassExecutor.NewStd(mysql.ErrMaskingPolicyNotExists)
+
+	ErrConnectToForeignDataSource = dbterror.ClassExecutor.NewStd(mysql.ErrConnectToForeignDataSource)
+	ErrQueryOnForeignDataSource   = dbterror.ClassExecutor.NewStd(mysql.ErrQueryOnForeignDataSource)
+	ErrForeignServerExists        = dbterror.ClassExecutor.NewStd(mysql.ErrForeignServerExists)
+	ErrForeignServerDoesntExist   = dbterror.ClassExecutor.NewStd(mysql.ErrForeignServerDoesntExist)
+
+	ErrSpNoRecursiveCreate = dbterror.ClassExecutor.NewStd(mysql.ErrSpNoRecursiveCreate)
+	ErrSpAlreadyExists     = dbterror.ClassExecutor.NewStd(mysql.ErrSpAlreadyExists)
+	ErrSpDoesNotExist      = dbterror.ClassExecutor.NewStd(mysql.ErrSpDoesNotExist)
+	ErrSpWrongNoOfArgs     = dbterror.ClassExecutor.NewStd(mysql.ErrSpWrongNoOfArgs)
+	ErrSpDupParam          = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupParam)
+	ErrSpDupVar            = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupVar)
+	ErrSpNotVarArg         = dbterror.ClassExecutor.NewStd(mysql.ErrSpNotVarArg)
+	ErrSpRecursionLimit    = dbterror.ClassExecutor.NewStd(mysql.ErrSpRecursionLimit)
+	ErrProcaccessDenied    = dbterror.ClassExecutor.NewStd(mysql.ErrProcaccessDenied)
+
+	ErrEventAlreadyExists               = dbterror.ClassExecutor.NewStd(mysql.ErrEventAlreadyExists)
+	ErrEventDoesNotExist                = dbterror.ClassExecutor.NewStd(mysql.ErrEventDoesNotExist)
+	ErrEventIntervalNotPositiveOrTooBig = dbterror.ClassExecutor.NewStd(mysql.ErrEventIntervalNotPositiveOrTooBig)
+	ErrEventEndsBeforeStarts            = dbterror.ClassExecutor.NewStd(mysql.ErrEventEndsBeforeStarts)
+	ErrEventExecTimeInThePast           = dbterror.ClassExecutor.NewStd(mysql.ErrEventExecTimeInThePast)
+	ErrEventCannotCreateInThePast       = dbterror.ClassExecutor.NewStd(mysql.ErrEventCannotCreateInThePast)
+	ErrNoSuchUser                       = dbterror.ClassExecutor.NewStd(mysql.ErrNoSuchUser)
+
+	ErrCantUpdateUsedTableInSfOrTrg = dbterror.ClassExecutor.NewStd(mysql.ErrCantUpdateUsedTableInSfOrTrg)
 )
@@ -39,6 +39,7 @@ func TestError(t *testing.T) {
 		ErrFieldNotInGroupBy,
 		ErrBadTable,
 		ErrKeyDoesNotExist,
+		ErrFtMatchingKeyNotFound,
 		ErrOperandColumns,
 		ErrInvalidGroupFuncUse,
 		ErrIllegalReference,
@@ -73,6 +74,7 @@ func TestError(t *testing.T) {
 		ErrMixOfGroupFuncAndFields,
 		ErrDBaccessDenied,
 		ErrTableaccessDenied,
+		ErrColumnaccessDenied,
 		ErrSpecificAccessDenied,
 		ErrViewNoExplain,
 		ErrWrongValueCountOnRow,
@@ -83,6 +85,7 @@ func TestError(t *testing.T) {
 		ErrStmtNotFound,
 		ErrAmbiguous,
 		ErrKeyPart0,
+		ErrMaskedTableNotWritable,
 	}
 	for _, err := range kvErrs {
 		code := terror.ToSQLError(err).Code
@@ -51,21 +51,24 @@ const (
 
 // WaitChainItem represents an entry in a deadlock's wait chain.
 type WaitChainItem struct {
-	SQLDigest      string
-	Key            []byte
-	AllSQLDigests  []string
-	TryLockTxn     uint64
-	TxnHoldingLock uint64
+	SQLDigest string `json:"sql_digest"`
+	Key       []byte `json:"key"`
+	// KeyInfo is the JSON of the key decoded with the table and index names, it's resolved when the deadlock is
+	// recorded, since the schema may have changed when the record is read.
+	KeyInfo        string   `json:"key_info,omitempty"`
+	AllSQLDigests  []string `json:"all_sql_digests,omitempty"`
+	TryLockTxn     uint64   `json:"try_lock_txn"`
+	TxnHoldingLock uint64   `json:"txn_holding_lock"`
 }
 
 // DeadlockRecord represents a deadlock events, and contains multiple transactions' information.
 type DeadlockRecord struct {
-	OccurTime time.Time
-	WaitChain []WaitChainItem
+	OccurTime time.Time       `json:"occur_time"`
+	WaitChain []WaitChainItem `json:"wait_chain"`
 	// The ID doesn't need to be set manually and it's set when it's added into the DeadlockHistory by invoking its Push
 	// method.
-	ID          uint64
-	IsRetryable bool
+	ID          uint64 `json:"-"`
+	IsRetryable bool   `json:"is_retryable"`
 }
 
 var columnValueGetterMap = map[string]func(rec *DeadlockRecord, waitChainIdx int) types.Datum{
@@ -95,6 +98,13 @@ var columnValueGetterMap = map[string]func(rec *DeadlockRecord, waitChainIdx int
 		}
 		return types.NewDatum(strings.ToUpper(hex.EncodeToString(key)))
 	},
+	ColKeyInfoStr: func(rec *DeadlockRecord, waitChainIdx int) types.Datum {
+		keyInfo := rec.WaitChain[waitChainIdx].KeyInfo
+		if len(keyInfo) == 0 {
+			return types.NewDatum(nil)
+		}
+		return types.NewDatum(keyInfo)
+	},
 	ColTrxHoldingLockStr: func(rec *DeadlockRecord, waitChainIdx int) types.Datum {
 		return types.NewDatum(rec.WaitChain[waitChainIdx].TxnHoldingLock)
 	},
@@ -122,6 +132,8 @@ type DeadlockHistory struct {
 	// currentID is used to allocate IDs for deadlock records pushed to the queue that's unique in the deadlock
 	// history queue instance.
 	currentID uint64
+	// storage persists the pushed records if it's not nil, see EnablePersist.
+	storage *persistentStorage
 	sync.RWMutex
 }
 
@@ -165,6 +177,14 @@ func (d *DeadlockHistory) Push(record *DeadlockRecord) {
 	d.Lock()
 	defer d.Unlock()
 
+	if d.storage != nil {
+		d.storage.write(record)
+	}
+	d.push(record)
+}
+
+// push is a thread unsafe version of Push() which doesn't persist the record.
+func (d *DeadlockHistory) push(record *DeadlockRecord) {
 	capacity := len(d.deadlocks)
 	if capacity == 0 {
 		return
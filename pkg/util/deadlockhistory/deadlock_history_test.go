@@ -15,6 +15,7 @@
 package deadlockhistory
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -351,3 +352,47 @@ func TestResize(t *testing.T) {
 	assert.Equal(t, 0, h.head)
 	assert.Equal(t, 1, h.size)
 }
+
+func TestPersist(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "deadlock.log")
+	now := time.Now()
+
+	h := NewDeadlockHistory(10)
+	assert.NoError(t, h.EnablePersist(filename, 7))
+	h.Push(&DeadlockRecord{
+		OccurTime:   now.Add(-8 * 24 * time.Hour),
+		IsRetryable: true,
+		WaitChain:   []WaitChainItem{{TryLockTxn: 1, Key: []byte("k1")}},
+	})
+	h.Push(&DeadlockRecord{
+		OccurTime: now,
+		WaitChain: []WaitChainItem{
+			{TryLockTxn: 2, SQLDigest: "digest", Key: []byte("k2"), KeyInfo: `{"table_name":"t"}`, AllSQLDigests: []string{"digest"}, TxnHoldingLock: 3},
+			{TryLockTxn: 3, Key: []byte("k3"), TxnHoldingLock: 2},
+		},
+	})
+	assert.Equal(t, 2, len(h.GetAll()))
+	assert.NoError(t, h.storage.close())
+
+	// The record out of retention is not loaded.
+	h2 := NewDeadlockHistory(10)
+	assert.NoError(t, h2.EnablePersist(filename, 7))
+	defer func() {
+		assert.NoError(t, h2.storage.close())
+	}()
+	records := h2.GetAll()
+	assert.Equal(t, 1, len(records))
+	assert.Equal(t, uint64(1), records[0].ID)
+	assert.True(t, records[0].OccurTime.Equal(now))
+	assert.False(t, records[0].IsRetryable)
+	assert.Equal(t, h.GetAll()[1].WaitChain, records[0].WaitChain)
+
+	// Records pushed after loading are appended to the file.
+	h2.Push(dummyRecord())
+	h3 := NewDeadlockHistory(10)
+	assert.NoError(t, h3.EnablePersist(filename, 0))
+	defer func() {
+		assert.NoError(t, h3.storage.close())
+	}()
+	assert.Equal(t, 3, len(h3.GetAll()))
+}
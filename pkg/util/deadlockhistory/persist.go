@@ -0,0 +1,153 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deadlockhistory
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	// persistFileMaxSize is the max size in megabytes of the persist file before it gets rotated.
+	persistFileMaxSize = 64
+	// persistLineMaxSize is the max size of a persisted record, a wait chain is hardly longer than it.
+	persistLineMaxSize = 16 << 20
+)
+
+// persistentStorage persists the deadlock records into a file, one JSON object per line. The file is rotated when
+// it's too large, and the rotated files older than maxDays are removed.
+type persistentStorage struct {
+	writer  *lumberjack.Logger
+	maxDays uint
+}
+
+func newPersistentStorage(filename string, maxDays uint) *persistentStorage {
+	return &persistentStorage{
+		writer: &lumberjack.Logger{
+			Filename:  filename,
+			MaxSize:   persistFileMaxSize,
+			MaxAge:    int(maxDays),
+			LocalTime: true,
+		},
+		maxDays: maxDays,
+	}
+}
+
+func (s *persistentStorage) write(record *DeadlockRecord) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		logutil.BgLogger().Warn("failed to marshal deadlock record", zap.Error(err))
+		return
+	}
+	b = append(b, '\n')
+	if _, err = s.writer.Write(b); err != nil {
+		logutil.BgLogger().Warn("failed to persist deadlock record", zap.Error(err))
+	}
+}
+
+func (s *persistentStorage) close() error {
+	return s.writer.Close()
+}
+
+// files returns the rotated files in the order they are rotated, followed by the current file.
+func (s *persistentStorage) files() ([]string, error) {
+	filename := s.writer.Filename
+	ext := filepath.Ext(filename)
+	// The rotated files are named as `name-2006-01-02T15-04-05.000.ext` by lumberjack.
+	backups, err := filepath.Glob(strings.TrimSuffix(filename, ext) + "-*" + ext)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Strings(backups)
+	return append(backups, filename), nil
+}
+
+// read reads the persisted records which occurred within maxDays before now, the earlier ones come first.
+func (s *persistentStorage) read(now time.Time) ([]*DeadlockRecord, error) {
+	files, err := s.files()
+	if err != nil {
+		return nil, err
+	}
+	var minOccurTime time.Time
+	if s.maxDays > 0 {
+		minOccurTime = now.Add(-time.Duration(s.maxDays) * 24 * time.Hour)
+	}
+
+	var records []*DeadlockRecord
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Trace(err)
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(nil, persistLineMaxSize)
+		for scanner.Scan() {
+			rec := &DeadlockRecord{}
+			if err := json.Unmarshal(scanner.Bytes(), rec); err != nil {
+				// The last line may be incomplete if the server exited while writing it.
+				logutil.BgLogger().Warn("skip the corrupted deadlock record", zap.String("file", file), zap.Error(err))
+				continue
+			}
+			if rec.OccurTime.Before(minOccurTime) {
+				continue
+			}
+			records = append(records, rec)
+		}
+		err = scanner.Err()
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return records, nil
+}
+
+// EnablePersist persists the records pushed afterwards into the file, and loads the records persisted within maxDays
+// into the history, so the deadlock events are kept across restarts. maxDays 0 means to retain all the records.
+func (d *DeadlockHistory) EnablePersist(filename string, maxDays uint) error {
+	storage := newPersistentStorage(filename, maxDays)
+	records, err := storage.read(time.Now())
+	if err != nil {
+		return err
+	}
+
+	d.Lock()
+	defer d.Unlock()
+	if d.storage != nil {
+		if err := d.storage.close(); err != nil {
+			logutil.BgLogger().Warn("failed to close the deadlock history file", zap.Error(err))
+		}
+	}
+	d.storage = storage
+	for _, rec := range records {
+		d.push(rec)
+	}
+	return nil
+}
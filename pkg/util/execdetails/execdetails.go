@@ -115,6 +115,12 @@ var StmtExecDetailKey = stmtExecDetailKeyType{}
 // StmtExecDetails contains stmt level execution detail info.
 type StmtExecDetails struct {
 	WriteSQLRespDuration time.Duration
+	// BackoffBudgetMs is the sum of the backoff budgets of the backoffers which have backed off.
+	BackoffBudgetMs int64
+	// BackoffBudgetUsedMs is the backoff time of the backoffers which have backed off.
+	BackoffBudgetUsedMs int64
+	// BackoffBudgetExhausted is the number of the backoffers which run out of the budget.
+	BackoffBudgetExhausted int64
 }
 
 const (
@@ -182,61 +188,76 @@ const (
 
 // String implements the fmt.Stringer interface.
 func (d ExecDetails) String() string {
-	parts := make([]string, 0, 8)
+	fields, values := d.Fields()
+	parts := make([]string, 0, len(fields))
+	for i := range fields {
+		parts = append(parts, fields[i]+": "+values[i])
+	}
+	return strings.Join(parts, " ")
+}
+
+// Fields returns the names and values of the details in the slow log, which are
+// in the same order as String.
+func (d ExecDetails) Fields() (fields, values []string) {
+	fields, values = make([]string, 0, 8), make([]string, 0, 8)
+	add := func(field, value string) {
+		fields = append(fields, field)
+		values = append(values, value)
+	}
 	if d.CopTime > 0 {
-		parts = append(parts, CopTimeStr+": "+strconv.FormatFloat(d.CopTime.Seconds(), 'f', -1, 64))
+		add(CopTimeStr, strconv.FormatFloat(d.CopTime.Seconds(), 'f', -1, 64))
 	}
 	if d.TimeDetail.ProcessTime > 0 {
-		parts = append(parts, ProcessTimeStr+": "+strconv.FormatFloat(d.TimeDetail.ProcessTime.Seconds(), 'f', -1, 64))
+		add(ProcessTimeStr, strconv.FormatFloat(d.TimeDetail.ProcessTime.Seconds(), 'f', -1, 64))
 	}
 	if d.TimeDetail.WaitTime > 0 {
-		parts = append(parts, WaitTimeStr+": "+strconv.FormatFloat(d.TimeDetail.WaitTime.Seconds(), 'f', -1, 64))
+		add(WaitTimeStr, strconv.FormatFloat(d.TimeDetail.WaitTime.Seconds(), 'f', -1, 64))
 	}
 	if d.BackoffTime > 0 {
-		parts = append(parts, BackoffTimeStr+": "+strconv.FormatFloat(d.BackoffTime.Seconds(), 'f', -1, 64))
+		add(BackoffTimeStr, strconv.FormatFloat(d.BackoffTime.Seconds(), 'f', -1, 64))
 	}
 	if d.LockKeysDuration > 0 {
-		parts = append(parts, LockKeysTimeStr+": "+strconv.FormatFloat(d.LockKeysDuration.Seconds(), 'f', -1, 64))
+		add(LockKeysTimeStr, strconv.FormatFloat(d.LockKeysDuration.Seconds(), 'f', -1, 64))
 	}
 	if d.RequestCount > 0 {
-		parts = append(parts, RequestCountStr+": "+strconv.FormatInt(int64(d.RequestCount), 10))
+		add(RequestCountStr, strconv.FormatInt(int64(d.RequestCount), 10))
 	}
 	commitDetails := d.CommitDetail
 	if commitDetails != nil {
 		if commitDetails.PrewriteTime > 0 {
-			parts = append(parts, PreWriteTimeStr+": "+strconv.FormatFloat(commitDetails.PrewriteTime.Seconds(), 'f', -1, 64))
+			add(PreWriteTimeStr, strconv.FormatFloat(commitDetails.PrewriteTime.Seconds(), 'f', -1, 64))
 		}
 		if commitDetails.WaitPrewriteBinlogTime > 0 {
-			parts = append(parts, WaitPrewriteBinlogTimeStr+": "+strconv.FormatFloat(commitDetails.WaitPrewriteBinlogTime.Seconds(), 'f', -1, 64))
+			add(WaitPrewriteBinlogTimeStr, strconv.FormatFloat(commitDetails.WaitPrewriteBinlogTime.Seconds(), 'f', -1, 64))
 		}
 		if commitDetails.CommitTime > 0 {
-			parts = append(parts, CommitTimeStr+": "+strconv.FormatFloat(commitDetails.CommitTime.Seconds(), 'f', -1, 64))
+			add(CommitTimeStr, strconv.FormatFloat(commitDetails.CommitTime.Seconds(), 'f', -1, 64))
 		}
 		if commitDetails.GetCommitTsTime > 0 {
-			parts = append(parts, GetCommitTSTimeStr+": "+strconv.FormatFloat(commitDetails.GetCommitTsTime.Seconds(), 'f', -1, 64))
+			add(GetCommitTSTimeStr, strconv.FormatFloat(commitDetails.GetCommitTsTime.Seconds(), 'f', -1, 64))
 		}
 		if commitDetails.GetLatestTsTime > 0 {
-			parts = append(parts, GetLatestTsTimeStr+": "+strconv.FormatFloat(commitDetails.GetLatestTsTime.Seconds(), 'f', -1, 64))
+			add(GetLatestTsTimeStr, strconv.FormatFloat(commitDetails.GetLatestTsTime.Seconds(), 'f', -1, 64))
 		}
 		commitDetails.Mu.Lock()
 		commitBackoffTime := commitDetails.Mu.CommitBackoffTime
 		if commitBackoffTime > 0 {
-			parts = append(parts, CommitBackoffTimeStr+": "+strconv.FormatFloat(time.Duration(commitBackoffTime).Seconds(), 'f', -1, 64))
+			add(CommitBackoffTimeStr, strconv.FormatFloat(time.Duration(commitBackoffTime).Seconds(), 'f', -1, 64))
 		}
 		if len(commitDetails.Mu.PrewriteBackoffTypes) > 0 {
-			parts = append(parts, "Prewrite_"+BackoffTypesStr+": "+fmt.Sprintf("%v", commitDetails.Mu.PrewriteBackoffTypes))
+			add("Prewrite_"+BackoffTypesStr, fmt.Sprintf("%v", commitDetails.Mu.PrewriteBackoffTypes))
 		}
 		if len(commitDetails.Mu.CommitBackoffTypes) > 0 {
-			parts = append(parts, "Commit_"+BackoffTypesStr+": "+fmt.Sprintf("%v", commitDetails.Mu.CommitBackoffTypes))
+			add("Commit_"+BackoffTypesStr, fmt.Sprintf("%v", commitDetails.Mu.CommitBackoffTypes))
 		}
 		if commitDetails.Mu.SlowestPrewrite.ReqTotalTime > 0 {
-			parts = append(parts, SlowestPrewriteRPCDetailStr+": {total:"+strconv.FormatFloat(commitDetails.Mu.SlowestPrewrite.ReqTotalTime.Seconds(), 'f', 3, 64)+
+			add(SlowestPrewriteRPCDetailStr, "{total:"+strconv.FormatFloat(commitDetails.Mu.SlowestPrewrite.ReqTotalTime.Seconds(), 'f', 3, 64)+
 				"s, region_id: "+strconv.FormatUint(commitDetails.Mu.SlowestPrewrite.Region, 10)+
 				", store: "+commitDetails.Mu.SlowestPrewrite.StoreAddr+
 				", "+commitDetails.Mu.SlowestPrewrite.ExecDetails.String()+"}")
 		}
 		if commitDetails.Mu.CommitPrimary.ReqTotalTime > 0 {
-			parts = append(parts, CommitPrimaryRPCDetailStr+": {total:"+strconv.FormatFloat(commitDetails.Mu.SlowestPrewrite.ReqTotalTime.Seconds(), 'f', 3, 64)+
+			add(CommitPrimaryRPCDetailStr, "{total:"+strconv.FormatFloat(commitDetails.Mu.SlowestPrewrite.ReqTotalTime.Seconds(), 'f', 3, 64)+
 				"s, region_id: "+strconv.FormatUint(commitDetails.Mu.SlowestPrewrite.Region, 10)+
 				", store: "+commitDetails.Mu.SlowestPrewrite.StoreAddr+
 				", "+commitDetails.Mu.SlowestPrewrite.ExecDetails.String()+"}")
@@ -244,56 +265,56 @@ func (d ExecDetails) String() string {
 		commitDetails.Mu.Unlock()
 		resolveLockTime := atomic.LoadInt64(&commitDetails.ResolveLock.ResolveLockTime)
 		if resolveLockTime > 0 {
-			parts = append(parts, ResolveLockTimeStr+": "+strconv.FormatFloat(time.Duration(resolveLockTime).Seconds(), 'f', -1, 64))
+			add(ResolveLockTimeStr, strconv.FormatFloat(time.Duration(resolveLockTime).Seconds(), 'f', -1, 64))
 		}
 		if commitDetails.LocalLatchTime > 0 {
-			parts = append(parts, LocalLatchWaitTimeStr+": "+strconv.FormatFloat(commitDetails.LocalLatchTime.Seconds(), 'f', -1, 64))
+			add(LocalLatchWaitTimeStr, strconv.FormatFloat(commitDetails.LocalLatchTime.Seconds(), 'f', -1, 64))
 		}
 		if commitDetails.WriteKeys > 0 {
-			parts = append(parts, WriteKeysStr+": "+strconv.FormatInt(int64(commitDetails.WriteKeys), 10))
+			add(WriteKeysStr, strconv.FormatInt(int64(commitDetails.WriteKeys), 10))
 		}
 		if commitDetails.WriteSize > 0 {
-			parts = append(parts, WriteSizeStr+": "+strconv.FormatInt(int64(commitDetails.WriteSize), 10))
+			add(WriteSizeStr, strconv.FormatInt(int64(commitDetails.WriteSize), 10))
 		}
 		prewriteRegionNum := atomic.LoadInt32(&commitDetails.PrewriteRegionNum)
 		if prewriteRegionNum > 0 {
-			parts = append(parts, PrewriteRegionStr+": "+strconv.FormatInt(int64(prewriteRegionNum), 10))
+			add(PrewriteRegionStr, strconv.FormatInt(int64(prewriteRegionNum), 10))
 		}
 		if commitDetails.TxnRetry > 0 {
-			parts = append(parts, TxnRetryStr+": "+strconv.FormatInt(int64(commitDetails.TxnRetry), 10))
+			add(TxnRetryStr, strconv.FormatInt(int64(commitDetails.TxnRetry), 10))
 		}
 	}
 	scanDetail := d.ScanDetail
 	if scanDetail != nil {
 		if scanDetail.ProcessedKeys > 0 {
-			parts = append(parts, ProcessKeysStr+": "+strconv.FormatInt(scanDetail.ProcessedKeys, 10))
+			add(ProcessKeysStr, strconv.FormatInt(scanDetail.ProcessedKeys, 10))
 		}
 		if scanDetail.TotalKeys > 0 {
-			parts = append(parts, TotalKeysStr+": "+strconv.FormatInt(scanDetail.TotalKeys, 10))
+			add(TotalKeysStr, strconv.FormatInt(scanDetail.TotalKeys, 10))
 		}
 		if scanDetail.GetSnapshotDuration > 0 {
-			parts = append(parts, GetSnapshotTimeStr+": "+strconv.FormatFloat(scanDetail.GetSnapshotDuration.Seconds(), 'f', 3, 64))
+			add(GetSnapshotTimeStr, strconv.FormatFloat(scanDetail.GetSnapshotDuration.Seconds(), 'f', 3, 64))
 		}
 		if scanDetail.RocksdbDeleteSkippedCount > 0 {
-			parts = append(parts, RocksdbDeleteSkippedCountStr+": "+strconv.FormatUint(scanDetail.RocksdbDeleteSkippedCount, 10))
+			add(RocksdbDeleteSkippedCountStr, strconv.FormatUint(scanDetail.RocksdbDeleteSkippedCount, 10))
 		}
 		if scanDetail.RocksdbKeySkippedCount > 0 {
-			parts = append(parts, RocksdbKeySkippedCountStr+": "+strconv.FormatUint(scanDetail.RocksdbKeySkippedCount, 10))
+			add(RocksdbKeySkippedCountStr, strconv.FormatUint(scanDetail.RocksdbKeySkippedCount, 10))
 		}
 		if scanDetail.RocksdbBlockCacheHitCount > 0 {
-			parts = append(parts, RocksdbBlockCacheHitCountStr+": "+strconv.FormatUint(scanDetail.RocksdbBlockCacheHitCount, 10))
+			add(RocksdbBlockCacheHitCountStr, strconv.FormatUint(scanDetail.RocksdbBlockCacheHitCount, 10))
 		}
 		if scanDetail.RocksdbBlockReadCount > 0 {
-			parts = append(parts, RocksdbBlockReadCountStr+": "+strconv.FormatUint(scanDetail.RocksdbBlockReadCount, 10))
+			add(RocksdbBlockReadCountStr, strconv.FormatUint(scanDetail.RocksdbBlockReadCount, 10))
 		}
 		if scanDetail.RocksdbBlockReadByte > 0 {
-			parts = append(parts, RocksdbBlockReadByteStr+": "+strconv.FormatUint(scanDetail.RocksdbBlockReadByte, 10))
+			add(RocksdbBlockReadByteStr, strconv.FormatUint(scanDetail.RocksdbBlockReadByte, 10))
 		}
 		if scanDetail.RocksdbBlockReadDuration > 0 {
-			parts = append(parts, RocksdbBlockReadTimeStr+": "+strconv.FormatFloat(scanDetail.RocksdbBlockReadDuration.Seconds(), 'f', 3, 64))
+			add(RocksdbBlockReadTimeStr, strconv.FormatFloat(scanDetail.RocksdbBlockReadDuration.Seconds(), 'f', 3, 64))
 		}
 	}
-	return strings.Join(parts, " ")
+	return fields, values
 }
 
 // ToZapFields wraps the ExecDetails as zap.Fields.
@@ -385,6 +406,9 @@ type basicCopRuntimeStats struct {
 	threads    int32
 	totalTasks int32
 	procTimes  Percentile[Duration]
+	// ruDetails is the RU consumption reported by TiFlash, which is the RU of the whole task and is only
+	// reported in the summary of one executor of the task.
+	ruDetails *util.RUDetails
 }
 
 type canGetFloat64 interface {
@@ -512,7 +536,11 @@ func (p *Percentile[valueType]) Sum() float64 {
 // String implements the RuntimeStats interface.
 func (e *basicCopRuntimeStats) String() string {
 	if e.storeType == "tiflash" {
-		return fmt.Sprintf("time:%v, loops:%d, threads:%d, ", FormatDuration(time.Duration(e.consume.Load())), e.loop.Load(), e.threads) + e.BasicRuntimeStats.tiflashScanContext.String()
+		str := fmt.Sprintf("time:%v, loops:%d, threads:%d, ", FormatDuration(time.Duration(e.consume.Load())), e.loop.Load(), e.threads) + e.BasicRuntimeStats.tiflashScanContext.String()
+		if ruStr := FormatRUDetails(e.ruDetails); ruStr != "" {
+			str += ", " + ruStr
+		}
+		return str
 	}
 	return fmt.Sprintf("time:%v, loops:%d", FormatDuration(time.Duration(e.consume.Load())), e.loop.Load())
 }
@@ -526,6 +554,9 @@ func (e *basicCopRuntimeStats) Clone() RuntimeStats {
 		totalTasks:        e.totalTasks,
 		procTimes:         e.procTimes,
 	}
+	if e.ruDetails != nil {
+		stats.ruDetails = e.ruDetails.Clone()
+	}
 	stats.loop.Store(e.loop.Load())
 	stats.consume.Store(e.consume.Load())
 	stats.rows.Store(e.rows.Load())
@@ -549,6 +580,12 @@ func (e *basicCopRuntimeStats) Merge(rs RuntimeStats) {
 		e.procTimes.MergePercentile(&tmp.procTimes)
 	}
 	e.tiflashScanContext.Merge(tmp.tiflashScanContext)
+	if tmp.ruDetails != nil {
+		if e.ruDetails == nil {
+			e.ruDetails = util.NewRUDetails()
+		}
+		e.ruDetails.Merge(tmp.ruDetails)
+	}
 }
 
 // Tp implements the RuntimeStats interface.
@@ -598,6 +635,13 @@ func (crs *CopRuntimeStats) RecordOneCopTask(address string, summary *tipb.Execu
 				totalDisaggReadCacheMissSize:        summary.GetTiflashScanContext().GetTotalDisaggReadCacheMissSize()}}, threads: int32(summary.GetConcurrency()),
 		totalTasks: 1,
 	}
+	if ruConsumption := summary.GetRuConsumption(); ruConsumption != nil {
+		consumption := new(resource_manager.Consumption)
+		if err := consumption.Unmarshal(ruConsumption); err == nil {
+			data.ruDetails = util.NewRUDetails()
+			data.ruDetails.Update(consumption, 0)
+		}
+	}
 	data.BasicRuntimeStats.loop.Store(int32(*summary.NumIterations))
 	data.BasicRuntimeStats.consume.Store(int64(*summary.TimeProcessedNs))
 	data.BasicRuntimeStats.rows.Store(int64(*summary.NumProducedRows))
@@ -667,6 +711,18 @@ func (crs *CopRuntimeStats) String() string {
 			buf.WriteString("}")
 		}
 	}
+	if isTiFlashCop {
+		ruDetails := util.NewRUDetails()
+		for _, instanceStats := range crs.stats {
+			if instanceStats.ruDetails != nil {
+				ruDetails.Merge(instanceStats.ruDetails)
+			}
+		}
+		if ruStr := FormatRUDetails(ruDetails); ruStr != "" {
+			buf.WriteString(", ")
+			buf.WriteString(ruStr)
+		}
+	}
 	if !isTiFlashCop && crs.scanDetail != nil {
 		detail := crs.scanDetail.String()
 		if detail != "" {
@@ -716,6 +772,12 @@ const (
 	TpFKCascadeRuntimeStats
 	// TpRURuntimeStats is the tp for RURuntimeStats
 	TpRURuntimeStats
+	// TpRuntimeFilterRuntimeStats is the tp for RuntimeFilterRuntimeStats
+	TpRuntimeFilterRuntimeStats
+	// TpSortRuntimeStats is the tp for SortRuntimeStats
+	TpSortRuntimeStats
+	// TpCTERuntimeStats is the tp for cteRuntimeStats
+	TpCTERuntimeStats
 )
 
 // RuntimeStats is used to express the executor runtime information.
@@ -779,6 +841,11 @@ func (context *TiFlashScanContext) Merge(other TiFlashScanContext) {
 	context.totalDisaggReadCacheMissSize += other.totalDisaggReadCacheMissSize
 }
 
+// DisaggReadCacheSize returns the bytes read from the local cache and the bytes read from S3 in disaggregated TiFlash.
+func (context *TiFlashScanContext) DisaggReadCacheSize() (hit, miss uint64) {
+	return context.totalDisaggReadCacheHitSize, context.totalDisaggReadCacheMissSize
+}
+
 // Empty check whether TiFlashScanContext is Empty, if scan no pack and skip no pack, we regard it as empty
 func (context *TiFlashScanContext) Empty() bool {
 	res := context.totalDmfileScannedPacks == 0 && context.totalDmfileSkippedPacks == 0 && context.totalLocalRegionNum == 0 && context.totalRemoteRegionNum == 0
@@ -1382,6 +1449,18 @@ func getUnit(d time.Duration) time.Duration {
 	return time.Nanosecond
 }
 
+// FormatRUDetails formats the RU consumed by an operator in the runtime stats, it returns an empty string
+// if no RU is consumed.
+func FormatRUDetails(ruDetails *util.RUDetails) string {
+	if ruDetails == nil || (ruDetails.RRU() == 0 && ruDetails.WRU() == 0 && ruDetails.RUWaitDuration() == 0) {
+		return ""
+	}
+	if ruDetails.RUWaitDuration() > 0 {
+		return fmt.Sprintf("ru:{rru:%.2f, wru:%.2f, wait:%v}", ruDetails.RRU(), ruDetails.WRU(), FormatDuration(ruDetails.RUWaitDuration()))
+	}
+	return fmt.Sprintf("ru:{rru:%.2f, wru:%.2f}", ruDetails.RRU(), ruDetails.WRU())
+}
+
 // MergeTiFlashRUConsumption merge execution summaries from selectResponse into ruDetails.
 func MergeTiFlashRUConsumption(executionSummaries []*tipb.ExecutorExecutionSummary, ruDetails *util.RUDetails) error {
 	newRUDetails := util.NewRUDetails()
@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pingcap/kvproto/pkg/resource_manager"
 	"github.com/pingcap/tipb/go-tipb"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/client-go/v2/util"
@@ -248,6 +249,26 @@ func TestCopRuntimeStatsForTiFlash(t *testing.T) {
 	require.True(t, stats.ExistsRootStats(tableReaderID))
 }
 
+func TestCopRuntimeStatsForTiFlashRU(t *testing.T) {
+	stats := NewRuntimeStatsColl(nil)
+	ru, err := (&resource_manager.Consumption{RRU: 1.5}).Marshal()
+	require.NoError(t, err)
+	// the RU of a task is only reported in the summary of one executor.
+	summary := mockExecutorExecutionSummaryForTiFlash(1, 1, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, "aggregation_1")
+	summary.RuConsumption = ru
+	stats.RecordOneCopTask(1, "tiflash", "8.8.8.8", summary)
+	stats.RecordOneCopTask(1, "tiflash", "8.8.8.9", summary)
+	stats.RecordOneCopTask(2, "tiflash", "8.8.8.8", mockExecutorExecutionSummaryForTiFlash(1, 1, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, "tablescan_2"))
+	cop := stats.GetOrCreateCopStats(1, "tiflash")
+	require.Equal(t, "tiflash_task:{proc max:1ns, min:1ns, avg: 1ns, p80:1ns, p95:1ns, iters:2, tasks:2, threads:2}, ru:{rru:3.00, wru:0.00}", cop.String())
+	require.Equal(t, "time:1ns, loops:1, threads:1, tiflash_scan:{dtfile:{total_scanned_packs:0, total_skipped_packs:0, total_scanned_rows:0, total_skipped_rows:0, total_rs_index_check_time: 0ms, total_read_time: 0ms, total_disagg_read_cache_hit_size: 0, total_disagg_read_cache_miss_size: 0}, total_create_snapshot_time: 0ms, total_local_region_num: 0, total_remote_region_num: 0, total_learner_read_time: 0ms}, ru:{rru:1.50, wru:0.00}", cop.stats["8.8.8.8"].String())
+	require.Equal(t, "tiflash_task:{time:1ns, loops:1, threads:1}", stats.GetOrCreateCopStats(2, "tiflash").String())
+
+	require.Equal(t, "", FormatRUDetails(nil))
+	require.Equal(t, "", FormatRUDetails(util.NewRUDetails()))
+	require.Equal(t, "ru:{rru:1.00, wru:2.00, wait:3ms}", FormatRUDetails(util.NewRUDetailsWith(1, 2, 3*time.Millisecond)))
+}
+
 func TestRuntimeStatsWithCommit(t *testing.T) {
 	commitDetail := &util.CommitDetails{
 		GetCommitTsTime: time.Second,
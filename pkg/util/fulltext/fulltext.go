@@ -0,0 +1,206 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+)
+
+const (
+	// NgramTokenSize is the number of characters of the tokens generated by the ngram parser.
+	NgramTokenSize = 2
+	// MaxTokenSize is the max number of characters of a token, the longer words are ignored.
+	MaxTokenSize = 84
+)
+
+// IsValidParser checks whether the parser name is supported.
+func IsValidParser(name string) bool {
+	switch model.FullTextParserType(strings.ToLower(name)) {
+	case model.FullTextParserTypeWhitespace, model.FullTextParserTypeNgram:
+		return true
+	}
+	return false
+}
+
+// Tokenize splits the text into the lowercase tokens in the order of their appearance.
+// The whitespace parser uses the words as the tokens, and the ngram parser splits every
+// word into the sequences of NgramTokenSize characters. The words are separated by the
+// characters which are neither letters, digits nor underscores.
+func Tokenize(parser model.FullTextParserType, text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	if parser != model.FullTextParserTypeNgram {
+		tokens := words[:0]
+		for _, word := range words {
+			if utf8.RuneCountInString(word) <= MaxTokenSize {
+				tokens = append(tokens, word)
+			}
+		}
+		return tokens
+	}
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		runes := []rune(word)
+		if len(runes) <= NgramTokenSize {
+			tokens = append(tokens, word)
+			continue
+		}
+		for i := 0; i+NgramTokenSize <= len(runes); i++ {
+			tokens = append(tokens, string(runes[i:i+NgramTokenSize]))
+		}
+	}
+	return tokens
+}
+
+// DistinctTokens returns the distinct tokens of the texts, it's used to build the index entries.
+func DistinctTokens(parser model.FullTextParserType, texts ...string) []string {
+	var tokens []string
+	exists := make(map[string]struct{})
+	for _, text := range texts {
+		for _, token := range Tokenize(parser, text) {
+			if _, ok := exists[token]; ok {
+				continue
+			}
+			exists[token] = struct{}{}
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// termOp is the operator of a term in the search string.
+type termOp byte
+
+const (
+	termOptional termOp = iota
+	termRequired
+	termExcluded
+)
+
+// term is a word or a quoted phrase in the search string.
+type term struct {
+	tokens []string
+	// prefix means the last token matches the tokens starting with it, e.g. 'data*'.
+	prefix bool
+	op     termOp
+}
+
+// Query is a parsed search string of MATCH ... AGAINST.
+type Query struct {
+	terms []term
+}
+
+// ParseQuery parses the search string. In natural language mode, every token of the search
+// string is an optional term. In boolean mode, the following operators are supported:
+//
+//	+word      the word must be present.
+//	-word      the word must not be present.
+//	word*      matches the words starting with 'word'.
+//	"a phrase" matches the words of the phrase in order.
+//
+// The other boolean operators are treated as separators.
+func ParseQuery(parser model.FullTextParserType, text string, booleanMode bool) *Query {
+	q := &Query{}
+	if !booleanMode {
+		for _, token := range DistinctTokens(parser, text) {
+			q.terms = append(q.terms, term{tokens: []string{token}})
+		}
+		return q
+	}
+	for len(text) > 0 {
+		op := termOptional
+		switch text[0] {
+		case '+':
+			op = termRequired
+			text = text[1:]
+		case '-':
+			op = termExcluded
+			text = text[1:]
+		}
+		var word string
+		if len(text) > 0 && text[0] == '"' {
+			end := strings.IndexByte(text[1:], '"')
+			if end < 0 {
+				word, text = text[1:], ""
+			} else {
+				word, text = text[1:end+1], text[end+2:]
+			}
+		} else {
+			end := strings.IndexAny(text, " \t\r\n\"+-")
+			if end < 0 {
+				word, text = text, ""
+			} else if end == 0 {
+				// the operator is not followed by a word.
+				text = text[1:]
+				continue
+			} else {
+				word, text = text[:end], text[end:]
+			}
+		}
+		t := term{op: op, prefix: strings.HasSuffix(word, "*")}
+		t.tokens = Tokenize(parser, word)
+		if len(t.tokens) == 0 {
+			continue
+		}
+		q.terms = append(q.terms, t)
+	}
+	return q
+}
+
+// Relevance returns the relevance of the tokens of a document to the query, which is the
+// number of the occurrences of the matched terms. Zero means the document is not matched.
+func (q *Query) Relevance(doc []string) float64 {
+	var relevance float64
+	for _, t := range q.terms {
+		cnt := t.occurrences(doc)
+		switch {
+		case t.op == termExcluded && cnt > 0:
+			return 0
+		case t.op == termRequired && cnt == 0:
+			return 0
+		case t.op != termExcluded:
+			relevance += float64(cnt)
+		}
+	}
+	return relevance
+}
+
+// occurrences returns how many times the term appears in the document.
+func (t *term) occurrences(doc []string) int {
+	cnt := 0
+	last := len(t.tokens) - 1
+	for i := 0; i+last < len(doc); i++ {
+		matched := true
+		for j, token := range t.tokens {
+			if j == last && t.prefix {
+				matched = strings.HasPrefix(doc[i+j], token)
+			} else {
+				matched = doc[i+j] == token
+			}
+			if !matched {
+				break
+			}
+		}
+		if matched {
+			cnt++
+		}
+	}
+	return cnt
+}
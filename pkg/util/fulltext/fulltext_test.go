@@ -0,0 +1,68 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	whitespace, ngram := model.FullTextParserTypeWhitespace, model.FullTextParserTypeNgram
+	require.Equal(t, []string{"hello", "world", "tidb_4"}, Tokenize(whitespace, " Hello, WORLD!\ttidb_4 "))
+	require.Empty(t, Tokenize(whitespace, " ,.; "))
+	require.Empty(t, Tokenize(whitespace, strings.Repeat("a", MaxTokenSize+1)))
+	require.Equal(t, []string{"数据", "据库", "ab", "a"}, Tokenize(ngram, "数据库 ab a"))
+	require.Equal(t, []string{"a", "b", "c"}, DistinctTokens(whitespace, "a b a", "c B"))
+
+	require.True(t, IsValidParser("NGRAM"))
+	require.True(t, IsValidParser("whitespace"))
+	require.False(t, IsValidParser("mecab"))
+}
+
+func TestRelevance(t *testing.T) {
+	doc := Tokenize(model.FullTextParserTypeWhitespace, "TiDB is a distributed SQL database, TiDB is MySQL compatible")
+	cases := []struct {
+		query       string
+		booleanMode bool
+		relevance   float64
+	}{
+		{"tidb", false, 2},
+		{"TiDB mysql oracle", false, 3},
+		{"oracle", false, 0},
+		{"+tidb +mysql", true, 3},
+		{"+tidb +oracle", true, 0},
+		{"tidb -mysql", true, 0},
+		{"tidb -oracle", true, 2},
+		{"-oracle", true, 0},
+		{"data*", true, 1},
+		{`"distributed sql"`, true, 1},
+		{`"sql distributed"`, true, 0},
+		{`+"tidb is" +compat*`, true, 3},
+		{"+ - ~oracle", true, 0},
+	}
+	for _, c := range cases {
+		q := ParseQuery(model.FullTextParserTypeWhitespace, c.query, c.booleanMode)
+		require.Equal(t, c.relevance, q.Relevance(doc), c.query)
+	}
+
+	doc = Tokenize(model.FullTextParserTypeNgram, "分布式数据库")
+	require.Equal(t, float64(1), ParseQuery(model.FullTextParserTypeNgram, "数据库", true).Relevance(doc))
+	require.Equal(t, float64(0), ParseQuery(model.FullTextParserTypeNgram, "数据式", true).Relevance(doc))
+	require.Equal(t, float64(1), ParseQuery(model.FullTextParserTypeNgram, "数据式", false).Relevance(doc))
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package generallog controls which statements are written to the general log,
+// and keeps the recent ones in memory for INFORMATION_SCHEMA.TIDB_GENERAL_LOG.
+package generallog
+
+import (
+	"math"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/util/fastrand"
+	"go.uber.org/atomic"
+)
+
+const (
+	// OutputFile writes the general log to the TiDB log file.
+	OutputFile = "FILE"
+	// OutputTable keeps the general log in INFORMATION_SCHEMA.TIDB_GENERAL_LOG.
+	OutputTable = "TABLE"
+)
+
+var (
+	sampleRate = atomic.NewFloat64(1)
+	// sampleThreshold is the sample rate scaled to [0, math.MaxUint32].
+	sampleThreshold = atomic.NewUint64(math.MaxUint32)
+	usersStr        = atomic.NewString("")
+	users           atomic.Pointer[map[string]struct{}]
+	outputFile      = atomic.NewBool(true)
+	outputTable     = atomic.NewBool(false)
+)
+
+// SetSampleRate sets the fraction of statements written to the general log.
+func SetSampleRate(rate float64) {
+	rate = math.Max(0, math.Min(1, rate))
+	sampleRate.Store(rate)
+	sampleThreshold.Store(uint64(rate * math.MaxUint32))
+}
+
+// SampleRate returns the fraction of statements written to the general log.
+func SampleRate() float64 {
+	return sampleRate.Load()
+}
+
+// SetUsers sets the comma separated user names whose statements are written to
+// the general log. An empty string means all users.
+func SetUsers(s string) {
+	var m map[string]struct{}
+	for _, u := range strings.Split(s, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			if m == nil {
+				m = make(map[string]struct{})
+			}
+			m[u] = struct{}{}
+		}
+	}
+	users.Store(&m)
+	usersStr.Store(s)
+}
+
+// Users returns the user filter of the general log.
+func Users() string {
+	return usersStr.Load()
+}
+
+// ParseOutput parses the comma separated output destinations, such as "FILE,TABLE".
+// It returns the normalized value.
+func ParseOutput(s string) (toFile, toTable bool, normalized string, err error) {
+	for _, o := range strings.Split(s, ",") {
+		switch strings.ToUpper(strings.TrimSpace(o)) {
+		case OutputFile:
+			toFile = true
+		case OutputTable:
+			toTable = true
+		default:
+			return false, false, "", errors.Errorf("invalid general log output '%s'", o)
+		}
+	}
+	switch {
+	case toFile && toTable:
+		normalized = OutputFile + "," + OutputTable
+	case toFile:
+		normalized = OutputFile
+	default:
+		normalized = OutputTable
+	}
+	return toFile, toTable, normalized, nil
+}
+
+// SetOutput sets the output destinations of the general log.
+func SetOutput(s string) error {
+	toFile, toTable, _, err := ParseOutput(s)
+	if err != nil {
+		return err
+	}
+	outputFile.Store(toFile)
+	outputTable.Store(toTable)
+	return nil
+}
+
+// Output returns the normalized output destinations of the general log.
+func Output() string {
+	switch toFile, toTable := outputFile.Load(), outputTable.Load(); {
+	case toFile && toTable:
+		return OutputFile + "," + OutputTable
+	case toTable:
+		return OutputTable
+	default:
+		return OutputFile
+	}
+}
+
+// OutputToFile returns whether the general log is written to the log file.
+func OutputToFile() bool {
+	return outputFile.Load()
+}
+
+// OutputToTable returns whether the general log is kept in INFORMATION_SCHEMA.TIDB_GENERAL_LOG.
+func OutputToTable() bool {
+	return outputTable.Load()
+}
+
+// ShouldLog returns whether a statement executed by the user should be written
+// to the general log, according to the user filter and the sample rate.
+func ShouldLog(user string) bool {
+	if m := users.Load(); m != nil && len(*m) > 0 {
+		if _, ok := (*m)[user]; !ok {
+			return false
+		}
+	}
+	threshold := sampleThreshold.Load()
+	if threshold >= math.MaxUint32 {
+		return true
+	}
+	return uint64(fastrand.Uint32()) < threshold
+}
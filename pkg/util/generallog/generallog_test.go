@@ -0,0 +1,104 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generallog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldLog(t *testing.T) {
+	defer func() {
+		SetSampleRate(1)
+		SetUsers("")
+	}()
+	require.True(t, ShouldLog("root"))
+
+	SetUsers(" u1, u2 ,")
+	require.Equal(t, " u1, u2 ,", Users())
+	require.True(t, ShouldLog("u1"))
+	require.True(t, ShouldLog("u2"))
+	require.False(t, ShouldLog("root"))
+	SetUsers("")
+	require.True(t, ShouldLog("root"))
+
+	SetSampleRate(0)
+	for i := 0; i < 100; i++ {
+		require.False(t, ShouldLog("root"))
+	}
+	SetSampleRate(0.5)
+	require.Equal(t, 0.5, SampleRate())
+	logged := 0
+	for i := 0; i < 10000; i++ {
+		if ShouldLog("root") {
+			logged++
+		}
+	}
+	require.Greater(t, logged, 4000)
+	require.Less(t, logged, 6000)
+}
+
+func TestOutput(t *testing.T) {
+	defer func() {
+		require.NoError(t, SetOutput(OutputFile))
+	}()
+	toFile, toTable, normalized, err := ParseOutput("table, file")
+	require.NoError(t, err)
+	require.True(t, toFile)
+	require.True(t, toTable)
+	require.Equal(t, "FILE,TABLE", normalized)
+	_, _, _, err = ParseOutput("file,none")
+	require.ErrorContains(t, err, "invalid general log output 'none'")
+
+	require.True(t, OutputToFile())
+	require.False(t, OutputToTable())
+	require.Equal(t, OutputFile, Output())
+	require.NoError(t, SetOutput("table"))
+	require.Equal(t, OutputTable, Output())
+	require.False(t, OutputToFile())
+	require.True(t, OutputToTable())
+	require.Error(t, SetOutput(""))
+	require.True(t, OutputToTable())
+}
+
+func TestHistory(t *testing.T) {
+	h := NewHistory(3)
+	require.Equal(t, 3, h.Capacity())
+	for i := 0; i < 5; i++ {
+		h.Push(&Record{Query: string(rune('a' + i))})
+	}
+	queries := func() (res []string) {
+		for _, r := range h.GetAll() {
+			res = append(res, r.Query)
+		}
+		return
+	}
+	require.Equal(t, []string{"c", "d", "e"}, queries())
+	require.Equal(t, uint64(5), h.GetAll()[2].ID)
+
+	h.Resize(2)
+	require.Equal(t, []string{"d", "e"}, queries())
+	h.Resize(4)
+	h.Push(&Record{Query: "f"})
+	require.Equal(t, []string{"d", "e", "f"}, queries())
+	require.Equal(t, uint64(6), h.GetAll()[2].ID)
+
+	h.Clear()
+	require.Empty(t, h.GetAll())
+	h.Resize(0)
+	h.Push(&Record{Query: "g"})
+	require.Empty(t, h.GetAll())
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generallog
+
+import (
+	"sync"
+	"time"
+)
+
+// Record is a statement in the general log.
+type Record struct {
+	// The ID is set when the record is pushed into the History.
+	ID     uint64
+	Time   time.Time
+	ConnID uint64
+	User   string
+	Host   string
+	DB     string
+	Query  string
+}
+
+// History keeps the most recent general log records. All its public APIs are thread safe.
+type History struct {
+	sync.RWMutex
+	records []*Record
+	// The valid records are records[head:head+size], wrapping around the end of the slice.
+	head      int
+	size      int
+	currentID uint64
+}
+
+// NewHistory creates a History with the capacity.
+func NewHistory(capacity uint) *History {
+	return &History{
+		records:   make([]*Record, capacity),
+		currentID: 1,
+	}
+}
+
+// GlobalHistory is the global History backing INFORMATION_SCHEMA.TIDB_GENERAL_LOG.
+// Its capacity is set by the tidb_general_log_table_size system variable.
+var GlobalHistory = NewHistory(DefaultTableSize)
+
+// DefaultTableSize is the default capacity of GlobalHistory.
+const DefaultTableSize = 1000
+
+// Resize changes the capacity of the History, keeping the most recent records.
+func (h *History) Resize(capacity uint) {
+	h.Lock()
+	defer h.Unlock()
+	if capacity == uint(len(h.records)) {
+		return
+	}
+	current := h.getAll()
+	if uint(len(current)) > capacity {
+		current = current[uint(len(current))-capacity:]
+	}
+	h.records = make([]*Record, capacity)
+	copy(h.records, current)
+	h.head = 0
+	h.size = len(current)
+}
+
+// Capacity returns the max number of records kept in the History.
+func (h *History) Capacity() int {
+	h.RLock()
+	defer h.RUnlock()
+	return len(h.records)
+}
+
+// Push pushes a record into the History, evicting the oldest one if it is full.
+// The record should not be modified after pushing.
+func (h *History) Push(r *Record) {
+	h.Lock()
+	defer h.Unlock()
+	capacity := len(h.records)
+	if capacity == 0 {
+		return
+	}
+	r.ID = h.currentID
+	h.currentID++
+	if h.size == capacity {
+		h.records[h.head] = r
+		h.head = (h.head + 1) % capacity
+	} else {
+		h.records[(h.head+h.size)%capacity] = r
+		h.size++
+	}
+}
+
+// GetAll returns all the records from the oldest to the newest.
+func (h *History) GetAll() []*Record {
+	h.RLock()
+	defer h.RUnlock()
+	return h.getAll()
+}
+
+func (h *History) getAll() []*Record {
+	res := make([]*Record, 0, h.size)
+	capacity := len(h.records)
+	if h.head+h.size <= capacity {
+		return append(res, h.records[h.head:h.head+h.size]...)
+	}
+	res = append(res, h.records[h.head:]...)
+	return append(res, h.records[:(h.head+h.size)%capacity]...)
+}
+
+// Clear removes all the records.
+func (h *History) Clear() {
+	h.Lock()
+	defer h.Unlock()
+	clear(h.records)
+	h.head = 0
+	h.size = 0
+}
@@ -0,0 +1,232 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hnsw implements the hierarchical navigable small world graph for the approximate
+// nearest neighbor search of vectors, see https://arxiv.org/abs/1603.09320.
+package hnsw
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+const (
+	// DefaultM is the default max number of the neighbors of a node on the upper layers.
+	DefaultM = 16
+	// DefaultEfConstruction is the default size of the dynamic candidate list when adding nodes.
+	DefaultEfConstruction = 100
+	// DefaultEfSearch is the default size of the dynamic candidate list when searching.
+	DefaultEfSearch = 64
+)
+
+// DistanceFunc returns the distance between two vectors of the same dimension.
+type DistanceFunc func(a, b types.VectorFloat32) float64
+
+// Neighbor is a node found by Search.
+type Neighbor struct {
+	// ID is the ID of the node returned by Add.
+	ID       int
+	Distance float64
+}
+
+type node struct {
+	vec types.VectorFloat32
+	// friends are the neighbors of the node on each layer.
+	friends [][]int
+}
+
+// Graph is a HNSW graph. It's not thread-safe.
+type Graph struct {
+	distance       DistanceFunc
+	m              int
+	mMax0          int
+	efConstruction int
+	levelMult      float64
+	rng            *rand.Rand
+
+	nodes    []*node
+	entry    int
+	maxLevel int
+}
+
+// NewGraph creates a HNSW graph with the distance function.
+func NewGraph(distance DistanceFunc) *Graph {
+	return &Graph{
+		distance:       distance,
+		m:              DefaultM,
+		mMax0:          DefaultM * 2,
+		efConstruction: DefaultEfConstruction,
+		levelMult:      1 / math.Log(DefaultM),
+		// A fixed seed makes the graph deterministic for the same sequence of vectors.
+		rng:      rand.New(rand.NewSource(1)),
+		maxLevel: -1,
+	}
+}
+
+// Len returns the number of the nodes in the graph.
+func (g *Graph) Len() int {
+	return len(g.nodes)
+}
+
+// Vector returns the vector of the node.
+func (g *Graph) Vector(id int) types.VectorFloat32 {
+	return g.nodes[id].vec
+}
+
+// Add adds a vector to the graph and returns the ID of the node, the IDs are allocated from 0.
+func (g *Graph) Add(vec types.VectorFloat32) int {
+	level := int(math.Floor(-math.Log(1-g.rng.Float64()) * g.levelMult))
+	id := len(g.nodes)
+	n := &node{vec: vec, friends: make([][]int, level+1)}
+	g.nodes = append(g.nodes, n)
+	if g.maxLevel < 0 {
+		g.entry, g.maxLevel = id, level
+		return id
+	}
+
+	ep := g.entry
+	for l := g.maxLevel; l > level; l-- {
+		ep = g.greedySearch(vec, ep, l)
+	}
+	for l := min(level, g.maxLevel); l >= 0; l-- {
+		candidates := g.searchLayer(vec, ep, g.efConstruction, l)
+		n.friends[l] = g.closest(candidates, g.m)
+		for _, friend := range n.friends[l] {
+			g.connect(friend, id, l)
+		}
+		ep = candidates[0].ID
+	}
+	if level > g.maxLevel {
+		g.entry, g.maxLevel = id, level
+	}
+	return id
+}
+
+// Search returns at most k nearest nodes of the query vector, ordered by the distance.
+// ef is the size of the dynamic candidate list, a larger ef has a better recall.
+func (g *Graph) Search(query types.VectorFloat32, k, ef int) []Neighbor {
+	if g.maxLevel < 0 || k <= 0 {
+		return nil
+	}
+	ep := g.entry
+	for l := g.maxLevel; l > 0; l-- {
+		ep = g.greedySearch(query, ep, l)
+	}
+	result := g.searchLayer(query, ep, max(ef, k), 0)
+	if len(result) > k {
+		result = result[:k]
+	}
+	return result
+}
+
+// greedySearch returns the closest node to the query on the layer, starting from ep.
+func (g *Graph) greedySearch(query types.VectorFloat32, ep int, level int) int {
+	dist := g.distance(query, g.nodes[ep].vec)
+	for changed := true; changed; {
+		changed = false
+		for _, friend := range g.nodes[ep].friends[level] {
+			if d := g.distance(query, g.nodes[friend].vec); d < dist {
+				ep, dist, changed = friend, d, true
+			}
+		}
+	}
+	return ep
+}
+
+// searchLayer returns at most ef closest nodes to the query on the layer, ordered by the distance.
+func (g *Graph) searchLayer(query types.VectorFloat32, ep int, ef int, level int) []Neighbor {
+	start := Neighbor{ID: ep, Distance: g.distance(query, g.nodes[ep].vec)}
+	visited := map[int]struct{}{ep: {}}
+	candidates := &neighborHeap{less: func(a, b Neighbor) bool { return a.Distance < b.Distance }}
+	results := &neighborHeap{less: func(a, b Neighbor) bool { return a.Distance > b.Distance }}
+	heap.Push(candidates, start)
+	heap.Push(results, start)
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(Neighbor)
+		if c.Distance > results.items[0].Distance {
+			break
+		}
+		for _, friend := range g.nodes[c.ID].friends[level] {
+			if _, ok := visited[friend]; ok {
+				continue
+			}
+			visited[friend] = struct{}{}
+			d := g.distance(query, g.nodes[friend].vec)
+			if results.Len() < ef || d < results.items[0].Distance {
+				heap.Push(candidates, Neighbor{ID: friend, Distance: d})
+				heap.Push(results, Neighbor{ID: friend, Distance: d})
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+	sort.Slice(results.items, func(i, j int) bool {
+		return results.items[i].Distance < results.items[j].Distance
+	})
+	return results.items
+}
+
+// connect adds the friend to the neighbors of the node on the layer, the farthest neighbors
+// are removed if there are too many neighbors.
+func (g *Graph) connect(id, friend int, level int) {
+	n := g.nodes[id]
+	n.friends[level] = append(n.friends[level], friend)
+	maxFriends := g.m
+	if level == 0 {
+		maxFriends = g.mMax0
+	}
+	if len(n.friends[level]) <= maxFriends {
+		return
+	}
+	neighbors := make([]Neighbor, 0, len(n.friends[level]))
+	for _, f := range n.friends[level] {
+		neighbors = append(neighbors, Neighbor{ID: f, Distance: g.distance(n.vec, g.nodes[f].vec)})
+	}
+	sort.Slice(neighbors, func(i, j int) bool { return neighbors[i].Distance < neighbors[j].Distance })
+	n.friends[level] = g.closest(neighbors, maxFriends)
+}
+
+// closest returns the IDs of the first m neighbors, which are ordered by the distance.
+func (*Graph) closest(neighbors []Neighbor, m int) []int {
+	ids := make([]int, 0, min(len(neighbors), m))
+	for i := 0; i < len(neighbors) && i < m; i++ {
+		ids = append(ids, neighbors[i].ID)
+	}
+	return ids
+}
+
+type neighborHeap struct {
+	items []Neighbor
+	less  func(a, b Neighbor) bool
+}
+
+func (h *neighborHeap) Len() int           { return len(h.items) }
+func (h *neighborHeap) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *neighborHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *neighborHeap) Push(x any) {
+	h.items = append(h.items, x.(Neighbor))
+}
+
+func (h *neighborHeap) Pop() any {
+	n := len(h.items)
+	item := h.items[n-1]
+	h.items = h.items[:n-1]
+	return item
+}
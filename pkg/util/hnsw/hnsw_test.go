@@ -0,0 +1,97 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hnsw
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func l2Distance(a, b types.VectorFloat32) float64 {
+	d, _ := a.L2Distance(b)
+	return d
+}
+
+func TestSearch(t *testing.T) {
+	g := NewGraph(l2Distance)
+	require.Empty(t, g.Search(types.VectorFloat32{1, 2}, 3, DefaultEfSearch))
+
+	g.Add(types.VectorFloat32{0, 0})
+	g.Add(types.VectorFloat32{10, 10})
+	g.Add(types.VectorFloat32{1, 1})
+	require.Equal(t, 3, g.Len())
+	result := g.Search(types.VectorFloat32{2, 2}, 2, DefaultEfSearch)
+	require.Len(t, result, 2)
+	require.Equal(t, 2, result[0].ID)
+	require.Equal(t, 0, result[1].ID)
+	require.InDelta(t, 1.414, result[0].Distance, 0.001)
+	require.Equal(t, types.VectorFloat32{1, 1}, g.Vector(2))
+	require.Len(t, g.Search(types.VectorFloat32{2, 2}, 10, DefaultEfSearch), 3)
+}
+
+func TestRecall(t *testing.T) {
+	const (
+		dims    = 16
+		count   = 2000
+		queries = 50
+		k       = 10
+	)
+	rng := rand.New(rand.NewSource(0))
+	randomVector := func() types.VectorFloat32 {
+		v := make(types.VectorFloat32, dims)
+		for i := range v {
+			v[i] = rng.Float32()
+		}
+		return v
+	}
+	g := NewGraph(l2Distance)
+	vecs := make([]types.VectorFloat32, 0, count)
+	for i := 0; i < count; i++ {
+		vec := randomVector()
+		require.Equal(t, i, g.Add(vec))
+		vecs = append(vecs, vec)
+	}
+
+	found := 0
+	for i := 0; i < queries; i++ {
+		query := randomVector()
+		ids := make([]int, count)
+		for j := range ids {
+			ids[j] = j
+		}
+		sort.Slice(ids, func(a, b int) bool {
+			return l2Distance(query, vecs[ids[a]]) < l2Distance(query, vecs[ids[b]])
+		})
+		exact := make(map[int]struct{}, k)
+		for _, id := range ids[:k] {
+			exact[id] = struct{}{}
+		}
+		result := g.Search(query, k, DefaultEfSearch)
+		require.Len(t, result, k)
+		for j, n := range result {
+			if j > 0 {
+				require.LessOrEqual(t, result[j-1].Distance, n.Distance)
+			}
+			if _, ok := exact[n.ID]; ok {
+				found++
+			}
+		}
+	}
+	require.Greater(t, float64(found)/float64(queries*k), 0.9)
+}
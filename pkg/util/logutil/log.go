@@ -48,6 +48,10 @@ const (
 	DefaultRecordPlanInSlowLog = 1
 	// DefaultTiDBEnableSlowLog enables TiDB to log slow queries.
 	DefaultTiDBEnableSlowLog = true
+	// SlowLogFormatText is the default slow log format, which is compatible with MySQL.
+	SlowLogFormatText = "text"
+	// SlowLogFormatJSON is the slow log format which writes each slow query as a JSON line.
+	SlowLogFormatJSON = "json"
 )
 
 const (
@@ -81,6 +85,13 @@ type LogConfig struct {
 
 	// SlowQueryFile filename, default to File log config on empty.
 	SlowQueryFile string
+	// SlowQueryFormat is the format of the slow query log, one of text or json.
+	SlowQueryFormat string
+	// SlowQueryRotateInterval rotates the slow query log file periodically, 0 means
+	// the file is only rotated by size.
+	SlowQueryRotateInterval time.Duration
+	// SlowQueryCompress compresses the rotated slow query log files using gzip.
+	SlowQueryCompress bool
 }
 
 // NewLogConfig creates a LogConfig.
@@ -20,8 +20,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pingcap/log"
@@ -209,6 +211,63 @@ func TestSlowQueryLoggerCreation(t *testing.T) {
 	require.Equal(t, fileConf.FileLogConfig, slowQueryConf.File)
 }
 
+func TestSlowQueryJSONLogger(t *testing.T) {
+	conf := NewLogConfig("info", DefaultLogFormat, "", EmptyFileLogConfig, false)
+	conf.SlowQueryFormat = "xml"
+	_, _, err := newSlowQueryLogger(conf)
+	require.Error(t, err)
+
+	conf.SlowQueryFormat = SlowLogFormatJSON
+	_, _, err = newSlowQueryLogger(conf)
+	require.NoError(t, err)
+	require.True(t, IsSlowQueryLogJSON())
+	conf.SlowQueryFormat = SlowLogFormatText
+	_, _, err = newSlowQueryLogger(conf)
+	require.NoError(t, err)
+	require.False(t, IsSlowQueryLogJSON())
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	enc := &slowLogJSONEncoder{}
+	buf, err := enc.EncodeEntry(zapcore.Entry{Time: ts, Message: `{"Query":"select 1;"}`}, nil)
+	require.NoError(t, err)
+	require.Equal(t, `{"Time":"2024-01-02T03:04:05Z","Query":"select 1;"}`+"\n", buf.String())
+	buf, err = enc.EncodeEntry(zapcore.Entry{Time: ts, Message: `{}`}, nil)
+	require.NoError(t, err)
+	require.Equal(t, `{"Time":"2024-01-02T03:04:05Z"}`+"\n", buf.String())
+}
+
+func TestSlowQueryFileWriterRotate(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "tidb-slow.log")
+	cfg := &log.Config{File: log.FileLogConfig{Filename: filename}}
+	w := newSlowQueryFileWriter(cfg, time.Hour, false)
+	defer func() {
+		require.NoError(t, w.logger.Close())
+	}()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local)
+	w.nowFunc = func() time.Time { return now }
+
+	countFiles := func() int {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		return len(entries)
+	}
+	_, err := w.Write([]byte("a\n"))
+	require.NoError(t, err)
+	now = now.Add(30 * time.Minute)
+	_, err = w.Write([]byte("b\n"))
+	require.NoError(t, err)
+	require.Equal(t, 1, countFiles())
+	// Crossing the boundary of the interval rotates the file.
+	now = now.Add(30 * time.Minute)
+	_, err = w.Write([]byte("c\n"))
+	require.NoError(t, err)
+	require.Equal(t, 2, countFiles())
+	content, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, "c\n", string(content))
+}
+
 func TestGlobalLoggerReplace(t *testing.T) {
 	fileCfg := FileLogConfig{log.FileLogConfig{Filename: "zap_log", MaxDays: 0, MaxSize: 4096}}
 	conf := NewLogConfig("info", DefaultLogFormat, "", fileCfg, false)
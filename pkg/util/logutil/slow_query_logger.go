@@ -16,34 +16,117 @@ package logutil
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"go.uber.org/zap/buffer"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var _pool = buffer.NewPool()
 
+// slowQueryLogJSON indicates whether the slow query logger writes JSON lines.
+var slowQueryLogJSON atomic.Bool
+
+// IsSlowQueryLogJSON returns whether the slow query log is in JSON format.
+func IsSlowQueryLogJSON() bool {
+	return slowQueryLogJSON.Load()
+}
+
 func newSlowQueryLogger(cfg *LogConfig) (*zap.Logger, *log.ZapProperties, error) {
+	var isJSON bool
+	switch strings.ToLower(cfg.SlowQueryFormat) {
+	case "", SlowLogFormatText:
+	case SlowLogFormatJSON:
+		isJSON = true
+	default:
+		return nil, nil, errors.Errorf("invalid slow query log format %s", cfg.SlowQueryFormat)
+	}
+
 	// create the slow query logger
-	sqLogger, prop, err := log.InitLogger(newSlowQueryLogConfig(cfg))
+	sqConfig := newSlowQueryLogConfig(cfg)
+	var sqLogger *zap.Logger
+	var prop *log.ZapProperties
+	var err error
+	if len(sqConfig.File.Filename) > 0 && (cfg.SlowQueryRotateInterval > 0 || cfg.SlowQueryCompress) {
+		output := zapcore.AddSync(newSlowQueryFileWriter(sqConfig, cfg.SlowQueryRotateInterval, cfg.SlowQueryCompress))
+		sqLogger, prop, err = log.InitLoggerWithWriteSyncer(sqConfig, output, output)
+	} else {
+		sqLogger, prop, err = log.InitLogger(sqConfig)
+	}
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
 
 	// replace 2018-12-19-unified-log-format text encoder with slow log encoder
-	newCore := log.NewTextCore(&slowLogEncoder{}, prop.Syncer, prop.Level)
+	var encoder zapcore.Encoder = &slowLogEncoder{}
+	if isJSON {
+		encoder = &slowLogJSONEncoder{}
+	}
+	newCore := log.NewTextCore(encoder, prop.Syncer, prop.Level)
 	sqLogger = sqLogger.WithOptions(zap.WrapCore(func(zapcore.Core) zapcore.Core {
 		return newCore
 	}))
 	prop.Core = newCore
+	slowQueryLogJSON.Store(isJSON)
 
 	return sqLogger, prop, nil
 }
 
+// slowQueryFileWriter writes the slow query log file, it rotates the file not only
+// by size but also by the time interval, and compresses the rotated files if needed.
+type slowQueryFileWriter struct {
+	mu       sync.Mutex
+	logger   *lumberjack.Logger
+	interval time.Duration
+	// nextRotate is the time when the file should be rotated next time.
+	nextRotate time.Time
+	nowFunc    func() time.Time
+}
+
+func newSlowQueryFileWriter(cfg *log.Config, interval time.Duration, compress bool) *slowQueryFileWriter {
+	maxSize := cfg.File.MaxSize
+	if maxSize == 0 {
+		maxSize = DefaultLogMaxSize
+	}
+	return &slowQueryFileWriter{
+		logger: &lumberjack.Logger{
+			Filename:   cfg.File.Filename,
+			MaxSize:    maxSize,
+			MaxBackups: cfg.File.MaxBackups,
+			MaxAge:     cfg.File.MaxDays,
+			LocalTime:  true,
+			Compress:   compress,
+		},
+		interval: interval,
+		nowFunc:  time.Now,
+	}
+}
+
+// Write implements io.Writer.
+func (w *slowQueryFileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.interval > 0 {
+		now := w.nowFunc()
+		if !w.nextRotate.IsZero() && !now.Before(w.nextRotate) {
+			if err := w.logger.Rotate(); err != nil {
+				return 0, err
+			}
+		}
+		if w.nextRotate.IsZero() || !now.Before(w.nextRotate) {
+			w.nextRotate = now.Truncate(w.interval).Add(w.interval)
+		}
+	}
+	return w.logger.Write(p)
+}
+
 func newSlowQueryLogConfig(cfg *LogConfig) *log.Config {
 	// copy the global log config to slow log config
 	// if the filename of slow log config is empty, slow log will behave the same as global log.
@@ -67,6 +150,28 @@ func (*slowLogEncoder) EncodeEntry(entry zapcore.Entry, _ []zapcore.Field) (*buf
 	return b, nil
 }
 
+// slowLogJSONEncoder writes each slow query as a JSON line. The message should be
+// a JSON object, and the "Time" field is added as the first field of it.
+type slowLogJSONEncoder struct {
+	slowLogEncoder
+}
+
+func (*slowLogJSONEncoder) EncodeEntry(entry zapcore.Entry, _ []zapcore.Field) (*buffer.Buffer, error) {
+	b := _pool.Get()
+	msg := strings.TrimSpace(entry.Message)
+	fmt.Fprintf(b, "{\"Time\":\"%s\"", entry.Time.Format(SlowLogTimeFormat))
+	if strings.HasPrefix(msg, "{") && strings.HasSuffix(msg, "}") {
+		if body := strings.TrimSpace(msg[1 : len(msg)-1]); len(body) > 0 {
+			b.AppendByte(',')
+			b.AppendString(body)
+		}
+	}
+	b.AppendString("}\n")
+	return b, nil
+}
+
+func (e *slowLogJSONEncoder) Clone() zapcore.Encoder { return e }
+
 func (e *slowLogEncoder) Clone() zapcore.Encoder                        { return e }
 func (*slowLogEncoder) AddArray(string, zapcore.ArrayMarshaler) error   { return nil }
 func (*slowLogEncoder) AddObject(string, zapcore.ObjectMarshaler) error { return nil }
@@ -860,6 +860,8 @@ const (
 	LabelForChunkDataInDiskByChunks int = -30
 	// LabelForSortPartition represents the label of the sort partition
 	LabelForSortPartition = -31
+	// LabelForAfterTriggerRows represents the label of the rows buffered for the AFTER triggers
+	LabelForAfterTriggerRows int = -32
 )
 
 // MetricsTypes is used to get label for metrics
@@ -152,3 +152,16 @@ func RestoreWithoutDB(node ast.StmtNode) string {
 	}
 	return sb.String()
 }
+
+// RestoreWithTableWildcard returns restore strings for StmtNode without schema name, and all the table names are
+// restored as the wildcard `*`.
+// This function is customized for SQL bindings with table name wildcards.
+func RestoreWithTableWildcard(node ast.StmtNode) string {
+	var sb strings.Builder
+	ctx := format.NewRestoreCtx(defaultRestoreFlag|format.RestoreWithoutSchemaName|format.RestoreTableNameAsWildcard, &sb)
+	if err := node.Restore(ctx); err != nil {
+		logutil.BgLogger().Debug("restore SQL failed", zap.String("category", "sql-bind"), zap.Error(err))
+		return ""
+	}
+	return sb.String()
+}
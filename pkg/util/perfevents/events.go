@@ -0,0 +1,404 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package perfevents records the statement and wait events of the connections,
+// which back the events_statements_* and events_waits_* tables in PERFORMANCE_SCHEMA.
+package perfevents
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"go.uber.org/atomic"
+)
+
+const (
+	// HistorySize is the number of events kept per connection, the same as
+	// performance_schema_events_statements_history_size in MySQL.
+	HistorySize = 10
+	// HistoryLongSize is the number of statement events kept globally, the same as
+	// performance_schema_events_statements_history_long_size in MySQL.
+	HistoryLongSize = 10000
+
+	// globalShards is the number of shards of the global events, the connections
+	// are mapped to the shards by their IDs so that they don't contend for one lock.
+	globalShards = 16
+)
+
+// Wait event names.
+const (
+	WaitKVRequest        = "wait/io/tikv/kv_request"
+	WaitPDRequest        = "wait/io/pd/request"
+	WaitRocksDBBlockRead = "wait/io/tikv/rocksdb_block_read"
+	WaitPessimisticLock  = "wait/lock/tikv/pessimistic_lock"
+	WaitBackoff          = "wait/synch/tikv/backoff"
+)
+
+// serverStart is the origin of the timer values, which are in picoseconds like MySQL.
+var serverStart = time.Now()
+
+// Timer converts the time to the picoseconds elapsed since the server started.
+func Timer(t time.Time) uint64 {
+	if t.Before(serverStart) {
+		return 0
+	}
+	return uint64(t.Sub(serverStart).Nanoseconds()) * 1000
+}
+
+// StatementEventName returns the event name of a statement by its label, such
+// as "statement/sql/create_table" for "CreateTable".
+func StatementEventName(stmtLabel string) string {
+	var sb strings.Builder
+	sb.WriteString("statement/sql/")
+	for i, c := range stmtLabel {
+		if unicode.IsUpper(c) {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			c = unicode.ToLower(c)
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+// StatementEvent is a statement executed by a connection.
+type StatementEvent struct {
+	ThreadID uint64
+	EventID  uint64
+	// EndEventID is 0 if the statement is still running.
+	EndEventID    uint64
+	EventName     string
+	User          string
+	StartTime     time.Time
+	EndTime       time.Time
+	LockTime      time.Duration
+	SQLText       string
+	Digest        string
+	DigestText    string
+	CurrentSchema string
+	MySQLErrno    uint16
+	SQLState      string
+	MessageText   string
+	Errors        uint64
+	Warnings      uint64
+	RowsAffected  uint64
+	RowsSent      uint64
+	RowsExamined  uint64
+}
+
+// Running returns whether the statement is still running.
+func (e *StatementEvent) Running() bool {
+	return e.EndEventID == 0
+}
+
+// WaitEvent is the time a statement spent waiting for a kind of resource.
+// The waits of the same kind in a statement are aggregated into one event.
+type WaitEvent struct {
+	ThreadID       uint64
+	EventID        uint64
+	EventName      string
+	Operation      string
+	User           string
+	Count          uint64
+	Duration       time.Duration
+	NestingEventID uint64
+}
+
+// WaitSummary is the aggregated waits of an event name.
+type WaitSummary struct {
+	EventName string
+	Count     uint64
+	Sum       time.Duration
+	Min       time.Duration
+	Max       time.Duration
+}
+
+type thread struct {
+	sync.Mutex
+	nextEventID  uint64
+	current      StatementEvent
+	hasCurrent   bool
+	stmtHistory  *ring[StatementEvent]
+	waitsHistory *ring[WaitEvent]
+}
+
+// globalShard keeps a part of the global statement history and the wait summaries.
+type globalShard struct {
+	sync.Mutex
+	stmtHistoryLong *ring[StatementEvent]
+	waitSummaries   map[string]*WaitSummary
+}
+
+func newGlobalShards() []*globalShard {
+	shards := make([]*globalShard, globalShards)
+	for i := range shards {
+		shards[i] = &globalShard{
+			stmtHistoryLong: newRing[StatementEvent](HistoryLongSize / globalShards),
+			waitSummaries:   make(map[string]*WaitSummary),
+		}
+	}
+	return shards
+}
+
+var (
+	enabled = atomic.NewBool(false)
+
+	// threadsMu only protects the threads map, the events of a thread are protected by its own lock.
+	threadsMu sync.RWMutex
+	threads   = make(map[uint64]*thread)
+
+	// shards keep the global events, each shard is protected by its own lock.
+	shards = newGlobalShards()
+)
+
+// SetEnabled enables or disables recording the events.
+func SetEnabled(v bool) {
+	enabled.Store(v)
+}
+
+// Enabled returns whether the events are recorded.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+func getOrCreateThread(threadID uint64) *thread {
+	threadsMu.RLock()
+	t, ok := threads[threadID]
+	threadsMu.RUnlock()
+	if ok {
+		return t
+	}
+	threadsMu.Lock()
+	defer threadsMu.Unlock()
+	if t, ok = threads[threadID]; !ok {
+		t = &thread{
+			nextEventID:  1,
+			stmtHistory:  newRing[StatementEvent](HistorySize),
+			waitsHistory: newRing[WaitEvent](HistorySize),
+		}
+		threads[threadID] = t
+	}
+	return t
+}
+
+// StartStatement records that the connection starts executing the statement.
+// The EventID of e is assigned by it.
+func StartStatement(e *StatementEvent) {
+	if !enabled.Load() {
+		return
+	}
+	t := getOrCreateThread(e.ThreadID)
+	t.Lock()
+	defer t.Unlock()
+	e.EventID = t.nextEventID
+	e.EndEventID = 0
+	t.nextEventID++
+	t.current = *e
+	t.hasCurrent = true
+}
+
+// EndStatement records that the statement is finished, along with the waits
+// during its execution. The statement keeps being the current one of the
+// connection until the next statement starts.
+func EndStatement(e *StatementEvent, waits []WaitEvent) {
+	if !enabled.Load() {
+		return
+	}
+	t := getOrCreateThread(e.ThreadID)
+	t.Lock()
+	if t.hasCurrent && t.current.Running() {
+		e.EventID = t.current.EventID
+	} else {
+		e.EventID = t.nextEventID
+		t.nextEventID++
+	}
+	for i := range waits {
+		w := &waits[i]
+		w.ThreadID = e.ThreadID
+		w.User = e.User
+		w.EventID = t.nextEventID
+		w.NestingEventID = e.EventID
+		t.nextEventID++
+		t.waitsHistory.push(*w)
+	}
+	e.EndEventID = t.nextEventID
+	t.nextEventID++
+	t.current = *e
+	t.hasCurrent = true
+	t.stmtHistory.push(*e)
+	t.Unlock()
+
+	shard := shards[e.ThreadID%globalShards]
+	shard.Lock()
+	defer shard.Unlock()
+	shard.stmtHistoryLong.push(*e)
+	for i := range waits {
+		w := &waits[i]
+		s, ok := shard.waitSummaries[w.EventName]
+		if !ok {
+			s = &WaitSummary{EventName: w.EventName, Min: w.Duration}
+			shard.waitSummaries[w.EventName] = s
+		}
+		s.Count += w.Count
+		s.Sum += w.Duration
+		s.Min = min(s.Min, w.Duration)
+		s.Max = max(s.Max, w.Duration)
+	}
+}
+
+// RemoveThread removes the current and history events of a closed connection.
+// The events are still kept in the global history.
+func RemoveThread(threadID uint64) {
+	threadsMu.Lock()
+	defer threadsMu.Unlock()
+	delete(threads, threadID)
+}
+
+// sortedThreads returns the threads ordered by their IDs.
+func sortedThreads() []*thread {
+	threadsMu.RLock()
+	ids := make([]uint64, 0, len(threads))
+	for id := range threads {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	res := make([]*thread, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, threads[id])
+	}
+	threadsMu.RUnlock()
+	return res
+}
+
+// StatementsCurrent returns the current statement of every connection.
+func StatementsCurrent() []StatementEvent {
+	ts := sortedThreads()
+	res := make([]StatementEvent, 0, len(ts))
+	for _, t := range ts {
+		t.Lock()
+		if t.hasCurrent {
+			res = append(res, t.current)
+		}
+		t.Unlock()
+	}
+	return res
+}
+
+// StatementsHistory returns the recent statements of every connection.
+func StatementsHistory() []StatementEvent {
+	var res []StatementEvent
+	for _, t := range sortedThreads() {
+		t.Lock()
+		res = t.stmtHistory.appendTo(res)
+		t.Unlock()
+	}
+	return res
+}
+
+// StatementsHistoryLong returns the recent statements of all connections ordered by their end time.
+func StatementsHistoryLong() []StatementEvent {
+	var res []StatementEvent
+	for _, shard := range shards {
+		shard.Lock()
+		res = shard.stmtHistoryLong.appendTo(res)
+		shard.Unlock()
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].EndTime.Before(res[j].EndTime) })
+	return res
+}
+
+// WaitsHistory returns the recent wait events of every connection.
+func WaitsHistory() []WaitEvent {
+	var res []WaitEvent
+	for _, t := range sortedThreads() {
+		t.Lock()
+		res = t.waitsHistory.appendTo(res)
+		t.Unlock()
+	}
+	return res
+}
+
+// WaitsSummary returns the aggregated waits of every event name.
+func WaitsSummary() []WaitSummary {
+	summaries := make(map[string]*WaitSummary)
+	for _, shard := range shards {
+		shard.Lock()
+		for name, s := range shard.waitSummaries {
+			merged, ok := summaries[name]
+			if !ok {
+				merged = &WaitSummary{EventName: name, Min: s.Min}
+				summaries[name] = merged
+			}
+			merged.Count += s.Count
+			merged.Sum += s.Sum
+			merged.Min = min(merged.Min, s.Min)
+			merged.Max = max(merged.Max, s.Max)
+		}
+		shard.Unlock()
+	}
+	res := make([]WaitSummary, 0, len(summaries))
+	for _, s := range summaries {
+		res = append(res, *s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].EventName < res[j].EventName })
+	return res
+}
+
+// Reset removes all the recorded events. It is only used in tests.
+func Reset() {
+	threadsMu.Lock()
+	threads = make(map[uint64]*thread)
+	threadsMu.Unlock()
+	for _, shard := range shards {
+		shard.Lock()
+		shard.stmtHistoryLong = newRing[StatementEvent](HistoryLongSize / globalShards)
+		shard.waitSummaries = make(map[string]*WaitSummary)
+		shard.Unlock()
+	}
+}
+
+// ring keeps the most recent items pushed into it. It is not thread safe.
+type ring[T any] struct {
+	items []T
+	head  int
+	size  int
+}
+
+func newRing[T any](capacity int) *ring[T] {
+	return &ring[T]{items: make([]T, capacity)}
+}
+
+func (r *ring[T]) push(item T) {
+	capacity := len(r.items)
+	if r.size == capacity {
+		r.items[r.head] = item
+		r.head = (r.head + 1) % capacity
+		return
+	}
+	r.items[(r.head+r.size)%capacity] = item
+	r.size++
+}
+
+// appendTo appends the items to res from the oldest to the newest.
+func (r *ring[T]) appendTo(res []T) []T {
+	capacity := len(r.items)
+	for i := 0; i < r.size; i++ {
+		res = append(res, r.items[(r.head+i)%capacity])
+	}
+	return res
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perfevents
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementEventName(t *testing.T) {
+	require.Equal(t, "statement/sql/select", StatementEventName("Select"))
+	require.Equal(t, "statement/sql/create_table", StatementEventName("CreateTable"))
+	require.Equal(t, "statement/sql/", StatementEventName(""))
+}
+
+func TestStatementEvents(t *testing.T) {
+	defer func() {
+		SetEnabled(false)
+		Reset()
+	}()
+	Reset()
+	SetEnabled(true)
+
+	e := &StatementEvent{ThreadID: 2, SQLText: "select 1", StartTime: time.Now()}
+	StartStatement(e)
+	require.Equal(t, uint64(1), e.EventID)
+	cur := StatementsCurrent()
+	require.Len(t, cur, 1)
+	require.True(t, cur[0].Running())
+	require.Empty(t, StatementsHistory())
+
+	EndStatement(e, []WaitEvent{
+		{EventName: WaitKVRequest, Count: 1, Duration: time.Millisecond},
+		{EventName: WaitPessimisticLock, Count: 1, Duration: 2 * time.Millisecond},
+	})
+	require.Equal(t, uint64(1), e.EventID)
+	require.Equal(t, uint64(4), e.EndEventID)
+	cur = StatementsCurrent()
+	require.Len(t, cur, 1)
+	require.False(t, cur[0].Running())
+	require.Len(t, StatementsHistory(), 1)
+	waits := WaitsHistory()
+	require.Len(t, waits, 2)
+	require.Equal(t, uint64(2), waits[0].EventID)
+	require.Equal(t, uint64(1), waits[0].NestingEventID)
+	require.Equal(t, uint64(2), waits[1].ThreadID)
+
+	// A statement ended without being started is still recorded.
+	EndStatement(&StatementEvent{ThreadID: 1, SQLText: "select 2"}, []WaitEvent{
+		{EventName: WaitKVRequest, Count: 2, Duration: 3 * time.Millisecond},
+	})
+	cur = StatementsCurrent()
+	require.Len(t, cur, 2)
+	require.Equal(t, uint64(1), cur[0].ThreadID)
+	require.Equal(t, uint64(1), cur[0].EventID)
+	require.Len(t, StatementsHistoryLong(), 2)
+	require.Equal(t, []WaitSummary{
+		{EventName: WaitKVRequest, Count: 3, Sum: 4 * time.Millisecond, Min: time.Millisecond, Max: 3 * time.Millisecond},
+		{EventName: WaitPessimisticLock, Count: 1, Sum: 2 * time.Millisecond, Min: 2 * time.Millisecond, Max: 2 * time.Millisecond},
+	}, WaitsSummary())
+
+	RemoveThread(2)
+	require.Len(t, StatementsCurrent(), 1)
+	require.Len(t, StatementsHistory(), 1)
+	require.Len(t, StatementsHistoryLong(), 2)
+}
+
+func TestHistoryBounded(t *testing.T) {
+	defer func() {
+		SetEnabled(false)
+		Reset()
+	}()
+	Reset()
+	SetEnabled(true)
+
+	for i := 0; i < HistorySize+5; i++ {
+		EndStatement(&StatementEvent{ThreadID: 1, SQLText: fmt.Sprintf("select %d", i)}, nil)
+	}
+	history := StatementsHistory()
+	require.Len(t, history, HistorySize)
+	require.Equal(t, "select 5", history[0].SQLText)
+	require.Equal(t, fmt.Sprintf("select %d", HistorySize+4), history[HistorySize-1].SQLText)
+
+	// The global history of the connections in different shards is ordered by the end time.
+	Reset()
+	start := time.Now()
+	for i := 0; i < 2*globalShards; i++ {
+		EndStatement(&StatementEvent{ThreadID: uint64(2*globalShards - i), EndTime: start.Add(time.Duration(i))}, nil)
+	}
+	historyLong := StatementsHistoryLong()
+	require.Len(t, historyLong, 2*globalShards)
+	for i, e := range historyLong {
+		require.Equal(t, uint64(2*globalShards-i), e.ThreadID)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	defer Reset()
+	Reset()
+	// The events are not recorded by default.
+	require.False(t, Enabled())
+	e := &StatementEvent{ThreadID: 1}
+	StartStatement(e)
+	EndStatement(e, nil)
+	require.Empty(t, StatementsCurrent())
+	require.Empty(t, StatementsHistoryLong())
+}
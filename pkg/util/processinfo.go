@@ -16,9 +16,11 @@ package util
 
 import (
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"net"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/pingcap/tidb/pkg/parser/auth"
@@ -40,27 +42,31 @@ type OOMAlarmVariablesInfo struct {
 
 // ProcessInfo is a struct used for show processlist statement.
 type ProcessInfo struct {
-	Time                  time.Time
-	ExpensiveLogTime      time.Time
-	ExpensiveTxnLogTime   time.Time
-	CurTxnCreateTime      time.Time
-	Plan                  any
-	StmtCtx               *stmtctx.StatementContext
-	RefCountOfStmtCtx     *stmtctx.ReferenceCount
-	MemTracker            *memory.Tracker
-	DiskTracker           *disk.Tracker
-	StatsInfo             func(any) map[string]uint64
-	RuntimeStatsColl      *execdetails.RuntimeStatsColl
-	User                  string
-	Digest                string
-	Host                  string
-	DB                    string
-	Info                  string
-	Port                  string
-	ResourceGroupName     string
-	SessionAlias          string
-	IndexNames            []string
-	TableIDs              []int64
+	Time                time.Time
+	ExpensiveLogTime    time.Time
+	ExpensiveTxnLogTime time.Time
+	CurTxnCreateTime    time.Time
+	Plan                any
+	StmtCtx             *stmtctx.StatementContext
+	RefCountOfStmtCtx   *stmtctx.ReferenceCount
+	MemTracker          *memory.Tracker
+	DiskTracker         *disk.Tracker
+	StatsInfo           func(any) map[string]uint64
+	RuntimeStatsColl    *execdetails.RuntimeStatsColl
+	User                string
+	Digest              string
+	Host                string
+	DB                  string
+	Info                string
+	Port                string
+	ResourceGroupName   string
+	SessionAlias        string
+	IndexNames          []string
+	TableIDs            []int64
+	// ConnectAttrs is the connection attributes sent by the client in the handshake.
+	ConnectAttrs map[string]string
+	// CompressionStats is nil if the connection doesn't use the protocol compression.
+	CompressionStats      *CompressionStats
 	PlanExplainRows       [][]string
 	OOMAlarmVariablesInfo OOMAlarmVariablesInfo
 	ID                    uint64
@@ -71,6 +77,9 @@ type ProcessInfo struct {
 	State            uint16
 	Command          byte
 	RedactSQL        bool
+	// GCProtected indicates the session enables tidb_enable_query_gc_protection, so CurTxnStartTS delays the
+	// GC safe point until tidb_query_gc_protection_max_time instead of tidb_gc_max_wait_time.
+	GCProtected bool
 }
 
 // Clone return a shallow clone copy of this processInfo.
@@ -138,7 +147,41 @@ func (pi *ProcessInfo) ToRow(tz *time.Location) []any {
 			diskConsumed = pi.DiskTracker.BytesConsumed()
 		}
 	}
-	return append(pi.ToRowForShow(true), pi.Digest, bytesConsumed, diskConsumed, pi.txnStartTs(tz), pi.ResourceGroupName, pi.SessionAlias)
+	row := append(pi.ToRowForShow(true), pi.Digest, bytesConsumed, diskConsumed, pi.txnStartTs(tz), pi.ResourceGroupName, pi.SessionAlias,
+		pi.ConnectAttrs[ProgramNameAttr], pi.connectAttrsJSON())
+	if stats := pi.CompressionStats; stats != nil {
+		return append(row, stats.Algorithm, stats.BytesSent.Load(), stats.CompressedBytesSent.Load(),
+			stats.BytesReceived.Load(), stats.CompressedBytesReceived.Load())
+	}
+	return append(row, "", nil, nil, nil, nil)
+}
+
+// CompressionStats counts the bytes of the MySQL protocol packets of a connection before and after compression.
+type CompressionStats struct {
+	// Algorithm is the name of the compression algorithm, such as "zlib" and "zstd".
+	Algorithm string
+	// BytesSent and BytesReceived are the bytes of the packets before compression.
+	BytesSent     atomic.Uint64
+	BytesReceived atomic.Uint64
+	// CompressedBytesSent and CompressedBytesReceived are the bytes of the compressed packets, including the headers.
+	CompressedBytesSent     atomic.Uint64
+	CompressedBytesReceived atomic.Uint64
+}
+
+// ProgramNameAttr is the connection attribute identifying the client application.
+const ProgramNameAttr = "program_name"
+
+// connectAttrsJSON returns the connection attributes as a JSON object, or nil if there are no attributes.
+func (pi *ProcessInfo) connectAttrsJSON() any {
+	if len(pi.ConnectAttrs) == 0 {
+		return nil
+	}
+	// The keys of a map are sorted by json.Marshal, so the output is stable.
+	b, err := json.Marshal(pi.ConnectAttrs)
+	if err != nil {
+		return nil
+	}
+	return string(b)
 }
 
 // ascServerStatus is a slice of all defined server status in ascending order.
@@ -189,6 +232,17 @@ func serverStatus2Str(state uint16) string {
 	return strings.Join(l, "; ")
 }
 
+// UserResourceUsage is the resource usage of an account on this instance, which is
+// limited by the MAX_USER_CONNECTIONS and MAX_QUERIES_PER_HOUR resource options.
+type UserResourceUsage struct {
+	User        string
+	Host        string
+	Connections int64
+	// Questions is the number of statements since HourStart.
+	Questions int64
+	HourStart time.Time
+}
+
 // SessionManager is an interface for session manage. Show processlist and
 // kill statement rely on this interface.
 type SessionManager interface {
@@ -213,4 +267,6 @@ type SessionManager interface {
 	KillNonFlashbackClusterConn()
 	// GetConAttrs gets the connection attributes
 	GetConAttrs(user *auth.UserIdentity) map[uint64]map[string]string
+	// GetUserResourceUsage gets the resource usage of the accounts
+	GetUserResourceUsage() []*UserResourceUsage
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/pkg/util/memory"
@@ -65,3 +66,39 @@ func TestProcessInfoShallowCP(t *testing.T) {
 	require.True(t, cp.RedactSQL == info.RedactSQL)
 	require.True(t, cp.SessionAlias == info.SessionAlias)
 }
+
+func TestProcessInfoConnectAttrs(t *testing.T) {
+	info := &ProcessInfo{
+		ID:      1,
+		User:    "root",
+		Host:    "127.0.0.1",
+		StmtCtx: stmtctx.NewStmtCtx(),
+	}
+	row := info.ToRow(time.UTC)
+	require.Equal(t, "", row[len(row)-7])
+	require.Nil(t, row[len(row)-6])
+
+	info.ConnectAttrs = map[string]string{"program_name": "myapp", "_os": "Linux"}
+	row = info.ToRow(time.UTC)
+	require.Equal(t, "myapp", row[len(row)-7])
+	require.Equal(t, `{"_os":"Linux","program_name":"myapp"}`, row[len(row)-6])
+}
+
+func TestProcessInfoCompressionStats(t *testing.T) {
+	info := &ProcessInfo{
+		ID:      1,
+		User:    "root",
+		Host:    "127.0.0.1",
+		StmtCtx: stmtctx.NewStmtCtx(),
+	}
+	row := info.ToRow(time.UTC)
+	require.Equal(t, []any{"", nil, nil, nil, nil}, row[len(row)-5:])
+
+	info.CompressionStats = &CompressionStats{Algorithm: "zstd"}
+	info.CompressionStats.BytesSent.Add(1000)
+	info.CompressionStats.CompressedBytesSent.Add(100)
+	info.CompressionStats.BytesReceived.Add(60)
+	info.CompressionStats.CompressedBytesReceived.Add(40)
+	row = info.ToRow(time.UTC)
+	require.Equal(t, []any{"zstd", uint64(1000), uint64(100), uint64(60), uint64(40)}, row[len(row)-5:])
+}
@@ -398,6 +398,7 @@ func (d *rangeDetacher) detachCNFCondAndBuildRangeForIndex(conditions []expressi
 					return res, nil
 				}
 			} else {
+				d.sctx.GetSessionVars().StmtCtx.RecordFixControl(fixcontrol.Fix44389)
 				considerCNFItemNonPointRanges := fixcontrol.GetBoolWithDefault(d.sctx.GetSessionVars().GetOptimizerFixControlMap(), fixcontrol.Fix44389, false)
 				if considerCNFItemNonPointRanges && !bestCNFItemRes.sameLenPointRanges && eqOrInCount == 0 && bestCNFItemRes.minColNum > 0 && bestCNFItemRes.maxColNum > 1 {
 					// When eqOrInCount is 0, if we don't enter the IF branch, we would use detachColumnCNFConditions to build
@@ -0,0 +1,190 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resultcache
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+// Result is a cached result set of a query.
+type Result struct {
+	// Chunks are the rows of the result set. They must not be modified once the result is cached.
+	Chunks []*chunk.Chunk
+	// TableIDs are the IDs of the tables (and partitions) read by the query. The result is
+	// invalidated once any of them is changed.
+	TableIDs []int64
+
+	size int64
+}
+
+// NewResult creates a Result from the chunks of a result set and the tables it reads.
+func NewResult(chunks []*chunk.Chunk, tableIDs []int64) *Result {
+	r := &Result{Chunks: chunks, TableIDs: tableIDs}
+	for _, chk := range chunks {
+		r.size += chk.MemoryUsage()
+	}
+	return r
+}
+
+// Size returns the memory usage of the result in bytes.
+func (r *Result) Size() int64 {
+	return r.size
+}
+
+type cacheEntry struct {
+	key    string
+	result *Result
+}
+
+// Cache is a least recently used cache of query results bounded by the memory usage. It is safe
+// for concurrent use.
+type Cache struct {
+	mu       sync.Mutex
+	capacity int64
+	size     int64
+	elements map[string]*list.Element
+	lru      *list.List
+	// tables maps a table ID to the keys of the results which read the table.
+	tables map[int64]map[string]struct{}
+	// epoch is increased by every invalidation. A result read before an invalidation is not
+	// put into the cache, since it may be computed from the data which is already changed.
+	epoch atomic.Uint64
+}
+
+// New creates a Cache whose memory usage is limited to capacity bytes.
+func New(capacity int64) *Cache {
+	return &Cache{
+		capacity: capacity,
+		elements: make(map[string]*list.Element),
+		lru:      list.New(),
+		tables:   make(map[int64]map[string]struct{}),
+	}
+}
+
+// Epoch returns the current invalidation epoch. It should be got before the query is executed,
+// and passed to Put when the result is ready.
+func (c *Cache) Epoch() uint64 {
+	return c.epoch.Load()
+}
+
+// Get returns the cached result of the key.
+func (c *Cache) Get(key string) (*Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.elements[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).result, true
+}
+
+// Put caches the result of the key. It returns false if the result is not cached, because it's
+// larger than the capacity or some tables are changed since epoch.
+func (c *Cache) Put(key string, epoch uint64, result *Result) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if epoch != c.epoch.Load() || result.size > c.capacity {
+		return false
+	}
+	if elem, ok := c.elements[key]; ok {
+		c.removeElement(elem)
+	}
+	c.elements[key] = c.lru.PushFront(&cacheEntry{key: key, result: result})
+	c.size += result.size
+	for _, id := range result.TableIDs {
+		keys, ok := c.tables[id]
+		if !ok {
+			keys = make(map[string]struct{})
+			c.tables[id] = keys
+		}
+		keys[key] = struct{}{}
+	}
+	c.evict()
+	return true
+}
+
+// InvalidateTables removes the results which read any of the tables.
+func (c *Cache) InvalidateTables(ids ...int64) {
+	if len(ids) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch.Add(1)
+	for _, id := range ids {
+		for key := range c.tables[id] {
+			if elem, ok := c.elements[key]; ok {
+				c.removeElement(elem)
+			}
+		}
+	}
+}
+
+// InvalidateAll removes all the results.
+func (c *Cache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch.Add(1)
+	c.size = 0
+	c.elements = make(map[string]*list.Element)
+	c.lru.Init()
+	c.tables = make(map[int64]map[string]struct{})
+}
+
+// SetCapacity sets the memory limit of the cache in bytes, evicting results if necessary.
+func (c *Cache) SetCapacity(capacity int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capacity = capacity
+	c.evict()
+}
+
+// Len returns the count of the cached results.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Size returns the memory usage of the cached results in bytes.
+func (c *Cache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+func (c *Cache) evict() {
+	for c.size > c.capacity {
+		c.removeElement(c.lru.Back())
+	}
+}
+
+func (c *Cache) removeElement(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.elements, entry.key)
+	c.size -= entry.result.size
+	for _, id := range entry.result.TableIDs {
+		keys := c.tables[id]
+		delete(keys, entry.key)
+		if len(keys) == 0 {
+			delete(c.tables, id)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resultcache
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestResult(rows int, tableIDs ...int64) *Result {
+	chk := chunk.NewChunkWithCapacity([]*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}, rows)
+	for i := 0; i < rows; i++ {
+		chk.AppendInt64(0, int64(i))
+	}
+	return NewResult([]*chunk.Chunk{chk}, tableIDs)
+}
+
+func TestPutAndGet(t *testing.T) {
+	c := New(1 << 20)
+	_, ok := c.Get("a")
+	require.False(t, ok)
+
+	r := newTestResult(3, 1)
+	require.True(t, c.Put("a", c.Epoch(), r))
+	got, ok := c.Get("a")
+	require.True(t, ok)
+	require.Same(t, r, got)
+	require.Equal(t, 1, c.Len())
+	require.Equal(t, r.Size(), c.Size())
+
+	// replace the result of the same key
+	r2 := newTestResult(5, 1)
+	require.True(t, c.Put("a", c.Epoch(), r2))
+	got, ok = c.Get("a")
+	require.True(t, ok)
+	require.Same(t, r2, got)
+	require.Equal(t, 1, c.Len())
+	require.Equal(t, r2.Size(), c.Size())
+}
+
+func TestEvict(t *testing.T) {
+	r1, r2, r3 := newTestResult(1, 1), newTestResult(1, 2), newTestResult(1, 3)
+	c := New(r1.Size() * 2)
+	require.True(t, c.Put("a", c.Epoch(), r1))
+	require.True(t, c.Put("b", c.Epoch(), r2))
+	// "a" is the most recently used one after Get.
+	_, ok := c.Get("a")
+	require.True(t, ok)
+	require.True(t, c.Put("c", c.Epoch(), r3))
+	_, ok = c.Get("b")
+	require.False(t, ok)
+	_, ok = c.Get("a")
+	require.True(t, ok)
+	_, ok = c.Get("c")
+	require.True(t, ok)
+
+	// too large to be cached
+	require.False(t, c.Put("d", c.Epoch(), newTestResult(1024, 4)))
+	require.Equal(t, 2, c.Len())
+
+	c.SetCapacity(r1.Size())
+	require.Equal(t, 1, c.Len())
+	_, ok = c.Get("a")
+	require.False(t, ok)
+	c.SetCapacity(0)
+	require.Equal(t, 0, c.Len())
+	require.Equal(t, int64(0), c.Size())
+}
+
+func TestInvalidate(t *testing.T) {
+	c := New(1 << 20)
+	require.True(t, c.Put("a", c.Epoch(), newTestResult(1, 1, 2)))
+	require.True(t, c.Put("b", c.Epoch(), newTestResult(1, 2)))
+	require.True(t, c.Put("c", c.Epoch(), newTestResult(1, 3)))
+
+	epoch := c.Epoch()
+	c.InvalidateTables(2, 4)
+	require.Equal(t, 1, c.Len())
+	_, ok := c.Get("c")
+	require.True(t, ok)
+	// the result is computed before the invalidation
+	require.False(t, c.Put("a", epoch, newTestResult(1, 1)))
+	require.True(t, c.Put("a", c.Epoch(), newTestResult(1, 1)))
+
+	c.InvalidateTables(1)
+	_, ok = c.Get("a")
+	require.False(t, ok)
+	require.Equal(t, 1, c.Len())
+
+	c.InvalidateAll()
+	require.Equal(t, 0, c.Len())
+	require.Equal(t, int64(0), c.Size())
+	require.True(t, c.Put("c", c.Epoch(), newTestResult(1, 3)))
+	c.InvalidateTables(3)
+	require.Equal(t, 0, c.Len())
+}
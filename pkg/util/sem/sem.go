@@ -143,6 +143,18 @@ func IsInvisibleSysVar(varNameInLower string) bool {
 		variable.TiDBExpensiveQueryTimeThreshold,
 		variable.TiDBForcePriority,
 		variable.TiDBGeneralLog,
+		variable.TiDBGeneralLogSampleRate,
+		variable.TiDBGeneralLogUsers,
+		variable.TiDBGeneralLogOutput,
+		variable.TiDBGeneralLogTableSize,
+		variable.TiDBAuditLogFilename,
+		variable.TiDBAuditLogFileMaxDays,
+		variable.TiDBAuditLogFileMaxSize,
+		variable.TiDBAuditLogFileMaxBackups,
+		variable.TiDBAuditLogQueueSize,
+		variable.TiDBAuditLogEnabled,
+		variable.TiDBAuditLogRules,
+		variable.TiDBAuditLogRedact,
 		variable.TiDBMetricSchemaRangeDuration,
 		variable.TiDBMetricSchemaStep,
 		variable.TiDBOptWriteRowID,
@@ -89,6 +89,10 @@ func TestIsInvisibleSysVar(t *testing.T) {
 	assert.True(IsInvisibleSysVar(variable.TiDBExpensiveQueryTimeThreshold))
 	assert.True(IsInvisibleSysVar(variable.TiDBForcePriority))
 	assert.True(IsInvisibleSysVar(variable.TiDBGeneralLog))
+	assert.True(IsInvisibleSysVar(variable.TiDBAuditLogFilename))
+	assert.True(IsInvisibleSysVar(variable.TiDBAuditLogEnabled))
+	assert.True(IsInvisibleSysVar(variable.TiDBAuditLogRules))
+	assert.True(IsInvisibleSysVar(variable.TiDBAuditLogRedact))
 	assert.True(IsInvisibleSysVar(variable.TiDBMetricSchemaRangeDuration))
 	assert.True(IsInvisibleSysVar(variable.TiDBMetricSchemaStep))
 	assert.True(IsInvisibleSysVar(variable.TiDBOptWriteRowID))
@@ -0,0 +1,214 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tablelatency
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+)
+
+// MaxObjects is the max number of tables and indexes whose latency is collected. The statements on other objects are
+// not recorded once it's reached, until the dropped objects are removed by GC.
+const MaxObjects = 10000
+
+// TableLevelIndexID is the index ID of the latency collected for the whole table.
+const TableLevelIndexID int64 = 0
+
+// BucketBounds are the upper bounds of the latency histogram buckets, the last bucket is for the latency larger than
+// all of them.
+var BucketBounds = [...]time.Duration{
+	500 * time.Microsecond,
+	time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	20 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// ObjectID identifies a table or an index, the IndexID is TableLevelIndexID for the table.
+type ObjectID struct {
+	TableID int64
+	IndexID int64
+}
+
+// Stats is the latency of the statements on a table or an index.
+type Stats struct {
+	ObjectID
+	// StartTime is the time when the first statement is recorded.
+	StartTime  time.Time
+	QueryTotal uint64
+	ErrorTotal uint64
+	SumLatency time.Duration
+	MaxLatency time.Duration
+	// Buckets is the number of the statements whose latency falls in each bucket of BucketBounds.
+	Buckets [len(BucketBounds) + 1]uint64
+}
+
+// QPS returns the average number of the statements per second since StartTime.
+func (s *Stats) QPS(now time.Time) float64 {
+	elapsed := now.Sub(s.StartTime).Seconds()
+	if elapsed < 1 {
+		elapsed = 1
+	}
+	return float64(s.QueryTotal) / elapsed
+}
+
+// Quantile estimates the latency of the quantile q by the upper bound of the bucket it falls in.
+func (s *Stats) Quantile(q float64) time.Duration {
+	if s.QueryTotal == 0 {
+		return 0
+	}
+	target := max(uint64(math.Ceil(q*float64(s.QueryTotal))), 1)
+	var count uint64
+	for i, n := range s.Buckets {
+		count += n
+		if count >= target {
+			if i < len(BucketBounds) && BucketBounds[i] < s.MaxLatency {
+				return BucketBounds[i]
+			}
+			return s.MaxLatency
+		}
+	}
+	return s.MaxLatency
+}
+
+type stats struct {
+	startTime  time.Time
+	queryTotal atomic.Uint64
+	errorTotal atomic.Uint64
+	sumLatency atomic.Int64
+	maxLatency atomic.Int64
+	buckets    [len(BucketBounds) + 1]atomic.Uint64
+}
+
+func (s *stats) record(latency time.Duration, succ bool) {
+	s.queryTotal.Add(1)
+	if !succ {
+		s.errorTotal.Add(1)
+	}
+	s.sumLatency.Add(int64(latency))
+	for {
+		maxLatency := s.maxLatency.Load()
+		if int64(latency) <= maxLatency || s.maxLatency.CompareAndSwap(maxLatency, int64(latency)) {
+			break
+		}
+	}
+	bucket, _ := slices.BinarySearch(BucketBounds[:], latency)
+	s.buckets[bucket].Add(1)
+}
+
+// Collector collects the latency of the statements per table and index. The objects are identified by their IDs in
+// the infoschema, so the cardinality is bounded by the number of the tables and indexes rather than the SQL texts.
+type Collector struct {
+	mu    sync.RWMutex
+	stats map[ObjectID]*stats
+}
+
+// NewCollector creates a Collector.
+func NewCollector() *Collector {
+	return &Collector{stats: make(map[ObjectID]*stats)}
+}
+
+// GlobalCollector is the Collector of the current TiDB instance.
+var GlobalCollector = NewCollector()
+
+// Record records the latency of a statement on the objects.
+func (c *Collector) Record(objects []ObjectID, latency time.Duration, succ bool) {
+	for _, id := range objects {
+		if s := c.getOrCreate(id); s != nil {
+			s.record(latency, succ)
+		}
+	}
+}
+
+func (c *Collector) getOrCreate(id ObjectID) *stats {
+	c.mu.RLock()
+	s, ok := c.stats[id]
+	c.mu.RUnlock()
+	if ok {
+		return s
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok = c.stats[id]; ok {
+		return s
+	}
+	if len(c.stats) >= MaxObjects {
+		return nil
+	}
+	s = &stats{startTime: time.Now()}
+	c.stats[id] = s
+	return s
+}
+
+// GetAll returns the latency of all the objects.
+func (c *Collector) GetAll() []Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result := make([]Stats, 0, len(c.stats))
+	for id, s := range c.stats {
+		item := Stats{
+			ObjectID:   id,
+			StartTime:  s.startTime,
+			QueryTotal: s.queryTotal.Load(),
+			ErrorTotal: s.errorTotal.Load(),
+			SumLatency: time.Duration(s.sumLatency.Load()),
+			MaxLatency: time.Duration(s.maxLatency.Load()),
+		}
+		for i := range s.buckets {
+			item.Buckets[i] = s.buckets[i].Load()
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// GC removes the latency of the dropped tables and indexes.
+// `tableMetaLookup` argument is represented as a function (but not `infoschema.InfoSchema`) to avoid depending on it.
+func (c *Collector) GC(tableMetaLookup func(id int64) (*model.TableInfo, bool)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id := range c.stats {
+		tbl, ok := tableMetaLookup(id.TableID)
+		if ok && id.IndexID != TableLevelIndexID {
+			ok = slices.ContainsFunc(tbl.Indices, func(idx *model.IndexInfo) bool {
+				return idx.ID == id.IndexID
+			})
+		}
+		if !ok {
+			delete(c.stats, id)
+		}
+	}
+}
+
+// Reset removes all the collected latency.
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats = make(map[ObjectID]*stats)
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tablelatency
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecord(t *testing.T) {
+	c := NewCollector()
+	tbl := ObjectID{TableID: 1, IndexID: TableLevelIndexID}
+	idx := ObjectID{TableID: 1, IndexID: 2}
+	c.Record([]ObjectID{tbl, idx}, time.Millisecond, true)
+	c.Record([]ObjectID{tbl}, 3*time.Millisecond, true)
+	c.Record([]ObjectID{tbl}, 30*time.Second, false)
+
+	all := c.GetAll()
+	slices.SortFunc(all, func(a, b Stats) int {
+		return cmp.Compare(a.IndexID, b.IndexID)
+	})
+	require.Len(t, all, 2)
+	require.Equal(t, tbl, all[0].ObjectID)
+	require.Equal(t, uint64(3), all[0].QueryTotal)
+	require.Equal(t, uint64(1), all[0].ErrorTotal)
+	require.Equal(t, 30*time.Second+4*time.Millisecond, all[0].SumLatency)
+	require.Equal(t, 30*time.Second, all[0].MaxLatency)
+	require.Equal(t, uint64(1), all[0].Buckets[1])
+	require.Equal(t, uint64(1), all[0].Buckets[3])
+	require.Equal(t, uint64(1), all[0].Buckets[len(BucketBounds)])
+	require.Equal(t, time.Millisecond, all[0].Quantile(0.1))
+	require.Equal(t, 5*time.Millisecond, all[0].Quantile(0.5))
+	require.Equal(t, 30*time.Second, all[0].Quantile(0.99))
+	require.Equal(t, idx, all[1].ObjectID)
+	require.Equal(t, uint64(1), all[1].QueryTotal)
+	require.Equal(t, time.Millisecond, all[1].Quantile(0.99))
+	require.InDelta(t, 3.0, all[0].QPS(all[0].StartTime), 1e-9)
+	require.InDelta(t, 0.3, all[0].QPS(all[0].StartTime.Add(10*time.Second)), 1e-9)
+}
+
+func TestMaxObjectsAndGC(t *testing.T) {
+	c := NewCollector()
+	for i := 0; i < MaxObjects+10; i++ {
+		c.Record([]ObjectID{{TableID: int64(i)}}, time.Millisecond, true)
+	}
+	require.Len(t, c.GetAll(), MaxObjects)
+
+	// the objects are collected again after the dropped ones are removed.
+	tblInfo := &model.TableInfo{ID: 1, Indices: []*model.IndexInfo{{ID: 1}}}
+	lookup := func(id int64) (*model.TableInfo, bool) {
+		return tblInfo, id == tblInfo.ID
+	}
+	c.GC(lookup)
+	require.Len(t, c.GetAll(), 1)
+	c.Record([]ObjectID{{TableID: 1, IndexID: 1}, {TableID: 1, IndexID: 2}}, time.Millisecond, true)
+	require.Len(t, c.GetAll(), 3)
+	c.GC(lookup)
+	all := c.GetAll()
+	require.Len(t, all, 2)
+	for _, stats := range all {
+		require.Equal(t, int64(1), stats.TableID)
+		require.NotEqual(t, int64(2), stats.IndexID)
+	}
+	tblInfo.Indices = append(tblInfo.Indices, &model.IndexInfo{ID: 2})
+	c.Record([]ObjectID{{TableID: 1, IndexID: 2}}, time.Millisecond, true)
+	c.GC(lookup)
+	require.Len(t, c.GetAll(), 3)
+
+	c.Reset()
+	require.Empty(t, c.GetAll())
+}
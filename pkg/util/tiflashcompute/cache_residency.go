@@ -0,0 +1,73 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiflashcompute
+
+import (
+	"sync"
+)
+
+const (
+	// cacheResidencyDecay is the weight of the previous hit ratio when a new scan is merged,
+	// so the hit ratio follows the changes of the local cache gradually.
+	cacheResidencyDecay = 0.7
+	// maxCacheResidencyTables limits the number of tables whose cache residency is recorded.
+	maxCacheResidencyTables = 100000
+)
+
+// cacheResidency records the ratio of the table data that is read from the local cache of
+// tiflash_compute nodes rather than S3 recently, keyed by table ID.
+type cacheResidency struct {
+	mu        sync.RWMutex
+	hitRatios map[int64]float64
+}
+
+var globalCacheResidency = &cacheResidency{hitRatios: make(map[int64]float64)}
+
+// UpdateTableCacheResidency merges the bytes that a TiFlash scan read from the local cache and S3 into the
+// cache hit ratio of the table.
+func UpdateTableCacheResidency(tableID int64, hitBytes, missBytes uint64) {
+	if hitBytes+missBytes == 0 {
+		return
+	}
+	ratio := float64(hitBytes) / float64(hitBytes+missBytes)
+	globalCacheResidency.mu.Lock()
+	defer globalCacheResidency.mu.Unlock()
+	if prev, ok := globalCacheResidency.hitRatios[tableID]; ok {
+		ratio = prev*cacheResidencyDecay + ratio*(1-cacheResidencyDecay)
+	} else if len(globalCacheResidency.hitRatios) >= maxCacheResidencyTables {
+		// Evict an arbitrary table, its cache residency is collected again the next time it's scanned.
+		for id := range globalCacheResidency.hitRatios {
+			delete(globalCacheResidency.hitRatios, id)
+			break
+		}
+	}
+	globalCacheResidency.hitRatios[tableID] = ratio
+}
+
+// GetTableCacheHitRatio returns the ratio of the table data read from the local cache of tiflash_compute nodes recently.
+// It returns false if the table hasn't been scanned by TiFlash yet.
+func GetTableCacheHitRatio(tableID int64) (float64, bool) {
+	globalCacheResidency.mu.RLock()
+	defer globalCacheResidency.mu.RUnlock()
+	ratio, ok := globalCacheResidency.hitRatios[tableID]
+	return ratio, ok
+}
+
+// RemoveTableCacheResidency removes the recorded cache residency of the table.
+func RemoveTableCacheResidency(tableID int64) {
+	globalCacheResidency.mu.Lock()
+	defer globalCacheResidency.mu.Unlock()
+	delete(globalCacheResidency.hitRatios, tableID)
+}
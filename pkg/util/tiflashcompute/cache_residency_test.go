@@ -0,0 +1,46 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiflashcompute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableCacheResidency(t *testing.T) {
+	const tableID = 100
+	_, ok := GetTableCacheHitRatio(tableID)
+	require.False(t, ok)
+
+	UpdateTableCacheResidency(tableID, 0, 0)
+	_, ok = GetTableCacheHitRatio(tableID)
+	require.False(t, ok)
+
+	UpdateTableCacheResidency(tableID, 0, 100)
+	ratio, ok := GetTableCacheHitRatio(tableID)
+	require.True(t, ok)
+	require.Equal(t, 0.0, ratio)
+
+	// The new hit ratio is merged into the previous one gradually.
+	UpdateTableCacheResidency(tableID, 100, 0)
+	ratio, ok = GetTableCacheHitRatio(tableID)
+	require.True(t, ok)
+	require.InDelta(t, 1-cacheResidencyDecay, ratio, 1e-9)
+
+	RemoveTableCacheResidency(tableID)
+	_, ok = GetTableCacheHitRatio(tableID)
+	require.False(t, ok)
+}
@@ -22,6 +22,8 @@ import (
 	"sync"
 	"syscall"
 	"time"
+	// Embed the tzdata as a fallback when the zoneinfo database is missing in the system.
+	_ "time/tzdata"
 
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/logutil"
@@ -45,6 +47,13 @@ var locCa *locCache
 // systemTZ is current TiDB's system timezone name.
 var systemTZ atomic.String
 
+// tzDataDir is the zoneinfo directory set by `tidb_tz_data_path`. The time zones are loaded from the
+// system zoneinfo database, or the embedded tzdata if the system one is missing, when it's empty.
+var tzDataDir atomic.String
+
+// tzDataVersion is increased every time the zoneinfo directory is changed.
+var tzDataVersion atomic.Uint64
+
 // locCache is a simple map with lock. It stores all used timezone during the lifetime of tidb instance.
 // Talked with Golang team about whether they can have some forms of cache policy available for programmer,
 // they suggests that only programmers knows which one is best for their use case.
@@ -168,10 +177,14 @@ func (lm *locCache) getLoc(name string) (*time.Location, error) {
 		return v, nil
 	}
 
-	if loc, err := time.LoadLocation(name); err == nil {
+	version := tzDataVersion.Load()
+	if loc, err := loadLocationFromTZData(name); err == nil {
 		// assign value back to map
 		lm.mu.Lock()
-		lm.locMap[name] = loc
+		// the location may be loaded from the stale zoneinfo directory, do not cache it.
+		if version == tzDataVersion.Load() {
+			lm.locMap[name] = loc
+		}
 		lm.mu.Unlock()
 		return loc, nil
 	}
@@ -179,6 +192,63 @@ func (lm *locCache) getLoc(name string) (*time.Location, error) {
 	return nil, fmt.Errorf("invalid name for timezone %s", name)
 }
 
+// reset clears all the cached locations.
+func (lm *locCache) reset() {
+	lm.mu.Lock()
+	lm.locMap = make(map[string]*time.Location)
+	lm.mu.Unlock()
+}
+
+// loadLocationFromTZData loads the location from the zoneinfo directory set by users first,
+// and falls back to `time.LoadLocation` if it's not set or the zone is not found there.
+func loadLocationFromTZData(name string) (*time.Location, error) {
+	if dir := tzDataDir.Load(); dir != "" && isValidZoneName(name) {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+			return time.LoadLocationFromTZData(name, data)
+		}
+	}
+	return time.LoadLocation(name)
+}
+
+// isValidZoneName checks whether the name can be used as a file path under the zoneinfo directory.
+func isValidZoneName(name string) bool {
+	if name == "" || name == "UTC" || name == "Local" {
+		return false
+	}
+	if name[0] == '/' || name[0] == '\\' || strings.Contains(name, "..") {
+		return false
+	}
+	return !strings.ContainsRune(name, 0)
+}
+
+// SetTZDataDir sets the zoneinfo directory to load time zones from. The cached locations are
+// dropped if the directory changes, so newer zoneinfo files can be used without restarting.
+// An empty dir means using the system zoneinfo database or the embedded tzdata.
+func SetTZDataDir(dir string) error {
+	if dir == tzDataDir.Load() {
+		return nil
+	}
+	if dir != "" {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", dir)
+		}
+	}
+	tzDataDir.Store(dir)
+	tzDataVersion.Inc()
+	locCa.reset()
+	logutil.BgLogger().Info("time zone data directory changed", zap.String("dir", dir))
+	return nil
+}
+
+// GetTZDataDir returns the zoneinfo directory set by SetTZDataDir.
+func GetTZDataDir() string {
+	return tzDataDir.Load()
+}
+
 // LoadLocation loads time.Location by IANA timezone time.
 func LoadLocation(name string) (*time.Location, error) {
 	return locCa.getLoc(name)
@@ -229,7 +299,7 @@ func ParseTimeZone(s string) (*time.Location, error) {
 		return SystemLocation(), nil
 	}
 
-	loc, err := time.LoadLocation(s)
+	loc, err := LoadLocation(s)
 	if err == nil {
 		return loc, nil
 	}
@@ -211,3 +211,46 @@ func TestConstructTimeZone(t *testing.T) {
 	_, err = ConstructTimeZone("asia/not-exist", 0)
 	require.EqualError(t, err, "invalid name for timezone asia/not-exist")
 }
+
+func TestSetTZDataDir(t *testing.T) {
+	utcData, err := os.ReadFile("/usr/share/zoneinfo/UTC")
+	if err != nil {
+		t.Skip("the system zoneinfo database is missing")
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "Asia"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "Custom"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Asia", "Shanghai"), utcData, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Custom", "Zone"), utcData, 0o644))
+	defer func() {
+		require.NoError(t, SetTZDataDir(""))
+	}()
+
+	getOffset := func(name string) int {
+		loc, err := LoadLocation(name)
+		require.NoError(t, err)
+		_, offset := time.Date(2018, 8, 15, 20, 0, 0, 0, loc).Zone()
+		return offset
+	}
+	require.Equal(t, 8*3600, getOffset("Asia/Shanghai"))
+	_, err = LoadLocation("Custom/Zone")
+	require.Error(t, err)
+
+	require.Error(t, SetTZDataDir(filepath.Join(dir, "not-exist")))
+	require.Error(t, SetTZDataDir(filepath.Join(dir, "Custom", "Zone")))
+	require.NoError(t, SetTZDataDir(dir))
+	require.Equal(t, dir, GetTZDataDir())
+	// the cached location is dropped and the zones are loaded from the directory.
+	require.Equal(t, 0, getOffset("Asia/Shanghai"))
+	require.Equal(t, 0, getOffset("Custom/Zone"))
+	// the zones missing in the directory are loaded from the system.
+	require.Equal(t, 9*3600, getOffset("Asia/Tokyo"))
+	// the names escaping from the directory are not loaded from it.
+	_, err = LoadLocation("../" + filepath.Base(dir) + "/Custom/Zone")
+	require.Error(t, err)
+
+	require.NoError(t, SetTZDataDir(""))
+	require.Equal(t, 8*3600, getOffset("Asia/Shanghai"))
+	_, err = LoadLocation("Custom/Zone")
+	require.Error(t, err)
+}
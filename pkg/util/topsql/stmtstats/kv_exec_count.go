@@ -51,14 +51,34 @@ type KvExecCounter struct {
 func (c *KvExecCounter) RPCInterceptor() interceptor.RPCInterceptor {
 	return interceptor.NewRPCInterceptor("kv-exec-counter", func(next interceptor.RPCInterceptorFunc) interceptor.RPCInterceptorFunc {
 		return func(target string, req *tikvrpc.Request) (*tikvrpc.Response, error) {
-			if topsqlstate.TopSQLEnabled() {
-				c.mark(target)
+			if !topsqlstate.TopSQLEnabled() {
+				return next(target, req)
 			}
-			return next(target, req)
+			c.mark(target)
+			resp, err := next(target, req)
+			if err == nil {
+				c.recordKvProcessTime(req, resp)
+			}
+			return resp, err
 		}
 	})
 }
 
+// recordKvProcessTime attributes the KV processing time reported in resp to the
+// physical table or index that req accesses.
+// recordKvProcessTime is thread-safe.
+func (c *KvExecCounter) recordKvProcessTime(req *tikvrpc.Request, resp *tikvrpc.Response) {
+	ns := kvProcessTimeFromResponse(resp)
+	if ns == 0 {
+		return
+	}
+	id, ok := tableIndexIDFromRequest(req)
+	if !ok {
+		return
+	}
+	c.stats.addKvProcessTime([]byte(c.digest.SQLDigest), []byte(c.digest.PlanDigest), id, ns)
+}
+
 // mark this target during the current execution of statement.
 // If this target is marked for the first time, then increase the number of execution.
 // mark is thread-safe.
@@ -17,6 +17,10 @@ package stmtstats
 import (
 	"testing"
 
+	"github.com/pingcap/kvproto/pkg/coprocessor"
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/tablecodec"
 	"github.com/pingcap/tidb/pkg/util/topsql/state"
 	"github.com/stretchr/testify/assert"
 	"github.com/tikv/client-go/v2/tikvrpc"
@@ -43,3 +47,51 @@ func TestKvExecCounter(t *testing.T) {
 	assert.NotNil(t, stats.data[SQLPlanDigest{SQLDigest: "SQL-1"}])
 	assert.Equal(t, uint64(1), stats.data[SQLPlanDigest{SQLDigest: "SQL-1"}].KvStatsItem.KvExecCount["TIKV-1"])
 }
+
+func TestKvExecCounterProcessTime(t *testing.T) {
+	state.EnableTopSQL()
+	old := keyDecoder.Load()
+	t.Cleanup(func() {
+		keyDecoder.Store(old)
+	})
+	SetKeyDecoder(func(key []byte) (tableID, indexID int64, ok bool) {
+		tableID, indexID, isRecord, err := tablecodec.DecodeKeyHead(key)
+		if isRecord {
+			indexID = 0
+		}
+		return tableID, indexID, err == nil
+	})
+	stats := CreateStatementStats()
+	counter := stats.CreateKvExecCounter([]byte("SQL-1"), []byte(""))
+	interceptor := counter.RPCInterceptor()
+	mockResp := func(ns uint64) *tikvrpc.Response {
+		return &tikvrpc.Response{Resp: &coprocessor.Response{
+			ExecDetailsV2: &kvrpcpb.ExecDetailsV2{TimeDetailV2: &kvrpcpb.TimeDetailV2{ProcessWallTimeNs: ns}},
+		}}
+	}
+	indexReq := tikvrpc.NewRequest(tikvrpc.CmdCop, &coprocessor.Request{
+		Ranges: []*coprocessor.KeyRange{{Start: tablecodec.EncodeIndexSeekKey(100, 2, nil)}},
+	})
+	for n := 0; n < 3; n++ {
+		_, _ = interceptor.Wrap(func(target string, req *tikvrpc.Request) (*tikvrpc.Response, error) {
+			return mockResp(100), nil
+		})("TIKV-1", indexReq)
+	}
+	rowReq := tikvrpc.NewRequest(tikvrpc.CmdGet, &kvrpcpb.GetRequest{
+		Key: tablecodec.EncodeRowKeyWithHandle(101, kv.IntHandle(1)),
+	})
+	_, _ = interceptor.Wrap(func(target string, req *tikvrpc.Request) (*tikvrpc.Response, error) {
+		return mockResp(50), nil
+	})("TIKV-1", rowReq)
+	// Requests on non-table keys are ignored.
+	metaReq := tikvrpc.NewRequest(tikvrpc.CmdGet, &kvrpcpb.GetRequest{Key: []byte("m_meta")})
+	_, _ = interceptor.Wrap(func(target string, req *tikvrpc.Request) (*tikvrpc.Response, error) {
+		return mockResp(10), nil
+	})("TIKV-1", metaReq)
+
+	item := stats.data[SQLPlanDigest{SQLDigest: "SQL-1"}]
+	assert.NotNil(t, item)
+	assert.Len(t, item.KvStatsItem.KvProcessTimeNs, 2)
+	assert.Equal(t, uint64(300), item.KvStatsItem.KvProcessTimeNs[TableIndexID{TableID: 100, IndexID: 2}])
+	assert.Equal(t, uint64(50), item.KvStatsItem.KvProcessTimeNs[TableIndexID{TableID: 101}])
+}
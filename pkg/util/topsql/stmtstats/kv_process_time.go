@@ -0,0 +1,110 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stmtstats
+
+import (
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	"github.com/tikv/client-go/v2/tikvrpc"
+	"go.uber.org/atomic"
+)
+
+// TableIndexID identifies the physical object that a KV request accesses.
+// TableID is the physical table ID, which means it is the partition ID for
+// partitioned tables. IndexID is 0 if the request accesses the record data.
+type TableIndexID struct {
+	TableID int64
+	IndexID int64
+}
+
+// execDetailsV2Getter is implemented by all KV responses carrying ExecDetailsV2.
+type execDetailsV2Getter interface {
+	GetExecDetailsV2() *kvrpcpb.ExecDetailsV2
+}
+
+// kvProcessTimeFromResponse returns the process wall time in nanoseconds reported by TiKV. TiKV doesn't report
+// the CPU time of a request, and the process wall time is close to it in most cases. It returns 0 if the response
+// does not carry such information.
+func kvProcessTimeFromResponse(resp *tikvrpc.Response) uint64 {
+	if resp == nil {
+		return 0
+	}
+	getter, ok := resp.Resp.(execDetailsV2Getter)
+	if !ok {
+		return 0
+	}
+	details := getter.GetExecDetailsV2()
+	if details == nil {
+		return 0
+	}
+	if td := details.GetTimeDetailV2(); td != nil && td.ProcessWallTimeNs > 0 {
+		return td.ProcessWallTimeNs
+	}
+	if td := details.GetTimeDetail(); td != nil {
+		return td.ProcessWallTimeMs * 1000000
+	}
+	return 0
+}
+
+// tableIndexIDFromRequest extracts the physical table ID and index ID from the
+// first key accessed by req.
+func tableIndexIDFromRequest(req *tikvrpc.Request) (TableIndexID, bool) {
+	if req == nil {
+		return TableIndexID{}, false
+	}
+	var key []byte
+	switch req.Type {
+	case tikvrpc.CmdCop, tikvrpc.CmdCopStream:
+		if ranges := req.Cop().GetRanges(); len(ranges) > 0 {
+			key = ranges[0].Start
+		}
+	case tikvrpc.CmdGet:
+		key = req.Get().GetKey()
+	case tikvrpc.CmdBatchGet:
+		if keys := req.BatchGet().GetKeys(); len(keys) > 0 {
+			key = keys[0]
+		}
+	case tikvrpc.CmdScan:
+		key = req.Scan().GetStartKey()
+	default:
+		return TableIndexID{}, false
+	}
+	return decodeTableIndexID(key)
+}
+
+// KeyDecoder decodes the physical table ID and index ID from the key accessed by a KV request. The index ID
+// is 0 for the record keys, and ok is false if the key is neither a record key nor an index key.
+type KeyDecoder func(key []byte) (tableID, indexID int64, ok bool)
+
+var keyDecoder atomic.Pointer[KeyDecoder]
+
+// SetKeyDecoder sets the KeyDecoder used to attribute the KV process time to the tables and indexes. The keys are
+// encoded by tablecodec which stmtstats can't depend on, so the decoder is passed in by the caller. The KV process
+// time is not attributed until the decoder is set.
+func SetKeyDecoder(d KeyDecoder) {
+	keyDecoder.Store(&d)
+}
+
+// decodeTableIndexID decodes the TableIndexID from a table record key or index key.
+func decodeTableIndexID(key []byte) (TableIndexID, bool) {
+	d := keyDecoder.Load()
+	if len(key) == 0 || d == nil {
+		return TableIndexID{}, false
+	}
+	tableID, indexID, ok := (*d)(key)
+	if !ok {
+		return TableIndexID{}, false
+	}
+	return TableIndexID{TableID: tableID, IndexID: indexID}, true
+}
@@ -108,6 +108,16 @@ func (s *StatementStats) addKvExecCount(sqlDigest, planDigest []byte, target str
 	item.KvStatsItem.KvExecCount[target] += n
 }
 
+// addKvProcessTime is used to accumulate the KV processing time of a certain SQLPlanDigest
+// for a certain physical table or index.
+// addKvProcessTime is thread-safe.
+func (s *StatementStats) addKvProcessTime(sqlDigest, planDigest []byte, id TableIndexID, ns uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item := s.GetOrCreateStatementStatsItem(sqlDigest, planDigest)
+	item.KvStatsItem.KvProcessTimeNs[id] += ns
+}
+
 // Take takes out all existing StatementStatsMap data from StatementStats.
 // Take is thread-safe.
 func (s *StatementStats) Take() StatementStatsMap {
@@ -213,12 +223,16 @@ func (i *StatementStatsItem) Merge(other *StatementStatsItem) {
 type KvStatementStatsItem struct {
 	// KvExecCount represents the number of SQL executions of TiKV.
 	KvExecCount map[string]uint64
+	// KvProcessTimeNs represents the KV processing time in nanoseconds, grouped
+	// by the physical table and index which the requests were sent to.
+	KvProcessTimeNs map[TableIndexID]uint64
 }
 
 // NewKvStatementStatsItem creates an empty KvStatementStatsItem.
 func NewKvStatementStatsItem() KvStatementStatsItem {
 	return KvStatementStatsItem{
-		KvExecCount: map[string]uint64{},
+		KvExecCount:     map[string]uint64{},
+		KvProcessTimeNs: map[TableIndexID]uint64{},
 	}
 }
 
@@ -237,4 +251,11 @@ func (i *KvStatementStatsItem) Merge(other KvStatementStatsItem) {
 			i.KvExecCount[target] += count
 		}
 	}
+	if i.KvProcessTimeNs == nil {
+		i.KvProcessTimeNs = other.KvProcessTimeNs
+	} else {
+		for id, ns := range other.KvProcessTimeNs {
+			i.KvProcessTimeNs[id] += ns
+		}
+	}
 }
@@ -0,0 +1,183 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topsql
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/util/topsql/stmtstats"
+	"github.com/tikv/client-go/v2/tikv"
+)
+
+const (
+	// tableIndexProcessTimeBucketSeconds is the time span of a single bucket.
+	tableIndexProcessTimeBucketSeconds = 60
+	// tableIndexProcessTimeRetention is the number of buckets kept in memory.
+	tableIndexProcessTimeRetention = 60
+	// maxTableIndexProcessTimeObjects limits the number of objects recorded in a single bucket.
+	maxTableIndexProcessTimeObjects = 10000
+)
+
+// TableIndexProcessTime is the KV process time attributed to a physical table or index.
+type TableIndexProcessTime struct {
+	TableID       int64  `json:"table_id"`
+	IndexID       int64  `json:"index_id"`
+	ProcessTimeNs uint64 `json:"process_time_ns"`
+	// TopSQLDigest is the hex encoded SQL digest that consumes most process time on this object.
+	TopSQLDigest string `json:"top_sql_digest"`
+}
+
+type tableIndexProcessTimeItem struct {
+	processTimeNs uint64
+	bySQLNs       map[stmtstats.BinaryDigest]uint64
+}
+
+type tableIndexProcessTimeBucket struct {
+	timestamp int64
+	items     map[stmtstats.TableIndexID]*tableIndexProcessTimeItem
+}
+
+// TableIndexProcessTimeCollector implements stmtstats.Collector. It aggregates the KV
+// process time by physical table and index, so the hottest objects can be pulled
+// without an external agent.
+type TableIndexProcessTimeCollector struct {
+	mu      sync.Mutex
+	buckets []*tableIndexProcessTimeBucket
+	nowFunc func() time.Time
+}
+
+var _ stmtstats.Collector = &TableIndexProcessTimeCollector{}
+
+// NewTableIndexProcessTimeCollector creates a TableIndexProcessTimeCollector.
+func NewTableIndexProcessTimeCollector() *TableIndexProcessTimeCollector {
+	return &TableIndexProcessTimeCollector{nowFunc: time.Now}
+}
+
+// CollectStmtStatsMap implements stmtstats.Collector.
+func (c *TableIndexProcessTimeCollector) CollectStmtStatsMap(data stmtstats.StatementStatsMap) {
+	ts := c.nowFunc().Unix() / tableIndexProcessTimeBucketSeconds * tableIndexProcessTimeBucketSeconds
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	bucket := c.currentBucketLocked(ts)
+	for digest, item := range data {
+		for id, ns := range item.KvStatsItem.KvProcessTimeNs {
+			obj, ok := bucket.items[id]
+			if !ok {
+				if len(bucket.items) >= maxTableIndexProcessTimeObjects {
+					continue
+				}
+				obj = &tableIndexProcessTimeItem{bySQLNs: make(map[stmtstats.BinaryDigest]uint64)}
+				bucket.items[id] = obj
+			}
+			obj.processTimeNs += ns
+			obj.bySQLNs[digest.SQLDigest] += ns
+		}
+	}
+}
+
+func (c *TableIndexProcessTimeCollector) currentBucketLocked(ts int64) *tableIndexProcessTimeBucket {
+	if n := len(c.buckets); n > 0 && c.buckets[n-1].timestamp == ts {
+		return c.buckets[n-1]
+	}
+	bucket := &tableIndexProcessTimeBucket{
+		timestamp: ts,
+		items:     make(map[stmtstats.TableIndexID]*tableIndexProcessTimeItem),
+	}
+	c.buckets = append(c.buckets, bucket)
+	if len(c.buckets) > tableIndexProcessTimeRetention {
+		c.buckets = c.buckets[len(c.buckets)-tableIndexProcessTimeRetention:]
+	}
+	return bucket
+}
+
+// TopN returns at most n objects which consume the most KV process time during the
+// last window, ordered by process time descending. If n <= 0, all objects are returned.
+func (c *TableIndexProcessTimeCollector) TopN(window time.Duration, n int) []TableIndexProcessTime {
+	since := c.nowFunc().Add(-window).Unix() / tableIndexProcessTimeBucketSeconds * tableIndexProcessTimeBucketSeconds
+	total := make(map[stmtstats.TableIndexID]*tableIndexProcessTimeItem)
+	c.mu.Lock()
+	for _, bucket := range c.buckets {
+		if bucket.timestamp < since {
+			continue
+		}
+		for id, item := range bucket.items {
+			obj, ok := total[id]
+			if !ok {
+				obj = &tableIndexProcessTimeItem{bySQLNs: make(map[stmtstats.BinaryDigest]uint64)}
+				total[id] = obj
+			}
+			obj.processTimeNs += item.processTimeNs
+			for digest, ns := range item.bySQLNs {
+				obj.bySQLNs[digest] += ns
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	result := make([]TableIndexProcessTime, 0, len(total))
+	for id, item := range total {
+		var topDigest stmtstats.BinaryDigest
+		var topNs uint64
+		for digest, ns := range item.bySQLNs {
+			if ns > topNs || (ns == topNs && digest < topDigest) {
+				topDigest, topNs = digest, ns
+			}
+		}
+		result = append(result, TableIndexProcessTime{
+			TableID:       id.TableID,
+			IndexID:       id.IndexID,
+			ProcessTimeNs: item.processTimeNs,
+			TopSQLDigest:  hex.EncodeToString([]byte(topDigest)),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ProcessTimeNs != result[j].ProcessTimeNs {
+			return result[i].ProcessTimeNs > result[j].ProcessTimeNs
+		}
+		if result[i].TableID != result[j].TableID {
+			return result[i].TableID < result[j].TableID
+		}
+		return result[i].IndexID < result[j].IndexID
+	})
+	if n > 0 && len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// decodeTableIndexID implements stmtstats.KeyDecoder. The keys with the API V2 keyspace prefix are supported.
+func decodeTableIndexID(key []byte) (tableID, indexID int64, ok bool) {
+	if !bytes.HasPrefix(key, tablecodec.TablePrefix()) {
+		_, k, err := tikv.DecodeKey(key, kvrpcpb.APIVersion_V2)
+		if err != nil {
+			return 0, 0, false
+		}
+		key = k
+	}
+	tableID, indexID, isRecord, err := tablecodec.DecodeKeyHead(key)
+	if err != nil || tableID == 0 {
+		return 0, 0, false
+	}
+	if isRecord {
+		indexID = 0
+	}
+	return tableID, indexID, true
+}
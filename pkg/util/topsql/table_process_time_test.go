@@ -0,0 +1,99 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topsql
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/util/topsql/stmtstats"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/tikv"
+)
+
+func TestTableIndexProcessTimeCollector(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	c := NewTableIndexProcessTimeCollector()
+	c.nowFunc = func() time.Time { return now }
+
+	newItem := func(ns map[stmtstats.TableIndexID]uint64) *stmtstats.StatementStatsItem {
+		item := stmtstats.NewStatementStatsItem()
+		item.KvStatsItem.KvProcessTimeNs = ns
+		return item
+	}
+	idx := stmtstats.TableIndexID{TableID: 100, IndexID: 1}
+	row := stmtstats.TableIndexID{TableID: 100}
+	c.CollectStmtStatsMap(stmtstats.StatementStatsMap{
+		{SQLDigest: "SQL-1"}: newItem(map[stmtstats.TableIndexID]uint64{idx: 300, row: 100}),
+		{SQLDigest: "SQL-2"}: newItem(map[stmtstats.TableIndexID]uint64{idx: 500}),
+	})
+	now = now.Add(2 * time.Minute)
+	c.CollectStmtStatsMap(stmtstats.StatementStatsMap{
+		{SQLDigest: "SQL-1"}: newItem(map[stmtstats.TableIndexID]uint64{row: 1000}),
+	})
+
+	result := c.TopN(time.Hour, 0)
+	require.Len(t, result, 2)
+	require.Equal(t, int64(100), result[0].TableID)
+	require.Equal(t, int64(0), result[0].IndexID)
+	require.Equal(t, uint64(1100), result[0].ProcessTimeNs)
+	require.Equal(t, hex.EncodeToString([]byte("SQL-1")), result[0].TopSQLDigest)
+	require.Equal(t, int64(1), result[1].IndexID)
+	require.Equal(t, uint64(800), result[1].ProcessTimeNs)
+	require.Equal(t, hex.EncodeToString([]byte("SQL-2")), result[1].TopSQLDigest)
+
+	// Only the latest bucket is in the window.
+	result = c.TopN(time.Minute, 0)
+	require.Len(t, result, 1)
+	require.Equal(t, uint64(1000), result[0].ProcessTimeNs)
+
+	result = c.TopN(time.Hour, 1)
+	require.Len(t, result, 1)
+
+	// Expired buckets are dropped.
+	for i := 0; i < tableIndexProcessTimeRetention; i++ {
+		now = now.Add(time.Minute)
+		c.CollectStmtStatsMap(stmtstats.StatementStatsMap{})
+	}
+	require.Len(t, c.buckets, tableIndexProcessTimeRetention)
+	require.Empty(t, c.TopN(24*time.Hour, 0))
+}
+
+func TestDecodeTableIndexID(t *testing.T) {
+	codec, err := tikv.NewCodecV2(tikv.ModeTxn, 1)
+	require.NoError(t, err)
+	for _, tc := range []struct {
+		key     []byte
+		tableID int64
+		indexID int64
+		ok      bool
+	}{
+		{tablecodec.EncodeRowKeyWithHandle(100, kv.IntHandle(1)), 100, 0, true},
+		{tablecodec.GenTableRecordPrefix(100), 100, 0, true},
+		{tablecodec.EncodeIndexSeekKey(101, 2, nil), 101, 2, true},
+		{codec.EncodeKey(tablecodec.EncodeIndexSeekKey(102, 3, []byte("a"))), 102, 3, true},
+		{[]byte("m_meta"), 0, 0, false},
+		{[]byte("t"), 0, 0, false},
+		{nil, 0, 0, false},
+	} {
+		tableID, indexID, ok := decodeTableIndexID(tc.key)
+		require.Equal(t, tc.ok, ok, "%q", tc.key)
+		require.Equal(t, tc.tableID, tableID)
+		require.Equal(t, tc.indexID, indexID)
+	}
+}
@@ -40,14 +40,16 @@ const (
 )
 
 var (
-	globalTopSQLReport   reporter.TopSQLReporter
-	singleTargetDataSink *reporter.SingleTargetDataSink
+	globalTopSQLReport    reporter.TopSQLReporter
+	singleTargetDataSink  *reporter.SingleTargetDataSink
+	tableIndexProcessTime = NewTableIndexProcessTimeCollector()
 )
 
 func init() {
 	remoteReporter := reporter.NewRemoteTopSQLReporter(plancodec.DecodeNormalizedPlan, plancodec.Compress)
 	globalTopSQLReport = remoteReporter
 	singleTargetDataSink = reporter.NewSingleTargetDataSink(remoteReporter)
+	stmtstats.SetKeyDecoder(decodeTableIndexID)
 }
 
 // SetupTopSQL sets up the top-sql worker.
@@ -56,6 +58,7 @@ func SetupTopSQL() {
 	singleTargetDataSink.Start()
 
 	stmtstats.RegisterCollector(globalTopSQLReport)
+	stmtstats.RegisterCollector(tableIndexProcessTime)
 	stmtstats.SetupAggregator()
 }
 
@@ -79,6 +82,12 @@ func Close() {
 	stmtstats.CloseAggregator()
 }
 
+// TopTableIndexProcessTime returns at most n physical tables or indexes which consume the
+// most KV process time during the last window.
+func TopTableIndexProcessTime(window time.Duration, n int) []TableIndexProcessTime {
+	return tableIndexProcessTime.TopN(window, n)
+}
+
 // RegisterSQL uses to register SQL information into Top SQL.
 func RegisterSQL(normalizedSQL string, sqlDigest *parser.Digest, isInternal bool) {
 	if sqlDigest != nil {
@@ -0,0 +1,112 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// TraceDiffSectionLogicalRule is the section of the logical rules applied to the plan.
+	TraceDiffSectionLogicalRule = "logical_rule"
+	// TraceDiffSectionPhysicalCandidate is the section of the candidate physical plans and their costs.
+	TraceDiffSectionPhysicalCandidate = "physical_candidate"
+	// TraceDiffSectionFinalPlan is the section of the operators in the final plan.
+	TraceDiffSectionFinalPlan = "final_plan"
+)
+
+// OptimizeTraceDiff is a difference between two optimizer traces. Left or Right is empty if the item
+// is missing in the corresponding trace.
+type OptimizeTraceDiff struct {
+	Section string
+	Item    string
+	Left    string
+	Right   string
+}
+
+// CompareOptimizeTrace compares two optimizer traces, and returns the differences of the logical rule
+// applications, the candidate physical plans and the final plans, which explain why the plans differ.
+func CompareOptimizeTrace(left, right *OptimizeTracer) []OptimizeTraceDiff {
+	diffs := make([]OptimizeTraceDiff, 0)
+	diffs = appendTraceDiffs(diffs, TraceDiffSectionLogicalRule, logicalRuleItems(left), logicalRuleItems(right))
+	diffs = appendTraceDiffs(diffs, TraceDiffSectionPhysicalCandidate, physicalCandidateItems(left), physicalCandidateItems(right))
+	diffs = appendTraceDiffs(diffs, TraceDiffSectionFinalPlan, finalPlanItems(left), finalPlanItems(right))
+	return diffs
+}
+
+func appendTraceDiffs(diffs []OptimizeTraceDiff, section string, left, right map[string]string) []OptimizeTraceDiff {
+	items := make([]string, 0, len(left)+len(right))
+	for item := range left {
+		items = append(items, item)
+	}
+	for item := range right {
+		if _, ok := left[item]; !ok {
+			items = append(items, item)
+		}
+	}
+	sort.Strings(items)
+	for _, item := range items {
+		if left[item] != right[item] {
+			diffs = append(diffs, OptimizeTraceDiff{Section: section, Item: item, Left: left[item], Right: right[item]})
+		}
+	}
+	return diffs
+}
+
+// logicalRuleItems returns the steps of each logical rule, the steps of a rule applied several times are concatenated.
+func logicalRuleItems(tracer *OptimizeTracer) map[string]string {
+	items := make(map[string]string)
+	if tracer == nil || tracer.Logical == nil {
+		return items
+	}
+	for _, rule := range tracer.Logical.Steps {
+		steps := make([]string, 0, len(rule.Steps))
+		for _, step := range rule.Steps {
+			steps = append(steps, fmt.Sprintf("%s: %s", step.Action, step.Reason))
+		}
+		if prev, ok := items[rule.RuleName]; ok {
+			steps = append([]string{prev}, steps...)
+		}
+		items[rule.RuleName] = strings.Join(steps, "; ")
+	}
+	return items
+}
+
+func physicalCandidateItems(tracer *OptimizeTracer) map[string]string {
+	items := make(map[string]string)
+	if tracer == nil || tracer.Physical == nil {
+		return items
+	}
+	for _, candidate := range tracer.Physical.Candidates {
+		if candidate.PlanTrace == nil {
+			continue
+		}
+		items[CodecPlanName(candidate.TP, candidate.ID)] = fmt.Sprintf("cost:%v, selected:%v", candidate.Cost, candidate.Selected)
+	}
+	return items
+}
+
+func finalPlanItems(tracer *OptimizeTracer) map[string]string {
+	items := make(map[string]string)
+	if tracer == nil {
+		return items
+	}
+	for _, plan := range tracer.FinalPlan {
+		items[CodecPlanName(plan.TP, plan.ID)] = plan.ExplainInfo
+	}
+	return items
+}
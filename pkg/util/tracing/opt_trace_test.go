@@ -113,3 +113,28 @@ func TestFlattenLogicalPlanTrace(t *testing.T) {
 	require.EqualValues(t, toFlattenPlanTrace(root1), expect1)
 	require.EqualValues(t, toFlattenPlanTrace(root2), expect2)
 }
+
+func TestCompareOptimizeTrace(t *testing.T) {
+	newTracer := func(pushDown bool, cost float64, final string) *OptimizeTracer {
+		logical := &LogicalOptimizeTracer{Steps: []*LogicalRuleOptimizeTracer{
+			{RuleName: "column_prune", Steps: []LogicalRuleOptimizeTraceStep{{Action: "DataSource_1's columns[a] have been pruned", Reason: ""}}},
+		}}
+		if pushDown {
+			logical.Steps = append(logical.Steps, &LogicalRuleOptimizeTracer{RuleName: "predicate_push_down",
+				Steps: []LogicalRuleOptimizeTraceStep{{Action: "Selection_2 is removed", Reason: "The conditions[a > 1] are pushed down"}}})
+		}
+		physical := &PhysicalOptimizeTracer{Candidates: map[int]*CandidatePlanTrace{
+			3: {PlanTrace: &PlanTrace{ID: 3, TP: "TableReader", Cost: 10, Selected: cost >= 10}},
+			4: {PlanTrace: &PlanTrace{ID: 4, TP: "IndexReader", Cost: cost, Selected: cost < 10}},
+		}}
+		return &OptimizeTracer{Logical: logical, Physical: physical, FinalPlan: []*PlanTrace{{ID: 5, TP: final, ExplainInfo: "data:" + final}}}
+	}
+	require.Empty(t, CompareOptimizeTrace(newTracer(true, 5, "IndexReader"), newTracer(true, 5, "IndexReader")))
+	require.Equal(t, []OptimizeTraceDiff{
+		{Section: TraceDiffSectionLogicalRule, Item: "predicate_push_down", Left: "Selection_2 is removed: The conditions[a > 1] are pushed down"},
+		{Section: TraceDiffSectionPhysicalCandidate, Item: "IndexReader_4", Left: "cost:5, selected:true", Right: "cost:20, selected:false"},
+		{Section: TraceDiffSectionPhysicalCandidate, Item: "TableReader_3", Left: "cost:10, selected:false", Right: "cost:10, selected:true"},
+		{Section: TraceDiffSectionFinalPlan, Item: "IndexReader_5", Left: "data:IndexReader"},
+		{Section: TraceDiffSectionFinalPlan, Item: "TableReader_5", Right: "data:TableReader"},
+	}, CompareOptimizeTrace(newTracer(true, 5, "IndexReader"), newTracer(false, 20, "TableReader")))
+}
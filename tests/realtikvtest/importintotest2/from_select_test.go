@@ -154,3 +154,26 @@ func (s *mockGCSSuite) TestImportFromSelectStaleRead() {
 	s.tk.MustExec("import into dst from " + staleReadSQL)
 	s.tk.MustQuery("select * from dst").Check(testkit.Rows("1 a", "2 b"))
 }
+
+func (s *mockGCSSuite) TestCreateTableAsSelect() {
+	s.prepareAndUseDB("from_select")
+	s.tk.MustExec("create table src(id int primary key, v varchar(64))")
+	s.tk.MustExec("insert into src values(4, 'aaaaaa'), (5, 'bbbbbb'), (6, 'cccccc'), (7, 'dddddd')")
+
+	s.tk.MustExec("create table dst(x int default 10, key(v)) select * from src where id > 4")
+	s.Equal(uint64(3), s.tk.Session().GetSessionVars().StmtCtx.AffectedRows())
+	s.tk.MustQuery("select * from dst").Sort().Check(testkit.Rows("10 5 bbbbbb", "10 6 cccccc", "10 7 dddddd"))
+	s.tk.MustQuery(`select column_name, column_type, is_nullable from information_schema.columns
+		where table_schema = 'from_select' and table_name = 'dst' order by ordinal_position`).Check(testkit.Rows(
+		"x int(11) YES", "id int(11) NO", "v varchar(64) YES"))
+	s.tk.MustExec("admin check table dst")
+	rows := s.tk.MustQuery("show import jobs").Rows()
+	s.Len(rows, 1)
+	s.Equal("`from_select`.`dst`", rows[0][2])
+	s.Equal("finished", rows[0][5])
+	s.Equal("3", rows[0][7])
+
+	// the table is invisible if the import fails.
+	s.Error(s.tk.ExecToErr("create table dst2(unique key(v)) select 1 as id, 'a' as v from src"))
+	s.tk.MustQuery("show tables").Check(testkit.Rows("dst", "src"))
+}